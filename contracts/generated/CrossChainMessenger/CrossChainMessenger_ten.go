@@ -0,0 +1,167 @@
+// Code generated by tools/bindgen - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package CrossChainMessenger
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = bind.NewBoundContract
+	_ = types.Log{}
+	_ = gethcommon.Hash{}
+)
+
+// the ABI of the contract, parsed once
+var parsedCrossChainMessengerABI = func() *abi.ABI {
+	parsed, err := CrossChainMessengerMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// ParsedCrossChainMessengerABI returns the parsed ABI of the CrossChainMessenger contract
+func ParsedCrossChainMessengerABI() *abi.ABI {
+	return parsedCrossChainMessengerABI
+}
+
+// unpackInput returns the arguments of a call to the method, checking that the call data is a call to it
+func unpackInput(methodName string, data []byte) ([]interface{}, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("the call data is too short to be a call to %s", methodName)
+	}
+	m, err := parsedCrossChainMessengerABI.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+	if m.Name != methodName {
+		return nil, fmt.Errorf("the call data is a call to %s, not %s", m.Name, methodName)
+	}
+	return m.Inputs.Unpack(data[4:])
+}
+
+// CrossChainSenderMethod is the name of the crossChainSender() method in the ABI
+const CrossChainSenderMethod = "crossChainSender"
+
+// PackCrossChainSender packs a call to crossChainSender()
+func PackCrossChainSender() ([]byte, error) {
+	return parsedCrossChainMessengerABI.Pack(CrossChainSenderMethod)
+}
+
+// UnpackCrossChainSenderOutput unpacks the result of a call to crossChainSender()
+func UnpackCrossChainSenderOutput(data []byte) (gethcommon.Address, error) {
+	values, err := parsedCrossChainMessengerABI.Unpack(CrossChainSenderMethod, data)
+	if err != nil {
+		return *new(gethcommon.Address), err
+	}
+	return *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address), nil
+}
+
+// EncodeCallMethod is the name of the encodeCall(address,bytes) method in the ABI
+const EncodeCallMethod = "encodeCall"
+
+// PackEncodeCall packs a call to encodeCall(address,bytes)
+func PackEncodeCall(target gethcommon.Address, payload []byte) ([]byte, error) {
+	return parsedCrossChainMessengerABI.Pack(EncodeCallMethod, target, payload)
+}
+
+// EncodeCallInput is the arguments of a call to encodeCall(address,bytes)
+type EncodeCallInput struct {
+	Target  gethcommon.Address
+	Payload []byte
+}
+
+// UnpackEncodeCallInput unpacks the arguments of a call to encodeCall(address,bytes) from its call data
+func UnpackEncodeCallInput(data []byte) (*EncodeCallInput, error) {
+	values, err := unpackInput(EncodeCallMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &EncodeCallInput{
+		Target:  *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+		Payload: *abi.ConvertType(values[1], new([]byte)).(*[]byte),
+	}, nil
+}
+
+// UnpackEncodeCallOutput unpacks the result of a call to encodeCall(address,bytes)
+func UnpackEncodeCallOutput(data []byte) ([]byte, error) {
+	values, err := parsedCrossChainMessengerABI.Unpack(EncodeCallMethod, data)
+	if err != nil {
+		return *new([]byte), err
+	}
+	return *abi.ConvertType(values[0], new([]byte)).(*[]byte), nil
+}
+
+// InitializeMethod is the name of the initialize(address) method in the ABI
+const InitializeMethod = "initialize"
+
+// PackInitialize packs a call to initialize(address)
+func PackInitialize(messageBusAddr gethcommon.Address) ([]byte, error) {
+	return parsedCrossChainMessengerABI.Pack(InitializeMethod, messageBusAddr)
+}
+
+// InitializeInput is the arguments of a call to initialize(address)
+type InitializeInput struct {
+	MessageBusAddr gethcommon.Address
+}
+
+// UnpackInitializeInput unpacks the arguments of a call to initialize(address) from its call data
+func UnpackInitializeInput(data []byte) (*InitializeInput, error) {
+	values, err := unpackInput(InitializeMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &InitializeInput{
+		MessageBusAddr: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// MessageBusMethod is the name of the messageBus() method in the ABI
+const MessageBusMethod = "messageBus"
+
+// PackMessageBus packs a call to messageBus()
+func PackMessageBus() ([]byte, error) {
+	return parsedCrossChainMessengerABI.Pack(MessageBusMethod)
+}
+
+// UnpackMessageBusOutput unpacks the result of a call to messageBus()
+func UnpackMessageBusOutput(data []byte) (gethcommon.Address, error) {
+	values, err := parsedCrossChainMessengerABI.Unpack(MessageBusMethod, data)
+	if err != nil {
+		return *new(gethcommon.Address), err
+	}
+	return *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address), nil
+}
+
+// RelayMessageMethod is the name of the relayMessage((address,uint64,uint32,uint32,bytes,uint8)) method in the ABI
+const RelayMessageMethod = "relayMessage"
+
+// PackRelayMessage packs a call to relayMessage((address,uint64,uint32,uint32,bytes,uint8))
+func PackRelayMessage(message StructsCrossChainMessage) ([]byte, error) {
+	return parsedCrossChainMessengerABI.Pack(RelayMessageMethod, message)
+}
+
+// RelayMessageInput is the arguments of a call to relayMessage((address,uint64,uint32,uint32,bytes,uint8))
+type RelayMessageInput struct {
+	Message StructsCrossChainMessage
+}
+
+// UnpackRelayMessageInput unpacks the arguments of a call to relayMessage((address,uint64,uint32,uint32,bytes,uint8)) from its call data
+func UnpackRelayMessageInput(data []byte) (*RelayMessageInput, error) {
+	values, err := unpackInput(RelayMessageMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &RelayMessageInput{
+		Message: *abi.ConvertType(values[0], new(StructsCrossChainMessage)).(*StructsCrossChainMessage),
+	}, nil
+}
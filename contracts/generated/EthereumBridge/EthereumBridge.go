@@ -29,10 +29,18 @@ var (
 	_ = abi.ConvertType
 )
 
+// ITokenRegistryTokenMapping is an auto generated low-level Go binding around an user-defined struct.
+type ITokenRegistryTokenMapping struct {
+	L1Token common.Address
+	L2Token common.Address
+	Name    string
+	Symbol  string
+}
+
 // EthereumBridgeMetaData contains all meta data concerning the EthereumBridge contract.
 var EthereumBridgeMetaData = &bind.MetaData{
-	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"remoteAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"localAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"symbol\",\"type\":\"string\"}],\"name\":\"CreatedWrappedToken\",\"type\":\"event\"},{\"stateMutability\":\"payable\",\"type\":\"fallback\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"messengerAddress\",\"type\":\"address\"}],\"name\":\"configure\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"wrappedToken\",\"type\":\"address\"}],\"name\":\"hasTokenMapping\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"messenger\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"remoteBridge\",\"type\":\"address\"}],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"localToRemoteToken\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"crossChainAddress\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"symbol\",\"type\":\"string\"}],\"name\":\"onCreateTokenCommand\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"receiveAssets\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"remoteToLocalToken\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"sendERC20\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"sendNative\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"wrappedTokens\",\"outputs\":[{\"internalType\":\"contractWrappedERC20\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"stateMutability\":\"payable\",\"type\":\"receive\"}]",
-	Bin: "0x60806040526001805463ffffffff60a01b1916905534801561002057600080fd5b50612d10806100306000396000f3fe608060405260043610620000c65760003560e01c806383bece4d11620000735780639e405b7111620000555780639e405b7114620002dc578063a381c8e21462000316578063d5c6b504146200033b576200013f565b806383bece4d14620002675780639813c7b2146200028c576200013f565b8063458ffd6311620000a9578063458ffd6314620001f8578063485cc955146200021d57806375cb26721462000242576200013f565b80628d48e314620001885780631888d71214620001df576200013f565b366200013f5760405162461bcd60e51b815260206004820152602360248201527f436f6e747261637420646f6573206e6f7420737570706f72742072656365697660448201527f652829000000000000000000000000000000000000000000000000000000000060648201526084015b60405180910390fd5b60405162461bcd60e51b815260206004820152601d60248201527f66616c6c6261636b2829206d6574686f6420756e737570706f72746564000000604482015260640162000136565b3480156200019557600080fd5b50620001c2620001a736600462000f61565b6004602052600090815260409020546001600160a01b031681565b6040516001600160a01b0390911681526020015b60405180910390f35b620001f6620001f036600462000f61565b62000375565b005b3480156200020557600080fd5b50620001f66200021736600462000fd4565b62000526565b3480156200022a57600080fd5b50620001f66200023c36600462001060565b62000740565b3480156200024f57600080fd5b50620001f66200026136600462000f61565b62000843565b3480156200027457600080fd5b50620001f6620002863660046200109e565b620009a3565b3480156200029957600080fd5b50620002cb620002ab36600462000f61565b6001600160a01b0390811660009081526002602052604090205416151590565b6040519015158152602001620001d6565b348015620002e957600080fd5b50620001c2620002fb36600462000f61565b6003602052600090815260409020546001600160a01b031681565b3480156200032357600080fd5b50620001f6620003353660046200109e565b62000bed565b3480156200034857600080fd5b50620001c26200035a36600462000f61565b6002602052600090815260409020546001600160a01b031681565b60003411620003c75760405162461bcd60e51b815260206004820152600d60248201527f4e6f7468696e672073656e742e00000000000000000000000000000000000000604482015260640162000136565b6000805260026020527fac33ff75c19e70fe83507db0d683fd3465c996598dc972688b7ace676c89077b546001600160a01b0316620004495760405162461bcd60e51b815260206004820152601560248201527f4e6f206d617070696e6720666f7220746f6b656e2e0000000000000000000000604482015260640162000136565b600080805260036020527f3617319a054d772f909f7c479a2cebe5066e836a939412e32403c99029b92eff546040516001600160a01b03918216602482015234604482015290831660648201526383bece4d60e01b9060840160408051601f198184030181529190526020810180517bffffffffffffffffffffffffffffffffffffffffffffffffffffffff167fffffffff000000000000000000000000000000000000000000000000000000009093169290921790915260055490915062000522906001600160a01b03168260005b60008062000d7c565b5050565b6005546000546001600160a01b039182169162010000909104163314620005b65760405162461bcd60e51b815260206004820152603060248201527f436f6e74726163742063616c6c6572206973206e6f742074686520726567697360448201527f7465726564206d657373656e6765722100000000000000000000000000000000606482015260840162000136565b806001600160a01b0316620005ca62000eaa565b6001600160a01b031614620006485760405162461bcd60e51b815260206004820152603160248201527f43726f737320636861696e206d65737361676520636f6d696e672066726f6d2060448201527f696e636f72726563742073656e64657221000000000000000000000000000000606482015260840162000136565b6000858585856040516200065c9062000f3a565b6200066b94939291906200110e565b604051809103906000f08015801562000688573d6000803e3d6000fd5b506001600160a01b038082166000818152600260209081526040808320805473ffffffffffffffffffffffffffffffffffffffff199081168617909155600383528184208054968f169682168717905594835260049091529081902080549093169091179091555190915081907f30c05779f384e0ae9d43bbf7ec4417f28bdc53d02a35551b6eb270a9c4c71dca906200072e908a9084908b908b908b908b9062001144565b60405180910390a15050505050505050565b600054610100900460ff166200075d5760005460ff161562000761565b303b155b620007d55760405162461bcd60e51b815260206004820152602e60248201527f496e697469616c697a61626c653a20636f6e747261637420697320616c72656160448201527f647920696e697469616c697a6564000000000000000000000000000000000000606482015260840162000136565b600054610100900460ff16158015620007f8576000805461ffff19166101011790555b620008038362000843565b6005805473ffffffffffffffffffffffffffffffffffffffff19166001600160a01b03841617905580156200083e576000805461ff00191690555b505050565b600054610100900460ff16620008c25760405162461bcd60e51b815260206004820152602b60248201527f496e697469616c697a61626c653a20636f6e7472616374206973206e6f74206960448201527f6e697469616c697a696e67000000000000000000000000000000000000000000606482015260840162000136565b80600060026101000a8154816001600160a01b0302191690836001600160a01b03160217905550600060029054906101000a90046001600160a01b03166001600160a01b031663a1a227fa6040518163ffffffff1660e01b815260040160206040518083038186803b1580156200093857600080fd5b505afa1580156200094d573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019062000973919062001195565b6001805473ffffffffffffffffffffffffffffffffffffffff19166001600160a01b039290921691909117905550565b6005546000546001600160a01b03918216916201000090910416331462000a335760405162461bcd60e51b815260206004820152603060248201527f436f6e74726163742063616c6c6572206973206e6f742074686520726567697360448201527f7465726564206d657373656e6765722100000000000000000000000000000000606482015260840162000136565b806001600160a01b031662000a4762000eaa565b6001600160a01b03161462000ac55760405162461bcd60e51b815260206004820152603160248201527f43726f737320636861696e206d65737361676520636f6d696e672066726f6d2060448201527f696e636f72726563742073656e64657221000000000000000000000000000000606482015260840162000136565b6001600160a01b0380851660009081526004602090815260408083205484168084526002909252909120549091168062000b685760405162461bcd60e51b815260206004820152602b60248201527f526563656976696e672061737365747320666f7220756e6b6e6f776e2077726160448201527f7070656420746f6b656e21000000000000000000000000000000000000000000606482015260840162000136565b6040517f979005ad0000000000000000000000000000000000000000000000000000000081526001600160a01b0385811660048301526024820187905282169063979005ad90604401600060405180830381600087803b15801562000bcc57600080fd5b505af115801562000be1573d6000803e3d6000fd5b50505050505050505050565b6001600160a01b038084166000908152600260205260409020541662000c565760405162461bcd60e51b815260206004820152601560248201527f4e6f206d617070696e6720666f7220746f6b656e2e0000000000000000000000604482015260640162000136565b6001600160a01b03838116600090815260026020526040908190205490517f1dd319cb000000000000000000000000000000000000000000000000000000008152336004820152602481018590529116908190631dd319cb90604401600060405180830381600087803b15801562000ccd57600080fd5b505af115801562000ce2573d6000803e3d6000fd5b505050506001600160a01b03848116600090815260036020908152604080832054815190851660248201526044810188905286851660648083019190915282518083039091018152608490910190915290810180517bffffffffffffffffffffffffffffffffffffffffffffffffffffffff166383bece4d60e01b179052600554909262000d7592911690839062000519565b5050505050565b60006040518060600160405280876001600160a01b031681526020018681526020018481525060405160200162000db4919062001205565b60408051808303601f19018152919052600180549192506001600160a01b0382169163b1454caa917401000000000000000000000000000000000000000090910463ffffffff1690601462000e09836200124c565b91906101000a81548163ffffffff021916908363ffffffff1602179055508684866040518563ffffffff1660e01b815260040162000e4b949392919062001298565b602060405180830381600087803b15801562000e6657600080fd5b505af115801562000e7b573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019062000ea19190620012d7565b50505050505050565b60008060029054906101000a90046001600160a01b03166001600160a01b03166363012de56040518163ffffffff1660e01b815260040160206040518083038186803b15801562000efa57600080fd5b505afa15801562000f0f573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019062000f35919062001195565b905090565b6119d7806200130483390190565b6001600160a01b038116811462000f5e57600080fd5b50565b60006020828403121562000f7457600080fd5b813562000f818162000f48565b9392505050565b60008083601f84011262000f9b57600080fd5b50813567ffffffffffffffff81111562000fb457600080fd5b60208301915083602082850101111562000fcd57600080fd5b9250929050565b60008060008060006060868803121562000fed57600080fd5b853562000ffa8162000f48565b9450602086013567ffffffffffffffff808211156200101857600080fd5b6200102689838a0162000f88565b909650945060408801359150808211156200104057600080fd5b506200104f8882890162000f88565b969995985093965092949392505050565b600080604083850312156200107457600080fd5b8235620010818162000f48565b91506020830135620010938162000f48565b809150509250929050565b600080600060608486031215620010b457600080fd5b8335620010c18162000f48565b9250602084013591506040840135620010da8162000f48565b809150509250925092565b81835281816020850137506000828201602090810191909152601f909101601f19169091010190565b60408152600062001124604083018688620010e5565b828103602084015262001139818587620010e5565b979650505050505050565b60006001600160a01b0380891683528088166020840152506080604083015262001173608083018688620010e5565b828103606084015262001188818587620010e5565b9998505050505050505050565b600060208284031215620011a857600080fd5b815162000f818162000f48565b6000815180845260005b81811015620011dd57602081850181015186830182015201620011bf565b81811115620011f0576000602083870101525b50601f01601f19169290920160200192915050565b602081526001600160a01b0382511660208201526000602083015160606040840152620012366080840182620011b5565b9050604084015160608401528091505092915050565b600063ffffffff808316818114156200128e577f4e487b7100000000000000000000000000000000000000000000000000000000600052601160045260246000fd5b6001019392505050565b600063ffffffff808716835280861660208401525060806040830152620012c36080830185620011b5565b905060ff8316606083015295945050505050565b600060208284031215620012ea57600080fd5b815167ffffffffffffffff8116811462000f8157600080fdfe6080604052600580546001600160a01b03191673deb34a740eca1ec42c8b8204cbec0ba34fdd27f31790553480156200003757600080fd5b50604051620019d7380380620019d78339810160408190526200005a91620002e3565b8181818181600390805190602001906200007692919062000170565b5080516200008c90600490602084019062000170565b5050505050620000c37fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c2177533620000cb60201b60201c565b50506200038a565b60008281526007602090815260408083206001600160a01b038516845290915290205460ff166200016c5760008281526007602090815260408083206001600160a01b03851684529091529020805460ff191660011790556200012b3390565b6001600160a01b0316816001600160a01b0316837f2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d60405160405180910390a45b5050565b8280546200017e906200034d565b90600052602060002090601f016020900481019282620001a25760008555620001ed565b82601f10620001bd57805160ff1916838001178555620001ed565b82800160010185558215620001ed579182015b82811115620001ed578251825591602001919060010190620001d0565b50620001fb929150620001ff565b5090565b5b80821115620001fb576000815560010162000200565b634e487b7160e01b600052604160045260246000fd5b600082601f8301126200023e57600080fd5b81516001600160401b03808211156200025b576200025b62000216565b604051601f8301601f19908116603f0116810190828211818310171562000286576200028662000216565b81604052838152602092508683858801011115620002a357600080fd5b600091505b83821015620002c75785820183015181830184015290820190620002a8565b83821115620002d95760008385830101525b9695505050505050565b60008060408385031215620002f757600080fd5b82516001600160401b03808211156200030f57600080fd5b6200031d868387016200022c565b935060208501519150808211156200033457600080fd5b5062000343858286016200022c565b9150509250929050565b600181811c908216806200036257607f821691505b602082108114156200038457634e487b7160e01b600052602260045260246000fd5b50919050565b61163d806200039a6000396000f3fe608060405234801561001057600080fd5b50600436106101775760003560e01c806339509351116100d8578063979005ad1161008c578063a9059cbb11610066578063a9059cbb1461031a578063d547741f1461032d578063dd62ed3e1461034057600080fd5b8063979005ad146102ec578063a217fddf146102ff578063a457c2d71461030757600080fd5b806375b238fc116100bd57806375b238fc1461028457806391d14854146102ab57806395d89b41146102e457600080fd5b8063395093511461025e57806370a082311461027157600080fd5b806323b872dd1161012f5780632f2ff15d116101145780632f2ff15d14610229578063313ce5671461023c57806336568abe1461024b57600080fd5b806323b872dd146101f3578063248a9ca31461020657600080fd5b8063095ea7b311610160578063095ea7b3146101b957806318160ddd146101cc5780631dd319cb146101de57600080fd5b806301ffc9a71461017c57806306fdde03146101a4575b600080fd5b61018f61018a3660046112f7565b610353565b60405190151581526020015b60405180910390f35b6101ac6103ec565b60405161019b9190611365565b61018f6101c73660046113b4565b61047e565b6002545b60405190815260200161019b565b6101f16101ec3660046113b4565b610496565b005b61018f6102013660046113de565b61052d565b6101d061021436600461141a565b60009081526007602052604090206001015490565b6101f1610237366004611433565b610551565b6040516012815260200161019b565b6101f1610259366004611433565b610577565b61018f61026c3660046113b4565b610603565b6101d061027f36600461145f565b610642565b6101d07fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c2177581565b61018f6102b9366004611433565b60009182526007602090815260408084206001600160a01b0393909316845291905290205460ff1690565b6101ac6106ea565b6101f16102fa3660046113b4565b6106f9565b6101d0600081565b61018f6103153660046113b4565b61072e565b61018f6103283660046113b4565b6107d8565b6101f161033b366004611433565b6107e6565b6101d061034e36600461147a565b61080c565b60007fffffffff0000000000000000000000000000000000000000000000000000000082167f7965db0b0000000000000000000000000000000000000000000000000000000014806103e657507f01ffc9a7000000000000000000000000000000000000000000000000000000007fffffffff000000000000000000000000000000000000000000000000000000008316145b92915050565b6060600380546103fb906114a4565b80601f0160208091040260200160405190810160405280929190818152602001828054610427906114a4565b80156104745780601f1061044957610100808354040283529160200191610474565b820191906000526020600020905b81548152906001019060200180831161045757829003601f168201915b5050505050905090565b60003361048c81858561091d565b5060019392505050565b7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c217756104c18133610a75565b816104cb84610642565b101561051e5760405162461bcd60e51b815260206004820152601560248201527f496e73756666696369656e742062616c616e63652e000000000000000000000060448201526064015b60405180910390fd5b6105288383610af5565b505050565b60003361053b858285610c7a565b610546858585610cf4565b506001949350505050565b60008281526007602052604090206001015461056d8133610a75565b6105288383610f0b565b6001600160a01b03811633146105f55760405162461bcd60e51b815260206004820152602f60248201527f416363657373436f6e74726f6c3a2063616e206f6e6c792072656e6f756e636560448201527f20726f6c657320666f722073656c6600000000000000000000000000000000006064820152608401610515565b6105ff8282610fad565b5050565b3360008181526001602090815260408083206001600160a01b038716845290915281205490919061048c908290869061063d9087906114f5565b61091d565b6000326001600160a01b0383161415610673576001600160a01b0382166000908152602081905260409020546103e6565b336001600160a01b03831614156106a2576001600160a01b0382166000908152602081905260409020546103e6565b60405162461bcd60e51b815260206004820152601f60248201527f4e6f7420616c6c6f77656420746f2072656164207468652062616c616e6365006044820152606401610515565b6060600480546103fb906114a4565b7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c217756107248133610a75565b6105288383611030565b3360008181526001602090815260408083206001600160a01b0387168452909152812054909190838110156107cb5760405162461bcd60e51b815260206004820152602560248201527f45524332303a2064656372656173656420616c6c6f77616e63652062656c6f7760448201527f207a65726f0000000000000000000000000000000000000000000000000000006064820152608401610515565b610546828686840361091d565b60003361048c818585610cf4565b6000828152600760205260409020600101546108028133610a75565b6105288383610fad565b6000326001600160a01b038416148061082d5750326001600160a01b038316145b15610860576001600160a01b038084166000908152600160209081526040808320938616835292905220545b90506103e6565b336001600160a01b038416148061087f5750336001600160a01b038316145b156108af576001600160a01b03808416600090815260016020908152604080832093861683529290522054610859565b60405162461bcd60e51b815260206004820152602160248201527f4e6f7420616c6c6f77656420746f20726561642074686520616c6c6f77616e6360448201527f65000000000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b0383166109985760405162461bcd60e51b8152602060048201526024808201527f45524332303a20617070726f76652066726f6d20746865207a65726f2061646460448201527f72657373000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b038216610a145760405162461bcd60e51b815260206004820152602260248201527f45524332303a20617070726f766520746f20746865207a65726f20616464726560448201527f73730000000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b0383811660008181526001602090815260408083209487168084529482529182902085905590518481527f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925910160405180910390a3505050565b60008281526007602090815260408083206001600160a01b038516845290915290205460ff166105ff57610ab3816001600160a01b0316601461110f565b610abe83602061110f565b604051602001610acf92919061150d565b60408051601f198184030181529082905262461bcd60e51b825261051591600401611365565b6001600160a01b038216610b715760405162461bcd60e51b815260206004820152602160248201527f45524332303a206275726e2066726f6d20746865207a65726f2061646472657360448201527f73000000000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b03821660009081526020819052604090205481811015610c005760405162461bcd60e51b815260206004820152602260248201527f45524332303a206275726e20616d6f756e7420657863656564732062616c616e60448201527f63650000000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b0383166000908152602081905260408120838303905560028054849290610c2f90849061158e565b90915550506040518281526000906001600160a01b038516907fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef9060200160405180910390a3505050565b6000610c86848461080c565b90506000198114610cee5781811015610ce15760405162461bcd60e51b815260206004820152601d60248201527f45524332303a20696e73756666696369656e7420616c6c6f77616e63650000006044820152606401610515565b610cee848484840361091d565b50505050565b6001600160a01b038316610d705760405162461bcd60e51b815260206004820152602560248201527f45524332303a207472616e736665722066726f6d20746865207a65726f20616460448201527f64726573730000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b038216610dec5760405162461bcd60e51b815260206004820152602360248201527f45524332303a207472616e7366657220746f20746865207a65726f206164647260448201527f65737300000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b03831660009081526020819052604090205481811015610e7b5760405162461bcd60e51b815260206004820152602660248201527f45524332303a207472616e7366657220616d6f756e742065786365656473206260448201527f616c616e636500000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b03808516600090815260208190526040808220858503905591851681529081208054849290610eb29084906114f5565b92505081905550826001600160a01b0316846001600160a01b03167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef84604051610efe91815260200190565b60405180910390a3610cee565b60008281526007602090815260408083206001600160a01b038516845290915290205460ff166105ff5760008281526007602090815260408083206001600160a01b03851684529091529020805460ff19166001179055610f693390565b6001600160a01b0316816001600160a01b0316837f2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d60405160405180910390a45050565b60008281526007602090815260408083206001600160a01b038516845290915290205460ff16156105ff5760008281526007602090815260408083206001600160a01b0385168085529252808320805460ff1916905551339285917ff6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b9190a45050565b6001600160a01b0382166110865760405162461bcd60e51b815260206004820152601f60248201527f45524332303a206d696e7420746f20746865207a65726f2061646472657373006044820152606401610515565b806002600082825461109891906114f5565b90915550506001600160a01b038216600090815260208190526040812080548392906110c59084906114f5565b90915550506040518181526001600160a01b038316906000907fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef9060200160405180910390a35050565b6060600061111e8360026115a5565b6111299060026114f5565b67ffffffffffffffff811115611141576111416115c4565b6040519080825280601f01601f19166020018201604052801561116b576020820181803683370190505b5090507f3000000000000000000000000000000000000000000000000000000000000000816000815181106111a2576111a26115da565b60200101906001600160f81b031916908160001a9053507f7800000000000000000000000000000000000000000000000000000000000000816001815181106111ed576111ed6115da565b60200101906001600160f81b031916908160001a90535060006112118460026115a5565b61121c9060016114f5565b90505b60018111156112a1577f303132333435363738396162636465660000000000000000000000000000000085600f166010811061125d5761125d6115da565b1a60f81b828281518110611273576112736115da565b60200101906001600160f81b031916908160001a90535060049490941c9361129a816115f0565b905061121f565b5083156112f05760405162461bcd60e51b815260206004820181905260248201527f537472696e67733a20686578206c656e67746820696e73756666696369656e746044820152606401610515565b9392505050565b60006020828403121561130957600080fd5b81357fffffffff00000000000000000000000000000000000000000000000000000000811681146112f057600080fd5b60005b8381101561135457818101518382015260200161133c565b83811115610cee5750506000910152565b6020815260008251806020840152611384816040850160208701611339565b601f01601f19169190910160400192915050565b80356001600160a01b03811681146113af57600080fd5b919050565b600080604083850312156113c757600080fd5b6113d083611398565b946020939093013593505050565b6000806000606084860312156113f357600080fd5b6113fc84611398565b925061140a60208501611398565b9150604084013590509250925092565b60006020828403121561142c57600080fd5b5035919050565b6000806040838503121561144657600080fd5b8235915061145660208401611398565b90509250929050565b60006020828403121561147157600080fd5b6112f082611398565b6000806040838503121561148d57600080fd5b61149683611398565b915061145660208401611398565b600181811c908216806114b857607f821691505b602082108114156114d957634e487b7160e01b600052602260045260246000fd5b50919050565b634e487b7160e01b600052601160045260246000fd5b60008219821115611508576115086114df565b500190565b7f416363657373436f6e74726f6c3a206163636f756e7420000000000000000000815260008351611545816017850160208801611339565b7f206973206d697373696e6720726f6c65200000000000000000000000000000006017918401918201528351611582816028840160208801611339565b01602801949350505050565b6000828210156115a0576115a06114df565b500390565b60008160001904831182151516156115bf576115bf6114df565b500290565b634e487b7160e01b600052604160045260246000fd5b634e487b7160e01b600052603260045260246000fd5b6000816115ff576115ff6114df565b50600019019056fea26469706673582212201c0c3a851d2bc9f4f94393362a160e99651c656d627113abd657cce4d7d8770964736f6c63430008090033a26469706673582212209025675371a7cd9667ebd11bf443f5ad333fd6b958c0b2dd506b3cf13882f17164736f6c63430008090033",
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"remoteAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"localAddress\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"symbol\",\"type\":\"string\"}],\"name\":\"CreatedWrappedToken\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"l1Token\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"l2Token\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"symbol\",\"type\":\"string\"}],\"name\":\"TokenRegistered\",\"type\":\"event\"},{\"stateMutability\":\"payable\",\"type\":\"fallback\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"messengerAddress\",\"type\":\"address\"}],\"name\":\"configure\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"wrappedToken\",\"type\":\"address\"}],\"name\":\"hasTokenMapping\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"messenger\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"remoteBridge\",\"type\":\"address\"}],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"l2Token\",\"type\":\"address\"}],\"name\":\"l1TokenOf\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"l1Token\",\"type\":\"address\"}],\"name\":\"l2TokenOf\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"localToRemoteToken\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"crossChainAddress\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"symbol\",\"type\":\"string\"}],\"name\":\"onCreateTokenCommand\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"receiveAssets\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"remoteToLocalToken\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"sendERC20\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"sendNative\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"offset\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"limit\",\"type\":\"uint256\"}],\"name\":\"tokenMappings\",\"outputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"l1Token\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"l2Token\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"symbol\",\"type\":\"string\"}],\"internalType\":\"structITokenRegistry.TokenMapping[]\",\"name\":\"\",\"type\":\"tuple[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"tokenMappingsCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"name\":\"wrappedTokens\",\"outputs\":[{\"internalType\":\"contractWrappedERC20\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"stateMutability\":\"payable\",\"type\":\"receive\"}]",
+	Bin: "0x60806040526001805463ffffffff60a01b1916905534801561002057600080fd5b5061351b806100306000396000f3fe608060405260043610620000f65760003560e01c806383bece4d116200008b5780639e405b7111620000615780639e405b71146200039d578063a381c8e214620003d7578063c098a8c014620003fc578063d5c6b5041462000439576200016f565b806383bece4d146200030857806396344470146200032d5780639813c7b2146200034d576200016f565b8063458ffd6311620000cd578063458ffd63146200025c578063485cc95514620002815780635c4994b114620002a657806375cb267214620002e3576200016f565b80628d48e314620001b85780631888d712146200020f5780632fb7ef4a1462000228576200016f565b366200016f5760405162461bcd60e51b815260206004820152602360248201527f436f6e747261637420646f6573206e6f7420737570706f72742072656365697660448201527f652829000000000000000000000000000000000000000000000000000000000060648201526084015b60405180910390fd5b60405162461bcd60e51b815260206004820152601d60248201527f66616c6c6261636b2829206d6574686f6420756e737570706f72746564000000604482015260640162000166565b348015620001c557600080fd5b50620001f2620001d7366004620014dc565b6004602052600090815260409020546001600160a01b031681565b6040516001600160a01b0390911681526020015b60405180910390f35b6200022662000220366004620014dc565b62000473565b005b3480156200023557600080fd5b506200024d6200024736600462001503565b62000624565b6040516200020691906200156e565b3480156200026957600080fd5b50620002266200027b36600462001669565b62000911565b3480156200028e57600080fd5b5062000226620002a0366004620016f5565b62000ceb565b348015620002b357600080fd5b50620001f2620002c5366004620014dc565b6001600160a01b039081166000908152600360205260409020541690565b348015620002f057600080fd5b506200022662000302366004620014dc565b62000dee565b3480156200031557600080fd5b50620002266200032736600462001733565b62000f3e565b3480156200033a57600080fd5b5060065460405190815260200162000206565b3480156200035a57600080fd5b506200038c6200036c366004620014dc565b6001600160a01b0390811660009081526002602052604090205416151590565b604051901515815260200162000206565b348015620003aa57600080fd5b50620001f2620003bc366004620014dc565b6003602052600090815260409020546001600160a01b031681565b348015620003e457600080fd5b5062000226620003f636600462001733565b62001188565b3480156200040957600080fd5b50620001f26200041b366004620014dc565b6001600160a01b039081166000908152600460205260409020541690565b3480156200044657600080fd5b50620001f262000458366004620014dc565b6002602052600090815260409020546001600160a01b031681565b60003411620004c55760405162461bcd60e51b815260206004820152600d60248201527f4e6f7468696e672073656e742e00000000000000000000000000000000000000604482015260640162000166565b6000805260026020527fac33ff75c19e70fe83507db0d683fd3465c996598dc972688b7ace676c89077b546001600160a01b0316620005475760405162461bcd60e51b815260206004820152601560248201527f4e6f206d617070696e6720666f7220746f6b656e2e0000000000000000000000604482015260640162000166565b600080805260036020527f3617319a054d772f909f7c479a2cebe5066e836a939412e32403c99029b92eff546040516001600160a01b03918216602482015234604482015290831660648201526383bece4d60e01b9060840160408051601f198184030181529190526020810180517bffffffffffffffffffffffffffffffffffffffffffffffffffffffff167fffffffff000000000000000000000000000000000000000000000000000000009093169290921790915260055490915062000620906001600160a01b03168260005b60008062001317565b5050565b6006546060908310620006885760408051600080825260208201909252906200067f565b6040805160808101825260008082526020820152606091810182905281810191909152815260200190600190039081620006485790505b5090506200090b565b6006546000906200069b908590620017a6565b905082811115620006a95750815b60008167ffffffffffffffff811115620006c757620006c76200177a565b6040519080825280602002602001820160405280156200071d57816020015b6040805160808101825260008082526020820152606091810182905281810191909152815260200190600190039081620006e65790505b50905060005b8281101562000906576007600060066200073e848a620017bc565b81548110620007515762000751620017d2565b60009182526020808320909101546001600160a01b03908116845283820194909452604092830190912082516080810184528154851681526001820154909416918401919091526002810180549192840191620007ae90620017e8565b80601f0160208091040260200160405190810160405280929190818152602001828054620007dc90620017e8565b80156200082d5780601f1062000801576101008083540402835291602001916200082d565b820191906000526020600020905b8154815290600101906020018083116200080f57829003601f168201915b505050505081526020016003820180546200084890620017e8565b80601f01602080910402602001604051908101604052809291908181526020018280546200087690620017e8565b8015620008c75780601f106200089b57610100808354040283529160200191620008c7565b820191906000526020600020905b815481529060010190602001808311620008a957829003601f168201915b505050505081525050828281518110620008e557620008e5620017d2565b60200260200101819052508080620008fd9062001824565b91505062000723565b509150505b92915050565b6005546000546001600160a01b039182169162010000909104163314620009a15760405162461bcd60e51b815260206004820152603060248201527f436f6e74726163742063616c6c6572206973206e6f742074686520726567697360448201527f7465726564206d657373656e6765722100000000000000000000000000000000606482015260840162000166565b806001600160a01b0316620009b562001435565b6001600160a01b03161462000a335760405162461bcd60e51b815260206004820152603160248201527f43726f737320636861696e206d65737361676520636f6d696e672066726f6d2060448201527f696e636f72726563742073656e64657221000000000000000000000000000000606482015260840162000166565b6001600160a01b038681166000908152600460205260409020541662000ce35760006001600160a01b0387168686868660405162000a7190620014b5565b62000a80949392919062001869565b8190604051809103906000f590508015801562000aa1573d6000803e3d6000fd5b506001600160a01b038082166000818152600260209081526040808320805473ffffffffffffffffffffffffffffffffffffffff199081168617909155600383528184208054968f16968216871790558584526004835281842080548216861790556006805460018101825594527ff652222313e28459528d920b65115c16c04f3efc82aaedc97be59f3f377c0d3f909301805490931685179092558151608081018352938452838101929092528051601f8a0183900483028101830182528981529394508493908301918a908a9081908401838280828437600092019190915250505090825250604080516020601f89018190048102820181019092528781529181019190889088908190840183828082843760009201829052509390945250506001600160a01b03808c168252600760209081526040928390208551815490841673ffffffffffffffffffffffffffffffffffffffff19918216178255918601516001820180549190941692169190911790915590830151909150600282019062000c2f9082620018e9565b506060820151600382019062000c469082620018e9565b509050507f30c05779f384e0ae9d43bbf7ec4417f28bdc53d02a35551b6eb270a9c4c71dca88828989898960405162000c8596959493929190620019b6565b60405180910390a1806001600160a01b0316886001600160a01b03167ffbe16c292428cdc3e66e97f954e91cc4f49b472d24989df1b2cdc27f295a70678989898960405162000cd8949392919062001869565b60405180910390a350505b505050505050565b600054610100900460ff1662000d085760005460ff161562000d0c565b303b155b62000d805760405162461bcd60e51b815260206004820152602e60248201527f496e697469616c697a61626c653a20636f6e747261637420697320616c72656160448201527f647920696e697469616c697a6564000000000000000000000000000000000000606482015260840162000166565b600054610100900460ff1615801562000da3576000805461ffff19166101011790555b62000dae8362000dee565b6005805473ffffffffffffffffffffffffffffffffffffffff19166001600160a01b038416179055801562000de9576000805461ff00191690555b505050565b600054610100900460ff1662000e6d5760405162461bcd60e51b815260206004820152602b60248201527f496e697469616c697a61626c653a20636f6e7472616374206973206e6f74206960448201527f6e697469616c697a696e67000000000000000000000000000000000000000000606482015260840162000166565b80600060026101000a8154816001600160a01b0302191690836001600160a01b03160217905550600060029054906101000a90046001600160a01b03166001600160a01b031663a1a227fa6040518163ffffffff1660e01b8152600401602060405180830381865afa15801562000ee8573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019062000f0e919062001a07565b6001805473ffffffffffffffffffffffffffffffffffffffff19166001600160a01b039290921691909117905550565b6005546000546001600160a01b03918216916201000090910416331462000fce5760405162461bcd60e51b815260206004820152603060248201527f436f6e74726163742063616c6c6572206973206e6f742074686520726567697360448201527f7465726564206d657373656e6765722100000000000000000000000000000000606482015260840162000166565b806001600160a01b031662000fe262001435565b6001600160a01b031614620010605760405162461bcd60e51b815260206004820152603160248201527f43726f737320636861696e206d65737361676520636f6d696e672066726f6d2060448201527f696e636f72726563742073656e64657221000000000000000000000000000000606482015260840162000166565b6001600160a01b03808516600090815260046020908152604080832054841680845260029092529091205490911680620011035760405162461bcd60e51b815260206004820152602b60248201527f526563656976696e672061737365747320666f7220756e6b6e6f776e2077726160448201527f7070656420746f6b656e21000000000000000000000000000000000000000000606482015260840162000166565b6040517f979005ad0000000000000000000000000000000000000000000000000000000081526001600160a01b0385811660048301526024820187905282169063979005ad90604401600060405180830381600087803b1580156200116757600080fd5b505af11580156200117c573d6000803e3d6000fd5b50505050505050505050565b6001600160a01b0380841660009081526002602052604090205416620011f15760405162461bcd60e51b815260206004820152601560248201527f4e6f206d617070696e6720666f7220746f6b656e2e0000000000000000000000604482015260640162000166565b6001600160a01b03838116600090815260026020526040908190205490517f1dd319cb000000000000000000000000000000000000000000000000000000008152336004820152602481018590529116908190631dd319cb90604401600060405180830381600087803b1580156200126857600080fd5b505af11580156200127d573d6000803e3d6000fd5b505050506001600160a01b03848116600090815260036020908152604080832054815190851660248201526044810188905286851660648083019190915282518083039091018152608490910190915290810180517bffffffffffffffffffffffffffffffffffffffffffffffffffffffff166383bece4d60e01b17905260055490926200131092911690839062000617565b5050505050565b60006040518060600160405280876001600160a01b03168152602001868152602001848152506040516020016200134f919062001a27565b60408051808303601f19018152919052600180549192506001600160a01b0382169163b1454caa917401000000000000000000000000000000000000000090910463ffffffff16906014620013a48362001a6e565b91906101000a81548163ffffffff021916908363ffffffff1602179055508684866040518563ffffffff1660e01b8152600401620013e6949392919062001a94565b6020604051808303816000875af115801562001406573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906200142c919062001ad3565b50505050505050565b60008060029054906101000a90046001600160a01b03166001600160a01b03166363012de56040518163ffffffff1660e01b8152600401602060405180830381865afa1580156200148a573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190620014b0919062001a07565b905090565b6119e68062001b0083390190565b6001600160a01b0381168114620014d957600080fd5b50565b600060208284031215620014ef57600080fd5b8135620014fc81620014c3565b9392505050565b600080604083850312156200151757600080fd5b50508035926020909101359150565b6000815180845260005b818110156200154e5760208185018101518683018201520162001530565b506000602082860101526020601f19601f83011685010191505092915050565b60006020808301818452808551808352604092508286019150828160051b87010184880160005b838110156200160f57603f19898403018552815160806001600160a01b03808351168652808a840151168a87015250878201518189870152620015db8287018262001526565b91505060608083015192508582038187015250620015fa818362001526565b96890196945050509086019060010162001595565b509098975050505050505050565b60008083601f8401126200163057600080fd5b50813567ffffffffffffffff8111156200164957600080fd5b6020830191508360208285010111156200166257600080fd5b9250929050565b6000806000806000606086880312156200168257600080fd5b85356200168f81620014c3565b9450602086013567ffffffffffffffff80821115620016ad57600080fd5b620016bb89838a016200161d565b90965094506040880135915080821115620016d557600080fd5b50620016e4888289016200161d565b969995985093965092949392505050565b600080604083850312156200170957600080fd5b82356200171681620014c3565b915060208301356200172881620014c3565b809150509250929050565b6000806000606084860312156200174957600080fd5b83356200175681620014c3565b92506020840135915060408401356200176f81620014c3565b809150509250925092565b634e487b7160e01b600052604160045260246000fd5b634e487b7160e01b600052601160045260246000fd5b818103818111156200090b576200090b62001790565b808201808211156200090b576200090b62001790565b634e487b7160e01b600052603260045260246000fd5b600181811c90821680620017fd57607f821691505b6020821081036200181e57634e487b7160e01b600052602260045260246000fd5b50919050565b60006001820162001839576200183962001790565b5060010190565b81835281816020850137506000828201602090810191909152601f909101601f19169091010190565b6040815260006200187f60408301868862001840565b82810360208401526200189481858762001840565b979650505050505050565b601f82111562000de957600081815260208120601f850160051c81016020861015620018c85750805b601f850160051c820191505b8181101562000ce357828155600101620018d4565b815167ffffffffffffffff8111156200190657620019066200177a565b6200191e81620019178454620017e8565b846200189f565b602080601f8311600181146200195657600084156200193d5750858301515b600019600386901b1c1916600185901b17855562000ce3565b600085815260208120601f198616915b82811015620019875788860151825594840194600190910190840162001966565b5085821015620019a65787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b60006001600160a01b03808916835280881660208401525060806040830152620019e560808301868862001840565b8281036060840152620019fa81858762001840565b9998505050505050505050565b60006020828403121562001a1a57600080fd5b8151620014fc81620014c3565b602081526001600160a01b038251166020820152600060208301516060604084015262001a58608084018262001526565b9050604084015160608401528091505092915050565b600063ffffffff80831681810362001a8a5762001a8a62001790565b6001019392505050565b600063ffffffff80871683528086166020840152506080604083015262001abf608083018562001526565b905060ff8316606083015295945050505050565b60006020828403121562001ae657600080fd5b815167ffffffffffffffff81168114620014fc57600080fdfe6080604052600580546001600160a01b03191673deb34a740eca1ec42c8b8204cbec0ba34fdd27f31790553480156200003757600080fd5b50604051620019e6380380620019e68339810160408190526200005a9162000224565b8181818160036200006c83826200031d565b5060046200007b82826200031d565b5050505050620000b27fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c2177533620000ba60201b60201c565b5050620003e9565b60008281526007602090815260408083206001600160a01b038516845290915290205460ff166200015b5760008281526007602090815260408083206001600160a01b03851684529091529020805460ff191660011790556200011a3390565b6001600160a01b0316816001600160a01b0316837f2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d60405160405180910390a45b5050565b634e487b7160e01b600052604160045260246000fd5b600082601f8301126200018757600080fd5b81516001600160401b0380821115620001a457620001a46200015f565b604051601f8301601f19908116603f01168101908282118183101715620001cf57620001cf6200015f565b81604052838152602092508683858801011115620001ec57600080fd5b600091505b83821015620002105785820183015181830184015290820190620001f1565b600093810190920192909252949350505050565b600080604083850312156200023857600080fd5b82516001600160401b03808211156200025057600080fd5b6200025e8683870162000175565b935060208501519150808211156200027557600080fd5b50620002848582860162000175565b9150509250929050565b600181811c90821680620002a357607f821691505b602082108103620002c457634e487b7160e01b600052602260045260246000fd5b50919050565b601f8211156200031857600081815260208120601f850160051c81016020861015620002f35750805b601f850160051c820191505b818110156200031457828155600101620002ff565b5050505b505050565b81516001600160401b038111156200033957620003396200015f565b62000351816200034a84546200028e565b84620002ca565b602080601f831160018114620003895760008415620003705750858301515b600019600386901b1c1916600185901b17855562000314565b600085815260208120601f198616915b82811015620003ba5788860151825594840194600190910190840162000399565b5085821015620003d95787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b6115ed80620003f96000396000f3fe608060405234801561001057600080fd5b50600436106101775760003560e01c806339509351116100d8578063979005ad1161008c578063a9059cbb11610066578063a9059cbb1461031a578063d547741f1461032d578063dd62ed3e1461034057600080fd5b8063979005ad146102ec578063a217fddf146102ff578063a457c2d71461030757600080fd5b806375b238fc116100bd57806375b238fc1461028457806391d14854146102ab57806395d89b41146102e457600080fd5b8063395093511461025e57806370a082311461027157600080fd5b806323b872dd1161012f5780632f2ff15d116101145780632f2ff15d14610229578063313ce5671461023c57806336568abe1461024b57600080fd5b806323b872dd146101f3578063248a9ca31461020657600080fd5b8063095ea7b311610160578063095ea7b3146101b957806318160ddd146101cc5780631dd319cb146101de57600080fd5b806301ffc9a71461017c57806306fdde03146101a4575b600080fd5b61018f61018a3660046112c1565b610353565b60405190151581526020015b60405180910390f35b6101ac6103ec565b60405161019b9190611327565b61018f6101c7366004611376565b61047e565b6002545b60405190815260200161019b565b6101f16101ec366004611376565b610496565b005b61018f6102013660046113a0565b61052d565b6101d06102143660046113dc565b60009081526007602052604090206001015490565b6101f16102373660046113f5565b610551565b6040516012815260200161019b565b6101f16102593660046113f5565b610577565b61018f61026c366004611376565b610603565b6101d061027f366004611421565b610625565b6101d07fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c2177581565b61018f6102b93660046113f5565b60009182526007602090815260408084206001600160a01b0393909316845291905290205460ff1690565b6101ac6106cb565b6101f16102fa366004611376565b6106da565b6101d0600081565b61018f610315366004611376565b61070f565b61018f610328366004611376565b6107a2565b6101f161033b3660046113f5565b6107b0565b6101d061034e36600461143c565b6107d6565b60007fffffffff0000000000000000000000000000000000000000000000000000000082167f7965db0b0000000000000000000000000000000000000000000000000000000014806103e657507f01ffc9a7000000000000000000000000000000000000000000000000000000007fffffffff000000000000000000000000000000000000000000000000000000008316145b92915050565b6060600380546103fb90611466565b80601f016020809104026020016040519081016040528092919081815260200182805461042790611466565b80156104745780601f1061044957610100808354040283529160200191610474565b820191906000526020600020905b81548152906001019060200180831161045757829003601f168201915b5050505050905090565b60003361048c8185856108e7565b5060019392505050565b7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c217756104c18133610a3f565b816104cb84610625565b101561051e5760405162461bcd60e51b815260206004820152601560248201527f496e73756666696369656e742062616c616e63652e000000000000000000000060448201526064015b60405180910390fd5b6105288383610abf565b505050565b60003361053b858285610c44565b610546858585610cbe565b506001949350505050565b60008281526007602052604090206001015461056d8133610a3f565b6105288383610ed5565b6001600160a01b03811633146105f55760405162461bcd60e51b815260206004820152602f60248201527f416363657373436f6e74726f6c3a2063616e206f6e6c792072656e6f756e636560448201527f20726f6c657320666f722073656c6600000000000000000000000000000000006064820152608401610515565b6105ff8282610f77565b5050565b60003361048c81858561061683836107d6565b61062091906114b6565b6108e7565b60006001600160a01b0382163203610655576001600160a01b0382166000908152602081905260409020546103e6565b6001600160a01b0382163303610683576001600160a01b0382166000908152602081905260409020546103e6565b60405162461bcd60e51b815260206004820152601f60248201527f4e6f7420616c6c6f77656420746f2072656164207468652062616c616e6365006044820152606401610515565b6060600480546103fb90611466565b7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c217756107058133610a3f565b6105288383610ffa565b6000338161071d82866107d6565b9050838110156107955760405162461bcd60e51b815260206004820152602560248201527f45524332303a2064656372656173656420616c6c6f77616e63652062656c6f7760448201527f207a65726f0000000000000000000000000000000000000000000000000000006064820152608401610515565b61054682868684036108e7565b60003361048c818585610cbe565b6000828152600760205260409020600101546107cc8133610a3f565b6105288383610f77565b6000326001600160a01b03841614806107f75750326001600160a01b038316145b1561082a576001600160a01b038084166000908152600160209081526040808320938616835292905220545b90506103e6565b336001600160a01b03841614806108495750336001600160a01b038316145b15610879576001600160a01b03808416600090815260016020908152604080832093861683529290522054610823565b60405162461bcd60e51b815260206004820152602160248201527f4e6f7420616c6c6f77656420746f20726561642074686520616c6c6f77616e6360448201527f65000000000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b0383166109625760405162461bcd60e51b8152602060048201526024808201527f45524332303a20617070726f76652066726f6d20746865207a65726f2061646460448201527f72657373000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b0382166109de5760405162461bcd60e51b815260206004820152602260248201527f45524332303a20617070726f766520746f20746865207a65726f20616464726560448201527f73730000000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b0383811660008181526001602090815260408083209487168084529482529182902085905590518481527f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925910160405180910390a3505050565b60008281526007602090815260408083206001600160a01b038516845290915290205460ff166105ff57610a7d816001600160a01b031660146110d9565b610a888360206110d9565b604051602001610a999291906114c9565b60408051601f198184030181529082905262461bcd60e51b825261051591600401611327565b6001600160a01b038216610b3b5760405162461bcd60e51b815260206004820152602160248201527f45524332303a206275726e2066726f6d20746865207a65726f2061646472657360448201527f73000000000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b03821660009081526020819052604090205481811015610bca5760405162461bcd60e51b815260206004820152602260248201527f45524332303a206275726e20616d6f756e7420657863656564732062616c616e60448201527f63650000000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b0383166000908152602081905260408120838303905560028054849290610bf990849061154a565b90915550506040518281526000906001600160a01b038516907fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef9060200160405180910390a3505050565b6000610c5084846107d6565b90506000198114610cb85781811015610cab5760405162461bcd60e51b815260206004820152601d60248201527f45524332303a20696e73756666696369656e7420616c6c6f77616e63650000006044820152606401610515565b610cb884848484036108e7565b50505050565b6001600160a01b038316610d3a5760405162461bcd60e51b815260206004820152602560248201527f45524332303a207472616e736665722066726f6d20746865207a65726f20616460448201527f64726573730000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b038216610db65760405162461bcd60e51b815260206004820152602360248201527f45524332303a207472616e7366657220746f20746865207a65726f206164647260448201527f65737300000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b03831660009081526020819052604090205481811015610e455760405162461bcd60e51b815260206004820152602660248201527f45524332303a207472616e7366657220616d6f756e742065786365656473206260448201527f616c616e636500000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b03808516600090815260208190526040808220858503905591851681529081208054849290610e7c9084906114b6565b92505081905550826001600160a01b0316846001600160a01b03167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef84604051610ec891815260200190565b60405180910390a3610cb8565b60008281526007602090815260408083206001600160a01b038516845290915290205460ff166105ff5760008281526007602090815260408083206001600160a01b03851684529091529020805460ff19166001179055610f333390565b6001600160a01b0316816001600160a01b0316837f2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d60405160405180910390a45050565b60008281526007602090815260408083206001600160a01b038516845290915290205460ff16156105ff5760008281526007602090815260408083206001600160a01b0385168085529252808320805460ff1916905551339285917ff6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b9190a45050565b6001600160a01b0382166110505760405162461bcd60e51b815260206004820152601f60248201527f45524332303a206d696e7420746f20746865207a65726f2061646472657373006044820152606401610515565b806002600082825461106291906114b6565b90915550506001600160a01b0382166000908152602081905260408120805483929061108f9084906114b6565b90915550506040518181526001600160a01b038316906000907fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef9060200160405180910390a35050565b606060006110e883600261155d565b6110f39060026114b6565b67ffffffffffffffff81111561110b5761110b611574565b6040519080825280601f01601f191660200182016040528015611135576020820181803683370190505b5090507f30000000000000000000000000000000000000000000000000000000000000008160008151811061116c5761116c61158a565b60200101906001600160f81b031916908160001a9053507f7800000000000000000000000000000000000000000000000000000000000000816001815181106111b7576111b761158a565b60200101906001600160f81b031916908160001a90535060006111db84600261155d565b6111e69060016114b6565b90505b600181111561126b577f303132333435363738396162636465660000000000000000000000000000000085600f16601081106112275761122761158a565b1a60f81b82828151811061123d5761123d61158a565b60200101906001600160f81b031916908160001a90535060049490941c93611264816115a0565b90506111e9565b5083156112ba5760405162461bcd60e51b815260206004820181905260248201527f537472696e67733a20686578206c656e67746820696e73756666696369656e746044820152606401610515565b9392505050565b6000602082840312156112d357600080fd5b81357fffffffff00000000000000000000000000000000000000000000000000000000811681146112ba57600080fd5b60005b8381101561131e578181015183820152602001611306565b50506000910152565b6020815260008251806020840152611346816040850160208701611303565b601f01601f19169190910160400192915050565b80356001600160a01b038116811461137157600080fd5b919050565b6000806040838503121561138957600080fd5b6113928361135a565b946020939093013593505050565b6000806000606084860312156113b557600080fd5b6113be8461135a565b92506113cc6020850161135a565b9150604084013590509250925092565b6000602082840312156113ee57600080fd5b5035919050565b6000806040838503121561140857600080fd5b823591506114186020840161135a565b90509250929050565b60006020828403121561143357600080fd5b6112ba8261135a565b6000806040838503121561144f57600080fd5b6114588361135a565b91506114186020840161135a565b600181811c9082168061147a57607f821691505b60208210810361149a57634e487b7160e01b600052602260045260246000fd5b50919050565b634e487b7160e01b600052601160045260246000fd5b808201808211156103e6576103e66114a0565b7f416363657373436f6e74726f6c3a206163636f756e7420000000000000000000815260008351611501816017850160208801611303565b7f206973206d697373696e6720726f6c6520000000000000000000000000000000601791840191820152835161153e816028840160208801611303565b01602801949350505050565b818103818111156103e6576103e66114a0565b80820281158282048414176103e6576103e66114a0565b634e487b7160e01b600052604160045260246000fd5b634e487b7160e01b600052603260045260246000fd5b6000816115af576115af6114a0565b50600019019056fea2646970667358221220fd40e0b6d2f7e05359ac778dd3e4f00a0a12c10314a12fd3959b3aa0dbe4844564736f6c63430008150033a26469706673582212201e9c4c04280ac62d391cb2bcf2e1d52996504bb2340c0cf0ddb52f428b7d274a64736f6c63430008150033",
 }
 
 // EthereumBridgeABI is the input ABI used to generate the binding from.
@@ -233,6 +241,68 @@ func (_EthereumBridge *EthereumBridgeCallerSession) HasTokenMapping(wrappedToken
 	return _EthereumBridge.Contract.HasTokenMapping(&_EthereumBridge.CallOpts, wrappedToken)
 }
 
+// L1TokenOf is a free data retrieval call binding the contract method 0x5c4994b1.
+//
+// Solidity: function l1TokenOf(address l2Token) view returns(address)
+func (_EthereumBridge *EthereumBridgeCaller) L1TokenOf(opts *bind.CallOpts, l2Token common.Address) (common.Address, error) {
+	var out []interface{}
+	err := _EthereumBridge.contract.Call(opts, &out, "l1TokenOf", l2Token)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// L1TokenOf is a free data retrieval call binding the contract method 0x5c4994b1.
+//
+// Solidity: function l1TokenOf(address l2Token) view returns(address)
+func (_EthereumBridge *EthereumBridgeSession) L1TokenOf(l2Token common.Address) (common.Address, error) {
+	return _EthereumBridge.Contract.L1TokenOf(&_EthereumBridge.CallOpts, l2Token)
+}
+
+// L1TokenOf is a free data retrieval call binding the contract method 0x5c4994b1.
+//
+// Solidity: function l1TokenOf(address l2Token) view returns(address)
+func (_EthereumBridge *EthereumBridgeCallerSession) L1TokenOf(l2Token common.Address) (common.Address, error) {
+	return _EthereumBridge.Contract.L1TokenOf(&_EthereumBridge.CallOpts, l2Token)
+}
+
+// L2TokenOf is a free data retrieval call binding the contract method 0xc098a8c0.
+//
+// Solidity: function l2TokenOf(address l1Token) view returns(address)
+func (_EthereumBridge *EthereumBridgeCaller) L2TokenOf(opts *bind.CallOpts, l1Token common.Address) (common.Address, error) {
+	var out []interface{}
+	err := _EthereumBridge.contract.Call(opts, &out, "l2TokenOf", l1Token)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// L2TokenOf is a free data retrieval call binding the contract method 0xc098a8c0.
+//
+// Solidity: function l2TokenOf(address l1Token) view returns(address)
+func (_EthereumBridge *EthereumBridgeSession) L2TokenOf(l1Token common.Address) (common.Address, error) {
+	return _EthereumBridge.Contract.L2TokenOf(&_EthereumBridge.CallOpts, l1Token)
+}
+
+// L2TokenOf is a free data retrieval call binding the contract method 0xc098a8c0.
+//
+// Solidity: function l2TokenOf(address l1Token) view returns(address)
+func (_EthereumBridge *EthereumBridgeCallerSession) L2TokenOf(l1Token common.Address) (common.Address, error) {
+	return _EthereumBridge.Contract.L2TokenOf(&_EthereumBridge.CallOpts, l1Token)
+}
+
 // LocalToRemoteToken is a free data retrieval call binding the contract method 0x9e405b71.
 //
 // Solidity: function localToRemoteToken(address ) view returns(address)
@@ -295,6 +365,68 @@ func (_EthereumBridge *EthereumBridgeCallerSession) RemoteToLocalToken(arg0 comm
 	return _EthereumBridge.Contract.RemoteToLocalToken(&_EthereumBridge.CallOpts, arg0)
 }
 
+// TokenMappings is a free data retrieval call binding the contract method 0x2fb7ef4a.
+//
+// Solidity: function tokenMappings(uint256 offset, uint256 limit) view returns((address,address,string,string)[])
+func (_EthereumBridge *EthereumBridgeCaller) TokenMappings(opts *bind.CallOpts, offset *big.Int, limit *big.Int) ([]ITokenRegistryTokenMapping, error) {
+	var out []interface{}
+	err := _EthereumBridge.contract.Call(opts, &out, "tokenMappings", offset, limit)
+
+	if err != nil {
+		return *new([]ITokenRegistryTokenMapping), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]ITokenRegistryTokenMapping)).(*[]ITokenRegistryTokenMapping)
+
+	return out0, err
+
+}
+
+// TokenMappings is a free data retrieval call binding the contract method 0x2fb7ef4a.
+//
+// Solidity: function tokenMappings(uint256 offset, uint256 limit) view returns((address,address,string,string)[])
+func (_EthereumBridge *EthereumBridgeSession) TokenMappings(offset *big.Int, limit *big.Int) ([]ITokenRegistryTokenMapping, error) {
+	return _EthereumBridge.Contract.TokenMappings(&_EthereumBridge.CallOpts, offset, limit)
+}
+
+// TokenMappings is a free data retrieval call binding the contract method 0x2fb7ef4a.
+//
+// Solidity: function tokenMappings(uint256 offset, uint256 limit) view returns((address,address,string,string)[])
+func (_EthereumBridge *EthereumBridgeCallerSession) TokenMappings(offset *big.Int, limit *big.Int) ([]ITokenRegistryTokenMapping, error) {
+	return _EthereumBridge.Contract.TokenMappings(&_EthereumBridge.CallOpts, offset, limit)
+}
+
+// TokenMappingsCount is a free data retrieval call binding the contract method 0x96344470.
+//
+// Solidity: function tokenMappingsCount() view returns(uint256)
+func (_EthereumBridge *EthereumBridgeCaller) TokenMappingsCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _EthereumBridge.contract.Call(opts, &out, "tokenMappingsCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TokenMappingsCount is a free data retrieval call binding the contract method 0x96344470.
+//
+// Solidity: function tokenMappingsCount() view returns(uint256)
+func (_EthereumBridge *EthereumBridgeSession) TokenMappingsCount() (*big.Int, error) {
+	return _EthereumBridge.Contract.TokenMappingsCount(&_EthereumBridge.CallOpts)
+}
+
+// TokenMappingsCount is a free data retrieval call binding the contract method 0x96344470.
+//
+// Solidity: function tokenMappingsCount() view returns(uint256)
+func (_EthereumBridge *EthereumBridgeCallerSession) TokenMappingsCount() (*big.Int, error) {
+	return _EthereumBridge.Contract.TokenMappingsCount(&_EthereumBridge.CallOpts)
+}
+
 // WrappedTokens is a free data retrieval call binding the contract method 0xd5c6b504.
 //
 // Solidity: function wrappedTokens(address ) view returns(address)
@@ -630,3 +762,158 @@ func (_EthereumBridge *EthereumBridgeFilterer) ParseCreatedWrappedToken(log type
 	event.Raw = log
 	return event, nil
 }
+
+// EthereumBridgeTokenRegisteredIterator is returned from FilterTokenRegistered and is used to iterate over the raw logs and unpacked data for TokenRegistered events raised by the EthereumBridge contract.
+type EthereumBridgeTokenRegisteredIterator struct {
+	Event *EthereumBridgeTokenRegistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *EthereumBridgeTokenRegisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(EthereumBridgeTokenRegistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(EthereumBridgeTokenRegistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *EthereumBridgeTokenRegisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *EthereumBridgeTokenRegisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// EthereumBridgeTokenRegistered represents a TokenRegistered event raised by the EthereumBridge contract.
+type EthereumBridgeTokenRegistered struct {
+	L1Token common.Address
+	L2Token common.Address
+	Name    string
+	Symbol  string
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterTokenRegistered is a free log retrieval operation binding the contract event 0xfbe16c292428cdc3e66e97f954e91cc4f49b472d24989df1b2cdc27f295a7067.
+//
+// Solidity: event TokenRegistered(address indexed l1Token, address indexed l2Token, string name, string symbol)
+func (_EthereumBridge *EthereumBridgeFilterer) FilterTokenRegistered(opts *bind.FilterOpts, l1Token []common.Address, l2Token []common.Address) (*EthereumBridgeTokenRegisteredIterator, error) {
+
+	var l1TokenRule []interface{}
+	for _, l1TokenItem := range l1Token {
+		l1TokenRule = append(l1TokenRule, l1TokenItem)
+	}
+	var l2TokenRule []interface{}
+	for _, l2TokenItem := range l2Token {
+		l2TokenRule = append(l2TokenRule, l2TokenItem)
+	}
+
+	logs, sub, err := _EthereumBridge.contract.FilterLogs(opts, "TokenRegistered", l1TokenRule, l2TokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return &EthereumBridgeTokenRegisteredIterator{contract: _EthereumBridge.contract, event: "TokenRegistered", logs: logs, sub: sub}, nil
+}
+
+// WatchTokenRegistered is a free log subscription operation binding the contract event 0xfbe16c292428cdc3e66e97f954e91cc4f49b472d24989df1b2cdc27f295a7067.
+//
+// Solidity: event TokenRegistered(address indexed l1Token, address indexed l2Token, string name, string symbol)
+func (_EthereumBridge *EthereumBridgeFilterer) WatchTokenRegistered(opts *bind.WatchOpts, sink chan<- *EthereumBridgeTokenRegistered, l1Token []common.Address, l2Token []common.Address) (event.Subscription, error) {
+
+	var l1TokenRule []interface{}
+	for _, l1TokenItem := range l1Token {
+		l1TokenRule = append(l1TokenRule, l1TokenItem)
+	}
+	var l2TokenRule []interface{}
+	for _, l2TokenItem := range l2Token {
+		l2TokenRule = append(l2TokenRule, l2TokenItem)
+	}
+
+	logs, sub, err := _EthereumBridge.contract.WatchLogs(opts, "TokenRegistered", l1TokenRule, l2TokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(EthereumBridgeTokenRegistered)
+				if err := _EthereumBridge.contract.UnpackLog(event, "TokenRegistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTokenRegistered is a log parse operation binding the contract event 0xfbe16c292428cdc3e66e97f954e91cc4f49b472d24989df1b2cdc27f295a7067.
+//
+// Solidity: event TokenRegistered(address indexed l1Token, address indexed l2Token, string name, string symbol)
+func (_EthereumBridge *EthereumBridgeFilterer) ParseTokenRegistered(log types.Log) (*EthereumBridgeTokenRegistered, error) {
+	event := new(EthereumBridgeTokenRegistered)
+	if err := _EthereumBridge.contract.UnpackLog(event, "TokenRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
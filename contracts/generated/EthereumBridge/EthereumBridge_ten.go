@@ -0,0 +1,495 @@
+// Code generated by tools/bindgen - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package EthereumBridge
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = bind.NewBoundContract
+	_ = types.Log{}
+	_ = gethcommon.Hash{}
+)
+
+// the ABI of the contract, parsed once
+var parsedEthereumBridgeABI = func() *abi.ABI {
+	parsed, err := EthereumBridgeMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// ParsedEthereumBridgeABI returns the parsed ABI of the EthereumBridge contract
+func ParsedEthereumBridgeABI() *abi.ABI {
+	return parsedEthereumBridgeABI
+}
+
+// unpackInput returns the arguments of a call to the method, checking that the call data is a call to it
+func unpackInput(methodName string, data []byte) ([]interface{}, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("the call data is too short to be a call to %s", methodName)
+	}
+	m, err := parsedEthereumBridgeABI.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+	if m.Name != methodName {
+		return nil, fmt.Errorf("the call data is a call to %s, not %s", m.Name, methodName)
+	}
+	return m.Inputs.Unpack(data[4:])
+}
+
+// ConfigureMethod is the name of the configure(address) method in the ABI
+const ConfigureMethod = "configure"
+
+// PackConfigure packs a call to configure(address)
+func PackConfigure(messengerAddress gethcommon.Address) ([]byte, error) {
+	return parsedEthereumBridgeABI.Pack(ConfigureMethod, messengerAddress)
+}
+
+// ConfigureInput is the arguments of a call to configure(address)
+type ConfigureInput struct {
+	MessengerAddress gethcommon.Address
+}
+
+// UnpackConfigureInput unpacks the arguments of a call to configure(address) from its call data
+func UnpackConfigureInput(data []byte) (*ConfigureInput, error) {
+	values, err := unpackInput(ConfigureMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &ConfigureInput{
+		MessengerAddress: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// HasTokenMappingMethod is the name of the hasTokenMapping(address) method in the ABI
+const HasTokenMappingMethod = "hasTokenMapping"
+
+// PackHasTokenMapping packs a call to hasTokenMapping(address)
+func PackHasTokenMapping(wrappedToken gethcommon.Address) ([]byte, error) {
+	return parsedEthereumBridgeABI.Pack(HasTokenMappingMethod, wrappedToken)
+}
+
+// HasTokenMappingInput is the arguments of a call to hasTokenMapping(address)
+type HasTokenMappingInput struct {
+	WrappedToken gethcommon.Address
+}
+
+// UnpackHasTokenMappingInput unpacks the arguments of a call to hasTokenMapping(address) from its call data
+func UnpackHasTokenMappingInput(data []byte) (*HasTokenMappingInput, error) {
+	values, err := unpackInput(HasTokenMappingMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &HasTokenMappingInput{
+		WrappedToken: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// UnpackHasTokenMappingOutput unpacks the result of a call to hasTokenMapping(address)
+func UnpackHasTokenMappingOutput(data []byte) (bool, error) {
+	values, err := parsedEthereumBridgeABI.Unpack(HasTokenMappingMethod, data)
+	if err != nil {
+		return *new(bool), err
+	}
+	return *abi.ConvertType(values[0], new(bool)).(*bool), nil
+}
+
+// InitializeMethod is the name of the initialize(address,address) method in the ABI
+const InitializeMethod = "initialize"
+
+// PackInitialize packs a call to initialize(address,address)
+func PackInitialize(messenger gethcommon.Address, remoteBridge gethcommon.Address) ([]byte, error) {
+	return parsedEthereumBridgeABI.Pack(InitializeMethod, messenger, remoteBridge)
+}
+
+// InitializeInput is the arguments of a call to initialize(address,address)
+type InitializeInput struct {
+	Messenger    gethcommon.Address
+	RemoteBridge gethcommon.Address
+}
+
+// UnpackInitializeInput unpacks the arguments of a call to initialize(address,address) from its call data
+func UnpackInitializeInput(data []byte) (*InitializeInput, error) {
+	values, err := unpackInput(InitializeMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &InitializeInput{
+		Messenger:    *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+		RemoteBridge: *abi.ConvertType(values[1], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// L1TokenOfMethod is the name of the l1TokenOf(address) method in the ABI
+const L1TokenOfMethod = "l1TokenOf"
+
+// PackL1TokenOf packs a call to l1TokenOf(address)
+func PackL1TokenOf(l2Token gethcommon.Address) ([]byte, error) {
+	return parsedEthereumBridgeABI.Pack(L1TokenOfMethod, l2Token)
+}
+
+// L1TokenOfInput is the arguments of a call to l1TokenOf(address)
+type L1TokenOfInput struct {
+	L2Token gethcommon.Address
+}
+
+// UnpackL1TokenOfInput unpacks the arguments of a call to l1TokenOf(address) from its call data
+func UnpackL1TokenOfInput(data []byte) (*L1TokenOfInput, error) {
+	values, err := unpackInput(L1TokenOfMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &L1TokenOfInput{
+		L2Token: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// UnpackL1TokenOfOutput unpacks the result of a call to l1TokenOf(address)
+func UnpackL1TokenOfOutput(data []byte) (gethcommon.Address, error) {
+	values, err := parsedEthereumBridgeABI.Unpack(L1TokenOfMethod, data)
+	if err != nil {
+		return *new(gethcommon.Address), err
+	}
+	return *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address), nil
+}
+
+// L2TokenOfMethod is the name of the l2TokenOf(address) method in the ABI
+const L2TokenOfMethod = "l2TokenOf"
+
+// PackL2TokenOf packs a call to l2TokenOf(address)
+func PackL2TokenOf(l1Token gethcommon.Address) ([]byte, error) {
+	return parsedEthereumBridgeABI.Pack(L2TokenOfMethod, l1Token)
+}
+
+// L2TokenOfInput is the arguments of a call to l2TokenOf(address)
+type L2TokenOfInput struct {
+	L1Token gethcommon.Address
+}
+
+// UnpackL2TokenOfInput unpacks the arguments of a call to l2TokenOf(address) from its call data
+func UnpackL2TokenOfInput(data []byte) (*L2TokenOfInput, error) {
+	values, err := unpackInput(L2TokenOfMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &L2TokenOfInput{
+		L1Token: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// UnpackL2TokenOfOutput unpacks the result of a call to l2TokenOf(address)
+func UnpackL2TokenOfOutput(data []byte) (gethcommon.Address, error) {
+	values, err := parsedEthereumBridgeABI.Unpack(L2TokenOfMethod, data)
+	if err != nil {
+		return *new(gethcommon.Address), err
+	}
+	return *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address), nil
+}
+
+// LocalToRemoteTokenMethod is the name of the localToRemoteToken(address) method in the ABI
+const LocalToRemoteTokenMethod = "localToRemoteToken"
+
+// PackLocalToRemoteToken packs a call to localToRemoteToken(address)
+func PackLocalToRemoteToken(arg0 gethcommon.Address) ([]byte, error) {
+	return parsedEthereumBridgeABI.Pack(LocalToRemoteTokenMethod, arg0)
+}
+
+// LocalToRemoteTokenInput is the arguments of a call to localToRemoteToken(address)
+type LocalToRemoteTokenInput struct {
+	Arg0 gethcommon.Address
+}
+
+// UnpackLocalToRemoteTokenInput unpacks the arguments of a call to localToRemoteToken(address) from its call data
+func UnpackLocalToRemoteTokenInput(data []byte) (*LocalToRemoteTokenInput, error) {
+	values, err := unpackInput(LocalToRemoteTokenMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &LocalToRemoteTokenInput{
+		Arg0: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// UnpackLocalToRemoteTokenOutput unpacks the result of a call to localToRemoteToken(address)
+func UnpackLocalToRemoteTokenOutput(data []byte) (gethcommon.Address, error) {
+	values, err := parsedEthereumBridgeABI.Unpack(LocalToRemoteTokenMethod, data)
+	if err != nil {
+		return *new(gethcommon.Address), err
+	}
+	return *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address), nil
+}
+
+// OnCreateTokenCommandMethod is the name of the onCreateTokenCommand(address,string,string) method in the ABI
+const OnCreateTokenCommandMethod = "onCreateTokenCommand"
+
+// PackOnCreateTokenCommand packs a call to onCreateTokenCommand(address,string,string)
+func PackOnCreateTokenCommand(crossChainAddress gethcommon.Address, name string, symbol string) ([]byte, error) {
+	return parsedEthereumBridgeABI.Pack(OnCreateTokenCommandMethod, crossChainAddress, name, symbol)
+}
+
+// OnCreateTokenCommandInput is the arguments of a call to onCreateTokenCommand(address,string,string)
+type OnCreateTokenCommandInput struct {
+	CrossChainAddress gethcommon.Address
+	Name              string
+	Symbol            string
+}
+
+// UnpackOnCreateTokenCommandInput unpacks the arguments of a call to onCreateTokenCommand(address,string,string) from its call data
+func UnpackOnCreateTokenCommandInput(data []byte) (*OnCreateTokenCommandInput, error) {
+	values, err := unpackInput(OnCreateTokenCommandMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &OnCreateTokenCommandInput{
+		CrossChainAddress: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+		Name:              *abi.ConvertType(values[1], new(string)).(*string),
+		Symbol:            *abi.ConvertType(values[2], new(string)).(*string),
+	}, nil
+}
+
+// ReceiveAssetsMethod is the name of the receiveAssets(address,uint256,address) method in the ABI
+const ReceiveAssetsMethod = "receiveAssets"
+
+// PackReceiveAssets packs a call to receiveAssets(address,uint256,address)
+func PackReceiveAssets(asset gethcommon.Address, amount *big.Int, receiver gethcommon.Address) ([]byte, error) {
+	return parsedEthereumBridgeABI.Pack(ReceiveAssetsMethod, asset, amount, receiver)
+}
+
+// ReceiveAssetsInput is the arguments of a call to receiveAssets(address,uint256,address)
+type ReceiveAssetsInput struct {
+	Asset    gethcommon.Address
+	Amount   *big.Int
+	Receiver gethcommon.Address
+}
+
+// UnpackReceiveAssetsInput unpacks the arguments of a call to receiveAssets(address,uint256,address) from its call data
+func UnpackReceiveAssetsInput(data []byte) (*ReceiveAssetsInput, error) {
+	values, err := unpackInput(ReceiveAssetsMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &ReceiveAssetsInput{
+		Asset:    *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+		Amount:   *abi.ConvertType(values[1], new(*big.Int)).(**big.Int),
+		Receiver: *abi.ConvertType(values[2], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// RemoteToLocalTokenMethod is the name of the remoteToLocalToken(address) method in the ABI
+const RemoteToLocalTokenMethod = "remoteToLocalToken"
+
+// PackRemoteToLocalToken packs a call to remoteToLocalToken(address)
+func PackRemoteToLocalToken(arg0 gethcommon.Address) ([]byte, error) {
+	return parsedEthereumBridgeABI.Pack(RemoteToLocalTokenMethod, arg0)
+}
+
+// RemoteToLocalTokenInput is the arguments of a call to remoteToLocalToken(address)
+type RemoteToLocalTokenInput struct {
+	Arg0 gethcommon.Address
+}
+
+// UnpackRemoteToLocalTokenInput unpacks the arguments of a call to remoteToLocalToken(address) from its call data
+func UnpackRemoteToLocalTokenInput(data []byte) (*RemoteToLocalTokenInput, error) {
+	values, err := unpackInput(RemoteToLocalTokenMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoteToLocalTokenInput{
+		Arg0: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// UnpackRemoteToLocalTokenOutput unpacks the result of a call to remoteToLocalToken(address)
+func UnpackRemoteToLocalTokenOutput(data []byte) (gethcommon.Address, error) {
+	values, err := parsedEthereumBridgeABI.Unpack(RemoteToLocalTokenMethod, data)
+	if err != nil {
+		return *new(gethcommon.Address), err
+	}
+	return *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address), nil
+}
+
+// SendERC20Method is the name of the sendERC20(address,uint256,address) method in the ABI
+const SendERC20Method = "sendERC20"
+
+// PackSendERC20 packs a call to sendERC20(address,uint256,address)
+func PackSendERC20(asset gethcommon.Address, amount *big.Int, receiver gethcommon.Address) ([]byte, error) {
+	return parsedEthereumBridgeABI.Pack(SendERC20Method, asset, amount, receiver)
+}
+
+// SendERC20Input is the arguments of a call to sendERC20(address,uint256,address)
+type SendERC20Input struct {
+	Asset    gethcommon.Address
+	Amount   *big.Int
+	Receiver gethcommon.Address
+}
+
+// UnpackSendERC20Input unpacks the arguments of a call to sendERC20(address,uint256,address) from its call data
+func UnpackSendERC20Input(data []byte) (*SendERC20Input, error) {
+	values, err := unpackInput(SendERC20Method, data)
+	if err != nil {
+		return nil, err
+	}
+	return &SendERC20Input{
+		Asset:    *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+		Amount:   *abi.ConvertType(values[1], new(*big.Int)).(**big.Int),
+		Receiver: *abi.ConvertType(values[2], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// SendNativeMethod is the name of the sendNative(address) method in the ABI
+const SendNativeMethod = "sendNative"
+
+// PackSendNative packs a call to sendNative(address)
+func PackSendNative(receiver gethcommon.Address) ([]byte, error) {
+	return parsedEthereumBridgeABI.Pack(SendNativeMethod, receiver)
+}
+
+// SendNativeInput is the arguments of a call to sendNative(address)
+type SendNativeInput struct {
+	Receiver gethcommon.Address
+}
+
+// UnpackSendNativeInput unpacks the arguments of a call to sendNative(address) from its call data
+func UnpackSendNativeInput(data []byte) (*SendNativeInput, error) {
+	values, err := unpackInput(SendNativeMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &SendNativeInput{
+		Receiver: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// TokenMappingsMethod is the name of the tokenMappings(uint256,uint256) method in the ABI
+const TokenMappingsMethod = "tokenMappings"
+
+// PackTokenMappings packs a call to tokenMappings(uint256,uint256)
+func PackTokenMappings(offset *big.Int, limit *big.Int) ([]byte, error) {
+	return parsedEthereumBridgeABI.Pack(TokenMappingsMethod, offset, limit)
+}
+
+// TokenMappingsInput is the arguments of a call to tokenMappings(uint256,uint256)
+type TokenMappingsInput struct {
+	Offset *big.Int
+	Limit  *big.Int
+}
+
+// UnpackTokenMappingsInput unpacks the arguments of a call to tokenMappings(uint256,uint256) from its call data
+func UnpackTokenMappingsInput(data []byte) (*TokenMappingsInput, error) {
+	values, err := unpackInput(TokenMappingsMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenMappingsInput{
+		Offset: *abi.ConvertType(values[0], new(*big.Int)).(**big.Int),
+		Limit:  *abi.ConvertType(values[1], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// UnpackTokenMappingsOutput unpacks the result of a call to tokenMappings(uint256,uint256)
+func UnpackTokenMappingsOutput(data []byte) ([]ITokenRegistryTokenMapping, error) {
+	values, err := parsedEthereumBridgeABI.Unpack(TokenMappingsMethod, data)
+	if err != nil {
+		return *new([]ITokenRegistryTokenMapping), err
+	}
+	return *abi.ConvertType(values[0], new([]ITokenRegistryTokenMapping)).(*[]ITokenRegistryTokenMapping), nil
+}
+
+// TokenMappingsCountMethod is the name of the tokenMappingsCount() method in the ABI
+const TokenMappingsCountMethod = "tokenMappingsCount"
+
+// PackTokenMappingsCount packs a call to tokenMappingsCount()
+func PackTokenMappingsCount() ([]byte, error) {
+	return parsedEthereumBridgeABI.Pack(TokenMappingsCountMethod)
+}
+
+// UnpackTokenMappingsCountOutput unpacks the result of a call to tokenMappingsCount()
+func UnpackTokenMappingsCountOutput(data []byte) (*big.Int, error) {
+	values, err := parsedEthereumBridgeABI.Unpack(TokenMappingsCountMethod, data)
+	if err != nil {
+		return *new(*big.Int), err
+	}
+	return *abi.ConvertType(values[0], new(*big.Int)).(**big.Int), nil
+}
+
+// WrappedTokensMethod is the name of the wrappedTokens(address) method in the ABI
+const WrappedTokensMethod = "wrappedTokens"
+
+// PackWrappedTokens packs a call to wrappedTokens(address)
+func PackWrappedTokens(arg0 gethcommon.Address) ([]byte, error) {
+	return parsedEthereumBridgeABI.Pack(WrappedTokensMethod, arg0)
+}
+
+// WrappedTokensInput is the arguments of a call to wrappedTokens(address)
+type WrappedTokensInput struct {
+	Arg0 gethcommon.Address
+}
+
+// UnpackWrappedTokensInput unpacks the arguments of a call to wrappedTokens(address) from its call data
+func UnpackWrappedTokensInput(data []byte) (*WrappedTokensInput, error) {
+	values, err := unpackInput(WrappedTokensMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &WrappedTokensInput{
+		Arg0: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// UnpackWrappedTokensOutput unpacks the result of a call to wrappedTokens(address)
+func UnpackWrappedTokensOutput(data []byte) (gethcommon.Address, error) {
+	values, err := parsedEthereumBridgeABI.Unpack(WrappedTokensMethod, data)
+	if err != nil {
+		return *new(gethcommon.Address), err
+	}
+	return *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address), nil
+}
+
+// CreatedWrappedTokenEventID is the topic of the CreatedWrappedToken(address,address,string,string) event
+var CreatedWrappedTokenEventID = gethcommon.HexToHash("0x30c05779f384e0ae9d43bbf7ec4417f28bdc53d02a35551b6eb270a9c4c71dca")
+
+// CreatedWrappedTokenIsLifecycle is true: on TEN, the CreatedWrappedToken events have no indexed address field, so they are lifecycle
+// events visible to everyone.
+const CreatedWrappedTokenIsLifecycle = true
+
+// UnpackCreatedWrappedTokenEvent unpacks a CreatedWrappedToken(address,address,string,string) log
+func UnpackCreatedWrappedTokenEvent(log types.Log) (*EthereumBridgeCreatedWrappedToken, error) {
+	event := new(EthereumBridgeCreatedWrappedToken)
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsedEthereumBridgeABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "CreatedWrappedToken", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// TokenRegisteredEventID is the topic of the TokenRegistered(address,address,string,string) event
+var TokenRegisteredEventID = gethcommon.HexToHash("0xfbe16c292428cdc3e66e97f954e91cc4f49b472d24989df1b2cdc27f295a7067")
+
+// TokenRegisteredIsLifecycle is false: on TEN, the TokenRegistered events are only visible to the user accounts in their
+// indexed l1Token, l2Token fields, or to everyone if none of them holds a user account.
+const TokenRegisteredIsLifecycle = false
+
+// UnpackTokenRegisteredEvent unpacks a TokenRegistered(address,address,string,string) log
+func UnpackTokenRegisteredEvent(log types.Log) (*EthereumBridgeTokenRegistered, error) {
+	event := new(EthereumBridgeTokenRegistered)
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsedEthereumBridgeABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "TokenRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -53,8 +53,8 @@ type StructsMetaRollup struct {
 
 // ManagementContractMetaData contains all meta data concerning the ManagementContract contract.
 var ManagementContractMetaData = &bind.MetaData{
-	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"newAddress\",\"type\":\"address\"}],\"name\":\"ImportantContractAddressUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"messageBusAddress\",\"type\":\"address\"}],\"name\":\"LogManagementContractCreated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"inputs\":[{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"Hash\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"AggregatorID\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"LastSequenceNumber\",\"type\":\"uint256\"}],\"internalType\":\"structStructs.MetaRollup\",\"name\":\"r\",\"type\":\"tuple\"},{\"internalType\":\"string\",\"name\":\"_rollupData\",\"type\":\"string\"},{\"components\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"internalType\":\"uint32\",\"name\":\"nonce\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"topic\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"},{\"internalType\":\"uint8\",\"name\":\"consistencyLevel\",\"type\":\"uint8\"}],\"internalType\":\"structStructs.CrossChainMessage[]\",\"name\":\"messages\",\"type\":\"tuple[]\"}],\"internalType\":\"structStructs.HeaderCrossChainData\",\"name\":\"crossChainData\",\"type\":\"tuple\"}],\"name\":\"AddRollup\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_addr\",\"type\":\"address\"}],\"name\":\"Attested\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"GetHostAddresses\",\"outputs\":[{\"internalType\":\"string[]\",\"name\":\"\",\"type\":\"string[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"GetImportantContractKeys\",\"outputs\":[{\"internalType\":\"string[]\",\"name\":\"\",\"type\":\"string[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"rollupHash\",\"type\":\"bytes32\"}],\"name\":\"GetRollupByHash\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"},{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"Hash\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"AggregatorID\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"LastSequenceNumber\",\"type\":\"uint256\"}],\"internalType\":\"structStructs.MetaRollup\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_aggregatorID\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"_initSecret\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"_hostAddress\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"_genesisAttestation\",\"type\":\"string\"}],\"name\":\"InitializeNetworkSecret\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"IsWithdrawalAvailable\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"requestReport\",\"type\":\"string\"}],\"name\":\"RequestNetworkSecret\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"attesterID\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"requesterID\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"attesterSig\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"responseSecret\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"hostAddress\",\"type\":\"string\"},{\"internalType\":\"bool\",\"name\":\"verifyAttester\",\"type\":\"bool\"}],\"name\":\"RespondNetworkSecret\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"RetrieveAllBridgeFunds\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"},{\"internalType\":\"address\",\"name\":\"newAddress\",\"type\":\"address\"}],\"name\":\"SetImportantContractAddress\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"name\":\"importantContractAddresses\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"importantContractKeys\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"lastBatchSeqNo\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"messageBus\",\"outputs\":[{\"internalType\":\"contractIMessageBus\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
-	Bin: "0x608060405234801561001057600080fd5b5061001a3361001f565b61006f565b600080546001600160a01b038381166001600160a01b0319831681178455604051919092169283917f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e09190a35050565b61303d8061007e6000396000f3fe608060405234801561001057600080fd5b50600436106101515760003560e01c80638129fc1c116100cd578063a1a227fa11610081578063bbd79e1511610066578063bbd79e151461035d578063e34fbfc814610370578063f2fde38b1461038357600080fd5b8063a1a227fa1461033a578063a52f433c1461034d57600080fd5b80638da5cb5b116100b25780638da5cb5b146102f65780638fa0d0531461030757806398077e861461031a57600080fd5b80638129fc1c146102535780638236a7ba1461025b57600080fd5b8063440c953b116101245780636a30d26c116101095780636a30d26c1461023b578063715018a614610243578063728109961461024b57600080fd5b8063440c953b1461021157806359a900711461022857600080fd5b806303e72e4814610156578063324ff8661461016b5780633e60a22f1461018957806343348b2f146101d5575b600080fd5b6101696101643660046115e2565b610396565b005b6101736104fd565b604051610180919061168c565b60405180910390f35b6101bd6101973660046116ee565b80516020818301810180516005825292820191909301209152546001600160a01b031681565b6040516001600160a01b039091168152602001610180565b6102016101e3366004611723565b6001600160a01b031660009081526002602052604090205460ff1690565b6040519015158152602001610180565b61021a60075481565b604051908152602001610180565b610169610236366004611789565b6105d6565b610173610660565b610169610730565b610169610796565b610169610868565b6102c3610269366004611830565b6040805160608082018352600080835260208084018290529284018190528481526008835283902083519182018452805480835260018201546001600160a01b031693830193909352600201549281019290925290911491565b60408051921515835281516020808501919091528201516001600160a01b03168382015201516060820152608001610180565b6000546001600160a01b03166101bd565b610169610315366004611849565b610a27565b61032d610328366004611830565b610ab6565b60405161018091906118d0565b6009546101bd906001600160a01b031681565b600654610100900460ff16610201565b61016961036b3660046118e3565b610b62565b61016961037e3660046119a9565b610cc7565b610169610391366004611723565b610ce6565b6000546001600160a01b031633146103f55760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e657260448201526064015b60405180910390fd5b60006001600160a01b031660058360405161041091906119eb565b908152604051908190036020019020546001600160a01b0316141561047457600480546001810182556000919091528251610472917f8a35acfbc15ff81a39ae7d344fd709f28e8600b4aa8c65c6b64bfe7fe36bd19b01906020850190611410565b505b8060058360405161048591906119eb565b90815260405190819003602001812080546001600160a01b039390931673ffffffffffffffffffffffffffffffffffffffff19909316929092179091557f17b2f9f5748931099ffee882b5b64f4a560b5c55da9b4f4e396dae3bb9f98cb5906104f19084908490611a07565b60405180910390a15050565b60606003805480602002602001604051908101604052809291908181526020016000905b828210156105cd57838290600052602060002001805461054090611a32565b80601f016020809104026020016040519081016040528092919081815260200182805461056c90611a32565b80156105b95780601f1061058e576101008083540402835291602001916105b9565b820191906000526020600020905b81548152906001019060200180831161059c57829003601f168201915b505050505081526020019060010190610521565b50505050905090565b60065460ff16156105e657600080fd5b60068054600160ff1991821681179092556001600160a01b038816600090815260026020908152604082208054909316841790925560038054938401815590528451610657927fc2575a0e9e593c00f959f8c92f12db2869c3395a3b0502d05e2516446f71f85b0191860190611410565b50505050505050565b60606004805480602002602001604051908101604052809291908181526020016000905b828210156105cd5783829060005260206000200180546106a390611a32565b80601f01602080910402602001604051908101604052809291908181526020018280546106cf90611a32565b801561071c5780601f106106f15761010080835404028352916020019161071c565b820191906000526020600020905b8154815290600101906020018083116106ff57829003601f168201915b505050505081526020019060010190610684565b6000546001600160a01b0316331461078a5760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e657260448201526064016103ec565b6107946000610dc1565b565b6000546001600160a01b031633146107f05760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e657260448201526064016103ec565b6009546040517f36d2da900000000000000000000000000000000000000000000000000000000081523360048201526001600160a01b03909116906336d2da9090602401600060405180830381600087803b15801561084e57600080fd5b505af1158015610862573d6000803e3d6000fd5b50505050565b600054600160a81b900460ff1661089d5760005474010000000000000000000000000000000000000000900460ff16156108a1565b303b155b6109135760405162461bcd60e51b815260206004820152602e60248201527f496e697469616c697a61626c653a20636f6e747261637420697320616c72656160448201527f647920696e697469616c697a656400000000000000000000000000000000000060648201526084016103ec565b600054600160a81b900460ff1615801561096857600080547fffffffffffffffffffff0000ffffffffffffffffffffffffffffffffffffffff1675010100000000000000000000000000000000000000001790555b600060075560405161097990611494565b604051809103906000f080158015610995573d6000803e3d6000fd5b506009805473ffffffffffffffffffffffffffffffffffffffff19166001600160a01b039290921691821790556040519081527fbd726cf82ac9c3260b1495107182e336e0654b25c10915648c0cc15b2bb72cbf9060200160405180910390a18015610a2457600080547fffffffffffffffffffff00ffffffffffffffffffffffffffffffffffffffffff1690555b50565b60026000610a3b6040870160208801611723565b6001600160a01b0316815260208101919091526040016000205460ff16610aa45760405162461bcd60e51b815260206004820152601760248201527f61676772656761746f72206e6f7420617474657374656400000000000000000060448201526064016103ec565b610aad84610e1e565b61086281610e53565b60048181548110610ac657600080fd5b906000526020600020016000915090508054610ae190611a32565b80601f0160208091040260200160405190810160405280929190818152602001828054610b0d90611a32565b8015610b5a5780601f10610b2f57610100808354040283529160200191610b5a565b820191906000526020600020905b815481529060010190602001808311610b3d57829003601f168201915b505050505081565b6001600160a01b03861660009081526002602052604090205460ff1680610b8857600080fd5b8115610c58576000610bbe88888688604051602001610baa9493929190611a6d565b604051602081830303815290604052610f0d565b90506000610bcc8288610f48565b9050886001600160a01b0316816001600160a01b031614610c555760405162461bcd60e51b815260206004820152602c60248201527f63616c63756c61746564206164647265737320616e642061747465737465724960448201527f4420646f6e74206d61746368000000000000000000000000000000000000000060648201526084016103ec565b50505b6001600160a01b03861660009081526002602090815260408220805460ff191660019081179091556003805491820181559092528451610cbd927fc2575a0e9e593c00f959f8c92f12db2869c3395a3b0502d05e2516446f71f85b0191860190611410565b5050505050505050565b336000908152600160205260409020610ce19083836114a1565b505050565b6000546001600160a01b03163314610d405760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e657260448201526064016103ec565b6001600160a01b038116610dbc5760405162461bcd60e51b815260206004820152602660248201527f4f776e61626c653a206e6577206f776e657220697320746865207a65726f206160448201527f646472657373000000000000000000000000000000000000000000000000000060648201526084016103ec565b610a24815b600080546001600160a01b0383811673ffffffffffffffffffffffffffffffffffffffff19831681178455604051919092169283917f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e09190a35050565b803560009081526008602052604090208190610e3a8282611ac9565b505060075460408201351115610a245760400135600755565b6000610e5f8280611b15565b9050905060005b81811015610ce1576009546001600160a01b0316639730886d610e898580611b15565b84818110610e9957610e99611b5f565b9050602002810190610eab9190611b75565b60016040518363ffffffff1660e01b8152600401610eca929190611c24565b600060405180830381600087803b158015610ee457600080fd5b505af1158015610ef8573d6000803e3d6000fd5b5050505080610f0690611cf1565b9050610e66565b6000610f198251610f6c565b82604051602001610f2b929190611d0c565b604051602081830303815290604052805190602001209050919050565b6000806000610f5785856110a6565b91509150610f6481611116565b509392505050565b606081610fac57505060408051808201909152600181527f3000000000000000000000000000000000000000000000000000000000000000602082015290565b8160005b8115610fd65780610fc081611cf1565b9150610fcf9050600a83611d7d565b9150610fb0565b60008167ffffffffffffffff811115610ff157610ff161152a565b6040519080825280601f01601f19166020018201604052801561101b576020820181803683370190505b5090505b841561109e57611030600183611d91565b915061103d600a86611da8565b611048906030611dbc565b60f81b81838151811061105d5761105d611b5f565b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a905350611097600a86611d7d565b945061101f565b949350505050565b6000808251604114156110dd5760208301516040840151606085015160001a6110d1878285856112d1565b9450945050505061110f565b82516040141561110757602083015160408401516110fc8683836113be565b93509350505061110f565b506000905060025b9250929050565b600081600481111561112a5761112a611dd4565b14156111335750565b600181600481111561114757611147611dd4565b14156111955760405162461bcd60e51b815260206004820152601860248201527f45434453413a20696e76616c6964207369676e6174757265000000000000000060448201526064016103ec565b60028160048111156111a9576111a9611dd4565b14156111f75760405162461bcd60e51b815260206004820152601f60248201527f45434453413a20696e76616c6964207369676e6174757265206c656e6774680060448201526064016103ec565b600381600481111561120b5761120b611dd4565b14156112645760405162461bcd60e51b815260206004820152602260248201527f45434453413a20696e76616c6964207369676e6174757265202773272076616c604482015261756560f01b60648201526084016103ec565b600481600481111561127857611278611dd4565b1415610a245760405162461bcd60e51b815260206004820152602260248201527f45434453413a20696e76616c6964207369676e6174757265202776272076616c604482015261756560f01b60648201526084016103ec565b6000807f7fffffffffffffffffffffffffffffff5d576e7357a4501ddfe92f46681b20a083111561130857506000905060036113b5565b8460ff16601b1415801561132057508460ff16601c14155b1561133157506000905060046113b5565b6040805160008082526020820180845289905260ff881692820192909252606081018690526080810185905260019060a0016020604051602081039080840390855afa158015611385573d6000803e3d6000fd5b5050604051601f1901519150506001600160a01b0381166113ae576000600192509250506113b5565b9150600090505b94509492505050565b6000807f7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff8316816113f460ff86901c601b611dbc565b9050611402878288856112d1565b935093505050935093915050565b82805461141c90611a32565b90600052602060002090601f01602090048101928261143e5760008555611484565b82601f1061145757805160ff1916838001178555611484565b82800160010185558215611484579182015b82811115611484578251825591602001919060010190611469565b50611490929150611515565b5090565b61121d80611deb83390190565b8280546114ad90611a32565b90600052602060002090601f0160209004810192826114cf5760008555611484565b82601f106114e85782800160ff19823516178555611484565b82800160010185558215611484579182015b828111156114845782358255916020019190600101906114fa565b5b808211156114905760008155600101611516565b634e487b7160e01b600052604160045260246000fd5b600082601f83011261155157600080fd5b813567ffffffffffffffff8082111561156c5761156c61152a565b604051601f8301601f19908116603f011681019082821181831017156115945761159461152a565b816040528381528660208588010111156115ad57600080fd5b836020870160208301376000602085830101528094505050505092915050565b6001600160a01b0381168114610a2457600080fd5b600080604083850312156115f557600080fd5b823567ffffffffffffffff81111561160c57600080fd5b61161885828601611540565b9250506020830135611629816115cd565b809150509250929050565b60005b8381101561164f578181015183820152602001611637565b838111156108625750506000910152565b60008151808452611678816020860160208601611634565b601f01601f19169290920160200192915050565b6000602080830181845280855180835260408601915060408160051b870101925083870160005b828110156116e157603f198886030184526116cf858351611660565b945092850192908501906001016116b3565b5092979650505050505050565b60006020828403121561170057600080fd5b813567ffffffffffffffff81111561171757600080fd5b61109e84828501611540565b60006020828403121561173557600080fd5b8135611740816115cd565b9392505050565b60008083601f84011261175957600080fd5b50813567ffffffffffffffff81111561177157600080fd5b60208301915083602082850101111561110f57600080fd5b600080600080600080608087890312156117a257600080fd5b86356117ad816115cd565b9550602087013567ffffffffffffffff808211156117ca57600080fd5b6117d68a838b01611747565b909750955060408901359150808211156117ef57600080fd5b6117fb8a838b01611540565b9450606089013591508082111561181157600080fd5b5061181e89828a01611747565b979a9699509497509295939492505050565b60006020828403121561184257600080fd5b5035919050565b60008060008084860360a081121561186057600080fd5b606081121561186e57600080fd5b50849350606085013567ffffffffffffffff8082111561188d57600080fd5b61189988838901611747565b909550935060808701359150808211156118b257600080fd5b508501602081880312156118c557600080fd5b939692955090935050565b6020815260006117406020830184611660565b60008060008060008060c087890312156118fc57600080fd5b8635611907816115cd565b95506020870135611917816115cd565b9450604087013567ffffffffffffffff8082111561193457600080fd5b6119408a838b01611540565b9550606089013591508082111561195657600080fd5b6119628a838b01611540565b9450608089013591508082111561197857600080fd5b5061198589828a01611540565b92505060a0870135801515811461199b57600080fd5b809150509295509295509295565b600080602083850312156119bc57600080fd5b823567ffffffffffffffff8111156119d357600080fd5b6119df85828601611747565b90969095509350505050565b600082516119fd818460208701611634565b9190910192915050565b604081526000611a1a6040830185611660565b90506001600160a01b03831660208301529392505050565b600181811c90821680611a4657607f821691505b60208210811415611a6757634e487b7160e01b600052602260045260246000fd5b50919050565b60006bffffffffffffffffffffffff19808760601b168352808660601b166014840152508351611aa4816028850160208801611634565b835190830190611abb816028840160208801611634565b016028019695505050505050565b81358155600181016020830135611adf816115cd565b6001600160a01b03811673ffffffffffffffffffffffffffffffffffffffff198354161782555050604082013560028201555050565b6000808335601e19843603018112611b2c57600080fd5b83018035915067ffffffffffffffff821115611b4757600080fd5b6020019150600581901b360382131561110f57600080fd5b634e487b7160e01b600052603260045260246000fd5b6000823560be198336030181126119fd57600080fd5b803563ffffffff81168114611b9f57600080fd5b919050565b6000808335601e19843603018112611bbb57600080fd5b830160208101925035905067ffffffffffffffff811115611bdb57600080fd5b80360383131561110f57600080fd5b81835281816020850137506000828201602090810191909152601f909101601f19169091010190565b803560ff81168114611b9f57600080fd5b6040815260008335611c35816115cd565b6001600160a01b03166040830152602084013567ffffffffffffffff8116808214611c5f57600080fd5b606084015250611c7160408501611b8b565b63ffffffff166080830152611c8860608501611b8b565b63ffffffff1660a0830152611ca06080850185611ba4565b60c080850152611cb561010085018284611bea565b915050611cc460a08601611c13565b60ff1660e084015260209092019290925292915050565b634e487b7160e01b600052601160045260246000fd5b6000600019821415611d0557611d05611cdb565b5060010190565b7f19457468657265756d205369676e6564204d6573736167653a0a000000000000815260008351611d4481601a850160208801611634565b835190830190611d5b81601a840160208801611634565b01601a01949350505050565b634e487b7160e01b600052601260045260246000fd5b600082611d8c57611d8c611d67565b500490565b600082821015611da357611da3611cdb565b500390565b600082611db757611db7611d67565b500690565b60008219821115611dcf57611dcf611cdb565b500190565b634e487b7160e01b600052602160045260246000fdfe608060405234801561001057600080fd5b5061001a3361001f565b61006f565b600080546001600160a01b038381166001600160a01b0319831681178455604051919092169283917f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e09190a35050565b61119f8061007e6000396000f3fe6080604052600436106100b55760003560e01c80638da5cb5b1161006957806399a3ad211161004e57806399a3ad2114610270578063b1454caa14610290578063f2fde38b146102c95761012e565b80638da5cb5b146102285780639730886d146102505761012e565b8063346633fb1161009a578063346633fb146101de57806336d2da90146101f3578063715018a6146102135761012e565b80630fcfbd111461017b57806333a88c72146101ae5761012e565b3661012e576040517f346633fb0000000000000000000000000000000000000000000000000000000081523360048201523460248201819052309163346633fb91906044016000604051808303818588803b15801561011357600080fd5b505af1158015610127573d6000803e3d6000fd5b5050505050005b60405162461bcd60e51b815260206004820152600b60248201527f756e737570706f7274656400000000000000000000000000000000000000000060448201526064015b60405180910390fd5b34801561018757600080fd5b5061019b610196366004610ad9565b6102e9565b6040519081526020015b60405180910390f35b3480156101ba57600080fd5b506101ce6101c9366004610ad9565b61039f565b60405190151581526020016101a5565b6101f16101ec366004610b23565b6103f2565b005b3480156101ff57600080fd5b506101f161020e366004610b4f565b6104be565b34801561021f57600080fd5b506101f16105bf565b34801561023457600080fd5b506000546040516001600160a01b0390911681526020016101a5565b34801561025c57600080fd5b506101f161026b366004610b6c565b610625565b34801561027c57600080fd5b506101f161028b366004610b23565b6107c9565b34801561029c57600080fd5b506102b06102ab366004610be2565b6108cb565b60405167ffffffffffffffff90911681526020016101a5565b3480156102d557600080fd5b506101f16102e4366004610b4f565b610924565b600080826040516020016102fd9190610cce565b60408051601f19818403018152918152815160209283012060008181526001909352912054909150806103985760405162461bcd60e51b815260206004820152602160248201527f54686973206d65737361676520776173206e65766572207375626d697474656460448201527f2e000000000000000000000000000000000000000000000000000000000000006064820152608401610172565b9392505050565b600080826040516020016103b39190610cce565b60408051601f1981840301815291815281516020928301206000818152600190935291205490915080158015906103ea5750428111155b949350505050565b60003411801561040157508034145b6104735760405162461bcd60e51b815260206004820152603060248201527f417474656d7074696e6720746f2073656e642076616c756520776974686f757460448201527f2070726f766964696e67204574686572000000000000000000000000000000006064820152608401610172565b604080513381526001600160a01b0384166020820152348183015290517ff1365f826a788d6c1a955db0eed5ba8642674219c4771f8c65918617511a15609181900360600190a15050565b6000546001600160a01b031633146105185760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152606401610172565b6000816001600160a01b03164760405160006040518083038185875af1925050503d8060008114610565576040519150601f19603f3d011682016040523d82523d6000602084013e61056a565b606091505b50509050806105bb5760405162461bcd60e51b815260206004820152601460248201527f6661696c65642073656e64696e672076616c75650000000000000000000000006044820152606401610172565b5050565b6000546001600160a01b031633146106195760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152606401610172565b6106236000610a06565b565b6000546001600160a01b0316331461067f5760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152606401610172565b600061068b8242610dce565b90506000836040516020016106a09190610cce565b60408051601f198184030181529181528151602092830120600081815260019093529120549091501561073b5760405162461bcd60e51b815260206004820152602160248201527f4d657373616765207375626d6974746564206d6f7265207468616e206f6e636560448201527f21000000000000000000000000000000000000000000000000000000000000006064820152608401610172565b600081815260016020908152604082208490556002919061075e90870187610b4f565b6001600160a01b03168152602081019190915260400160009081209061078a6080870160608801610de6565b63ffffffff168152602080820192909252604001600090812080546001810182559082529190208591600402016107c18282610fc3565b505050505050565b6000546001600160a01b031633146108235760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152606401610172565b6000826001600160a01b03168260405160006040518083038185875af1925050503d8060008114610870576040519150601f19603f3d011682016040523d82523d6000602084013e610875565b606091505b50509050806108c65760405162461bcd60e51b815260206004820152601460248201527f6661696c65642073656e64696e672076616c75650000000000000000000000006044820152606401610172565b505050565b60006108d633610a63565b90507fb93c37389233beb85a3a726c3f15c2d15533ee74cb602f20f490dfffef7759373382888888888860405161091397969594939291906110dd565b60405180910390a195945050505050565b6000546001600160a01b0316331461097e5760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152606401610172565b6001600160a01b0381166109fa5760405162461bcd60e51b815260206004820152602660248201527f4f776e61626c653a206e6577206f776e657220697320746865207a65726f206160448201527f64647265737300000000000000000000000000000000000000000000000000006064820152608401610172565b610a0381610a06565b50565b600080546001600160a01b0383811673ffffffffffffffffffffffffffffffffffffffff19831681178455604051919092169283917f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e09190a35050565b6001600160a01b0381166000908152600360205260408120805467ffffffffffffffff169160019190610a96838561113d565b92506101000a81548167ffffffffffffffff021916908367ffffffffffffffff160217905550919050565b600060c08284031215610ad357600080fd5b50919050565b600060208284031215610aeb57600080fd5b813567ffffffffffffffff811115610b0257600080fd5b6103ea84828501610ac1565b6001600160a01b0381168114610a0357600080fd5b60008060408385031215610b3657600080fd5b8235610b4181610b0e565b946020939093013593505050565b600060208284031215610b6157600080fd5b813561039881610b0e565b60008060408385031215610b7f57600080fd5b823567ffffffffffffffff811115610b9657600080fd5b610ba285828601610ac1565b95602094909401359450505050565b63ffffffff81168114610a0357600080fd5b60ff81168114610a0357600080fd5b8035610bdd81610bc3565b919050565b600080600080600060808688031215610bfa57600080fd5b8535610c0581610bb1565b94506020860135610c1581610bb1565b9350604086013567ffffffffffffffff80821115610c3257600080fd5b818801915088601f830112610c4657600080fd5b813581811115610c5557600080fd5b896020828501011115610c6757600080fd5b6020830195508094505050506060860135610c8181610bc3565b809150509295509295909350565b67ffffffffffffffff81168114610a0357600080fd5b81835281816020850137506000828201602090810191909152601f909101601f19169091010190565b6020815260008235610cdf81610b0e565b6001600160a01b0381166020840152506020830135610cfd81610c8f565b67ffffffffffffffff808216604085015260408501359150610d1e82610bb1565b63ffffffff808316606086015260608601359250610d3b83610bb1565b80831660808601525060808501359150601e19853603018212610d5d57600080fd5b90840190813581811115610d7057600080fd5b803603861315610d7f57600080fd5b60c060a0860152610d9760e086018260208601610ca5565b92505050610da760a08501610bd2565b60ff811660c0850152509392505050565b634e487b7160e01b600052601160045260246000fd5b60008219821115610de157610de1610db8565b500190565b600060208284031215610df857600080fd5b813561039881610bb1565b60008135610e1081610bb1565b92915050565b6000808335601e19843603018112610e2d57600080fd5b83018035915067ffffffffffffffff821115610e4857600080fd5b602001915036819003821315610e5d57600080fd5b9250929050565b634e487b7160e01b600052604160045260246000fd5b600181811c90821680610e8e57607f821691505b60208210811415610ad357634e487b7160e01b600052602260045260246000fd5b601f8211156108c657600081815260208120601f850160051c81016020861015610ed65750805b601f850160051c820191505b818110156107c157828155600101610ee2565b67ffffffffffffffff831115610f0d57610f0d610e64565b610f2183610f1b8354610e7a565b83610eaf565b6000601f841160018114610f555760008515610f3d5750838201355b600019600387901b1c1916600186901b178355610faf565b600083815260209020601f19861690835b82811015610f865786850135825560209485019460019092019101610f66565b5086821015610fa35760001960f88860031b161c19848701351681555b505060018560011b0183555b5050505050565b60008135610e1081610bc3565b8135610fce81610b0e565b6001600160a01b038116905081548173ffffffffffffffffffffffffffffffffffffffff198216178355602084013561100681610c8f565b7bffffffffffffffff00000000000000000000000000000000000000008160a01b1690507fffffffff000000000000000000000000000000000000000000000000000000008184828516171785556040860135925061106483610bb1565b921760e09190911b90911617815561109c61108160608401610e03565b6001830163ffffffff821663ffffffff198254161781555050565b6110a96080830183610e16565b6110b7818360028601610ef5565b50506105bb6110c860a08401610fb6565b6003830160ff821660ff198254161781555050565b6001600160a01b038816815267ffffffffffffffff87166020820152600063ffffffff808816604084015280871660608401525060c0608083015261112660c083018587610ca5565b905060ff831660a083015298975050505050505050565b600067ffffffffffffffff80831681851680830382111561116057611160610db8565b0194935050505056fea2646970667358221220a54aad59b2972196a793838aab3ac603764d8f10f53f8b069358ac232fba77fe64736f6c63430008090033a26469706673582212204f3aa48020ee7ed6375c2fef601af17d8aad13dee08c197cbddc2f4500931a8f64736f6c63430008090033",
+	ABI: "[{\"inputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"constructor\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"GovernanceParameterChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"newAddress\",\"type\":\"address\"}],\"name\":\"ImportantContractAddressUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"messageBusAddress\",\"type\":\"address\"}],\"name\":\"LogManagementContractCreated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"inputs\":[{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"Hash\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"AggregatorID\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"LastSequenceNumber\",\"type\":\"uint256\"}],\"internalType\":\"structStructs.MetaRollup\",\"name\":\"r\",\"type\":\"tuple\"},{\"internalType\":\"string\",\"name\":\"_rollupData\",\"type\":\"string\"},{\"components\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"internalType\":\"uint32\",\"name\":\"nonce\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"topic\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"},{\"internalType\":\"uint8\",\"name\":\"consistencyLevel\",\"type\":\"uint8\"}],\"internalType\":\"structStructs.CrossChainMessage[]\",\"name\":\"messages\",\"type\":\"tuple[]\"}],\"internalType\":\"structStructs.HeaderCrossChainData\",\"name\":\"crossChainData\",\"type\":\"tuple\"}],\"name\":\"AddRollup\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_addr\",\"type\":\"address\"}],\"name\":\"Attested\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"hostID\",\"type\":\"address\"}],\"name\":\"GetHostAddress\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"GetHostAddresses\",\"outputs\":[{\"internalType\":\"string[]\",\"name\":\"\",\"type\":\"string[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"GetImportantContractKeys\",\"outputs\":[{\"internalType\":\"string[]\",\"name\":\"\",\"type\":\"string[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"rollupHash\",\"type\":\"bytes32\"}],\"name\":\"GetRollupByHash\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"},{\"components\":[{\"internalType\":\"bytes32\",\"name\":\"Hash\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"AggregatorID\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"LastSequenceNumber\",\"type\":\"uint256\"}],\"internalType\":\"structStructs.MetaRollup\",\"name\":\"\",\"type\":\"tuple\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"_aggregatorID\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"_initSecret\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"_hostAddress\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"_genesisAttestation\",\"type\":\"string\"}],\"name\":\"InitializeNetworkSecret\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"IsWithdrawalAvailable\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"requestReport\",\"type\":\"string\"}],\"name\":\"RequestNetworkSecret\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"attesterID\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"requesterID\",\"type\":\"address\"},{\"internalType\":\"bytes\",\"name\":\"attesterSig\",\"type\":\"bytes\"},{\"internalType\":\"bytes\",\"name\":\"responseSecret\",\"type\":\"bytes\"},{\"internalType\":\"string\",\"name\":\"hostAddress\",\"type\":\"string\"},{\"internalType\":\"bool\",\"name\":\"verifyAttester\",\"type\":\"bool\"}],\"name\":\"RespondNetworkSecret\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"RetrieveAllBridgeFunds\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"uint256\",\"name\":\"value\",\"type\":\"uint256\"}],\"name\":\"SetGovernanceParameter\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"key\",\"type\":\"string\"},{\"internalType\":\"address\",\"name\":\"newAddress\",\"type\":\"address\"}],\"name\":\"SetImportantContractAddress\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"name\":\"importantContractAddresses\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"name\":\"importantContractKeys\",\"outputs\":[{\"internalType\":\"string\",\"name\":\"\",\"type\":\"string\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"lastBatchSeqNo\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"messageBus\",\"outputs\":[{\"internalType\":\"contractIMessageBus\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x608060405234801561001057600080fd5b5061001a3361001f565b61006f565b600080546001600160a01b038381166001600160a01b0319831681178455604051919092169283917f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e09190a35050565b6137d78061007e6000396000f3fe608060405234801561001057600080fd5b50600436106101775760003560e01c80638129fc1c116100d85780639dba15911161008c578063bbd79e1511610066578063bbd79e15146103a9578063e34fbfc8146103bc578063f2fde38b146103cf57600080fd5b80639dba159114610373578063a1a227fa14610386578063a52f433c1461039957600080fd5b80638da5cb5b116100bd5780638da5cb5b1461032f5780638fa0d0531461034057806398077e861461035357600080fd5b80638129fc1c1461028c5780638236a7ba1461029457600080fd5b8063440c953b1161012f5780636a30d26c116101145780636a30d26c14610274578063715018a61461027c578063728109961461028457600080fd5b8063440c953b1461024a57806359a900711461026157600080fd5b8063324ff86611610160578063324ff866146101a45780633e60a22f146101c257806343348b2f1461020e57600080fd5b806303e72e481461017c57806321d56aef14610191575b600080fd5b61018f61018a36600461175e565b6103e2565b005b61018f61019f3660046117b0565b610619565b6101ac6106a4565b6040516101b99190611845565b60405180910390f35b6101f66101d03660046118a7565b80516020818301810180516006825292820191909301209152546001600160a01b031681565b6040516001600160a01b0390911681526020016101b9565b61023a61021c3660046118dc565b6001600160a01b031660009081526002602052604090205460ff1690565b60405190151581526020016101b9565b61025360085481565b6040519081526020016101b9565b61018f61026f366004611942565b61077d565b6101ac610823565b61018f6108f3565b61018f610959565b61018f610a2b565b6102fc6102a23660046119e9565b6040805160608082018352600080835260208084018290529284018190528481526009835283902083519182018452805480835260018201546001600160a01b031693830193909352600201549281019290925290911491565b60408051921515835281516020808501919091528201516001600160a01b031683820152015160608201526080016101b9565b6000546001600160a01b03166101f6565b61018f61034e366004611a02565b610bea565b6103666103613660046119e9565b610c79565b6040516101b99190611a89565b6103666103813660046118dc565b610d25565b600a546101f6906001600160a01b031681565b600754610100900460ff1661023a565b61018f6103b7366004611a9c565b610dd1565b61018f6103ca366004611b62565b610f52565b61018f6103dd3660046118dc565b610f71565b6000546001600160a01b031633146104415760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e657260448201526064015b60405180910390fd5b60006001600160a01b031660068360405161045c9190611ba4565b908152604051908190036020019020546001600160a01b0316036104b857600580546001810182556000919091527f036b6384b5eca791c62761152d0c79bb0604c104a5fb6f4eb0703f3154bb3db0016104b68382611c48565b505b806006836040516104c99190611ba4565b90815260408051918290036020908101832080546001600160a01b039590951673ffffffffffffffffffffffffffffffffffffffff19909516949094179093558181019052601581527f4c3143726f7373436861696e4d657373656e6765720000000000000000000000908201528251908301207fc44824f8c2193956d355efa8d55d999911d00e4c3cc4c910829498f8f85ad7ca016105dc57600a546040517f662859670000000000000000000000000000000000000000000000000000000081526001600160a01b03838116600483015290911690636628596790602401600060405180830381600087803b1580156105c357600080fd5b505af11580156105d7573d6000803e3d6000fd5b505050505b7f17b2f9f5748931099ffee882b5b64f4a560b5c55da9b4f4e396dae3bb9f98cb5828260405161060d929190611d08565b60405180910390a15050565b6000546001600160a01b031633146106735760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152606401610438565b7ff74d93d18fc574f65aec2be88c3d2d878f5488a3e0ca04ca69546fd9a1cb603a828260405161060d929190611d33565b60606003805480602002602001604051908101604052809291908181526020016000905b828210156107745783829060005260206000200180546106e790611bc0565b80601f016020809104026020016040519081016040528092919081815260200182805461071390611bc0565b80156107605780601f1061073557610100808354040283529160200191610760565b820191906000526020600020905b81548152906001019060200180831161074357829003601f168201915b5050505050815260200190600101906106c8565b50505050905090565b60075460ff161561078d57600080fd5b60078054600160ff1991821681179092556001600160a01b03881660009081526002602052604081208054909216831790915560038054928301815590527fc2575a0e9e593c00f959f8c92f12db2869c3395a3b0502d05e2516446f71f85b016107f78482611c48565b506001600160a01b038616600090815260046020526040902061081a8482611c48565b50505050505050565b60606005805480602002602001604051908101604052809291908181526020016000905b8282101561077457838290600052602060002001805461086690611bc0565b80601f016020809104026020016040519081016040528092919081815260200182805461089290611bc0565b80156108df5780601f106108b4576101008083540402835291602001916108df565b820191906000526020600020905b8154815290600101906020018083116108c257829003601f168201915b505050505081526020019060010190610847565b6000546001600160a01b0316331461094d5760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152606401610438565b610957600061104c565b565b6000546001600160a01b031633146109b35760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152606401610438565b600a546040517f36d2da900000000000000000000000000000000000000000000000000000000081523360048201526001600160a01b03909116906336d2da9090602401600060405180830381600087803b158015610a1157600080fd5b505af1158015610a25573d6000803e3d6000fd5b50505050565b600054600160a81b900460ff16610a605760005474010000000000000000000000000000000000000000900460ff1615610a64565b303b155b610ad65760405162461bcd60e51b815260206004820152602e60248201527f496e697469616c697a61626c653a20636f6e747261637420697320616c72656160448201527f647920696e697469616c697a65640000000000000000000000000000000000006064820152608401610438565b600054600160a81b900460ff16158015610b2b57600080547fffffffffffffffffffff0000ffffffffffffffffffffffffffffffffffffffff1675010100000000000000000000000000000000000000001790555b6000600855604051610b3c90611699565b604051809103906000f080158015610b58573d6000803e3d6000fd5b50600a805473ffffffffffffffffffffffffffffffffffffffff19166001600160a01b039290921691821790556040519081527fbd726cf82ac9c3260b1495107182e336e0654b25c10915648c0cc15b2bb72cbf9060200160405180910390a18015610be757600080547fffffffffffffffffffff00ffffffffffffffffffffffffffffffffffffffffff1690555b50565b60026000610bfe60408701602088016118dc565b6001600160a01b0316815260208101919091526040016000205460ff16610c675760405162461bcd60e51b815260206004820152601760248201527f61676772656761746f72206e6f742061747465737465640000000000000000006044820152606401610438565b610c70846110a9565b610a25816110de565b60058181548110610c8957600080fd5b906000526020600020016000915090508054610ca490611bc0565b80601f0160208091040260200160405190810160405280929190818152602001828054610cd090611bc0565b8015610d1d5780601f10610cf257610100808354040283529160200191610d1d565b820191906000526020600020905b815481529060010190602001808311610d0057829003601f168201915b505050505081565b6001600160a01b0381166000908152600460205260409020805460609190610d4c90611bc0565b80601f0160208091040260200160405190810160405280929190818152602001828054610d7890611bc0565b8015610dc55780601f10610d9a57610100808354040283529160200191610dc5565b820191906000526020600020905b815481529060010190602001808311610da857829003601f168201915b50505050509050919050565b6001600160a01b03861660009081526002602052604090205460ff1680610df757600080fd5b8115610ec7576000610e2d88888688604051602001610e199493929190611d55565b604051602081830303815290604052611198565b90506000610e3b82886111d3565b9050886001600160a01b0316816001600160a01b031614610ec45760405162461bcd60e51b815260206004820152602c60248201527f63616c63756c61746564206164647265737320616e642061747465737465724960448201527f4420646f6e74206d6174636800000000000000000000000000000000000000006064820152608401610438565b50505b6001600160a01b0386166000908152600260205260408120805460ff191660019081179091556003805491820181559091527fc2575a0e9e593c00f959f8c92f12db2869c3395a3b0502d05e2516446f71f85b01610f258482611c48565b506001600160a01b0386166000908152600460205260409020610f488482611c48565b5050505050505050565b336000908152600160205260409020610f6c828483611db1565b505050565b6000546001600160a01b03163314610fcb5760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152606401610438565b6001600160a01b0381166110475760405162461bcd60e51b815260206004820152602660248201527f4f776e61626c653a206e6577206f776e657220697320746865207a65726f206160448201527f64647265737300000000000000000000000000000000000000000000000000006064820152608401610438565b610be7815b600080546001600160a01b0383811673ffffffffffffffffffffffffffffffffffffffff19831681178455604051919092169283917f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e09190a35050565b8035600090815260096020526040902081906110c58282611e72565b505060085460408201351115610be75760400135600855565b60006110ea8280611ebe565b9050905060005b81811015610f6c57600a546001600160a01b0316639730886d6111148580611ebe565b8481811061112457611124611f08565b90506020028101906111369190611f1e565b60016040518363ffffffff1660e01b8152600401611155929190611fcd565b600060405180830381600087803b15801561116f57600080fd5b505af1158015611183573d6000803e3d6000fd5b50505050806111919061209a565b90506110f1565b60006111a482516111f9565b826040516020016111b69291906120b3565b604051602081830303815290604052805190602001209050919050565b60008060006111e28585611336565b915091506111ef816113a4565b5090505b92915050565b60608160000361123c57505060408051808201909152600181527f3000000000000000000000000000000000000000000000000000000000000000602082015290565b8160005b811561126657806112508161209a565b915061125f9050600a83612124565b9150611240565b60008167ffffffffffffffff811115611281576112816116a6565b6040519080825280601f01601f1916602001820160405280156112ab576020820181803683370190505b5090505b841561132e576112c0600183612138565b91506112cd600a8661214b565b6112d890603061215f565b60f81b8183815181106112ed576112ed611f08565b60200101907effffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff1916908160001a905350611327600a86612124565b94506112af565b949350505050565b600080825160410361136c5760208301516040840151606085015160001a6113608782858561155a565b9450945050505061139d565b8251604003611395576020830151604084015161138a868383611647565b93509350505061139d565b506000905060025b9250929050565b60008160048111156113b8576113b8612172565b036113c05750565b60018160048111156113d4576113d4612172565b036114215760405162461bcd60e51b815260206004820152601860248201527f45434453413a20696e76616c6964207369676e617475726500000000000000006044820152606401610438565b600281600481111561143557611435612172565b036114825760405162461bcd60e51b815260206004820152601f60248201527f45434453413a20696e76616c6964207369676e6174757265206c656e677468006044820152606401610438565b600381600481111561149657611496612172565b036114ee5760405162461bcd60e51b815260206004820152602260248201527f45434453413a20696e76616c6964207369676e6174757265202773272076616c604482015261756560f01b6064820152608401610438565b600481600481111561150257611502612172565b03610be75760405162461bcd60e51b815260206004820152602260248201527f45434453413a20696e76616c6964207369676e6174757265202776272076616c604482015261756560f01b6064820152608401610438565b6000807f7fffffffffffffffffffffffffffffff5d576e7357a4501ddfe92f46681b20a0831115611591575060009050600361163e565b8460ff16601b141580156115a957508460ff16601c14155b156115ba575060009050600461163e565b6040805160008082526020820180845289905260ff881692820192909252606081018690526080810185905260019060a0016020604051602081039080840390855afa15801561160e573d6000803e3d6000fd5b5050604051601f1901519150506001600160a01b0381166116375760006001925092505061163e565b9150600090505b94509492505050565b6000807f7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff83168161167d60ff86901c601b61215f565b905061168b8782888561155a565b935093505050935093915050565b6116198061218983390190565b634e487b7160e01b600052604160045260246000fd5b600082601f8301126116cd57600080fd5b813567ffffffffffffffff808211156116e8576116e86116a6565b604051601f8301601f19908116603f01168101908282118183101715611710576117106116a6565b8160405283815286602085880101111561172957600080fd5b836020870160208301376000602085830101528094505050505092915050565b6001600160a01b0381168114610be757600080fd5b6000806040838503121561177157600080fd5b823567ffffffffffffffff81111561178857600080fd5b611794858286016116bc565b92505060208301356117a581611749565b809150509250929050565b600080604083850312156117c357600080fd5b823567ffffffffffffffff8111156117da57600080fd5b6117e6858286016116bc565b95602094909401359450505050565b60005b838110156118105781810151838201526020016117f8565b50506000910152565b600081518084526118318160208601602086016117f5565b601f01601f19169290920160200192915050565b6000602080830181845280855180835260408601915060408160051b870101925083870160005b8281101561189a57603f19888603018452611888858351611819565b9450928501929085019060010161186c565b5092979650505050505050565b6000602082840312156118b957600080fd5b813567ffffffffffffffff8111156118d057600080fd5b61132e848285016116bc565b6000602082840312156118ee57600080fd5b81356118f981611749565b9392505050565b60008083601f84011261191257600080fd5b50813567ffffffffffffffff81111561192a57600080fd5b60208301915083602082850101111561139d57600080fd5b6000806000806000806080878903121561195b57600080fd5b863561196681611749565b9550602087013567ffffffffffffffff8082111561198357600080fd5b61198f8a838b01611900565b909750955060408901359150808211156119a857600080fd5b6119b48a838b016116bc565b945060608901359150808211156119ca57600080fd5b506119d789828a01611900565b979a9699509497509295939492505050565b6000602082840312156119fb57600080fd5b5035919050565b60008060008084860360a0811215611a1957600080fd5b6060811215611a2757600080fd5b50849350606085013567ffffffffffffffff80821115611a4657600080fd5b611a5288838901611900565b90955093506080870135915080821115611a6b57600080fd5b50850160208188031215611a7e57600080fd5b939692955090935050565b6020815260006118f96020830184611819565b60008060008060008060c08789031215611ab557600080fd5b8635611ac081611749565b95506020870135611ad081611749565b9450604087013567ffffffffffffffff80821115611aed57600080fd5b611af98a838b016116bc565b95506060890135915080821115611b0f57600080fd5b611b1b8a838b016116bc565b94506080890135915080821115611b3157600080fd5b50611b3e89828a016116bc565b92505060a08701358015158114611b5457600080fd5b809150509295509295509295565b60008060208385031215611b7557600080fd5b823567ffffffffffffffff811115611b8c57600080fd5b611b9885828601611900565b90969095509350505050565b60008251611bb68184602087016117f5565b9190910192915050565b600181811c90821680611bd457607f821691505b602082108103611bf457634e487b7160e01b600052602260045260246000fd5b50919050565b601f821115610f6c57600081815260208120601f850160051c81016020861015611c215750805b601f850160051c820191505b81811015611c4057828155600101611c2d565b505050505050565b815167ffffffffffffffff811115611c6257611c626116a6565b611c7681611c708454611bc0565b84611bfa565b602080601f831160018114611cab5760008415611c935750858301515b600019600386901b1c1916600185901b178555611c40565b600085815260208120601f198616915b82811015611cda57888601518255948401946001909101908401611cbb565b5085821015611cf85787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b604081526000611d1b6040830185611819565b90506001600160a01b03831660208301529392505050565b604081526000611d466040830185611819565b90508260208301529392505050565b60006bffffffffffffffffffffffff19808760601b168352808660601b166014840152508351611d8c8160288501602088016117f5565b835190830190611da38160288401602088016117f5565b016028019695505050505050565b67ffffffffffffffff831115611dc957611dc96116a6565b611ddd83611dd78354611bc0565b83611bfa565b6000601f841160018114611e115760008515611df95750838201355b600019600387901b1c1916600186901b178355611e6b565b600083815260209020601f19861690835b82811015611e425786850135825560209485019460019092019101611e22565b5086821015611e5f5760001960f88860031b161c19848701351681555b505060018560011b0183555b5050505050565b81358155600181016020830135611e8881611749565b6001600160a01b03811673ffffffffffffffffffffffffffffffffffffffff198354161782555050604082013560028201555050565b6000808335601e19843603018112611ed557600080fd5b83018035915067ffffffffffffffff821115611ef057600080fd5b6020019150600581901b360382131561139d57600080fd5b634e487b7160e01b600052603260045260246000fd5b6000823560be19833603018112611bb657600080fd5b803563ffffffff81168114611f4857600080fd5b919050565b6000808335601e19843603018112611f6457600080fd5b830160208101925035905067ffffffffffffffff811115611f8457600080fd5b80360382131561139d57600080fd5b81835281816020850137506000828201602090810191909152601f909101601f19169091010190565b803560ff81168114611f4857600080fd5b6040815260008335611fde81611749565b6001600160a01b03166040830152602084013567ffffffffffffffff811680821461200857600080fd5b60608401525061201a60408501611f34565b63ffffffff16608083015261203160608501611f34565b63ffffffff1660a08301526120496080850185611f4d565b60c08085015261205e61010085018284611f93565b91505061206d60a08601611fbc565b60ff1660e084015260209092019290925292915050565b634e487b7160e01b600052601160045260246000fd5b6000600182016120ac576120ac612084565b5060010190565b7f19457468657265756d205369676e6564204d6573736167653a0a0000000000008152600083516120eb81601a8501602088016117f5565b83519083019061210281601a8401602088016117f5565b01601a01949350505050565b634e487b7160e01b600052601260045260246000fd5b6000826121335761213361210e565b500490565b818103818111156111f3576111f3612084565b60008261215a5761215a61210e565b500690565b808201808211156111f3576111f3612084565b634e487b7160e01b600052602160045260246000fdfe608060405234801561001057600080fd5b5061001a3361001f565b61006f565b600080546001600160a01b038381166001600160a01b0319831681178455604051919092169283917f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e09190a35050565b61159b8061007e6000396000f3fe6080604052600436106100d65760003560e01c8063715018a61161007f57806399a3ad211161005957806399a3ad21146102d8578063b1454caa146102f8578063cd6e65c014610331578063f2fde38b146103515761014a565b8063715018a6146102855780638da5cb5b1461029a5780639730886d146102b85761014a565b806336d2da90116100b057806336d2da901461020d5780633cb747bf1461022d57806366285967146102655761014a565b80630fcfbd111461019757806333a88c72146101ca578063346633fb146101fa5761014a565b3661014a576040517f346633fb0000000000000000000000000000000000000000000000000000000081523360048201523460248201819052309163346633fb91906044016000604051808303818588803b15801561013457600080fd5b505af1158015610148573d6000803e3d6000fd5b005b60405162461bcd60e51b815260206004820152600b60248201527f756e737570706f7274656400000000000000000000000000000000000000000060448201526064015b60405180910390fd5b3480156101a357600080fd5b506101b76101b2366004610e6a565b610371565b6040519081526020015b60405180910390f35b3480156101d657600080fd5b506101ea6101e5366004610e6a565b610427565b60405190151581526020016101c1565b610148610208366004610eb4565b61047a565b34801561021957600080fd5b50610148610228366004610ee0565b610546565b34801561023957600080fd5b5060045461024d906001600160a01b031681565b6040516001600160a01b0390911681526020016101c1565b34801561027157600080fd5b50610148610280366004610ee0565b610647565b34801561029157600080fd5b50610148610702565b3480156102a657600080fd5b506000546001600160a01b031661024d565b3480156102c457600080fd5b506101486102d3366004610efd565b610768565b3480156102e457600080fd5b506101486102f3366004610eb4565b61090c565b34801561030457600080fd5b50610318610313366004610f73565b610a0e565b60405167ffffffffffffffff90911681526020016101c1565b34801561033d57600080fd5b5061014861034c366004611020565b610a67565b34801561035d57600080fd5b5061014861036c366004610ee0565b610cb5565b6000808260405160200161038591906110b1565b60408051601f19818403018152918152815160209283012060008181526001909352912054909150806104205760405162461bcd60e51b815260206004820152602160248201527f54686973206d65737361676520776173206e65766572207375626d697474656460448201527f2e00000000000000000000000000000000000000000000000000000000000000606482015260840161018e565b9392505050565b6000808260405160200161043b91906110b1565b60408051601f1981840301815291815281516020928301206000818152600190935291205490915080158015906104725750428111155b949350505050565b60003411801561048957508034145b6104fb5760405162461bcd60e51b815260206004820152603060248201527f417474656d7074696e6720746f2073656e642076616c756520776974686f757460448201527f2070726f766964696e6720457468657200000000000000000000000000000000606482015260840161018e565b604080513381526001600160a01b0384166020820152348183015290517ff1365f826a788d6c1a955db0eed5ba8642674219c4771f8c65918617511a15609181900360600190a15050565b6000546001600160a01b031633146105a05760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572604482015260640161018e565b6000816001600160a01b03164760405160006040518083038185875af1925050503d80600081146105ed576040519150601f19603f3d011682016040523d82523d6000602084013e6105f2565b606091505b50509050806106435760405162461bcd60e51b815260206004820152601460248201527f6661696c65642073656e64696e672076616c7565000000000000000000000000604482015260640161018e565b5050565b6000546001600160a01b031633146106a15760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572604482015260640161018e565b6004805473ffffffffffffffffffffffffffffffffffffffff19166001600160a01b0383169081179091556040519081527f46411ba53a3aaae1ac6b6babc952489fe874a367391ebe59d9276bf58508f10b9060200160405180910390a150565b6000546001600160a01b0316331461075c5760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572604482015260640161018e565b6107666000610d97565b565b6000546001600160a01b031633146107c25760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572604482015260640161018e565b60006107ce82426111b2565b90506000836040516020016107e391906110b1565b60408051601f198184030181529181528151602092830120600081815260019093529120549091501561087e5760405162461bcd60e51b815260206004820152602160248201527f4d657373616765207375626d6974746564206d6f7265207468616e206f6e636560448201527f2100000000000000000000000000000000000000000000000000000000000000606482015260840161018e565b60008181526001602090815260408220849055600291906108a190870187610ee0565b6001600160a01b0316815260208101919091526040016000908120906108cd60808701606088016111cb565b63ffffffff1681526020808201929092526040016000908120805460018101825590825291902085916004020161090482826113a1565b505050505050565b6000546001600160a01b031633146109665760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572604482015260640161018e565b6000826001600160a01b03168260405160006040518083038185875af1925050503d80600081146109b3576040519150601f19603f3d011682016040523d82523d6000602084013e6109b8565b606091505b5050905080610a095760405162461bcd60e51b815260206004820152601460248201527f6661696c65642073656e64696e672076616c7565000000000000000000000000604482015260640161018e565b505050565b6000610a1933610df4565b90507fb93c37389233beb85a3a726c3f15c2d15533ee74cb602f20f490dfffef77593733828888888888604051610a5697969594939291906114bb565b60405180910390a195945050505050565b6004546001600160a01b03163314610ae75760405162461bcd60e51b815260206004820152602560248201527f4f6e6c7920746865206d657373656e6765722063616e207265636f726420726560448201527f6c6179732e000000000000000000000000000000000000000000000000000000606482015260840161018e565b6040517f33a88c7200000000000000000000000000000000000000000000000000000000815230906333a88c7290610b239085906004016110b1565b602060405180830381865afa158015610b40573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610b64919061151b565b610bb05760405162461bcd60e51b815260206004820152601f60248201527f4d657373616765206e6f7420666f756e64206f722066696e616c697a65642e00604482015260640161018e565b600082604051602001610bc391906110b1565b60408051601f198184030181529181528151602092830120600081815260039093529120549091506001600160a01b031615610c415760405162461bcd60e51b815260206004820152601760248201527f52656c617920616c7265616479207265636f726465642e000000000000000000604482015260640161018e565b600081815260036020908152604091829020805473ffffffffffffffffffffffffffffffffffffffff19166001600160a01b0386169081179091558251848152918201527ff449a9f37e33ab4ba56111d3c5aeca5afdbe51aedc43e4effd68c66ff0d58c80910160405180910390a1505050565b6000546001600160a01b03163314610d0f5760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572604482015260640161018e565b6001600160a01b038116610d8b5760405162461bcd60e51b815260206004820152602660248201527f4f776e61626c653a206e6577206f776e657220697320746865207a65726f206160448201527f6464726573730000000000000000000000000000000000000000000000000000606482015260840161018e565b610d9481610d97565b50565b600080546001600160a01b0383811673ffffffffffffffffffffffffffffffffffffffff19831681178455604051919092169283917f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e09190a35050565b6001600160a01b0381166000908152600560205260408120805467ffffffffffffffff169160019190610e27838561153d565b92506101000a81548167ffffffffffffffff021916908367ffffffffffffffff160217905550919050565b600060c08284031215610e6457600080fd5b50919050565b600060208284031215610e7c57600080fd5b813567ffffffffffffffff811115610e9357600080fd5b61047284828501610e52565b6001600160a01b0381168114610d9457600080fd5b60008060408385031215610ec757600080fd5b8235610ed281610e9f565b946020939093013593505050565b600060208284031215610ef257600080fd5b813561042081610e9f565b60008060408385031215610f1057600080fd5b823567ffffffffffffffff811115610f2757600080fd5b610f3385828601610e52565b95602094909401359450505050565b63ffffffff81168114610d9457600080fd5b60ff81168114610d9457600080fd5b8035610f6e81610f54565b919050565b600080600080600060808688031215610f8b57600080fd5b8535610f9681610f42565b94506020860135610fa681610f42565b9350604086013567ffffffffffffffff80821115610fc357600080fd5b818801915088601f830112610fd757600080fd5b813581811115610fe657600080fd5b896020828501011115610ff857600080fd5b602083019550809450505050606086013561101281610f54565b809150509295509295909350565b6000806040838503121561103357600080fd5b823567ffffffffffffffff81111561104a57600080fd5b61105685828601610e52565b925050602083013561106781610e9f565b809150509250929050565b67ffffffffffffffff81168114610d9457600080fd5b81835281816020850137506000828201602090810191909152601f909101601f19169091010190565b60208152600082356110c281610e9f565b6001600160a01b03811660208401525060208301356110e081611072565b67ffffffffffffffff80821660408501526040850135915061110182610f42565b63ffffffff80831660608601526060860135925061111e83610f42565b80831660808601525060808501359150601e1985360301821261114057600080fd5b602091850191820191358181111561115757600080fd5b80360383131561116657600080fd5b60c060a086015261117b60e086018285611088565b9250505061118b60a08501610f63565b60ff811660c0850152509392505050565b634e487b7160e01b600052601160045260246000fd5b808201808211156111c5576111c561119c565b92915050565b6000602082840312156111dd57600080fd5b813561042081610f42565b600081356111c581610f42565b6000808335601e1984360301811261120c57600080fd5b83018035915067ffffffffffffffff82111561122757600080fd5b60200191503681900382131561123c57600080fd5b9250929050565b634e487b7160e01b600052604160045260246000fd5b600181811c9082168061126d57607f821691505b602082108103610e6457634e487b7160e01b600052602260045260246000fd5b601f821115610a0957600081815260208120601f850160051c810160208610156112b45750805b601f850160051c820191505b81811015610904578281556001016112c0565b67ffffffffffffffff8311156112eb576112eb611243565b6112ff836112f98354611259565b8361128d565b6000601f841160018114611333576000851561131b5750838201355b600019600387901b1c1916600186901b17835561138d565b600083815260209020601f19861690835b828110156113645786850135825560209485019460019092019101611344565b50868210156113815760001960f88860031b161c19848701351681555b505060018560011b0183555b5050505050565b600081356111c581610f54565b81356113ac81610e9f565b6001600160a01b038116905081548173ffffffffffffffffffffffffffffffffffffffff19821617835560208401356113e481611072565b7bffffffffffffffff00000000000000000000000000000000000000008160a01b1690507fffffffff000000000000000000000000000000000000000000000000000000008184828516171785556040860135925061144283610f42565b921760e09190911b90911617815561147a61145f606084016111e8565b6001830163ffffffff821663ffffffff198254161781555050565b61148760808301836111f5565b6114958183600286016112d3565b50506106436114a660a08401611394565b6003830160ff821660ff198254161781555050565b6001600160a01b038816815267ffffffffffffffff87166020820152600063ffffffff808816604084015280871660608401525060c0608083015261150460c083018587611088565b905060ff831660a083015298975050505050505050565b60006020828403121561152d57600080fd5b8151801515811461042057600080fd5b67ffffffffffffffff81811683821601908082111561155e5761155e61119c565b509291505056fea2646970667358221220b2074d38eeea18a6cb6840004fe6cfecaa5d70a900af49df1420cc912222613364736f6c63430008150033a2646970667358221220810f539980dfbad5303212ecd1d4956366fe0d32113cf5196ca903f517723d6764736f6c63430008150033",
 }
 
 // ManagementContractABI is the input ABI used to generate the binding from.
@@ -255,6 +255,37 @@ func (_ManagementContract *ManagementContractCallerSession) Attested(_addr commo
 	return _ManagementContract.Contract.Attested(&_ManagementContract.CallOpts, _addr)
 }
 
+// GetHostAddress is a free data retrieval call binding the contract method 0x9dba1591.
+//
+// Solidity: function GetHostAddress(address hostID) view returns(string)
+func (_ManagementContract *ManagementContractCaller) GetHostAddress(opts *bind.CallOpts, hostID common.Address) (string, error) {
+	var out []interface{}
+	err := _ManagementContract.contract.Call(opts, &out, "GetHostAddress", hostID)
+
+	if err != nil {
+		return *new(string), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(string)).(*string)
+
+	return out0, err
+
+}
+
+// GetHostAddress is a free data retrieval call binding the contract method 0x9dba1591.
+//
+// Solidity: function GetHostAddress(address hostID) view returns(string)
+func (_ManagementContract *ManagementContractSession) GetHostAddress(hostID common.Address) (string, error) {
+	return _ManagementContract.Contract.GetHostAddress(&_ManagementContract.CallOpts, hostID)
+}
+
+// GetHostAddress is a free data retrieval call binding the contract method 0x9dba1591.
+//
+// Solidity: function GetHostAddress(address hostID) view returns(string)
+func (_ManagementContract *ManagementContractCallerSession) GetHostAddress(hostID common.Address) (string, error) {
+	return _ManagementContract.Contract.GetHostAddress(&_ManagementContract.CallOpts, hostID)
+}
+
 // GetHostAddresses is a free data retrieval call binding the contract method 0x324ff866.
 //
 // Solidity: function GetHostAddresses() view returns(string[])
@@ -640,6 +671,27 @@ func (_ManagementContract *ManagementContractTransactorSession) RetrieveAllBridg
 	return _ManagementContract.Contract.RetrieveAllBridgeFunds(&_ManagementContract.TransactOpts)
 }
 
+// SetGovernanceParameter is a paid mutator transaction binding the contract method 0x21d56aef.
+//
+// Solidity: function SetGovernanceParameter(string name, uint256 value) returns()
+func (_ManagementContract *ManagementContractTransactor) SetGovernanceParameter(opts *bind.TransactOpts, name string, value *big.Int) (*types.Transaction, error) {
+	return _ManagementContract.contract.Transact(opts, "SetGovernanceParameter", name, value)
+}
+
+// SetGovernanceParameter is a paid mutator transaction binding the contract method 0x21d56aef.
+//
+// Solidity: function SetGovernanceParameter(string name, uint256 value) returns()
+func (_ManagementContract *ManagementContractSession) SetGovernanceParameter(name string, value *big.Int) (*types.Transaction, error) {
+	return _ManagementContract.Contract.SetGovernanceParameter(&_ManagementContract.TransactOpts, name, value)
+}
+
+// SetGovernanceParameter is a paid mutator transaction binding the contract method 0x21d56aef.
+//
+// Solidity: function SetGovernanceParameter(string name, uint256 value) returns()
+func (_ManagementContract *ManagementContractTransactorSession) SetGovernanceParameter(name string, value *big.Int) (*types.Transaction, error) {
+	return _ManagementContract.Contract.SetGovernanceParameter(&_ManagementContract.TransactOpts, name, value)
+}
+
 // SetImportantContractAddress is a paid mutator transaction binding the contract method 0x03e72e48.
 //
 // Solidity: function SetImportantContractAddress(string key, address newAddress) returns()
@@ -724,6 +776,141 @@ func (_ManagementContract *ManagementContractTransactorSession) TransferOwnershi
 	return _ManagementContract.Contract.TransferOwnership(&_ManagementContract.TransactOpts, newOwner)
 }
 
+// ManagementContractGovernanceParameterChangedIterator is returned from FilterGovernanceParameterChanged and is used to iterate over the raw logs and unpacked data for GovernanceParameterChanged events raised by the ManagementContract contract.
+type ManagementContractGovernanceParameterChangedIterator struct {
+	Event *ManagementContractGovernanceParameterChanged // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ManagementContractGovernanceParameterChangedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ManagementContractGovernanceParameterChanged)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ManagementContractGovernanceParameterChanged)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ManagementContractGovernanceParameterChangedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ManagementContractGovernanceParameterChangedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ManagementContractGovernanceParameterChanged represents a GovernanceParameterChanged event raised by the ManagementContract contract.
+type ManagementContractGovernanceParameterChanged struct {
+	Name  string
+	Value *big.Int
+	Raw   types.Log // Blockchain specific contextual infos
+}
+
+// FilterGovernanceParameterChanged is a free log retrieval operation binding the contract event 0xf74d93d18fc574f65aec2be88c3d2d878f5488a3e0ca04ca69546fd9a1cb603a.
+//
+// Solidity: event GovernanceParameterChanged(string name, uint256 value)
+func (_ManagementContract *ManagementContractFilterer) FilterGovernanceParameterChanged(opts *bind.FilterOpts) (*ManagementContractGovernanceParameterChangedIterator, error) {
+
+	logs, sub, err := _ManagementContract.contract.FilterLogs(opts, "GovernanceParameterChanged")
+	if err != nil {
+		return nil, err
+	}
+	return &ManagementContractGovernanceParameterChangedIterator{contract: _ManagementContract.contract, event: "GovernanceParameterChanged", logs: logs, sub: sub}, nil
+}
+
+// WatchGovernanceParameterChanged is a free log subscription operation binding the contract event 0xf74d93d18fc574f65aec2be88c3d2d878f5488a3e0ca04ca69546fd9a1cb603a.
+//
+// Solidity: event GovernanceParameterChanged(string name, uint256 value)
+func (_ManagementContract *ManagementContractFilterer) WatchGovernanceParameterChanged(opts *bind.WatchOpts, sink chan<- *ManagementContractGovernanceParameterChanged) (event.Subscription, error) {
+
+	logs, sub, err := _ManagementContract.contract.WatchLogs(opts, "GovernanceParameterChanged")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ManagementContractGovernanceParameterChanged)
+				if err := _ManagementContract.contract.UnpackLog(event, "GovernanceParameterChanged", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseGovernanceParameterChanged is a log parse operation binding the contract event 0xf74d93d18fc574f65aec2be88c3d2d878f5488a3e0ca04ca69546fd9a1cb603a.
+//
+// Solidity: event GovernanceParameterChanged(string name, uint256 value)
+func (_ManagementContract *ManagementContractFilterer) ParseGovernanceParameterChanged(log types.Log) (*ManagementContractGovernanceParameterChanged, error) {
+	event := new(ManagementContractGovernanceParameterChanged)
+	if err := _ManagementContract.contract.UnpackLog(event, "GovernanceParameterChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
 // ManagementContractImportantContractAddressUpdatedIterator is returned from FilterImportantContractAddressUpdated and is used to iterate over the raw logs and unpacked data for ImportantContractAddressUpdated events raised by the ManagementContract contract.
 type ManagementContractImportantContractAddressUpdatedIterator struct {
 	Event *ManagementContractImportantContractAddressUpdated // Event containing the contract specifics and raw log
@@ -0,0 +1,615 @@
+// Code generated by tools/bindgen - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package ManagementContract
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = bind.NewBoundContract
+	_ = types.Log{}
+	_ = gethcommon.Hash{}
+)
+
+// the ABI of the contract, parsed once
+var parsedManagementContractABI = func() *abi.ABI {
+	parsed, err := ManagementContractMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// ParsedManagementContractABI returns the parsed ABI of the ManagementContract contract
+func ParsedManagementContractABI() *abi.ABI {
+	return parsedManagementContractABI
+}
+
+// unpackInput returns the arguments of a call to the method, checking that the call data is a call to it
+func unpackInput(methodName string, data []byte) ([]interface{}, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("the call data is too short to be a call to %s", methodName)
+	}
+	m, err := parsedManagementContractABI.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+	if m.Name != methodName {
+		return nil, fmt.Errorf("the call data is a call to %s, not %s", m.Name, methodName)
+	}
+	return m.Inputs.Unpack(data[4:])
+}
+
+// AddRollupMethod is the name of the AddRollup((bytes32,address,uint256),string,((address,uint64,uint32,uint32,bytes,uint8)[])) method in the ABI
+const AddRollupMethod = "AddRollup"
+
+// PackAddRollup packs a call to AddRollup((bytes32,address,uint256),string,((address,uint64,uint32,uint32,bytes,uint8)[]))
+func PackAddRollup(r StructsMetaRollup, rollupData string, crossChainData StructsHeaderCrossChainData) ([]byte, error) {
+	return parsedManagementContractABI.Pack(AddRollupMethod, r, rollupData, crossChainData)
+}
+
+// AddRollupInput is the arguments of a call to AddRollup((bytes32,address,uint256),string,((address,uint64,uint32,uint32,bytes,uint8)[]))
+type AddRollupInput struct {
+	R              StructsMetaRollup
+	RollupData     string
+	CrossChainData StructsHeaderCrossChainData
+}
+
+// UnpackAddRollupInput unpacks the arguments of a call to AddRollup((bytes32,address,uint256),string,((address,uint64,uint32,uint32,bytes,uint8)[])) from its call data
+func UnpackAddRollupInput(data []byte) (*AddRollupInput, error) {
+	values, err := unpackInput(AddRollupMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &AddRollupInput{
+		R:              *abi.ConvertType(values[0], new(StructsMetaRollup)).(*StructsMetaRollup),
+		RollupData:     *abi.ConvertType(values[1], new(string)).(*string),
+		CrossChainData: *abi.ConvertType(values[2], new(StructsHeaderCrossChainData)).(*StructsHeaderCrossChainData),
+	}, nil
+}
+
+// AttestedMethod is the name of the Attested(address) method in the ABI
+const AttestedMethod = "Attested"
+
+// PackAttested packs a call to Attested(address)
+func PackAttested(addr gethcommon.Address) ([]byte, error) {
+	return parsedManagementContractABI.Pack(AttestedMethod, addr)
+}
+
+// AttestedInput is the arguments of a call to Attested(address)
+type AttestedInput struct {
+	Addr gethcommon.Address
+}
+
+// UnpackAttestedInput unpacks the arguments of a call to Attested(address) from its call data
+func UnpackAttestedInput(data []byte) (*AttestedInput, error) {
+	values, err := unpackInput(AttestedMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &AttestedInput{
+		Addr: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// UnpackAttestedOutput unpacks the result of a call to Attested(address)
+func UnpackAttestedOutput(data []byte) (bool, error) {
+	values, err := parsedManagementContractABI.Unpack(AttestedMethod, data)
+	if err != nil {
+		return *new(bool), err
+	}
+	return *abi.ConvertType(values[0], new(bool)).(*bool), nil
+}
+
+// GetHostAddressMethod is the name of the GetHostAddress(address) method in the ABI
+const GetHostAddressMethod = "GetHostAddress"
+
+// PackGetHostAddress packs a call to GetHostAddress(address)
+func PackGetHostAddress(hostID gethcommon.Address) ([]byte, error) {
+	return parsedManagementContractABI.Pack(GetHostAddressMethod, hostID)
+}
+
+// GetHostAddressInput is the arguments of a call to GetHostAddress(address)
+type GetHostAddressInput struct {
+	HostID gethcommon.Address
+}
+
+// UnpackGetHostAddressInput unpacks the arguments of a call to GetHostAddress(address) from its call data
+func UnpackGetHostAddressInput(data []byte) (*GetHostAddressInput, error) {
+	values, err := unpackInput(GetHostAddressMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &GetHostAddressInput{
+		HostID: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// UnpackGetHostAddressOutput unpacks the result of a call to GetHostAddress(address)
+func UnpackGetHostAddressOutput(data []byte) (string, error) {
+	values, err := parsedManagementContractABI.Unpack(GetHostAddressMethod, data)
+	if err != nil {
+		return *new(string), err
+	}
+	return *abi.ConvertType(values[0], new(string)).(*string), nil
+}
+
+// GetHostAddressesMethod is the name of the GetHostAddresses() method in the ABI
+const GetHostAddressesMethod = "GetHostAddresses"
+
+// PackGetHostAddresses packs a call to GetHostAddresses()
+func PackGetHostAddresses() ([]byte, error) {
+	return parsedManagementContractABI.Pack(GetHostAddressesMethod)
+}
+
+// UnpackGetHostAddressesOutput unpacks the result of a call to GetHostAddresses()
+func UnpackGetHostAddressesOutput(data []byte) ([]string, error) {
+	values, err := parsedManagementContractABI.Unpack(GetHostAddressesMethod, data)
+	if err != nil {
+		return *new([]string), err
+	}
+	return *abi.ConvertType(values[0], new([]string)).(*[]string), nil
+}
+
+// GetImportantContractKeysMethod is the name of the GetImportantContractKeys() method in the ABI
+const GetImportantContractKeysMethod = "GetImportantContractKeys"
+
+// PackGetImportantContractKeys packs a call to GetImportantContractKeys()
+func PackGetImportantContractKeys() ([]byte, error) {
+	return parsedManagementContractABI.Pack(GetImportantContractKeysMethod)
+}
+
+// UnpackGetImportantContractKeysOutput unpacks the result of a call to GetImportantContractKeys()
+func UnpackGetImportantContractKeysOutput(data []byte) ([]string, error) {
+	values, err := parsedManagementContractABI.Unpack(GetImportantContractKeysMethod, data)
+	if err != nil {
+		return *new([]string), err
+	}
+	return *abi.ConvertType(values[0], new([]string)).(*[]string), nil
+}
+
+// GetRollupByHashMethod is the name of the GetRollupByHash(bytes32) method in the ABI
+const GetRollupByHashMethod = "GetRollupByHash"
+
+// PackGetRollupByHash packs a call to GetRollupByHash(bytes32)
+func PackGetRollupByHash(rollupHash [32]byte) ([]byte, error) {
+	return parsedManagementContractABI.Pack(GetRollupByHashMethod, rollupHash)
+}
+
+// GetRollupByHashInput is the arguments of a call to GetRollupByHash(bytes32)
+type GetRollupByHashInput struct {
+	RollupHash [32]byte
+}
+
+// UnpackGetRollupByHashInput unpacks the arguments of a call to GetRollupByHash(bytes32) from its call data
+func UnpackGetRollupByHashInput(data []byte) (*GetRollupByHashInput, error) {
+	values, err := unpackInput(GetRollupByHashMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &GetRollupByHashInput{
+		RollupHash: *abi.ConvertType(values[0], new([32]byte)).(*[32]byte),
+	}, nil
+}
+
+// GetRollupByHashOutput is the results of a call to GetRollupByHash(bytes32)
+type GetRollupByHashOutput struct {
+	Out0 bool
+	Out1 StructsMetaRollup
+}
+
+// UnpackGetRollupByHashOutput unpacks the results of a call to GetRollupByHash(bytes32)
+func UnpackGetRollupByHashOutput(data []byte) (*GetRollupByHashOutput, error) {
+	values, err := parsedManagementContractABI.Unpack(GetRollupByHashMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &GetRollupByHashOutput{
+		Out0: *abi.ConvertType(values[0], new(bool)).(*bool),
+		Out1: *abi.ConvertType(values[1], new(StructsMetaRollup)).(*StructsMetaRollup),
+	}, nil
+}
+
+// InitializeNetworkSecretMethod is the name of the InitializeNetworkSecret(address,bytes,string,string) method in the ABI
+const InitializeNetworkSecretMethod = "InitializeNetworkSecret"
+
+// PackInitializeNetworkSecret packs a call to InitializeNetworkSecret(address,bytes,string,string)
+func PackInitializeNetworkSecret(aggregatorID gethcommon.Address, initSecret []byte, hostAddress string, genesisAttestation string) ([]byte, error) {
+	return parsedManagementContractABI.Pack(InitializeNetworkSecretMethod, aggregatorID, initSecret, hostAddress, genesisAttestation)
+}
+
+// InitializeNetworkSecretInput is the arguments of a call to InitializeNetworkSecret(address,bytes,string,string)
+type InitializeNetworkSecretInput struct {
+	AggregatorID       gethcommon.Address
+	InitSecret         []byte
+	HostAddress        string
+	GenesisAttestation string
+}
+
+// UnpackInitializeNetworkSecretInput unpacks the arguments of a call to InitializeNetworkSecret(address,bytes,string,string) from its call data
+func UnpackInitializeNetworkSecretInput(data []byte) (*InitializeNetworkSecretInput, error) {
+	values, err := unpackInput(InitializeNetworkSecretMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &InitializeNetworkSecretInput{
+		AggregatorID:       *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+		InitSecret:         *abi.ConvertType(values[1], new([]byte)).(*[]byte),
+		HostAddress:        *abi.ConvertType(values[2], new(string)).(*string),
+		GenesisAttestation: *abi.ConvertType(values[3], new(string)).(*string),
+	}, nil
+}
+
+// IsWithdrawalAvailableMethod is the name of the IsWithdrawalAvailable() method in the ABI
+const IsWithdrawalAvailableMethod = "IsWithdrawalAvailable"
+
+// PackIsWithdrawalAvailable packs a call to IsWithdrawalAvailable()
+func PackIsWithdrawalAvailable() ([]byte, error) {
+	return parsedManagementContractABI.Pack(IsWithdrawalAvailableMethod)
+}
+
+// UnpackIsWithdrawalAvailableOutput unpacks the result of a call to IsWithdrawalAvailable()
+func UnpackIsWithdrawalAvailableOutput(data []byte) (bool, error) {
+	values, err := parsedManagementContractABI.Unpack(IsWithdrawalAvailableMethod, data)
+	if err != nil {
+		return *new(bool), err
+	}
+	return *abi.ConvertType(values[0], new(bool)).(*bool), nil
+}
+
+// RequestNetworkSecretMethod is the name of the RequestNetworkSecret(string) method in the ABI
+const RequestNetworkSecretMethod = "RequestNetworkSecret"
+
+// PackRequestNetworkSecret packs a call to RequestNetworkSecret(string)
+func PackRequestNetworkSecret(requestReport string) ([]byte, error) {
+	return parsedManagementContractABI.Pack(RequestNetworkSecretMethod, requestReport)
+}
+
+// RequestNetworkSecretInput is the arguments of a call to RequestNetworkSecret(string)
+type RequestNetworkSecretInput struct {
+	RequestReport string
+}
+
+// UnpackRequestNetworkSecretInput unpacks the arguments of a call to RequestNetworkSecret(string) from its call data
+func UnpackRequestNetworkSecretInput(data []byte) (*RequestNetworkSecretInput, error) {
+	values, err := unpackInput(RequestNetworkSecretMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &RequestNetworkSecretInput{
+		RequestReport: *abi.ConvertType(values[0], new(string)).(*string),
+	}, nil
+}
+
+// RespondNetworkSecretMethod is the name of the RespondNetworkSecret(address,address,bytes,bytes,string,bool) method in the ABI
+const RespondNetworkSecretMethod = "RespondNetworkSecret"
+
+// PackRespondNetworkSecret packs a call to RespondNetworkSecret(address,address,bytes,bytes,string,bool)
+func PackRespondNetworkSecret(attesterID gethcommon.Address, requesterID gethcommon.Address, attesterSig []byte, responseSecret []byte, hostAddress string, verifyAttester bool) ([]byte, error) {
+	return parsedManagementContractABI.Pack(RespondNetworkSecretMethod, attesterID, requesterID, attesterSig, responseSecret, hostAddress, verifyAttester)
+}
+
+// RespondNetworkSecretInput is the arguments of a call to RespondNetworkSecret(address,address,bytes,bytes,string,bool)
+type RespondNetworkSecretInput struct {
+	AttesterID     gethcommon.Address
+	RequesterID    gethcommon.Address
+	AttesterSig    []byte
+	ResponseSecret []byte
+	HostAddress    string
+	VerifyAttester bool
+}
+
+// UnpackRespondNetworkSecretInput unpacks the arguments of a call to RespondNetworkSecret(address,address,bytes,bytes,string,bool) from its call data
+func UnpackRespondNetworkSecretInput(data []byte) (*RespondNetworkSecretInput, error) {
+	values, err := unpackInput(RespondNetworkSecretMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &RespondNetworkSecretInput{
+		AttesterID:     *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+		RequesterID:    *abi.ConvertType(values[1], new(gethcommon.Address)).(*gethcommon.Address),
+		AttesterSig:    *abi.ConvertType(values[2], new([]byte)).(*[]byte),
+		ResponseSecret: *abi.ConvertType(values[3], new([]byte)).(*[]byte),
+		HostAddress:    *abi.ConvertType(values[4], new(string)).(*string),
+		VerifyAttester: *abi.ConvertType(values[5], new(bool)).(*bool),
+	}, nil
+}
+
+// RetrieveAllBridgeFundsMethod is the name of the RetrieveAllBridgeFunds() method in the ABI
+const RetrieveAllBridgeFundsMethod = "RetrieveAllBridgeFunds"
+
+// PackRetrieveAllBridgeFunds packs a call to RetrieveAllBridgeFunds()
+func PackRetrieveAllBridgeFunds() ([]byte, error) {
+	return parsedManagementContractABI.Pack(RetrieveAllBridgeFundsMethod)
+}
+
+// SetGovernanceParameterMethod is the name of the SetGovernanceParameter(string,uint256) method in the ABI
+const SetGovernanceParameterMethod = "SetGovernanceParameter"
+
+// PackSetGovernanceParameter packs a call to SetGovernanceParameter(string,uint256)
+func PackSetGovernanceParameter(name string, value *big.Int) ([]byte, error) {
+	return parsedManagementContractABI.Pack(SetGovernanceParameterMethod, name, value)
+}
+
+// SetGovernanceParameterInput is the arguments of a call to SetGovernanceParameter(string,uint256)
+type SetGovernanceParameterInput struct {
+	Name  string
+	Value *big.Int
+}
+
+// UnpackSetGovernanceParameterInput unpacks the arguments of a call to SetGovernanceParameter(string,uint256) from its call data
+func UnpackSetGovernanceParameterInput(data []byte) (*SetGovernanceParameterInput, error) {
+	values, err := unpackInput(SetGovernanceParameterMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &SetGovernanceParameterInput{
+		Name:  *abi.ConvertType(values[0], new(string)).(*string),
+		Value: *abi.ConvertType(values[1], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// SetImportantContractAddressMethod is the name of the SetImportantContractAddress(string,address) method in the ABI
+const SetImportantContractAddressMethod = "SetImportantContractAddress"
+
+// PackSetImportantContractAddress packs a call to SetImportantContractAddress(string,address)
+func PackSetImportantContractAddress(key string, newAddress gethcommon.Address) ([]byte, error) {
+	return parsedManagementContractABI.Pack(SetImportantContractAddressMethod, key, newAddress)
+}
+
+// SetImportantContractAddressInput is the arguments of a call to SetImportantContractAddress(string,address)
+type SetImportantContractAddressInput struct {
+	Key        string
+	NewAddress gethcommon.Address
+}
+
+// UnpackSetImportantContractAddressInput unpacks the arguments of a call to SetImportantContractAddress(string,address) from its call data
+func UnpackSetImportantContractAddressInput(data []byte) (*SetImportantContractAddressInput, error) {
+	values, err := unpackInput(SetImportantContractAddressMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &SetImportantContractAddressInput{
+		Key:        *abi.ConvertType(values[0], new(string)).(*string),
+		NewAddress: *abi.ConvertType(values[1], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// ImportantContractAddressesMethod is the name of the importantContractAddresses(string) method in the ABI
+const ImportantContractAddressesMethod = "importantContractAddresses"
+
+// PackImportantContractAddresses packs a call to importantContractAddresses(string)
+func PackImportantContractAddresses(arg0 string) ([]byte, error) {
+	return parsedManagementContractABI.Pack(ImportantContractAddressesMethod, arg0)
+}
+
+// ImportantContractAddressesInput is the arguments of a call to importantContractAddresses(string)
+type ImportantContractAddressesInput struct {
+	Arg0 string
+}
+
+// UnpackImportantContractAddressesInput unpacks the arguments of a call to importantContractAddresses(string) from its call data
+func UnpackImportantContractAddressesInput(data []byte) (*ImportantContractAddressesInput, error) {
+	values, err := unpackInput(ImportantContractAddressesMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &ImportantContractAddressesInput{
+		Arg0: *abi.ConvertType(values[0], new(string)).(*string),
+	}, nil
+}
+
+// UnpackImportantContractAddressesOutput unpacks the result of a call to importantContractAddresses(string)
+func UnpackImportantContractAddressesOutput(data []byte) (gethcommon.Address, error) {
+	values, err := parsedManagementContractABI.Unpack(ImportantContractAddressesMethod, data)
+	if err != nil {
+		return *new(gethcommon.Address), err
+	}
+	return *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address), nil
+}
+
+// ImportantContractKeysMethod is the name of the importantContractKeys(uint256) method in the ABI
+const ImportantContractKeysMethod = "importantContractKeys"
+
+// PackImportantContractKeys packs a call to importantContractKeys(uint256)
+func PackImportantContractKeys(arg0 *big.Int) ([]byte, error) {
+	return parsedManagementContractABI.Pack(ImportantContractKeysMethod, arg0)
+}
+
+// ImportantContractKeysInput is the arguments of a call to importantContractKeys(uint256)
+type ImportantContractKeysInput struct {
+	Arg0 *big.Int
+}
+
+// UnpackImportantContractKeysInput unpacks the arguments of a call to importantContractKeys(uint256) from its call data
+func UnpackImportantContractKeysInput(data []byte) (*ImportantContractKeysInput, error) {
+	values, err := unpackInput(ImportantContractKeysMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &ImportantContractKeysInput{
+		Arg0: *abi.ConvertType(values[0], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// UnpackImportantContractKeysOutput unpacks the result of a call to importantContractKeys(uint256)
+func UnpackImportantContractKeysOutput(data []byte) (string, error) {
+	values, err := parsedManagementContractABI.Unpack(ImportantContractKeysMethod, data)
+	if err != nil {
+		return *new(string), err
+	}
+	return *abi.ConvertType(values[0], new(string)).(*string), nil
+}
+
+// InitializeMethod is the name of the initialize() method in the ABI
+const InitializeMethod = "initialize"
+
+// PackInitialize packs a call to initialize()
+func PackInitialize() ([]byte, error) {
+	return parsedManagementContractABI.Pack(InitializeMethod)
+}
+
+// LastBatchSeqNoMethod is the name of the lastBatchSeqNo() method in the ABI
+const LastBatchSeqNoMethod = "lastBatchSeqNo"
+
+// PackLastBatchSeqNo packs a call to lastBatchSeqNo()
+func PackLastBatchSeqNo() ([]byte, error) {
+	return parsedManagementContractABI.Pack(LastBatchSeqNoMethod)
+}
+
+// UnpackLastBatchSeqNoOutput unpacks the result of a call to lastBatchSeqNo()
+func UnpackLastBatchSeqNoOutput(data []byte) (*big.Int, error) {
+	values, err := parsedManagementContractABI.Unpack(LastBatchSeqNoMethod, data)
+	if err != nil {
+		return *new(*big.Int), err
+	}
+	return *abi.ConvertType(values[0], new(*big.Int)).(**big.Int), nil
+}
+
+// MessageBusMethod is the name of the messageBus() method in the ABI
+const MessageBusMethod = "messageBus"
+
+// PackMessageBus packs a call to messageBus()
+func PackMessageBus() ([]byte, error) {
+	return parsedManagementContractABI.Pack(MessageBusMethod)
+}
+
+// UnpackMessageBusOutput unpacks the result of a call to messageBus()
+func UnpackMessageBusOutput(data []byte) (gethcommon.Address, error) {
+	values, err := parsedManagementContractABI.Unpack(MessageBusMethod, data)
+	if err != nil {
+		return *new(gethcommon.Address), err
+	}
+	return *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address), nil
+}
+
+// OwnerMethod is the name of the owner() method in the ABI
+const OwnerMethod = "owner"
+
+// PackOwner packs a call to owner()
+func PackOwner() ([]byte, error) {
+	return parsedManagementContractABI.Pack(OwnerMethod)
+}
+
+// UnpackOwnerOutput unpacks the result of a call to owner()
+func UnpackOwnerOutput(data []byte) (gethcommon.Address, error) {
+	values, err := parsedManagementContractABI.Unpack(OwnerMethod, data)
+	if err != nil {
+		return *new(gethcommon.Address), err
+	}
+	return *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address), nil
+}
+
+// RenounceOwnershipMethod is the name of the renounceOwnership() method in the ABI
+const RenounceOwnershipMethod = "renounceOwnership"
+
+// PackRenounceOwnership packs a call to renounceOwnership()
+func PackRenounceOwnership() ([]byte, error) {
+	return parsedManagementContractABI.Pack(RenounceOwnershipMethod)
+}
+
+// TransferOwnershipMethod is the name of the transferOwnership(address) method in the ABI
+const TransferOwnershipMethod = "transferOwnership"
+
+// PackTransferOwnership packs a call to transferOwnership(address)
+func PackTransferOwnership(newOwner gethcommon.Address) ([]byte, error) {
+	return parsedManagementContractABI.Pack(TransferOwnershipMethod, newOwner)
+}
+
+// TransferOwnershipInput is the arguments of a call to transferOwnership(address)
+type TransferOwnershipInput struct {
+	NewOwner gethcommon.Address
+}
+
+// UnpackTransferOwnershipInput unpacks the arguments of a call to transferOwnership(address) from its call data
+func UnpackTransferOwnershipInput(data []byte) (*TransferOwnershipInput, error) {
+	values, err := unpackInput(TransferOwnershipMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &TransferOwnershipInput{
+		NewOwner: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// GovernanceParameterChangedEventID is the topic of the GovernanceParameterChanged(string,uint256) event
+var GovernanceParameterChangedEventID = gethcommon.HexToHash("0xf74d93d18fc574f65aec2be88c3d2d878f5488a3e0ca04ca69546fd9a1cb603a")
+
+// GovernanceParameterChangedIsLifecycle is true: on TEN, the GovernanceParameterChanged events have no indexed address field, so they are lifecycle
+// events visible to everyone.
+const GovernanceParameterChangedIsLifecycle = true
+
+// UnpackGovernanceParameterChangedEvent unpacks a GovernanceParameterChanged(string,uint256) log
+func UnpackGovernanceParameterChangedEvent(log types.Log) (*ManagementContractGovernanceParameterChanged, error) {
+	event := new(ManagementContractGovernanceParameterChanged)
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsedManagementContractABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "GovernanceParameterChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ImportantContractAddressUpdatedEventID is the topic of the ImportantContractAddressUpdated(string,address) event
+var ImportantContractAddressUpdatedEventID = gethcommon.HexToHash("0x17b2f9f5748931099ffee882b5b64f4a560b5c55da9b4f4e396dae3bb9f98cb5")
+
+// ImportantContractAddressUpdatedIsLifecycle is true: on TEN, the ImportantContractAddressUpdated events have no indexed address field, so they are lifecycle
+// events visible to everyone.
+const ImportantContractAddressUpdatedIsLifecycle = true
+
+// UnpackImportantContractAddressUpdatedEvent unpacks a ImportantContractAddressUpdated(string,address) log
+func UnpackImportantContractAddressUpdatedEvent(log types.Log) (*ManagementContractImportantContractAddressUpdated, error) {
+	event := new(ManagementContractImportantContractAddressUpdated)
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsedManagementContractABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "ImportantContractAddressUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// LogManagementContractCreatedEventID is the topic of the LogManagementContractCreated(address) event
+var LogManagementContractCreatedEventID = gethcommon.HexToHash("0xbd726cf82ac9c3260b1495107182e336e0654b25c10915648c0cc15b2bb72cbf")
+
+// LogManagementContractCreatedIsLifecycle is true: on TEN, the LogManagementContractCreated events have no indexed address field, so they are lifecycle
+// events visible to everyone.
+const LogManagementContractCreatedIsLifecycle = true
+
+// UnpackLogManagementContractCreatedEvent unpacks a LogManagementContractCreated(address) log
+func UnpackLogManagementContractCreatedEvent(log types.Log) (*ManagementContractLogManagementContractCreated, error) {
+	event := new(ManagementContractLogManagementContractCreated)
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsedManagementContractABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "LogManagementContractCreated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// OwnershipTransferredEventID is the topic of the OwnershipTransferred(address,address) event
+var OwnershipTransferredEventID = gethcommon.HexToHash("0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0")
+
+// OwnershipTransferredIsLifecycle is false: on TEN, the OwnershipTransferred events are only visible to the user accounts in their
+// indexed previousOwner, newOwner fields, or to everyone if none of them holds a user account.
+const OwnershipTransferredIsLifecycle = false
+
+// UnpackOwnershipTransferredEvent unpacks a OwnershipTransferred(address,address) log
+func UnpackOwnershipTransferredEvent(log types.Log) (*ManagementContractOwnershipTransferred, error) {
+	event := new(ManagementContractOwnershipTransferred)
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsedManagementContractABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
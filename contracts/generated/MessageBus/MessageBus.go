@@ -41,8 +41,8 @@ type StructsCrossChainMessage struct {
 
 // MessageBusMetaData contains all meta data concerning the MessageBus contract.
 var MessageBusMetaData = &bind.MetaData{
-	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint32\",\"name\":\"nonce\",\"type\":\"uint32\"},{\"indexed\":false,\"internalType\":\"uint32\",\"name\":\"topic\",\"type\":\"uint32\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint8\",\"name\":\"consistencyLevel\",\"type\":\"uint8\"}],\"name\":\"LogMessagePublished\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"ValueTransfer\",\"type\":\"event\"},{\"stateMutability\":\"payable\",\"type\":\"fallback\"},{\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"internalType\":\"uint32\",\"name\":\"nonce\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"topic\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"},{\"internalType\":\"uint8\",\"name\":\"consistencyLevel\",\"type\":\"uint8\"}],\"internalType\":\"structStructs.CrossChainMessage\",\"name\":\"crossChainMessage\",\"type\":\"tuple\"}],\"name\":\"getMessageTimeOfFinality\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"nonce\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"topic\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"},{\"internalType\":\"uint8\",\"name\":\"consistencyLevel\",\"type\":\"uint8\"}],\"name\":\"publishMessage\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"receiveValueFromL2\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"retrieveAllFunds\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"sendValueToL2\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"internalType\":\"uint32\",\"name\":\"nonce\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"topic\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"},{\"internalType\":\"uint8\",\"name\":\"consistencyLevel\",\"type\":\"uint8\"}],\"internalType\":\"structStructs.CrossChainMessage\",\"name\":\"crossChainMessage\",\"type\":\"tuple\"},{\"internalType\":\"uint256\",\"name\":\"finalAfterTimestamp\",\"type\":\"uint256\"}],\"name\":\"storeCrossChainMessage\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"internalType\":\"uint32\",\"name\":\"nonce\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"topic\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"},{\"internalType\":\"uint8\",\"name\":\"consistencyLevel\",\"type\":\"uint8\"}],\"internalType\":\"structStructs.CrossChainMessage\",\"name\":\"crossChainMessage\",\"type\":\"tuple\"}],\"name\":\"verifyMessageFinalized\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"stateMutability\":\"payable\",\"type\":\"receive\"}]",
-	Bin: "0x608060405234801561001057600080fd5b5061001a3361001f565b61006f565b600080546001600160a01b038381166001600160a01b0319831681178455604051919092169283917f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e09190a35050565b61119f8061007e6000396000f3fe6080604052600436106100b55760003560e01c80638da5cb5b1161006957806399a3ad211161004e57806399a3ad2114610270578063b1454caa14610290578063f2fde38b146102c95761012e565b80638da5cb5b146102285780639730886d146102505761012e565b8063346633fb1161009a578063346633fb146101de57806336d2da90146101f3578063715018a6146102135761012e565b80630fcfbd111461017b57806333a88c72146101ae5761012e565b3661012e576040517f346633fb0000000000000000000000000000000000000000000000000000000081523360048201523460248201819052309163346633fb91906044016000604051808303818588803b15801561011357600080fd5b505af1158015610127573d6000803e3d6000fd5b5050505050005b60405162461bcd60e51b815260206004820152600b60248201527f756e737570706f7274656400000000000000000000000000000000000000000060448201526064015b60405180910390fd5b34801561018757600080fd5b5061019b610196366004610ad9565b6102e9565b6040519081526020015b60405180910390f35b3480156101ba57600080fd5b506101ce6101c9366004610ad9565b61039f565b60405190151581526020016101a5565b6101f16101ec366004610b23565b6103f2565b005b3480156101ff57600080fd5b506101f161020e366004610b4f565b6104be565b34801561021f57600080fd5b506101f16105bf565b34801561023457600080fd5b506000546040516001600160a01b0390911681526020016101a5565b34801561025c57600080fd5b506101f161026b366004610b6c565b610625565b34801561027c57600080fd5b506101f161028b366004610b23565b6107c9565b34801561029c57600080fd5b506102b06102ab366004610be2565b6108cb565b60405167ffffffffffffffff90911681526020016101a5565b3480156102d557600080fd5b506101f16102e4366004610b4f565b610924565b600080826040516020016102fd9190610cce565b60408051601f19818403018152918152815160209283012060008181526001909352912054909150806103985760405162461bcd60e51b815260206004820152602160248201527f54686973206d65737361676520776173206e65766572207375626d697474656460448201527f2e000000000000000000000000000000000000000000000000000000000000006064820152608401610172565b9392505050565b600080826040516020016103b39190610cce565b60408051601f1981840301815291815281516020928301206000818152600190935291205490915080158015906103ea5750428111155b949350505050565b60003411801561040157508034145b6104735760405162461bcd60e51b815260206004820152603060248201527f417474656d7074696e6720746f2073656e642076616c756520776974686f757460448201527f2070726f766964696e67204574686572000000000000000000000000000000006064820152608401610172565b604080513381526001600160a01b0384166020820152348183015290517ff1365f826a788d6c1a955db0eed5ba8642674219c4771f8c65918617511a15609181900360600190a15050565b6000546001600160a01b031633146105185760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152606401610172565b6000816001600160a01b03164760405160006040518083038185875af1925050503d8060008114610565576040519150601f19603f3d011682016040523d82523d6000602084013e61056a565b606091505b50509050806105bb5760405162461bcd60e51b815260206004820152601460248201527f6661696c65642073656e64696e672076616c75650000000000000000000000006044820152606401610172565b5050565b6000546001600160a01b031633146106195760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152606401610172565b6106236000610a06565b565b6000546001600160a01b0316331461067f5760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152606401610172565b600061068b8242610dce565b90506000836040516020016106a09190610cce565b60408051601f198184030181529181528151602092830120600081815260019093529120549091501561073b5760405162461bcd60e51b815260206004820152602160248201527f4d657373616765207375626d6974746564206d6f7265207468616e206f6e636560448201527f21000000000000000000000000000000000000000000000000000000000000006064820152608401610172565b600081815260016020908152604082208490556002919061075e90870187610b4f565b6001600160a01b03168152602081019190915260400160009081209061078a6080870160608801610de6565b63ffffffff168152602080820192909252604001600090812080546001810182559082529190208591600402016107c18282610fc3565b505050505050565b6000546001600160a01b031633146108235760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152606401610172565b6000826001600160a01b03168260405160006040518083038185875af1925050503d8060008114610870576040519150601f19603f3d011682016040523d82523d6000602084013e610875565b606091505b50509050806108c65760405162461bcd60e51b815260206004820152601460248201527f6661696c65642073656e64696e672076616c75650000000000000000000000006044820152606401610172565b505050565b60006108d633610a63565b90507fb93c37389233beb85a3a726c3f15c2d15533ee74cb602f20f490dfffef7759373382888888888860405161091397969594939291906110dd565b60405180910390a195945050505050565b6000546001600160a01b0316331461097e5760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e65726044820152606401610172565b6001600160a01b0381166109fa5760405162461bcd60e51b815260206004820152602660248201527f4f776e61626c653a206e6577206f776e657220697320746865207a65726f206160448201527f64647265737300000000000000000000000000000000000000000000000000006064820152608401610172565b610a0381610a06565b50565b600080546001600160a01b0383811673ffffffffffffffffffffffffffffffffffffffff19831681178455604051919092169283917f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e09190a35050565b6001600160a01b0381166000908152600360205260408120805467ffffffffffffffff169160019190610a96838561113d565b92506101000a81548167ffffffffffffffff021916908367ffffffffffffffff160217905550919050565b600060c08284031215610ad357600080fd5b50919050565b600060208284031215610aeb57600080fd5b813567ffffffffffffffff811115610b0257600080fd5b6103ea84828501610ac1565b6001600160a01b0381168114610a0357600080fd5b60008060408385031215610b3657600080fd5b8235610b4181610b0e565b946020939093013593505050565b600060208284031215610b6157600080fd5b813561039881610b0e565b60008060408385031215610b7f57600080fd5b823567ffffffffffffffff811115610b9657600080fd5b610ba285828601610ac1565b95602094909401359450505050565b63ffffffff81168114610a0357600080fd5b60ff81168114610a0357600080fd5b8035610bdd81610bc3565b919050565b600080600080600060808688031215610bfa57600080fd5b8535610c0581610bb1565b94506020860135610c1581610bb1565b9350604086013567ffffffffffffffff80821115610c3257600080fd5b818801915088601f830112610c4657600080fd5b813581811115610c5557600080fd5b896020828501011115610c6757600080fd5b6020830195508094505050506060860135610c8181610bc3565b809150509295509295909350565b67ffffffffffffffff81168114610a0357600080fd5b81835281816020850137506000828201602090810191909152601f909101601f19169091010190565b6020815260008235610cdf81610b0e565b6001600160a01b0381166020840152506020830135610cfd81610c8f565b67ffffffffffffffff808216604085015260408501359150610d1e82610bb1565b63ffffffff808316606086015260608601359250610d3b83610bb1565b80831660808601525060808501359150601e19853603018212610d5d57600080fd5b90840190813581811115610d7057600080fd5b803603861315610d7f57600080fd5b60c060a0860152610d9760e086018260208601610ca5565b92505050610da760a08501610bd2565b60ff811660c0850152509392505050565b634e487b7160e01b600052601160045260246000fd5b60008219821115610de157610de1610db8565b500190565b600060208284031215610df857600080fd5b813561039881610bb1565b60008135610e1081610bb1565b92915050565b6000808335601e19843603018112610e2d57600080fd5b83018035915067ffffffffffffffff821115610e4857600080fd5b602001915036819003821315610e5d57600080fd5b9250929050565b634e487b7160e01b600052604160045260246000fd5b600181811c90821680610e8e57607f821691505b60208210811415610ad357634e487b7160e01b600052602260045260246000fd5b601f8211156108c657600081815260208120601f850160051c81016020861015610ed65750805b601f850160051c820191505b818110156107c157828155600101610ee2565b67ffffffffffffffff831115610f0d57610f0d610e64565b610f2183610f1b8354610e7a565b83610eaf565b6000601f841160018114610f555760008515610f3d5750838201355b600019600387901b1c1916600186901b178355610faf565b600083815260209020601f19861690835b82811015610f865786850135825560209485019460019092019101610f66565b5086821015610fa35760001960f88860031b161c19848701351681555b505060018560011b0183555b5050505050565b60008135610e1081610bc3565b8135610fce81610b0e565b6001600160a01b038116905081548173ffffffffffffffffffffffffffffffffffffffff198216178355602084013561100681610c8f565b7bffffffffffffffff00000000000000000000000000000000000000008160a01b1690507fffffffff000000000000000000000000000000000000000000000000000000008184828516171785556040860135925061106483610bb1565b921760e09190911b90911617815561109c61108160608401610e03565b6001830163ffffffff821663ffffffff198254161781555050565b6110a96080830183610e16565b6110b7818360028601610ef5565b50506105bb6110c860a08401610fb6565b6003830160ff821660ff198254161781555050565b6001600160a01b038816815267ffffffffffffffff87166020820152600063ffffffff808816604084015280871660608401525060c0608083015261112660c083018587610ca5565b905060ff831660a083015298975050505050505050565b600067ffffffffffffffff80831681851680830382111561116057611160610db8565b0194935050505056fea2646970667358221220a54aad59b2972196a793838aab3ac603764d8f10f53f8b069358ac232fba77fe64736f6c63430008090033",
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"indexed\":false,\"internalType\":\"uint32\",\"name\":\"nonce\",\"type\":\"uint32\"},{\"indexed\":false,\"internalType\":\"uint32\",\"name\":\"topic\",\"type\":\"uint32\"},{\"indexed\":false,\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"},{\"indexed\":false,\"internalType\":\"uint8\",\"name\":\"consistencyLevel\",\"type\":\"uint8\"}],\"name\":\"LogMessagePublished\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"bytes32\",\"name\":\"msgHash\",\"type\":\"bytes32\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"relayer\",\"type\":\"address\"}],\"name\":\"MessageRelayed\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"messenger\",\"type\":\"address\"}],\"name\":\"MessengerUpdated\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"previousOwner\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"OwnershipTransferred\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":false,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"ValueTransfer\",\"type\":\"event\"},{\"stateMutability\":\"payable\",\"type\":\"fallback\"},{\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"internalType\":\"uint32\",\"name\":\"nonce\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"topic\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"},{\"internalType\":\"uint8\",\"name\":\"consistencyLevel\",\"type\":\"uint8\"}],\"internalType\":\"structStructs.CrossChainMessage\",\"name\":\"crossChainMessage\",\"type\":\"tuple\"}],\"name\":\"getMessageTimeOfFinality\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"messenger\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"owner\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint32\",\"name\":\"nonce\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"topic\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"},{\"internalType\":\"uint8\",\"name\":\"consistencyLevel\",\"type\":\"uint8\"}],\"name\":\"publishMessage\",\"outputs\":[{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"}],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"receiveValueFromL2\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"internalType\":\"uint32\",\"name\":\"nonce\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"topic\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"},{\"internalType\":\"uint8\",\"name\":\"consistencyLevel\",\"type\":\"uint8\"}],\"internalType\":\"structStructs.CrossChainMessage\",\"name\":\"crossChainMessage\",\"type\":\"tuple\"},{\"internalType\":\"address\",\"name\":\"relayer\",\"type\":\"address\"}],\"name\":\"recordRelay\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"renounceOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"retrieveAllFunds\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"}],\"name\":\"sendValueToL2\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"messengerAddress\",\"type\":\"address\"}],\"name\":\"setMessenger\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"internalType\":\"uint32\",\"name\":\"nonce\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"topic\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"},{\"internalType\":\"uint8\",\"name\":\"consistencyLevel\",\"type\":\"uint8\"}],\"internalType\":\"structStructs.CrossChainMessage\",\"name\":\"crossChainMessage\",\"type\":\"tuple\"},{\"internalType\":\"uint256\",\"name\":\"finalAfterTimestamp\",\"type\":\"uint256\"}],\"name\":\"storeCrossChainMessage\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newOwner\",\"type\":\"address\"}],\"name\":\"transferOwnership\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"},{\"internalType\":\"uint64\",\"name\":\"sequence\",\"type\":\"uint64\"},{\"internalType\":\"uint32\",\"name\":\"nonce\",\"type\":\"uint32\"},{\"internalType\":\"uint32\",\"name\":\"topic\",\"type\":\"uint32\"},{\"internalType\":\"bytes\",\"name\":\"payload\",\"type\":\"bytes\"},{\"internalType\":\"uint8\",\"name\":\"consistencyLevel\",\"type\":\"uint8\"}],\"internalType\":\"structStructs.CrossChainMessage\",\"name\":\"crossChainMessage\",\"type\":\"tuple\"}],\"name\":\"verifyMessageFinalized\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"stateMutability\":\"payable\",\"type\":\"receive\"}]",
+	Bin: "0x608060405234801561001057600080fd5b5061001a3361001f565b61006f565b600080546001600160a01b038381166001600160a01b0319831681178455604051919092169283917f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e09190a35050565b61159b8061007e6000396000f3fe6080604052600436106100d65760003560e01c8063715018a61161007f57806399a3ad211161005957806399a3ad21146102d8578063b1454caa146102f8578063cd6e65c014610331578063f2fde38b146103515761014a565b8063715018a6146102855780638da5cb5b1461029a5780639730886d146102b85761014a565b806336d2da90116100b057806336d2da901461020d5780633cb747bf1461022d57806366285967146102655761014a565b80630fcfbd111461019757806333a88c72146101ca578063346633fb146101fa5761014a565b3661014a576040517f346633fb0000000000000000000000000000000000000000000000000000000081523360048201523460248201819052309163346633fb91906044016000604051808303818588803b15801561013457600080fd5b505af1158015610148573d6000803e3d6000fd5b005b60405162461bcd60e51b815260206004820152600b60248201527f756e737570706f7274656400000000000000000000000000000000000000000060448201526064015b60405180910390fd5b3480156101a357600080fd5b506101b76101b2366004610e6a565b610371565b6040519081526020015b60405180910390f35b3480156101d657600080fd5b506101ea6101e5366004610e6a565b610427565b60405190151581526020016101c1565b610148610208366004610eb4565b61047a565b34801561021957600080fd5b50610148610228366004610ee0565b610546565b34801561023957600080fd5b5060045461024d906001600160a01b031681565b6040516001600160a01b0390911681526020016101c1565b34801561027157600080fd5b50610148610280366004610ee0565b610647565b34801561029157600080fd5b50610148610702565b3480156102a657600080fd5b506000546001600160a01b031661024d565b3480156102c457600080fd5b506101486102d3366004610efd565b610768565b3480156102e457600080fd5b506101486102f3366004610eb4565b61090c565b34801561030457600080fd5b50610318610313366004610f73565b610a0e565b60405167ffffffffffffffff90911681526020016101c1565b34801561033d57600080fd5b5061014861034c366004611020565b610a67565b34801561035d57600080fd5b5061014861036c366004610ee0565b610cb5565b6000808260405160200161038591906110b1565b60408051601f19818403018152918152815160209283012060008181526001909352912054909150806104205760405162461bcd60e51b815260206004820152602160248201527f54686973206d65737361676520776173206e65766572207375626d697474656460448201527f2e00000000000000000000000000000000000000000000000000000000000000606482015260840161018e565b9392505050565b6000808260405160200161043b91906110b1565b60408051601f1981840301815291815281516020928301206000818152600190935291205490915080158015906104725750428111155b949350505050565b60003411801561048957508034145b6104fb5760405162461bcd60e51b815260206004820152603060248201527f417474656d7074696e6720746f2073656e642076616c756520776974686f757460448201527f2070726f766964696e6720457468657200000000000000000000000000000000606482015260840161018e565b604080513381526001600160a01b0384166020820152348183015290517ff1365f826a788d6c1a955db0eed5ba8642674219c4771f8c65918617511a15609181900360600190a15050565b6000546001600160a01b031633146105a05760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572604482015260640161018e565b6000816001600160a01b03164760405160006040518083038185875af1925050503d80600081146105ed576040519150601f19603f3d011682016040523d82523d6000602084013e6105f2565b606091505b50509050806106435760405162461bcd60e51b815260206004820152601460248201527f6661696c65642073656e64696e672076616c7565000000000000000000000000604482015260640161018e565b5050565b6000546001600160a01b031633146106a15760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572604482015260640161018e565b6004805473ffffffffffffffffffffffffffffffffffffffff19166001600160a01b0383169081179091556040519081527f46411ba53a3aaae1ac6b6babc952489fe874a367391ebe59d9276bf58508f10b9060200160405180910390a150565b6000546001600160a01b0316331461075c5760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572604482015260640161018e565b6107666000610d97565b565b6000546001600160a01b031633146107c25760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572604482015260640161018e565b60006107ce82426111b2565b90506000836040516020016107e391906110b1565b60408051601f198184030181529181528151602092830120600081815260019093529120549091501561087e5760405162461bcd60e51b815260206004820152602160248201527f4d657373616765207375626d6974746564206d6f7265207468616e206f6e636560448201527f2100000000000000000000000000000000000000000000000000000000000000606482015260840161018e565b60008181526001602090815260408220849055600291906108a190870187610ee0565b6001600160a01b0316815260208101919091526040016000908120906108cd60808701606088016111cb565b63ffffffff1681526020808201929092526040016000908120805460018101825590825291902085916004020161090482826113a1565b505050505050565b6000546001600160a01b031633146109665760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572604482015260640161018e565b6000826001600160a01b03168260405160006040518083038185875af1925050503d80600081146109b3576040519150601f19603f3d011682016040523d82523d6000602084013e6109b8565b606091505b5050905080610a095760405162461bcd60e51b815260206004820152601460248201527f6661696c65642073656e64696e672076616c7565000000000000000000000000604482015260640161018e565b505050565b6000610a1933610df4565b90507fb93c37389233beb85a3a726c3f15c2d15533ee74cb602f20f490dfffef77593733828888888888604051610a5697969594939291906114bb565b60405180910390a195945050505050565b6004546001600160a01b03163314610ae75760405162461bcd60e51b815260206004820152602560248201527f4f6e6c7920746865206d657373656e6765722063616e207265636f726420726560448201527f6c6179732e000000000000000000000000000000000000000000000000000000606482015260840161018e565b6040517f33a88c7200000000000000000000000000000000000000000000000000000000815230906333a88c7290610b239085906004016110b1565b602060405180830381865afa158015610b40573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190610b64919061151b565b610bb05760405162461bcd60e51b815260206004820152601f60248201527f4d657373616765206e6f7420666f756e64206f722066696e616c697a65642e00604482015260640161018e565b600082604051602001610bc391906110b1565b60408051601f198184030181529181528151602092830120600081815260039093529120549091506001600160a01b031615610c415760405162461bcd60e51b815260206004820152601760248201527f52656c617920616c7265616479207265636f726465642e000000000000000000604482015260640161018e565b600081815260036020908152604091829020805473ffffffffffffffffffffffffffffffffffffffff19166001600160a01b0386169081179091558251848152918201527ff449a9f37e33ab4ba56111d3c5aeca5afdbe51aedc43e4effd68c66ff0d58c80910160405180910390a1505050565b6000546001600160a01b03163314610d0f5760405162461bcd60e51b815260206004820181905260248201527f4f776e61626c653a2063616c6c6572206973206e6f7420746865206f776e6572604482015260640161018e565b6001600160a01b038116610d8b5760405162461bcd60e51b815260206004820152602660248201527f4f776e61626c653a206e6577206f776e657220697320746865207a65726f206160448201527f6464726573730000000000000000000000000000000000000000000000000000606482015260840161018e565b610d9481610d97565b50565b600080546001600160a01b0383811673ffffffffffffffffffffffffffffffffffffffff19831681178455604051919092169283917f8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e09190a35050565b6001600160a01b0381166000908152600560205260408120805467ffffffffffffffff169160019190610e27838561153d565b92506101000a81548167ffffffffffffffff021916908367ffffffffffffffff160217905550919050565b600060c08284031215610e6457600080fd5b50919050565b600060208284031215610e7c57600080fd5b813567ffffffffffffffff811115610e9357600080fd5b61047284828501610e52565b6001600160a01b0381168114610d9457600080fd5b60008060408385031215610ec757600080fd5b8235610ed281610e9f565b946020939093013593505050565b600060208284031215610ef257600080fd5b813561042081610e9f565b60008060408385031215610f1057600080fd5b823567ffffffffffffffff811115610f2757600080fd5b610f3385828601610e52565b95602094909401359450505050565b63ffffffff81168114610d9457600080fd5b60ff81168114610d9457600080fd5b8035610f6e81610f54565b919050565b600080600080600060808688031215610f8b57600080fd5b8535610f9681610f42565b94506020860135610fa681610f42565b9350604086013567ffffffffffffffff80821115610fc357600080fd5b818801915088601f830112610fd757600080fd5b813581811115610fe657600080fd5b896020828501011115610ff857600080fd5b602083019550809450505050606086013561101281610f54565b809150509295509295909350565b6000806040838503121561103357600080fd5b823567ffffffffffffffff81111561104a57600080fd5b61105685828601610e52565b925050602083013561106781610e9f565b809150509250929050565b67ffffffffffffffff81168114610d9457600080fd5b81835281816020850137506000828201602090810191909152601f909101601f19169091010190565b60208152600082356110c281610e9f565b6001600160a01b03811660208401525060208301356110e081611072565b67ffffffffffffffff80821660408501526040850135915061110182610f42565b63ffffffff80831660608601526060860135925061111e83610f42565b80831660808601525060808501359150601e1985360301821261114057600080fd5b602091850191820191358181111561115757600080fd5b80360383131561116657600080fd5b60c060a086015261117b60e086018285611088565b9250505061118b60a08501610f63565b60ff811660c0850152509392505050565b634e487b7160e01b600052601160045260246000fd5b808201808211156111c5576111c561119c565b92915050565b6000602082840312156111dd57600080fd5b813561042081610f42565b600081356111c581610f42565b6000808335601e1984360301811261120c57600080fd5b83018035915067ffffffffffffffff82111561122757600080fd5b60200191503681900382131561123c57600080fd5b9250929050565b634e487b7160e01b600052604160045260246000fd5b600181811c9082168061126d57607f821691505b602082108103610e6457634e487b7160e01b600052602260045260246000fd5b601f821115610a0957600081815260208120601f850160051c810160208610156112b45750805b601f850160051c820191505b81811015610904578281556001016112c0565b67ffffffffffffffff8311156112eb576112eb611243565b6112ff836112f98354611259565b8361128d565b6000601f841160018114611333576000851561131b5750838201355b600019600387901b1c1916600186901b17835561138d565b600083815260209020601f19861690835b828110156113645786850135825560209485019460019092019101611344565b50868210156113815760001960f88860031b161c19848701351681555b505060018560011b0183555b5050505050565b600081356111c581610f54565b81356113ac81610e9f565b6001600160a01b038116905081548173ffffffffffffffffffffffffffffffffffffffff19821617835560208401356113e481611072565b7bffffffffffffffff00000000000000000000000000000000000000008160a01b1690507fffffffff000000000000000000000000000000000000000000000000000000008184828516171785556040860135925061144283610f42565b921760e09190911b90911617815561147a61145f606084016111e8565b6001830163ffffffff821663ffffffff198254161781555050565b61148760808301836111f5565b6114958183600286016112d3565b50506106436114a660a08401611394565b6003830160ff821660ff198254161781555050565b6001600160a01b038816815267ffffffffffffffff87166020820152600063ffffffff808816604084015280871660608401525060c0608083015261150460c083018587611088565b905060ff831660a083015298975050505050505050565b60006020828403121561152d57600080fd5b8151801515811461042057600080fd5b67ffffffffffffffff81811683821601908082111561155e5761155e61119c565b509291505056fea2646970667358221220b2074d38eeea18a6cb6840004fe6cfecaa5d70a900af49df1420cc912222613364736f6c63430008150033",
 }
 
 // MessageBusABI is the input ABI used to generate the binding from.
@@ -243,6 +243,37 @@ func (_MessageBus *MessageBusCallerSession) GetMessageTimeOfFinality(crossChainM
 	return _MessageBus.Contract.GetMessageTimeOfFinality(&_MessageBus.CallOpts, crossChainMessage)
 }
 
+// Messenger is a free data retrieval call binding the contract method 0x3cb747bf.
+//
+// Solidity: function messenger() view returns(address)
+func (_MessageBus *MessageBusCaller) Messenger(opts *bind.CallOpts) (common.Address, error) {
+	var out []interface{}
+	err := _MessageBus.contract.Call(opts, &out, "messenger")
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// Messenger is a free data retrieval call binding the contract method 0x3cb747bf.
+//
+// Solidity: function messenger() view returns(address)
+func (_MessageBus *MessageBusSession) Messenger() (common.Address, error) {
+	return _MessageBus.Contract.Messenger(&_MessageBus.CallOpts)
+}
+
+// Messenger is a free data retrieval call binding the contract method 0x3cb747bf.
+//
+// Solidity: function messenger() view returns(address)
+func (_MessageBus *MessageBusCallerSession) Messenger() (common.Address, error) {
+	return _MessageBus.Contract.Messenger(&_MessageBus.CallOpts)
+}
+
 // Owner is a free data retrieval call binding the contract method 0x8da5cb5b.
 //
 // Solidity: function owner() view returns(address)
@@ -347,6 +378,27 @@ func (_MessageBus *MessageBusTransactorSession) ReceiveValueFromL2(receiver comm
 	return _MessageBus.Contract.ReceiveValueFromL2(&_MessageBus.TransactOpts, receiver, amount)
 }
 
+// RecordRelay is a paid mutator transaction binding the contract method 0xcd6e65c0.
+//
+// Solidity: function recordRelay((address,uint64,uint32,uint32,bytes,uint8) crossChainMessage, address relayer) returns()
+func (_MessageBus *MessageBusTransactor) RecordRelay(opts *bind.TransactOpts, crossChainMessage StructsCrossChainMessage, relayer common.Address) (*types.Transaction, error) {
+	return _MessageBus.contract.Transact(opts, "recordRelay", crossChainMessage, relayer)
+}
+
+// RecordRelay is a paid mutator transaction binding the contract method 0xcd6e65c0.
+//
+// Solidity: function recordRelay((address,uint64,uint32,uint32,bytes,uint8) crossChainMessage, address relayer) returns()
+func (_MessageBus *MessageBusSession) RecordRelay(crossChainMessage StructsCrossChainMessage, relayer common.Address) (*types.Transaction, error) {
+	return _MessageBus.Contract.RecordRelay(&_MessageBus.TransactOpts, crossChainMessage, relayer)
+}
+
+// RecordRelay is a paid mutator transaction binding the contract method 0xcd6e65c0.
+//
+// Solidity: function recordRelay((address,uint64,uint32,uint32,bytes,uint8) crossChainMessage, address relayer) returns()
+func (_MessageBus *MessageBusTransactorSession) RecordRelay(crossChainMessage StructsCrossChainMessage, relayer common.Address) (*types.Transaction, error) {
+	return _MessageBus.Contract.RecordRelay(&_MessageBus.TransactOpts, crossChainMessage, relayer)
+}
+
 // RenounceOwnership is a paid mutator transaction binding the contract method 0x715018a6.
 //
 // Solidity: function renounceOwnership() returns()
@@ -410,6 +462,27 @@ func (_MessageBus *MessageBusTransactorSession) SendValueToL2(receiver common.Ad
 	return _MessageBus.Contract.SendValueToL2(&_MessageBus.TransactOpts, receiver, amount)
 }
 
+// SetMessenger is a paid mutator transaction binding the contract method 0x66285967.
+//
+// Solidity: function setMessenger(address messengerAddress) returns()
+func (_MessageBus *MessageBusTransactor) SetMessenger(opts *bind.TransactOpts, messengerAddress common.Address) (*types.Transaction, error) {
+	return _MessageBus.contract.Transact(opts, "setMessenger", messengerAddress)
+}
+
+// SetMessenger is a paid mutator transaction binding the contract method 0x66285967.
+//
+// Solidity: function setMessenger(address messengerAddress) returns()
+func (_MessageBus *MessageBusSession) SetMessenger(messengerAddress common.Address) (*types.Transaction, error) {
+	return _MessageBus.Contract.SetMessenger(&_MessageBus.TransactOpts, messengerAddress)
+}
+
+// SetMessenger is a paid mutator transaction binding the contract method 0x66285967.
+//
+// Solidity: function setMessenger(address messengerAddress) returns()
+func (_MessageBus *MessageBusTransactorSession) SetMessenger(messengerAddress common.Address) (*types.Transaction, error) {
+	return _MessageBus.Contract.SetMessenger(&_MessageBus.TransactOpts, messengerAddress)
+}
+
 // StoreCrossChainMessage is a paid mutator transaction binding the contract method 0x9730886d.
 //
 // Solidity: function storeCrossChainMessage((address,uint64,uint32,uint32,bytes,uint8) crossChainMessage, uint256 finalAfterTimestamp) returns()
@@ -633,6 +706,275 @@ func (_MessageBus *MessageBusFilterer) ParseLogMessagePublished(log types.Log) (
 	return event, nil
 }
 
+// MessageBusMessageRelayedIterator is returned from FilterMessageRelayed and is used to iterate over the raw logs and unpacked data for MessageRelayed events raised by the MessageBus contract.
+type MessageBusMessageRelayedIterator struct {
+	Event *MessageBusMessageRelayed // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *MessageBusMessageRelayedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(MessageBusMessageRelayed)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(MessageBusMessageRelayed)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *MessageBusMessageRelayedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *MessageBusMessageRelayedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// MessageBusMessageRelayed represents a MessageRelayed event raised by the MessageBus contract.
+type MessageBusMessageRelayed struct {
+	MsgHash [32]byte
+	Relayer common.Address
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterMessageRelayed is a free log retrieval operation binding the contract event 0xf449a9f37e33ab4ba56111d3c5aeca5afdbe51aedc43e4effd68c66ff0d58c80.
+//
+// Solidity: event MessageRelayed(bytes32 msgHash, address relayer)
+func (_MessageBus *MessageBusFilterer) FilterMessageRelayed(opts *bind.FilterOpts) (*MessageBusMessageRelayedIterator, error) {
+
+	logs, sub, err := _MessageBus.contract.FilterLogs(opts, "MessageRelayed")
+	if err != nil {
+		return nil, err
+	}
+	return &MessageBusMessageRelayedIterator{contract: _MessageBus.contract, event: "MessageRelayed", logs: logs, sub: sub}, nil
+}
+
+// WatchMessageRelayed is a free log subscription operation binding the contract event 0xf449a9f37e33ab4ba56111d3c5aeca5afdbe51aedc43e4effd68c66ff0d58c80.
+//
+// Solidity: event MessageRelayed(bytes32 msgHash, address relayer)
+func (_MessageBus *MessageBusFilterer) WatchMessageRelayed(opts *bind.WatchOpts, sink chan<- *MessageBusMessageRelayed) (event.Subscription, error) {
+
+	logs, sub, err := _MessageBus.contract.WatchLogs(opts, "MessageRelayed")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(MessageBusMessageRelayed)
+				if err := _MessageBus.contract.UnpackLog(event, "MessageRelayed", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMessageRelayed is a log parse operation binding the contract event 0xf449a9f37e33ab4ba56111d3c5aeca5afdbe51aedc43e4effd68c66ff0d58c80.
+//
+// Solidity: event MessageRelayed(bytes32 msgHash, address relayer)
+func (_MessageBus *MessageBusFilterer) ParseMessageRelayed(log types.Log) (*MessageBusMessageRelayed, error) {
+	event := new(MessageBusMessageRelayed)
+	if err := _MessageBus.contract.UnpackLog(event, "MessageRelayed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// MessageBusMessengerUpdatedIterator is returned from FilterMessengerUpdated and is used to iterate over the raw logs and unpacked data for MessengerUpdated events raised by the MessageBus contract.
+type MessageBusMessengerUpdatedIterator struct {
+	Event *MessageBusMessengerUpdated // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *MessageBusMessengerUpdatedIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(MessageBusMessengerUpdated)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(MessageBusMessengerUpdated)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *MessageBusMessengerUpdatedIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *MessageBusMessengerUpdatedIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// MessageBusMessengerUpdated represents a MessengerUpdated event raised by the MessageBus contract.
+type MessageBusMessengerUpdated struct {
+	Messenger common.Address
+	Raw       types.Log // Blockchain specific contextual infos
+}
+
+// FilterMessengerUpdated is a free log retrieval operation binding the contract event 0x46411ba53a3aaae1ac6b6babc952489fe874a367391ebe59d9276bf58508f10b.
+//
+// Solidity: event MessengerUpdated(address messenger)
+func (_MessageBus *MessageBusFilterer) FilterMessengerUpdated(opts *bind.FilterOpts) (*MessageBusMessengerUpdatedIterator, error) {
+
+	logs, sub, err := _MessageBus.contract.FilterLogs(opts, "MessengerUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return &MessageBusMessengerUpdatedIterator{contract: _MessageBus.contract, event: "MessengerUpdated", logs: logs, sub: sub}, nil
+}
+
+// WatchMessengerUpdated is a free log subscription operation binding the contract event 0x46411ba53a3aaae1ac6b6babc952489fe874a367391ebe59d9276bf58508f10b.
+//
+// Solidity: event MessengerUpdated(address messenger)
+func (_MessageBus *MessageBusFilterer) WatchMessengerUpdated(opts *bind.WatchOpts, sink chan<- *MessageBusMessengerUpdated) (event.Subscription, error) {
+
+	logs, sub, err := _MessageBus.contract.WatchLogs(opts, "MessengerUpdated")
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(MessageBusMessengerUpdated)
+				if err := _MessageBus.contract.UnpackLog(event, "MessengerUpdated", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseMessengerUpdated is a log parse operation binding the contract event 0x46411ba53a3aaae1ac6b6babc952489fe874a367391ebe59d9276bf58508f10b.
+//
+// Solidity: event MessengerUpdated(address messenger)
+func (_MessageBus *MessageBusFilterer) ParseMessengerUpdated(log types.Log) (*MessageBusMessengerUpdated, error) {
+	event := new(MessageBusMessengerUpdated)
+	if err := _MessageBus.contract.UnpackLog(event, "MessengerUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
 // MessageBusOwnershipTransferredIterator is returned from FilterOwnershipTransferred and is used to iterate over the raw logs and unpacked data for OwnershipTransferred events raised by the MessageBus contract.
 type MessageBusOwnershipTransferredIterator struct {
 	Event *MessageBusOwnershipTransferred // Event containing the contract specifics and raw log
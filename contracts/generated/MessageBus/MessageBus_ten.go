@@ -0,0 +1,464 @@
+// Code generated by tools/bindgen - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package MessageBus
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = bind.NewBoundContract
+	_ = types.Log{}
+	_ = gethcommon.Hash{}
+)
+
+// the ABI of the contract, parsed once
+var parsedMessageBusABI = func() *abi.ABI {
+	parsed, err := MessageBusMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// ParsedMessageBusABI returns the parsed ABI of the MessageBus contract
+func ParsedMessageBusABI() *abi.ABI {
+	return parsedMessageBusABI
+}
+
+// unpackInput returns the arguments of a call to the method, checking that the call data is a call to it
+func unpackInput(methodName string, data []byte) ([]interface{}, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("the call data is too short to be a call to %s", methodName)
+	}
+	m, err := parsedMessageBusABI.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+	if m.Name != methodName {
+		return nil, fmt.Errorf("the call data is a call to %s, not %s", m.Name, methodName)
+	}
+	return m.Inputs.Unpack(data[4:])
+}
+
+// GetMessageTimeOfFinalityMethod is the name of the getMessageTimeOfFinality((address,uint64,uint32,uint32,bytes,uint8)) method in the ABI
+const GetMessageTimeOfFinalityMethod = "getMessageTimeOfFinality"
+
+// PackGetMessageTimeOfFinality packs a call to getMessageTimeOfFinality((address,uint64,uint32,uint32,bytes,uint8))
+func PackGetMessageTimeOfFinality(crossChainMessage StructsCrossChainMessage) ([]byte, error) {
+	return parsedMessageBusABI.Pack(GetMessageTimeOfFinalityMethod, crossChainMessage)
+}
+
+// GetMessageTimeOfFinalityInput is the arguments of a call to getMessageTimeOfFinality((address,uint64,uint32,uint32,bytes,uint8))
+type GetMessageTimeOfFinalityInput struct {
+	CrossChainMessage StructsCrossChainMessage
+}
+
+// UnpackGetMessageTimeOfFinalityInput unpacks the arguments of a call to getMessageTimeOfFinality((address,uint64,uint32,uint32,bytes,uint8)) from its call data
+func UnpackGetMessageTimeOfFinalityInput(data []byte) (*GetMessageTimeOfFinalityInput, error) {
+	values, err := unpackInput(GetMessageTimeOfFinalityMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &GetMessageTimeOfFinalityInput{
+		CrossChainMessage: *abi.ConvertType(values[0], new(StructsCrossChainMessage)).(*StructsCrossChainMessage),
+	}, nil
+}
+
+// UnpackGetMessageTimeOfFinalityOutput unpacks the result of a call to getMessageTimeOfFinality((address,uint64,uint32,uint32,bytes,uint8))
+func UnpackGetMessageTimeOfFinalityOutput(data []byte) (*big.Int, error) {
+	values, err := parsedMessageBusABI.Unpack(GetMessageTimeOfFinalityMethod, data)
+	if err != nil {
+		return *new(*big.Int), err
+	}
+	return *abi.ConvertType(values[0], new(*big.Int)).(**big.Int), nil
+}
+
+// MessengerMethod is the name of the messenger() method in the ABI
+const MessengerMethod = "messenger"
+
+// PackMessenger packs a call to messenger()
+func PackMessenger() ([]byte, error) {
+	return parsedMessageBusABI.Pack(MessengerMethod)
+}
+
+// UnpackMessengerOutput unpacks the result of a call to messenger()
+func UnpackMessengerOutput(data []byte) (gethcommon.Address, error) {
+	values, err := parsedMessageBusABI.Unpack(MessengerMethod, data)
+	if err != nil {
+		return *new(gethcommon.Address), err
+	}
+	return *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address), nil
+}
+
+// OwnerMethod is the name of the owner() method in the ABI
+const OwnerMethod = "owner"
+
+// PackOwner packs a call to owner()
+func PackOwner() ([]byte, error) {
+	return parsedMessageBusABI.Pack(OwnerMethod)
+}
+
+// UnpackOwnerOutput unpacks the result of a call to owner()
+func UnpackOwnerOutput(data []byte) (gethcommon.Address, error) {
+	values, err := parsedMessageBusABI.Unpack(OwnerMethod, data)
+	if err != nil {
+		return *new(gethcommon.Address), err
+	}
+	return *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address), nil
+}
+
+// PublishMessageMethod is the name of the publishMessage(uint32,uint32,bytes,uint8) method in the ABI
+const PublishMessageMethod = "publishMessage"
+
+// PackPublishMessage packs a call to publishMessage(uint32,uint32,bytes,uint8)
+func PackPublishMessage(nonce uint32, topic uint32, payload []byte, consistencyLevel uint8) ([]byte, error) {
+	return parsedMessageBusABI.Pack(PublishMessageMethod, nonce, topic, payload, consistencyLevel)
+}
+
+// PublishMessageInput is the arguments of a call to publishMessage(uint32,uint32,bytes,uint8)
+type PublishMessageInput struct {
+	Nonce            uint32
+	Topic            uint32
+	Payload          []byte
+	ConsistencyLevel uint8
+}
+
+// UnpackPublishMessageInput unpacks the arguments of a call to publishMessage(uint32,uint32,bytes,uint8) from its call data
+func UnpackPublishMessageInput(data []byte) (*PublishMessageInput, error) {
+	values, err := unpackInput(PublishMessageMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &PublishMessageInput{
+		Nonce:            *abi.ConvertType(values[0], new(uint32)).(*uint32),
+		Topic:            *abi.ConvertType(values[1], new(uint32)).(*uint32),
+		Payload:          *abi.ConvertType(values[2], new([]byte)).(*[]byte),
+		ConsistencyLevel: *abi.ConvertType(values[3], new(uint8)).(*uint8),
+	}, nil
+}
+
+// UnpackPublishMessageOutput unpacks the result of a call to publishMessage(uint32,uint32,bytes,uint8)
+func UnpackPublishMessageOutput(data []byte) (uint64, error) {
+	values, err := parsedMessageBusABI.Unpack(PublishMessageMethod, data)
+	if err != nil {
+		return *new(uint64), err
+	}
+	return *abi.ConvertType(values[0], new(uint64)).(*uint64), nil
+}
+
+// ReceiveValueFromL2Method is the name of the receiveValueFromL2(address,uint256) method in the ABI
+const ReceiveValueFromL2Method = "receiveValueFromL2"
+
+// PackReceiveValueFromL2 packs a call to receiveValueFromL2(address,uint256)
+func PackReceiveValueFromL2(receiver gethcommon.Address, amount *big.Int) ([]byte, error) {
+	return parsedMessageBusABI.Pack(ReceiveValueFromL2Method, receiver, amount)
+}
+
+// ReceiveValueFromL2Input is the arguments of a call to receiveValueFromL2(address,uint256)
+type ReceiveValueFromL2Input struct {
+	Receiver gethcommon.Address
+	Amount   *big.Int
+}
+
+// UnpackReceiveValueFromL2Input unpacks the arguments of a call to receiveValueFromL2(address,uint256) from its call data
+func UnpackReceiveValueFromL2Input(data []byte) (*ReceiveValueFromL2Input, error) {
+	values, err := unpackInput(ReceiveValueFromL2Method, data)
+	if err != nil {
+		return nil, err
+	}
+	return &ReceiveValueFromL2Input{
+		Receiver: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+		Amount:   *abi.ConvertType(values[1], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// RecordRelayMethod is the name of the recordRelay((address,uint64,uint32,uint32,bytes,uint8),address) method in the ABI
+const RecordRelayMethod = "recordRelay"
+
+// PackRecordRelay packs a call to recordRelay((address,uint64,uint32,uint32,bytes,uint8),address)
+func PackRecordRelay(crossChainMessage StructsCrossChainMessage, relayer gethcommon.Address) ([]byte, error) {
+	return parsedMessageBusABI.Pack(RecordRelayMethod, crossChainMessage, relayer)
+}
+
+// RecordRelayInput is the arguments of a call to recordRelay((address,uint64,uint32,uint32,bytes,uint8),address)
+type RecordRelayInput struct {
+	CrossChainMessage StructsCrossChainMessage
+	Relayer           gethcommon.Address
+}
+
+// UnpackRecordRelayInput unpacks the arguments of a call to recordRelay((address,uint64,uint32,uint32,bytes,uint8),address) from its call data
+func UnpackRecordRelayInput(data []byte) (*RecordRelayInput, error) {
+	values, err := unpackInput(RecordRelayMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &RecordRelayInput{
+		CrossChainMessage: *abi.ConvertType(values[0], new(StructsCrossChainMessage)).(*StructsCrossChainMessage),
+		Relayer:           *abi.ConvertType(values[1], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// RenounceOwnershipMethod is the name of the renounceOwnership() method in the ABI
+const RenounceOwnershipMethod = "renounceOwnership"
+
+// PackRenounceOwnership packs a call to renounceOwnership()
+func PackRenounceOwnership() ([]byte, error) {
+	return parsedMessageBusABI.Pack(RenounceOwnershipMethod)
+}
+
+// RetrieveAllFundsMethod is the name of the retrieveAllFunds(address) method in the ABI
+const RetrieveAllFundsMethod = "retrieveAllFunds"
+
+// PackRetrieveAllFunds packs a call to retrieveAllFunds(address)
+func PackRetrieveAllFunds(receiver gethcommon.Address) ([]byte, error) {
+	return parsedMessageBusABI.Pack(RetrieveAllFundsMethod, receiver)
+}
+
+// RetrieveAllFundsInput is the arguments of a call to retrieveAllFunds(address)
+type RetrieveAllFundsInput struct {
+	Receiver gethcommon.Address
+}
+
+// UnpackRetrieveAllFundsInput unpacks the arguments of a call to retrieveAllFunds(address) from its call data
+func UnpackRetrieveAllFundsInput(data []byte) (*RetrieveAllFundsInput, error) {
+	values, err := unpackInput(RetrieveAllFundsMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &RetrieveAllFundsInput{
+		Receiver: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// SendValueToL2Method is the name of the sendValueToL2(address,uint256) method in the ABI
+const SendValueToL2Method = "sendValueToL2"
+
+// PackSendValueToL2 packs a call to sendValueToL2(address,uint256)
+func PackSendValueToL2(receiver gethcommon.Address, amount *big.Int) ([]byte, error) {
+	return parsedMessageBusABI.Pack(SendValueToL2Method, receiver, amount)
+}
+
+// SendValueToL2Input is the arguments of a call to sendValueToL2(address,uint256)
+type SendValueToL2Input struct {
+	Receiver gethcommon.Address
+	Amount   *big.Int
+}
+
+// UnpackSendValueToL2Input unpacks the arguments of a call to sendValueToL2(address,uint256) from its call data
+func UnpackSendValueToL2Input(data []byte) (*SendValueToL2Input, error) {
+	values, err := unpackInput(SendValueToL2Method, data)
+	if err != nil {
+		return nil, err
+	}
+	return &SendValueToL2Input{
+		Receiver: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+		Amount:   *abi.ConvertType(values[1], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// SetMessengerMethod is the name of the setMessenger(address) method in the ABI
+const SetMessengerMethod = "setMessenger"
+
+// PackSetMessenger packs a call to setMessenger(address)
+func PackSetMessenger(messengerAddress gethcommon.Address) ([]byte, error) {
+	return parsedMessageBusABI.Pack(SetMessengerMethod, messengerAddress)
+}
+
+// SetMessengerInput is the arguments of a call to setMessenger(address)
+type SetMessengerInput struct {
+	MessengerAddress gethcommon.Address
+}
+
+// UnpackSetMessengerInput unpacks the arguments of a call to setMessenger(address) from its call data
+func UnpackSetMessengerInput(data []byte) (*SetMessengerInput, error) {
+	values, err := unpackInput(SetMessengerMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &SetMessengerInput{
+		MessengerAddress: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// StoreCrossChainMessageMethod is the name of the storeCrossChainMessage((address,uint64,uint32,uint32,bytes,uint8),uint256) method in the ABI
+const StoreCrossChainMessageMethod = "storeCrossChainMessage"
+
+// PackStoreCrossChainMessage packs a call to storeCrossChainMessage((address,uint64,uint32,uint32,bytes,uint8),uint256)
+func PackStoreCrossChainMessage(crossChainMessage StructsCrossChainMessage, finalAfterTimestamp *big.Int) ([]byte, error) {
+	return parsedMessageBusABI.Pack(StoreCrossChainMessageMethod, crossChainMessage, finalAfterTimestamp)
+}
+
+// StoreCrossChainMessageInput is the arguments of a call to storeCrossChainMessage((address,uint64,uint32,uint32,bytes,uint8),uint256)
+type StoreCrossChainMessageInput struct {
+	CrossChainMessage   StructsCrossChainMessage
+	FinalAfterTimestamp *big.Int
+}
+
+// UnpackStoreCrossChainMessageInput unpacks the arguments of a call to storeCrossChainMessage((address,uint64,uint32,uint32,bytes,uint8),uint256) from its call data
+func UnpackStoreCrossChainMessageInput(data []byte) (*StoreCrossChainMessageInput, error) {
+	values, err := unpackInput(StoreCrossChainMessageMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &StoreCrossChainMessageInput{
+		CrossChainMessage:   *abi.ConvertType(values[0], new(StructsCrossChainMessage)).(*StructsCrossChainMessage),
+		FinalAfterTimestamp: *abi.ConvertType(values[1], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// TransferOwnershipMethod is the name of the transferOwnership(address) method in the ABI
+const TransferOwnershipMethod = "transferOwnership"
+
+// PackTransferOwnership packs a call to transferOwnership(address)
+func PackTransferOwnership(newOwner gethcommon.Address) ([]byte, error) {
+	return parsedMessageBusABI.Pack(TransferOwnershipMethod, newOwner)
+}
+
+// TransferOwnershipInput is the arguments of a call to transferOwnership(address)
+type TransferOwnershipInput struct {
+	NewOwner gethcommon.Address
+}
+
+// UnpackTransferOwnershipInput unpacks the arguments of a call to transferOwnership(address) from its call data
+func UnpackTransferOwnershipInput(data []byte) (*TransferOwnershipInput, error) {
+	values, err := unpackInput(TransferOwnershipMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &TransferOwnershipInput{
+		NewOwner: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// VerifyMessageFinalizedMethod is the name of the verifyMessageFinalized((address,uint64,uint32,uint32,bytes,uint8)) method in the ABI
+const VerifyMessageFinalizedMethod = "verifyMessageFinalized"
+
+// PackVerifyMessageFinalized packs a call to verifyMessageFinalized((address,uint64,uint32,uint32,bytes,uint8))
+func PackVerifyMessageFinalized(crossChainMessage StructsCrossChainMessage) ([]byte, error) {
+	return parsedMessageBusABI.Pack(VerifyMessageFinalizedMethod, crossChainMessage)
+}
+
+// VerifyMessageFinalizedInput is the arguments of a call to verifyMessageFinalized((address,uint64,uint32,uint32,bytes,uint8))
+type VerifyMessageFinalizedInput struct {
+	CrossChainMessage StructsCrossChainMessage
+}
+
+// UnpackVerifyMessageFinalizedInput unpacks the arguments of a call to verifyMessageFinalized((address,uint64,uint32,uint32,bytes,uint8)) from its call data
+func UnpackVerifyMessageFinalizedInput(data []byte) (*VerifyMessageFinalizedInput, error) {
+	values, err := unpackInput(VerifyMessageFinalizedMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &VerifyMessageFinalizedInput{
+		CrossChainMessage: *abi.ConvertType(values[0], new(StructsCrossChainMessage)).(*StructsCrossChainMessage),
+	}, nil
+}
+
+// UnpackVerifyMessageFinalizedOutput unpacks the result of a call to verifyMessageFinalized((address,uint64,uint32,uint32,bytes,uint8))
+func UnpackVerifyMessageFinalizedOutput(data []byte) (bool, error) {
+	values, err := parsedMessageBusABI.Unpack(VerifyMessageFinalizedMethod, data)
+	if err != nil {
+		return *new(bool), err
+	}
+	return *abi.ConvertType(values[0], new(bool)).(*bool), nil
+}
+
+// LogMessagePublishedEventID is the topic of the LogMessagePublished(address,uint64,uint32,uint32,bytes,uint8) event
+var LogMessagePublishedEventID = gethcommon.HexToHash("0xb93c37389233beb85a3a726c3f15c2d15533ee74cb602f20f490dfffef775937")
+
+// LogMessagePublishedIsLifecycle is true: on TEN, the LogMessagePublished events have no indexed address field, so they are lifecycle
+// events visible to everyone.
+const LogMessagePublishedIsLifecycle = true
+
+// UnpackLogMessagePublishedEvent unpacks a LogMessagePublished(address,uint64,uint32,uint32,bytes,uint8) log
+func UnpackLogMessagePublishedEvent(log types.Log) (*MessageBusLogMessagePublished, error) {
+	event := new(MessageBusLogMessagePublished)
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsedMessageBusABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "LogMessagePublished", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// MessageRelayedEventID is the topic of the MessageRelayed(bytes32,address) event
+var MessageRelayedEventID = gethcommon.HexToHash("0xf449a9f37e33ab4ba56111d3c5aeca5afdbe51aedc43e4effd68c66ff0d58c80")
+
+// MessageRelayedIsLifecycle is true: on TEN, the MessageRelayed events have no indexed address field, so they are lifecycle
+// events visible to everyone.
+const MessageRelayedIsLifecycle = true
+
+// UnpackMessageRelayedEvent unpacks a MessageRelayed(bytes32,address) log
+func UnpackMessageRelayedEvent(log types.Log) (*MessageBusMessageRelayed, error) {
+	event := new(MessageBusMessageRelayed)
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsedMessageBusABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "MessageRelayed", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// MessengerUpdatedEventID is the topic of the MessengerUpdated(address) event
+var MessengerUpdatedEventID = gethcommon.HexToHash("0x46411ba53a3aaae1ac6b6babc952489fe874a367391ebe59d9276bf58508f10b")
+
+// MessengerUpdatedIsLifecycle is true: on TEN, the MessengerUpdated events have no indexed address field, so they are lifecycle
+// events visible to everyone.
+const MessengerUpdatedIsLifecycle = true
+
+// UnpackMessengerUpdatedEvent unpacks a MessengerUpdated(address) log
+func UnpackMessengerUpdatedEvent(log types.Log) (*MessageBusMessengerUpdated, error) {
+	event := new(MessageBusMessengerUpdated)
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsedMessageBusABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "MessengerUpdated", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// OwnershipTransferredEventID is the topic of the OwnershipTransferred(address,address) event
+var OwnershipTransferredEventID = gethcommon.HexToHash("0x8be0079c531659141344cd1fd0a4f28419497f9722a3daafe3b4186f6b6457e0")
+
+// OwnershipTransferredIsLifecycle is false: on TEN, the OwnershipTransferred events are only visible to the user accounts in their
+// indexed previousOwner, newOwner fields, or to everyone if none of them holds a user account.
+const OwnershipTransferredIsLifecycle = false
+
+// UnpackOwnershipTransferredEvent unpacks a OwnershipTransferred(address,address) log
+func UnpackOwnershipTransferredEvent(log types.Log) (*MessageBusOwnershipTransferred, error) {
+	event := new(MessageBusOwnershipTransferred)
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsedMessageBusABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "OwnershipTransferred", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// ValueTransferEventID is the topic of the ValueTransfer(address,address,uint256) event
+var ValueTransferEventID = gethcommon.HexToHash("0xf1365f826a788d6c1a955db0eed5ba8642674219c4771f8c65918617511a1560")
+
+// ValueTransferIsLifecycle is true: on TEN, the ValueTransfer events have no indexed address field, so they are lifecycle
+// events visible to everyone.
+const ValueTransferIsLifecycle = true
+
+// UnpackValueTransferEvent unpacks a ValueTransfer(address,address,uint256) log
+func UnpackValueTransferEvent(log types.Log) (*MessageBusValueTransfer, error) {
+	event := new(MessageBusValueTransfer)
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsedMessageBusABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "ValueTransfer", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
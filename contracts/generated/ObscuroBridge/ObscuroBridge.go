@@ -29,10 +29,18 @@ var (
 	_ = abi.ConvertType
 )
 
+// ITokenRegistryTokenMapping is an auto generated low-level Go binding around an user-defined struct.
+type ITokenRegistryTokenMapping struct {
+	L1Token common.Address
+	L2Token common.Address
+	Name    string
+	Symbol  string
+}
+
 // ObscuroBridgeMetaData contains all meta data concerning the ObscuroBridge contract.
 var ObscuroBridgeMetaData = &bind.MetaData{
-	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"previousAdminRole\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"newAdminRole\",\"type\":\"bytes32\"}],\"name\":\"RoleAdminChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"RoleGranted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"RoleRevoked\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"ADMIN_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"DEFAULT_ADMIN_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"ERC20_TOKEN_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"NATIVE_TOKEN_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"messengerAddress\",\"type\":\"address\"}],\"name\":\"configure\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"}],\"name\":\"getRoleAdmin\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"grantRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"hasRole\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"messenger\",\"type\":\"address\"}],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newAdmin\",\"type\":\"address\"}],\"name\":\"promoteToAdmin\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"receiveAssets\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"}],\"name\":\"removeToken\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"renounceRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"revokeRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"sendERC20\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"sendNative\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"bridge\",\"type\":\"address\"}],\"name\":\"setRemoteBridge\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"interfaceId\",\"type\":\"bytes4\"}],\"name\":\"supportsInterface\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"symbol\",\"type\":\"string\"}],\"name\":\"whitelistToken\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
-	Bin: "0x60806040526001805463ffffffff60a01b1916905534801561002057600080fd5b50611cec806100306000396000f3fe6080604052600436106101445760003560e01c806375b238fc116100c0578063a217fddf11610074578063c4d66de811610059578063c4d66de8146103b4578063d547741f146103d4578063e4c3ebc7146103f457600080fd5b8063a217fddf1461037f578063a381c8e21461039457600080fd5b806383bece4d116100a557806383bece4d146102f957806391d148541461031957806393b374421461035f57600080fd5b806375b238fc146102a557806375cb2672146102d957600080fd5b80632f2ff15d11610117578063498d82ab116100fc578063498d82ab146102315780635d872970146102515780635fa7b5841461028557600080fd5b80632f2ff15d146101f157806336568abe1461021157600080fd5b806301ffc9a71461014957806316ce81491461017e5780631888d712146101a0578063248a9ca3146101b3575b600080fd5b34801561015557600080fd5b506101696101643660046117ee565b610428565b60405190151581526020015b60405180910390f35b34801561018a57600080fd5b5061019e610199366004611830565b610491565b005b61019e6101ae366004611830565b6104ec565b3480156101bf57600080fd5b506101e36101ce36600461184d565b60009081526002602052604090206001015490565b604051908152602001610175565b3480156101fd57600080fd5b5061019e61020c366004611866565b610625565b34801561021d57600080fd5b5061019e61022c366004611866565b610650565b34801561023d57600080fd5b5061019e61024c3660046118df565b6106dc565b34801561025d57600080fd5b506101e37f9f225881f6e7ac8a885b63aa2269cbce78dd6a669864ccd2cd2517a8e709d73a81565b34801561029157600080fd5b5061019e6102a0366004611830565b6107a1565b3480156102b157600080fd5b506101e37fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c2177581565b3480156102e557600080fd5b5061019e6102f4366004611830565b6107f6565b34801561030557600080fd5b5061019e610314366004611962565b610950565b34801561032557600080fd5b50610169610334366004611866565b60009182526002602090815260408084206001600160a01b0393909316845291905290205460ff1690565b34801561036b57600080fd5b5061019e61037a366004611830565b610b79565b34801561038b57600080fd5b506101e3600081565b3480156103a057600080fd5b5061019e6103af366004611962565b610bce565b3480156103c057600080fd5b5061019e6103cf366004611830565b610d7b565b3480156103e057600080fd5b5061019e6103ef366004611866565b610ea1565b34801561040057600080fd5b506101e37fd2fb17ceaa388942529b17e0006ffc4d559f040dd4f2157b8070f17ad211057881565b60006001600160e01b031982167f7965db0b00000000000000000000000000000000000000000000000000000000148061048b57507f01ffc9a7000000000000000000000000000000000000000000000000000000006001600160e01b03198316145b92915050565b7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c217756104bc8133610ec7565b506003805473ffffffffffffffffffffffffffffffffffffffff19166001600160a01b0392909216919091179055565b600034116105415760405162461bcd60e51b815260206004820152600f60248201527f456d707479207472616e736665722e000000000000000000000000000000000060448201526064015b60405180910390fd5b604080518082018252348082526001600160a01b03848116602093840190815284519384019290925290518116828401528251808303840181526060909201909252600354909161059991168260025b600080610f47565b6001546001600160a01b03166040517f346633fb0000000000000000000000000000000000000000000000000000000081526001600160a01b038481166004830152346024830181905292169163346633fb916044016000604051808303818588803b15801561060857600080fd5b505af115801561061c573d6000803e3d6000fd5b50505050505050565b6000828152600260205260409020600101546106418133610ec7565b61064b8383611062565b505050565b6001600160a01b03811633146106ce5760405162461bcd60e51b815260206004820152602f60248201527f416363657373436f6e74726f6c3a2063616e206f6e6c792072656e6f756e636560448201527f20726f6c657320666f722073656c6600000000000000000000000000000000006064820152608401610538565b6106d88282611104565b5050565b7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c217756107078133610ec7565b6107317f9f225881f6e7ac8a885b63aa2269cbce78dd6a669864ccd2cd2517a8e709d73a87611062565b600063458ffd6360e01b87878787876040516024016107549594939291906119cd565b60408051601f198184030181529190526020810180516001600160e01b03166001600160e01b03199093169290921790915260035490915061061c906001600160a01b0316826001610591565b7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c217756107cc8133610ec7565b6106d87f9f225881f6e7ac8a885b63aa2269cbce78dd6a669864ccd2cd2517a8e709d73a83611104565b600054610100900460ff166108735760405162461bcd60e51b815260206004820152602b60248201527f496e697469616c697a61626c653a20636f6e7472616374206973206e6f74206960448201527f6e697469616c697a696e670000000000000000000000000000000000000000006064820152608401610538565b80600060026101000a8154816001600160a01b0302191690836001600160a01b03160217905550600060029054906101000a90046001600160a01b03166001600160a01b031663a1a227fa6040518163ffffffff1660e01b815260040160206040518083038186803b1580156108e857600080fd5b505afa1580156108fc573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906109209190611a0f565b6001805473ffffffffffffffffffffffffffffffffffffffff19166001600160a01b039290921691909117905550565b6003546000546001600160a01b0391821691620100009091041633146109de5760405162461bcd60e51b815260206004820152603060248201527f436f6e74726163742063616c6c6572206973206e6f742074686520726567697360448201527f7465726564206d657373656e67657221000000000000000000000000000000006064820152608401610538565b806001600160a01b03166109f0611187565b6001600160a01b031614610a6c5760405162461bcd60e51b815260206004820152603160248201527f43726f737320636861696e206d65737361676520636f6d696e672066726f6d2060448201527f696e636f72726563742073656e646572210000000000000000000000000000006064820152608401610538565b6001600160a01b03841660009081527f32ef73018533fa188e9e42b313c0a4048c6052342b662fb7510c0d1abcea3413602052604090205460ff1615610abc57610ab7848484611213565b610b73565b6001600160a01b03841660009081527f13ad2d85210d477fe1a6e25654c8250308cf29b050a4bf0b039d70467486712c602052604090205460ff1615610b0557610ab78261121e565b60405162461bcd60e51b815260206004820152602560248201527f417474656d7074696e6720746f20776974686472617720756e6b6e6f776e206160448201527f737365742e0000000000000000000000000000000000000000000000000000006064820152608401610538565b50505050565b7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c21775610ba48133610ec7565b6106d87fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c2177583611062565b60008211610c1e5760405162461bcd60e51b815260206004820152601a60248201527f417474656d7074696e6720656d707479207472616e736665722e0000000000006044820152606401610538565b6001600160a01b03831660009081527f32ef73018533fa188e9e42b313c0a4048c6052342b662fb7510c0d1abcea3413602052604090205460ff16610cf15760405162461bcd60e51b815260206004820152604e60248201527f54686973206164647265737320686173206e6f74206265656e20676976656e2060448201527f61207479706520616e64206973207468757320636f6e73696465726564206e6f60648201527f742077686974656c69737465642e000000000000000000000000000000000000608482015260a401610538565b610cfd833330856112bc565b604080516001600160a01b038581166024830152604482018590528381166064808401919091528351808403909101815260849092019092526020810180516001600160e01b03167f83bece4d000000000000000000000000000000000000000000000000000000001790526003549091610b739116826000610591565b600054610100900460ff16610d965760005460ff1615610d9a565b303b155b610e0c5760405162461bcd60e51b815260206004820152602e60248201527f496e697469616c697a61626c653a20636f6e747261637420697320616c72656160448201527f647920696e697469616c697a65640000000000000000000000000000000000006064820152608401610538565b600054610100900460ff16158015610e2e576000805461ffff19166101011790555b610e37826107f6565b610e617fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c2177533611062565b610e8c7fd2fb17ceaa388942529b17e0006ffc4d559f040dd4f2157b8070f17ad21105786000611062565b80156106d8576000805461ff00191690555050565b600082815260026020526040902060010154610ebd8133610ec7565b61064b8383611104565b60008281526002602090815260408083206001600160a01b038516845290915290205460ff166106d857610f05816001600160a01b03166014611340565b610f10836020611340565b604051602001610f21929190611a58565b60408051601f198184030181529082905262461bcd60e51b825261053891600401611b05565b60006040518060600160405280876001600160a01b0316815260200186815260200184815250604051602001610f7d9190611b18565b60408051808303601f19018152919052600180549192506001600160a01b0382169163b1454caa917401000000000000000000000000000000000000000090910463ffffffff16906014610fd083611b73565b91906101000a81548163ffffffff021916908363ffffffff1602179055508684866040518563ffffffff1660e01b81526004016110109493929190611b97565b602060405180830381600087803b15801561102a57600080fd5b505af115801561103e573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019061061c9190611bd4565b60008281526002602090815260408083206001600160a01b038516845290915290205460ff166106d85760008281526002602090815260408083206001600160a01b03851684529091529020805460ff191660011790556110c03390565b6001600160a01b0316816001600160a01b0316837f2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d60405160405180910390a45050565b60008281526002602090815260408083206001600160a01b038516845290915290205460ff16156106d85760008281526002602090815260408083206001600160a01b0385168085529252808320805460ff1916905551339285917ff6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b9190a45050565b60008060029054906101000a90046001600160a01b03166001600160a01b03166363012de56040518163ffffffff1660e01b815260040160206040518083038186803b1580156111d657600080fd5b505afa1580156111ea573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019061120e9190611a0f565b905090565b61064b838284611528565b6040516000906001600160a01b038316908281818181865af19150503d8060008114611266576040519150601f19603f3d011682016040523d82523d6000602084013e61126b565b606091505b50509050806106d85760405162461bcd60e51b815260206004820152601460248201527f4661696c656420746f2073656e642045746865720000000000000000000000006044820152606401610538565b6040516001600160a01b0380851660248301528316604482015260648101829052610b739085907f23b872dd00000000000000000000000000000000000000000000000000000000906084015b60408051601f198184030181529190526020810180516001600160e01b03166001600160e01b031990931692909217909152611571565b6060600061134f836002611bfe565b61135a906002611c1d565b67ffffffffffffffff81111561137257611372611c35565b6040519080825280601f01601f19166020018201604052801561139c576020820181803683370190505b5090507f3000000000000000000000000000000000000000000000000000000000000000816000815181106113d3576113d3611c4b565b60200101906001600160f81b031916908160001a9053507f78000000000000000000000000000000000000000000000000000000000000008160018151811061141e5761141e611c4b565b60200101906001600160f81b031916908160001a9053506000611442846002611bfe565b61144d906001611c1d565b90505b60018111156114d2577f303132333435363738396162636465660000000000000000000000000000000085600f166010811061148e5761148e611c4b565b1a60f81b8282815181106114a4576114a4611c4b565b60200101906001600160f81b031916908160001a90535060049490941c936114cb81611c61565b9050611450565b5083156115215760405162461bcd60e51b815260206004820181905260248201527f537472696e67733a20686578206c656e67746820696e73756666696369656e746044820152606401610538565b9392505050565b6040516001600160a01b03831660248201526044810182905261064b9084907fa9059cbb0000000000000000000000000000000000000000000000000000000090606401611309565b60006115c6826040518060400160405280602081526020017f5361666545524332303a206c6f772d6c6576656c2063616c6c206661696c6564815250856001600160a01b03166116569092919063ffffffff16565b80519091501561064b57808060200190518101906115e49190611c78565b61064b5760405162461bcd60e51b815260206004820152602a60248201527f5361666545524332303a204552433230206f7065726174696f6e20646964206e60448201527f6f742073756363656564000000000000000000000000000000000000000000006064820152608401610538565b6060611665848460008561166d565b949350505050565b6060824710156116e55760405162461bcd60e51b815260206004820152602660248201527f416464726573733a20696e73756666696369656e742062616c616e636520666f60448201527f722063616c6c00000000000000000000000000000000000000000000000000006064820152608401610538565b6001600160a01b0385163b61173c5760405162461bcd60e51b815260206004820152601d60248201527f416464726573733a2063616c6c20746f206e6f6e2d636f6e74726163740000006044820152606401610538565b600080866001600160a01b031685876040516117589190611c9a565b60006040518083038185875af1925050503d8060008114611795576040519150601f19603f3d011682016040523d82523d6000602084013e61179a565b606091505b50915091506117aa8282866117b5565b979650505050505050565b606083156117c4575081611521565b8251156117d45782518084602001fd5b8160405162461bcd60e51b81526004016105389190611b05565b60006020828403121561180057600080fd5b81356001600160e01b03198116811461152157600080fd5b6001600160a01b038116811461182d57600080fd5b50565b60006020828403121561184257600080fd5b813561152181611818565b60006020828403121561185f57600080fd5b5035919050565b6000806040838503121561187957600080fd5b82359150602083013561188b81611818565b809150509250929050565b60008083601f8401126118a857600080fd5b50813567ffffffffffffffff8111156118c057600080fd5b6020830191508360208285010111156118d857600080fd5b9250929050565b6000806000806000606086880312156118f757600080fd5b853561190281611818565b9450602086013567ffffffffffffffff8082111561191f57600080fd5b61192b89838a01611896565b9096509450604088013591508082111561194457600080fd5b5061195188828901611896565b969995985093965092949392505050565b60008060006060848603121561197757600080fd5b833561198281611818565b925060208401359150604084013561199981611818565b809150509250925092565b81835281816020850137506000828201602090810191909152601f909101601f19169091010190565b6001600160a01b03861681526060602082015260006119f06060830186886119a4565b8281036040840152611a038185876119a4565b98975050505050505050565b600060208284031215611a2157600080fd5b815161152181611818565b60005b83811015611a47578181015183820152602001611a2f565b83811115610b735750506000910152565b7f416363657373436f6e74726f6c3a206163636f756e7420000000000000000000815260008351611a90816017850160208801611a2c565b7f206973206d697373696e6720726f6c65200000000000000000000000000000006017918401918201528351611acd816028840160208801611a2c565b01602801949350505050565b60008151808452611af1816020860160208601611a2c565b601f01601f19169290920160200192915050565b6020815260006115216020830184611ad9565b602081526001600160a01b0382511660208201526000602083015160606040840152611b476080840182611ad9565b9050604084015160608401528091505092915050565b634e487b7160e01b600052601160045260246000fd5b600063ffffffff80831681811415611b8d57611b8d611b5d565b6001019392505050565b600063ffffffff808716835280861660208401525060806040830152611bc06080830185611ad9565b905060ff8316606083015295945050505050565b600060208284031215611be657600080fd5b815167ffffffffffffffff8116811461152157600080fd5b6000816000190483118215151615611c1857611c18611b5d565b500290565b60008219821115611c3057611c30611b5d565b500190565b634e487b7160e01b600052604160045260246000fd5b634e487b7160e01b600052603260045260246000fd5b600081611c7057611c70611b5d565b506000190190565b600060208284031215611c8a57600080fd5b8151801515811461152157600080fd5b60008251611cac818460208701611a2c565b919091019291505056fea2646970667358221220ff3d89bdd2a8c139f63057a849b2b635520784cd22e91cfd13360119ac56ca6e64736f6c63430008090033",
+	ABI: "[{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"previousAdminRole\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"newAdminRole\",\"type\":\"bytes32\"}],\"name\":\"RoleAdminChanged\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"RoleGranted\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"sender\",\"type\":\"address\"}],\"name\":\"RoleRevoked\",\"type\":\"event\"},{\"anonymous\":false,\"inputs\":[{\"indexed\":true,\"internalType\":\"address\",\"name\":\"l1Token\",\"type\":\"address\"},{\"indexed\":true,\"internalType\":\"address\",\"name\":\"l2Token\",\"type\":\"address\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"indexed\":false,\"internalType\":\"string\",\"name\":\"symbol\",\"type\":\"string\"}],\"name\":\"TokenRegistered\",\"type\":\"event\"},{\"inputs\":[],\"name\":\"ADMIN_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"DEFAULT_ADMIN_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"ERC20_TOKEN_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"NATIVE_TOKEN_ROLE\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"autoRegistration\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"messengerAddress\",\"type\":\"address\"}],\"name\":\"configure\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"}],\"name\":\"getRoleAdmin\",\"outputs\":[{\"internalType\":\"bytes32\",\"name\":\"\",\"type\":\"bytes32\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"grantRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"hasRole\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"messenger\",\"type\":\"address\"}],\"name\":\"initialize\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"l2Token\",\"type\":\"address\"}],\"name\":\"l1TokenOf\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"l1Token\",\"type\":\"address\"}],\"name\":\"l2TokenOf\",\"outputs\":[{\"internalType\":\"address\",\"name\":\"\",\"type\":\"address\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"newAdmin\",\"type\":\"address\"}],\"name\":\"promoteToAdmin\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"receiveAssets\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"}],\"name\":\"removeToken\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"renounceRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes32\",\"name\":\"role\",\"type\":\"bytes32\"},{\"internalType\":\"address\",\"name\":\"account\",\"type\":\"address\"}],\"name\":\"revokeRole\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"uint256\",\"name\":\"amount\",\"type\":\"uint256\"},{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"sendERC20\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"receiver\",\"type\":\"address\"}],\"name\":\"sendNative\",\"outputs\":[],\"stateMutability\":\"payable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bool\",\"name\":\"enabled\",\"type\":\"bool\"}],\"name\":\"setAutoRegistration\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"bridge\",\"type\":\"address\"}],\"name\":\"setRemoteBridge\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"bytes4\",\"name\":\"interfaceId\",\"type\":\"bytes4\"}],\"name\":\"supportsInterface\",\"outputs\":[{\"internalType\":\"bool\",\"name\":\"\",\"type\":\"bool\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"uint256\",\"name\":\"offset\",\"type\":\"uint256\"},{\"internalType\":\"uint256\",\"name\":\"limit\",\"type\":\"uint256\"}],\"name\":\"tokenMappings\",\"outputs\":[{\"components\":[{\"internalType\":\"address\",\"name\":\"l1Token\",\"type\":\"address\"},{\"internalType\":\"address\",\"name\":\"l2Token\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"symbol\",\"type\":\"string\"}],\"internalType\":\"structITokenRegistry.TokenMapping[]\",\"name\":\"\",\"type\":\"tuple[]\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[],\"name\":\"tokenMappingsCount\",\"outputs\":[{\"internalType\":\"uint256\",\"name\":\"\",\"type\":\"uint256\"}],\"stateMutability\":\"view\",\"type\":\"function\"},{\"inputs\":[{\"internalType\":\"address\",\"name\":\"asset\",\"type\":\"address\"},{\"internalType\":\"string\",\"name\":\"name\",\"type\":\"string\"},{\"internalType\":\"string\",\"name\":\"symbol\",\"type\":\"string\"}],\"name\":\"whitelistToken\",\"outputs\":[],\"stateMutability\":\"nonpayable\",\"type\":\"function\"}]",
+	Bin: "0x60806040526001805463ffffffff60a01b1916905534801561002057600080fd5b50614530806100306000396000f3fe608060405260043610620001b75760003560e01c806375cb267211620000f3578063a381c8e21162000095578063c4d66de8116200006c578063c4d66de81462000572578063d547741f1462000597578063e23120f914620005bc578063e4c3ebc714620005d857600080fd5b8063a381c8e214620004e8578063aa1ddcca146200050d578063c098a8c0146200053257600080fd5b806393b3744211620000ca57806393b3744214620004955780639634447014620004ba578063a217fddf14620004d157600080fd5b806375cb2672146200040157806383bece4d146200042657806391d14854146200044b57600080fd5b806336568abe116200015d5780635d87297011620001345780635d87297014620003705780635fa7b58414620003a657806375b238fc14620003cb57600080fd5b806336568abe14620002d0578063498d82ab14620002f55780635c4994b1146200031a57600080fd5b8063248a9ca31162000192578063248a9ca314620002345780632f2ff15d14620002775780632fb7ef4a146200029c57600080fd5b806301ffc9a714620001bc57806316ce814914620001f65780631888d712146200021d575b600080fd5b348015620001c957600080fd5b50620001e1620001db366004620022b8565b6200060e565b60405190151581526020015b60405180910390f35b3480156200020357600080fd5b506200021b62000215366004620022fd565b62000678565b005b6200021b6200022e366004620022fd565b620006d5565b3480156200024157600080fd5b5062000268620002533660046200231d565b60009081526002602052604090206001015490565b604051908152602001620001ed565b3480156200028457600080fd5b506200021b6200029636600462002337565b62000813565b348015620002a957600080fd5b50620002c1620002bb3660046200236a565b62000842565b604051620001ed9190620023e1565b348015620002dd57600080fd5b506200021b620002ef36600462002337565b62000b2d565b3480156200030257600080fd5b506200021b62000314366004620024dc565b62000bbd565b3480156200032757600080fd5b506200035762000339366004620022fd565b6001600160a01b039081166000908152600660205260409020541690565b6040516001600160a01b039091168152602001620001ed565b3480156200037d57600080fd5b50620002687f9f225881f6e7ac8a885b63aa2269cbce78dd6a669864ccd2cd2517a8e709d73a81565b348015620003b357600080fd5b506200021b620003c5366004620022fd565b62000cb4565b348015620003d857600080fd5b50620002687fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c2177581565b3480156200040e57600080fd5b506200021b62000420366004620022fd565b62000d0d565b3480156200043357600080fd5b506200021b6200044536600462002568565b62000e5d565b3480156200045857600080fd5b50620001e16200046a36600462002337565b60009182526002602090815260408084206001600160a01b0393909316845291905290205460ff1690565b348015620004a257600080fd5b506200021b620004b4366004620022fd565b62001094565b348015620004c757600080fd5b5060045462000268565b348015620004de57600080fd5b5062000268600081565b348015620004f557600080fd5b506200021b6200050736600462002568565b620010ed565b3480156200051a57600080fd5b506200021b6200052c366004620025be565b620012de565b3480156200053f57600080fd5b506200035762000551366004620022fd565b6001600160a01b039081166000908152600560205260409020600101541690565b3480156200057f57600080fd5b506200021b62000591366004620022fd565b6200131f565b348015620005a457600080fd5b506200021b620005b636600462002337565b62001451565b348015620005c957600080fd5b50600754620001e19060ff1681565b348015620005e557600080fd5b50620002687fd2fb17ceaa388942529b17e0006ffc4d559f040dd4f2157b8070f17ad211057881565b60006001600160e01b031982167f7965db0b0000000000000000000000000000000000000000000000000000000014806200067257507f01ffc9a7000000000000000000000000000000000000000000000000000000006001600160e01b03198316145b92915050565b7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c21775620006a581336200147b565b506003805473ffffffffffffffffffffffffffffffffffffffff19166001600160a01b0392909216919091179055565b600034116200072b5760405162461bcd60e51b815260206004820152600f60248201527f456d707479207472616e736665722e000000000000000000000000000000000060448201526064015b60405180910390fd5b604080518082018252348082526001600160a01b0384811660209384019081528451938401929092529051811682840152825180830384018152606090920190925260035490916200078591168260025b60008062001504565b6001546001600160a01b03166040517f346633fb0000000000000000000000000000000000000000000000000000000081526001600160a01b038481166004830152346024830181905292169163346633fb916044016000604051808303818588803b158015620007f557600080fd5b505af11580156200080a573d6000803e3d6000fd5b50505050505050565b6000828152600260205260409020600101546200083181336200147b565b6200083d838362001619565b505050565b6004546060908310620008a65760408051600080825260208201909252906200089d565b6040805160808101825260008082526020820152606091810182905281810191909152815260200190600190039081620008665790505b50905062000672565b600454600090620008b99085906200260a565b905082811115620008c75750815b60008167ffffffffffffffff811115620008e557620008e5620025de565b6040519080825280602002602001820160405280156200093b57816020015b6040805160808101825260008082526020820152606091810182905281810191909152815260200190600190039081620009045790505b50905060005b8281101562000b24576005600060046200095c848a62002620565b815481106200096f576200096f62002636565b60009182526020808320909101546001600160a01b03908116845283820194909452604092830190912082516080810184528154851681526001820154909416918401919091526002810180549192840191620009cc906200264c565b80601f0160208091040260200160405190810160405280929190818152602001828054620009fa906200264c565b801562000a4b5780601f1062000a1f5761010080835404028352916020019162000a4b565b820191906000526020600020905b81548152906001019060200180831162000a2d57829003601f168201915b5050505050815260200160038201805462000a66906200264c565b80601f016020809104026020016040519081016040528092919081815260200182805462000a94906200264c565b801562000ae55780601f1062000ab95761010080835404028352916020019162000ae5565b820191906000526020600020905b81548152906001019060200180831162000ac757829003601f168201915b50505050508152505082828151811062000b035762000b0362002636565b6020026020010181905250808062000b1b9062002688565b91505062000941565b50949350505050565b6001600160a01b038116331462000bad5760405162461bcd60e51b815260206004820152602f60248201527f416363657373436f6e74726f6c3a2063616e206f6e6c792072656e6f756e636560448201527f20726f6c657320666f722073656c660000000000000000000000000000000000606482015260840162000722565b62000bb98282620016bd565b5050565b7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c2177562000bea81336200147b565b62000c167f9f225881f6e7ac8a885b63aa2269cbce78dd6a669864ccd2cd2517a8e709d73a8762001619565b6001600160a01b038087166000908152600560205260409020541662000cac5762000cac8686868080601f01602080910402602001604051908101604052809392919081815260200183838082843760009201919091525050604080516020601f8a0181900481028201810190925288815292508891508790819084018382808284376000920191909152506200174192505050565b505050505050565b7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c2177562000ce181336200147b565b62000bb97f9f225881f6e7ac8a885b63aa2269cbce78dd6a669864ccd2cd2517a8e709d73a83620016bd565b600054610100900460ff1662000d8c5760405162461bcd60e51b815260206004820152602b60248201527f496e697469616c697a61626c653a20636f6e7472616374206973206e6f74206960448201527f6e697469616c697a696e67000000000000000000000000000000000000000000606482015260840162000722565b80600060026101000a8154816001600160a01b0302191690836001600160a01b03160217905550600060029054906101000a90046001600160a01b03166001600160a01b031663a1a227fa6040518163ffffffff1660e01b8152600401602060405180830381865afa15801562000e07573d6000803e3d6000fd5b505050506040513d601f19601f8201168201806040525081019062000e2d9190620026a4565b6001805473ffffffffffffffffffffffffffffffffffffffff19166001600160a01b039290921691909117905550565b6003546000546001600160a01b03918216916201000090910416331462000eed5760405162461bcd60e51b815260206004820152603060248201527f436f6e74726163742063616c6c6572206973206e6f742074686520726567697360448201527f7465726564206d657373656e6765722100000000000000000000000000000000606482015260840162000722565b806001600160a01b031662000f0162001957565b6001600160a01b03161462000f7f5760405162461bcd60e51b815260206004820152603160248201527f43726f737320636861696e206d65737361676520636f6d696e672066726f6d2060448201527f696e636f72726563742073656e64657221000000000000000000000000000000606482015260840162000722565b6001600160a01b03841660009081527f32ef73018533fa188e9e42b313c0a4048c6052342b662fb7510c0d1abcea3413602052604090205460ff161562000fd35762000fcd848484620019d7565b6200108e565b6001600160a01b03841660009081527f13ad2d85210d477fe1a6e25654c8250308cf29b050a4bf0b039d70467486712c602052604090205460ff16156200101f5762000fcd82620019e4565b60405162461bcd60e51b815260206004820152602560248201527f417474656d7074696e6720746f20776974686472617720756e6b6e6f776e206160448201527f737365742e000000000000000000000000000000000000000000000000000000606482015260840162000722565b50505050565b7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c21775620010c181336200147b565b62000bb97fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c217758362001619565b600082116200113f5760405162461bcd60e51b815260206004820152601a60248201527f417474656d7074696e6720656d707479207472616e736665722e000000000000604482015260640162000722565b60075460ff1680156200116a57506001600160a01b0383811660009081526005602052604090205416155b156200117b576200117b8362001a86565b6001600160a01b03831660009081527f32ef73018533fa188e9e42b313c0a4048c6052342b662fb7510c0d1abcea3413602052604090205460ff16620012505760405162461bcd60e51b815260206004820152604e60248201527f54686973206164647265737320686173206e6f74206265656e20676976656e2060448201527f61207479706520616e64206973207468757320636f6e73696465726564206e6f60648201527f742077686974656c69737465642e000000000000000000000000000000000000608482015260a40162000722565b6200125e8333308562001c4e565b604080516001600160a01b038581166024830152604482018590528381166064808401919091528351808403909101815260849092019092526020810180516001600160e01b03167f83bece4d0000000000000000000000000000000000000000000000000000000017905260035490916200108e91168260006200077c565b7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c217756200130b81336200147b565b506007805460ff1916911515919091179055565b600054610100900460ff166200133c5760005460ff161562001340565b303b155b620013b45760405162461bcd60e51b815260206004820152602e60248201527f496e697469616c697a61626c653a20636f6e747261637420697320616c72656160448201527f647920696e697469616c697a6564000000000000000000000000000000000000606482015260840162000722565b600054610100900460ff16158015620013d7576000805461ffff19166101011790555b620013e28262000d0d565b6200140e7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c217753362001619565b6200143b7fd2fb17ceaa388942529b17e0006ffc4d559f040dd4f2157b8070f17ad2110578600062001619565b801562000bb9576000805461ff00191690555050565b6000828152600260205260409020600101546200146f81336200147b565b6200083d8383620016bd565b60008281526002602090815260408083206001600160a01b038516845290915290205460ff1662000bb957620014bc816001600160a01b0316601462001cd4565b620014c983602062001cd4565b604051602001620014dc929190620026c4565b60408051601f198184030181529082905262461bcd60e51b8252620007229160040162002749565b60006040518060600160405280876001600160a01b03168152602001868152602001848152506040516020016200153c91906200275e565b60408051808303601f19018152919052600180549192506001600160a01b0382169163b1454caa917401000000000000000000000000000000000000000090910463ffffffff169060146200159183620027a5565b91906101000a81548163ffffffff021916908363ffffffff1602179055508684866040518563ffffffff1660e01b8152600401620015d39493929190620027cb565b6020604051808303816000875af1158015620015f3573d6000803e3d6000fd5b505050506040513d601f19601f820116820180604052508101906200080a91906200280a565b60008281526002602090815260408083206001600160a01b038516845290915290205460ff1662000bb95760008281526002602090815260408083206001600160a01b03851684529091529020805460ff19166001179055620016793390565b6001600160a01b0316816001600160a01b0316837f2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d60405160405180910390a45050565b60008281526002602090815260408083206001600160a01b038516845290915290205460ff161562000bb95760008281526002602090815260408083206001600160a01b0385168085529252808320805460ff1916905551339285917ff6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b9190a45050565b6003546000906200175e906001600160a01b031685858562001eda565b6004805460018082019092557f8a35acfbc15ff81a39ae7d344fd709f28e8600b4aa8c65c6b64bfe7fe36bd19b01805473ffffffffffffffffffffffffffffffffffffffff199081166001600160a01b038981169182179093556040805160808101825282815286851660208083019182528284018c8152606084018c90526000958652600590915292909320815181548616908716178155925195830180549094169590941694909417909155915192935091600282019062001823908262002880565b50606082015160038201906200183a908262002880565b5050506001600160a01b03818116600090815260066020526040808220805473ffffffffffffffffffffffffffffffffffffffff19169388169390931790925590517f458ffd630000000000000000000000000000000000000000000000000000000090620018b2908790879087906024016200294d565b60408051601f198184030181529190526020810180516001600160e01b03166001600160e01b03199093169290921790915260035490915062001901906001600160a01b03168260016200077c565b816001600160a01b0316856001600160a01b03167ffbe16c292428cdc3e66e97f954e91cc4f49b472d24989df1b2cdc27f295a70678686604051620019489291906200298f565b60405180910390a35050505050565b60008060029054906101000a90046001600160a01b03166001600160a01b03166363012de56040518163ffffffff1660e01b8152600401602060405180830381865afa158015620019ac573d6000803e3d6000fd5b505050506040513d601f19601f82011682018060405250810190620019d29190620026a4565b905090565b6200083d83828462001fca565b6040516000906001600160a01b038316908281818181865af19150503d806000811462001a2e576040519150601f19603f3d011682016040523d82523d6000602084013e62001a33565b606091505b505090508062000bb95760405162461bcd60e51b815260206004820152601460248201527f4661696c656420746f2073656e64204574686572000000000000000000000000604482015260640162000722565b606080826001600160a01b03166306fdde036040518163ffffffff1660e01b8152600401600060405180830381865afa92505050801562001aeb57506040513d6000823e601f3d908101601f1916820160405262001ae89190810190620029c1565b60015b62001b4d5760405162461bcd60e51b815260206004820152602b60248201527f546f6b656e20776974686f7574206d65746164617461206d757374206265207760448201526a3434ba32b634b9ba32b21760a91b606482015260840162000722565b9150826001600160a01b03166395d89b416040518163ffffffff1660e01b8152600401600060405180830381865afa92505050801562001bb157506040513d6000823e601f3d908101601f1916820160405262001bae9190810190620029c1565b60015b62001c135760405162461bcd60e51b815260206004820152602b60248201527f546f6b656e20776974686f7574206d65746164617461206d757374206265207760448201526a3434ba32b634b9ba32b21760a91b606482015260840162000722565b905062001c417f9f225881f6e7ac8a885b63aa2269cbce78dd6a669864ccd2cd2517a8e709d73a8462001619565b6200083d83838362001741565b6040516001600160a01b03808516602483015283166044820152606481018290526200108e9085907f23b872dd00000000000000000000000000000000000000000000000000000000906084015b60408051601f198184030181529190526020810180516001600160e01b03166001600160e01b03199093169290921790915262002015565b6060600062001ce583600262002a6f565b62001cf290600262002620565b67ffffffffffffffff81111562001d0d5762001d0d620025de565b6040519080825280601f01601f19166020018201604052801562001d38576020820181803683370190505b5090507f30000000000000000000000000000000000000000000000000000000000000008160008151811062001d725762001d7262002636565b60200101906001600160f81b031916908160001a9053507f78000000000000000000000000000000000000000000000000000000000000008160018151811062001dc05762001dc062002636565b60200101906001600160f81b031916908160001a905350600062001de684600262002a6f565b62001df390600162002620565b90505b600181111562001e82577f303132333435363738396162636465660000000000000000000000000000000085600f166010811062001e385762001e3862002636565b1a60f81b82828151811062001e515762001e5162002636565b60200101906001600160f81b031916908160001a90535060049490941c9362001e7a8162002a89565b905062001df6565b50831562001ed35760405162461bcd60e51b815260206004820181905260248201527f537472696e67733a20686578206c656e67746820696e73756666696369656e74604482015260640162000722565b9392505050565b6000806040518060200162001eef90620022aa565b601f1982820381018352601f90910116604081905262001f1690869086906020016200298f565b60408051601f198184030181529082905262001f36929160200162002aa3565b60408051601f1981840301815282825280516020918201207fff000000000000000000000000000000000000000000000000000000000000008285015260609990991b6bffffffffffffffffffffffff191660218401526001600160a01b03979097166035830152605580830198909852805180830390980188526075909101905250508351939092019290922092915050565b6040516001600160a01b0383166024820152604481018290526200083d9084907fa9059cbb000000000000000000000000000000000000000000000000000000009060640162001c9c565b60006200206c826040518060400160405280602081526020017f5361666545524332303a206c6f772d6c6576656c2063616c6c206661696c6564815250856001600160a01b0316620021019092919063ffffffff16565b8051909150156200083d57808060200190518101906200208d919062002ad6565b6200083d5760405162461bcd60e51b815260206004820152602a60248201527f5361666545524332303a204552433230206f7065726174696f6e20646964206e60448201527f6f74207375636365656400000000000000000000000000000000000000000000606482015260840162000722565b60606200211284846000856200211a565b949350505050565b606082471015620021945760405162461bcd60e51b815260206004820152602660248201527f416464726573733a20696e73756666696369656e742062616c616e636520666f60448201527f722063616c6c0000000000000000000000000000000000000000000000000000606482015260840162000722565b6001600160a01b0385163b620021ed5760405162461bcd60e51b815260206004820152601d60248201527f416464726573733a2063616c6c20746f206e6f6e2d636f6e7472616374000000604482015260640162000722565b600080866001600160a01b031685876040516200220b919062002af6565b60006040518083038185875af1925050503d80600081146200224a576040519150601f19603f3d011682016040523d82523d6000602084013e6200224f565b606091505b5091509150620022618282866200226c565b979650505050505050565b606083156200227d57508162001ed3565b8251156200228e5782518084602001fd5b8160405162461bcd60e51b815260040162000722919062002749565b6119e68062002b1583390190565b600060208284031215620022cb57600080fd5b81356001600160e01b03198116811462001ed357600080fd5b6001600160a01b0381168114620022fa57600080fd5b50565b6000602082840312156200231057600080fd5b813562001ed381620022e4565b6000602082840312156200233057600080fd5b5035919050565b600080604083850312156200234b57600080fd5b8235915060208301356200235f81620022e4565b809150509250929050565b600080604083850312156200237e57600080fd5b50508035926020909101359150565b60005b83811015620023aa57818101518382015260200162002390565b50506000910152565b60008151808452620023cd8160208601602086016200238d565b601f01601f19169290920160200192915050565b60006020808301818452808551808352604092508286019150828160051b87010184880160005b838110156200248257603f19898403018552815160806001600160a01b03808351168652808a840151168a870152508782015181898701526200244e82870182620023b3565b915050606080830151925085820381870152506200246d8183620023b3565b96890196945050509086019060010162002408565b509098975050505050505050565b60008083601f840112620024a357600080fd5b50813567ffffffffffffffff811115620024bc57600080fd5b602083019150836020828501011115620024d557600080fd5b9250929050565b600080600080600060608688031215620024f557600080fd5b85356200250281620022e4565b9450602086013567ffffffffffffffff808211156200252057600080fd5b6200252e89838a0162002490565b909650945060408801359150808211156200254857600080fd5b50620025578882890162002490565b969995985093965092949392505050565b6000806000606084860312156200257e57600080fd5b83356200258b81620022e4565b9250602084013591506040840135620025a481620022e4565b809150509250925092565b8015158114620022fa57600080fd5b600060208284031215620025d157600080fd5b813562001ed381620025af565b634e487b7160e01b600052604160045260246000fd5b634e487b7160e01b600052601160045260246000fd5b81810381811115620006725762000672620025f4565b80820180821115620006725762000672620025f4565b634e487b7160e01b600052603260045260246000fd5b600181811c908216806200266157607f821691505b6020821081036200268257634e487b7160e01b600052602260045260246000fd5b50919050565b6000600182016200269d576200269d620025f4565b5060010190565b600060208284031215620026b757600080fd5b815162001ed381620022e4565b7f416363657373436f6e74726f6c3a206163636f756e7420000000000000000000815260008351620026fe8160178501602088016200238d565b7f206973206d697373696e6720726f6c652000000000000000000000000000000060179184019182015283516200273d8160288401602088016200238d565b01602801949350505050565b60208152600062001ed36020830184620023b3565b602081526001600160a01b03825116602082015260006020830151606060408401526200278f6080840182620023b3565b9050604084015160608401528091505092915050565b600063ffffffff808316818103620027c157620027c1620025f4565b6001019392505050565b600063ffffffff808716835280861660208401525060806040830152620027f66080830185620023b3565b905060ff8316606083015295945050505050565b6000602082840312156200281d57600080fd5b815167ffffffffffffffff8116811462001ed357600080fd5b601f8211156200083d57600081815260208120601f850160051c810160208610156200285f5750805b601f850160051c820191505b8181101562000cac578281556001016200286b565b815167ffffffffffffffff8111156200289d576200289d620025de565b620028b581620028ae84546200264c565b8462002836565b602080601f831160018114620028ed5760008415620028d45750858301515b600019600386901b1c1916600185901b17855562000cac565b600085815260208120601f198616915b828110156200291e57888601518255948401946001909101908401620028fd565b50858210156200293d5787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b6001600160a01b0384168152606060208201526000620029716060830185620023b3565b8281036040840152620029858185620023b3565b9695505050505050565b604081526000620029a46040830185620023b3565b8281036020840152620029b88185620023b3565b95945050505050565b600060208284031215620029d457600080fd5b815167ffffffffffffffff80821115620029ed57600080fd5b818401915084601f83011262002a0257600080fd5b81518181111562002a175762002a17620025de565b604051601f8201601f19908116603f0116810190838211818310171562002a425762002a42620025de565b8160405282815287602084870101111562002a5c57600080fd5b620022618360208301602088016200238d565b8082028115828204841417620006725762000672620025f4565b60008162002a9b5762002a9b620025f4565b506000190190565b6000835162002ab78184602088016200238d565b83519083019062002acd8183602088016200238d565b01949350505050565b60006020828403121562002ae957600080fd5b815162001ed381620025af565b6000825162002b0a8184602087016200238d565b919091019291505056fe6080604052600580546001600160a01b03191673deb34a740eca1ec42c8b8204cbec0ba34fdd27f31790553480156200003757600080fd5b50604051620019e6380380620019e68339810160408190526200005a9162000224565b8181818160036200006c83826200031d565b5060046200007b82826200031d565b5050505050620000b27fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c2177533620000ba60201b60201c565b5050620003e9565b60008281526007602090815260408083206001600160a01b038516845290915290205460ff166200015b5760008281526007602090815260408083206001600160a01b03851684529091529020805460ff191660011790556200011a3390565b6001600160a01b0316816001600160a01b0316837f2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d60405160405180910390a45b5050565b634e487b7160e01b600052604160045260246000fd5b600082601f8301126200018757600080fd5b81516001600160401b0380821115620001a457620001a46200015f565b604051601f8301601f19908116603f01168101908282118183101715620001cf57620001cf6200015f565b81604052838152602092508683858801011115620001ec57600080fd5b600091505b83821015620002105785820183015181830184015290820190620001f1565b600093810190920192909252949350505050565b600080604083850312156200023857600080fd5b82516001600160401b03808211156200025057600080fd5b6200025e8683870162000175565b935060208501519150808211156200027557600080fd5b50620002848582860162000175565b9150509250929050565b600181811c90821680620002a357607f821691505b602082108103620002c457634e487b7160e01b600052602260045260246000fd5b50919050565b601f8211156200031857600081815260208120601f850160051c81016020861015620002f35750805b601f850160051c820191505b818110156200031457828155600101620002ff565b5050505b505050565b81516001600160401b038111156200033957620003396200015f565b62000351816200034a84546200028e565b84620002ca565b602080601f831160018114620003895760008415620003705750858301515b600019600386901b1c1916600185901b17855562000314565b600085815260208120601f198616915b82811015620003ba5788860151825594840194600190910190840162000399565b5085821015620003d95787850151600019600388901b60f8161c191681555b5050505050600190811b01905550565b6115ed80620003f96000396000f3fe608060405234801561001057600080fd5b50600436106101775760003560e01c806339509351116100d8578063979005ad1161008c578063a9059cbb11610066578063a9059cbb1461031a578063d547741f1461032d578063dd62ed3e1461034057600080fd5b8063979005ad146102ec578063a217fddf146102ff578063a457c2d71461030757600080fd5b806375b238fc116100bd57806375b238fc1461028457806391d14854146102ab57806395d89b41146102e457600080fd5b8063395093511461025e57806370a082311461027157600080fd5b806323b872dd1161012f5780632f2ff15d116101145780632f2ff15d14610229578063313ce5671461023c57806336568abe1461024b57600080fd5b806323b872dd146101f3578063248a9ca31461020657600080fd5b8063095ea7b311610160578063095ea7b3146101b957806318160ddd146101cc5780631dd319cb146101de57600080fd5b806301ffc9a71461017c57806306fdde03146101a4575b600080fd5b61018f61018a3660046112c1565b610353565b60405190151581526020015b60405180910390f35b6101ac6103ec565b60405161019b9190611327565b61018f6101c7366004611376565b61047e565b6002545b60405190815260200161019b565b6101f16101ec366004611376565b610496565b005b61018f6102013660046113a0565b61052d565b6101d06102143660046113dc565b60009081526007602052604090206001015490565b6101f16102373660046113f5565b610551565b6040516012815260200161019b565b6101f16102593660046113f5565b610577565b61018f61026c366004611376565b610603565b6101d061027f366004611421565b610625565b6101d07fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c2177581565b61018f6102b93660046113f5565b60009182526007602090815260408084206001600160a01b0393909316845291905290205460ff1690565b6101ac6106cb565b6101f16102fa366004611376565b6106da565b6101d0600081565b61018f610315366004611376565b61070f565b61018f610328366004611376565b6107a2565b6101f161033b3660046113f5565b6107b0565b6101d061034e36600461143c565b6107d6565b60007fffffffff0000000000000000000000000000000000000000000000000000000082167f7965db0b0000000000000000000000000000000000000000000000000000000014806103e657507f01ffc9a7000000000000000000000000000000000000000000000000000000007fffffffff000000000000000000000000000000000000000000000000000000008316145b92915050565b6060600380546103fb90611466565b80601f016020809104026020016040519081016040528092919081815260200182805461042790611466565b80156104745780601f1061044957610100808354040283529160200191610474565b820191906000526020600020905b81548152906001019060200180831161045757829003601f168201915b5050505050905090565b60003361048c8185856108e7565b5060019392505050565b7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c217756104c18133610a3f565b816104cb84610625565b101561051e5760405162461bcd60e51b815260206004820152601560248201527f496e73756666696369656e742062616c616e63652e000000000000000000000060448201526064015b60405180910390fd5b6105288383610abf565b505050565b60003361053b858285610c44565b610546858585610cbe565b506001949350505050565b60008281526007602052604090206001015461056d8133610a3f565b6105288383610ed5565b6001600160a01b03811633146105f55760405162461bcd60e51b815260206004820152602f60248201527f416363657373436f6e74726f6c3a2063616e206f6e6c792072656e6f756e636560448201527f20726f6c657320666f722073656c6600000000000000000000000000000000006064820152608401610515565b6105ff8282610f77565b5050565b60003361048c81858561061683836107d6565b61062091906114b6565b6108e7565b60006001600160a01b0382163203610655576001600160a01b0382166000908152602081905260409020546103e6565b6001600160a01b0382163303610683576001600160a01b0382166000908152602081905260409020546103e6565b60405162461bcd60e51b815260206004820152601f60248201527f4e6f7420616c6c6f77656420746f2072656164207468652062616c616e6365006044820152606401610515565b6060600480546103fb90611466565b7fa49807205ce4d355092ef5a8a18f56e8913cf4a201fbe287825b095693c217756107058133610a3f565b6105288383610ffa565b6000338161071d82866107d6565b9050838110156107955760405162461bcd60e51b815260206004820152602560248201527f45524332303a2064656372656173656420616c6c6f77616e63652062656c6f7760448201527f207a65726f0000000000000000000000000000000000000000000000000000006064820152608401610515565b61054682868684036108e7565b60003361048c818585610cbe565b6000828152600760205260409020600101546107cc8133610a3f565b6105288383610f77565b6000326001600160a01b03841614806107f75750326001600160a01b038316145b1561082a576001600160a01b038084166000908152600160209081526040808320938616835292905220545b90506103e6565b336001600160a01b03841614806108495750336001600160a01b038316145b15610879576001600160a01b03808416600090815260016020908152604080832093861683529290522054610823565b60405162461bcd60e51b815260206004820152602160248201527f4e6f7420616c6c6f77656420746f20726561642074686520616c6c6f77616e6360448201527f65000000000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b0383166109625760405162461bcd60e51b8152602060048201526024808201527f45524332303a20617070726f76652066726f6d20746865207a65726f2061646460448201527f72657373000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b0382166109de5760405162461bcd60e51b815260206004820152602260248201527f45524332303a20617070726f766520746f20746865207a65726f20616464726560448201527f73730000000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b0383811660008181526001602090815260408083209487168084529482529182902085905590518481527f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925910160405180910390a3505050565b60008281526007602090815260408083206001600160a01b038516845290915290205460ff166105ff57610a7d816001600160a01b031660146110d9565b610a888360206110d9565b604051602001610a999291906114c9565b60408051601f198184030181529082905262461bcd60e51b825261051591600401611327565b6001600160a01b038216610b3b5760405162461bcd60e51b815260206004820152602160248201527f45524332303a206275726e2066726f6d20746865207a65726f2061646472657360448201527f73000000000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b03821660009081526020819052604090205481811015610bca5760405162461bcd60e51b815260206004820152602260248201527f45524332303a206275726e20616d6f756e7420657863656564732062616c616e60448201527f63650000000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b0383166000908152602081905260408120838303905560028054849290610bf990849061154a565b90915550506040518281526000906001600160a01b038516907fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef9060200160405180910390a3505050565b6000610c5084846107d6565b90506000198114610cb85781811015610cab5760405162461bcd60e51b815260206004820152601d60248201527f45524332303a20696e73756666696369656e7420616c6c6f77616e63650000006044820152606401610515565b610cb884848484036108e7565b50505050565b6001600160a01b038316610d3a5760405162461bcd60e51b815260206004820152602560248201527f45524332303a207472616e736665722066726f6d20746865207a65726f20616460448201527f64726573730000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b038216610db65760405162461bcd60e51b815260206004820152602360248201527f45524332303a207472616e7366657220746f20746865207a65726f206164647260448201527f65737300000000000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b03831660009081526020819052604090205481811015610e455760405162461bcd60e51b815260206004820152602660248201527f45524332303a207472616e7366657220616d6f756e742065786365656473206260448201527f616c616e636500000000000000000000000000000000000000000000000000006064820152608401610515565b6001600160a01b03808516600090815260208190526040808220858503905591851681529081208054849290610e7c9084906114b6565b92505081905550826001600160a01b0316846001600160a01b03167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef84604051610ec891815260200190565b60405180910390a3610cb8565b60008281526007602090815260408083206001600160a01b038516845290915290205460ff166105ff5760008281526007602090815260408083206001600160a01b03851684529091529020805460ff19166001179055610f333390565b6001600160a01b0316816001600160a01b0316837f2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d60405160405180910390a45050565b60008281526007602090815260408083206001600160a01b038516845290915290205460ff16156105ff5760008281526007602090815260408083206001600160a01b0385168085529252808320805460ff1916905551339285917ff6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b9190a45050565b6001600160a01b0382166110505760405162461bcd60e51b815260206004820152601f60248201527f45524332303a206d696e7420746f20746865207a65726f2061646472657373006044820152606401610515565b806002600082825461106291906114b6565b90915550506001600160a01b0382166000908152602081905260408120805483929061108f9084906114b6565b90915550506040518181526001600160a01b038316906000907fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef9060200160405180910390a35050565b606060006110e883600261155d565b6110f39060026114b6565b67ffffffffffffffff81111561110b5761110b611574565b6040519080825280601f01601f191660200182016040528015611135576020820181803683370190505b5090507f30000000000000000000000000000000000000000000000000000000000000008160008151811061116c5761116c61158a565b60200101906001600160f81b031916908160001a9053507f7800000000000000000000000000000000000000000000000000000000000000816001815181106111b7576111b761158a565b60200101906001600160f81b031916908160001a90535060006111db84600261155d565b6111e69060016114b6565b90505b600181111561126b577f303132333435363738396162636465660000000000000000000000000000000085600f16601081106112275761122761158a565b1a60f81b82828151811061123d5761123d61158a565b60200101906001600160f81b031916908160001a90535060049490941c93611264816115a0565b90506111e9565b5083156112ba5760405162461bcd60e51b815260206004820181905260248201527f537472696e67733a20686578206c656e67746820696e73756666696369656e746044820152606401610515565b9392505050565b6000602082840312156112d357600080fd5b81357fffffffff00000000000000000000000000000000000000000000000000000000811681146112ba57600080fd5b60005b8381101561131e578181015183820152602001611306565b50506000910152565b6020815260008251806020840152611346816040850160208701611303565b601f01601f19169190910160400192915050565b80356001600160a01b038116811461137157600080fd5b919050565b6000806040838503121561138957600080fd5b6113928361135a565b946020939093013593505050565b6000806000606084860312156113b557600080fd5b6113be8461135a565b92506113cc6020850161135a565b9150604084013590509250925092565b6000602082840312156113ee57600080fd5b5035919050565b6000806040838503121561140857600080fd5b823591506114186020840161135a565b90509250929050565b60006020828403121561143357600080fd5b6112ba8261135a565b6000806040838503121561144f57600080fd5b6114588361135a565b91506114186020840161135a565b600181811c9082168061147a57607f821691505b60208210810361149a57634e487b7160e01b600052602260045260246000fd5b50919050565b634e487b7160e01b600052601160045260246000fd5b808201808211156103e6576103e66114a0565b7f416363657373436f6e74726f6c3a206163636f756e7420000000000000000000815260008351611501816017850160208801611303565b7f206973206d697373696e6720726f6c6520000000000000000000000000000000601791840191820152835161153e816028840160208801611303565b01602801949350505050565b818103818111156103e6576103e66114a0565b80820281158282048414176103e6576103e66114a0565b634e487b7160e01b600052604160045260246000fd5b634e487b7160e01b600052603260045260246000fd5b6000816115af576115af6114a0565b50600019019056fea26469706673582212208c0c98c11a714c67119b0ee32e1b0ec44cca2659e3f7fab948c5f6bbfd4acdc664736f6c63430008150033a26469706673582212206d87db3f09a74a0172749ddefe2478bf9c79809f9dcf0170d60c7f7ea6ffe27264736f6c63430008150033",
 }
 
 // ObscuroBridgeABI is the input ABI used to generate the binding from.
@@ -326,6 +334,37 @@ func (_ObscuroBridge *ObscuroBridgeCallerSession) NATIVETOKENROLE() ([32]byte, e
 	return _ObscuroBridge.Contract.NATIVETOKENROLE(&_ObscuroBridge.CallOpts)
 }
 
+// AutoRegistration is a free data retrieval call binding the contract method 0xe23120f9.
+//
+// Solidity: function autoRegistration() view returns(bool)
+func (_ObscuroBridge *ObscuroBridgeCaller) AutoRegistration(opts *bind.CallOpts) (bool, error) {
+	var out []interface{}
+	err := _ObscuroBridge.contract.Call(opts, &out, "autoRegistration")
+
+	if err != nil {
+		return *new(bool), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(bool)).(*bool)
+
+	return out0, err
+
+}
+
+// AutoRegistration is a free data retrieval call binding the contract method 0xe23120f9.
+//
+// Solidity: function autoRegistration() view returns(bool)
+func (_ObscuroBridge *ObscuroBridgeSession) AutoRegistration() (bool, error) {
+	return _ObscuroBridge.Contract.AutoRegistration(&_ObscuroBridge.CallOpts)
+}
+
+// AutoRegistration is a free data retrieval call binding the contract method 0xe23120f9.
+//
+// Solidity: function autoRegistration() view returns(bool)
+func (_ObscuroBridge *ObscuroBridgeCallerSession) AutoRegistration() (bool, error) {
+	return _ObscuroBridge.Contract.AutoRegistration(&_ObscuroBridge.CallOpts)
+}
+
 // GetRoleAdmin is a free data retrieval call binding the contract method 0x248a9ca3.
 //
 // Solidity: function getRoleAdmin(bytes32 role) view returns(bytes32)
@@ -388,6 +427,68 @@ func (_ObscuroBridge *ObscuroBridgeCallerSession) HasRole(role [32]byte, account
 	return _ObscuroBridge.Contract.HasRole(&_ObscuroBridge.CallOpts, role, account)
 }
 
+// L1TokenOf is a free data retrieval call binding the contract method 0x5c4994b1.
+//
+// Solidity: function l1TokenOf(address l2Token) view returns(address)
+func (_ObscuroBridge *ObscuroBridgeCaller) L1TokenOf(opts *bind.CallOpts, l2Token common.Address) (common.Address, error) {
+	var out []interface{}
+	err := _ObscuroBridge.contract.Call(opts, &out, "l1TokenOf", l2Token)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// L1TokenOf is a free data retrieval call binding the contract method 0x5c4994b1.
+//
+// Solidity: function l1TokenOf(address l2Token) view returns(address)
+func (_ObscuroBridge *ObscuroBridgeSession) L1TokenOf(l2Token common.Address) (common.Address, error) {
+	return _ObscuroBridge.Contract.L1TokenOf(&_ObscuroBridge.CallOpts, l2Token)
+}
+
+// L1TokenOf is a free data retrieval call binding the contract method 0x5c4994b1.
+//
+// Solidity: function l1TokenOf(address l2Token) view returns(address)
+func (_ObscuroBridge *ObscuroBridgeCallerSession) L1TokenOf(l2Token common.Address) (common.Address, error) {
+	return _ObscuroBridge.Contract.L1TokenOf(&_ObscuroBridge.CallOpts, l2Token)
+}
+
+// L2TokenOf is a free data retrieval call binding the contract method 0xc098a8c0.
+//
+// Solidity: function l2TokenOf(address l1Token) view returns(address)
+func (_ObscuroBridge *ObscuroBridgeCaller) L2TokenOf(opts *bind.CallOpts, l1Token common.Address) (common.Address, error) {
+	var out []interface{}
+	err := _ObscuroBridge.contract.Call(opts, &out, "l2TokenOf", l1Token)
+
+	if err != nil {
+		return *new(common.Address), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(common.Address)).(*common.Address)
+
+	return out0, err
+
+}
+
+// L2TokenOf is a free data retrieval call binding the contract method 0xc098a8c0.
+//
+// Solidity: function l2TokenOf(address l1Token) view returns(address)
+func (_ObscuroBridge *ObscuroBridgeSession) L2TokenOf(l1Token common.Address) (common.Address, error) {
+	return _ObscuroBridge.Contract.L2TokenOf(&_ObscuroBridge.CallOpts, l1Token)
+}
+
+// L2TokenOf is a free data retrieval call binding the contract method 0xc098a8c0.
+//
+// Solidity: function l2TokenOf(address l1Token) view returns(address)
+func (_ObscuroBridge *ObscuroBridgeCallerSession) L2TokenOf(l1Token common.Address) (common.Address, error) {
+	return _ObscuroBridge.Contract.L2TokenOf(&_ObscuroBridge.CallOpts, l1Token)
+}
+
 // SupportsInterface is a free data retrieval call binding the contract method 0x01ffc9a7.
 //
 // Solidity: function supportsInterface(bytes4 interfaceId) view returns(bool)
@@ -419,6 +520,68 @@ func (_ObscuroBridge *ObscuroBridgeCallerSession) SupportsInterface(interfaceId
 	return _ObscuroBridge.Contract.SupportsInterface(&_ObscuroBridge.CallOpts, interfaceId)
 }
 
+// TokenMappings is a free data retrieval call binding the contract method 0x2fb7ef4a.
+//
+// Solidity: function tokenMappings(uint256 offset, uint256 limit) view returns((address,address,string,string)[])
+func (_ObscuroBridge *ObscuroBridgeCaller) TokenMappings(opts *bind.CallOpts, offset *big.Int, limit *big.Int) ([]ITokenRegistryTokenMapping, error) {
+	var out []interface{}
+	err := _ObscuroBridge.contract.Call(opts, &out, "tokenMappings", offset, limit)
+
+	if err != nil {
+		return *new([]ITokenRegistryTokenMapping), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new([]ITokenRegistryTokenMapping)).(*[]ITokenRegistryTokenMapping)
+
+	return out0, err
+
+}
+
+// TokenMappings is a free data retrieval call binding the contract method 0x2fb7ef4a.
+//
+// Solidity: function tokenMappings(uint256 offset, uint256 limit) view returns((address,address,string,string)[])
+func (_ObscuroBridge *ObscuroBridgeSession) TokenMappings(offset *big.Int, limit *big.Int) ([]ITokenRegistryTokenMapping, error) {
+	return _ObscuroBridge.Contract.TokenMappings(&_ObscuroBridge.CallOpts, offset, limit)
+}
+
+// TokenMappings is a free data retrieval call binding the contract method 0x2fb7ef4a.
+//
+// Solidity: function tokenMappings(uint256 offset, uint256 limit) view returns((address,address,string,string)[])
+func (_ObscuroBridge *ObscuroBridgeCallerSession) TokenMappings(offset *big.Int, limit *big.Int) ([]ITokenRegistryTokenMapping, error) {
+	return _ObscuroBridge.Contract.TokenMappings(&_ObscuroBridge.CallOpts, offset, limit)
+}
+
+// TokenMappingsCount is a free data retrieval call binding the contract method 0x96344470.
+//
+// Solidity: function tokenMappingsCount() view returns(uint256)
+func (_ObscuroBridge *ObscuroBridgeCaller) TokenMappingsCount(opts *bind.CallOpts) (*big.Int, error) {
+	var out []interface{}
+	err := _ObscuroBridge.contract.Call(opts, &out, "tokenMappingsCount")
+
+	if err != nil {
+		return *new(*big.Int), err
+	}
+
+	out0 := *abi.ConvertType(out[0], new(*big.Int)).(**big.Int)
+
+	return out0, err
+
+}
+
+// TokenMappingsCount is a free data retrieval call binding the contract method 0x96344470.
+//
+// Solidity: function tokenMappingsCount() view returns(uint256)
+func (_ObscuroBridge *ObscuroBridgeSession) TokenMappingsCount() (*big.Int, error) {
+	return _ObscuroBridge.Contract.TokenMappingsCount(&_ObscuroBridge.CallOpts)
+}
+
+// TokenMappingsCount is a free data retrieval call binding the contract method 0x96344470.
+//
+// Solidity: function tokenMappingsCount() view returns(uint256)
+func (_ObscuroBridge *ObscuroBridgeCallerSession) TokenMappingsCount() (*big.Int, error) {
+	return _ObscuroBridge.Contract.TokenMappingsCount(&_ObscuroBridge.CallOpts)
+}
+
 // Configure is a paid mutator transaction binding the contract method 0x75cb2672.
 //
 // Solidity: function configure(address messengerAddress) returns()
@@ -629,6 +792,27 @@ func (_ObscuroBridge *ObscuroBridgeTransactorSession) SendNative(receiver common
 	return _ObscuroBridge.Contract.SendNative(&_ObscuroBridge.TransactOpts, receiver)
 }
 
+// SetAutoRegistration is a paid mutator transaction binding the contract method 0xaa1ddcca.
+//
+// Solidity: function setAutoRegistration(bool enabled) returns()
+func (_ObscuroBridge *ObscuroBridgeTransactor) SetAutoRegistration(opts *bind.TransactOpts, enabled bool) (*types.Transaction, error) {
+	return _ObscuroBridge.contract.Transact(opts, "setAutoRegistration", enabled)
+}
+
+// SetAutoRegistration is a paid mutator transaction binding the contract method 0xaa1ddcca.
+//
+// Solidity: function setAutoRegistration(bool enabled) returns()
+func (_ObscuroBridge *ObscuroBridgeSession) SetAutoRegistration(enabled bool) (*types.Transaction, error) {
+	return _ObscuroBridge.Contract.SetAutoRegistration(&_ObscuroBridge.TransactOpts, enabled)
+}
+
+// SetAutoRegistration is a paid mutator transaction binding the contract method 0xaa1ddcca.
+//
+// Solidity: function setAutoRegistration(bool enabled) returns()
+func (_ObscuroBridge *ObscuroBridgeTransactorSession) SetAutoRegistration(enabled bool) (*types.Transaction, error) {
+	return _ObscuroBridge.Contract.SetAutoRegistration(&_ObscuroBridge.TransactOpts, enabled)
+}
+
 // SetRemoteBridge is a paid mutator transaction binding the contract method 0x16ce8149.
 //
 // Solidity: function setRemoteBridge(address bridge) returns()
@@ -1156,3 +1340,158 @@ func (_ObscuroBridge *ObscuroBridgeFilterer) ParseRoleRevoked(log types.Log) (*O
 	event.Raw = log
 	return event, nil
 }
+
+// ObscuroBridgeTokenRegisteredIterator is returned from FilterTokenRegistered and is used to iterate over the raw logs and unpacked data for TokenRegistered events raised by the ObscuroBridge contract.
+type ObscuroBridgeTokenRegisteredIterator struct {
+	Event *ObscuroBridgeTokenRegistered // Event containing the contract specifics and raw log
+
+	contract *bind.BoundContract // Generic contract to use for unpacking event data
+	event    string              // Event name to use for unpacking event data
+
+	logs chan types.Log        // Log channel receiving the found contract events
+	sub  ethereum.Subscription // Subscription for errors, completion and termination
+	done bool                  // Whether the subscription completed delivering logs
+	fail error                 // Occurred error to stop iteration
+}
+
+// Next advances the iterator to the subsequent event, returning whether there
+// are any more events found. In case of a retrieval or parsing error, false is
+// returned and Error() can be queried for the exact failure.
+func (it *ObscuroBridgeTokenRegisteredIterator) Next() bool {
+	// If the iterator failed, stop iterating
+	if it.fail != nil {
+		return false
+	}
+	// If the iterator completed, deliver directly whatever's available
+	if it.done {
+		select {
+		case log := <-it.logs:
+			it.Event = new(ObscuroBridgeTokenRegistered)
+			if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+				it.fail = err
+				return false
+			}
+			it.Event.Raw = log
+			return true
+
+		default:
+			return false
+		}
+	}
+	// Iterator still in progress, wait for either a data or an error event
+	select {
+	case log := <-it.logs:
+		it.Event = new(ObscuroBridgeTokenRegistered)
+		if err := it.contract.UnpackLog(it.Event, it.event, log); err != nil {
+			it.fail = err
+			return false
+		}
+		it.Event.Raw = log
+		return true
+
+	case err := <-it.sub.Err():
+		it.done = true
+		it.fail = err
+		return it.Next()
+	}
+}
+
+// Error returns any retrieval or parsing error occurred during filtering.
+func (it *ObscuroBridgeTokenRegisteredIterator) Error() error {
+	return it.fail
+}
+
+// Close terminates the iteration process, releasing any pending underlying
+// resources.
+func (it *ObscuroBridgeTokenRegisteredIterator) Close() error {
+	it.sub.Unsubscribe()
+	return nil
+}
+
+// ObscuroBridgeTokenRegistered represents a TokenRegistered event raised by the ObscuroBridge contract.
+type ObscuroBridgeTokenRegistered struct {
+	L1Token common.Address
+	L2Token common.Address
+	Name    string
+	Symbol  string
+	Raw     types.Log // Blockchain specific contextual infos
+}
+
+// FilterTokenRegistered is a free log retrieval operation binding the contract event 0xfbe16c292428cdc3e66e97f954e91cc4f49b472d24989df1b2cdc27f295a7067.
+//
+// Solidity: event TokenRegistered(address indexed l1Token, address indexed l2Token, string name, string symbol)
+func (_ObscuroBridge *ObscuroBridgeFilterer) FilterTokenRegistered(opts *bind.FilterOpts, l1Token []common.Address, l2Token []common.Address) (*ObscuroBridgeTokenRegisteredIterator, error) {
+
+	var l1TokenRule []interface{}
+	for _, l1TokenItem := range l1Token {
+		l1TokenRule = append(l1TokenRule, l1TokenItem)
+	}
+	var l2TokenRule []interface{}
+	for _, l2TokenItem := range l2Token {
+		l2TokenRule = append(l2TokenRule, l2TokenItem)
+	}
+
+	logs, sub, err := _ObscuroBridge.contract.FilterLogs(opts, "TokenRegistered", l1TokenRule, l2TokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return &ObscuroBridgeTokenRegisteredIterator{contract: _ObscuroBridge.contract, event: "TokenRegistered", logs: logs, sub: sub}, nil
+}
+
+// WatchTokenRegistered is a free log subscription operation binding the contract event 0xfbe16c292428cdc3e66e97f954e91cc4f49b472d24989df1b2cdc27f295a7067.
+//
+// Solidity: event TokenRegistered(address indexed l1Token, address indexed l2Token, string name, string symbol)
+func (_ObscuroBridge *ObscuroBridgeFilterer) WatchTokenRegistered(opts *bind.WatchOpts, sink chan<- *ObscuroBridgeTokenRegistered, l1Token []common.Address, l2Token []common.Address) (event.Subscription, error) {
+
+	var l1TokenRule []interface{}
+	for _, l1TokenItem := range l1Token {
+		l1TokenRule = append(l1TokenRule, l1TokenItem)
+	}
+	var l2TokenRule []interface{}
+	for _, l2TokenItem := range l2Token {
+		l2TokenRule = append(l2TokenRule, l2TokenItem)
+	}
+
+	logs, sub, err := _ObscuroBridge.contract.WatchLogs(opts, "TokenRegistered", l1TokenRule, l2TokenRule)
+	if err != nil {
+		return nil, err
+	}
+	return event.NewSubscription(func(quit <-chan struct{}) error {
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case log := <-logs:
+				// New log arrived, parse the event and forward to the user
+				event := new(ObscuroBridgeTokenRegistered)
+				if err := _ObscuroBridge.contract.UnpackLog(event, "TokenRegistered", log); err != nil {
+					return err
+				}
+				event.Raw = log
+
+				select {
+				case sink <- event:
+				case err := <-sub.Err():
+					return err
+				case <-quit:
+					return nil
+				}
+			case err := <-sub.Err():
+				return err
+			case <-quit:
+				return nil
+			}
+		}
+	}), nil
+}
+
+// ParseTokenRegistered is a log parse operation binding the contract event 0xfbe16c292428cdc3e66e97f954e91cc4f49b472d24989df1b2cdc27f295a7067.
+//
+// Solidity: event TokenRegistered(address indexed l1Token, address indexed l2Token, string name, string symbol)
+func (_ObscuroBridge *ObscuroBridgeFilterer) ParseTokenRegistered(log types.Log) (*ObscuroBridgeTokenRegistered, error) {
+	event := new(ObscuroBridgeTokenRegistered)
+	if err := _ObscuroBridge.contract.UnpackLog(event, "TokenRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
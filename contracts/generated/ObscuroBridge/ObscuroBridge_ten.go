@@ -0,0 +1,757 @@
+// Code generated by tools/bindgen - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package ObscuroBridge
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = bind.NewBoundContract
+	_ = types.Log{}
+	_ = gethcommon.Hash{}
+)
+
+// the ABI of the contract, parsed once
+var parsedObscuroBridgeABI = func() *abi.ABI {
+	parsed, err := ObscuroBridgeMetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// ParsedObscuroBridgeABI returns the parsed ABI of the ObscuroBridge contract
+func ParsedObscuroBridgeABI() *abi.ABI {
+	return parsedObscuroBridgeABI
+}
+
+// unpackInput returns the arguments of a call to the method, checking that the call data is a call to it
+func unpackInput(methodName string, data []byte) ([]interface{}, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("the call data is too short to be a call to %s", methodName)
+	}
+	m, err := parsedObscuroBridgeABI.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+	if m.Name != methodName {
+		return nil, fmt.Errorf("the call data is a call to %s, not %s", m.Name, methodName)
+	}
+	return m.Inputs.Unpack(data[4:])
+}
+
+// ADMINROLEMethod is the name of the ADMIN_ROLE() method in the ABI
+const ADMINROLEMethod = "ADMIN_ROLE"
+
+// PackADMINROLE packs a call to ADMIN_ROLE()
+func PackADMINROLE() ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(ADMINROLEMethod)
+}
+
+// UnpackADMINROLEOutput unpacks the result of a call to ADMIN_ROLE()
+func UnpackADMINROLEOutput(data []byte) ([32]byte, error) {
+	values, err := parsedObscuroBridgeABI.Unpack(ADMINROLEMethod, data)
+	if err != nil {
+		return *new([32]byte), err
+	}
+	return *abi.ConvertType(values[0], new([32]byte)).(*[32]byte), nil
+}
+
+// DEFAULTADMINROLEMethod is the name of the DEFAULT_ADMIN_ROLE() method in the ABI
+const DEFAULTADMINROLEMethod = "DEFAULT_ADMIN_ROLE"
+
+// PackDEFAULTADMINROLE packs a call to DEFAULT_ADMIN_ROLE()
+func PackDEFAULTADMINROLE() ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(DEFAULTADMINROLEMethod)
+}
+
+// UnpackDEFAULTADMINROLEOutput unpacks the result of a call to DEFAULT_ADMIN_ROLE()
+func UnpackDEFAULTADMINROLEOutput(data []byte) ([32]byte, error) {
+	values, err := parsedObscuroBridgeABI.Unpack(DEFAULTADMINROLEMethod, data)
+	if err != nil {
+		return *new([32]byte), err
+	}
+	return *abi.ConvertType(values[0], new([32]byte)).(*[32]byte), nil
+}
+
+// ERC20TOKENROLEMethod is the name of the ERC20_TOKEN_ROLE() method in the ABI
+const ERC20TOKENROLEMethod = "ERC20_TOKEN_ROLE"
+
+// PackERC20TOKENROLE packs a call to ERC20_TOKEN_ROLE()
+func PackERC20TOKENROLE() ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(ERC20TOKENROLEMethod)
+}
+
+// UnpackERC20TOKENROLEOutput unpacks the result of a call to ERC20_TOKEN_ROLE()
+func UnpackERC20TOKENROLEOutput(data []byte) ([32]byte, error) {
+	values, err := parsedObscuroBridgeABI.Unpack(ERC20TOKENROLEMethod, data)
+	if err != nil {
+		return *new([32]byte), err
+	}
+	return *abi.ConvertType(values[0], new([32]byte)).(*[32]byte), nil
+}
+
+// NATIVETOKENROLEMethod is the name of the NATIVE_TOKEN_ROLE() method in the ABI
+const NATIVETOKENROLEMethod = "NATIVE_TOKEN_ROLE"
+
+// PackNATIVETOKENROLE packs a call to NATIVE_TOKEN_ROLE()
+func PackNATIVETOKENROLE() ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(NATIVETOKENROLEMethod)
+}
+
+// UnpackNATIVETOKENROLEOutput unpacks the result of a call to NATIVE_TOKEN_ROLE()
+func UnpackNATIVETOKENROLEOutput(data []byte) ([32]byte, error) {
+	values, err := parsedObscuroBridgeABI.Unpack(NATIVETOKENROLEMethod, data)
+	if err != nil {
+		return *new([32]byte), err
+	}
+	return *abi.ConvertType(values[0], new([32]byte)).(*[32]byte), nil
+}
+
+// AutoRegistrationMethod is the name of the autoRegistration() method in the ABI
+const AutoRegistrationMethod = "autoRegistration"
+
+// PackAutoRegistration packs a call to autoRegistration()
+func PackAutoRegistration() ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(AutoRegistrationMethod)
+}
+
+// UnpackAutoRegistrationOutput unpacks the result of a call to autoRegistration()
+func UnpackAutoRegistrationOutput(data []byte) (bool, error) {
+	values, err := parsedObscuroBridgeABI.Unpack(AutoRegistrationMethod, data)
+	if err != nil {
+		return *new(bool), err
+	}
+	return *abi.ConvertType(values[0], new(bool)).(*bool), nil
+}
+
+// ConfigureMethod is the name of the configure(address) method in the ABI
+const ConfigureMethod = "configure"
+
+// PackConfigure packs a call to configure(address)
+func PackConfigure(messengerAddress gethcommon.Address) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(ConfigureMethod, messengerAddress)
+}
+
+// ConfigureInput is the arguments of a call to configure(address)
+type ConfigureInput struct {
+	MessengerAddress gethcommon.Address
+}
+
+// UnpackConfigureInput unpacks the arguments of a call to configure(address) from its call data
+func UnpackConfigureInput(data []byte) (*ConfigureInput, error) {
+	values, err := unpackInput(ConfigureMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &ConfigureInput{
+		MessengerAddress: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// GetRoleAdminMethod is the name of the getRoleAdmin(bytes32) method in the ABI
+const GetRoleAdminMethod = "getRoleAdmin"
+
+// PackGetRoleAdmin packs a call to getRoleAdmin(bytes32)
+func PackGetRoleAdmin(role [32]byte) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(GetRoleAdminMethod, role)
+}
+
+// GetRoleAdminInput is the arguments of a call to getRoleAdmin(bytes32)
+type GetRoleAdminInput struct {
+	Role [32]byte
+}
+
+// UnpackGetRoleAdminInput unpacks the arguments of a call to getRoleAdmin(bytes32) from its call data
+func UnpackGetRoleAdminInput(data []byte) (*GetRoleAdminInput, error) {
+	values, err := unpackInput(GetRoleAdminMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &GetRoleAdminInput{
+		Role: *abi.ConvertType(values[0], new([32]byte)).(*[32]byte),
+	}, nil
+}
+
+// UnpackGetRoleAdminOutput unpacks the result of a call to getRoleAdmin(bytes32)
+func UnpackGetRoleAdminOutput(data []byte) ([32]byte, error) {
+	values, err := parsedObscuroBridgeABI.Unpack(GetRoleAdminMethod, data)
+	if err != nil {
+		return *new([32]byte), err
+	}
+	return *abi.ConvertType(values[0], new([32]byte)).(*[32]byte), nil
+}
+
+// GrantRoleMethod is the name of the grantRole(bytes32,address) method in the ABI
+const GrantRoleMethod = "grantRole"
+
+// PackGrantRole packs a call to grantRole(bytes32,address)
+func PackGrantRole(role [32]byte, account gethcommon.Address) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(GrantRoleMethod, role, account)
+}
+
+// GrantRoleInput is the arguments of a call to grantRole(bytes32,address)
+type GrantRoleInput struct {
+	Role    [32]byte
+	Account gethcommon.Address
+}
+
+// UnpackGrantRoleInput unpacks the arguments of a call to grantRole(bytes32,address) from its call data
+func UnpackGrantRoleInput(data []byte) (*GrantRoleInput, error) {
+	values, err := unpackInput(GrantRoleMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &GrantRoleInput{
+		Role:    *abi.ConvertType(values[0], new([32]byte)).(*[32]byte),
+		Account: *abi.ConvertType(values[1], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// HasRoleMethod is the name of the hasRole(bytes32,address) method in the ABI
+const HasRoleMethod = "hasRole"
+
+// PackHasRole packs a call to hasRole(bytes32,address)
+func PackHasRole(role [32]byte, account gethcommon.Address) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(HasRoleMethod, role, account)
+}
+
+// HasRoleInput is the arguments of a call to hasRole(bytes32,address)
+type HasRoleInput struct {
+	Role    [32]byte
+	Account gethcommon.Address
+}
+
+// UnpackHasRoleInput unpacks the arguments of a call to hasRole(bytes32,address) from its call data
+func UnpackHasRoleInput(data []byte) (*HasRoleInput, error) {
+	values, err := unpackInput(HasRoleMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &HasRoleInput{
+		Role:    *abi.ConvertType(values[0], new([32]byte)).(*[32]byte),
+		Account: *abi.ConvertType(values[1], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// UnpackHasRoleOutput unpacks the result of a call to hasRole(bytes32,address)
+func UnpackHasRoleOutput(data []byte) (bool, error) {
+	values, err := parsedObscuroBridgeABI.Unpack(HasRoleMethod, data)
+	if err != nil {
+		return *new(bool), err
+	}
+	return *abi.ConvertType(values[0], new(bool)).(*bool), nil
+}
+
+// InitializeMethod is the name of the initialize(address) method in the ABI
+const InitializeMethod = "initialize"
+
+// PackInitialize packs a call to initialize(address)
+func PackInitialize(messenger gethcommon.Address) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(InitializeMethod, messenger)
+}
+
+// InitializeInput is the arguments of a call to initialize(address)
+type InitializeInput struct {
+	Messenger gethcommon.Address
+}
+
+// UnpackInitializeInput unpacks the arguments of a call to initialize(address) from its call data
+func UnpackInitializeInput(data []byte) (*InitializeInput, error) {
+	values, err := unpackInput(InitializeMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &InitializeInput{
+		Messenger: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// L1TokenOfMethod is the name of the l1TokenOf(address) method in the ABI
+const L1TokenOfMethod = "l1TokenOf"
+
+// PackL1TokenOf packs a call to l1TokenOf(address)
+func PackL1TokenOf(l2Token gethcommon.Address) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(L1TokenOfMethod, l2Token)
+}
+
+// L1TokenOfInput is the arguments of a call to l1TokenOf(address)
+type L1TokenOfInput struct {
+	L2Token gethcommon.Address
+}
+
+// UnpackL1TokenOfInput unpacks the arguments of a call to l1TokenOf(address) from its call data
+func UnpackL1TokenOfInput(data []byte) (*L1TokenOfInput, error) {
+	values, err := unpackInput(L1TokenOfMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &L1TokenOfInput{
+		L2Token: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// UnpackL1TokenOfOutput unpacks the result of a call to l1TokenOf(address)
+func UnpackL1TokenOfOutput(data []byte) (gethcommon.Address, error) {
+	values, err := parsedObscuroBridgeABI.Unpack(L1TokenOfMethod, data)
+	if err != nil {
+		return *new(gethcommon.Address), err
+	}
+	return *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address), nil
+}
+
+// L2TokenOfMethod is the name of the l2TokenOf(address) method in the ABI
+const L2TokenOfMethod = "l2TokenOf"
+
+// PackL2TokenOf packs a call to l2TokenOf(address)
+func PackL2TokenOf(l1Token gethcommon.Address) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(L2TokenOfMethod, l1Token)
+}
+
+// L2TokenOfInput is the arguments of a call to l2TokenOf(address)
+type L2TokenOfInput struct {
+	L1Token gethcommon.Address
+}
+
+// UnpackL2TokenOfInput unpacks the arguments of a call to l2TokenOf(address) from its call data
+func UnpackL2TokenOfInput(data []byte) (*L2TokenOfInput, error) {
+	values, err := unpackInput(L2TokenOfMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &L2TokenOfInput{
+		L1Token: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// UnpackL2TokenOfOutput unpacks the result of a call to l2TokenOf(address)
+func UnpackL2TokenOfOutput(data []byte) (gethcommon.Address, error) {
+	values, err := parsedObscuroBridgeABI.Unpack(L2TokenOfMethod, data)
+	if err != nil {
+		return *new(gethcommon.Address), err
+	}
+	return *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address), nil
+}
+
+// PromoteToAdminMethod is the name of the promoteToAdmin(address) method in the ABI
+const PromoteToAdminMethod = "promoteToAdmin"
+
+// PackPromoteToAdmin packs a call to promoteToAdmin(address)
+func PackPromoteToAdmin(newAdmin gethcommon.Address) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(PromoteToAdminMethod, newAdmin)
+}
+
+// PromoteToAdminInput is the arguments of a call to promoteToAdmin(address)
+type PromoteToAdminInput struct {
+	NewAdmin gethcommon.Address
+}
+
+// UnpackPromoteToAdminInput unpacks the arguments of a call to promoteToAdmin(address) from its call data
+func UnpackPromoteToAdminInput(data []byte) (*PromoteToAdminInput, error) {
+	values, err := unpackInput(PromoteToAdminMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &PromoteToAdminInput{
+		NewAdmin: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// ReceiveAssetsMethod is the name of the receiveAssets(address,uint256,address) method in the ABI
+const ReceiveAssetsMethod = "receiveAssets"
+
+// PackReceiveAssets packs a call to receiveAssets(address,uint256,address)
+func PackReceiveAssets(asset gethcommon.Address, amount *big.Int, receiver gethcommon.Address) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(ReceiveAssetsMethod, asset, amount, receiver)
+}
+
+// ReceiveAssetsInput is the arguments of a call to receiveAssets(address,uint256,address)
+type ReceiveAssetsInput struct {
+	Asset    gethcommon.Address
+	Amount   *big.Int
+	Receiver gethcommon.Address
+}
+
+// UnpackReceiveAssetsInput unpacks the arguments of a call to receiveAssets(address,uint256,address) from its call data
+func UnpackReceiveAssetsInput(data []byte) (*ReceiveAssetsInput, error) {
+	values, err := unpackInput(ReceiveAssetsMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &ReceiveAssetsInput{
+		Asset:    *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+		Amount:   *abi.ConvertType(values[1], new(*big.Int)).(**big.Int),
+		Receiver: *abi.ConvertType(values[2], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// RemoveTokenMethod is the name of the removeToken(address) method in the ABI
+const RemoveTokenMethod = "removeToken"
+
+// PackRemoveToken packs a call to removeToken(address)
+func PackRemoveToken(asset gethcommon.Address) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(RemoveTokenMethod, asset)
+}
+
+// RemoveTokenInput is the arguments of a call to removeToken(address)
+type RemoveTokenInput struct {
+	Asset gethcommon.Address
+}
+
+// UnpackRemoveTokenInput unpacks the arguments of a call to removeToken(address) from its call data
+func UnpackRemoveTokenInput(data []byte) (*RemoveTokenInput, error) {
+	values, err := unpackInput(RemoveTokenMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &RemoveTokenInput{
+		Asset: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// RenounceRoleMethod is the name of the renounceRole(bytes32,address) method in the ABI
+const RenounceRoleMethod = "renounceRole"
+
+// PackRenounceRole packs a call to renounceRole(bytes32,address)
+func PackRenounceRole(role [32]byte, account gethcommon.Address) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(RenounceRoleMethod, role, account)
+}
+
+// RenounceRoleInput is the arguments of a call to renounceRole(bytes32,address)
+type RenounceRoleInput struct {
+	Role    [32]byte
+	Account gethcommon.Address
+}
+
+// UnpackRenounceRoleInput unpacks the arguments of a call to renounceRole(bytes32,address) from its call data
+func UnpackRenounceRoleInput(data []byte) (*RenounceRoleInput, error) {
+	values, err := unpackInput(RenounceRoleMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &RenounceRoleInput{
+		Role:    *abi.ConvertType(values[0], new([32]byte)).(*[32]byte),
+		Account: *abi.ConvertType(values[1], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// RevokeRoleMethod is the name of the revokeRole(bytes32,address) method in the ABI
+const RevokeRoleMethod = "revokeRole"
+
+// PackRevokeRole packs a call to revokeRole(bytes32,address)
+func PackRevokeRole(role [32]byte, account gethcommon.Address) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(RevokeRoleMethod, role, account)
+}
+
+// RevokeRoleInput is the arguments of a call to revokeRole(bytes32,address)
+type RevokeRoleInput struct {
+	Role    [32]byte
+	Account gethcommon.Address
+}
+
+// UnpackRevokeRoleInput unpacks the arguments of a call to revokeRole(bytes32,address) from its call data
+func UnpackRevokeRoleInput(data []byte) (*RevokeRoleInput, error) {
+	values, err := unpackInput(RevokeRoleMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &RevokeRoleInput{
+		Role:    *abi.ConvertType(values[0], new([32]byte)).(*[32]byte),
+		Account: *abi.ConvertType(values[1], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// SendERC20Method is the name of the sendERC20(address,uint256,address) method in the ABI
+const SendERC20Method = "sendERC20"
+
+// PackSendERC20 packs a call to sendERC20(address,uint256,address)
+func PackSendERC20(asset gethcommon.Address, amount *big.Int, receiver gethcommon.Address) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(SendERC20Method, asset, amount, receiver)
+}
+
+// SendERC20Input is the arguments of a call to sendERC20(address,uint256,address)
+type SendERC20Input struct {
+	Asset    gethcommon.Address
+	Amount   *big.Int
+	Receiver gethcommon.Address
+}
+
+// UnpackSendERC20Input unpacks the arguments of a call to sendERC20(address,uint256,address) from its call data
+func UnpackSendERC20Input(data []byte) (*SendERC20Input, error) {
+	values, err := unpackInput(SendERC20Method, data)
+	if err != nil {
+		return nil, err
+	}
+	return &SendERC20Input{
+		Asset:    *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+		Amount:   *abi.ConvertType(values[1], new(*big.Int)).(**big.Int),
+		Receiver: *abi.ConvertType(values[2], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// SendNativeMethod is the name of the sendNative(address) method in the ABI
+const SendNativeMethod = "sendNative"
+
+// PackSendNative packs a call to sendNative(address)
+func PackSendNative(receiver gethcommon.Address) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(SendNativeMethod, receiver)
+}
+
+// SendNativeInput is the arguments of a call to sendNative(address)
+type SendNativeInput struct {
+	Receiver gethcommon.Address
+}
+
+// UnpackSendNativeInput unpacks the arguments of a call to sendNative(address) from its call data
+func UnpackSendNativeInput(data []byte) (*SendNativeInput, error) {
+	values, err := unpackInput(SendNativeMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &SendNativeInput{
+		Receiver: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// SetAutoRegistrationMethod is the name of the setAutoRegistration(bool) method in the ABI
+const SetAutoRegistrationMethod = "setAutoRegistration"
+
+// PackSetAutoRegistration packs a call to setAutoRegistration(bool)
+func PackSetAutoRegistration(enabled bool) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(SetAutoRegistrationMethod, enabled)
+}
+
+// SetAutoRegistrationInput is the arguments of a call to setAutoRegistration(bool)
+type SetAutoRegistrationInput struct {
+	Enabled bool
+}
+
+// UnpackSetAutoRegistrationInput unpacks the arguments of a call to setAutoRegistration(bool) from its call data
+func UnpackSetAutoRegistrationInput(data []byte) (*SetAutoRegistrationInput, error) {
+	values, err := unpackInput(SetAutoRegistrationMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &SetAutoRegistrationInput{
+		Enabled: *abi.ConvertType(values[0], new(bool)).(*bool),
+	}, nil
+}
+
+// SetRemoteBridgeMethod is the name of the setRemoteBridge(address) method in the ABI
+const SetRemoteBridgeMethod = "setRemoteBridge"
+
+// PackSetRemoteBridge packs a call to setRemoteBridge(address)
+func PackSetRemoteBridge(bridge gethcommon.Address) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(SetRemoteBridgeMethod, bridge)
+}
+
+// SetRemoteBridgeInput is the arguments of a call to setRemoteBridge(address)
+type SetRemoteBridgeInput struct {
+	Bridge gethcommon.Address
+}
+
+// UnpackSetRemoteBridgeInput unpacks the arguments of a call to setRemoteBridge(address) from its call data
+func UnpackSetRemoteBridgeInput(data []byte) (*SetRemoteBridgeInput, error) {
+	values, err := unpackInput(SetRemoteBridgeMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &SetRemoteBridgeInput{
+		Bridge: *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+	}, nil
+}
+
+// SupportsInterfaceMethod is the name of the supportsInterface(bytes4) method in the ABI
+const SupportsInterfaceMethod = "supportsInterface"
+
+// PackSupportsInterface packs a call to supportsInterface(bytes4)
+func PackSupportsInterface(interfaceId [4]byte) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(SupportsInterfaceMethod, interfaceId)
+}
+
+// SupportsInterfaceInput is the arguments of a call to supportsInterface(bytes4)
+type SupportsInterfaceInput struct {
+	InterfaceId [4]byte
+}
+
+// UnpackSupportsInterfaceInput unpacks the arguments of a call to supportsInterface(bytes4) from its call data
+func UnpackSupportsInterfaceInput(data []byte) (*SupportsInterfaceInput, error) {
+	values, err := unpackInput(SupportsInterfaceMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &SupportsInterfaceInput{
+		InterfaceId: *abi.ConvertType(values[0], new([4]byte)).(*[4]byte),
+	}, nil
+}
+
+// UnpackSupportsInterfaceOutput unpacks the result of a call to supportsInterface(bytes4)
+func UnpackSupportsInterfaceOutput(data []byte) (bool, error) {
+	values, err := parsedObscuroBridgeABI.Unpack(SupportsInterfaceMethod, data)
+	if err != nil {
+		return *new(bool), err
+	}
+	return *abi.ConvertType(values[0], new(bool)).(*bool), nil
+}
+
+// TokenMappingsMethod is the name of the tokenMappings(uint256,uint256) method in the ABI
+const TokenMappingsMethod = "tokenMappings"
+
+// PackTokenMappings packs a call to tokenMappings(uint256,uint256)
+func PackTokenMappings(offset *big.Int, limit *big.Int) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(TokenMappingsMethod, offset, limit)
+}
+
+// TokenMappingsInput is the arguments of a call to tokenMappings(uint256,uint256)
+type TokenMappingsInput struct {
+	Offset *big.Int
+	Limit  *big.Int
+}
+
+// UnpackTokenMappingsInput unpacks the arguments of a call to tokenMappings(uint256,uint256) from its call data
+func UnpackTokenMappingsInput(data []byte) (*TokenMappingsInput, error) {
+	values, err := unpackInput(TokenMappingsMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &TokenMappingsInput{
+		Offset: *abi.ConvertType(values[0], new(*big.Int)).(**big.Int),
+		Limit:  *abi.ConvertType(values[1], new(*big.Int)).(**big.Int),
+	}, nil
+}
+
+// UnpackTokenMappingsOutput unpacks the result of a call to tokenMappings(uint256,uint256)
+func UnpackTokenMappingsOutput(data []byte) ([]ITokenRegistryTokenMapping, error) {
+	values, err := parsedObscuroBridgeABI.Unpack(TokenMappingsMethod, data)
+	if err != nil {
+		return *new([]ITokenRegistryTokenMapping), err
+	}
+	return *abi.ConvertType(values[0], new([]ITokenRegistryTokenMapping)).(*[]ITokenRegistryTokenMapping), nil
+}
+
+// TokenMappingsCountMethod is the name of the tokenMappingsCount() method in the ABI
+const TokenMappingsCountMethod = "tokenMappingsCount"
+
+// PackTokenMappingsCount packs a call to tokenMappingsCount()
+func PackTokenMappingsCount() ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(TokenMappingsCountMethod)
+}
+
+// UnpackTokenMappingsCountOutput unpacks the result of a call to tokenMappingsCount()
+func UnpackTokenMappingsCountOutput(data []byte) (*big.Int, error) {
+	values, err := parsedObscuroBridgeABI.Unpack(TokenMappingsCountMethod, data)
+	if err != nil {
+		return *new(*big.Int), err
+	}
+	return *abi.ConvertType(values[0], new(*big.Int)).(**big.Int), nil
+}
+
+// WhitelistTokenMethod is the name of the whitelistToken(address,string,string) method in the ABI
+const WhitelistTokenMethod = "whitelistToken"
+
+// PackWhitelistToken packs a call to whitelistToken(address,string,string)
+func PackWhitelistToken(asset gethcommon.Address, name string, symbol string) ([]byte, error) {
+	return parsedObscuroBridgeABI.Pack(WhitelistTokenMethod, asset, name, symbol)
+}
+
+// WhitelistTokenInput is the arguments of a call to whitelistToken(address,string,string)
+type WhitelistTokenInput struct {
+	Asset  gethcommon.Address
+	Name   string
+	Symbol string
+}
+
+// UnpackWhitelistTokenInput unpacks the arguments of a call to whitelistToken(address,string,string) from its call data
+func UnpackWhitelistTokenInput(data []byte) (*WhitelistTokenInput, error) {
+	values, err := unpackInput(WhitelistTokenMethod, data)
+	if err != nil {
+		return nil, err
+	}
+	return &WhitelistTokenInput{
+		Asset:  *abi.ConvertType(values[0], new(gethcommon.Address)).(*gethcommon.Address),
+		Name:   *abi.ConvertType(values[1], new(string)).(*string),
+		Symbol: *abi.ConvertType(values[2], new(string)).(*string),
+	}, nil
+}
+
+// RoleAdminChangedEventID is the topic of the RoleAdminChanged(bytes32,bytes32,bytes32) event
+var RoleAdminChangedEventID = gethcommon.HexToHash("0xbd79b86ffe0ab8e8776151514217cd7cacd52c909f66475c3af44e129f0b00ff")
+
+// RoleAdminChangedIsLifecycle is true: on TEN, the RoleAdminChanged events have no indexed address field, so they are lifecycle
+// events visible to everyone.
+const RoleAdminChangedIsLifecycle = true
+
+// UnpackRoleAdminChangedEvent unpacks a RoleAdminChanged(bytes32,bytes32,bytes32) log
+func UnpackRoleAdminChangedEvent(log types.Log) (*ObscuroBridgeRoleAdminChanged, error) {
+	event := new(ObscuroBridgeRoleAdminChanged)
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsedObscuroBridgeABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "RoleAdminChanged", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// RoleGrantedEventID is the topic of the RoleGranted(bytes32,address,address) event
+var RoleGrantedEventID = gethcommon.HexToHash("0x2f8788117e7eff1d82e926ec794901d17c78024a50270940304540a733656f0d")
+
+// RoleGrantedIsLifecycle is false: on TEN, the RoleGranted events are only visible to the user accounts in their
+// indexed account, sender fields, or to everyone if none of them holds a user account.
+const RoleGrantedIsLifecycle = false
+
+// UnpackRoleGrantedEvent unpacks a RoleGranted(bytes32,address,address) log
+func UnpackRoleGrantedEvent(log types.Log) (*ObscuroBridgeRoleGranted, error) {
+	event := new(ObscuroBridgeRoleGranted)
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsedObscuroBridgeABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "RoleGranted", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// RoleRevokedEventID is the topic of the RoleRevoked(bytes32,address,address) event
+var RoleRevokedEventID = gethcommon.HexToHash("0xf6391f5c32d9c69d2a47ea670b442974b53935d1edc7fd64eb21e047a839171b")
+
+// RoleRevokedIsLifecycle is false: on TEN, the RoleRevoked events are only visible to the user accounts in their
+// indexed account, sender fields, or to everyone if none of them holds a user account.
+const RoleRevokedIsLifecycle = false
+
+// UnpackRoleRevokedEvent unpacks a RoleRevoked(bytes32,address,address) log
+func UnpackRoleRevokedEvent(log types.Log) (*ObscuroBridgeRoleRevoked, error) {
+	event := new(ObscuroBridgeRoleRevoked)
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsedObscuroBridgeABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "RoleRevoked", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+
+// TokenRegisteredEventID is the topic of the TokenRegistered(address,address,string,string) event
+var TokenRegisteredEventID = gethcommon.HexToHash("0xfbe16c292428cdc3e66e97f954e91cc4f49b472d24989df1b2cdc27f295a7067")
+
+// TokenRegisteredIsLifecycle is false: on TEN, the TokenRegistered events are only visible to the user accounts in their
+// indexed l1Token, l2Token fields, or to everyone if none of them holds a user account.
+const TokenRegisteredIsLifecycle = false
+
+// UnpackTokenRegisteredEvent unpacks a TokenRegistered(address,address,string,string) log
+func UnpackTokenRegisteredEvent(log types.Log) (*ObscuroBridgeTokenRegistered, error) {
+	event := new(ObscuroBridgeTokenRegistered)
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsedObscuroBridgeABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "TokenRegistered", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
@@ -0,0 +1,38 @@
+// SPDX-License-Identifier: Apache 2
+
+pragma solidity >=0.7.0 <0.9.0;
+
+// ITokenRegistry is implemented by both bridges, so that the UIs can resolve the wrapped token of an L1 token, and the
+// L1 token of a wrapped token, on either layer without configuring the token pairs.
+interface ITokenRegistry {
+    struct TokenMapping {
+        address l1Token;
+        address l2Token;
+        string name;
+        string symbol;
+    }
+
+    // Emitted when a token is registered, either whitelisted by an admin or on its first deposit.
+    event TokenRegistered(
+        address indexed l1Token,
+        address indexed l2Token,
+        string name,
+        string symbol
+    );
+
+    // l2TokenOf - returns the address of the wrapped token of the L1 token, or zero if the token was never registered.
+    function l2TokenOf(address l1Token) external view returns (address);
+
+    // l1TokenOf - returns the address of the L1 token of the wrapped token, or zero if it is not a wrapped token.
+    function l1TokenOf(address l2Token) external view returns (address);
+
+    // tokenMappingsCount - returns the number of tokens registered.
+    function tokenMappingsCount() external view returns (uint256);
+
+    // tokenMappings - returns at most `limit` mappings of the tokens registered, starting from `offset`, in the order
+    // of their registration.
+    function tokenMappings(
+        uint256 offset,
+        uint256 limit
+    ) external view returns (TokenMapping[] memory);
+}
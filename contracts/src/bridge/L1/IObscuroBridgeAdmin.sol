@@ -6,7 +6,8 @@ pragma solidity >=0.7.0 <0.9.0;
 // and has the functionality to modify it.
 interface IObscuroBridgeAdmin {
     // This will whitelist a token and generate a cross chain message to the ITokenFactory
-    // to create wrapped tokens in case of succcess.
+    // to create wrapped tokens in case of succcess. A token already registered, e.g. by its first deposit or before
+    // its removal, is whitelisted again with its existing wrapped token.
     function whitelistToken(
         address asset,
         string calldata name,
@@ -18,4 +19,8 @@ interface IObscuroBridgeAdmin {
     function removeToken(address asset) external;
 
     function setRemoteBridge(address bridge) external;
+
+    // Enables or disables the registration of the tokens by their first deposit. When it is disabled, only the
+    // whitelisted tokens can be bridged.
+    function setAutoRegistration(bool enabled) external;
 }
@@ -5,18 +5,24 @@ pragma solidity >=0.7.0 <0.9.0;
 import "./IObscuroBridgeAdmin.sol";
 import "../IBridge.sol";
 import "../ITokenFactory.sol";
+import "../ITokenRegistry.sol";
+import "../WrappedTokenAddress.sol";
 import "../../messaging/messenger/CrossChainEnabledObscuro.sol";
 import "@openzeppelin/contracts/token/ERC20/utils/SafeERC20.sol";
+import "@openzeppelin/contracts/token/ERC20/extensions/IERC20Metadata.sol";
 import "@openzeppelin/contracts/access/AccessControl.sol";
 import "@openzeppelin/contracts/proxy/utils/Initializable.sol";
 
 
 // This is the Ethereum side of the Obscuro Bridge.
 // End-users can interact with it to transfer ERC20 tokens and native eth to the Layer 2 Obscuro.
+// When the admins enable the auto registration, the first deposit of a token that was never registered registers it, so
+// its wrapped token is deployed on the L2.
 contract ObscuroBridge is
     CrossChainEnabledObscuro,
     IBridge,
     IObscuroBridgeAdmin,
+    ITokenRegistry,
     AccessControl
 {
     // This is the role that is given to the address that represents a native currency
@@ -32,6 +38,16 @@ contract ObscuroBridge is
 
     address remoteBridgeAddress;
 
+    // The tokens registered, in order, and their mappings. A removed token stays registered, so it is not registered
+    // again by a deposit, and keeps its wrapped token when it is whitelisted again.
+    address[] private registeredTokens;
+    mapping(address => TokenMapping) private mappingOfL1Token;
+    mapping(address => address) private l1TokenOfL2Token;
+
+    // Whether the first deposit of a token that was never registered registers it. It is disabled by default, as it
+    // lets anyone bridge any ERC20, including the tokens with misleading metadata and the ones that misbehave on transfer.
+    bool public autoRegistration;
+
     function initialize(address messenger) public initializer() {
          CrossChainEnabledObscuro.configure(messenger);
         _grantRole(ADMIN_ROLE, msg.sender);
@@ -48,20 +64,9 @@ contract ObscuroBridge is
         string calldata symbol
     ) external onlyRole(ADMIN_ROLE) {
         _grantRole(ERC20_TOKEN_ROLE, asset);
-
-        bytes memory data = abi.encodeWithSelector(
-            ITokenFactory.onCreateTokenCommand.selector,
-            asset,
-            name,
-            symbol
-        );
-        queueMessage(
-            remoteBridgeAddress,
-            data,
-            uint32(Topics.MANAGEMENT),
-            0,
-            0
-        );
+        if (!_isRegistered(asset)) {
+            _registerToken(asset, name, symbol);
+        }
     }
 
     function removeToken(address asset) external onlyRole(ADMIN_ROLE) {
@@ -72,6 +77,10 @@ contract ObscuroBridge is
         remoteBridgeAddress = bridge;
     }
 
+    function setAutoRegistration(bool enabled) external onlyRole(ADMIN_ROLE) {
+        autoRegistration = enabled;
+    }
+
     // This cross chain message is specialized and will result in automatic increase
     // of balance on the other side. 
     // NOTE: If sent to a contract, there will be no fallback function executed.
@@ -90,6 +99,9 @@ contract ObscuroBridge is
         address receiver
     ) external override {
         require(amount > 0, "Attempting empty transfer.");
+        if (autoRegistration && !_isRegistered(asset)) {
+            _registerOnFirstDeposit(asset);
+        }
         require(
             hasRole(ERC20_TOKEN_ROLE, asset),
             "This address has not been given a type and is thus considered not whitelisted."
@@ -126,6 +138,92 @@ contract ObscuroBridge is
         }
     }
 
+    function l2TokenOf(address l1Token) external view override returns (address) {
+        return mappingOfL1Token[l1Token].l2Token;
+    }
+
+    function l1TokenOf(address l2Token) external view override returns (address) {
+        return l1TokenOfL2Token[l2Token];
+    }
+
+    function tokenMappingsCount() external view override returns (uint256) {
+        return registeredTokens.length;
+    }
+
+    function tokenMappings(
+        uint256 offset,
+        uint256 limit
+    ) external view override returns (TokenMapping[] memory) {
+        if (offset >= registeredTokens.length) {
+            return new TokenMapping[](0);
+        }
+        uint256 count = registeredTokens.length - offset;
+        if (count > limit) {
+            count = limit;
+        }
+        TokenMapping[] memory page = new TokenMapping[](count);
+        for (uint256 i = 0; i < count; i++) {
+            page[i] = mappingOfL1Token[registeredTokens[offset + i]];
+        }
+        return page;
+    }
+
+    function _isRegistered(address asset) private view returns (bool) {
+        return mappingOfL1Token[asset].l1Token != address(0x0);
+    }
+
+    // Registers the token with the name and symbol of its metadata. The tokens without metadata have to be whitelisted
+    // by an admin.
+    function _registerOnFirstDeposit(address asset) private {
+        string memory name;
+        string memory symbol;
+        try IERC20Metadata(asset).name() returns (string memory n) {
+            name = n;
+        } catch {
+            revert("Token without metadata must be whitelisted.");
+        }
+        try IERC20Metadata(asset).symbol() returns (string memory s) {
+            symbol = s;
+        } catch {
+            revert("Token without metadata must be whitelisted.");
+        }
+        _grantRole(ERC20_TOKEN_ROLE, asset);
+        _registerToken(asset, name, symbol);
+    }
+
+    // Records the mapping of the token and queues the message creating its wrapped token on the L2, at the address the
+    // L2 bridge deploys it to.
+    function _registerToken(
+        address asset,
+        string memory name,
+        string memory symbol
+    ) private {
+        address l2Token = WrappedTokenAddress.compute(
+            remoteBridgeAddress,
+            asset,
+            name,
+            symbol
+        );
+        registeredTokens.push(asset);
+        mappingOfL1Token[asset] = TokenMapping(asset, l2Token, name, symbol);
+        l1TokenOfL2Token[l2Token] = asset;
+
+        bytes memory data = abi.encodeWithSelector(
+            ITokenFactory.onCreateTokenCommand.selector,
+            asset,
+            name,
+            symbol
+        );
+        queueMessage(
+            remoteBridgeAddress,
+            data,
+            uint32(Topics.MANAGEMENT),
+            0,
+            0
+        );
+        emit TokenRegistered(asset, l2Token, name, symbol);
+    }
+
     function _receiveTokens(
         address asset,
         uint256 amount,
@@ -8,12 +8,15 @@ import "@openzeppelin/contracts/proxy/utils/Initializable.sol";
 
 import "../IBridge.sol";
 import "../ITokenFactory.sol";
+import "../ITokenRegistry.sol";
+import "../WrappedTokenAddress.sol";
 import "../../messaging/messenger/CrossChainEnabledObscuro.sol";
 import "../../common/WrappedERC20.sol";
 
 contract EthereumBridge is
     IBridge,
     ITokenFactory,
+    ITokenRegistry,
     CrossChainEnabledObscuro
 {
     event CreatedWrappedToken(
@@ -29,6 +32,10 @@ contract EthereumBridge is
 
     address remoteBridgeAddress;
 
+    // the L1 tokens of the wrapped tokens, in the order they were created
+    address[] private remoteTokens;
+    mapping(address => TokenMapping) private mappingOfRemoteToken;
+
     function initialize(
         address messenger,
         address remoteBridge
@@ -42,14 +49,58 @@ contract EthereumBridge is
         string calldata name,
         string calldata symbol
     ) external onlyCrossChainSender(remoteBridgeAddress) {
-        WrappedERC20 newToken = new WrappedERC20(name, symbol);
+        // the wrapped token is deployed once, at the address the L1 bridge computed for it
+        if (remoteToLocalToken[crossChainAddress] != address(0x0)) {
+            return;
+        }
+        WrappedERC20 newToken = new WrappedERC20{
+            salt: WrappedTokenAddress.salt(crossChainAddress)
+        }(name, symbol);
         address localAddress = address(newToken);
 
         wrappedTokens[localAddress] = newToken;
         localToRemoteToken[localAddress] = crossChainAddress;
         remoteToLocalToken[crossChainAddress] = localAddress;
+        remoteTokens.push(crossChainAddress);
+        mappingOfRemoteToken[crossChainAddress] = TokenMapping(
+            crossChainAddress,
+            localAddress,
+            name,
+            symbol
+        );
 
         emit CreatedWrappedToken(crossChainAddress, localAddress, name, symbol);
+        emit TokenRegistered(crossChainAddress, localAddress, name, symbol);
+    }
+
+    function l2TokenOf(address l1Token) external view override returns (address) {
+        return remoteToLocalToken[l1Token];
+    }
+
+    function l1TokenOf(address l2Token) external view override returns (address) {
+        return localToRemoteToken[l2Token];
+    }
+
+    function tokenMappingsCount() external view override returns (uint256) {
+        return remoteTokens.length;
+    }
+
+    function tokenMappings(
+        uint256 offset,
+        uint256 limit
+    ) external view override returns (TokenMapping[] memory) {
+        if (offset >= remoteTokens.length) {
+            return new TokenMapping[](0);
+        }
+        uint256 count = remoteTokens.length - offset;
+        if (count > limit) {
+            count = limit;
+        }
+        TokenMapping[] memory page = new TokenMapping[](count);
+        for (uint256 i = 0; i < count; i++) {
+            page[i] = mappingOfRemoteToken[remoteTokens[offset + i]];
+        }
+        return page;
     }
 
     function hasTokenMapping(address wrappedToken) public view returns (bool) {
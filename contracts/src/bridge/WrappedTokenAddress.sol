@@ -0,0 +1,32 @@
+// SPDX-License-Identifier: Apache 2
+
+pragma solidity >=0.7.0 <0.9.0;
+
+import "../common/WrappedERC20.sol";
+
+// The L2 bridge deploys the wrapped tokens with CREATE2, salted with the address of the L1 token, so the address of a
+// wrapped token is known on both layers before it is deployed.
+library WrappedTokenAddress {
+    function salt(address l1Token) internal pure returns (bytes32) {
+        return bytes32(uint256(uint160(l1Token)));
+    }
+
+    // compute - returns the address of the wrapped token the L2 bridge deploys for the L1 token.
+    function compute(
+        address l2Bridge,
+        address l1Token,
+        string memory name,
+        string memory symbol
+    ) internal pure returns (address) {
+        bytes32 initCodeHash = keccak256(
+            abi.encodePacked(
+                type(WrappedERC20).creationCode,
+                abi.encode(name, symbol)
+            )
+        );
+        bytes32 hash = keccak256(
+            abi.encodePacked(bytes1(0xff), l2Bridge, salt(l1Token), initCodeHash)
+        );
+        return address(uint160(uint256(hash)));
+    }
+}
@@ -18,11 +18,16 @@ contract ManagementContract is Ownable, Initializable {
     event LogManagementContractCreated(address messageBusAddress);
     // Event to log changes to important contract addresses
     event ImportantContractAddressUpdated(string key, address newAddress);
+    // Emitted when the owner changes a network parameter. The enclaves validate the change, and apply it a fixed number of
+    // L1 blocks later (see go/enclave/governance).
+    event GovernanceParameterChanged(string name, uint256 value);
 
     mapping(address => string) private attestationRequests;
     mapping(address => bool) private attested;
     // TODO - Revisit the decision to store the host addresses in the smart contract.
     string[] private hostAddresses; // The addresses of all the Obscuro hosts on the network.
+    // The address of each attested host, so that its peers only accept it at that address.
+    mapping(address => string) private hostAddressOf;
 
     // In the near-term it is convenient to have an accessible source of truth for important contract addresses
     // TODO - this is probably not appropriate long term but currently useful for testnets. Look to remove.
@@ -38,6 +43,9 @@ contract ManagementContract is Ownable, Initializable {
 
     uint256 public lastBatchSeqNo;
 
+    // The key of the L1 cross chain messenger in the important contract addresses
+    string private constant L1_CROSS_CHAIN_MESSENGER_KEY = "L1CrossChainMessenger";
+
     Structs.RollupStorage private rollups;
     //The messageBus where messages can be sent to Obscuro
     MessageBus.IMessageBus public messageBus;
@@ -95,6 +103,7 @@ contract ManagementContract is Ownable, Initializable {
         // aggregator is now on the list of attested aggregators and its host address is available
         attested[_aggregatorID] = true;
         hostAddresses.push(_hostAddress);
+        hostAddressOf[_aggregatorID] = _hostAddress;
     }
 
     // Aggregators can request the Network Secret given an attestation request report
@@ -127,12 +136,18 @@ contract ManagementContract is Ownable, Initializable {
         attested[requesterID] = true;
         // TODO - Consider whether to remove duplicates.
         hostAddresses.push(hostAddress);
+        hostAddressOf[requesterID] = hostAddress;
     }
 
     function GetHostAddresses() public view returns (string[] memory) {
         return hostAddresses;
     }
 
+    // Accessor that returns the address registered for an attested host, empty if the host is not attested
+    function GetHostAddress(address hostID) view public returns (string memory) {
+        return hostAddressOf[hostID];
+    }
+
 
     // Accessor to check if the contract is locked or not
     function IsWithdrawalAvailable() view public returns (bool) {
@@ -149,16 +164,25 @@ contract ManagementContract is Ownable, Initializable {
         messageBus.retrieveAllFunds(msg.sender);
     }
 
-    // Function to set an important contract's address, only callable by owner
+    // Function to set an important contract's address, only callable by owner. The L1 cross chain messenger is also
+    // the only contract allowed to record the relays of the messages on the message bus.
     function SetImportantContractAddress(string memory key, address newAddress) public onlyOwner {
         if (importantContractAddresses[key] == address(0)) {
             importantContractKeys.push(key);
         }
         importantContractAddresses[key] = newAddress;
+        if (keccak256(bytes(key)) == keccak256(bytes(L1_CROSS_CHAIN_MESSENGER_KEY))) {
+            messageBus.setMessenger(newAddress);
+        }
         emit ImportantContractAddressUpdated(key, newAddress);
     }
 
     function GetImportantContractKeys() public view returns(string[] memory) {
         return importantContractKeys;
     }
+
+    // Function to change a network parameter (baseFee, batchGasLimit or rollupInterval), only callable by owner
+    function SetGovernanceParameter(string memory name, uint256 value) public onlyOwner {
+        emit GovernanceParameterChanged(name, value);
+    }
 }
@@ -27,6 +27,20 @@ interface IMessageBus {
         uint256 amount
     );
 
+    // The event emitted when the relay of a message is recorded. The enclave pays the relay fee attached to the
+    // message on the L2 to the relayer.
+    event MessageRelayed
+    (
+        bytes32 msgHash,
+        address relayer
+    );
+
+    // The event emitted when the messenger allowed to record the relays changes.
+    event MessengerUpdated
+    (
+        address messenger
+    );
+
     // This method is called from contracts to publish messages to the other linked message bus.
     // nonce - This is provided and serves as deduplication nonce. It can also be used to group a batch of messages together.
     // topic - This is the topic for which the payload is published. 
@@ -56,6 +70,13 @@ interface IMessageBus {
     // and returns true only if the challenge period for the message has passed.
     function verifyMessageFinalized(Structs.CrossChainMessage calldata crossChainMessage) external view returns (bool);
     
+    // Sets the messenger allowed to record the relays. Only callable by the owner of the message bus.
+    function setMessenger(address messengerAddress) external;
+
+    // Records the relayer of a finalized message, only once per message. It is called by the messenger when it
+    // delivers a message, so that the relayer is paid the relay fee attached to the message on the other layer.
+    function recordRelay(Structs.CrossChainMessage calldata crossChainMessage, address relayer) external;
+
     // Returns the time when a message is final (when the rollup challenge period has passed). If the message was never submitted the call will revert.
     function getMessageTimeOfFinality(Structs.CrossChainMessage calldata crossChainMessage) external view returns (uint256);
 
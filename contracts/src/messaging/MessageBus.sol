@@ -18,6 +18,12 @@ contract MessageBus is IMessageBus, Ownable {
     // The stored messages, currently unconsumed.
     mapping(address => mapping(uint32 => Structs.CrossChainMessage[])) messages;
 
+    // The relayers of the delivered messages, recorded once per message.
+    mapping(bytes32 => address) messageRelayers;
+
+    // The messenger that delivers the messages, the only caller allowed to record their relayers.
+    address public messenger;
+
     // This stores the current sequence number that each address has reached.
     // Whenever a message is published, this sequence number increments.
     // This gives ordering to messages, guaranteed by us.
@@ -88,6 +94,29 @@ contract MessageBus is IMessageBus, Ownable {
 
     }
 
+    // Sets the messenger allowed to record the relayers of the messages it delivers.
+    function setMessenger(
+        address messengerAddress
+    ) external override onlyOwner {
+        messenger = messengerAddress;
+        emit MessengerUpdated(messengerAddress);
+    }
+
+    // Records the relayer of a finalized message, only once per message. Only the messenger can record a relay, when it
+    // delivers the message, so the relayer is the account that called the delivery.
+    function recordRelay(
+        Structs.CrossChainMessage calldata crossChainMessage,
+        address relayer
+    ) external override {
+        require(msg.sender == messenger, "Only the messenger can record relays.");
+        require(this.verifyMessageFinalized(crossChainMessage), "Message not found or finalized.");
+        bytes32 msgHash = keccak256(abi.encode(crossChainMessage));
+        require(messageRelayers[msgHash] == address(0), "Relay already recorded.");
+
+        messageRelayers[msgHash] = relayer;
+        emit MessageRelayed(msgHash, relayer);
+    }
+
     // Returns the time when a message is final (when the rollup challenge period has passed). If the message was never submitted the call will revert.
     function getMessageTimeOfFinality(
         Structs.CrossChainMessage calldata crossChainMessage
@@ -14,7 +14,9 @@ import "@openzeppelin/contracts/proxy/utils/Initializable.sol";
 // It's also responsible for marking messages as consumed whenever a successful call happens. This means
 // that CrossChainEnabled contracts need not bother with anything related to verification, apart from confirming
 // from whom the messages are coming from.
-// Notice that this Messenger has no restrictions on who can relay messages, nor does it have any understanding of fees.
+// Notice that this Messenger has no restrictions on who can relay messages. It records the relayer on the message bus, which
+// the enclave pays the relay fee attached to the message on the L2. The message bus only accepts the relays recorded by the
+// messenger registered with it (the L1CrossChainMessenger of the management contract).
 // You can opt in to deploy a customer messenger for your cross chain dApp with more specialized logic.
 contract CrossChainMessenger is ICrossChainMessenger, Initializable {
     error CallFailed(bytes error);
@@ -83,5 +85,9 @@ contract CrossChainMessenger is ICrossChainMessenger, Initializable {
         }
 
         crossChainSender = address(0x0);
+
+        // the relayer is paid the relay fee of the message on the other layer. A failure to record it must not revert
+        // the delivery, e.g. if another messenger recorded the relay first.
+        try messageBusContract.recordRelay(message, msg.sender) {} catch {}
     }
 }
@@ -8,6 +8,9 @@ import * as fs from "fs";
 export const abiExportPath = "./exported/";
 export const bytecodeExporterPath = "./exported/"
 
+// The system contracts that also get the typed TEN bindings of tools/bindgen, next to their abigen bindings.
+const tenBindingsContracts = ["ManagementContract", "MessageBus", "ObscuroBridge", "EthereumBridge", "CrossChainMessenger"];
+
 task("generate-abi-bindings", "Using the evm bytecode and exported abi's of the contract export go bindings.")
 .addFlag('noCompile', 'Don\'t compile before running this task')
 .addParam('outputDir', 'Location to dump bindings')
@@ -68,6 +71,18 @@ task("generate-abi-bindings", "Using the evm bytecode and exported abi's of the
     
       if (abigenSpawn.status == 0) {
         console.log(`Successfully generated go binding for ${sourceName}`);
+        if (tenBindingsContracts.includes(contractName)) {
+          const bindgenSpawn = spawnSync("go", [
+            "run", "./tools/bindgen",
+            `--abi=${abiFilePath}`,
+            `--pkg=${contractName}`,
+            `--out=${path.resolve(outputFileDir, contractName + "_ten.go")}`],
+            { cwd: path.resolve(hre.config.paths.root, "..") }
+          );
+          if (bindgenSpawn.status != 0) {
+            console.log(`Error[${bindgenSpawn.status}] generating TEN bindings for ${sourceName};\n   Output: ${bindgenSpawn.stderr}`);
+          }
+        }
       } else {
         console.log(`Error[${abigenSpawn.status}] generating go binding for ${sourceName};\n   Output: ${abigenSpawn.stderr}`);
       }
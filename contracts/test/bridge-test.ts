@@ -313,6 +313,87 @@ describe("Bridge", function () {
       })).to.be.revertedWith("Message not found or finalized.");
   });
 
+  it("The first deposit of a token does not register it unless the auto registration is enabled.", async function () {
+      const [owner] = await ethers.getSigners();
+
+      const wrappedERC20 = await hre.ethers.getContractFactory("WrappedERC20");
+      const l1Erc20 : WrappedERC20 = await wrappedERC20.deploy("ZZZ", "ZZZ");
+      await (await l1Erc20.issueFor(owner.address, 1_000)).wait();
+      await (await l1Erc20.approve(bridgeL1.address, 1_000)).wait();
+
+      await expect(bridgeL1.sendERC20(l1Erc20.address, 1_000, owner.address))
+        .revertedWith("This address has not been given a type and is thus considered not whitelisted.");
+      await expect(await bridgeL1.l2TokenOf(l1Erc20.address)).to.hexEqual(ethers.constants.AddressZero);
+
+      const [, user] = await ethers.getSigners();
+      await expect(bridgeL1.connect(user).setAutoRegistration(true)).to.be.reverted;
+  });
+
+  it("The first deposit of a token registers it and creates its wrapped token at the predicted address.", async function () {
+      const [owner] = await ethers.getSigners();
+      await (await bridgeL1.setAutoRegistration(true)).wait();
+
+      const wrappedERC20 = await hre.ethers.getContractFactory("WrappedERC20");
+      const l1Erc20 : WrappedERC20 = await wrappedERC20.deploy("ZZZ", "ZZZ");
+      await (await l1Erc20.issueFor(owner.address, 1_000)).wait();
+      await (await l1Erc20.approve(bridgeL1.address, 1_000)).wait();
+
+      const depositTx = bridgeL1.sendERC20(l1Erc20.address, 1_000, owner.address);
+      await expect(depositTx, "The first deposit of a token should register it").to.emit(bridgeL1, "TokenRegistered");
+
+      const predictedL2Token = await bridgeL1.l2TokenOf(l1Erc20.address);
+      await expect(predictedL2Token).to.not.hexEqual(ethers.constants.AddressZero);
+      await expect(await bridgeL1.l1TokenOf(predictedL2Token)).to.hexEqual(l1Erc20.address);
+
+      const messages = await submitMessagesFromTx(await depositTx);
+      await expect(messages, "The first deposit should create the wrapped token and transfer the assets").not.null;
+      // the wrapped token is created before the assets are received
+      for (const binding of messages!.bindings) {
+        await (await binding.messenger.relayMessage(binding.msg)).wait();
+      }
+
+      await expect(await bridgeL2.l2TokenOf(l1Erc20.address)).to.hexEqual(predictedL2Token);
+      await expect(await bridgeL2.l1TokenOf(predictedL2Token)).to.hexEqual(l1Erc20.address);
+      const l2Erc20 : WrappedERC20 = wrappedERC20.attach(predictedL2Token);
+      await expect(await l2Erc20.balanceOf(owner.address)).to.equal(1_000);
+
+      for (const registry of [bridgeL1, bridgeL2]) {
+        await expect(await registry.tokenMappingsCount()).to.equal(1);
+        const mappings = await registry.tokenMappings(0, 10);
+        await expect(mappings.length).to.equal(1);
+        await expect(mappings[0].l1Token).to.hexEqual(l1Erc20.address);
+        await expect(mappings[0].l2Token).to.hexEqual(predictedL2Token);
+        await expect(mappings[0].symbol).to.equal("ZZZ");
+        await expect((await registry.tokenMappings(1, 10)).length).to.equal(0);
+      }
+
+      // a second deposit does not register the token again
+      await (await l1Erc20.issueFor(owner.address, 1_000)).wait();
+      await (await l1Erc20.approve(bridgeL1.address, 1_000)).wait();
+      await expect(bridgeL1.sendERC20(l1Erc20.address, 1_000, owner.address)).to.not.emit(bridgeL1, "TokenRegistered");
+  });
+
+  it("A removed token is not registered again by a deposit.", async function () {
+      const [owner] = await ethers.getSigners();
+      await (await bridgeL1.setAutoRegistration(true)).wait();
+
+      const wrappedERC20 = await hre.ethers.getContractFactory("WrappedERC20");
+      const l1Erc20 : WrappedERC20 = await wrappedERC20.deploy("ZZZ", "ZZZ");
+      await (await bridgeL1.whitelistToken(l1Erc20.address, "o.ZZZ", "o.ZZZ")).wait();
+      await (await bridgeL1.removeToken(l1Erc20.address)).wait();
+
+      await (await l1Erc20.issueFor(owner.address, 1_000)).wait();
+      await (await l1Erc20.approve(bridgeL1.address, 1_000)).wait();
+      await expect(bridgeL1.sendERC20(l1Erc20.address, 1_000, owner.address))
+        .revertedWith("This address has not been given a type and is thus considered not whitelisted.");
+
+      // whitelisting it again keeps its wrapped token
+      const l2Token = await bridgeL1.l2TokenOf(l1Erc20.address);
+      await expect(bridgeL1.whitelistToken(l1Erc20.address, "o.ZZZ", "o.ZZZ")).to.not.emit(busL1, "LogMessagePublished");
+      await expect(await bridgeL1.l2TokenOf(l1Erc20.address)).to.hexEqual(l2Token);
+      await expect(await bridgeL1.tokenMappingsCount()).to.equal(1);
+  });
+
   it("MessageBus retrieveAllFunds method should allow owner to extract all native funds from the message bus", async function() {
     const [owner] = await ethers.getSigners();
     const amount = ethers.utils.parseEther("0.01");
@@ -0,0 +1,199 @@
+package common
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
+)
+
+// AccountProofQuery is the argument of the AccountProofCQMethod private custom query. Only the entries keyed by the
+// requesting address in the mappings of the given contracts can be proven (e.g. its balance in an ERC20 contract), so
+// that the proofs do not disclose the state of the other accounts.
+type AccountProofQuery struct {
+	Address    common.Address      `json:"address"`
+	BatchSeqNo tentypes.BatchSeqNo `json:"batchSeqNo"` // usually the last batch of a rollup that published a state root checkpoint
+	Mappings   []MappingQuery      `json:"mappings,omitempty"`
+}
+
+// MappingQuery lists the storage slots of the mappings of a contract that are keyed by address
+type MappingQuery struct {
+	Contract common.Address   `json:"contract"`
+	Slots    []hexutil.Uint64 `json:"slots"`
+}
+
+// AccountProof is the Merkle proof of the state of an account, and of its entries in contract mappings, against the
+// state root of a batch. The state roots are published to the L1 in the rollup headers every few rollups, so the proof
+// can be checked by anyone with access to the L1 without trusting the node that produced it.
+type AccountProof struct {
+	Address      common.Address      `json:"address"`
+	BatchSeqNo   tentypes.BatchSeqNo `json:"batchSeqNo"`
+	StateRoot    common.Hash         `json:"stateRoot"`
+	Balance      *hexutil.Big        `json:"balance"`
+	Nonce        hexutil.Uint64      `json:"nonce"`
+	CodeHash     common.Hash         `json:"codeHash"`
+	StorageHash  common.Hash         `json:"storageHash"`
+	AccountProof []hexutil.Bytes     `json:"accountProof"`
+	Mappings     []MappingEntryProof `json:"mappings,omitempty"`
+}
+
+// MappingEntryProof is the Merkle proof of the entry keyed by the account in a mapping of a contract. The proof of the
+// contract account against the state root is included, since the contract's storage root is needed to check the entry.
+type MappingEntryProof struct {
+	Contract      common.Address  `json:"contract"`
+	Slot          hexutil.Uint64  `json:"slot"`
+	Key           common.Hash     `json:"key"`
+	Value         common.Hash     `json:"value"`
+	StorageHash   common.Hash     `json:"storageHash"`
+	ContractProof []hexutil.Bytes `json:"contractProof"`
+	Proof         []hexutil.Bytes `json:"proof"`
+}
+
+// ProofQuery is the argument of the ProofCQMethod private custom query. Like the balance returned by eth_getBalance, the
+// proofs are only returned to the account, or to the deployer of a contract.
+type ProofQuery struct {
+	Address     common.Address        `json:"address"`
+	StorageKeys []common.Hash         `json:"storageKeys"`
+	Block       rpc.BlockNumberOrHash `json:"block"`
+}
+
+// Proof is the Merkle proof of the state of an account and of its storage slots against the state root of a batch, in
+// the format of eth_getProof, with the batch it was taken at
+type Proof struct {
+	Address      common.Address      `json:"address"`
+	AccountProof []hexutil.Bytes     `json:"accountProof"`
+	Balance      *hexutil.Big        `json:"balance"`
+	CodeHash     common.Hash         `json:"codeHash"`
+	Nonce        hexutil.Uint64      `json:"nonce"`
+	StorageHash  common.Hash         `json:"storageHash"`
+	StorageProof []StorageProof      `json:"storageProof"`
+	BatchHash    common.Hash         `json:"batchHash"`
+	BatchSeqNo   tentypes.BatchSeqNo `json:"batchSeqNo"`
+	StateRoot    common.Hash         `json:"stateRoot"`
+}
+
+// StorageProof is the Merkle proof of a storage slot against the storage hash of its account
+type StorageProof struct {
+	Key   common.Hash     `json:"key"`
+	Value *hexutil.Big    `json:"value"`
+	Proof []hexutil.Bytes `json:"proof"`
+}
+
+// MappingKey returns the storage key of the entry keyed by address in the mapping stored at the given slot, following
+// the Solidity storage layout.
+func MappingKey(address common.Address, slot uint64) common.Hash {
+	return crypto.Keccak256Hash(
+		common.LeftPadBytes(address.Bytes(), common.HashLength),
+		common.LeftPadBytes(new(big.Int).SetUint64(slot).Bytes(), common.HashLength),
+	)
+}
+
+// Verify checks the proofs of the account and of its mapping entries against the state root
+func (p *AccountProof) Verify() error {
+	account, err := verifyAccount(p.StateRoot, p.Address, p.AccountProof)
+	if err != nil {
+		return err
+	}
+	if account.Nonce != uint64(p.Nonce) || account.Balance.Cmp(p.Balance.ToInt()) != 0 ||
+		account.Root != p.StorageHash || !bytes.Equal(account.CodeHash, p.CodeHash.Bytes()) {
+		return fmt.Errorf("the state of account %s does not match its proof", p.Address)
+	}
+
+	for _, entry := range p.Mappings {
+		contract, err := verifyAccount(p.StateRoot, entry.Contract, entry.ContractProof)
+		if err != nil {
+			return err
+		}
+		if contract.Root != entry.StorageHash {
+			return fmt.Errorf("the storage hash of contract %s does not match its proof", entry.Contract)
+		}
+		if entry.Key != MappingKey(p.Address, uint64(entry.Slot)) {
+			return fmt.Errorf("the key of slot %d of contract %s is not keyed by %s", entry.Slot, entry.Contract, p.Address)
+		}
+
+		value, err := verifySlot(entry.StorageHash, entry.Key, entry.Proof)
+		if err != nil {
+			return fmt.Errorf("invalid proof of slot %d of contract %s - %w", entry.Slot, entry.Contract, err)
+		}
+		if value != entry.Value {
+			return fmt.Errorf("the value of slot %d of contract %s does not match its proof", entry.Slot, entry.Contract)
+		}
+	}
+	return nil
+}
+
+// Verify checks the proofs of the account and of its storage slots against the state root
+func (p *Proof) Verify() error {
+	account, err := verifyAccount(p.StateRoot, p.Address, p.AccountProof)
+	if err != nil {
+		return err
+	}
+	if account.Nonce != uint64(p.Nonce) || account.Balance.Cmp(p.Balance.ToInt()) != 0 ||
+		account.Root != p.StorageHash || !bytes.Equal(account.CodeHash, p.CodeHash.Bytes()) {
+		return fmt.Errorf("the state of account %s does not match its proof", p.Address)
+	}
+
+	for _, slot := range p.StorageProof {
+		value, err := verifySlot(p.StorageHash, slot.Key, slot.Proof)
+		if err != nil {
+			return fmt.Errorf("invalid proof of slot %s - %w", slot.Key, err)
+		}
+		if value.Big().Cmp(slot.Value.ToInt()) != 0 {
+			return fmt.Errorf("the value of slot %s does not match its proof", slot.Key)
+		}
+	}
+	return nil
+}
+
+// returns the account proven against the state root. The accounts that do not exist are returned empty
+func verifyAccount(stateRoot common.Hash, address common.Address, proof []hexutil.Bytes) (*types.StateAccount, error) {
+	value, err := trie.VerifyProof(stateRoot, crypto.Keccak256(address.Bytes()), toProofDB(proof))
+	if err != nil {
+		return nil, fmt.Errorf("invalid proof of account %s - %w", address, err)
+	}
+	if len(value) == 0 {
+		return &types.StateAccount{Balance: new(big.Int), Root: types.EmptyRootHash, CodeHash: types.EmptyCodeHash.Bytes()}, nil
+	}
+	var account types.StateAccount
+	if err := rlp.DecodeBytes(value, &account); err != nil {
+		return nil, fmt.Errorf("could not decode account %s - %w", address, err)
+	}
+	return &account, nil
+}
+
+// returns the value of the storage slot proven against the storage hash. The slots that were never written are zero,
+// like all the slots of the accounts without storage
+func verifySlot(storageHash common.Hash, key common.Hash, proof []hexutil.Bytes) (common.Hash, error) {
+	if storageHash == types.EmptyRootHash {
+		// the accounts without storage have no storage trie to prove the slot against
+		return common.Hash{}, nil
+	}
+	value, err := trie.VerifyProof(storageHash, crypto.Keccak256(key.Bytes()), toProofDB(proof))
+	if err != nil {
+		return common.Hash{}, err
+	}
+	var content []byte
+	if len(value) > 0 {
+		if _, content, _, err = rlp.Split(value); err != nil {
+			return common.Hash{}, fmt.Errorf("could not decode the slot - %w", err)
+		}
+	}
+	return common.BytesToHash(content), nil
+}
+
+func toProofDB(proof []hexutil.Bytes) *memorydb.Database {
+	db := memorydb.New()
+	for _, node := range proof {
+		_ = db.Put(crypto.Keccak256(node), node) // writes to a memory db do not fail
+	}
+	return db
+}
@@ -0,0 +1,184 @@
+package common
+
+import (
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	proofAccount  = gethcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	proofContract = gethcommon.HexToAddress("0x2000000000000000000000000000000000000002")
+)
+
+// returns the proof of the balance of the account in the mapping at slot 0 of the contract
+func newTestAccountProof(t *testing.T) *AccountProof {
+	stateDB, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	stateDB.SetBalance(proofAccount, big.NewInt(1000))
+	stateDB.SetNonce(proofAccount, 3)
+	stateDB.SetCode(proofContract, []byte{0x60, 0x00})
+	stateDB.SetState(proofContract, MappingKey(proofAccount, 0), gethcommon.BigToHash(big.NewInt(42)))
+	root, err := stateDB.Commit(0, true)
+	require.NoError(t, err)
+	stateDB, err = state.New(root, stateDB.Database(), nil)
+	require.NoError(t, err)
+
+	accountProof, err := stateDB.GetProof(proofAccount)
+	require.NoError(t, err)
+	contractProof, err := stateDB.GetProof(proofContract)
+	require.NoError(t, err)
+	storageTrie, err := stateDB.StorageTrie(proofContract)
+	require.NoError(t, err)
+	key := MappingKey(proofAccount, 0)
+	storageProof, err := stateDB.GetStorageProof(proofContract, key)
+	require.NoError(t, err)
+
+	return &AccountProof{
+		Address:      proofAccount,
+		BatchSeqNo:   1,
+		StateRoot:    root,
+		Balance:      (*hexutil.Big)(big.NewInt(1000)),
+		Nonce:        3,
+		CodeHash:     types.EmptyCodeHash,
+		StorageHash:  types.EmptyRootHash,
+		AccountProof: toTestProof(accountProof),
+		Mappings: []MappingEntryProof{{
+			Contract:      proofContract,
+			Slot:          0,
+			Key:           key,
+			Value:         gethcommon.BigToHash(big.NewInt(42)),
+			StorageHash:   storageTrie.Hash(),
+			ContractProof: toTestProof(contractProof),
+			Proof:         toTestProof(storageProof),
+		}},
+	}
+}
+
+func toTestProof(proof [][]byte) []hexutil.Bytes {
+	result := make([]hexutil.Bytes, len(proof))
+	for i, node := range proof {
+		result[i] = node
+	}
+	return result
+}
+
+func TestVerifiesAccountProofs(t *testing.T) {
+	require.NoError(t, newTestAccountProof(t).Verify())
+}
+
+func TestRejectsTamperedAccountProofs(t *testing.T) {
+	tests := map[string]func(p *AccountProof){
+		"balance": func(p *AccountProof) {
+			p.Balance = (*hexutil.Big)(big.NewInt(1001))
+		},
+		"nonce": func(p *AccountProof) {
+			p.Nonce = 4
+		},
+		"state root": func(p *AccountProof) {
+			p.StateRoot = gethcommon.HexToHash("0x01")
+		},
+		"mapping value": func(p *AccountProof) {
+			p.Mappings[0].Value = gethcommon.BigToHash(big.NewInt(43))
+		},
+		"mapping key of another account": func(p *AccountProof) {
+			p.Mappings[0].Key = MappingKey(proofContract, 0)
+		},
+		"contract storage hash": func(p *AccountProof) {
+			p.Mappings[0].StorageHash = types.EmptyRootHash
+		},
+	}
+
+	for name, tamper := range tests {
+		t.Run(name, func(t *testing.T) {
+			proof := newTestAccountProof(t)
+			tamper(proof)
+			require.Error(t, proof.Verify())
+		})
+	}
+}
+
+func TestVerifiesTheAbsenceOfAccounts(t *testing.T) {
+	absent := gethcommon.HexToAddress("0x3000000000000000000000000000000000000003")
+	stateDB, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	stateDB.SetBalance(proofAccount, big.NewInt(1000))
+	root, err := stateDB.Commit(0, true)
+	require.NoError(t, err)
+	stateDB, err = state.New(root, stateDB.Database(), nil)
+	require.NoError(t, err)
+	absenceProof, err := stateDB.GetProof(absent)
+	require.NoError(t, err)
+
+	proof := &AccountProof{
+		Address:      absent,
+		StateRoot:    root,
+		Balance:      (*hexutil.Big)(new(big.Int)),
+		CodeHash:     types.EmptyCodeHash,
+		StorageHash:  types.EmptyRootHash,
+		AccountProof: toTestProof(absenceProof),
+	}
+	require.NoError(t, proof.Verify())
+
+	proof.Balance = (*hexutil.Big)(big.NewInt(1))
+	require.Error(t, proof.Verify())
+}
+
+func TestVerifiesStorageProofs(t *testing.T) {
+	written, unwritten := gethcommon.HexToHash("0x01"), gethcommon.HexToHash("0x02")
+	stateDB, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	stateDB.SetBalance(proofAccount, big.NewInt(1000))
+	stateDB.SetCode(proofContract, []byte{0x60, 0x00})
+	stateDB.SetState(proofContract, written, gethcommon.BigToHash(big.NewInt(42)))
+	root, err := stateDB.Commit(0, true)
+	require.NoError(t, err)
+	stateDB, err = state.New(root, stateDB.Database(), nil)
+	require.NoError(t, err)
+
+	contractProof, err := stateDB.GetProof(proofContract)
+	require.NoError(t, err)
+	storageTrie, err := stateDB.StorageTrie(proofContract)
+	require.NoError(t, err)
+	proof := &Proof{
+		Address:      proofContract,
+		StateRoot:    root,
+		Balance:      (*hexutil.Big)(new(big.Int)),
+		CodeHash:     stateDB.GetCodeHash(proofContract),
+		StorageHash:  storageTrie.Hash(),
+		AccountProof: toTestProof(contractProof),
+	}
+	for _, key := range []gethcommon.Hash{written, unwritten} {
+		storageProof, err := stateDB.GetStorageProof(proofContract, key)
+		require.NoError(t, err)
+		proof.StorageProof = append(proof.StorageProof, StorageProof{
+			Key:   key,
+			Value: (*hexutil.Big)(stateDB.GetState(proofContract, key).Big()),
+			Proof: toTestProof(storageProof),
+		})
+	}
+	require.NoError(t, proof.Verify())
+
+	proof.StorageProof[1].Value = (*hexutil.Big)(big.NewInt(42))
+	require.Error(t, proof.Verify())
+
+	// the slots of an account without storage are zero, and need no proof
+	accountProof, err := stateDB.GetProof(proofAccount)
+	require.NoError(t, err)
+	proof = &Proof{
+		Address:      proofAccount,
+		StateRoot:    root,
+		Balance:      (*hexutil.Big)(big.NewInt(1000)),
+		CodeHash:     types.EmptyCodeHash,
+		StorageHash:  types.EmptyRootHash,
+		AccountProof: toTestProof(accountProof),
+		StorageProof: []StorageProof{{Key: written, Value: (*hexutil.Big)(new(big.Int))}},
+	}
+	require.NoError(t, proof.Verify())
+}
@@ -0,0 +1,108 @@
+package common
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// ReadOnlyModeRequest switches the sequencer in or out of the read-only mode, in which it stops producing batches and
+// publishing rollups but keeps serving the RPC requests. It is signed with the key of the sequencer admin configured in
+// the host.
+type ReadOnlyModeRequest struct {
+	ReadOnly  bool           `json:"readOnly"`
+	Reason    string         `json:"reason"`
+	Timestamp hexutil.Uint64 `json:"timestamp"` // the unix time of the request, which expires shortly after
+	Signature hexutil.Bytes  `json:"signature"`
+}
+
+// Message returns the text signed by the sequencer admin, with the personal_sign scheme so any wallet can sign it
+func (r *ReadOnlyModeRequest) Message() string {
+	return fmt.Sprintf("Set the read-only mode of the TEN sequencer to %t at %d: %s", r.ReadOnly, r.Timestamp, r.Reason)
+}
+
+// Sign signs the request with the key of the sequencer admin
+func (r *ReadOnlyModeRequest) Sign(key *ecdsa.PrivateKey) error {
+	signature, err := signText(r.Message(), key)
+	if err != nil {
+		return fmt.Errorf("could not sign the read-only mode request - %w", err)
+	}
+	r.Signature = signature
+	return nil
+}
+
+// Signer returns the address of the account that signed the request
+func (r *ReadOnlyModeRequest) Signer() (gethcommon.Address, error) {
+	return recoverTextSigner(r.Message(), r.Signature)
+}
+
+// PromoteEnclaveRequest makes a standby enclave of the sequencer host the active one, which produces the batches and the
+// rollups. The enclaves are numbered from 0, the primary enclave, in the order of their configuration. It is signed with
+// the key of the sequencer admin configured in the host.
+type PromoteEnclaveRequest struct {
+	Enclave   hexutil.Uint64 `json:"enclave"`
+	Timestamp hexutil.Uint64 `json:"timestamp"` // the unix time of the request, which expires shortly after
+	Signature hexutil.Bytes  `json:"signature"`
+}
+
+// Message returns the text signed by the sequencer admin, with the personal_sign scheme so any wallet can sign it
+func (r *PromoteEnclaveRequest) Message() string {
+	return fmt.Sprintf("Promote the enclave %d of the TEN sequencer at %d", r.Enclave, r.Timestamp)
+}
+
+// Sign signs the request with the key of the sequencer admin
+func (r *PromoteEnclaveRequest) Sign(key *ecdsa.PrivateKey) error {
+	signature, err := signText(r.Message(), key)
+	if err != nil {
+		return fmt.Errorf("could not sign the enclave promotion request - %w", err)
+	}
+	r.Signature = signature
+	return nil
+}
+
+// Signer returns the address of the account that signed the request
+func (r *PromoteEnclaveRequest) Signer() (gethcommon.Address, error) {
+	return recoverTextSigner(r.Message(), r.Signature)
+}
+
+// signs the text with the personal_sign scheme, with a recovery id of 27 or 28 like the wallets
+func signText(text string, key *ecdsa.PrivateKey) ([]byte, error) {
+	signature, err := crypto.Sign(accounts.TextHash([]byte(text)), key)
+	if err != nil {
+		return nil, err
+	}
+	signature[crypto.RecoveryIDOffset] += 27
+	return signature, nil
+}
+
+// returns the address of the account that signed the text with the personal_sign scheme
+func recoverTextSigner(text string, signature []byte) (gethcommon.Address, error) {
+	pubKey, err := recoverTextSignerKey(text, signature)
+	if err != nil {
+		return gethcommon.Address{}, err
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
+
+// recovers the public key of the signer of the text, signed with the personal_sign scheme
+func recoverTextSignerKey(text string, signature []byte) (*ecdsa.PublicKey, error) {
+	if len(signature) != crypto.SignatureLength {
+		return nil, fmt.Errorf("invalid signature length %d", len(signature))
+	}
+	normalized := make([]byte, crypto.SignatureLength)
+	copy(normalized, signature)
+	// wallets produce a recovery id of 27 or 28
+	if normalized[crypto.RecoveryIDOffset] >= 27 {
+		normalized[crypto.RecoveryIDOffset] -= 27
+	}
+	pubKey, err := crypto.SigToPub(accounts.TextHash([]byte(text)), normalized)
+	if err != nil {
+		return nil, fmt.Errorf("could not recover the signer - %w", err)
+	}
+	return pubKey, nil
+}
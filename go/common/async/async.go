@@ -1,7 +1,10 @@
 package async
 
 import (
+	"context"
 	"time"
+
+	"github.com/ten-protocol/go-ten/go/common/clock"
 )
 
 type (
@@ -10,10 +13,27 @@ type (
 
 // Schedule runs the function after the delay
 func Schedule(delay time.Duration, fun ScheduledFunc) {
-	ticker := time.NewTicker(delay)
+	ScheduleWithClock(clock.New(), delay, fun)
+}
+
+// ScheduleWithClock runs the function once the clock moved forward by the delay
+func ScheduleWithClock(c clock.Clock, delay time.Duration, fun ScheduledFunc) {
+	after := c.After(delay)
 	go func() {
-		<-ticker.C
-		ticker.Stop()
+		<-after
 		fun()
 	}()
 }
+
+// ScheduleWithContext runs the function after the delay, unless the context is done by then
+func ScheduleWithContext(ctx context.Context, delay time.Duration, fun ScheduledFunc) {
+	go func() {
+		timer := time.NewTimer(delay)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			fun()
+		case <-ctx.Done():
+		}
+	}()
+}
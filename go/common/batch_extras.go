@@ -0,0 +1,98 @@
+package common
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BatchExtraKey identifies an entry of the extras of a batch header
+type BatchExtraKey uint64
+
+// The registered keys of the extras of the batch headers. A key is never reused, even once its feature is dropped, so
+// that the old batches keep their meaning.
+const (
+	PaymasterExtraKey  BatchExtraKey = 1 // the data of the paymasters sponsoring the transactions of the batch
+	RandomnessExtraKey BatchExtraKey = 2 // the output of a randomness beacon
+	DAHintsExtraKey    BatchExtraKey = 3 // the hints on where the data of the batch is available
+)
+
+// BatchExtraKeys are the names of the registered keys. Only the registered keys can be set on a batch
+var BatchExtraKeys = map[BatchExtraKey]string{
+	PaymasterExtraKey:  "paymaster",
+	RandomnessExtraKey: "randomness",
+	DAHintsExtraKey:    "daHints",
+}
+
+const (
+	// MaxBatchExtras is the max number of extras of a batch header
+	MaxBatchExtras = 16
+	// MaxBatchExtraSize is the max size of the value of an extra, in bytes
+	MaxBatchExtraSize = 1024
+)
+
+// BatchExtra is an entry of the extras of a batch header
+type BatchExtra struct {
+	Key   BatchExtraKey `json:"key"`
+	Value hexutil.Bytes `json:"value"`
+}
+
+// BatchExtras is the extensible section of the batch header, where the new features add their data without changing the
+// layout of the header. The entries are sorted by key, with a single entry per key, so a header has one encoding.
+//
+// The extras are hashed with the header. A header without extras is encoded and hashed as before they existed, and the
+// nodes keep the entries of the keys they don't know, so a batch using a new feature is still parsed and relayed by the
+// nodes that predate it.
+type BatchExtras []BatchExtra
+
+// Get returns the value of the key, if set
+func (e BatchExtras) Get(key BatchExtraKey) ([]byte, bool) {
+	i := sort.Search(len(e), func(i int) bool { return e[i].Key >= key })
+	if i < len(e) && e[i].Key == key {
+		return e[i].Value, true
+	}
+	return nil, false
+}
+
+// With returns a copy of the extras with the value set for the registered key. An empty value removes the key.
+func (e BatchExtras) With(key BatchExtraKey, value []byte) (BatchExtras, error) {
+	if _, found := BatchExtraKeys[key]; !found {
+		return nil, fmt.Errorf("unregistered batch extra key %d", key)
+	}
+	extras := make(BatchExtras, 0, len(e)+1)
+	for _, extra := range e {
+		if extra.Key != key {
+			extras = append(extras, extra)
+		}
+	}
+	if len(value) > 0 {
+		extras = append(extras, BatchExtra{Key: key, Value: value})
+		sort.Slice(extras, func(i, j int) bool { return extras[i].Key < extras[j].Key })
+	}
+	if len(extras) == 0 {
+		return nil, nil
+	}
+	if err := extras.Validate(); err != nil {
+		return nil, err
+	}
+	return extras, nil
+}
+
+// Validate checks that the extras are in their canonical form and within their bounds. The keys that are not registered
+// are accepted, as they may belong to a newer version of the protocol.
+func (e BatchExtras) Validate() error {
+	if len(e) > MaxBatchExtras {
+		return fmt.Errorf("too many batch extras: %d, the max is %d", len(e), MaxBatchExtras)
+	}
+	for i, extra := range e {
+		if i > 0 && extra.Key <= e[i-1].Key {
+			return errors.New("the batch extras are not sorted by key, or hold a key twice")
+		}
+		if len(extra.Value) == 0 || len(extra.Value) > MaxBatchExtraSize {
+			return fmt.Errorf("the batch extra %d has %d bytes, it must have between 1 and %d", extra.Key, len(extra.Value), MaxBatchExtraSize)
+		}
+	}
+	return nil
+}
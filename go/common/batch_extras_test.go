@@ -0,0 +1,54 @@
+package common
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBatchExtras(t *testing.T) {
+	extras, err := BatchExtras(nil).With(DAHintsExtraKey, []byte("hint"))
+	require.NoError(t, err)
+	extras, err = extras.With(PaymasterExtraKey, []byte("paymaster"))
+	require.NoError(t, err)
+	require.Equal(t, BatchExtras{{Key: PaymasterExtraKey, Value: []byte("paymaster")}, {Key: DAHintsExtraKey, Value: []byte("hint")}}, extras)
+
+	value, found := extras.Get(DAHintsExtraKey)
+	require.True(t, found)
+	require.Equal(t, []byte("hint"), value)
+	_, found = extras.Get(RandomnessExtraKey)
+	require.False(t, found)
+
+	// setting a key replaces its value, and an empty value removes it
+	replaced, err := extras.With(DAHintsExtraKey, []byte("other hint"))
+	require.NoError(t, err)
+	require.Len(t, replaced, 2)
+	removed, err := extras.With(DAHintsExtraKey, nil)
+	require.NoError(t, err)
+	require.Len(t, removed, 1)
+	require.Len(t, extras, 2, "the extras are not modified")
+	removed, err = removed.With(PaymasterExtraKey, nil)
+	require.NoError(t, err)
+	require.Nil(t, removed)
+
+	_, err = extras.With(BatchExtraKey(1000), []byte("unknown"))
+	require.Error(t, err)
+	_, err = extras.With(RandomnessExtraKey, bytes.Repeat([]byte{1}, MaxBatchExtraSize+1))
+	require.Error(t, err)
+}
+
+func TestBatchExtrasValidate(t *testing.T) {
+	// the keys of the newer versions of the protocol are accepted
+	require.NoError(t, BatchExtras{{Key: PaymasterExtraKey, Value: []byte{1}}, {Key: BatchExtraKey(1000), Value: []byte{2}}}.Validate())
+
+	require.Error(t, BatchExtras{{Key: DAHintsExtraKey, Value: []byte{1}}, {Key: PaymasterExtraKey, Value: []byte{2}}}.Validate())
+	require.Error(t, BatchExtras{{Key: DAHintsExtraKey, Value: []byte{1}}, {Key: DAHintsExtraKey, Value: []byte{2}}}.Validate())
+	require.Error(t, BatchExtras{{Key: DAHintsExtraKey, Value: []byte{}}}.Validate())
+
+	tooMany := make(BatchExtras, MaxBatchExtras+1)
+	for i := range tooMany {
+		tooMany[i] = BatchExtra{Key: BatchExtraKey(i + 1), Value: []byte{1}}
+	}
+	require.Error(t, tooMany.Validate())
+}
@@ -45,8 +45,10 @@ func (b *ExtBatch) SDump() string {
 	return fmt.Sprintf("Tx_Len=%d, encrypted_blob_len=%d", len(b.TxHashes), len(b.EncryptedTxBlob))
 }
 
-// BatchRequest is used when requesting a range of batches from a peer.
+// BatchRequest is used when requesting a range of batches from a peer. The peer responds with a page of the batches of
+// the range, and the requester requests the rest of the range from the batch after the last one of the page.
 type BatchRequest struct {
 	Requester string   // The address of the requester, used to direct the response
 	FromSeqNo *big.Int // The requester's view of the current head seq no, or nil if they haven't stored any batches.
+	ToSeqNo   *big.Int `rlp:"optional"` // The last batch of the range, or nil for the batches up to the head of the peer
 }
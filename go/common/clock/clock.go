@@ -0,0 +1,60 @@
+package clock
+
+import "time"
+
+// Clock is the source of time of the components whose logic depends on it (schedulers, periodic tasks, timeouts...), so
+// that a Fake clock can replace the system clock in the tests and simulations
+type Clock interface {
+	Now() time.Time
+	Since(t time.Time) time.Duration
+	Sleep(d time.Duration)
+	// After sends the time on the returned channel once the duration has elapsed
+	After(d time.Duration) <-chan time.Time
+	// NewTicker sends the time on the channel of the returned ticker every period. It panics if the period is not positive
+	NewTicker(period time.Duration) Ticker
+}
+
+// Ticker is the Clock equivalent of a time.Ticker
+type Ticker interface {
+	C() <-chan time.Time
+	Stop()
+}
+
+// New returns the system clock
+func New() Clock {
+	return systemClock{}
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) Since(t time.Time) time.Duration {
+	return time.Since(t)
+}
+
+func (systemClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+func (systemClock) NewTicker(period time.Duration) Ticker {
+	return &systemTicker{ticker: time.NewTicker(period)}
+}
+
+type systemTicker struct {
+	ticker *time.Ticker
+}
+
+func (t *systemTicker) C() <-chan time.Time {
+	return t.ticker.C
+}
+
+func (t *systemTicker) Stop() {
+	t.ticker.Stop()
+}
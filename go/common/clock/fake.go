@@ -0,0 +1,138 @@
+package clock
+
+import (
+	"sync"
+	"time"
+)
+
+// Fake is a Clock whose time only moves when it is advanced, so that the time-dependent logic can be tested instantly and
+// deterministically. The timers and tickers due when the clock is advanced fire in order, as the system ones would
+type Fake struct {
+	lock    sync.Mutex
+	changed *sync.Cond // signalled when a timer or ticker is created
+	now     time.Time
+	timers  []*fakeTimer
+}
+
+// fakeTimer backs both the After channels, which fire once, and the tickers
+type fakeTimer struct {
+	clock  *Fake
+	at     time.Time
+	period time.Duration // zero if the timer fires once
+	ch     chan time.Time
+}
+
+// NewFake returns a fake clock set to the given time
+func NewFake(now time.Time) *Fake {
+	f := &Fake{now: now}
+	f.changed = sync.NewCond(&f.lock)
+	return f
+}
+
+func (f *Fake) Now() time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	return f.now
+}
+
+func (f *Fake) Since(t time.Time) time.Duration {
+	return f.Now().Sub(t)
+}
+
+// Sleep blocks until the clock is advanced by the duration
+func (f *Fake) Sleep(d time.Duration) {
+	<-f.After(d)
+}
+
+func (f *Fake) After(d time.Duration) <-chan time.Time {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	timer := &fakeTimer{clock: f, at: f.now.Add(d), ch: make(chan time.Time, 1)}
+	if d <= 0 {
+		timer.ch <- f.now
+		return timer.ch
+	}
+	f.add(timer)
+	return timer.ch
+}
+
+func (f *Fake) NewTicker(period time.Duration) Ticker {
+	if period <= 0 {
+		panic("non-positive interval for NewTicker")
+	}
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	// like the system tickers, the channel holds a single tick and the ticks are dropped while it is full
+	timer := &fakeTimer{clock: f, at: f.now.Add(period), period: period, ch: make(chan time.Time, 1)}
+	f.add(timer)
+	return timer
+}
+
+// Advance moves the clock forward, firing the timers and tickers due by the new time
+func (f *Fake) Advance(d time.Duration) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	end := f.now.Add(d)
+	for {
+		next := f.nextTimer()
+		if next == nil || next.at.After(end) {
+			break
+		}
+		f.now = next.at
+		select {
+		case next.ch <- f.now:
+		default:
+		}
+		if next.period == 0 {
+			f.remove(next)
+		} else {
+			next.at = next.at.Add(next.period)
+		}
+	}
+	f.now = end
+}
+
+// BlockUntil waits until there are at least n pending timers and tickers, which tells that the goroutines under test are
+// waiting on the clock before it is advanced
+func (f *Fake) BlockUntil(n int) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for len(f.timers) < n {
+		f.changed.Wait()
+	}
+}
+
+func (f *Fake) add(timer *fakeTimer) {
+	f.timers = append(f.timers, timer)
+	f.changed.Broadcast()
+}
+
+func (f *Fake) remove(timer *fakeTimer) {
+	for i, t := range f.timers {
+		if t == timer {
+			f.timers = append(f.timers[:i], f.timers[i+1:]...)
+			return
+		}
+	}
+}
+
+// nextTimer returns the earliest timer, the one created first if several are due at the same time
+func (f *Fake) nextTimer() *fakeTimer {
+	var next *fakeTimer
+	for _, t := range f.timers {
+		if next == nil || t.at.Before(next.at) {
+			next = t
+		}
+	}
+	return next
+}
+
+func (t *fakeTimer) C() <-chan time.Time {
+	return t.ch
+}
+
+func (t *fakeTimer) Stop() {
+	t.clock.lock.Lock()
+	defer t.clock.lock.Unlock()
+	t.clock.remove(t)
+}
@@ -0,0 +1,64 @@
+package clock
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFakeClock(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	clock := NewFake(start)
+
+	after := clock.After(3 * time.Second)
+	ticker := clock.NewTicker(time.Second)
+	clock.BlockUntil(2)
+
+	clock.Advance(999 * time.Millisecond)
+	require.Equal(t, start.Add(999*time.Millisecond), clock.Now())
+	requireNoTick(t, ticker.C())
+
+	clock.Advance(time.Millisecond)
+	require.Equal(t, start.Add(time.Second), <-ticker.C())
+	requireNoTick(t, after)
+
+	// the ticks are dropped while the channel is full, as with a system ticker
+	clock.Advance(5 * time.Second)
+	require.Equal(t, start.Add(2*time.Second), <-ticker.C())
+	requireNoTick(t, ticker.C())
+	require.Equal(t, start.Add(3*time.Second), <-after)
+	require.Equal(t, 6*time.Second, clock.Since(start))
+
+	ticker.Stop()
+	clock.Advance(time.Second)
+	requireNoTick(t, ticker.C())
+}
+
+func TestFakeClockSleep(t *testing.T) {
+	clock := NewFake(time.Now())
+	woken := make(chan struct{})
+	go func() {
+		clock.Sleep(time.Minute)
+		close(woken)
+	}()
+
+	clock.BlockUntil(1)
+	clock.Advance(59 * time.Second)
+	select {
+	case <-woken:
+		t.Fatal("woke up before the end of the sleep")
+	default:
+	}
+	clock.Advance(time.Second)
+	<-woken
+}
+
+func requireNoTick(t *testing.T, ch <-chan time.Time) {
+	t.Helper()
+	select {
+	case tick := <-ch:
+		t.Fatalf("unexpected tick at %s", tick)
+	default:
+	}
+}
@@ -0,0 +1,74 @@
+package codec
+
+import (
+	"fmt"
+
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+const (
+	rlpName      = "rlp"
+	protobufName = "protobuf"
+	unknownName  = "unknown"
+)
+
+// Type is the wire format of the batches sent by the enclave to its host, and by the sequencer host to its peers
+type Type int
+
+const (
+	// RLP is the format the batches are hashed with, and the default one
+	RLP Type = iota
+	// Protobuf is the format the batches are submitted to the enclave with
+	Protobuf
+)
+
+func (t Type) String() string {
+	switch t {
+	case RLP:
+		return rlpName
+	case Protobuf:
+		return protobufName
+	default:
+		return unknownName
+	}
+}
+
+func ToType(s string) (Type, error) {
+	switch s {
+	case rlpName:
+		return RLP, nil
+	case protobufName:
+		return Protobuf, nil
+	default:
+		return RLP, fmt.Errorf("string '%s' cannot be converted to a codec", s)
+	}
+}
+
+// Codec encodes the batches in one of the wire formats
+type Codec interface {
+	Type() Type
+	EncodeBatch(batch *common.ExtBatch) ([]byte, error)
+	DecodeBatch(data []byte) (*common.ExtBatch, error)
+	// EncodeBatches encodes the batches sent together to a peer, either as they are produced (live) or in response to a
+	// batch request
+	EncodeBatches(batches []*common.ExtBatch, isLive bool) ([]byte, error)
+	DecodeBatches(data []byte) ([]*common.ExtBatch, bool, error)
+}
+
+// New returns the codec of the given type
+func New(t Type) Codec {
+	if t == Protobuf {
+		return protobufCodec{}
+	}
+	return rlpCodec{}
+}
+
+// Detect returns the codec the data was encoded with, so that the nodes of a network can switch codecs one at a time.
+// The RLP encodings of the batches always start with a list prefix (0xc0 and above), while the protobuf ones start with
+// the tag of one of the first fields, or are empty
+func Detect(data []byte) Codec {
+	if len(data) > 0 && data[0] >= 0xc0 {
+		return rlpCodec{}
+	}
+	return protobufCodec{}
+}
@@ -0,0 +1,148 @@
+package codec
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/contracts/generated/MessageBus"
+	"github.com/ten-protocol/go-ten/go/common"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+var codecs = []Type{RLP, Protobuf}
+
+func newTestBatch(seqNo int64, numTxs int) *common.ExtBatch {
+	txHashes := make([]gethcommon.Hash, numTxs)
+	for i := range txHashes {
+		txHashes[i] = gethcommon.BigToHash(big.NewInt(int64(i)))
+	}
+	return &common.ExtBatch{
+		Header: &common.BatchHeader{
+			ParentHash:       gethcommon.HexToHash("0x01"),
+			Root:             gethcommon.HexToHash("0x02"),
+			Number:           big.NewInt(seqNo),
+			SequencerOrderNo: big.NewInt(seqNo),
+			GasLimit:         30_000_000,
+			Time:             1_700_000_000,
+			BaseFee:          big.NewInt(1),
+			L1Proof:          gethcommon.HexToHash("0x03"),
+			R:                big.NewInt(4),
+			S:                big.NewInt(5),
+			CrossChainMessages: []MessageBus.StructsCrossChainMessage{
+				{Sender: gethcommon.HexToAddress("0x06"), Sequence: 7, Nonce: 8, Payload: []byte{9}},
+			},
+			LatestInboundCrossChainHeight: big.NewInt(10),
+		},
+		TxHashes:        txHashes,
+		EncryptedTxBlob: make([]byte, 150*numTxs),
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	batches := []*common.ExtBatch{newTestBatch(1, 0), newTestBatch(2, 3)}
+	for _, codecType := range codecs {
+		codec := New(codecType)
+		require.Equal(t, codecType, codec.Type())
+
+		encoded, err := codec.EncodeBatch(batches[1])
+		require.NoError(t, err)
+		require.Equal(t, codecType, Detect(encoded).Type())
+		decoded, err := codec.DecodeBatch(encoded)
+		require.NoError(t, err)
+		// the hash covers the whole header, so the batch signature still matches
+		require.Equal(t, batches[1].Hash(), decoded.Hash())
+		require.Equal(t, batches[1].TxHashes, decoded.TxHashes)
+		require.Equal(t, batches[1].EncryptedTxBlob, decoded.EncryptedTxBlob)
+
+		for _, isLive := range []bool{true, false} {
+			for _, sent := range [][]*common.ExtBatch{batches, nil} {
+				encoded, err = codec.EncodeBatches(sent, isLive)
+				require.NoError(t, err)
+				require.Equal(t, codecType, Detect(encoded).Type())
+				received, receivedLive, err := codec.DecodeBatches(encoded)
+				require.NoError(t, err)
+				require.Equal(t, isLive, receivedLive)
+				require.Len(t, received, len(sent))
+				for i := range sent {
+					require.Equal(t, sent[i].Hash(), received[i].Hash())
+				}
+			}
+		}
+	}
+}
+
+// the RLP codec keeps the P2P wire format of the hosts that predate the codecs
+func TestRLPMatchesBatchMsg(t *testing.T) {
+	batchMsg := struct {
+		Batches []*common.ExtBatch
+		IsLive  bool
+	}{Batches: []*common.ExtBatch{newTestBatch(1, 2)}, IsLive: true}
+	expected, err := rlp.EncodeToBytes(batchMsg)
+	require.NoError(t, err)
+
+	encoded, err := New(RLP).EncodeBatches(batchMsg.Batches, batchMsg.IsLive)
+	require.NoError(t, err)
+	require.Equal(t, expected, encoded)
+}
+
+func TestToType(t *testing.T) {
+	for _, codecType := range codecs {
+		parsed, err := ToType(codecType.String())
+		require.NoError(t, err)
+		require.Equal(t, codecType, parsed)
+	}
+	_, err := ToType("json")
+	require.Error(t, err)
+}
+
+// the benchmarks compare the CPU cost of each codec, and report the size of its encoding
+func BenchmarkEncodeBatch(b *testing.B) {
+	batch := newTestBatch(1, 100)
+	for _, codecType := range codecs {
+		b.Run(codecType.String(), func(b *testing.B) {
+			codec := New(codecType)
+			var encoded []byte
+			for i := 0; i < b.N; i++ {
+				encoded, _ = codec.EncodeBatch(batch)
+			}
+			b.ReportMetric(float64(len(encoded)), "bytes/batch")
+		})
+	}
+}
+
+func BenchmarkDecodeBatch(b *testing.B) {
+	batch := newTestBatch(1, 100)
+	for _, codecType := range codecs {
+		b.Run(codecType.String(), func(b *testing.B) {
+			codec := New(codecType)
+			encoded, err := codec.EncodeBatch(batch)
+			require.NoError(b, err)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := codec.DecodeBatch(encoded); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkEncodeBatches(b *testing.B) {
+	batches := make([]*common.ExtBatch, 50)
+	for i := range batches {
+		batches[i] = newTestBatch(int64(i+1), 10)
+	}
+	for _, codecType := range codecs {
+		b.Run(codecType.String(), func(b *testing.B) {
+			codec := New(codecType)
+			var encoded []byte
+			for i := 0; i < b.N; i++ {
+				encoded, _ = codec.EncodeBatches(batches, false)
+			}
+			b.ReportMetric(float64(len(encoded)), "bytes/msg")
+		})
+	}
+}
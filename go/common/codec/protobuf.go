@@ -0,0 +1,100 @@
+package codec
+
+import (
+	"fmt"
+
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/rpc"
+	"github.com/ten-protocol/go-ten/go/common/rpc/generated"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+)
+
+// the fields of the list of batches, which is encoded by hand as it is not part of the enclave gRPC service:
+//
+//	message BatchesMsg {
+//	  repeated ExtBatchMsg batches = 1;
+//	  bool isLive = 2;
+//	}
+const (
+	batchesField protowire.Number = 1
+	isLiveField  protowire.Number = 2
+)
+
+// protobufCodec encodes the batches with the messages of the enclave gRPC service
+type protobufCodec struct{}
+
+func (protobufCodec) Type() Type {
+	return Protobuf
+}
+
+func (protobufCodec) EncodeBatch(batch *common.ExtBatch) ([]byte, error) {
+	msg := rpc.ToExtBatchMsg(batch)
+	return proto.Marshal(&msg)
+}
+
+func (protobufCodec) DecodeBatch(data []byte) (*common.ExtBatch, error) {
+	var msg generated.ExtBatchMsg
+	if err := proto.Unmarshal(data, &msg); err != nil {
+		return nil, err
+	}
+	return rpc.FromExtBatchMsg(&msg), nil
+}
+
+func (c protobufCodec) EncodeBatches(batches []*common.ExtBatch, isLive bool) ([]byte, error) {
+	var data []byte
+	for _, batch := range batches {
+		encoded, err := c.EncodeBatch(batch)
+		if err != nil {
+			return nil, err
+		}
+		data = protowire.AppendTag(data, batchesField, protowire.BytesType)
+		data = protowire.AppendBytes(data, encoded)
+	}
+	if isLive {
+		data = protowire.AppendTag(data, isLiveField, protowire.VarintType)
+		data = protowire.AppendVarint(data, 1)
+	}
+	return data, nil
+}
+
+func (c protobufCodec) DecodeBatches(data []byte) ([]*common.ExtBatch, bool, error) {
+	var batches []*common.ExtBatch
+	isLive := false
+	for len(data) > 0 {
+		field, wireType, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return nil, false, protowire.ParseError(n)
+		}
+		data = data[n:]
+
+		switch {
+		case field == batchesField && wireType == protowire.BytesType:
+			encoded, n := protowire.ConsumeBytes(data)
+			if n < 0 {
+				return nil, false, protowire.ParseError(n)
+			}
+			batch, err := c.DecodeBatch(encoded)
+			if err != nil {
+				return nil, false, fmt.Errorf("could not decode batch - %w", err)
+			}
+			batches = append(batches, batch)
+			data = data[n:]
+		case field == isLiveField && wireType == protowire.VarintType:
+			value, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return nil, false, protowire.ParseError(n)
+			}
+			isLive = value != 0
+			data = data[n:]
+		default:
+			// skips the fields added by later versions
+			n := protowire.ConsumeFieldValue(field, wireType, data)
+			if n < 0 {
+				return nil, false, protowire.ParseError(n)
+			}
+			data = data[n:]
+		}
+	}
+	return batches, isLive, nil
+}
@@ -0,0 +1,38 @@
+package codec
+
+import (
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+// rlpBatches is the P2P message of the batches, as the hosts that predate the codecs encode it
+type rlpBatches struct {
+	Batches []*common.ExtBatch // The batches being sent.
+	IsLive  bool               // true if these batches are being sent as new, false if in response to a p2p request
+}
+
+type rlpCodec struct{}
+
+func (rlpCodec) Type() Type {
+	return RLP
+}
+
+func (rlpCodec) EncodeBatch(batch *common.ExtBatch) ([]byte, error) {
+	return batch.Encoded()
+}
+
+func (rlpCodec) DecodeBatch(data []byte) (*common.ExtBatch, error) {
+	return common.DecodeExtBatch(data)
+}
+
+func (rlpCodec) EncodeBatches(batches []*common.ExtBatch, isLive bool) ([]byte, error) {
+	return rlp.EncodeToBytes(&rlpBatches{Batches: batches, IsLive: isLive})
+}
+
+func (rlpCodec) DecodeBatches(data []byte) ([]*common.ExtBatch, bool, error) {
+	var msg rlpBatches
+	if err := rlp.DecodeBytes(data, &msg); err != nil {
+		return nil, false, err
+	}
+	return msg.Batches, msg.IsLive, nil
+}
@@ -2,17 +2,75 @@ package compression
 
 import (
 	"bytes"
+	"errors"
+	"fmt"
 	"io"
+	"math"
 
 	"github.com/andybalholm/brotli"
 )
 
+// Limits are the ceiling of the decompressed data, so that a small malicious payload (e.g. in a rollup or a batch
+// received over P2P) can't exhaust the memory of the enclave. The data decompresses to at most MaxExpansion times its
+// compressed size, or MinSize if larger, and never to more than MaxSize. The limits decide which rollups are valid, so
+// they are part of the network genesis. The zero Limits don't bound the data.
+type Limits struct {
+	MaxExpansion int64 `json:"maxExpansion"`
+	MinSize      int64 `json:"minSize"`
+	MaxSize      int64 `json:"maxSize"`
+}
+
+// DefaultLimits are the limits of the networks whose genesis does not set them
+var DefaultLimits = Limits{
+	MaxExpansion: 100,
+	MinSize:      1024 * 1024,
+	MaxSize:      64 * 1024 * 1024,
+}
+
+// ErrDecompressionLimit is returned when the data decompresses to more than its ceiling
+var ErrDecompressionLimit = errors.New("the data exceeds the decompression limit")
+
+// IsZero returns whether the limits don't bound the data
+func (l Limits) IsZero() bool {
+	return l == Limits{}
+}
+
+// Validate checks that the limits are consistent
+func (l Limits) Validate() error {
+	if l.IsZero() {
+		return nil
+	}
+	if l.MaxExpansion <= 0 || l.MinSize <= 0 || l.MaxSize < l.MinSize {
+		return fmt.Errorf("invalid decompression limits %+v - the expansion and the sizes must be positive, and the max size at least the min size", l)
+	}
+	return nil
+}
+
+// SizeLimit returns the maximum size of the decompressed data, given its compressed size
+func (l Limits) SizeLimit(compressedSize int) int64 {
+	if l.IsZero() {
+		return math.MaxInt64
+	}
+	limit := l.MaxExpansion * int64(compressedSize)
+	if limit/l.MaxExpansion != int64(compressedSize) || limit > l.MaxSize {
+		return l.MaxSize
+	}
+	if limit < l.MinSize {
+		return l.MinSize
+	}
+	return limit
+}
+
 type DataCompressionService interface {
 	// CompressRollup - uses the maximum compression level, because the final size matters when publishing to Ethereum
 	CompressRollup(blob []byte) ([]byte, error)
 	// CompressBatch - uses the default compression level, because the compression is for the efficiency of the p2p transfer
 	CompressBatch(blob []byte) ([]byte, error)
-	Decompress(blob []byte) ([]byte, error)
+	// Decompress - returns ErrDecompressionLimit if the blob decompresses to more than the limits allow
+	Decompress(blob []byte, limits Limits) ([]byte, error)
+	// DecompressStream - returns a reader of the decompressed blob, so that a large blob can be consumed without holding
+	// it in memory decompressed. The reader returns ErrDecompressionLimit past the limits
+	DecompressStream(blob []byte, limits Limits) io.Reader
 }
 
 func NewBrotliDataCompressionService() DataCompressionService {
@@ -29,9 +87,35 @@ func (cs *brotliDataCompressionService) CompressBatch(blob []byte) ([]byte, erro
 	return cs.compress(blob, brotli.DefaultCompression)
 }
 
-func (cs *brotliDataCompressionService) Decompress(in []byte) ([]byte, error) {
-	r := brotli.NewReader(bytes.NewReader(in))
-	return io.ReadAll(r)
+func (cs *brotliDataCompressionService) Decompress(in []byte, limits Limits) ([]byte, error) {
+	return io.ReadAll(cs.DecompressStream(in, limits))
+}
+
+func (cs *brotliDataCompressionService) DecompressStream(in []byte, limits Limits) io.Reader {
+	return &limitedReader{reader: brotli.NewReader(bytes.NewReader(in)), left: limits.SizeLimit(len(in))}
+}
+
+// limitedReader fails once more than its limit was read, unlike io.LimitedReader which ends the data silently
+type limitedReader struct {
+	reader io.Reader
+	left   int64
+}
+
+func (r *limitedReader) Read(p []byte) (int, error) {
+	if r.left <= 0 {
+		// the data may end exactly at the limit
+		var b [1]byte
+		if n, err := r.reader.Read(b[:]); n == 0 {
+			return 0, err
+		}
+		return 0, ErrDecompressionLimit
+	}
+	if int64(len(p)) > r.left {
+		p = p[:r.left]
+	}
+	n, err := r.reader.Read(p)
+	r.left -= int64(n)
+	return n, err
 }
 
 func (cs *brotliDataCompressionService) compress(in []byte, level int) ([]byte, error) {
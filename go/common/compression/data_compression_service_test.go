@@ -0,0 +1,77 @@
+package compression
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"math"
+	"testing"
+)
+
+func TestDecompressionLimit(t *testing.T) {
+	cs := NewBrotliDataCompressionService()
+
+	// the data up to the limit decompresses, whatever its compression ratio
+	data := bytes.Repeat([]byte{0x01}, int(DefaultLimits.MinSize))
+	compressed, err := cs.CompressBatch(data)
+	if err != nil {
+		t.Fatalf("could not compress. Cause: %s", err)
+	}
+	decompressed, err := cs.Decompress(compressed, DefaultLimits)
+	if err != nil {
+		t.Fatalf("could not decompress. Cause: %s", err)
+	}
+	if !bytes.Equal(data, decompressed) {
+		t.Fatal("the decompressed data differs")
+	}
+
+	// a bomb of a few bytes does not
+	bomb, err := cs.CompressBatch(make([]byte, 2*DefaultLimits.MinSize))
+	if err != nil {
+		t.Fatalf("could not compress. Cause: %s", err)
+	}
+	if _, err = cs.Decompress(bomb, DefaultLimits); !errors.Is(err, ErrDecompressionLimit) {
+		t.Fatalf("expected the decompression limit error, got %v", err)
+	}
+	if _, err = io.Copy(io.Discard, cs.DecompressStream(bomb, DefaultLimits)); !errors.Is(err, ErrDecompressionLimit) {
+		t.Fatalf("expected the decompression limit error from the stream, got %v", err)
+	}
+
+	// the zero limits don't bound the data
+	if decompressed, err = cs.Decompress(bomb, Limits{}); err != nil || len(decompressed) != 2*int(DefaultLimits.MinSize) {
+		t.Fatalf("expected the bomb to decompress without limits, got %d bytes and %v", len(decompressed), err)
+	}
+}
+
+func TestSizeLimit(t *testing.T) {
+	tests := []struct {
+		compressed int
+		expected   int64
+	}{
+		{compressed: 10, expected: DefaultLimits.MinSize},
+		{compressed: 100_000, expected: 100_000 * DefaultLimits.MaxExpansion},
+		{compressed: int(DefaultLimits.MaxSize), expected: DefaultLimits.MaxSize},
+		{compressed: math.MaxInt, expected: DefaultLimits.MaxSize},
+	}
+	for _, tc := range tests {
+		if limit := DefaultLimits.SizeLimit(tc.compressed); limit != tc.expected {
+			t.Errorf("expected a limit of %d for %d compressed bytes, got %d", tc.expected, tc.compressed, limit)
+		}
+	}
+	if limit := (Limits{}).SizeLimit(10); limit != math.MaxInt64 {
+		t.Errorf("expected no limit, got %d", limit)
+	}
+}
+
+func TestValidateLimits(t *testing.T) {
+	for _, valid := range []Limits{{}, DefaultLimits, {MaxExpansion: 1, MinSize: 1, MaxSize: 1}} {
+		if err := valid.Validate(); err != nil {
+			t.Errorf("expected %+v to be valid, got %v", valid, err)
+		}
+	}
+	for _, invalid := range []Limits{{MaxExpansion: 100}, {MaxExpansion: 100, MinSize: 10, MaxSize: 5}, {MaxExpansion: -1, MinSize: 1, MaxSize: 1}} {
+		if err := invalid.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", invalid)
+		}
+	}
+}
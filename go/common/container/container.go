@@ -6,9 +6,13 @@ import (
 	"log"
 	"os"
 	"os/signal"
+	"syscall"
 	"time"
 )
 
+// the time given to a container to stop when the grace period is not configured
+const defaultGracePeriod = 5 * time.Second
+
 // Container is a Start-able server process that is expected to self-recover from any issues until Stop is called.
 // In future it might expose methods like `Status()` for monitoring/interacting with the process.
 //
@@ -20,42 +24,79 @@ type Container interface {
 	Stop() error
 }
 
+// Drainable is implemented by the containers that can stop taking new work and complete the work in progress before
+// being stopped, e.g. when the orchestrator terminates the process during a rolling update
+type Drainable interface {
+	// StopReadiness makes the readiness probe of the container fail, so that the orchestrator stops routing requests
+	// to it. The container keeps serving requests.
+	StopReadiness()
+	// Drain waits for the work in progress to complete, or for the context to be done
+	Drain(ctx context.Context) error
+}
+
 // Serve is a convenience method to be called from the `main` runner for a container. It will attempt to cleanly shutdown
 // the container on OS signal
 // todo: maybe expose the status to the operator from here (admin http service or a monitoring service)
 func Serve(container Container) {
-	sigCh := make(chan os.Signal, 1)
-	signal.Notify(sigCh, os.Interrupt)
-
-	ctx, cancel := context.WithCancel(context.Background())
+	ServeWithGracePeriod(container, defaultGracePeriod, 0)
+}
 
-	go func() {
-		oscall := <-sigCh
-		log.Printf("OS interrupt:%+v\n", oscall)
-		cancel()
-	}()
+// ServeWithGracePeriod is like Serve, with the time the container has to shut down once it receives SIGINT or SIGTERM.
+// The grace period should match the one of the orchestration environment. Drainable containers first fail their
+// readiness probe and keep serving requests for the drain delay, which gives the load balancers time to stop routing
+// requests to them, then they are drained for up to half of the remaining grace period before being stopped.
+func ServeWithGracePeriod(container Container, gracePeriod time.Duration, drainDelay time.Duration) {
+	sigCh := make(chan os.Signal, 1)
+	signal.Notify(sigCh, os.Interrupt, syscall.SIGTERM)
+	os.Exit(serve(container, gracePeriod, drainDelay, sigCh))
+}
 
+// serve starts the container and terminates it on the first signal received. It returns the exit code of the process.
+func serve(container Container, gracePeriod time.Duration, drainDelay time.Duration, sigCh <-chan os.Signal) int {
 	err := container.Start()
 	if err != nil {
 		fmt.Printf("failed to start container - %s\n", err)
-		os.Exit(1)
+		return 1
 	}
 	fmt.Println("Server started.")
 
-	<-ctx.Done()
+	oscall := <-sigCh
+	log.Printf("OS interrupt:%+v\n", oscall)
 
 	fmt.Println("Stopping server...")
+	stopped := make(chan error, 1)
 	go func() {
-		time.Sleep(5 * time.Second)
-		fmt.Println("Failed to stop after 5 seconds. Exiting.")
-		os.Exit(1)
+		stopped <- terminate(container, gracePeriod, drainDelay)
 	}()
-	err = container.Stop()
-	if err != nil {
-		fmt.Printf("failed to stop gracefully - %s\n", err)
-		os.Exit(1)
+	select {
+	case err = <-stopped:
+		if err != nil {
+			fmt.Printf("failed to stop gracefully - %s\n", err)
+			return 1
+		}
+		// Graceful shutdown complete
+		return 0
+	case <-time.After(gracePeriod):
+		fmt.Printf("Failed to stop after %s. Exiting.\n", gracePeriod)
+		return 1
 	}
+}
+
+// terminate drains the container if it is Drainable, then stops it
+func terminate(container Container, gracePeriod time.Duration, drainDelay time.Duration) error {
+	if drainable, ok := container.(Drainable); ok {
+		// the delay can't use up the time needed to drain and stop the container
+		if drainDelay > gracePeriod/2 {
+			drainDelay = gracePeriod / 2
+		}
+		drainable.StopReadiness()
+		time.Sleep(drainDelay)
 
-	// Graceful shutdown complete
-	os.Exit(0)
+		drainCtx, cancelDrain := context.WithTimeout(context.Background(), (gracePeriod-drainDelay)/2)
+		if err := drainable.Drain(drainCtx); err != nil {
+			fmt.Printf("failed to drain - %s\n", err)
+		}
+		cancelDrain()
+	}
+	return container.Stop()
 }
@@ -0,0 +1,125 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"os"
+	"sync"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// recordingContainer records the calls made by serve, and when they were made
+type recordingContainer struct {
+	mu       sync.Mutex
+	calls    []string
+	times    map[string]time.Time
+	deadline time.Duration // the time left to drain when Drain is called
+
+	startErr  error
+	drainErr  error
+	stopErr   error
+	stopDelay time.Duration
+}
+
+func (c *recordingContainer) record(call string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, call)
+	if c.times == nil {
+		c.times = map[string]time.Time{}
+	}
+	c.times[call] = time.Now()
+}
+
+func (c *recordingContainer) recorded() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]string{}, c.calls...)
+}
+
+func (c *recordingContainer) Start() error {
+	c.record("start")
+	return c.startErr
+}
+
+func (c *recordingContainer) Stop() error {
+	c.record("stop")
+	time.Sleep(c.stopDelay)
+	return c.stopErr
+}
+
+type drainableContainer struct {
+	recordingContainer
+}
+
+func (c *drainableContainer) StopReadiness() {
+	c.record("stopReadiness")
+}
+
+func (c *drainableContainer) Drain(ctx context.Context) error {
+	c.record("drain")
+	deadline, ok := ctx.Deadline()
+	if ok {
+		c.deadline = time.Until(deadline)
+	}
+	return c.drainErr
+}
+
+func terminated() <-chan os.Signal {
+	sigCh := make(chan os.Signal, 1)
+	sigCh <- syscall.SIGTERM
+	return sigCh
+}
+
+func TestServeDrainsBeforeStopping(t *testing.T) {
+	c := &drainableContainer{}
+	gracePeriod, drainDelay := time.Second, 100*time.Millisecond
+
+	require.Zero(t, serve(c, gracePeriod, drainDelay, terminated()))
+	require.Equal(t, []string{"start", "stopReadiness", "drain", "stop"}, c.recorded())
+	// the container keeps serving requests for the drain delay after failing its readiness probe
+	require.GreaterOrEqual(t, c.times["drain"].Sub(c.times["stopReadiness"]), drainDelay)
+	require.Positive(t, c.deadline)
+	require.LessOrEqual(t, c.deadline, (gracePeriod-drainDelay)/2)
+}
+
+func TestServeStopsAfterFailedDrain(t *testing.T) {
+	c := &drainableContainer{recordingContainer{drainErr: errors.New("calls in progress")}}
+
+	require.Zero(t, serve(c, time.Second, 0, terminated()))
+	require.Equal(t, []string{"start", "stopReadiness", "drain", "stop"}, c.recorded())
+}
+
+func TestServeLimitsTheDrainDelay(t *testing.T) {
+	c := &drainableContainer{}
+	gracePeriod := 200 * time.Millisecond
+
+	// a delay longer than the grace period leaves half of it to drain and stop the container
+	require.Zero(t, serve(c, gracePeriod, time.Hour, terminated()))
+	require.Equal(t, []string{"start", "stopReadiness", "drain", "stop"}, c.recorded())
+	require.Positive(t, c.deadline)
+}
+
+func TestServe(t *testing.T) {
+	tests := []struct {
+		name          string
+		container     *recordingContainer
+		expectedCode  int
+		expectedCalls []string
+	}{
+		{name: "not drainable", container: &recordingContainer{}, expectedCode: 0, expectedCalls: []string{"start", "stop"}},
+		{name: "start failure", container: &recordingContainer{startErr: errors.New("no enclave")}, expectedCode: 1, expectedCalls: []string{"start"}},
+		{name: "stop failure", container: &recordingContainer{stopErr: errors.New("stuck")}, expectedCode: 1, expectedCalls: []string{"start", "stop"}},
+		{name: "stop exceeds the grace period", container: &recordingContainer{stopDelay: time.Second}, expectedCode: 1, expectedCalls: []string{"start", "stop"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expectedCode, serve(tt.container, 100*time.Millisecond, 0, terminated()))
+			require.Equal(t, tt.expectedCalls, tt.container.recorded())
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"time"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ten-protocol/go-ten/go/common/log"
+)
+
+const (
+	LivenessPath  = "/livez"
+	ReadinessPath = "/readyz"
+
+	probeShutdownTimeout = time.Second
+)
+
+// ProbeServer serves the liveness and readiness endpoints used by orchestrators like Kubernetes.
+// The liveness endpoint succeeds as long as the process serves requests. The readiness endpoint succeeds only when the
+// ready check passes, so that traffic is not routed to a component that is starting or draining.
+type ProbeServer struct {
+	port   uint
+	ready  func() error
+	server *http.Server
+	logger gethlog.Logger
+}
+
+func NewProbeServer(port uint, ready func() error, logger gethlog.Logger) *ProbeServer {
+	return &ProbeServer{
+		port:   port,
+		ready:  ready,
+		logger: logger,
+	}
+}
+
+func (p *ProbeServer) Start() error {
+	// listens on all the interfaces, IPv4 and IPv6
+	listener, err := net.Listen("tcp", net.JoinHostPort("", strconv.FormatUint(uint64(p.port), 10)))
+	if err != nil {
+		return fmt.Errorf("could not listen for probes on port %d - %w", p.port, err)
+	}
+	p.server = &http.Server{Handler: p.handler(), ReadHeaderTimeout: probeShutdownTimeout}
+	go func() {
+		if err := p.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			p.logger.Error("Probe server stopped unexpectedly", log.ErrKey, err)
+		}
+	}()
+	p.logger.Info("Probe server started", "port", p.port)
+	return nil
+}
+
+func (p *ProbeServer) Stop() error {
+	if p.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), probeShutdownTimeout)
+	defer cancel()
+	return p.server.Shutdown(ctx)
+}
+
+func (p *ProbeServer) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(LivenessPath, func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc(ReadinessPath, func(w http.ResponseWriter, _ *http.Request) {
+		if err := p.ready(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	return mux
+}
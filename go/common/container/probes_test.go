@@ -0,0 +1,31 @@
+package container
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProbes(t *testing.T) {
+	var readyErr error
+	handler := NewProbeServer(0, func() error { return readyErr }, gethlog.New()).handler()
+
+	probe := func(path string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+		return rec
+	}
+
+	require.Equal(t, http.StatusOK, probe(LivenessPath).Code)
+	require.Equal(t, http.StatusOK, probe(ReadinessPath).Code)
+
+	readyErr = errors.New("draining")
+	require.Equal(t, http.StatusOK, probe(LivenessPath).Code)
+	notReady := probe(ReadinessPath)
+	require.Equal(t, http.StatusServiceUnavailable, notReady.Code)
+	require.Contains(t, notReady.Body.String(), "draining")
+}
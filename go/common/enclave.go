@@ -3,6 +3,7 @@ package common
 import (
 	"encoding/json"
 	"math/big"
+	"time"
 
 	"github.com/ten-protocol/go-ten/go/common/errutil"
 
@@ -18,9 +19,11 @@ type StatusCode int
 // Status represents the enclave's current state - whether the enclave is healthy and ready to process requests, as well
 // as its latest known heads for the L1 and L2 chains
 type Status struct {
-	StatusCode StatusCode
-	L1Head     gethcommon.Hash
-	L2Head     *big.Int
+	StatusCode     StatusCode
+	L1Head         gethcommon.Hash
+	L2Head         *big.Int
+	RollupInterval time.Duration // the interval between rollups set by the L1 governance, zero if it was never set
+	Time           time.Time     // the enclave clock when the status was reported, the batch timestamps are taken from it
 }
 
 const (
@@ -125,6 +128,24 @@ type Enclave interface {
 	StreamL2Updates() (chan StreamL2UpdatesResponse, func())
 	// DebugEventLogRelevancy returns the logs of a transaction
 	DebugEventLogRelevancy(hash gethcommon.Hash) (json.RawMessage, SystemError)
+
+	// DebugProfile returns a gzipped pprof profile of the enclave, encrypted to the key of the profiling admin, if the
+	// request was signed by the admin
+	DebugProfile(request *ProfileRequest) ([]byte, SystemError)
+
+	// MempoolReport returns the pending transactions that waited in the mempool of the sequencer for longer than the
+	// inclusion deadline
+	MempoolReport() (*MempoolReport, SystemError)
+
+	// MempoolStats returns the aggregate statistics of the mempool of the sequencer, which reveal no individual transaction
+	MempoolStats() (*MempoolStats, SystemError)
+
+	// RollupWitness re-executes the batches of a stored rollup, and returns the encrypted witness of the data they read
+	RollupWitness(rollupHash L2RollupHash) ([]byte, SystemError)
+
+	// VerifyRollupWitness re-executes the batches of a rollup from its witness alone, without the chain state of the
+	// enclave, and returns the verdict signed by the enclave key
+	VerifyRollupWitness(witness []byte) (*RollupVerdict, SystemError)
 }
 
 // EnclaveScan represents the methods that are used for data scanning in the enclave
@@ -0,0 +1,92 @@
+package errutil
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// BridgeErrorCode identifies why a bridge operation failed, so that the UIs can act on it (e.g. wait for the finality of
+// a message rather than retry a claim that already happened)
+type BridgeErrorCode string
+
+const (
+	// BridgeInsufficientFinality is returned while the message or state to bridge is not final yet
+	BridgeInsufficientFinality BridgeErrorCode = "INSUFFICIENT_FINALITY"
+	// BridgeProofMismatch is returned when a message or proof does not match what was published on the other layer
+	BridgeProofMismatch BridgeErrorCode = "PROOF_MISMATCH"
+	// BridgeAlreadyClaimed is returned when the message was already consumed
+	BridgeAlreadyClaimed BridgeErrorCode = "ALREADY_CLAIMED"
+	// BridgePausedAsset is returned when the asset is not (or no longer) enabled on the bridge
+	BridgePausedAsset BridgeErrorCode = "PAUSED_ASSET"
+)
+
+var (
+	ErrBridgeInsufficientFinality = &BridgeError{Code: BridgeInsufficientFinality}
+	ErrBridgeProofMismatch        = &BridgeError{Code: BridgeProofMismatch}
+	ErrBridgeAlreadyClaimed       = &BridgeError{Code: BridgeAlreadyClaimed}
+	ErrBridgePausedAsset          = &BridgeError{Code: BridgePausedAsset}
+)
+
+// the revert reasons of the bridge and messaging contracts, by the code of the error they stand for
+var bridgeRevertReasons = map[string]BridgeErrorCode{
+	"Message not found or finalized.":             BridgeInsufficientFinality,
+	"This message was never submitted.":           BridgeProofMismatch,
+	"Message submitted more than once!":           BridgeAlreadyClaimed,
+	"Message already consumed.":                   BridgeAlreadyClaimed,
+	"Attempting to withdraw unknown asset.":       BridgePausedAsset,
+	"Receiving assets for unknown wrapped token!": BridgePausedAsset,
+	"No mapping for token.":                       BridgePausedAsset,
+	"This address has not been given a type and is thus considered not whitelisted.": BridgePausedAsset,
+}
+
+// the errors lose their type when sent over the wire, so the code is recovered from their message
+var bridgeErrorCodeRegex = regexp.MustCompile(`bridge error ([A-Z_]+):`)
+
+// BridgeError is a failed bridge operation, with the code of the failure
+type BridgeError struct {
+	Code    BridgeErrorCode
+	Message string
+}
+
+func NewBridgeError(code BridgeErrorCode, format string, args ...interface{}) *BridgeError {
+	return &BridgeError{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func (e *BridgeError) Error() string {
+	return fmt.Sprintf("bridge error %s: %s", e.Code, e.Message)
+}
+
+// Is implementation supports the errors.Is() behaviour against the ErrBridge errors, which match any error of their code
+func (e *BridgeError) Is(err error) bool {
+	var target *BridgeError
+	return errors.As(err, &target) && target.Code == e.Code
+}
+
+// BridgeErrorFromRevert returns the bridge error the revert reason of a bridge contract stands for, or nil if it is not
+// one of the known reasons
+func BridgeErrorFromRevert(reason string) *BridgeError {
+	code, found := bridgeRevertReasons[strings.TrimSpace(reason)]
+	if !found {
+		return nil
+	}
+	return &BridgeError{Code: code, Message: reason}
+}
+
+// AsBridgeError returns the bridge error in the chain of the error, including when it was received over the wire and only
+// its message is left
+func AsBridgeError(err error) (*BridgeError, bool) {
+	if err == nil {
+		return nil, false
+	}
+	var bridgeErr *BridgeError
+	if errors.As(err, &bridgeErr) {
+		return bridgeErr, true
+	}
+	match := bridgeErrorCodeRegex.FindStringSubmatch(err.Error())
+	if match == nil {
+		return nil, false
+	}
+	return &BridgeError{Code: BridgeErrorCode(match[1]), Message: err.Error()}, true
+}
@@ -0,0 +1,49 @@
+package errutil
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBridgeErrorFromRevert(t *testing.T) {
+	tests := map[string]struct {
+		reason string
+		code   BridgeErrorCode
+	}{
+		"message not final":   {reason: "Message not found or finalized.", code: BridgeInsufficientFinality},
+		"message consumed":    {reason: "Message already consumed.", code: BridgeAlreadyClaimed},
+		"message unknown":     {reason: "This message was never submitted.", code: BridgeProofMismatch},
+		"asset not mapped":    {reason: "No mapping for token.", code: BridgePausedAsset},
+		"other revert reason": {reason: "Forced require"},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			bridgeErr := BridgeErrorFromRevert(test.reason)
+			if test.code == "" {
+				require.Nil(t, bridgeErr)
+				return
+			}
+			require.Equal(t, test.code, bridgeErr.Code)
+			require.Equal(t, test.reason, bridgeErr.Message)
+		})
+	}
+}
+
+func TestAsBridgeError(t *testing.T) {
+	wrapped := fmt.Errorf("execution reverted: %w", BridgeErrorFromRevert("Message already consumed."))
+	require.ErrorIs(t, wrapped, ErrBridgeAlreadyClaimed)
+	require.NotErrorIs(t, wrapped, ErrBridgeInsufficientFinality)
+
+	// the type of the errors is lost over the wire
+	received := errors.New(wrapped.Error())
+	bridgeErr, ok := AsBridgeError(received)
+	require.True(t, ok)
+	require.Equal(t, BridgeAlreadyClaimed, bridgeErr.Code)
+
+	_, ok = AsBridgeError(errors.New("execution reverted: Forced require"))
+	require.False(t, ok)
+}
@@ -0,0 +1,127 @@
+package common
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
+)
+
+// FeeDistribution is how the base fees collected in a batch are distributed, as set by the governance
+type FeeDistribution uint64
+
+const (
+	// FeeToCoinbase credits the base fees to the coinbase of the batch, i.e. the sequencer. It is the default
+	FeeToCoinbase FeeDistribution = iota
+	// FeeBurn burns the base fees
+	FeeBurn
+	// FeeToTreasury credits the base fees to the treasury address set by the governance
+	FeeToTreasury
+	// FeeToValidators splits the base fees evenly between the validator addresses set by the governance
+	FeeToValidators
+)
+
+func (d FeeDistribution) String() string {
+	switch d {
+	case FeeToCoinbase:
+		return "coinbase"
+	case FeeBurn:
+		return "burn"
+	case FeeToTreasury:
+		return "treasury"
+	case FeeToValidators:
+		return "validators"
+	default:
+		return "unknown"
+	}
+}
+
+// BatchFees are the fees collected in a batch
+type BatchFees struct {
+	SeqNo        uint64
+	L1Fees       *big.Int        // paid by the transactions for their publication on the L1, credited to the coinbase
+	BaseFees     *big.Int        // the base fee of the gas used by the transactions, distributed with the Distribution
+	Tips         *big.Int        // the priority fees of the transactions, credited to the pool address
+	Distribution FeeDistribution // how the base fees were distributed
+	Credits      []FeeCredit     // the base fees credited, none if they were burnt
+	// Sponsored are the fees paid in the fee token by the paymasters, which are not part of the fees above
+	Sponsored []SponsoredFee `rlp:"optional"`
+}
+
+// FeeCredit is an amount of base fees credited to a recipient
+type FeeCredit struct {
+	Recipient common.Address
+	Amount    *big.Int
+}
+
+// SponsoredFee are the fees of a transaction paid by a paymaster in the fee token, instead of by its sender. The token
+// amounts are the native amounts: the fee token is priced 1:1 with the native currency.
+type SponsoredFee struct {
+	TxHash    common.Hash
+	Paymaster common.Address
+	Token     common.Address
+	GasFee    *big.Int // the gas used, at the gas price of the transaction
+	L1Fee     *big.Int // the cost of publishing the transaction on the L1
+}
+
+// FeeRevenue is the revenue accrued over a range of batches
+type FeeRevenue struct {
+	FromBatchSeqNo tentypes.BatchSeqNo `json:"fromBatchSeqNo"`
+	ToBatchSeqNo   tentypes.BatchSeqNo `json:"toBatchSeqNo"`
+	Batches        hexutil.Uint64      `json:"batches"` // the number of executed batches in the range
+	L1Fees         *hexutil.Big        `json:"l1Fees"`
+	BaseFees       *hexutil.Big        `json:"baseFees"`
+	Tips           *hexutil.Big        `json:"tips"`
+	// Distributed is the amount of base fees credited to each recipient, the burnt base fees are not in it
+	Distributed map[common.Address]*hexutil.Big `json:"distributed"`
+	Burnt       *hexutil.Big                    `json:"burnt"`
+	// Sponsored is the amount of fee token paid by each paymaster for the transactions it sponsored
+	Sponsored map[common.Address]*hexutil.Big `json:"sponsored"`
+}
+
+// NewFeeRevenue sums the fees of the batches of the range
+func NewFeeRevenue(from, to tentypes.BatchSeqNo, fees []*BatchFees) *FeeRevenue {
+	l1Fees, baseFees, tips, burnt := new(big.Int), new(big.Int), new(big.Int), new(big.Int)
+	distributed := map[common.Address]*big.Int{}
+	sponsored := map[common.Address]*big.Int{}
+	for _, f := range fees {
+		l1Fees.Add(l1Fees, f.L1Fees)
+		baseFees.Add(baseFees, f.BaseFees)
+		tips.Add(tips, f.Tips)
+		credited := new(big.Int)
+		for _, credit := range f.Credits {
+			if distributed[credit.Recipient] == nil {
+				distributed[credit.Recipient] = new(big.Int)
+			}
+			distributed[credit.Recipient].Add(distributed[credit.Recipient], credit.Amount)
+			credited.Add(credited, credit.Amount)
+		}
+		burnt.Add(burnt, credited.Sub(f.BaseFees, credited))
+		for _, fee := range f.Sponsored {
+			if sponsored[fee.Paymaster] == nil {
+				sponsored[fee.Paymaster] = new(big.Int)
+			}
+			sponsored[fee.Paymaster].Add(sponsored[fee.Paymaster], new(big.Int).Add(fee.GasFee, fee.L1Fee))
+		}
+	}
+
+	revenue := &FeeRevenue{
+		FromBatchSeqNo: from,
+		ToBatchSeqNo:   to,
+		Batches:        hexutil.Uint64(len(fees)),
+		L1Fees:         (*hexutil.Big)(l1Fees),
+		BaseFees:       (*hexutil.Big)(baseFees),
+		Tips:           (*hexutil.Big)(tips),
+		Distributed:    make(map[common.Address]*hexutil.Big, len(distributed)),
+		Burnt:          (*hexutil.Big)(burnt),
+		Sponsored:      make(map[common.Address]*hexutil.Big, len(sponsored)),
+	}
+	for recipient, amount := range distributed {
+		revenue.Distributed[recipient] = (*hexutil.Big)(amount)
+	}
+	for paymaster, amount := range sponsored {
+		revenue.Sponsored[paymaster] = (*hexutil.Big)(amount)
+	}
+	return revenue
+}
@@ -0,0 +1,42 @@
+package common
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFeeRevenue(t *testing.T) {
+	treasury := common.HexToAddress("0x01")
+	validator := common.HexToAddress("0x02")
+	revenue := NewFeeRevenue(3, 5, []*BatchFees{
+		{
+			SeqNo: 3, L1Fees: big.NewInt(10), BaseFees: big.NewInt(100), Tips: big.NewInt(1),
+			Distribution: FeeToTreasury,
+			Credits:      []FeeCredit{{Recipient: treasury, Amount: big.NewInt(100)}},
+		},
+		{
+			SeqNo: 4, L1Fees: big.NewInt(20), BaseFees: big.NewInt(200), Tips: big.NewInt(2),
+			Distribution: FeeBurn,
+		},
+		{
+			SeqNo: 5, L1Fees: big.NewInt(30), BaseFees: big.NewInt(300), Tips: big.NewInt(3),
+			Distribution: FeeToValidators,
+			Credits: []FeeCredit{
+				{Recipient: validator, Amount: big.NewInt(150)},
+				{Recipient: treasury, Amount: big.NewInt(150)},
+			},
+		},
+	})
+
+	require.Equal(t, hexutil.Uint64(3), revenue.Batches)
+	require.Equal(t, big.NewInt(60), revenue.L1Fees.ToInt())
+	require.Equal(t, big.NewInt(600), revenue.BaseFees.ToInt())
+	require.Equal(t, big.NewInt(6), revenue.Tips.ToInt())
+	require.Equal(t, big.NewInt(200), revenue.Burnt.ToInt())
+	require.Equal(t, big.NewInt(250), revenue.Distributed[treasury].ToInt())
+	require.Equal(t, big.NewInt(150), revenue.Distributed[validator].ToInt())
+}
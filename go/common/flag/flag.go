@@ -3,6 +3,7 @@ package flag
 import (
 	"flag"
 	"fmt"
+	"strconv"
 )
 
 type TenFlag struct {
@@ -108,6 +109,41 @@ func (f TenFlag) IsSet() bool {
 	return found
 }
 
+// SetFromString parses the raw string value according to the flag type and sets it as the flag value
+func (f *TenFlag) SetFromString(val string) error {
+	switch f.FlagType {
+	case "string":
+		f.Value = val
+	case "bool":
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return fmt.Errorf("unable to parse flag %s - %w", f.Name, err)
+		}
+		f.Value = b
+	case "int":
+		i, err := strconv.Atoi(val)
+		if err != nil {
+			return fmt.Errorf("unable to parse flag %s - %w", f.Name, err)
+		}
+		f.Value = i
+	case "int64":
+		i, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to parse flag %s - %w", f.Name, err)
+		}
+		f.Value = i
+	case "uint64":
+		i, err := strconv.ParseUint(val, 10, 64)
+		if err != nil {
+			return fmt.Errorf("unable to parse flag %s - %w", f.Name, err)
+		}
+		f.Value = i
+	default:
+		return fmt.Errorf("unexpected flag type %s", f.FlagType)
+	}
+	return nil
+}
+
 func CreateCLIFlags(flags map[string]*TenFlag) error {
 	for _, tflag := range flags {
 		switch tflag.FlagType {
@@ -313,3 +313,129 @@ func ExtractPrivateCustomQuery(_ interface{}, query interface{}) (*common.Privat
 
 	return &result, nil
 }
+
+// ExtractAccountProofQuery decodes the argument of the AccountProofCQMethod custom query
+func ExtractAccountProofQuery(query interface{}) (*common.AccountProofQuery, error) {
+	jsonData, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result common.AccountProofQuery
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExtractProofQuery decodes the argument of the ProofCQMethod custom query
+func ExtractProofQuery(query interface{}) (*common.ProofQuery, error) {
+	jsonData, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result common.ProofQuery
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExtractLogsPageQuery decodes the argument of the LogsPageCQMethod custom query
+func ExtractLogsPageQuery(query interface{}) (*common.LogsPageQuery, error) {
+	jsonData, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result common.LogsPageQuery
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExtractTransactionByNonceQuery decodes the argument of the TransactionByNonceCQMethod custom query
+func ExtractTransactionByNonceQuery(query interface{}) (*common.TransactionByNonceQuery, error) {
+	jsonData, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result common.TransactionByNonceQuery
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExtractTransactionErrorQuery decodes the argument of the TransactionErrorCQMethod custom query
+func ExtractTransactionErrorQuery(query interface{}) (*common.TransactionErrorQuery, error) {
+	jsonData, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result common.TransactionErrorQuery
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExtractFeeRevenueQuery decodes the argument of the FeeRevenueCQMethod custom query
+func ExtractFeeRevenueQuery(query interface{}) (*common.FeeRevenueQuery, error) {
+	jsonData, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result common.FeeRevenueQuery
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExtractAddressBatchesQuery decodes the argument of the AddressBatchesCQMethod custom query
+func ExtractAddressBatchesQuery(query interface{}) (*common.AddressBatchesQuery, error) {
+	jsonData, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result common.AddressBatchesQuery
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExtractRelayableMessagesQuery decodes the argument of the RelayableMessagesCQMethod custom query
+func ExtractRelayableMessagesQuery(query interface{}) (*common.RelayableMessagesQuery, error) {
+	jsonData, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result common.RelayableMessagesQuery
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// ExtractRelayFeeQuoteQuery decodes the argument of the RelayFeeQuoteCQMethod custom query
+func ExtractRelayFeeQuoteQuery(query interface{}) (*common.RelayFeeQuoteQuery, error) {
+	jsonData, err := json.Marshal(query)
+	if err != nil {
+		return nil, err
+	}
+
+	var result common.RelayFeeQuoteQuery
+	if err := json.Unmarshal(jsonData, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
@@ -0,0 +1,38 @@
+package common
+
+import (
+	"math/big"
+)
+
+// The network parameters that can be changed by the governance of the management contract
+const (
+	GovernanceBaseFee        = "baseFee"        // the base fee of the batches, in wei
+	GovernanceBatchGasLimit  = "batchGasLimit"  // the gas limit of the batches
+	GovernanceRollupInterval = "rollupInterval" // the interval between rollups, in seconds
+
+	// the bounds of the gas limit of the batches, which follows the demand once both are set
+	GovernanceBatchGasLimitMin = "batchGasLimitMin"
+	GovernanceBatchGasLimitMax = "batchGasLimitMax"
+
+	GovernanceFeeDistribution     = "feeDistribution"     // how the base fees are distributed, a FeeDistribution
+	GovernanceFeeTreasury         = "feeTreasury"         // the address receiving the base fees with the FeeToTreasury distribution
+	GovernanceFeeValidatorAdded   = "feeValidatorAdded"   // an address added to the validators splitting the base fees with the FeeToValidators distribution
+	GovernanceFeeValidatorRemoved = "feeValidatorRemoved" // an address removed from those validators
+
+	GovernanceFeeToken         = "feeToken"         // the ERC-20 the paymasters pay the fees of the transactions they sponsor in
+	GovernancePaymasterAdded   = "paymasterAdded"   // a contract approved to pay the fees of the transactions sent to it
+	GovernancePaymasterRemoved = "paymasterRemoved" // a contract no longer approved to pay the fees
+
+	// the hash of the policy of the optional screening module of the sequencer, zero disables the screening
+	GovernanceScreeningPolicy = "screeningPolicy"
+)
+
+// GovernanceChange is a change of a network parameter published by the management contract on the L1. It applies to
+// the batches whose L1 proof is at least ActivationHeight, on a chain that contains the L1 block of the change.
+type GovernanceChange struct {
+	Name             string
+	Value            *big.Int
+	L1BlockHash      L1BlockHash
+	L1BlockHeight    uint64
+	ActivationHeight uint64
+}
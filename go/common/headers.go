@@ -44,6 +44,9 @@ type BatchHeader struct {
 	LatestInboundCrossChainHash   common.Hash                           `json:"inboundCrossChainHash"`   // The block hash of the latest block that has been scanned for cross chain messages.
 	LatestInboundCrossChainHeight *big.Int                              `json:"inboundCrossChainHeight"` // The block height of the latest block that has been scanned for cross chain messages.
 	TransfersTree                 common.Hash                           `json:"transfersTree"`           // This is a merkle tree of all of the outbound value transfers for the MainNet
+
+	// Extras is the extensible section of the header (see BatchExtras). Left out of the encoding when empty
+	Extras BatchExtras `json:"extras" rlp:"optional"`
 }
 
 type batchHeaderEncoding struct {
@@ -57,7 +60,7 @@ type batchHeaderEncoding struct {
 	GasLimit         hexutil.Uint64  `json:"gasLimit"`
 	GasUsed          hexutil.Uint64  `json:"gasUsed"`
 	Time             hexutil.Uint64  `json:"timestamp"`
-	Extra            []byte          `json:"extraData"`
+	Extra            hexutil.Bytes   `json:"extraData"`
 	BaseFee          *hexutil.Big    `json:"baseFeePerGas"`
 	Coinbase         *common.Address `json:"miner"`
 
@@ -68,6 +71,7 @@ type batchHeaderEncoding struct {
 	LatestInboundCrossChainHash   common.Hash                           `json:"inboundCrossChainHash"`   // The block hash of the latest block that has been scanned for cross chain messages.
 	LatestInboundCrossChainHeight *hexutil.Big                          `json:"inboundCrossChainHeight"` // The block height of the latest block that has been scanned for cross chain messages.
 	TransfersTree                 common.Hash
+	Extras                        BatchExtras `json:"extras,omitempty"`
 }
 
 // MarshalJSON custom marshals the BatchHeader into a json
@@ -93,6 +97,7 @@ func (b *BatchHeader) MarshalJSON() ([]byte, error) {
 		b.LatestInboundCrossChainHash,
 		(*hexutil.Big)(b.LatestInboundCrossChainHeight),
 		b.TransfersTree,
+		b.Extras,
 	})
 }
 
@@ -122,6 +127,11 @@ func (b *BatchHeader) UnmarshalJSON(data []byte) error {
 	b.LatestInboundCrossChainHash = dec.LatestInboundCrossChainHash
 	b.LatestInboundCrossChainHeight = (*big.Int)(dec.LatestInboundCrossChainHeight)
 	b.TransfersTree = dec.TransfersTree
+	b.Extras = dec.Extras
+	// an empty list of extras would be encoded, and change the hash of the header
+	if len(b.Extras) == 0 {
+		b.Extras = nil
+	}
 	return nil
 }
 
@@ -137,6 +147,10 @@ type RollupHeader struct {
 	R, S        *big.Int    // signature values
 
 	LastBatchSeqNo uint64
+
+	// StateRootCheckpoint is the state root of the last batch of the rollup, published every few rollups so that external
+	// verifiers can check the proofs of account state against the L1. Empty for the rollups that are not checkpoints.
+	StateRootCheckpoint common.Hash `rlp:"optional"`
 }
 
 // CalldataRollupHeader contains all information necessary to reconstruct the batches included in the rollup.
@@ -155,11 +169,37 @@ type CalldataRollupHeader struct {
 
 	L1HeightDeltas [][]byte // delta of the block height. Stored as a byte array because rlp can't encode negative numbers
 
-	// these fields are for debugging the compression. Uncomment if there are issues
-	// BatchHashes  []L2BatchHash
-	// BatchHeaders []*BatchHeader
-
 	ReOrgs [][]byte `rlp:"optional"` // sparse list of reorged headers - non null only for reorgs.
+
+	// CoinbaseChanges are the exceptions to the Coinbase, e.g. after a rotation of the sequencer key. Ordered by batch
+	// index, and only for the canonical batches whose coinbase differs from the one of the previous canonical batch.
+	CoinbaseChanges []CoinbaseChange `rlp:"optional"`
+
+	// GasLimitDeltas are the changes of the gas limit from a batch to the next, starting from the GasLimit. Nil while
+	// the gas limit is the same for the whole rollup. Stored as byte arrays because rlp can't encode negative numbers
+	GasLimitDeltas [][]byte `rlp:"optional"`
+
+	// BatchHashes are the hashes of the batches of the rollup, and BatchHashesRoot their merkle root. They are only
+	// published by the sequencers running in verification mode, so that the validators check every batch they recreate
+	// against them. Nil and empty otherwise, because the hashes don't compress at all.
+	BatchHashes     []L2BatchHash `rlp:"optional"`
+	BatchHashesRoot common.Hash   `rlp:"optional"`
+
+	// BatchExtras are the extras of the canonical batches that have some, ordered by batch index (the reorged batches
+	// have their full header). Nil while no batch of the rollup has extras.
+	BatchExtras []RollupBatchExtras `rlp:"optional"`
+}
+
+// CoinbaseChange sets the coinbase of the batches of a rollup from the batch at the index onwards
+type CoinbaseChange struct {
+	BatchIdx uint64
+	Coinbase common.Address
+}
+
+// RollupBatchExtras are the extras of the header of the batch at the index in the rollup
+type RollupBatchExtras struct {
+	BatchIdx uint64
+	Extras   BatchExtras
 }
 
 // MarshalJSON custom marshals the RollupHeader into a json
@@ -3,10 +3,12 @@ package common
 import (
 	"crypto/rand"
 	"encoding/json"
+	"math/big"
 	"testing"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/contracts/generated/MessageBus"
 )
 
 func TestBatchHeader_MarshalJSON(t *testing.T) {
@@ -65,3 +67,67 @@ func randomHash() gethcommon.Hash {
 
 	return gethcommon.BytesToHash(byteArr)
 }
+
+func TestRollupHeaderHashIsUnchangedWithoutStateRootCheckpoint(t *testing.T) {
+	// the header of the rollups published before the state root checkpoints were introduced
+	type legacyRollupHeader struct {
+		Coinbase           gethcommon.Address
+		CompressionL1Head  L1BlockHash
+		CrossChainMessages []MessageBus.StructsCrossChainMessage
+		PayloadHash        gethcommon.Hash
+		R, S               *big.Int
+		LastBatchSeqNo     uint64
+	}
+	header := &RollupHeader{CompressionL1Head: randomHash(), LastBatchSeqNo: 10}
+	legacyHash, err := rlpHash(legacyRollupHeader{CompressionL1Head: header.CompressionL1Head, LastBatchSeqNo: 10})
+	require.NoError(t, err)
+	require.Equal(t, legacyHash, header.Hash())
+
+	header.StateRootCheckpoint = randomHash()
+	require.NotEqual(t, legacyHash, header.Hash())
+}
+
+func TestBatchHeaderHashIsUnchangedWithoutExtras(t *testing.T) {
+	// the header of the batches produced before the extras were introduced
+	type legacyBatchHeader struct {
+		ParentHash                    L2BatchHash
+		Root                          StateRoot
+		TxHash                        gethcommon.Hash
+		ReceiptHash                   gethcommon.Hash
+		Number                        *big.Int
+		SequencerOrderNo              *big.Int
+		GasLimit                      uint64
+		GasUsed                       uint64
+		Time                          uint64
+		Extra                         []byte
+		BaseFee                       *big.Int
+		Coinbase                      gethcommon.Address
+		L1Proof                       L1BlockHash
+		R, S                          *big.Int
+		CrossChainMessages            []MessageBus.StructsCrossChainMessage
+		LatestInboundCrossChainHash   gethcommon.Hash
+		LatestInboundCrossChainHeight *big.Int
+		TransfersTree                 gethcommon.Hash
+	}
+	header := &BatchHeader{ParentHash: randomHash(), Number: gethcommon.Big1, SequencerOrderNo: gethcommon.Big2, BaseFee: gethcommon.Big1}
+	legacyHash, err := rlpHash(legacyBatchHeader{ParentHash: header.ParentHash, Number: gethcommon.Big1, SequencerOrderNo: gethcommon.Big2, BaseFee: gethcommon.Big1})
+	require.NoError(t, err)
+	require.Equal(t, legacyHash, header.Hash())
+
+	header.Extras, err = header.Extras.With(RandomnessExtraKey, []byte{0x01})
+	require.NoError(t, err)
+	require.NotEqual(t, legacyHash, header.Hash())
+
+	// the extras survive the JSON encoding, and no extras are decoded as none
+	jsonMarshalled, err := json.Marshal(header)
+	require.NoError(t, err)
+	var unmarshalled BatchHeader
+	require.NoError(t, json.Unmarshal(jsonMarshalled, &unmarshalled))
+	require.Equal(t, header.Hash(), unmarshalled.Hash())
+
+	header.Extras = nil
+	jsonMarshalled, err = json.Marshal(header)
+	require.NoError(t, err)
+	require.NoError(t, json.Unmarshal(jsonMarshalled, &unmarshalled))
+	require.Equal(t, legacyHash, unmarshalled.Hash())
+}
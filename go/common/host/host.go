@@ -1,6 +1,8 @@
 package host
 
 import (
+	"time"
+
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ten-protocol/go-ten/go/common"
@@ -23,6 +25,9 @@ type Host interface {
 	Subscribe(id rpc.ID, encryptedLogSubscription common.EncryptedParamsLogSubscription, matchedLogs chan []byte) error
 	// Unsubscribe terminates a log subscription between the host and the enclave.
 	Unsubscribe(id rpc.ID)
+	// SubscribeHeadEvents subscribes a client connection to the new batch heads, the new rollups or the sync status
+	// changes. It returns the channel of the events and the func terminating the subscription.
+	SubscribeHeadEvents(eventType HeadEventType, conn string) (<-chan any, func(), error)
 	// Stop gracefully stops the host execution.
 	Stop() error
 
@@ -31,6 +36,37 @@ type Host interface {
 
 	// ObscuroConfig returns the info of the Obscuro network
 	ObscuroConfig() (*common.ObscuroNetworkInfo, error)
+
+	// SetReadOnlyMode switches the sequencer in or out of the read-only mode, if the request is signed by the sequencer admin
+	SetReadOnlyMode(request *common.ReadOnlyModeRequest) error
+
+	// PromoteEnclave makes a standby enclave of the sequencer the active one, if the request is signed by the sequencer admin
+	PromoteEnclave(request *common.PromoteEnclaveRequest) error
+
+	// MempoolReport returns the pending transactions that waited in the mempool of the sequencer for longer than the
+	// inclusion deadline
+	MempoolReport() (*common.MempoolReport, error)
+
+	// MempoolStats returns the aggregate statistics of the mempool of the sequencer, if the host serves them
+	MempoolStats() (*common.MempoolStats, error)
+
+	// FailedL1Transactions returns the dead-letter queue of the L1 publisher: the L1 transactions the host gave up on
+	// after their retries
+	FailedL1Transactions() ([]common.FailedL1Transaction, error)
+
+	// RollupCostEstimate estimates the L1 cost of publishing the pending rollup of the sequencer as calldata and as blobs,
+	// and recommends the cheapest route
+	RollupCostEstimate() (*common.RollupCostEstimate, error)
+
+	// SyncStatus returns the sync status of the node, which tells a node that is catching up slowly from a stalled one
+	SyncStatus() *common.SyncStatus
+
+	// RollupWitness returns the encrypted witness of the batches of a rollup, which an enclave can verify without the
+	// chain state
+	RollupWitness(rollupHash common.L2RollupHash) ([]byte, error)
+
+	// VerifyRollupWitness returns the verdict of the enclave on a rollup, signed by the enclave key
+	VerifyRollupWitness(witness []byte) (*common.RollupVerdict, error)
 }
 
 type BlockStream struct {
@@ -38,11 +74,6 @@ type BlockStream struct {
 	Stop   func()              // function to permanently stop the stream and clean up any associated processes/resources
 }
 
-type BatchMsg struct {
-	Batches []*common.ExtBatch // The batches being sent.
-	IsLive  bool               // true if these batches are being sent as new, false if in response to a p2p request
-}
-
 type P2PHostService interface {
 	Service
 	P2P
@@ -52,3 +83,49 @@ type L1RepoService interface {
 	Service
 	L1BlockRepository
 }
+
+// P2PTraffic is the data exchanged with a peer, or for a type of message, since the host started
+type P2PTraffic struct {
+	BytesSent        uint64 `json:"bytesSent"`
+	BytesReceived    uint64 `json:"bytesReceived"`
+	MessagesSent     uint64 `json:"messagesSent"`
+	MessagesReceived uint64 `json:"messagesReceived"`
+}
+
+// P2PBandwidth is the data exchanged by the P2P service, by peer and by message type
+type P2PBandwidth struct {
+	ByPeer        map[string]P2PTraffic `json:"byPeer"`
+	ByMessageType map[string]P2PTraffic `json:"byMessageType"`
+}
+
+// the sources the P2P peers are discovered from
+const (
+	P2PPeerSourceL1  = "l1"  // the hosts registered in the management contract
+	P2PPeerSourceDNS = "dns" // the bootstrap peers published in the DNS records of the p2pBootstrapDNS domain
+)
+
+// P2PPeer is a peer of the host, with the sources it was discovered from and its health. A peer that could not be reached
+// is not gossiped to until its backoff is over and the host reconnected to it
+type P2PPeer struct {
+	Address             string    `json:"address"`
+	Sources             []string  `json:"sources"`
+	Score               int       `json:"score"`
+	Healthy             bool      `json:"healthy"`
+	ConsecutiveFailures int       `json:"consecutiveFailures"`
+	BackoffUntil        time.Time `json:"backoffUntil,omitempty"`
+	LastSuccess         time.Time `json:"lastSuccess,omitempty"`
+}
+
+// P2PPeerVersion is the protocol version last announced by a peer
+type P2PPeerVersion struct {
+	ProtocolVersion uint64    `json:"protocolVersion"`
+	SeenAt          time.Time `json:"seenAt"`
+}
+
+// P2PPeerVersions is the protocol version of the host and the ones of its peers, with a warning when a significant
+// fraction of the peers run a newer version
+type P2PPeerVersions struct {
+	ProtocolVersion uint64                    `json:"protocolVersion"`
+	Peers           map[string]P2PPeerVersion `json:"peers"`
+	Warning         string                    `json:"warning,omitempty"`
+}
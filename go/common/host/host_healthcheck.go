@@ -10,6 +10,11 @@ type HealthStatus interface {
 type HealthCheck struct {
 	OverallHealth bool
 	Errors        []string
+	// ReadOnlyMode is set when the sequencer stopped producing batches and publishing rollups, for the ReadOnlyReason
+	ReadOnlyMode   bool
+	ReadOnlyReason string
+	// Startup is the timing of the startup of the host
+	Startup *StartupReport
 }
 
 // BasicErrHealthStatus is a simple health status implementation, if the ErrMsg is non-empty then OK() returns false
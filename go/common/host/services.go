@@ -20,6 +20,9 @@ const (
 	L2BatchRepositoryName      = "l2-batch-repo"
 	EnclaveServiceName         = "enclaves"
 	LogSubscriptionServiceName = "log-subs"
+	ArchiverName               = "archiver"
+	EventLogName               = "event-log"
+	HeadFeedName               = "head-feed"
 )
 
 // The host has a number of services that encapsulate the various responsibilities of the host.
@@ -42,8 +45,9 @@ type P2P interface {
 	// SendTxToSequencer sends the encrypted transaction to the sequencer.
 	SendTxToSequencer(tx common.EncryptedTx) error
 
-	// RequestBatchesFromSequencer asynchronously requests batches from the sequencer, from the given sequence number
-	RequestBatchesFromSequencer(fromSeqNo *big.Int) error
+	// RequestBatches asynchronously requests the batches of the sequence number range [fromSeqNo, toSeqNo] (toSeqNo nil
+	// for the batches up to the head of the peer) from a peer, the peers taking turns to serve the requests
+	RequestBatches(fromSeqNo *big.Int, toSeqNo *big.Int) error
 	// RespondToBatchRequest sends the requested batches to the requesting peer
 	RespondToBatchRequest(requestID string, batches []*common.ExtBatch) error
 
@@ -73,7 +77,7 @@ type P2PTxHandler interface {
 
 type P2PBatchRequestHandler interface {
 	// HandleBatchRequest will be called in a new goroutine for each new batch request as it arrives
-	HandleBatchRequest(requestID string, fromSeqNo *big.Int)
+	HandleBatchRequest(requestID string, fromSeqNo *big.Int, toSeqNo *big.Int)
 }
 
 // L1BlockRepository provides an interface for the host to request L1 block data (live-streaming and historical)
@@ -91,10 +95,30 @@ type L1BlockRepository interface {
 
 // L1BlockHandler is an interface for receiving new blocks from the repository as they arrive
 type L1BlockHandler interface {
-	// HandleBlock will be called in a new goroutine for each new block as it arrives
+	// HandleBlock is called for each new canonical block, in order. When the head skips some blocks or moves to a fork,
+	// the blocks that became canonical are handled from the one after the previous head or the fork point.
 	HandleBlock(block *types.Block)
 }
 
+// L1ReorgHandler is implemented by the L1 block handlers that are notified of the L1 reorgs, before the blocks of the
+// new branch are handled
+type L1ReorgHandler interface {
+	HandleL1Reorg(reorg *L1Reorg)
+}
+
+// L1Reorg is a change of the canonical L1 chain to a branch that does not extend the previous head
+type L1Reorg struct {
+	ForkPoint *types.Header   // the latest block of both branches
+	Reverted  []*types.Header // the blocks of the previous branch that are no longer canonical, from the previous head
+	OldHead   *types.Header
+	NewHead   *types.Header
+}
+
+// Depth returns the number of blocks of the previous branch that are no longer canonical
+func (r *L1Reorg) Depth() int {
+	return len(r.Reverted)
+}
+
 // L1Publisher provides an interface for the host to interact with Obscuro data (management contract etc.) on L1
 type L1Publisher interface {
 	// InitializeSecret will send a management contract transaction to initialize the network with the generated secret
@@ -110,13 +134,25 @@ type L1Publisher interface {
 	PublishSecretResponse(secretResponse *common.ProducedSecretResponse) error
 
 	FetchLatestPeersList() ([]string, error)
+	// IsHostAttested returns whether the host is attested in the management contract, i.e. it was admitted to the network
+	// and was not revoked since
+	IsHostAttested(hostID gethcommon.Address) (bool, error)
+	// HostAddressOf returns the P2P address registered for the host in the management contract when it was attested,
+	// empty if it was never attested
+	HostAddressOf(hostID gethcommon.Address) (string, error)
 
 	FetchLatestSeqNo() (*big.Int, error)
+	// EstimateRollupCost estimates the cost of publishing a rollup of the given size as calldata and as blobs, with the
+	// fees of the L1 head
+	EstimateRollupCost(rollupSize uint64, batchCount uint64, maxRollupSize uint64) (*common.RollupCostEstimate, error)
 
 	// GetImportantContracts returns a (cached) record of addresses of the important network contracts
 	GetImportantContracts() map[string]gethcommon.Address
 	// ResyncImportantContracts will fetch the latest important contracts from the management contract, update the cache
 	ResyncImportantContracts() error
+
+	// FailedTransactions returns the L1 transactions the publisher gave up on after their retries, oldest first
+	FailedTransactions() []common.FailedL1Transaction
 }
 
 // L2BatchRepository provides an interface for the host to request L2 batch data (live-streaming and historical)
@@ -152,6 +188,50 @@ type EnclaveService interface {
 
 	Subscribe(id rpc.ID, encryptedLogSubscription common.EncryptedParamsLogSubscription) error
 	Unsubscribe(id rpc.ID) error
+
+	// ReadOnlyMode returns whether the sequencer stopped producing batches and publishing rollups, and why
+	ReadOnlyMode() (bool, string)
+	// SetReadOnlyMode switches the sequencer in or out of the read-only mode, in which it keeps serving the RPC requests
+	SetReadOnlyMode(readOnly bool, reason string) error
+
+	// PromoteEnclave makes a standby enclave of the host the active one, which produces the batches of a sequencer
+	PromoteEnclave(enclave int) error
+
+	// RollupCostEstimate estimates the L1 cost of publishing the pending rollup of the sequencer
+	RollupCostEstimate() (*common.RollupCostEstimate, error)
+
+	// SyncStatus returns the sync status of the enclave, with its latest progress through the rollups of the L1 blocks
+	SyncStatus() *common.SyncStatus
+}
+
+// EventLog records the public lifecycle events of the network for external indexers. The L1 reorgs and finality are
+// recorded from the L1 blocks it subscribes to.
+type EventLog interface {
+	// BatchSealed records a batch produced or executed by the enclave
+	BatchSealed(batch *common.ExtBatch)
+	// RollupPublished records a rollup found in an L1 block
+	RollupPublished(rollup *common.ExtRollup, block *types.Block)
+}
+
+// HeadEventType is the type of the events streamed to the client subscriptions by the head feed
+type HeadEventType string
+
+const (
+	NewHeadsEvent   HeadEventType = "newHeads"   // the header of a batch produced or executed by the enclave
+	NewRollupsEvent HeadEventType = "newRollups" // a rollup found in an L1 block
+	SyncStatusEvent HeadEventType = "syncStatus" // the sync status of the node, when it changes
+)
+
+// HeadFeed streams the new batch heads, the rollups published on the L1 and the changes of the sync status of the node
+// to the client subscriptions
+type HeadFeed interface {
+	// BatchSealed notifies the subscribers of a batch produced or executed by the enclave
+	BatchSealed(batch *common.ExtBatch)
+	// RollupPublished notifies the subscribers of a rollup found in an L1 block
+	RollupPublished(rollup *common.ExtRollup, block *types.Block)
+	// Subscribe registers a subscription of a client connection to the events of a type. It returns the channel the
+	// events are sent to, which is closed when the feed stops, and the func terminating the subscription.
+	Subscribe(eventType HeadEventType, conn string) (<-chan any, func(), error)
 }
 
 // LogSubscriptionManager provides an interface for the host to manage log subscriptions
@@ -0,0 +1,131 @@
+package host
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ten-protocol/go-ten/go/common/clock"
+)
+
+// StartupPhase is a phase of the startup of the host. The phases can overlap, e.g. the host receives the L1 blocks while
+// it waits for the enclave.
+type StartupPhase string
+
+const (
+	EnclaveWaitPhase        StartupPhase = "enclaveWait"        // until the enclave reports that it is available
+	SecretProvisioningPhase StartupPhase = "secretProvisioning" // while the enclave awaits the shared secret
+	L1FeedPhase             StartupPhase = "l1Feed"             // until the first L1 block is received
+	BlockIngestionPhase     StartupPhase = "blockIngestion"     // while the enclave catches up with the L1 chain
+	P2PReadyPhase           StartupPhase = "p2pReady"           // the start of the P2P service
+)
+
+// StartupPhaseTiming is the timing of a phase of the startup
+type StartupPhaseTiming struct {
+	Phase     StartupPhase  `json:"phase"`
+	Offset    time.Duration `json:"offset"`   // the time between the start of the host and the start of the phase
+	Duration  time.Duration `json:"duration"` // so far, if the phase is not completed
+	Completed bool          `json:"completed"`
+}
+
+// StartupReport is the timing of the startup of the host, until its enclave is live
+type StartupReport struct {
+	Completed bool                 `json:"completed"`
+	Duration  time.Duration        `json:"duration"` // so far, if the startup is not completed
+	Phases    []StartupPhaseTiming `json:"phases"`   // in the order they started
+}
+
+func (r *StartupReport) String() string {
+	phases := make([]string, len(r.Phases))
+	for i, phase := range r.Phases {
+		phases[i] = fmt.Sprintf("%s=%s", phase.Phase, phase.Duration)
+		if !phase.Completed {
+			phases[i] += " (in progress)"
+		}
+	}
+	return fmt.Sprintf("total=%s [%s]", r.Duration, strings.Join(phases, ", "))
+}
+
+type phaseTiming struct {
+	phase StartupPhase
+	begin time.Time
+	end   time.Time
+}
+
+// StartupTimer records when the phases of the startup of the host begin and end. A phase is only recorded the first
+// time, e.g. a reconnection to the enclave after the startup does not count as a phase of the startup.
+type StartupTimer struct {
+	lock      sync.Mutex
+	clock     clock.Clock
+	started   time.Time
+	completed time.Time
+	phases    []*phaseTiming
+}
+
+func NewStartupTimer(clk clock.Clock) *StartupTimer {
+	return &StartupTimer{clock: clk, started: clk.Now()}
+}
+
+// BeginPhase records the beginning of the phase, unless it already began
+func (t *StartupTimer) BeginPhase(phase StartupPhase) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if t.find(phase) != nil || !t.completed.IsZero() {
+		return
+	}
+	t.phases = append(t.phases, &phaseTiming{phase: phase, begin: t.clock.Now()})
+}
+
+// EndPhase records the end of the phase, if it began and did not end yet
+func (t *StartupTimer) EndPhase(phase StartupPhase) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if timing := t.find(phase); timing != nil && timing.end.IsZero() {
+		timing.end = t.clock.Now()
+	}
+}
+
+// Complete records the end of the startup. It returns true the first time only.
+func (t *StartupTimer) Complete() bool {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if !t.completed.IsZero() {
+		return false
+	}
+	t.completed = t.clock.Now()
+	return true
+}
+
+// Report returns the timing of the startup so far
+func (t *StartupTimer) Report() *StartupReport {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	now := t.clock.Now()
+	report := &StartupReport{Completed: !t.completed.IsZero(), Duration: now.Sub(t.started), Phases: make([]StartupPhaseTiming, len(t.phases))}
+	if report.Completed {
+		report.Duration = t.completed.Sub(t.started)
+	}
+	for i, timing := range t.phases {
+		end := timing.end
+		if end.IsZero() {
+			end = now
+		}
+		report.Phases[i] = StartupPhaseTiming{
+			Phase:     timing.phase,
+			Offset:    timing.begin.Sub(t.started),
+			Duration:  end.Sub(timing.begin),
+			Completed: !timing.end.IsZero(),
+		}
+	}
+	return report
+}
+
+func (t *StartupTimer) find(phase StartupPhase) *phaseTiming {
+	for _, timing := range t.phases {
+		if timing.phase == phase {
+			return timing
+		}
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package host
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+)
+
+func TestStartupTimer(t *testing.T) {
+	clk := clock.NewFake(time.Now())
+	timer := NewStartupTimer(clk)
+	timer.BeginPhase(EnclaveWaitPhase)
+	timer.BeginPhase(L1FeedPhase)
+
+	clk.Advance(time.Second)
+	timer.EndPhase(L1FeedPhase)
+	timer.EndPhase(SecretProvisioningPhase) // never began, so not recorded
+
+	clk.Advance(2 * time.Second)
+	timer.EndPhase(EnclaveWaitPhase)
+	timer.BeginPhase(BlockIngestionPhase)
+	clk.Advance(3 * time.Second)
+
+	report := timer.Report()
+	require.False(t, report.Completed)
+	require.Equal(t, 6*time.Second, report.Duration)
+	require.Equal(t, []StartupPhaseTiming{
+		{Phase: EnclaveWaitPhase, Duration: 3 * time.Second, Completed: true},
+		{Phase: L1FeedPhase, Duration: time.Second, Completed: true},
+		{Phase: BlockIngestionPhase, Offset: 3 * time.Second, Duration: 3 * time.Second},
+	}, report.Phases)
+
+	timer.EndPhase(BlockIngestionPhase)
+	require.True(t, timer.Complete())
+	require.False(t, timer.Complete())
+
+	// the phases that happen again after the startup, e.g. when the enclave restarts, are not recorded
+	clk.Advance(time.Minute)
+	timer.BeginPhase(SecretProvisioningPhase)
+	timer.BeginPhase(BlockIngestionPhase)
+	report = timer.Report()
+	require.True(t, report.Completed)
+	require.Equal(t, 6*time.Second, report.Duration)
+	require.Len(t, report.Phases, 3)
+	require.Equal(t, 3*time.Second, report.Phases[2].Duration)
+}
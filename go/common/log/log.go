@@ -32,6 +32,7 @@ const (
 	EnclaveCmp      = "enclave"
 	HostCmp         = "host"
 	HostRPCCmp      = "host_rpc"
+	HostRESTCmp     = "host_rest"
 	TxInjectCmp     = "tx_inject"
 	TestLogCmp      = "test_log"
 	P2PCmp          = "p2p"
@@ -50,8 +51,13 @@ const (
 	SysOut = "sys_out"
 )
 
-// New - helper function used to create a top level logger for a component.
+// New - helper function used to create a top level logger for a component, with the default redaction.
 func New(component string, level int, out string, ctx ...interface{}) gethlog.Logger {
+	return NewWithRedaction(component, level, out, DefaultRedaction(), ctx...)
+}
+
+// NewWithRedaction creates a top level logger for a component, redacting the sensitive values of its entries
+func NewWithRedaction(component string, level int, out string, redaction Redaction, ctx ...interface{}) gethlog.Logger {
 	context := append(ctx, CmpKey, component)
 	l := gethlog.New(context...)
 	var s gethlog.Handler
@@ -64,6 +70,6 @@ func New(component string, level int, out string, ctx ...interface{}) gethlog.Lo
 		}
 		s = s1
 	}
-	l.SetHandler(gethlog.LvlFilterHandler(gethlog.Lvl(level), s))
+	l.SetHandler(gethlog.LvlFilterHandler(gethlog.Lvl(level), RedactionHandler(redaction, s)))
 	return l
 }
@@ -0,0 +1,118 @@
+package log
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+const redacted = "<redacted>"
+
+// DefaultRedactHexBytes is the default size above which the hex blobs are redacted. It is above the size of the hashes,
+// addresses and signatures, which are routinely logged.
+const DefaultRedactHexBytes = 128
+
+var (
+	// the keys labelled secret or key, e.g. privateKey or shared_secret
+	labelledKeyRegex = regexp.MustCompile(`(?i)secret|key`)
+	// the values of the keys labelled secret or key embedded in a value, e.g. a config formatted as JSON, TOML or with %+v
+	labelledValueRegex = regexp.MustCompile(`(?i)(\w*(?:secret|key)\w*)("?: ?"|"?:|\s*=\s*"?)([^\s",{}\[\]]+)`)
+	hexRegex           = regexp.MustCompile(`(0x)?[0-9a-fA-F]+`)
+)
+
+// Redaction is the redaction applied to the entries of a logger before they are written
+type Redaction struct {
+	// DenyList is the keys whose values are always redacted, in addition to the keys labelled secret or key
+	DenyList []string
+	// MaxHexBytes is the size above which the hex blobs of the messages and values are redacted (0 disables it)
+	MaxHexBytes int
+}
+
+// DefaultRedaction returns the redaction applied by the loggers of the components that do not configure one
+func DefaultRedaction() Redaction {
+	return Redaction{
+		DenyList:    []string{"password", "passphrase", "mnemonic", "seed", "token", "credentials"},
+		MaxHexBytes: DefaultRedactHexBytes,
+	}
+}
+
+// WithDenyList returns the redaction with the keys added to its deny-list
+func (r Redaction) WithDenyList(keys []string) Redaction {
+	r.DenyList = append(append([]string{}, r.DenyList...), keys...)
+	return r
+}
+
+// IsDenied returns whether the values logged with the key are redacted
+func (r Redaction) IsDenied(key string) bool {
+	if labelledKeyRegex.MatchString(key) {
+		return true
+	}
+	for _, denied := range r.DenyList {
+		if strings.EqualFold(key, denied) {
+			return true
+		}
+	}
+	return false
+}
+
+// RedactionHandler redacts the values of the denied keys, and the hex blobs and labelled values found in the messages and
+// the other values, before passing the entries to the handler
+func RedactionHandler(redaction Redaction, h gethlog.Handler) gethlog.Handler {
+	return gethlog.FuncHandler(func(r *gethlog.Record) error {
+		r.Msg = redaction.redactString(r.Msg)
+		ctx := make([]interface{}, len(r.Ctx))
+		copy(ctx, r.Ctx)
+		for i := 0; i+1 < len(ctx); i += 2 {
+			if key, ok := ctx[i].(string); ok && redaction.IsDenied(key) {
+				ctx[i+1] = redacted
+				continue
+			}
+			ctx[i+1] = redaction.redactValue(ctx[i+1])
+		}
+		r.Ctx = ctx
+		return h.Log(r)
+	})
+}
+
+func (r Redaction) redactValue(value interface{}) interface{} {
+	switch v := value.(type) {
+	case string:
+		return r.redactString(v)
+	case []byte:
+		if r.MaxHexBytes > 0 && len(v) > r.MaxHexBytes {
+			return fmt.Sprintf("<redacted %d bytes>", len(v))
+		}
+	case error:
+		if msg := r.redactString(v.Error()); msg != v.Error() {
+			return msg
+		}
+	}
+	return value
+}
+
+func (r Redaction) redactString(s string) string {
+	s = labelledValueRegex.ReplaceAllString(s, "${1}${2}"+redacted)
+	if r.MaxHexBytes <= 0 {
+		return s
+	}
+	return hexRegex.ReplaceAllStringFunc(s, func(blob string) string {
+		size := len(strings.TrimPrefix(blob, "0x")) / 2
+		if size <= r.MaxHexBytes {
+			return blob
+		}
+		return fmt.Sprintf("<redacted %d bytes>", size)
+	})
+}
+
+// ParseKeys returns the keys of a comma-separated list
+func ParseKeys(list string) []string {
+	var keys []string
+	for _, key := range strings.Split(list, ",") {
+		if key = strings.TrimSpace(key); key != "" {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,156 @@
+package log
+
+import (
+	"errors"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+func TestRedactsTheSensitiveValues(t *testing.T) {
+	var records []*gethlog.Record
+	redaction := DefaultRedaction().WithDenyList([]string{"viewer"})
+	logger := gethlog.New()
+	logger.SetHandler(RedactionHandler(redaction, gethlog.FuncHandler(func(r *gethlog.Record) error {
+		records = append(records, r)
+		return nil
+	})))
+
+	blob := strings.Repeat("ab", DefaultRedactHexBytes+1)
+	hash := strings.Repeat("cd", 32)
+	logger.Info("Received blob 0x"+blob,
+		"privateKey", "0x1234",
+		"shared_secret", []byte{1},
+		"Viewer", "alice",
+		"password", "hunter2",
+		"hash", hash,
+		"data", []byte(blob),
+		"cfg", `{"PrivateKeyString": "0x1234", "ArchiveSecretKey":"abc", "L1ChainID": 1337} ArchiveAccessKey:xyz NTPServer: SecretKey = "def"`,
+		ErrKey, errors.New("could not use key=0x1234"),
+	)
+
+	require.Len(t, records, 1)
+	require.Equal(t, "Received blob <redacted 129 bytes>", records[0].Msg)
+	require.Equal(t, []interface{}{
+		"privateKey", redacted,
+		"shared_secret", redacted,
+		"Viewer", redacted,
+		"password", redacted,
+		"hash", hash,
+		"data", "<redacted 258 bytes>",
+		"cfg", `{"PrivateKeyString": "<redacted>", "ArchiveSecretKey":"<redacted>", "L1ChainID": 1337} ArchiveAccessKey:<redacted> NTPServer: SecretKey = "<redacted>"`,
+		ErrKey, "could not use key=<redacted>",
+	}, records[0].Ctx)
+}
+
+func TestHexRedactionCanBeDisabled(t *testing.T) {
+	redaction := Redaction{}
+	blob := strings.Repeat("ab", 1000)
+	require.Equal(t, blob, redaction.redactValue(blob))
+	require.Equal(t, "privateKey=<redacted>", redaction.redactValue("privateKey=0x1234"))
+}
+
+// logMethods are the methods of the loggers, whose arguments after the message alternate keys and values
+var logMethods = map[string]bool{"Trace": true, "Debug": true, "Info": true, "Warn": true, "Error": true, "Crit": true}
+
+// TestLogSitesDoNotLogSensitiveKeys fails if a log site of the repository logs a value under a key that is redacted by
+// default, so that the sensitive values are not logged in the first place rather than only redacted
+func TestLogSitesDoNotLogSensitiveKeys(t *testing.T) {
+	root, err := filepath.Abs(filepath.Join("..", "..", ".."))
+	require.NoError(t, err)
+	keyConsts := logKeyConsts(t)
+	redaction := DefaultRedaction()
+
+	var leaks []string
+	err = filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if name := d.Name(); path != root && (strings.HasPrefix(name, ".") || name == "node_modules") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		fset := token.NewFileSet()
+		file, err := parser.ParseFile(fset, path, nil, 0)
+		if err != nil {
+			return nil //nolint:nilerr // files that do not parse are left to the compiler
+		}
+		ast.Inspect(file, func(n ast.Node) bool {
+			call, ok := n.(*ast.CallExpr)
+			if !ok {
+				return true
+			}
+			selector, ok := call.Fun.(*ast.SelectorExpr)
+			if !ok || !logMethods[selector.Sel.Name] {
+				return true
+			}
+			for i := 1; i < len(call.Args); i += 2 {
+				if key, ok := logKey(call.Args[i], keyConsts); ok && redaction.IsDenied(key) {
+					leaks = append(leaks, fset.Position(call.Args[i].Pos()).String()+": "+key)
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	require.NoError(t, err)
+	require.Empty(t, leaks, "log sites logging values under sensitive keys")
+}
+
+// logKey returns the key of a log entry, if it is a string literal or a key constant of this package
+func logKey(expr ast.Expr, keyConsts map[string]string) (string, bool) {
+	switch e := expr.(type) {
+	case *ast.BasicLit:
+		if e.Kind != token.STRING {
+			return "", false
+		}
+		key, err := strconv.Unquote(e.Value)
+		return key, err == nil
+	case *ast.SelectorExpr:
+		if pkg, ok := e.X.(*ast.Ident); ok && pkg.Name == "log" {
+			key, ok := keyConsts[e.Sel.Name]
+			return key, ok
+		}
+	}
+	return "", false
+}
+
+// logKeyConsts returns the string constants of this package
+func logKeyConsts(t *testing.T) map[string]string {
+	src, err := os.ReadFile("log.go")
+	require.NoError(t, err)
+	file, err := parser.ParseFile(token.NewFileSet(), "log.go", src, 0)
+	require.NoError(t, err)
+
+	consts := map[string]string{}
+	for _, decl := range file.Decls {
+		gen, ok := decl.(*ast.GenDecl)
+		if !ok || gen.Tok != token.CONST {
+			continue
+		}
+		for _, spec := range gen.Specs {
+			value := spec.(*ast.ValueSpec)
+			for i, name := range value.Names {
+				if lit, ok := value.Values[i].(*ast.BasicLit); ok && lit.Kind == token.STRING {
+					consts[name.Name], _ = strconv.Unquote(lit.Value)
+				}
+			}
+		}
+	}
+	return consts
+}
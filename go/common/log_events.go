@@ -50,3 +50,26 @@ type FilterCriteriaJSON struct {
 	Addresses interface{}      `json:"address"`
 	Topics    []interface{}    `json:"topics"`
 }
+
+// LogCursor is the position of a log in the chain. A page of logs ends with the cursor of the first log of the next page
+type LogCursor struct {
+	BatchHeight uint64 `json:"batchHeight"`
+	TxIndex     uint   `json:"txIndex"`
+	LogIndex    uint   `json:"logIndex"`
+}
+
+// LogsPageQuery is the argument of the LogsPageCQMethod private custom query: the eth_getLogs filter of the logs of an
+// account, and the cursor returned with the previous page, nil for the first page
+type LogsPageQuery struct {
+	Address common.Address     `json:"address"`
+	Filter  FilterCriteriaJSON `json:"filter"`
+	Cursor  *LogCursor         `json:"cursor"`
+}
+
+// LogsPage is a page of the logs matching a LogsPageQuery, with the cursor from which the next page starts. The cursor is
+// nil once all the logs were returned. A page can be empty while the cursor is not, when the batches it scanned held no
+// matching log
+type LogsPage struct {
+	Logs   []*types.Log `json:"logs"`
+	Cursor *LogCursor   `json:"cursor"`
+}
@@ -1,17 +1,24 @@
 package metrics
 
 import (
-	"fmt"
+	"net"
+	"net/http"
 	"runtime"
 	"runtime/pprof"
+	"strconv"
 	"time"
 
 	"github.com/ethereum/go-ethereum/metrics/exp"
+	"github.com/ethereum/go-ethereum/metrics/prometheus"
+	"github.com/ten-protocol/go-ten/go/common/log"
 
 	gethlog "github.com/ethereum/go-ethereum/log"
 	gethmetrics "github.com/ethereum/go-ethereum/metrics"
 )
 
+// DefaultPath is the path on which the metrics are served in the Prometheus format, if none is configured
+const DefaultPath = "/metrics"
+
 var (
 	_collectProcessMetricsRefreshDuration = 3 * time.Second
 	_threadCreateProfile                  = pprof.Lookup("threadcreate")
@@ -23,14 +30,19 @@ var (
 type Service struct {
 	registry gethmetrics.Registry
 	port     uint
+	path     string // the path of the Prometheus endpoint
 	logger   gethlog.Logger
 }
 
-func New(enabled bool, port uint, logger gethlog.Logger) *Service {
+func New(enabled bool, port uint, path string, logger gethlog.Logger) *Service {
 	gethmetrics.Enabled = enabled
+	if path == "" {
+		path = DefaultPath
+	}
 	return &Service{
 		registry: gethmetrics.NewRegistry(),
 		port:     port,
+		path:     path,
 		logger:   logger,
 	}
 }
@@ -45,11 +57,28 @@ func (m *Service) Start() {
 	// start the process collection metric on it's own thread
 	go m.CollectProcessMetrics()
 
-	// starts the metric server
-	address := fmt.Sprintf("%s:%d", "0.0.0.0", m.port)
-	m.logger.Info("HTTP Metric server started", "address", address)
+	// starts the metric server on all the interfaces, IPv4 and IPv6
+	address := net.JoinHostPort("", strconv.FormatUint(uint64(m.port), 10))
+	server := &http.Server{Addr: address, Handler: m.handler(), ReadHeaderTimeout: 3 * time.Second}
+	m.logger.Info("HTTP Metric server started", "address", address, "path", m.path)
 	// todo - re-write this http server so to have a stop method
-	exp.Setup(address)
+	go func() {
+		if err := server.ListenAndServe(); err != nil {
+			m.logger.Error("Metric server stopped", log.ErrKey, err)
+		}
+	}()
+}
+
+// handler serves the metrics of the registry in the expvar format, and in the Prometheus format on the configured path
+// and on /debug/metrics/prometheus
+func (m *Service) handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.Handle("/debug/metrics", exp.ExpHandler(m.registry))
+	mux.Handle("/debug/metrics/prometheus", prometheus.Handler(m.registry))
+	if m.path != "/debug/metrics" && m.path != "/debug/metrics/prometheus" {
+		mux.Handle(m.path, prometheus.Handler(m.registry))
+	}
+	return mux
 }
 
 // Registry returns the registry for the metrics service
@@ -0,0 +1,35 @@
+package metrics
+
+import (
+	"io"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestServesThePrometheusMetricsOnTheConfiguredPath(t *testing.T) {
+	service := New(true, 0, "/custom/metrics", gethlog.New())
+	gethmetrics.NewRegisteredCounter("host/test/count", service.Registry()).Inc(3)
+
+	server := httptest.NewServer(service.handler())
+	defer server.Close()
+
+	for _, path := range []string{"/custom/metrics", "/debug/metrics/prometheus"} {
+		resp, err := server.Client().Get(server.URL + path)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+		require.Equal(t, 200, resp.StatusCode)
+		require.Contains(t, string(body), "host_test_count 3")
+	}
+}
+
+func TestDefaultsThePath(t *testing.T) {
+	service := New(false, 0, "", gethlog.New())
+	require.Equal(t, DefaultPath, service.path)
+}
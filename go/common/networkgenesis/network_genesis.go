@@ -0,0 +1,160 @@
+package networkgenesis
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ten-protocol/go-ten/go/common/compression"
+)
+
+// The names of the forks of the schedule
+const (
+	// RelevancyRulesV1Fork is the batch height from which the V1 rules decide which accounts can view the logs
+	RelevancyRulesV1Fork = "relevancyRulesV1"
+	// L1MessageVerifierFork is the batch height from which the contracts can verify the L1 messages through the precompile
+	L1MessageVerifierFork = "l1MessageVerifier"
+	// RollupDecompressionLimitsFork is the L1 height of the compression block of the rollups from which their data is
+	// bounded by the decompression limits. The rollups compressed on top of an earlier block are not bounded
+	RollupDecompressionLimitsFork = "rollupDecompressionLimits"
+)
+
+// knownForks are the forks the enclaves activate. A fork they don't know would be silently ignored, so the genesis is
+// rejected instead
+var knownForks = map[string]bool{
+	RelevancyRulesV1Fork:          true,
+	L1MessageVerifierFork:         true,
+	RollupDecompressionLimitsFork: true,
+}
+
+// Account is an L2 account that is prefunded when the network launches
+type Account struct {
+	Address gethcommon.Address `json:"address"`
+	Amount  *big.Int           `json:"amount"`
+}
+
+// Contract is an L2 contract that is predeployed when the network launches
+type Contract struct {
+	Address gethcommon.Address                  `json:"address"`
+	Balance *big.Int                            `json:"balance,omitempty"`
+	Code    hexutil.Bytes                       `json:"code"`
+	Storage map[gethcommon.Hash]gethcommon.Hash `json:"storage,omitempty"`
+}
+
+// NetworkGenesis holds the parameters that define a TEN network and that must be identical on all its nodes.
+// It is read from a genesis.json file by both the host and the enclave at startup, and its hash is included in the
+// enclave attestation, so enclaves started with a different genesis will not trust each other.
+type NetworkGenesis struct {
+	// The ID of the TEN chain
+	ChainID int64 `json:"chainId"`
+	// The ID of the L1 chain
+	L1ChainID int64 `json:"l1ChainId"`
+	// The identity of the sequencer for the network
+	SequencerID gethcommon.Address `json:"sequencerId"`
+	// The management contract address on the L1 network
+	ManagementContractAddress gethcommon.Address `json:"managementContractAddress"`
+	// The message bus contract address on the L1 network
+	MessageBusAddress gethcommon.Address `json:"messageBusAddress"`
+	// The hash of the L1 block the network state is streamed from (e.g. management contract deployment block)
+	L1StartHash gethcommon.Hash `json:"l1StartHash"`
+	// The L2 accounts funded at genesis
+	Accounts []Account `json:"accounts"`
+	// The system contracts deployed at genesis
+	Contracts []Contract `json:"contracts,omitempty"`
+	// The fork schedule, mapping the name of each fork to the height it activates at (see the fork names for the heights
+	// they refer to). It is part of the hash, so nodes with different schedules do not join the same network
+	Forks map[string]uint64 `json:"forks,omitempty"`
+	// The ceiling of the decompressed data of the batches and the rollups. Nil keeps the compression.DefaultLimits
+	DecompressionLimits *compression.Limits `json:"decompressionLimits,omitempty"`
+}
+
+// Load reads and validates the genesis file at path
+func Load(path string) (*NetworkGenesis, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read network genesis file at %s - %w", path, err)
+	}
+	return Parse(content)
+}
+
+// Parse decodes and validates a genesis from its json representation
+func Parse(content []byte) (*NetworkGenesis, error) {
+	g := &NetworkGenesis{}
+	if err := json.Unmarshal(content, g); err != nil {
+		return nil, fmt.Errorf("could not parse network genesis - %w", err)
+	}
+	if err := g.Validate(); err != nil {
+		return nil, err
+	}
+	return g, nil
+}
+
+// Validate checks the genesis contains the mandatory parameters
+func (g *NetworkGenesis) Validate() error {
+	if g.ChainID <= 0 {
+		return errors.New("invalid network genesis - chainId must be set")
+	}
+	if g.L1ChainID <= 0 {
+		return errors.New("invalid network genesis - l1ChainId must be set")
+	}
+	if g.SequencerID == (gethcommon.Address{}) {
+		return errors.New("invalid network genesis - sequencerId must be set")
+	}
+	if g.ManagementContractAddress == (gethcommon.Address{}) {
+		return errors.New("invalid network genesis - managementContractAddress must be set")
+	}
+	allocated := map[gethcommon.Address]bool{}
+	for _, acc := range g.Accounts {
+		if acc.Amount == nil || acc.Amount.Sign() < 0 {
+			return fmt.Errorf("invalid network genesis - invalid amount for account %s", acc.Address.Hex())
+		}
+		if allocated[acc.Address] {
+			return fmt.Errorf("invalid network genesis - duplicate allocation for %s", acc.Address.Hex())
+		}
+		allocated[acc.Address] = true
+	}
+	for _, contract := range g.Contracts {
+		if len(contract.Code) == 0 {
+			return fmt.Errorf("invalid network genesis - no code for contract %s", contract.Address.Hex())
+		}
+		if contract.Balance != nil && contract.Balance.Sign() < 0 {
+			return fmt.Errorf("invalid network genesis - invalid balance for contract %s", contract.Address.Hex())
+		}
+		if allocated[contract.Address] {
+			return fmt.Errorf("invalid network genesis - duplicate allocation for %s", contract.Address.Hex())
+		}
+		allocated[contract.Address] = true
+	}
+	for name := range g.Forks {
+		if name == "" {
+			return errors.New("invalid network genesis - a fork has no name")
+		}
+		if !knownForks[name] {
+			return fmt.Errorf("invalid network genesis - unknown fork %s", name)
+		}
+	}
+	if g.DecompressionLimits != nil {
+		if g.DecompressionLimits.IsZero() {
+			return errors.New("invalid network genesis - the decompression limits must bound the data")
+		}
+		if err := g.DecompressionLimits.Validate(); err != nil {
+			return fmt.Errorf("invalid network genesis - %w", err)
+		}
+	}
+	return nil
+}
+
+// Hash returns the hash of the canonical json encoding of the genesis
+func (g *NetworkGenesis) Hash() (gethcommon.Hash, error) {
+	// encoding/json writes struct fields in declaration order and map keys sorted, so the encoding is deterministic
+	encoded, err := json.Marshal(g)
+	if err != nil {
+		return gethcommon.Hash{}, fmt.Errorf("could not encode network genesis - %w", err)
+	}
+	return gethcrypto.Keccak256Hash(encoded), nil
+}
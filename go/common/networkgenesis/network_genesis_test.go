@@ -0,0 +1,90 @@
+package networkgenesis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testGenesis = `{
+	"chainId": 443,
+	"l1ChainId": 1337,
+	"sequencerId": "0x0654D8B60033144D567f25bF41baC1FB0D60F23B",
+	"managementContractAddress": "0xeDa66Cc53bd2f26896f6Ba6b736B1Ca325DE04eF",
+	"messageBusAddress": "0xFD03804faCA2538F4633B3EBdfEfc38adafa259B",
+	"accounts": [{"address": "0xA58C60cc047592DE97BF1E8d2f225Fc5D959De77", "amount": 7500000000000000000000000000000}],
+	"forks": {"relevancyRulesV1": 100}
+}`
+
+func TestParseAndHash(t *testing.T) {
+	g, err := Parse([]byte(testGenesis))
+	require.NoError(t, err)
+	require.Equal(t, int64(443), g.ChainID)
+	require.Len(t, g.Accounts, 1)
+	require.Equal(t, uint64(100), g.Forks[RelevancyRulesV1Fork])
+
+	hash, err := g.Hash()
+	require.NoError(t, err)
+
+	// the hash must not depend on the formatting of the file
+	other, err := Parse([]byte(`{"forks":{"relevancyRulesV1":100},"chainId":443,"l1ChainId":1337,` +
+		`"sequencerId":"0x0654D8B60033144D567f25bF41baC1FB0D60F23B",` +
+		`"managementContractAddress":"0xeDa66Cc53bd2f26896f6Ba6b736B1Ca325DE04eF",` +
+		`"messageBusAddress":"0xFD03804faCA2538F4633B3EBdfEfc38adafa259B",` +
+		`"accounts":[{"address":"0xA58C60cc047592DE97BF1E8d2f225Fc5D959De77","amount":7500000000000000000000000000000}]}`))
+	require.NoError(t, err)
+	otherHash, err := other.Hash()
+	require.NoError(t, err)
+	require.Equal(t, hash, otherHash)
+
+	// the nodes with another fork schedule are on another network
+	other.Forks[RelevancyRulesV1Fork] = 101
+	otherHash, err = other.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, hash, otherHash)
+
+	other.Forks[RelevancyRulesV1Fork] = 100
+	other.ChainID = 444
+	otherHash, err = other.Hash()
+	require.NoError(t, err)
+	require.NotEqual(t, hash, otherHash)
+}
+
+func TestParseRejectsIncompleteGenesis(t *testing.T) {
+	_, err := Parse([]byte(`{"chainId": 443}`))
+	require.Error(t, err)
+
+	_, err = Parse([]byte(`{"chainId": 443, "l1ChainId": 1337, "sequencerId": "0x0654D8B60033144D567f25bF41baC1FB0D60F23B", ` +
+		`"managementContractAddress": "0xeDa66Cc53bd2f26896f6Ba6b736B1Ca325DE04eF", "forks": {"": 10}}`))
+	require.ErrorContains(t, err, "a fork has no name")
+
+	_, err = Parse([]byte(`{"chainId": 443, "l1ChainId": 1337, "sequencerId": "0x0654D8B60033144D567f25bF41baC1FB0D60F23B", ` +
+		`"managementContractAddress": "0xeDa66Cc53bd2f26896f6Ba6b736B1Ca325DE04eF", "forks": {"relevancyRulesV2": 10}}`))
+	require.ErrorContains(t, err, "unknown fork relevancyRulesV2")
+}
+
+func TestValidateRejectsDuplicateAllocations(t *testing.T) {
+	addr := `"0xA58C60cc047592DE97BF1E8d2f225Fc5D959De77"`
+	base := `"chainId": 443, "l1ChainId": 1337, "sequencerId": "0x0654D8B60033144D567f25bF41baC1FB0D60F23B", ` +
+		`"managementContractAddress": "0xeDa66Cc53bd2f26896f6Ba6b736B1Ca325DE04eF"`
+
+	_, err := Parse([]byte(`{` + base + `, "accounts": [{"address": ` + addr + `, "amount": 1}, {"address": ` + addr + `, "amount": 2}]}`))
+	require.ErrorContains(t, err, "duplicate allocation")
+
+	_, err = Parse([]byte(`{` + base + `, "accounts": [{"address": ` + addr + `, "amount": 1}], "contracts": [{"address": ` + addr + `, "code": "0x60"}]}`))
+	require.ErrorContains(t, err, "duplicate allocation")
+}
+
+func TestValidateDecompressionLimits(t *testing.T) {
+	base := `"chainId": 443, "l1ChainId": 1337, "sequencerId": "0x0654D8B60033144D567f25bF41baC1FB0D60F23B", ` +
+		`"managementContractAddress": "0xeDa66Cc53bd2f26896f6Ba6b736B1Ca325DE04eF"`
+
+	g, err := Parse([]byte(`{` + base + `, "decompressionLimits": {"maxExpansion": 50, "minSize": 1024, "maxSize": 4096}}`))
+	require.NoError(t, err)
+	require.Equal(t, int64(50), g.DecompressionLimits.MaxExpansion)
+
+	_, err = Parse([]byte(`{` + base + `, "decompressionLimits": {}}`))
+	require.ErrorContains(t, err, "must bound the data")
+	_, err = Parse([]byte(`{` + base + `, "decompressionLimits": {"maxExpansion": 50, "minSize": 4096, "maxSize": 1024}}`))
+	require.ErrorContains(t, err, "invalid decompression limits")
+}
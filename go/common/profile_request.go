@@ -0,0 +1,63 @@
+package common
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	CPUProfile  = "cpu"
+	HeapProfile = "heap"
+)
+
+// ProfileRequest asks the enclave for a pprof profile. It is signed with the key of the profiling admin configured in
+// the enclave, so that the host relaying it cannot request profiles on its own. The request is bound to an enclave and
+// a chain, so it cannot be replayed against another enclave sharing the same admin.
+type ProfileRequest struct {
+	Kind      string             `json:"kind"`      // CPUProfile or HeapProfile
+	Seconds   hexutil.Uint64     `json:"seconds"`   // the duration of a CPU profile
+	Timestamp hexutil.Uint64     `json:"timestamp"` // the unix time of the request, which expires shortly after
+	EnclaveID gethcommon.Address `json:"enclaveID"` // the address of the key of the enclave, which identifies it
+	ChainID   hexutil.Uint64     `json:"chainID"`   // the ID of the L2 chain of the enclave
+	Signature hexutil.Bytes      `json:"signature"`
+}
+
+// Message returns the text signed by the profiling admin, with the personal_sign scheme so any wallet can sign it
+func (r *ProfileRequest) Message() string {
+	return fmt.Sprintf("Profile the TEN enclave %s of the chain %d: %s for %d seconds at %d",
+		r.EnclaveID.Hex(), r.ChainID, r.Kind, r.Seconds, r.Timestamp)
+}
+
+// Sign signs the request with the key of the profiling admin
+func (r *ProfileRequest) Sign(key *ecdsa.PrivateKey) error {
+	signature, err := signText(r.Message(), key)
+	if err != nil {
+		return fmt.Errorf("could not sign the profile request - %w", err)
+	}
+	r.Signature = signature
+	return nil
+}
+
+// Signer returns the address of the account that signed the request
+func (r *ProfileRequest) Signer() (gethcommon.Address, error) {
+	return recoverTextSigner(r.Message(), r.Signature)
+}
+
+// SignerKey returns the public key of the account that signed the request, which the profile is encrypted to
+func (r *ProfileRequest) SignerKey() (*ecdsa.PublicKey, error) {
+	return recoverTextSignerKey(r.Message(), r.Signature)
+}
+
+// DecryptProfile decrypts the profile returned by the enclave with the key of the profiling admin
+func DecryptProfile(adminKey *ecdsa.PrivateKey, encryptedProfile []byte) ([]byte, error) {
+	profile, err := ecies.ImportECDSA(adminKey).Decrypt(encryptedProfile, nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not decrypt the profile - %w", err)
+	}
+	return profile, nil
+}
@@ -2,7 +2,9 @@ package profiler
 
 import (
 	"fmt"
+	"net"
 	"net/http"
+	"strconv"
 	"time"
 
 	gethlog "github.com/ethereum/go-ethereum/log"
@@ -21,7 +23,7 @@ type Profiler struct {
 	logger gethlog.Logger
 }
 
-// NewProfiler returns a new profiler that binds on 0.0.0.0:port
+// NewProfiler returns a new profiler that binds on the port of all the interfaces, IPv4 and IPv6
 func NewProfiler(port int, logger gethlog.Logger) *Profiler {
 	return &Profiler{port: port, logger: logger}
 }
@@ -29,7 +31,7 @@ func NewProfiler(port int, logger gethlog.Logger) *Profiler {
 // Start starts the profiler
 func (p *Profiler) Start() error {
 	go func() {
-		address := fmt.Sprintf("0.0.0.0:%d", p.port)
+		address := net.JoinHostPort("", strconv.Itoa(p.port))
 		server := &http.Server{
 			Addr:              address,
 			ReadHeaderTimeout: 3 * time.Second,
@@ -5,7 +5,10 @@ import (
 	"fmt"
 	"math/big"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
 
 	"github.com/ethereum/go-ethereum/common"
 )
@@ -79,11 +82,141 @@ func (p *QueryPagination) UnmarshalJSON(data []byte) error {
 	return nil
 }
 
+// SimulateTransactionCQMethod names the private custom query, served through eth_getStorageAt, that simulates a transaction
+const SimulateTransactionCQMethod = "simulateTransaction"
+
+// AccountProofCQMethod names the private custom query, served through eth_getStorageAt, that returns the Merkle proof of
+// the state of an account at a batch
+const AccountProofCQMethod = "getAccountProof"
+
+// ProofCQMethod names the private custom query, served through eth_getStorageAt, that returns the Merkle proofs of an
+// account and of its storage slots at a batch, like eth_getProof
+const ProofCQMethod = "getProof"
+
+// LogsPageCQMethod names the private custom query, served through eth_getStorageAt, that returns the logs matching an
+// eth_getLogs filter page by page, so that the ranges and the result sets too big for eth_getLogs can be fetched
+const LogsPageCQMethod = "getLogsPage"
+
+// ListPersonalTransactionsCQMethod names the private custom query, served through eth_getStorageAt, that lists the
+// receipts of the transactions of an account
+const ListPersonalTransactionsCQMethod = "listPersonalTransactions"
+
+// TransactionByNonceCQMethod names the private custom query, served through eth_getStorageAt, that returns the
+// transaction of an account included with a nonce. Wallets use it to find which of the replacements of a transaction
+// (e.g. to speed it up or cancel it) was included.
+const TransactionByNonceCQMethod = "getTransactionByNonce"
+
+// TransactionByNonceQuery is the argument of the TransactionByNonceCQMethod private custom query
+type TransactionByNonceQuery struct {
+	Address common.Address `json:"address"`
+	Nonce   hexutil.Uint64 `json:"nonce"`
+}
+
+// IncludedTransaction is the transaction of an account included in the canonical chain with a nonce
+type IncludedTransaction struct {
+	TxHash      TxHash         `json:"txHash"`
+	BatchHash   L2BatchHash    `json:"batchHash"`
+	BatchHeight hexutil.Uint64 `json:"batchHeight"`
+}
+
+// TransactionErrorCQMethod names the private custom query, served through eth_getStorageAt, that returns why a transaction
+// failed: the validation error that excluded it from a batch, or the error and the revert data of its execution. Only the
+// sender of the transaction gets the details, the other accounts only learn that it failed.
+const TransactionErrorCQMethod = "getTransactionError"
+
+// TransactionErrorQuery is the argument of the TransactionErrorCQMethod private custom query
+type TransactionErrorQuery struct {
+	Address common.Address `json:"address"` // the account of the viewing key the response is encrypted with
+	TxHash  TxHash         `json:"txHash"`
+}
+
+// The stages at which a transaction can fail
+const (
+	TxFailedValidation = "validation" // the transaction was invalid when the batch was produced, and was excluded from it
+	TxFailedExecution  = "execution"  // the transaction was included in the batch, but its execution failed
+)
+
+// TransactionError is why a transaction failed, as recorded by the enclave that executed its batch
+type TransactionError struct {
+	TxHash       TxHash         `json:"txHash"`
+	Sender       common.Address `json:"sender"`
+	Stage        string         `json:"stage"`
+	BatchSeqNo   hexutil.Uint64 `json:"batchSeqNo"` // the batch the transaction was excluded from, or failed in
+	Reason       string         `json:"reason"`
+	RevertReason string         `json:"revertReason,omitempty"` // the reason of a Solidity revert, decoded from the revert data
+	RevertData   hexutil.Bytes  `json:"revertData,omitempty"`
+}
+
+// FeeRevenueCQMethod names the private custom query, served through eth_getStorageAt, that returns the fees collected over
+// a range of batches, or in the batches of a rollup
+const FeeRevenueCQMethod = "getFeeRevenue"
+
+// FeeRevenueQuery is the argument of the FeeRevenueCQMethod private custom query. The range of batches is the one of the
+// rollup if RollupHash is set
+type FeeRevenueQuery struct {
+	Address        common.Address       `json:"address"` // the account of the viewing key the response is encrypted with
+	FromBatchSeqNo tentypes.BatchSeqNo  `json:"fromBatchSeqNo"`
+	ToBatchSeqNo   tentypes.BatchSeqNo  `json:"toBatchSeqNo"`
+	RollupHash     *tentypes.RollupHash `json:"rollupHash,omitempty"`
+}
+
+// AddressBatchesCQMethod names the private custom query, served through eth_getStorageAt, that lists the batches
+// involving an account: the batches of its transactions, of the transactions to it and of the logs of its contracts
+const AddressBatchesCQMethod = "listAddressBatches"
+
+// AddressBatchesQuery is the argument of the AddressBatchesCQMethod private custom query
+type AddressBatchesQuery struct {
+	Address        common.Address      `json:"address"`
+	FromBatchSeqNo tentypes.BatchSeqNo `json:"fromBatchSeqNo"`
+	ToBatchSeqNo   tentypes.BatchSeqNo `json:"toBatchSeqNo"`
+}
+
+// RelayableMessagesCQMethod names the private custom query, served through eth_getStorageAt, that lists the L2->L1
+// messages of a range of batches that were not relayed yet, the highest relay fees first
+const RelayableMessagesCQMethod = "listRelayableMessages"
+
+// RelayableMessagesQuery is the argument of the RelayableMessagesCQMethod private custom query
+type RelayableMessagesQuery struct {
+	Address        common.Address      `json:"address"` // the account of the viewing key the response is encrypted with
+	FromBatchSeqNo tentypes.BatchSeqNo `json:"fromBatchSeqNo"`
+	ToBatchSeqNo   tentypes.BatchSeqNo `json:"toBatchSeqNo"`
+	MinFee         *hexutil.Big        `json:"minFee,omitempty"` // the messages with a lower relay fee are left out
+}
+
+// RelayFeeQuoteCQMethod names the private custom query, served through eth_getStorageAt, that quotes the relay fee of an
+// L2->L1 message
+const RelayFeeQuoteCQMethod = "quoteRelayFee"
+
+// RelayFeeQuoteQuery is the argument of the RelayFeeQuoteCQMethod private custom query
+type RelayFeeQuoteQuery struct {
+	Address     common.Address `json:"address"` // the account of the viewing key the response is encrypted with
+	PayloadSize hexutil.Uint64 `json:"payloadSize"`
+}
+
+// AddressBatch is a canonical batch involving an account
+type AddressBatch struct {
+	SeqNo  tentypes.BatchSeqNo `json:"seqNo"`
+	Hash   L2BatchHash         `json:"hash"`
+	Height hexutil.Uint64      `json:"height"`
+}
+
 type PrivateCustomQueryListTransactions struct {
 	Address    common.Address  `json:"address"`
 	Pagination QueryPagination `json:"pagination"`
 }
 
+// SimulatedTransaction is the outcome of executing a transaction against the latest state without submitting it.
+// Only the logs visible to the sender of the transaction are returned.
+type SimulatedTransaction struct {
+	GasUsed      hexutil.Uint64          `json:"gasUsed"`
+	Failed       bool                    `json:"failed"`
+	ReturnData   hexutil.Bytes           `json:"returnData"`
+	Error        string                  `json:"error,omitempty"`        // the reason the execution failed, if it did
+	RevertReason string                  `json:"revertReason,omitempty"` // the decoded revert reason, if the execution reverted with one
+	BridgeError  errutil.BridgeErrorCode `json:"bridgeError,omitempty"`  // the code of the failure, if a bridge contract reverted
+	Logs         []*types.Log            `json:"logs"`
+}
+
 type ObscuroNetworkInfo struct {
 	ManagementContractAddress common.Address
 	L1StartHash               common.Hash
@@ -92,3 +225,154 @@ type ObscuroNetworkInfo struct {
 	L2MessageBusAddress       common.Address
 	ImportantContracts        map[string]common.Address // map of contract name to address
 }
+
+// MempoolReport lists the pending transactions that have waited in the mempool of the sequencer for longer than the
+// inclusion deadline. The durations are in seconds.
+type MempoolReport struct {
+	InclusionDeadline   hexutil.Uint64       `json:"inclusionDeadline"`
+	PendingCount        hexutil.Uint64       `json:"pendingCount"`
+	OldestTimeInMempool hexutil.Uint64       `json:"oldestTimeInMempool"`
+	OverdueTransactions []OverdueTransaction `json:"overdueTransactions"`
+}
+
+// OverdueTransaction is a pending transaction that exceeded the inclusion deadline
+type OverdueTransaction struct {
+	TxHash        TxHash         `json:"txHash"`
+	FirstSeen     hexutil.Uint64 `json:"firstSeen"` // the unix time the transaction entered the mempool
+	TimeInMempool hexutil.Uint64 `json:"timeInMempool"`
+}
+
+// MempoolStats are the aggregate statistics of the mempool of the sequencer, for the wallets to suggest fees. They reveal
+// no individual transaction, so the percentiles and the latency are only computed over a minimum number of
+// transactions, and are left empty below it. The gas prices are in wei and the durations in seconds.
+type MempoolStats struct {
+	PendingCount        hexutil.Uint64       `json:"pendingCount"`
+	QueuedCount         hexutil.Uint64       `json:"queuedCount"`
+	GasPricePercentiles []GasPricePercentile `json:"gasPricePercentiles"` // the gas prices of the pending transactions
+	InclusionLatency    *hexutil.Uint64      `json:"inclusionLatency"`    // the median wait of the recent transactions included in a batch
+}
+
+// GasPricePercentile is the gas price below which the given percentage of the pending transactions are
+type GasPricePercentile struct {
+	Percentile hexutil.Uint64 `json:"percentile"`
+	GasPrice   *hexutil.Big   `json:"gasPrice"`
+}
+
+// FailedL1Transaction is an L1 transaction the host gave up publishing, after its retries, and kept in the dead-letter
+// queue of its L1 publisher for the operator to resolve
+type FailedL1Transaction struct {
+	TxHash   common.Hash     `json:"txHash"` // the hash of the last attempt
+	To       *common.Address `json:"to"`
+	Nonce    hexutil.Uint64  `json:"nonce"`
+	Attempts hexutil.Uint64  `json:"attempts"`
+	Error    string          `json:"error"`
+	FailedAt hexutil.Uint64  `json:"failedAt"` // the unix time the host gave up on the transaction
+}
+
+// RollupPublicationRoute is the way a rollup is posted to the L1
+type RollupPublicationRoute string
+
+const (
+	CalldataRoute RollupPublicationRoute = "calldata"
+	BlobRoute     RollupPublicationRoute = "blob"
+)
+
+// RollupCostEstimate is the estimated L1 cost, in wei, of publishing the pending rollup of the sequencer as calldata and
+// as blobs, with the fees of the L1 head
+type RollupCostEstimate struct {
+	L1BlockHash    L1BlockHash            `json:"l1BlockHash"`
+	PendingBatches hexutil.Uint64         `json:"pendingBatches"`
+	RollupSize     hexutil.Uint64         `json:"rollupSize"` // the estimated size of the compressed rollup, in bytes
+	GasPrice       *hexutil.Big           `json:"gasPrice"`
+	BlobGasPrice   *hexutil.Big           `json:"blobGasPrice"` // nil if the L1 does not support the blobs
+	CalldataCost   *hexutil.Big           `json:"calldataCost"`
+	BlobCost       *hexutil.Big           `json:"blobCost"` // nil if the L1 does not support the blobs
+	Route          RollupPublicationRoute `json:"route"`
+	// BreakEvenBatches is the number of batches of the average pending size from which the blobs are cheaper than the
+	// calldata. It is 0 if they are not cheaper for any rollup up to the max rollup size, or if no batch is pending.
+	BreakEvenBatches hexutil.Uint64 `json:"breakEvenBatches"`
+}
+
+// RollupVerdict is the result of the re-execution of the batches of a rollup from a witness of the state they read,
+// signed by the enclave that re-executed them. The auditors check that the signer is the key of an attested enclave,
+// and compare the batches and the state roots with the rollups published on the L1.
+type RollupVerdict struct {
+	RollupHash      L2RollupHash   `json:"rollupHash"`
+	FirstBatchSeqNo hexutil.Uint64 `json:"firstBatchSeqNo"`
+	LastBatchSeqNo  hexutil.Uint64 `json:"lastBatchSeqNo"`
+	LastBatchHash   L2BatchHash    `json:"lastBatchHash"`
+	PreStateRoot    StateRoot      `json:"preStateRoot"`  // the state root of the parent of the first batch
+	PostStateRoot   StateRoot      `json:"postStateRoot"` // the state root of the last batch
+	Valid           bool           `json:"valid"`
+	Reason          string         `json:"reason"` // why the rollup is invalid, empty if it is valid
+	Enclave         common.Address `json:"enclave"`
+	Signature       hexutil.Bytes  `json:"signature"` // the signature of the enclave over the hash of the verdict
+}
+
+// Hash returns the hash of the verdict that the enclave signs, which covers all the fields but the signature
+func (v *RollupVerdict) Hash() common.Hash {
+	hash, err := rlpHash([]interface{}{
+		v.RollupHash, uint64(v.FirstBatchSeqNo), uint64(v.LastBatchSeqNo), v.LastBatchHash,
+		v.PreStateRoot, v.PostStateRoot, v.Valid, v.Reason, v.Enclave,
+	})
+	if err != nil {
+		panic("err hashing rollup verdict")
+	}
+	return hash
+}
+
+// RollupSyncProgress is the progress of the enclave through the rollups published in an L1 block, reported while it
+// executes their batches. The ETA is in seconds, and Time is the unix time of the report.
+type RollupSyncProgress struct {
+	L1Block    L1BlockHash    `json:"l1Block"`
+	Rollup     hexutil.Uint64 `json:"rollup"` // the index of the rollup being executed
+	Rollups    hexutil.Uint64 `json:"rollups"`
+	Batch      hexutil.Uint64 `json:"batch"` // the number of batches of the rollup executed so far
+	Batches    hexutil.Uint64 `json:"batches"`
+	BatchSeqNo *hexutil.Big   `json:"batchSeqNo"` // the sequence number of the last batch executed
+	ETA        hexutil.Uint64 `json:"eta"`        // the estimated time left to execute the rollups of the block
+	Time       hexutil.Uint64 `json:"time"`
+}
+
+// SyncStatus is the view of the host on the sync of its enclave. The node is stalled when it is syncing and the enclave
+// did not make any progress for a while, as opposed to a node that is syncing slowly.
+type SyncStatus struct {
+	Status        string              `json:"status"`
+	Syncing       bool                `json:"syncing"`
+	Stalled       bool                `json:"stalled"`
+	L1Head        L1BlockHash         `json:"l1Head"`
+	EnclaveL1Head L1BlockHash         `json:"enclaveL1Head"`
+	L2Head        *hexutil.Big        `json:"l2Head"` // the sequence number of the latest batch known to the host
+	EnclaveL2Head *hexutil.Big        `json:"enclaveL2Head"`
+	LastProgress  hexutil.Uint64      `json:"lastProgress"` // the unix time the enclave last processed a block, batch or rollup batch
+	RollupSync    *RollupSyncProgress `json:"rollupSync"`   // the latest rollup progress report, nil if none was reported
+}
+
+// PublishedRollup is a rollup found in an L1 block, as streamed to the subscribers of the new rollups
+type PublishedRollup struct {
+	Hash           L2RollupHash   `json:"hash"`
+	LastBatchSeqNo hexutil.Uint64 `json:"lastBatchSeqNo"`
+	L1BlockHash    L1BlockHash    `json:"l1BlockHash"`
+	L1BlockNumber  hexutil.Uint64 `json:"l1BlockNumber"`
+}
+
+// RollupCompressionStats are the sizes of a rollup, recorded to track how the changes to the rollup format affect its L1
+// cost. The raw transactions are the RLP-encoded transactions of the batches, before they are compressed and encrypted.
+// The payload and the header are measured as published, i.e. compressed and encrypted.
+type RollupCompressionStats struct {
+	RollupHash      L2RollupHash   `json:"rollupHash"`
+	Batches         hexutil.Uint64 `json:"batches"`
+	RawTxBytes      hexutil.Uint64 `json:"rawTxBytes"`
+	PayloadBytes    hexutil.Uint64 `json:"payloadBytes"`
+	HeaderBytes     hexutil.Uint64 `json:"headerBytes"`
+	ReorgExceptions hexutil.Uint64 `json:"reorgExceptions"` // the batches published with their full header because they were reorged
+}
+
+// CompressionRatio returns the size of the published payload relative to the raw transactions, or 0 for a rollup
+// without transactions
+func (s *RollupCompressionStats) CompressionRatio() float64 {
+	if s.RawTxBytes == 0 {
+		return 0
+	}
+	return float64(s.PayloadBytes) / float64(s.RawTxBytes)
+}
@@ -0,0 +1,48 @@
+package queue
+
+import "fmt"
+
+const (
+	block       = "block"
+	dropOldest  = "drop-oldest"
+	spillToDisk = "spill-to-disk"
+	unknown     = "unknown"
+)
+
+// OverflowPolicy is what a Queue does with the items pushed while it is full.
+type OverflowPolicy int
+
+const (
+	// Block makes the push wait for room
+	Block OverflowPolicy = iota
+	// DropOldest drops the oldest item to make room, the dropped items are counted in a metric
+	DropOldest
+	// SpillToDisk writes the items to a file until there is room, so the push neither waits nor loses an item
+	SpillToDisk
+)
+
+func (p OverflowPolicy) String() string {
+	switch p {
+	case Block:
+		return block
+	case DropOldest:
+		return dropOldest
+	case SpillToDisk:
+		return spillToDisk
+	default:
+		return unknown
+	}
+}
+
+func ToOverflowPolicy(s string) (OverflowPolicy, error) {
+	switch s {
+	case block:
+		return Block, nil
+	case dropOldest:
+		return DropOldest, nil
+	case spillToDisk:
+		return SpillToDisk, nil
+	default:
+		return Block, fmt.Errorf("string '%s' cannot be converted to an overflow policy", s)
+	}
+}
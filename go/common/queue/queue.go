@@ -0,0 +1,178 @@
+package queue
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+	"github.com/ten-protocol/go-ten/go/common/log"
+)
+
+var ErrClosed = errors.New("queue closed")
+
+// Queue is a bounded FIFO queue between a producer that must not be stalled by a slow consumer (e.g. the L1 block
+// listener and the enclave), with a policy for the items pushed while it is full
+type Queue[T any] struct {
+	name     string
+	capacity int
+	policy   OverflowPolicy
+
+	lock    sync.Mutex
+	items   []T
+	spill   *spillFile[T] // the items that did not fit in memory, with the SpillToDisk policy
+	closed  bool
+	changed chan struct{} // closed, and replaced, whenever an item is pushed or popped
+
+	lengthGauge    gethmetrics.Gauge
+	spilledGauge   gethmetrics.Gauge
+	droppedCounter gethmetrics.Counter
+	logger         gethlog.Logger
+}
+
+// New returns a queue holding up to capacity items in memory. The spilled items are stored in a file in spillDir (the
+// system temp directory if empty). The metrics are registered under queue/<name>
+func New[T any](name string, capacity int, policy OverflowPolicy, spillDir string, registry gethmetrics.Registry, logger gethlog.Logger) *Queue[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+	q := &Queue[T]{
+		name:           name,
+		capacity:       capacity,
+		policy:         policy,
+		changed:        make(chan struct{}),
+		lengthGauge:    gethmetrics.NewRegisteredGauge(fmt.Sprintf("queue/%s/length", name), registry),
+		spilledGauge:   gethmetrics.NewRegisteredGauge(fmt.Sprintf("queue/%s/spilled", name), registry),
+		droppedCounter: gethmetrics.NewRegisteredCounter(fmt.Sprintf("queue/%s/dropped", name), registry),
+		logger:         logger,
+	}
+	if policy == SpillToDisk {
+		q.spill = &spillFile[T]{dir: spillDir, name: name}
+	}
+	return q
+}
+
+// Push adds the item to the queue. With the Block policy it waits for room until the context is done, the other policies
+// never wait
+func (q *Queue[T]) Push(ctx context.Context, item T) error {
+	for {
+		q.lock.Lock()
+		if q.closed {
+			q.lock.Unlock()
+			return ErrClosed
+		}
+
+		// with the SpillToDisk policy, the items go to disk as long as earlier ones are there, to keep them in order
+		if q.spill != nil && (q.spill.count > 0 || len(q.items) >= q.capacity) {
+			err := q.spill.write(item)
+			q.spilledGauge.Update(int64(q.spill.count))
+			q.lock.Unlock()
+			return err
+		}
+
+		if len(q.items) < q.capacity || q.policy == DropOldest {
+			if len(q.items) >= q.capacity {
+				var zero T
+				q.items[0] = zero
+				q.items = q.items[1:]
+				q.droppedCounter.Inc(1)
+			}
+			q.items = append(q.items, item)
+			q.notify()
+			q.lock.Unlock()
+			return nil
+		}
+
+		changed := q.changed
+		q.lock.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// Pop removes and returns the oldest item, waiting for one until the context is done or the queue is closed
+func (q *Queue[T]) Pop(ctx context.Context) (T, error) {
+	for {
+		q.lock.Lock()
+		if len(q.items) > 0 {
+			item := q.items[0]
+			var zero T
+			q.items[0] = zero
+			q.items = q.items[1:]
+			q.refill()
+			q.notify()
+			q.lock.Unlock()
+			return item, nil
+		}
+		if q.closed {
+			q.lock.Unlock()
+			var zero T
+			return zero, ErrClosed
+		}
+
+		changed := q.changed
+		q.lock.Unlock()
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			var zero T
+			return zero, ctx.Err()
+		}
+	}
+}
+
+// Len returns the number of items in the queue, in memory and on disk
+func (q *Queue[T]) Len() int {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.spill != nil {
+		return len(q.items) + q.spill.count
+	}
+	return len(q.items)
+}
+
+// Close wakes up the waiting pushes and pops, and deletes the spill file. The items still in the queue are lost
+func (q *Queue[T]) Close() error {
+	q.lock.Lock()
+	defer q.lock.Unlock()
+	if q.closed {
+		return nil
+	}
+	q.closed = true
+	q.items = nil
+	q.notify()
+	if q.spill != nil {
+		return q.spill.remove()
+	}
+	return nil
+}
+
+// refill moves the spilled items back to memory as room is made. Must be called with the lock held
+func (q *Queue[T]) refill() {
+	for q.spill != nil && q.spill.count > 0 && len(q.items) < q.capacity {
+		item, err := q.spill.read()
+		if err != nil {
+			// the rest of the file cannot be trusted anymore
+			q.logger.Error("Could not read the spilled items of the queue, dropping them", "queue", q.name, "dropped", q.spill.count, log.ErrKey, err)
+			q.droppedCounter.Inc(int64(q.spill.count))
+			q.spill.reset()
+			break
+		}
+		q.items = append(q.items, item)
+	}
+	if q.spill != nil {
+		q.spilledGauge.Update(int64(q.spill.count))
+	}
+}
+
+// notify wakes up the waiting pushes and pops. Must be called with the lock held
+func (q *Queue[T]) notify() {
+	q.lengthGauge.Update(int64(len(q.items)))
+	close(q.changed)
+	q.changed = make(chan struct{})
+}
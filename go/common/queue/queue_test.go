@@ -0,0 +1,92 @@
+package queue
+
+import (
+	"context"
+	"os"
+	"testing"
+	"time"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBlockPolicy(t *testing.T) {
+	q := newTestQueue(t, Block, "")
+	ctx := context.Background()
+	require.NoError(t, q.Push(ctx, 1))
+	require.NoError(t, q.Push(ctx, 2))
+
+	timeout, cancel := context.WithTimeout(ctx, 50*time.Millisecond)
+	defer cancel()
+	require.ErrorIs(t, q.Push(timeout, 3), context.DeadlineExceeded)
+
+	pushed := make(chan error)
+	go func() { pushed <- q.Push(ctx, 3) }()
+	requirePop(t, q, 1)
+	require.NoError(t, <-pushed)
+	requirePop(t, q, 2)
+	requirePop(t, q, 3)
+}
+
+func TestDropOldestPolicy(t *testing.T) {
+	q := newTestQueue(t, DropOldest, "")
+	ctx := context.Background()
+	for i := uint64(1); i <= 5; i++ {
+		require.NoError(t, q.Push(ctx, i))
+	}
+
+	require.Equal(t, 2, q.Len())
+	requirePop(t, q, 4)
+	requirePop(t, q, 5)
+}
+
+func TestSpillToDiskPolicy(t *testing.T) {
+	dir := t.TempDir()
+	q := newTestQueue(t, SpillToDisk, dir)
+	ctx := context.Background()
+	for i := uint64(1); i <= 6; i++ {
+		require.NoError(t, q.Push(ctx, i))
+	}
+	require.Equal(t, 6, q.Len())
+	files, err := os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Len(t, files, 1)
+
+	// the spilled items come back in order, even when more are pushed meanwhile
+	requirePop(t, q, 1)
+	require.NoError(t, q.Push(ctx, 7))
+	for i := uint64(2); i <= 7; i++ {
+		requirePop(t, q, i)
+	}
+	require.Zero(t, q.Len())
+
+	require.NoError(t, q.Close())
+	files, err = os.ReadDir(dir)
+	require.NoError(t, err)
+	require.Empty(t, files)
+}
+
+func TestClose(t *testing.T) {
+	q := newTestQueue(t, Block, "")
+	popped := make(chan error)
+	go func() {
+		_, err := q.Pop(context.Background())
+		popped <- err
+	}()
+
+	require.NoError(t, q.Close())
+	require.ErrorIs(t, <-popped, ErrClosed)
+	require.ErrorIs(t, q.Push(context.Background(), 1), ErrClosed)
+}
+
+func newTestQueue(t *testing.T, policy OverflowPolicy, spillDir string) *Queue[uint64] {
+	return New[uint64](t.Name(), 2, policy, spillDir, gethmetrics.NewRegistry(), gethlog.New())
+}
+
+func requirePop(t *testing.T, q *Queue[uint64], expected uint64) {
+	t.Helper()
+	item, err := q.Pop(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, expected, item)
+}
@@ -0,0 +1,82 @@
+package queue
+
+import (
+	"encoding/binary"
+	"fmt"
+	"os"
+
+	"github.com/ethereum/go-ethereum/rlp"
+)
+
+// spillFile holds the items a queue spilled to disk, in the order they were pushed. The items are RLP-encoded and
+// prefixed with their length
+type spillFile[T any] struct {
+	dir  string
+	name string
+	file *os.File // created on the first spill
+
+	readOffset  int64
+	writeOffset int64
+	count       int
+}
+
+func (s *spillFile[T]) write(item T) error {
+	data, err := rlp.EncodeToBytes(item)
+	if err != nil {
+		return fmt.Errorf("could not encode the item - %w", err)
+	}
+	if s.file == nil {
+		s.file, err = os.CreateTemp(s.dir, fmt.Sprintf("queue-%s-*", s.name))
+		if err != nil {
+			return fmt.Errorf("could not create the spill file - %w", err)
+		}
+	}
+
+	record := make([]byte, 4+len(data))
+	binary.BigEndian.PutUint32(record, uint32(len(data)))
+	copy(record[4:], data)
+	if _, err = s.file.WriteAt(record, s.writeOffset); err != nil {
+		return fmt.Errorf("could not write to the spill file - %w", err)
+	}
+	s.writeOffset += int64(len(record))
+	s.count++
+	return nil
+}
+
+func (s *spillFile[T]) read() (T, error) {
+	var item T
+	length := make([]byte, 4)
+	if _, err := s.file.ReadAt(length, s.readOffset); err != nil {
+		return item, fmt.Errorf("could not read from the spill file - %w", err)
+	}
+	data := make([]byte, binary.BigEndian.Uint32(length))
+	if _, err := s.file.ReadAt(data, s.readOffset+4); err != nil {
+		return item, fmt.Errorf("could not read from the spill file - %w", err)
+	}
+	if err := rlp.DecodeBytes(data, &item); err != nil {
+		return item, fmt.Errorf("could not decode the item - %w", err)
+	}
+
+	s.readOffset += int64(4 + len(data))
+	s.count--
+	if s.count == 0 {
+		s.reset()
+	}
+	return item, nil
+}
+
+// reset empties the file once all its items were read, so it does not grow forever
+func (s *spillFile[T]) reset() {
+	s.readOffset, s.writeOffset, s.count = 0, 0, 0
+	if s.file != nil {
+		_ = s.file.Truncate(0)
+	}
+}
+
+func (s *spillFile[T]) remove() error {
+	if s.file == nil {
+		return nil
+	}
+	_ = s.file.Close()
+	return os.Remove(s.file.Name())
+}
@@ -0,0 +1,69 @@
+package common
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ten-protocol/go-ten/contracts/generated/MessageBus"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
+)
+
+// RelayFeeEscrowAddress holds the relay fees attached to the L2->L1 messages until they are relayed. No key controls it,
+// only the enclaves move its funds, to the relayer of the message or back to the sender of an invalid fee.
+var RelayFeeEscrowAddress = common.BytesToAddress(crypto.Keccak256([]byte("ten.relayFeeEscrow")))
+
+// CrossChainMessageHash returns the hash the message buses identify a cross chain message with, the keccak256 hash of
+// its ABI encoding
+func CrossChainMessageHash(msg CrossChainMessage) (common.Hash, error) {
+	// the single argument of verifyMessageFinalized is the message, so its encoding is abi.encode(message)
+	encoded, err := MessageBus.ParsedMessageBusABI().Methods[MessageBus.VerifyMessageFinalizedMethod].Inputs.Pack(msg)
+	if err != nil {
+		return common.Hash{}, fmt.Errorf("could not encode the cross chain message - %w", err)
+	}
+	return crypto.Keccak256Hash(encoded), nil
+}
+
+// MessageRelay is the delivery of an L2->L1 message, recorded by the message bus on the L1. The relay fee of the message
+// is paid to the relayer.
+type MessageRelay struct {
+	MessageHash common.Hash
+	Relayer     common.Address
+}
+
+// MessageRelays are the relays recorded in an L1 block
+type MessageRelays = []MessageRelay
+
+// OutboundMessage is an L2->L1 message published in a batch
+type OutboundMessage struct {
+	Hash       common.Hash
+	BatchSeqNo uint64
+	Message    CrossChainMessage
+}
+
+// RelayableMessage is an L2->L1 message that was not relayed yet, with the relay fee attached to it
+type RelayableMessage struct {
+	Hash             common.Hash         `json:"hash"`
+	BatchSeqNo       tentypes.BatchSeqNo `json:"batchSeqNo"`
+	Sender           common.Address      `json:"sender"`
+	Sequence         hexutil.Uint64      `json:"sequence"`
+	Nonce            uint32              `json:"nonce"`
+	Topic            uint32              `json:"topic"`
+	Payload          hexutil.Bytes       `json:"payload"`
+	ConsistencyLevel uint8               `json:"consistencyLevel"`
+	Fee              *hexutil.Big        `json:"fee"`
+	// RelayCost is the quoted cost of the relay on the L1, so the relayers can tell the profitable messages
+	RelayCost *hexutil.Big `json:"relayCost"`
+	// RolledUp is whether the batch of the message was published in a rollup, the message can only be relayed once the
+	// challenge period of the rollup is over
+	RolledUp bool `json:"rolledUp"`
+}
+
+// RelayFeeQuote is the relay fee quoted for a message, from the L1 gas its relay is expected to use and the base fee of
+// the L1 head
+type RelayFeeQuote struct {
+	Fee       *hexutil.Big   `json:"fee"`
+	L1Gas     hexutil.Uint64 `json:"l1Gas"`
+	L1BaseFee *hexutil.Big   `json:"l1BaseFee"`
+}
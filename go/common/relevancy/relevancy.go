@@ -0,0 +1,103 @@
+// Package relevancy holds the versions of the rules that decide which accounts can view the logs of the TEN chain.
+//
+// A log is relevant to the user accounts referenced by its topics, and a log that references no user account is a
+// lifecycle event, relevant to everyone. Changing how the user accounts are recognised changes the visibility of the
+// logs, so each version of the rules applies from a batch height: the logs of a batch are always filtered by the rules
+// that were active at its height, however many times they are derived again.
+package relevancy
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// Version identifies a version of the relevancy rules
+type Version uint64
+
+const (
+	// V0 are the rules applied before the rules were versioned, and they differ between the stored logs and the
+	// subscriptions. The stored logs treat as user accounts the topics holding 80 to 160 bits of data that have no code
+	// in the state of the parent batch, or that were user accounts in earlier logs. The subscriptions, receipts and
+	// simulations treat as user accounts the topics with the 12 leading zero bytes of an address that have a non-zero
+	// nonce and no code in the state of the batch.
+	V0 Version = 0
+	// V1 applies the same rules to all the logs: a topic is a user account if it has the 12 leading zero bytes of an
+	// address, and the account has a non-zero nonce and no code in the state of the batch. The non-zero nonce prevents the
+	// accidental or malicious sending of funds to an address matching a topic from making its logs private.
+	V1 Version = 1
+
+	// Latest is the most recent version of the rules
+	Latest = V1
+)
+
+// addressPadding is the number of leading zero bytes of a topic holding an address
+const addressPadding = gethcommon.HashLength - gethcommon.AddressLength
+
+// Fork activates a version of the rules from a batch height
+type Fork struct {
+	Version Version
+	Height  uint64
+}
+
+// Forks is the schedule of the versions of the rules, ordered by height. The V0 rules apply below the first fork, so
+// an empty schedule keeps the V0 rules for the whole chain.
+type Forks []Fork
+
+// Validate checks that the versions of the schedule are known, and that the versions and the heights increase
+func (f Forks) Validate() error {
+	previous := Fork{Version: V0}
+	for i, fork := range f {
+		if fork.Version > Latest {
+			return fmt.Errorf("unknown relevancy rules version %d", fork.Version)
+		}
+		if fork.Version <= previous.Version {
+			return fmt.Errorf("relevancy rules version %d does not follow version %d", fork.Version, previous.Version)
+		}
+		if i > 0 && fork.Height <= previous.Height {
+			return fmt.Errorf("relevancy rules version %d activates at height %d, not after version %d at height %d",
+				fork.Version, fork.Height, previous.Version, previous.Height)
+		}
+		previous = fork
+	}
+	return nil
+}
+
+// VersionAt returns the version of the rules that applies to the logs of the batch at the height
+func (f Forks) VersionAt(height uint64) Version {
+	i := sort.Search(len(f), func(i int) bool { return f[i].Height > height })
+	if i == 0 {
+		return V0
+	}
+	return f[i-1].Version
+}
+
+func (f Forks) String() string {
+	entries := make([]string, len(f))
+	for i, fork := range f {
+		entries[i] = fmt.Sprintf("%d:%d", fork.Version, fork.Height)
+	}
+	return strings.Join(entries, ",")
+}
+
+// AccountReader is the state the user accounts are looked up in
+type AccountReader interface {
+	GetNonce(gethcommon.Address) uint64
+	GetCode(gethcommon.Address) []byte
+}
+
+// UserAccount returns the user account held by the topic under the V1 rules, or nil if the topic is not a user account
+func UserAccount(accounts AccountReader, topic gethcommon.Hash) *gethcommon.Address {
+	for _, b := range topic[:addressPadding] {
+		if b != 0 {
+			return nil
+		}
+	}
+	addr := gethcommon.BytesToAddress(topic[addressPadding:])
+	if accounts.GetNonce(addr) == 0 || accounts.GetCode(addr) != nil {
+		return nil
+	}
+	return &addr
+}
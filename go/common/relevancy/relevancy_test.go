@@ -0,0 +1,52 @@
+package relevancy
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+func TestVersionAt(t *testing.T) {
+	require.Equal(t, V0, Forks{}.VersionAt(1_000_000))
+
+	forks := Forks{{Version: V1, Height: 100}}
+	require.Equal(t, "1:100", forks.String())
+	require.Equal(t, V0, forks.VersionAt(0))
+	require.Equal(t, V0, forks.VersionAt(99))
+	require.Equal(t, V1, forks.VersionAt(100))
+	require.Equal(t, V1, forks.VersionAt(1_000_000))
+}
+
+func TestForksMustIncrease(t *testing.T) {
+	// a later version cannot activate before or with an earlier one
+	require.Error(t, Forks{{Version: 1, Height: 200}, {Version: 2, Height: 100}}.Validate())
+	require.Error(t, Forks{{Version: 1, Height: 100}, {Version: 2, Height: 100}}.Validate())
+	require.NoError(t, Forks{{Version: V1, Height: 0}}.Validate())
+}
+
+func TestUserAccount(t *testing.T) {
+	user := gethcommon.HexToAddress("0x2000000000000000000000000000000000000001")
+	funded := gethcommon.HexToAddress("0x2000000000000000000000000000000000000002")
+	contract := gethcommon.HexToAddress("0x2000000000000000000000000000000000000003")
+	stateDB, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	stateDB.SetNonce(user, 1)
+	stateDB.AddBalance(funded, gethcommon.Big1)
+	stateDB.SetNonce(contract, 1)
+	stateDB.SetCode(contract, []byte{0x60, 0x00})
+
+	require.Equal(t, &user, UserAccount(stateDB, gethcommon.BytesToHash(user.Bytes())))
+	// an account that never sent a transaction cannot make the logs referencing it private
+	require.Nil(t, UserAccount(stateDB, gethcommon.BytesToHash(funded.Bytes())))
+	require.Nil(t, UserAccount(stateDB, gethcommon.BytesToHash(contract.Bytes())))
+
+	// a topic holding more than an address is not one, even if its last 20 bytes are a user account
+	notPadded := gethcommon.BytesToHash(user.Bytes())
+	notPadded[0] = 1
+	require.Nil(t, UserAccount(stateDB, notPadded))
+}
@@ -1,12 +1,18 @@
 package retry
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"time"
 )
 
 func Do(fn func() error, retryStrat Strategy) error {
+	return DoWithContext(context.Background(), fn, retryStrat)
+}
+
+// DoWithContext is Do, but stops retrying once the context is done (e.g. the caller is shutting down)
+func DoWithContext(ctx context.Context, fn func() error, retryStrat Strategy) error {
 	// Reset tells the strategy we are about to start making attempts (it might reset attempts counter/record start time)
 	retryStrat.Reset()
 
@@ -31,7 +37,11 @@ func Do(fn func() error, retryStrat Strategy) error {
 			return fmt.Errorf("%s - latest error: %w", retryStrat.Summary(), err)
 		}
 
-		time.Sleep(nextInterval)
+		select {
+		case <-time.After(nextInterval):
+		case <-ctx.Done():
+			return fmt.Errorf("retry interrupted - %w - latest error: %w", ctx.Err(), err)
+		}
 	}
 }
 
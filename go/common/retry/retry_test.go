@@ -1,6 +1,7 @@
 package retry
 
 import (
+	"context"
 	"fmt"
 	"testing"
 	"time"
@@ -110,3 +111,18 @@ func TestRetryForeverWithBackoffs(t *testing.T) {
 		assert.Fail(t, "unexpectedly succeeded earlier than expected, check the test")
 	}
 }
+
+func TestDoWithContext_StopsWhenCancelled(t *testing.T) {
+	var count int
+	ctx, cancel := context.WithCancel(context.Background())
+	testFunc := func() error {
+		count = count + 1
+		if count == 2 {
+			cancel()
+		}
+		return fmt.Errorf("attempt number %d", count)
+	}
+	err := DoWithContext(ctx, testFunc, NewTimeoutStrategy(time.Minute, 10*time.Millisecond))
+	assert.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 2, count, "expected no attempt after the context was cancelled")
+}
@@ -106,6 +106,26 @@ func FromCrossChainMsgs(messages []*generated.CrossChainMsg) []MessageBus.Struct
 	return outMessages
 }
 
+func ToBatchExtraMsgs(extras common.BatchExtras) []*generated.BatchExtraMsg {
+	generatedExtras := make([]*generated.BatchExtraMsg, len(extras))
+	for i, extra := range extras {
+		generatedExtras[i] = &generated.BatchExtraMsg{Key: uint64(extra.Key), Value: extra.Value}
+	}
+	return generatedExtras
+}
+
+// FromBatchExtraMsgs returns nil when there are no extras, so that the header keeps the hash it had before the extras
+func FromBatchExtraMsgs(extras []*generated.BatchExtraMsg) common.BatchExtras {
+	if len(extras) == 0 {
+		return nil
+	}
+	outExtras := make(common.BatchExtras, len(extras))
+	for i, extra := range extras {
+		outExtras[i] = common.BatchExtra{Key: common.BatchExtraKey(extra.Key), Value: extra.Value}
+	}
+	return outExtras
+}
+
 func ToExtBatchMsg(batch *common.ExtBatch) generated.ExtBatchMsg {
 	if batch == nil || batch.Header == nil {
 		return generated.ExtBatchMsg{}
@@ -148,6 +168,7 @@ func ToBatchHeaderMsg(header *common.BatchHeader) *generated.BatchHeaderMsg {
 		Coinbase:                    header.Coinbase.Bytes(),
 		CrossChainMessages:          ToCrossChainMsgs(header.CrossChainMessages),
 		LatestInboundCrossChainHash: header.LatestInboundCrossChainHash.Bytes(),
+		Extras:                      ToBatchExtraMsgs(header.Extras),
 	}
 
 	if header.LatestInboundCrossChainHeight != nil {
@@ -204,6 +225,7 @@ func FromBatchHeaderMsg(header *generated.BatchHeaderMsg) *common.BatchHeader {
 		CrossChainMessages:            FromCrossChainMsgs(header.CrossChainMessages),
 		LatestInboundCrossChainHash:   gethcommon.BytesToHash(header.LatestInboundCrossChainHash),
 		LatestInboundCrossChainHeight: big.NewInt(0).SetBytes(header.LatestInboundCrossChainHeight),
+		Extras:                        FromBatchExtraMsgs(header.Extras),
 	}
 }
 
@@ -220,12 +242,13 @@ func ToRollupHeaderMsg(header *common.RollupHeader) *generated.RollupHeaderMsg {
 		return nil
 	}
 	headerMsg := generated.RollupHeaderMsg{
-		CompressionL1Head:  header.CompressionL1Head.Bytes(),
-		R:                  header.R.Bytes(),
-		S:                  header.S.Bytes(),
-		Coinbase:           header.Coinbase.Bytes(),
-		CrossChainMessages: ToCrossChainMsgs(header.CrossChainMessages),
-		LastBatchSeqNo:     header.LastBatchSeqNo,
+		CompressionL1Head:   header.CompressionL1Head.Bytes(),
+		R:                   header.R.Bytes(),
+		S:                   header.S.Bytes(),
+		Coinbase:            header.Coinbase.Bytes(),
+		CrossChainMessages:  ToCrossChainMsgs(header.CrossChainMessages),
+		LastBatchSeqNo:      header.LastBatchSeqNo,
+		StateRootCheckpoint: header.StateRootCheckpoint.Bytes(),
 	}
 
 	return &headerMsg
@@ -253,11 +276,12 @@ func FromRollupHeaderMsg(header *generated.RollupHeaderMsg) *common.RollupHeader
 	r := &big.Int{}
 	s := &big.Int{}
 	return &common.RollupHeader{
-		CompressionL1Head:  gethcommon.BytesToHash(header.CompressionL1Head),
-		R:                  r.SetBytes(header.R),
-		S:                  s.SetBytes(header.S),
-		Coinbase:           gethcommon.BytesToAddress(header.Coinbase),
-		CrossChainMessages: FromCrossChainMsgs(header.CrossChainMessages),
-		LastBatchSeqNo:     header.LastBatchSeqNo,
+		CompressionL1Head:   gethcommon.BytesToHash(header.CompressionL1Head),
+		R:                   r.SetBytes(header.R),
+		S:                   s.SetBytes(header.S),
+		Coinbase:            gethcommon.BytesToAddress(header.Coinbase),
+		CrossChainMessages:  FromCrossChainMsgs(header.CrossChainMessages),
+		LastBatchSeqNo:      header.LastBatchSeqNo,
+		StateRootCheckpoint: gethcommon.BytesToHash(header.StateRootCheckpoint),
 	}
 }
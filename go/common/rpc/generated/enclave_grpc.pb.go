@@ -79,6 +79,16 @@ type EnclaveProtoClient interface {
 	GetPublicTransactionData(ctx context.Context, in *GetPublicTransactionDataRequest, opts ...grpc.CallOption) (*GetPublicTransactionDataResponse, error)
 	// EnclavePublicConfig returns public network data that is known to the enclave but may not be known to the host
 	EnclavePublicConfig(ctx context.Context, in *EnclavePublicConfigRequest, opts ...grpc.CallOption) (*EnclavePublicConfigResponse, error)
+	// DebugProfile streams a pprof profile of the enclave. The request must be signed by the profiling admin
+	DebugProfile(ctx context.Context, in *DebugProfileRequest, opts ...grpc.CallOption) (EnclaveProto_DebugProfileClient, error)
+	// MempoolReport returns the pending transactions that waited in the mempool of the sequencer for longer than the inclusion deadline
+	MempoolReport(ctx context.Context, in *MempoolReportRequest, opts ...grpc.CallOption) (*MempoolReportResponse, error)
+	// RollupWitness re-executes the batches of a stored rollup, and returns the encrypted witness of the data they read
+	RollupWitness(ctx context.Context, in *RollupWitnessRequest, opts ...grpc.CallOption) (*RollupWitnessResponse, error)
+	// VerifyRollupWitness re-executes the batches of a rollup from its witness alone, and returns the signed verdict
+	VerifyRollupWitness(ctx context.Context, in *VerifyRollupWitnessRequest, opts ...grpc.CallOption) (*VerifyRollupWitnessResponse, error)
+	// MempoolStats returns the aggregate statistics of the mempool of the sequencer, which reveal no individual transaction
+	MempoolStats(ctx context.Context, in *MempoolStatsRequest, opts ...grpc.CallOption) (*MempoolStatsResponse, error)
 }
 
 type enclaveProtoClient struct {
@@ -382,6 +392,74 @@ func (c *enclaveProtoClient) EnclavePublicConfig(ctx context.Context, in *Enclav
 	return out, nil
 }
 
+func (c *enclaveProtoClient) DebugProfile(ctx context.Context, in *DebugProfileRequest, opts ...grpc.CallOption) (EnclaveProto_DebugProfileClient, error) {
+	stream, err := c.cc.NewStream(ctx, &EnclaveProto_ServiceDesc.Streams[1], "/generated.EnclaveProto/DebugProfile", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &enclaveProtoDebugProfileClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type EnclaveProto_DebugProfileClient interface {
+	Recv() (*DebugProfileChunk, error)
+	grpc.ClientStream
+}
+
+type enclaveProtoDebugProfileClient struct {
+	grpc.ClientStream
+}
+
+func (x *enclaveProtoDebugProfileClient) Recv() (*DebugProfileChunk, error) {
+	m := new(DebugProfileChunk)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *enclaveProtoClient) MempoolReport(ctx context.Context, in *MempoolReportRequest, opts ...grpc.CallOption) (*MempoolReportResponse, error) {
+	out := new(MempoolReportResponse)
+	err := c.cc.Invoke(ctx, "/generated.EnclaveProto/MempoolReport", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *enclaveProtoClient) RollupWitness(ctx context.Context, in *RollupWitnessRequest, opts ...grpc.CallOption) (*RollupWitnessResponse, error) {
+	out := new(RollupWitnessResponse)
+	err := c.cc.Invoke(ctx, "/generated.EnclaveProto/RollupWitness", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *enclaveProtoClient) VerifyRollupWitness(ctx context.Context, in *VerifyRollupWitnessRequest, opts ...grpc.CallOption) (*VerifyRollupWitnessResponse, error) {
+	out := new(VerifyRollupWitnessResponse)
+	err := c.cc.Invoke(ctx, "/generated.EnclaveProto/VerifyRollupWitness", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *enclaveProtoClient) MempoolStats(ctx context.Context, in *MempoolStatsRequest, opts ...grpc.CallOption) (*MempoolStatsResponse, error) {
+	out := new(MempoolStatsResponse)
+	err := c.cc.Invoke(ctx, "/generated.EnclaveProto/MempoolStats", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // EnclaveProtoServer is the server API for EnclaveProto service.
 // All implementations must embed UnimplementedEnclaveProtoServer
 // for forward compatibility
@@ -443,6 +521,16 @@ type EnclaveProtoServer interface {
 	GetPublicTransactionData(context.Context, *GetPublicTransactionDataRequest) (*GetPublicTransactionDataResponse, error)
 	// EnclavePublicConfig returns public network data that is known to the enclave but may not be known to the host
 	EnclavePublicConfig(context.Context, *EnclavePublicConfigRequest) (*EnclavePublicConfigResponse, error)
+	// DebugProfile streams a pprof profile of the enclave. The request must be signed by the profiling admin
+	DebugProfile(*DebugProfileRequest, EnclaveProto_DebugProfileServer) error
+	// MempoolReport returns the pending transactions that waited in the mempool of the sequencer for longer than the inclusion deadline
+	MempoolReport(context.Context, *MempoolReportRequest) (*MempoolReportResponse, error)
+	// RollupWitness re-executes the batches of a stored rollup, and returns the encrypted witness of the data they read
+	RollupWitness(context.Context, *RollupWitnessRequest) (*RollupWitnessResponse, error)
+	// VerifyRollupWitness re-executes the batches of a rollup from its witness alone, and returns the signed verdict
+	VerifyRollupWitness(context.Context, *VerifyRollupWitnessRequest) (*VerifyRollupWitnessResponse, error)
+	// MempoolStats returns the aggregate statistics of the mempool of the sequencer, which reveal no individual transaction
+	MempoolStats(context.Context, *MempoolStatsRequest) (*MempoolStatsResponse, error)
 	mustEmbedUnimplementedEnclaveProtoServer()
 }
 
@@ -540,6 +628,21 @@ func (UnimplementedEnclaveProtoServer) GetPublicTransactionData(context.Context,
 func (UnimplementedEnclaveProtoServer) EnclavePublicConfig(context.Context, *EnclavePublicConfigRequest) (*EnclavePublicConfigResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method EnclavePublicConfig not implemented")
 }
+func (UnimplementedEnclaveProtoServer) DebugProfile(*DebugProfileRequest, EnclaveProto_DebugProfileServer) error {
+	return status.Errorf(codes.Unimplemented, "method DebugProfile not implemented")
+}
+func (UnimplementedEnclaveProtoServer) MempoolReport(context.Context, *MempoolReportRequest) (*MempoolReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MempoolReport not implemented")
+}
+func (UnimplementedEnclaveProtoServer) RollupWitness(context.Context, *RollupWitnessRequest) (*RollupWitnessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RollupWitness not implemented")
+}
+func (UnimplementedEnclaveProtoServer) VerifyRollupWitness(context.Context, *VerifyRollupWitnessRequest) (*VerifyRollupWitnessResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method VerifyRollupWitness not implemented")
+}
+func (UnimplementedEnclaveProtoServer) MempoolStats(context.Context, *MempoolStatsRequest) (*MempoolStatsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MempoolStats not implemented")
+}
 func (UnimplementedEnclaveProtoServer) mustEmbedUnimplementedEnclaveProtoServer() {}
 
 // UnsafeEnclaveProtoServer may be embedded to opt out of forward compatibility for this service.
@@ -1096,6 +1199,99 @@ func _EnclaveProto_EnclavePublicConfig_Handler(srv interface{}, ctx context.Cont
 	return interceptor(ctx, in, info, handler)
 }
 
+func _EnclaveProto_DebugProfile_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(DebugProfileRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(EnclaveProtoServer).DebugProfile(m, &enclaveProtoDebugProfileServer{stream})
+}
+
+type EnclaveProto_DebugProfileServer interface {
+	Send(*DebugProfileChunk) error
+	grpc.ServerStream
+}
+
+type enclaveProtoDebugProfileServer struct {
+	grpc.ServerStream
+}
+
+func (x *enclaveProtoDebugProfileServer) Send(m *DebugProfileChunk) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _EnclaveProto_MempoolReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MempoolReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnclaveProtoServer).MempoolReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/generated.EnclaveProto/MempoolReport",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnclaveProtoServer).MempoolReport(ctx, req.(*MempoolReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnclaveProto_RollupWitness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollupWitnessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnclaveProtoServer).RollupWitness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/generated.EnclaveProto/RollupWitness",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnclaveProtoServer).RollupWitness(ctx, req.(*RollupWitnessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnclaveProto_VerifyRollupWitness_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyRollupWitnessRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnclaveProtoServer).VerifyRollupWitness(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/generated.EnclaveProto/VerifyRollupWitness",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnclaveProtoServer).VerifyRollupWitness(ctx, req.(*VerifyRollupWitnessRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _EnclaveProto_MempoolStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MempoolStatsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(EnclaveProtoServer).MempoolStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/generated.EnclaveProto/MempoolStats",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(EnclaveProtoServer).MempoolStats(ctx, req.(*MempoolStatsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // EnclaveProto_ServiceDesc is the grpc.ServiceDesc for EnclaveProto service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1219,6 +1415,22 @@ var EnclaveProto_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "EnclavePublicConfig",
 			Handler:    _EnclaveProto_EnclavePublicConfig_Handler,
 		},
+		{
+			MethodName: "MempoolReport",
+			Handler:    _EnclaveProto_MempoolReport_Handler,
+		},
+		{
+			MethodName: "RollupWitness",
+			Handler:    _EnclaveProto_RollupWitness_Handler,
+		},
+		{
+			MethodName: "VerifyRollupWitness",
+			Handler:    _EnclaveProto_VerifyRollupWitness_Handler,
+		},
+		{
+			MethodName: "MempoolStats",
+			Handler:    _EnclaveProto_MempoolStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1226,6 +1438,11 @@ var EnclaveProto_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _EnclaveProto_StreamL2Updates_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "DebugProfile",
+			Handler:       _EnclaveProto_DebugProfile_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "enclave.proto",
 }
@@ -1,35 +1,38 @@
 package stopcontrol
 
 import (
-	"sync"
-	"sync/atomic"
+	"context"
 )
 
-// StopControl allows for any instance to thread-safely check if the status is stopping or not
+// StopControl allows for any instance to thread-safely check if the status is stopping or not. It is backed by a context
+// that is cancelled on stop, so that the stop can also cancel the calls in progress
 type StopControl struct {
-	stop     *int32
-	stopChan chan interface{}
-	closer   sync.Once
+	ctx    context.Context
+	cancel context.CancelFunc
 }
 
 func New() *StopControl {
+	ctx, cancel := context.WithCancel(context.Background())
 	return &StopControl{
-		stop:     new(int32),
-		stopChan: make(chan interface{}),
+		ctx:    ctx,
+		cancel: cancel,
 	}
 }
 
 func (s *StopControl) Stop() {
-	s.closer.Do(func() {
-		atomic.StoreInt32(s.stop, 1)
-		close(s.stopChan)
-	})
+	s.cancel()
 }
 
 func (s *StopControl) IsStopping() bool {
-	return atomic.LoadInt32(s.stop) == 1
+	return s.ctx.Err() != nil
 }
 
-func (s *StopControl) Done() chan interface{} {
-	return s.stopChan
+func (s *StopControl) Done() <-chan struct{} {
+	return s.ctx.Done()
+}
+
+// Context is cancelled on stop. The calls derive their contexts from it (e.g. to add a timeout), so that they are
+// interrupted when the instance stops
+func (s *StopControl) Context() context.Context {
+	return s.ctx
 }
@@ -1,6 +1,8 @@
 package stopcontrol
 
 import (
+	"context"
+	"errors"
 	"testing"
 	"time"
 )
@@ -58,3 +60,19 @@ func TestStopControl_Done(t *testing.T) {
 		t.Error("Expected Done channel to be closed immediately after Stop")
 	}
 }
+
+func TestStopControl_Context(t *testing.T) {
+	sc := New()
+	ctx, cancel := context.WithTimeout(sc.Context(), time.Hour)
+	defer cancel()
+
+	if ctx.Err() != nil {
+		t.Error("Expected the derived context to be active initially")
+	}
+
+	sc.Stop()
+
+	if !errors.Is(ctx.Err(), context.Canceled) {
+		t.Errorf("Expected the derived context to be cancelled after Stop, but got %v", ctx.Err())
+	}
+}
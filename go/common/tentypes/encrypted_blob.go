@@ -0,0 +1,48 @@
+package tentypes
+
+import (
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// BlobNonceLength is the length of the AES-GCM nonce prepended to the encrypted blobs
+	BlobNonceLength = 12
+	// blobTagLength is the length of the AES-GCM authentication tag that ends the encrypted blobs
+	blobTagLength = 16
+)
+
+// EncryptedBlob is a blob encrypted with AES-GCM, with its nonce prepended, as the transactions of a rollup. It is a
+// 0x-prefixed hex string in JSON and a byte string in RLP
+type EncryptedBlob []byte
+
+// Validate returns an error if the blob is too short to hold a nonce and an authentication tag
+func (b EncryptedBlob) Validate() error {
+	if len(b) < BlobNonceLength+blobTagLength {
+		return fmt.Errorf("encrypted blob too short to contain the nonce and the authentication tag. Length: %d", len(b))
+	}
+	return nil
+}
+
+// Nonce returns the nonce the blob was encrypted with. The blob must be valid
+func (b EncryptedBlob) Nonce() []byte {
+	return b[:BlobNonceLength]
+}
+
+// Ciphertext returns the blob without its nonce. The blob must be valid
+func (b EncryptedBlob) Ciphertext() []byte {
+	return b[BlobNonceLength:]
+}
+
+func (b EncryptedBlob) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(b).MarshalText()
+}
+
+func (b *EncryptedBlob) UnmarshalJSON(input []byte) error {
+	return (*hexutil.Bytes)(b).UnmarshalJSON(input)
+}
+
+func (b *EncryptedBlob) UnmarshalText(input []byte) error {
+	return (*hexutil.Bytes)(b).UnmarshalText(input)
+}
@@ -0,0 +1,46 @@
+package tentypes
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+var rollupHashT = reflect.TypeOf(RollupHash{})
+
+// RollupHash is the hash of the header of a rollup. It is a 0x-prefixed hex string in JSON and a 32 bytes string in RLP,
+// as the Ethereum hashes
+type RollupHash gethcommon.Hash
+
+// ParseRollupHash parses a 0x-prefixed hex hash, rejecting the ones that are not 32 bytes long
+func ParseRollupHash(s string) (RollupHash, error) {
+	var h RollupHash
+	if err := h.UnmarshalText([]byte(s)); err != nil {
+		return RollupHash{}, fmt.Errorf("invalid rollup hash %q - %w", s, err)
+	}
+	return h, nil
+}
+
+// Hash returns the hash as the rest of the codebase, and geth, handle it
+func (h RollupHash) Hash() gethcommon.Hash {
+	return gethcommon.Hash(h)
+}
+
+func (h RollupHash) String() string {
+	return h.Hash().Hex()
+}
+
+func (h RollupHash) MarshalText() ([]byte, error) {
+	return hexutil.Bytes(h[:]).MarshalText()
+}
+
+func (h *RollupHash) UnmarshalJSON(input []byte) error {
+	return hexutil.UnmarshalFixedJSON(rollupHashT, input, h[:])
+}
+
+func (h *RollupHash) UnmarshalText(input []byte) error {
+	return hexutil.UnmarshalFixedText("RollupHash", input, h[:])
+}
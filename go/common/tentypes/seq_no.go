@@ -0,0 +1,71 @@
+package tentypes
+
+import (
+	"fmt"
+	"math/big"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// FirstBatchSeqNo is the sequence number of the genesis batch, the sequence numbers start at 1
+const FirstBatchSeqNo = BatchSeqNo(1)
+
+// BatchSeqNo is the sequence number the sequencer gave to a batch. It is a hex quantity in JSON (like the block numbers
+// of the Ethereum RPC API) and an integer in RLP
+type BatchSeqNo uint64
+
+// ParseBatchSeqNo parses a sequence number written as a hex quantity (0x-prefixed) or as a decimal
+func ParseBatchSeqNo(s string) (BatchSeqNo, error) {
+	var value uint64
+	var err error
+	if strings.HasPrefix(s, "0x") || strings.HasPrefix(s, "0X") {
+		value, err = hexutil.DecodeUint64(s)
+	} else {
+		value, err = strconv.ParseUint(s, 10, 64)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("invalid batch sequence number %q - %w", s, err)
+	}
+	seqNo := BatchSeqNo(value)
+	return seqNo, seqNo.Validate()
+}
+
+// BatchSeqNoFromBig converts the sequence numbers of the batch headers
+func BatchSeqNoFromBig(value *big.Int) (BatchSeqNo, error) {
+	if value == nil || value.Sign() < 0 || !value.IsUint64() {
+		return 0, fmt.Errorf("invalid batch sequence number %v", value)
+	}
+	seqNo := BatchSeqNo(value.Uint64())
+	return seqNo, seqNo.Validate()
+}
+
+// Validate returns an error if the sequence number cannot be the one of a batch
+func (n BatchSeqNo) Validate() error {
+	if n < FirstBatchSeqNo {
+		return fmt.Errorf("invalid batch sequence number %d, the sequence numbers start at %d", n, FirstBatchSeqNo)
+	}
+	return nil
+}
+
+// Big returns the sequence number as stored in the batch headers
+func (n BatchSeqNo) Big() *big.Int {
+	return new(big.Int).SetUint64(uint64(n))
+}
+
+func (n BatchSeqNo) String() string {
+	return strconv.FormatUint(uint64(n), 10)
+}
+
+func (n BatchSeqNo) MarshalText() ([]byte, error) {
+	return hexutil.Uint64(n).MarshalText()
+}
+
+func (n *BatchSeqNo) UnmarshalJSON(input []byte) error {
+	return (*hexutil.Uint64)(n).UnmarshalJSON(input)
+}
+
+func (n *BatchSeqNo) UnmarshalText(input []byte) error {
+	return (*hexutil.Uint64)(n).UnmarshalText(input)
+}
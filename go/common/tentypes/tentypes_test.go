@@ -0,0 +1,101 @@
+package tentypes
+
+import (
+	"encoding/json"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// the values round-trip through the JSON and RLP encodings, with the JSON encodings the RPC clients expect
+func TestEncodings(t *testing.T) {
+	type encoded struct {
+		SeqNo        BatchSeqNo    `json:"seqNo"`
+		RollupHash   RollupHash    `json:"rollupHash"`
+		Blob         EncryptedBlob `json:"blob"`
+		ViewingKeyID ViewingKeyID  `json:"viewingKeyID"`
+	}
+	value := encoded{
+		SeqNo:        255,
+		RollupHash:   RollupHash(gethcommon.HexToHash("0x01")),
+		Blob:         EncryptedBlob{0xab, 0xcd},
+		ViewingKeyID: NewViewingKeyID([]byte("public key")),
+	}
+
+	jsonBytes, err := json.Marshal(value)
+	require.NoError(t, err)
+	require.JSONEq(t, `{
+		"seqNo": "0xff",
+		"rollupHash": "0x0000000000000000000000000000000000000000000000000000000000000001",
+		"blob": "0xabcd",
+		"viewingKeyID": "`+value.ViewingKeyID.String()+`"
+	}`, string(jsonBytes))
+	var fromJSON encoded
+	require.NoError(t, json.Unmarshal(jsonBytes, &fromJSON))
+	require.Equal(t, value, fromJSON)
+
+	rlpBytes, err := rlp.EncodeToBytes(value)
+	require.NoError(t, err)
+	var fromRLP encoded
+	require.NoError(t, rlp.DecodeBytes(rlpBytes, &fromRLP))
+	require.Equal(t, value, fromRLP)
+}
+
+func TestParseBatchSeqNo(t *testing.T) {
+	for input, expected := range map[string]BatchSeqNo{"0x10": 16, "16": 16, "1": FirstBatchSeqNo} {
+		seqNo, err := ParseBatchSeqNo(input)
+		require.NoError(t, err)
+		require.Equal(t, expected, seqNo)
+	}
+	for _, input := range []string{"0", "0x0", "-1", "0x", "ten", "0x10000000000000000"} {
+		_, err := ParseBatchSeqNo(input)
+		require.Error(t, err, input)
+	}
+
+	seqNo, err := BatchSeqNoFromBig(big.NewInt(42))
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(42), seqNo.Big())
+	_, err = BatchSeqNoFromBig(big.NewInt(-1))
+	require.Error(t, err)
+}
+
+func TestParseRollupHash(t *testing.T) {
+	hash := gethcommon.HexToHash("0xabcdef")
+	parsed, err := ParseRollupHash(hash.Hex())
+	require.NoError(t, err)
+	require.Equal(t, hash, parsed.Hash())
+	require.Equal(t, hash.Hex(), parsed.String())
+
+	for _, input := range []string{"", "abcdef", hash.Hex()[2:], hash.Hex() + "00", "0x" + strings.Repeat("zz", 32)} {
+		_, err = ParseRollupHash(input)
+		require.Error(t, err, input)
+	}
+}
+
+func TestEncryptedBlob(t *testing.T) {
+	require.Error(t, EncryptedBlob(make([]byte, BlobNonceLength)).Validate())
+
+	blob := EncryptedBlob(make([]byte, 40))
+	blob[0], blob[BlobNonceLength] = 1, 2
+	require.NoError(t, blob.Validate())
+	require.Len(t, blob.Nonce(), BlobNonceLength)
+	require.Equal(t, byte(1), blob.Nonce()[0])
+	require.Equal(t, byte(2), blob.Ciphertext()[0])
+}
+
+func TestParseViewingKeyID(t *testing.T) {
+	id := NewViewingKeyID([]byte("public key"))
+	parsed, err := ParseViewingKeyID(id.String())
+	require.NoError(t, err)
+	require.Equal(t, id, parsed)
+
+	for _, input := range []string{"", "0x" + id.String(), id.String()[2:], strings.Repeat("zz", ViewingKeyIDLength)} {
+		_, err = ParseViewingKeyID(input)
+		require.Error(t, err, input)
+	}
+}
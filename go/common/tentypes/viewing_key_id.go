@@ -0,0 +1,49 @@
+package tentypes
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// ViewingKeyIDLength is the length of the viewing key IDs, which are truncated to the size of an address
+const ViewingKeyIDLength = 20
+
+// ViewingKeyID identifies a viewing key, and the gateway user it belongs to. It is the truncated hash of the public key
+// of the viewing key. It is an unprefixed hex string in JSON, as in the gateway URLs, and a byte string in RLP
+type ViewingKeyID [ViewingKeyIDLength]byte
+
+// NewViewingKeyID returns the ID of the viewing key with the given public key
+func NewViewingKeyID(vkPubKeyBytes []byte) ViewingKeyID {
+	var id ViewingKeyID
+	copy(id[:], crypto.Keccak256Hash(vkPubKeyBytes).Bytes()[:ViewingKeyIDLength])
+	return id
+}
+
+// ParseViewingKeyID parses an unprefixed hex ID, rejecting the ones that are not 20 bytes long
+func ParseViewingKeyID(s string) (ViewingKeyID, error) {
+	var id ViewingKeyID
+	if err := id.UnmarshalText([]byte(s)); err != nil {
+		return ViewingKeyID{}, err
+	}
+	return id, nil
+}
+
+func (id ViewingKeyID) String() string {
+	return hex.EncodeToString(id[:])
+}
+
+func (id ViewingKeyID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+func (id *ViewingKeyID) UnmarshalText(input []byte) error {
+	if len(input) != 2*ViewingKeyIDLength {
+		return fmt.Errorf("invalid viewing key ID %q, must be %d hex characters", input, 2*ViewingKeyIDLength)
+	}
+	if _, err := hex.Decode(id[:], input); err != nil {
+		return fmt.Errorf("invalid viewing key ID %q - %w", input, err)
+	}
+	return nil
+}
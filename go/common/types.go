@@ -7,6 +7,7 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ten-protocol/go-ten/contracts/generated/EthereumBridge"
 	"github.com/ten-protocol/go-ten/contracts/generated/MessageBus"
 )
 
@@ -22,8 +23,10 @@ type (
 	// when streaming batches out of the enclave.
 	// The properties inside need to be encrypted according to the privacy rules.
 	StreamL2UpdatesResponse struct {
-		Batch *ExtBatch
-		Logs  EncryptedSubscriptionLogs
+		Batch        *ExtBatch
+		Logs         EncryptedSubscriptionLogs
+		SyncProgress *RollupSyncProgress     // the progress of the enclave through the rollups of an L1 block
+		RollupStats  *RollupCompressionStats // the sizes of a rollup processed by the enclave
 	}
 
 	// MainNet aliases
@@ -46,6 +49,7 @@ type (
 
 	CrossChainMessage  = MessageBus.StructsCrossChainMessage
 	CrossChainMessages = []CrossChainMessage
+	TokenMapping       = EthereumBridge.ITokenRegistryTokenMapping // an L1 token bridged to the L2, and its wrapped token
 	ValueTransferEvent struct {
 		Sender   common.Address
 		Receiver common.Address
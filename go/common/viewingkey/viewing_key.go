@@ -13,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/crypto/ecies"
 	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
 	"github.com/ten-protocol/go-ten/go/wallet"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
@@ -38,7 +39,7 @@ const (
 	EIP712EncryptionTokenV2  = "EncryptionToken"
 	EIP712DomainNameValue    = "Ten"
 	EIP712DomainVersionValue = "1.0"
-	UserIDHexLength          = 40
+	UserIDHexLength          = 2 * tentypes.ViewingKeyIDLength
 )
 
 // EIP712EncryptionTokens is a list of all possible options for Encryption token name
@@ -149,6 +150,49 @@ func getBytesFromTypedData(typedData apitypes.TypedData) ([]byte, error) {
 // GenerateAuthenticationEIP712RawDataOptions generates all the options or raw data messages (bytes)
 // for an EIP-712 message used to authenticate an address with user
 func GenerateAuthenticationEIP712RawDataOptions(userID string, chainID int64) ([][]byte, error) {
+	rawDataOptions := make([][]byte, 0, len(EIP712EncryptionTokens))
+	for _, encTokenName := range EIP712EncryptionTokens {
+		typedData, err := authenticationTypedData(encTokenName, userID, chainID)
+		if err != nil {
+			return nil, err
+		}
+		rawData, err := getBytesFromTypedData(*typedData)
+		if err != nil {
+			return nil, err
+		}
+		rawDataOptions = append(rawDataOptions, rawData)
+	}
+	return rawDataOptions, nil
+}
+
+// GenerateAuthenticationEIP712TypedData returns the EIP-712 message used to authenticate an address with user, as
+// expected by the wallets implementing eth_signTypedData_v4
+func GenerateAuthenticationEIP712TypedData(userID string, chainID int64) (*apitypes.TypedData, error) {
+	return authenticationTypedData(EIP712EncryptionToken, userID, chainID)
+}
+
+// GenerateAuthenticationEIP712Hashes returns the domain separator hash and the message hash of the EIP-712 message used
+// to authenticate an address with user. Hardware wallets that can't parse the typed data (e.g. Ledger with
+// signEIP712HashedMessage, or Trezor with ethereumSignTypedHash) sign these two hashes instead.
+func GenerateAuthenticationEIP712Hashes(userID string, chainID int64) ([]byte, []byte, error) {
+	typedData, err := GenerateAuthenticationEIP712TypedData(userID, chainID)
+	if err != nil {
+		return nil, nil, err
+	}
+	domainHash, err := typedData.HashStruct(EIP712Domain, typedData.Domain.Map())
+	if err != nil {
+		return nil, nil, err
+	}
+	messageHash, err := typedData.HashStruct(typedData.PrimaryType, typedData.Message)
+	if err != nil {
+		return nil, nil, err
+	}
+	return domainHash, messageHash, nil
+}
+
+// authenticationTypedData creates the EIP-712 message used to authenticate an address with user, with the given name for
+// the encryption token
+func authenticationTypedData(encTokenName string, userID string, chainID int64) (*apitypes.TypedData, error) {
 	if len(userID) != UserIDHexLength {
 		return nil, fmt.Errorf("userID hex length must be %d, received %d", UserIDHexLength, len(userID))
 	}
@@ -160,52 +204,39 @@ func GenerateAuthenticationEIP712RawDataOptions(userID string, chainID int64) ([
 		ChainId: (*math.HexOrDecimal256)(big.NewInt(chainID)),
 	}
 
-	typedDataList := make([]apitypes.TypedData, 0, len(EIP712EncryptionTokens))
-	for _, encTokenName := range EIP712EncryptionTokens {
-		message := map[string]interface{}{
-			encTokenName: encryptionToken,
-		}
-
-		types := apitypes.Types{
-			EIP712Domain: {
-				{Name: EIP712DomainName, Type: "string"},
-				{Name: EIP712DomainVersion, Type: "string"},
-				{Name: EIP712DomainChainID, Type: "uint256"},
-			},
-			EIP712Type: {
-				{Name: encTokenName, Type: "address"},
-			},
-		}
-
-		newTypeElement := apitypes.TypedData{
-			Types:       types,
-			PrimaryType: EIP712Type,
-			Domain:      domain,
-			Message:     message,
-		}
-		typedDataList = append(typedDataList, newTypeElement)
+	message := map[string]interface{}{
+		encTokenName: encryptionToken,
 	}
 
-	rawDataOptions := make([][]byte, 0, len(typedDataList))
-	for _, typedDataItem := range typedDataList {
-		rawData, err := getBytesFromTypedData(typedDataItem)
-		if err != nil {
-			return nil, err
-		}
-		rawDataOptions = append(rawDataOptions, rawData)
+	types := apitypes.Types{
+		EIP712Domain: {
+			{Name: EIP712DomainName, Type: "string"},
+			{Name: EIP712DomainVersion, Type: "string"},
+			{Name: EIP712DomainChainID, Type: "uint256"},
+		},
+		EIP712Type: {
+			{Name: encTokenName, Type: "address"},
+		},
 	}
-	return rawDataOptions, nil
+
+	return &apitypes.TypedData{
+		Types:       types,
+		PrimaryType: EIP712Type,
+		Domain:      domain,
+		Message:     message,
+	}, nil
 }
 
 // CalculateUserIDHex CalculateUserID calculates userID from a public key
 // (we truncate it, because we want it to have length 20) and encode to hex strings
 func CalculateUserIDHex(publicKeyBytes []byte) string {
-	return hex.EncodeToString(CalculateUserID(publicKeyBytes))
+	return tentypes.NewViewingKeyID(publicKeyBytes).String()
 }
 
 // CalculateUserID calculates userID from a public key (we truncate it, because we want it to have length 20)
 func CalculateUserID(publicKeyBytes []byte) []byte {
-	return crypto.Keccak256Hash(publicKeyBytes).Bytes()[:20]
+	id := tentypes.NewViewingKeyID(publicKeyBytes)
+	return id[:]
 }
 
 // CheckSignatureAndAddress checks if the signature is valid for hash of the message and checks if
@@ -0,0 +1,56 @@
+package viewingkey
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+const testChainID = 443
+
+// hardware wallets sign keccak256(0x1901 || domainHash || messageHash), and return V as 27/28
+func signHashesLikeHardwareWallet(t *testing.T, domainHash []byte, messageHash []byte) ([]byte, gethcommon.Address) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	digest := crypto.Keccak256(append([]byte("\x19\x01"), append(domainHash, messageHash...)...))
+	signature, err := crypto.Sign(digest, key)
+	require.NoError(t, err)
+	signature[64] += 27
+	return signature, crypto.PubkeyToAddress(key.PublicKey)
+}
+
+func TestHardwareWalletSignatureOfHashesIsValid(t *testing.T) {
+	userID := CalculateUserIDHex([]byte("user"))
+	domainHash, messageHash, err := GenerateAuthenticationEIP712Hashes(userID, testChainID)
+	require.NoError(t, err)
+
+	signature, address := signHashesLikeHardwareWallet(t, domainHash, messageHash)
+	valid, err := VerifySignatureEIP712(userID, &address, signature, testChainID)
+	require.NoError(t, err)
+	require.True(t, valid)
+
+	// the signature of another user's message is rejected
+	otherUserID := CalculateUserIDHex([]byte("other user"))
+	valid, err = VerifySignatureEIP712(otherUserID, &address, signature, testChainID)
+	require.Error(t, err)
+	require.False(t, valid)
+}
+
+func TestTypedDataMatchesHashes(t *testing.T) {
+	userID := CalculateUserIDHex([]byte("user"))
+	typedData, err := GenerateAuthenticationEIP712TypedData(userID, testChainID)
+	require.NoError(t, err)
+	rawData, err := getBytesFromTypedData(*typedData)
+	require.NoError(t, err)
+
+	domainHash, messageHash, err := GenerateAuthenticationEIP712Hashes(userID, testChainID)
+	require.NoError(t, err)
+	require.Equal(t, append([]byte("\x19\x01"), append(domainHash, messageHash...)...), rawData)
+
+	_, _, err = GenerateAuthenticationEIP712Hashes("0x1234", testChainID)
+	require.Error(t, err)
+}
@@ -2,7 +2,9 @@ package config
 
 import (
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/codec"
 	"github.com/ten-protocol/go-ten/go/common/flag"
+	"github.com/ten-protocol/go-ten/go/common/log"
 )
 
 // Flag names.
@@ -18,6 +20,8 @@ const (
 	ManagementContractAddressFlag = "managementContractAddress"
 	LogLevelFlag                  = "logLevel"
 	LogPathFlag                   = "logPath"
+	LogRedactKeysFlag             = "logRedactKeys"
+	LogRedactHexBytesFlag         = "logRedactHexBytes"
 	UseInMemoryDBFlag             = "useInMemoryDB"
 	EdgelessDBHostFlag            = "edgelessDBHost"
 	SQLiteDBPathFlag              = "sqliteDBPath"
@@ -33,6 +37,26 @@ const (
 	L2CoinbaseFlag                = "l2Coinbase"
 	GasBatchExecutionLimit        = "gasBatchExecutionLimit"
 	GasLocalExecutionCapFlag      = "gasLocalExecutionCap"
+	MaxLogsBlockRangeFlag         = "maxLogsBlockRange"
+	MaxLogsPerQueryFlag           = "maxLogsPerQuery"
+	BatchTimeBudgetFlag           = "batchTimeBudget"
+	BatchGasBudgetFlag            = "batchGasBudget"
+	SQLiteJournalModeFlag         = "sqliteJournalMode"
+	SQLiteCacheSizeFlag           = "sqliteCacheSize"
+	SQLiteBusyTimeoutFlag         = "sqliteBusyTimeout"
+	MaxDBConnectionsFlag          = "maxDBConnections"
+	AccountCacheSizeFlag          = "accountCacheSize"
+	StorageBenchmarkEnabledFlag   = "storageBenchmarkEnabled"
+	NetworkGenesisPathFlag        = "networkGenesisPath"
+	StateRootCheckpointFlag       = "stateRootCheckpointInterval"
+	L1FinalityDepthFlag           = "l1FinalityDepth"
+	ProfilingAdminFlag            = "profilingAdminAddress"
+	TxPoolPriceBumpFlag           = "txPoolPriceBump"
+	TxInclusionDeadlineFlag       = "txInclusionDeadline"
+	BatchCodecFlag                = "batchCodec"
+	RollupVerificationWorkersFlag = "rollupVerificationWorkers"
+	RollupIntegrityCheckFlag      = "rollupIntegrityCheck"
+	ScreeningDenyListPathFlag     = "screeningDenyListPath"
 )
 
 // EnclaveFlags are the flags that the enclave can receive
@@ -46,23 +70,45 @@ var EnclaveFlags = map[string]*flag.TenFlag{
 	ManagementContractAddressFlag: flag.NewStringFlag(ManagementContractAddressFlag, "", "The management contract address on the L1"),
 	LogLevelFlag:                  flag.NewIntFlag(LogLevelFlag, 3, "The verbosity level of logs. (Defaults to Info)"),
 	LogPathFlag:                   flag.NewStringFlag(LogPathFlag, "stdout", "The path to use for the enclave service's log file"),
+	LogRedactKeysFlag:             flag.NewStringFlag(LogRedactKeysFlag, "", "A comma-separated list of log keys whose values are redacted, in addition to the default deny-list and the keys labelled secret or key"),
+	LogRedactHexBytesFlag:         flag.NewIntFlag(LogRedactHexBytesFlag, log.DefaultRedactHexBytes, "The size in bytes above which the hex blobs in the logs are redacted. Zero disables it"),
 	EdgelessDBHostFlag:            flag.NewStringFlag(EdgelessDBHostFlag, "", "Host address for the edgeless DB instance (can be empty if useInMemoryDB is true or if not using attestation"),
 	SQLiteDBPathFlag:              flag.NewStringFlag(SQLiteDBPathFlag, "", "Filepath for the sqlite DB persistence file (can be empty if a throwaway file in /tmp/ is acceptable or if using InMemory DB or if using attestation/EdgelessDB)"),
 	MinGasPriceFlag:               flag.NewInt64Flag(MinGasPriceFlag, 1, "The minimum gas price for mining a transaction"),
+	TxPoolPriceBumpFlag:           flag.NewUint64Flag(TxPoolPriceBumpFlag, 10, "The percentage by which the fees of a transaction must exceed the ones of the pending transaction with the same sender and nonce to replace it"),
+	TxInclusionDeadlineFlag:       flag.NewUint64Flag(TxInclusionDeadlineFlag, 60, "The number of seconds a transaction can wait in the mempool of the sequencer before it is reported as overdue. Zero disables the tracking"),
 	MessageBusAddressFlag:         flag.NewStringFlag(MessageBusAddressFlag, "", "The address of the L1 message bus contract owned by the management contract."),
 	SequencerIDFlag:               flag.NewStringFlag(SequencerIDFlag, "", "The 20 bytes of the address of the sequencer for this network"),
 	MaxBatchSizeFlag:              flag.NewUint64Flag(MaxBatchSizeFlag, 1024*25, "The maximum size a batch is allowed to reach uncompressed"),
 	MaxRollupSizeFlag:             flag.NewUint64Flag(MaxRollupSizeFlag, 1024*64, "The maximum size a rollup is allowed to reach"),
+	RollupVerificationWorkersFlag: flag.NewIntFlag(RollupVerificationWorkersFlag, 4, "The maximum number of rollups decrypted, decompressed and checked concurrently, across the L1 blocks ingested during a catch-up. Their batches are always executed in order"),
+	RollupIntegrityCheckFlag:      flag.NewBoolFlag(RollupIntegrityCheckFlag, false, "Whether the sequencer publishes the hashes of the batches in its rollups, so that the validators check every batch they recreate against them"),
+	StateRootCheckpointFlag:       flag.NewUint64Flag(StateRootCheckpointFlag, 10, "The number of rollups between two publications of the L2 state root to the L1. Zero disables the publication"),
+	L1FinalityDepthFlag:           flag.NewUint64Flag(L1FinalityDepthFlag, 64, "The number of L1 blocks on top of the compression block of a rollup for its batches to be returned for the finalized block tag"),
+	ScreeningDenyListPathFlag:     flag.NewStringFlag(ScreeningDenyListPathFlag, "", "The path to the deny list screening the transactions of the sequencer, one address per line. It only applies once the governance publishes its policy hash. Empty disables the screening"),
 	L2BaseFeeFlag:                 flag.NewUint64Flag(L2BaseFeeFlag, 1, ""),
 	L2CoinbaseFlag:                flag.NewStringFlag(L2CoinbaseFlag, "0xd6C9230053f45F873Cb66D8A02439380a37A4fbF", ""),
 	GasBatchExecutionLimit:        flag.NewUint64Flag(GasBatchExecutionLimit, 30_000_000, "Max gas that can be executed in a single batch"),
+	BatchTimeBudgetFlag:           flag.NewUint64Flag(BatchTimeBudgetFlag, 0, "The number of milliseconds the sequencer spends executing the transactions of a batch before it seals it, leaving the rest for the next batches. Zero disables it"),
+	BatchGasBudgetFlag:            flag.NewUint64Flag(BatchGasBudgetFlag, 0, "The gas used by the transactions of a batch above which the sequencer seals it, leaving the rest for the next batches. Zero disables it"),
 	ObscuroGenesisFlag:            flag.NewStringFlag(ObscuroGenesisFlag, "", "The json string with the obscuro genesis"),
 	L1ChainIDFlag:                 flag.NewInt64Flag(L1ChainIDFlag, 1337, "An integer representing the unique chain id of the Ethereum chain used as an L1 (default 1337)"),
 	ObscuroChainIDFlag:            flag.NewInt64Flag(ObscuroChainIDFlag, 443, "An integer representing the unique chain id of the Obscuro chain (default 443)"),
 	UseInMemoryDBFlag:             flag.NewBoolFlag(UseInMemoryDBFlag, true, "Whether the enclave will use an in-memory DB rather than persist data"),
 	ProfilerEnabledFlag:           flag.NewBoolFlag(ProfilerEnabledFlag, false, "Runs a profiler instance (Defaults to false)"),
+	ProfilingAdminFlag:            flag.NewStringFlag(ProfilingAdminFlag, "", "The address of the account allowed to request the pprof profiles of the enclave through the host. Only used by the enclaves built with the enclaveprofiling build tag"),
 	DebugNamespaceEnabledFlag:     flag.NewBoolFlag(DebugNamespaceEnabledFlag, false, "Whether the debug namespace is enabled"),
 	GasLocalExecutionCapFlag:      flag.NewUint64Flag(GasLocalExecutionCapFlag, 40_000_000, "Max gas usage when executing local transactions"),
+	MaxLogsBlockRangeFlag:         flag.NewUint64Flag(MaxLogsBlockRangeFlag, 10_000, "The maximum number of batches searched by an eth_getLogs request, or by a page of the getLogsPage query. Zero disables it"),
+	MaxLogsPerQueryFlag:           flag.NewIntFlag(MaxLogsPerQueryFlag, 10_000, "The maximum number of logs returned by an eth_getLogs request, or in a page of the getLogsPage query. Zero disables it"),
+	SQLiteJournalModeFlag:         flag.NewStringFlag(SQLiteJournalModeFlag, "", "The sqlite journal mode (e.g. WAL, DELETE). Empty uses the driver default"),
+	SQLiteCacheSizeFlag:           flag.NewInt64Flag(SQLiteCacheSizeFlag, 0, "The sqlite page cache size (positive is pages, negative is KiB). Zero uses the driver default"),
+	SQLiteBusyTimeoutFlag:         flag.NewInt64Flag(SQLiteBusyTimeoutFlag, 0, "Milliseconds sqlite waits on a locked database before failing. Zero uses the driver default"),
+	MaxDBConnectionsFlag:          flag.NewIntFlag(MaxDBConnectionsFlag, 0, "The maximum number of open connections to the backing database. Zero keeps the default (one for sqlite, unlimited for EdgelessDB). More than one sqlite connection requires the WAL journal mode"),
+	AccountCacheSizeFlag:          flag.NewIntFlag(AccountCacheSizeFlag, 10_000, "The number of recently touched accounts the enclave keeps in memory for the execution of the next batches, in front of the account trie. Zero disables it"),
+	StorageBenchmarkEnabledFlag:   flag.NewBoolFlag(StorageBenchmarkEnabledFlag, false, "Whether to run a short storage read/write benchmark at startup and log the throughput. The benchmark writes (and then deletes) temporary entries in the enclave database"),
+	NetworkGenesisPathFlag:        flag.NewStringFlag(NetworkGenesisPathFlag, "", "The path to the network genesis.json file. When set it overrides the sequencer ID and the contract addresses, and the chain IDs and the obscuro genesis must match it"),
+	BatchCodecFlag:                flag.NewStringFlag(BatchCodecFlag, codec.RLP.String(), "The wire format of the batches returned to the host: rlp or protobuf. The host detects the format of the batches it receives"),
 }
 
 // enclaveRestrictedFlags are the flags that the enclave can receive ONLY over the Ego signed enclave.json
@@ -73,4 +119,17 @@ var enclaveRestrictedFlags = []string{
 	UseInMemoryDBFlag,
 	ProfilerEnabledFlag,
 	DebugNamespaceEnabledFlag,
+	NetworkGenesisPathFlag,
+	ProfilingAdminFlag,
+	LogRedactKeysFlag,
+	LogRedactHexBytesFlag,
+}
+
+// enclaveOptionalRestrictedFlags are the restricted flags that may be left empty in the signed enclave.json, they then
+// take their default value
+var enclaveOptionalRestrictedFlags = map[string]bool{
+	NetworkGenesisPathFlag: true,
+	ProfilingAdminFlag:     true,
+	LogRedactKeysFlag:      true,
+	LogRedactHexBytesFlag:  true,
 }
@@ -4,12 +4,15 @@ import (
 	"fmt"
 	"math/big"
 	"os"
-	"strconv"
-	"strings"
+	"time"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/codec"
+	"github.com/ten-protocol/go-ten/go/common/compression"
 	"github.com/ten-protocol/go-ten/go/common/flag"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/relevancy"
 )
 
 // EnclaveConfig contains the full configuration for an Obscuro enclave service.
@@ -38,6 +41,10 @@ type EnclaveConfig struct {
 	LogLevel int
 	// The path that the enclave's logs are written to
 	LogPath string
+	// The log keys whose values are redacted, in addition to the default deny-list and the keys labelled secret or key
+	LogRedactKeys []string
+	// The size above which the hex blobs in the logs are redacted (0 disables it)
+	LogRedactHexBytes int
 	// Whether the enclave should use in-memory or persistent storage
 	UseInMemoryDB bool
 	// host address for the edgeless DB instance (can be empty if using InMemory DB or if attestation is disabled)
@@ -45,16 +52,41 @@ type EnclaveConfig struct {
 	// filepath for the sqlite DB persistence file (can be empty if a throwaway file in /tmp/ is acceptable or
 	//	if using InMemory DB or if attestation is enabled)
 	SqliteDBPath string
+	// SqliteJournalMode is the sqlite journal mode (e.g. WAL), empty means the driver default
+	SqliteJournalMode string
+	// SqliteCacheSize is the sqlite page cache size (positive is pages, negative is KiB), zero means the driver default
+	SqliteCacheSize int64
+	// SqliteBusyTimeout is the number of milliseconds sqlite waits on a locked database
+	SqliteBusyTimeout int64
+	// MaxDBConnections is the maximum number of open connections to the backing database (zero keeps the default)
+	MaxDBConnections int
+	// AccountCacheSize is the number of recently touched accounts kept in memory in front of the account trie (zero
+	// disables it)
+	AccountCacheSize int
+	// StorageBenchmarkEnabled runs a short read/write benchmark against the database at startup
+	StorageBenchmarkEnabled bool
 	// ProfilerEnabled starts a profiler instance
 	ProfilerEnabled bool
+	// ProfilingAdminAddress is the account allowed to request the pprof profiles of the enclave through the host
+	ProfilingAdminAddress gethcommon.Address
 	// MinGasPrice is the minimum gas price for mining a transaction
 	MinGasPrice *big.Int
+	// TxPoolPriceBump is the fee increase, in percent, a transaction needs to replace a pending one with the same nonce
+	// (zero uses the default of 10%)
+	TxPoolPriceBump uint64
+	// TxInclusionDeadline is how long a transaction can wait in the mempool of the sequencer before it is reported as
+	// overdue, which can reveal censorship or scheduling bugs (zero disables the tracking)
+	TxInclusionDeadline time.Duration
 	// MessageBus L1 Address
 	MessageBusAddress gethcommon.Address
 	// The identity of the sequencer for the network
 	SequencerID gethcommon.Address
 	// A json string that specifies the prefunded addresses at the genesis of the Obscuro network
 	ObscuroGenesis string
+	// The path to the network genesis file, which overrides the network-wide parameters when set
+	NetworkGenesisPath string
+	// The hash of the network genesis the enclave was started with, included in the attestation (nil when no genesis file is used)
+	NetworkGenesisHash *gethcommon.Hash
 	// Whether debug calls are available
 	DebugNamespaceEnabled bool
 	// Maximum bytes a batch can be uncompressed.
@@ -64,11 +96,52 @@ type EnclaveConfig struct {
 	// a protocol limit, but a miner imposed limit and it might be hard to find someone
 	// to include a transaction if it goes above it
 	MaxRollupSize uint64
+	// StateRootCheckpointInterval - the sequencer publishes the state root of the last batch in every Nth rollup, so that
+	// external verifiers can check the proofs of account state. Zero disables the publication
+	StateRootCheckpointInterval uint64
+	// RollupVerificationWorkers is the maximum number of rollups verified concurrently, before their batches are executed
+	// in order. While catching up with the L1, the rollups of up to this many blocks are verified ahead of their execution
+	RollupVerificationWorkers int
+	// RollupIntegrityCheck - the sequencer publishes the hashes of the batches of each rollup and their merkle root, so
+	// that the validators check every batch they recreate. It makes the rollups bigger, so it is meant for debugging
+	RollupIntegrityCheck bool
+	// L1FinalityDepth is the number of L1 blocks on top of the compression block of a rollup for its batches to be
+	// returned for the "finalized" block tag
+	L1FinalityDepth uint64
+	// ScreeningDenyListPath is the path to the optional deny list of the sequencer. It only applies once the governance
+	// published its policy hash on the L1. Empty disables the screening
+	ScreeningDenyListPath string
 
 	GasPaymentAddress        gethcommon.Address
 	BaseFee                  *big.Int
 	GasBatchExecutionLimit   uint64
 	GasLocalExecutionCapFlag uint64
+	// MaxLogsBlockRange is the maximum number of batches searched by an eth_getLogs request, or by a page of the
+	// getLogsPage query (zero disables it)
+	MaxLogsBlockRange uint64
+	// MaxLogsPerQuery is the maximum number of logs returned by an eth_getLogs request, or in a page of the getLogsPage
+	// query (zero disables it)
+	MaxLogsPerQuery int
+	// BatchTimeBudget is the time the sequencer spends executing the transactions of a batch before it seals it, leaving
+	// the rest in the mempool (zero disables it)
+	BatchTimeBudget time.Duration
+	// BatchGasBudget is the gas used by the transactions of a batch above which the sequencer seals it (zero disables it)
+	BatchGasBudget uint64
+
+	// BatchCodec is the wire format of the batches returned to the host
+	BatchCodec codec.Type
+	// RelevancyRulesForks is the schedule of the versions of the rules deciding which accounts can view the logs. It is
+	// derived from the forks of the network genesis, so it is empty for the enclaves started without one
+	RelevancyRulesForks relevancy.Forks
+	// L1MessageVerifierFork is the batch height the precompile verifying the L1 messages activates at, from the forks of
+	// the network genesis. Nil while it is not scheduled
+	L1MessageVerifierFork *uint64
+	// DecompressionLimits bound the decompressed data of the batches and, from RollupDecompressionLimitsFork, of the
+	// rollups. They come from the network genesis, and the zero limits are the compression.DefaultLimits
+	DecompressionLimits compression.Limits
+	// RollupDecompressionLimitsFork is the height of the compression L1 block of the rollups from which the decompression
+	// limits apply to them, from the forks of the network genesis. Nil while it is not scheduled
+	RollupDecompressionLimitsFork *uint64
 }
 
 func NewConfigFromFlags(cliFlags map[string]*flag.TenFlag) (*EnclaveConfig, error) {
@@ -115,40 +188,21 @@ func retrieveEnvFlags() (map[string]*flag.TenFlag, error) {
 	parsedFlags := map[string]*flag.TenFlag{}
 
 	for _, eflag := range enclaveRestrictedFlags {
-		val := os.Getenv("EDG_" + strings.ToUpper(eflag))
+		val := os.Getenv(EnclaveSchema.EnvName(eflag))
 
-		// all env flags must be set
-		if val == "" {
+		// all env flags must be set, apart from the optional ones
+		if val == "" && !enclaveOptionalRestrictedFlags[eflag] {
 			return nil, fmt.Errorf("env var not set: %s", eflag)
 		}
 
-		switch EnclaveFlags[eflag].FlagType {
-		case "string":
-			parsedFlag := flag.NewStringFlag(eflag, "", "")
-			parsedFlag.Value = val
-
-			parsedFlags[eflag] = parsedFlag
-		case "int64":
-			i, err := strconv.ParseInt(val, 10, 64)
-			if err != nil {
-				return nil, fmt.Errorf("unable to parse flag %s - %w", eflag, err)
-			}
-
-			parsedFlag := flag.NewIntFlag(eflag, 0, "")
-			parsedFlag.Value = i
-			parsedFlags[eflag] = parsedFlag
-		case "bool":
-			b, err := strconv.ParseBool(val)
-			if err != nil {
-				return nil, fmt.Errorf("unable to parse flag %s - %w", eflag, err)
-			}
-
-			parsedFlag := flag.NewBoolFlag(eflag, false, "")
-			parsedFlag.Value = b
-			parsedFlags[eflag] = parsedFlag
-		default:
-			return nil, fmt.Errorf("unexpected type: %s", EnclaveFlags[eflag].FlagType)
+		parsedFlag := &flag.TenFlag{Name: eflag, FlagType: EnclaveFlags[eflag].FlagType}
+		if val == "" {
+			// the optional flags left empty take their default value
+			parsedFlag.Value = EnclaveFlags[eflag].DefaultValue
+		} else if err := parsedFlag.SetFromString(val); err != nil {
+			return nil, err
 		}
+		parsedFlags[eflag] = parsedFlag
 	}
 	return parsedFlags, nil
 }
@@ -172,21 +226,54 @@ func newConfig(flags map[string]*flag.TenFlag) (*EnclaveConfig, error) {
 	cfg.ManagementContractAddress = gethcommon.HexToAddress(flags[ManagementContractAddressFlag].String())
 	cfg.LogLevel = flags[LogLevelFlag].Int()
 	cfg.LogPath = flags[LogPathFlag].String()
+	cfg.LogRedactKeys = log.ParseKeys(flags[LogRedactKeysFlag].String())
+	cfg.LogRedactHexBytes = flags[LogRedactHexBytesFlag].Int()
 	cfg.UseInMemoryDB = flags[UseInMemoryDBFlag].Bool()
 	cfg.EdgelessDBHost = flags[EdgelessDBHostFlag].String()
 	cfg.SqliteDBPath = flags[SQLiteDBPathFlag].String()
+	cfg.SqliteJournalMode = flags[SQLiteJournalModeFlag].String()
+	cfg.SqliteCacheSize = flags[SQLiteCacheSizeFlag].Int64()
+	cfg.SqliteBusyTimeout = flags[SQLiteBusyTimeoutFlag].Int64()
+	cfg.MaxDBConnections = flags[MaxDBConnectionsFlag].Int()
+	cfg.AccountCacheSize = flags[AccountCacheSizeFlag].Int()
+	cfg.StorageBenchmarkEnabled = flags[StorageBenchmarkEnabledFlag].Bool()
 	cfg.ProfilerEnabled = flags[ProfilerEnabledFlag].Bool()
+	cfg.ProfilingAdminAddress = gethcommon.HexToAddress(flags[ProfilingAdminFlag].String())
 	cfg.MinGasPrice = big.NewInt(flags[MinGasPriceFlag].Int64())
+	cfg.TxPoolPriceBump = flags[TxPoolPriceBumpFlag].Uint64()
+	cfg.TxInclusionDeadline = time.Duration(flags[TxInclusionDeadlineFlag].Uint64()) * time.Second
 	cfg.MessageBusAddress = gethcommon.HexToAddress(flags[MessageBusAddressFlag].String())
 	cfg.SequencerID = gethcommon.HexToAddress(flags[SequencerIDFlag].String())
 	cfg.ObscuroGenesis = flags[ObscuroGenesisFlag].String()
+	cfg.NetworkGenesisPath = flags[NetworkGenesisPathFlag].String()
 	cfg.DebugNamespaceEnabled = flags[DebugNamespaceEnabledFlag].Bool()
 	cfg.MaxBatchSize = flags[MaxBatchSizeFlag].Uint64()
 	cfg.MaxRollupSize = flags[MaxRollupSizeFlag].Uint64()
+	cfg.StateRootCheckpointInterval = flags[StateRootCheckpointFlag].Uint64()
+	cfg.L1FinalityDepth = flags[L1FinalityDepthFlag].Uint64()
+	cfg.ScreeningDenyListPath = flags[ScreeningDenyListPathFlag].String()
+	cfg.RollupVerificationWorkers = flags[RollupVerificationWorkersFlag].Int()
+	cfg.RollupIntegrityCheck = flags[RollupIntegrityCheckFlag].Bool()
 	cfg.BaseFee = big.NewInt(0).SetUint64(flags[L2BaseFeeFlag].Uint64())
 	cfg.GasPaymentAddress = gethcommon.HexToAddress(flags[L2CoinbaseFlag].String())
 	cfg.GasBatchExecutionLimit = flags[GasBatchExecutionLimit].Uint64()
 	cfg.GasLocalExecutionCapFlag = flags[GasLocalExecutionCapFlag].Uint64()
+	cfg.MaxLogsBlockRange = flags[MaxLogsBlockRangeFlag].Uint64()
+	cfg.MaxLogsPerQuery = flags[MaxLogsPerQueryFlag].Int()
+	cfg.BatchTimeBudget = time.Duration(flags[BatchTimeBudgetFlag].Uint64()) * time.Millisecond
+	cfg.BatchGasBudget = flags[BatchGasBudgetFlag].Uint64()
+	cfg.BatchCodec, err = codec.ToType(flags[BatchCodecFlag].String())
+	if err != nil {
+		return nil, err
+	}
 
 	return cfg, nil
 }
+
+// DecompressionLimitsOrDefault returns the decompression limits of the network
+func (c *EnclaveConfig) DecompressionLimitsOrDefault() compression.Limits {
+	if c.DecompressionLimits.IsZero() {
+		return compression.DefaultLimits
+	}
+	return c.DecompressionLimits
+}
@@ -8,6 +8,7 @@ import (
 
 	"github.com/stretchr/testify/require"
 	tenflag "github.com/ten-protocol/go-ten/go/common/flag"
+	"github.com/ten-protocol/go-ten/go/common/log"
 )
 
 func TestCLIFlagTypes(t *testing.T) {
@@ -99,6 +100,7 @@ func TestRestrictedMode(t *testing.T) {
 	require.Equal(t, true, enclaveConfig.UseInMemoryDB)
 	require.Equal(t, true, enclaveConfig.ProfilerEnabled)
 	require.Equal(t, true, enclaveConfig.DebugNamespaceEnabled)
+	require.Equal(t, log.DefaultRedactHexBytes, enclaveConfig.LogRedactHexBytes)
 }
 
 func TestRestrictedModeNoCLIDuplication(t *testing.T) {
@@ -4,6 +4,10 @@ import (
 	"time"
 
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/codec"
+	"github.com/ten-protocol/go-ten/go/common/queue"
+
+	tenlog "github.com/ten-protocol/go-ten/go/common/log"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/log"
@@ -29,17 +33,37 @@ type HostInputConfig struct {
 	HasClientRPCWebsockets bool
 	// Port on which to handle websocket client RPC requests
 	ClientRPCPortWS uint64
+	// The API namespaces served over HTTP (empty serves all of them)
+	ClientRPCHTTPModules []string
+	// The API namespaces served over websockets (empty serves all of them)
+	ClientRPCWSModules []string
+	// The path of the unix socket on which to serve all the API namespaces over IPC (empty disables IPC)
+	ClientRPCIPCPath string
+	// The maximum size of the client RPC requests over HTTP, in bytes (0 keeps the 5 MiB limit of geth, the maximum)
+	ClientRPCMaxRequestSize int64
+	// Port on which to serve the REST API over the public data of the node (0 disables it)
+	ClientRESTPort uint64
 	// Host on which to handle client RPC requests
 	ClientRPCHost string
 	// Address on which to connect to the enclave
 	EnclaveRPCAddress string
 	// P2PBindAddress is the address where the P2P server is bound to
 	P2PBindAddress string
-	// P2PPublicAddress is the advertised P2P server address
+	// P2PPublicAddress is the advertised P2P server address. If its host is empty (e.g. ":10000"), the external address
+	// of the router is discovered with the P2PNAT mechanism
 	P2PPublicAddress string
+	// P2PNAT is the mechanism used to map the P2P port on the router of a host behind NAT and to discover its external
+	// address: "none", "any", "upnp", "pmp", "pmp:<gateway IP>" or "extip:<IP>"
+	P2PNAT string
+	// P2PBootstrapDNS is the domain whose SRV (_ten-p2p._tcp.<domain>) and TXT ("ten-p2p=<host>:<port>") records list
+	// bootstrap peers, added to the peers registered on the L1. Empty disables the DNS discovery
+	P2PBootstrapDNS string
+	// P2PInsecure sends and accepts the P2P messages in plaintext, without authenticating the peers. All the hosts of the
+	// network must set it alike, as it is not compatible with the authenticated transport
+	P2PInsecure bool
 	// L1WebsocketURL is the RPC address for interactions with the L1
 	L1WebsocketURL string
-	// Timeout duration for RPC requests to the enclave service
+	// Timeout duration for RPC requests to the enclave service, until the latencies of each RPC method are known
 	EnclaveRPCTimeout time.Duration
 	// Timeout duration for connecting to, and communicating with, the L1 node
 	L1RPCTimeout time.Duration
@@ -53,6 +77,14 @@ type HostInputConfig struct {
 	LogLevel int
 	// The path that the node's logs are written to
 	LogPath string
+	// LogRedactKeys are the log keys whose values are redacted, in addition to the default deny-list
+	LogRedactKeys []string
+	// LogRedactHexBytes is the size above which the hex blobs in the logs are redacted (0 disables it)
+	LogRedactHexBytes int
+	// RequestLogSampleRate is the share of the client RPC requests logged (0 disables the request log)
+	RequestLogSampleRate float64
+	// RequestLogMethodRates overrides the share of the client RPC requests logged for some methods
+	RequestLogMethodRates map[string]float64
 	// The stringified private key for the host's L1 wallet
 	PrivateKeyString string
 	// The ID of the L1 chain
@@ -71,15 +103,22 @@ type HostInputConfig struct {
 
 	// MetricsHTTPPort sets the port where the http server is available
 	MetricsHTTPPort uint
+	// MetricsPath is the path on which the metrics are served in the Prometheus format
+	MetricsPath string
 
 	// UseInMemoryDB sets whether the host should use in-memory or persistent storage
 	UseInMemoryDB bool
 
 	// LevelDBPath path for the levelDB persistence dir (can be empty if a throwaway file in /tmp/ is acceptable, or if using InMemory DB)
 	LevelDBPath string
+	// DBType is the backend of the persistent host DB: leveldb, pebble or sqlite. The DB is stored at LevelDBPath
+	DBType string
 
 	// DebugNamespaceEnabled enables the debug namespace handler in the host rpc server
 	DebugNamespaceEnabled bool
+	// SequencerAdminAddress is the address of the account allowed to switch the sequencer in and out of the read-only
+	// mode, with signed requests sent to the admin_setReadOnlyMode RPC method
+	SequencerAdminAddress gethcommon.Address
 
 	// Min interval before creating the next batch (only used by Sequencer nodes)
 	BatchInterval time.Duration
@@ -93,12 +132,110 @@ type HostInputConfig struct {
 
 	// The expected time between blocks on the L1 network
 	L1BlockTime time.Duration
+	// L1BlobsAvailable is whether the L1 network accepts blob transactions
+	L1BlobsAvailable bool
+	// L1Profile is the name of the built-in L1 profile setting the L1 parameters (empty to configure them one by one)
+	L1Profile string
 
 	// Whether inbound p2p is enabled or not
 	IsInboundP2PDisabled bool
 
 	// MaxRollupSize specifies the threshold size which the sequencer-host publishes a rollup
 	MaxRollupSize uint64
+
+	// NetworkGenesisPath is the path to the network genesis file, which overrides the network-wide parameters when set
+	NetworkGenesisPath string
+
+	// ProbesHTTPPort is the port where the liveness and readiness probes are served (0 disables them)
+	ProbesHTTPPort uint
+
+	// TerminationGracePeriod is the time the host has to drain and stop once it is asked to terminate
+	TerminationGracePeriod time.Duration
+
+	// DrainDelay is the time the host keeps serving requests after failing its readiness probe, before it drains
+	DrainDelay time.Duration
+
+	// ArchiveAge is how old the rolled-up batches must be to be archived to the object storage (0 disables the archiving)
+	ArchiveAge time.Duration
+	// ArchiveInterval is the interval between the runs of the job archiving the batches older than ArchiveAge
+	ArchiveInterval time.Duration
+	// ArchiveEndpoint is the URL of the S3-compatible object storage the batches are archived to
+	ArchiveEndpoint string
+	// ArchiveBucket is the bucket the batches are archived to
+	ArchiveBucket string
+	// ArchiveRegion is the region of the bucket, used to sign the requests
+	ArchiveRegion string
+	// ArchiveAccessKey is the access key of the object storage
+	ArchiveAccessKey string
+	// ArchiveSecretKey is the secret key of the object storage
+	ArchiveSecretKey string
+
+	// NTPServer is the NTP server the sequencer compares its clocks with (empty disables the NTP check)
+	NTPServer string
+	// ClockSkewWarning is the skew between the enclave clock, the host clock, the NTP time and the L1 block timestamps
+	// above which the sequencer warns
+	ClockSkewWarning time.Duration
+	// MaxClockSkew is the skew above which the sequencer refuses to produce batches (0 disables it)
+	MaxClockSkew time.Duration
+
+	// IngestionQueueSize is the number of live L1 blocks, and of P2P transactions, held in memory for the enclave
+	IngestionQueueSize int
+	// L1BlockQueuePolicy is what happens to the live L1 blocks received while their queue is full
+	L1BlockQueuePolicy queue.OverflowPolicy
+	// TxQueuePolicy is what happens to the P2P transactions received while their queue is full
+	TxQueuePolicy queue.OverflowPolicy
+	// QueueSpillDir is the directory of the files of the queues with the spill-to-disk policy (empty for the temp dir)
+	QueueSpillDir string
+
+	// BatchCodec is the wire format of the batches sent to the peers
+	BatchCodec codec.Type
+
+	// EventLogPath is the file the public lifecycle events of the network are appended to (empty disables the event log)
+	EventLogPath string
+	// EventLogFinalityDepth is the number of L1 blocks on top of an L1 block for the event log to report it finalised, and
+	// for the batches of its rollups to be returned for the "finalized" block tag
+	EventLogFinalityDepth uint64
+
+	// L1TxMaxRetries is the number of times an L1 transaction that fails to broadcast or gets stuck is re-sent before the
+	// host gives up on it
+	L1TxMaxRetries int
+	// L1RebidBlocks is the number of L1 blocks after which a transaction without receipt is re-sent with a higher bid
+	L1RebidBlocks int
+	// L1GasOracleBlocks is the number of recent L1 blocks the priority fee of the L1 transactions is taken from
+	L1GasOracleBlocks int
+	// L1MaxFeePerGas caps the EIP-1559 fee bid by the L1 transactions, in wei (0 for no cap)
+	L1MaxFeePerGas uint64
+	// L1MaxPriorityFeePerGas caps the EIP-1559 priority fee bid by the L1 transactions, in wei (0 for no cap)
+	L1MaxPriorityFeePerGas uint64
+
+	// DisableSecretResponses stops the host publishing the responses to the secret requests of the other nodes
+	DisableSecretResponses bool
+	// SecretResponseCooldown is the time before a requester is responded to again (0 responds to every request)
+	SecretResponseCooldown time.Duration
+	// MaxSecretResponsesPerHour is the number of secret responses published in an hour at most (0 for no limit)
+	MaxSecretResponsesPerHour int
+
+	// P2PMaxMessageSize is the size of the largest P2P message accepted, in bytes (0 for no limit)
+	P2PMaxMessageSize int
+	// P2PMaxPeerMsgsPerSecond is the number of P2P messages accepted from a peer per second at most (0 for no limit)
+	P2PMaxPeerMsgsPerSecond int
+	// P2PBanThreshold is the number of offences of a peer within P2PBanDuration that get it banned (0 never bans)
+	P2PBanThreshold int
+	// P2PBanDuration is the time a peer is banned for, and the window its offences are counted over
+	P2PBanDuration time.Duration
+
+	// PublicMempoolStats serves the aggregate statistics of the mempool of the sequencer with the public RPC
+	PublicMempoolStats bool
+	// EnclaveRPCMinTimeout and EnclaveRPCMaxTimeout bound the deadlines of the enclave calls, derived from the latencies
+	// observed for each RPC method. EnclaveRPCTimeout is the deadline of a method until its latencies are known
+	EnclaveRPCMinTimeout time.Duration
+	EnclaveRPCMaxTimeout time.Duration
+	// StandbyEnclaveAddresses are the addresses of the hot standby enclaves of the host, fed like the primary enclave at
+	// EnclaveRPCAddress and promoted when the active enclave fails
+	StandbyEnclaveAddresses []string
+	// EnclaveFailoverThreshold is the number of consecutive failed health checks of the active enclave before a standby
+	// enclave is promoted (0 only promotes them with the admin API)
+	EnclaveFailoverThreshold int
 }
 
 // ToHostConfig returns a HostConfig given a HostInputConfig
@@ -110,10 +247,18 @@ func (p HostInputConfig) ToHostConfig() *HostConfig {
 		ClientRPCPortHTTP:         p.ClientRPCPortHTTP,
 		HasClientRPCWebsockets:    p.HasClientRPCWebsockets,
 		ClientRPCPortWS:           p.ClientRPCPortWS,
+		ClientRPCHTTPModules:      p.ClientRPCHTTPModules,
+		ClientRPCWSModules:        p.ClientRPCWSModules,
+		ClientRPCIPCPath:          p.ClientRPCIPCPath,
+		ClientRPCMaxRequestSize:   p.ClientRPCMaxRequestSize,
+		ClientRESTPort:            p.ClientRESTPort,
 		ClientRPCHost:             p.ClientRPCHost,
 		EnclaveRPCAddress:         p.EnclaveRPCAddress,
 		P2PBindAddress:            p.P2PBindAddress,
 		P2PPublicAddress:          p.P2PPublicAddress,
+		P2PNAT:                    p.P2PNAT,
+		P2PBootstrapDNS:           p.P2PBootstrapDNS,
+		P2PInsecure:               p.P2PInsecure,
 		L1WebsocketURL:            p.L1WebsocketURL,
 		EnclaveRPCTimeout:         p.EnclaveRPCTimeout,
 		L1RPCTimeout:              p.L1RPCTimeout,
@@ -122,6 +267,10 @@ func (p HostInputConfig) ToHostConfig() *HostConfig {
 		MessageBusAddress:         p.MessageBusAddress,
 		LogLevel:                  p.LogLevel,
 		LogPath:                   p.LogPath,
+		LogRedactKeys:             p.LogRedactKeys,
+		LogRedactHexBytes:         p.LogRedactHexBytes,
+		RequestLogSampleRate:      p.RequestLogSampleRate,
+		RequestLogMethodRates:     p.RequestLogMethodRates,
 		PrivateKeyString:          p.PrivateKeyString,
 		L1ChainID:                 p.L1ChainID,
 		ObscuroChainID:            p.ObscuroChainID,
@@ -131,15 +280,54 @@ func (p HostInputConfig) ToHostConfig() *HostConfig {
 		ID:                        gethcommon.Address{},
 		MetricsEnabled:            p.MetricsEnabled,
 		MetricsHTTPPort:           p.MetricsHTTPPort,
+		MetricsPath:               p.MetricsPath,
 		UseInMemoryDB:             p.UseInMemoryDB,
 		LevelDBPath:               p.LevelDBPath,
+		DBType:                    p.DBType,
 		DebugNamespaceEnabled:     p.DebugNamespaceEnabled,
+		SequencerAdminAddress:     p.SequencerAdminAddress,
 		BatchInterval:             p.BatchInterval,
 		MaxBatchInterval:          p.MaxBatchInterval,
 		RollupInterval:            p.RollupInterval,
 		L1BlockTime:               p.L1BlockTime,
+		L1BlobsAvailable:          p.L1BlobsAvailable,
 		IsInboundP2PDisabled:      p.IsInboundP2PDisabled,
 		MaxRollupSize:             p.MaxRollupSize,
+		ProbesHTTPPort:            p.ProbesHTTPPort,
+		ArchiveAge:                p.ArchiveAge,
+		ArchiveInterval:           p.ArchiveInterval,
+		ArchiveEndpoint:           p.ArchiveEndpoint,
+		ArchiveBucket:             p.ArchiveBucket,
+		ArchiveRegion:             p.ArchiveRegion,
+		ArchiveAccessKey:          p.ArchiveAccessKey,
+		ArchiveSecretKey:          p.ArchiveSecretKey,
+		NTPServer:                 p.NTPServer,
+		ClockSkewWarning:          p.ClockSkewWarning,
+		MaxClockSkew:              p.MaxClockSkew,
+		IngestionQueueSize:        p.IngestionQueueSize,
+		L1BlockQueuePolicy:        p.L1BlockQueuePolicy,
+		TxQueuePolicy:             p.TxQueuePolicy,
+		QueueSpillDir:             p.QueueSpillDir,
+		BatchCodec:                p.BatchCodec,
+		EventLogPath:              p.EventLogPath,
+		EventLogFinalityDepth:     p.EventLogFinalityDepth,
+		L1TxMaxRetries:            p.L1TxMaxRetries,
+		L1RebidBlocks:             p.L1RebidBlocks,
+		L1GasOracleBlocks:         p.L1GasOracleBlocks,
+		L1MaxFeePerGas:            p.L1MaxFeePerGas,
+		L1MaxPriorityFeePerGas:    p.L1MaxPriorityFeePerGas,
+		DisableSecretResponses:    p.DisableSecretResponses,
+		SecretResponseCooldown:    p.SecretResponseCooldown,
+		MaxSecretResponsesPerHour: p.MaxSecretResponsesPerHour,
+		P2PMaxMessageSize:         p.P2PMaxMessageSize,
+		P2PMaxPeerMsgsPerSecond:   p.P2PMaxPeerMsgsPerSecond,
+		P2PBanThreshold:           p.P2PBanThreshold,
+		P2PBanDuration:            p.P2PBanDuration,
+		PublicMempoolStats:        p.PublicMempoolStats,
+		EnclaveRPCMinTimeout:      p.EnclaveRPCMinTimeout,
+		EnclaveRPCMaxTimeout:      p.EnclaveRPCMaxTimeout,
+		StandbyEnclaveAddresses:   p.StandbyEnclaveAddresses,
+		EnclaveFailoverThreshold:  p.EnclaveFailoverThreshold,
 	}
 }
 
@@ -172,6 +360,8 @@ type HostConfig struct {
 	MaxRollupSize uint64
 	// The expected time between blocks on the L1 network
 	L1BlockTime time.Duration
+	// Whether the L1 network accepts blob transactions. When it does not, the rollups are never reported cheaper as blobs
+	L1BlobsAvailable bool
 
 	/////
 	// NODE CONFIG
@@ -189,10 +379,20 @@ type HostConfig struct {
 	LogLevel int
 	// The path that the node's logs are written to
 	LogPath string
+	// The log keys whose values are redacted, in addition to the default deny-list and the keys labelled secret or key
+	LogRedactKeys []string
+	// The size above which the hex blobs in the logs are redacted (0 disables it)
+	LogRedactHexBytes int
+	// The share of the client RPC requests whose method, latency, status and caller hash are logged (0 disables it)
+	RequestLogSampleRate float64
+	// The share of the client RPC requests logged by method, overriding RequestLogSampleRate
+	RequestLogMethodRates map[string]float64
 	// Whether the host should use in-memory or persistent storage
 	UseInMemoryDB bool
 	// filepath for the levelDB persistence dir (can be empty if a throwaway file in /tmp/ is acceptable, or if using InMemory DB)
 	LevelDBPath string
+	// The backend of the persistent host DB: leveldb, pebble or sqlite. The DB is stored at LevelDBPath
+	DBType string
 
 	//////
 	// NODE NETWORKING
@@ -206,14 +406,34 @@ type HostConfig struct {
 	HasClientRPCWebsockets bool
 	// Port on which to handle websocket client RPC requests
 	ClientRPCPortWS uint64
+	// The API namespaces served over HTTP (empty serves all of them)
+	ClientRPCHTTPModules []string
+	// The API namespaces served over websockets (empty serves all of them)
+	ClientRPCWSModules []string
+	// The path of the unix socket on which to serve all the API namespaces over IPC (empty disables IPC)
+	ClientRPCIPCPath string
+	// The maximum size of the client RPC requests over HTTP, in bytes (0 keeps the 5 MiB limit of geth, the maximum)
+	ClientRPCMaxRequestSize int64
+	// Port on which to serve the REST API over the public data of the node (0 disables it)
+	ClientRESTPort uint64
 	// Host on which to handle client RPC requests
 	ClientRPCHost string
 	// Address on which to connect to the enclave
 	EnclaveRPCAddress string
 	// P2PBindAddress is the address where the P2P server is bound to
 	P2PBindAddress string
-	// P2PPublicAddress is the advertised P2P server address
+	// P2PPublicAddress is the advertised P2P server address. If its host is empty (e.g. ":10000"), the external address
+	// of the router is discovered with the P2PNAT mechanism
 	P2PPublicAddress string
+	// P2PNAT is the mechanism used to map the P2P port on the router of a host behind NAT and to discover its external
+	// address: "none", "any", "upnp", "pmp", "pmp:<gateway IP>" or "extip:<IP>"
+	P2PNAT string
+	// P2PBootstrapDNS is the domain whose SRV (_ten-p2p._tcp.<domain>) and TXT ("ten-p2p=<host>:<port>") records list
+	// bootstrap peers, added to the peers registered on the L1. Empty disables the DNS discovery
+	P2PBootstrapDNS string
+	// P2PInsecure sends and accepts the P2P messages in plaintext, without authenticating the peers. All the hosts of the
+	// network must set it alike, as it is not compatible with the authenticated transport
+	P2PInsecure bool
 	// L1WebsocketURL is the RPC address for interactions with the L1
 	L1WebsocketURL string
 	// Timeout duration for RPC requests to the enclave service
@@ -228,10 +448,107 @@ type HostConfig struct {
 	MetricsEnabled bool
 	// MetricsHTTPPort sets the port where the http server is available
 	MetricsHTTPPort uint
+	// MetricsPath is the path on which the metrics are served in the Prometheus format
+	MetricsPath string
 	// DebugNamespaceEnabled enables the debug namespace handler in the host rpc server
 	DebugNamespaceEnabled bool
+	// SequencerAdminAddress is the address of the account allowed to switch the sequencer in and out of the read-only
+	// mode, with signed requests sent to the admin_setReadOnlyMode RPC method
+	SequencerAdminAddress gethcommon.Address
 	// Whether p2p is enabled or not
 	IsInboundP2PDisabled bool
+	// ProbesHTTPPort is the port where the liveness and readiness probes are served (0 disables them)
+	ProbesHTTPPort uint
+	// How old the rolled-up batches must be to be archived to the object storage, with the rollups that published them
+	// (0 disables the archiving). The archived batches are pruned from the host DB, and fetched back from the object
+	// storage
+	ArchiveAge time.Duration
+	// Interval between the runs of the job archiving the batches older than ArchiveAge
+	ArchiveInterval time.Duration
+	// The URL of the S3-compatible object storage (e.g. AWS S3, GCS with HMAC keys or MinIO) the batches are archived to
+	ArchiveEndpoint string
+	// The bucket the batches are archived to
+	ArchiveBucket string
+	// The region of the bucket, used to sign the requests ("auto" for GCS)
+	ArchiveRegion string
+	// The access key of the object storage
+	ArchiveAccessKey string
+	// The secret key of the object storage, left out of the config logged at startup
+	ArchiveSecretKey string `json:"-"`
+	// The NTP server the sequencer compares its clocks with (empty disables the NTP check)
+	NTPServer string
+	// The skew between the enclave clock, the host clock, the NTP time and the L1 block timestamps above which the
+	// sequencer warns
+	ClockSkewWarning time.Duration
+	// The skew above which the sequencer refuses to produce batches, as their timestamps are taken from the enclave clock
+	// (0 disables it)
+	MaxClockSkew time.Duration
+	// The number of live L1 blocks, and of P2P transactions, held in memory while they wait for the enclave
+	IngestionQueueSize int
+	// What happens to the live L1 blocks received while their queue is full. The blocks dropped are fetched again by
+	// the L1 catch-up if the enclave needs them
+	L1BlockQueuePolicy queue.OverflowPolicy
+	// What happens to the P2P transactions received while their queue is full
+	TxQueuePolicy queue.OverflowPolicy
+	// The directory of the files of the queues with the spill-to-disk policy (empty for the system temp directory)
+	QueueSpillDir string
+	// The wire format of the batches sent to the peers. The peers decode the batches with the format they were sent in,
+	// so the hosts of a network can switch format one at a time
+	BatchCodec codec.Type
+	// The file the public lifecycle events of the network (batches sealed, rollups published, L1 reorgs and finality)
+	// are appended to as newline-delimited JSON, for external indexers (empty disables the event log)
+	EventLogPath string
+	// The number of L1 blocks on top of an L1 block for the event log to report it finalised, and for the batches of its
+	// rollups to be returned for the "finalized" block tag
+	EventLogFinalityDepth uint64
+
+	// The number of times an L1 transaction that fails to broadcast, or gets no receipt in time, is re-sent with a higher
+	// gas price before the host gives up on it and moves it to the dead-letter queue of the publisher
+	L1TxMaxRetries int
+	// The number of L1 blocks a transaction is given to be included before it is re-sent with a higher bid
+	L1RebidBlocks int
+	// The number of recent L1 blocks whose median priority fee is bid by the L1 transactions, with twice the base fee of
+	// the L1 head as fee cap
+	L1GasOracleBlocks int
+	// The caps of the EIP-1559 fee and priority fee bid by the L1 transactions, in wei, also when they are re-sent (0 for
+	// no cap)
+	L1MaxFeePerGas         uint64
+	L1MaxPriorityFeePerGas uint64
+
+	// Whether the host never publishes the responses to the secret requests of the other nodes, e.g. on a validator-only
+	// node. The enclave answers every request of every block, including the requests replayed on the L1, so the host
+	// publishes a response to a requester once per cooldown, and a limited number of responses per hour
+	DisableSecretResponses bool
+	// The time before a requester is responded to again (0 responds to every request)
+	SecretResponseCooldown time.Duration
+	// The number of secret responses published in an hour at most (0 for no limit)
+	MaxSecretResponsesPerHour int
+
+	// The flood protection of the P2P layer: the gossiped messages already seen are dropped, and a peer is banned for
+	// P2PBanDuration once it reaches P2PBanThreshold offences (exceeding P2PMaxPeerMsgsPerSecond, sending messages over
+	// P2PMaxMessageSize, undecodable messages or the same message twice) within that duration. 0 disables each of them
+	P2PMaxMessageSize       int
+	P2PMaxPeerMsgsPerSecond int
+	P2PBanThreshold         int
+	P2PBanDuration          time.Duration
+
+	// Whether the sequencer host serves the aggregate statistics of its mempool (the number of transactions, the
+	// percentiles of their gas prices and the inclusion latency) with the public ten_mempoolStats RPC, for the wallets to
+	// suggest fees. The enclave computes them, and leaves out the ones that would reveal an individual transaction
+	PublicMempoolStats bool
+
+	// The deadline of each enclave call is three times the p99 of the latencies of the latest calls of its RPC method,
+	// within EnclaveRPCMinTimeout and EnclaveRPCMaxTimeout, and it doubles when a call runs out of time. The calls of a
+	// method take EnclaveRPCTimeout until 20 of them were observed
+	EnclaveRPCMinTimeout time.Duration
+	EnclaveRPCMaxTimeout time.Duration
+
+	// The host can run hot standby enclaves next to its primary enclave (at EnclaveRPCAddress). They are fed the L1 blocks
+	// and, for a sequencer, the batches of the active enclave, which alone produces the batches and the rollups. Once the
+	// active enclave failed EnclaveFailoverThreshold consecutive health checks, the first live standby enclave holding all
+	// the batches of the host is promoted to be the active one (0 only promotes them with the admin API)
+	StandbyEnclaveAddresses  []string
+	EnclaveFailoverThreshold int
 }
 
 // DefaultHostParsedConfig returns a HostConfig with default values.
@@ -243,10 +560,15 @@ func DefaultHostParsedConfig() *HostInputConfig {
 		ClientRPCPortHTTP:         80,
 		HasClientRPCWebsockets:    true,
 		ClientRPCPortWS:           81,
+		ClientRPCHTTPModules:      nil,
+		ClientRPCWSModules:        nil,
+		ClientRPCIPCPath:          "",
+		ClientRPCMaxRequestSize:   0,
 		ClientRPCHost:             "127.0.0.1",
 		EnclaveRPCAddress:         "127.0.0.1:11000",
-		P2PBindAddress:            "0.0.0.0:10000",
+		P2PBindAddress:            ":10000", // all the interfaces, IPv4 and IPv6
 		P2PPublicAddress:          "127.0.0.1:10000",
+		P2PNAT:                    "none",
 		L1WebsocketURL:            "ws://127.0.0.1:8546",
 		EnclaveRPCTimeout:         time.Duration(defaultRPCTimeoutSecs) * time.Second,
 		L1RPCTimeout:              time.Duration(defaultL1RPCTimeoutSecs) * time.Second,
@@ -255,6 +577,10 @@ func DefaultHostParsedConfig() *HostInputConfig {
 		MessageBusAddress:         gethcommon.BytesToAddress([]byte("")),
 		LogLevel:                  int(log.LvlInfo),
 		LogPath:                   "",
+		LogRedactKeys:             nil,
+		LogRedactHexBytes:         tenlog.DefaultRedactHexBytes,
+		RequestLogSampleRate:      0,
+		RequestLogMethodRates:     nil,
 		PrivateKeyString:          "0000000000000000000000000000000000000000000000000000000000000001",
 		L1ChainID:                 1337,
 		ObscuroChainID:            443,
@@ -263,12 +589,47 @@ func DefaultHostParsedConfig() *HostInputConfig {
 		SequencerID:               gethcommon.BytesToAddress([]byte("")),
 		MetricsEnabled:            true,
 		MetricsHTTPPort:           14000,
+		MetricsPath:               "/metrics",
 		UseInMemoryDB:             true,
+		DBType:                    "leveldb",
 		DebugNamespaceEnabled:     false, BatchInterval: 1 * time.Second,
 		MaxBatchInterval:     1 * time.Second,
 		RollupInterval:       5 * time.Second,
 		L1BlockTime:          15 * time.Second,
+		L1BlobsAvailable:     true,
 		IsInboundP2PDisabled: false,
 		MaxRollupSize:        1024 * 64,
+		// matches the default termination grace period of Kubernetes pods
+		TerminationGracePeriod:    30 * time.Second,
+		DrainDelay:                5 * time.Second,
+		ArchiveAge:                0,
+		ArchiveInterval:           time.Hour,
+		ArchiveRegion:             "us-east-1",
+		ClockSkewWarning:          time.Second,
+		MaxClockSkew:              5 * time.Second,
+		IngestionQueueSize:        256,
+		L1BlockQueuePolicy:        queue.DropOldest,
+		TxQueuePolicy:             queue.SpillToDisk,
+		QueueSpillDir:             "",
+		BatchCodec:                codec.RLP,
+		EventLogPath:              "",
+		EventLogFinalityDepth:     64, // two epochs of the beacon chain
+		L1TxMaxRetries:            10,
+		L1RebidBlocks:             6,
+		L1GasOracleBlocks:         20,
+		L1MaxFeePerGas:            0,
+		L1MaxPriorityFeePerGas:    0,
+		DisableSecretResponses:    false,
+		SecretResponseCooldown:    10 * time.Minute,
+		MaxSecretResponsesPerHour: 60,
+		P2PMaxMessageSize:         64 * 1024 * 1024,
+		P2PMaxPeerMsgsPerSecond:   1000,
+		P2PBanThreshold:           10,
+		P2PBanDuration:            time.Hour,
+		PublicMempoolStats:        false,
+		EnclaveRPCMinTimeout:      time.Second,
+		EnclaveRPCMaxTimeout:      2 * time.Minute,
+		StandbyEnclaveAddresses:   nil,
+		EnclaveFailoverThreshold:  3,
 	}
 }
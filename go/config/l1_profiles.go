@@ -0,0 +1,79 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// L1Profile holds the parameters of a known L1 chain, so that a host can be pointed at it by name
+type L1Profile struct {
+	Name string
+	// The ID of the L1 chain
+	ChainID int64
+	// The expected time between blocks on the L1 chain
+	BlockTime time.Duration
+	// The number of blocks on top of a block for it to be considered final (two epochs of the beacon chain)
+	FinalityDepth uint64
+	// Whether the L1 chain accepts blob transactions (EIP-4844)
+	BlobsAvailable bool
+	// The management contract addresses of the TEN network deployed on the L1 chain. They are left empty when the
+	// network the host joins is not known in advance, and must then be configured
+	ManagementContractAddress gethcommon.Address
+	MessageBusAddress         gethcommon.Address
+}
+
+// l1Profiles are the built-in L1 profiles, by name
+var l1Profiles = map[string]*L1Profile{
+	"sepolia": {
+		Name:           "sepolia",
+		ChainID:        11155111,
+		BlockTime:      12 * time.Second,
+		FinalityDepth:  64,
+		BlobsAvailable: true,
+	},
+	"holesky": {
+		Name:           "holesky",
+		ChainID:        17000,
+		BlockTime:      12 * time.Second,
+		FinalityDepth:  64,
+		BlobsAvailable: true,
+	},
+}
+
+// L1ProfileByName returns the built-in L1 profile with the name
+func L1ProfileByName(name string) (*L1Profile, error) {
+	profile, ok := l1Profiles[strings.ToLower(name)]
+	if !ok {
+		return nil, fmt.Errorf("unknown L1 profile '%s', expected one of %s", name, strings.Join(L1ProfileNames(), ", "))
+	}
+	return profile, nil
+}
+
+// L1ProfileNames returns the names of the built-in L1 profiles, sorted
+func L1ProfileNames() []string {
+	names := make([]string, 0, len(l1Profiles))
+	for name := range l1Profiles {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// ApplyL1Profile sets the L1 parameters of the host config from the profile. The management contract addresses of the
+// profile only fill in the addresses left empty in the config, so that a host can join any network deployed on the L1.
+func (p *HostInputConfig) ApplyL1Profile(profile *L1Profile) {
+	p.L1ChainID = profile.ChainID
+	p.L1BlockTime = profile.BlockTime
+	p.EventLogFinalityDepth = profile.FinalityDepth
+	p.L1BlobsAvailable = profile.BlobsAvailable
+	if p.ManagementContractAddress == (gethcommon.Address{}) {
+		p.ManagementContractAddress = profile.ManagementContractAddress
+	}
+	if p.MessageBusAddress == (gethcommon.Address{}) {
+		p.MessageBusAddress = profile.MessageBusAddress
+	}
+}
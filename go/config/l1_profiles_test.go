@@ -0,0 +1,37 @@
+package config
+
+import (
+	"testing"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+)
+
+func TestL1ProfileSetsTheL1Parameters(t *testing.T) {
+	profile, err := L1ProfileByName("Holesky")
+	require.NoError(t, err)
+
+	cfg := DefaultHostParsedConfig()
+	cfg.L1BlobsAvailable = false
+	cfg.ApplyL1Profile(profile)
+	require.Equal(t, int64(17000), cfg.L1ChainID)
+	require.Equal(t, 12*time.Second, cfg.L1BlockTime)
+	require.Equal(t, uint64(64), cfg.EventLogFinalityDepth)
+	require.True(t, cfg.L1BlobsAvailable)
+}
+
+func TestL1ProfileKeepsTheConfiguredContracts(t *testing.T) {
+	profile := &L1Profile{ChainID: 1, ManagementContractAddress: gethcommon.HexToAddress("0x01"), MessageBusAddress: gethcommon.HexToAddress("0x02")}
+
+	cfg := DefaultHostParsedConfig()
+	cfg.ManagementContractAddress = gethcommon.HexToAddress("0x03")
+	cfg.ApplyL1Profile(profile)
+	require.Equal(t, gethcommon.HexToAddress("0x03"), cfg.ManagementContractAddress)
+	require.Equal(t, gethcommon.HexToAddress("0x02"), cfg.MessageBusAddress)
+}
+
+func TestUnknownL1ProfileIsRejected(t *testing.T) {
+	_, err := L1ProfileByName("goerli")
+	require.ErrorContains(t, err, "holesky, sepolia")
+}
@@ -0,0 +1,91 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+
+	"github.com/ten-protocol/go-ten/go/common/compression"
+	"github.com/ten-protocol/go-ten/go/common/networkgenesis"
+	"github.com/ten-protocol/go-ten/go/common/relevancy"
+)
+
+// genesisAllocations is the format of the prefunded accounts and predeployed contracts in the enclave genesis
+type genesisAllocations struct {
+	Accounts  []networkgenesis.Account
+	Contracts []networkgenesis.Contract
+}
+
+// ApplyNetworkGenesis sets the network-wide parameters of the enclave config from the network genesis.
+// The chain IDs and the allocations are restricted parameters, which may only come from the signed enclave.json, so the
+// genesis never overrides them: the enclave refuses to start if they disagree with it. The allocations are only taken
+// from the genesis when the enclave was started without any. The activation heights of the rules come from the forks of
+// the genesis.
+func (c *EnclaveConfig) ApplyNetworkGenesis(g *networkgenesis.NetworkGenesis) error {
+	if c.L1ChainID != g.L1ChainID {
+		return fmt.Errorf("network genesis l1ChainId=%d does not match the configured %s=%d", g.L1ChainID, L1ChainIDFlag, c.L1ChainID)
+	}
+	if c.ObscuroChainID != g.ChainID {
+		return fmt.Errorf("network genesis chainId=%d does not match the configured %s=%d", g.ChainID, ObscuroChainIDFlag, c.ObscuroChainID)
+	}
+
+	allocations := genesisAllocations{Accounts: g.Accounts, Contracts: g.Contracts}
+	if c.ObscuroGenesis != "" {
+		configured := genesisAllocations{}
+		if err := json.Unmarshal([]byte(c.ObscuroGenesis), &configured); err != nil {
+			return fmt.Errorf("could not parse the configured %s - %w", ObscuroGenesisFlag, err)
+		}
+		if !sameAllocations(configured, allocations) {
+			return fmt.Errorf("network genesis allocations do not match the configured %s", ObscuroGenesisFlag)
+		}
+	} else {
+		encoded, err := json.Marshal(allocations)
+		if err != nil {
+			return fmt.Errorf("could not encode genesis allocations - %w", err)
+		}
+		c.ObscuroGenesis = string(encoded)
+	}
+
+	genesisHash, err := g.Hash()
+	if err != nil {
+		return err
+	}
+	c.SequencerID = g.SequencerID
+	c.ManagementContractAddress = g.ManagementContractAddress
+	c.MessageBusAddress = g.MessageBusAddress
+	c.NetworkGenesisHash = &genesisHash
+	c.RelevancyRulesForks = nil
+	if height, ok := g.Forks[networkgenesis.RelevancyRulesV1Fork]; ok {
+		c.RelevancyRulesForks = relevancy.Forks{{Version: relevancy.V1, Height: height}}
+	}
+	c.L1MessageVerifierFork = nil
+	if height, ok := g.Forks[networkgenesis.L1MessageVerifierFork]; ok {
+		c.L1MessageVerifierFork = &height
+	}
+	c.RollupDecompressionLimitsFork = nil
+	if height, ok := g.Forks[networkgenesis.RollupDecompressionLimitsFork]; ok {
+		c.RollupDecompressionLimitsFork = &height
+	}
+	c.DecompressionLimits = compression.DefaultLimits
+	if g.DecompressionLimits != nil {
+		c.DecompressionLimits = *g.DecompressionLimits
+	}
+	return nil
+}
+
+// sameAllocations compares the allocations through their json encoding, which is canonical for the same values
+func sameAllocations(a, b genesisAllocations) bool {
+	encodedA, errA := json.Marshal(a)
+	encodedB, errB := json.Marshal(b)
+	return errA == nil && errB == nil && bytes.Equal(encodedA, encodedB)
+}
+
+// ApplyNetworkGenesis overrides the network-wide parameters of the host config with the ones in the network genesis
+func (p *HostInputConfig) ApplyNetworkGenesis(g *networkgenesis.NetworkGenesis) {
+	p.ObscuroChainID = g.ChainID
+	p.L1ChainID = g.L1ChainID
+	p.SequencerID = g.SequencerID
+	p.ManagementContractAddress = g.ManagementContractAddress
+	p.MessageBusAddress = g.MessageBusAddress
+	p.L1StartHash = g.L1StartHash
+}
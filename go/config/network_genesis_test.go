@@ -0,0 +1,73 @@
+package config
+
+import (
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/compression"
+	"github.com/ten-protocol/go-ten/go/common/networkgenesis"
+	"github.com/ten-protocol/go-ten/go/common/relevancy"
+)
+
+func testNetworkGenesis() *networkgenesis.NetworkGenesis {
+	return &networkgenesis.NetworkGenesis{
+		ChainID:                   443,
+		L1ChainID:                 1337,
+		SequencerID:               gethcommon.HexToAddress("0x01"),
+		ManagementContractAddress: gethcommon.HexToAddress("0x02"),
+		Accounts:                  []networkgenesis.Account{{Address: gethcommon.HexToAddress("0x03"), Amount: big.NewInt(100)}},
+	}
+}
+
+func TestNetworkGenesisSetsUnrestrictedParameters(t *testing.T) {
+	cfg := &EnclaveConfig{L1ChainID: 1337, ObscuroChainID: 443, SequencerID: gethcommon.HexToAddress("0x99")}
+	require.NoError(t, cfg.ApplyNetworkGenesis(testNetworkGenesis()))
+	require.Equal(t, gethcommon.HexToAddress("0x01"), cfg.SequencerID)
+	require.Equal(t, gethcommon.HexToAddress("0x02"), cfg.ManagementContractAddress)
+	require.NotNil(t, cfg.NetworkGenesisHash)
+
+	// the allocations are taken from the genesis when none are configured, and accepted when they match
+	sameAllocations := &EnclaveConfig{L1ChainID: 1337, ObscuroChainID: 443, ObscuroGenesis: cfg.ObscuroGenesis}
+	require.NoError(t, sameAllocations.ApplyNetworkGenesis(testNetworkGenesis()))
+	require.Equal(t, cfg.ObscuroGenesis, sameAllocations.ObscuroGenesis)
+}
+
+func TestNetworkGenesisSetsTheForks(t *testing.T) {
+	cfg := &EnclaveConfig{L1ChainID: 1337, ObscuroChainID: 443}
+	require.NoError(t, cfg.ApplyNetworkGenesis(testNetworkGenesis()))
+	require.Empty(t, cfg.RelevancyRulesForks)
+	require.Nil(t, cfg.L1MessageVerifierFork)
+	require.Nil(t, cfg.RollupDecompressionLimitsFork)
+	require.Equal(t, compression.DefaultLimits, cfg.DecompressionLimitsOrDefault())
+
+	g := testNetworkGenesis()
+	g.Forks = map[string]uint64{
+		networkgenesis.RelevancyRulesV1Fork:          50_000,
+		networkgenesis.L1MessageVerifierFork:         60_000,
+		networkgenesis.RollupDecompressionLimitsFork: 70_000,
+	}
+	g.DecompressionLimits = &compression.Limits{MaxExpansion: 50, MinSize: 1024, MaxSize: 4096}
+	require.NoError(t, cfg.ApplyNetworkGenesis(g))
+	require.Equal(t, relevancy.Forks{{Version: relevancy.V1, Height: 50_000}}, cfg.RelevancyRulesForks)
+	require.Equal(t, uint64(60_000), *cfg.L1MessageVerifierFork)
+	require.Equal(t, uint64(70_000), *cfg.RollupDecompressionLimitsFork)
+	require.Equal(t, *g.DecompressionLimits, cfg.DecompressionLimitsOrDefault())
+}
+
+func TestNetworkGenesisNeverOverridesRestrictedParameters(t *testing.T) {
+	tests := map[string]*EnclaveConfig{
+		"l1 chain id":    {L1ChainID: 1, ObscuroChainID: 443},
+		"ten chain id":   {L1ChainID: 1337, ObscuroChainID: 444},
+		"allocations":    {L1ChainID: 1337, ObscuroChainID: 443, ObscuroGenesis: `{"Accounts": [{"Address": "0x0000000000000000000000000000000000000004", "Amount": 100}]}`},
+		"no allocations": {L1ChainID: 1337, ObscuroChainID: 443, ObscuroGenesis: `{}`},
+	}
+	for name, cfg := range tests {
+		t.Run(name, func(t *testing.T) {
+			original := *cfg
+			require.Error(t, cfg.ApplyNetworkGenesis(testNetworkGenesis()))
+			require.Equal(t, original, *cfg)
+		})
+	}
+}
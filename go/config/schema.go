@@ -0,0 +1,168 @@
+package config
+
+import (
+	goflag "flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/naoina/toml"
+	"github.com/ten-protocol/go-ten/go/common/flag"
+)
+
+const (
+	// EnclaveConfigDocsFile is the generated documentation of the enclave configuration, kept next to the flags
+	EnclaveConfigDocsFile = "enclave_config.md"
+
+	// the key of the schema version in the config files
+	versionKey = "version"
+)
+
+// Schema describes the configuration accepted by a component.
+// The same definition is used to read the env vars and the config files and to generate the documentation, so they
+// can't drift from the flags the component accepts.
+// The config files are versioned: a file written for another version of the schema is rejected rather than half-applied.
+type Schema struct {
+	Component string                   // the component the schema configures (e.g. enclave)
+	Version   int                      // bumped whenever a flag is renamed, removed or changes meaning
+	EnvPrefix string                   // the prefix of the env vars that can set the flags
+	Flags     map[string]*flag.TenFlag // the flags, keyed by name. They are shared with the component, so never mutated here
+}
+
+// EnclaveSchema is the configuration schema of the enclave
+var EnclaveSchema = &Schema{
+	Component: "enclave",
+	Version:   1,
+	EnvPrefix: "EDG_",
+	Flags:     EnclaveFlags,
+}
+
+// NewFlagSetSchema describes the flags defined on a standard flag set, for the components that don't use TenFlags
+func NewFlagSetSchema(component string, version int, envPrefix string, fs *goflag.FlagSet) *Schema {
+	flags := map[string]*flag.TenFlag{}
+	fs.VisitAll(func(f *goflag.Flag) {
+		flagType := "string"
+		if getter, ok := f.Value.(goflag.Getter); ok {
+			flagType = fmt.Sprintf("%T", getter.Get())
+		}
+		flags[f.Name] = &flag.TenFlag{Name: f.Name, FlagType: flagType, Description: f.Usage, DefaultValue: f.DefValue}
+	})
+	return &Schema{
+		Component: component,
+		Version:   version,
+		EnvPrefix: envPrefix,
+		Flags:     flags,
+	}
+}
+
+// EnvName returns the name of the env var that sets the flag
+func (s *Schema) EnvName(name string) string {
+	return s.EnvPrefix + strings.ToUpper(name)
+}
+
+// CheckVersion returns an error if a config file was written for another version of the schema
+func (s *Schema) CheckVersion(version int) error {
+	if version != s.Version {
+		return fmt.Errorf("the %s config is for version %d of the schema, expected version %d", s.Component, version, s.Version)
+	}
+	return nil
+}
+
+// BindEnv sets the flags of the parsed flag set that were not passed on the command line from their env vars
+func (s *Schema) BindEnv(fs *goflag.FlagSet) error {
+	set := setFlags(fs)
+	for _, name := range s.flagNames() {
+		val, found := os.LookupEnv(s.EnvName(name))
+		if !found || set[name] {
+			continue
+		}
+		if err := fs.Set(name, val); err != nil {
+			return fmt.Errorf("invalid value for %s - %w", s.EnvName(name), err)
+		}
+	}
+	return nil
+}
+
+// BindFile sets the flags of the parsed flag set that were neither passed on the command line nor set by an env var
+// from the TOML config file at path. The keys of the file are the names of the flags, and its version key must match the
+// version of the schema. The unknown keys and the invalid values are rejected.
+func (s *Schema) BindFile(fs *goflag.FlagSet, path string) error {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("could not read the %s config file at %s - %w", s.Component, path, err)
+	}
+	values := map[string]interface{}{}
+	if err := toml.Unmarshal(content, &values); err != nil {
+		return fmt.Errorf("could not parse the %s config file at %s - %w", s.Component, path, err)
+	}
+
+	version, ok := values[versionKey].(int64)
+	if !ok {
+		return fmt.Errorf("the %s config file at %s has no %s", s.Component, path, versionKey)
+	}
+	if err := s.CheckVersion(int(version)); err != nil {
+		return fmt.Errorf("%s config file at %s - %w", s.Component, path, err)
+	}
+	delete(values, versionKey)
+
+	set := setFlags(fs)
+	for name, value := range values {
+		if _, known := s.Flags[name]; !known {
+			return fmt.Errorf("unknown key %s in the %s config file at %s", name, s.Component, path)
+		}
+		if set[name] {
+			continue
+		}
+		if err := fs.Set(name, tomlValueString(value)); err != nil {
+			return fmt.Errorf("invalid value for %s in the %s config file at %s - %w", name, s.Component, path, err)
+		}
+	}
+	return nil
+}
+
+// Markdown generates the documentation of the schema as a markdown table
+func (s *Schema) Markdown() string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("# %s configuration\n\n", s.Component))
+	sb.WriteString("<!-- Generated from the flags by Schema.Markdown - DO NOT EDIT. -->\n\n")
+	sb.WriteString(fmt.Sprintf("Schema version: %d\n\n", s.Version))
+	sb.WriteString("| Flag | Env var | Type | Default | Description |\n")
+	sb.WriteString("|------|---------|------|---------|-------------|\n")
+	for _, name := range s.flagNames() {
+		f := s.Flags[name]
+		sb.WriteString(fmt.Sprintf("| %s | %s | %s | %v | %s |\n", name, s.EnvName(name), f.FlagType, f.DefaultValue, f.Description))
+	}
+	return sb.String()
+}
+
+func (s *Schema) flagNames() []string {
+	names := make([]string, 0, len(s.Flags))
+	for name := range s.Flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// the flags that were set since the flag set was created, on the command line or by a binding
+func setFlags(fs *goflag.FlagSet) map[string]bool {
+	set := map[string]bool{}
+	fs.Visit(func(f *goflag.Flag) {
+		set[f.Name] = true
+	})
+	return set
+}
+
+// the lists of the config files are the comma-separated values of the flags
+func tomlValueString(value interface{}) string {
+	list, ok := value.([]interface{})
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	items := make([]string, len(list))
+	for i, item := range list {
+		items[i] = fmt.Sprint(item)
+	}
+	return strings.Join(items, ",")
+}
@@ -0,0 +1,96 @@
+package config
+
+import (
+	goflag "flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// set to true to regenerate the committed docs when the flags change
+const regenerateDocs = false
+
+func TestSchemaMarkdown(t *testing.T) {
+	docs := EnclaveSchema.Markdown()
+	for name := range EnclaveFlags {
+		require.Contains(t, docs, "| "+name+" | EDG_"+strings.ToUpper(name)+" |")
+	}
+}
+
+func TestEnclaveConfigDocsAreUpToDate(t *testing.T) {
+	docs := EnclaveSchema.Markdown()
+	if regenerateDocs {
+		require.NoError(t, os.WriteFile(EnclaveConfigDocsFile, []byte(docs), 0o600))
+	}
+	committed, err := os.ReadFile(EnclaveConfigDocsFile)
+	require.NoError(t, err)
+	require.Equal(t, docs, string(committed), "%s is out of date, regenerate it with regenerateDocs", EnclaveConfigDocsFile)
+}
+
+func newTestFlagSet(t *testing.T, args ...string) (*goflag.FlagSet, *Schema) {
+	fs := goflag.NewFlagSet("test", goflag.ContinueOnError)
+	fs.String("name", "default", "the name")
+	fs.Duration("interval", time.Second, "the interval")
+	fs.Uint64("port", 80, "the port")
+	fs.String("modules", "eth", "the modules")
+	require.NoError(t, fs.Parse(args))
+	return fs, NewFlagSetSchema("test", 2, "TEST_", fs)
+}
+
+func writeConfigFile(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "config.toml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestNewFlagSetSchema(t *testing.T) {
+	_, schema := newTestFlagSet(t)
+	require.Equal(t, "time.Duration", schema.Flags["interval"].FlagType)
+	require.Equal(t, "uint64", schema.Flags["port"].FlagType)
+	require.Equal(t, "1s", schema.Flags["interval"].DefaultValue)
+	require.Contains(t, schema.Markdown(), "| port | TEST_PORT | uint64 | 80 | the port |")
+}
+
+// the command line wins over the env vars, which win over the config file
+func TestBindingPrecedence(t *testing.T) {
+	fs, schema := newTestFlagSet(t, "-name", "cli")
+	t.Setenv("TEST_NAME", "env")
+	t.Setenv("TEST_INTERVAL", "2s")
+	path := writeConfigFile(t, "version = 2\nname = \"file\"\ninterval = \"3s\"\nport = 8080\nmodules = [\"eth\", \"ten\"]\n")
+
+	require.NoError(t, schema.BindEnv(fs))
+	require.NoError(t, schema.BindFile(fs, path))
+
+	require.Equal(t, "cli", fs.Lookup("name").Value.String())
+	require.Equal(t, "2s", fs.Lookup("interval").Value.String())
+	require.Equal(t, "8080", fs.Lookup("port").Value.String())
+	require.Equal(t, "eth,ten", fs.Lookup("modules").Value.String())
+}
+
+func TestBindingRejectsInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		err     string
+	}{
+		{name: "no version", content: "port = 8080\n", err: "has no version"},
+		{name: "another version", content: "version = 1\nport = 8080\n", err: "version 1 of the schema, expected version 2"},
+		{name: "unknown key", content: "version = 2\nprot = 8080\n", err: "unknown key prot"},
+		{name: "invalid duration", content: "version = 2\ninterval = \"soon\"\n", err: "invalid value for interval"},
+		{name: "invalid number", content: "version = 2\nport = -1\n", err: "invalid value for port"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs, schema := newTestFlagSet(t)
+			require.ErrorContains(t, schema.BindFile(fs, writeConfigFile(t, tt.content)), tt.err)
+		})
+	}
+
+	fs, schema := newTestFlagSet(t)
+	t.Setenv("TEST_PORT", "eighty")
+	require.ErrorContains(t, schema.BindEnv(fs), "invalid value for TEST_PORT")
+}
@@ -16,6 +16,8 @@ type IDData struct {
 	Owner       gethcommon.Address
 	PubKey      []byte
 	HostAddress string
+	// the hash of the network genesis, omitted for networks that don't use a genesis file
+	NetworkGenesisHash *gethcommon.Hash `json:",omitempty"`
 }
 
 type AttestationProvider interface {
@@ -25,10 +27,12 @@ type AttestationProvider interface {
 	VerifyReport(att *common.AttestationReport) ([]byte, error)
 }
 
-type EgoAttestationProvider struct{}
+type EgoAttestationProvider struct {
+	NetworkGenesisHash *gethcommon.Hash // included in the report data, so only enclaves of the same network trust each other
+}
 
 func (e *EgoAttestationProvider) GetReport(pubKey []byte, owner gethcommon.Address, hostAddress string) (*common.AttestationReport, error) {
-	idHash, err := getIDHash(owner, pubKey, hostAddress)
+	idHash, err := getIDHash(owner, pubKey, hostAddress, e.NetworkGenesisHash)
 	if err != nil {
 		return nil, err
 	}
@@ -54,7 +58,9 @@ func (e *EgoAttestationProvider) VerifyReport(att *common.AttestationReport) ([]
 	return remoteReport.Data, nil
 }
 
-type DummyAttestationProvider struct{}
+type DummyAttestationProvider struct {
+	NetworkGenesisHash *gethcommon.Hash
+}
 
 func (e *DummyAttestationProvider) GetReport(pubKey []byte, owner gethcommon.Address, hostAddress string) (*common.AttestationReport, error) {
 	return &common.AttestationReport{
@@ -66,15 +72,16 @@ func (e *DummyAttestationProvider) GetReport(pubKey []byte, owner gethcommon.Add
 }
 
 func (e *DummyAttestationProvider) VerifyReport(att *common.AttestationReport) ([]byte, error) {
-	return getIDHash(att.Owner, att.PubKey, att.HostAddress)
+	return getIDHash(att.Owner, att.PubKey, att.HostAddress, e.NetworkGenesisHash)
 }
 
 // getIDHash provides a hash of identifying data to be included in an attestation report (or verified against the contents of an attestation report)
-func getIDHash(owner gethcommon.Address, pubKey []byte, hostAddress string) ([]byte, error) {
+func getIDHash(owner gethcommon.Address, pubKey []byte, hostAddress string, networkGenesisHash *gethcommon.Hash) ([]byte, error) {
 	idData := IDData{
-		Owner:       owner,
-		PubKey:      pubKey,
-		HostAddress: hostAddress,
+		Owner:              owner,
+		PubKey:             pubKey,
+		HostAddress:        hostAddress,
+		NetworkGenesisHash: networkGenesisHash,
 	}
 	idJSON, err := json.Marshal(idData)
 	if err != nil {
@@ -84,8 +91,9 @@ func getIDHash(owner gethcommon.Address, pubKey []byte, hostAddress string) ([]b
 	return hash[:], nil
 }
 
-func VerifyIdentity(data []byte, att *common.AttestationReport) error {
-	expectedIDHash, err := getIDHash(att.Owner, att.PubKey, att.HostAddress)
+// VerifyIdentity checks the report data matches the identity in the attestation and the network genesis of the verifier
+func VerifyIdentity(data []byte, att *common.AttestationReport, networkGenesisHash *gethcommon.Hash) error {
+	expectedIDHash, err := getIDHash(att.Owner, att.PubKey, att.HostAddress, networkGenesisHash)
 	if err != nil {
 		return fmt.Errorf("failed to create ID data to check attestation report with owner: %s. Cause: %w", att.Owner, err)
 	}
@@ -12,6 +12,7 @@ import (
 	"github.com/ten-protocol/go-ten/go/enclave/storage"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/rlp"
 
 	"github.com/ethereum/go-ethereum/core/state"
@@ -26,11 +27,16 @@ import (
 	"github.com/ten-protocol/go-ten/go/enclave/core"
 	"github.com/ten-protocol/go-ten/go/enclave/crosschain"
 	"github.com/ten-protocol/go-ten/go/enclave/evm"
+	"github.com/ten-protocol/go-ten/go/enclave/fees"
 	"github.com/ten-protocol/go-ten/go/enclave/genesis"
+	"github.com/ten-protocol/go-ten/go/enclave/governance"
 )
 
 var ErrNoTransactionsToProcess = fmt.Errorf("no transactions to process")
 
+// maxRevertDataSize is the size of the revert data of a failed transaction kept for its sender
+const maxRevertDataSize = 4 * 1024
+
 // batchExecutor - the component responsible for executing batches
 type batchExecutor struct {
 	storage              storage.Storage
@@ -39,6 +45,8 @@ type batchExecutor struct {
 	logger               gethlog.Logger
 	gasOracle            gas.Oracle
 	chainConfig          *params.ChainConfig
+	l1MessagesFork       *uint64 // the batch height the L1 message verifier precompile activates at
+	governance           governance.Manager
 
 	// stateDBMutex - used to protect calls to stateDB.Commit as it is not safe for async access.
 	stateDBMutex sync.Mutex
@@ -52,6 +60,8 @@ func NewBatchExecutor(
 	genesis *genesis.Genesis,
 	gasOracle gas.Oracle,
 	chainConfig *params.ChainConfig,
+	l1MessagesFork *uint64,
+	governance governance.Manager,
 	batchGasLimit uint64,
 	logger gethlog.Logger,
 ) BatchExecutor {
@@ -60,6 +70,8 @@ func NewBatchExecutor(
 		crossChainProcessors: cc,
 		genesis:              genesis,
 		chainConfig:          chainConfig,
+		l1MessagesFork:       l1MessagesFork,
+		governance:           governance,
 		logger:               logger,
 		gasOracle:            gasOracle,
 		stateDBMutex:         sync.Mutex{},
@@ -69,10 +81,11 @@ func NewBatchExecutor(
 
 // payL1Fees - this function modifies the state db according to the transactions contained within the batch context
 // in order to subtract gas fees from the balance. It returns a list of the transactions that have prepaid for their L1
-// publishing costs.
-func (executor *batchExecutor) payL1Fees(stateDB *state.StateDB, context *BatchExecutionContext) (common.L2Transactions, common.L2Transactions) {
+// publishing costs, and the total they paid.
+func (executor *batchExecutor) payL1Fees(stateDB *state.StateDB, context *BatchExecutionContext) (common.L2Transactions, common.L2Transactions, *big.Int) {
 	transactions := make(common.L2Transactions, 0)
 	freeTransactions := make(common.L2Transactions, 0)
+	paid := big.NewInt(0)
 	block, _ := executor.storage.FetchBlock(context.BlockPtr)
 
 	for _, tx := range context.Transactions {
@@ -106,15 +119,18 @@ func (executor *batchExecutor) payL1Fees(stateDB *state.StateDB, context *BatchE
 		}
 		stateDB.SubBalance(*sender, cost)
 		stateDB.AddBalance(context.Creator, cost)
+		paid.Add(paid, cost)
 		// todo - add refund logic.
 
 		transactions = append(transactions, tx)
 	}
-	return transactions, freeTransactions
+	return transactions, freeTransactions, paid
 }
 
-func (executor *batchExecutor) refundL1Fees(stateDB *state.StateDB, context *BatchExecutionContext, transactions []*common.L2Tx) {
+// refundL1Fees returns the L1 fees paid by the transactions that were excluded from the batch, and the total refunded
+func (executor *batchExecutor) refundL1Fees(stateDB *state.StateDB, context *BatchExecutionContext, transactions []*common.L2Tx) *big.Int {
 	block, _ := executor.storage.FetchBlock(context.BlockPtr)
+	refunded := big.NewInt(0)
 	for _, tx := range transactions {
 		cost, err := executor.gasOracle.EstimateL1StorageGasCost(tx, block)
 		if err != nil {
@@ -131,7 +147,9 @@ func (executor *batchExecutor) refundL1Fees(stateDB *state.StateDB, context *Bat
 
 		stateDB.AddBalance(*sender, cost)
 		stateDB.SubBalance(context.Creator, cost)
+		refunded.Add(refunded, cost)
 	}
+	return refunded
 }
 
 func (executor *batchExecutor) ComputeBatch(context *BatchExecutionContext, failForEmptyBatch bool) (*ComputedBatch, error) { //nolint:gocognit
@@ -145,6 +163,16 @@ func (executor *batchExecutor) ComputeBatch(context *BatchExecutionContext, fail
 		return nil, fmt.Errorf("failed to retrieve block %s for batch. Cause: %w", context.BlockPtr, err)
 	}
 
+	// the parameters set by the L1 governance override the configured ones. The validators recompute the batch with them,
+	// so they reject the batches of a sequencer that does not apply them.
+	governed, err := executor.governance.ActiveParameters(block)
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve the governed parameters for block %s. Cause: %w", context.BlockPtr, err)
+	}
+	if governed.BaseFee != nil {
+		context.BaseFee = governed.BaseFee
+	}
+
 	// These variables will be used to create the new batch
 	parent, err := executor.storage.FetchBatch(context.ParentPtr)
 	if errors.Is(err, errutil.ErrNotFound) {
@@ -167,6 +195,19 @@ func (executor *batchExecutor) ComputeBatch(context *BatchExecutionContext, fail
 
 	// Create a new batch based on the fromBlock of inclusion of the previous, including all new transactions
 	batch := core.DeterministicEmptyBatch(parent.Header, block, context.AtTime, context.SequencerNo, context.BaseFee, context.Creator)
+	if err := context.Extras.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid batch extras. Cause: %w", err)
+	}
+	batch.Header.Extras = context.Extras
+	gasLimit := executor.batchGasLimit
+	switch {
+	case governed.AdaptiveGasLimit():
+		gasLimit = gas.NextBatchGasLimit(parent.Header.GasLimit, parent.Header.GasUsed, governed.BatchGasLimitMin, governed.BatchGasLimitMax)
+		batch.Header.GasLimit = gasLimit
+	case governed.BatchGasLimit != 0:
+		gasLimit = governed.BatchGasLimit
+		batch.Header.GasLimit = gasLimit
+	}
 
 	stateDB, err := executor.storage.CreateStateDB(batch.Header.ParentHash)
 	if err != nil {
@@ -176,33 +217,62 @@ func (executor *batchExecutor) ComputeBatch(context *BatchExecutionContext, fail
 
 	var messages common.CrossChainMessages
 	var transfers common.ValueTransferEvents
+	var relays common.MessageRelays
 	if context.SequencerNo.Int64() > int64(common.L2GenesisSeqNo+1) {
-		messages, transfers = executor.crossChainProcessors.Local.RetrieveInboundMessages(parentBlock, block, stateDB)
+		messages, transfers, relays = executor.crossChainProcessors.Local.RetrieveInboundMessages(parentBlock, block, stateDB)
 	}
 
 	crossChainTransactions := executor.crossChainProcessors.Local.CreateSyntheticTransactions(messages, stateDB)
 	executor.crossChainProcessors.Local.ExecuteValueTransfers(transfers, stateDB)
 
-	transactionsToProcess, freeTransactions := executor.payL1Fees(stateDB, context)
+	transactionsToProcess, freeTransactions, l1Fees := executor.payL1Fees(stateDB, context)
 
 	crossChainTransactions = append(crossChainTransactions, freeTransactions...)
 
-	successfulTxs, excludedTxs, txReceipts, err := executor.processTransactions(batch, 0, transactionsToProcess, stateDB, context.ChainConfig, false)
+	sponsor := executor.gasSponsor(governed, block, transactionsToProcess)
+	processed, err := executor.processTransactions(batch, 0, transactionsToProcess, stateDB, context.ChainConfig, gasLimit, context.Budget, sponsor, false)
 	if err != nil {
 		return nil, fmt.Errorf("could not process transactions. Cause: %w", err)
 	}
+	successfulTxs, txReceipts := processed.executed, processed.receipts
+	if len(processed.carriedOver) > 0 {
+		executor.logger.Info("Execution budget exhausted, sealing the batch", log.BatchSeqNoKey, context.SequencerNo,
+			"executed", len(successfulTxs), "carriedOver", len(processed.carriedOver))
+	}
 
-	executor.refundL1Fees(stateDB, context, excludedTxs)
+	// the L1 fees of the transactions left out of the batch are paid when they are included
+	l1Fees.Sub(l1Fees, executor.refundL1Fees(stateDB, context, append(processed.excluded, processed.carriedOver...)))
+	// and the ones of the sponsored transactions are paid by their paymaster
+	for _, sponsored := range processed.sponsored {
+		l1Fees.Sub(l1Fees, sponsored.L1Fee)
+	}
 
-	ccSuccessfulTxs, _, ccReceipts, err := executor.processTransactions(batch, len(successfulTxs), crossChainTransactions, stateDB, context.ChainConfig, true)
+	ccProcessed, err := executor.processTransactions(batch, len(successfulTxs), crossChainTransactions, stateDB, context.ChainConfig, gasLimit, nil, nil, true)
 	if err != nil {
 		return nil, err
 	}
+	ccSuccessfulTxs, ccReceipts := ccProcessed.executed, ccProcessed.receipts
 
 	if err = executor.verifyInboundCrossChainTransactions(crossChainTransactions, ccSuccessfulTxs, ccReceipts); err != nil {
 		return nil, fmt.Errorf("batch computation failed due to cross chain messages. Cause: %w", err)
 	}
 
+	// the relay fees paid in the batch are attached to the L2->L1 messages, and paid to the relayers recorded on the L1
+	published, err := executor.crossChainProcessors.Local.ExtractOutboundMessages(txReceipts)
+	if err != nil {
+		return nil, fmt.Errorf("could not extract cross chain messages. Cause: %w", err)
+	}
+	if err = executor.crossChainProcessors.Local.ExecuteRelayFees(published, relays, successfulTxs, txReceipts, stateDB); err != nil {
+		return nil, fmt.Errorf("could not execute the relay fees. Cause: %w", err)
+	}
+
+	// the base fees burnt by the EVM are distributed as the governance decided
+	batchFees, err := executor.distributeFees(stateDB, batch, successfulTxs, txReceipts, processed.sponsored, governed)
+	if err != nil {
+		return nil, err
+	}
+	batchFees.L1Fees = l1Fees
+
 	// we need to copy the batch to reset the internal hash cache
 	copyBatch := *batch
 	copyBatch.Header.Root = stateDB.IntermediateRoot(false)
@@ -213,15 +283,21 @@ func (executor *batchExecutor) ComputeBatch(context *BatchExecutionContext, fail
 		return nil, fmt.Errorf("failed adding cross chain data to batch. Cause: %w", err)
 	}
 
+	outboundMessages, err := toOutboundMessages(&copyBatch)
+	if err != nil {
+		return nil, err
+	}
+
 	allReceipts := append(txReceipts, ccReceipts...)
-	executor.populateHeader(&copyBatch, allReceipts)
+	executor.populateHeader(&copyBatch, allReceipts, governed.AdaptiveGasLimit())
 	if failForEmptyBatch &&
 		len(txReceipts) == 0 &&
 		len(ccReceipts) == 0 &&
-		len(transactionsToProcess)-len(excludedTxs) == 0 &&
+		len(successfulTxs) == 0 &&
 		len(crossChainTransactions) == 0 &&
 		len(messages) == 0 &&
-		len(transfers) == 0 {
+		len(transfers) == 0 &&
+		len(relays) == 0 {
 		// revert any unexpected mutation to the statedb
 		stateDB.RevertToSnapshot(snap)
 		return nil, ErrNoTransactionsToProcess
@@ -238,6 +314,7 @@ func (executor *batchExecutor) ComputeBatch(context *BatchExecutionContext, fail
 	return &ComputedBatch{
 		Batch:    &copyBatch,
 		Receipts: allReceipts,
+		Fees:     batchFees,
 		Commit: func(deleteEmptyObjects bool) (gethcommon.Hash, error) {
 			executor.stateDBMutex.Lock()
 			defer executor.stateDBMutex.Unlock()
@@ -246,8 +323,19 @@ func (executor *batchExecutor) ComputeBatch(context *BatchExecutionContext, fail
 				return gethcommon.Hash{}, fmt.Errorf("commit failure for batch %d. Cause: %w", batch.SeqNo(), err)
 			}
 			trieDB := executor.storage.TrieDB()
-			err = trieDB.Commit(h, false)
-			return h, err
+			if err = trieDB.Commit(h, false); err != nil {
+				return h, err
+			}
+			if err = executor.storage.StoreBatchFees(batchFees); err != nil {
+				return h, fmt.Errorf("could not store the fees of batch %d. Cause: %w", batch.SeqNo(), err)
+			}
+			if err = executor.storage.StoreTxFailures(processed.failures); err != nil {
+				return h, fmt.Errorf("could not store the failed transactions of batch %d. Cause: %w", batch.SeqNo(), err)
+			}
+			if err = executor.storage.StoreOutboundMessages(outboundMessages); err != nil {
+				return h, fmt.Errorf("could not store the cross chain messages of batch %d. Cause: %w", batch.SeqNo(), err)
+			}
+			return h, nil
 		},
 	}, nil
 }
@@ -269,6 +357,7 @@ func (executor *batchExecutor) ExecuteBatch(batch *core.Batch) (types.Receipts,
 		SequencerNo:  batch.Header.SequencerOrderNo,
 		Creator:      batch.Header.Coinbase,
 		BaseFee:      batch.Header.BaseFee,
+		Extras:       batch.Header.Extras,
 	}, false) // this execution is not used when first producing a batch, we never want to fail for empty batches
 	if err != nil {
 		return nil, fmt.Errorf("failed computing batch %s. Cause: %w", batch.Hash(), err)
@@ -370,13 +459,95 @@ func (executor *batchExecutor) populateOutboundCrossChainData(batch *core.Batch,
 	return nil
 }
 
-func (executor *batchExecutor) populateHeader(batch *core.Batch, receipts types.Receipts) {
+// toOutboundMessages returns the L2->L1 messages of the batch with their hash, for the relayers to find them
+func toOutboundMessages(batch *core.Batch) ([]*common.OutboundMessage, error) {
+	result := make([]*common.OutboundMessage, 0, len(batch.Header.CrossChainMessages))
+	for _, msg := range batch.Header.CrossChainMessages {
+		hash, err := common.CrossChainMessageHash(msg)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, &common.OutboundMessage{Hash: hash, BatchSeqNo: batch.SeqNo().Uint64(), Message: msg})
+	}
+	return result, nil
+}
+
+// gasSponsor returns the paymaster hook with the fee token and the paymasters in force for the batch, nil if there are
+// none. The sponsored transactions paid their L1 fees like the others, and get them back with their gas fees.
+func (executor *batchExecutor) gasSponsor(governed *governance.Parameters, block *types.Block, txs []*common.L2Tx) *evm.GasSponsor {
+	sponsor := evm.NewGasSponsor(governed.FeeToken, governed.Paymasters)
+	for _, tx := range txs {
+		if !sponsor.IsSponsored(tx.To()) {
+			continue
+		}
+		cost, err := executor.gasOracle.EstimateL1StorageGasCost(tx, block)
+		if err != nil {
+			executor.logger.Warn("Unable to get gas cost for tx", log.TxKey, tx.Hash(), log.ErrKey, err)
+			continue
+		}
+		sponsor.L1Fees[tx.Hash()] = cost
+	}
+	return sponsor
+}
+
+// distributeFees credits the base fees of the gas used by the paid transactions with the distributor selected by the
+// governance, and returns the fees collected in the batch. The fees of the sponsored transactions were paid in the fee
+// token, so they are recorded apart.
+func (executor *batchExecutor) distributeFees(stateDB *state.StateDB, batch *core.Batch, txs []*common.L2Tx, receipts types.Receipts, sponsored []*common.SponsoredFee, governed *governance.Parameters) (*common.BatchFees, error) {
+	batchFees := &common.BatchFees{
+		SeqNo:    batch.SeqNo().Uint64(),
+		BaseFees: big.NewInt(0),
+		Tips:     big.NewInt(0),
+	}
+	baseFee := batch.Header.BaseFee
+	if baseFee == nil {
+		baseFee = big.NewInt(0)
+	}
+
+	txsByHash := make(map[gethcommon.Hash]*common.L2Tx, len(txs))
+	for _, tx := range txs {
+		txsByHash[tx.Hash()] = tx
+	}
+	sponsoredTxs := make(map[gethcommon.Hash]bool, len(sponsored))
+	for _, fee := range sponsored {
+		sponsoredTxs[fee.TxHash] = true
+		batchFees.Sponsored = append(batchFees.Sponsored, *fee)
+	}
+	for _, receipt := range receipts {
+		if sponsoredTxs[receipt.TxHash] {
+			continue
+		}
+		gasUsed := new(big.Int).SetUint64(receipt.GasUsed)
+		batchFees.BaseFees.Add(batchFees.BaseFees, new(big.Int).Mul(gasUsed, baseFee))
+		tip, err := txsByHash[receipt.TxHash].EffectiveGasTip(baseFee)
+		if err != nil {
+			return nil, fmt.Errorf("could not compute the tip of tx %s. Cause: %w", receipt.TxHash, err)
+		}
+		batchFees.Tips.Add(batchFees.Tips, gasUsed.Mul(gasUsed, tip))
+	}
+
+	distributor := fees.NewDistributor(governed)
+	batchFees.Distribution = distributor.Distribution()
+	batchFees.Credits = distributor.Distribute(stateDB, batch.Header, batchFees.BaseFees)
+	return batchFees, nil
+}
+
+func (executor *batchExecutor) populateHeader(batch *core.Batch, receipts types.Receipts, adaptiveGasLimit bool) {
 	if len(receipts) == 0 {
 		batch.Header.ReceiptHash = types.EmptyRootHash
 	} else {
 		batch.Header.ReceiptHash = types.DeriveSha(receipts, trie.NewStackTrie(nil))
 	}
 
+	// the gas used drives the gas limit of the next batch. It is part of the hash of the header, so it is only set once
+	// the adaptive gas limit is active, and the batches from before keep their hash
+	batch.Header.GasUsed = 0
+	if adaptiveGasLimit {
+		for _, receipt := range receipts {
+			batch.Header.GasUsed += receipt.GasUsed
+		}
+	}
+
 	if len(batch.Transactions) == 0 {
 		batch.Header.TxHash = types.EmptyRootHash
 	} else {
@@ -398,46 +569,88 @@ func (executor *batchExecutor) verifyInboundCrossChainTransactions(transactions
 	return nil
 }
 
+// processedTxs is the outcome of the execution of a list of transactions
+type processedTxs struct {
+	executed    []*common.L2Tx
+	excluded    []*common.L2Tx // the invalid transactions, left out of the batch
+	carriedOver []*common.L2Tx // the transactions left for the next batches once the execution budget ran out
+	receipts    []*types.Receipt
+	failures    []*common.TransactionError
+	sponsored   []*common.SponsoredFee // the fees paid by the paymasters, in the order of the transactions
+}
+
 func (executor *batchExecutor) processTransactions(
 	batch *core.Batch,
 	tCount int,
 	txs []*common.L2Tx,
 	stateDB *state.StateDB,
 	cc *params.ChainConfig,
+	gasLimit uint64,
+	budget *evm.ExecutionBudget,
+	sponsor *evm.GasSponsor,
 	noBaseFee bool,
-) ([]*common.L2Tx, []*common.L2Tx, []*types.Receipt, error) {
-	var executedTransactions []*common.L2Tx
-	var excludedTransactions []*common.L2Tx
-	var txReceipts []*types.Receipt
+) (*processedTxs, error) {
+	processed := &processedTxs{}
 	txResults := evm.ExecuteTransactions(
 		txs,
 		stateDB,
 		batch.Header,
 		executor.storage,
 		cc,
+		executor.l1MessagesFork,
 		tCount,
 		noBaseFee,
-		executor.batchGasLimit,
+		gasLimit,
+		budget,
+		sponsor,
 		executor.logger,
 	)
 	for _, tx := range txs {
 		result, f := txResults[tx.Hash()]
 		if !f {
-			return nil, nil, nil, fmt.Errorf("there should be an entry for each transaction")
+			return nil, fmt.Errorf("there should be an entry for each transaction")
 		}
-		rec, foundReceipt := result.(*types.Receipt)
-		if foundReceipt {
-			executedTransactions = append(executedTransactions, tx)
-			txReceipts = append(txReceipts, rec)
-		} else {
+		switch {
+		case result.Receipt != nil:
+			processed.executed = append(processed.executed, tx)
+			processed.receipts = append(processed.receipts, result.Receipt)
+			if result.Sponsored != nil {
+				processed.sponsored = append(processed.sponsored, result.Sponsored)
+			}
+			if result.VMErr != nil {
+				processed.failures = append(processed.failures, txFailure(cc, batch, tx, common.TxFailedExecution, result.VMErr, result.Revert))
+			}
+		case errors.Is(result.Err, evm.ErrBudgetExhausted):
+			// the transaction stays in the mempool
+			processed.carriedOver = append(processed.carriedOver, tx)
+		default:
 			// Exclude all errors
-			excludedTransactions = append(excludedTransactions, tx)
-			executor.logger.Info("Excluding transaction from batch", log.TxKey, tx.Hash(), log.BatchHashKey, batch.Hash(), "cause", result)
+			processed.excluded = append(processed.excluded, tx)
+			processed.failures = append(processed.failures, txFailure(cc, batch, tx, common.TxFailedValidation, result.Err, nil))
+			executor.logger.Info("Excluding transaction from batch", log.TxKey, tx.Hash(), log.BatchHashKey, batch.Hash(), "cause", result.Err)
 		}
 	}
-	sort.Sort(sortByTxIndex(txReceipts))
+	sort.Sort(sortByTxIndex(processed.receipts))
 
-	return executedTransactions, excludedTransactions, txReceipts, nil
+	return processed, nil
+}
+
+// txFailure returns the record of why the transaction failed, which only its sender can read. The revert data is
+// truncated to maxRevertDataSize.
+func txFailure(cc *params.ChainConfig, batch *core.Batch, tx *common.L2Tx, stage string, err error, revert []byte) *common.TransactionError {
+	// the sender of an unsigned transaction is left empty, nobody can read its failure
+	sender, _ := types.Sender(types.LatestSigner(cc), tx)
+	if len(revert) > maxRevertDataSize {
+		revert = revert[:maxRevertDataSize]
+	}
+	return &common.TransactionError{
+		TxHash:     tx.Hash(),
+		Sender:     sender,
+		Stage:      stage,
+		BatchSeqNo: hexutil.Uint64(batch.SeqNo().Uint64()),
+		Reason:     err.Error(),
+		RevertData: revert,
+	}
 }
 
 type sortByTxIndex []*types.Receipt
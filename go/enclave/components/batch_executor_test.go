@@ -0,0 +1,31 @@
+package components
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+)
+
+func TestGasUsedOnlySetWithAdaptiveGasLimit(t *testing.T) {
+	receipts := types.Receipts{{GasUsed: 21_000}, {GasUsed: 50_000}}
+	newBatch := func() *core.Batch {
+		return &core.Batch{Header: &common.BatchHeader{Number: big.NewInt(1), SequencerOrderNo: big.NewInt(1), GasLimit: 1_000_000}}
+	}
+	executor := &batchExecutor{}
+
+	// the header of a batch without the adaptive gas limit keeps its hash
+	staticBatch := newBatch()
+	executor.populateHeader(staticBatch, receipts, false)
+	require.Zero(t, staticBatch.Header.GasUsed)
+	adaptiveBatch := newBatch()
+	executor.populateHeader(adaptiveBatch, receipts, true)
+	require.EqualValues(t, 71_000, adaptiveBatch.Header.GasUsed)
+	require.NotEqual(t, staticBatch.Header.Hash(), adaptiveBatch.Header.Hash())
+
+	adaptiveBatch.Header.GasUsed = 0
+	require.Equal(t, staticBatch.Header.Hash(), adaptiveBatch.Header.Hash())
+}
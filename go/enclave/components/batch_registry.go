@@ -23,12 +23,14 @@ type batchRegistry struct {
 	storage      storage.Storage
 	logger       gethlog.Logger
 	headBatchSeq *big.Int // keep track of the last executed batch to optimise db access
+	// the number of L1 blocks on top of the compression block of a rollup for its batches to be finalized
+	l1FinalityDepth uint64
 
 	batchesCallback func(*core.Batch, types.Receipts)
 	callbackMutex   sync.RWMutex
 }
 
-func NewBatchRegistry(storage storage.Storage, logger gethlog.Logger) BatchRegistry {
+func NewBatchRegistry(storage storage.Storage, l1FinalityDepth uint64, logger gethlog.Logger) BatchRegistry {
 	var headBatchSeq *big.Int
 	headBatch, err := storage.FetchHeadBatch()
 	if err != nil {
@@ -42,9 +44,10 @@ func NewBatchRegistry(storage storage.Storage, logger gethlog.Logger) BatchRegis
 		headBatchSeq = headBatch.SeqNo()
 	}
 	return &batchRegistry{
-		storage:      storage,
-		headBatchSeq: headBatchSeq,
-		logger:       logger,
+		storage:         storage,
+		headBatchSeq:    headBatchSeq,
+		l1FinalityDepth: l1FinalityDepth,
+		logger:          logger,
 	}
 }
 
@@ -165,6 +168,27 @@ func (br *batchRegistry) GetBatchStateAtHeight(blockNumber *gethrpc.BlockNumber)
 	return blockchainState, err
 }
 
+// rolledUpBatch returns the last batch included in a rollup of the canonical L1 chain, which is the "safe" batch. The
+// "finalized" batch is the last batch included in a rollup whose compression block is l1FinalityDepth blocks deep
+func (br *batchRegistry) rolledUpBatch(finalized bool) (*core.Batch, error) {
+	l1Head, err := br.storage.FetchHeadBlock()
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve the L1 head. Cause: %w", err)
+	}
+	maxL1Height := l1Head.NumberU64()
+	if finalized {
+		if maxL1Height < br.l1FinalityDepth {
+			return nil, errutil.ErrNotFound
+		}
+		maxL1Height -= br.l1FinalityDepth
+	}
+	seqNo, err := br.storage.FetchRolledUpBatchSeqNo(maxL1Height)
+	if err != nil {
+		return nil, err
+	}
+	return br.storage.FetchBatchBySeqNo(seqNo)
+}
+
 func (br *batchRegistry) GetBatchAtHeight(height gethrpc.BlockNumber) (*core.Batch, error) {
 	if br.headBatchSeq == nil {
 		return nil, fmt.Errorf("chain not initialised")
@@ -177,13 +201,19 @@ func (br *batchRegistry) GetBatchAtHeight(height gethrpc.BlockNumber) (*core.Bat
 			return nil, fmt.Errorf("could not retrieve genesis rollup. Cause: %w", err)
 		}
 		batch = genesisBatch
-	// note: our API currently treats all these block statuses the same for obscuro batches
-	case gethrpc.SafeBlockNumber, gethrpc.FinalizedBlockNumber, gethrpc.LatestBlockNumber, gethrpc.PendingBlockNumber:
+	// the pending batch is not exposed, so it is the head batch
+	case gethrpc.LatestBlockNumber, gethrpc.PendingBlockNumber:
 		headBatch, err := br.storage.FetchBatchBySeqNo(br.headBatchSeq.Uint64())
 		if err != nil {
 			return nil, fmt.Errorf("batch with requested height %d was not found. Cause: %w", height, err)
 		}
 		batch = headBatch
+	case gethrpc.SafeBlockNumber, gethrpc.FinalizedBlockNumber:
+		rolledUpBatch, err := br.rolledUpBatch(height == gethrpc.FinalizedBlockNumber)
+		if err != nil {
+			return nil, fmt.Errorf("%s batch could not be retrieved. Cause: %w", height, err)
+		}
+		batch = rolledUpBatch
 	default:
 		maybeBatch, err := br.storage.FetchBatchByHeight(uint64(height))
 		if err != nil {
@@ -7,6 +7,7 @@ import (
 	"github.com/ten-protocol/go-ten/go/enclave/core"
 
 	"github.com/ten-protocol/go-ten/go/enclave/gas"
+	"github.com/ten-protocol/go-ten/go/enclave/governance"
 	"github.com/ten-protocol/go-ten/go/enclave/storage"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
@@ -24,13 +25,14 @@ type l1BlockProcessor struct {
 	gasOracle            gas.Oracle
 	logger               gethlog.Logger
 	crossChainProcessors *crosschain.Processors
+	governance           governance.Manager
 
 	// we store the l1 head to avoid expensive db access
 	// the host is responsible to always submitting the head l1 block
 	currentL1Head *common.L1BlockHash
 }
 
-func NewBlockProcessor(storage storage.Storage, cc *crosschain.Processors, gasOracle gas.Oracle, logger gethlog.Logger) L1BlockProcessor {
+func NewBlockProcessor(storage storage.Storage, cc *crosschain.Processors, governance governance.Manager, gasOracle gas.Oracle, logger gethlog.Logger) L1BlockProcessor {
 	var l1BlockHash *common.L1BlockHash
 	head, err := storage.FetchHeadBlock()
 	if err != nil {
@@ -47,6 +49,7 @@ func NewBlockProcessor(storage storage.Storage, cc *crosschain.Processors, gasOr
 		logger:               logger,
 		gasOracle:            gasOracle,
 		crossChainProcessors: cc,
+		governance:           governance,
 		currentL1Head:        l1BlockHash,
 	}
 }
@@ -70,6 +73,16 @@ func (bp *l1BlockProcessor) Process(br *common.BlockAndReceipts) (*BlockIngestio
 		if err != nil {
 			return nil, fmt.Errorf("failed to process cross chain transfers. Cause: %w", err)
 		}
+
+		err = bp.crossChainProcessors.Remote.StoreMessageRelays(br.Block, *br.Receipts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process message relays. Cause: %w", err)
+		}
+
+		err = bp.governance.ProcessL1Block(br.Block, *br.Receipts)
+		if err != nil {
+			return nil, fmt.Errorf("failed to process governance changes. Cause: %w", err)
+		}
 	}
 
 	// todo @siliev - not sure if this is the best way to update the price, will pick up random stale blocks from forks?
@@ -11,6 +11,7 @@ import (
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/enclave/core"
+	"github.com/ten-protocol/go-ten/go/enclave/evm"
 	"github.com/ten-protocol/go-ten/go/enclave/limiters"
 )
 
@@ -49,6 +50,8 @@ type BatchExecutionContext struct {
 	ChainConfig  *params.ChainConfig
 	SequencerNo  *big.Int
 	BaseFee      *big.Int
+	Budget       *evm.ExecutionBudget // bounds the execution of the transactions of the sequencer (nil for no bound)
+	Extras       common.BatchExtras   // the extras of the header of the batch, set by the sequencer
 }
 
 // ComputedBatch - a structure representing the result of a batch
@@ -60,6 +63,7 @@ type BatchExecutionContext struct {
 type ComputedBatch struct {
 	Batch    *core.Batch
 	Receipts types.Receipts
+	Fees     *common.BatchFees // the fees collected in the batch, stored when the batch is committed
 	Commit   func(bool) (gethcommon.Hash, error)
 }
 
@@ -112,6 +116,24 @@ type RollupProducer interface {
 type RollupConsumer interface {
 	// ProcessRollupsInBlock - extracts the rollup from the block's transactions
 	// and verifies its integrity, saving and processing any batches that have
-	// not been seen previously.
-	ProcessRollupsInBlock(b *common.BlockAndReceipts) error
+	// not been seen previously. While catching up with the L1 (the block is not
+	// the latest), the execution of the rollups is deferred by a few blocks, so
+	// that the rollups of consecutive blocks are verified concurrently. The
+	// failures are returned as a BlockRollupsError for each block, which may be
+	// an earlier block than b.
+	ProcessRollupsInBlock(b *common.BlockAndReceipts, isLatest bool) error
+
+	// ExecuteDeferredRollups executes, in order, the rollups whose execution was deferred, unless the next block to be
+	// ingested, whose parent is given, extends the last block processed. A nil parent always executes them. The failures
+	// are returned as a BlockRollupsError for each block.
+	ExecuteDeferredRollups(nextParent *common.L1BlockHash) error
+
+	// SubscribeForProgress registers the callback receiving the progress through the batches of the rollups of a block,
+	// so that a long catch-up can be followed
+	SubscribeForProgress(callback func(*common.RollupSyncProgress))
+	UnsubscribeFromProgress()
+
+	// SubscribeForRollupStats registers the callback receiving the compression statistics of each rollup processed
+	SubscribeForRollupStats(callback func(*common.RollupCompressionStats))
+	UnsubscribeFromRollupStats()
 }
@@ -0,0 +1,136 @@
+package components
+
+import (
+	"fmt"
+	"math/big"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+	"github.com/ten-protocol/go-ten/go/enclave/crosschain"
+	"github.com/ten-protocol/go-ten/go/enclave/gas"
+	"github.com/ten-protocol/go-ten/go/enclave/governance"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
+)
+
+// RollupAuditor produces the witnesses of the rollups of the enclave chain, and verifies the witnesses produced by other
+// enclaves by re-executing their batches without the chain state
+type RollupAuditor struct {
+	storage             storage.Storage
+	mgmtContractAddress *common.L1Address
+	messageBusAddress   *gethcommon.Address
+	chainID             *big.Int
+	chainConfig         *params.ChainConfig
+	l1MessagesFork      *uint64
+	batchGasLimit       uint64
+	logger              gethlog.Logger
+}
+
+func NewRollupAuditor(
+	storage storage.Storage,
+	mgmtContractAddress *common.L1Address,
+	messageBusAddress *gethcommon.Address,
+	chainID *big.Int,
+	chainConfig *params.ChainConfig,
+	l1MessagesFork *uint64,
+	batchGasLimit uint64,
+	logger gethlog.Logger,
+) *RollupAuditor {
+	return &RollupAuditor{
+		storage:             storage,
+		mgmtContractAddress: mgmtContractAddress,
+		messageBusAddress:   messageBusAddress,
+		chainID:             chainID,
+		chainConfig:         chainConfig,
+		l1MessagesFork:      l1MessagesFork,
+		batchGasLimit:       batchGasLimit,
+		logger:              logger,
+	}
+}
+
+// Witness re-executes the batches of the stored rollup, and returns the witness of the data they read. The genesis batch
+// is not re-executed, so it is left out of the witness of the first rollup.
+func (a *RollupAuditor) Witness(rollupHash common.L2RollupHash) (*RollupWitness, error) {
+	first, last, err := a.storage.FetchRollupBatchRange(rollupHash)
+	if err != nil {
+		return nil, err
+	}
+	if first <= common.L2GenesisSeqNo {
+		first = common.L2GenesisSeqNo + 1
+	}
+	if last < first {
+		return nil, fmt.Errorf("rollup %s only holds the genesis batch", rollupHash)
+	}
+
+	batches := make([]*core.Batch, 0, last-first+1)
+	for seqNo := first; seqNo <= last; seqNo++ {
+		batch, err := a.storage.FetchBatchBySeqNo(seqNo)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch batch %d - %w", seqNo, err)
+		}
+		batches = append(batches, batch)
+	}
+	parent, err := a.storage.FetchBatchHeader(batches[0].Header.ParentHash)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch the parent of batch %d - %w", first, err)
+	}
+
+	recorder := newWitnessRecorder(a.storage)
+	if err = a.reExecute(recorder, batches); err != nil {
+		return nil, fmt.Errorf("could not re-execute the batches of rollup %s - %w", rollupHash, err)
+	}
+	return recorder.witness(rollupHash, parent, batches), nil
+}
+
+// Verify re-executes the batches of the witness over the data of the witness only, and returns the unsigned verdict. The
+// verdict is invalid if the witness is inconsistent, or if a batch differs from its re-execution.
+func (a *RollupAuditor) Verify(witness *RollupWitness) *common.RollupVerdict {
+	verdict := &common.RollupVerdict{RollupHash: witness.RollupHash}
+	if witness.Parent != nil {
+		verdict.PreStateRoot = witness.Parent.Root
+	}
+	if len(witness.Batches) > 0 {
+		lastBatch := witness.Batches[len(witness.Batches)-1]
+		verdict.FirstBatchSeqNo = hexutil.Uint64(witness.Batches[0].SeqNo().Uint64())
+		verdict.LastBatchSeqNo = hexutil.Uint64(lastBatch.SeqNo().Uint64())
+		verdict.LastBatchHash = lastBatch.Hash()
+		verdict.PostStateRoot = lastBatch.Header.Root
+	}
+
+	ws, err := newWitnessStorage(a.storage, witness)
+	if err != nil {
+		verdict.Reason = fmt.Sprintf("invalid witness - %s", err)
+		return verdict
+	}
+	if err = a.reExecute(ws, witness.Batches); err != nil {
+		// the state reads of the EVM do not fail, a missing entry changes the state root instead
+		if missing := ws.kv.missingKeys(); len(missing) > 0 {
+			verdict.Reason = fmt.Sprintf("incomplete witness - %d state entries are missing, the first is %x", len(missing), missing[0])
+			return verdict
+		}
+		verdict.Reason = err.Error()
+		return verdict
+	}
+	verdict.Valid = true
+	return verdict
+}
+
+// reExecute executes the batches in order over the storage, with components that read it instead of the enclave storage
+func (a *RollupAuditor) reExecute(s storage.Storage, batches []*core.Batch) error {
+	governanceManager, err := governance.NewManager(a.mgmtContractAddress, s, a.logger)
+	if err != nil {
+		return err
+	}
+	crossChainProcessors := crosschain.New(a.messageBusAddress, s, a.chainID, a.logger)
+	// the batches are never genesis batches, so the executor does not need the genesis
+	executor := NewBatchExecutor(s, crossChainProcessors, nil, gas.NewGasOracle(), a.chainConfig, a.l1MessagesFork, governanceManager, a.batchGasLimit, a.logger)
+	for _, batch := range batches {
+		if _, err = executor.ExecuteBatch(batch); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package components
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+	"github.com/ten-protocol/go-ten/go/enclave/limiters"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
+)
+
+// ErrRollupNotBuilt is returned when the builder does not hold the first batch of the requested rollup, e.g. because it
+// was produced before the enclave restarted. The rollup has to be created from the stored batches.
+var ErrRollupNotBuilt = errors.New("the rollup builder does not hold the first batch of the rollup")
+
+// RollupSnapshot is a rollup taken from the RollupBuilder, with the batches it already encoded
+type RollupSnapshot struct {
+	Rollup  *core.Rollup
+	batches []*encodedBatch // nil when the batches have to be encoded with the rollup
+}
+
+// RollupBuilder accumulates the batches produced by the sequencer as they are stored, and encodes the parts of the
+// rollup that only depend on each batch and on the one before it: the transactions, and the deltas of the time, the
+// L1 height and the gas limit. A rollup is a snapshot of the batches accumulated so far, so the sequencer publishes it
+// without reading its batches and their L1 blocks back from the database, or encoding them again.
+//
+// The builder holds consecutive batches, from the first one not published yet to the head. The batches published in a
+// rollup are dropped when the next rollup is taken.
+type RollupBuilder struct {
+	sequencerID gethcommon.Address
+	storage     storage.Storage
+	logger      gethlog.Logger
+
+	mu      sync.Mutex
+	batches []*encodedBatch
+}
+
+func NewRollupBuilder(sequencerID gethcommon.Address, storage storage.Storage, logger gethlog.Logger) *RollupBuilder {
+	return &RollupBuilder{
+		sequencerID: sequencerID,
+		storage:     storage,
+		logger:      logger,
+	}
+}
+
+// AddBatch encodes the batch produced by the sequencer, after the batch before it. A batch that does not follow the
+// last one starts over, so the rollups starting before it are created from the database.
+func (rb *RollupBuilder) AddBatch(batch *core.Batch) error {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	var prev *encodedBatch
+	if len(rb.batches) > 0 {
+		prev = rb.batches[len(rb.batches)-1]
+		if prev.batch.SeqNo().Uint64()+1 != batch.SeqNo().Uint64() {
+			rb.logger.Warn("Batch out of sequence for the rollup builder. Starting over", log.BatchSeqNoKey, batch.SeqNo(), "last", prev.batch.SeqNo())
+			rb.batches = nil
+			prev = nil
+		}
+	}
+
+	l1Block, err := rb.l1Block(batch, prev)
+	if err != nil {
+		rb.batches = nil
+		return err
+	}
+	encoded, err := encodeBatch(batch, l1Block, prev)
+	if err != nil {
+		rb.batches = nil
+		return fmt.Errorf("could not encode batch %d for the rollup. Cause: %w", batch.SeqNo(), err)
+	}
+	rb.batches = append(rb.batches, encoded)
+	return nil
+}
+
+// the consecutive batches mostly share their L1 proof
+func (rb *RollupBuilder) l1Block(batch *core.Batch, prev *encodedBatch) (*types.Block, error) {
+	if prev != nil && prev.l1Block.Hash() == batch.Header.L1Proof {
+		return prev.l1Block, nil
+	}
+	block, err := rb.storage.FetchBlock(batch.Header.L1Proof)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve the L1 proof of batch %d. Cause: %w", batch.SeqNo(), err)
+	}
+	return block, nil
+}
+
+// Snapshot returns the rollup of the batches accumulated from fromBatchNo, like the RollupProducer: it stops at the first
+// batch with an L1 proof above upToL1Height, or rejected by the limiter. The batches before fromBatchNo were published,
+// so they are dropped. Returns ErrRollupNotBuilt when the builder does not hold the batch fromBatchNo.
+func (rb *RollupBuilder) Snapshot(fromBatchNo uint64, upToL1Height uint64, limiter limiters.RollupLimiter) (*RollupSnapshot, error) {
+	rb.mu.Lock()
+	defer rb.mu.Unlock()
+
+	for len(rb.batches) > 0 && rb.batches[0].batch.SeqNo().Uint64() < fromBatchNo {
+		rb.batches[0] = nil
+		rb.batches = rb.batches[1:]
+	}
+	if len(rb.batches) == 0 || rb.batches[0].batch.SeqNo().Uint64() != fromBatchNo {
+		return nil, ErrRollupNotBuilt
+	}
+
+	var batches []*core.Batch
+	var blocks []*types.Block
+	for _, encoded := range rb.batches {
+		if encoded.l1Block.NumberU64() > upToL1Height {
+			break
+		}
+		if !limiter.AcceptEncodedBatch(encoded.transactions) {
+			break
+		}
+		if len(blocks) == 0 || blocks[len(blocks)-1].Hash() != encoded.l1Block.Hash() {
+			blocks = append(blocks, encoded.l1Block)
+		}
+		batches = append(batches, encoded.batch)
+	}
+	if len(batches) == 0 {
+		return nil, fmt.Errorf("no batches for rollup")
+	}
+
+	block, err := rb.storage.FetchCanonicaBlockByHeight(big.NewInt(int64(upToL1Height)))
+	if err != nil {
+		return nil, err
+	}
+
+	rollup := newInternalRollup(rb.sequencerID, block.Hash(), batches, blocks)
+	rb.logger.Info(fmt.Sprintf("Created new rollup %s with %d batches from the builder. From %d to %d", rollup.Hash(), len(rollup.Batches), fromBatchNo, rollup.Header.LastBatchSeqNo))
+
+	// the following batches may be appended while the rollup is published, so it gets its own slice
+	return &RollupSnapshot{Rollup: rollup, batches: append([]*encodedBatch(nil), rb.batches[:len(batches)]...)}, nil
+}
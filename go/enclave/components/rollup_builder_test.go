@@ -0,0 +1,137 @@
+package components
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/compression"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+	"github.com/ten-protocol/go-ten/go/enclave/crypto"
+)
+
+// canonicalBlockStorage also serves the canonical L1 blocks by height
+type canonicalBlockStorage struct {
+	*inMemoryBatchStorage
+	canonical []*types.Block
+}
+
+func (s *canonicalBlockStorage) FetchCanonicaBlockByHeight(height *big.Int) (*types.Block, error) {
+	return s.canonical[height.Int64()], nil
+}
+
+// batchCountLimiter accepts a number of batches
+type batchCountLimiter struct {
+	remaining int
+}
+
+func (l *batchCountLimiter) AcceptBatch(*core.Batch) (bool, error) {
+	return l.AcceptEncodedBatch(nil), nil
+}
+
+func (l *batchCountLimiter) AcceptEncodedBatch([]byte) bool {
+	if l.remaining == 0 {
+		return false
+	}
+	l.remaining--
+	return true
+}
+
+// TestRollupBuilderSnapshots checks that the rollups taken from the builder, as the batches are produced, are the same
+// as the rollups created from the stored batches, and that a validator recreates their batches
+func TestRollupBuilderSnapshots(t *testing.T) {
+	for run := int64(0); run < 20; run++ {
+		seed := run
+		t.Run(fmt.Sprintf("seed-%d", seed), func(t *testing.T) {
+			seq := newRandomBatchSequence(t, rand.New(rand.NewSource(seed))) //nolint:gosec
+			sequencerStorage := &canonicalBlockStorage{inMemoryBatchStorage: newInMemoryBatchStorage(seq.l1Blocks), canonical: seq.canonical}
+			validatorStorage := newInMemoryBatchStorage(seq.l1Blocks)
+
+			logger := gethlog.New()
+			encryption := crypto.NewDataEncryptionService(logger)
+			compressionService := compression.NewBrotliDataCompressionService()
+			sequencer := NewRollupCompression(&noopBatchRegistry{}, nil, encryption, compressionService, sequencerStorage, nil, false, compression.DefaultLimits, limitsFromGenesis, logger)
+			validator := NewRollupCompression(&noopBatchRegistry{}, &deterministicBatchExecutor{storage: validatorStorage}, encryption, compressionService, validatorStorage, nil, false, compression.DefaultLimits, limitsFromGenesis, logger)
+			builder := NewRollupBuilder(testCoinbase, sequencerStorage, logger)
+
+			// the builder accumulates batches after the ones of each rollup
+			for _, b := range seq.batches {
+				require.NoError(t, sequencerStorage.StoreBatch(b))
+				require.NoError(t, builder.AddBatch(b))
+			}
+			sequencerStorage.reorgSeq = seq.reorgSeq
+
+			l1Head := seq.canonical[len(seq.canonical)-1]
+			for _, batches := range seq.rollups {
+				snapshot, err := builder.Snapshot(batches[0].SeqNo().Uint64(), l1Head.NumberU64(), &batchCountLimiter{remaining: len(batches)})
+				require.NoError(t, err)
+				require.Equal(t, batches, snapshot.Rollup.Batches)
+				require.Equal(t, l1Head.Hash(), snapshot.Rollup.Header.CompressionL1Head)
+
+				fromBuilder, err := sequencer.CreateExtRollupFromSnapshot(snapshot)
+				require.NoError(t, err)
+				fromStorage, err := sequencer.CreateExtRollup(&core.Rollup{Header: snapshot.Rollup.Header, Batches: batches, Blocks: snapshot.Rollup.Blocks})
+				require.NoError(t, err)
+				require.Equal(t, decryptAndDecompress(t, sequencer, fromStorage.CalldataRollupHeader), decryptAndDecompress(t, sequencer, fromBuilder.CalldataRollupHeader))
+				require.Equal(t, decryptAndDecompress(t, sequencer, fromStorage.BatchPayloads), decryptAndDecompress(t, sequencer, fromBuilder.BatchPayloads))
+
+				_, err = validator.ProcessExtRollup(fromBuilder)
+				require.NoError(t, err)
+			}
+
+			for _, expected := range seq.batches {
+				recreated, err := validatorStorage.FetchBatchBySeqNo(expected.SeqNo().Uint64())
+				require.NoError(t, err)
+				require.Equal(t, expected.Hash(), recreated.Hash())
+			}
+		})
+	}
+}
+
+// TestRollupBuilderMissingBatches checks that the builder only creates the rollups starting with a batch it holds
+func TestRollupBuilderMissingBatches(t *testing.T) {
+	seq := newRandomBatchSequence(t, rand.New(rand.NewSource(7))) //nolint:gosec
+	require.Greater(t, len(seq.batches), 3)
+	sequencerStorage := &canonicalBlockStorage{inMemoryBatchStorage: newInMemoryBatchStorage(seq.l1Blocks), canonical: seq.canonical}
+	builder := NewRollupBuilder(testCoinbase, sequencerStorage, gethlog.New())
+	l1Height := seq.canonical[len(seq.canonical)-1].NumberU64()
+
+	_, err := builder.Snapshot(seq.batches[0].SeqNo().Uint64(), l1Height, &batchCountLimiter{remaining: 10})
+	require.ErrorIs(t, err, ErrRollupNotBuilt)
+
+	// a batch that does not follow the last one starts over
+	require.NoError(t, builder.AddBatch(seq.batches[0]))
+	require.NoError(t, builder.AddBatch(seq.batches[2]))
+	require.NoError(t, builder.AddBatch(seq.batches[3]))
+	_, err = builder.Snapshot(seq.batches[0].SeqNo().Uint64(), l1Height, &batchCountLimiter{remaining: 10})
+	require.ErrorIs(t, err, ErrRollupNotBuilt)
+
+	snapshot, err := builder.Snapshot(seq.batches[2].SeqNo().Uint64(), l1Height, &batchCountLimiter{remaining: 10})
+	require.NoError(t, err)
+	require.Equal(t, []*core.Batch{seq.batches[2], seq.batches[3]}, snapshot.Rollup.Batches)
+
+	// the published batches are dropped
+	_, err = builder.Snapshot(seq.batches[2].SeqNo().Uint64()-1, l1Height, &batchCountLimiter{remaining: 10})
+	require.ErrorIs(t, err, ErrRollupNotBuilt)
+	snapshot, err = builder.Snapshot(seq.batches[3].SeqNo().Uint64(), l1Height, &batchCountLimiter{remaining: 10})
+	require.NoError(t, err)
+	require.Equal(t, []*core.Batch{seq.batches[3]}, snapshot.Rollup.Batches)
+	_, err = builder.Snapshot(seq.batches[2].SeqNo().Uint64(), l1Height, &batchCountLimiter{remaining: 10})
+	require.ErrorIs(t, err, ErrRollupNotBuilt)
+
+	// the batches whose L1 proof is too recent wait for the next rollups
+	_, err = builder.Snapshot(seq.batches[3].SeqNo().Uint64(), seq.l1Blocks[seq.batches[3].Header.L1Proof].NumberU64()-1, &batchCountLimiter{remaining: 10})
+	require.ErrorContains(t, err, "no batches")
+}
+
+func decryptAndDecompress(t *testing.T, rc *RollupCompression, blob []byte) []byte {
+	compressed, err := rc.dataEncryptionService.Decrypt(blob)
+	require.NoError(t, err)
+	decompressed, err := rc.dataCompressionService.Decompress(compressed, compression.DefaultLimits)
+	require.NoError(t, err)
+	return decompressed
+}
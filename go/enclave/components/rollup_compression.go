@@ -3,6 +3,8 @@ package components
 import (
 	"errors"
 	"fmt"
+	"io"
+	"math"
 	"math/big"
 
 	"golang.org/x/exp/slices"
@@ -12,10 +14,10 @@ import (
 	"github.com/ten-protocol/go-ten/go/common/errutil"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	gethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rlp"
-	"github.com/ethereum/go-ethereum/trie"
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/compression"
 	"github.com/ten-protocol/go-ten/go/common/log"
@@ -43,6 +45,8 @@ Eg. If the time between 2 batches is always 1second, there is no need to store a
 3. To avoid storing hashes, which don't compress at all, we execute each batch to be able to populate the parent hash.
 4. The Signatures over the batches are not stored, since the rollup is itself signed.
 5. The cross chain messages are calculated.
+6. In verification mode, the sequencer also publishes the hashes of the batches and their merkle root, and the
+validators check each batch they recreate against them. The first batch that diverges fails the rollup with a RollupIntegrityError.
 */
 type RollupCompression struct {
 	dataEncryptionService  crypto.DataEncryptionService
@@ -51,7 +55,12 @@ type RollupCompression struct {
 	batchExecutor          BatchExecutor
 	storage                storage.Storage
 	chainConfig            *params.ChainConfig
-	logger                 gethlog.Logger
+	integrityCheck         bool // whether the rollups created publish the hashes of their batches
+	// decompressionLimits bound the data of the rollups compressed on top of the L1 blocks from the height
+	// decompressionLimitsFork. Nil while the limits are not scheduled
+	decompressionLimits     compression.Limits
+	decompressionLimitsFork *uint64
+	logger                  gethlog.Logger
 }
 
 func NewRollupCompression(
@@ -61,50 +70,92 @@ func NewRollupCompression(
 	dataCompressionService compression.DataCompressionService,
 	storage storage.Storage,
 	chainConfig *params.ChainConfig,
+	integrityCheck bool,
+	decompressionLimits compression.Limits,
+	decompressionLimitsFork *uint64,
 	logger gethlog.Logger,
 ) *RollupCompression {
 	return &RollupCompression{
-		batchRegistry:          batchRegistry,
-		batchExecutor:          batchExecutor,
-		dataEncryptionService:  dataEncryptionService,
-		dataCompressionService: dataCompressionService,
-		storage:                storage,
-		chainConfig:            chainConfig,
-		logger:                 logger,
+		batchRegistry:           batchRegistry,
+		batchExecutor:           batchExecutor,
+		dataEncryptionService:   dataEncryptionService,
+		dataCompressionService:  dataCompressionService,
+		storage:                 storage,
+		chainConfig:             chainConfig,
+		integrityCheck:          integrityCheck,
+		decompressionLimits:     decompressionLimits,
+		decompressionLimitsFork: decompressionLimitsFork,
+		logger:                  logger,
 	}
 }
 
-// temporary data structure to help build a batch from the information found in the rollup
+// temporary data structure to help build a batch from the information found in the rollup header. The transactions
+// are decoded from the payload of the rollup when the batch is executed
 type batchFromRollup struct {
-	transactions []*common.L2Tx
-	seqNo        *big.Int
-	height       *big.Int
-	txHash       gethcommon.Hash
-	time         uint64
-	l1Proof      common.L1BlockHash
-	coinbase     gethcommon.Address
-	baseFee      *big.Int
-	gasLimit     uint64
+	seqNo    *big.Int
+	height   *big.Int
+	time     uint64
+	l1Proof  common.L1BlockHash
+	coinbase gethcommon.Address
+	baseFee  *big.Int
+	gasLimit uint64
+	extras   common.BatchExtras
 
 	header *common.BatchHeader // for reorgs
 }
 
 // CreateExtRollup - creates a compressed and encrypted External rollup from the internal data structure
 func (rc *RollupCompression) CreateExtRollup(r *core.Rollup) (*common.ExtRollup, error) {
-	header, err := rc.createRollupHeader(r)
+	return rc.CreateExtRollupFromSnapshot(&RollupSnapshot{Rollup: r})
+}
+
+// CreateExtRollupFromSnapshot - creates the External rollup of a snapshot of the RollupBuilder, reusing the parts of the
+// batches it already encoded
+func (rc *RollupCompression) CreateExtRollupFromSnapshot(snapshot *RollupSnapshot) (*common.ExtRollup, error) {
+	r := snapshot.Rollup
+	batches := snapshot.batches
+	if batches == nil {
+		var err error
+		batches, err = encodeBatches(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// from the fork, the validators reject the data that decompresses past its limit, so the rollup keeps only the
+	// batches that fit and the following ones are published in the next rollups
+	for {
+		extRollup, err := rc.createExtRollup(r, batches)
+		if !errors.Is(err, compression.ErrDecompressionLimit) || len(batches) == 1 {
+			return extRollup, err
+		}
+		rc.logger.Warn("Splitting rollup exceeding the decompression limit", "batches", len(batches), log.ErrKey, err)
+		batches = batches[:len(batches)/2]
+		truncateRollup(r, len(batches))
+		snapshot.batches = batches
+	}
+}
+
+func (rc *RollupCompression) createExtRollup(r *core.Rollup, batches []*encodedBatch) (*common.ExtRollup, error) {
+	limits, err := rc.limitsOfRollup(r.Header.CompressionL1Head)
 	if err != nil {
 		return nil, err
 	}
-	encryptedHeader, err := rc.serialiseCompressAndEncrypt(header)
+	header, err := rc.createRollupHeader(batches)
+	if err != nil {
+		return nil, err
+	}
+	encryptedHeader, err := rc.serialiseCompressAndEncrypt(header, limits)
 	if err != nil {
 		return nil, err
 	}
 
-	transactions := make([][]*common.L2Tx, len(r.Batches))
-	for i, batch := range r.Batches {
-		transactions[i] = batch.Transactions
+	// the encoded transactions of each batch are published as is, as the list of the transactions of the batches
+	transactions := make([]rlp.RawValue, len(batches))
+	for i, batch := range batches {
+		transactions[i] = batch.transactions
 	}
-	encryptedTransactions, err := rc.serialiseCompressAndEncrypt(transactions)
+	encryptedTransactions, err := rc.serialiseCompressAndEncrypt(transactions, limits)
 	if err != nil {
 		return nil, err
 	}
@@ -116,16 +167,129 @@ func (rc *RollupCompression) CreateExtRollup(r *core.Rollup) (*common.ExtRollup,
 	}, nil
 }
 
+// encodedBatch holds the parts of the rollup that only depend on a batch and on the batch before it
+type encodedBatch struct {
+	batch        *core.Batch
+	l1Block      *types.Block
+	transactions rlp.RawValue
+	deltas       batchDeltas // from the previous batch. The first batch of a rollup has its own
+}
+
+// batchDeltas are the changes from a batch to the next, as published in the rollup header. They are stored as byte
+// arrays because rlp can't encode negative numbers
+type batchDeltas struct {
+	time            []byte
+	l1Height        []byte
+	gasLimit        []byte
+	gasLimitChanged bool
+}
+
+func encodeBatch(batch *core.Batch, l1Block *types.Block, prev *encodedBatch) (*encodedBatch, error) {
+	transactions, err := rlp.EncodeToBytes(batch.Transactions)
+	if err != nil {
+		return nil, err
+	}
+	deltas, err := newBatchDeltas(batch, l1Block, prev)
+	if err != nil {
+		return nil, err
+	}
+	return &encodedBatch{batch: batch, l1Block: l1Block, transactions: transactions, deltas: deltas}, nil
+}
+
+// encodeBatches encodes all the batches of a rollup
+func encodeBatches(rollup *core.Rollup) ([]*encodedBatch, error) {
+	batches := make([]*encodedBatch, len(rollup.Batches))
+	var prev *encodedBatch
+	for i, batch := range rollup.Batches {
+		// since this is the sequencer, it must have all the blocks, because it created the batches in the first place
+		encoded, err := encodeBatch(batch, rollup.Blocks[batch.Header.L1Proof], prev)
+		if err != nil {
+			return nil, err
+		}
+		batches[i] = encoded
+		prev = encoded
+	}
+	return batches, nil
+}
+
+// newBatchDeltas returns the deltas of the batch from the previous batch. Without a previous batch, the time and the gas
+// limit don't change, and the L1 height is the actual height
+func newBatchDeltas(batch *core.Batch, l1Block *types.Block, prev *encodedBatch) (batchDeltas, error) {
+	deltaTime := big.NewInt(0)
+	l1HeightDelta := l1Block.Number()
+	gasLimitDelta := big.NewInt(0)
+	if prev != nil {
+		deltaTime = big.NewInt(int64(batch.Header.Time - prev.batch.Header.Time))
+		l1HeightDelta = big.NewInt(l1Block.Number().Int64() - prev.l1Block.Number().Int64())
+		gasLimitDelta = new(big.Int).Sub(new(big.Int).SetUint64(batch.Header.GasLimit), new(big.Int).SetUint64(prev.batch.Header.GasLimit))
+	}
+
+	var deltas batchDeltas
+	var err error
+	if deltas.time, err = deltaTime.GobEncode(); err != nil {
+		return batchDeltas{}, err
+	}
+	if deltas.l1Height, err = l1HeightDelta.GobEncode(); err != nil {
+		return batchDeltas{}, err
+	}
+	if deltas.gasLimit, err = gasLimitDelta.GobEncode(); err != nil {
+		return batchDeltas{}, err
+	}
+	deltas.gasLimitChanged = gasLimitDelta.Sign() != 0
+	return deltas, nil
+}
+
+// verifiedRollup is a rollup whose batches were recreated from its calldata and checked against its header, but not
+// executed yet
+type verifiedRollup struct {
+	calldataRollupHeader *common.CalldataRollupHeader
+	incompleteBatches    []*batchFromRollup
+	payloads             *batchPayloadStream
+	stateRootCheckpoint  common.StateRoot // the state root of the last batch published by the sequencer, if any
+}
+
+// compressionStats returns the sizes of a rollup whose batches were executed, so its payload was read to the end
+func compressionStats(rollup *common.ExtRollup, verified *verifiedRollup) *common.RollupCompressionStats {
+	reorgs := 0
+	for _, header := range verified.calldataRollupHeader.ReOrgs {
+		if len(header) > 0 {
+			reorgs++
+		}
+	}
+	return &common.RollupCompressionStats{
+		RollupHash:      rollup.Hash(),
+		Batches:         hexutil.Uint64(len(verified.incompleteBatches)),
+		RawTxBytes:      hexutil.Uint64(verified.payloads.raw.count),
+		PayloadBytes:    hexutil.Uint64(len(rollup.BatchPayloads)),
+		HeaderBytes:     hexutil.Uint64(len(rollup.CalldataRollupHeader)),
+		ReorgExceptions: hexutil.Uint64(reorgs),
+	}
+}
+
 // ProcessExtRollup - given an External rollup, responsible with checking and saving all batches found inside
 func (rc *RollupCompression) ProcessExtRollup(rollup *common.ExtRollup) (*common.CalldataRollupHeader, error) {
-	transactionsPerBatch := make([][]*common.L2Tx, 0)
-	err := rc.decryptDecompressAndDeserialise(rollup.BatchPayloads, &transactionsPerBatch)
+	verified, err := rc.verifyExtRollup(rollup)
+	if err != nil {
+		return nil, err
+	}
+	return rc.executeVerifiedRollup(verified, nil)
+}
+
+// verifyExtRollup decrypts the rollup, and recreates its batches from its header without executing them. It does not
+// depend on the state, so multiple rollups can be verified concurrently. The transactions of the batches are only
+// decompressed and decoded when the batches are executed, one batch at a time.
+func (rc *RollupCompression) verifyExtRollup(rollup *common.ExtRollup) (*verifiedRollup, error) {
+	limits, err := rc.limitsOfRollup(rollup.Header.CompressionL1Head)
+	if err != nil {
+		return nil, err
+	}
+	payloads, err := rc.openBatchPayloads(rollup.BatchPayloads, limits)
 	if err != nil {
 		return nil, err
 	}
 
 	calldataRollupHeader := new(common.CalldataRollupHeader)
-	err = rc.decryptDecompressAndDeserialise(rollup.CalldataRollupHeader, calldataRollupHeader)
+	err = rc.decryptDecompressAndDeserialise(rollup.CalldataRollupHeader, calldataRollupHeader, limits)
 	if err != nil {
 		return nil, err
 	}
@@ -133,37 +297,59 @@ func (rc *RollupCompression) ProcessExtRollup(rollup *common.ExtRollup) (*common
 	// The recreation of batches is a 2-step process:
 
 	// 1. calculate fields like: sequence, height, time, l1Proof, from the implicit and explicit information from the metadata
-	incompleteBatches, err := rc.createIncompleteBatches(calldataRollupHeader, transactionsPerBatch, rollup.Header.CompressionL1Head)
+	incompleteBatches, err := rc.createIncompleteBatches(calldataRollupHeader, rollup.Header.CompressionL1Head)
 	if err != nil {
 		return nil, err
 	}
+	return &verifiedRollup{
+		calldataRollupHeader: calldataRollupHeader,
+		incompleteBatches:    incompleteBatches,
+		payloads:             payloads,
+		stateRootCheckpoint:  rollup.Header.StateRootCheckpoint,
+	}, nil
+}
 
+// executeVerifiedRollup executes and stores the batches of a verified rollup. The rollups have to be executed in order.
+// onBatch, if not nil, is called with the index and the sequence number of each batch once it is stored.
+func (rc *RollupCompression) executeVerifiedRollup(rollup *verifiedRollup, onBatch func(batchIdx int, seqNo *big.Int)) (*common.CalldataRollupHeader, error) {
 	// 2. execute each batch to be able to calculate the hash which is necessary for the next batch as it is the parent.
-	err = rc.executeAndSaveIncompleteBatches(calldataRollupHeader, incompleteBatches)
+	stateRoot, err := rc.executeAndSaveIncompleteBatches(rollup.calldataRollupHeader, rollup.incompleteBatches, rollup.payloads, onBatch)
 	if err != nil {
 		return nil, err
 	}
 
-	return calldataRollupHeader, nil
+	// the external tools trust the published state roots, so a rollup with a checkpoint that differs from the state
+	// root recomputed by this enclave is rejected
+	if rollup.stateRootCheckpoint != (common.StateRoot{}) && rollup.stateRootCheckpoint != stateRoot {
+		return nil, fmt.Errorf("the state root checkpoint %s of the rollup differs from the state root %s of its last batch",
+			rollup.stateRootCheckpoint, stateRoot)
+	}
+
+	return rollup.calldataRollupHeader, nil
 }
 
 // the main logic that goes from a list of batches to the rollup header
-func (rc *RollupCompression) createRollupHeader(rollup *core.Rollup) (*common.CalldataRollupHeader, error) {
-	batches := rollup.Batches
+func (rc *RollupCompression) createRollupHeader(batches []*encodedBatch) (*common.CalldataRollupHeader, error) {
 	reorgs := make([]*common.BatchHeader, len(batches))
 
-	deltaTimes := make([]*big.Int, len(batches))
-	startTime := batches[0].Header.Time
-	prev := startTime
-
-	l1HeightDeltas := make([]*big.Int, len(batches))
-	var prevL1Height *big.Int
+	startTime := batches[0].batch.Header.Time
+	timeDeltasBA := make([][]byte, len(batches))
+	l1DeltasBA := make([][]byte, len(batches))
 
 	batchHashes := make([]common.L2BatchHash, len(batches))
-	batchHeaders := make([]*common.BatchHeader, len(batches))
+
+	// the coinbase of the first batch applies to the whole rollup, except for the changes
+	coinbase := batches[0].batch.Header.Coinbase
+	var coinbaseChanges []common.CoinbaseChange
+
+	gasLimitDeltas := make([][]byte, len(batches))
+	gasLimitChanged := false
+
+	// the extras are rare, so only the batches that have some are listed
+	var batchExtras []common.RollupBatchExtras
 
 	// create an efficient structure to determine whether a batch is canonical
-	reorgedBatches, err := rc.storage.FetchNonCanonicalBatchesBetween(batches[0].SeqNo().Uint64(), batches[len(batches)-1].SeqNo().Uint64())
+	reorgedBatches, err := rc.storage.FetchNonCanonicalBatchesBetween(batches[0].batch.SeqNo().Uint64(), batches[len(batches)-1].batch.SeqNo().Uint64())
 	if err != nil {
 		return nil, err
 	}
@@ -172,7 +358,8 @@ func (rc *RollupCompression) createRollupHeader(rollup *core.Rollup) (*common.Ca
 		reorgMap[batch.SeqNo().Uint64()] = true
 	}
 
-	for i, batch := range batches {
+	for i, encoded := range batches {
+		batch := encoded.batch
 		rc.logger.Info("Compressing batch to rollup", log.BatchSeqNoKey, batch.SeqNo(), log.BatchHeightKey, batch.Number(), log.BatchHashKey, batch.Hash())
 		// determine whether the batch is canonical
 		if reorgMap[batch.SeqNo().Uint64()] {
@@ -182,41 +369,34 @@ func (rc *RollupCompression) createRollupHeader(rollup *core.Rollup) (*common.Ca
 			rc.logger.Info("Reorg", "pos", i)
 		} else {
 			reorgs[i] = nil
+			if batch.Header.Coinbase != coinbase {
+				coinbase = batch.Header.Coinbase
+				coinbaseChanges = append(coinbaseChanges, common.CoinbaseChange{BatchIdx: uint64(i), Coinbase: coinbase})
+			}
+			if len(batch.Header.Extras) > 0 {
+				batchExtras = append(batchExtras, common.RollupBatchExtras{BatchIdx: uint64(i), Extras: batch.Header.Extras})
+			}
 		}
 		batchHashes[i] = batch.Hash()
-		batchHeaders[i] = batch.Header
-
-		deltaTimes[i] = big.NewInt(int64(batch.Header.Time - prev))
-		prev = batch.Header.Time
-
-		// since this is the sequencer, it must have all the blocks, because it created the batches in the first place
-		block := rollup.Blocks[batch.Header.L1Proof]
 
-		// the first element is the actual height
+		// the deltas of the first batch of the rollup are not relative to the batch before it
+		deltas := encoded.deltas
 		if i == 0 {
-			l1HeightDeltas[i] = block.Number()
-		} else {
-			l1HeightDeltas[i] = big.NewInt(block.Number().Int64() - prevL1Height.Int64())
-		}
-		prevL1Height = block.Number()
-	}
-
-	l1DeltasBA := make([][]byte, len(l1HeightDeltas))
-	for i, delta := range l1HeightDeltas {
-		v, err := delta.GobEncode()
-		if err != nil {
-			return nil, err
+			deltas, err = newBatchDeltas(batch, encoded.l1Block, nil)
+			if err != nil {
+				return nil, err
+			}
 		}
-		l1DeltasBA[i] = v
+		timeDeltasBA[i] = deltas.time
+		l1DeltasBA[i] = deltas.l1Height
+		gasLimitDeltas[i] = deltas.gasLimit
+		gasLimitChanged = gasLimitChanged || deltas.gasLimitChanged
 	}
 
-	timeDeltasBA := make([][]byte, len(deltaTimes))
-	for i, delta := range deltaTimes {
-		v, err := delta.GobEncode()
-		if err != nil {
-			return nil, err
-		}
-		timeDeltasBA[i] = v
+	// optimisation for the rollups where the gas limit does not change
+	var gasLimitDeltasBA [][]byte
+	if gasLimitChanged {
+		gasLimitDeltasBA = gasLimitDeltas
 	}
 
 	reorgsBA, err := transformToByteArray(reorgs)
@@ -230,48 +410,62 @@ func (rc *RollupCompression) createRollupHeader(rollup *core.Rollup) (*common.Ca
 
 	// get the first canonical batch ( which means there is no entry in the reorgs array for it)
 	// this is necessary because the height calculations always have to be performed according to what is perceived as a canonical batch.
-	firstCanonBatchHeight := batches[0].Number()
-	firstCanonParentHash := batches[0].Header.ParentHash
+	firstCanonBatchHeight := batches[0].batch.Number()
+	firstCanonParentHash := batches[0].batch.Header.ParentHash
 	for i, reorg := range reorgs {
 		if reorg == nil {
-			firstCanonBatchHeight = batches[i].Number()
-			firstCanonParentHash = batches[i].Header.ParentHash
+			firstCanonBatchHeight = batches[i].batch.Number()
+			firstCanonParentHash = batches[i].batch.Header.ParentHash
 			break
 		}
 	}
 
 	calldataRollupHeader := &common.CalldataRollupHeader{
-		FirstBatchSequence:    batches[0].SeqNo(),
+		FirstBatchSequence:    batches[0].batch.SeqNo(),
 		FirstCanonBatchHeight: firstCanonBatchHeight,
 		FirstCanonParentHash:  firstCanonParentHash,
 		StartTime:             startTime,
 		BatchTimeDeltas:       timeDeltasBA,
 		ReOrgs:                reorgsBA,
 		L1HeightDeltas:        l1DeltasBA,
-		//	BatchHashes:           batchHashes,
-		//	BatchHeaders:          batchHeaders,
-		Coinbase: batches[0].Header.Coinbase,
-		BaseFee:  batches[0].Header.BaseFee,
-		GasLimit: batches[0].Header.GasLimit,
+		Coinbase:              batches[0].batch.Header.Coinbase,
+		CoinbaseChanges:       coinbaseChanges,
+		BaseFee:               batches[0].batch.Header.BaseFee,
+		GasLimit:              batches[0].batch.Header.GasLimit,
+		GasLimitDeltas:        gasLimitDeltasBA,
+		BatchExtras:           batchExtras,
+	}
+	if rc.integrityCheck {
+		calldataRollupHeader.BatchHashes = batchHashes
+		calldataRollupHeader.BatchHashesRoot = batchHashesRoot(batchHashes)
 	}
 
 	return calldataRollupHeader, nil
 }
 
 // the main logic to recreate the batches from the header. The logical pair of: `createRollupHeader`
-func (rc *RollupCompression) createIncompleteBatches(calldataRollupHeader *common.CalldataRollupHeader, transactionsPerBatch [][]*common.L2Tx, compressionL1Head common.L1BlockHash) ([]*batchFromRollup, error) {
-	incompleteBatches := make([]*batchFromRollup, len(transactionsPerBatch))
+func (rc *RollupCompression) createIncompleteBatches(calldataRollupHeader *common.CalldataRollupHeader, compressionL1Head common.L1BlockHash) ([]*batchFromRollup, error) {
+	// the header holds a time delta for each batch of the rollup
+	nrBatches := len(calldataRollupHeader.BatchTimeDeltas)
+	if err := validateCalldataRollupHeader(calldataRollupHeader, nrBatches); err != nil {
+		return nil, err
+	}
+	incompleteBatches := make([]*batchFromRollup, nrBatches)
 
 	startAtSeq := calldataRollupHeader.FirstBatchSequence.Int64()
 	currentHeight := calldataRollupHeader.FirstCanonBatchHeight.Int64() - 1
 	currentTime := int64(calldataRollupHeader.StartTime)
+	currentCoinbase := calldataRollupHeader.Coinbase
+	coinbaseChanges := calldataRollupHeader.CoinbaseChanges
+	currentGasLimit := new(big.Int).SetUint64(calldataRollupHeader.GasLimit)
+	batchExtras := calldataRollupHeader.BatchExtras
 
 	rollupL1Block, err := rc.storage.FetchBlock(compressionL1Head)
 	if err != nil {
 		return nil, fmt.Errorf("can't find the block used for compression. Cause: %w", err)
 	}
 
-	l1Heights, err := rc.calculateL1HeightsFromDeltas(calldataRollupHeader, transactionsPerBatch)
+	l1Heights, err := rc.calculateL1HeightsFromDeltas(calldataRollupHeader, nrBatches)
 	if err != nil {
 		return nil, err
 	}
@@ -283,7 +477,7 @@ func (rc *RollupCompression) createIncompleteBatches(calldataRollupHeader *commo
 		return nil, err
 	}
 
-	for currentBatchIdx, batchTransactions := range transactionsPerBatch {
+	for currentBatchIdx := 0; currentBatchIdx < nrBatches; currentBatchIdx++ {
 		// the l1 proofs are stored as deltas, which compress well as it should be a series of 1s and 0s
 		// get the block with the currentL1Height, relative to the rollupL1Block
 		block, f := l1BlocksAtHeight[l1Heights[currentBatchIdx]]
@@ -298,7 +492,25 @@ func (rc *RollupCompression) createIncompleteBatches(calldataRollupHeader *commo
 		if err != nil {
 			return nil, err
 		}
+		if !timeDelta.IsInt64() || (timeDelta.Sign() > 0 && currentTime > math.MaxInt64-timeDelta.Int64()) {
+			return nil, fmt.Errorf("invalid rollup header. Time overflow for batch %d", currentBatchIdx)
+		}
 		currentTime += timeDelta.Int64()
+		if currentTime < 0 {
+			return nil, fmt.Errorf("invalid rollup header. Negative time for batch %d", currentBatchIdx)
+		}
+
+		if len(calldataRollupHeader.GasLimitDeltas) > 0 {
+			gasLimitDelta := big.NewInt(0)
+			err = gasLimitDelta.GobDecode(calldataRollupHeader.GasLimitDeltas[currentBatchIdx])
+			if err != nil {
+				return nil, err
+			}
+			currentGasLimit.Add(currentGasLimit, gasLimitDelta)
+			if !currentGasLimit.IsUint64() {
+				return nil, fmt.Errorf("invalid rollup header. Gas limit out of range for batch %d", currentBatchIdx)
+			}
+		}
 
 		// the transactions stored in a valid rollup belong to sequential batches
 		currentSeqNo := big.NewInt(startAtSeq + int64(currentBatchIdx))
@@ -318,6 +530,10 @@ func (rc *RollupCompression) createIncompleteBatches(calldataRollupHeader *commo
 				if err != nil {
 					return nil, err
 				}
+				// the reorged header is stored as is, so it has to sit at the position it occupies in the rollup
+				if fullReorgedHeader.SequencerOrderNo == nil || fullReorgedHeader.SequencerOrderNo.Cmp(currentSeqNo) != 0 {
+					return nil, fmt.Errorf("invalid rollup header. Reorged batch %d has the wrong sequence number", currentBatchIdx)
+				}
 			}
 		}
 
@@ -326,43 +542,109 @@ func (rc *RollupCompression) createIncompleteBatches(calldataRollupHeader *commo
 			currentHeight = currentHeight + 1
 		}
 
-		// calculate the hash of the txs
-		var txHash gethcommon.Hash
-		if len(batchTransactions) == 0 {
-			txHash = types.EmptyRootHash
-		} else {
-			txHash = types.DeriveSha(types.Transactions(batchTransactions), trie.NewStackTrie(nil))
+		// the coinbase changes are only recorded for the canonical batches, the reorged ones have their full header
+		if len(coinbaseChanges) > 0 && coinbaseChanges[0].BatchIdx == uint64(currentBatchIdx) {
+			if !isCanonical {
+				return nil, fmt.Errorf("invalid rollup header. Coinbase change for the reorged batch %d", currentBatchIdx)
+			}
+			if coinbaseChanges[0].Coinbase == currentCoinbase {
+				return nil, fmt.Errorf("invalid rollup header. Coinbase change for batch %d does not change it", currentBatchIdx)
+			}
+			currentCoinbase = coinbaseChanges[0].Coinbase
+			coinbaseChanges = coinbaseChanges[1:]
+		}
+
+		var extras common.BatchExtras
+		if len(batchExtras) > 0 && batchExtras[0].BatchIdx == uint64(currentBatchIdx) {
+			if !isCanonical {
+				return nil, fmt.Errorf("invalid rollup header. Extras for the reorged batch %d", currentBatchIdx)
+			}
+			extras = batchExtras[0].Extras
+			batchExtras = batchExtras[1:]
 		}
 
 		incompleteBatches[currentBatchIdx] = &batchFromRollup{
-			transactions: batchTransactions,
-			seqNo:        currentSeqNo,
-			height:       big.NewInt(currentHeight),
-			txHash:       txHash,
-			time:         uint64(currentTime),
-			l1Proof:      block.Hash(),
-			header:       fullReorgedHeader,
-			coinbase:     calldataRollupHeader.Coinbase,
-			baseFee:      calldataRollupHeader.BaseFee,
-			gasLimit:     calldataRollupHeader.GasLimit,
+			seqNo:    currentSeqNo,
+			height:   big.NewInt(currentHeight),
+			time:     uint64(currentTime),
+			l1Proof:  block.Hash(),
+			header:   fullReorgedHeader,
+			coinbase: currentCoinbase,
+			baseFee:  calldataRollupHeader.BaseFee,
+			gasLimit: currentGasLimit.Uint64(),
+			extras:   extras,
 		}
 		rc.logger.Info("Rollup decompressed batch", log.BatchSeqNoKey, currentSeqNo, log.BatchHeightKey, currentHeight, "rollup_idx", currentBatchIdx, "l1_height", block.Number(), "l1_hash", block.Hash())
 	}
 	return incompleteBatches, nil
 }
 
-func (rc *RollupCompression) calculateL1HeightsFromDeltas(calldataRollupHeader *common.CalldataRollupHeader, transactionsPerBatch [][]*common.L2Tx) ([]uint64, error) {
+// validateCalldataRollupHeader checks that the header published in the rollup is consistent with the batches it
+// describes. The rollup is read from the L1, so a malformed one has to result in an error and not a panic.
+func validateCalldataRollupHeader(header *common.CalldataRollupHeader, nrBatches int) error {
+	if nrBatches == 0 {
+		return errors.New("invalid rollup. It contains no batches")
+	}
+	if header.FirstBatchSequence == nil || header.FirstCanonBatchHeight == nil || header.BaseFee == nil {
+		return errors.New("invalid rollup header. Missing fields")
+	}
+	if header.FirstBatchSequence.Sign() < 0 || header.FirstCanonBatchHeight.Sign() < 0 {
+		return errors.New("invalid rollup header. Negative sequence or height")
+	}
+	// the sequence numbers and heights of all the batches in the rollup have to fit in an int64
+	if !header.FirstBatchSequence.IsInt64() || header.FirstBatchSequence.Int64() > math.MaxInt64-int64(nrBatches) ||
+		!header.FirstCanonBatchHeight.IsInt64() || header.FirstCanonBatchHeight.Int64() > math.MaxInt64-int64(nrBatches) {
+		return errors.New("invalid rollup header. Sequence or height out of range")
+	}
+	if header.StartTime > math.MaxInt64 {
+		return errors.New("invalid rollup header. Start time out of range")
+	}
+	if len(header.BatchTimeDeltas) != nrBatches {
+		return fmt.Errorf("invalid rollup header. Expected %d time deltas, found %d", nrBatches, len(header.BatchTimeDeltas))
+	}
+	if len(header.L1HeightDeltas) != nrBatches {
+		return fmt.Errorf("invalid rollup header. Expected %d l1 height deltas, found %d", nrBatches, len(header.L1HeightDeltas))
+	}
+	if len(header.ReOrgs) > 0 && len(header.ReOrgs) != nrBatches {
+		return fmt.Errorf("invalid rollup header. Expected %d reorg entries, found %d", nrBatches, len(header.ReOrgs))
+	}
+	if len(header.GasLimitDeltas) > 0 && len(header.GasLimitDeltas) != nrBatches {
+		return fmt.Errorf("invalid rollup header. Expected %d gas limit deltas, found %d", nrBatches, len(header.GasLimitDeltas))
+	}
+	// the first batch has the coinbase of the header, the changes apply to the following batches in order
+	prevIdx := uint64(0)
+	for _, change := range header.CoinbaseChanges {
+		if change.BatchIdx <= prevIdx || change.BatchIdx >= uint64(nrBatches) {
+			return fmt.Errorf("invalid rollup header. Coinbase change for batch %d out of order or out of range", change.BatchIdx)
+		}
+		prevIdx = change.BatchIdx
+	}
+	for i, batchExtras := range header.BatchExtras {
+		if (i > 0 && batchExtras.BatchIdx <= header.BatchExtras[i-1].BatchIdx) || batchExtras.BatchIdx >= uint64(nrBatches) {
+			return fmt.Errorf("invalid rollup header. Extras for batch %d out of order or out of range", batchExtras.BatchIdx)
+		}
+		if len(batchExtras.Extras) == 0 {
+			return fmt.Errorf("invalid rollup header. Empty extras for batch %d", batchExtras.BatchIdx)
+		}
+	}
+	return validateBatchHashes(header, nrBatches)
+}
+
+func (rc *RollupCompression) calculateL1HeightsFromDeltas(calldataRollupHeader *common.CalldataRollupHeader, nrBatches int) ([]uint64, error) {
 	referenceHeight := big.NewInt(0)
 	// the first element in the deltas is the actual height
 	err := referenceHeight.GobDecode(calldataRollupHeader.L1HeightDeltas[0])
 	if err != nil {
 		return nil, err
 	}
+	if referenceHeight.Sign() < 0 || !referenceHeight.IsInt64() {
+		return nil, errors.New("invalid rollup header. Invalid L1 height")
+	}
 
 	l1Heights := make([]uint64, 0)
 	l1Heights = append(l1Heights, referenceHeight.Uint64())
 	prevHeight := l1Heights[0]
-	for currentBatchIdx := range transactionsPerBatch {
+	for currentBatchIdx := 0; currentBatchIdx < nrBatches; currentBatchIdx++ {
 		// the l1 proofs are stored as deltas, which compress well as it should be a series of 1s and 0s
 		if currentBatchIdx > 0 {
 			l1Delta := big.NewInt(0)
@@ -370,9 +652,12 @@ func (rc *RollupCompression) calculateL1HeightsFromDeltas(calldataRollupHeader *
 			if err != nil {
 				return nil, err
 			}
+			if !l1Delta.IsInt64() || (l1Delta.Sign() > 0 && int64(prevHeight) > math.MaxInt64-l1Delta.Int64()) {
+				return nil, fmt.Errorf("invalid rollup header. L1 height overflow for batch %d", currentBatchIdx)
+			}
 			value := l1Delta.Int64() + int64(prevHeight)
 			if value < 0 {
-				rc.logger.Crit("Should not have a negative height")
+				return nil, fmt.Errorf("invalid rollup header. Negative L1 height for batch %d", currentBatchIdx)
 			}
 			l1Heights = append(l1Heights, uint64(value))
 			prevHeight = uint64(value)
@@ -393,113 +678,160 @@ func (rc *RollupCompression) calcL1AncestorsOfHeight(fromHeight *big.Int, toBloc
 	return rc.calcL1AncestorsOfHeight(fromHeight, p, path)
 }
 
-func (rc *RollupCompression) executeAndSaveIncompleteBatches(calldataRollupHeader *common.CalldataRollupHeader, incompleteBatches []*batchFromRollup) error { //nolint:gocognit
+func (rc *RollupCompression) executeAndSaveIncompleteBatches(calldataRollupHeader *common.CalldataRollupHeader, incompleteBatches []*batchFromRollup, payloads *batchPayloadStream, onBatch func(batchIdx int, seqNo *big.Int)) (common.StateRoot, error) { //nolint:gocognit
 	parentHash := calldataRollupHeader.FirstCanonParentHash
+	var stateRoot common.StateRoot // the state root of the last batch
 
 	if calldataRollupHeader.FirstBatchSequence.Uint64() != common.L2GenesisSeqNo {
 		_, err := rc.storage.FetchBatch(parentHash)
 		if err != nil {
 			rc.logger.Error("Could not find batch mentioned in the rollup. This should not happen.", log.ErrKey, err)
-			return err
+			return common.StateRoot{}, err
 		}
 	}
 
-	for _, incompleteBatch := range incompleteBatches {
+	for i, incompleteBatch := range incompleteBatches {
+		// the transactions of each batch are decoded from the payload before it is executed, so only the transactions of
+		// one batch are held in memory at a time
+		transactions, err := payloads.next()
+		if err != nil {
+			return common.StateRoot{}, err
+		}
+
 		// check whether the batch is already stored in the database
 		b, err := rc.storage.FetchBatchBySeqNo(incompleteBatch.seqNo.Uint64())
 		if err == nil {
+			if err := checkBatchIntegrity(calldataRollupHeader, i, incompleteBatch.seqNo, b.Hash()); err != nil {
+				return common.StateRoot{}, err
+			}
+			stateRoot = b.Header.Root
 			// chain to a parent only if the batch is not a reorg
 			if incompleteBatch.header == nil {
 				parentHash = b.Hash()
 			}
+			if onBatch != nil {
+				onBatch(i, incompleteBatch.seqNo)
+			}
 			continue
 		}
 		if !errors.Is(err, errutil.ErrNotFound) {
-			return err
+			return common.StateRoot{}, err
 		}
 
 		switch {
 		// this batch was re-orged
 		case incompleteBatch.header != nil:
+			if err := checkBatchIntegrity(calldataRollupHeader, i, incompleteBatch.seqNo, incompleteBatch.header.Hash()); err != nil {
+				return common.StateRoot{}, err
+			}
 			err := rc.storage.StoreBatch(&core.Batch{
 				Header:       incompleteBatch.header,
-				Transactions: incompleteBatch.transactions,
+				Transactions: transactions,
 			})
 			if err != nil {
-				return err
+				return common.StateRoot{}, err
 			}
+			stateRoot = incompleteBatch.header.Root
 
 		// handle genesis
 		case incompleteBatch.seqNo.Uint64() == common.L2GenesisSeqNo:
 			genBatch, _, err := rc.batchExecutor.CreateGenesisState(
 				incompleteBatch.l1Proof,
 				incompleteBatch.time,
-				calldataRollupHeader.Coinbase,
+				incompleteBatch.coinbase,
 				calldataRollupHeader.BaseFee,
 			)
 			if err != nil {
-				return err
+				return common.StateRoot{}, err
+			}
+			if err := checkBatchIntegrity(calldataRollupHeader, i, incompleteBatch.seqNo, genBatch.Hash()); err != nil {
+				return common.StateRoot{}, err
 			}
-			// Sanity check - uncomment when debugging
-			/*if genBatch.Hash() != calldataRollupHeader.BatchHashes[i] {
-				rc.logger.Info(fmt.Sprintf("Good %+v \n Calc %+v", calldataRollupHeader.BatchHeaders[i], genBatch.Header))
-				rc.logger.Crit("Rollup decompression failure. The check hashes don't match")
-			}*/
 
 			err = rc.storage.StoreBatch(genBatch)
 			if err != nil {
-				return err
+				return common.StateRoot{}, err
 			}
 			err = rc.storage.StoreExecutedBatch(genBatch, nil)
 			if err != nil {
-				return err
+				return common.StateRoot{}, err
 			}
 			rc.batchRegistry.OnBatchExecuted(genBatch, nil)
 
 			rc.logger.Info("Stored genesis", log.BatchHashKey, genBatch.Hash())
 			parentHash = genBatch.Hash()
+			stateRoot = genBatch.Header.Root
 
 		default:
 			// transforms the incompleteBatch into a BatchHeader by executing the transactions
 			// and then the info can be used to fill in the parent
 			computedBatch, err := rc.computeBatch(incompleteBatch.l1Proof,
 				parentHash,
-				incompleteBatch.transactions,
+				transactions,
 				incompleteBatch.time,
 				incompleteBatch.seqNo,
 				incompleteBatch.coinbase,
 				incompleteBatch.baseFee,
+				incompleteBatch.extras,
 			)
 			if err != nil {
-				return err
+				return common.StateRoot{}, err
+			}
+			if err := checkBatchIntegrity(calldataRollupHeader, i, incompleteBatch.seqNo, computedBatch.Batch.Hash()); err != nil {
+				return common.StateRoot{}, err
+			}
+
+			// the gas limit is derived from the parent batch and the governance, so a different one means that this
+			// node does not follow the same chain as the sequencer
+			if computedBatch.Batch.Header.GasLimit != incompleteBatch.gasLimit {
+				return common.StateRoot{}, fmt.Errorf("gas limit of batch seq=%d is %d, but the rollup recorded %d", incompleteBatch.seqNo, computedBatch.Batch.Header.GasLimit, incompleteBatch.gasLimit)
 			}
-			// Sanity check - uncomment when debugging
-			/*		if computedBatch.Batch.Hash() != calldataRollupHeader.BatchHashes[i] {
-					rc.logger.Info(fmt.Sprintf("Good %+v\nCalc %+v", calldataRollupHeader.BatchHeaders[i], computedBatch.Batch.Header))
-					rc.logger.Crit("Rollup decompression failure. The check hashes don't match")
-				}*/
 
 			if _, err := computedBatch.Commit(true); err != nil {
-				return fmt.Errorf("cannot commit stateDB for incoming valid batch seq=%d. Cause: %w", incompleteBatch.seqNo, err)
+				return common.StateRoot{}, fmt.Errorf("cannot commit stateDB for incoming valid batch seq=%d. Cause: %w", incompleteBatch.seqNo, err)
 			}
 
 			err = rc.storage.StoreBatch(computedBatch.Batch)
 			if err != nil {
-				return err
+				return common.StateRoot{}, err
 			}
 			err = rc.storage.StoreExecutedBatch(computedBatch.Batch, computedBatch.Receipts)
 			if err != nil {
-				return err
+				return common.StateRoot{}, err
 			}
 			rc.batchRegistry.OnBatchExecuted(computedBatch.Batch, nil)
 
 			parentHash = computedBatch.Batch.Hash()
+			stateRoot = computedBatch.Batch.Header.Root
+		}
+		if onBatch != nil {
+			onBatch(i, incompleteBatch.seqNo)
 		}
 	}
-	return nil
+	return stateRoot, payloads.close()
 }
 
-func (rc *RollupCompression) serialiseCompressAndEncrypt(obj any) ([]byte, error) {
+// limitsOfRollup returns the decompression limits of the rollup compressed on top of the L1 block. The compression block
+// is signed with the rollup, so the sequencer and the validators apply the same limits, and the rollups published before
+// the fork stay valid when the chain is synced again.
+func (rc *RollupCompression) limitsOfRollup(compressionL1Head common.L1BlockHash) (compression.Limits, error) {
+	if rc.decompressionLimitsFork == nil {
+		return compression.Limits{}, nil
+	}
+	if *rc.decompressionLimitsFork == 0 {
+		return rc.decompressionLimits, nil
+	}
+	block, err := rc.storage.FetchBlock(compressionL1Head)
+	if err != nil {
+		return compression.Limits{}, fmt.Errorf("could not fetch the compression block of the rollup. Cause: %w", err)
+	}
+	if block.NumberU64() < *rc.decompressionLimitsFork {
+		return compression.Limits{}, nil
+	}
+	return rc.decompressionLimits, nil
+}
+
+func (rc *RollupCompression) serialiseCompressAndEncrypt(obj any, limits compression.Limits) ([]byte, error) {
 	serialised, err := rlp.EncodeToBytes(obj)
 	if err != nil {
 		return nil, err
@@ -508,6 +840,9 @@ func (rc *RollupCompression) serialiseCompressAndEncrypt(obj any) ([]byte, error
 	if err != nil {
 		return nil, err
 	}
+	if limit := limits.SizeLimit(len(compressed)); int64(len(serialised)) > limit {
+		return nil, fmt.Errorf("%w: %d bytes compressed to %d, over the limit of %d", compression.ErrDecompressionLimit, len(serialised), len(compressed), limit)
+	}
 	encrypted, err := rc.dataEncryptionService.Encrypt(compressed)
 	if err != nil {
 		return nil, err
@@ -515,12 +850,12 @@ func (rc *RollupCompression) serialiseCompressAndEncrypt(obj any) ([]byte, error
 	return encrypted, nil
 }
 
-func (rc *RollupCompression) decryptDecompressAndDeserialise(blob []byte, obj any) error {
+func (rc *RollupCompression) decryptDecompressAndDeserialise(blob []byte, obj any, limits compression.Limits) error {
 	plaintextBlob, err := rc.dataEncryptionService.Decrypt(blob)
 	if err != nil {
 		return err
 	}
-	serialisedBlob, err := rc.dataCompressionService.Decompress(plaintextBlob)
+	serialisedBlob, err := rc.dataCompressionService.Decompress(plaintextBlob, limits)
 	if err != nil {
 		return err
 	}
@@ -531,6 +866,66 @@ func (rc *RollupCompression) decryptDecompressAndDeserialise(blob []byte, obj an
 	return nil
 }
 
+// batchPayloadStream decodes the transactions of the batches of a rollup from its decompressed payload one batch at a
+// time, so that the payload of a large rollup is never held in memory decompressed
+type batchPayloadStream struct {
+	stream *rlp.Stream
+	raw    *countingReader // the decompressed payload
+}
+
+type countingReader struct {
+	io.Reader
+	count int
+}
+
+func (r *countingReader) Read(p []byte) (int, error) {
+	n, err := r.Reader.Read(p)
+	r.count += n
+	return n, err
+}
+
+// openBatchPayloads decrypts the payload of a rollup. The payload is authenticated as a whole, so it can't be decrypted
+// incrementally, but it is only decompressed as the batches are read
+func (rc *RollupCompression) openBatchPayloads(blob []byte, limits compression.Limits) (*batchPayloadStream, error) {
+	compressed, err := rc.dataEncryptionService.Decrypt(blob)
+	if err != nil {
+		return nil, err
+	}
+	raw := &countingReader{Reader: rc.dataCompressionService.DecompressStream(compressed, limits)}
+	// the limit stops the stream from allocating the sizes declared by a malicious payload before reading them
+	stream := rlp.NewStream(raw, uint64(limits.SizeLimit(len(compressed))))
+	if _, err := stream.List(); err != nil {
+		return nil, fmt.Errorf("invalid rollup payload. Cause: %w", err)
+	}
+	return &batchPayloadStream{stream: stream, raw: raw}, nil
+}
+
+// next returns the transactions of the next batch of the payload
+func (p *batchPayloadStream) next() ([]*common.L2Tx, error) {
+	var transactions []*common.L2Tx
+	if err := p.stream.Decode(&transactions); err != nil {
+		if errors.Is(err, rlp.EOL) {
+			return nil, errors.New("invalid rollup payload. It holds fewer batches than the header")
+		}
+		return nil, fmt.Errorf("invalid rollup payload. Cause: %w", err)
+	}
+	return transactions, nil
+}
+
+// close checks that all the batches of the payload were read, and that nothing follows them
+func (p *batchPayloadStream) close() error {
+	if p.stream.MoreDataInList() {
+		return errors.New("invalid rollup payload. It holds more batches than the header")
+	}
+	if err := p.stream.ListEnd(); err != nil {
+		return fmt.Errorf("invalid rollup payload. Cause: %w", err)
+	}
+	if _, _, err := p.stream.Kind(); !errors.Is(err, io.EOF) {
+		return errors.New("invalid rollup payload. Unexpected data after the batches")
+	}
+	return nil
+}
+
 func (rc *RollupCompression) computeBatch(
 	BlockPtr common.L1BlockHash,
 	ParentPtr common.L2BatchHash,
@@ -539,6 +934,7 @@ func (rc *RollupCompression) computeBatch(
 	SequencerNo *big.Int,
 	Coinbase gethcommon.Address,
 	BaseFee *big.Int,
+	Extras common.BatchExtras,
 ) (*ComputedBatch, error) {
 	return rc.batchExecutor.ComputeBatch(&BatchExecutionContext{
 		BlockPtr:     BlockPtr,
@@ -549,6 +945,7 @@ func (rc *RollupCompression) computeBatch(
 		ChainConfig:  rc.chainConfig,
 		SequencerNo:  SequencerNo,
 		BaseFee:      big.NewInt(0).Set(BaseFee),
+		Extras:       Extras,
 	}, false)
 }
 
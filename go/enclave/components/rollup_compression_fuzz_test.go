@@ -0,0 +1,253 @@
+package components
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/compression"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+	"github.com/ten-protocol/go-ten/go/enclave/crypto"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
+)
+
+const fuzzL1ChainLength = 10
+
+var errFuzzExecution = errors.New("batch execution is not part of the fuzz")
+
+// readOnlyBlockStorage only serves L1 blocks. Any other storage call panics through the nil embedded interface.
+type readOnlyBlockStorage struct {
+	storage.Storage
+	blocks map[gethcommon.Hash]*types.Block
+}
+
+func (s *readOnlyBlockStorage) FetchBlock(blockHash common.L1BlockHash) (*types.Block, error) {
+	b, found := s.blocks[blockHash]
+	if !found {
+		return nil, errutil.ErrNotFound
+	}
+	return b, nil
+}
+
+// recordingStorage pretends that the parent of any rollup is known and records the batches written by the rollup
+// processing, so the fuzzers can check what a malformed rollup would have written to the database.
+type recordingStorage struct {
+	*readOnlyBlockStorage
+	stored []*core.Batch
+}
+
+func (s *recordingStorage) FetchBatch(common.L2BatchHash) (*core.Batch, error) {
+	return &core.Batch{Header: &common.BatchHeader{}}, nil
+}
+
+func (s *recordingStorage) FetchBatchBySeqNo(uint64) (*core.Batch, error) {
+	return nil, errutil.ErrNotFound
+}
+
+func (s *recordingStorage) StoreBatch(batch *core.Batch) error {
+	s.stored = append(s.stored, batch)
+	return nil
+}
+
+func (s *recordingStorage) StoreExecutedBatch(*core.Batch, []*types.Receipt) error {
+	return nil
+}
+
+// failingBatchExecutor stops the processing of a decoded rollup at the first batch that would have to be executed
+type failingBatchExecutor struct {
+	BatchExecutor
+}
+
+func (e *failingBatchExecutor) ComputeBatch(*BatchExecutionContext, bool) (*ComputedBatch, error) {
+	return nil, errFuzzExecution
+}
+
+func (e *failingBatchExecutor) CreateGenesisState(common.L1BlockHash, uint64, gethcommon.Address, *big.Int) (*core.Batch, *types.Transaction, error) {
+	return nil, nil, errFuzzExecution
+}
+
+func newFuzzRollupCompression() (*RollupCompression, *recordingStorage, *types.Block) {
+	s := &recordingStorage{readOnlyBlockStorage: &readOnlyBlockStorage{blocks: map[gethcommon.Hash]*types.Block{}}}
+	var head *types.Block
+	parent := gethcommon.Hash{}
+	for i := int64(0); i < fuzzL1ChainLength; i++ {
+		head = types.NewBlockWithHeader(&types.Header{Number: big.NewInt(i), ParentHash: parent})
+		s.blocks[head.Hash()] = head
+		parent = head.Hash()
+	}
+	logger := gethlog.New()
+	rc := NewRollupCompression(nil, &failingBatchExecutor{}, crypto.NewDataEncryptionService(logger), compression.NewBrotliDataCompressionService(), s, nil, false, compression.DefaultLimits, limitsFromGenesis, logger)
+	return rc, s, head
+}
+
+func gobBigInt(t *testing.T, v int64) []byte {
+	b, err := big.NewInt(v).GobEncode()
+	require.NoError(t, err)
+	return b
+}
+
+// FuzzDecryptDecompressAndDeserialise feeds mutated blobs to each layer of the rollup decoding: as raw ciphertext,
+// as the compressed payload and as the serialised payload.
+func FuzzDecryptDecompressAndDeserialise(f *testing.F) {
+	rc, _, _ := newFuzzRollupCompression()
+
+	validHeader, err := rlp.EncodeToBytes(&common.CalldataRollupHeader{
+		FirstBatchSequence:    big.NewInt(1),
+		FirstCanonBatchHeight: big.NewInt(1),
+		BaseFee:               big.NewInt(1),
+		BatchTimeDeltas:       [][]byte{{}},
+		L1HeightDeltas:        [][]byte{{}},
+	})
+	require.NoError(f, err)
+	f.Add(validHeader)
+	f.Add([]byte{})
+	f.Add([]byte{0xc0})
+	f.Add(make([]byte, crypto.NonceLength-1))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		compressed, err := rc.dataCompressionService.CompressRollup(data)
+		require.NoError(t, err)
+		encryptedSerialised, err := rc.dataEncryptionService.Encrypt(compressed)
+		require.NoError(t, err)
+		encryptedCompressed, err := rc.dataEncryptionService.Encrypt(data)
+		require.NoError(t, err)
+
+		for _, blob := range [][]byte{data, encryptedCompressed, encryptedSerialised} {
+			header := new(common.CalldataRollupHeader)
+			_ = rc.decryptDecompressAndDeserialise(blob, header, compression.DefaultLimits)
+			transactions := make([][]*common.L2Tx, 0)
+			_ = rc.decryptDecompressAndDeserialise(blob, &transactions, compression.DefaultLimits)
+			if payloads, err := rc.openBatchPayloads(blob, compression.DefaultLimits); err == nil {
+				for err == nil {
+					_, err = payloads.next()
+				}
+			}
+		}
+	})
+}
+
+// FuzzCreateIncompleteBatches mutates the header fields of a rollup and checks that inconsistent headers are
+// rejected with an error and that accepted ones describe the expected sequence of batches.
+func FuzzCreateIncompleteBatches(f *testing.F) {
+	rc, _, head := newFuzzRollupCompression()
+
+	f.Add(int64(5), int64(5), uint64(1000), int64(1), int64(0), []byte(nil), uint8(3), uint8(3), uint8(3))
+	f.Add(int64(5), int64(5), uint64(1000), int64(-2000), int64(-5), []byte{0x01}, uint8(2), uint8(1), uint8(2))
+	f.Add(int64(-1), int64(0), uint64(0), int64(0), int64(20), []byte{0xff, 0x00}, uint8(1), uint8(1), uint8(1))
+
+	f.Fuzz(func(t *testing.T, firstSeq, firstHeight int64, startTime uint64, timeDelta, l1Delta int64, reorg []byte, nrBatches, nrTimeDeltas, nrL1Deltas uint8) {
+		timeDeltas := make([][]byte, nrTimeDeltas)
+		for i := range timeDeltas {
+			timeDeltas[i] = gobBigInt(t, timeDelta)
+		}
+		l1Deltas := make([][]byte, nrL1Deltas)
+		for i := range l1Deltas {
+			// the first L1 delta is the absolute height, keep it on the chain most of the time
+			if i == 0 {
+				l1Deltas[i] = gobBigInt(t, l1Delta%fuzzL1ChainLength)
+				continue
+			}
+			l1Deltas[i] = gobBigInt(t, l1Delta)
+		}
+		var reorgs [][]byte
+		if len(reorg) > 0 && nrBatches > 0 {
+			reorgs = make([][]byte, nrBatches)
+			reorgs[0] = reorg
+		}
+
+		header := &common.CalldataRollupHeader{
+			FirstBatchSequence:    big.NewInt(firstSeq),
+			FirstCanonBatchHeight: big.NewInt(firstHeight),
+			BaseFee:               big.NewInt(1),
+			StartTime:             startTime,
+			BatchTimeDeltas:       timeDeltas,
+			L1HeightDeltas:        l1Deltas,
+			ReOrgs:                reorgs,
+		}
+		batches, err := rc.createIncompleteBatches(header, head.Hash())
+		if err != nil {
+			return
+		}
+		require.Len(t, batches, int(nrTimeDeltas))
+		require.Equal(t, nrTimeDeltas, nrL1Deltas)
+		for i, b := range batches {
+			require.Equal(t, firstSeq+int64(i), b.seqNo.Int64())
+			require.LessOrEqual(t, b.height.Int64(), firstHeight+int64(i))
+		}
+	})
+}
+
+// FuzzProcessExtRollup builds encrypted rollups from mutated header fields, including sequence numbers, heights and
+// deltas outside the int64 range, and checks that the rollups rejected while decoding write nothing to the storage,
+// and that the batches written for the accepted ones sit in the range described by the header.
+func FuzzProcessExtRollup(f *testing.F) {
+	f.Add([]byte{5}, []byte{5}, uint64(1000), []byte{1}, false, int64(0), int64(-1), uint8(3))
+	f.Add([]byte{5}, []byte{5}, uint64(1000), []byte{1}, false, int64(0), int64(5), uint8(2))
+	f.Add([]byte{5}, []byte{5}, uint64(1000), []byte{1}, false, int64(0), int64(6), uint8(2))
+	f.Add([]byte{0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, []byte{1}, uint64(1<<63), []byte{0xff}, true, int64(1), int64(-1), uint8(4))
+	f.Add([]byte{1}, []byte{1}, uint64(1<<62), []byte{0x7f, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff}, false, int64(0), int64(1), uint8(3))
+
+	f.Fuzz(func(t *testing.T, firstSeq, firstHeight []byte, startTime uint64, timeDelta []byte, negativeTime bool, l1Delta int64, reorgSeq int64, nrBatches uint8) {
+		rc, s, head := newFuzzRollupCompression()
+		nrBatches %= 8
+
+		delta := new(big.Int).SetBytes(timeDelta)
+		if negativeTime {
+			delta.Neg(delta)
+		}
+		encDelta, err := delta.GobEncode()
+		require.NoError(t, err)
+		timeDeltas := make([][]byte, nrBatches)
+		l1Deltas := make([][]byte, nrBatches)
+		for i := range timeDeltas {
+			timeDeltas[i] = encDelta
+			if i == 0 {
+				l1Deltas[i] = gobBigInt(t, fuzzL1ChainLength-1)
+				continue
+			}
+			l1Deltas[i] = gobBigInt(t, l1Delta)
+		}
+		// the first batch of the rollup was reorged, and the rollup carries its full header
+		var reorgs [][]byte
+		if reorgSeq >= 0 && nrBatches > 0 {
+			reorgs = make([][]byte, nrBatches)
+			reorgs[0], err = rlp.EncodeToBytes(&common.BatchHeader{SequencerOrderNo: big.NewInt(reorgSeq), Number: big.NewInt(0), BaseFee: big.NewInt(1)})
+			require.NoError(t, err)
+		}
+
+		header := &common.CalldataRollupHeader{
+			FirstBatchSequence:    new(big.Int).SetBytes(firstSeq),
+			FirstCanonBatchHeight: new(big.Int).SetBytes(firstHeight),
+			BaseFee:               big.NewInt(1),
+			StartTime:             startTime,
+			BatchTimeDeltas:       timeDeltas,
+			L1HeightDeltas:        l1Deltas,
+			ReOrgs:                reorgs,
+		}
+		encHeader, err := rc.serialiseCompressAndEncrypt(header, compression.DefaultLimits)
+		require.NoError(t, err)
+		encPayloads, err := rc.serialiseCompressAndEncrypt(make([][]*common.L2Tx, nrBatches), compression.DefaultLimits)
+		require.NoError(t, err)
+
+		_, err = rc.ProcessExtRollup(&common.ExtRollup{
+			Header:               &common.RollupHeader{CompressionL1Head: head.Hash()},
+			BatchPayloads:        encPayloads,
+			CalldataRollupHeader: encHeader,
+		})
+		if err != nil && !errors.Is(err, errFuzzExecution) {
+			require.Empty(t, s.stored)
+			return
+		}
+		for _, b := range s.stored {
+			require.True(t, b.Header.SequencerOrderNo.Cmp(header.FirstBatchSequence) >= 0)
+			require.True(t, b.Header.SequencerOrderNo.Cmp(new(big.Int).Add(header.FirstBatchSequence, big.NewInt(int64(nrBatches)))) < 0)
+		}
+	})
+}
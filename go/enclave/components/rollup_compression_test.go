@@ -0,0 +1,694 @@
+package components
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/contracts/generated/MessageBus"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/compression"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+	"github.com/ten-protocol/go-ten/go/enclave/crypto"
+	"github.com/ten-protocol/go-ten/go/enclave/gas"
+)
+
+const (
+	propertyTestRuns  = 200
+	testBatchGasLimit = 30_000_000
+	// low enough for the random transactions to push the gas limit up
+	testMinBatchGasLimit = 200_000
+	maxStepsPerRun       = 30
+	testChainIDForTxs    = 443
+	testL1ChainLength    = 200
+	testStartTimestamp   = 1_700_000_000
+)
+
+var (
+	testCoinbase = gethcommon.HexToAddress("0xd6C9230053f45F873Cb66D8A02439380a37A4fbF")
+	testBaseFee  = big.NewInt(1_000_000_000)
+	// the decompression limits apply to the rollups compressed on top of any L1 block
+	limitsFromGenesis = new(uint64)
+)
+
+// inMemoryBatchStorage keeps the batches of a single node in memory
+type inMemoryBatchStorage struct {
+	*readOnlyBlockStorage
+	batches  map[gethcommon.Hash]*core.Batch
+	bySeqNo  map[uint64]*core.Batch
+	reorgSeq map[uint64]bool
+}
+
+func newInMemoryBatchStorage(blocks map[gethcommon.Hash]*types.Block) *inMemoryBatchStorage {
+	return &inMemoryBatchStorage{
+		readOnlyBlockStorage: &readOnlyBlockStorage{blocks: blocks},
+		batches:              map[gethcommon.Hash]*core.Batch{},
+		bySeqNo:              map[uint64]*core.Batch{},
+		reorgSeq:             map[uint64]bool{},
+	}
+}
+
+func (s *inMemoryBatchStorage) FetchBatch(hash common.L2BatchHash) (*core.Batch, error) {
+	b, found := s.batches[hash]
+	if !found {
+		return nil, errutil.ErrNotFound
+	}
+	return b, nil
+}
+
+func (s *inMemoryBatchStorage) FetchBatchBySeqNo(seqNum uint64) (*core.Batch, error) {
+	b, found := s.bySeqNo[seqNum]
+	if !found {
+		return nil, errutil.ErrNotFound
+	}
+	return b, nil
+}
+
+func (s *inMemoryBatchStorage) FetchNonCanonicalBatchesBetween(startSeq uint64, endSeq uint64) ([]*core.Batch, error) {
+	var reorged []*core.Batch
+	for seq := startSeq; seq <= endSeq; seq++ {
+		if s.reorgSeq[seq] {
+			reorged = append(reorged, s.bySeqNo[seq])
+		}
+	}
+	return reorged, nil
+}
+
+func (s *inMemoryBatchStorage) StoreBatch(batch *core.Batch) error {
+	s.batches[batch.Hash()] = batch
+	s.bySeqNo[batch.SeqNo().Uint64()] = batch
+	return nil
+}
+
+func (s *inMemoryBatchStorage) StoreExecutedBatch(*core.Batch, []*types.Receipt) error {
+	return nil
+}
+
+// deterministicBatchExecutor recreates batches without executing transactions, so that the tests only depend on the
+// rollup compression logic
+type deterministicBatchExecutor struct {
+	BatchExecutor
+	storage *inMemoryBatchStorage
+}
+
+func (e *deterministicBatchExecutor) ComputeBatch(ctx *BatchExecutionContext, _ bool) (*ComputedBatch, error) {
+	parent, err := e.storage.FetchBatch(ctx.ParentPtr)
+	if err != nil {
+		return nil, err
+	}
+	block, err := e.storage.FetchBlock(ctx.BlockPtr)
+	if err != nil {
+		return nil, err
+	}
+	batch := newTestBatch(parent.Header, block, ctx.AtTime, ctx.SequencerNo, ctx.BaseFee, ctx.Creator, ctx.Transactions)
+	batch.Header.Extras = ctx.Extras
+	return &ComputedBatch{
+		Batch:  batch,
+		Commit: func(bool) (gethcommon.Hash, error) { return gethcommon.Hash{}, nil },
+	}, nil
+}
+
+func (e *deterministicBatchExecutor) CreateGenesisState(l1Proof common.L1BlockHash, time uint64, coinbase gethcommon.Address, baseFee *big.Int) (*core.Batch, *types.Transaction, error) {
+	return newTestGenesisBatch(l1Proof, time, coinbase, baseFee), nil, nil
+}
+
+type noopBatchRegistry struct {
+	BatchRegistry
+}
+
+func (r *noopBatchRegistry) OnBatchExecuted(*core.Batch, types.Receipts) {}
+
+func newTestGenesisBatch(l1Proof common.L1BlockHash, time uint64, coinbase gethcommon.Address, baseFee *big.Int) *core.Batch {
+	return &core.Batch{
+		Header: &common.BatchHeader{
+			L1Proof:          l1Proof,
+			TxHash:           types.EmptyRootHash,
+			Number:           big.NewInt(int64(common.L2GenesisHeight)),
+			SequencerOrderNo: big.NewInt(int64(common.L2GenesisSeqNo)),
+			Time:             time,
+			Coinbase:         coinbase,
+			BaseFee:          baseFee,
+			GasLimit:         testBatchGasLimit,
+		},
+		Transactions: []*common.L2Tx{},
+	}
+}
+
+func newTestBatch(parent *common.BatchHeader, block *types.Block, time uint64, seqNo *big.Int, baseFee *big.Int, coinbase gethcommon.Address, txs []*common.L2Tx) *core.Batch {
+	batch := core.DeterministicEmptyBatch(parent, block, time, seqNo, baseFee, coinbase)
+	batch.Header.TxHash = types.EmptyRootHash
+	if len(txs) > 0 {
+		batch.Header.TxHash = types.DeriveSha(types.Transactions(txs), trie.NewStackTrie(nil))
+	}
+	// the gas limit follows the demand, so it changes within the rollups
+	batch.Header.GasLimit = gas.NextBatchGasLimit(parent.GasLimit, parent.GasUsed, testMinBatchGasLimit, testBatchGasLimit)
+	for _, tx := range txs {
+		batch.Header.GasUsed += tx.Gas()
+	}
+	batch.Transactions = txs
+	return batch
+}
+
+// randomBatchSequence builds an L1 chain with forks and a random sequence of batches on top of it, with empty batches,
+// time jumps, gaps and backwards steps in the L1 proofs, and reorged batches. The batches are grouped in rollups.
+type randomBatchSequence struct {
+	l1Blocks  map[gethcommon.Hash]*types.Block
+	canonical []*types.Block
+	batches   []*core.Batch
+	rollups   [][]*core.Batch
+	reorgSeq  map[uint64]bool
+}
+
+func newRandomBatchSequence(t *testing.T, rnd *rand.Rand) *randomBatchSequence {
+	seq := &randomBatchSequence{l1Blocks: map[gethcommon.Hash]*types.Block{}, reorgSeq: map[uint64]bool{}}
+
+	parent := gethcommon.Hash{}
+	for i := 0; i < testL1ChainLength; i++ {
+		block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(int64(i)), ParentHash: parent})
+		seq.l1Blocks[block.Hash()] = block
+		seq.canonical = append(seq.canonical, block)
+		parent = block.Hash()
+	}
+	forkBlock := func(height int) *types.Block {
+		block := types.NewBlockWithHeader(&types.Header{
+			Number:     big.NewInt(int64(height)),
+			ParentHash: seq.canonical[height].ParentHash(),
+			Extra:      []byte(fmt.Sprintf("fork-%d", rnd.Int())),
+		})
+		seq.l1Blocks[block.Hash()] = block
+		return block
+	}
+
+	key, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	nonce := uint64(0)
+
+	l1Height := rnd.Intn(testL1ChainLength / 4)
+	time := uint64(testStartTimestamp + rnd.Intn(1000))
+	genesis := newTestGenesisBatch(seq.canonical[l1Height].Hash(), time, testCoinbase, testBaseFee)
+	seq.batches = append(seq.batches, genesis)
+	canonParent := genesis.Header
+
+	coinbase := testCoinbase
+	nextBatch := func(block *types.Block) *core.Batch {
+		time = nextTime(rnd, time)
+		txs := randomTxs(t, rnd, key, &nonce)
+		seqNo := big.NewInt(int64(common.L2GenesisSeqNo) + int64(len(seq.batches)))
+		batch := newTestBatch(canonParent, block, time, seqNo, testBaseFee, coinbase, txs)
+		// a few batches carry extras
+		if rnd.Intn(8) == 0 {
+			value := make([]byte, rnd.Intn(32)+1)
+			rnd.Read(value)
+			batch.Header.Extras, err = batch.Header.Extras.With(common.RandomnessExtraKey, value)
+			require.NoError(t, err)
+		}
+		seq.batches = append(seq.batches, batch)
+		return batch
+	}
+
+	rollupStart := 0
+	steps := rnd.Intn(maxStepsPerRun)
+	for i := 0; i < steps; i++ {
+		l1Height = nextL1Height(rnd, l1Height)
+
+		// the coinbase changes from time to time, e.g. after a rotation of the sequencer key
+		if rnd.Intn(10) == 0 {
+			coinbase = gethcommon.BigToAddress(big.NewInt(rnd.Int63()))
+		}
+
+		// a batch created on a fork of the L1, replaced by a canonical batch at the same height
+		if rnd.Intn(5) == 0 {
+			reorged := nextBatch(forkBlock(l1Height))
+			seq.reorgSeq[reorged.SeqNo().Uint64()] = true
+		}
+		canonParent = nextBatch(seq.canonical[l1Height]).Header
+
+		if rnd.Intn(4) == 0 {
+			seq.rollups = append(seq.rollups, seq.batches[rollupStart:])
+			rollupStart = len(seq.batches)
+		}
+	}
+	if rollupStart < len(seq.batches) {
+		seq.rollups = append(seq.rollups, seq.batches[rollupStart:])
+	}
+	return seq
+}
+
+// mostly 1 second apart, with the occasional jump forwards and step backwards
+func nextTime(rnd *rand.Rand, time uint64) uint64 {
+	switch rnd.Intn(10) {
+	case 0:
+		return time + uint64(rnd.Intn(100_000))
+	case 1:
+		return time - uint64(rnd.Intn(10))
+	default:
+		return time + 1
+	}
+}
+
+// mostly the same or the next L1 block, with the occasional gap and step backwards
+func nextL1Height(rnd *rand.Rand, height int) int {
+	switch rnd.Intn(10) {
+	case 0:
+		height += rnd.Intn(10)
+	case 1:
+		height -= rnd.Intn(3)
+	default:
+		height += rnd.Intn(2)
+	}
+	if height < 0 {
+		return 0
+	}
+	if height >= testL1ChainLength {
+		return testL1ChainLength - 1
+	}
+	return height
+}
+
+func randomTxs(t *testing.T, rnd *rand.Rand, key *ecdsa.PrivateKey, nonce *uint64) []*common.L2Tx {
+	// half of the batches are empty
+	if rnd.Intn(2) == 0 {
+		return []*common.L2Tx{}
+	}
+	signer := types.LatestSignerForChainID(big.NewInt(testChainIDForTxs))
+	txs := make([]*common.L2Tx, rnd.Intn(5)+1)
+	for i := range txs {
+		to := gethcommon.BigToAddress(big.NewInt(rnd.Int63()))
+		data := make([]byte, rnd.Intn(64))
+		rnd.Read(data)
+		tx, err := types.SignNewTx(key, signer, &types.LegacyTx{
+			Nonce:    *nonce,
+			GasPrice: testBaseFee,
+			Gas:      21_000 + uint64(rnd.Intn(100_000)),
+			To:       &to,
+			Value:    big.NewInt(rnd.Int63()),
+			Data:     data,
+		})
+		require.NoError(t, err)
+		txs[i] = tx
+		*nonce++
+	}
+	return txs
+}
+
+// TestRollupCompressionRoundTrip checks the property that any sequence of batches published in rollups by the
+// sequencer is recreated with byte-identical headers by a validator that processes those rollups.
+func TestRollupCompressionRoundTrip(t *testing.T) {
+	for run := int64(0); run < propertyTestRuns; run++ {
+		seed := run
+		t.Run(fmt.Sprintf("seed-%d", seed), func(t *testing.T) {
+			seq := newRandomBatchSequence(t, rand.New(rand.NewSource(seed))) //nolint:gosec
+
+			sequencerStorage := newInMemoryBatchStorage(seq.l1Blocks)
+			for _, b := range seq.batches {
+				require.NoError(t, sequencerStorage.StoreBatch(b))
+			}
+			sequencerStorage.reorgSeq = seq.reorgSeq
+			validatorStorage := newInMemoryBatchStorage(seq.l1Blocks)
+
+			logger := gethlog.New()
+			encryption := crypto.NewDataEncryptionService(logger)
+			compressionService := compression.NewBrotliDataCompressionService()
+			// half of the runs publish the batch hashes, which the validator checks
+			sequencer := NewRollupCompression(&noopBatchRegistry{}, nil, encryption, compressionService, sequencerStorage, nil, seed%2 == 0, compression.DefaultLimits, limitsFromGenesis, logger)
+			validator := NewRollupCompression(&noopBatchRegistry{}, &deterministicBatchExecutor{storage: validatorStorage}, encryption, compressionService, validatorStorage, nil, false, compression.DefaultLimits, limitsFromGenesis, logger)
+
+			l1Head := seq.canonical[len(seq.canonical)-1]
+			for _, batches := range seq.rollups {
+				rollup := &core.Rollup{
+					Header:  &common.RollupHeader{CompressionL1Head: l1Head.Hash()},
+					Batches: batches,
+					Blocks:  seq.l1Blocks,
+				}
+				extRollup, err := sequencer.CreateExtRollup(rollup)
+				require.NoError(t, err)
+				_, err = validator.ProcessExtRollup(extRollup)
+				require.NoError(t, err)
+			}
+
+			require.Len(t, validatorStorage.bySeqNo, len(seq.batches))
+			for _, expected := range seq.batches {
+				recreated, err := validatorStorage.FetchBatchBySeqNo(expected.SeqNo().Uint64())
+				require.NoError(t, err)
+				expectedHeader, err := rlp.EncodeToBytes(expected.Header)
+				require.NoError(t, err)
+				recreatedHeader, err := rlp.EncodeToBytes(recreated.Header)
+				require.NoError(t, err)
+				require.Equal(t, expectedHeader, recreatedHeader, "batch %d", expected.SeqNo())
+				require.Equal(t, expected.Hash(), recreated.Hash())
+				require.Len(t, recreated.Transactions, len(expected.Transactions))
+			}
+		})
+	}
+}
+
+func TestRollupIsSplitAtTheDecompressionLimit(t *testing.T) {
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(10)})
+	blocks := map[gethcommon.Hash]*types.Block{block.Hash(): block}
+	sequencerStorage := newInMemoryBatchStorage(blocks)
+
+	// the transactions compress far better than the expansion accepted by the validators
+	key, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	signer := types.LatestSignerForChainID(big.NewInt(testChainIDForTxs))
+	batches := []*core.Batch{newTestGenesisBatch(block.Hash(), testStartTimestamp, testCoinbase, testBaseFee)}
+	for i := uint64(0); i < 8; i++ {
+		tx, err := types.SignNewTx(key, signer, &types.LegacyTx{Nonce: i, GasPrice: testBaseFee, Gas: 1_000_000, Data: make([]byte, 150_000)})
+		require.NoError(t, err)
+		parent := batches[len(batches)-1]
+		seqNo := big.NewInt(parent.SeqNo().Int64() + 1)
+		batches = append(batches, newTestBatch(parent.Header, block, parent.Header.Time+1, seqNo, testBaseFee, testCoinbase, []*common.L2Tx{tx}))
+	}
+	for _, b := range batches {
+		require.NoError(t, sequencerStorage.StoreBatch(b))
+	}
+
+	logger := gethlog.New()
+	encryption := crypto.NewDataEncryptionService(logger)
+	compressionService := compression.NewBrotliDataCompressionService()
+	// the limits are lower than the defaults, and apply from the L1 block of the rollups
+	limits := compression.Limits{MaxExpansion: 50, MinSize: 512 * 1024, MaxSize: 4 * 1024 * 1024}
+	fork := block.NumberU64()
+	newValidator := func(fork uint64) (*RollupCompression, *inMemoryBatchStorage) {
+		validatorStorage := newInMemoryBatchStorage(blocks)
+		return NewRollupCompression(&noopBatchRegistry{}, &deterministicBatchExecutor{storage: validatorStorage}, encryption, compressionService, validatorStorage, nil, false, limits, &fork, logger), validatorStorage
+	}
+
+	// the rollup of all the batches exceeds the limits
+	encoded, err := encodeBatches(newInternalRollup(testCoinbase, block.Hash(), batches, []*types.Block{block}))
+	require.NoError(t, err)
+	payloads := make([]rlp.RawValue, len(encoded))
+	for i, b := range encoded {
+		payloads[i] = b.transactions
+	}
+	serialised, err := rlp.EncodeToBytes(payloads)
+	require.NoError(t, err)
+	compressed, err := compressionService.CompressRollup(serialised)
+	require.NoError(t, err)
+	require.Greater(t, int64(len(serialised)), limits.SizeLimit(len(compressed)))
+
+	// a sequencer ignoring the limits publishes it, and the validators reject it from the fork
+	unbounded := NewRollupCompression(&noopBatchRegistry{}, nil, encryption, compressionService, sequencerStorage, nil, false, limits, nil, logger)
+	extRollup, err := unbounded.CreateExtRollup(newInternalRollup(testCoinbase, block.Hash(), batches, []*types.Block{block}))
+	require.NoError(t, err)
+	require.Equal(t, batches[len(batches)-1].SeqNo().Uint64(), extRollup.Header.LastBatchSeqNo)
+	validator, _ := newValidator(fork)
+	// the limit stops the decoding of the payload before its batches are decompressed
+	_, err = validator.ProcessExtRollup(extRollup)
+	require.ErrorContains(t, err, "invalid rollup payload")
+
+	// the rollups compressed on top of the blocks before the fork stay valid
+	validator, validatorStorage := newValidator(fork + 1)
+	_, err = validator.ProcessExtRollup(extRollup)
+	require.NoError(t, err)
+	require.Len(t, validatorStorage.bySeqNo, len(batches))
+
+	// from the fork, the first rollup keeps the batches that fit, and the next rollups publish the rest
+	sequencer := NewRollupCompression(&noopBatchRegistry{}, nil, encryption, compressionService, sequencerStorage, nil, false, limits, &fork, logger)
+	validator, validatorStorage = newValidator(fork)
+	rollup := newInternalRollup(testCoinbase, block.Hash(), batches, []*types.Block{block})
+	extRollup, err = sequencer.CreateExtRollup(rollup)
+	require.NoError(t, err)
+	require.Len(t, rollup.Batches, 4)
+	require.Equal(t, batches[3].SeqNo().Uint64(), extRollup.Header.LastBatchSeqNo)
+	_, err = validator.ProcessExtRollup(extRollup)
+	require.NoError(t, err)
+
+	for next := 4; next < len(batches); next += len(rollup.Batches) {
+		rollup = newInternalRollup(testCoinbase, block.Hash(), batches[next:], []*types.Block{block})
+		extRollup, err = sequencer.CreateExtRollup(rollup)
+		require.NoError(t, err)
+		require.Less(t, len(rollup.Batches), len(batches)-1)
+		_, err = validator.ProcessExtRollup(extRollup)
+		require.NoError(t, err)
+	}
+	require.Len(t, validatorStorage.bySeqNo, len(batches))
+}
+
+func TestTruncateRollup(t *testing.T) {
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0)})
+	batches := []*core.Batch{newTestGenesisBatch(block.Hash(), testStartTimestamp, testCoinbase, testBaseFee)}
+	for i := uint64(1); i < 4; i++ {
+		parent := batches[len(batches)-1]
+		batch := newTestBatch(parent.Header, block, parent.Header.Time+1, big.NewInt(int64(i+common.L2GenesisSeqNo)), testBaseFee, testCoinbase, nil)
+		batch.Header.CrossChainMessages = []MessageBus.StructsCrossChainMessage{{Sequence: i}}
+		batches = append(batches, batch)
+	}
+	rollup := newInternalRollup(testCoinbase, block.Hash(), batches, []*types.Block{block})
+	require.Len(t, rollup.Header.CrossChainMessages, 3)
+
+	// the rollup keeps the messages of the batches it keeps
+	truncateRollup(rollup, 2)
+	require.Equal(t, batches[:2], rollup.Batches)
+	require.Equal(t, batches[1].SeqNo().Uint64(), rollup.Header.LastBatchSeqNo)
+	require.Equal(t, []MessageBus.StructsCrossChainMessage{{Sequence: 1}}, rollup.Header.CrossChainMessages)
+}
+
+// divergentBatchExecutor recreates one of the batches with another state root
+type divergentBatchExecutor struct {
+	*deterministicBatchExecutor
+	seqNo uint64
+}
+
+func (e *divergentBatchExecutor) ComputeBatch(ctx *BatchExecutionContext, failForEmptyBatch bool) (*ComputedBatch, error) {
+	computed, err := e.deterministicBatchExecutor.ComputeBatch(ctx, failForEmptyBatch)
+	if err == nil && ctx.SequencerNo.Uint64() == e.seqNo {
+		computed.Batch.Header.Root = gethcommon.HexToHash("0xbad")
+	}
+	return computed, err
+}
+
+// TestRollupIntegrityCheck checks that a validator fails a rollup published in verification mode at the first batch it
+// recreates with another hash, without storing it, and that the batch hashes have to match their root
+func TestRollupIntegrityCheck(t *testing.T) {
+	seq := newRandomBatchSequence(t, rand.New(rand.NewSource(3))) //nolint:gosec
+	sequencerStorage := newInMemoryBatchStorage(seq.l1Blocks)
+	for _, b := range seq.batches {
+		require.NoError(t, sequencerStorage.StoreBatch(b))
+	}
+	sequencerStorage.reorgSeq = seq.reorgSeq
+
+	// the last canonical batch is recreated by executing it
+	divergentIdx := len(seq.batches) - 1
+	for seq.reorgSeq[seq.batches[divergentIdx].SeqNo().Uint64()] {
+		divergentIdx--
+	}
+	require.Greater(t, divergentIdx, 0)
+	divergent := seq.batches[divergentIdx]
+
+	logger := gethlog.New()
+	encryption := crypto.NewDataEncryptionService(logger)
+	compressionService := compression.NewBrotliDataCompressionService()
+	sequencer := NewRollupCompression(&noopBatchRegistry{}, nil, encryption, compressionService, sequencerStorage, nil, true, compression.DefaultLimits, limitsFromGenesis, logger)
+	l1Head := seq.canonical[len(seq.canonical)-1]
+	rollup := &core.Rollup{
+		Header:  &common.RollupHeader{CompressionL1Head: l1Head.Hash()},
+		Batches: seq.batches,
+		Blocks:  seq.l1Blocks,
+	}
+	encoded, err := encodeBatches(rollup)
+	require.NoError(t, err)
+	header, err := sequencer.createRollupHeader(encoded)
+	require.NoError(t, err)
+	require.Len(t, header.BatchHashes, len(seq.batches))
+	require.Equal(t, divergent.Hash(), header.BatchHashes[divergentIdx])
+	extRollup, err := sequencer.CreateExtRollup(rollup)
+	require.NoError(t, err)
+
+	validatorStorage := newInMemoryBatchStorage(seq.l1Blocks)
+	executor := &divergentBatchExecutor{deterministicBatchExecutor: &deterministicBatchExecutor{storage: validatorStorage}, seqNo: divergent.SeqNo().Uint64()}
+	validator := NewRollupCompression(&noopBatchRegistry{}, executor, encryption, compressionService, validatorStorage, nil, false, compression.DefaultLimits, limitsFromGenesis, logger)
+	_, err = validator.ProcessExtRollup(extRollup)
+
+	var integrityErr *RollupIntegrityError
+	require.ErrorAs(t, err, &integrityErr)
+	require.Equal(t, divergentIdx, integrityErr.BatchIdx)
+	require.Equal(t, divergent.SeqNo(), integrityErr.SeqNo)
+	require.Equal(t, divergent.Hash(), integrityErr.Expected)
+	require.NotEqual(t, divergent.Hash(), integrityErr.Computed)
+	// the batches before the divergent one are stored
+	require.Len(t, validatorStorage.bySeqNo, divergentIdx)
+
+	// the hashes have to cover all the batches, and match their root
+	header.BatchHashes[divergentIdx] = gethcommon.HexToHash("0xbad")
+	require.ErrorContains(t, validateCalldataRollupHeader(header, len(seq.batches)), "root")
+	header.BatchHashes = header.BatchHashes[1:]
+	require.ErrorContains(t, validateCalldataRollupHeader(header, len(seq.batches)), "batch hashes")
+}
+
+func TestCoinbaseChanges(t *testing.T) {
+	rc, _, head := newFuzzRollupCompression()
+	coinbase1 := gethcommon.HexToAddress("0x01")
+	coinbase2 := gethcommon.HexToAddress("0x02")
+	reorged, err := rlp.EncodeToBytes(&common.BatchHeader{SequencerOrderNo: big.NewInt(6)})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		changes   []common.CoinbaseChange
+		reorgs    [][]byte
+		coinbases []gethcommon.Address
+		err       string
+	}{
+		{
+			name:      "no change",
+			coinbases: []gethcommon.Address{testCoinbase, testCoinbase, testCoinbase},
+		},
+		{
+			name:      "changes",
+			changes:   []common.CoinbaseChange{{BatchIdx: 1, Coinbase: coinbase1}, {BatchIdx: 2, Coinbase: coinbase2}},
+			coinbases: []gethcommon.Address{testCoinbase, coinbase1, coinbase2},
+		},
+		{
+			name:      "change after a reorged batch",
+			changes:   []common.CoinbaseChange{{BatchIdx: 2, Coinbase: coinbase1}},
+			reorgs:    [][]byte{nil, reorged, nil},
+			coinbases: []gethcommon.Address{testCoinbase, testCoinbase, coinbase1},
+		},
+		{
+			name:    "change of the first batch",
+			changes: []common.CoinbaseChange{{BatchIdx: 0, Coinbase: coinbase1}},
+			err:     "out of order or out of range",
+		},
+		{
+			name:    "change out of range",
+			changes: []common.CoinbaseChange{{BatchIdx: 3, Coinbase: coinbase1}},
+			err:     "out of order or out of range",
+		},
+		{
+			name:    "changes out of order",
+			changes: []common.CoinbaseChange{{BatchIdx: 2, Coinbase: coinbase1}, {BatchIdx: 1, Coinbase: coinbase2}},
+			err:     "out of order or out of range",
+		},
+		{
+			name:    "change without effect",
+			changes: []common.CoinbaseChange{{BatchIdx: 1, Coinbase: testCoinbase}},
+			err:     "does not change it",
+		},
+		{
+			name:    "change of a reorged batch",
+			changes: []common.CoinbaseChange{{BatchIdx: 1, Coinbase: coinbase1}},
+			reorgs:  [][]byte{nil, reorged, nil},
+			err:     "reorged batch",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := &common.CalldataRollupHeader{
+				FirstBatchSequence:    big.NewInt(5),
+				FirstCanonBatchHeight: big.NewInt(5),
+				Coinbase:              testCoinbase,
+				BaseFee:               testBaseFee,
+				StartTime:             testStartTimestamp,
+				BatchTimeDeltas:       [][]byte{gobBigInt(t, 0), gobBigInt(t, 1), gobBigInt(t, 1)},
+				L1HeightDeltas:        [][]byte{gobBigInt(t, 1), gobBigInt(t, 0), gobBigInt(t, 0)},
+				ReOrgs:                tt.reorgs,
+				CoinbaseChanges:       tt.changes,
+			}
+			batches, err := rc.createIncompleteBatches(header, head.Hash())
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			for i, b := range batches {
+				require.Equal(t, tt.coinbases[i], b.coinbase, "batch %d", i)
+			}
+		})
+	}
+}
+
+func TestRollupBatchExtras(t *testing.T) {
+	rc, _, head := newFuzzRollupCompression()
+	extras := common.BatchExtras{{Key: common.DAHintsExtraKey, Value: []byte("hint")}}
+	reorged, err := rlp.EncodeToBytes(&common.BatchHeader{SequencerOrderNo: big.NewInt(6)})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name        string
+		batchExtras []common.RollupBatchExtras
+		reorgs      [][]byte
+		err         string
+	}{
+		{name: "extras", batchExtras: []common.RollupBatchExtras{{BatchIdx: 0, Extras: extras}, {BatchIdx: 2, Extras: extras}}},
+		{name: "out of range", batchExtras: []common.RollupBatchExtras{{BatchIdx: 3, Extras: extras}}, err: "out of order or out of range"},
+		{name: "out of order", batchExtras: []common.RollupBatchExtras{{BatchIdx: 2, Extras: extras}, {BatchIdx: 2, Extras: extras}}, err: "out of order or out of range"},
+		{name: "empty", batchExtras: []common.RollupBatchExtras{{BatchIdx: 1}}, err: "Empty extras"},
+		{name: "reorged batch", batchExtras: []common.RollupBatchExtras{{BatchIdx: 1, Extras: extras}}, reorgs: [][]byte{nil, reorged, nil}, err: "reorged batch"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := &common.CalldataRollupHeader{
+				FirstBatchSequence:    big.NewInt(5),
+				FirstCanonBatchHeight: big.NewInt(5),
+				Coinbase:              testCoinbase,
+				BaseFee:               testBaseFee,
+				StartTime:             testStartTimestamp,
+				BatchTimeDeltas:       [][]byte{gobBigInt(t, 0), gobBigInt(t, 1), gobBigInt(t, 1)},
+				L1HeightDeltas:        [][]byte{gobBigInt(t, 1), gobBigInt(t, 0), gobBigInt(t, 0)},
+				ReOrgs:                tt.reorgs,
+				BatchExtras:           tt.batchExtras,
+			}
+			batches, err := rc.createIncompleteBatches(header, head.Hash())
+			if tt.err != "" {
+				require.ErrorContains(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, extras, batches[0].extras)
+			require.Nil(t, batches[1].extras)
+			require.Equal(t, extras, batches[2].extras)
+		})
+	}
+}
+
+func TestBatchPayloadsAreStreamedOneBatchAtATime(t *testing.T) {
+	rc, _, _ := newFuzzRollupCompression()
+	signer := types.LatestSignerForChainID(big.NewInt(testChainIDForTxs))
+	key, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Gas: 21_000, GasPrice: big.NewInt(1)}), signer, key)
+	require.NoError(t, err)
+	transactionsPerBatch := [][]*common.L2Tx{{tx}, {}, {tx, tx}}
+
+	// readBatches reads the number of batches from the payload, and returns the error of its closing
+	readBatches := func(blob []byte, nrBatches int) error {
+		payloads, err := rc.openBatchPayloads(blob, compression.DefaultLimits)
+		require.NoError(t, err)
+		for i := 0; i < nrBatches; i++ {
+			transactions, err := payloads.next()
+			if err != nil {
+				return err
+			}
+			require.Len(t, transactions, len(transactionsPerBatch[i]))
+		}
+		return payloads.close()
+	}
+
+	blob, err := rc.serialiseCompressAndEncrypt(transactionsPerBatch, compression.DefaultLimits)
+	require.NoError(t, err)
+	require.NoError(t, readBatches(blob, 3))
+	require.ErrorContains(t, readBatches(blob, 2), "more batches than the header")
+
+	blob, err = rc.serialiseCompressAndEncrypt(transactionsPerBatch[:2], compression.DefaultLimits)
+	require.NoError(t, err)
+	require.ErrorContains(t, readBatches(blob, 3), "fewer batches than the header")
+
+	serialised, err := rlp.EncodeToBytes(transactionsPerBatch)
+	require.NoError(t, err)
+	compressed, err := rc.dataCompressionService.CompressRollup(append(serialised, 0x80))
+	require.NoError(t, err)
+	blob, err = rc.dataEncryptionService.Encrypt(compressed)
+	require.NoError(t, err)
+	require.ErrorContains(t, readBatches(blob, 3), "after the batches")
+}
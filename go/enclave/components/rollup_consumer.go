@@ -1,7 +1,11 @@
 package components
 
 import (
+	"errors"
 	"fmt"
+	"math/big"
+	"sync"
+	"time"
 
 	"github.com/ten-protocol/go-ten/go/enclave/core"
 
@@ -26,6 +30,67 @@ type rollupConsumerImpl struct {
 
 	storage      storage.Storage
 	sigValidator *SignatureValidator
+
+	// the maximum number of rollups verified concurrently, across the blocks being ingested
+	verificationWorkers int
+	workers             chan struct{}
+
+	// the rollups of the blocks ingested while catching up with the L1, being verified but not executed yet, in order.
+	// Not safe for concurrent use: the blocks are ingested under the main mutex of the enclave
+	deferredBlocks []*blockVerification
+	lastBlock      common.L1BlockHash
+
+	progressCallback func(*common.RollupSyncProgress)
+	statsCallback    func(*common.RollupCompressionStats)
+	callbackMutex    sync.RWMutex
+}
+
+// BlockRollupsError is the failure of the rollups of an L1 block. While catching up with the L1, the rollups of a block
+// are executed while the next blocks are ingested, so the error names the block that carried the rollups rather than the
+// block being ingested.
+type BlockRollupsError struct {
+	L1Block common.L1BlockHash
+	Err     error
+}
+
+func (e *BlockRollupsError) Error() string {
+	return fmt.Sprintf("could not process the rollups of block %s - %s", e.L1Block, e.Err)
+}
+
+func (e *BlockRollupsError) Unwrap() error {
+	return e.Err
+}
+
+// BlockRollupsErrors returns the failures of the rollups of each block joined in the error returned by the rollup
+// consumer
+func BlockRollupsErrors(err error) []*BlockRollupsError {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok { //nolint:errorlint
+		var blockErrs []*BlockRollupsError
+		for _, e := range joined.Unwrap() {
+			blockErrs = append(blockErrs, BlockRollupsErrors(e)...)
+		}
+		return blockErrs
+	}
+	var blockErr *BlockRollupsError
+	if errors.As(err, &blockErr) {
+		return []*BlockRollupsError{blockErr}
+	}
+	return nil
+}
+
+// rollupVerification is the outcome of the verification of a rollup. Both fields are nil if the rollup is skipped.
+type rollupVerification struct {
+	rollup *verifiedRollup
+	err    error
+}
+
+// blockVerification holds the verifications of the rollups of a block, in progress or complete
+type blockVerification struct {
+	l1Block       common.L1BlockHash
+	rollups       []*common.ExtRollup
+	verifications []chan rollupVerification
+	// closed once the rollups are executed, to abandon the verifications not started yet
+	stop chan struct{}
 }
 
 func NewRollupConsumer(
@@ -35,46 +100,144 @@ func NewRollupConsumer(
 	storage storage.Storage,
 	logger gethlog.Logger,
 	verifier *SignatureValidator,
+	verificationWorkers int,
 ) RollupConsumer {
+	if verificationWorkers < 1 {
+		verificationWorkers = 1
+	}
 	return &rollupConsumerImpl{
-		MgmtContractLib:   mgmtContractLib,
-		batchRegistry:     batchRegistry,
-		rollupCompression: rollupCompression,
-		logger:            logger,
-		storage:           storage,
-		sigValidator:      verifier,
+		MgmtContractLib:     mgmtContractLib,
+		batchRegistry:       batchRegistry,
+		rollupCompression:   rollupCompression,
+		logger:              logger,
+		storage:             storage,
+		sigValidator:        verifier,
+		verificationWorkers: verificationWorkers,
+		workers:             make(chan struct{}, verificationWorkers),
 	}
 }
 
-func (rc *rollupConsumerImpl) ProcessRollupsInBlock(b *common.BlockAndReceipts) error {
+func (rc *rollupConsumerImpl) ProcessRollupsInBlock(b *common.BlockAndReceipts, isLatest bool) error {
 	defer core.LogMethodDuration(rc.logger, measure.NewStopwatch(), "Rollup consumer processed block", log.BlockHashKey, b.Block.Hash())
 
-	rollups := rc.extractRollups(b)
-	if len(rollups) == 0 {
-		return nil
+	rc.lastBlock = b.Block.Hash()
+	var err error
+	if rollups := rc.extractRollups(b); len(rollups) > 0 {
+		rollups, err = rc.getSignedRollup(rollups)
+		if err == nil {
+			rc.deferredBlocks = append(rc.deferredBlocks, rc.verifyRollups(b.Block.Hash(), rollups))
+		} else {
+			err = &BlockRollupsError{L1Block: b.Block.Hash(), Err: err}
+		}
 	}
 
-	rollups, err := rc.getSignedRollup(rollups)
-	if err != nil {
-		return err
+	// while catching up with the L1, the execution of the rollups of up to verificationWorkers blocks is deferred, so
+	// that their verification overlaps with the verification of the rollups of the next blocks
+	maxDeferred := rc.verificationWorkers
+	if isLatest {
+		maxDeferred = 0
 	}
+	return errors.Join(err, rc.executeDeferredRollups(maxDeferred))
+}
 
-	for _, rollup := range rollups {
-		l1CompressionBlock, err := rc.storage.FetchBlock(rollup.Header.CompressionL1Head)
-		if err != nil {
-			rc.logger.Warn("Can't process rollup because the l1 block used for compression is not available", "block_hash", rollup.Header.CompressionL1Head, log.RollupHashKey, rollup.Hash(), log.ErrKey, err)
-			continue
+func (rc *rollupConsumerImpl) ExecuteDeferredRollups(nextParent *common.L1BlockHash) error {
+	if nextParent != nil && *nextParent == rc.lastBlock {
+		return nil
+	}
+	return rc.executeDeferredRollups(0)
+}
+
+// executes the rollups of the oldest deferred blocks, in order, until at most maxDeferred blocks are left. The blocks
+// are independent, so the failure of the rollups of a block does not prevent the execution of the next blocks. The
+// failures are returned as a BlockRollupsError for each block.
+func (rc *rollupConsumerImpl) executeDeferredRollups(maxDeferred int) error {
+	var errs []error
+	for len(rc.deferredBlocks) > maxDeferred {
+		block := rc.deferredBlocks[0]
+		rc.deferredBlocks = rc.deferredBlocks[1:]
+		if err := rc.executeRollups(block); err != nil {
+			errs = append(errs, &BlockRollupsError{L1Block: block.l1Block, Err: err})
 		}
-		canonicalBlockByHeight, err := rc.storage.FetchCanonicaBlockByHeight(l1CompressionBlock.Number())
-		if err != nil {
-			return err
+	}
+	return errors.Join(errs...)
+}
+
+func (rc *rollupConsumerImpl) SubscribeForProgress(callback func(*common.RollupSyncProgress)) {
+	rc.callbackMutex.Lock()
+	defer rc.callbackMutex.Unlock()
+	rc.progressCallback = callback
+}
+
+func (rc *rollupConsumerImpl) UnsubscribeFromProgress() {
+	rc.callbackMutex.Lock()
+	defer rc.callbackMutex.Unlock()
+	rc.progressCallback = nil
+}
+
+func (rc *rollupConsumerImpl) SubscribeForRollupStats(callback func(*common.RollupCompressionStats)) {
+	rc.callbackMutex.Lock()
+	defer rc.callbackMutex.Unlock()
+	rc.statsCallback = callback
+}
+
+func (rc *rollupConsumerImpl) UnsubscribeFromRollupStats() {
+	rc.callbackMutex.Lock()
+	defer rc.callbackMutex.Unlock()
+	rc.statsCallback = nil
+}
+
+func (rc *rollupConsumerImpl) reportProgress(progress *common.RollupSyncProgress) {
+	rc.callbackMutex.RLock()
+	defer rc.callbackMutex.RUnlock()
+	if rc.progressCallback != nil {
+		rc.progressCallback(progress)
+	}
+}
+
+func (rc *rollupConsumerImpl) reportStats(stats *common.RollupCompressionStats) {
+	rc.logger.Info("Rollup compression", log.RollupHashKey, stats.RollupHash, "batches", uint64(stats.Batches),
+		"raw_tx_bytes", uint64(stats.RawTxBytes), "payload_bytes", uint64(stats.PayloadBytes), "header_bytes", uint64(stats.HeaderBytes),
+		"reorg_exceptions", uint64(stats.ReorgExceptions), "ratio", fmt.Sprintf("%.3f", stats.CompressionRatio()))
+
+	rc.callbackMutex.RLock()
+	defer rc.callbackMutex.RUnlock()
+	if rc.statsCallback != nil {
+		rc.statsCallback(stats)
+	}
+}
+
+// processRollups stores the batches of the signed rollups and the rollups, in order
+func (rc *rollupConsumerImpl) processRollups(l1Block common.L1BlockHash, rollups []*common.ExtRollup) error {
+	return rc.executeRollups(rc.verifyRollups(l1Block, rollups))
+}
+
+// executeRollups executes the batches of the rollups of a block and stores them, in order, as soon as the verification of
+// each rollup completes
+func (rc *rollupConsumerImpl) executeRollups(block *blockVerification) error {
+	defer close(block.stop)
+	l1Block, rollups := block.l1Block, block.rollups
+	progress := newRollupSyncProgress(l1Block, len(rollups), time.Now())
+
+	for i, rollup := range rollups {
+		verification := <-block.verifications[i]
+		if verification.err != nil {
+			rc.logger.Error("Failed processing rollup", log.RollupHashKey, rollup.Hash(), log.ErrKey, verification.err)
+			// todo - issue challenge as a validator
+			return verification.err
 		}
-		if canonicalBlockByHeight.Hash() != l1CompressionBlock.Hash() {
-			rc.logger.Warn("Skipping rollup because it was compressed on top of a non-canonical rollup", "block_hash", rollup.Header.CompressionL1Head, log.RollupHashKey, rollup.Hash(), log.ErrKey, err)
+		if verification.rollup == nil {
 			continue
 		}
-		// read batch data from rollup, verify and store it
-		internalHeader, err := rc.rollupCompression.ProcessExtRollup(rollup)
+
+		batches := len(verification.rollup.incompleteBatches)
+		progress.onRollup(batches)
+		internalHeader, err := rc.rollupCompression.executeVerifiedRollup(verification.rollup, func(batchIdx int, seqNo *big.Int) {
+			if report := progress.onBatch(i, batchIdx, batches, seqNo, time.Now()); report != nil {
+				rc.logger.Info("Rollup sync progress", log.RollupHashKey, rollup.Hash(), "rollup", i+1, "rollups", len(rollups),
+					"batch", batchIdx+1, "batches", batches, log.BatchSeqNoKey, seqNo, "eta", time.Duration(report.ETA)*time.Second)
+				rc.reportProgress(report)
+			}
+		})
 		if err != nil {
 			rc.logger.Error("Failed processing rollup", log.RollupHashKey, rollup.Hash(), log.ErrKey, err)
 			// todo - issue challenge as a validator
@@ -84,11 +247,64 @@ func (rc *rollupConsumerImpl) ProcessRollupsInBlock(b *common.BlockAndReceipts)
 			rc.logger.Error("Failed storing rollup", log.RollupHashKey, rollup.Hash(), log.ErrKey, err)
 			return err
 		}
+		rc.reportStats(compressionStats(rollup, verification.rollup))
 	}
 
 	return nil
 }
 
+// verifyRollups starts the verification of the rollups of a block. The verification of a rollup does not depend on the
+// state, so the rollups of all the blocks being ingested share the verificationWorkers goroutines. The outcome of each
+// rollup is sent on the channel at its index.
+func (rc *rollupConsumerImpl) verifyRollups(l1Block common.L1BlockHash, rollups []*common.ExtRollup) *blockVerification {
+	block := &blockVerification{
+		l1Block:       l1Block,
+		rollups:       rollups,
+		verifications: make([]chan rollupVerification, len(rollups)),
+		stop:          make(chan struct{}),
+	}
+	for i := range rollups {
+		// buffered, so that a verification completes even if its outcome is not read
+		block.verifications[i] = make(chan rollupVerification, 1)
+	}
+
+	go func() {
+		for i, rollup := range rollups {
+			select {
+			case rc.workers <- struct{}{}:
+			case <-block.stop:
+				return
+			}
+			go func(i int, rollup *common.ExtRollup) {
+				defer func() { <-rc.workers }()
+				verified, err := rc.verifyRollup(rollup)
+				block.verifications[i] <- rollupVerification{rollup: verified, err: err}
+			}(i, rollup)
+		}
+	}()
+	return block
+}
+
+// verifyRollup decrypts the rollup and recreates its batches from its header. It returns nil if the rollup can't be
+// processed on the canonical chain of this enclave.
+func (rc *rollupConsumerImpl) verifyRollup(rollup *common.ExtRollup) (*verifiedRollup, error) {
+	l1CompressionBlock, err := rc.storage.FetchBlock(rollup.Header.CompressionL1Head)
+	if err != nil {
+		rc.logger.Warn("Can't process rollup because the l1 block used for compression is not available", "block_hash", rollup.Header.CompressionL1Head, log.RollupHashKey, rollup.Hash(), log.ErrKey, err)
+		return nil, nil //nolint:nilnil
+	}
+	canonicalBlockByHeight, err := rc.storage.FetchCanonicaBlockByHeight(l1CompressionBlock.Number())
+	if err != nil {
+		return nil, err
+	}
+	if canonicalBlockByHeight.Hash() != l1CompressionBlock.Hash() {
+		rc.logger.Warn("Skipping rollup because it was compressed on top of a non-canonical rollup", "block_hash", rollup.Header.CompressionL1Head, log.RollupHashKey, rollup.Hash(), log.ErrKey, err)
+		return nil, nil //nolint:nilnil
+	}
+	// read batch data from rollup and verify it
+	return rc.rollupCompression.verifyExtRollup(rollup)
+}
+
 func (rc *rollupConsumerImpl) getSignedRollup(rollups []*common.ExtRollup) ([]*common.ExtRollup, error) {
 	signedRollup := make([]*common.ExtRollup, 0)
 
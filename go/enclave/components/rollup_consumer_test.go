@@ -0,0 +1,328 @@
+package components
+
+import (
+	"crypto/ecdsa"
+	cryptorand "crypto/rand"
+	"errors"
+	"math/big"
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/compression"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+	"github.com/ten-protocol/go-ten/go/enclave/crypto"
+	"github.com/ten-protocol/go-ten/go/ethadapter"
+	"github.com/ten-protocol/go-ten/go/ethadapter/mgmtcontractlib"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// rollupConsumerStorage records the rollups stored by the rollup consumer
+type rollupConsumerStorage struct {
+	*inMemoryBatchStorage
+	canonical []*types.Block
+	rollups   []common.L2RollupHash
+}
+
+func (s *rollupConsumerStorage) FetchCanonicaBlockByHeight(height *big.Int) (*types.Block, error) {
+	return s.canonical[height.Uint64()], nil
+}
+
+func (s *rollupConsumerStorage) StoreRollup(rollup *common.ExtRollup, _ *common.CalldataRollupHeader) error {
+	s.rollups = append(s.rollups, rollup.Hash())
+	return nil
+}
+
+func TestRollupsAreVerifiedConcurrentlyAndExecutedInOrder(t *testing.T) {
+	seq, rollups := newTestExtRollups(t, 4)
+	validatorStorage := &rollupConsumerStorage{inMemoryBatchStorage: newInMemoryBatchStorage(seq.l1Blocks), canonical: seq.canonical}
+	consumer := newTestRollupConsumer(validatorStorage, 3)
+	var reports []*common.RollupSyncProgress
+	consumer.SubscribeForProgress(func(progress *common.RollupSyncProgress) {
+		reports = append(reports, progress)
+	})
+
+	l1Block := seq.canonical[len(seq.canonical)-1].Hash()
+	require.NoError(t, consumer.processRollups(l1Block, rollups))
+
+	// the last batch of the block is always reported
+	require.NotEmpty(t, reports)
+	last := reports[len(reports)-1]
+	require.Equal(t, l1Block, last.L1Block)
+	require.EqualValues(t, len(rollups)-1, last.Rollup)
+	require.Equal(t, last.Batches, last.Batch)
+	require.EqualValues(t, rollups[len(rollups)-1].Header.LastBatchSeqNo, last.BatchSeqNo.ToInt().Uint64())
+	require.Zero(t, last.ETA)
+
+	require.Len(t, validatorStorage.rollups, len(rollups))
+	for i, rollup := range rollups {
+		require.Equal(t, rollup.Hash(), validatorStorage.rollups[i])
+	}
+	require.Len(t, validatorStorage.bySeqNo, len(seq.batches))
+	for _, expected := range seq.batches {
+		recreated, err := validatorStorage.FetchBatchBySeqNo(expected.SeqNo().Uint64())
+		require.NoError(t, err)
+		require.Equal(t, expected.Hash(), recreated.Hash())
+	}
+}
+
+// verificationBarrierStorage holds the verifications of the rollups until the given number of them are in progress at
+// once, and serves the attested key of the sequencer
+type verificationBarrierStorage struct {
+	*rollupConsumerStorage
+	sequencerKey *ecdsa.PublicKey
+
+	lock     sync.Mutex
+	arrived  int
+	parties  int
+	released chan struct{}
+}
+
+func (s *verificationBarrierStorage) FetchBlock(blockHash common.L1BlockHash) (*types.Block, error) {
+	s.lock.Lock()
+	s.arrived++
+	if s.arrived == s.parties {
+		close(s.released)
+	}
+	s.lock.Unlock()
+
+	select {
+	case <-s.released:
+		return s.rollupConsumerStorage.FetchBlock(blockHash)
+	case <-time.After(5 * time.Second):
+		return nil, errors.New("the rollups were not verified concurrently")
+	}
+}
+
+func (s *verificationBarrierStorage) FetchAttestedKeys(gethcommon.Address) ([]*ecdsa.PublicKey, error) {
+	return []*ecdsa.PublicKey{s.sequencerKey}, nil
+}
+
+func TestRollupsOfConsecutiveBlocksAreVerifiedConcurrently(t *testing.T) {
+	seq, rollups := newTestExtRollups(t, 3)
+	rollups = rollups[:3]
+	sequencerKey, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	validatorStorage := &verificationBarrierStorage{
+		rollupConsumerStorage: &rollupConsumerStorage{inMemoryBatchStorage: newInMemoryBatchStorage(seq.l1Blocks), canonical: seq.canonical},
+		sequencerKey:          &sequencerKey.PublicKey,
+		parties:               len(rollups),
+		released:              make(chan struct{}),
+	}
+	logger := gethlog.New()
+	mgmtContract := gethcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	mgmtLib := mgmtcontractlib.NewMgmtContractLib(&mgmtContract, logger)
+	sigValidator, err := NewSignatureValidator(gethcommon.Address{0x01}, validatorStorage)
+	require.NoError(t, err)
+	rollupCompression := NewRollupCompression(&noopBatchRegistry{}, &deterministicBatchExecutor{storage: validatorStorage.inMemoryBatchStorage},
+		crypto.NewDataEncryptionService(logger), compression.NewBrotliDataCompressionService(), validatorStorage.rollupConsumerStorage, nil, false, compression.DefaultLimits, limitsFromGenesis, logger)
+	consumer := NewRollupConsumer(mgmtLib, &noopBatchRegistry{}, rollupCompression, validatorStorage, logger, sigValidator, len(rollups))
+
+	// each rollup is published in its own block, and the blocks are ingested while catching up with the L1. The
+	// verification of the rollup of a block only completes once the rollups of all the blocks are being verified.
+	for i, rollup := range rollups {
+		br := newTestRollupBlock(t, mgmtLib, sequencerKey, rollup, i)
+		require.NoError(t, consumer.ProcessRollupsInBlock(br, i == len(rollups)-1))
+		if i < len(rollups)-1 {
+			require.Empty(t, validatorStorage.rollups)
+		}
+	}
+
+	require.Len(t, validatorStorage.rollups, len(rollups))
+	for i, rollup := range rollups {
+		require.Equal(t, rollup.Hash(), validatorStorage.rollups[i])
+	}
+}
+
+// signedRollupStorage serves the attested key of the sequencer
+type signedRollupStorage struct {
+	*rollupConsumerStorage
+	sequencerKey *ecdsa.PublicKey
+}
+
+func (s *signedRollupStorage) FetchAttestedKeys(gethcommon.Address) ([]*ecdsa.PublicKey, error) {
+	return []*ecdsa.PublicKey{s.sequencerKey}, nil
+}
+
+func TestDeferredRollupFailureIsReportedAgainstItsBlock(t *testing.T) {
+	seq, rollups := newTestExtRollups(t, 3)
+	rollups = rollups[:3]
+	rollups[0].BatchPayloads = append([]byte{}, rollups[0].BatchPayloads...)
+	rollups[0].BatchPayloads[len(rollups[0].BatchPayloads)-1]++
+	sequencerKey, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	validatorStorage := &signedRollupStorage{
+		rollupConsumerStorage: &rollupConsumerStorage{inMemoryBatchStorage: newInMemoryBatchStorage(seq.l1Blocks), canonical: seq.canonical},
+		sequencerKey:          &sequencerKey.PublicKey,
+	}
+	logger := gethlog.New()
+	mgmtContract := gethcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	mgmtLib := mgmtcontractlib.NewMgmtContractLib(&mgmtContract, logger)
+	sigValidator, err := NewSignatureValidator(gethcommon.Address{0x01}, validatorStorage)
+	require.NoError(t, err)
+	rollupCompression := NewRollupCompression(&noopBatchRegistry{}, &deterministicBatchExecutor{storage: validatorStorage.inMemoryBatchStorage},
+		crypto.NewDataEncryptionService(logger), compression.NewBrotliDataCompressionService(), validatorStorage.rollupConsumerStorage, nil, false, compression.DefaultLimits, limitsFromGenesis, logger)
+	consumer := NewRollupConsumer(mgmtLib, &noopBatchRegistry{}, rollupCompression, validatorStorage, logger, sigValidator, 1)
+
+	// the execution of the rollups of the first block is deferred while the second block is ingested
+	blocks := make([]*common.BlockAndReceipts, len(rollups))
+	for i, rollup := range rollups {
+		blocks[i] = newTestRollupBlock(t, mgmtLib, sequencerKey, rollup, i)
+	}
+	require.NoError(t, consumer.ProcessRollupsInBlock(blocks[0], false))
+	err = consumer.ProcessRollupsInBlock(blocks[1], false)
+	blockErrs := BlockRollupsErrors(err)
+	require.Len(t, blockErrs, 1)
+	require.Equal(t, blocks[0].Block.Hash(), blockErrs[0].L1Block)
+
+	// the rollups of the next blocks extend the batches of the invalid rollup, so each of them fails against its block
+	blockErrs = BlockRollupsErrors(consumer.ProcessRollupsInBlock(blocks[2], true))
+	require.Len(t, blockErrs, 2)
+	require.Equal(t, blocks[1].Block.Hash(), blockErrs[0].L1Block)
+	require.Equal(t, blocks[2].Block.Hash(), blockErrs[1].L1Block)
+	require.Empty(t, validatorStorage.rollups)
+}
+
+// newTestRollupBlock returns the L1 block at the given index of a catch-up, publishing the rollup signed by the sequencer
+func newTestRollupBlock(t *testing.T, mgmtLib mgmtcontractlib.MgmtContractLib, sequencerKey *ecdsa.PrivateKey, rollup *common.ExtRollup, index int) *common.BlockAndReceipts {
+	var err error
+	hash := rollup.Hash()
+	rollup.Header.R, rollup.Header.S, err = ecdsa.Sign(cryptorand.Reader, sequencerKey, hash[:])
+	require.NoError(t, err)
+	encoded, err := common.EncodeRollup(rollup)
+	require.NoError(t, err)
+	tx := types.NewTx(mgmtLib.CreateRollup(&ethadapter.L1RollupTx{Rollup: encoded}))
+	receipts := types.Receipts{{Status: types.ReceiptStatusSuccessful, TxHash: tx.Hash()}}
+	block := types.NewBlock(&types.Header{Number: big.NewInt(int64(1000 + index))}, types.Transactions{tx}, nil, receipts, trie.NewStackTrie(nil))
+	br, err := common.ParseBlockAndReceipts(block, &receipts)
+	require.NoError(t, err)
+	return br
+}
+
+func TestRollupCompressionStats(t *testing.T) {
+	seq, rollups := newTestExtRollups(t, 2)
+	validatorStorage := &rollupConsumerStorage{inMemoryBatchStorage: newInMemoryBatchStorage(seq.l1Blocks), canonical: seq.canonical}
+	consumer := newTestRollupConsumer(validatorStorage, 1)
+	var stats []*common.RollupCompressionStats
+	consumer.SubscribeForRollupStats(func(s *common.RollupCompressionStats) {
+		stats = append(stats, s)
+	})
+
+	require.NoError(t, consumer.processRollups(seq.canonical[len(seq.canonical)-1].Hash(), rollups))
+
+	require.Len(t, stats, len(rollups))
+	for i, rollup := range rollups {
+		transactions := make([][]*common.L2Tx, len(seq.rollups[i]))
+		for j, batch := range seq.rollups[i] {
+			transactions[j] = batch.Transactions
+		}
+		raw, err := rlp.EncodeToBytes(transactions)
+		require.NoError(t, err)
+
+		require.Equal(t, rollup.Hash(), stats[i].RollupHash)
+		require.EqualValues(t, len(seq.rollups[i]), stats[i].Batches)
+		require.EqualValues(t, len(raw), stats[i].RawTxBytes)
+		require.EqualValues(t, len(rollup.BatchPayloads), stats[i].PayloadBytes)
+		require.EqualValues(t, len(rollup.CalldataRollupHeader), stats[i].HeaderBytes)
+	}
+}
+
+func TestRollupsAfterAnInvalidRollupAreNotExecuted(t *testing.T) {
+	seq, rollups := newTestExtRollups(t, 4)
+	rollups[2].BatchPayloads = append([]byte{}, rollups[2].BatchPayloads...)
+	rollups[2].BatchPayloads[len(rollups[2].BatchPayloads)-1]++
+
+	validatorStorage := &rollupConsumerStorage{inMemoryBatchStorage: newInMemoryBatchStorage(seq.l1Blocks), canonical: seq.canonical}
+	consumer := newTestRollupConsumer(validatorStorage, len(rollups))
+
+	require.Error(t, consumer.processRollups(seq.canonical[len(seq.canonical)-1].Hash(), rollups))
+	require.Equal(t, []common.L2RollupHash{rollups[0].Hash(), rollups[1].Hash()}, validatorStorage.rollups)
+	lastSeqNo := rollups[1].Header.LastBatchSeqNo
+	require.Len(t, validatorStorage.bySeqNo, int(lastSeqNo-common.L2GenesisSeqNo+1))
+}
+
+func TestRollupWithWrongStateRootCheckpointIsRejected(t *testing.T) {
+	seq, rollups := newTestExtRollups(t, 3)
+	lastBatch := seq.rollups[0][len(seq.rollups[0])-1]
+	rollups[0].Header.StateRootCheckpoint = lastBatch.Header.Root
+	rollups[1].Header.StateRootCheckpoint = common.StateRoot{0x01}
+
+	validatorStorage := &rollupConsumerStorage{inMemoryBatchStorage: newInMemoryBatchStorage(seq.l1Blocks), canonical: seq.canonical}
+	consumer := newTestRollupConsumer(validatorStorage, 1)
+
+	err := consumer.processRollups(seq.canonical[len(seq.canonical)-1].Hash(), rollups)
+	require.ErrorContains(t, err, "state root checkpoint")
+	require.Equal(t, []common.L2RollupHash{rollups[0].Hash()}, validatorStorage.rollups)
+}
+
+func TestRollupSyncProgressETA(t *testing.T) {
+	start := time.Unix(1_700_000_000, 0)
+	progress := newRollupSyncProgress(common.L1BlockHash{0x01}, 3, start)
+
+	// 2 of the 4 batches of the first rollup took 2 seconds each, so the 2 left and the 8 of the next 2 rollups take 20s
+	progress.onRollup(4)
+	require.NotNil(t, progress.onBatch(0, 0, 4, big.NewInt(1), start.Add(2*time.Second)))
+	report := progress.onBatch(0, 1, 4, big.NewInt(2), start.Add(4*time.Second))
+	require.NotNil(t, report)
+	require.EqualValues(t, 20, report.ETA)
+	require.EqualValues(t, 2, report.Batch)
+
+	// the reports are throttled, except for the last batch of the block
+	require.Nil(t, progress.onBatch(0, 2, 4, big.NewInt(3), start.Add(4*time.Second)))
+	progress.onBatch(0, 3, 4, big.NewInt(4), start.Add(6*time.Second))
+	progress.onRollup(2)
+	progress.onBatch(1, 0, 2, big.NewInt(5), start.Add(7*time.Second))
+	progress.onBatch(1, 1, 2, big.NewInt(6), start.Add(8*time.Second))
+	progress.onRollup(1)
+	report = progress.onBatch(2, 0, 1, big.NewInt(7), start.Add(8*time.Second))
+	require.NotNil(t, report)
+	require.Zero(t, report.ETA)
+}
+
+func newTestRollupConsumer(s *rollupConsumerStorage, workers int) *rollupConsumerImpl {
+	logger := gethlog.New()
+	rollupCompression := NewRollupCompression(&noopBatchRegistry{}, &deterministicBatchExecutor{storage: s.inMemoryBatchStorage},
+		crypto.NewDataEncryptionService(logger), compression.NewBrotliDataCompressionService(), s, nil, false, compression.DefaultLimits, limitsFromGenesis, logger)
+	return NewRollupConsumer(nil, &noopBatchRegistry{}, rollupCompression, s, logger, nil, workers).(*rollupConsumerImpl)
+}
+
+// newTestExtRollups returns a random sequence of batches published in at least minRollups rollups
+func newTestExtRollups(t *testing.T, minRollups int) (*randomBatchSequence, []*common.ExtRollup) {
+	var seq *randomBatchSequence
+	for seed := int64(0); seq == nil || len(seq.rollups) < minRollups; seed++ {
+		seq = newRandomBatchSequence(t, rand.New(rand.NewSource(seed))) //nolint:gosec
+	}
+
+	sequencerStorage := newInMemoryBatchStorage(seq.l1Blocks)
+	for _, b := range seq.batches {
+		require.NoError(t, sequencerStorage.StoreBatch(b))
+	}
+	sequencerStorage.reorgSeq = seq.reorgSeq
+	logger := gethlog.New()
+	sequencer := NewRollupCompression(&noopBatchRegistry{}, nil, crypto.NewDataEncryptionService(logger), compression.NewBrotliDataCompressionService(), sequencerStorage, nil, false, compression.DefaultLimits, limitsFromGenesis, logger)
+
+	l1Head := seq.canonical[len(seq.canonical)-1]
+	rollups := make([]*common.ExtRollup, len(seq.rollups))
+	for i, batches := range seq.rollups {
+		extRollup, err := sequencer.CreateExtRollup(&core.Rollup{
+			Header: &common.RollupHeader{
+				CompressionL1Head: l1Head.Hash(),
+				LastBatchSeqNo:    batches[len(batches)-1].SeqNo().Uint64(),
+			},
+			Batches: batches,
+			Blocks:  seq.l1Blocks,
+		})
+		require.NoError(t, err)
+		rollups[i] = extRollup
+	}
+	return seq, rollups
+}
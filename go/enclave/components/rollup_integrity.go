@@ -0,0 +1,70 @@
+package components
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+// RollupIntegrityError is returned when a batch recreated from a rollup published in verification mode does not have
+// the hash the sequencer committed to in the rollup header. It identifies the first divergent batch of the rollup.
+type RollupIntegrityError struct {
+	BatchIdx int      // the position of the batch in the rollup
+	SeqNo    *big.Int // the sequence number of the batch
+	Expected common.L2BatchHash
+	Computed common.L2BatchHash
+}
+
+func (e *RollupIntegrityError) Error() string {
+	return fmt.Sprintf("rollup integrity check failed. Batch %d of the rollup (seq=%d) was recreated with hash %s, but the sequencer published %s",
+		e.BatchIdx, e.SeqNo, e.Computed, e.Expected)
+}
+
+// BatchHashes is the list of the hashes of the batches of a rollup, whose merkle root is published in the rollup header
+type BatchHashes []common.L2BatchHash
+
+func (bh BatchHashes) Len() int {
+	return len(bh)
+}
+
+func (bh BatchHashes) EncodeIndex(index int, w *bytes.Buffer) {
+	w.Write(bh[index].Bytes())
+}
+
+func batchHashesRoot(hashes []common.L2BatchHash) gethcommon.Hash {
+	return types.DeriveSha(BatchHashes(hashes), trie.NewStackTrie(nil))
+}
+
+// validateBatchHashes checks that the batch hashes of a rollup published in verification mode cover all its batches,
+// and match their root. The rollups published without them are not checked.
+func validateBatchHashes(header *common.CalldataRollupHeader, nrBatches int) error {
+	if len(header.BatchHashes) == 0 && header.BatchHashesRoot == (gethcommon.Hash{}) {
+		return nil
+	}
+	if len(header.BatchHashes) != nrBatches {
+		return fmt.Errorf("invalid rollup header. Expected %d batch hashes, found %d", nrBatches, len(header.BatchHashes))
+	}
+	if root := batchHashesRoot(header.BatchHashes); root != header.BatchHashesRoot {
+		return fmt.Errorf("invalid rollup header. The batch hashes have the root %s, but the header published %s", root, header.BatchHashesRoot)
+	}
+	return nil
+}
+
+// checkBatchIntegrity returns a RollupIntegrityError when the rollup was published in verification mode, and the batch
+// recreated at the index has another hash than the one published for it
+func checkBatchIntegrity(header *common.CalldataRollupHeader, batchIdx int, seqNo *big.Int, computed common.L2BatchHash) error {
+	if len(header.BatchHashes) == 0 || header.BatchHashes[batchIdx] == computed {
+		return nil
+	}
+	return &RollupIntegrityError{
+		BatchIdx: batchIdx,
+		SeqNo:    seqNo,
+		Expected: header.BatchHashes[batchIdx],
+		Computed: computed,
+	}
+}
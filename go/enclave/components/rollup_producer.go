@@ -52,9 +52,18 @@ func (re *rollupProducerImpl) CreateInternalRollup(fromBatchNo uint64, upToL1Hei
 		return nil, err
 	}
 
+	newRollup := newInternalRollup(re.sequencerID, block.Hash(), batches, blocks)
+	re.logger.Info(fmt.Sprintf("Created new rollup %s with %d batches. From %d to %d", newRollup.Hash(), len(newRollup.Batches), batches[0].SeqNo(), newRollup.Header.LastBatchSeqNo))
+
+	return newRollup, nil
+}
+
+// newInternalRollup creates the rollup of the batches, compressed relative to the L1 block. The blocks are the L1
+// proofs of the batches
+func newInternalRollup(sequencerID gethcommon.Address, compressionL1Head common.L1BlockHash, batches []*core.Batch, blocks []*types.Block) *core.Rollup {
 	rh := common.RollupHeader{}
-	rh.CompressionL1Head = block.Hash()
-	rh.Coinbase = re.sequencerID
+	rh.CompressionL1Head = compressionL1Head
+	rh.Coinbase = sequencerID
 
 	rh.CrossChainMessages = make([]MessageBus.StructsCrossChainMessage, 0)
 	for _, b := range batches {
@@ -69,13 +78,19 @@ func (re *rollupProducerImpl) CreateInternalRollup(fromBatchNo uint64, upToL1Hei
 		blockMap[b.Hash()] = b
 	}
 
-	newRollup := &core.Rollup{
+	return &core.Rollup{
 		Header:  &rh,
 		Blocks:  blockMap,
 		Batches: batches,
 	}
+}
 
-	re.logger.Info(fmt.Sprintf("Created new rollup %s with %d batches. From %d to %d", newRollup.Hash(), len(newRollup.Batches), batches[0].SeqNo(), rh.LastBatchSeqNo))
-
-	return newRollup, nil
+// truncateRollup keeps the first batches of the rollup, and the cross chain messages of those batches
+func truncateRollup(r *core.Rollup, nrBatches int) {
+	r.Batches = r.Batches[:nrBatches]
+	r.Header.CrossChainMessages = make([]MessageBus.StructsCrossChainMessage, 0)
+	for _, b := range r.Batches {
+		r.Header.CrossChainMessages = append(r.Header.CrossChainMessages, b.Header.CrossChainMessages...)
+	}
+	r.Header.LastBatchSeqNo = r.Batches[nrBatches-1].SeqNo().Uint64()
 }
@@ -0,0 +1,67 @@
+package components
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+// the minimum interval between two progress reports, except for the last one of a block
+const rollupProgressInterval = time.Second
+
+// rollupSyncProgress tracks the execution of the batches of the rollups of an L1 block, which takes long when a node
+// catches up, and reports it at most once per rollupProgressInterval.
+//
+// The ETA is the average time it took to execute a batch of the block so far, times the batches left. The rollups not
+// started yet are assumed to hold as many batches as the ones started so far.
+type rollupSyncProgress struct {
+	l1Block common.L1BlockHash
+	rollups int
+	start   time.Time
+
+	startedBatches  int // the batches of the rollups started so far, including the current one
+	executedBatches int
+	lastReport      time.Time
+}
+
+func newRollupSyncProgress(l1Block common.L1BlockHash, rollups int, now time.Time) *rollupSyncProgress {
+	return &rollupSyncProgress{l1Block: l1Block, rollups: rollups, start: now}
+}
+
+// onRollup is called before the batches of the next rollup are executed
+func (p *rollupSyncProgress) onRollup(batches int) {
+	p.startedBatches += batches
+}
+
+// onBatch is called after a batch of the rollup at rollupIdx was executed or found in the database. It returns nil when
+// the previous report is too recent, unless the batch is the last one of the block.
+func (p *rollupSyncProgress) onBatch(rollupIdx int, batchIdx int, batches int, seqNo *big.Int, now time.Time) *common.RollupSyncProgress {
+	p.executedBatches++
+	last := rollupIdx == p.rollups-1 && batchIdx == batches-1
+	if !last && now.Sub(p.lastReport) < rollupProgressInterval {
+		return nil
+	}
+	p.lastReport = now
+	return &common.RollupSyncProgress{
+		L1Block:    p.l1Block,
+		Rollup:     hexutil.Uint64(rollupIdx),
+		Rollups:    hexutil.Uint64(p.rollups),
+		Batch:      hexutil.Uint64(batchIdx + 1),
+		Batches:    hexutil.Uint64(batches),
+		BatchSeqNo: (*hexutil.Big)(new(big.Int).Set(seqNo)),
+		ETA:        hexutil.Uint64(p.eta(rollupIdx, now) / time.Second),
+		Time:       hexutil.Uint64(now.Unix()),
+	}
+}
+
+func (p *rollupSyncProgress) eta(rollupIdx int, now time.Time) time.Duration {
+	if p.executedBatches == 0 {
+		return 0
+	}
+	perBatch := now.Sub(p.start) / time.Duration(p.executedBatches)
+	averageRollup := p.startedBatches / (rollupIdx + 1)
+	left := p.startedBatches - p.executedBatches + averageRollup*(p.rollups-rollupIdx-1)
+	return perBatch * time.Duration(left)
+}
@@ -0,0 +1,564 @@
+package components
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
+)
+
+// RollupWitness is everything the batches of a rollup read when they are executed: the state entries, the batch headers,
+// the L1 blocks and the cross chain messages and relays. An enclave can re-execute the batches from the witness alone, without the
+// chain state. The state entries and the L1 blocks are addressed by their hash, so a witness cannot forge them.
+type RollupWitness struct {
+	RollupHash common.L2RollupHash
+	Parent     *common.BatchHeader   // the header of the batch the rollup builds on, which holds the prior state root
+	Batches    []*core.Batch         // the batches of the rollup, in order
+	Headers    []*common.BatchHeader // the headers of the other batches read, by the BLOCKHASH opcode
+	L1Blocks   []*types.Header
+	// the L1 blocks that were canonical at their height, for the governance changes
+	CanonicalL1Blocks []common.L1BlockHash
+	L1Messages        []*WitnessL1Messages
+	GovernanceChanges []*common.GovernanceChange
+	StateEntries      []*WitnessEntry
+}
+
+// WitnessL1Messages is the cross chain messages, value transfers and message relays of an L1 block
+type WitnessL1Messages struct {
+	BlockHash common.L1BlockHash
+	Messages  common.CrossChainMessages
+	Transfers common.ValueTransferEvents
+	Relays    common.MessageRelays `rlp:"optional"`
+}
+
+// WitnessEntry is an entry of the state database: a trie node or a contract code
+type WitnessEntry struct {
+	Key   []byte
+	Value []byte
+}
+
+// witnessRecorder is a storage that records the data read by the batches it executes. The state written by the
+// execution is kept in memory, so that recording a witness does not write to the enclave database.
+type witnessRecorder struct {
+	storage.Storage
+	kv      *recordingKV
+	stateDB state.Database
+
+	lock              sync.Mutex
+	batches           map[common.L2BatchHash]*common.BatchHeader
+	blocks            map[common.L1BlockHash]*types.Header
+	canonical         map[common.L1BlockHash]bool
+	messages          map[common.L1BlockHash]*WitnessL1Messages
+	governanceChanges []*common.GovernanceChange
+}
+
+func newWitnessRecorder(s storage.Storage) *witnessRecorder {
+	kv := &recordingKV{Database: memorydb.New(), disk: s.StateDB().DiskDB(), reads: map[string][]byte{}}
+	return &witnessRecorder{
+		Storage: s,
+		kv:      kv,
+		// a state database without caches, so that every entry read is recorded
+		stateDB:   state.NewDatabaseWithConfig(rawdb.NewDatabase(kv), &trie.Config{}),
+		batches:   map[common.L2BatchHash]*common.BatchHeader{},
+		blocks:    map[common.L1BlockHash]*types.Header{},
+		canonical: map[common.L1BlockHash]bool{},
+		messages:  map[common.L1BlockHash]*WitnessL1Messages{},
+	}
+}
+
+func (r *witnessRecorder) FetchBatch(hash common.L2BatchHash) (*core.Batch, error) {
+	batch, err := r.Storage.FetchBatch(hash)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.batches[hash] = batch.Header
+	return batch, nil
+}
+
+func (r *witnessRecorder) FetchBatchHeader(hash common.L2BatchHash) (*common.BatchHeader, error) {
+	header, err := r.Storage.FetchBatchHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.batches[hash] = header
+	return header, nil
+}
+
+func (r *witnessRecorder) FetchBlock(blockHash common.L1BlockHash) (*types.Block, error) {
+	block, err := r.Storage.FetchBlock(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.blocks[blockHash] = block.Header()
+	return block, nil
+}
+
+func (r *witnessRecorder) FetchCanonicaBlockByHeight(height *big.Int) (*types.Block, error) {
+	block, err := r.Storage.FetchCanonicaBlockByHeight(height)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.blocks[block.Hash()] = block.Header()
+	r.canonical[block.Hash()] = true
+	return block, nil
+}
+
+func (r *witnessRecorder) IsAncestor(block *types.Block, maybeAncestor *types.Block) bool {
+	return isAncestor(r.FetchBlock, block, maybeAncestor)
+}
+
+func (r *witnessRecorder) IsBlockAncestor(block *types.Block, maybeAncestor common.L1BlockHash) bool {
+	ancestor, err := r.FetchBlock(maybeAncestor)
+	if err != nil {
+		return false
+	}
+	return isAncestor(r.FetchBlock, block, ancestor)
+}
+
+func (r *witnessRecorder) GetL1Messages(blockHash common.L1BlockHash) (common.CrossChainMessages, error) {
+	messages, err := r.Storage.GetL1Messages(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.blockMessages(blockHash).Messages = messages
+	return messages, nil
+}
+
+func (r *witnessRecorder) GetL1Transfers(blockHash common.L1BlockHash) (common.ValueTransferEvents, error) {
+	transfers, err := r.Storage.GetL1Transfers(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.blockMessages(blockHash).Transfers = transfers
+	return transfers, nil
+}
+
+func (r *witnessRecorder) GetL1Relays(blockHash common.L1BlockHash) (common.MessageRelays, error) {
+	relays, err := r.Storage.GetL1Relays(blockHash)
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.blockMessages(blockHash).Relays = relays
+	return relays, nil
+}
+
+func (r *witnessRecorder) blockMessages(blockHash common.L1BlockHash) *WitnessL1Messages {
+	if _, found := r.messages[blockHash]; !found {
+		r.messages[blockHash] = &WitnessL1Messages{BlockHash: blockHash}
+	}
+	return r.messages[blockHash]
+}
+
+func (r *witnessRecorder) FetchGovernanceChanges() ([]*common.GovernanceChange, error) {
+	changes, err := r.Storage.FetchGovernanceChanges()
+	if err != nil {
+		return nil, err
+	}
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.governanceChanges = changes
+	return changes, nil
+}
+
+func (r *witnessRecorder) CreateStateDB(hash common.L2BatchHash) (*state.StateDB, error) {
+	batch, err := r.FetchBatch(hash)
+	if err != nil {
+		return nil, err
+	}
+	return state.New(batch.Header.Root, r.stateDB, nil)
+}
+
+func (r *witnessRecorder) TrieDB() *trie.Database {
+	return r.stateDB.TrieDB()
+}
+
+func (r *witnessRecorder) StateDB() state.Database {
+	return r.stateDB
+}
+
+// StoreBatchFees does not store the fees, the batches are already stored
+func (r *witnessRecorder) StoreBatchFees(*common.BatchFees) error {
+	return nil
+}
+
+// StoreTxFailures does not store the failed transactions, the batches are already stored
+func (r *witnessRecorder) StoreTxFailures([]*common.TransactionError) error {
+	return nil
+}
+
+// StoreOutboundMessages does not store the messages, the batches are already stored
+func (r *witnessRecorder) StoreOutboundMessages([]*common.OutboundMessage) error {
+	return nil
+}
+
+// witness returns the witness of the rollup holding the batches, from the data read while executing them
+func (r *witnessRecorder) witness(rollupHash common.L2RollupHash, parent *common.BatchHeader, batches []*core.Batch) *RollupWitness {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	w := &RollupWitness{
+		RollupHash:        rollupHash,
+		Parent:            parent,
+		Batches:           batches,
+		GovernanceChanges: r.governanceChanges,
+	}
+	inRollup := map[common.L2BatchHash]bool{parent.Hash(): true}
+	for _, batch := range batches {
+		inRollup[batch.Hash()] = true
+	}
+	for hash, header := range r.batches {
+		if !inRollup[hash] {
+			w.Headers = append(w.Headers, header)
+		}
+	}
+	sort.Slice(w.Headers, func(i, j int) bool { return w.Headers[i].SequencerOrderNo.Cmp(w.Headers[j].SequencerOrderNo) < 0 })
+
+	for hash, header := range r.blocks {
+		w.L1Blocks = append(w.L1Blocks, header)
+		if r.canonical[hash] {
+			w.CanonicalL1Blocks = append(w.CanonicalL1Blocks, hash)
+		}
+	}
+	sort.Slice(w.L1Blocks, func(i, j int) bool { return w.L1Blocks[i].Number.Cmp(w.L1Blocks[j].Number) < 0 })
+	sort.Slice(w.CanonicalL1Blocks, func(i, j int) bool {
+		return bytes.Compare(w.CanonicalL1Blocks[i].Bytes(), w.CanonicalL1Blocks[j].Bytes()) < 0
+	})
+
+	for _, messages := range r.messages {
+		w.L1Messages = append(w.L1Messages, messages)
+	}
+	sort.Slice(w.L1Messages, func(i, j int) bool {
+		return bytes.Compare(w.L1Messages[i].BlockHash.Bytes(), w.L1Messages[j].BlockHash.Bytes()) < 0
+	})
+
+	w.StateEntries = r.kv.entries()
+	return w
+}
+
+// recordingKV records the entries read from the disk. The entries written are kept in memory and are not recorded.
+type recordingKV struct {
+	*memorydb.Database
+	disk ethdb.KeyValueReader
+
+	lock  sync.Mutex
+	reads map[string][]byte
+}
+
+func (kv *recordingKV) Has(key []byte) (bool, error) {
+	if found, _ := kv.Database.Has(key); found {
+		return true, nil
+	}
+	_, err := kv.Get(key)
+	return err == nil, nil
+}
+
+func (kv *recordingKV) Get(key []byte) ([]byte, error) {
+	if value, err := kv.Database.Get(key); err == nil {
+		return value, nil
+	}
+	value, err := kv.disk.Get(key)
+	if err != nil {
+		return nil, err
+	}
+	kv.lock.Lock()
+	defer kv.lock.Unlock()
+	kv.reads[string(key)] = value
+	return value, nil
+}
+
+func (kv *recordingKV) entries() []*WitnessEntry {
+	kv.lock.Lock()
+	defer kv.lock.Unlock()
+	entries := make([]*WitnessEntry, 0, len(kv.reads))
+	for key, value := range kv.reads {
+		entries = append(entries, &WitnessEntry{Key: []byte(key), Value: value})
+	}
+	sort.Slice(entries, func(i, j int) bool { return bytes.Compare(entries[i].Key, entries[j].Key) < 0 })
+	return entries
+}
+
+// witnessStorage is a storage that serves the data of a witness only, and returns errutil.ErrNotFound for the rest. The
+// enclave storage it wraps is only used for the shared secret.
+type witnessStorage struct {
+	storage.Storage
+	kv      *witnessKV
+	stateDB state.Database
+
+	batches   map[common.L2BatchHash]*core.Batch
+	blocks    map[common.L1BlockHash]*types.Block
+	canonical map[uint64]*types.Block
+	messages  map[common.L1BlockHash]*WitnessL1Messages
+	changes   []*common.GovernanceChange
+}
+
+// newWitnessStorage checks that the witness is consistent, and returns a storage serving its data
+func newWitnessStorage(s storage.Storage, w *RollupWitness) (*witnessStorage, error) {
+	if w.Parent == nil || len(w.Batches) == 0 {
+		return nil, fmt.Errorf("the witness has no batch")
+	}
+
+	ws := &witnessStorage{
+		Storage:   s,
+		kv:        &witnessKV{Database: memorydb.New()},
+		batches:   map[common.L2BatchHash]*core.Batch{},
+		blocks:    map[common.L1BlockHash]*types.Block{},
+		canonical: map[uint64]*types.Block{},
+		messages:  map[common.L1BlockHash]*WitnessL1Messages{},
+		changes:   w.GovernanceChanges,
+	}
+	ws.stateDB = state.NewDatabaseWithConfig(rawdb.NewDatabase(ws.kv), &trie.Config{})
+
+	for _, header := range append([]*common.BatchHeader{w.Parent}, w.Headers...) {
+		ws.batches[header.Hash()] = &core.Batch{Header: header}
+	}
+	parent := w.Parent
+	for _, batch := range w.Batches {
+		if batch.Header.ParentHash != parent.Hash() {
+			return nil, fmt.Errorf("batch %s does not follow batch %s", batch.Hash(), parent.Hash())
+		}
+		if batch.SeqNo().Uint64() != parent.SequencerOrderNo.Uint64()+1 {
+			return nil, fmt.Errorf("batch %d does not follow batch %d", batch.SeqNo(), parent.SequencerOrderNo)
+		}
+		ws.batches[batch.Hash()] = batch
+		parent = batch.Header
+	}
+
+	for _, header := range w.L1Blocks {
+		ws.blocks[header.Hash()] = types.NewBlockWithHeader(header)
+	}
+	for _, hash := range w.CanonicalL1Blocks {
+		block, found := ws.blocks[hash]
+		if !found {
+			return nil, fmt.Errorf("the canonical L1 block %s is not in the witness", hash)
+		}
+		ws.canonical[block.NumberU64()] = block
+	}
+	for _, messages := range w.L1Messages {
+		ws.messages[messages.BlockHash] = messages
+	}
+
+	for _, entry := range w.StateEntries {
+		hash := gethcrypto.Keccak256Hash(entry.Value)
+		isCode, codeHash := rawdb.IsCodeKey(entry.Key)
+		if !bytes.Equal(entry.Key, hash.Bytes()) && !(isCode && bytes.Equal(codeHash, hash.Bytes())) {
+			return nil, fmt.Errorf("the state entry %x is not keyed by its hash", entry.Key)
+		}
+		if err := ws.kv.Database.Put(entry.Key, entry.Value); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := ws.checkL1Chain(w); err != nil {
+		return nil, err
+	}
+	return ws, nil
+}
+
+// checkL1Chain checks that the witness holds the L1 blocks and the cross chain messages the batches read. The cross chain
+// processing fails hard when they are missing from the storage, as a synced enclave always has them.
+func (ws *witnessStorage) checkL1Chain(w *RollupWitness) error {
+	parent := w.Parent
+	for _, batch := range w.Batches {
+		block, found := ws.blocks[batch.Header.L1Proof]
+		if !found {
+			return fmt.Errorf("the L1 block %s of batch %s is not in the witness", batch.Header.L1Proof, batch.Hash())
+		}
+		parentBlock, found := ws.blocks[parent.L1Proof]
+		if !found {
+			return fmt.Errorf("the L1 block %s of batch %s is not in the witness", parent.L1Proof, parent.Hash())
+		}
+		parent = batch.Header
+		if batch.SeqNo().Uint64() <= common.L2GenesisSeqNo+1 {
+			continue
+		}
+		for block.Hash() != parentBlock.Hash() {
+			if _, found = ws.messages[block.Hash()]; !found {
+				return fmt.Errorf("the cross chain messages of the L1 block %s are not in the witness", block.Hash())
+			}
+			if block.NumberU64() <= parentBlock.NumberU64() {
+				return fmt.Errorf("the L1 block %s is not a descendant of the L1 block %s", batch.Header.L1Proof, parentBlock.Hash())
+			}
+			if block, found = ws.blocks[block.ParentHash()]; !found {
+				return fmt.Errorf("the L1 chain of batch %s is not in the witness", batch.Hash())
+			}
+		}
+	}
+	return nil
+}
+
+func (ws *witnessStorage) FetchBatch(hash common.L2BatchHash) (*core.Batch, error) {
+	batch, found := ws.batches[hash]
+	if !found {
+		return nil, errutil.ErrNotFound
+	}
+	return batch, nil
+}
+
+func (ws *witnessStorage) FetchBatchHeader(hash common.L2BatchHash) (*common.BatchHeader, error) {
+	batch, err := ws.FetchBatch(hash)
+	if err != nil {
+		return nil, err
+	}
+	return batch.Header, nil
+}
+
+func (ws *witnessStorage) FetchBlock(blockHash common.L1BlockHash) (*types.Block, error) {
+	block, found := ws.blocks[blockHash]
+	if !found {
+		return nil, errutil.ErrNotFound
+	}
+	return block, nil
+}
+
+func (ws *witnessStorage) FetchCanonicaBlockByHeight(height *big.Int) (*types.Block, error) {
+	block, found := ws.canonical[height.Uint64()]
+	if !found {
+		return nil, errutil.ErrNotFound
+	}
+	return block, nil
+}
+
+func (ws *witnessStorage) IsAncestor(block *types.Block, maybeAncestor *types.Block) bool {
+	return isAncestor(ws.FetchBlock, block, maybeAncestor)
+}
+
+func (ws *witnessStorage) IsBlockAncestor(block *types.Block, maybeAncestor common.L1BlockHash) bool {
+	ancestor, err := ws.FetchBlock(maybeAncestor)
+	if err != nil {
+		return false
+	}
+	return isAncestor(ws.FetchBlock, block, ancestor)
+}
+
+func (ws *witnessStorage) GetL1Messages(blockHash common.L1BlockHash) (common.CrossChainMessages, error) {
+	messages, found := ws.messages[blockHash]
+	if !found {
+		return nil, errutil.ErrNotFound
+	}
+	return messages.Messages, nil
+}
+
+func (ws *witnessStorage) GetL1Transfers(blockHash common.L1BlockHash) (common.ValueTransferEvents, error) {
+	messages, found := ws.messages[blockHash]
+	if !found {
+		return nil, errutil.ErrNotFound
+	}
+	return messages.Transfers, nil
+}
+
+func (ws *witnessStorage) GetL1Relays(blockHash common.L1BlockHash) (common.MessageRelays, error) {
+	messages, found := ws.messages[blockHash]
+	if !found {
+		return nil, errutil.ErrNotFound
+	}
+	return messages.Relays, nil
+}
+
+func (ws *witnessStorage) FetchGovernanceChanges() ([]*common.GovernanceChange, error) {
+	return ws.changes, nil
+}
+
+func (ws *witnessStorage) CreateStateDB(hash common.L2BatchHash) (*state.StateDB, error) {
+	batch, err := ws.FetchBatch(hash)
+	if err != nil {
+		return nil, err
+	}
+	return state.New(batch.Header.Root, ws.stateDB, nil)
+}
+
+func (ws *witnessStorage) TrieDB() *trie.Database {
+	return ws.stateDB.TrieDB()
+}
+
+func (ws *witnessStorage) StateDB() state.Database {
+	return ws.stateDB
+}
+
+// StoreBatchFees does not store the fees, the batches of a witness are not part of the chain of the enclave
+func (ws *witnessStorage) StoreBatchFees(*common.BatchFees) error {
+	return nil
+}
+
+// StoreTxFailures does not store the failed transactions, the batches of a witness are not part of the chain of the enclave
+func (ws *witnessStorage) StoreTxFailures([]*common.TransactionError) error {
+	return nil
+}
+
+// StoreOutboundMessages does not store the messages, the batches of a witness are not part of the chain of the enclave
+func (ws *witnessStorage) StoreOutboundMessages([]*common.OutboundMessage) error {
+	return nil
+}
+
+// witnessKV is the state entries of a witness, which keeps the keys that were read but are not in the witness
+type witnessKV struct {
+	*memorydb.Database
+
+	lock    sync.Mutex
+	missing [][]byte
+}
+
+func (kv *witnessKV) Has(key []byte) (bool, error) {
+	_, err := kv.Get(key)
+	return err == nil, nil
+}
+
+func (kv *witnessKV) Get(key []byte) ([]byte, error) {
+	value, err := kv.Database.Get(key)
+	if err != nil {
+		kv.lock.Lock()
+		defer kv.lock.Unlock()
+		kv.missing = append(kv.missing, gethcommon.CopyBytes(key))
+		return nil, err
+	}
+	return value, nil
+}
+
+func (kv *witnessKV) missingKeys() [][]byte {
+	kv.lock.Lock()
+	defer kv.lock.Unlock()
+	return kv.missing
+}
+
+// isAncestor walks back the L1 chain of the block like the storage does, so that the blocks walked are fetched from the
+// storage wrapping it
+func isAncestor(fetchBlock func(common.L1BlockHash) (*types.Block, error), block *types.Block, maybeAncestor *types.Block) bool {
+	for block.Hash() != maybeAncestor.Hash() {
+		if maybeAncestor.NumberU64() >= block.NumberU64() {
+			return false
+		}
+		parent, err := fetchBlock(block.ParentHash())
+		if err != nil {
+			return false
+		}
+		block = parent
+	}
+	return true
+}
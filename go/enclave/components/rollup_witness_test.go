@@ -0,0 +1,130 @@
+package components
+
+import (
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+)
+
+func TestWitnessHoldsTheStateRead(t *testing.T) {
+	account := gethcommon.HexToAddress("0x1")
+	code := []byte{0x60, 0x00}
+	disk := memorydb.New()
+	diskState := state.NewDatabaseWithConfig(rawdb.NewDatabase(disk), &trie.Config{})
+	stateDB, err := state.New(types.EmptyRootHash, diskState, nil)
+	require.NoError(t, err)
+	stateDB.SetBalance(account, big.NewInt(42))
+	stateDB.SetCode(account, code)
+	for i := int64(2); i < 100; i++ {
+		stateDB.SetBalance(gethcommon.BigToAddress(big.NewInt(i)), big.NewInt(i))
+	}
+	root, err := stateDB.Commit(1, true)
+	require.NoError(t, err)
+	require.NoError(t, diskState.TrieDB().Commit(root, false))
+
+	recording := &recordingKV{Database: memorydb.New(), disk: disk, reads: map[string][]byte{}}
+	readState(t, recording, root, account, code)
+	entries := recording.entries()
+	require.Less(t, len(entries), disk.Len(), "only the entries read are recorded")
+
+	// the witness alone is enough to read the state
+	witness := &witnessKV{Database: memorydb.New()}
+	for _, entry := range entries {
+		require.NoError(t, witness.Database.Put(entry.Key, entry.Value))
+	}
+	readState(t, witness, root, account, code)
+	require.Empty(t, witness.missingKeys())
+
+	// the entries left out of the witness are reported
+	incomplete := &witnessKV{Database: memorydb.New()}
+	for _, entry := range entries[1:] {
+		require.NoError(t, incomplete.Database.Put(entry.Key, entry.Value))
+	}
+	incompleteState, err := state.New(root, state.NewDatabaseWithConfig(rawdb.NewDatabase(incomplete), &trie.Config{}), nil)
+	if err == nil {
+		incompleteState.GetBalance(account)
+		incompleteState.GetCode(account)
+	}
+	require.Contains(t, incomplete.missingKeys(), entries[0].Key)
+}
+
+func readState(t *testing.T, kv ethdb.KeyValueStore, root gethcommon.Hash, account gethcommon.Address, code []byte) {
+	stateDB, err := state.New(root, state.NewDatabaseWithConfig(rawdb.NewDatabase(kv), &trie.Config{}), nil)
+	require.NoError(t, err)
+	require.Equal(t, int64(42), stateDB.GetBalance(account).Int64())
+	require.Equal(t, code, stateDB.GetCode(account))
+	require.NoError(t, stateDB.Error())
+}
+
+func TestWitnessRoundTrip(t *testing.T) {
+	w := newTestWitness()
+	encoded, err := rlp.EncodeToBytes(w)
+	require.NoError(t, err)
+
+	var decoded RollupWitness
+	require.NoError(t, rlp.DecodeBytes(encoded, &decoded))
+	require.Equal(t, w.Parent.Hash(), decoded.Parent.Hash())
+	require.Equal(t, w.Batches[1].Hash(), decoded.Batches[1].Hash())
+	require.Equal(t, w.L1Blocks[1].Hash(), decoded.L1Blocks[1].Hash())
+	_, err = newWitnessStorage(nil, &decoded)
+	require.NoError(t, err)
+}
+
+func TestInconsistentWitnessesAreRejected(t *testing.T) {
+	tests := []struct {
+		name   string
+		tamper func(w *RollupWitness)
+	}{
+		{name: "no batch", tamper: func(w *RollupWitness) { w.Batches = nil }},
+		{name: "forged state entry", tamper: func(w *RollupWitness) {
+			w.StateEntries = append(w.StateEntries, &WitnessEntry{Key: gethcommon.Hash{}.Bytes(), Value: []byte{1}})
+		}},
+		{name: "batch not following its parent", tamper: func(w *RollupWitness) { w.Batches = w.Batches[1:] }},
+		{name: "missing L1 block", tamper: func(w *RollupWitness) { w.L1Blocks = w.L1Blocks[1:] }},
+		{name: "missing cross chain messages", tamper: func(w *RollupWitness) { w.L1Messages = nil }},
+		{name: "missing canonical L1 block", tamper: func(w *RollupWitness) {
+			w.CanonicalL1Blocks = append(w.CanonicalL1Blocks, gethcommon.Hash{1})
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := newTestWitness()
+			tt.tamper(w)
+			_, err := newWitnessStorage(nil, w)
+			require.Error(t, err)
+		})
+	}
+}
+
+// newTestWitness returns a consistent witness of two batches built on batch 5, on two successive L1 blocks
+func newTestWitness() *RollupWitness {
+	l1Parent := &types.Header{Number: big.NewInt(10), Difficulty: big.NewInt(0)}
+	l1Block := &types.Header{Number: big.NewInt(11), ParentHash: l1Parent.Hash(), Difficulty: big.NewInt(0)}
+
+	parent := &common.BatchHeader{Number: big.NewInt(5), SequencerOrderNo: big.NewInt(5), L1Proof: l1Parent.Hash()}
+	first := &common.BatchHeader{ParentHash: parent.Hash(), Number: big.NewInt(6), SequencerOrderNo: big.NewInt(6), L1Proof: l1Block.Hash()}
+	second := &common.BatchHeader{ParentHash: first.Hash(), Number: big.NewInt(7), SequencerOrderNo: big.NewInt(7), L1Proof: l1Block.Hash()}
+
+	code := []byte{0x60, 0x00}
+	return &RollupWitness{
+		RollupHash:        gethcommon.Hash{1},
+		Parent:            parent,
+		Batches:           []*core.Batch{{Header: first}, {Header: second}},
+		L1Blocks:          []*types.Header{l1Parent, l1Block},
+		CanonicalL1Blocks: []common.L1BlockHash{l1Block.Hash()},
+		L1Messages:        []*WitnessL1Messages{{BlockHash: l1Block.Hash()}},
+		StateEntries:      []*WitnessEntry{{Key: append(append([]byte{}, rawdb.CodePrefix...), gethcrypto.Keccak256(code)...), Value: code}},
+	}
+}
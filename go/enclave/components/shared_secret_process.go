@@ -3,6 +3,9 @@ package components
 import (
 	"fmt"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
 	gethcrypto "github.com/ethereum/go-ethereum/crypto"
 	gethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/ten-protocol/go-ten/go/common"
@@ -13,19 +16,28 @@ import (
 	"github.com/ten-protocol/go-ten/go/ethadapter/mgmtcontractlib"
 )
 
+// the number of attestations whose successful verification is remembered
+const _verifiedAttestationsCacheSize = 1000
+
 type SharedSecretProcessor struct {
 	mgmtContractLib     mgmtcontractlib.MgmtContractLib
 	attestationProvider AttestationProvider // interface for producing attestation reports and verifying them
+	networkGenesisHash  *gethcommon.Hash    // the secret is only shared with enclaves attesting to the same network genesis
 	storage             storage.Storage
-	logger              gethlog.Logger
+	// the attestations already verified, so that the requests replayed on the L1 do not cost a verification of the report
+	// each. The failures are not cached, as the verification of a valid report can fail transiently
+	verifiedAttestations *lru.Cache[gethcommon.Hash, struct{}]
+	logger               gethlog.Logger
 }
 
-func NewSharedSecretProcessor(mgmtcontractlib mgmtcontractlib.MgmtContractLib, attestationProvider AttestationProvider, storage storage.Storage, logger gethlog.Logger) *SharedSecretProcessor {
+func NewSharedSecretProcessor(mgmtcontractlib mgmtcontractlib.MgmtContractLib, attestationProvider AttestationProvider, networkGenesisHash *gethcommon.Hash, storage storage.Storage, logger gethlog.Logger) *SharedSecretProcessor {
 	return &SharedSecretProcessor{
-		mgmtContractLib:     mgmtcontractlib,
-		attestationProvider: attestationProvider,
-		storage:             storage,
-		logger:              logger,
+		mgmtContractLib:      mgmtcontractlib,
+		attestationProvider:  attestationProvider,
+		networkGenesisHash:   networkGenesisHash,
+		storage:              storage,
+		verifiedAttestations: lru.NewCache[gethcommon.Hash, struct{}](_verifiedAttestationsCacheSize),
+		logger:               logger,
 	}
 }
 
@@ -40,7 +52,7 @@ func (ssp *SharedSecretProcessor) ProcessNetworkSecretMsgs(br *common.BlockAndRe
 		// this transaction is for a node that has joined the network and needs to be sent the network secret
 		if scrtReqTx, ok := t.(*ethadapter.L1RequestSecretTx); ok {
 			ssp.logger.Info("Process shared secret request.", log.BlockHeightKey, block.Number(), log.BlockHashKey, block.Hash(), log.TxKey, tx.Hash())
-			resp, err := ssp.processSecretRequest(scrtReqTx)
+			resp, err := ssp.processSecretRequest(tx, scrtReqTx)
 			if err != nil {
 				ssp.logger.Error("Failed to process shared secret request.", log.ErrKey, err)
 				continue
@@ -67,7 +79,7 @@ func (ssp *SharedSecretProcessor) ProcessNetworkSecretMsgs(br *common.BlockAndRe
 	return responses
 }
 
-func (ssp *SharedSecretProcessor) processSecretRequest(req *ethadapter.L1RequestSecretTx) (*common.ProducedSecretResponse, error) {
+func (ssp *SharedSecretProcessor) processSecretRequest(tx *types.Transaction, req *ethadapter.L1RequestSecretTx) (*common.ProducedSecretResponse, error) {
 	att, err := common.DecodeAttestation(req.Attestation)
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode attestation - %w", err)
@@ -79,9 +91,11 @@ func (ssp *SharedSecretProcessor) processSecretRequest(req *ethadapter.L1Request
 		return nil, fmt.Errorf("secret request failed, no response will be published - %w", err)
 	}
 
-	// Store the attested key only if the attestation process succeeded.
-	err = ssp.storeAttestation(att)
-	if err != nil {
+	// Store the attested key only if the attestation process succeeded, and the owner registered the enclave itself: anyone
+	// can have an enclave attested on behalf of a host, e.g. of the sequencer, which must not be able to sign for it
+	if !requestedByOwner(tx, att) {
+		ssp.logger.Warn("The secret request was not sent by the owner of the attestation, its key is not authorized to sign for it.", "owner", att.Owner, log.TxKey, tx.Hash())
+	} else if err = ssp.storeAttestation(att); err != nil {
 		return nil, fmt.Errorf("could not store attestation, no response will be published. Cause: %w", err)
 	}
 
@@ -95,22 +109,47 @@ func (ssp *SharedSecretProcessor) processSecretRequest(req *ethadapter.L1Request
 
 // ShareSecret verifies the request and if it trusts the report and the public key it will return the secret encrypted with that public key.
 func (ssp *SharedSecretProcessor) verifyAttestationAndEncryptSecret(att *common.AttestationReport) (common.EncryptedSharedEnclaveSecret, error) {
+	if err := ssp.verifyAttestation(att); err != nil {
+		return nil, err
+	}
+
+	secret, err := ssp.storage.FetchSecret()
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve secret; this should not happen. Cause: %w", err)
+	}
+	return crypto.EncryptSecret(att.PubKey, *secret, ssp.logger)
+}
+
+// verifyAttestation verifies the attestation, unless it was already verified
+func (ssp *SharedSecretProcessor) verifyAttestation(att *common.AttestationReport) error {
+	encoded, err := common.EncodeAttestation(att)
+	if err != nil {
+		return fmt.Errorf("failed to encode attestation - %w", err)
+	}
+	key := gethcrypto.Keccak256Hash(encoded)
+	if ssp.verifiedAttestations.Contains(key) {
+		ssp.logger.Debug(fmt.Sprintf("Attestation already verified. Owner: %s", att.Owner))
+		return nil
+	}
+
 	// First we verify the attestation report has come from a valid obscuro enclave running in a verified TEE.
 	data, err := ssp.attestationProvider.VerifyReport(att)
 	if err != nil {
-		return nil, fmt.Errorf("unable to verify report - %w", err)
+		return fmt.Errorf("unable to verify report - %w", err)
 	}
 	// Then we verify the public key provided has come from the same enclave as that attestation report
-	if err = VerifyIdentity(data, att); err != nil {
-		return nil, fmt.Errorf("unable to verify identity - %w", err)
+	if err = VerifyIdentity(data, att, ssp.networkGenesisHash); err != nil {
+		return fmt.Errorf("unable to verify identity - %w", err)
 	}
 	ssp.logger.Info(fmt.Sprintf("Successfully verified attestation and identity. Owner: %s", att.Owner))
+	ssp.verifiedAttestations.Add(key, struct{}{})
+	return nil
+}
 
-	secret, err := ssp.storage.FetchSecret()
-	if err != nil {
-		return nil, fmt.Errorf("could not retrieve secret; this should not happen. Cause: %w", err)
-	}
-	return crypto.EncryptSecret(att.PubKey, *secret, ssp.logger)
+// requestedByOwner returns whether the secret request was sent from the L1 account of the owner of the attestation
+func requestedByOwner(tx *types.Transaction, att *common.AttestationReport) bool {
+	sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+	return err == nil && sender == att.Owner
 }
 
 // storeAttestation stores the attested keys of other nodes so we can decrypt their rollups
@@ -0,0 +1,32 @@
+package components
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+// the key of an enclave is only authorized to sign for the host that registered it on the L1 itself
+func TestRequestedByOwner(t *testing.T) {
+	owner, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	att := &common.AttestationReport{Owner: crypto.PubkeyToAddress(owner.PublicKey)}
+
+	unsigned := types.NewTx(&types.DynamicFeeTx{ChainID: big.NewInt(1337), Gas: 100_000})
+	signedBy := func(key *ecdsa.PrivateKey) *types.Transaction {
+		tx, err := types.SignTx(unsigned, types.LatestSignerForChainID(big.NewInt(1337)), key)
+		require.NoError(t, err)
+		return tx
+	}
+
+	require.True(t, requestedByOwner(signedBy(owner), att))
+	require.False(t, requestedByOwner(signedBy(other), att))
+	require.False(t, requestedByOwner(unsigned, att))
+}
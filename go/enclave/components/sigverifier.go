@@ -4,6 +4,7 @@ import (
 	"crypto/ecdsa"
 	"fmt"
 	"math/big"
+	"sync"
 
 	"github.com/ten-protocol/go-ten/go/enclave/storage"
 
@@ -12,8 +13,13 @@ import (
 
 type SignatureValidator struct {
 	SequencerID gethcommon.Address
-	attestedKey *ecdsa.PublicKey
 	storage     storage.Storage
+
+	// the keys of the enclaves authorized to sign for the sequencer: its primary enclave, registered when the network
+	// secret was initialized, and the hot standbys it registered on the L1 from its own account, any of which can be the
+	// active one
+	lock         sync.Mutex
+	attestedKeys []*ecdsa.PublicKey
 }
 
 func NewSignatureValidator(seqID gethcommon.Address, storage storage.Storage) (*SignatureValidator, error) {
@@ -21,7 +27,6 @@ func NewSignatureValidator(seqID gethcommon.Address, storage storage.Storage) (*
 	return &SignatureValidator{
 		SequencerID: seqID,
 		storage:     storage,
-		attestedKey: nil,
 	}, nil
 }
 
@@ -31,16 +36,29 @@ func (sigChecker *SignatureValidator) CheckSequencerSignature(headerHash gethcom
 		return fmt.Errorf("missing signature on batch")
 	}
 
-	if sigChecker.attestedKey == nil {
-		attestedKey, err := sigChecker.storage.FetchAttestedKey(sigChecker.SequencerID)
-		if err != nil {
-			return fmt.Errorf("could not retrieve attested key for aggregator %s. Cause: %w", sigChecker.SequencerID, err)
-		}
-		sigChecker.attestedKey = attestedKey
+	sigChecker.lock.Lock()
+	defer sigChecker.lock.Unlock()
+	if sigChecker.verify(headerHash, sigR, sigS) {
+		return nil
 	}
 
-	if !ecdsa.Verify(sigChecker.attestedKey, headerHash.Bytes(), sigR, sigS) {
+	// a standby enclave of the sequencer may have been registered since the keys were fetched
+	attestedKeys, err := sigChecker.storage.FetchAttestedKeys(sigChecker.SequencerID)
+	if err != nil {
+		return fmt.Errorf("could not retrieve attested key for aggregator %s. Cause: %w", sigChecker.SequencerID, err)
+	}
+	sigChecker.attestedKeys = attestedKeys
+	if !sigChecker.verify(headerHash, sigR, sigS) {
 		return fmt.Errorf("could not verify ECDSA signature")
 	}
 	return nil
 }
+
+func (sigChecker *SignatureValidator) verify(headerHash gethcommon.Hash, sigR *big.Int, sigS *big.Int) bool {
+	for _, attestedKey := range sigChecker.attestedKeys {
+		if ecdsa.Verify(attestedKey, headerHash.Bytes(), sigR, sigS) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,54 @@
+package components
+
+import (
+	"crypto/ecdsa"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// attestedKeysStorage holds the attested keys of the enclaves of the sequencer
+type attestedKeysStorage struct {
+	storage.Storage
+	keys    []*ecdsa.PublicKey
+	fetches int
+}
+
+func (s *attestedKeysStorage) FetchAttestedKeys(gethcommon.Address) ([]*ecdsa.PublicKey, error) {
+	s.fetches++
+	return s.keys, nil
+}
+
+func TestCheckSequencerSignature(t *testing.T) {
+	primary, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	standby, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	unattested, err := crypto.GenerateKey()
+	require.NoError(t, err)
+
+	store := &attestedKeysStorage{keys: []*ecdsa.PublicKey{&primary.PublicKey}}
+	validator, err := NewSignatureValidator(gethcommon.Address{0x01}, store)
+	require.NoError(t, err)
+	headerHash := gethcommon.Hash{0x10}
+	check := func(key *ecdsa.PrivateKey) error {
+		r, s, err := ecdsa.Sign(crypto.NewKeccakState(), key, headerHash.Bytes())
+		require.NoError(t, err)
+		return validator.CheckSequencerSignature(headerHash, r, s)
+	}
+
+	require.NoError(t, check(primary))
+	require.NoError(t, check(primary))
+	require.Equal(t, 1, store.fetches)
+
+	// the keys are fetched again for an enclave attested since
+	require.Error(t, check(standby))
+	store.keys = append(store.keys, &standby.PublicKey)
+	require.NoError(t, check(standby))
+	require.NoError(t, check(primary))
+	require.Error(t, check(unattested))
+}
@@ -7,6 +7,7 @@ import (
 	gethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/networkgenesis"
 	"github.com/ten-protocol/go-ten/go/enclave"
 
 	"github.com/ten-protocol/go-ten/go/config"
@@ -47,7 +48,9 @@ func (e *EnclaveContainer) Stop() error {
 func NewEnclaveContainerFromConfig(config *config.EnclaveConfig) *EnclaveContainer {
 	// todo - improve this wiring, perhaps setup DB etc. at this level and inject into enclave
 	// (at that point the WithLogger constructor could be a full DI constructor like the HostContainer tries, for testability)
-	logger := log.New(log.EnclaveCmp, config.LogLevel, config.LogPath, log.NodeIDKey, config.HostID)
+	redaction := log.DefaultRedaction().WithDenyList(config.LogRedactKeys)
+	redaction.MaxHexBytes = config.LogRedactHexBytes
+	logger := log.NewWithRedaction(log.EnclaveCmp, config.LogLevel, config.LogPath, redaction, log.NodeIDKey, config.HostID)
 
 	// todo - this is for debugging purposes only, should be remove in the future
 	fmt.Printf("Building enclave container with config: %+v\n", config)
@@ -58,20 +61,31 @@ func NewEnclaveContainerFromConfig(config *config.EnclaveConfig) *EnclaveContain
 
 // NewEnclaveContainerWithLogger is useful for testing etc.
 func NewEnclaveContainerWithLogger(config *config.EnclaveConfig, logger gethlog.Logger) *EnclaveContainer {
-	contractAddr := config.ManagementContractAddress
-	mgmtContractLib := mgmtcontractlib.NewMgmtContractLib(&contractAddr, logger)
-
 	if config.ValidateL1Blocks {
 		config.GenesisJSON = []byte(hardcodedGenesisJSON)
 	}
 
+	if config.NetworkGenesisPath != "" {
+		networkGenesis, err := networkgenesis.Load(config.NetworkGenesisPath)
+		if err != nil {
+			logger.Crit("unable to load the network genesis", log.ErrKey, err)
+		}
+		if err = config.ApplyNetworkGenesis(networkGenesis); err != nil {
+			logger.Crit("unable to apply the network genesis", log.ErrKey, err)
+		}
+		logger.Info("Loaded network genesis", "hash", config.NetworkGenesisHash)
+	}
+
+	contractAddr := config.ManagementContractAddress
+	mgmtContractLib := mgmtcontractlib.NewMgmtContractLib(&contractAddr, logger)
+
 	genesis, err := obscuroGenesis.New(config.ObscuroGenesis)
 	if err != nil {
 		logger.Crit("unable to parse obscuro genesis", log.ErrKey, err)
 	}
 
 	encl := enclave.NewEnclave(config, genesis, mgmtContractLib, logger)
-	rpcServer := enclave.NewEnclaveRPCServer(config.Address, encl, logger)
+	rpcServer := enclave.NewEnclaveRPCServer(config.Address, encl, config.BatchCodec, logger)
 
 	return &EnclaveContainer{
 		Enclave:   encl,
@@ -0,0 +1,62 @@
+package core
+
+import (
+	"fmt"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// InvolvedAddresses returns the addresses involved in an executed batch: the senders and the recipients of its
+// transactions, the contracts they created and the contracts that emitted their logs
+func InvolvedAddresses(batch *Batch, receipts types.Receipts) ([]gethcommon.Address, error) {
+	addresses := make([]gethcommon.Address, 0, 2*len(batch.Transactions))
+	for _, tx := range batch.Transactions {
+		sender, err := types.Sender(types.LatestSignerForChainID(tx.ChainId()), tx)
+		if err != nil {
+			return nil, fmt.Errorf("could not recover the sender of tx %s. Cause: %w", tx.Hash(), err)
+		}
+		addresses = append(addresses, sender)
+		if tx.To() != nil {
+			addresses = append(addresses, *tx.To())
+		}
+	}
+	for _, receipt := range receipts {
+		if receipt.ContractAddress != (gethcommon.Address{}) {
+			addresses = append(addresses, receipt.ContractAddress)
+		}
+		for _, l := range receipt.Logs {
+			addresses = append(addresses, l.Address)
+		}
+	}
+	return addresses, nil
+}
+
+// AddressBloom returns the bloom of the addresses involved in an executed batch. It lets the lookups of the batches of
+// an address skip most of the batches without reading their transactions and receipts.
+func AddressBloom(batch *Batch, receipts types.Receipts) (types.Bloom, error) {
+	var bloom types.Bloom
+	addresses, err := InvolvedAddresses(batch, receipts)
+	if err != nil {
+		return bloom, err
+	}
+	for _, address := range addresses {
+		bloom.Add(address.Bytes())
+	}
+	return bloom, nil
+}
+
+// InvolvesAddress returns whether the address is involved in the executed batch. Unlike a bloom lookup, it has no false
+// positives.
+func InvolvesAddress(batch *Batch, receipts types.Receipts, address gethcommon.Address) (bool, error) {
+	addresses, err := InvolvedAddresses(batch, receipts)
+	if err != nil {
+		return false, err
+	}
+	for _, involved := range addresses {
+		if involved == address {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -80,12 +80,12 @@ func (b *Batch) ToExtBatch(transactionBlobCrypto crypto.DataEncryptionService, c
 	}, nil
 }
 
-func ToBatch(extBatch *common.ExtBatch, transactionBlobCrypto crypto.DataEncryptionService, compression compression.DataCompressionService) (*Batch, error) {
+func ToBatch(extBatch *common.ExtBatch, transactionBlobCrypto crypto.DataEncryptionService, compressionService compression.DataCompressionService, limits compression.Limits) (*Batch, error) {
 	compressed, err := transactionBlobCrypto.Decrypt(extBatch.EncryptedTxBlob)
 	if err != nil {
 		return nil, err
 	}
-	encoded, err := compression.Decompress(compressed)
+	encoded, err := compressionService.Decompress(compressed, limits)
 	if err != nil {
 		return nil, err
 	}
@@ -71,6 +71,37 @@ func (m *blockMessageExtractor) StoreCrossChainValueTransfers(block *common.L1Bl
 	return nil
 }
 
+// StoreMessageRelays - extracts the relays of L2->L1 messages recorded by the message bus in the block, whose relay fees
+// are paid to the relayers by the batches that include the block.
+func (m *blockMessageExtractor) StoreMessageRelays(block *common.L1Block, receipts common.L1Receipts) error {
+	if len(receipts) == 0 {
+		return nil
+	}
+
+	logs, err := filterLogsFromReceipts(receipts, m.GetBusAddress(), &MessageRelayedEventID)
+	if err != nil {
+		m.logger.Error("Error encountered when filtering receipt logs.", log.ErrKey, err)
+		return err
+	}
+	relays, err := convertLogsToRelays(logs)
+	if err != nil {
+		m.logger.Error("Error encountered when converting message relay receipt logs.", log.ErrKey, err)
+		return err
+	}
+	if len(relays) == 0 {
+		return nil
+	}
+
+	m.logger.Trace(fmt.Sprintf("Storing %d message relays for block", len(relays)), log.BlockHashKey, block.Hash())
+	err = m.storage.StoreL1Relays(block.Hash(), relays)
+	if err != nil {
+		m.logger.Crit("Unable to store the message relays", log.ErrKey, err)
+		return err
+	}
+
+	return nil
+}
+
 // StoreCrossChainMessages - extracts the cross chain messages for the corresponding block from the receipts.
 // The messages will be stored in DB storage for later usage.
 // block - the L1 block for which events are extracted.
@@ -120,7 +151,7 @@ func (m *blockMessageExtractor) getCrossChainMessages(block *common.L1Block, rec
 	}
 	m.logger.Trace("Extracted cross chain logs from receipts", "logCount", len(logs))
 
-	messages, err := convertLogsToMessages(logs, CrossChainEventName, MessageBusABI)
+	messages, err := convertLogsToMessages(logs)
 	if err != nil {
 		m.logger.Error("Error encountered converting the extracted relevant logs to messages", log.ErrKey, err)
 		return make(common.CrossChainMessages, 0), err
@@ -143,7 +174,7 @@ func (m *blockMessageExtractor) getValueTransferMessages(receipts common.L1Recei
 		return make(common.ValueTransferEvents, 0), err
 	}
 
-	transfers, err := convertLogsToValueTransfers(logs, ValueTransferEventName, MessageBusABI)
+	transfers, err := convertLogsToValueTransfers(logs)
 	if err != nil {
 		m.logger.Error("Error encountered when converting value transfer receipt logs.", log.ErrKey, err)
 		return make(common.ValueTransferEvents, 0), err
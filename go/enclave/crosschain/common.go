@@ -3,9 +3,7 @@ package crosschain
 import (
 	"bytes"
 	"errors"
-	"strings"
 
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -16,11 +14,9 @@ import (
 )
 
 var (
-	MessageBusABI, _       = abi.JSON(strings.NewReader(MessageBus.MessageBusMetaData.ABI))
-	CrossChainEventName    = "LogMessagePublished"
-	CrossChainEventID      = MessageBusABI.Events[CrossChainEventName].ID
-	ValueTransferEventName = "ValueTransfer"
-	ValueTransferEventID   = MessageBusABI.Events["ValueTransfer"].ID
+	CrossChainEventID     = MessageBus.LogMessagePublishedEventID
+	ValueTransferEventID  = MessageBus.ValueTransferEventID
+	MessageRelayedEventID = MessageBus.MessageRelayedEventID
 )
 
 func lazilyLogReceiptChecksum(msg string, receipts types.Receipts, logger gethlog.Logger) {
@@ -121,12 +117,11 @@ func filterLogsFromReceipt(receipt *types.Receipt, address *gethcommon.Address,
 }
 
 // convertLogsToMessages - converts the logs of the event to messages. The logs should be filtered, otherwise fails.
-func convertLogsToMessages(logs []types.Log, eventName string, messageBusABI abi.ABI) (common.CrossChainMessages, error) {
+func convertLogsToMessages(logs []types.Log) (common.CrossChainMessages, error) {
 	messages := make(common.CrossChainMessages, 0)
 
 	for _, log := range logs {
-		var event MessageBus.MessageBusLogMessagePublished
-		err := messageBusABI.UnpackIntoInterface(&event, eventName, log.Data)
+		event, err := MessageBus.UnpackLogMessagePublishedEvent(log)
 		if err != nil {
 			return nil, err
 		}
@@ -139,7 +134,7 @@ func convertLogsToMessages(logs []types.Log, eventName string, messageBusABI abi
 }
 
 // createCrossChainMessage - Uses the logged event by the message bus to produce a cross chain message struct
-func createCrossChainMessage(event MessageBus.MessageBusLogMessagePublished) MessageBus.StructsCrossChainMessage {
+func createCrossChainMessage(event *MessageBus.MessageBusLogMessagePublished) MessageBus.StructsCrossChainMessage {
 	return MessageBus.StructsCrossChainMessage{
 		Sender:   event.Sender,
 		Sequence: event.Sequence,
@@ -150,12 +145,11 @@ func createCrossChainMessage(event MessageBus.MessageBusLogMessagePublished) Mes
 }
 
 // convertLogsToMessages - converts the logs of the event to messages. The logs should be filtered, otherwise fails.
-func convertLogsToValueTransfers(logs []types.Log, eventName string, messageBusABI abi.ABI) (common.ValueTransferEvents, error) {
+func convertLogsToValueTransfers(logs []types.Log) (common.ValueTransferEvents, error) {
 	messages := make(common.ValueTransferEvents, 0)
 
 	for _, log := range logs {
-		var event MessageBus.MessageBusValueTransfer
-		err := messageBusABI.UnpackIntoInterface(&event, eventName, log.Data)
+		event, err := MessageBus.UnpackValueTransferEvent(log)
 		if err != nil {
 			return nil, err
 		}
@@ -3,6 +3,7 @@ package crosschain
 import (
 	"github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ten-protocol/go-ten/go/common"
 )
 
@@ -18,6 +19,9 @@ type BlockMessageExtractor interface {
 
 	StoreCrossChainValueTransfers(block *common.L1Block, receipts common.L1Receipts) error
 
+	// StoreMessageRelays - saves the relays of L2->L1 messages recorded by the message bus in the block
+	StoreMessageRelays(block *common.L1Block, receipts common.L1Receipts) error
+
 	// GetBusAddress - Returns the L1 message bus address.
 	GetBusAddress() *common.L1Address
 
@@ -51,5 +55,8 @@ type Manager interface {
 
 	ExecuteValueTransfers(transfers common.ValueTransferEvents, rollupState *state.StateDB)
 
-	RetrieveInboundMessages(fromBlock *common.L1Block, toBlock *common.L1Block, rollupState *state.StateDB) (common.CrossChainMessages, common.ValueTransferEvents)
+	// ExecuteRelayFees - attaches the relay fees paid in a batch to the L2->L1 messages, and pays them to the relayers.
+	ExecuteRelayFees(published common.CrossChainMessages, relays common.MessageRelays, txs common.L2Transactions, receipts types.Receipts, rollupState *state.StateDB) error
+
+	RetrieveInboundMessages(fromBlock *common.L1Block, toBlock *common.L1Block, rollupState *state.StateDB) (common.CrossChainMessages, common.ValueTransferEvents, common.MessageRelays)
 }
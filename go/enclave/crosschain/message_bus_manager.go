@@ -111,7 +111,7 @@ func (m *MessageBusManager) ExtractOutboundMessages(receipts common.L2Receipts)
 		return make(common.CrossChainMessages, 0), err
 	}
 
-	messages, err := convertLogsToMessages(logs, CrossChainEventName, MessageBusABI)
+	messages, err := convertLogsToMessages(logs)
 	if err != nil {
 		m.logger.Error("Error converting messages from L2 message bus!", log.ErrKey, err)
 		return make(common.CrossChainMessages, 0), err
@@ -128,7 +128,7 @@ func (m *MessageBusManager) ExtractOutboundTransfers(receipts common.L2Receipts)
 		return make(common.ValueTransferEvents, 0), err
 	}
 
-	transfers, err := convertLogsToValueTransfers(logs, ValueTransferEventName, MessageBusABI)
+	transfers, err := convertLogsToValueTransfers(logs)
 	if err != nil {
 		m.logger.Error("Error converting transfers from L2 message bus!", log.ErrKey, err)
 		return make(common.ValueTransferEvents, 0), err
@@ -141,9 +141,10 @@ func (m *MessageBusManager) ExtractOutboundTransfers(receipts common.L2Receipts)
 // todo (@stefan) - fix ordering of messages, currently it is irrelevant.
 // todo (@stefan) - do not extract messages below their consistency level. Irrelevant security wise.
 // todo (@stefan) - surface errors
-func (m *MessageBusManager) RetrieveInboundMessages(fromBlock *common.L1Block, toBlock *common.L1Block, _ *state.StateDB) (common.CrossChainMessages, common.ValueTransferEvents) {
+func (m *MessageBusManager) RetrieveInboundMessages(fromBlock *common.L1Block, toBlock *common.L1Block, _ *state.StateDB) (common.CrossChainMessages, common.ValueTransferEvents, common.MessageRelays) {
 	messages := make(common.CrossChainMessages, 0)
 	transfers := make(common.ValueTransferEvents, 0)
+	relays := make(common.MessageRelays, 0)
 
 	from := fromBlock.Hash()
 	height := fromBlock.NumberU64()
@@ -169,8 +170,15 @@ func (m *MessageBusManager) RetrieveInboundMessages(fromBlock *common.L1Block, t
 			m.logger.Crit("Unable to get L1 transfers for block that should be there.", log.ErrKey, err)
 		}
 
+		relaysForBlock, err := m.storage.GetL1Relays(b.Hash())
+		if err != nil {
+			m.logger.Crit("Unable to get the message relays for block that should be there.", log.ErrKey, err)
+		}
+
 		messages = append(messages, messagesForBlock...) // Ordering here might work in POBI, but might be weird for fast finality
 		transfers = append(transfers, transfersForBlock...)
+		// the blocks are walked from the newest, the relays are paid in the order they were recorded
+		relays = append(relaysForBlock, relays...)
 
 		// No deposits before genesis.
 		if b.NumberU64() < height {
@@ -184,12 +192,12 @@ func (m *MessageBusManager) RetrieveInboundMessages(fromBlock *common.L1Block, t
 	}
 
 	logf := m.logger.Info
-	if len(messages)+len(transfers) == 0 {
+	if len(messages)+len(transfers)+len(relays) == 0 {
 		logf = m.logger.Debug
 	}
-	logf(fmt.Sprintf("Extracted cross chain messages for block height %d ->%d", fromBlock.NumberU64(), toBlock.NumberU64()), "no_msgs", len(messages), "no_value_transfers", len(transfers))
+	logf(fmt.Sprintf("Extracted cross chain messages for block height %d ->%d", fromBlock.NumberU64(), toBlock.NumberU64()), "no_msgs", len(messages), "no_value_transfers", len(transfers), "no_relays", len(relays))
 
-	return messages, transfers
+	return messages, transfers, relays
 }
 
 func (m *MessageBusManager) ExecuteValueTransfers(transfers common.ValueTransferEvents, rollupState *state.StateDB) {
@@ -207,7 +215,7 @@ func (m *MessageBusManager) CreateSyntheticTransactions(messages common.CrossCha
 	signedTransactions := make(types.Transactions, 0)
 	for idx, message := range messages {
 		delayInBlocks := big.NewInt(int64(message.ConsistencyLevel))
-		data, err := MessageBusABI.Pack("storeCrossChainMessage", message, delayInBlocks)
+		data, err := MessageBus.PackStoreCrossChainMessage(message, delayInBlocks)
 		if err != nil {
 			m.logger.Crit("Failed packing submitOutOfNetwork message!")
 			return signedTransactions
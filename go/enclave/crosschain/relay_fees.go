@@ -0,0 +1,139 @@
+package crosschain
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ten-protocol/go-ten/contracts/generated/MessageBus"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/enclave/rpc"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// The L1 gas used by CrossChainMessenger.relayMessage besides the call it relays, and per byte of payload. The relay
+// fees are quoted from them.
+const (
+	RelayBaseGas    = 120_000
+	RelayGasPerByte = 40
+)
+
+// the relay status of the L2->L1 messages, kept in the storage of the escrow account
+var (
+	relayPending = gethcommon.BigToHash(big.NewInt(1))
+	relayDone    = gethcommon.BigToHash(big.NewInt(2))
+)
+
+// the fee of a message is stored at its hash, and its status at the hash of the message hash and of the status suffix
+func relayStatusSlot(msgHash gethcommon.Hash) gethcommon.Hash {
+	return crypto.Keccak256Hash(msgHash.Bytes(), []byte("status"))
+}
+
+// QuoteRelayFee returns the relay fee that covers the L1 gas of the relay of a message with the payload size, at the L1
+// base fee. The relayers still weigh it against the gas of the call they relay, which depends on the message.
+func QuoteRelayFee(payloadSize uint64, l1BaseFee *big.Int) *common.RelayFeeQuote {
+	l1Gas := RelayBaseGas + RelayGasPerByte*payloadSize
+	return &common.RelayFeeQuote{
+		Fee:       (*hexutil.Big)(new(big.Int).Mul(new(big.Int).SetUint64(l1Gas), l1BaseFee)),
+		L1Gas:     hexutil.Uint64(l1Gas),
+		L1BaseFee: (*hexutil.Big)(l1BaseFee),
+	}
+}
+
+// PendingRelayFee returns the relay fee attached to a message, and whether the message is waiting to be relayed
+func PendingRelayFee(rollupState *state.StateDB, msgHash gethcommon.Hash) (*big.Int, bool) {
+	escrow := common.RelayFeeEscrowAddress
+	if rollupState.GetState(escrow, relayStatusSlot(msgHash)) != relayPending {
+		return nil, false
+	}
+	return rollupState.GetState(escrow, msgHash).Big(), true
+}
+
+// ExecuteRelayFees keeps the relay fees of the L2->L1 messages in the storage of the escrow account, so that they are
+// part of the state every node computes. The messages published in the batch become relayable, the fees paid to the
+// escrow by the transactions of the batch are attached to their message, and the relays recorded on the L1 pay the fee
+// of their message to the relayer. A fee paid for a message that is unknown or already relayed is refunded.
+func (m *MessageBusManager) ExecuteRelayFees(published common.CrossChainMessages, relays common.MessageRelays, txs common.L2Transactions, receipts types.Receipts, rollupState *state.StateDB) error {
+	escrow := common.RelayFeeEscrowAddress
+
+	var feeTxs common.L2Transactions
+	for _, tx := range txs {
+		if tx.To() != nil && *tx.To() == escrow && tx.Value().Sign() > 0 {
+			feeTxs = append(feeTxs, tx)
+		}
+	}
+	if len(published)+len(feeTxs)+len(relays) == 0 {
+		return nil
+	}
+	if rollupState.GetNonce(escrow) == 0 {
+		// an account without nonce, balance and code would be deleted with its storage
+		rollupState.SetNonce(escrow, 1)
+	}
+
+	for _, msg := range published {
+		msgHash, err := common.CrossChainMessageHash(msg)
+		if err != nil {
+			return err
+		}
+		rollupState.SetState(escrow, relayStatusSlot(msgHash), relayPending)
+	}
+
+	succeeded := make(map[gethcommon.Hash]bool, len(receipts))
+	for _, receipt := range receipts {
+		succeeded[receipt.TxHash] = receipt.Status == types.ReceiptStatusSuccessful
+	}
+	for _, tx := range feeTxs {
+		if !succeeded[tx.Hash()] {
+			continue
+		}
+		msgHash := gethcommon.BytesToHash(tx.Data())
+		if len(tx.Data()) == gethcommon.HashLength && rollupState.GetState(escrow, relayStatusSlot(msgHash)) == relayPending {
+			fee := rollupState.GetState(escrow, msgHash).Big()
+			rollupState.SetState(escrow, msgHash, gethcommon.BigToHash(fee.Add(fee, tx.Value())))
+			continue
+		}
+
+		sender, err := rpc.GetSender(tx)
+		if err != nil {
+			return fmt.Errorf("could not refund the relay fee of transaction %s - %w", tx.Hash(), err)
+		}
+		m.logger.Debug("Refunding a relay fee paid for an unknown or relayed message", log.TxKey, tx.Hash())
+		rollupState.SubBalance(escrow, tx.Value())
+		rollupState.AddBalance(sender, tx.Value())
+	}
+
+	for _, relay := range relays {
+		fee, pending := PendingRelayFee(rollupState, relay.MessageHash)
+		if !pending {
+			continue
+		}
+		rollupState.SetState(escrow, relayStatusSlot(relay.MessageHash), relayDone)
+		rollupState.SetState(escrow, relay.MessageHash, gethcommon.Hash{})
+		rollupState.SubBalance(escrow, fee)
+		rollupState.AddBalance(relay.Relayer, fee)
+	}
+	return nil
+}
+
+// convertLogsToRelays - converts the logs of the MessageRelayed event to relays. The logs should be filtered, otherwise fails.
+func convertLogsToRelays(logs []types.Log) (common.MessageRelays, error) {
+	relays := make(common.MessageRelays, 0)
+
+	for _, l := range logs {
+		event, err := MessageBus.UnpackMessageRelayedEvent(l)
+		if err != nil {
+			return nil, fmt.Errorf("could not decode the MessageRelayed event - %w", err)
+		}
+		relays = append(relays, common.MessageRelay{
+			MessageHash: event.MsgHash,
+			Relayer:     event.Relayer,
+		})
+	}
+
+	return relays, nil
+}
@@ -0,0 +1,214 @@
+package crosschain
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind/backends"
+	"github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/contracts/generated/CrossChainMessenger"
+	"github.com/ten-protocol/go-ten/contracts/generated/ManagementContract"
+	"github.com/ten-protocol/go-ten/contracts/generated/MessageBus"
+	"github.com/ten-protocol/go-ten/go/common"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+var testChainID = big.NewInt(443)
+
+func mustKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := crypto.HexToECDSA("8dfb8083da6275ae3e4f41e3e8a8c19d028d32c9247e24530933782f2a05035b")
+	require.NoError(t, err)
+	return key
+}
+
+// feeTx returns a transfer of the value to the escrow with the data, and the receipt of its execution
+func feeTx(t *testing.T, stateDB *state.StateDB, nonce uint64, value int64, data []byte) (*types.Transaction, *types.Receipt) {
+	tx, err := types.SignNewTx(mustKey(t), types.LatestSignerForChainID(testChainID), &types.LegacyTx{
+		Nonce: nonce, To: &common.RelayFeeEscrowAddress, Value: big.NewInt(value), Gas: 50_000, GasPrice: big.NewInt(1), Data: data,
+	})
+	require.NoError(t, err)
+	// the transfer executed by the EVM
+	stateDB.AddBalance(common.RelayFeeEscrowAddress, big.NewInt(value))
+	return tx, &types.Receipt{TxHash: tx.Hash(), Status: types.ReceiptStatusSuccessful}
+}
+
+func TestRelayFees(t *testing.T) {
+	manager := NewObscuroMessageBusManager(nil, testChainID, gethlog.New())
+	stateDB, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	escrow := common.RelayFeeEscrowAddress
+	user := crypto.PubkeyToAddress(mustKey(t).PublicKey)
+	relayer := gethcommon.HexToAddress("0x0fee")
+
+	// nothing to do leaves the state untouched
+	require.NoError(t, manager.ExecuteRelayFees(nil, nil, nil, nil, stateDB))
+	require.False(t, stateDB.Exist(escrow))
+
+	msg := common.CrossChainMessage{Sender: gethcommon.HexToAddress("0x01"), Sequence: 7, Payload: []byte{1, 2, 3}}
+	msgHash, err := common.CrossChainMessageHash(msg)
+	require.NoError(t, err)
+
+	// the message is published, and a fee is paid for it in the same batch, and another one for an unknown message
+	paid, paidReceipt := feeTx(t, stateDB, 0, 100, msgHash.Bytes())
+	unknown, unknownReceipt := feeTx(t, stateDB, 1, 50, gethcommon.HexToHash("0x02").Bytes())
+	require.NoError(t, manager.ExecuteRelayFees(common.CrossChainMessages{msg}, nil, types.Transactions{paid, unknown}, types.Receipts{paidReceipt, unknownReceipt}, stateDB))
+
+	fee, pending := PendingRelayFee(stateDB, msgHash)
+	require.True(t, pending)
+	require.Equal(t, big.NewInt(100), fee)
+	require.Equal(t, big.NewInt(100), stateDB.GetBalance(escrow))
+	require.Equal(t, big.NewInt(50), stateDB.GetBalance(user))
+
+	// the relay recorded on the L1 pays the fee to the relayer, once
+	relays := common.MessageRelays{{MessageHash: msgHash, Relayer: relayer}, {MessageHash: msgHash, Relayer: user}}
+	require.NoError(t, manager.ExecuteRelayFees(nil, relays, nil, nil, stateDB))
+	_, pending = PendingRelayFee(stateDB, msgHash)
+	require.False(t, pending)
+	require.Equal(t, big.NewInt(100), stateDB.GetBalance(relayer))
+	require.Equal(t, big.NewInt(50), stateDB.GetBalance(user))
+	require.Zero(t, stateDB.GetBalance(escrow).Sign())
+
+	// a fee paid once the message is relayed is refunded
+	late, lateReceipt := feeTx(t, stateDB, 2, 30, msgHash.Bytes())
+	require.NoError(t, manager.ExecuteRelayFees(nil, nil, types.Transactions{late}, types.Receipts{lateReceipt}, stateDB))
+	require.Equal(t, big.NewInt(80), stateDB.GetBalance(user))
+	require.Zero(t, stateDB.GetBalance(escrow).Sign())
+}
+
+// newSimulatedL1 returns an L1 chain where the accounts of the keys are funded
+func newSimulatedL1(t *testing.T, keys ...*ecdsa.PrivateKey) *backends.SimulatedBackend {
+	alloc := core.GenesisAlloc{}
+	for _, key := range keys {
+		alloc[crypto.PubkeyToAddress(key.PublicKey)] = core.GenesisAccount{Balance: big.NewInt(1e18)}
+	}
+	backend := backends.NewSimulatedBackend(alloc, 30_000_000)
+	t.Cleanup(func() { backend.Close() })
+	return backend
+}
+
+func transactor(t *testing.T, key *ecdsa.PrivateKey) *bind.TransactOpts {
+	opts, err := bind.NewKeyedTransactorWithChainID(key, big.NewInt(1337))
+	require.NoError(t, err)
+	// the relay of a message does not revert when recording it fails, so the gas is not estimated
+	opts.GasLimit = 5_000_000
+	return opts
+}
+
+func TestOnlyTheMessengerRecordsRelays(t *testing.T) {
+	ownerKey := mustKey(t)
+	relayerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	relayer := crypto.PubkeyToAddress(relayerKey.PublicKey)
+	backend := newSimulatedL1(t, ownerKey, relayerKey)
+	owner := transactor(t, ownerKey)
+
+	// the L1 message bus, owned by the management contract, and the messenger that delivers its messages
+	busAddress, _, bus, err := MessageBus.DeployMessageBus(owner, backend)
+	require.NoError(t, err)
+	messengerAddress, _, messenger, err := CrossChainMessenger.DeployCrossChainMessenger(owner, backend)
+	require.NoError(t, err)
+	backend.Commit()
+	_, err = messenger.Initialize(owner, busAddress)
+	require.NoError(t, err)
+
+	payload, err := messenger.EncodeCall(nil, gethcommon.HexToAddress("0x0123"), nil)
+	require.NoError(t, err)
+	msg := common.CrossChainMessage{Sender: gethcommon.HexToAddress("0x01"), Sequence: 7, Payload: payload}
+	_, err = bus.StoreCrossChainMessage(owner, msg, big.NewInt(0))
+	require.NoError(t, err)
+	backend.Commit()
+
+	// a relay can not be recorded directly, nor by a messenger that is not registered with the bus
+	tx, err := bus.RecordRelay(transactor(t, relayerKey), msg, relayer)
+	require.NoError(t, err)
+	backend.Commit()
+	receipt, err := backend.TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusFailed, receipt.Status)
+	tx, err = messenger.RelayMessage(transactor(t, relayerKey), CrossChainMessenger.StructsCrossChainMessage(msg))
+	require.NoError(t, err)
+	backend.Commit()
+	require.Empty(t, relaysOf(t, backend, busAddress, tx))
+
+	// the registered messenger records the account that delivered the message as its relayer
+	msg.Sequence++
+	_, err = bus.StoreCrossChainMessage(owner, msg, big.NewInt(0))
+	require.NoError(t, err)
+	_, err = bus.SetMessenger(owner, messengerAddress)
+	require.NoError(t, err)
+	backend.Commit()
+	tx, err = messenger.RelayMessage(transactor(t, relayerKey), CrossChainMessenger.StructsCrossChainMessage(msg))
+	require.NoError(t, err)
+	backend.Commit()
+
+	msgHash, err := common.CrossChainMessageHash(msg)
+	require.NoError(t, err)
+	require.Equal(t, common.MessageRelays{{MessageHash: msgHash, Relayer: relayer}}, relaysOf(t, backend, busAddress, tx))
+}
+
+// relaysOf returns the relays recorded by the successful transaction
+func relaysOf(t *testing.T, backend *backends.SimulatedBackend, busAddress gethcommon.Address, tx *types.Transaction) common.MessageRelays {
+	receipt, err := backend.TransactionReceipt(context.Background(), tx.Hash())
+	require.NoError(t, err)
+	require.Equal(t, types.ReceiptStatusSuccessful, receipt.Status)
+	logs, err := filterLogsFromReceipts(types.Receipts{receipt}, &busAddress, &MessageRelayedEventID)
+	require.NoError(t, err)
+	relays, err := convertLogsToRelays(logs)
+	require.NoError(t, err)
+	return relays
+}
+
+func TestTheL1MessengerIsRegisteredWithTheBus(t *testing.T) {
+	ownerKey := mustKey(t)
+	backend := newSimulatedL1(t, ownerKey)
+	owner := transactor(t, ownerKey)
+
+	_, _, mgmtContract, err := ManagementContract.DeployManagementContract(owner, backend)
+	require.NoError(t, err)
+	backend.Commit()
+	_, err = mgmtContract.Initialize(owner)
+	require.NoError(t, err)
+	backend.Commit()
+	busAddress, err := mgmtContract.MessageBus(nil)
+	require.NoError(t, err)
+	bus, err := MessageBus.NewMessageBus(busAddress, backend)
+	require.NoError(t, err)
+
+	messengerAddress := gethcommon.HexToAddress("0x0123")
+	_, err = mgmtContract.SetImportantContractAddress(owner, "L1CrossChainMessenger", messengerAddress)
+	require.NoError(t, err)
+	// the other important contracts are not allowed to record relays
+	_, err = mgmtContract.SetImportantContractAddress(owner, "L1Bridge", gethcommon.HexToAddress("0x0456"))
+	require.NoError(t, err)
+	backend.Commit()
+	messenger, err := bus.Messenger(nil)
+	require.NoError(t, err)
+	require.Equal(t, messengerAddress, messenger)
+}
+
+func TestQuoteRelayFee(t *testing.T) {
+	quote := QuoteRelayFee(100, big.NewInt(10))
+	require.Equal(t, uint64(RelayBaseGas+100*RelayGasPerByte), uint64(quote.L1Gas))
+	require.Equal(t, big.NewInt(10*(RelayBaseGas+100*RelayGasPerByte)), quote.Fee.ToInt())
+}
+
+func TestConvertLogsToRelays(t *testing.T) {
+	msgHash := gethcommon.HexToHash("0x01")
+	relayer := gethcommon.HexToAddress("0x02")
+	data, err := MessageBus.ParsedMessageBusABI().Events["MessageRelayed"].Inputs.Pack(msgHash, relayer)
+	require.NoError(t, err)
+
+	relays, err := convertLogsToRelays([]types.Log{{Topics: []gethcommon.Hash{MessageRelayedEventID}, Data: data}})
+	require.NoError(t, err)
+	require.Equal(t, common.MessageRelays{{MessageHash: msgHash, Relayer: relayer}}, relays)
+}
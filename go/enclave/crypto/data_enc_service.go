@@ -9,6 +9,7 @@ import (
 	gethlog "github.com/ethereum/go-ethereum/log"
 
 	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 )
@@ -18,7 +19,7 @@ const (
 	// todo (#1053) - replace this fixed key with derived, rotating keys.
 	RollupEncryptionKeyHex = "bddbc0d46a0666ce57a466168d99c1830b0c65e052d77188f2cbfc3f6486588c"
 	// NonceLength is the nonce's length in bytes for encrypting and decrypting transactions.
-	NonceLength = 12
+	NonceLength = tentypes.BlobNonceLength
 )
 
 // DataEncryptionService handles the encryption and decryption of the transaction blobs stored inside a rollup.
@@ -64,10 +65,12 @@ func (t dataEncryptionServiceImpl) Encrypt(blob []byte) ([]byte, error) {
 
 func (t dataEncryptionServiceImpl) Decrypt(blob []byte) ([]byte, error) {
 	// The nonce is prepended to the ciphertext.
-	nonce := blob[0:NonceLength]
-	ciphertext := blob[NonceLength:]
+	encrypted := tentypes.EncryptedBlob(blob)
+	if err := encrypted.Validate(); err != nil {
+		return nil, err
+	}
 
-	plaintext, err := t.cipher.Open(nil, nonce, ciphertext, nil)
+	plaintext, err := t.cipher.Open(nil, encrypted.Nonce(), encrypted.Ciphertext(), nil)
 	if err != nil {
 		t.logger.Error("could not decrypt blob.", log.ErrKey, err)
 		return nil, err
@@ -0,0 +1,131 @@
+package debugger
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"time"
+
+	"github.com/ten-protocol/go-ten/go/common"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	maxCPUProfileSeconds = 60
+	// the profile requests must be relayed to the enclave within this window of their signature
+	profileRequestValidity = time.Minute
+)
+
+// ErrProfilingNotBuilt is returned when the enclave was built without the enclaveprofiling build tag
+var ErrProfilingNotBuilt = errors.New("the enclave was built without profiling support")
+
+// Profiler captures the pprof profiles of the enclave requested by the profiling admin. Since the requests are relayed
+// by the host, they are authenticated with the signature of the admin, and each request can only be used once, by the
+// enclave it was signed for. The profiles are encrypted to the key of the admin, so the host cannot read them.
+type Profiler struct {
+	admin     gethcommon.Address
+	enclaveID gethcommon.Address
+	chainID   uint64
+	// persists the timestamp of the last request, the timestamps must increase to prevent replays, also across restarts
+	storage RequestStorage
+
+	mu  sync.Mutex // a single profile is captured at a time
+	now func() time.Time
+}
+
+// RequestStorage persists the timestamp of the last profile request served
+type RequestStorage interface {
+	StoreLastProfileRequest(timestamp uint64) error
+	FetchLastProfileRequest() (uint64, error)
+}
+
+func NewProfiler(admin gethcommon.Address, enclaveID gethcommon.Address, chainID uint64, storage RequestStorage) *Profiler {
+	return &Profiler{admin: admin, enclaveID: enclaveID, chainID: chainID, storage: storage, now: time.Now}
+}
+
+// Profile returns the gzipped pprof profile requested
+func (p *Profiler) Profile(req *common.ProfileRequest) ([]byte, error) {
+	if !profilingEnabled {
+		return nil, ErrProfilingNotBuilt
+	}
+	switch req.Kind {
+	case common.CPUProfile:
+		if req.Seconds == 0 || req.Seconds > maxCPUProfileSeconds {
+			return nil, fmt.Errorf("the CPU profile must last between 1 and %d seconds", maxCPUProfileSeconds)
+		}
+	case common.HeapProfile:
+	default:
+		return nil, fmt.Errorf("unknown profile %q, expected %s or %s", req.Kind, common.CPUProfile, common.HeapProfile)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	adminKey, err := p.authorize(req)
+	if err != nil {
+		return nil, err
+	}
+
+	var profile bytes.Buffer
+	if req.Kind == common.HeapProfile {
+		runtime.GC() // so the profile reflects the live objects
+		if err := pprof.WriteHeapProfile(&profile); err != nil {
+			return nil, fmt.Errorf("could not write the heap profile - %w", err)
+		}
+	} else {
+		if err := pprof.StartCPUProfile(&profile); err != nil {
+			return nil, fmt.Errorf("could not start the CPU profile - %w", err)
+		}
+		time.Sleep(time.Duration(req.Seconds) * time.Second)
+		pprof.StopCPUProfile()
+	}
+
+	encrypted, err := ecies.Encrypt(rand.Reader, ecies.ImportECDSAPublic(adminKey), profile.Bytes(), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not encrypt the profile - %w", err)
+	}
+	return encrypted, nil
+}
+
+// checks the request was signed by the profiling admin for this enclave recently, and was not used before, and returns
+// the key of the admin. Must be called with the lock
+func (p *Profiler) authorize(req *common.ProfileRequest) (*ecdsa.PublicKey, error) {
+	if p.admin == (gethcommon.Address{}) {
+		return nil, errors.New("no profiling admin is configured")
+	}
+	if req.EnclaveID != p.enclaveID || uint64(req.ChainID) != p.chainID {
+		return nil, fmt.Errorf("the profile request is for the enclave %s of the chain %d, not for the enclave %s of the chain %d",
+			req.EnclaveID, uint64(req.ChainID), p.enclaveID, p.chainID)
+	}
+	signerKey, err := req.SignerKey()
+	if err != nil {
+		return nil, fmt.Errorf("invalid profile request signature - %w", err)
+	}
+	if signer := crypto.PubkeyToAddress(*signerKey); signer != p.admin {
+		return nil, fmt.Errorf("the profile request was signed by %s, not by the profiling admin", signer)
+	}
+
+	age := p.now().Sub(time.Unix(int64(req.Timestamp), 0))
+	if age > profileRequestValidity || age < -profileRequestValidity {
+		return nil, fmt.Errorf("the profile request expired, it must be sent within %s of its timestamp", profileRequestValidity)
+	}
+	lastTimestamp, err := p.storage.FetchLastProfileRequest()
+	if err != nil {
+		return nil, fmt.Errorf("could not read the last profile request - %w", err)
+	}
+	if uint64(req.Timestamp) <= lastTimestamp {
+		return nil, errors.New("the profile request was already used")
+	}
+	if err := p.storage.StoreLastProfileRequest(uint64(req.Timestamp)); err != nil {
+		return nil, fmt.Errorf("could not store the profile request - %w", err)
+	}
+	return signerKey, nil
+}
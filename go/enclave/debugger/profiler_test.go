@@ -0,0 +1,115 @@
+package debugger
+
+import (
+	"crypto/ecdsa"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// memoryRequestStorage keeps the last profile request in memory, like the enclave DB across restarts
+type memoryRequestStorage struct {
+	lastTimestamp uint64
+}
+
+func (s *memoryRequestStorage) StoreLastProfileRequest(timestamp uint64) error {
+	s.lastTimestamp = timestamp
+	return nil
+}
+
+func (s *memoryRequestStorage) FetchLastProfileRequest() (uint64, error) {
+	return s.lastTimestamp, nil
+}
+
+// authorize returns the error of the authorization of the request
+func authorize(profiler *Profiler, req *common.ProfileRequest) error {
+	_, err := profiler.authorize(req)
+	return err
+}
+
+func TestAuthorizesTheProfileRequestsOfTheAdmin(t *testing.T) {
+	adminKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	now := time.Unix(1_700_000_000, 0)
+	enclaveID := gethcommon.HexToAddress("0x01")
+
+	newRequest := func(key *ecdsa.PrivateKey, timestamp time.Time) *common.ProfileRequest {
+		req := &common.ProfileRequest{Kind: common.HeapProfile, Timestamp: hexutil.Uint64(timestamp.Unix()), EnclaveID: enclaveID, ChainID: 443}
+		require.NoError(t, req.Sign(key))
+		return req
+	}
+
+	store := &memoryRequestStorage{}
+	profiler := NewProfiler(crypto.PubkeyToAddress(adminKey.PublicKey), enclaveID, 443, store)
+	profiler.now = func() time.Time { return now }
+
+	require.ErrorContains(t, authorize(profiler, newRequest(otherKey, now)), "not by the profiling admin")
+	require.ErrorContains(t, authorize(profiler, newRequest(adminKey, now.Add(-2*time.Minute))), "expired")
+
+	req := newRequest(adminKey, now)
+	require.NoError(t, authorize(profiler, req))
+	require.ErrorContains(t, authorize(profiler, req), "already used")
+
+	// the used requests are remembered across the restarts of the enclave
+	restarted := NewProfiler(crypto.PubkeyToAddress(adminKey.PublicKey), enclaveID, 443, store)
+	restarted.now = profiler.now
+	require.ErrorContains(t, authorize(restarted, req), "already used")
+
+	tampered := newRequest(adminKey, now.Add(time.Second))
+	tampered.Kind = common.CPUProfile
+	require.ErrorContains(t, authorize(profiler, tampered), "not by the profiling admin")
+
+	require.NoError(t, authorize(profiler, newRequest(adminKey, now.Add(time.Second))))
+
+	// the requests signed for another enclave or another chain are rejected, even once their fields are changed
+	otherEnclave := newRequest(adminKey, now.Add(2*time.Second))
+	otherEnclave.EnclaveID = gethcommon.HexToAddress("0x02")
+	require.NoError(t, otherEnclave.Sign(adminKey))
+	require.ErrorContains(t, authorize(profiler, otherEnclave), "not for the enclave")
+	otherEnclave.EnclaveID = enclaveID
+	require.ErrorContains(t, authorize(profiler, otherEnclave), "not by the profiling admin")
+
+	otherChain := newRequest(adminKey, now.Add(2*time.Second))
+	otherChain.ChainID = 444
+	require.ErrorContains(t, authorize(profiler, otherChain), "not for the enclave")
+}
+
+func TestRejectsProfileRequestsWithoutAdmin(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	req := &common.ProfileRequest{Kind: common.HeapProfile, Timestamp: hexutil.Uint64(time.Now().Unix())}
+	require.NoError(t, req.Sign(key))
+
+	require.ErrorContains(t, authorize(NewProfiler(gethcommon.Address{}, gethcommon.Address{}, 0, &memoryRequestStorage{}), req), "no profiling admin")
+}
+
+func TestServesProfilesOnlyWhenBuiltWithProfiling(t *testing.T) {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	req := &common.ProfileRequest{Kind: common.HeapProfile, Timestamp: hexutil.Uint64(time.Now().Unix())}
+	require.NoError(t, req.Sign(key))
+
+	encrypted, err := NewProfiler(crypto.PubkeyToAddress(key.PublicKey), gethcommon.Address{}, 0, &memoryRequestStorage{}).Profile(req)
+	if !profilingEnabled {
+		require.ErrorIs(t, err, ErrProfilingNotBuilt)
+		return
+	}
+	require.NoError(t, err)
+
+	// the profile is only readable by the admin
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	_, err = common.DecryptProfile(otherKey, encrypted)
+	require.Error(t, err)
+	profile, err := common.DecryptProfile(key, encrypted)
+	require.NoError(t, err)
+	require.NotEmpty(t, profile)
+}
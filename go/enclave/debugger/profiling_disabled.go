@@ -0,0 +1,6 @@
+//go:build !enclaveprofiling
+
+package debugger
+
+// the pprof profiles are only served by the enclaves built with the enclaveprofiling build tag
+const profilingEnabled = false
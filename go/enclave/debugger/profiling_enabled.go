@@ -0,0 +1,6 @@
+//go:build enclaveprofiling
+
+package debugger
+
+// the enclave was built with the enclaveprofiling build tag, so the profiling admin can request its pprof profiles
+const profilingEnabled = true
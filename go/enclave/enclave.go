@@ -7,13 +7,16 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"sync"
 	"time"
 
 	"github.com/ten-protocol/go-ten/go/common/compression"
 	"github.com/ten-protocol/go-ten/go/common/measure"
+	"github.com/ten-protocol/go-ten/go/enclave/evm"
 	"github.com/ten-protocol/go-ten/go/enclave/evm/ethchainadapter"
 	"github.com/ten-protocol/go-ten/go/enclave/gas"
+	"github.com/ten-protocol/go-ten/go/enclave/governance"
 	"github.com/ten-protocol/go-ten/go/enclave/storage"
 	"github.com/ten-protocol/go-ten/go/enclave/txpool"
 	"github.com/ten-protocol/go-ten/go/enclave/vkhandler"
@@ -32,6 +35,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	"github.com/ethereum/go-ethereum/crypto/ecies"
@@ -44,6 +48,7 @@ import (
 	"github.com/ten-protocol/go-ten/go/common/profiler"
 	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
 	"github.com/ten-protocol/go-ten/go/common/syserr"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
 	"github.com/ten-protocol/go-ten/go/common/tracers"
 	"github.com/ten-protocol/go-ten/go/config"
 	"github.com/ten-protocol/go-ten/go/enclave/crosschain"
@@ -52,6 +57,7 @@ import (
 	"github.com/ten-protocol/go-ten/go/enclave/events"
 
 	"github.com/ten-protocol/go-ten/go/enclave/rpc"
+	"github.com/ten-protocol/go-ten/go/enclave/screening"
 	"github.com/ten-protocol/go-ten/go/ethadapter/mgmtcontractlib"
 
 	_ "github.com/ten-protocol/go-ten/go/common/tracers/native" // make sure the tracers are loaded
@@ -60,6 +66,7 @@ import (
 	gethcore "github.com/ethereum/go-ethereum/core"
 	gethcrypto "github.com/ethereum/go-ethereum/crypto"
 	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/rlp"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -76,10 +83,15 @@ type enclaveImpl struct {
 	subscriptionManager   *events.SubscriptionManager
 	crossChainProcessors  *crosschain.Processors
 	sharedSecretProcessor *components.SharedSecretProcessor
+	governance            governance.Manager
+	rollupAuditor         *components.RollupAuditor
 
 	chain    l2chain.ObscuroChain
 	service  nodetype.NodeType
 	registry components.BatchRegistry
+	// executes the batches of the sequencer: the service of a validator, or the hot standby enclave of the sequencer
+	// following the batches of the active one
+	batchFollower nodetype.ObsValidator
 
 	mgmtContractLib     mgmtcontractlib.MgmtContractLib
 	attestationProvider components.AttestationProvider // interface for producing attestation reports and verifying them
@@ -91,6 +103,7 @@ type enclaveImpl struct {
 	dataCompressionService compression.DataCompressionService
 	profiler               *profiler.Profiler
 	debugger               *debugger.Debugger
+	debugProfiler          *debugger.Profiler
 	logger                 gethlog.Logger
 
 	stopControl *stopcontrol.StopControl
@@ -140,10 +153,10 @@ func NewEnclave(
 	// todo (#1474) - make sure the enclave cannot be started in production with WillAttest=false
 	var attestationProvider components.AttestationProvider
 	if config.WillAttest {
-		attestationProvider = &components.EgoAttestationProvider{}
+		attestationProvider = &components.EgoAttestationProvider{NetworkGenesisHash: config.NetworkGenesisHash}
 	} else {
 		logger.Info("WARNING - Attestation is not enabled, enclave will not create a verified attestation report.")
-		attestationProvider = &components.DummyAttestationProvider{}
+		attestationProvider = &components.DummyAttestationProvider{NetworkGenesisHash: config.NetworkGenesisHash}
 	}
 
 	// attempt to fetch the enclave key from the database
@@ -167,6 +180,7 @@ func NewEnclave(
 
 	serializedEnclavePubKey := gethcrypto.CompressPubkey(&enclaveKey.PublicKey)
 	logger.Info(fmt.Sprintf("Generated public key %s", gethcommon.Bytes2Hex(serializedEnclavePubKey)))
+	logger.Info(fmt.Sprintf("The enclave ID is %s", gethcrypto.PubkeyToAddress(enclaveKey.PublicKey)))
 
 	obscuroKey := crypto.GetObscuroKey(logger)
 	rpcEncryptionManager := rpc.NewEncryptionManager(ecies.ImportECDSA(obscuroKey))
@@ -176,29 +190,45 @@ func NewEnclave(
 
 	crossChainProcessors := crosschain.New(&config.MessageBusAddress, storage, big.NewInt(config.ObscuroChainID), logger)
 
-	subscriptionManager := events.NewSubscriptionManager(&rpcEncryptionManager, storage, config.ObscuroChainID, logger)
+	subscriptionManager := events.NewSubscriptionManager(&rpcEncryptionManager, storage, config.RelevancyRulesForks, config.ObscuroChainID, logger)
+
+	governanceManager, err := governance.NewManager(&config.ManagementContractAddress, storage, logger)
+	if err != nil {
+		logger.Crit("Could not initialise the governance manager", log.ErrKey, err)
+	}
 
 	gasOracle := gas.NewGasOracle()
-	blockProcessor := components.NewBlockProcessor(storage, crossChainProcessors, gasOracle, logger)
-	batchExecutor := components.NewBatchExecutor(storage, crossChainProcessors, genesis, gasOracle, chainConfig, config.GasBatchExecutionLimit, logger)
+	blockProcessor := components.NewBlockProcessor(storage, crossChainProcessors, governanceManager, gasOracle, logger)
+	batchExecutor := components.NewBatchExecutor(storage, crossChainProcessors, genesis, gasOracle, chainConfig, config.L1MessageVerifierFork, governanceManager, config.GasBatchExecutionLimit, logger)
 	sigVerifier, err := components.NewSignatureValidator(config.SequencerID, storage)
-	registry := components.NewBatchRegistry(storage, logger)
+	registry := components.NewBatchRegistry(storage, config.L1FinalityDepth, logger)
 	rProducer := components.NewRollupProducer(config.SequencerID, storage, registry, logger)
 	if err != nil {
 		logger.Crit("Could not initialise the signature validator", log.ErrKey, err)
 	}
-	rollupCompression := components.NewRollupCompression(registry, batchExecutor, dataEncryptionService, dataCompressionService, storage, chainConfig, logger)
-	rConsumer := components.NewRollupConsumer(mgmtContractLib, registry, rollupCompression, storage, logger, sigVerifier)
-	sharedSecretProcessor := components.NewSharedSecretProcessor(mgmtContractLib, attestationProvider, storage, logger)
+	rollupCompression := components.NewRollupCompression(registry, batchExecutor, dataEncryptionService, dataCompressionService, storage, chainConfig, config.RollupIntegrityCheck, config.DecompressionLimitsOrDefault(), config.RollupDecompressionLimitsFork, logger)
+	rConsumer := components.NewRollupConsumer(mgmtContractLib, registry, rollupCompression, storage, logger, sigVerifier, config.RollupVerificationWorkers)
+	sharedSecretProcessor := components.NewSharedSecretProcessor(mgmtContractLib, attestationProvider, config.NetworkGenesisHash, storage, logger)
+	rollupAuditor := components.NewRollupAuditor(storage, &config.ManagementContractAddress, &config.MessageBusAddress, big.NewInt(config.ObscuroChainID), chainConfig, config.L1MessageVerifierFork, config.GasBatchExecutionLimit, logger)
 
 	blockchain := ethchainadapter.NewEthChainAdapter(big.NewInt(config.ObscuroChainID), registry, storage, logger)
-	mempool, err := txpool.NewTxPool(blockchain, config.MinGasPrice, logger)
+	mempool, err := txpool.NewTxPool(blockchain, config.MinGasPrice, config.TxPoolPriceBump, config.TxInclusionDeadline, storage, logger)
 	if err != nil {
 		logger.Crit("unable to init eth tx pool", log.ErrKey, err)
 	}
 
-	var service nodetype.NodeType
+	validator := nodetype.NewValidator(blockProcessor, batchExecutor, registry, rConsumer, chainConfig, config.SequencerID, storage, sigVerifier, logger)
+	var service nodetype.NodeType = validator
 	if config.NodeType == common.Sequencer {
+		var screener screening.Screener
+		if config.ScreeningDenyListPath != "" {
+			denyList, err := screening.LoadDenyList(config.ScreeningDenyListPath)
+			if err != nil {
+				logger.Crit("Could not load the screening deny list", log.ErrKey, err)
+			}
+			logger.Info("Loaded the screening deny list. It applies once the governance publishes its policy hash", "policyHash", denyList.PolicyHash())
+			screener = denyList
+		}
 		service = nodetype.NewSequencer(
 			blockProcessor,
 			batchExecutor,
@@ -206,6 +236,8 @@ func NewEnclave(
 			rProducer,
 			rConsumer,
 			rollupCompression,
+			components.NewRollupBuilder(config.SequencerID, storage, logger),
+			governanceManager,
 			logger,
 			config.HostID,
 			chainConfig,
@@ -215,21 +247,23 @@ func NewEnclave(
 			dataEncryptionService,
 			dataCompressionService,
 			nodetype.SequencerSettings{
-				MaxBatchSize:      config.MaxBatchSize,
-				MaxRollupSize:     config.MaxRollupSize,
-				GasPaymentAddress: config.GasPaymentAddress,
-				BatchGasLimit:     config.GasBatchExecutionLimit,
-				BaseFee:           config.BaseFee,
+				MaxBatchSize:                config.MaxBatchSize,
+				MaxRollupSize:               config.MaxRollupSize,
+				GasPaymentAddress:           config.GasPaymentAddress,
+				BatchGasLimit:               config.GasBatchExecutionLimit,
+				BaseFee:                     config.BaseFee,
+				BatchBudget:                 evm.ExecutionBudget{Duration: config.BatchTimeBudget, Gas: config.BatchGasBudget},
+				StateRootCheckpointInterval: config.StateRootCheckpointInterval,
+				Screener:                    screener,
 			},
 			blockchain,
 		)
-	} else {
-		service = nodetype.NewValidator(blockProcessor, batchExecutor, registry, rConsumer, chainConfig, config.SequencerID, storage, sigVerifier, logger)
 	}
 
 	chain := l2chain.NewChain(
 		storage,
 		chainConfig,
+		config.L1MessageVerifierFork,
 		genesis,
 		logger,
 		registry,
@@ -259,6 +293,8 @@ func NewEnclave(
 		mgmtContractLib:        mgmtContractLib,
 		attestationProvider:    attestationProvider,
 		sharedSecretProcessor:  sharedSecretProcessor,
+		governance:             governanceManager,
+		rollupAuditor:          rollupAuditor,
 		enclaveKey:             enclaveKey,
 		enclavePubKey:          serializedEnclavePubKey,
 		dataEncryptionService:  dataEncryptionService,
@@ -266,11 +302,13 @@ func NewEnclave(
 		profiler:               prof,
 		logger:                 logger,
 		debugger:               debug,
+		debugProfiler:          debugger.NewProfiler(config.ProfilingAdminAddress, gethcrypto.PubkeyToAddress(enclaveKey.PublicKey), uint64(config.ObscuroChainID), storage),
 		stopControl:            stopcontrol.New(),
 
-		chain:    chain,
-		registry: registry,
-		service:  service,
+		chain:         chain,
+		registry:      registry,
+		service:       service,
+		batchFollower: validator,
 
 		mainMutex: sync.Mutex{},
 	}
@@ -311,17 +349,25 @@ func (e *enclaveImpl) Status() (common.Status, common.SystemError) {
 	_, err := e.storage.FetchSecret()
 	if err != nil {
 		if errors.Is(err, errutil.ErrNotFound) {
-			return common.Status{StatusCode: common.AwaitingSecret, L2Head: _noHeadBatch}, nil
+			return common.Status{StatusCode: common.AwaitingSecret, L2Head: _noHeadBatch, Time: time.Now()}, nil
 		}
 		return common.Status{StatusCode: common.Unavailable}, responses.ToInternalError(err)
 	}
 	var l1HeadHash gethcommon.Hash
+	var rollupInterval time.Duration
 	l1Head, err := e.l1BlockProcessor.GetHead()
 	if err != nil {
 		// this might be normal while enclave is starting up, just send empty hash
 		e.logger.Debug("failed to fetch L1 head block for status response", log.ErrKey, err)
 	} else {
 		l1HeadHash = l1Head.Hash()
+		// the host produces the rollups, so it follows the interval in force at the L1 head
+		governed, err := e.governance.ActiveParameters(l1Head)
+		if err != nil {
+			e.logger.Warn("failed to resolve the governed parameters for status response", log.ErrKey, err)
+		} else {
+			rollupInterval = governed.RollupInterval
+		}
 	}
 	// we use zero when there's no head batch yet, the first seq number is 1
 	l2HeadSeqNo := _noHeadBatch
@@ -333,7 +379,7 @@ func (e *enclaveImpl) Status() (common.Status, common.SystemError) {
 	} else {
 		l2HeadSeqNo = currSeqNo
 	}
-	return common.Status{StatusCode: common.Running, L1Head: l1HeadHash, L2Head: l2HeadSeqNo}, nil
+	return common.Status{StatusCode: common.Running, L1Head: l1HeadHash, L2Head: l2HeadSeqNo, RollupInterval: rollupInterval, Time: time.Now()}, nil
 }
 
 // StopClient is only implemented by the RPC wrapper
@@ -384,13 +430,23 @@ func (e *enclaveImpl) StreamL2Updates() (chan common.StreamL2UpdatesResponse, fu
 		}
 	})
 
+	e.rollupConsumer.SubscribeForProgress(func(progress *common.RollupSyncProgress) {
+		l2UpdatesChannel <- common.StreamL2UpdatesResponse{SyncProgress: progress}
+	})
+
+	e.rollupConsumer.SubscribeForRollupStats(func(stats *common.RollupCompressionStats) {
+		l2UpdatesChannel <- common.StreamL2UpdatesResponse{RollupStats: stats}
+	})
+
 	return l2UpdatesChannel, func() {
 		e.registry.UnsubscribeFromBatches()
+		e.rollupConsumer.UnsubscribeFromProgress()
+		e.rollupConsumer.UnsubscribeFromRollupStats()
 	}
 }
 
 // SubmitL1Block is used to update the enclave with an additional L1 block.
-func (e *enclaveImpl) SubmitL1Block(block types.Block, receipts types.Receipts, _ bool) (*common.BlockSubmissionResponse, common.SystemError) {
+func (e *enclaveImpl) SubmitL1Block(block types.Block, receipts types.Receipts, isLatest bool) (*common.BlockSubmissionResponse, common.SystemError) {
 	if e.stopControl.IsStopping() {
 		return nil, responses.ToInternalError(fmt.Errorf("requested SubmitL1Block with the enclave stopping"))
 	}
@@ -406,7 +462,7 @@ func (e *enclaveImpl) SubmitL1Block(block types.Block, receipts types.Receipts,
 		return nil, e.rejectBlockErr(fmt.Errorf("could not submit L1 block. Cause: %w", err))
 	}
 
-	result, err := e.ingestL1Block(br)
+	result, err := e.ingestL1Block(br, isLatest)
 	if err != nil {
 		return nil, e.rejectBlockErr(fmt.Errorf("could not submit L1 block. Cause: %w", err))
 	}
@@ -424,8 +480,12 @@ func (e *enclaveImpl) SubmitL1Block(block types.Block, receipts types.Receipts,
 	return bsr, nil
 }
 
-func (e *enclaveImpl) ingestL1Block(br *common.BlockAndReceipts) (*components.BlockIngestionType, error) {
+func (e *enclaveImpl) ingestL1Block(br *common.BlockAndReceipts, isLatest bool) (*components.BlockIngestionType, error) {
 	e.logger.Info("Start ingesting block", log.BlockHashKey, br.Block.Hash())
+	// the rollups deferred during a catch-up are executed before a block that does not extend them, such as a fork
+	parent := br.Block.ParentHash()
+	e.logRollupErrors(e.rollupConsumer.ExecuteDeferredRollups(&parent))
+
 	ingestion, err := e.l1BlockProcessor.Process(br)
 	if err != nil {
 		// only warn for unexpected errors
@@ -437,11 +497,9 @@ func (e *enclaveImpl) ingestL1Block(br *common.BlockAndReceipts) (*components.Bl
 		return nil, err
 	}
 
-	err = e.rollupConsumer.ProcessRollupsInBlock(br)
-	if err != nil && !errors.Is(err, components.ErrDuplicateRollup) {
-		e.logger.Error("Encountered error while processing l1 block", log.ErrKey, err)
-		// Unsure what to do here; block has been stored
-	}
+	// the block has been stored, the failures of its rollups, or of the rollups of the blocks before it whose execution
+	// was deferred, do not prevent its ingestion
+	e.logRollupErrors(e.rollupConsumer.ProcessRollupsInBlock(br, isLatest))
 
 	if ingestion.IsFork() {
 		err := e.service.OnL1Fork(ingestion.ChainFork)
@@ -452,6 +510,20 @@ func (e *enclaveImpl) ingestL1Block(br *common.BlockAndReceipts) (*components.Bl
 	return ingestion, nil
 }
 
+// logRollupErrors logs the failures of the rollups of each block against the block that carried them
+func (e *enclaveImpl) logRollupErrors(err error) {
+	blockErrs := components.BlockRollupsErrors(err)
+	if err != nil && len(blockErrs) == 0 {
+		e.logger.Error("Encountered error while processing rollups", log.ErrKey, err)
+	}
+	for _, blockErr := range blockErrs {
+		if errors.Is(blockErr, components.ErrDuplicateRollup) {
+			continue
+		}
+		e.logger.Error("Encountered error while processing the rollups of l1 block", log.BlockHashKey, blockErr.L1Block, log.ErrKey, blockErr.Err)
+	}
+}
+
 func (e *enclaveImpl) SubmitTx(tx common.EncryptedTx) (*responses.RawTx, common.SystemError) {
 	if e.stopControl.IsStopping() {
 		return nil, responses.ToInternalError(fmt.Errorf("requested SubmitTx with the enclave stopping"))
@@ -503,14 +575,6 @@ func (e *enclaveImpl) SubmitTx(tx common.EncryptedTx) (*responses.RawTx, common.
 	return responses.AsEncryptedResponse(&hash, vkHandler), nil
 }
 
-func (e *enclaveImpl) Validator() nodetype.ObsValidator {
-	validator, ok := e.service.(nodetype.ObsValidator)
-	if !ok {
-		panic("enclave service is not a validator but validator was requested!")
-	}
-	return validator
-}
-
 func (e *enclaveImpl) Sequencer() nodetype.Sequencer {
 	sequencer, ok := e.service.(nodetype.Sequencer)
 	if !ok {
@@ -527,12 +591,12 @@ func (e *enclaveImpl) SubmitBatch(extBatch *common.ExtBatch) common.SystemError
 	core.LogMethodDuration(e.logger, measure.NewStopwatch(), "SubmitBatch call completed.", log.BatchHashKey, extBatch.Hash())
 
 	e.logger.Info("Received new p2p batch", log.BatchHeightKey, extBatch.Header.Number, log.BatchHashKey, extBatch.Hash(), "l1", extBatch.Header.L1Proof)
-	batch, err := core.ToBatch(extBatch, e.dataEncryptionService, e.dataCompressionService)
+	batch, err := core.ToBatch(extBatch, e.dataEncryptionService, e.dataCompressionService, e.config.DecompressionLimitsOrDefault())
 	if err != nil {
 		return responses.ToInternalError(fmt.Errorf("could not convert batch. Cause: %w", err))
 	}
 
-	err = e.Validator().VerifySequencerSignature(batch)
+	err = e.batchFollower.VerifySequencerSignature(batch)
 	if err != nil {
 		return responses.ToInternalError(fmt.Errorf("invalid batch received. Could not verify signature. Cause: %w", err))
 	}
@@ -546,7 +610,7 @@ func (e *enclaveImpl) SubmitBatch(extBatch *common.ExtBatch) common.SystemError
 		return responses.ToInternalError(fmt.Errorf("could not store batch. Cause: %w", err))
 	}
 
-	err = e.Validator().ExecuteStoredBatches()
+	err = e.batchFollower.ExecuteStoredBatches()
 	if err != nil {
 		return responses.ToInternalError(fmt.Errorf("could not execute batches. Cause: %w", err))
 	}
@@ -961,6 +1025,11 @@ func (e *enclaveImpl) Stop() common.SystemError {
 		}
 	}
 
+	// the rollups of the last blocks ingested are not processed again after a restart
+	e.mainMutex.Lock()
+	e.logRollupErrors(e.rollupConsumer.ExecuteDeferredRollups(nil))
+	e.mainMutex.Unlock()
+
 	if e.registry != nil {
 		e.registry.UnsubscribeFromBatches()
 	}
@@ -1072,44 +1141,126 @@ func (e *enclaveImpl) GetLogs(encryptedParams common.EncryptedParamsGetLogs) (*r
 		return responses.AsPlaintextError(fmt.Errorf("unable to create VK encryptor - %w", err)), nil
 	}
 
-	// todo logic to check that the filter is valid
-	// can't have both from and blockhash
-	// from <=to
-	// todo (@stefan) - return user error
-	if filter.BlockHash != nil && filter.FromBlock != nil {
-		return responses.AsEncryptedError(fmt.Errorf("invalid filter. Cannot have both blockhash and fromBlock"), vkHandler), nil
+	from, to, err := e.logsRange(filter)
+	if err != nil {
+		if errors.Is(err, syserr.InternalError{}) {
+			return nil, responses.ToInternalError(err)
+		}
+		return responses.AsEncryptedError(err, vkHandler), nil
+	}
+	if span := new(big.Int).Sub(to, from).Uint64() + 1; e.config.MaxLogsBlockRange > 0 && span > e.config.MaxLogsBlockRange {
+		err = fmt.Errorf("the filter spans %d batches, more than the limit of %d, use the %s query to fetch the logs page by page", span, e.config.MaxLogsBlockRange, common.LogsPageCQMethod)
+		return responses.AsEncryptedError(err, vkHandler), nil
 	}
 
-	from := filter.FromBlock
-	if from != nil && from.Int64() < 0 {
-		batch, err := e.storage.FetchBatchBySeqNo(e.registry.HeadBatchSeq().Uint64())
-		if err != nil {
-			return responses.AsPlaintextError(fmt.Errorf("could not retrieve head batch. Cause: %w", err)), nil
+	// We retrieve the relevant logs that match the filter, one more than the limit to tell when it is exceeded.
+	limit := e.config.MaxLogsPerQuery
+	if limit > 0 {
+		limit++
+	}
+	filteredLogs, err := e.storage.FilterLogsPage(forAddress, from, to, filter.Addresses, filter.Topics, nil, limit)
+	if err != nil {
+		if errors.Is(err, syserr.InternalError{}) {
+			return nil, responses.ToInternalError(err)
 		}
-		from = batch.Number()
+		err = fmt.Errorf("could not retrieve logs matching the filter. Cause: %w", err)
+		return responses.AsEncryptedError(err, vkHandler), nil
 	}
+	if e.config.MaxLogsPerQuery > 0 && len(filteredLogs) > e.config.MaxLogsPerQuery {
+		err = fmt.Errorf("the filter matches more than %d logs, use the %s query to fetch the logs page by page", e.config.MaxLogsPerQuery, common.LogsPageCQMethod)
+		return responses.AsEncryptedError(err, vkHandler), nil
+	}
+
+	return responses.AsEncryptedResponse(&filteredLogs, vkHandler), nil
+}
 
-	// Set from to the height of the block hash
-	if from == nil && filter.BlockHash != nil {
+// logsRange returns the range of batch heights searched by a logs filter, both included. The range starts at the genesis
+// batch and ends at the head batch by default, and it is the batch of the hash of the filter when it has one
+func (e *enclaveImpl) logsRange(filter *filters.FilterCriteria) (*big.Int, *big.Int, error) {
+	// todo (@stefan) - return user error
+	if filter.BlockHash != nil && filter.FromBlock != nil {
+		return nil, nil, fmt.Errorf("invalid filter. Cannot have both blockhash and fromBlock")
+	}
+	if filter.BlockHash != nil {
 		batch, err := e.storage.FetchBatchHeader(*filter.BlockHash)
 		if err != nil {
-			return nil, responses.ToInternalError(err)
+			return nil, nil, syserr.NewInternalError(err)
 		}
-		from = batch.Number
+		return batch.Number, batch.Number, nil
 	}
 
-	to := filter.ToBlock
-	// when to=="latest", don't filter on it
-	if to != nil && to.Int64() < 0 {
-		to = nil
+	head, err := e.registry.GetBatchAtHeight(gethrpc.LatestBlockNumber)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not retrieve head batch. Cause: %w", err)
+	}
+	from, to := filter.FromBlock, filter.ToBlock
+	switch {
+	case from == nil:
+		from = big.NewInt(0)
+	case from.Sign() < 0:
+		from = head.Number()
+	}
+	// when to=="latest", the range ends at the head batch
+	if to == nil || to.Sign() < 0 {
+		to = head.Number()
+	}
+	if from.Cmp(to) > 0 {
+		return nil, nil, fmt.Errorf("invalid filter. from (%d) > to (%d)", from, to)
 	}
+	return from, to, nil
+}
 
-	if from != nil && to != nil && from.Cmp(to) > 0 {
-		return responses.AsEncryptedError(fmt.Errorf("invalid filter. from (%d) > to (%d)", from, to), vkHandler), nil
+// getLogsPage returns a page of the logs matching an eth_getLogs filter, with the cursor the next page starts from. A page
+// searches at most MaxLogsBlockRange batches and holds at most MaxLogsPerQuery logs, so that the ranges and the result
+// sets too big for eth_getLogs are fetched incrementally.
+// Parameters are [ViewingKey, LogsPageCQMethod, LogsPageQuery, null]
+func (e *enclaveImpl) getLogsPage(paramList []interface{}) (*responses.PrivateQueryResponse, common.SystemError) {
+	query, err := gethencoding.ExtractLogsPageQuery(paramList[2])
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to extract query - %w", err)), nil
+	}
+	filter, forAddress, err := extractGetLogsParams([]interface{}{query.Filter, query.Address.Hex()})
+	if err != nil {
+		return responses.AsPlaintextError(err), nil
 	}
 
-	// We retrieve the relevant logs that match the filter.
-	filteredLogs, err := e.storage.FilterLogs(forAddress, from, to, nil, filter.Addresses, filter.Topics)
+	vkHandler, err := createVKHandler(forAddress, paramList[0], e.config.ObscuroChainID)
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to create VK encryptor - %w", err)), nil
+	}
+
+	from, to, err := e.logsRange(filter)
+	if err != nil {
+		if errors.Is(err, syserr.InternalError{}) {
+			return nil, responses.ToInternalError(err)
+		}
+		return responses.AsEncryptedError(err, vkHandler), nil
+	}
+
+	// the page starts at the cursor, unless it is before the range of the filter
+	cursor := query.Cursor
+	if cursor != nil && cursor.BatchHeight < from.Uint64() {
+		cursor = nil
+	}
+	if cursor != nil {
+		from = new(big.Int).SetUint64(cursor.BatchHeight)
+	}
+	if from.Cmp(to) > 0 {
+		return responses.AsEncryptedResponse(&common.LogsPage{Logs: []*types.Log{}}, vkHandler), nil
+	}
+	pageTo := to
+	if e.config.MaxLogsBlockRange > 0 {
+		if last := new(big.Int).Add(from, new(big.Int).SetUint64(e.config.MaxLogsBlockRange-1)); last.Cmp(to) < 0 {
+			pageTo = last
+		}
+	}
+
+	// We retrieve one more log than the page holds, which is the first log of the next page.
+	limit := e.config.MaxLogsPerQuery
+	if limit > 0 {
+		limit++
+	}
+	logs, err := e.storage.FilterLogsPage(forAddress, from, pageTo, filter.Addresses, filter.Topics, cursor, limit)
 	if err != nil {
 		if errors.Is(err, syserr.InternalError{}) {
 			return nil, responses.ToInternalError(err)
@@ -1118,7 +1269,16 @@ func (e *enclaveImpl) GetLogs(encryptedParams common.EncryptedParamsGetLogs) (*r
 		return responses.AsEncryptedError(err, vkHandler), nil
 	}
 
-	return responses.AsEncryptedResponse(&filteredLogs, vkHandler), nil
+	page := &common.LogsPage{Logs: logs}
+	switch {
+	case e.config.MaxLogsPerQuery > 0 && len(logs) > e.config.MaxLogsPerQuery:
+		next := logs[e.config.MaxLogsPerQuery]
+		page.Logs = logs[:e.config.MaxLogsPerQuery]
+		page.Cursor = &common.LogCursor{BatchHeight: next.BlockNumber, TxIndex: next.TxIndex, LogIndex: next.Index}
+	case pageTo.Cmp(to) < 0:
+		page.Cursor = &common.LogCursor{BatchHeight: pageTo.Uint64() + 1}
+	}
+	return responses.AsEncryptedResponse(page, vkHandler), nil
 }
 
 // DoEstimateGas returns the estimation of minimum gas required to execute transaction
@@ -1299,6 +1459,100 @@ func (e *enclaveImpl) DebugEventLogRelevancy(txHash gethcommon.Hash) (json.RawMe
 	return jsonMsg, nil
 }
 
+func (e *enclaveImpl) DebugProfile(request *common.ProfileRequest) ([]byte, common.SystemError) {
+	// ensure the enclave is running
+	if e.stopControl.IsStopping() {
+		return nil, responses.ToInternalError(fmt.Errorf("requested DebugProfile with the enclave stopping"))
+	}
+
+	e.logger.Info("Profiling the enclave", "kind", request.Kind, "seconds", uint64(request.Seconds))
+	profile, err := e.debugProfiler.Profile(request)
+	if err != nil {
+		return nil, responses.ToInternalError(err)
+	}
+	return profile, nil
+}
+
+func (e *enclaveImpl) MempoolReport() (*common.MempoolReport, common.SystemError) {
+	// ensure the enclave is running
+	if e.stopControl.IsStopping() {
+		return nil, responses.ToInternalError(fmt.Errorf("requested MempoolReport with the enclave stopping"))
+	}
+	if e.config.NodeType != common.Sequencer {
+		return nil, responses.ToInternalError(fmt.Errorf("only the sequencer has a mempool"))
+	}
+
+	return e.Sequencer().MempoolReport(), nil
+}
+
+func (e *enclaveImpl) MempoolStats() (*common.MempoolStats, common.SystemError) {
+	// ensure the enclave is running
+	if e.stopControl.IsStopping() {
+		return nil, responses.ToInternalError(fmt.Errorf("requested MempoolStats with the enclave stopping"))
+	}
+	if e.config.NodeType != common.Sequencer {
+		return nil, responses.ToInternalError(fmt.Errorf("only the sequencer has a mempool"))
+	}
+
+	return e.Sequencer().MempoolStats(), nil
+}
+
+// the witnesses are sent in a single gRPC message, so they must fit in the default max message size
+const maxRollupWitnessSize = 4*1024*1024 - 1024
+
+func (e *enclaveImpl) RollupWitness(rollupHash common.L2RollupHash) ([]byte, common.SystemError) {
+	// ensure the enclave is running
+	if e.stopControl.IsStopping() {
+		return nil, responses.ToInternalError(fmt.Errorf("requested RollupWitness with the enclave stopping"))
+	}
+
+	witness, err := e.rollupAuditor.Witness(rollupHash)
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			return nil, responses.ToInternalError(fmt.Errorf("rollup %s not found", rollupHash))
+		}
+		return nil, responses.ToInternalError(fmt.Errorf("could not produce the witness of rollup %s - %w", rollupHash, err))
+	}
+	encoded, err := rlp.EncodeToBytes(witness)
+	if err != nil {
+		return nil, responses.ToInternalError(fmt.Errorf("could not encode the witness - %w", err))
+	}
+	// the witness holds the transactions and the state they read, so it is encrypted like the rollups, for the enclaves only
+	encrypted, err := e.dataEncryptionService.Encrypt(encoded)
+	if err != nil {
+		return nil, responses.ToInternalError(fmt.Errorf("could not encrypt the witness - %w", err))
+	}
+	if len(encrypted) > maxRollupWitnessSize {
+		return nil, responses.ToInternalError(fmt.Errorf("the witness of rollup %s is %d bytes, above the limit of %d bytes", rollupHash, len(encrypted), maxRollupWitnessSize))
+	}
+	return encrypted, nil
+}
+
+func (e *enclaveImpl) VerifyRollupWitness(witness []byte) (*common.RollupVerdict, common.SystemError) {
+	// ensure the enclave is running
+	if e.stopControl.IsStopping() {
+		return nil, responses.ToInternalError(fmt.Errorf("requested VerifyRollupWitness with the enclave stopping"))
+	}
+
+	decrypted, err := e.dataEncryptionService.Decrypt(witness)
+	if err != nil {
+		return nil, responses.ToInternalError(fmt.Errorf("could not decrypt the witness - %w", err))
+	}
+	var decoded components.RollupWitness
+	if err = rlp.DecodeBytes(decrypted, &decoded); err != nil {
+		return nil, responses.ToInternalError(fmt.Errorf("could not decode the witness - %w", err))
+	}
+
+	verdict := e.rollupAuditor.Verify(&decoded)
+	e.logger.Info("Verified rollup witness", "rollup", verdict.RollupHash, "valid", verdict.Valid, "reason", verdict.Reason)
+	verdict.Enclave = gethcrypto.PubkeyToAddress(e.enclaveKey.PublicKey)
+	verdict.Signature, err = gethcrypto.Sign(verdict.Hash().Bytes(), e.enclaveKey)
+	if err != nil {
+		return nil, responses.ToInternalError(fmt.Errorf("could not sign the verdict - %w", err))
+	}
+	return verdict, nil
+}
+
 func (e *enclaveImpl) GetTotalContractCount() (*big.Int, common.SystemError) {
 	// ensure the enclave is running
 	if e.stopControl.IsStopping() {
@@ -1325,6 +1579,37 @@ func (e *enclaveImpl) GetCustomQuery(encryptedParams common.EncryptedParamsGetSt
 		return responses.AsPlaintextError(fmt.Errorf("unexpected number of parameters")), nil
 	}
 
+	if method, ok := paramList[1].(string); ok && method == common.SimulateTransactionCQMethod {
+		return e.simulateTransaction(paramList)
+	}
+	if method, ok := paramList[1].(string); ok && method == common.AccountProofCQMethod {
+		return e.getAccountProof(paramList)
+	}
+	if method, ok := paramList[1].(string); ok && method == common.ProofCQMethod {
+		return e.getProof(paramList)
+	}
+	if method, ok := paramList[1].(string); ok && method == common.LogsPageCQMethod {
+		return e.getLogsPage(paramList)
+	}
+	if method, ok := paramList[1].(string); ok && method == common.TransactionByNonceCQMethod {
+		return e.getTransactionByNonce(paramList)
+	}
+	if method, ok := paramList[1].(string); ok && method == common.TransactionErrorCQMethod {
+		return e.getTransactionError(paramList)
+	}
+	if method, ok := paramList[1].(string); ok && method == common.FeeRevenueCQMethod {
+		return e.getFeeRevenue(paramList)
+	}
+	if method, ok := paramList[1].(string); ok && method == common.AddressBatchesCQMethod {
+		return e.listAddressBatches(paramList)
+	}
+	if method, ok := paramList[1].(string); ok && method == common.RelayableMessagesCQMethod {
+		return e.listRelayableMessages(paramList)
+	}
+	if method, ok := paramList[1].(string); ok && method == common.RelayFeeQuoteCQMethod {
+		return e.quoteRelayFee(paramList)
+	}
+
 	privateCustomQuery, err := gethencoding.ExtractPrivateCustomQuery(paramList[1], paramList[2])
 	if err != nil {
 		return responses.AsPlaintextError(fmt.Errorf("unable to extract query - %w", err)), nil
@@ -1353,6 +1638,420 @@ func (e *enclaveImpl) GetCustomQuery(encryptedParams common.EncryptedParamsGetSt
 	}, vkHandler), nil
 }
 
+// simulateTransaction executes a transaction against the state of the requested batch (the head batch by default) without
+// submitting it, and returns the gas used, the revert reason and the logs visible to the sender.
+// Parameters are [ViewingKey, SimulateTransactionCQMethod, TransactionArgs, BlockNumber]
+func (e *enclaveImpl) simulateTransaction(paramList []interface{}) (*responses.PrivateQueryResponse, common.SystemError) {
+	apiArgs, err := gethencoding.ExtractEthCall(paramList[2])
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to decode the transaction - %w", err)), nil
+	}
+
+	// encryption will fail if no From address is provided
+	if apiArgs.From == nil {
+		return responses.AsPlaintextError(fmt.Errorf("no from address provided")), nil
+	}
+
+	// extract, create and validate the VK encryption handler
+	vkHandler, err := createVKHandler(apiArgs.From, paramList[0], e.config.ObscuroChainID)
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to create VK encryptor - %w", err)), nil
+	}
+
+	blkNumber := gethrpc.LatestBlockNumber
+	if paramList[3] != nil {
+		requested, err := gethencoding.ExtractBlockNumber(paramList[3])
+		if err != nil {
+			return responses.AsEncryptedError(fmt.Errorf("unable to extract requested block number - %w", err), vkHandler), nil
+		}
+		blkNumber = *requested
+	}
+
+	result, logs, stateDB, err := e.chain.SimulateTransaction(apiArgs, &blkNumber)
+	if err != nil {
+		e.logger.Debug("Failed to simulate transaction.", log.ErrKey, err)
+
+		// make sure it's not some internal error
+		if errors.Is(err, syserr.InternalError{}) {
+			return nil, responses.ToInternalError(err)
+		}
+
+		// make sure to serialize any possible EVM error
+		evmErr, err := serializeEVMError(err)
+		if err == nil {
+			err = fmt.Errorf(string(evmErr))
+		}
+		return responses.AsEncryptedError(err, vkHandler), nil
+	}
+
+	simulated := &common.SimulatedTransaction{
+		GasUsed:    hexutil.Uint64(result.UsedGas),
+		Failed:     result.Failed(),
+		ReturnData: result.ReturnData,
+		Logs:       events.FilterLogsForAccount(logs, stateDB, e.config.RelevancyRulesForks, apiArgs.From),
+	}
+	if result.Err != nil {
+		simulated.Error = result.Err.Error()
+	}
+	if reason, err := abi.UnpackRevert(result.Revert()); err == nil {
+		simulated.RevertReason = reason
+		if bridgeErr := errutil.BridgeErrorFromRevert(reason); bridgeErr != nil {
+			simulated.BridgeError = bridgeErr.Code
+		}
+	}
+	return responses.AsEncryptedResponse(simulated, vkHandler), nil
+}
+
+// getTransactionByNonce returns the transaction of the account included with the nonce, so that the wallets know which
+// of the replacements of a transaction made it into the chain. An error is returned while none is included.
+// Parameters are [ViewingKey, TransactionByNonceCQMethod, TransactionByNonceQuery, null]
+func (e *enclaveImpl) getTransactionByNonce(paramList []interface{}) (*responses.PrivateQueryResponse, common.SystemError) {
+	query, err := gethencoding.ExtractTransactionByNonceQuery(paramList[2])
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to extract query - %w", err)), nil
+	}
+
+	// the transactions are only returned to their sender
+	vkHandler, err := createVKHandler(&query.Address, paramList[0], e.config.ObscuroChainID)
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to create VK encryptor - %w", err)), nil
+	}
+
+	tx, err := e.storage.GetTransactionBySenderNonce(query.Address, uint64(query.Nonce))
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			return responses.AsEncryptedError(fmt.Errorf("no transaction included with nonce %d", query.Nonce), vkHandler), nil
+		}
+		return nil, responses.ToInternalError(fmt.Errorf("unable to fetch the transaction with nonce %d - %w", query.Nonce, err))
+	}
+	return responses.AsEncryptedResponse(tx, vkHandler), nil
+}
+
+// getTransactionError returns why a transaction failed. The details are only returned to the sender of the transaction,
+// the other accounts get a generic failure.
+// Parameters are [ViewingKey, TransactionErrorCQMethod, TransactionErrorQuery, null]
+func (e *enclaveImpl) getTransactionError(paramList []interface{}) (*responses.PrivateQueryResponse, common.SystemError) {
+	query, err := gethencoding.ExtractTransactionErrorQuery(paramList[2])
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to extract query - %w", err)), nil
+	}
+
+	vkHandler, err := createVKHandler(&query.Address, paramList[0], e.config.ObscuroChainID)
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to create VK encryptor - %w", err)), nil
+	}
+
+	failure, err := e.storage.FetchTxFailure(query.TxHash)
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			return responses.AsEncryptedError(fmt.Errorf("no failure recorded for transaction %s", query.TxHash), vkHandler), nil
+		}
+		return nil, responses.ToInternalError(fmt.Errorf("unable to fetch the failure of transaction %s - %w", query.TxHash, err))
+	}
+	if failure.Stage == common.TxFailedValidation {
+		// a transaction excluded from a batch can be included in a later one, which replaces the failure if it fails again
+		if _, err := e.storage.GetTransactionReceipt(query.TxHash); err == nil {
+			return responses.AsEncryptedError(fmt.Errorf("no failure recorded for transaction %s", query.TxHash), vkHandler), nil
+		}
+	}
+	if failure.Sender != query.Address {
+		return responses.AsEncryptedError(fmt.Errorf("transaction %s failed", query.TxHash), vkHandler), nil
+	}
+
+	if reason, err := abi.UnpackRevert(failure.RevertData); err == nil {
+		failure.RevertReason = reason
+	}
+	return responses.AsEncryptedResponse(failure, vkHandler), nil
+}
+
+// getAccountProof returns the Merkle proof of the state of the requesting account, and of its entries in the requested
+// contract mappings, against the state root of a batch.
+// Parameters are [ViewingKey, AccountProofCQMethod, AccountProofQuery, null]
+func (e *enclaveImpl) getAccountProof(paramList []interface{}) (*responses.PrivateQueryResponse, common.SystemError) {
+	query, err := gethencoding.ExtractAccountProofQuery(paramList[2])
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to extract query - %w", err)), nil
+	}
+
+	// the proofs are only returned to the owner of the account
+	vkHandler, err := createVKHandler(&query.Address, paramList[0], e.config.ObscuroChainID)
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to create VK encryptor - %w", err)), nil
+	}
+	if err := query.BatchSeqNo.Validate(); err != nil {
+		return responses.AsEncryptedError(err, vkHandler), nil
+	}
+
+	batch, err := e.storage.FetchBatchBySeqNo(uint64(query.BatchSeqNo))
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			return responses.AsEncryptedError(fmt.Errorf("batch %d not found", query.BatchSeqNo), vkHandler), nil
+		}
+		return nil, responses.ToInternalError(fmt.Errorf("unable to fetch batch %d - %w", query.BatchSeqNo, err))
+	}
+	stateDB, err := e.storage.CreateStateDB(batch.Hash())
+	if err != nil {
+		return nil, responses.ToInternalError(fmt.Errorf("unable to open the state of batch %d - %w", query.BatchSeqNo, err))
+	}
+
+	proof, err := accountProof(stateDB, query.Address)
+	if err != nil {
+		return nil, responses.ToInternalError(err)
+	}
+	proof.BatchSeqNo = query.BatchSeqNo
+	proof.StateRoot = batch.Header.Root
+
+	for _, mapping := range query.Mappings {
+		contract, err := accountProof(stateDB, mapping.Contract)
+		if err != nil {
+			return nil, responses.ToInternalError(err)
+		}
+		for _, slot := range mapping.Slots {
+			key := common.MappingKey(query.Address, uint64(slot))
+			entry := common.MappingEntryProof{
+				Contract:      mapping.Contract,
+				Slot:          slot,
+				Key:           key,
+				Value:         stateDB.GetState(mapping.Contract, key),
+				StorageHash:   contract.StorageHash,
+				ContractProof: contract.AccountProof,
+			}
+			// the contracts without storage have no storage trie, and the proof of the state root is enough
+			if contract.StorageHash != types.EmptyRootHash {
+				storageProof, err := stateDB.GetStorageProof(mapping.Contract, key)
+				if err != nil {
+					return nil, responses.ToInternalError(fmt.Errorf("unable to prove slot %d of contract %s - %w", slot, mapping.Contract, err))
+				}
+				entry.Proof = toHexBytes(storageProof)
+			}
+			proof.Mappings = append(proof.Mappings, entry)
+		}
+	}
+
+	return responses.AsEncryptedResponse(proof, vkHandler), nil
+}
+
+// maxProofStorageKeys bounds the number of storage slots proven by a single proof query
+const maxProofStorageKeys = 1024
+
+// getProof returns the Merkle proofs of an account and of its storage slots against the state root of a batch, like
+// eth_getProof. The proofs are encrypted for the account, or for the deployer of a contract, like its balance.
+// Parameters are [ViewingKey, ProofCQMethod, ProofQuery, null]
+func (e *enclaveImpl) getProof(paramList []interface{}) (*responses.PrivateQueryResponse, common.SystemError) {
+	query, err := gethencoding.ExtractProofQuery(paramList[2])
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to extract query - %w", err)), nil
+	}
+	if len(query.StorageKeys) > maxProofStorageKeys {
+		return responses.AsPlaintextError(fmt.Errorf("cannot prove more than %d storage slots at once", maxProofStorageKeys)), nil
+	}
+
+	var batch *core.Batch
+	if hash, ok := query.Block.Hash(); ok {
+		batch, err = e.storage.FetchBatch(hash)
+	} else {
+		number, ok := query.Block.Number()
+		if !ok {
+			number = gethrpc.LatestBlockNumber
+		}
+		batch, err = e.registry.GetBatchAtHeight(number)
+	}
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			return responses.AsPlaintextError(fmt.Errorf("batch not found")), nil
+		}
+		return nil, responses.ToInternalError(fmt.Errorf("unable to fetch the requested batch - %w", err))
+	}
+	stateDB, err := e.storage.CreateStateDB(batch.Hash())
+	if err != nil {
+		return nil, responses.ToInternalError(fmt.Errorf("unable to open the state of batch %s - %w", batch.Hash(), err))
+	}
+
+	owner, err := e.chain.GetAccountOwner(query.Address, stateDB)
+	if err != nil {
+		return nil, responses.ToInternalError(fmt.Errorf("unable to find the owner of account %s - %w", query.Address, err))
+	}
+	vkHandler, err := createVKHandler(owner, paramList[0], e.config.ObscuroChainID)
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to create VK encryptor - %w", err)), nil
+	}
+
+	account, err := accountProof(stateDB, query.Address)
+	if err != nil {
+		return nil, responses.ToInternalError(err)
+	}
+	proof := &common.Proof{
+		Address:      account.Address,
+		AccountProof: account.AccountProof,
+		Balance:      account.Balance,
+		CodeHash:     account.CodeHash,
+		Nonce:        account.Nonce,
+		StorageHash:  account.StorageHash,
+		StorageProof: make([]common.StorageProof, 0, len(query.StorageKeys)),
+		BatchHash:    batch.Hash(),
+		BatchSeqNo:   tentypes.BatchSeqNo(batch.SeqNo().Uint64()),
+		StateRoot:    batch.Header.Root,
+	}
+	for _, key := range query.StorageKeys {
+		slot := common.StorageProof{
+			Key:   key,
+			Value: (*hexutil.Big)(stateDB.GetState(query.Address, key).Big()),
+			Proof: []hexutil.Bytes{},
+		}
+		// the accounts without storage have no storage trie, and the proof of the account is enough
+		if account.StorageHash != types.EmptyRootHash {
+			storageProof, err := stateDB.GetStorageProof(query.Address, key)
+			if err != nil {
+				return nil, responses.ToInternalError(fmt.Errorf("unable to prove slot %s of account %s - %w", key, query.Address, err))
+			}
+			slot.Proof = toHexBytes(storageProof)
+		}
+		proof.StorageProof = append(proof.StorageProof, slot)
+	}
+
+	return responses.AsEncryptedResponse(proof, vkHandler), nil
+}
+
+// maxFeeRevenueBatches bounds the number of batches whose fees are summed by a single fee revenue query
+const maxFeeRevenueBatches = 10_000
+
+// getFeeRevenue returns the fees collected in a range of batches, or in the batches of a rollup, and how the base fees
+// were distributed. The fees are public to anyone running a validator, so any viewing key can query them.
+// Parameters are [ViewingKey, FeeRevenueCQMethod, FeeRevenueQuery, null]
+func (e *enclaveImpl) getFeeRevenue(paramList []interface{}) (*responses.PrivateQueryResponse, common.SystemError) {
+	query, err := gethencoding.ExtractFeeRevenueQuery(paramList[2])
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to extract query - %w", err)), nil
+	}
+
+	vkHandler, err := createVKHandler(&query.Address, paramList[0], e.config.ObscuroChainID)
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to create VK encryptor - %w", err)), nil
+	}
+
+	from, to := query.FromBatchSeqNo, query.ToBatchSeqNo
+	if query.RollupHash != nil {
+		start, end, err := e.storage.FetchRollupBatchRange(query.RollupHash.Hash())
+		if err != nil {
+			if errors.Is(err, errutil.ErrNotFound) {
+				return responses.AsEncryptedError(fmt.Errorf("rollup %s not found", query.RollupHash), vkHandler), nil
+			}
+			return nil, responses.ToInternalError(fmt.Errorf("unable to fetch rollup %s - %w", query.RollupHash, err))
+		}
+		from, to = tentypes.BatchSeqNo(start), tentypes.BatchSeqNo(end)
+	}
+	if err := from.Validate(); err != nil {
+		return responses.AsEncryptedError(err, vkHandler), nil
+	}
+	if to < from {
+		return responses.AsEncryptedError(fmt.Errorf("invalid range - batch %d is before batch %d", to, from), vkHandler), nil
+	}
+	if to-from >= maxFeeRevenueBatches {
+		return responses.AsEncryptedError(fmt.Errorf("the range can not span more than %d batches", maxFeeRevenueBatches), vkHandler), nil
+	}
+
+	fees, err := e.storage.FetchBatchFees(uint64(from), uint64(to))
+	if err != nil {
+		return nil, responses.ToInternalError(fmt.Errorf("unable to fetch the fees of batches %d to %d - %w", from, to, err))
+	}
+	return responses.AsEncryptedResponse(common.NewFeeRevenue(from, to, fees), vkHandler), nil
+}
+
+// maxAddressBatchesRange bounds the number of batches searched by a single address batches query
+const maxAddressBatchesRange = 10_000
+
+// listAddressBatches returns the canonical batches in a range that involve the account of the viewing key. The address
+// blooms of the batches select the candidates, which are then checked against their transactions and receipts.
+// Parameters are [ViewingKey, AddressBatchesCQMethod, AddressBatchesQuery, null]
+func (e *enclaveImpl) listAddressBatches(paramList []interface{}) (*responses.PrivateQueryResponse, common.SystemError) {
+	query, err := gethencoding.ExtractAddressBatchesQuery(paramList[2])
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to extract query - %w", err)), nil
+	}
+
+	vkHandler, err := createVKHandler(&query.Address, paramList[0], e.config.ObscuroChainID)
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to create VK encryptor - %w", err)), nil
+	}
+
+	from, to := query.FromBatchSeqNo, query.ToBatchSeqNo
+	if err := from.Validate(); err != nil {
+		return responses.AsEncryptedError(err, vkHandler), nil
+	}
+	if to < from {
+		return responses.AsEncryptedError(fmt.Errorf("invalid range - batch %d is before batch %d", to, from), vkHandler), nil
+	}
+	if to-from >= maxAddressBatchesRange {
+		return responses.AsEncryptedError(fmt.Errorf("the range can not span more than %d batches", maxAddressBatchesRange), vkHandler), nil
+	}
+
+	candidates, err := e.storage.FetchBatchSeqNosByAddress(query.Address, uint64(from), uint64(to))
+	if err != nil {
+		return nil, responses.ToInternalError(fmt.Errorf("unable to fetch the batches of %s - %w", query.Address, err))
+	}
+
+	result := make([]common.AddressBatch, 0)
+	for _, seqNo := range candidates {
+		batch, err := e.storage.FetchBatchBySeqNo(seqNo)
+		if err != nil {
+			return nil, responses.ToInternalError(fmt.Errorf("unable to fetch batch %d - %w", seqNo, err))
+		}
+		receipts, err := e.storage.GetReceiptsByBatchHash(batch.Hash())
+		if err != nil {
+			return nil, responses.ToInternalError(fmt.Errorf("unable to fetch the receipts of batch %s - %w", batch.Hash(), err))
+		}
+		involved, err := core.InvolvesAddress(batch, receipts, query.Address)
+		if err != nil {
+			return nil, responses.ToInternalError(err)
+		}
+		if involved {
+			result = append(result, common.AddressBatch{
+				SeqNo:  tentypes.BatchSeqNo(seqNo),
+				Hash:   batch.Hash(),
+				Height: hexutil.Uint64(batch.NumberU64()),
+			})
+		}
+	}
+	return responses.AsEncryptedResponse(&result, vkHandler), nil
+}
+
+// returns the proof of the account against the state root of the state db, with the proven state of the account
+func accountProof(stateDB *state.StateDB, address gethcommon.Address) (*common.AccountProof, error) {
+	proof, err := stateDB.GetProof(address)
+	if err != nil {
+		return nil, fmt.Errorf("unable to prove account %s - %w", address, err)
+	}
+
+	storageHash, codeHash := types.EmptyRootHash, types.EmptyCodeHash
+	if stateDB.Exist(address) {
+		codeHash = stateDB.GetCodeHash(address)
+		storageTrie, err := stateDB.StorageTrie(address)
+		if err != nil {
+			return nil, fmt.Errorf("unable to open the storage of account %s - %w", address, err)
+		}
+		if storageTrie != nil {
+			storageHash = storageTrie.Hash()
+		}
+	}
+
+	return &common.AccountProof{
+		Address:      address,
+		Balance:      (*hexutil.Big)(stateDB.GetBalance(address)),
+		Nonce:        hexutil.Uint64(stateDB.GetNonce(address)),
+		CodeHash:     codeHash,
+		StorageHash:  storageHash,
+		AccountProof: toHexBytes(proof),
+	}, nil
+}
+
+func toHexBytes(proof [][]byte) []hexutil.Bytes {
+	result := make([]hexutil.Bytes, len(proof))
+	for i, node := range proof {
+		result[i] = node
+	}
+	return result
+}
+
 func (e *enclaveImpl) GetPublicTransactionData(pagination *common.QueryPagination) (*common.TransactionListingResponse, common.SystemError) {
 	// ensure the enclave is running
 	if e.stopControl.IsStopping() {
@@ -1404,6 +2103,10 @@ func newRevertError(result *gethcore.ExecutionResult) *revertError {
 	err := errors.New("execution reverted")
 	if errUnpack == nil {
 		err = fmt.Errorf("execution reverted: %v", reason)
+		// the failures of the bridge contracts carry their code, for the UIs to act on them
+		if bridgeErr := errutil.BridgeErrorFromRevert(reason); bridgeErr != nil {
+			err = fmt.Errorf("execution reverted: %w", bridgeErr)
+		}
 	}
 	return &revertError{
 		error:  err,
@@ -1454,6 +2157,119 @@ func extractGetLogsParams(paramList []interface{}) (*filters.FilterCriteria, *ge
 	return &filter, &forAddress, nil
 }
 
+// maxRelayableMessagesRange bounds the number of batches searched by a single relayable messages query
+const maxRelayableMessagesRange = 10_000
+
+// listRelayableMessages returns the L2->L1 messages published in a range of batches that were not relayed yet, with their
+// relay fee and the quoted cost of their relay, the highest fees first. The messages are published in the rollups, so
+// any viewing key can query them.
+// Parameters are [ViewingKey, RelayableMessagesCQMethod, RelayableMessagesQuery, null]
+func (e *enclaveImpl) listRelayableMessages(paramList []interface{}) (*responses.PrivateQueryResponse, common.SystemError) {
+	query, err := gethencoding.ExtractRelayableMessagesQuery(paramList[2])
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to extract query - %w", err)), nil
+	}
+
+	vkHandler, err := createVKHandler(&query.Address, paramList[0], e.config.ObscuroChainID)
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to create VK encryptor - %w", err)), nil
+	}
+
+	from, to := query.FromBatchSeqNo, query.ToBatchSeqNo
+	if err := from.Validate(); err != nil {
+		return responses.AsEncryptedError(err, vkHandler), nil
+	}
+	if to < from {
+		return responses.AsEncryptedError(fmt.Errorf("invalid range - batch %d is before batch %d", to, from), vkHandler), nil
+	}
+	if to-from >= maxRelayableMessagesRange {
+		return responses.AsEncryptedError(fmt.Errorf("the range can not span more than %d batches", maxRelayableMessagesRange), vkHandler), nil
+	}
+	minFee := big.NewInt(0)
+	if query.MinFee != nil {
+		minFee = query.MinFee.ToInt()
+	}
+
+	headSeqNo := e.registry.HeadBatchSeq()
+	if headSeqNo == nil {
+		return responses.AsEncryptedError(errors.New("no batch was produced yet"), vkHandler), nil
+	}
+	headBatch, err := e.storage.FetchBatchBySeqNo(headSeqNo.Uint64())
+	if err != nil {
+		return nil, responses.ToInternalError(fmt.Errorf("unable to fetch the head batch - %w", err))
+	}
+	stateDB, err := e.storage.CreateStateDB(headBatch.Hash())
+	if err != nil {
+		return nil, responses.ToInternalError(fmt.Errorf("unable to open the state of the head batch - %w", err))
+	}
+	l1Head, err := e.l1BlockProcessor.GetHead()
+	if err != nil {
+		return nil, responses.ToInternalError(fmt.Errorf("unable to fetch the L1 head - %w", err))
+	}
+	rolledUpSeqNo, err := e.storage.FetchRolledUpBatchSeqNo(l1Head.NumberU64())
+	if err != nil && !errors.Is(err, errutil.ErrNotFound) {
+		return nil, responses.ToInternalError(fmt.Errorf("unable to fetch the last rolled up batch - %w", err))
+	}
+
+	outbound, err := e.storage.FetchOutboundMessages(uint64(from), uint64(to))
+	if err != nil {
+		return nil, responses.ToInternalError(fmt.Errorf("unable to fetch the messages of batches %d to %d - %w", from, to, err))
+	}
+	result := make([]common.RelayableMessage, 0)
+	for _, msg := range outbound {
+		fee, pending := crosschain.PendingRelayFee(stateDB, msg.Hash)
+		if !pending || fee.Cmp(minFee) < 0 {
+			continue
+		}
+		quote := crosschain.QuoteRelayFee(uint64(len(msg.Message.Payload)), l1BaseFee(l1Head))
+		result = append(result, common.RelayableMessage{
+			Hash:             msg.Hash,
+			BatchSeqNo:       tentypes.BatchSeqNo(msg.BatchSeqNo),
+			Sender:           msg.Message.Sender,
+			Sequence:         hexutil.Uint64(msg.Message.Sequence),
+			Nonce:            msg.Message.Nonce,
+			Topic:            msg.Message.Topic,
+			Payload:          msg.Message.Payload,
+			ConsistencyLevel: msg.Message.ConsistencyLevel,
+			Fee:              (*hexutil.Big)(fee),
+			RelayCost:        quote.Fee,
+			RolledUp:         msg.BatchSeqNo <= rolledUpSeqNo,
+		})
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].Fee.ToInt().Cmp(result[j].Fee.ToInt()) > 0
+	})
+	return responses.AsEncryptedResponse(&result, vkHandler), nil
+}
+
+// quoteRelayFee returns the relay fee that covers the L1 gas of the relay of a message, at the base fee of the L1 head.
+// Parameters are [ViewingKey, RelayFeeQuoteCQMethod, RelayFeeQuoteQuery, null]
+func (e *enclaveImpl) quoteRelayFee(paramList []interface{}) (*responses.PrivateQueryResponse, common.SystemError) {
+	query, err := gethencoding.ExtractRelayFeeQuoteQuery(paramList[2])
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to extract query - %w", err)), nil
+	}
+
+	vkHandler, err := createVKHandler(&query.Address, paramList[0], e.config.ObscuroChainID)
+	if err != nil {
+		return responses.AsPlaintextError(fmt.Errorf("unable to create VK encryptor - %w", err)), nil
+	}
+
+	l1Head, err := e.l1BlockProcessor.GetHead()
+	if err != nil {
+		return nil, responses.ToInternalError(fmt.Errorf("unable to fetch the L1 head - %w", err))
+	}
+	return responses.AsEncryptedResponse(crosschain.QuoteRelayFee(uint64(query.PayloadSize), l1BaseFee(l1Head)), vkHandler), nil
+}
+
+// l1BaseFee returns the base fee of the L1 block, zero before London
+func l1BaseFee(block *common.L1Block) *big.Int {
+	if block.BaseFee() == nil {
+		return big.NewInt(0)
+	}
+	return block.BaseFee()
+}
+
 func (e *enclaveImpl) rejectBlockErr(cause error) *errutil.BlockRejectError {
 	var hash common.L1BlockHash
 	l1Head, err := e.l1BlockProcessor.GetHead()
@@ -0,0 +1,170 @@
+package events
+
+import (
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ten-protocol/go-ten/go/common/relevancy"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// logMatcher is a subscription filter compiled into sets, so that matching a log does not depend on the number of
+// addresses and topics in the filter.
+// The matching rules are those of eth/filters/filter.go in the go-ethereum repository.
+type logMatcher struct {
+	fromBlock *uint64
+	toBlock   *uint64
+	addresses map[gethcommon.Address]struct{} // empty matches any address
+	topics    []map[gethcommon.Hash]struct{}  // an empty set matches any topic at that position
+}
+
+func newLogMatcher(filter *filters.FilterCriteria) *logMatcher {
+	m := &logMatcher{}
+	if filter == nil {
+		return m
+	}
+	if filter.FromBlock != nil && filter.FromBlock.Sign() >= 0 {
+		from := filter.FromBlock.Uint64()
+		m.fromBlock = &from
+	}
+	if filter.ToBlock != nil && filter.ToBlock.Sign() > 0 {
+		to := filter.ToBlock.Uint64()
+		m.toBlock = &to
+	}
+	if len(filter.Addresses) > 0 {
+		m.addresses = make(map[gethcommon.Address]struct{}, len(filter.Addresses))
+		for _, addr := range filter.Addresses {
+			m.addresses[addr] = struct{}{}
+		}
+	}
+	m.topics = make([]map[gethcommon.Hash]struct{}, len(filter.Topics))
+	for i, alternatives := range filter.Topics {
+		if len(alternatives) == 0 {
+			continue
+		}
+		m.topics[i] = make(map[gethcommon.Hash]struct{}, len(alternatives))
+		for _, topic := range alternatives {
+			m.topics[i][topic] = struct{}{}
+		}
+	}
+	return m
+}
+
+func (m *logMatcher) matches(logItem *types.Log) bool {
+	if m.fromBlock != nil && *m.fromBlock > logItem.BlockNumber {
+		return false
+	}
+	if m.toBlock != nil && *m.toBlock < logItem.BlockNumber {
+		return false
+	}
+	if m.addresses != nil {
+		if _, found := m.addresses[logItem.Address]; !found {
+			return false
+		}
+	}
+	// the log must have at least as many topics as the filter
+	if len(m.topics) > len(logItem.Topics) {
+		return false
+	}
+	for i, alternatives := range m.topics {
+		if alternatives == nil {
+			continue
+		}
+		if _, found := alternatives[logItem.Topics[i]]; !found {
+			return false
+		}
+	}
+	return true
+}
+
+// relevancyCache extracts the user addresses of each log once, with the version of the relevancy rules of the batch the
+// logs were emitted in. The accounts are looked up in the state once per address, since the same addresses appear in
+// many logs of a batch.
+type relevancyCache struct {
+	stateDB       *state.StateDB
+	version       relevancy.Version
+	userAddrs     map[*types.Log][]gethcommon.Address
+	isUserAddress map[gethcommon.Address]bool
+}
+
+func newRelevancyCache(stateDB *state.StateDB, version relevancy.Version) *relevancyCache {
+	return &relevancyCache{
+		stateDB:       stateDB,
+		version:       version,
+		userAddrs:     map[*types.Log][]gethcommon.Address{},
+		isUserAddress: map[gethcommon.Address]bool{},
+	}
+}
+
+// isRelevant returns whether the account is allowed to view the log
+func (c *relevancyCache) isRelevant(logItem *types.Log, account gethcommon.Address) bool {
+	userAddrs := c.userAddrsOf(logItem)
+	// If there are no user addresses, this is a lifecycle event, and is therefore relevant to everyone.
+	if len(userAddrs) == 0 {
+		return true
+	}
+	for _, addr := range userAddrs {
+		if addr == account {
+			return true
+		}
+	}
+	return false
+}
+
+// Of the log's topics, returns those that are (potentially) user addresses. Under the V0 rules, a topic is considered a
+// user address if:
+//   - It has 12 leading zero bytes (since addresses are 20 bytes long, while hashes are 32)
+//   - It has a non-zero nonce (to prevent accidental or malicious creation of the address matching a given topic,
+//     forcing its events to become permanently private
+//   - It does not have associated code (meaning it's a smart-contract address)
+//
+// The later versions of the rules are applied by the relevancy package.
+func (c *relevancyCache) userAddrsOf(logItem *types.Log) []gethcommon.Address {
+	if userAddrs, found := c.userAddrs[logItem]; found {
+		return userAddrs
+	}
+	if c.version != relevancy.V0 {
+		return c.versionedUserAddrsOf(logItem)
+	}
+
+	var userAddrs []gethcommon.Address
+	for _, topic := range topicsAfterEventID(logItem) {
+		if !hasAddressPadding(topic) {
+			continue
+		}
+		potentialAddr := gethcommon.BytesToAddress(topic.Bytes())
+		isUser, found := c.isUserAddress[potentialAddr]
+		if !found {
+			isUser = isUserAddress(c.stateDB, potentialAddr)
+			c.isUserAddress[potentialAddr] = isUser
+		}
+		if isUser {
+			userAddrs = append(userAddrs, potentialAddr)
+		}
+	}
+	c.userAddrs[logItem] = userAddrs
+	return userAddrs
+}
+
+// versionedUserAddrsOf returns the user addresses of the log under the versions of the rules that follow V0
+func (c *relevancyCache) versionedUserAddrsOf(logItem *types.Log) []gethcommon.Address {
+	var userAddrs []gethcommon.Address
+	for _, topic := range topicsAfterEventID(logItem) {
+		// the cache is keyed by address, so the topics that cannot hold one are skipped first
+		if !hasAddressPadding(topic) {
+			continue
+		}
+		potentialAddr := gethcommon.BytesToAddress(topic.Bytes())
+		isUser, found := c.isUserAddress[potentialAddr]
+		if !found {
+			isUser = relevancy.UserAccount(c.stateDB, topic) != nil
+			c.isUserAddress[potentialAddr] = isUser
+		}
+		if isUser {
+			userAddrs = append(userAddrs, potentialAddr)
+		}
+	}
+	c.userAddrs[logItem] = userAddrs
+	return userAddrs
+}
@@ -3,7 +3,6 @@ package events
 import (
 	"encoding/json"
 	"fmt"
-	"math/big"
 	"sync"
 
 	"github.com/ten-protocol/go-ten/go/enclave/core"
@@ -14,6 +13,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/relevancy"
 	"github.com/ten-protocol/go-ten/go/enclave/rpc"
 	"github.com/ten-protocol/go-ten/go/enclave/vkhandler"
 
@@ -32,20 +32,24 @@ const (
 type SubscriptionManager struct {
 	rpcEncryptionManager *rpc.EncryptionManager
 	storage              storage.Storage
+	relevancyForks       relevancy.Forks // the schedule of the rules deciding which accounts can view the logs
 
 	subscriptions     map[gethrpc.ID]*common.LogSubscription
+	matchers          map[gethrpc.ID]*logMatcher // the filters of the subscriptions, compiled when they are added
 	chainID           int64
 	subscriptionMutex *sync.RWMutex // the mutex guards the subscriptions/lastHead pair
 
 	logger gethlog.Logger
 }
 
-func NewSubscriptionManager(rpcEncryptionManager *rpc.EncryptionManager, storage storage.Storage, chainID int64, logger gethlog.Logger) *SubscriptionManager {
+func NewSubscriptionManager(rpcEncryptionManager *rpc.EncryptionManager, storage storage.Storage, relevancyForks relevancy.Forks, chainID int64, logger gethlog.Logger) *SubscriptionManager {
 	return &SubscriptionManager{
 		rpcEncryptionManager: rpcEncryptionManager,
 		storage:              storage,
+		relevancyForks:       relevancyForks,
 
 		subscriptions:     map[gethrpc.ID]*common.LogSubscription{},
+		matchers:          map[gethrpc.ID]*logMatcher{},
 		chainID:           chainID,
 		subscriptionMutex: &sync.RWMutex{},
 		logger:            logger,
@@ -75,6 +79,7 @@ func (s *SubscriptionManager) AddSubscription(id gethrpc.ID, encryptedSubscripti
 	s.subscriptionMutex.Lock()
 	defer s.subscriptionMutex.Unlock()
 	s.subscriptions[id] = subscription
+	s.matchers[id] = newLogMatcher(subscription.Filter)
 
 	return nil
 }
@@ -85,24 +90,33 @@ func (s *SubscriptionManager) RemoveSubscription(id gethrpc.ID) {
 	s.subscriptionMutex.Lock()
 	defer s.subscriptionMutex.Unlock()
 	delete(s.subscriptions, id)
+	delete(s.matchers, id)
 }
 
 // FilterLogsForReceipt removes the logs that the sender of a transaction is not allowed to view
 func (s *SubscriptionManager) FilterLogsForReceipt(receipt *types.Receipt, account *gethcommon.Address) ([]*types.Log, error) {
-	filteredLogs := []*types.Log{}
 	stateDB, err := s.storage.CreateStateDB(receipt.BlockHash)
 	if err != nil {
 		return nil, fmt.Errorf("could not create state DB to filter logs. Cause: %w", err)
 	}
 
-	for _, logItem := range receipt.Logs {
-		userAddrs := getUserAddrsFromLogTopics(logItem, stateDB)
-		if isRelevant(account, userAddrs) {
+	return FilterLogsForAccount(receipt.Logs, stateDB, s.relevancyForks, account), nil
+}
+
+// FilterLogsForAccount removes the logs of a batch that the account is not allowed to view, given the state the logs were
+// emitted in. The logs are filtered with the version of the relevancy rules active at the height of the batch.
+func FilterLogsForAccount(logs []*types.Log, stateDB *state.StateDB, relevancyForks relevancy.Forks, account *gethcommon.Address) []*types.Log {
+	if len(logs) == 0 {
+		return []*types.Log{}
+	}
+	cache := newRelevancyCache(stateDB, relevancyForks.VersionAt(logs[0].BlockNumber))
+	filteredLogs := []*types.Log{}
+	for _, logItem := range logs {
+		if cache.isRelevant(logItem, *account) {
 			filteredLogs = append(filteredLogs, logItem)
 		}
 	}
-
-	return filteredLogs, nil
+	return filteredLogs
 }
 
 // GetSubscribedLogsForBatch - Retrieves and encrypts the logs for the batch in live mode.
@@ -116,8 +130,6 @@ func (s *SubscriptionManager) GetSubscribedLogsForBatch(batch *core.Batch, recei
 		return nil, nil
 	}
 
-	relevantLogsPerSubscription := map[gethrpc.ID][]*types.Log{}
-
 	// extract the logs from all receipts
 	var allLogs []*types.Log
 	for _, receipt := range receipts {
@@ -134,47 +146,72 @@ func (s *SubscriptionManager) GetSubscribedLogsForBatch(batch *core.Batch, recei
 		return nil, fmt.Errorf("could not create state DB to filter logs. Cause: %w", err)
 	}
 
-	// cache for the user addresses extracted from the individual logs
-	// this is an expensive operation so we are doing it lazy, and caching the result
-	userAddrsForLog := map[*types.Log][]*gethcommon.Address{}
+	return s.encryptLogs(s.relevantLogsPerSubscription(allLogs, stateDB, s.relevancyForks.VersionAt(batch.NumberU64())))
+}
 
+// relevantLogsPerSubscription returns the logs of each subscription that match its filter and that its account is allowed
+// to view. The logs are indexed once by the user addresses they are relevant to, so each account only scans the logs it
+// can view, and all the precompiled filters of the account are evaluated in that single pass.
+// Must be called with the subscription lock.
+func (s *SubscriptionManager) relevantLogsPerSubscription(logs []*types.Log, stateDB *state.StateDB, version relevancy.Version) map[gethrpc.ID][]*types.Log {
+	type compiledSubscription struct {
+		id      gethrpc.ID
+		matcher *logMatcher
+	}
+	subscriptionsByAccount := map[gethcommon.Address][]compiledSubscription{}
 	for id, sub := range s.subscriptions {
-		// first filter the logs
-		filteredLogs := filterLogs(allLogs, sub.Filter.FromBlock, sub.Filter.ToBlock, sub.Filter.Addresses, sub.Filter.Topics, s.logger)
-
-		relevantLogsForSub := []*types.Log{}
-		for _, logItem := range filteredLogs {
-			userAddrs, f := userAddrsForLog[logItem]
-			if !f {
-				userAddrs = getUserAddrsFromLogTopics(logItem, stateDB)
-				userAddrsForLog[logItem] = userAddrs
-			}
-			relevant := isRelevant(sub.Account, userAddrs)
-			if relevant {
-				relevantLogsForSub = append(relevantLogsForSub, logItem)
+		account := *sub.Account
+		subscriptionsByAccount[account] = append(subscriptionsByAccount[account], compiledSubscription{id: id, matcher: s.matchers[id]})
+	}
+
+	publicLogs, logsByUser := indexLogsByUser(logs, newRelevancyCache(stateDB, version))
+	relevantLogsPerSubscription := map[gethrpc.ID][]*types.Log{}
+	for account, subs := range subscriptionsByAccount {
+		for _, i := range mergeIndexes(publicLogs, logsByUser[account]) {
+			for _, sub := range subs {
+				if sub.matcher.matches(logs[i]) {
+					relevantLogsPerSubscription[sub.id] = append(relevantLogsPerSubscription[sub.id], logs[i])
+				}
 			}
-			s.logger.Debug(fmt.Sprintf("Subscription %s. Account %s. Log %v. Extracted addresses: %v. Relevant: %t", id, sub.Account, logItem, userAddrs, relevant))
-		}
-		if len(relevantLogsForSub) > 0 {
-			relevantLogsPerSubscription[id] = relevantLogsForSub
 		}
 	}
-
-	// Encrypt the results
-	return s.encryptLogs(relevantLogsPerSubscription)
+	s.logger.Debug("Filtered the logs of the subscriptions", "logs", len(logs), "subscriptions", len(s.subscriptions),
+		"accounts", len(subscriptionsByAccount), "subscriptionsWithLogs", len(relevantLogsPerSubscription))
+	return relevantLogsPerSubscription
 }
 
-func isRelevant(sub *gethcommon.Address, userAddrs []*gethcommon.Address) bool {
-	// If there are no user addresses, this is a lifecycle event, and is therefore relevant to everyone.
-	if len(userAddrs) == 0 {
-		return true
+// indexLogsByUser returns the positions of the logs visible to everyone, and of the logs visible to each user address
+func indexLogsByUser(logs []*types.Log, cache *relevancyCache) ([]int, map[gethcommon.Address][]int) {
+	var publicLogs []int
+	logsByUser := map[gethcommon.Address][]int{}
+	for i, logItem := range logs {
+		userAddrs := cache.userAddrsOf(logItem)
+		if len(userAddrs) == 0 {
+			publicLogs = append(publicLogs, i)
+			continue
+		}
+		for _, addr := range userAddrs {
+			// the same user can appear in several topics of the log
+			if indexes := logsByUser[addr]; len(indexes) == 0 || indexes[len(indexes)-1] != i {
+				logsByUser[addr] = append(indexes, i)
+			}
+		}
 	}
-	for _, addr := range userAddrs {
-		if *addr == *sub {
-			return true
+	return publicLogs, logsByUser
+}
+
+// mergeIndexes merges two increasing lists of positions, so the logs are returned in the order of the batch
+func mergeIndexes(a, b []int) []int {
+	merged := make([]int, 0, len(a)+len(b))
+	for len(a) > 0 && len(b) > 0 {
+		if a[0] < b[0] {
+			merged, a = append(merged, a[0]), a[1:]
+		} else {
+			merged, b = append(merged, b[0]), b[1:]
 		}
 	}
-	return false
+	merged = append(merged, a...)
+	return append(merged, b...)
 }
 
 // Encrypts each log with the appropriate viewing key.
@@ -203,84 +240,22 @@ func (s *SubscriptionManager) encryptLogs(logsByID map[gethrpc.ID][]*types.Log)
 	return encryptedLogsByID, nil
 }
 
-// Of the log's topics, returns those that are (potentially) user addresses. A topic is considered a user address if:
-//   - It has 12 leading zero bytes (since addresses are 20 bytes long, while hashes are 32)
-//   - It has a non-zero nonce (to prevent accidental or malicious creation of the address matching a given topic,
-//     forcing its events to become permanently private
-//   - It does not have associated code (meaning it's a smart-contract address)
-func getUserAddrsFromLogTopics(log *types.Log, db *state.StateDB) []*gethcommon.Address {
-	var userAddrs []*gethcommon.Address
-
-	// We skip over the first topic, which is always the hash of the event.
-	for _, topic := range log.Topics[1:len(log.Topics)] {
-		if topic.Hex()[2:len(zeroBytesHex)+2] != zeroBytesHex {
-			continue
-		}
-
-		potentialAddr := gethcommon.BytesToAddress(topic.Bytes())
-
-		// A user address must have a non-zero nonce. This prevents accidental or malicious sending of funds to an
-		// address matching a topic, forcing its events to become permanently private.
-		if db.GetNonce(potentialAddr) != 0 {
-			// If the address has code, it's a smart contract address instead.
-			if db.GetCode(potentialAddr) == nil {
-				userAddrs = append(userAddrs, &potentialAddr)
-			}
-		}
+// We skip over the first topic, which is always the hash of the event.
+func topicsAfterEventID(log *types.Log) []gethcommon.Hash {
+	if len(log.Topics) == 0 {
+		return nil
 	}
-
-	return userAddrs
+	return log.Topics[1:]
 }
 
-// Lifted from eth/filters/filter.go in the go-ethereum repository.
-// filterLogs creates a slice of logs matching the given criteria.
-func filterLogs(logs []*types.Log, fromBlock, toBlock *big.Int, addresses []gethcommon.Address, topics [][]gethcommon.Hash, logger gethlog.Logger) []*types.Log { //nolint:gocognit
-	var ret []*types.Log
-Logs:
-	for _, logItem := range logs {
-		if fromBlock != nil && fromBlock.Int64() >= 0 && fromBlock.Uint64() > logItem.BlockNumber {
-			logger.Debug(fmt.Sprintf("Skipping log = %v", logItem), "reason", "In the past. The starting block num for filter is bigger than log")
-			continue
-		}
-		if toBlock != nil && toBlock.Int64() > 0 && toBlock.Uint64() < logItem.BlockNumber {
-			logger.Debug(fmt.Sprintf("Skipping log = %v", logItem), "reason", "In the future. The ending block num for filter is smaller than log")
-			continue
-		}
-
-		if len(addresses) > 0 && !includes(addresses, logItem.Address) {
-			logger.Debug(fmt.Sprintf("Skipping log = %v", logItem), "reason", "The contract address of the log is not an address of interest")
-			continue
-		}
-		// If the to filtered topics is greater than the amount of topics in logs, skip.
-		if len(topics) > len(logItem.Topics) {
-			logger.Debug(fmt.Sprintf("Skipping log = %v", logItem), "reason", "Insufficient topics. The log has less topics than the required one to satisfy the query")
-			continue
-		}
-		for i, sub := range topics {
-			match := len(sub) == 0 // empty rule set == wildcard
-			for _, topic := range sub {
-				if logItem.Topics[i] == topic {
-					match = true
-					break
-				}
-			}
-			if !match {
-				logger.Debug(fmt.Sprintf("Skipping log = %v", logItem), "reason", "Topics do not match.")
-				continue Logs
-			}
-		}
-		ret = append(ret, logItem)
-	}
-	return ret
+// returns whether the topic has the leading zero bytes of an address
+func hasAddressPadding(topic gethcommon.Hash) bool {
+	return topic.Hex()[2:len(zeroBytesHex)+2] == zeroBytesHex
 }
 
-// Lifted from eth/filters/filter.go in the go-ethereum repository.
-func includes(addresses []gethcommon.Address, a gethcommon.Address) bool {
-	for _, addr := range addresses {
-		if addr == a {
-			return true
-		}
-	}
-
-	return false
+// A user address must have a non-zero nonce. This prevents accidental or malicious sending of funds to an address
+// matching a topic, forcing its events to become permanently private.
+// If the address has code, it's a smart contract address instead.
+func isUserAddress(db *state.StateDB, addr gethcommon.Address) bool {
+	return db.GetNonce(addr) != 0 && db.GetCode(addr) == nil
 }
@@ -0,0 +1,192 @@
+package events
+
+import (
+	"fmt"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/relevancy"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	transferEventID = crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)"))
+	approvalEventID = crypto.Keccak256Hash([]byte("Approval(address,address,uint256)"))
+	tokenContract   = gethcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	otherContract   = gethcommon.HexToAddress("0x1000000000000000000000000000000000000002")
+)
+
+// returns a state in which the users have sent transactions, and the contracts have code
+func newTestStateDB(t testing.TB, users []gethcommon.Address, contracts []gethcommon.Address) *state.StateDB {
+	stateDB, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	for _, user := range users {
+		stateDB.SetNonce(user, 1)
+	}
+	for _, contract := range contracts {
+		stateDB.SetNonce(contract, 1)
+		stateDB.SetCode(contract, []byte{0x60, 0x00})
+	}
+	return stateDB
+}
+
+func newTransferLog(contract, from, to gethcommon.Address, blockNumber uint64) *types.Log {
+	return &types.Log{
+		Address:     contract,
+		Topics:      []gethcommon.Hash{transferEventID, gethcommon.BytesToHash(from.Bytes()), gethcommon.BytesToHash(to.Bytes())},
+		BlockNumber: blockNumber,
+	}
+}
+
+func newTestSubscriptionManager(subscriptions map[gethrpc.ID]*common.LogSubscription) *SubscriptionManager {
+	matchers := map[gethrpc.ID]*logMatcher{}
+	for id, sub := range subscriptions {
+		matchers[id] = newLogMatcher(sub.Filter)
+	}
+	return &SubscriptionManager{subscriptions: subscriptions, matchers: matchers, logger: gethlog.New()}
+}
+
+func TestLogMatcherFollowsTheFilterCriteria(t *testing.T) {
+	from := gethcommon.HexToAddress("0x2000000000000000000000000000000000000001")
+	to := gethcommon.HexToAddress("0x2000000000000000000000000000000000000002")
+	logItem := newTransferLog(tokenContract, from, to, 10)
+
+	tests := map[string]struct {
+		filter  *filters.FilterCriteria
+		matches bool
+	}{
+		"no filter": {
+			filter:  nil,
+			matches: true,
+		},
+		"empty filter": {
+			filter:  &filters.FilterCriteria{},
+			matches: true,
+		},
+		"from a later block": {
+			filter:  &filters.FilterCriteria{FromBlock: big.NewInt(11)},
+			matches: false,
+		},
+		"up to an earlier block": {
+			filter:  &filters.FilterCriteria{ToBlock: big.NewInt(9)},
+			matches: false,
+		},
+		"latest block range": {
+			filter:  &filters.FilterCriteria{FromBlock: big.NewInt(-1), ToBlock: big.NewInt(-1)},
+			matches: true,
+		},
+		"one of the addresses": {
+			filter:  &filters.FilterCriteria{Addresses: []gethcommon.Address{otherContract, tokenContract}},
+			matches: true,
+		},
+		"other address": {
+			filter:  &filters.FilterCriteria{Addresses: []gethcommon.Address{otherContract}},
+			matches: false,
+		},
+		"wildcard then one of the topics": {
+			filter:  &filters.FilterCriteria{Topics: [][]gethcommon.Hash{{}, {gethcommon.BytesToHash(to.Bytes()), gethcommon.BytesToHash(from.Bytes())}}},
+			matches: true,
+		},
+		"other event": {
+			filter:  &filters.FilterCriteria{Topics: [][]gethcommon.Hash{{approvalEventID}}},
+			matches: false,
+		},
+		"more topics than the log": {
+			filter:  &filters.FilterCriteria{Topics: [][]gethcommon.Hash{{}, {}, {}, {}}},
+			matches: false,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Equal(t, test.matches, newLogMatcher(test.filter).matches(logItem))
+		})
+	}
+}
+
+func TestRelevantLogsPerSubscription(t *testing.T) {
+	alice := gethcommon.HexToAddress("0x2000000000000000000000000000000000000001")
+	bob := gethcommon.HexToAddress("0x2000000000000000000000000000000000000002")
+	stateDB := newTestStateDB(t, []gethcommon.Address{alice, bob}, []gethcommon.Address{otherContract})
+
+	toAlice := newTransferLog(tokenContract, otherContract, alice, 1)
+	toBob := newTransferLog(tokenContract, otherContract, bob, 1)
+	// the contract topics are not user addresses, so this log is visible to everyone
+	betweenContracts := newTransferLog(tokenContract, otherContract, tokenContract, 1)
+	lifecycle := &types.Log{Address: otherContract, Topics: []gethcommon.Hash{approvalEventID}, BlockNumber: 1}
+	anonymous := &types.Log{Address: otherContract, BlockNumber: 1}
+	logs := []*types.Log{toAlice, toBob, betweenContracts, lifecycle, anonymous}
+
+	manager := newTestSubscriptionManager(map[gethrpc.ID]*common.LogSubscription{
+		"aliceToken": {Account: &alice, Filter: &filters.FilterCriteria{Addresses: []gethcommon.Address{tokenContract}}},
+		"aliceAll":   {Account: &alice, Filter: &filters.FilterCriteria{}},
+		"bobToken":   {Account: &bob, Filter: &filters.FilterCriteria{Addresses: []gethcommon.Address{tokenContract}}},
+		"bobOther":   {Account: &bob, Filter: &filters.FilterCriteria{Addresses: []gethcommon.Address{gethcommon.HexToAddress("0x03")}}},
+	})
+
+	// the subscriptions already required a non-zero nonce before the rules were versioned
+	for _, version := range []relevancy.Version{relevancy.V0, relevancy.V1} {
+		relevantLogs := manager.relevantLogsPerSubscription(logs, stateDB, version)
+		require.Equal(t, map[gethrpc.ID][]*types.Log{
+			"aliceToken": {toAlice, betweenContracts},
+			"aliceAll":   {toAlice, betweenContracts, lifecycle, anonymous},
+			"bobToken":   {toBob, betweenContracts},
+		}, relevantLogs)
+	}
+
+	require.Equal(t, []*types.Log{toBob, betweenContracts, lifecycle, anonymous}, FilterLogsForAccount(logs, stateDB, nil, &bob))
+	forks := relevancy.Forks{{Version: relevancy.V1, Height: 1}}
+	require.Equal(t, []*types.Log{toBob, betweenContracts, lifecycle, anonymous}, FilterLogsForAccount(logs, stateDB, forks, &bob))
+}
+
+// Benchmarks the filtering of the logs of a batch for dapps whose users each subscribe to the events of many contracts.
+func BenchmarkRelevantLogsPerSubscription(b *testing.B) {
+	for _, size := range []struct{ accounts, subsPerAccount, addrsPerSub, logs int }{
+		{accounts: 10, subsPerAccount: 1, addrsPerSub: 1, logs: 100},
+		{accounts: 100, subsPerAccount: 5, addrsPerSub: 50, logs: 1000},
+		{accounts: 500, subsPerAccount: 10, addrsPerSub: 200, logs: 2000},
+	} {
+		name := fmt.Sprintf("%d accounts/%d subs/%d addresses/%d logs", size.accounts, size.subsPerAccount, size.addrsPerSub, size.logs)
+		b.Run(name, func(b *testing.B) {
+			users := make([]gethcommon.Address, size.accounts)
+			for i := range users {
+				users[i] = gethcommon.BigToAddress(big.NewInt(int64(0x10000 + i)))
+			}
+			contracts := make([]gethcommon.Address, size.addrsPerSub)
+			for i := range contracts {
+				contracts[i] = gethcommon.BigToAddress(big.NewInt(int64(0x20000 + i)))
+			}
+			stateDB := newTestStateDB(b, users, contracts)
+
+			subscriptions := map[gethrpc.ID]*common.LogSubscription{}
+			for i := range users {
+				for j := 0; j < size.subsPerAccount; j++ {
+					subscriptions[gethrpc.ID(fmt.Sprintf("%d-%d", i, j))] = &common.LogSubscription{
+						Account: &users[i],
+						Filter:  &filters.FilterCriteria{Addresses: contracts, Topics: [][]gethcommon.Hash{{transferEventID}}},
+					}
+				}
+			}
+			logs := make([]*types.Log, size.logs)
+			for i := range logs {
+				logs[i] = newTransferLog(contracts[i%len(contracts)], users[i%len(users)], users[(i+1)%len(users)], 1)
+			}
+			manager := newTestSubscriptionManager(subscriptions)
+
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				manager.relevantLogsPerSubscription(logs, stateDB, relevancy.Latest)
+			}
+		})
+	}
+}
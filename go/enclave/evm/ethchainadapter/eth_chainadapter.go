@@ -137,14 +137,19 @@ func (e *EthChainAdapter) IngestNewBlock(batch *core.Batch) error {
 	return nil
 }
 
-func NewLegacyPoolConfig() legacypool.Config {
+// NewLegacyPoolConfig returns the config of the mempool. A transaction replaces the pending one with the same sender and
+// nonce if its fees are higher by priceBump percent (zero uses the geth default).
+func NewLegacyPoolConfig(priceBump uint64) legacypool.Config {
+	if priceBump == 0 {
+		priceBump = legacypool.DefaultConfig.PriceBump
+	}
 	return legacypool.Config{
 		Locals:       nil,
 		NoLocals:     false,
 		Journal:      "",
 		Rejournal:    0,
 		PriceLimit:   legacypool.DefaultConfig.PriceLimit,
-		PriceBump:    legacypool.DefaultConfig.PriceBump,
+		PriceBump:    priceBump,
 		AccountSlots: legacypool.DefaultConfig.AccountSlots,
 		GlobalSlots:  legacypool.DefaultConfig.GlobalSlots,
 		AccountQueue: legacypool.DefaultConfig.AccountQueue,
@@ -0,0 +1,319 @@
+package evm_test
+
+import (
+	"crypto/ecdsa"
+	"math/big"
+	"testing"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/consensus"
+	"github.com/ethereum/go-ethereum/consensus/ethash"
+	gethcore "github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/gethencoding"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+	"github.com/ten-protocol/go-ten/go/enclave/crypto"
+	"github.com/ten-protocol/go-ten/go/enclave/evm"
+	"github.com/ten-protocol/go-ten/go/enclave/evm/ethchainadapter"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
+)
+
+const diffBatchGasLimit = 30_000_000
+
+var (
+	diffChainConfig = ethchainadapter.ChainParams(big.NewInt(443))
+	diffCoinbase    = gethcommon.HexToAddress("0xd6C9230053f45F873Cb66D8A02439380a37A4fbF")
+	diffBaseFee     = big.NewInt(params.GWei)
+
+	// stores the first word of the calldata in slot 0 and emits it in a log with topic 0xaa
+	storeAndLogRuntime = gethcommon.FromHex("0x60003560005560003560005260aa60206000a100")
+	// returns storeAndLogRuntime as the code of the deployed contract
+	storeAndLogInit = append(gethcommon.FromHex("0x6014600c60003960146000f3"), storeAndLogRuntime...)
+	// always reverts with revertData
+	revertingCode    = gethcommon.FromHex("0x63deadbeef6000526004601cfd")
+	revertData       = gethcommon.FromHex("0xdeadbeef")
+	revertingAddress = gethcommon.HexToAddress("0x00000000000000000000000000000000000000ee")
+)
+
+// secretOnlyStorage provides the enclave secret to the EVM, and no historical batches
+type secretOnlyStorage struct {
+	storage.Storage
+	secret crypto.SharedEnclaveSecret
+}
+
+func (s *secretOnlyStorage) FetchSecret() (*crypto.SharedEnclaveSecret, error) {
+	return &s.secret, nil
+}
+
+func (s *secretOnlyStorage) FetchBatch(common.L2BatchHash) (*core.Batch, error) {
+	return nil, errutil.ErrNotFound
+}
+
+// vanillaChainContext is the chain context of an unmodified Geth node
+type vanillaChainContext struct{}
+
+func (vanillaChainContext) Engine() consensus.Engine {
+	return ethash.NewFaker()
+}
+
+func (vanillaChainContext) GetHeader(gethcommon.Hash, uint64) *types.Header {
+	return nil
+}
+
+// executeWithVanillaGeth runs the transactions the way an unmodified Geth miner does, except for the changes TEN
+// makes on purpose, which are applied explicitly so that only unintended divergences remain:
+// - the tips go to the pool address instead of the block author
+// - the randomness exposed to each transaction is derived from the batch randomness and the tx index
+//
+// Because the reference applies the same fee changes, a bug in how TEN implements them can't show up in the diff.
+// TestFeeAccounting checks those paths separately, against the balances expected from the receipts.
+func executeWithVanillaGeth(t *testing.T, txs []*common.L2Tx, s *state.StateDB, header *common.BatchHeader, secret []byte) map[common.TxHash]interface{} {
+	ethHeader, err := gethencoding.CreateEthHeaderForBatch(header, secret)
+	require.NoError(t, err)
+	batchRnd := ethHeader.MixDigest
+	gp := gethcore.GasPool(diffBatchGasLimit)
+	usedGas := uint64(0)
+	result := map[common.TxHash]interface{}{}
+
+	for i, tx := range txs {
+		snap := s.Snapshot()
+		s.SetTxContext(tx.Hash(), i)
+		ethHeader.MixDigest = crypto.CalculateTxRnd(batchRnd.Bytes(), i)
+		receipt, err := gethcore.ApplyTransaction(diffChainConfig, vanillaChainContext{}, &evm.PoolAddress, &gp, s, ethHeader, tx, &usedGas, vm.Config{})
+		if err != nil {
+			s.RevertToSnapshot(snap)
+			result[tx.Hash()] = err
+			continue
+		}
+		result[tx.Hash()] = receipt
+	}
+	s.Finalise(true)
+	return result
+}
+
+type txBuilder func(t *testing.T, key *ecdsa.PrivateKey) *common.L2Tx
+
+func signedTx(t *testing.T, key *ecdsa.PrivateKey, txData types.TxData) *common.L2Tx {
+	tx, err := types.SignNewTx(key, types.LatestSigner(diffChainConfig), txData)
+	require.NoError(t, err)
+	return tx
+}
+
+func transfer(nonce uint64, to gethcommon.Address, value int64) txBuilder {
+	return func(t *testing.T, key *ecdsa.PrivateKey) *common.L2Tx {
+		return signedTx(t, key, &types.LegacyTx{Nonce: nonce, GasPrice: diffBaseFee, Gas: params.TxGas, To: &to, Value: big.NewInt(value)})
+	}
+}
+
+func deploy(nonce uint64, code []byte) txBuilder {
+	return func(t *testing.T, key *ecdsa.PrivateKey) *common.L2Tx {
+		return signedTx(t, key, &types.DynamicFeeTx{
+			ChainID: diffChainConfig.ChainID, Nonce: nonce, GasTipCap: big.NewInt(params.GWei), GasFeeCap: big.NewInt(2 * params.GWei), Gas: 200_000, Data: code,
+		})
+	}
+}
+
+func call(nonce uint64, to gethcommon.Address, gas uint64, data []byte) txBuilder {
+	return func(t *testing.T, key *ecdsa.PrivateKey) *common.L2Tx {
+		return signedTx(t, key, &types.DynamicFeeTx{
+			ChainID: diffChainConfig.ChainID, Nonce: nonce, GasTipCap: big.NewInt(params.GWei), GasFeeCap: big.NewInt(2 * params.GWei), Gas: gas, To: &to, Data: data,
+		})
+	}
+}
+
+// TestDifferentialExecutionAgainstGeth runs the same batches through the TEN EVM and through a vanilla Geth EVM
+// starting from the same state, and diffs the results, the receipts and the resulting state roots.
+func TestDifferentialExecutionAgainstGeth(t *testing.T) {
+	key, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	sender := gethcrypto.PubkeyToAddress(key.PublicKey)
+	deployed := gethcrypto.CreateAddress(sender, 0)
+	recipient := gethcommon.HexToAddress("0x00000000000000000000000000000000000000aa")
+	word := gethcommon.LeftPadBytes([]byte{0x2a}, 32)
+
+	tests := []struct {
+		name string
+		txs  []txBuilder
+	}{
+		{name: "value transfer", txs: []txBuilder{transfer(0, recipient, 1000)}},
+		{name: "transfer to a precompile", txs: []txBuilder{transfer(0, gethcommon.BytesToAddress([]byte{0x04}), 1)}},
+		{name: "deploy then store and log", txs: []txBuilder{deploy(0, storeAndLogInit), call(1, deployed, 100_000, word), call(2, deployed, 100_000, nil)}},
+		{name: "reverted call", txs: []txBuilder{call(0, revertingAddress, 100_000, nil)}},
+		{name: "out of gas", txs: []txBuilder{deploy(0, storeAndLogInit), call(1, deployed, params.TxGas+100, word)}},
+		{name: "invalid nonce is skipped", txs: []txBuilder{transfer(5, recipient, 1), transfer(0, recipient, 2)}},
+		{name: "intrinsic gas too low", txs: []txBuilder{call(0, recipient, params.TxGas-1, nil), transfer(0, recipient, 3)}},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			db := state.NewDatabase(rawdb.NewMemoryDatabase())
+			genesis, err := state.New(types.EmptyRootHash, db, nil)
+			require.NoError(t, err)
+			genesis.AddBalance(sender, new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(100)))
+			genesis.SetCode(revertingAddress, revertingCode)
+			root, err := genesis.Commit(0, true)
+			require.NoError(t, err)
+
+			txs := make([]*common.L2Tx, len(tc.txs))
+			for i, build := range tc.txs {
+				txs[i] = build(t, key)
+			}
+
+			header := &common.BatchHeader{
+				Number:   big.NewInt(1),
+				Time:     1_700_000_000,
+				BaseFee:  diffBaseFee,
+				GasLimit: diffBatchGasLimit,
+				Coinbase: diffCoinbase,
+			}
+			store := &secretOnlyStorage{secret: crypto.SharedEnclaveSecret{0x01}}
+
+			tenState, err := state.New(root, db, nil)
+			require.NoError(t, err)
+			tenResults := evm.ExecuteTransactions(txs, tenState, header, store, diffChainConfig, nil, 0, false, diffBatchGasLimit, nil, nil, gethlog.New())
+
+			gethState, err := state.New(root, db, nil)
+			require.NoError(t, err)
+			gethResults := executeWithVanillaGeth(t, txs, gethState, header, store.secret[:])
+
+			for _, tx := range txs {
+				requireSameResult(t, gethResults[tx.Hash()], tenResults[tx.Hash()])
+			}
+			require.Equal(t, gethState.IntermediateRoot(true), tenState.IntermediateRoot(true), "state roots diverged")
+		})
+	}
+}
+
+func requireSameResult(t *testing.T, expected interface{}, actual *evm.TxExecResult) {
+	expectedReceipt, expectedOK := expected.(*types.Receipt)
+	actualReceipt := actual.Receipt
+	require.Equal(t, expectedOK, actualReceipt != nil, "geth result: %v, ten result: %v", expected, actual.Err)
+	if !expectedOK {
+		require.EqualError(t, actual.Err, expected.(error).Error())
+		return
+	}
+	require.Equal(t, expectedReceipt.Status == types.ReceiptStatusFailed, actual.VMErr != nil)
+
+	require.Equal(t, expectedReceipt.Type, actualReceipt.Type)
+	require.Equal(t, expectedReceipt.Status, actualReceipt.Status)
+	require.Equal(t, expectedReceipt.GasUsed, actualReceipt.GasUsed)
+	require.Equal(t, expectedReceipt.CumulativeGasUsed, actualReceipt.CumulativeGasUsed)
+	require.Equal(t, expectedReceipt.EffectiveGasPrice, actualReceipt.EffectiveGasPrice)
+	require.Equal(t, expectedReceipt.ContractAddress, actualReceipt.ContractAddress)
+	require.Equal(t, expectedReceipt.Bloom, actualReceipt.Bloom)
+	require.Equal(t, len(expectedReceipt.Logs), len(actualReceipt.Logs))
+	// the block hash and number of the logs are the ones of the batch in TEN
+	for i, l := range expectedReceipt.Logs {
+		require.Equal(t, l.Address, actualReceipt.Logs[i].Address)
+		require.Equal(t, l.Topics, actualReceipt.Logs[i].Topics)
+		require.Equal(t, l.Data, actualReceipt.Logs[i].Data)
+		require.Equal(t, l.Index, actualReceipt.Logs[i].Index)
+	}
+}
+
+// TestFeeAccounting checks the fee changes TEN makes to the Geth execution, which the differential test applies to
+// both sides: the tips go to the pool address, and the base fee is burnt, whatever the coinbase. The batch executor
+// distributes the base fees afterwards.
+func TestFeeAccounting(t *testing.T) {
+	key, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	sender := gethcrypto.PubkeyToAddress(key.PublicKey)
+	deployed := gethcrypto.CreateAddress(sender, 0)
+	recipient := gethcommon.HexToAddress("0x00000000000000000000000000000000000000aa")
+	word := gethcommon.LeftPadBytes([]byte{0x2a}, 32)
+
+	for _, coinbase := range []gethcommon.Address{diffCoinbase, {}} {
+		t.Run(coinbase.Hex(), func(t *testing.T) {
+			s, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+			require.NoError(t, err)
+			s.AddBalance(sender, new(big.Int).Mul(big.NewInt(params.Ether), big.NewInt(100)))
+			s.SetCode(revertingAddress, revertingCode)
+
+			builders := []txBuilder{transfer(0, recipient, 1000), deploy(1, storeAndLogInit), call(2, deployed, 100_000, word), call(3, revertingAddress, 100_000, nil)}
+			txs := make([]*common.L2Tx, len(builders))
+			for i, build := range builders {
+				txs[i] = build(t, key)
+			}
+			header := &common.BatchHeader{
+				Number:   big.NewInt(1),
+				Time:     1_700_000_000,
+				BaseFee:  diffBaseFee,
+				GasLimit: diffBatchGasLimit,
+				Coinbase: coinbase,
+			}
+
+			poolBefore := s.GetBalance(evm.PoolAddress)
+			coinbaseBefore := s.GetBalance(coinbase)
+			results := evm.ExecuteTransactions(txs, s, header, &secretOnlyStorage{secret: crypto.SharedEnclaveSecret{0x01}}, diffChainConfig, nil, 0, false, diffBatchGasLimit, nil, nil, gethlog.New())
+
+			tips := big.NewInt(0)
+			burned := big.NewInt(0)
+			for _, tx := range txs {
+				receipt := results[tx.Hash()].Receipt
+				require.NotNil(t, receipt, "tx %s failed: %v", tx.Hash(), results[tx.Hash()].Err)
+				gasUsed := new(big.Int).SetUint64(receipt.GasUsed)
+				tip, err := tx.EffectiveGasTip(diffBaseFee)
+				require.NoError(t, err)
+				tips.Add(tips, new(big.Int).Mul(gasUsed, tip))
+				if tx.To() != nil && *tx.To() == revertingAddress {
+					require.ErrorIs(t, results[tx.Hash()].VMErr, vm.ErrExecutionReverted)
+					require.Equal(t, revertData, results[tx.Hash()].Revert)
+				}
+				burned.Add(burned, new(big.Int).Mul(gasUsed, diffBaseFee))
+			}
+			require.Positive(t, tips.Sign())
+
+			require.Positive(t, burned.Sign())
+			require.Equal(t, new(big.Int).Add(poolBefore, tips), s.GetBalance(evm.PoolAddress))
+			require.Equal(t, coinbaseBefore, s.GetBalance(coinbase))
+		})
+	}
+}
+
+// TestExecutionBudget checks that the transactions left once the budget is exhausted are not executed, and that the
+// first transaction is executed whatever the budget
+func TestExecutionBudget(t *testing.T) {
+	key, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	sender := gethcrypto.PubkeyToAddress(key.PublicKey)
+	recipient := gethcommon.HexToAddress("0x00000000000000000000000000000000000000aa")
+
+	tests := map[string]struct {
+		budget   *evm.ExecutionBudget
+		executed int
+	}{
+		"no budget":        {budget: nil, executed: 3},
+		"gas budget":       {budget: &evm.ExecutionBudget{Gas: 2 * params.TxGas}, executed: 2},
+		"exhausted budget": {budget: &evm.ExecutionBudget{Duration: time.Nanosecond, Gas: 1}, executed: 1},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			s, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+			require.NoError(t, err)
+			s.AddBalance(sender, big.NewInt(params.Ether))
+
+			txs := []*common.L2Tx{transfer(0, recipient, 1)(t, key), transfer(1, recipient, 1)(t, key), transfer(2, recipient, 1)(t, key)}
+			header := &common.BatchHeader{Number: big.NewInt(1), Time: 1_700_000_000, BaseFee: diffBaseFee, GasLimit: diffBatchGasLimit}
+			results := evm.ExecuteTransactions(txs, s, header, &secretOnlyStorage{secret: crypto.SharedEnclaveSecret{0x01}}, diffChainConfig, nil, 0, false, diffBatchGasLimit, tt.budget, nil, gethlog.New())
+
+			for i, tx := range txs {
+				if i < tt.executed {
+					require.NotNil(t, results[tx.Hash()].Receipt)
+				} else {
+					require.ErrorIs(t, results[tx.Hash()].Err, evm.ErrBudgetExhausted)
+				}
+			}
+			require.Equal(t, uint64(tt.executed), s.GetNonce(sender))
+		})
+	}
+}
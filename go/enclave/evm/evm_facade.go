@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
+	"time"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -22,29 +23,68 @@ import (
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	gethcore "github.com/ethereum/go-ethereum/core"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
 	gethlog "github.com/ethereum/go-ethereum/log"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 )
 
+// TxExecResult is the outcome of the execution of a transaction: the receipt of an included transaction, with the error
+// and the data returned by a failed execution, or the error that excludes the transaction from the batch
+type TxExecResult struct {
+	Receipt *types.Receipt
+	VMErr   error  // the error of a failed execution (e.g. a revert, or running out of gas)
+	Revert  []byte // the data returned by a reverted execution
+	Err     error  // the error that excludes the transaction from the batch
+	// Sponsored are the fees paid by a paymaster instead of the sender, nil if the sender paid them
+	Sponsored *common.SponsoredFee
+}
+
+// ErrBudgetExhausted is the result of the transactions left unexecuted once the execution budget ran out
+var ErrBudgetExhausted = errors.New("execution budget exhausted")
+
+// ExecutionBudget bounds the execution of a list of transactions, so that a batch is sealed on time under heavy load.
+// Unlike the batch gas limit, it is only applied by the sequencer: the transactions left over are not in the batch, so
+// the validators execute the same transactions without it.
+type ExecutionBudget struct {
+	Duration time.Duration // the max time spent executing the transactions (0 for no limit)
+	Gas      uint64        // the gas used by the transactions above which no more are executed (0 for no limit)
+}
+
+// exhausted returns whether no more transactions can be executed. A nil budget is never exhausted
+func (b *ExecutionBudget) exhausted(start time.Time, usedGas uint64) bool {
+	if b == nil {
+		return false
+	}
+	return (b.Duration > 0 && time.Since(start) >= b.Duration) || (b.Gas > 0 && usedGas >= b.Gas)
+}
+
 // ExecuteTransactions
 // header - the header of the rollup where this transaction will be included
 // fromTxIndex - for the receipts and events, the evm needs to know for each transaction the order in which it was executed in the block.
+// The tips go to the pool address, and the base fees are burnt: the batch executor distributes them once the batch is executed.
+// The sponsor, if any, makes the approved paymasters pay the fees of the transactions sent to them (see GasSponsor).
+// The L1 message verifier precompile checks the L1 messages against the L1 view of the batch (see L1MessageVerifierAddress),
+// from the batch height l1MessagesFork.
 func ExecuteTransactions(
 	txs []*common.L2Tx,
 	s *state.StateDB,
 	header *common.BatchHeader,
 	storage storage.Storage,
 	chainConfig *params.ChainConfig,
+	l1MessagesFork *uint64,
 	fromTxIndex int,
 	noBaseFee bool,
 	batchGasLimit uint64,
+	budget *ExecutionBudget,
+	sponsor *GasSponsor,
 	logger gethlog.Logger,
-) map[common.TxHash]interface{} {
+) map[common.TxHash]*TxExecResult {
+	defer BindL1View(storage, header, l1MessagesFork)()
 	chain, vmCfg := initParams(storage, noBaseFee, logger)
 	gp := gethcore.GasPool(batchGasLimit)
 	zero := uint64(0)
 	usedGas := &zero
-	result := map[common.TxHash]interface{}{}
+	result := map[common.TxHash]*TxExecResult{}
 
 	ethHeader, err := gethencoding.CreateEthHeaderForBatch(header, secret(storage))
 	if err != nil {
@@ -53,8 +93,15 @@ func ExecuteTransactions(
 	}
 
 	hash := header.Hash()
+	start := time.Now()
+	included := 0
 	for i, t := range txs {
-		r, err := executeTransaction(
+		// the budget only applies once a transaction was included, so that the batches make progress
+		if included > 0 && budget.exhausted(start, *usedGas) {
+			result[t.Hash()] = &TxExecResult{Err: ErrBudgetExhausted}
+			continue
+		}
+		r, execResult, sponsored, err := executeTransaction(
 			s,
 			chainConfig,
 			chain,
@@ -66,13 +113,15 @@ func ExecuteTransactions(
 			fromTxIndex+i,
 			hash,
 			header.Number.Uint64(),
+			sponsor,
 		)
 		if err != nil {
-			result[t.Hash()] = err
+			result[t.Hash()] = &TxExecResult{Err: err}
 			logger.Info("Failed to execute tx:", log.TxKey, t.Hash(), log.CtrErrKey, err)
 			continue
 		}
-		result[t.Hash()] = r
+		result[t.Hash()] = &TxExecResult{Receipt: r, VMErr: execResult.Err, Revert: execResult.Revert(), Sponsored: sponsored}
+		included++
 		logReceipt(r, logger)
 	}
 	s.Finalise(true)
@@ -91,11 +140,12 @@ func executeTransaction(
 	tCount int,
 	batchHash common.L2BatchHash,
 	batchHeight uint64,
-) (*types.Receipt, error) {
+	sponsor *GasSponsor,
+) (*types.Receipt, *gethcore.ExecutionResult, *common.SponsoredFee, error) {
 	rules := cc.Rules(big.NewInt(0), true, 0)
 	from, err := types.Sender(types.LatestSigner(cc), t)
 	if err != nil {
-		return nil, err
+		return nil, nil, nil, err
 	}
 	s.Prepare(rules, from, gethcommon.Address{}, t.To(), nil, nil)
 	snap := s.Snapshot()
@@ -104,7 +154,7 @@ func executeTransaction(
 	before := header.MixDigest
 	// calculate a random value per transaction
 	header.MixDigest = crypto.CalculateTxRnd(before.Bytes(), tCount)
-	receipt, err := gethcore.ApplyTransaction(cc, chain, nil, gp, s, header, t, usedGas, vmCfg)
+	receipt, result, sponsored, err := applyTransaction(cc, chain, gp, s, header, t, usedGas, vmCfg, sponsor)
 
 	// adjust the receipt to point to the right batch hash
 	if receipt != nil {
@@ -114,25 +164,69 @@ func executeTransaction(
 		for _, l := range receipt.Logs {
 			l.BlockHash = batchHash
 		}
-
-		// Do not increase the balance of zero address as it is the contract deployment address.
-		// Doing so might cause weird interactions.
-		if header.Coinbase.Big().Cmp(gethcommon.Big0) != 0 {
-			gasUsed := big.NewInt(0).SetUint64(receipt.GasUsed)
-			executionGasCost := big.NewInt(0).Mul(gasUsed, header.BaseFee)
-			// As the baseFee is burned, we add it back to the coinbase.
-			// Geth should automatically add the tips.
-			s.AddBalance(header.Coinbase, executionGasCost)
-		}
 	}
 
 	header.MixDigest = before
 	if err != nil {
 		s.RevertToSnapshot(snap)
-		return receipt, err
+		return receipt, nil, nil, err
 	}
 
-	return receipt, nil
+	return receipt, result, sponsored, nil
+}
+
+// applyTransaction is gethcore.ApplyTransaction, which does not return the result of the execution. The result holds
+// the error and the revert data of a failed execution, which are reported to the sender of the transaction. The fees
+// are paid by the paymaster of a sponsored transaction once it is executed, so the receipt holds the token transfer.
+func applyTransaction(
+	cc *params.ChainConfig,
+	chain gethcore.ChainContext,
+	gp *gethcore.GasPool,
+	s *state.StateDB,
+	header *types.Header,
+	t *common.L2Tx,
+	usedGas *uint64,
+	vmCfg vm.Config,
+	sponsor *GasSponsor,
+) (*types.Receipt, *gethcore.ExecutionResult, *common.SponsoredFee, error) {
+	msg, err := gethcore.TransactionToMessage(t, types.MakeSigner(cc, header.Number, header.Time), header.BaseFee)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	blockContext := gethcore.NewEVMBlockContext(header, chain, nil)
+	vmenv := vm.NewEVM(blockContext, gethcore.NewEVMTxContext(msg), s, cc, vmCfg)
+
+	result, err := gethcore.ApplyMessage(vmenv, msg, gp)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	sponsored := sponsor.sponsor(vmenv, s, header.Coinbase, msg, t.Hash(), result)
+
+	var root []byte
+	if cc.IsByzantium(header.Number) {
+		s.Finalise(true)
+	} else {
+		root = s.IntermediateRoot(cc.IsEIP158(header.Number)).Bytes()
+	}
+	*usedGas += result.UsedGas
+
+	receipt := &types.Receipt{Type: t.Type(), PostState: root, CumulativeGasUsed: *usedGas}
+	if result.Failed() {
+		receipt.Status = types.ReceiptStatusFailed
+	} else {
+		receipt.Status = types.ReceiptStatusSuccessful
+	}
+	receipt.TxHash = t.Hash()
+	receipt.GasUsed = result.UsedGas
+	if msg.To == nil {
+		receipt.ContractAddress = gethcrypto.CreateAddress(vmenv.TxContext.Origin, t.Nonce())
+	}
+	receipt.Logs = s.GetLogs(t.Hash(), header.Number.Uint64(), header.Hash())
+	receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	receipt.BlockHash = header.Hash()
+	receipt.BlockNumber = header.Number
+	receipt.TransactionIndex = uint(s.TxIndex())
+	return receipt, result, sponsored, nil
 }
 
 func logReceipt(r *types.Receipt, logger gethlog.Logger) {
@@ -158,28 +252,19 @@ func ExecuteObsCall(
 	header *common.BatchHeader,
 	storage storage.Storage,
 	chainConfig *params.ChainConfig,
+	l1MessagesFork *uint64,
 	gasEstimationCap uint64,
 	logger gethlog.Logger,
 ) (*gethcore.ExecutionResult, error) {
-	noBaseFee := true
-	if header.BaseFee != nil && header.BaseFee.Cmp(gethcommon.Big0) != 0 && msg.GasPrice.Cmp(gethcommon.Big0) != 0 {
-		noBaseFee = false
-	}
-
 	defer core.LogMethodDuration(logger, measure.NewStopwatch(), "evm_facade.go:ObsCall()")
+	defer BindL1View(storage, header, l1MessagesFork)()
 
 	gp := gethcore.GasPool(gasEstimationCap)
 	gp.SetGas(gasEstimationCap)
-	chain, vmCfg := initParams(storage, noBaseFee, nil)
-	ethHeader, err := gethencoding.CreateEthHeaderForBatch(header, secret(storage))
+	vmenv, _, err := newCallEVM(msg, s, header, storage, chainConfig)
 	if err != nil {
 		return nil, err
 	}
-	blockContext := gethcore.NewEVMBlockContext(ethHeader, chain, nil)
-
-	// sets TxKey.origin
-	txContext := gethcore.NewEVMTxContext(msg)
-	vmenv := vm.NewEVM(blockContext, txContext, s, chainConfig, vmCfg)
 
 	result, err := gethcore.ApplyMessage(vmenv, msg, &gp)
 	// Follow the same error check structure as in geth
@@ -206,6 +291,60 @@ func ExecuteObsCall(
 	return result, nil
 }
 
+// SimulateTransaction executes the message on top of the state as if it was a transaction, and returns the result of the
+// execution together with the logs it emitted. Unlike ExecuteObsCall, a reverted execution is not an error: the revert
+// is part of the result.
+func SimulateTransaction(
+	msg *gethcore.Message,
+	s *state.StateDB,
+	header *common.BatchHeader,
+	storage storage.Storage,
+	chainConfig *params.ChainConfig,
+	l1MessagesFork *uint64,
+	gasEstimationCap uint64,
+	logger gethlog.Logger,
+) (*gethcore.ExecutionResult, []*types.Log, error) {
+	defer core.LogMethodDuration(logger, measure.NewStopwatch(), "evm_facade.go:SimulateTransaction()")
+	defer BindL1View(storage, header, l1MessagesFork)()
+
+	gp := gethcore.GasPool(gasEstimationCap)
+	vmenv, ethHeader, err := newCallEVM(msg, s, header, storage, chainConfig)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// the simulated transaction has no hash, the logs are recorded against the zero hash
+	s.SetTxContext(gethcommon.Hash{}, 0)
+	result, err := gethcore.ApplyMessage(vmenv, msg, &gp)
+	if dbErr := s.Error(); dbErr != nil {
+		return nil, nil, newErrorWithReasonAndCode(dbErr)
+	}
+	if err != nil {
+		// the transaction would not be accepted (e.g. insufficient funds for the gas)
+		return nil, nil, newErrorWithReasonAndCode(err)
+	}
+	return result, s.GetLogs(gethcommon.Hash{}, ethHeader.Number.Uint64(), gethcommon.Hash{}), nil
+}
+
+// creates the EVM executing the message in the context of the batch. The base fee is only charged if the message has a
+// gas price, like in geth.
+func newCallEVM(msg *gethcore.Message, s *state.StateDB, header *common.BatchHeader, storage storage.Storage, chainConfig *params.ChainConfig) (*vm.EVM, *types.Header, error) {
+	noBaseFee := true
+	if header.BaseFee != nil && header.BaseFee.Cmp(gethcommon.Big0) != 0 && msg.GasPrice.Cmp(gethcommon.Big0) != 0 {
+		noBaseFee = false
+	}
+	chain, vmCfg := initParams(storage, noBaseFee, nil)
+	ethHeader, err := gethencoding.CreateEthHeaderForBatch(header, secret(storage))
+	if err != nil {
+		return nil, nil, err
+	}
+	blockContext := gethcore.NewEVMBlockContext(ethHeader, chain, nil)
+
+	// sets TxKey.origin
+	txContext := gethcore.NewEVMTxContext(msg)
+	return vm.NewEVM(blockContext, txContext, s, chainConfig, vmCfg), ethHeader, nil
+}
+
 func initParams(storage storage.Storage, noBaseFee bool, l gethlog.Logger) (*ObscuroChainContext, vm.Config) {
 	vmCfg := vm.Config{
 		NoBaseFee: noBaseFee,
@@ -244,6 +383,10 @@ func newRevertError(result *gethcore.ExecutionResult) error {
 	err := errors.New("execution reverted")
 	if errUnpack == nil {
 		err = fmt.Errorf("execution reverted: %v", reason)
+		// the failures of the bridge contracts carry their code, for the UIs to act on them
+		if bridgeErr := errutil.BridgeErrorFromRevert(reason); bridgeErr != nil {
+			err = fmt.Errorf("execution reverted: %w", bridgeErr)
+		}
 	}
 	return &errutil.EVMSerialisableError{
 		Err:    err.Error(),
@@ -0,0 +1,239 @@
+package evm
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/ethdb/memorydb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// the gas of a verification, on top of the gas paid for each byte of the input
+	_l1MessageVerificationGas = 30_000
+	_l1MessageInputByteGas    = 8
+	// the messages can be verified in the L1 blocks up to this many blocks older than the L1 proof of the batch, like the
+	// BLOCKHASH opcode, which bounds the walk back the L1 chain
+	_l1MessageMaxAge = 256
+)
+
+// L1MessageVerifierAddress is the address of the precompile verifying that a log was emitted on the L1. It is called with
+// `abi.encode(bytes32 blockHash, uint256 txIndex, bytes[] receiptProof, uint256 logIndex)`, where the proof is the list
+// of the nodes of the receipts trie of the L1 block leading to the receipt of the transaction. It returns
+// `abi.encode(address emitter, bytes32[] topics, bytes data, uint256 blockNumber)`, and fails when the block is not one
+// of the canonical L1 blocks of the batch, or when the proof does not hold.
+var L1MessageVerifierAddress = gethcommon.HexToAddress("0x000000000000000000000000000000000000f001")
+
+var (
+	bytes32Type, _   = abi.NewType("bytes32", "", nil)
+	bytes32sType, _  = abi.NewType("bytes32[]", "", nil)
+	uint256Type, _   = abi.NewType("uint256", "", nil)
+	bytesType, _     = abi.NewType("bytes", "", nil)
+	bytesListType, _ = abi.NewType("bytes[]", "", nil)
+	addressType, _   = abi.NewType("address", "", nil)
+
+	l1MessageInput = abi.Arguments{
+		{Name: "blockHash", Type: bytes32Type},
+		{Name: "txIndex", Type: uint256Type},
+		{Name: "receiptProof", Type: bytesListType},
+		{Name: "logIndex", Type: uint256Type},
+	}
+	l1MessageOutput = abi.Arguments{
+		{Name: "emitter", Type: addressType},
+		{Name: "topics", Type: bytes32sType},
+		{Name: "data", Type: bytesType},
+		{Name: "blockNumber", Type: uint256Type},
+	}
+)
+
+// The L2 runs the Cancun rules, unlike the L1 chain validated by the enclave, so the precompile does not change the L1
+// execution. Its address is not warm by default, so that it costs the same as an empty account where it is not active.
+func init() {
+	vm.PrecompiledContractsCancun[L1MessageVerifierAddress] = &l1MessageVerifier{}
+}
+
+// l1View is the view of the L1 chain of the batch being executed: the L1 blocks verified by the enclave, up to the L1
+// proof of the batch. The sequencer and the validators have the same view of a batch, so they verify the same messages.
+type l1View struct {
+	storage storage.Storage
+	l1Proof common.L1BlockHash
+}
+
+// newL1View returns the view of the batch, or nil if the precompile is not active at its height. l1MessagesFork is the
+// batch height the precompile activates at, and nil while it is not scheduled.
+func newL1View(storage storage.Storage, header *common.BatchHeader, l1MessagesFork *uint64) *l1View {
+	if l1MessagesFork == nil || header.Number == nil || header.Number.Uint64() < *l1MessagesFork {
+		return nil
+	}
+	return &l1View{storage: storage, l1Proof: header.L1Proof}
+}
+
+func (v *l1View) equals(other *l1View) bool {
+	if v == nil || other == nil {
+		return v == other
+	}
+	return v.storage == other.storage && v.l1Proof == other.l1Proof
+}
+
+// The precompiles of Geth only receive their input, and they are looked up in a map shared by all the EVMs, so the view
+// of an execution can't reach the precompile through its EVM. The view is bound to the precompile for the duration of
+// each execution instead: the executions with the same view (e.g. the calls against the same batch) run concurrently,
+// and the others wait for them to finish.
+type l1ViewBinding struct {
+	mu      sync.Mutex
+	freed   *sync.Cond
+	view    *l1View // nil while the precompile is not active for the executions holding the binding
+	holders int
+	waiting int
+}
+
+var boundL1View = newL1ViewBinding()
+
+func newL1ViewBinding() *l1ViewBinding {
+	b := &l1ViewBinding{}
+	b.freed = sync.NewCond(&b.mu)
+	return b
+}
+
+// bind binds the view to the precompile, until the returned function is called
+func (b *l1ViewBinding) bind(view *l1View) func() {
+	b.mu.Lock()
+	// the executions with the same view do not join while others wait, so that the others are not starved
+	if b.holders > 0 && (!b.view.equals(view) || b.waiting > 0) {
+		b.waiting++
+		for b.holders > 0 {
+			b.freed.Wait()
+		}
+		b.waiting--
+	}
+	if b.holders == 0 {
+		b.view = view
+	}
+	b.holders++
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		defer b.mu.Unlock()
+		b.holders--
+		if b.holders == 0 {
+			b.view = nil
+			b.freed.Broadcast()
+		}
+	}
+}
+
+func (b *l1ViewBinding) current() *l1View {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.view
+}
+
+// BindL1View binds the L1 view of the batch to the precompile verifying the L1 messages, until the returned function is
+// called. The executions of the transactions of the batch must hold it, or they would verify the messages against the
+// view of another execution.
+func BindL1View(storage storage.Storage, header *common.BatchHeader, l1MessagesFork *uint64) func() {
+	return boundL1View.bind(newL1View(storage, header, l1MessagesFork))
+}
+
+type l1MessageVerifier struct{}
+
+func (v *l1MessageVerifier) RequiredGas(input []byte) uint64 {
+	if boundL1View.current() == nil {
+		return 0
+	}
+	return _l1MessageVerificationGas + _l1MessageInputByteGas*uint64(len(input))
+}
+
+func (v *l1MessageVerifier) Run(input []byte) ([]byte, error) {
+	view := boundL1View.current()
+	if view == nil {
+		// below the fork, or outside the executions, the address is an empty account
+		return nil, nil
+	}
+	return verifyL1Message(view, input)
+}
+
+func verifyL1Message(view *l1View, input []byte) ([]byte, error) {
+	args, err := l1MessageInput.Unpack(input)
+	if err != nil {
+		return nil, fmt.Errorf("invalid input. Cause: %w", err)
+	}
+	blockHash := gethcommon.Hash(args[0].([32]byte))
+	txIndex := args[1].(*big.Int)
+	proof := args[2].([][]byte)
+	logIndex := args[3].(*big.Int)
+	if !txIndex.IsUint64() || !logIndex.IsUint64() {
+		return nil, errors.New("index out of range")
+	}
+
+	header, err := canonicalL1Header(view, blockHash)
+	if err != nil {
+		return nil, err
+	}
+
+	proofDB := memorydb.New()
+	for _, node := range proof {
+		if err := proofDB.Put(gethcrypto.Keccak256(node), node); err != nil {
+			return nil, err
+		}
+	}
+	key, err := rlp.EncodeToBytes(txIndex.Uint64())
+	if err != nil {
+		return nil, err
+	}
+	encodedReceipt, err := trie.VerifyProof(header.ReceiptHash, key, proofDB)
+	if err != nil {
+		return nil, fmt.Errorf("invalid receipt proof. Cause: %w", err)
+	}
+	if encodedReceipt == nil {
+		return nil, errors.New("no receipt at the transaction index")
+	}
+	var receipt types.Receipt
+	if err := receipt.UnmarshalBinary(encodedReceipt); err != nil {
+		return nil, fmt.Errorf("invalid receipt. Cause: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		return nil, errors.New("the transaction failed")
+	}
+	if logIndex.Uint64() >= uint64(len(receipt.Logs)) {
+		return nil, errors.New("no log at the log index")
+	}
+
+	l1Log := receipt.Logs[logIndex.Uint64()]
+	topics := make([][32]byte, len(l1Log.Topics))
+	for i, topic := range l1Log.Topics {
+		topics[i] = topic
+	}
+	return l1MessageOutput.Pack(l1Log.Address, topics, l1Log.Data, header.Number)
+}
+
+// returns the header of the L1 block if it is the L1 proof of the batch or one of its latest ancestors
+func canonicalL1Header(view *l1View, blockHash gethcommon.Hash) (*types.Header, error) {
+	proofBlock, err := view.storage.FetchBlock(view.l1Proof)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch the L1 proof of the batch. Cause: %w", err)
+	}
+	block, err := view.storage.FetchBlock(blockHash)
+	if err != nil {
+		return nil, fmt.Errorf("unknown L1 block %s", blockHash)
+	}
+	if block.NumberU64() > proofBlock.NumberU64() || proofBlock.NumberU64()-block.NumberU64() > _l1MessageMaxAge {
+		return nil, fmt.Errorf("the L1 block %s is not within the latest %d blocks of the batch", blockHash, _l1MessageMaxAge)
+	}
+	if !view.storage.IsAncestor(proofBlock, block) {
+		return nil, fmt.Errorf("the L1 block %s is not canonical", blockHash)
+	}
+	return block.Header(), nil
+}
@@ -0,0 +1,184 @@
+package evm
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// l1BlocksStorage holds the L1 blocks verified by the enclave
+type l1BlocksStorage struct {
+	storage.Storage
+	blocks map[common.L1BlockHash]*types.Block
+}
+
+func (s *l1BlocksStorage) FetchBlock(hash common.L1BlockHash) (*types.Block, error) {
+	block, found := s.blocks[hash]
+	if !found {
+		return nil, errutil.ErrNotFound
+	}
+	return block, nil
+}
+
+func (s *l1BlocksStorage) IsAncestor(block *types.Block, maybeAncestor *types.Block) bool {
+	for ; block != nil; block = s.blocks[block.ParentHash()] {
+		if block.Hash() == maybeAncestor.Hash() {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *l1BlocksStorage) add(parent *types.Block, number uint64, receiptHash gethcommon.Hash) *types.Block {
+	header := &types.Header{Number: new(big.Int).SetUint64(number), ReceiptHash: receiptHash, Extra: []byte{byte(len(s.blocks))}}
+	if parent != nil {
+		header.ParentHash = parent.Hash()
+	}
+	block := types.NewBlockWithHeader(header)
+	s.blocks[block.Hash()] = block
+	return block
+}
+
+// proofNodes collects the nodes of a proof of the receipts trie
+type proofNodes [][]byte
+
+func (p *proofNodes) Put(_ []byte, value []byte) error {
+	*p = append(*p, value)
+	return nil
+}
+
+func (p *proofNodes) Delete([]byte) error {
+	return nil
+}
+
+func TestVerifyL1Message(t *testing.T) {
+	emitter := gethcommon.HexToAddress("0x00000000000000000000000000000000000000aa")
+	l1Log := &types.Log{Address: emitter, Topics: []gethcommon.Hash{{0x01}, {0x02}}, Data: []byte("message")}
+	receipts := types.Receipts{
+		{Type: types.LegacyTxType, Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 21_000, Logs: []*types.Log{}},
+		{Type: types.DynamicFeeTxType, Status: types.ReceiptStatusSuccessful, CumulativeGasUsed: 50_000, Logs: []*types.Log{{Address: emitter}, l1Log}},
+		{Type: types.DynamicFeeTxType, Status: types.ReceiptStatusFailed, CumulativeGasUsed: 80_000, Logs: []*types.Log{}},
+	}
+	for _, receipt := range receipts {
+		receipt.Bloom = types.CreateBloom(types.Receipts{receipt})
+	}
+	receiptsTrie := trie.NewEmpty(trie.NewDatabase(rawdb.NewMemoryDatabase()))
+	for i := range receipts {
+		key, err := rlp.EncodeToBytes(uint64(i))
+		require.NoError(t, err)
+		var value bytes.Buffer
+		receipts.EncodeIndex(i, &value)
+		require.NoError(t, receiptsTrie.Update(key, value.Bytes()))
+	}
+	require.Equal(t, types.DeriveSha(receipts, trie.NewStackTrie(nil)), receiptsTrie.Hash())
+	proof := func(txIndex uint64) [][]byte {
+		key, err := rlp.EncodeToBytes(txIndex)
+		require.NoError(t, err)
+		var nodes proofNodes
+		require.NoError(t, receiptsTrie.Prove(key, &nodes))
+		return nodes
+	}
+
+	store := &l1BlocksStorage{blocks: map[common.L1BlockHash]*types.Block{}}
+	genesis := store.add(nil, 1000, types.EmptyReceiptsHash)
+	messageBlock := store.add(genesis, 1001, receiptsTrie.Hash())
+	l1Proof := store.add(messageBlock, 1002, types.EmptyReceiptsHash)
+	forkBlock := store.add(genesis, 1001, receiptsTrie.Hash())
+	view := &l1View{storage: store, l1Proof: l1Proof.Hash()}
+
+	input := func(blockHash gethcommon.Hash, txIndex uint64, logIndex uint64) []byte {
+		packed, err := l1MessageInput.Pack(blockHash, new(big.Int).SetUint64(txIndex), proof(txIndex), new(big.Int).SetUint64(logIndex))
+		require.NoError(t, err)
+		return packed
+	}
+
+	output, err := verifyL1Message(view, input(messageBlock.Hash(), 1, 1))
+	require.NoError(t, err)
+	values, err := l1MessageOutput.Unpack(output)
+	require.NoError(t, err)
+	require.Equal(t, emitter, values[0])
+	require.Equal(t, [][32]byte{{0x01}, {0x02}}, values[1])
+	require.Equal(t, []byte("message"), values[2])
+	require.EqualValues(t, 1001, values[3].(*big.Int).Int64())
+
+	// the logs of the failed transactions, the blocks of other forks and the later blocks are not messages
+	_, err = verifyL1Message(view, input(messageBlock.Hash(), 2, 0))
+	require.ErrorContains(t, err, "failed")
+	_, err = verifyL1Message(view, input(messageBlock.Hash(), 1, 2))
+	require.ErrorContains(t, err, "no log")
+	_, err = verifyL1Message(view, input(forkBlock.Hash(), 1, 1))
+	require.ErrorContains(t, err, "not canonical")
+	_, err = verifyL1Message(&l1View{storage: store, l1Proof: genesis.Hash()}, input(messageBlock.Hash(), 1, 1))
+	require.ErrorContains(t, err, "not within")
+
+	// the proof must lead to the receipt in the receipts trie of the block
+	forged, err := l1MessageInput.Pack(messageBlock.Hash(), big.NewInt(0), proof(1), big.NewInt(1))
+	require.NoError(t, err)
+	_, err = verifyL1Message(view, forged)
+	require.Error(t, err)
+}
+
+func TestL1MessageVerifierActivatesAtItsFork(t *testing.T) {
+	verifier := &l1MessageVerifier{}
+	fork := uint64(100)
+	header := func(height int64) *common.BatchHeader {
+		return &common.BatchHeader{Number: big.NewInt(height), L1Proof: gethcommon.Hash{0x01}}
+	}
+
+	// without the fork, or below it, the address is an empty account
+	for _, release := range []func(){BindL1View(nil, header(200), nil), BindL1View(nil, header(99), &fork)} {
+		require.Zero(t, verifier.RequiredGas([]byte{0x01}))
+		output, err := verifier.Run([]byte{0x01})
+		require.NoError(t, err)
+		require.Nil(t, output)
+		release()
+	}
+
+	release := BindL1View(nil, header(100), &fork)
+	require.Equal(t, uint64(_l1MessageVerificationGas+_l1MessageInputByteGas), verifier.RequiredGas([]byte{0x01}))
+	_, err := verifier.Run([]byte{0x01})
+	require.ErrorContains(t, err, "invalid input")
+	release()
+	require.Nil(t, boundL1View.current())
+}
+
+func TestBindL1View(t *testing.T) {
+	fork := uint64(0)
+	header := func(l1Proof byte) *common.BatchHeader {
+		return &common.BatchHeader{Number: big.NewInt(1), L1Proof: gethcommon.Hash{l1Proof}}
+	}
+
+	// the executions with the same view share the binding
+	releaseFirst := BindL1View(nil, header(0x01), &fork)
+	releaseSecond := BindL1View(nil, header(0x01), &fork)
+	require.Equal(t, gethcommon.Hash{0x01}, boundL1View.current().l1Proof)
+
+	// an execution with another view waits for them to finish
+	bound := make(chan gethcommon.Hash)
+	go func() {
+		release := BindL1View(nil, header(0x02), &fork)
+		bound <- boundL1View.current().l1Proof
+		release()
+	}()
+	releaseFirst()
+	select {
+	case <-bound:
+		t.Fatal("the view was bound while another one was in use")
+	case <-time.After(50 * time.Millisecond):
+	}
+	require.Equal(t, gethcommon.Hash{0x01}, boundL1View.current().l1Proof)
+	releaseSecond()
+	require.Equal(t, gethcommon.Hash{0x02}, <-bound)
+}
@@ -0,0 +1,109 @@
+package evm
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ten-protocol/go-ten/go/common"
+	"golang.org/x/exp/slices"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethcore "github.com/ethereum/go-ethereum/core"
+)
+
+// the gas available to the transfer of the fee token, which is not paid by anyone
+const feeTransferGas = 100_000
+
+const erc20TransferABI = `[{"inputs":[{"internalType":"address","name":"to","type":"address"},{"internalType":"uint256","name":"amount","type":"uint256"}],"name":"transfer","outputs":[{"internalType":"bool","name":"","type":"bool"}],"stateMutability":"nonpayable","type":"function"}]`
+
+var erc20ABI, _ = abi.JSON(strings.NewReader(erc20TransferABI))
+
+// GasSponsor is the paymaster hook of the execution: the contracts approved by the governance pay the fees of the
+// transactions sent to them, in the fee token.
+//
+// A sponsored transaction is executed like any other, so its sender still needs the balance to buy its gas, then the
+// paymaster transfers the fees to the coinbase in the fee token and the native fees are refunded to the sender: the tips
+// are taken back from the pool address, the L1 fee from the coinbase, and the base fees are burnt and not distributed.
+// When the paymaster cannot pay (e.g. it does not hold enough tokens, or the token reverts), the sender pays.
+// The hook runs as part of the batch, with the parameters in force for it, so the validators reproduce the same payments.
+type GasSponsor struct {
+	Token      gethcommon.Address
+	Paymasters []gethcommon.Address
+	L1Fees     map[gethcommon.Hash]*big.Int // the L1 fees paid by the transactions sent to the paymasters, refunded with their gas
+}
+
+// NewGasSponsor returns nil when no paymaster can pay, i.e. there is no fee token or no approved paymaster
+func NewGasSponsor(token gethcommon.Address, paymasters []gethcommon.Address) *GasSponsor {
+	if token == (gethcommon.Address{}) || len(paymasters) == 0 {
+		return nil
+	}
+	return &GasSponsor{Token: token, Paymasters: paymasters, L1Fees: map[gethcommon.Hash]*big.Int{}}
+}
+
+// IsSponsored returns whether the transaction is sent to an approved paymaster. A nil sponsor sponsors nothing
+func (s *GasSponsor) IsSponsored(to *gethcommon.Address) bool {
+	return s != nil && to != nil && slices.Contains(s.Paymasters, *to)
+}
+
+// sponsor makes the paymaster pay the fees of the executed transaction to the coinbase of the batch, and returns them.
+// It returns nil when the transaction is not sponsored, or when the paymaster could not pay.
+func (s *GasSponsor) sponsor(vmenv *vm.EVM, st *state.StateDB, coinbase gethcommon.Address, msg *gethcore.Message, txHash gethcommon.Hash, result *gethcore.ExecutionResult) *common.SponsoredFee {
+	if !s.IsSponsored(msg.To) {
+		return nil
+	}
+	// the EVM pays the tips to the author of the batch, i.e. the pool address
+	pool := vmenv.Context.Coinbase
+	if coinbase == (gethcommon.Address{}) || st.GetCodeSize(s.Token) == 0 {
+		return nil
+	}
+
+	gasUsed := new(big.Int).SetUint64(result.UsedGas)
+	gasFee := new(big.Int).Mul(gasUsed, msg.GasPrice)
+	tips := new(big.Int).Mul(gasUsed, new(big.Int).Sub(msg.GasPrice, vmenv.Context.BaseFee))
+	l1Fee := new(big.Int)
+	if fee, found := s.L1Fees[txHash]; found {
+		l1Fee.Set(fee)
+	}
+	amount := new(big.Int).Add(gasFee, l1Fee)
+	if amount.Sign() == 0 || st.GetBalance(pool).Cmp(tips) < 0 || st.GetBalance(coinbase).Cmp(l1Fee) < 0 {
+		return nil
+	}
+
+	input, err := erc20ABI.Pack("transfer", coinbase, amount)
+	if err != nil {
+		return nil
+	}
+	snap := st.Snapshot()
+	ret, _, err := vmenv.Call(vm.AccountRef(*msg.To), s.Token, input, feeTransferGas, gethcommon.Big0)
+	if err != nil || !transferSucceeded(ret) {
+		st.RevertToSnapshot(snap)
+		return nil
+	}
+
+	st.AddBalance(msg.From, amount)
+	st.SubBalance(pool, tips)
+	st.SubBalance(coinbase, l1Fee)
+	return &common.SponsoredFee{
+		TxHash:    txHash,
+		Paymaster: *msg.To,
+		Token:     s.Token,
+		GasFee:    gasFee,
+		L1Fee:     l1Fee,
+	}
+}
+
+// transferSucceeded returns whether the ERC-20 transfer returned true, or nothing like the tokens predating the standard
+func transferSucceeded(ret []byte) bool {
+	if len(ret) == 0 {
+		return true
+	}
+	values, err := erc20ABI.Unpack("transfer", ret)
+	if err != nil || len(values) != 1 {
+		return false
+	}
+	ok, _ := values[0].(bool)
+	return ok
+}
@@ -0,0 +1,114 @@
+package evm_test
+
+import (
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethcore "github.com/ethereum/go-ethereum/core"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/core/vm"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/contracts/generated/ConstantSupplyERC20"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/enclave/crypto"
+	"github.com/ten-protocol/go-ten/go/enclave/evm"
+)
+
+// TestGasSponsor checks that an approved paymaster pays the fees of the transactions sent to it in the fee token, that
+// the sender gets its native fees back, and that the sender pays when the paymaster cannot
+func TestGasSponsor(t *testing.T) {
+	paymasterKey, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	userKey, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	paymaster := gethcrypto.PubkeyToAddress(paymasterKey.PublicKey)
+	user := gethcrypto.PubkeyToAddress(userKey.PublicKey)
+	token := gethcrypto.CreateAddress(paymaster, 0)
+	brokePaymaster := gethcommon.HexToAddress("0x00000000000000000000000000000000000000bb")
+	store := &secretOnlyStorage{secret: crypto.SharedEnclaveSecret{0x01}}
+	header := &common.BatchHeader{Number: big.NewInt(1), Time: 1_700_000_000, BaseFee: diffBaseFee, GasLimit: diffBatchGasLimit, Coinbase: diffCoinbase}
+
+	s, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	s.AddBalance(paymaster, big.NewInt(params.Ether))
+	s.AddBalance(user, big.NewInt(params.Ether))
+
+	// the paymaster deploys the fee token, and holds its supply
+	tokenABI, err := ConstantSupplyERC20.ConstantSupplyERC20MetaData.GetAbi()
+	require.NoError(t, err)
+	args, err := tokenABI.Pack("", "Fee Token", "FEE", big.NewInt(params.Ether))
+	require.NoError(t, err)
+	code := append(gethcommon.FromHex(ConstantSupplyERC20.ConstantSupplyERC20MetaData.Bin), args...)
+	deployTx := signedTx(t, paymasterKey, &types.DynamicFeeTx{
+		ChainID: diffChainConfig.ChainID, GasTipCap: big.NewInt(params.GWei), GasFeeCap: big.NewInt(2 * params.GWei), Gas: 2_000_000, Data: code,
+	})
+	results := evm.ExecuteTransactions([]*common.L2Tx{deployTx}, s, header, store, diffChainConfig, nil, 0, false, diffBatchGasLimit, nil, nil, gethlog.New())
+	require.Equal(t, types.ReceiptStatusSuccessful, results[deployTx.Hash()].Receipt.Status)
+
+	sponsoredTx := call(0, paymaster, 100_000, nil)(t, userKey)
+	unpaidTx := call(1, brokePaymaster, 100_000, nil)(t, userKey)
+	sponsor := evm.NewGasSponsor(token, []gethcommon.Address{paymaster, brokePaymaster})
+	require.True(t, sponsor.IsSponsored(sponsoredTx.To()))
+	require.False(t, sponsor.IsSponsored(deployTx.To()))
+	require.Nil(t, evm.NewGasSponsor(gethcommon.Address{}, []gethcommon.Address{paymaster}))
+
+	// the L1 fees paid by the transactions before they are executed
+	l1Fee := big.NewInt(1000)
+	sponsor.L1Fees[sponsoredTx.Hash()] = l1Fee
+	s.SubBalance(user, l1Fee)
+	s.AddBalance(diffCoinbase, l1Fee)
+
+	userBefore := new(big.Int).Add(s.GetBalance(user), l1Fee)
+	poolBefore := s.GetBalance(evm.PoolAddress)
+	coinbaseBefore := s.GetBalance(diffCoinbase)
+	paymasterTokens := tokenBalance(t, s, token, paymaster)
+
+	results = evm.ExecuteTransactions([]*common.L2Tx{sponsoredTx, unpaidTx}, s, header, store, diffChainConfig, nil, 0, false, diffBatchGasLimit, nil, sponsor, gethlog.New())
+
+	sponsored := results[sponsoredTx.Hash()]
+	require.NotNil(t, sponsored.Receipt)
+	require.NotNil(t, sponsored.Sponsored)
+	price, err := sponsoredTx.EffectiveGasTip(diffBaseFee)
+	require.NoError(t, err)
+	price.Add(price, diffBaseFee)
+	gasFee := new(big.Int).Mul(new(big.Int).SetUint64(sponsored.Receipt.GasUsed), price)
+	require.Equal(t, &common.SponsoredFee{TxHash: sponsoredTx.Hash(), Paymaster: paymaster, Token: token, GasFee: gasFee, L1Fee: l1Fee}, sponsored.Sponsored)
+	// the receipt holds the transfer of the fee token
+	require.Len(t, sponsored.Receipt.Logs, 1)
+	require.Equal(t, token, sponsored.Receipt.Logs[0].Address)
+
+	paid := new(big.Int).Add(gasFee, l1Fee)
+	require.Equal(t, new(big.Int).Sub(paymasterTokens, paid), tokenBalance(t, s, token, paymaster))
+	require.Equal(t, paid, tokenBalance(t, s, token, diffCoinbase))
+
+	// the paymaster without tokens does not pay, so the sender paid the fees of its transaction
+	unpaid := results[unpaidTx.Hash()]
+	require.NotNil(t, unpaid.Receipt)
+	require.Nil(t, unpaid.Sponsored)
+	unpaidTip, err := unpaidTx.EffectiveGasTip(diffBaseFee)
+	require.NoError(t, err)
+	unpaidGas := new(big.Int).SetUint64(unpaid.Receipt.GasUsed)
+	unpaidFee := new(big.Int).Mul(unpaidGas, new(big.Int).Add(unpaidTip, diffBaseFee))
+
+	require.Zero(t, new(big.Int).Sub(userBefore, unpaidFee).Cmp(s.GetBalance(user)))
+	require.Zero(t, new(big.Int).Add(poolBefore, new(big.Int).Mul(unpaidGas, unpaidTip)).Cmp(s.GetBalance(evm.PoolAddress)))
+	require.Zero(t, new(big.Int).Sub(coinbaseBefore, l1Fee).Cmp(s.GetBalance(diffCoinbase)))
+}
+
+func tokenBalance(t *testing.T, s *state.StateDB, token gethcommon.Address, holder gethcommon.Address) *big.Int {
+	tokenABI, err := ConstantSupplyERC20.ConstantSupplyERC20MetaData.GetAbi()
+	require.NoError(t, err)
+	input, err := tokenABI.Pack("balanceOf", holder)
+	require.NoError(t, err)
+	blockContext := gethcore.NewEVMBlockContext(&types.Header{Number: big.NewInt(1), Difficulty: big.NewInt(0), BaseFee: diffBaseFee}, vanillaChainContext{}, &diffCoinbase)
+	vmenv := vm.NewEVM(blockContext, vm.TxContext{}, s, diffChainConfig, vm.Config{})
+	ret, _, err := vmenv.StaticCall(vm.AccountRef(holder), token, input, 100_000)
+	require.NoError(t, err)
+	return new(big.Int).SetBytes(ret)
+}
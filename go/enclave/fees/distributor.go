@@ -0,0 +1,104 @@
+package fees
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/enclave/governance"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// Distributor credits the base fees collected in a batch. It runs as part of the batch execution, so the validators
+// recompute the same distribution: it must only depend on the batch and on the governed parameters in force for it.
+type Distributor interface {
+	Distribution() common.FeeDistribution
+	// Distribute credits the amount of base fees in the state, and returns the credits
+	Distribute(stateDB *state.StateDB, batch *common.BatchHeader, amount *big.Int) []common.FeeCredit
+}
+
+// NewDistributor returns the distributor selected by the governance. The base fees are credited to the coinbase if the
+// governance did not select a distribution, or did not set the addresses the selected one needs.
+func NewDistributor(params *governance.Parameters) Distributor {
+	switch params.FeeDistribution {
+	case common.FeeBurn:
+		return burnDistributor{}
+	case common.FeeToTreasury:
+		if params.FeeTreasury != (gethcommon.Address{}) {
+			return &treasuryDistributor{treasury: params.FeeTreasury}
+		}
+	case common.FeeToValidators:
+		if len(params.FeeValidators) > 0 {
+			return &validatorsDistributor{validators: params.FeeValidators}
+		}
+	case common.FeeToCoinbase:
+	}
+	return coinbaseDistributor{}
+}
+
+type coinbaseDistributor struct{}
+
+func (coinbaseDistributor) Distribution() common.FeeDistribution {
+	return common.FeeToCoinbase
+}
+
+func (coinbaseDistributor) Distribute(stateDB *state.StateDB, batch *common.BatchHeader, amount *big.Int) []common.FeeCredit {
+	// the zero address is the contract deployment address, crediting it could cause weird interactions
+	if batch.Coinbase == (gethcommon.Address{}) {
+		return nil
+	}
+	return credit(stateDB, batch.Coinbase, amount)
+}
+
+type burnDistributor struct{}
+
+func (burnDistributor) Distribution() common.FeeDistribution {
+	return common.FeeBurn
+}
+
+func (burnDistributor) Distribute(*state.StateDB, *common.BatchHeader, *big.Int) []common.FeeCredit {
+	return nil
+}
+
+type treasuryDistributor struct {
+	treasury gethcommon.Address
+}
+
+func (d *treasuryDistributor) Distribution() common.FeeDistribution {
+	return common.FeeToTreasury
+}
+
+func (d *treasuryDistributor) Distribute(stateDB *state.StateDB, _ *common.BatchHeader, amount *big.Int) []common.FeeCredit {
+	return credit(stateDB, d.treasury, amount)
+}
+
+type validatorsDistributor struct {
+	validators []gethcommon.Address
+}
+
+func (d *validatorsDistributor) Distribution() common.FeeDistribution {
+	return common.FeeToValidators
+}
+
+// Distribute splits the amount evenly, the first validator gets the remainder of the division
+func (d *validatorsDistributor) Distribute(stateDB *state.StateDB, _ *common.BatchHeader, amount *big.Int) []common.FeeCredit {
+	share, remainder := new(big.Int).QuoRem(amount, big.NewInt(int64(len(d.validators))), new(big.Int))
+	var credits []common.FeeCredit
+	for i, validator := range d.validators {
+		validatorShare := share
+		if i == 0 {
+			validatorShare = new(big.Int).Add(share, remainder)
+		}
+		credits = append(credits, credit(stateDB, validator, validatorShare)...)
+	}
+	return credits
+}
+
+func credit(stateDB *state.StateDB, recipient gethcommon.Address, amount *big.Int) []common.FeeCredit {
+	if amount.Sign() == 0 {
+		return nil
+	}
+	stateDB.AddBalance(recipient, amount)
+	return []common.FeeCredit{{Recipient: recipient, Amount: new(big.Int).Set(amount)}}
+}
@@ -0,0 +1,92 @@
+package fees
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/enclave/governance"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	testCoinbase = gethcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	testTreasury = gethcommon.HexToAddress("0x2000000000000000000000000000000000000002")
+	validator1   = gethcommon.HexToAddress("0x3000000000000000000000000000000000000003")
+	validator2   = gethcommon.HexToAddress("0x4000000000000000000000000000000000000004")
+)
+
+func newTestStateDB(t *testing.T) *state.StateDB {
+	stateDB, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	return stateDB
+}
+
+func TestDistributors(t *testing.T) {
+	tests := []struct {
+		name         string
+		params       governance.Parameters
+		coinbase     gethcommon.Address
+		distribution common.FeeDistribution
+		credits      []common.FeeCredit
+	}{
+		{
+			name:         "coinbase by default",
+			coinbase:     testCoinbase,
+			distribution: common.FeeToCoinbase,
+			credits:      []common.FeeCredit{{Recipient: testCoinbase, Amount: big.NewInt(101)}},
+		},
+		{
+			name:         "zero coinbase",
+			distribution: common.FeeToCoinbase,
+		},
+		{
+			name:         "burn",
+			params:       governance.Parameters{FeeDistribution: common.FeeBurn},
+			coinbase:     testCoinbase,
+			distribution: common.FeeBurn,
+		},
+		{
+			name:         "treasury",
+			params:       governance.Parameters{FeeDistribution: common.FeeToTreasury, FeeTreasury: testTreasury},
+			coinbase:     testCoinbase,
+			distribution: common.FeeToTreasury,
+			credits:      []common.FeeCredit{{Recipient: testTreasury, Amount: big.NewInt(101)}},
+		},
+		{
+			name:         "treasury not set",
+			params:       governance.Parameters{FeeDistribution: common.FeeToTreasury},
+			coinbase:     testCoinbase,
+			distribution: common.FeeToCoinbase,
+			credits:      []common.FeeCredit{{Recipient: testCoinbase, Amount: big.NewInt(101)}},
+		},
+		{
+			name:         "validators",
+			params:       governance.Parameters{FeeDistribution: common.FeeToValidators, FeeValidators: []gethcommon.Address{validator1, validator2}},
+			coinbase:     testCoinbase,
+			distribution: common.FeeToValidators,
+			credits: []common.FeeCredit{
+				{Recipient: validator1, Amount: big.NewInt(51)},
+				{Recipient: validator2, Amount: big.NewInt(50)},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stateDB := newTestStateDB(t)
+			distributor := NewDistributor(&tt.params)
+			require.Equal(t, tt.distribution, distributor.Distribution())
+
+			credits := distributor.Distribute(stateDB, &common.BatchHeader{Coinbase: tt.coinbase}, big.NewInt(101))
+			require.Equal(t, tt.credits, credits)
+			for _, credit := range tt.credits {
+				require.Equal(t, credit.Amount, stateDB.GetBalance(credit.Recipient))
+			}
+		})
+	}
+}
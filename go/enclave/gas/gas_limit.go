@@ -0,0 +1,53 @@
+package gas
+
+const (
+	// GasLimitElasticity is the ratio between the gas limit of a batch and its gas target, like the elasticity
+	// multiplier of EIP-1559. The gas limit grows when the batches use more than the target, and shrinks otherwise.
+	GasLimitElasticity = 2
+	// GasLimitChangeDenominator bounds the change of the gas limit between two batches to 1/8 of the parent limit,
+	// reached when the parent batch is full or empty
+	GasLimitChangeDenominator = 8
+)
+
+// NextBatchGasLimit returns the gas limit of the batch following a batch with the given gas limit and gas used. The
+// limit moves toward the demand, proportionally to the distance of the gas used from the target, and stays within the
+// bounds. It only depends on the parent header and on the bounds, so every node derives the same limit.
+func NextBatchGasLimit(parentGasLimit, parentGasUsed, minGasLimit, maxGasLimit uint64) uint64 {
+	// the bounds might have changed since the parent batch
+	parentGasLimit = clamp(parentGasLimit, minGasLimit, maxGasLimit)
+	// the governance bounds keep the products below within an uint64
+	if parentGasUsed > parentGasLimit {
+		parentGasUsed = parentGasLimit
+	}
+
+	target := parentGasLimit / GasLimitElasticity
+	if target == 0 {
+		return parentGasLimit
+	}
+	var next uint64
+	switch {
+	case parentGasUsed > target:
+		// the limit grows by at least 1 gas, so it recovers from the lower bound
+		delta := parentGasLimit * (parentGasUsed - target) / target / GasLimitChangeDenominator
+		if delta == 0 {
+			delta = 1
+		}
+		next = parentGasLimit + delta
+	case parentGasUsed < target:
+		delta := parentGasLimit * (target - parentGasUsed) / target / GasLimitChangeDenominator
+		next = parentGasLimit - delta
+	default:
+		next = parentGasLimit
+	}
+	return clamp(next, minGasLimit, maxGasLimit)
+}
+
+func clamp(v, lo, hi uint64) uint64 {
+	if v < lo {
+		return lo
+	}
+	if v > hi {
+		return hi
+	}
+	return v
+}
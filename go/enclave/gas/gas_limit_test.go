@@ -0,0 +1,50 @@
+package gas
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextBatchGasLimit(t *testing.T) {
+	const (
+		minLimit = 10_000_000
+		maxLimit = 100_000_000
+	)
+	tests := []struct {
+		name        string
+		parentLimit uint64
+		parentUsed  uint64
+		expected    uint64
+	}{
+		{name: "at target", parentLimit: 40_000_000, parentUsed: 20_000_000, expected: 40_000_000},
+		{name: "full", parentLimit: 40_000_000, parentUsed: 40_000_000, expected: 45_000_000},
+		{name: "empty", parentLimit: 40_000_000, parentUsed: 0, expected: 35_000_000},
+		{name: "above target", parentLimit: 40_000_000, parentUsed: 30_000_000, expected: 42_500_000},
+		{name: "just above target", parentLimit: 40_000_000, parentUsed: 20_000_001, expected: 40_000_001},
+		{name: "used above the limit", parentLimit: 40_000_000, parentUsed: 80_000_000, expected: 45_000_000},
+		{name: "upper bound", parentLimit: 95_000_000, parentUsed: 95_000_000, expected: maxLimit},
+		{name: "lower bound", parentLimit: 11_000_000, parentUsed: 0, expected: minLimit},
+		{name: "parent above the bounds", parentLimit: 500_000_000, parentUsed: 50_000_000, expected: maxLimit},
+		{name: "parent below the bounds", parentLimit: 1_000_000, parentUsed: 1_000_000, expected: minLimit},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, NextBatchGasLimit(tt.parentLimit, tt.parentUsed, minLimit, maxLimit))
+		})
+	}
+}
+
+// TestNextBatchGasLimitConverges checks that a constant demand moves the limit until the demand is the gas target
+func TestNextBatchGasLimitConverges(t *testing.T) {
+	limit := uint64(10_000_000)
+	const demand = 30_000_000
+	for i := 0; i < 100; i++ {
+		used := limit
+		if used > demand {
+			used = demand
+		}
+		limit = NextBatchGasLimit(limit, used, 1_000_000, 1_000_000_000)
+	}
+	require.InDelta(t, demand*GasLimitElasticity, limit, demand*GasLimitElasticity/100)
+}
@@ -8,6 +8,7 @@ import (
 	"github.com/ten-protocol/go-ten/go/enclave/storage"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ten-protocol/go-ten/go/common"
 )
@@ -18,9 +19,18 @@ type Account struct {
 	Amount  *big.Int
 }
 
-// Genesis holds a range of prefunded accounts
+// Contract specifies a contract that's predeployed at genesis, with its runtime code and initial storage
+type Contract struct {
+	Address gethcommon.Address
+	Balance *big.Int
+	Code    hexutil.Bytes
+	Storage map[gethcommon.Hash]gethcommon.Hash
+}
+
+// Genesis holds a range of prefunded accounts and predeployed contracts
 type Genesis struct {
-	Accounts []Account
+	Accounts  []Account
+	Contracts []Contract
 }
 
 // New creates a new Genesis given a json string
@@ -72,5 +82,16 @@ func (g Genesis) applyAllocations(storage storage.Storage) (*state.StateDB, erro
 		s.SetBalance(acc.Address, acc.Amount)
 	}
 
+	// deploy the genesis contracts
+	for _, contract := range g.Contracts {
+		s.SetCode(contract.Address, contract.Code)
+		for key, value := range contract.Storage {
+			s.SetState(contract.Address, key, value)
+		}
+		if contract.Balance != nil {
+			s.SetBalance(contract.Address, contract.Balance)
+		}
+	}
+
 	return s, nil
 }
@@ -1,6 +1,7 @@
 package genesis
 
 import (
+	"bytes"
 	"fmt"
 	"math/big"
 	"testing"
@@ -12,6 +13,7 @@ import (
 
 	"github.com/ten-protocol/go-ten/integration/datagenerator"
 
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	gethlog "github.com/ethereum/go-ethereum/log"
 )
 
@@ -38,7 +40,7 @@ func TestDefaultGenesis(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to create temp db: %s", err)
 	}
-	storageDB := storage.NewStorage(backingDB, nil, gethlog.New())
+	storageDB := storage.NewStorage(backingDB, nil, nil, 0, gethlog.New())
 	stateDB, err := gen.applyAllocations(storageDB)
 	if err != nil {
 		t.Fatalf("unable to apply genesis allocations")
@@ -81,7 +83,7 @@ func TestCustomGenesis(t *testing.T) {
 	if err != nil {
 		t.Fatalf("unable to create temp db: %s", err)
 	}
-	storageDB := storage.NewStorage(backingDB, nil, gethlog.New())
+	storageDB := storage.NewStorage(backingDB, nil, nil, 0, gethlog.New())
 	stateDB, err := gen.applyAllocations(storageDB)
 	if err != nil {
 		t.Fatalf("unable to apply genesis allocations")
@@ -94,3 +96,43 @@ func TestCustomGenesis(t *testing.T) {
 		t.Fatalf("unexpected balance")
 	}
 }
+
+func TestGenesisWithContracts(t *testing.T) {
+	testlog.Setup(&testlog.Cfg{
+		LogDir:      testLogs,
+		TestType:    "unit",
+		TestSubtype: "genesis",
+		LogLevel:    gethlog.LvlInfo,
+	})
+
+	contractAddr := datagenerator.RandomAddress()
+	slot := gethcommon.HexToHash("0x01")
+	value := gethcommon.HexToHash("0x2a")
+
+	gen, err := New(fmt.Sprintf(
+		`{"Contracts": [{"Address": "%s", "Balance": 1000, "Code": "0x6080604052", "Storage": {"%s": "%s"}}]}`,
+		contractAddr.Hex(), slot.Hex(), value.Hex()))
+	if err != nil {
+		t.Fatalf("unexpected error %s", err)
+	}
+
+	backingDB, err := sqlite.CreateTemporarySQLiteDB("", "", testlog.Logger())
+	if err != nil {
+		t.Fatalf("unable to create temp db: %s", err)
+	}
+	storageDB := storage.NewStorage(backingDB, nil, nil, 0, gethlog.New())
+	stateDB, err := gen.applyAllocations(storageDB)
+	if err != nil {
+		t.Fatalf("unable to apply genesis allocations")
+	}
+
+	if !bytes.Equal(gethcommon.FromHex("0x6080604052"), stateDB.GetCode(contractAddr)) {
+		t.Fatalf("unexpected code")
+	}
+	if stateDB.GetState(contractAddr, slot) != value {
+		t.Fatalf("unexpected storage value")
+	}
+	if big.NewInt(1000).Cmp(stateDB.GetBalance(contractAddr)) != 0 {
+		t.Fatalf("unexpected balance")
+	}
+}
@@ -0,0 +1,262 @@
+package governance
+
+import (
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/contracts/generated/ManagementContract"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
+	"golang.org/x/exp/slices"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+// ActivationDelay is the number of L1 blocks between the block of a governance change and the first L1 proof of the
+// batches that apply it. Every node derives the same activation from the L1 chain, so they all switch at the same batch,
+// and the delay leaves time for the L1 block of the change to become final.
+const ActivationDelay = 32
+
+// the values accepted for each governed parameter, the changes outside of them are ignored
+var bounds = map[string]struct{ min, max *big.Int }{
+	common.GovernanceBaseFee:        {min: big.NewInt(1), max: big.NewInt(1_000_000_000_000)},
+	common.GovernanceBatchGasLimit:  {min: big.NewInt(1_000_000), max: big.NewInt(1_000_000_000)},
+	common.GovernanceRollupInterval: {min: big.NewInt(1), max: big.NewInt(int64(24 * time.Hour / time.Second))},
+
+	common.GovernanceBatchGasLimitMin: {min: big.NewInt(1_000_000), max: big.NewInt(1_000_000_000)},
+	common.GovernanceBatchGasLimitMax: {min: big.NewInt(1_000_000), max: big.NewInt(1_000_000_000)},
+
+	common.GovernanceFeeDistribution:     {min: big.NewInt(int64(common.FeeToCoinbase)), max: big.NewInt(int64(common.FeeToValidators))},
+	common.GovernanceFeeTreasury:         {min: big.NewInt(1), max: maxAddress},
+	common.GovernanceFeeValidatorAdded:   {min: big.NewInt(1), max: maxAddress},
+	common.GovernanceFeeValidatorRemoved: {min: big.NewInt(1), max: maxAddress},
+
+	common.GovernanceFeeToken:         {min: big.NewInt(1), max: maxAddress},
+	common.GovernancePaymasterAdded:   {min: big.NewInt(1), max: maxAddress},
+	common.GovernancePaymasterRemoved: {min: big.NewInt(1), max: maxAddress},
+
+	common.GovernanceScreeningPolicy: {min: big.NewInt(0), max: maxHash},
+}
+
+// the addresses and the hashes are published as uint256 values
+var (
+	maxAddress = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 160), big.NewInt(1))
+	maxHash    = new(big.Int).Sub(new(big.Int).Lsh(big.NewInt(1), 256), big.NewInt(1))
+)
+
+// Parameters are the governed parameters in force for a batch. The zero values mean that the parameter was never
+// changed by the governance, and that the configured value of the node applies.
+type Parameters struct {
+	BaseFee        *big.Int
+	BatchGasLimit  uint64
+	RollupInterval time.Duration
+
+	BatchGasLimitMin uint64
+	BatchGasLimitMax uint64
+
+	FeeDistribution common.FeeDistribution
+	FeeTreasury     gethcommon.Address
+	FeeValidators   []gethcommon.Address // in the order they were added
+
+	FeeToken   gethcommon.Address   // the ERC-20 the paymasters pay the fees in
+	Paymasters []gethcommon.Address // the contracts approved to pay the fees of the transactions sent to them, in the order they were added
+
+	ScreeningPolicy gethcommon.Hash // the hash of the screening policy the sequencer applies, zero if none
+}
+
+// AdaptiveGasLimit returns whether the gas limit of the batches follows the demand, within the bounds. Otherwise the
+// gas limit is the static BatchGasLimit, or the configured one.
+func (p *Parameters) AdaptiveGasLimit() bool {
+	return p.BatchGasLimitMin != 0 && p.BatchGasLimitMax >= p.BatchGasLimitMin
+}
+
+// Manager ingests the governance changes published by the management contract, and resolves the parameters in force
+type Manager interface {
+	// ProcessL1Block stores the valid governance changes found in the receipts of the block
+	ProcessL1Block(block *common.L1Block, receipts common.L1Receipts) error
+
+	// ActiveParameters returns the parameters in force for the batches with the block as L1 proof
+	ActiveParameters(block *common.L1Block) (*Parameters, error)
+}
+
+type manager struct {
+	mgmtContractAddress *common.L1Address
+	storage             storage.Storage
+	logger              gethlog.Logger
+
+	// the changes are cached in the order of their L1 blocks, and the later changes of a parameter override the earlier
+	// ones. A change can be ingested after the changes of higher blocks when its block is reorged into the chain.
+	changesLock sync.RWMutex
+	changes     []*common.GovernanceChange
+}
+
+func NewManager(mgmtContractAddress *common.L1Address, storage storage.Storage, logger gethlog.Logger) (Manager, error) {
+	changes, err := storage.FetchGovernanceChanges()
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch the governance changes - %w", err)
+	}
+	sortByL1Height(changes)
+	return &manager{
+		mgmtContractAddress: mgmtContractAddress,
+		storage:             storage,
+		logger:              logger,
+		changes:             changes,
+	}, nil
+}
+
+func (m *manager) ProcessL1Block(block *common.L1Block, receipts common.L1Receipts) error {
+	if m.mgmtContractAddress == nil {
+		return nil
+	}
+
+	var changes []*common.GovernanceChange
+	for _, receipt := range receipts {
+		if receipt.Status == types.ReceiptStatusFailed {
+			continue
+		}
+		for _, l := range receipt.Logs {
+			if l.Address != *m.mgmtContractAddress || len(l.Topics) == 0 || l.Topics[0] != ManagementContract.GovernanceParameterChangedEventID {
+				continue
+			}
+			change, err := m.decodeChange(l, block)
+			if err != nil {
+				m.logger.Warn("Ignoring invalid governance change", log.BlockHashKey, block.Hash(), log.ErrKey, err)
+				continue
+			}
+			m.logger.Info("Governance change published", "name", change.Name, "value", change.Value,
+				log.BlockHeightKey, change.L1BlockHeight, "activationHeight", change.ActivationHeight)
+			changes = append(changes, change)
+		}
+	}
+	if len(changes) == 0 {
+		return nil
+	}
+
+	m.changesLock.Lock()
+	defer m.changesLock.Unlock()
+	if err := m.storage.StoreGovernanceChanges(changes); err != nil {
+		return fmt.Errorf("could not store the governance changes - %w", err)
+	}
+	m.changes = append(m.changes, changes...)
+	sortByL1Height(m.changes)
+	return nil
+}
+
+func (m *manager) ActiveParameters(block *common.L1Block) (*Parameters, error) {
+	m.changesLock.RLock()
+	defer m.changesLock.RUnlock()
+
+	params := &Parameters{}
+	for _, change := range m.changes {
+		if change.ActivationHeight > block.NumberU64() {
+			continue
+		}
+		onChain, err := m.isOnChainOf(change, block)
+		if err != nil {
+			return nil, err
+		}
+		if !onChain {
+			continue
+		}
+		switch change.Name {
+		case common.GovernanceBaseFee:
+			params.BaseFee = change.Value
+		case common.GovernanceBatchGasLimit:
+			params.BatchGasLimit = change.Value.Uint64()
+		case common.GovernanceRollupInterval:
+			params.RollupInterval = time.Duration(change.Value.Int64()) * time.Second
+		case common.GovernanceBatchGasLimitMin:
+			params.BatchGasLimitMin = change.Value.Uint64()
+		case common.GovernanceBatchGasLimitMax:
+			params.BatchGasLimitMax = change.Value.Uint64()
+		case common.GovernanceFeeDistribution:
+			params.FeeDistribution = common.FeeDistribution(change.Value.Uint64())
+		case common.GovernanceFeeTreasury:
+			params.FeeTreasury = gethcommon.BigToAddress(change.Value)
+		case common.GovernanceFeeValidatorAdded:
+			validator := gethcommon.BigToAddress(change.Value)
+			if !slices.Contains(params.FeeValidators, validator) {
+				params.FeeValidators = append(params.FeeValidators, validator)
+			}
+		case common.GovernanceFeeValidatorRemoved:
+			validator := gethcommon.BigToAddress(change.Value)
+			params.FeeValidators = slices.DeleteFunc(params.FeeValidators, func(a gethcommon.Address) bool { return a == validator })
+		case common.GovernanceFeeToken:
+			params.FeeToken = gethcommon.BigToAddress(change.Value)
+		case common.GovernancePaymasterAdded:
+			paymaster := gethcommon.BigToAddress(change.Value)
+			if !slices.Contains(params.Paymasters, paymaster) {
+				params.Paymasters = append(params.Paymasters, paymaster)
+			}
+		case common.GovernancePaymasterRemoved:
+			paymaster := gethcommon.BigToAddress(change.Value)
+			params.Paymasters = slices.DeleteFunc(params.Paymasters, func(a gethcommon.Address) bool { return a == paymaster })
+		case common.GovernanceScreeningPolicy:
+			params.ScreeningPolicy = gethcommon.BigToHash(change.Value)
+		}
+	}
+	return params, nil
+}
+
+// the changes published in the L1 blocks that were reorged out of the chain of the block do not apply
+func (m *manager) isOnChainOf(change *common.GovernanceChange, block *common.L1Block) (bool, error) {
+	// the canonical chain is indexed by height, so we avoid walking back the chain when the block is canonical
+	canonical, err := m.storage.FetchCanonicaBlockByHeight(block.Number())
+	if err != nil {
+		return false, fmt.Errorf("could not fetch the canonical block at height %d - %w", block.NumberU64(), err)
+	}
+	if canonical.Hash() != block.Hash() {
+		return m.storage.IsBlockAncestor(block, change.L1BlockHash), nil
+	}
+	changeBlock, err := m.storage.FetchCanonicaBlockByHeight(big.NewInt(int64(change.L1BlockHeight)))
+	if err != nil {
+		return false, fmt.Errorf("could not fetch the canonical block at height %d - %w", change.L1BlockHeight, err)
+	}
+	return changeBlock.Hash() == change.L1BlockHash, nil
+}
+
+func (m *manager) decodeChange(l *types.Log, block *common.L1Block) (*common.GovernanceChange, error) {
+	event, err := ManagementContract.UnpackGovernanceParameterChangedEvent(*l)
+	if err != nil {
+		return nil, fmt.Errorf("could not decode the event - %w", err)
+	}
+	if err := validate(event.Name, event.Value); err != nil {
+		return nil, err
+	}
+	return &common.GovernanceChange{
+		Name:             event.Name,
+		Value:            event.Value,
+		L1BlockHash:      block.Hash(),
+		L1BlockHeight:    block.NumberU64(),
+		ActivationHeight: block.NumberU64() + ActivationDelay,
+	}, nil
+}
+
+// the sort is stable, so the changes of a block stay in the order of their logs
+func sortByL1Height(changes []*common.GovernanceChange) {
+	slices.SortStableFunc(changes, func(a, b *common.GovernanceChange) int {
+		switch {
+		case a.L1BlockHeight < b.L1BlockHeight:
+			return -1
+		case a.L1BlockHeight > b.L1BlockHeight:
+			return 1
+		}
+		return 0
+	})
+}
+
+func validate(name string, value *big.Int) error {
+	bound, found := bounds[name]
+	if !found {
+		return fmt.Errorf("unknown parameter %q", name)
+	}
+	if value == nil || value.Cmp(bound.min) < 0 || value.Cmp(bound.max) > 0 {
+		return fmt.Errorf("the value %s of %s is not between %s and %s", value, name, bound.min, bound.max)
+	}
+	return nil
+}
@@ -0,0 +1,270 @@
+package governance
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/contracts/generated/ManagementContract"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/gethutil"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
+	"github.com/ten-protocol/go-ten/go/enclave/storage/init/sqlite"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	testMgmtContract = gethcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	otherContract    = gethcommon.HexToAddress("0x1000000000000000000000000000000000000002")
+)
+
+func newTestStorage(t *testing.T) storage.Storage {
+	backingDB, err := sqlite.CreateTemporarySQLiteDB("", "", gethlog.New())
+	require.NoError(t, err)
+	return storage.NewStorage(backingDB, nil, nil, 0, gethlog.New())
+}
+
+// extends the chain with blocks up to the height, the branch tag differentiates the blocks of forks
+func extendChain(t *testing.T, s storage.Storage, parent *types.Block, height uint64, branch byte) []*types.Block {
+	var blocks []*types.Block
+	for number := parent.NumberU64() + 1; number <= height; number++ {
+		header := &types.Header{Number: new(big.Int).SetUint64(number), ParentHash: parent.Hash(), Extra: []byte{branch}}
+		block := types.NewBlock(header, nil, nil, nil, trie.NewStackTrie(nil))
+		head, err := s.FetchHeadBlock()
+		require.NoError(t, err)
+		var fork *common.ChainFork
+		if block.ParentHash() != head.Hash() {
+			fork, err = gethutil.LCA(block, head, s)
+			require.NoError(t, err)
+		}
+		require.NoError(t, s.StoreBlock(block, fork))
+		blocks = append(blocks, block)
+		parent = block
+	}
+	return blocks
+}
+
+func newChangeReceipt(t *testing.T, contract gethcommon.Address, status uint64, name string, value int64) *types.Receipt {
+	return newBigChangeReceipt(t, contract, status, name, big.NewInt(value))
+}
+
+func newAddressChangeReceipt(t *testing.T, name string, address gethcommon.Address) *types.Receipt {
+	return newBigChangeReceipt(t, testMgmtContract, types.ReceiptStatusSuccessful, name, address.Big())
+}
+
+func newBigChangeReceipt(t *testing.T, contract gethcommon.Address, status uint64, name string, value *big.Int) *types.Receipt {
+	event := ManagementContract.ParsedManagementContractABI().Events["GovernanceParameterChanged"]
+	data, err := event.Inputs.Pack(name, value)
+	require.NoError(t, err)
+	return &types.Receipt{
+		Status: status,
+		Logs:   []*types.Log{{Address: contract, Topics: []gethcommon.Hash{ManagementContract.GovernanceParameterChangedEventID}, Data: data}},
+	}
+}
+
+func TestGovernanceChangesApplyAfterTheActivationDelay(t *testing.T) {
+	s := newTestStorage(t)
+	genesis := types.NewBlock(&types.Header{Number: big.NewInt(0)}, nil, nil, nil, trie.NewStackTrie(nil))
+	require.NoError(t, s.StoreBlock(genesis, nil))
+	chain := append([]*types.Block{genesis}, extendChain(t, s, genesis, 10+ActivationDelay, 0)...)
+
+	manager, err := NewManager(&testMgmtContract, s, gethlog.New())
+	require.NoError(t, err)
+	err = manager.ProcessL1Block(chain[10], types.Receipts{
+		newChangeReceipt(t, testMgmtContract, types.ReceiptStatusSuccessful, common.GovernanceBaseFee, 7),
+		newChangeReceipt(t, testMgmtContract, types.ReceiptStatusSuccessful, common.GovernanceRollupInterval, 600),
+		newChangeReceipt(t, testMgmtContract, types.ReceiptStatusFailed, common.GovernanceBatchGasLimit, 50_000_000),
+		newChangeReceipt(t, otherContract, types.ReceiptStatusSuccessful, common.GovernanceBatchGasLimit, 50_000_000),
+		newChangeReceipt(t, testMgmtContract, types.ReceiptStatusSuccessful, common.GovernanceBatchGasLimit, 1),
+		newChangeReceipt(t, testMgmtContract, types.ReceiptStatusSuccessful, "unknownParameter", 1),
+	})
+	require.NoError(t, err)
+
+	params, err := manager.ActiveParameters(chain[10+ActivationDelay-1])
+	require.NoError(t, err)
+	require.Equal(t, &Parameters{}, params)
+
+	expected := &Parameters{BaseFee: big.NewInt(7), RollupInterval: 10 * time.Minute}
+	params, err = manager.ActiveParameters(chain[10+ActivationDelay])
+	require.NoError(t, err)
+	require.Equal(t, expected, params)
+
+	// the changes are reloaded from the storage on restart
+	restarted, err := NewManager(&testMgmtContract, s, gethlog.New())
+	require.NoError(t, err)
+	params, err = restarted.ActiveParameters(chain[10+ActivationDelay])
+	require.NoError(t, err)
+	require.Equal(t, expected, params)
+}
+
+func TestGovernanceChangesOfReorgedBlocksDoNotApply(t *testing.T) {
+	s := newTestStorage(t)
+	genesis := types.NewBlock(&types.Header{Number: big.NewInt(0)}, nil, nil, nil, trie.NewStackTrie(nil))
+	require.NoError(t, s.StoreBlock(genesis, nil))
+	chain := append([]*types.Block{genesis}, extendChain(t, s, genesis, 10+ActivationDelay, 0)...)
+
+	manager, err := NewManager(&testMgmtContract, s, gethlog.New())
+	require.NoError(t, err)
+	err = manager.ProcessL1Block(chain[10], types.Receipts{
+		newChangeReceipt(t, testMgmtContract, types.ReceiptStatusSuccessful, common.GovernanceBatchGasLimit, 50_000_000),
+	})
+	require.NoError(t, err)
+
+	// the fork from block 9 becomes canonical and does not contain the change
+	fork := extendChain(t, s, chain[9], 11+ActivationDelay, 1)
+	params, err := manager.ActiveParameters(fork[len(fork)-1])
+	require.NoError(t, err)
+	require.Equal(t, &Parameters{}, params)
+
+	// the batches of the old branch still apply it
+	params, err = manager.ActiveParameters(chain[10+ActivationDelay])
+	require.NoError(t, err)
+	require.Equal(t, &Parameters{BatchGasLimit: 50_000_000}, params)
+}
+
+func TestGovernanceChangesApplyInTheOrderOfTheirBlocks(t *testing.T) {
+	s := newTestStorage(t)
+	genesis := types.NewBlock(&types.Header{Number: big.NewInt(0)}, nil, nil, nil, trie.NewStackTrie(nil))
+	require.NoError(t, s.StoreBlock(genesis, nil))
+	chain := append([]*types.Block{genesis}, extendChain(t, s, genesis, 12+ActivationDelay, 0)...)
+
+	manager, err := NewManager(&testMgmtContract, s, gethlog.New())
+	require.NoError(t, err)
+	// the change of block 11 is ingested after the one of block 12, as when block 11 is reorged into the chain
+	err = manager.ProcessL1Block(chain[12], types.Receipts{
+		newChangeReceipt(t, testMgmtContract, types.ReceiptStatusSuccessful, common.GovernanceBaseFee, 7),
+	})
+	require.NoError(t, err)
+	err = manager.ProcessL1Block(chain[11], types.Receipts{
+		newChangeReceipt(t, testMgmtContract, types.ReceiptStatusSuccessful, common.GovernanceBaseFee, 5),
+	})
+	require.NoError(t, err)
+
+	params, err := manager.ActiveParameters(chain[12+ActivationDelay])
+	require.NoError(t, err)
+	require.Equal(t, &Parameters{BaseFee: big.NewInt(7)}, params)
+
+	// a restarted node loads the same order
+	restarted, err := NewManager(&testMgmtContract, s, gethlog.New())
+	require.NoError(t, err)
+	params, err = restarted.ActiveParameters(chain[12+ActivationDelay])
+	require.NoError(t, err)
+	require.Equal(t, &Parameters{BaseFee: big.NewInt(7)}, params)
+}
+
+func TestFeeDistributionChanges(t *testing.T) {
+	s := newTestStorage(t)
+	genesis := types.NewBlock(&types.Header{Number: big.NewInt(0)}, nil, nil, nil, trie.NewStackTrie(nil))
+	require.NoError(t, s.StoreBlock(genesis, nil))
+	chain := append([]*types.Block{genesis}, extendChain(t, s, genesis, 11+ActivationDelay, 0)...)
+
+	treasury := gethcommon.HexToAddress("0x3000000000000000000000000000000000000003")
+	validator1 := gethcommon.HexToAddress("0x4000000000000000000000000000000000000004")
+	validator2 := gethcommon.HexToAddress("0x5000000000000000000000000000000000000005")
+
+	manager, err := NewManager(&testMgmtContract, s, gethlog.New())
+	require.NoError(t, err)
+	err = manager.ProcessL1Block(chain[10], types.Receipts{
+		newChangeReceipt(t, testMgmtContract, types.ReceiptStatusSuccessful, common.GovernanceFeeDistribution, int64(common.FeeToValidators)),
+		newChangeReceipt(t, testMgmtContract, types.ReceiptStatusSuccessful, common.GovernanceFeeDistribution, 4),
+		newAddressChangeReceipt(t, common.GovernanceFeeTreasury, treasury),
+		newAddressChangeReceipt(t, common.GovernanceFeeValidatorAdded, validator1),
+		newAddressChangeReceipt(t, common.GovernanceFeeValidatorAdded, validator2),
+		newAddressChangeReceipt(t, common.GovernanceFeeValidatorAdded, validator1),
+		newAddressChangeReceipt(t, common.GovernanceFeeValidatorAdded, gethcommon.Address{}),
+	})
+	require.NoError(t, err)
+	err = manager.ProcessL1Block(chain[11], types.Receipts{
+		newAddressChangeReceipt(t, common.GovernanceFeeValidatorRemoved, validator1),
+	})
+	require.NoError(t, err)
+
+	params, err := manager.ActiveParameters(chain[10+ActivationDelay])
+	require.NoError(t, err)
+	require.Equal(t, common.FeeToValidators, params.FeeDistribution)
+	require.Equal(t, treasury, params.FeeTreasury)
+	require.Equal(t, []gethcommon.Address{validator1, validator2}, params.FeeValidators)
+
+	params, err = manager.ActiveParameters(chain[11+ActivationDelay])
+	require.NoError(t, err)
+	require.Equal(t, []gethcommon.Address{validator2}, params.FeeValidators)
+}
+
+func TestPaymasterChanges(t *testing.T) {
+	s := newTestStorage(t)
+	genesis := types.NewBlock(&types.Header{Number: big.NewInt(0)}, nil, nil, nil, trie.NewStackTrie(nil))
+	require.NoError(t, s.StoreBlock(genesis, nil))
+	chain := append([]*types.Block{genesis}, extendChain(t, s, genesis, 11+ActivationDelay, 0)...)
+
+	token := gethcommon.HexToAddress("0x6000000000000000000000000000000000000006")
+	paymaster1 := gethcommon.HexToAddress("0x7000000000000000000000000000000000000007")
+	paymaster2 := gethcommon.HexToAddress("0x8000000000000000000000000000000000000008")
+
+	manager, err := NewManager(&testMgmtContract, s, gethlog.New())
+	require.NoError(t, err)
+	err = manager.ProcessL1Block(chain[10], types.Receipts{
+		newAddressChangeReceipt(t, common.GovernanceFeeToken, token),
+		newAddressChangeReceipt(t, common.GovernancePaymasterAdded, paymaster1),
+		newAddressChangeReceipt(t, common.GovernancePaymasterAdded, paymaster2),
+		newAddressChangeReceipt(t, common.GovernancePaymasterAdded, paymaster1),
+		newAddressChangeReceipt(t, common.GovernancePaymasterAdded, gethcommon.Address{}),
+	})
+	require.NoError(t, err)
+	err = manager.ProcessL1Block(chain[11], types.Receipts{
+		newAddressChangeReceipt(t, common.GovernancePaymasterRemoved, paymaster1),
+	})
+	require.NoError(t, err)
+
+	params, err := manager.ActiveParameters(chain[10+ActivationDelay])
+	require.NoError(t, err)
+	require.Equal(t, token, params.FeeToken)
+	require.Equal(t, []gethcommon.Address{paymaster1, paymaster2}, params.Paymasters)
+
+	params, err = manager.ActiveParameters(chain[11+ActivationDelay])
+	require.NoError(t, err)
+	require.Equal(t, []gethcommon.Address{paymaster2}, params.Paymasters)
+}
+
+func TestScreeningPolicyChanges(t *testing.T) {
+	s := newTestStorage(t)
+	genesis := types.NewBlock(&types.Header{Number: big.NewInt(0)}, nil, nil, nil, trie.NewStackTrie(nil))
+	require.NoError(t, s.StoreBlock(genesis, nil))
+	chain := append([]*types.Block{genesis}, extendChain(t, s, genesis, 11+ActivationDelay, 0)...)
+
+	policy := gethcommon.HexToHash("0xff00000000000000000000000000000000000000000000000000000000000001")
+
+	manager, err := NewManager(&testMgmtContract, s, gethlog.New())
+	require.NoError(t, err)
+	require.NoError(t, manager.ProcessL1Block(chain[10], types.Receipts{
+		newBigChangeReceipt(t, testMgmtContract, types.ReceiptStatusSuccessful, common.GovernanceScreeningPolicy, policy.Big()),
+	}))
+	// publishing zero disables the screening
+	require.NoError(t, manager.ProcessL1Block(chain[11], types.Receipts{
+		newChangeReceipt(t, testMgmtContract, types.ReceiptStatusSuccessful, common.GovernanceScreeningPolicy, 0),
+	}))
+
+	params, err := manager.ActiveParameters(chain[9+ActivationDelay])
+	require.NoError(t, err)
+	require.Equal(t, gethcommon.Hash{}, params.ScreeningPolicy)
+
+	params, err = manager.ActiveParameters(chain[10+ActivationDelay])
+	require.NoError(t, err)
+	require.Equal(t, policy, params.ScreeningPolicy)
+
+	params, err = manager.ActiveParameters(chain[11+ActivationDelay])
+	require.NoError(t, err)
+	require.Equal(t, gethcommon.Hash{}, params.ScreeningPolicy)
+}
+
+func TestAdaptiveGasLimit(t *testing.T) {
+	require.False(t, (&Parameters{}).AdaptiveGasLimit())
+	require.False(t, (&Parameters{BatchGasLimitMin: 10_000_000}).AdaptiveGasLimit())
+	require.False(t, (&Parameters{BatchGasLimitMax: 10_000_000}).AdaptiveGasLimit())
+	require.False(t, (&Parameters{BatchGasLimitMin: 20_000_000, BatchGasLimitMax: 10_000_000}).AdaptiveGasLimit())
+	require.True(t, (&Parameters{BatchGasLimitMin: 10_000_000, BatchGasLimitMax: 10_000_000}).AdaptiveGasLimit())
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	gethcore "github.com/ethereum/go-ethereum/core"
 	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/core/vm"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 	"github.com/ten-protocol/go-ten/go/common/gethapi"
@@ -18,6 +19,10 @@ type ObscuroChain interface {
 	// Note this method also returns the address we should encrypt the balance for, unlike `GetBalanceAtBlock`
 	GetBalance(accountAddress gethcommon.Address, blockNumber *gethrpc.BlockNumber) (*gethcommon.Address, *hexutil.Big, error)
 
+	// GetAccountOwner - Returns the address the private state of an account is visible to in the given state: the account
+	// itself, or the deployer of a contract. It is the address the balance of the account is encrypted for.
+	GetAccountOwner(accountAddress gethcommon.Address, chainState *state.StateDB) (*gethcommon.Address, error)
+
 	// GetBalanceAtBlock - will return the balance of a specific address at the specific given block number (batch number).
 	GetBalanceAtBlock(accountAddr gethcommon.Address, blockNumber *gethrpc.BlockNumber) (*hexutil.Big, error)
 
@@ -27,6 +32,10 @@ type ObscuroChain interface {
 	// ObsCallAtBlock - Execute eth_call RPC against obscuro for a specific block (batch) number.
 	ObsCallAtBlock(apiArgs *gethapi.TransactionArgs, blockNumber *gethrpc.BlockNumber) (*gethcore.ExecutionResult, error)
 
+	// SimulateTransaction - Executes the transaction against the state of a specific block (batch) number without
+	// persisting it, and returns the result, the logs emitted and the resulting state.
+	SimulateTransaction(apiArgs *gethapi.TransactionArgs, blockNumber *gethrpc.BlockNumber) (*gethcore.ExecutionResult, []*types.Log, *state.StateDB, error)
+
 	// GetChainStateAtTransaction - returns the stateDB after applying all the transactions in the batch leading to the desired transaction.
 	GetChainStateAtTransaction(batch *core.Batch, txIndex int, reexec uint64) (*gethcore.Message, vm.BlockContext, *state.StateDB, error)
 }
@@ -27,7 +27,8 @@ import (
 )
 
 type obscuroChain struct {
-	chainConfig *params.ChainConfig
+	chainConfig    *params.ChainConfig
+	l1MessagesFork *uint64 // the batch height the L1 message verifier precompile activates at
 
 	storage storage.Storage
 	genesis *genesis.Genesis
@@ -41,6 +42,7 @@ type obscuroChain struct {
 func NewChain(
 	storage storage.Storage,
 	chainConfig *params.ChainConfig,
+	l1MessagesFork *uint64,
 	genesis *genesis.Genesis,
 	logger gethlog.Logger,
 	registry components.BatchRegistry,
@@ -49,6 +51,7 @@ func NewChain(
 	return &obscuroChain{
 		storage:          storage,
 		chainConfig:      chainConfig,
+		l1MessagesFork:   l1MessagesFork,
 		logger:           logger,
 		genesis:          genesis,
 		Registry:         registry,
@@ -73,26 +76,41 @@ func (oc *obscuroChain) GetBalance(accountAddress gethcommon.Address, blockNumbe
 	address := accountAddress
 	// If the accountAddress is a contract, encrypt with the address of the contract owner
 	if isAddrContract {
-		txHash, err := oc.storage.GetContractCreationTx(accountAddress)
+		deployer, err := oc.contractDeployer(accountAddress)
 		if err != nil {
 			return nil, nil, err
 		}
-		transaction, _, _, _, err := oc.storage.GetTransaction(*txHash)
-		if err != nil {
-			return nil, nil, err
-		}
-		signer := types.NewLondonSigner(oc.chainConfig.ChainID)
-
-		sender, err := signer.Sender(transaction)
-		if err != nil {
-			return nil, nil, err
-		}
-		address = sender
+		address = deployer
 	}
 
 	return &address, balance, nil
 }
 
+func (oc *obscuroChain) GetAccountOwner(accountAddress gethcommon.Address, chainState *state.StateDB) (*gethcommon.Address, error) {
+	if len(chainState.GetCode(accountAddress)) == 0 {
+		return &accountAddress, nil
+	}
+	deployer, err := oc.contractDeployer(accountAddress)
+	if err != nil {
+		return nil, err
+	}
+	return &deployer, nil
+}
+
+// returns the sender of the transaction that created the contract
+func (oc *obscuroChain) contractDeployer(contractAddress gethcommon.Address) (gethcommon.Address, error) {
+	txHash, err := oc.storage.GetContractCreationTx(contractAddress)
+	if err != nil {
+		return gethcommon.Address{}, err
+	}
+	transaction, _, _, _, err := oc.storage.GetTransaction(*txHash)
+	if err != nil {
+		return gethcommon.Address{}, err
+	}
+	signer := types.NewLondonSigner(oc.chainConfig.ChainID)
+	return signer.Sender(transaction)
+}
+
 func (oc *obscuroChain) GetBalanceAtBlock(accountAddr gethcommon.Address, blockNumber *gethrpc.BlockNumber) (*hexutil.Big, error) {
 	chainState, err := oc.Registry.GetBatchStateAtHeight(blockNumber)
 	if err != nil {
@@ -147,7 +165,7 @@ func (oc *obscuroChain) ObsCallAtBlock(apiArgs *gethapi.TransactionArgs, blockNu
 			batch.Header.Root.Hex())
 	}})
 
-	result, err := evm.ExecuteObsCall(callMsg, blockState, batch.Header, oc.storage, oc.chainConfig, oc.gasEstimationCap, oc.logger)
+	result, err := evm.ExecuteObsCall(callMsg, blockState, batch.Header, oc.storage, oc.chainConfig, oc.l1MessagesFork, oc.gasEstimationCap, oc.logger)
 	if err != nil {
 		// also return the result as the result can be evaluated on some errors like ErrIntrinsicGas
 		return result, err
@@ -156,6 +174,33 @@ func (oc *obscuroChain) ObsCallAtBlock(apiArgs *gethapi.TransactionArgs, blockNu
 	return result, nil
 }
 
+func (oc *obscuroChain) SimulateTransaction(apiArgs *gethapi.TransactionArgs, blockNumber *gethrpc.BlockNumber) (*gethcore.ExecutionResult, []*types.Log, *state.StateDB, error) {
+	// fetch the chain state at given batch
+	blockState, err := oc.Registry.GetBatchStateAtHeight(blockNumber)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	batch, err := oc.Registry.GetBatchAtHeight(*blockNumber)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to fetch head state batch. Cause: %w", err)
+	}
+
+	msg, err := apiArgs.ToMessage(batch.Header.GasLimit, batch.Header.BaseFee)
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("unable to convert TransactionArgs to Message - %w", err)
+	}
+	// unlike a call, the simulated transaction must be one the sender could submit
+	msg.SkipAccountChecks = false
+	msg.Nonce = blockState.GetNonce(msg.From)
+
+	result, logs, err := evm.SimulateTransaction(msg, blockState, batch.Header, oc.storage, oc.chainConfig, oc.l1MessagesFork, oc.gasEstimationCap, oc.logger)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return result, logs, blockState, nil
+}
+
 // GetChainStateAtTransaction Returns the state of the chain at certain block height after executing transactions up to the selected transaction
 // TODO make this cacheable
 func (oc *obscuroChain) GetChainStateAtTransaction(batch *core.Batch, txIndex int, _ uint64) (*gethcore.Message, vm.BlockContext, *state.StateDB, error) {
@@ -183,6 +228,7 @@ func (oc *obscuroChain) GetChainStateAtTransaction(batch *core.Batch, txIndex in
 	// TODO - Once the enclave's genesis.json is set, retrieve the signer type using `types.MakeSigner`.
 	rules := oc.chainConfig.Rules(big.NewInt(0), true, 0)
 	signer := types.LatestSigner(oc.chainConfig)
+	defer evm.BindL1View(oc.storage, batch.Header, oc.l1MessagesFork)()
 	for idx, tx := range batch.Transactions {
 		// Assemble the transaction call message and return if the requested offset
 		msg, err := gethcore.TransactionToMessage(tx, signer, big.NewInt(0))
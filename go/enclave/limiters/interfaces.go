@@ -17,4 +17,6 @@ var ErrInsufficientSpace = errors.New("insufficient space in BatchSizeLimiter")
 
 type RollupLimiter interface {
 	AcceptBatch(batch *core.Batch) (bool, error)
+	// AcceptEncodedBatch - same as AcceptBatch, for a batch whose transactions are already rlp encoded
+	AcceptEncodedBatch(encodedTransactions []byte) bool
 }
@@ -31,13 +31,16 @@ func (rl *rollupLimiter) AcceptBatch(batch *core.Batch) (bool, error) {
 	if err != nil {
 		return false, fmt.Errorf("failed to encode data. Cause: %w", err)
 	}
+	return rl.AcceptEncodedBatch(encodedData), nil
+}
 
+func (rl *rollupLimiter) AcceptEncodedBatch(encodedTransactions []byte) bool {
 	// adjust with a compression factor and add the size of a compressed batch header
-	encodedSize := uint64(float64(len(encodedData))*txCompressionFactor) + compressedHeaderSize
+	encodedSize := uint64(float64(len(encodedTransactions))*txCompressionFactor) + compressedHeaderSize
 	if encodedSize > rl.remainingSize {
-		return false, nil
+		return false
 	}
 
 	rl.remainingSize -= encodedSize
-	return true, nil
+	return true
 }
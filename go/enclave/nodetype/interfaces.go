@@ -32,6 +32,12 @@ type Sequencer interface {
 	// and adds as many batches to it as possible.
 	CreateRollup(lastBatchNo uint64) (*common.ExtRollup, error)
 
+	// MempoolReport - returns the pending transactions that waited in the mempool for longer than the inclusion deadline
+	MempoolReport() *common.MempoolReport
+
+	// MempoolStats - returns the aggregate statistics of the mempool, which reveal no individual transaction
+	MempoolStats() *common.MempoolStats
+
 	NodeType
 }
 
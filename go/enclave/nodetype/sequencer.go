@@ -13,6 +13,8 @@ import (
 	"github.com/ten-protocol/go-ten/go/common/errutil"
 	"github.com/ten-protocol/go-ten/go/common/measure"
 	"github.com/ten-protocol/go-ten/go/enclave/evm/ethchainadapter"
+	"github.com/ten-protocol/go-ten/go/enclave/governance"
+	"github.com/ten-protocol/go-ten/go/enclave/screening"
 	"github.com/ten-protocol/go-ten/go/enclave/storage"
 	"github.com/ten-protocol/go-ten/go/enclave/txpool"
 
@@ -26,6 +28,7 @@ import (
 	"github.com/ten-protocol/go-ten/go/enclave/components"
 	"github.com/ten-protocol/go-ten/go/enclave/core"
 	"github.com/ten-protocol/go-ten/go/enclave/crypto"
+	"github.com/ten-protocol/go-ten/go/enclave/evm"
 	"github.com/ten-protocol/go-ten/go/enclave/limiters"
 )
 
@@ -37,6 +40,12 @@ type SequencerSettings struct {
 	GasPaymentAddress gethcommon.Address
 	BatchGasLimit     uint64
 	BaseFee           *big.Int
+	// bounds the execution of the transactions of a batch, the transactions left over wait for the next batches
+	BatchBudget evm.ExecutionBudget
+	// the number of rollups between two state root checkpoints. Zero disables the checkpoints
+	StateRootCheckpointInterval uint64
+	// screens the transactions once the governance published the hash of its policy. Nil disables the screening
+	Screener screening.Screener
 }
 
 type sequencer struct {
@@ -46,6 +55,8 @@ type sequencer struct {
 	rollupProducer    components.RollupProducer
 	rollupConsumer    components.RollupConsumer
 	rollupCompression *components.RollupCompression
+	rollupBuilder     *components.RollupBuilder
+	governance        governance.Manager
 
 	logger gethlog.Logger
 
@@ -58,6 +69,8 @@ type sequencer struct {
 	dataCompressionService compression.DataCompressionService
 	settings               SequencerSettings
 	blockchain             *ethchainadapter.EthChainAdapter
+
+	rollupsSinceCheckpoint uint64 // the number of rollups created since the last state root checkpoint
 }
 
 func NewSequencer(
@@ -67,6 +80,8 @@ func NewSequencer(
 	rollupProducer components.RollupProducer,
 	rollupConsumer components.RollupConsumer,
 	rollupCompression *components.RollupCompression,
+	rollupBuilder *components.RollupBuilder,
+	governance governance.Manager,
 	logger gethlog.Logger,
 	hostID gethcommon.Address,
 	chainConfig *params.ChainConfig,
@@ -85,6 +100,8 @@ func NewSequencer(
 		rollupProducer:         rollupProducer,
 		rollupConsumer:         rollupConsumer,
 		rollupCompression:      rollupCompression,
+		rollupBuilder:          rollupBuilder,
+		governance:             governance,
 		logger:                 logger,
 		hostID:                 hostID,
 		chainConfig:            chainConfig,
@@ -124,6 +141,9 @@ func (s *sequencer) CreateBatch(skipBatchIfEmpty bool) error {
 		}
 	}
 
+	// the transactions left out of the previous batches for longer than the inclusion deadline are reported
+	s.mempool.InclusionReport()
+
 	return s.createNewHeadBatch(l1HeadBlock, skipBatchIfEmpty)
 }
 
@@ -174,6 +194,7 @@ func (s *sequencer) createGenesisBatch(block *common.L1Block) error {
 		common.L2Transactions{msgBusTx},
 		uint64(time.Now().Unix()),
 		false,
+		nil,
 	)
 	if err != nil {
 		if errors.Is(err, components.ErrNoTransactionsToProcess) {
@@ -217,12 +238,23 @@ func (s *sequencer) createNewHeadBatch(l1HeadBlock *common.L1Block, skipBatchIfE
 
 	// todo (@stefan) - limit on receipts too
 	limiter := limiters.NewBatchSizeLimiter(s.settings.MaxBatchSize)
+	screener, err := s.activeScreener(l1HeadBlock)
+	if err != nil {
+		return err
+	}
 	pendingTransactions := s.mempool.PendingTransactions()
 	var transactions []*types.Transaction
-	for _, group := range pendingTransactions {
+	for sender, group := range pendingTransactions {
 		// lazily resolve transactions until the batch runs out of space
 		for _, lazyTx := range group {
 			if tx := lazyTx.Resolve(); tx != nil {
+				if screener != nil {
+					// the later transactions of the sender cannot be executed without this one
+					if err := screener.Screen(sender, tx.Tx); err != nil {
+						s.logger.Debug("Transaction left out of the batch", log.TxKey, tx.Tx.Hash(), log.ErrKey, err)
+						break
+					}
+				}
 				err = limiter.AcceptTransaction(tx.Tx)
 				if err != nil {
 					if errors.Is(err, limiters.ErrInsufficientSpace) { // Batch ran out of space
@@ -242,7 +274,8 @@ func (s *sequencer) createNewHeadBatch(l1HeadBlock *common.L1Block, skipBatchIfE
 	}
 
 	// todo - time is set only here; take from l1 block?
-	if _, err := s.produceBatch(sequencerNo.Add(sequencerNo, big.NewInt(1)), l1HeadBlock.Hash(), headBatch.Hash(), transactions, uint64(time.Now().Unix()), skipBatchIfEmpty); err != nil {
+	cb, err := s.produceBatch(sequencerNo.Add(sequencerNo, big.NewInt(1)), l1HeadBlock.Hash(), headBatch.Hash(), transactions, uint64(time.Now().Unix()), skipBatchIfEmpty, &s.settings.BatchBudget)
+	if err != nil {
 		if errors.Is(err, components.ErrNoTransactionsToProcess) {
 			// skip batch production when there are no transactions to process
 			// todo: this might be a useful event to track for metrics (skipping batch production because empty batch)
@@ -251,6 +284,8 @@ func (s *sequencer) createNewHeadBatch(l1HeadBlock *common.L1Block, skipBatchIfE
 		}
 		return fmt.Errorf(" failed producing batch. Cause: %w", err)
 	}
+	// the waits of the transactions taken from the mempool estimate the inclusion latency
+	s.mempool.RecordInclusions(cb.Batch.Transactions)
 
 	return nil
 }
@@ -262,6 +297,7 @@ func (s *sequencer) produceBatch(
 	transactions common.L2Transactions,
 	batchTime uint64,
 	failForEmptyBatch bool,
+	budget *evm.ExecutionBudget, // only bounds the transactions of the mempool, the others are not carried over
 ) (*components.ComputedBatch, error) {
 	cb, err := s.batchProducer.ComputeBatch(&components.BatchExecutionContext{
 		BlockPtr:     l1Hash,
@@ -272,6 +308,7 @@ func (s *sequencer) produceBatch(
 		BaseFee:      s.settings.BaseFee,
 		ChainConfig:  s.chainConfig,
 		SequencerNo:  sequencerNo,
+		Budget:       budget,
 	}, failForEmptyBatch)
 	if err != nil {
 		return nil, fmt.Errorf("failed computing batch. Cause: %w", err)
@@ -297,6 +334,7 @@ func (s *sequencer) produceBatch(
 	if err != nil {
 		return nil, fmt.Errorf("unable to remove tx from mempool - %w", err)
 	}
+	s.mempool.Forget(cb.Batch.Transactions)
 
 	return cb, nil
 }
@@ -322,6 +360,11 @@ func (s *sequencer) StoreExecutedBatch(batch *core.Batch, receipts types.Receipt
 
 	s.batchRegistry.OnBatchExecuted(batch, receipts)
 
+	// the rollups are created from the database when the builder misses batches, so the batch is stored regardless
+	if err := s.rollupBuilder.AddBatch(batch); err != nil {
+		s.logger.Warn("Could not add the batch to the rollup builder", log.BatchHashKey, batch.Hash(), log.ErrKey, err)
+	}
+
 	return nil
 }
 
@@ -333,24 +376,53 @@ func (s *sequencer) CreateRollup(lastBatchNo uint64) (*common.ExtRollup, error)
 		return nil, err
 	}
 	upToL1Height := currentL1Head.NumberU64() - RollupDelay
-	rollup, err := s.rollupProducer.CreateInternalRollup(lastBatchNo, upToL1Height, rollupLimiter)
+	snapshot, err := s.rollupBuilder.Snapshot(lastBatchNo, upToL1Height, rollupLimiter)
+	if errors.Is(err, components.ErrRollupNotBuilt) {
+		// e.g. the batches produced before the enclave restarted
+		var rollup *core.Rollup
+		rollup, err = s.rollupProducer.CreateInternalRollup(lastBatchNo, upToL1Height, rollupLimiter)
+		snapshot = &components.RollupSnapshot{Rollup: rollup}
+	}
 	if err != nil {
 		return nil, err
 	}
+	rollup := snapshot.Rollup
 
-	extRollup, err := s.rollupCompression.CreateExtRollup(rollup)
+	extRollup, err := s.rollupCompression.CreateExtRollupFromSnapshot(snapshot)
 	if err != nil {
 		return nil, fmt.Errorf("failed to compress rollup: %w", err)
 	}
+	// after the compression, which drops the last batches of a rollup exceeding the decompression limit. The
+	// checkpoint is part of the signed header, which the external rollup shares
+	isCheckpoint := s.addStateRootCheckpoint(rollup)
 
 	// todo - double-check that this signing approach is secure, and it properly includes the entire payload
 	if err := s.signRollup(extRollup); err != nil {
 		return nil, fmt.Errorf("failed to sign created rollup: %w", err)
 	}
 
+	if isCheckpoint {
+		s.rollupsSinceCheckpoint = 0
+		s.logger.Info("Created rollup with a state root checkpoint", log.RollupHashKey, extRollup.Hash(),
+			"lastBatchSeqNo", rollup.Header.LastBatchSeqNo, "stateRoot", rollup.Header.StateRootCheckpoint)
+	}
+	s.rollupsSinceCheckpoint++
 	return extRollup, nil
 }
 
+// The state root of the last batch of the rollup is published every StateRootCheckpointInterval rollups, starting with
+// the first rollup created by this enclave. Returns whether the rollup is a checkpoint.
+func (s *sequencer) addStateRootCheckpoint(rollup *core.Rollup) bool {
+	if s.settings.StateRootCheckpointInterval == 0 || len(rollup.Batches) == 0 {
+		return false
+	}
+	if s.rollupsSinceCheckpoint != 0 && s.rollupsSinceCheckpoint < s.settings.StateRootCheckpointInterval {
+		return false
+	}
+	rollup.Header.StateRootCheckpoint = rollup.Batches[len(rollup.Batches)-1].Header.Root
+	return true
+}
+
 func (s *sequencer) duplicateBatches(l1Head *types.Block, nonCanonicalL1Path []common.L1BlockHash) error {
 	batchesToDuplicate := make([]*core.Batch, 0)
 
@@ -389,7 +461,7 @@ func (s *sequencer) duplicateBatches(l1Head *types.Block, nonCanonicalL1Path []c
 		}
 		sequencerNo = sequencerNo.Add(sequencerNo, big.NewInt(1))
 		// create the duplicate and store/broadcast it, recreate batch even if it was empty
-		cb, err := s.produceBatch(sequencerNo, l1Head.ParentHash(), currentHead, orphanBatch.Transactions, orphanBatch.Header.Time, false)
+		cb, err := s.produceBatch(sequencerNo, l1Head.ParentHash(), currentHead, orphanBatch.Transactions, orphanBatch.Header.Time, false, nil)
 		if err != nil {
 			return fmt.Errorf("could not produce batch. Cause %w", err)
 		}
@@ -401,9 +473,38 @@ func (s *sequencer) duplicateBatches(l1Head *types.Block, nonCanonicalL1Path []c
 }
 
 func (s *sequencer) SubmitTransaction(transaction *common.L2Tx) error {
+	l1Head, err := s.blockProcessor.GetHead()
+	if err != nil {
+		return fmt.Errorf("failed retrieving l1 head. Cause: %w", err)
+	}
+	screener, err := s.activeScreener(l1Head)
+	if err != nil {
+		return err
+	}
+	if screener != nil {
+		sender, err := types.Sender(types.LatestSignerForChainID(transaction.ChainId()), transaction)
+		if err != nil {
+			return fmt.Errorf("could not recover the sender of the transaction - %w", err)
+		}
+		if err := screener.Screen(sender, transaction); err != nil {
+			return err
+		}
+	}
 	return s.mempool.Add(transaction)
 }
 
+// activeScreener returns the screener of the sequencer if the governance published the hash of its policy, nil otherwise
+func (s *sequencer) activeScreener(l1Head *common.L1Block) (screening.Screener, error) {
+	if s.settings.Screener == nil {
+		return nil, nil //nolint:nilnil
+	}
+	params, err := s.governance.ActiveParameters(l1Head)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve the governance parameters - %w", err)
+	}
+	return screening.Active(s.settings.Screener, params.ScreeningPolicy), nil
+}
+
 func (s *sequencer) OnL1Fork(fork *common.ChainFork) error {
 	if !fork.IsFork() {
 		return nil
@@ -427,6 +528,14 @@ func (s *sequencer) OnL1Fork(fork *common.ChainFork) error {
 	return nil
 }
 
+func (s *sequencer) MempoolReport() *common.MempoolReport {
+	return s.mempool.InclusionReport()
+}
+
+func (s *sequencer) MempoolStats() *common.MempoolStats {
+	return s.mempool.Stats()
+}
+
 func (s *sequencer) signBatch(batch *core.Batch) error {
 	var err error
 	h := batch.Hash()
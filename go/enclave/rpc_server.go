@@ -7,10 +7,13 @@ import (
 	"fmt"
 	"math/big"
 	"net"
+	"time"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/codec"
 	"github.com/ten-protocol/go-ten/go/common/errutil"
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/common/rpc"
@@ -30,15 +33,17 @@ type RPCServer struct {
 	grpcServer    *grpc.Server
 	logger        gethlog.Logger
 	listenAddress string
+	batchCodec    codec.Codec // encodes the batches returned to the host
 }
 
 // NewEnclaveRPCServer prepares an enclave RPCServer (doesn't start listening until `StartServer` is called
-func NewEnclaveRPCServer(listenAddress string, enclave common.Enclave, logger gethlog.Logger) *RPCServer {
+func NewEnclaveRPCServer(listenAddress string, enclave common.Enclave, batchCodec codec.Type, logger gethlog.Logger) *RPCServer {
 	return &RPCServer{
 		enclave:       enclave,
 		grpcServer:    grpc.NewServer(),
 		logger:        logger,
 		listenAddress: listenAddress,
+		batchCodec:    codec.New(batchCodec),
 	}
 }
 
@@ -72,11 +77,17 @@ func (s *RPCServer) Status(context.Context, *generated.StatusRequest) (*generate
 	if status.L2Head != nil {
 		l2Head = status.L2Head.Bytes()
 	}
+	var enclaveTime int64
+	if !status.Time.IsZero() {
+		enclaveTime = status.Time.UnixMilli()
+	}
 	return &generated.StatusResponse{
-		StatusCode:  int32(status.StatusCode),
-		L1Head:      status.L1Head.Bytes(),
-		L2Head:      l2Head,
-		SystemError: toRPCError(sysError),
+		StatusCode:     int32(status.StatusCode),
+		L1Head:         status.L1Head.Bytes(),
+		L2Head:         l2Head,
+		SystemError:    toRPCError(sysError),
+		RollupInterval: uint64(status.RollupInterval / time.Second),
+		Time:           enclaveTime,
 	}, nil
 }
 
@@ -318,7 +329,7 @@ func (s *RPCServer) GetBatch(_ context.Context, request *generated.GetBatchReque
 		return nil, err
 	}
 
-	encodedBatch, encodingErr := batch.Encoded()
+	encodedBatch, encodingErr := s.batchCodec.EncodeBatch(batch)
 	var sysErr *generated.SystemError
 	if encodingErr != nil {
 		sysErr = &generated.SystemError{
@@ -340,7 +351,7 @@ func (s *RPCServer) GetBatchBySeqNo(_ context.Context, request *generated.GetBat
 		return nil, err
 	}
 
-	encodedBatch, encodingErr := batch.Encoded()
+	encodedBatch, encodingErr := s.batchCodec.EncodeBatch(batch)
 	var sysErr *generated.SystemError
 	if encodingErr != nil {
 		sysErr = &generated.SystemError{
@@ -393,6 +404,86 @@ func (s *RPCServer) DebugEventLogRelevancy(_ context.Context, req *generated.Deb
 	return &generated.DebugEventLogRelevancyResponse{Msg: string(logs), SystemError: toRPCError(sysError)}, nil
 }
 
+func (s *RPCServer) MempoolReport(_ context.Context, _ *generated.MempoolReportRequest) (*generated.MempoolReportResponse, error) {
+	report, sysError := s.enclave.MempoolReport()
+	if sysError != nil {
+		s.logger.Error("Error reporting the mempool", log.ErrKey, sysError)
+		return &generated.MempoolReportResponse{SystemError: toRPCError(sysError)}, nil
+	}
+
+	reportBytes, err := json.Marshal(report)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode the mempool report - %w", err)
+	}
+	return &generated.MempoolReportResponse{Report: reportBytes}, nil
+}
+
+func (s *RPCServer) MempoolStats(_ context.Context, _ *generated.MempoolStatsRequest) (*generated.MempoolStatsResponse, error) {
+	stats, sysError := s.enclave.MempoolStats()
+	if sysError != nil {
+		s.logger.Error("Error computing the mempool stats", log.ErrKey, sysError)
+		return &generated.MempoolStatsResponse{SystemError: toRPCError(sysError)}, nil
+	}
+
+	statsBytes, err := json.Marshal(stats)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode the mempool stats - %w", err)
+	}
+	return &generated.MempoolStatsResponse{Stats: statsBytes}, nil
+}
+
+func (s *RPCServer) RollupWitness(_ context.Context, req *generated.RollupWitnessRequest) (*generated.RollupWitnessResponse, error) {
+	witness, sysError := s.enclave.RollupWitness(gethcommon.BytesToHash(req.RollupHash))
+	if sysError != nil {
+		s.logger.Error("Error producing the rollup witness", log.ErrKey, sysError)
+		return &generated.RollupWitnessResponse{SystemError: toRPCError(sysError)}, nil
+	}
+	return &generated.RollupWitnessResponse{Witness: witness}, nil
+}
+
+func (s *RPCServer) VerifyRollupWitness(_ context.Context, req *generated.VerifyRollupWitnessRequest) (*generated.VerifyRollupWitnessResponse, error) {
+	verdict, sysError := s.enclave.VerifyRollupWitness(req.Witness)
+	if sysError != nil {
+		s.logger.Error("Error verifying the rollup witness", log.ErrKey, sysError)
+		return &generated.VerifyRollupWitnessResponse{SystemError: toRPCError(sysError)}, nil
+	}
+
+	verdictBytes, err := json.Marshal(verdict)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode the rollup verdict - %w", err)
+	}
+	return &generated.VerifyRollupWitnessResponse{Verdict: verdictBytes}, nil
+}
+
+// the profiles are streamed in chunks that fit within the default gRPC message size
+const profileChunkSize = 1024 * 1024
+
+func (s *RPCServer) DebugProfile(req *generated.DebugProfileRequest, stream generated.EnclaveProto_DebugProfileServer) error {
+	profile, sysError := s.enclave.DebugProfile(&common.ProfileRequest{
+		Kind:      req.Kind,
+		Seconds:   hexutil.Uint64(req.Seconds),
+		Timestamp: hexutil.Uint64(req.Timestamp),
+		EnclaveID: gethcommon.BytesToAddress(req.EnclaveID),
+		ChainID:   hexutil.Uint64(req.ChainID),
+		Signature: req.Signature,
+	})
+	if sysError != nil {
+		s.logger.Error("Error profiling the enclave", log.ErrKey, sysError)
+		return stream.Send(&generated.DebugProfileChunk{SystemError: toRPCError(sysError)})
+	}
+
+	for start := 0; start < len(profile); start += profileChunkSize {
+		end := start + profileChunkSize
+		if end > len(profile) {
+			end = len(profile)
+		}
+		if err := stream.Send(&generated.DebugProfileChunk{Data: profile[start:end]}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (s *RPCServer) GetTotalContractCount(_ context.Context, _ *generated.GetTotalContractCountRequest) (*generated.GetTotalContractCountResponse, error) {
 	count, sysError := s.enclave.GetTotalContractCount()
 	if sysError != nil {
@@ -0,0 +1,102 @@
+package screening
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ten-protocol/go-ten/go/common"
+	"golang.org/x/exp/slices"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// ErrScreened is returned for the transactions denied by the screening policy in force
+var ErrScreened = errors.New("transaction denied by the screening policy of the sequencer")
+
+// Screener is an optional module screening the transactions the sequencer includes in its batches. It only applies
+// once the governance has published the hash of its policy on the L1, so anyone can verify which policy, if any, the
+// sequencer applies.
+type Screener interface {
+	// PolicyHash identifies the policy applied by the screener
+	PolicyHash() gethcommon.Hash
+	// Screen returns ErrScreened if the policy denies the transaction of the sender
+	Screen(sender gethcommon.Address, tx *common.L2Tx) error
+}
+
+// DenyList screens the transactions sent by, or to, the addresses of the list
+type DenyList struct {
+	denied     map[gethcommon.Address]struct{}
+	policyHash gethcommon.Hash
+}
+
+// NewDenyList returns the screener denying the addresses. Its policy hash is the keccak256 hash of the concatenated
+// addresses, in ascending order and without duplicates, so it does not depend on how the list is written.
+func NewDenyList(addresses []gethcommon.Address) *DenyList {
+	sorted := slices.Clone(addresses)
+	slices.SortFunc(sorted, func(a, b gethcommon.Address) int { return bytes.Compare(a.Bytes(), b.Bytes()) })
+	sorted = slices.Compact(sorted)
+
+	denied := make(map[gethcommon.Address]struct{}, len(sorted))
+	preimage := make([]byte, 0, len(sorted)*gethcommon.AddressLength)
+	for _, address := range sorted {
+		denied[address] = struct{}{}
+		preimage = append(preimage, address.Bytes()...)
+	}
+	return &DenyList{denied: denied, policyHash: crypto.Keccak256Hash(preimage)}
+}
+
+// LoadDenyList reads the deny list from a file with one hex address per line. The empty lines and the lines starting
+// with # are ignored.
+func LoadDenyList(path string) (*DenyList, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not open the deny list - %w", err)
+	}
+	defer file.Close()
+
+	var addresses []gethcommon.Address
+	scanner := bufio.NewScanner(file)
+	for lineNo := 1; scanner.Scan(); lineNo++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if !gethcommon.IsHexAddress(line) {
+			return nil, fmt.Errorf("invalid address %q on line %d of the deny list", line, lineNo)
+		}
+		addresses = append(addresses, gethcommon.HexToAddress(line))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("could not read the deny list - %w", err)
+	}
+	return NewDenyList(addresses), nil
+}
+
+func (d *DenyList) PolicyHash() gethcommon.Hash {
+	return d.policyHash
+}
+
+func (d *DenyList) Screen(sender gethcommon.Address, tx *common.L2Tx) error {
+	if _, denied := d.denied[sender]; denied {
+		return fmt.Errorf("%w: sender %s", ErrScreened, sender)
+	}
+	if tx.To() != nil {
+		if _, denied := d.denied[*tx.To()]; denied {
+			return fmt.Errorf("%w: recipient %s", ErrScreened, tx.To())
+		}
+	}
+	return nil
+}
+
+// Active returns the screener if the governance published the hash of its policy, nil otherwise
+func Active(screener Screener, governedPolicy gethcommon.Hash) Screener {
+	if screener == nil || governedPolicy == (gethcommon.Hash{}) || screener.PolicyHash() != governedPolicy {
+		return nil
+	}
+	return screener
+}
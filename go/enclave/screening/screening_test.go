@@ -0,0 +1,62 @@
+package screening
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	deniedAddress  = gethcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	deniedAddress2 = gethcommon.HexToAddress("0x2000000000000000000000000000000000000002")
+	allowedAddress = gethcommon.HexToAddress("0x3000000000000000000000000000000000000003")
+)
+
+func newTx(to gethcommon.Address) *types.Transaction {
+	return types.NewTx(&types.LegacyTx{To: &to, GasPrice: big.NewInt(1), Gas: 21_000})
+}
+
+func TestDenyListScreensTheSendersAndTheRecipients(t *testing.T) {
+	denyList := NewDenyList([]gethcommon.Address{deniedAddress, deniedAddress2})
+
+	require.NoError(t, denyList.Screen(allowedAddress, newTx(allowedAddress)))
+	require.ErrorIs(t, denyList.Screen(deniedAddress, newTx(allowedAddress)), ErrScreened)
+	require.ErrorIs(t, denyList.Screen(allowedAddress, newTx(deniedAddress2)), ErrScreened)
+	// the contract deployments have no recipient
+	require.NoError(t, denyList.Screen(allowedAddress, types.NewTx(&types.LegacyTx{GasPrice: big.NewInt(1), Gas: 100_000})))
+}
+
+func TestDenyListPolicyHashDoesNotDependOnTheOrder(t *testing.T) {
+	hash := NewDenyList([]gethcommon.Address{deniedAddress, deniedAddress2}).PolicyHash()
+	require.Equal(t, hash, NewDenyList([]gethcommon.Address{deniedAddress2, deniedAddress, deniedAddress2}).PolicyHash())
+	require.NotEqual(t, hash, NewDenyList([]gethcommon.Address{deniedAddress}).PolicyHash())
+}
+
+func TestLoadDenyList(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "denylist.txt")
+	content := "# sanctioned addresses\n" + deniedAddress2.Hex() + "\n\n  " + deniedAddress.Hex() + "  \n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	denyList, err := LoadDenyList(path)
+	require.NoError(t, err)
+	require.Equal(t, NewDenyList([]gethcommon.Address{deniedAddress, deniedAddress2}).PolicyHash(), denyList.PolicyHash())
+
+	require.NoError(t, os.WriteFile(path, []byte("not an address\n"), 0o600))
+	_, err = LoadDenyList(path)
+	require.ErrorContains(t, err, "line 1")
+}
+
+func TestScreenerOnlyAppliesOnceItsPolicyIsPublished(t *testing.T) {
+	denyList := NewDenyList([]gethcommon.Address{deniedAddress})
+
+	require.Nil(t, Active(nil, denyList.PolicyHash()))
+	require.Nil(t, Active(denyList, gethcommon.Hash{}))
+	require.Nil(t, Active(denyList, gethcommon.HexToHash("0x01")))
+	require.Equal(t, denyList, Active(denyList, denyList.PolicyHash()))
+}
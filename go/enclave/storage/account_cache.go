@@ -0,0 +1,202 @@
+package storage
+
+import (
+	"fmt"
+	"sync"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ethereum/go-ethereum/trie/trienode"
+)
+
+// the number of state commits between two logs of the statistics of the account cache
+const accountCacheStatsInterval = 1000
+
+// AccountCacheStats are the statistics of the hot tier of the account state
+type AccountCacheStats struct {
+	Hits          uint64
+	Misses        uint64 // the reads served by the account trie
+	Evictions     uint64 // the accounts evicted to make room for more recently touched ones
+	Invalidations uint64 // the times the cache was emptied because the state was committed on another branch
+	Size          int
+}
+
+// hotAccountCache is the hot tier of the account state: the accounts recently touched by the batches, as of the root
+// of the last state committed by a batch. The cold tier is the account trie, in the database.
+//
+// The cache only serves the tries opened at its root. When a batch built on that root commits its state, the accounts
+// it updated are written through and the cache moves to the new root, so the next batch reads them from memory. A
+// state committed on top of another root (e.g. after a reorg) empties the cache, which then follows the new branch.
+type hotAccountCache struct {
+	mu       sync.Mutex
+	root     gethcommon.Hash
+	accounts lru.BasicLRU[gethcommon.Address, *types.StateAccount] // a nil account is known not to exist
+	stats    AccountCacheStats
+	commits  uint64
+	logger   gethlog.Logger
+}
+
+func newHotAccountCache(capacity int, logger gethlog.Logger) *hotAccountCache {
+	return &hotAccountCache{
+		accounts: lru.NewBasicLRU[gethcommon.Address, *types.StateAccount](capacity),
+		logger:   logger,
+	}
+}
+
+// get returns the account as of the root, if the cache holds it
+func (c *hotAccountCache) get(root gethcommon.Hash, addr gethcommon.Address) (*types.StateAccount, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if root != c.root {
+		return nil, false
+	}
+	account, ok := c.accounts.Get(addr)
+	if !ok {
+		c.stats.Misses++
+		return nil, false
+	}
+	c.stats.Hits++
+	return copyAccount(account), true
+}
+
+// add caches the account read from the trie at the root, unless the cache moved on to another root meanwhile
+func (c *hotAccountCache) add(root gethcommon.Hash, addr gethcommon.Address, account *types.StateAccount) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if root == c.root {
+		c.put(addr, account)
+	}
+}
+
+// commit moves the cache to the root, applying the accounts updated on top of the parent root. A nil account was
+// deleted
+func (c *hotAccountCache) commit(parent gethcommon.Hash, root gethcommon.Hash, updated map[gethcommon.Address]*types.StateAccount) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	// the entries of another branch are stale, but the accounts updated by the commit are current either way
+	if parent != c.root {
+		if c.root != (gethcommon.Hash{}) {
+			c.stats.Invalidations++
+		}
+		c.accounts.Purge()
+	}
+	for addr, account := range updated {
+		c.put(addr, account)
+	}
+	c.root = root
+
+	c.commits++
+	if c.commits%accountCacheStatsInterval == 0 {
+		c.logger.Info("Account cache statistics", "hits", c.stats.Hits, "misses", c.stats.Misses,
+			"evictions", c.stats.Evictions, "invalidations", c.stats.Invalidations, "size", c.accounts.Len())
+	}
+}
+
+func (c *hotAccountCache) put(addr gethcommon.Address, account *types.StateAccount) {
+	if c.accounts.Add(addr, copyAccount(account)) {
+		c.stats.Evictions++
+	}
+}
+
+func (c *hotAccountCache) Stats() AccountCacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	stats := c.stats
+	stats.Size = c.accounts.Len()
+	return stats
+}
+
+func copyAccount(account *types.StateAccount) *types.StateAccount {
+	if account == nil {
+		return nil
+	}
+	return account.Copy()
+}
+
+// hotStateDatabase is the state database whose account tries read through the hot account cache
+type hotStateDatabase struct {
+	state.Database
+	cache *hotAccountCache
+}
+
+func (db *hotStateDatabase) OpenTrie(root gethcommon.Hash) (state.Trie, error) {
+	t, err := db.Database.OpenTrie(root)
+	if err != nil {
+		return nil, err
+	}
+	return &hotTrie{Trie: t, root: normaliseRoot(root), cache: db.cache, updated: map[gethcommon.Address]*types.StateAccount{}}, nil
+}
+
+func (db *hotStateDatabase) CopyTrie(t state.Trie) state.Trie {
+	hot, ok := t.(*hotTrie)
+	if !ok {
+		return db.Database.CopyTrie(t)
+	}
+	updated := make(map[gethcommon.Address]*types.StateAccount, len(hot.updated))
+	for addr, account := range hot.updated {
+		updated[addr] = copyAccount(account)
+	}
+	return &hotTrie{Trie: db.Database.CopyTrie(hot.Trie), root: hot.root, cache: hot.cache, updated: updated}
+}
+
+// hotTrie is an account trie that reads the accounts through the hot account cache, and writes the accounts it
+// updated through to the cache when it is committed
+type hotTrie struct {
+	state.Trie
+	root    gethcommon.Hash // the root the trie was opened at
+	cache   *hotAccountCache
+	updated map[gethcommon.Address]*types.StateAccount // the accounts updated since the trie was opened
+}
+
+func (t *hotTrie) GetAccount(addr gethcommon.Address) (*types.StateAccount, error) {
+	// the accounts updated since the trie was opened are read from the trie, which holds their new values
+	if _, ok := t.updated[addr]; !ok {
+		if account, ok := t.cache.get(t.root, addr); ok {
+			return account, nil
+		}
+	}
+	account, err := t.Trie.GetAccount(addr)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := t.updated[addr]; !ok {
+		t.cache.add(t.root, addr, account)
+	}
+	return account, nil
+}
+
+func (t *hotTrie) UpdateAccount(addr gethcommon.Address, account *types.StateAccount) error {
+	if err := t.Trie.UpdateAccount(addr, account); err != nil {
+		return err
+	}
+	t.updated[addr] = copyAccount(account)
+	return nil
+}
+
+func (t *hotTrie) DeleteAccount(addr gethcommon.Address) error {
+	if err := t.Trie.DeleteAccount(addr); err != nil {
+		return err
+	}
+	t.updated[addr] = nil
+	return nil
+}
+
+func (t *hotTrie) Commit(collectLeaf bool) (gethcommon.Hash, *trienode.NodeSet, error) {
+	root, nodes, err := t.Trie.Commit(collectLeaf)
+	if err != nil {
+		return root, nodes, fmt.Errorf("could not commit the account trie - %w", err)
+	}
+	t.cache.commit(t.root, normaliseRoot(root), t.updated)
+	return root, nodes, nil
+}
+
+// normaliseRoot returns the root of the empty trie for the zero hash, which both stand for the empty state
+func normaliseRoot(root gethcommon.Hash) gethcommon.Hash {
+	if root == (gethcommon.Hash{}) {
+		return types.EmptyRootHash
+	}
+	return root
+}
@@ -0,0 +1,109 @@
+package storage
+
+import (
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	alice = gethcommon.HexToAddress("0xa11ce")
+	bob   = gethcommon.HexToAddress("0xb0b")
+)
+
+func newHotStateDatabase(capacity int) *hotStateDatabase {
+	return &hotStateDatabase{
+		Database: state.NewDatabase(rawdb.NewMemoryDatabase()),
+		cache:    newHotAccountCache(capacity, gethlog.New()),
+	}
+}
+
+// commitBalances commits the balances on top of the state at the root, and returns the new root
+func commitBalances(t *testing.T, db state.Database, root gethcommon.Hash, balances map[gethcommon.Address]int64) gethcommon.Hash {
+	stateDB, err := state.New(root, db, nil)
+	require.NoError(t, err)
+	for addr, balance := range balances {
+		stateDB.SetBalance(addr, big.NewInt(balance))
+	}
+	newRoot, err := stateDB.Commit(0, true)
+	require.NoError(t, err)
+	require.NoError(t, db.TrieDB().Commit(newRoot, false))
+	return newRoot
+}
+
+func balanceAt(t *testing.T, db state.Database, root gethcommon.Hash, addr gethcommon.Address) int64 {
+	stateDB, err := state.New(root, db, nil)
+	require.NoError(t, err)
+	return stateDB.GetBalance(addr).Int64()
+}
+
+func TestAccountCacheFollowsCommittedState(t *testing.T) {
+	db := newHotStateDatabase(10)
+
+	root := commitBalances(t, db, types.EmptyRootHash, map[gethcommon.Address]int64{alice: 1, bob: 2})
+	// the accounts written by the commit are served from memory
+	require.EqualValues(t, 1, balanceAt(t, db, root, alice))
+	require.EqualValues(t, 2, balanceAt(t, db, root, bob))
+	stats := db.cache.Stats()
+	require.EqualValues(t, 2, stats.Hits)
+	require.EqualValues(t, 0, stats.Misses)
+
+	next := commitBalances(t, db, root, map[gethcommon.Address]int64{alice: 3})
+	require.EqualValues(t, 3, balanceAt(t, db, next, alice))
+	require.EqualValues(t, 2, balanceAt(t, db, next, bob))
+
+	// the state at an older root is read from the trie
+	require.EqualValues(t, 1, balanceAt(t, db, root, alice))
+	require.Equal(t, stats.Misses, db.cache.Stats().Misses)
+}
+
+func TestAccountCacheIgnoresUncommittedState(t *testing.T) {
+	db := newHotStateDatabase(10)
+	root := commitBalances(t, db, types.EmptyRootHash, map[gethcommon.Address]int64{alice: 1})
+
+	// e.g. an eth_call, which changes the state without committing it
+	stateDB, err := state.New(root, db, nil)
+	require.NoError(t, err)
+	stateDB.SetBalance(alice, big.NewInt(5))
+	stateDB.IntermediateRoot(true)
+
+	require.EqualValues(t, 1, balanceAt(t, db, root, alice))
+}
+
+func TestAccountCacheInvalidatedByOtherBranch(t *testing.T) {
+	db := newHotStateDatabase(10)
+	ancestor := commitBalances(t, db, types.EmptyRootHash, map[gethcommon.Address]int64{alice: 1})
+	commitBalances(t, db, ancestor, map[gethcommon.Address]int64{alice: 2})
+
+	// a reorg commits a sibling state on top of the ancestor, which only keeps the accounts it updated
+	sibling := commitBalances(t, db, ancestor, map[gethcommon.Address]int64{alice: 7})
+	stats := db.cache.Stats()
+	require.EqualValues(t, 1, stats.Invalidations)
+	require.Equal(t, 1, stats.Size)
+
+	require.EqualValues(t, 7, balanceAt(t, db, sibling, alice))
+	require.Equal(t, stats.Hits+1, db.cache.Stats().Hits)
+}
+
+func TestAccountCacheEvictsLeastRecentlyTouched(t *testing.T) {
+	db := newHotStateDatabase(2)
+	carol := gethcommon.HexToAddress("0xca201")
+	balances := map[gethcommon.Address]int64{alice: 1, bob: 2, carol: 3}
+	root := commitBalances(t, db, types.EmptyRootHash, balances)
+
+	stats := db.cache.Stats()
+	require.EqualValues(t, 1, stats.Evictions)
+	require.Equal(t, 2, stats.Size)
+
+	// the evicted account is read from the trie
+	for _, addr := range []gethcommon.Address{alice, bob, carol} {
+		require.EqualValues(t, balances[addr], balanceAt(t, db, root, addr))
+	}
+	require.Positive(t, db.cache.Stats().Misses)
+}
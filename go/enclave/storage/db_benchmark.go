@@ -0,0 +1,99 @@
+package storage
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/measure"
+	"github.com/ten-protocol/go-ten/go/enclave/storage/enclavedb"
+)
+
+const (
+	benchmarkEntries   = 1000
+	benchmarkValueSize = 256
+)
+
+// all the benchmark keys are written under this prefix so they can't clash with real data
+var benchmarkKeyPrefix = []byte("storage-benchmark-")
+
+// benchmarkDB writes, reads and deletes a fixed set of entries and logs the effective throughput.
+// It gives operators a rough idea of how the configured storage performs on their disk class.
+// Failures are logged and never prevent the enclave from starting.
+func benchmarkDB(db enclavedb.EnclaveDB, logger gethlog.Logger) {
+	logger.Warn(fmt.Sprintf("Running the storage benchmark, %d temporary entries will be written to and deleted from the enclave database", benchmarkEntries))
+	value := make([]byte, benchmarkValueSize)
+	keys := make([][]byte, benchmarkEntries)
+	for i := range keys {
+		keys[i] = benchmarkKey(i)
+	}
+
+	writeDuration, err := timeDBOperation(func() error {
+		batch := db.NewBatch()
+		for _, k := range keys {
+			if err := batch.Put(k, value); err != nil {
+				return err
+			}
+		}
+		return batch.Write()
+	})
+	if err != nil {
+		logger.Warn("Storage benchmark failed on write", log.ErrKey, err)
+		return
+	}
+
+	readDuration, err := timeDBOperation(func() error {
+		for _, k := range keys {
+			if _, err := db.Get(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		logger.Warn("Storage benchmark failed on read", log.ErrKey, err)
+	}
+
+	batch := db.NewBatch()
+	for _, k := range keys {
+		if err := batch.Delete(k); err != nil {
+			logger.Warn("Storage benchmark failed to clean up", log.ErrKey, err)
+			return
+		}
+	}
+	if err := batch.Write(); err != nil {
+		logger.Warn("Storage benchmark failed to clean up", log.ErrKey, err)
+		return
+	}
+
+	logger.Info("Storage benchmark completed",
+		"entries", benchmarkEntries,
+		"value_size", benchmarkValueSize,
+		"write_duration", writeDuration,
+		"write_ops_per_sec", opsPerSecond(benchmarkEntries, writeDuration),
+		"read_duration", readDuration,
+		"read_ops_per_sec", opsPerSecond(benchmarkEntries, readDuration),
+	)
+}
+
+func timeDBOperation(op func() error) (time.Duration, error) {
+	stopwatch := measure.NewStopwatch()
+	err := op()
+	return stopwatch.Measure(), err
+}
+
+func benchmarkKey(i int) []byte {
+	key := make([]byte, len(benchmarkKeyPrefix)+8)
+	copy(key, benchmarkKeyPrefix)
+	binary.BigEndian.PutUint64(key[len(benchmarkKeyPrefix):], uint64(i))
+	return key
+}
+
+func opsPerSecond(ops int, duration time.Duration) string {
+	if duration <= 0 {
+		return "n/a"
+	}
+	return fmt.Sprintf("%.0f", float64(ops)/duration.Seconds())
+}
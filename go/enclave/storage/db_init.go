@@ -2,6 +2,7 @@ package storage
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/ten-protocol/go-ten/go/enclave/storage/enclavedb"
 
@@ -13,11 +14,36 @@ import (
 	"github.com/ten-protocol/go-ten/go/config"
 )
 
+// the journal modes supported by sqlite
+var validJournalModes = map[string]bool{
+	"DELETE":   true,
+	"TRUNCATE": true,
+	"PERSIST":  true,
+	"MEMORY":   true,
+	"WAL":      true,
+	"OFF":      true,
+}
+
 // CreateDBFromConfig creates an appropriate ethdb.Database instance based on your config
 func CreateDBFromConfig(cfg *config.EnclaveConfig, logger gethlog.Logger) (enclavedb.EnclaveDB, error) {
 	if err := validateDBConf(cfg); err != nil {
 		return nil, err
 	}
+	db, err := createDB(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	// the in-memory db is shared between connections via the sqlite cache, so it keeps the single connection default
+	if !cfg.UseInMemoryDB && cfg.MaxDBConnections > 0 {
+		db.GetSQLDB().SetMaxOpenConns(cfg.MaxDBConnections)
+	}
+	if cfg.StorageBenchmarkEnabled {
+		benchmarkDB(db, logger)
+	}
+	return db, nil
+}
+
+func createDB(cfg *config.EnclaveConfig, logger gethlog.Logger) (enclavedb.EnclaveDB, error) {
 	if cfg.UseInMemoryDB {
 		logger.Info("UseInMemoryDB flag is true, data will not be persisted. Creating in-memory database...")
 		// this creates a temporary sqlite sqldb
@@ -29,7 +55,7 @@ func CreateDBFromConfig(cfg *config.EnclaveConfig, logger gethlog.Logger) (encla
 		logger.Warn("Attestation is disabled, using a basic sqlite DB for persistence")
 		// when we want to test persistence after node restart the SqliteDBPath should be set
 		// (if empty string then a temp sqldb file will be created for the lifetime of the enclave)
-		return sqlite.CreateTemporarySQLiteDB(cfg.SqliteDBPath, "_foreign_keys=on", logger)
+		return sqlite.CreateTemporarySQLiteDB(cfg.SqliteDBPath, sqliteDBOptions(cfg), logger)
 	}
 
 	// persistent and with attestation means connecting to edgeless DB in a trusted enclave from a secure enclave
@@ -37,6 +63,21 @@ func CreateDBFromConfig(cfg *config.EnclaveConfig, logger gethlog.Logger) (encla
 	return getEdgelessDB(cfg, logger)
 }
 
+// sqliteDBOptions builds the connection string options for a persistent sqlite db from the tuning config
+func sqliteDBOptions(cfg *config.EnclaveConfig) string {
+	opts := []string{"_foreign_keys=on"}
+	if cfg.SqliteJournalMode != "" {
+		opts = append(opts, "_journal_mode="+strings.ToUpper(cfg.SqliteJournalMode))
+	}
+	if cfg.SqliteCacheSize != 0 {
+		opts = append(opts, fmt.Sprintf("_cache_size=%d", cfg.SqliteCacheSize))
+	}
+	if cfg.SqliteBusyTimeout > 0 {
+		opts = append(opts, fmt.Sprintf("_busy_timeout=%d", cfg.SqliteBusyTimeout))
+	}
+	return strings.Join(opts, "&")
+}
+
 // validateDBConf high-level checks that you have a valid configuration for DB creation
 func validateDBConf(cfg *config.EnclaveConfig) error {
 	if cfg.UseInMemoryDB && cfg.EdgelessDBHost != "" {
@@ -54,6 +95,20 @@ func validateDBConf(cfg *config.EnclaveConfig) error {
 	if cfg.SqliteDBPath != "" && cfg.WillAttest {
 		return fmt.Errorf("willAttest=true so sqlite database will not be used and no path is needed, but sqliteDBPath=%s", cfg.SqliteDBPath)
 	}
+	if cfg.SqliteJournalMode != "" && !validJournalModes[strings.ToUpper(cfg.SqliteJournalMode)] {
+		return fmt.Errorf("invalid db config, unsupported sqliteJournalMode=%s", cfg.SqliteJournalMode)
+	}
+	if cfg.SqliteBusyTimeout < 0 {
+		return fmt.Errorf("invalid db config, sqliteBusyTimeout must not be negative, but sqliteBusyTimeout=%d", cfg.SqliteBusyTimeout)
+	}
+	if cfg.MaxDBConnections < 0 {
+		return fmt.Errorf("invalid db config, maxDBConnections must not be negative, but maxDBConnections=%d", cfg.MaxDBConnections)
+	}
+	// sqlite fails with table locks when there are multiple connections, unless it runs in WAL mode
+	usesSqliteFile := !cfg.UseInMemoryDB && !cfg.WillAttest
+	if usesSqliteFile && cfg.MaxDBConnections > 1 && strings.ToUpper(cfg.SqliteJournalMode) != "WAL" {
+		return fmt.Errorf("invalid db config, maxDBConnections=%d requires sqliteJournalMode=WAL, but sqliteJournalMode=%s", cfg.MaxDBConnections, cfg.SqliteJournalMode)
+	}
 	return nil
 }
 
@@ -0,0 +1,47 @@
+package storage
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/config"
+)
+
+func TestSqliteDBOptions(t *testing.T) {
+	cfg := &config.EnclaveConfig{}
+	// no tuning keeps the previous connection string
+	require.Equal(t, "_foreign_keys=on", sqliteDBOptions(cfg))
+
+	cfg.SqliteJournalMode = "wal"
+	cfg.SqliteCacheSize = -2000
+	cfg.SqliteBusyTimeout = 5000
+	require.Equal(t, "_foreign_keys=on&_journal_mode=WAL&_cache_size=-2000&_busy_timeout=5000", sqliteDBOptions(cfg))
+}
+
+func TestValidateSqliteTuning(t *testing.T) {
+	tests := []struct {
+		name  string
+		cfg   config.EnclaveConfig
+		valid bool
+	}{
+		{name: "defaults", cfg: config.EnclaveConfig{}, valid: true},
+		{name: "unsupported journal mode", cfg: config.EnclaveConfig{SqliteJournalMode: "FAST"}, valid: false},
+		{name: "negative busy timeout", cfg: config.EnclaveConfig{SqliteBusyTimeout: -1}, valid: false},
+		{name: "negative connections", cfg: config.EnclaveConfig{MaxDBConnections: -1}, valid: false},
+		{name: "single sqlite connection", cfg: config.EnclaveConfig{MaxDBConnections: 1}, valid: true},
+		{name: "multiple sqlite connections without WAL", cfg: config.EnclaveConfig{MaxDBConnections: 4, SqliteJournalMode: "DELETE"}, valid: false},
+		{name: "multiple sqlite connections with WAL", cfg: config.EnclaveConfig{MaxDBConnections: 4, SqliteJournalMode: "wal"}, valid: true},
+		{name: "multiple in-memory connections are ignored", cfg: config.EnclaveConfig{UseInMemoryDB: true, MaxDBConnections: 4}, valid: true},
+		{name: "multiple EdgelessDB connections", cfg: config.EnclaveConfig{WillAttest: true, EdgelessDBHost: "edb", MaxDBConnections: 4}, valid: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateDBConf(&tt.cfg)
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package enclavedb
+
+import (
+	"database/sql"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+const (
+	addressBloomInsert = "replace into batch_address_bloom values (?,?)"
+	// the batches executed before the blooms were introduced have no bloom
+	addressBloomSelect = "select b.sequence, abl.bloom from batch b left join batch_address_bloom abl on abl.sequence=b.sequence" +
+		" where b.is_canonical=true and b.is_executed=true and b.sequence >= ? and b.sequence <= ? order by b.sequence"
+)
+
+func WriteBatchAddressBloom(dbtx DBTransaction, seqNo uint64, bloom types.Bloom) {
+	dbtx.ExecuteSQL(addressBloomInsert, seqNo, bloom.Bytes())
+}
+
+// ReadBatchSeqNosByAddress returns the sequence numbers of the canonical executed batches between the two sequence
+// numbers included whose address bloom matches the address. The batches without a bloom are always returned, so the
+// result can contain false positives but no false negatives.
+func ReadBatchSeqNosByAddress(db *sql.DB, address gethcommon.Address, from uint64, to uint64) ([]uint64, error) {
+	rows, err := db.Query(addressBloomSelect, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []uint64
+	for rows.Next() {
+		var seqNo uint64
+		var bloom []byte
+		if err := rows.Scan(&seqNo, &bloom); err != nil {
+			return nil, err
+		}
+		if len(bloom) == 0 || types.BytesToBloom(bloom).Test(address.Bytes()) {
+			result = append(result, seqNo)
+		}
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return result, nil
+}
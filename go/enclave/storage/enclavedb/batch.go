@@ -9,6 +9,7 @@ import (
 	"math/big"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/params"
 
 	"github.com/ethereum/go-ethereum/core/types"
@@ -38,6 +39,8 @@ const (
 
 	selectTxQuery = "select tx.content, batch.full_hash, batch.height, tx.idx from exec_tx join tx on tx.hash=exec_tx.tx join batch on batch.sequence=exec_tx.batch where batch.is_canonical=true and tx.hash=?"
 
+	selectTxBySenderNonce = "select tx.full_hash, batch.full_hash, batch.height from exec_tx join tx on tx.hash=exec_tx.tx join batch on batch.sequence=exec_tx.batch where batch.is_canonical=true and tx.sender_address=? and tx.nonce=?"
+
 	selectContractCreationTx    = "select tx.full_hash from exec_tx join tx on tx.hash=exec_tx.tx where created_contract_address=?"
 	selectTotalCreatedContracts = "select count( distinct created_contract_address) from exec_tx "
 	queryBatchWasExecuted       = "select is_executed from batch where is_canonical=true and hash=?"
@@ -425,6 +428,27 @@ func ReadTransaction(db *sql.DB, txHash gethcommon.Hash) (*types.Transaction, ge
 	return tx, batch, height, idx, nil
 }
 
+func ReadTransactionBySenderNonce(db *sql.DB, sender gethcommon.Address, nonce uint64) (*common.IncludedTransaction, error) {
+	row := db.QueryRow(selectTxBySenderNonce, sender.Bytes(), nonce)
+
+	var txHash []byte
+	var batchHash []byte
+	var height uint64
+	err := row.Scan(&txHash, &batchHash, &height)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// make sure the error is converted to obscuro-wide not found error
+			return nil, errutil.ErrNotFound
+		}
+		return nil, err
+	}
+	return &common.IncludedTransaction{
+		TxHash:      gethcommon.BytesToHash(txHash),
+		BatchHash:   gethcommon.BytesToHash(batchHash),
+		BatchHeight: hexutil.Uint64(height),
+	}, nil
+}
+
 func GetContractCreationTx(db *sql.DB, address gethcommon.Address) (*gethcommon.Hash, error) {
 	row := db.QueryRow(selectContractCreationTx, address.Bytes())
 
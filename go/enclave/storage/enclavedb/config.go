@@ -32,11 +32,15 @@ func WriteConfig(db *sql.DB, key string, value []byte) (sql.Result, error) {
 }
 
 func UpdateConfigToBatch(dbtx DBTransaction, key string, value []byte) {
-	dbtx.ExecuteSQL(cfgUpdate, key, value)
+	dbtx.ExecuteSQL(cfgUpdate, value, key)
+}
+
+func UpdateConfigToTx(dbtx *sql.Tx, key string, value []byte) (sql.Result, error) {
+	return dbtx.Exec(cfgUpdate, value, key)
 }
 
 func UpdateConfig(db *sql.DB, key string, value []byte) (sql.Result, error) {
-	return db.Exec(cfgUpdate, key, value)
+	return db.Exec(cfgUpdate, value, key)
 }
 
 func FetchConfig(db *sql.DB, key string) ([]byte, error) {
@@ -47,8 +51,30 @@ func WriteAttKey(db *sql.DB, party common.Address, key []byte) (sql.Result, erro
 	return db.Exec(attInsert, party.Bytes(), key)
 }
 
-func FetchAttKey(db *sql.DB, party common.Address) ([]byte, error) {
-	return readSingleRow(db, attSelect, party.Bytes())
+// FetchAttKeys returns the keys attested for the party, in the order they were stored. A host can run several enclaves
+// (e.g. a primary and its hot standbys), each with its own key
+func FetchAttKeys(db *sql.DB, party common.Address) ([][]byte, error) {
+	rows, err := db.Query(attSelect, party.Bytes())
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var keys [][]byte
+	for rows.Next() {
+		var key []byte
+		if err := rows.Scan(&key); err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	if len(keys) == 0 {
+		return nil, errutil.ErrNotFound
+	}
+	return keys, nil
 }
 
 func readSingleRow(db *sql.DB, query string, v any) ([]byte, error) {
@@ -10,6 +10,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/relevancy"
 	"github.com/ten-protocol/go-ten/go/common/tracers"
 )
 
@@ -19,15 +20,17 @@ const (
 	baseEventsJoin             = "from events e join exec_tx extx on e.exec_tx_id=extx.id join tx on extx.tx=tx.hash join batch b on extx.batch=b.sequence where b.is_canonical=true "
 	insertEvent                = "insert into events values "
 	insertEventValues          = "(?,?,?,?,?,?,?,?,?,?,?,?,?,?)"
-	orderBy                    = " order by b.height, tx.idx asc"
+	orderBy                    = " order by b.height, tx.idx, log_idx asc"
 )
 
-func StoreEventLogs(dbtx DBTransaction, receipts []*types.Receipt, stateDB *state.StateDB) error {
+// StoreEventLogs stores the logs of the receipts of a batch with their relevancy metadata, derived with the version of the
+// relevancy rules active at the height of the batch
+func StoreEventLogs(dbtx DBTransaction, receipts []*types.Receipt, stateDB *state.StateDB, version relevancy.Version) error {
 	var args []any
 	totalLogs := 0
 	for _, receipt := range receipts {
 		for _, l := range receipt.Logs {
-			logArgs, err := logDBValues(dbtx.GetDB(), l, receipt, stateDB)
+			logArgs, err := logDBValues(dbtx.GetDB(), l, receipt, stateDB, version)
 			if err != nil {
 				return err
 			}
@@ -49,7 +52,7 @@ func StoreEventLogs(dbtx DBTransaction, receipts []*types.Receipt, stateDB *stat
 // The other 4 topics are set by the programmer
 // According to the data relevancy rules, an event is relevant to accounts referenced directly in topics
 // If the event is not referring any user address, it is considered a "lifecycle event", and is relevant to everyone
-func logDBValues(db *sql.DB, l *types.Log, receipt *types.Receipt, stateDB *state.StateDB) ([]any, error) {
+func logDBValues(db *sql.DB, l *types.Log, receipt *types.Receipt, stateDB *state.StateDB, version relevancy.Version) ([]any, error) {
 	// The topics are stored in an array with a maximum of 5 entries, but usually less
 	var t0, t1, t2, t3, t4 []byte
 
@@ -72,7 +75,7 @@ func logDBValues(db *sql.DB, l *types.Log, receipt *types.Receipt, stateDB *stat
 	// if yes, then mark it as relevant for that account
 	if n > 1 {
 		t1 = l.Topics[1].Bytes()
-		isUserAccount, addr1, err = isEndUserAccount(db, l.Topics[1], stateDB)
+		isUserAccount, addr1, err = isEndUserAccount(db, l.Topics[1], stateDB, version)
 		if err != nil {
 			return nil, err
 		}
@@ -83,7 +86,7 @@ func logDBValues(db *sql.DB, l *types.Log, receipt *types.Receipt, stateDB *stat
 	}
 	if n > 2 {
 		t2 = l.Topics[2].Bytes()
-		isUserAccount, addr2, err = isEndUserAccount(db, l.Topics[2], stateDB)
+		isUserAccount, addr2, err = isEndUserAccount(db, l.Topics[2], stateDB, version)
 		if err != nil {
 			return nil, err
 		}
@@ -94,7 +97,7 @@ func logDBValues(db *sql.DB, l *types.Log, receipt *types.Receipt, stateDB *stat
 	}
 	if n > 3 {
 		t3 = l.Topics[3].Bytes()
-		isUserAccount, addr3, err = isEndUserAccount(db, l.Topics[3], stateDB)
+		isUserAccount, addr3, err = isEndUserAccount(db, l.Topics[3], stateDB, version)
 		if err != nil {
 			return nil, err
 		}
@@ -105,7 +108,7 @@ func logDBValues(db *sql.DB, l *types.Log, receipt *types.Receipt, stateDB *stat
 	}
 	if n > 4 {
 		t4 = l.Topics[4].Bytes()
-		isUserAccount, addr4, err = isEndUserAccount(db, l.Topics[4], stateDB)
+		isUserAccount, addr4, err = isEndUserAccount(db, l.Topics[4], stateDB, version)
 		if err != nil {
 			return nil, err
 		}
@@ -137,6 +140,41 @@ func FilterLogs(
 	addresses []gethcommon.Address,
 	topics [][]gethcommon.Hash,
 ) ([]*types.Log, error) {
+	query, queryParams, err := logsFilterCondition(fromBlock, toBlock, batchHash, addresses, topics)
+	if err != nil {
+		return nil, err
+	}
+	return loadLogs(db, requestingAccount, query, queryParams, 0)
+}
+
+// FilterLogsPage returns up to limit logs matching the filter, in the order of the chain, starting at the position of
+// the cursor, included. A nil cursor starts at fromBlock and a zero limit returns all the logs
+func FilterLogsPage(
+	db *sql.DB,
+	requestingAccount *gethcommon.Address,
+	fromBlock, toBlock *big.Int,
+	addresses []gethcommon.Address,
+	topics [][]gethcommon.Hash,
+	cursor *common.LogCursor,
+	limit int,
+) ([]*types.Log, error) {
+	query, queryParams, err := logsFilterCondition(fromBlock, toBlock, nil, addresses, topics)
+	if err != nil {
+		return nil, err
+	}
+	if cursor != nil {
+		query += " AND (b.height > ? OR (b.height = ? AND (tx.idx > ? OR (tx.idx = ? AND log_idx >= ?))))"
+		queryParams = append(queryParams, cursor.BatchHeight, cursor.BatchHeight, cursor.TxIndex, cursor.TxIndex, cursor.LogIndex)
+	}
+	return loadLogs(db, requestingAccount, query, queryParams, limit)
+}
+
+func logsFilterCondition(
+	fromBlock, toBlock *big.Int,
+	batchHash *common.L2BatchHash,
+	addresses []gethcommon.Address,
+	topics [][]gethcommon.Hash,
+) (string, []any, error) {
 	queryParams := []any{}
 	query := ""
 	if batchHash != nil {
@@ -149,7 +187,7 @@ func FilterLogs(
 		query += " AND b.height >= ?"
 		queryParams = append(queryParams, fromBlock.Int64())
 	}
-	if toBlock != nil && toBlock.Sign() > 0 {
+	if toBlock != nil && toBlock.Sign() >= 0 {
 		query += " AND b.height <= ?"
 		queryParams = append(queryParams, toBlock.Int64())
 	}
@@ -161,7 +199,7 @@ func FilterLogs(
 		}
 	}
 	if len(topics) > 5 {
-		return nil, fmt.Errorf("invalid filter. Too many topics")
+		return "", nil, fmt.Errorf("invalid filter. Too many topics")
 	}
 	if len(topics) > 0 {
 		for i, sub := range topics {
@@ -175,8 +213,7 @@ func FilterLogs(
 			}
 		}
 	}
-
-	return loadLogs(db, requestingAccount, query, queryParams)
+	return query, queryParams, nil
 }
 
 func DebugGetLogs(db *sql.DB, txHash common.TxHash) ([]*tracers.DebugLogs, error) {
@@ -252,14 +289,20 @@ func bytesToAddress(b []byte) *gethcommon.Address {
 	return nil
 }
 
-// Of the log's topics, returns those that are (potentially) user addresses. A topic is considered a user address if:
+// Of the log's topics, returns those that are (potentially) user addresses. Under the V0 rules a topic is considered a
+// user address if:
 //   - It has at least 12 leading zero bytes (since addresses are 20 bytes long, while hashes are 32) and at most 22 leading zero bytes
-//   - It does not have associated code (meaning it's a smart-contract address)
-//   - It has a non-zero nonce (to prevent accidental or malicious creation of the address matching a given topic,
-//     forcing its events to become permanently private (this is not implemented for now)
+//   - It was already a user address in earlier events, or it does not have associated code (meaning it's a smart-contract address)
+//
+// The later versions of the rules are applied by the relevancy package, and also require a non-zero nonce.
 //
 // todo - find a more efficient way
-func isEndUserAccount(db *sql.DB, topic gethcommon.Hash, stateDB *state.StateDB) (bool, *gethcommon.Address, error) {
+func isEndUserAccount(db *sql.DB, topic gethcommon.Hash, stateDB *state.StateDB, version relevancy.Version) (bool, *gethcommon.Address, error) {
+	if version != relevancy.V0 {
+		userAddr := relevancy.UserAccount(stateDB, topic)
+		return userAddr != nil, userAddr, nil
+	}
+
 	potentialAddr := common.ExtractPotentialAddress(topic)
 	if potentialAddr == nil {
 		return false, nil, nil
@@ -278,10 +321,6 @@ func isEndUserAccount(db *sql.DB, topic gethcommon.Hash, stateDB *state.StateDB)
 		return true, potentialAddr, nil
 	}
 
-	// TODO A user address must have a non-zero nonce. This prevents accidental or malicious sending of funds to an
-	// address matching a topic, forcing its events to become permanently private.
-	// if db.GetNonce(potentialAddr) != 0
-
 	// If the address has code, it's a smart contract address instead.
 	if stateDB.GetCode(*potentialAddr) == nil {
 		return true, potentialAddr, nil
@@ -292,7 +331,7 @@ func isEndUserAccount(db *sql.DB, topic gethcommon.Hash, stateDB *state.StateDB)
 
 // utility function that knows how to load relevant logs from the database
 // todo always pass in the actual batch hashes because of reorgs, or make sure to clean up log entries from discarded batches
-func loadLogs(db *sql.DB, requestingAccount *gethcommon.Address, whereCondition string, whereParams []any) ([]*types.Log, error) {
+func loadLogs(db *sql.DB, requestingAccount *gethcommon.Address, whereCondition string, whereParams []any, limit int) ([]*types.Log, error) {
 	if requestingAccount == nil {
 		return nil, fmt.Errorf("logs can only be requested for an account")
 	}
@@ -314,6 +353,10 @@ func loadLogs(db *sql.DB, requestingAccount *gethcommon.Address, whereCondition
 	queryParams = append(queryParams, whereParams...)
 
 	query += orderBy
+	if limit > 0 {
+		query += " limit ?"
+		queryParams = append(queryParams, limit)
+	}
 
 	rows, err := db.Query(query, queryParams...)
 	if err != nil {
@@ -0,0 +1,83 @@
+package enclavedb
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+)
+
+const (
+	batchFeesInsert = "replace into batch_fees values (?,?)"
+	batchFeesSelect = "select fees from batch_fees where sequence >= ? and sequence <= ? order by sequence"
+
+	rollupRangeSelect = "select start_seq, end_seq from rollup where hash=?"
+	rolledUpSeqSelect = "select max(r.end_seq) from rollup r join block b on r.compression_block=b.hash where b.is_canonical=true and b.height<=?"
+)
+
+func WriteBatchFees(db *sql.DB, fees *common.BatchFees) error {
+	data, err := rlp.EncodeToBytes(fees)
+	if err != nil {
+		return fmt.Errorf("could not encode batch fees. Cause: %w", err)
+	}
+	_, err = db.Exec(batchFeesInsert, fees.SeqNo, data)
+	return err
+}
+
+// ReadBatchFees returns the fees of the executed batches between the two sequence numbers included
+func ReadBatchFees(db *sql.DB, from uint64, to uint64) ([]*common.BatchFees, error) {
+	rows, err := db.Query(batchFeesSelect, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*common.BatchFees
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		fees := new(common.BatchFees)
+		if err := rlp.DecodeBytes(data, fees); err != nil {
+			return nil, fmt.Errorf("could not decode batch fees. Cause: %w", err)
+		}
+		result = append(result, fees)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return result, nil
+}
+
+// FetchRollupBatchRange returns the sequence numbers of the first and last batches of the rollup
+func FetchRollupBatchRange(db *sql.DB, rollupHash common.L2RollupHash) (uint64, uint64, error) {
+	var start, end uint64
+	err := db.QueryRow(rollupRangeSelect, truncTo16(rollupHash)).Scan(&start, &end)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			// make sure the error is converted to obscuro-wide not found error
+			return 0, 0, errutil.ErrNotFound
+		}
+		return 0, 0, err
+	}
+	return start, end, nil
+}
+
+// FetchRolledUpBatchSeqNo returns the sequence number of the last batch included in the rollups of the canonical L1
+// chain, whose compression block is at or below the given L1 height
+func FetchRolledUpBatchSeqNo(db *sql.DB, maxL1Height uint64) (uint64, error) {
+	var seqNo sql.NullInt64
+	err := db.QueryRow(rolledUpSeqSelect, maxL1Height).Scan(&seqNo)
+	if err != nil {
+		return 0, err
+	}
+	if !seqNo.Valid {
+		// make sure the error is converted to obscuro-wide not found error
+		return 0, errutil.ErrNotFound
+	}
+	return uint64(seqNo.Int64), nil
+}
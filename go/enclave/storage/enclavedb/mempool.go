@@ -0,0 +1,70 @@
+package enclavedb
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+const (
+	mempoolTxInsert = "replace into mempool_tx values (?,?,?)"
+	mempoolTxDelete = "delete from mempool_tx where hash in "
+	mempoolTxSelect = "select content, first_seen from mempool_tx order by first_seen"
+)
+
+func WriteMempoolTx(db *sql.DB, tx *common.L2Tx) error {
+	content, err := tx.MarshalBinary()
+	if err != nil {
+		return fmt.Errorf("could not encode transaction. Cause: %w", err)
+	}
+	_, err = db.Exec(mempoolTxInsert, tx.Hash().Bytes(), content, tx.Time().Unix())
+	return err
+}
+
+func DeleteMempoolTxs(db *sql.DB, hashes []common.L2TxHash) error {
+	if len(hashes) == 0 {
+		return nil
+	}
+	argPlaceholders := strings.Repeat("?,", len(hashes))
+	argPlaceholders = argPlaceholders[0 : len(argPlaceholders)-1] // remove trailing comma
+
+	args := make([]any, 0, len(hashes))
+	for _, hash := range hashes {
+		args = append(args, hash.Bytes())
+	}
+	_, err := db.Exec(mempoolTxDelete+"("+argPlaceholders+")", args...)
+	return err
+}
+
+// ReadMempoolTxs returns the persisted mempool transactions, the first seen first. Their time is the time they were
+// first seen.
+func ReadMempoolTxs(db *sql.DB) ([]*common.L2Tx, error) {
+	rows, err := db.Query(mempoolTxSelect)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*common.L2Tx
+	for rows.Next() {
+		var content []byte
+		var firstSeen int64
+		if err := rows.Scan(&content, &firstSeen); err != nil {
+			return nil, err
+		}
+		tx := new(types.Transaction)
+		if err := tx.UnmarshalBinary(content); err != nil {
+			return nil, fmt.Errorf("could not decode transaction. Cause: %w", err)
+		}
+		tx.SetTime(time.Unix(firstSeen, 0))
+		result = append(result, tx)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return result, nil
+}
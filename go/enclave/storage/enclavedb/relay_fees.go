@@ -0,0 +1,90 @@
+package enclavedb
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+const (
+	l1RelayInsert = "replace into l1_relay values (?,?)"
+	l1RelaySelect = "select relays from l1_relay where block=?"
+
+	outboundMsgInsert = "replace into outbound_msg values "
+	outboundMsgSelect = "select message from outbound_msg where sequence >= ? and sequence <= ? order by sequence"
+)
+
+// WriteL1Relays stores the relays of L2->L1 messages recorded in an L1 block
+func WriteL1Relays(db *sql.DB, blockHash common.L1BlockHash, relays common.MessageRelays) error {
+	data, err := rlp.EncodeToBytes(relays)
+	if err != nil {
+		return fmt.Errorf("could not encode message relays. Cause: %w", err)
+	}
+	_, err = db.Exec(l1RelayInsert, truncTo16(blockHash), data)
+	return err
+}
+
+// FetchL1Relays returns the relays of L2->L1 messages recorded in an L1 block, none if the block has no relays
+func FetchL1Relays(db *sql.DB, blockHash common.L1BlockHash) (common.MessageRelays, error) {
+	var data []byte
+	err := db.QueryRow(l1RelaySelect, truncTo16(blockHash)).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var relays common.MessageRelays
+	if err := rlp.DecodeBytes(data, &relays); err != nil {
+		return nil, fmt.Errorf("could not decode message relays. Cause: %w", err)
+	}
+	return relays, nil
+}
+
+// WriteOutboundMessages stores the L2->L1 messages published in an executed batch
+func WriteOutboundMessages(db *sql.DB, messages []*common.OutboundMessage) error {
+	if len(messages) == 0 {
+		return nil
+	}
+	args := make([]any, 0, 3*len(messages))
+	for _, msg := range messages {
+		data, err := rlp.EncodeToBytes(msg)
+		if err != nil {
+			return fmt.Errorf("could not encode outbound message. Cause: %w", err)
+		}
+		args = append(args, msg.Hash.Bytes(), msg.BatchSeqNo, data)
+	}
+	values := strings.Repeat("(?,?,?),", len(messages))
+	_, err := db.Exec(outboundMsgInsert+values[:len(values)-1], args...)
+	return err
+}
+
+// ReadOutboundMessages returns the L2->L1 messages published in the batches between the two sequence numbers included
+func ReadOutboundMessages(db *sql.DB, from uint64, to uint64) ([]*common.OutboundMessage, error) {
+	rows, err := db.Query(outboundMsgSelect, from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*common.OutboundMessage
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		msg := new(common.OutboundMessage)
+		if err := rlp.DecodeBytes(data, msg); err != nil {
+			return nil, fmt.Errorf("could not decode outbound message. Cause: %w", err)
+		}
+		result = append(result, msg)
+	}
+	if rows.Err() != nil {
+		return nil, rows.Err()
+	}
+	return result, nil
+}
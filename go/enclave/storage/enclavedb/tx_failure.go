@@ -0,0 +1,52 @@
+package enclavedb
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+)
+
+const (
+	txFailureInsert = "replace into tx_failure values "
+	txFailureSelect = "select failure from tx_failure where hash=?"
+)
+
+// WriteTxFailures stores why the transactions failed, replacing the earlier failures of the same transactions
+func WriteTxFailures(db *sql.DB, failures []*common.TransactionError) error {
+	if len(failures) == 0 {
+		return nil
+	}
+	args := make([]any, 0, 2*len(failures))
+	for _, failure := range failures {
+		data, err := rlp.EncodeToBytes(failure)
+		if err != nil {
+			return fmt.Errorf("could not encode transaction failure. Cause: %w", err)
+		}
+		args = append(args, failure.TxHash.Bytes(), data)
+	}
+	values := strings.Repeat("(?,?),", len(failures))
+	_, err := db.Exec(txFailureInsert+values[:len(values)-1], args...)
+	return err
+}
+
+// ReadTxFailure returns the last recorded failure of the transaction
+func ReadTxFailure(db *sql.DB, txHash common.L2TxHash) (*common.TransactionError, error) {
+	var data []byte
+	err := db.QueryRow(txFailureSelect, txHash.Bytes()).Scan(&data)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errutil.ErrNotFound
+		}
+		return nil, err
+	}
+	failure := new(common.TransactionError)
+	if err := rlp.DecodeBytes(data, failure); err != nil {
+		return nil, fmt.Errorf("could not decode transaction failure. Cause: %w", err)
+	}
+	return failure, nil
+}
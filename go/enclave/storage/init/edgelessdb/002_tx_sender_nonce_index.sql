@@ -0,0 +1 @@
+create index if not exists IDX_TX_SENDER_NONCE on obsdb.tx (sender_address, nonce);
@@ -0,0 +1,7 @@
+create table if not exists obsdb.batch_fees
+(
+    sequence INT,
+    fees     BLOB NOT NULL,
+    primary key (sequence)
+);
+GRANT ALL ON obsdb.batch_fees TO obscuro;
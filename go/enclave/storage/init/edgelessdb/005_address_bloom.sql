@@ -0,0 +1,7 @@
+create table if not exists obsdb.batch_address_bloom
+(
+    sequence INT,
+    bloom    BINARY(256) NOT NULL,
+    primary key (sequence)
+);
+GRANT ALL ON obsdb.batch_address_bloom TO obscuro;
@@ -0,0 +1,7 @@
+create table if not exists obsdb.tx_failure
+(
+    hash    binary(32),
+    failure mediumblob NOT NULL,
+    primary key (hash)
+);
+GRANT ALL ON obsdb.tx_failure TO obscuro;
@@ -0,0 +1,17 @@
+create table if not exists obsdb.l1_relay
+(
+    block  binary(16),
+    relays BLOB NOT NULL,
+    primary key (block)
+);
+GRANT ALL ON obsdb.l1_relay TO obscuro;
+
+create table if not exists obsdb.outbound_msg
+(
+    hash     binary(32),
+    sequence INT  NOT NULL,
+    message  BLOB NOT NULL,
+    INDEX (sequence),
+    primary key (hash)
+);
+GRANT ALL ON obsdb.outbound_msg TO obscuro;
@@ -17,6 +17,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
 
@@ -62,7 +63,7 @@ var defaultEDBConstraints = &EdgelessAttestationConstraints{
 // performEDBRemoteAttestation perform the SGX enclave attestation to verify edb running in a legit enclave and with expected edb version etc.
 func performEDBRemoteAttestation(edbHost string, constraints *EdgelessAttestationConstraints, logger gethlog.Logger) (string, error) {
 	logger.Info("Verifying attestation from edgeless DB...")
-	edbHTTPAddr := fmt.Sprintf("%s:%s", edbHost, edbHTTPPort)
+	edbHTTPAddr := net.JoinHostPort(edbHost, edbHTTPPort)
 	certs, tcbStatus, err := performRAAndFetchTLSCert(edbHTTPAddr, constraints)
 	if err != nil {
 		// todo (#1550) - should we check the error type with: err == attestation.ErrTCBLevelInvalid?
@@ -160,7 +160,7 @@ func Connector(edbCfg *Config, logger gethlog.Logger) (enclavedb.EnclaveDB, erro
 
 func waitForEdgelessDBToStart(edbHost string, logger gethlog.Logger) error {
 	start := time.Now()
-	edgelessHTTPAddr := fmt.Sprintf("%s:%s", edbHost, edbHTTPPort)
+	edgelessHTTPAddr := net.JoinHostPort(edbHost, edbHTTPPort)
 	logger.Info("Waiting to ensure Edgeless DB is available for http requests...")
 	var conn net.Conn
 	var err error
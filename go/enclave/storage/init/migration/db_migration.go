@@ -26,17 +26,20 @@ func DBMigration(db *sql.DB, sqlFiles embed.FS, logger gethlog.Logger) error {
 
 	maxMigration := int64(len(migrationFiles))
 
+	// the stored version is the index of the last executed migration file
 	var maxDB int64
+	versionStored := true
 	config, err := enclavedb.FetchConfig(db, currentMigrationVersionKey)
 	if err != nil {
 		// first time there is no entry, so 001 was executed already ( triggered at launch/manifest time )
 		if errors.Is(err, errutil.ErrNotFound) {
 			maxDB = 1
+			versionStored = false
 		} else {
 			return err
 		}
 	} else {
-		maxDB = ByteArrayToInt(config)
+		maxDB = ByteArrayToInt(config) + 1
 	}
 
 	// write to the database
@@ -46,17 +49,18 @@ func DBMigration(db *sql.DB, sqlFiles embed.FS, logger gethlog.Logger) error {
 		if err != nil {
 			return err
 		}
-		err = executeMigration(db, string(content), i)
+		err = executeMigration(db, string(content), i, versionStored)
 		if err != nil {
 			return fmt.Errorf("unable to execute migration for %s - %w", migrationFiles[i].Name(), err)
 		}
+		versionStored = true
 		logger.Info("Successfully executed", "file", migrationFiles[i].Name(), "index", i)
 	}
 
 	return nil
 }
 
-func executeMigration(db *sql.DB, content string, migrationOrder int64) error {
+func executeMigration(db *sql.DB, content string, migrationOrder int64, versionStored bool) error {
 	tx, err := db.Begin()
 	if err != nil {
 		return err
@@ -66,7 +70,12 @@ func executeMigration(db *sql.DB, content string, migrationOrder int64) error {
 		return err
 	}
 
-	_, err = enclavedb.WriteConfigToTx(tx, currentMigrationVersionKey, big.NewInt(migrationOrder).Bytes())
+	version := big.NewInt(migrationOrder).Bytes()
+	if versionStored {
+		_, err = enclavedb.UpdateConfigToTx(tx, currentMigrationVersionKey, version)
+	} else {
+		_, err = enclavedb.WriteConfigToTx(tx, currentMigrationVersionKey, version)
+	}
 	if err != nil {
 		return err
 	}
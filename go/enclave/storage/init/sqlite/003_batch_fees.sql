@@ -0,0 +1,5 @@
+create table if not exists batch_fees
+(
+    sequence int primary key,
+    fees     blob NOT NULL
+);
@@ -0,0 +1,6 @@
+create table if not exists mempool_tx
+(
+    hash       binary(32) primary key,
+    content    mediumblob NOT NULL,
+    first_seen int        NOT NULL
+);
@@ -0,0 +1,5 @@
+create table if not exists batch_address_bloom
+(
+    sequence int primary key,
+    bloom    binary(256) NOT NULL
+);
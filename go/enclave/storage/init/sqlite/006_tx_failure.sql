@@ -0,0 +1,5 @@
+create table if not exists tx_failure
+(
+    hash    binary(32) primary key,
+    failure mediumblob NOT NULL
+);
@@ -0,0 +1,12 @@
+create table if not exists l1_relay
+(
+    block  binary(16) primary key,
+    relays blob NOT NULL
+);
+create table if not exists outbound_msg
+(
+    hash     binary(32) primary key,
+    sequence int  NOT NULL,
+    message  blob NOT NULL
+);
+create index if not exists IDX_OUTBOUND_MSG_SEQ on outbound_msg (sequence);
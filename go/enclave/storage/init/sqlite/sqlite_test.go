@@ -0,0 +1,43 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"testing"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/enclave/storage/init/migration"
+)
+
+// The migrations run on every start of the enclave, so running them again on a migrated database must not execute any
+// migration file a second time.
+func TestDBMigrationRunsOnce(t *testing.T) {
+	dbPath, err := CreateTempDBFile()
+	require.NoError(t, err)
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_foreign_keys=on", dbPath))
+	require.NoError(t, err)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+	require.NoError(t, initialiseDB(db))
+
+	executed := 0
+	logger := gethlog.New()
+	logger.SetHandler(gethlog.FuncHandler(func(r *gethlog.Record) error {
+		if r.Msg == "Executing db migration" {
+			executed++
+		}
+		return nil
+	}))
+
+	migrationFiles, err := sqlFiles.ReadDir(".")
+	require.NoError(t, err)
+
+	require.NoError(t, migration.DBMigration(db, sqlFiles, logger))
+	// 001 is executed when the database is initialised
+	require.Equal(t, len(migrationFiles)-1, executed)
+
+	executed = 0
+	require.NoError(t, migration.DBMigration(db, sqlFiles, logger))
+	require.Equal(t, 0, executed)
+}
@@ -65,6 +65,10 @@ type BatchResolver interface {
 	StoreBatch(batch *core.Batch) error
 	// StoreExecutedBatch - store the batch after it was executed
 	StoreExecutedBatch(batch *core.Batch, receipts []*types.Receipt) error
+	// FetchBatchSeqNosByAddress returns the sequence numbers of the canonical executed batches between the two sequence
+	// numbers included that may involve the address. It relies on the address blooms of the batches, so it can
+	// return batches that do not involve the address.
+	FetchBatchSeqNosByAddress(address gethcommon.Address, fromSeqNo uint64, toSeqNo uint64) ([]uint64, error)
 
 	// StoreRollup
 	StoreRollup(rollup *common.ExtRollup, header *common.CalldataRollupHeader) error
@@ -88,6 +92,8 @@ type SharedSecretStorage interface {
 type TransactionStorage interface {
 	// GetTransaction - returns the positional metadata of the tx by hash
 	GetTransaction(txHash common.L2TxHash) (*types.Transaction, gethcommon.Hash, uint64, uint64, error)
+	// GetTransactionBySenderNonce - returns the transaction of the sender included in the canonical chain with the nonce
+	GetTransactionBySenderNonce(sender gethcommon.Address, nonce uint64) (*common.IncludedTransaction, error)
 	// GetTransactionReceipt - returns the receipt of a tx by tx hash
 	GetTransactionReceipt(txHash common.L2TxHash) (*types.Receipt, error)
 	// GetReceiptsByBatchHash retrieves the receipts for all transactions in a given rollup.
@@ -97,8 +103,8 @@ type TransactionStorage interface {
 }
 
 type AttestationStorage interface {
-	// FetchAttestedKey returns the public key of an attested aggregator
-	FetchAttestedKey(aggregator gethcommon.Address) (*ecdsa.PublicKey, error)
+	// FetchAttestedKeys returns the public keys of the attested enclaves of an aggregator
+	FetchAttestedKeys(aggregator gethcommon.Address) ([]*ecdsa.PublicKey, error)
 	// StoreAttestedKey - store the public key of an attested aggregator
 	StoreAttestedKey(aggregator gethcommon.Address, key *ecdsa.PublicKey) error
 }
@@ -109,6 +115,16 @@ type CrossChainMessagesStorage interface {
 
 	StoreValueTransfers(blockHash common.L1BlockHash, transfers common.ValueTransferEvents) error
 	GetL1Transfers(blockHash common.L1BlockHash) (common.ValueTransferEvents, error)
+
+	// StoreL1Relays stores the relays of L2->L1 messages recorded by the message bus in an L1 block
+	StoreL1Relays(blockHash common.L1BlockHash, relays common.MessageRelays) error
+	// GetL1Relays returns the relays recorded in an L1 block, none if there are none
+	GetL1Relays(blockHash common.L1BlockHash) (common.MessageRelays, error)
+
+	// StoreOutboundMessages stores the L2->L1 messages published in an executed batch
+	StoreOutboundMessages(messages []*common.OutboundMessage) error
+	// FetchOutboundMessages returns the L2->L1 messages published in the batches between the two sequence numbers included
+	FetchOutboundMessages(fromSeqNo uint64, toSeqNo uint64) ([]*common.OutboundMessage, error)
 }
 
 type EnclaveKeyStorage interface {
@@ -116,6 +132,51 @@ type EnclaveKeyStorage interface {
 	GetEnclaveKey() (*ecdsa.PrivateKey, error)
 }
 
+type GovernanceStorage interface {
+	// StoreGovernanceChanges adds the governance changes published by the management contract in an L1 block
+	StoreGovernanceChanges(changes []*common.GovernanceChange) error
+	// FetchGovernanceChanges returns all the stored governance changes, including those of reorged L1 blocks
+	FetchGovernanceChanges() ([]*common.GovernanceChange, error)
+}
+
+type ProfilerStorage interface {
+	// StoreLastProfileRequest stores the timestamp of the last profile request served, so it is not replayed after a restart
+	StoreLastProfileRequest(timestamp uint64) error
+	// FetchLastProfileRequest returns the timestamp of the last profile request served, 0 if none was
+	FetchLastProfileRequest() (uint64, error)
+}
+
+type FeeStorage interface {
+	// StoreBatchFees stores the fees collected in an executed batch
+	StoreBatchFees(fees *common.BatchFees) error
+	// FetchBatchFees returns the fees of the executed batches between the two sequence numbers included
+	FetchBatchFees(fromSeqNo uint64, toSeqNo uint64) ([]*common.BatchFees, error)
+	// FetchRollupBatchRange returns the sequence numbers of the first and last batches of a stored rollup
+	FetchRollupBatchRange(rollupHash common.L2RollupHash) (uint64, uint64, error)
+	// FetchRolledUpBatchSeqNo returns the sequence number of the last batch included in the rollups of the canonical
+	// L1 chain compressed at or below the given L1 height
+	FetchRolledUpBatchSeqNo(maxL1Height uint64) (uint64, error)
+}
+
+// TxFailureStorage keeps why the transactions failed, for their senders
+type TxFailureStorage interface {
+	// StoreTxFailures stores why the transactions of an executed batch failed, replacing the earlier failures of the same
+	// transactions
+	StoreTxFailures(failures []*common.TransactionError) error
+	// FetchTxFailure returns the last recorded failure of the transaction
+	FetchTxFailure(txHash common.L2TxHash) (*common.TransactionError, error)
+}
+
+// MempoolStorage persists the transactions of the mempool, so that they survive the restarts of the sequencer
+type MempoolStorage interface {
+	// StoreMempoolTx persists a transaction accepted in the mempool
+	StoreMempoolTx(tx *common.L2Tx) error
+	// DeleteMempoolTxs removes the transactions that left the mempool
+	DeleteMempoolTxs(hashes []common.L2TxHash) error
+	// FetchMempoolTxs returns the persisted transactions, the first seen first
+	FetchMempoolTxs() ([]*common.L2Tx, error)
+}
+
 // Storage is the enclave's interface for interacting with the enclave's datastore
 type Storage interface {
 	BlockResolver
@@ -126,6 +187,11 @@ type Storage interface {
 	AttestationStorage
 	CrossChainMessagesStorage
 	EnclaveKeyStorage
+	GovernanceStorage
+	ProfilerStorage
+	FeeStorage
+	TxFailureStorage
+	MempoolStorage
 	ScanStorage
 	io.Closer
 
@@ -137,6 +203,10 @@ type Storage interface {
 	// the blockHash should always be nil.
 	FilterLogs(requestingAccount *gethcommon.Address, fromBlock, toBlock *big.Int, blockHash *common.L2BatchHash, addresses []gethcommon.Address, topics [][]gethcommon.Hash) ([]*types.Log, error)
 
+	// FilterLogsPage - like FilterLogs, returns up to limit logs between fromBlock and toBlock included, starting at the
+	// cursor (nil starts at fromBlock). A zero limit returns all the logs
+	FilterLogsPage(requestingAccount *gethcommon.Address, fromBlock, toBlock *big.Int, addresses []gethcommon.Address, topics [][]gethcommon.Hash, cursor *common.LogCursor, limit int) ([]*types.Log, error)
+
 	// DebugGetLogs returns logs for a given tx hash without any constraints - should only be used for debug purposes
 	DebugGetLogs(txHash common.TxHash) ([]*tracers.DebugLogs, error)
 
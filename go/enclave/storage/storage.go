@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"crypto/ecdsa"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"math/big"
@@ -25,6 +26,7 @@ import (
 	gethcrypto "github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/trie"
 
+	"github.com/ten-protocol/go-ten/go/common/errutil"
 	"github.com/ten-protocol/go-ten/go/common/syserr"
 
 	"github.com/ethereum/go-ethereum/core/state"
@@ -32,6 +34,7 @@ import (
 	"github.com/ethereum/go-ethereum/params"
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/relevancy"
 	"github.com/ten-protocol/go-ten/go/common/tracers"
 	"github.com/ten-protocol/go-ten/go/enclave/core"
 	"github.com/ten-protocol/go-ten/go/enclave/crypto"
@@ -59,7 +62,9 @@ type storageImpl struct {
 
 	stateDB     state.Database
 	chainConfig *params.ChainConfig
-	logger      gethlog.Logger
+	// the schedule of the relevancy rules the logs are stored with
+	relevancyForks relevancy.Forks
+	logger         gethlog.Logger
 }
 
 func NewStorageFromConfig(config *config.EnclaveConfig, chainConfig *params.ChainConfig, logger gethlog.Logger) Storage {
@@ -67,10 +72,10 @@ func NewStorageFromConfig(config *config.EnclaveConfig, chainConfig *params.Chai
 	if err != nil {
 		logger.Crit("Failed to connect to backing database", log.ErrKey, err)
 	}
-	return NewStorage(backingDB, chainConfig, logger)
+	return NewStorage(backingDB, chainConfig, config.RelevancyRulesForks, config.AccountCacheSize, logger)
 }
 
-func NewStorage(backingDB enclavedb.EnclaveDB, chainConfig *params.ChainConfig, logger gethlog.Logger) Storage {
+func NewStorage(backingDB enclavedb.EnclaveDB, chainConfig *params.ChainConfig, relevancyForks relevancy.Forks, accountCacheSize int, logger gethlog.Logger) Storage {
 	cacheConfig := &gethcore.CacheConfig{
 		TrieCleanLimit: 256,
 		TrieDirtyLimit: 256,
@@ -91,16 +96,23 @@ func NewStorage(backingDB enclavedb.EnclaveDB, chainConfig *params.ChainConfig,
 
 	bigcacheStore := bigcache_store.NewBigcache(bigcacheClient)
 
+	stateDB := state.NewDatabaseWithConfig(backingDB, &trie.Config{
+		Cache:     cacheConfig.TrieCleanLimit,
+		Preimages: cacheConfig.Preimages,
+	})
+	// the recently touched accounts are kept in memory, in front of the account trie
+	if accountCacheSize > 0 {
+		stateDB = &hotStateDatabase{Database: stateDB, cache: newHotAccountCache(accountCacheSize, logger)}
+	}
+
 	return &storageImpl{
-		db: backingDB,
-		stateDB: state.NewDatabaseWithConfig(backingDB, &trie.Config{
-			Cache:     cacheConfig.TrieCleanLimit,
-			Preimages: cacheConfig.Preimages,
-		}),
-		chainConfig: chainConfig,
-		batchCache:  cache.New[[]byte](bigcacheStore),
-		blockCache:  cache.New[[]byte](bigcacheStore),
-		logger:      logger,
+		db:             backingDB,
+		stateDB:        stateDB,
+		chainConfig:    chainConfig,
+		relevancyForks: relevancyForks,
+		batchCache:     cache.New[[]byte](bigcacheStore),
+		blockCache:     cache.New[[]byte](bigcacheStore),
+		logger:         logger,
 	}
 }
 
@@ -312,6 +324,11 @@ func (s *storageImpl) GetTransaction(txHash gethcommon.Hash) (*types.Transaction
 	return enclavedb.ReadTransaction(s.db.GetSQLDB(), txHash)
 }
 
+func (s *storageImpl) GetTransactionBySenderNonce(sender gethcommon.Address, nonce uint64) (*common.IncludedTransaction, error) {
+	defer s.logDuration("GetTransactionBySenderNonce", measure.NewStopwatch())
+	return enclavedb.ReadTransactionBySenderNonce(s.db.GetSQLDB(), sender, nonce)
+}
+
 func (s *storageImpl) GetContractCreationTx(address gethcommon.Address) (*gethcommon.Hash, error) {
 	defer s.logDuration("GetContractCreationTx", measure.NewStopwatch())
 	return enclavedb.GetContractCreationTx(s.db.GetSQLDB(), address)
@@ -322,19 +339,22 @@ func (s *storageImpl) GetTransactionReceipt(txHash gethcommon.Hash) (*types.Rece
 	return enclavedb.ReadReceipt(s.db.GetSQLDB(), txHash, s.chainConfig)
 }
 
-func (s *storageImpl) FetchAttestedKey(address gethcommon.Address) (*ecdsa.PublicKey, error) {
-	defer s.logDuration("FetchAttestedKey", measure.NewStopwatch())
-	key, err := enclavedb.FetchAttKey(s.db.GetSQLDB(), address)
+func (s *storageImpl) FetchAttestedKeys(address gethcommon.Address) ([]*ecdsa.PublicKey, error) {
+	defer s.logDuration("FetchAttestedKeys", measure.NewStopwatch())
+	keys, err := enclavedb.FetchAttKeys(s.db.GetSQLDB(), address)
 	if err != nil {
-		return nil, fmt.Errorf("could not retrieve attestation key for address %s. Cause: %w", address, err)
+		return nil, fmt.Errorf("could not retrieve attestation keys for address %s. Cause: %w", address, err)
 	}
 
-	publicKey, err := gethcrypto.DecompressPubkey(key)
-	if err != nil {
-		return nil, fmt.Errorf("could not parse key from db. Cause: %w", err)
+	publicKeys := make([]*ecdsa.PublicKey, len(keys))
+	for i, key := range keys {
+		publicKeys[i], err = gethcrypto.DecompressPubkey(key)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse key from db. Cause: %w", err)
+		}
 	}
 
-	return publicKey, nil
+	return publicKeys, nil
 }
 
 func (s *storageImpl) StoreAttestedKey(aggregator gethcommon.Address, key *ecdsa.PublicKey) error {
@@ -401,13 +421,25 @@ func (s *storageImpl) StoreExecutedBatch(batch *core.Batch, receipts []*types.Re
 		return fmt.Errorf("could not write transaction receipts. Cause: %w", err)
 	}
 
+	bloom, err := core.AddressBloom(batch, receipts)
+	if err != nil {
+		return fmt.Errorf("could not compute the address bloom. Cause: %w", err)
+	}
+	enclavedb.WriteBatchAddressBloom(dbTx, batch.SeqNo().Uint64(), bloom)
+
 	if batch.Number().Int64() > 1 {
-		stateDB, err := s.CreateStateDB(batch.Header.ParentHash)
+		// the V0 rules look the accounts up in the state of the parent batch, the later ones in the state of the batch
+		version := s.relevancyForks.VersionAt(batch.NumberU64())
+		stateHash := batch.Hash()
+		if version == relevancy.V0 {
+			stateHash = batch.Header.ParentHash
+		}
+		stateDB, err := s.CreateStateDB(stateHash)
 		if err != nil {
 			return fmt.Errorf("could not create state DB to filter logs. Cause: %w", err)
 		}
 
-		err = enclavedb.StoreEventLogs(dbTx, receipts, stateDB)
+		err = enclavedb.StoreEventLogs(dbTx, receipts, stateDB, version)
 		if err != nil {
 			return fmt.Errorf("could not save logs %w", err)
 		}
@@ -420,6 +452,11 @@ func (s *storageImpl) StoreExecutedBatch(batch *core.Batch, receipts []*types.Re
 	return nil
 }
 
+func (s *storageImpl) FetchBatchSeqNosByAddress(address gethcommon.Address, fromSeqNo uint64, toSeqNo uint64) ([]uint64, error) {
+	defer s.logDuration("FetchBatchSeqNosByAddress", measure.NewStopwatch())
+	return enclavedb.ReadBatchSeqNosByAddress(s.db.GetSQLDB(), address, fromSeqNo, toSeqNo)
+}
+
 func (s *storageImpl) StoreValueTransfers(blockHash common.L1BlockHash, transfers common.ValueTransferEvents) error {
 	return enclavedb.WriteL1Messages(s.db.GetSQLDB(), blockHash, transfers, true)
 }
@@ -438,6 +475,26 @@ func (s *storageImpl) GetL1Transfers(blockHash common.L1BlockHash) (common.Value
 	return enclavedb.FetchL1Messages[common.ValueTransferEvent](s.db.GetSQLDB(), blockHash, true)
 }
 
+func (s *storageImpl) StoreL1Relays(blockHash common.L1BlockHash, relays common.MessageRelays) error {
+	defer s.logDuration("StoreL1Relays", measure.NewStopwatch())
+	return enclavedb.WriteL1Relays(s.db.GetSQLDB(), blockHash, relays)
+}
+
+func (s *storageImpl) GetL1Relays(blockHash common.L1BlockHash) (common.MessageRelays, error) {
+	defer s.logDuration("GetL1Relays", measure.NewStopwatch())
+	return enclavedb.FetchL1Relays(s.db.GetSQLDB(), blockHash)
+}
+
+func (s *storageImpl) StoreOutboundMessages(messages []*common.OutboundMessage) error {
+	defer s.logDuration("StoreOutboundMessages", measure.NewStopwatch())
+	return enclavedb.WriteOutboundMessages(s.db.GetSQLDB(), messages)
+}
+
+func (s *storageImpl) FetchOutboundMessages(fromSeqNo uint64, toSeqNo uint64) ([]*common.OutboundMessage, error) {
+	defer s.logDuration("FetchOutboundMessages", measure.NewStopwatch())
+	return enclavedb.ReadOutboundMessages(s.db.GetSQLDB(), fromSeqNo, toSeqNo)
+}
+
 const enclaveKeyKey = "ek"
 
 func (s *storageImpl) StoreEnclaveKey(enclaveKey *ecdsa.PrivateKey) error {
@@ -464,6 +521,126 @@ func (s *storageImpl) GetEnclaveKey() (*ecdsa.PrivateKey, error) {
 	return enclaveKey, nil
 }
 
+const governanceChangesKey = "gov"
+
+func (s *storageImpl) StoreGovernanceChanges(changes []*common.GovernanceChange) error {
+	defer s.logDuration("StoreGovernanceChanges", measure.NewStopwatch())
+	stored, err := s.FetchGovernanceChanges()
+	if err != nil {
+		return err
+	}
+	enc, err := rlp.EncodeToBytes(append(stored, changes...))
+	if err != nil {
+		return fmt.Errorf("could not encode governance changes. Cause: %w", err)
+	}
+	if len(stored) == 0 {
+		_, err = enclavedb.WriteConfig(s.db.GetSQLDB(), governanceChangesKey, enc)
+	} else {
+		_, err = enclavedb.UpdateConfig(s.db.GetSQLDB(), governanceChangesKey, enc)
+	}
+	if err != nil {
+		return fmt.Errorf("could not store governance changes in DB. Cause: %w", err)
+	}
+	return nil
+}
+
+func (s *storageImpl) FetchGovernanceChanges() ([]*common.GovernanceChange, error) {
+	defer s.logDuration("FetchGovernanceChanges", measure.NewStopwatch())
+	enc, err := enclavedb.FetchConfig(s.db.GetSQLDB(), governanceChangesKey)
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	var changes []*common.GovernanceChange
+	if err := rlp.DecodeBytes(enc, &changes); err != nil {
+		return nil, fmt.Errorf("could not decode governance changes. Cause: %w", err)
+	}
+	return changes, nil
+}
+
+const lastProfileRequestKey = "prof"
+
+func (s *storageImpl) StoreLastProfileRequest(timestamp uint64) error {
+	defer s.logDuration("StoreLastProfileRequest", measure.NewStopwatch())
+	stored, err := s.FetchLastProfileRequest()
+	if err != nil {
+		return err
+	}
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, timestamp)
+	if stored == 0 {
+		_, err = enclavedb.WriteConfig(s.db.GetSQLDB(), lastProfileRequestKey, enc)
+	} else {
+		_, err = enclavedb.UpdateConfig(s.db.GetSQLDB(), lastProfileRequestKey, enc)
+	}
+	if err != nil {
+		return fmt.Errorf("could not store the last profile request in DB. Cause: %w", err)
+	}
+	return nil
+}
+
+func (s *storageImpl) FetchLastProfileRequest() (uint64, error) {
+	defer s.logDuration("FetchLastProfileRequest", measure.NewStopwatch())
+	enc, err := enclavedb.FetchConfig(s.db.GetSQLDB(), lastProfileRequestKey)
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	if len(enc) != 8 {
+		return 0, fmt.Errorf("invalid last profile request of %d bytes", len(enc))
+	}
+	return binary.BigEndian.Uint64(enc), nil
+}
+
+func (s *storageImpl) StoreBatchFees(fees *common.BatchFees) error {
+	defer s.logDuration("StoreBatchFees", measure.NewStopwatch())
+	return enclavedb.WriteBatchFees(s.db.GetSQLDB(), fees)
+}
+
+func (s *storageImpl) FetchBatchFees(fromSeqNo uint64, toSeqNo uint64) ([]*common.BatchFees, error) {
+	defer s.logDuration("FetchBatchFees", measure.NewStopwatch())
+	return enclavedb.ReadBatchFees(s.db.GetSQLDB(), fromSeqNo, toSeqNo)
+}
+
+func (s *storageImpl) FetchRollupBatchRange(rollupHash common.L2RollupHash) (uint64, uint64, error) {
+	defer s.logDuration("FetchRollupBatchRange", measure.NewStopwatch())
+	return enclavedb.FetchRollupBatchRange(s.db.GetSQLDB(), rollupHash)
+}
+
+func (s *storageImpl) FetchRolledUpBatchSeqNo(maxL1Height uint64) (uint64, error) {
+	defer s.logDuration("FetchRolledUpBatchSeqNo", measure.NewStopwatch())
+	return enclavedb.FetchRolledUpBatchSeqNo(s.db.GetSQLDB(), maxL1Height)
+}
+
+func (s *storageImpl) StoreTxFailures(failures []*common.TransactionError) error {
+	defer s.logDuration("StoreTxFailures", measure.NewStopwatch())
+	return enclavedb.WriteTxFailures(s.db.GetSQLDB(), failures)
+}
+
+func (s *storageImpl) FetchTxFailure(txHash common.L2TxHash) (*common.TransactionError, error) {
+	defer s.logDuration("FetchTxFailure", measure.NewStopwatch())
+	return enclavedb.ReadTxFailure(s.db.GetSQLDB(), txHash)
+}
+
+func (s *storageImpl) StoreMempoolTx(tx *common.L2Tx) error {
+	defer s.logDuration("StoreMempoolTx", measure.NewStopwatch())
+	return enclavedb.WriteMempoolTx(s.db.GetSQLDB(), tx)
+}
+
+func (s *storageImpl) DeleteMempoolTxs(hashes []common.L2TxHash) error {
+	defer s.logDuration("DeleteMempoolTxs", measure.NewStopwatch())
+	return enclavedb.DeleteMempoolTxs(s.db.GetSQLDB(), hashes)
+}
+
+func (s *storageImpl) FetchMempoolTxs() ([]*common.L2Tx, error) {
+	defer s.logDuration("FetchMempoolTxs", measure.NewStopwatch())
+	return enclavedb.ReadMempoolTxs(s.db.GetSQLDB())
+}
+
 func (s *storageImpl) StoreRollup(rollup *common.ExtRollup, internalHeader *common.CalldataRollupHeader) error {
 	defer s.logDuration("StoreRollup", measure.NewStopwatch())
 	dbBatch := s.db.NewDBTransaction()
@@ -498,6 +675,18 @@ func (s *storageImpl) FilterLogs(
 	return enclavedb.FilterLogs(s.db.GetSQLDB(), requestingAccount, fromBlock, toBlock, blockHash, addresses, topics)
 }
 
+func (s *storageImpl) FilterLogsPage(
+	requestingAccount *gethcommon.Address,
+	fromBlock, toBlock *big.Int,
+	addresses []gethcommon.Address,
+	topics [][]gethcommon.Hash,
+	cursor *common.LogCursor,
+	limit int,
+) ([]*types.Log, error) {
+	defer s.logDuration("FilterLogsPage", measure.NewStopwatch())
+	return enclavedb.FilterLogsPage(s.db.GetSQLDB(), requestingAccount, fromBlock, toBlock, addresses, topics, cursor, limit)
+}
+
 func (s *storageImpl) GetContractCount() (*big.Int, error) {
 	defer s.logDuration("GetContractCount", measure.NewStopwatch())
 	return enclavedb.ReadContractCreationCount(s.db.GetSQLDB())
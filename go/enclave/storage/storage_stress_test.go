@@ -0,0 +1,307 @@
+package storage
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+	"github.com/ten-protocol/go-ten/go/enclave/storage/init/sqlite"
+)
+
+// stressEnvVar enables TestStorageStress, which is too slow for the default test run
+const stressEnvVar = "TEN_STORAGE_STRESS"
+
+const (
+	stressTxsPerBatch    = 20
+	stressLogsPerReceipt = 2
+	stressBatchesPerFork = 5   // the batches rewound by each reorg
+	stressTestOps        = 200 // the operations of each workload run by TestStorageStress
+)
+
+// stressBackends are the databases the workloads run against, by name. EdgelessDB only runs in an enclave, so it is
+// not covered.
+var stressBackends = map[string]string{
+	"sqlite-memory": "mode=memory&cache=shared&_foreign_keys=on",
+	"sqlite-file":   "_foreign_keys=on",
+	"sqlite-wal":    "_foreign_keys=on&_journal_mode=WAL",
+}
+
+// stressWorkload is a write pattern of the enclave. Each operation is prepared, untimed, then run and timed.
+type stressWorkload struct {
+	name string
+	// the p99 latency above which TestStorageStress fails, set well above the measured latencies so that only
+	// regressions fail the run
+	maxP99  time.Duration
+	prepare func(c *stressChain) (func() error, error)
+}
+
+var stressWorkloads = []stressWorkload{
+	{
+		// the sequencer and the validators store each batch, then its receipts once it was executed
+		name:   "batch-commit",
+		maxP99: 500 * time.Millisecond,
+		prepare: func(c *stressChain) (func() error, error) {
+			batch, receipts, err := c.nextBatch(c.head)
+			if err != nil {
+				return nil, err
+			}
+			return func() error {
+				if err := c.s.StoreBatch(batch); err != nil {
+					return err
+				}
+				return c.s.StoreExecutedBatch(batch, receipts)
+			}, nil
+		},
+	},
+	{
+		// the batches received from the sequencer are stored before they are executed
+		name:   "receipt-write",
+		maxP99: 500 * time.Millisecond,
+		prepare: func(c *stressChain) (func() error, error) {
+			batch, receipts, err := c.nextBatch(c.head)
+			if err != nil {
+				return nil, err
+			}
+			if err = c.s.StoreBatch(batch); err != nil {
+				return nil, err
+			}
+			return func() error {
+				return c.s.StoreExecutedBatch(batch, receipts)
+			}, nil
+		},
+	},
+	{
+		// an L1 reorg replaces the head block, and the batches of the replaced block are no longer canonical
+		name:   "reorg-rewind",
+		maxP99: 250 * time.Millisecond,
+		prepare: func(c *stressChain) (func() error, error) {
+			ancestor := c.head
+			orphaned := c.nextBlock(ancestor, 0)
+			if err := c.s.StoreBlock(orphaned, nil); err != nil {
+				return nil, err
+			}
+			for i := 0; i < stressBatchesPerFork; i++ {
+				batch, receipts, err := c.nextBatch(orphaned)
+				if err != nil {
+					return nil, err
+				}
+				if err = c.s.StoreBatch(batch); err != nil {
+					return nil, err
+				}
+				if err = c.s.StoreExecutedBatch(batch, receipts); err != nil {
+					return nil, err
+				}
+			}
+			canonical := c.nextBlock(ancestor, 1)
+			fork := &common.ChainFork{
+				NewCanonical:     canonical,
+				OldCanonical:     orphaned,
+				CommonAncestor:   ancestor,
+				CanonicalPath:    []common.L1BlockHash{canonical.Hash()},
+				NonCanonicalPath: []common.L1BlockHash{orphaned.Hash()},
+			}
+			return func() error {
+				return c.s.StoreBlock(canonical, fork)
+			}, nil
+		},
+	},
+}
+
+// stressChain generates a chain of L1 blocks and of batches of signed transactions, with their receipts and logs
+type stressChain struct {
+	s      Storage
+	key    *ecdsa.PrivateKey
+	signer types.Signer
+	head   *types.Block // the L1 block the batches are built on
+	parent *core.Batch
+	nonce  uint64
+}
+
+func newStressChain(backend string, dir string) (*stressChain, error) {
+	db, err := sqlite.CreateTemporarySQLiteDB(filepath.Join(dir, backend+".db"), stressBackends[backend], gethlog.New())
+	if err != nil {
+		return nil, err
+	}
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		return nil, err
+	}
+	c := &stressChain{
+		s:      NewStorage(db, nil, nil, 0, gethlog.New()),
+		key:    key,
+		signer: types.LatestSignerForChainID(big.NewInt(443)),
+	}
+	genesis := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(0)})
+	if err = c.s.StoreBlock(genesis, nil); err != nil {
+		return nil, err
+	}
+	c.head = genesis
+	return c, nil
+}
+
+// nextBlock returns a child of the block. The salt tells the siblings apart
+func (c *stressChain) nextBlock(parent *types.Block, salt uint64) *types.Block {
+	block := types.NewBlockWithHeader(&types.Header{
+		ParentHash: parent.Hash(),
+		Number:     big.NewInt(0).Add(parent.Number(), big.NewInt(1)),
+		Nonce:      types.EncodeNonce(salt),
+	})
+	c.head = block
+	return block
+}
+
+// nextBatch returns the next batch on the L1 block, with a receipt holding logs for each of its transactions
+func (c *stressChain) nextBatch(block *types.Block) (*core.Batch, []*types.Receipt, error) {
+	header := &common.BatchHeader{
+		L1Proof:          block.Hash(),
+		Number:           big.NewInt(0),
+		SequencerOrderNo: big.NewInt(int64(common.L2GenesisSeqNo)),
+		Root:             types.EmptyRootHash,
+	}
+	if c.parent != nil {
+		header.ParentHash = c.parent.Hash()
+		header.Number = big.NewInt(0).Add(c.parent.Number(), big.NewInt(1))
+		header.SequencerOrderNo = big.NewInt(0).Add(c.parent.SeqNo(), big.NewInt(1))
+	}
+	batch := &core.Batch{Header: header}
+
+	receipts := make([]*types.Receipt, 0, stressTxsPerBatch)
+	for i := 0; i < stressTxsPerBatch; i++ {
+		to := gethcommon.BigToAddress(big.NewInt(int64(c.nonce)))
+		tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: c.nonce, GasPrice: big.NewInt(1), Gas: 100_000, To: &to, Data: make([]byte, 68)}), c.signer, c.key)
+		if err != nil {
+			return nil, nil, err
+		}
+		c.nonce++
+		batch.Transactions = append(batch.Transactions, tx)
+
+		receipt := &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: tx.Hash(), GasUsed: 50_000, TransactionIndex: uint(i)}
+		for j := 0; j < stressLogsPerReceipt; j++ {
+			receipt.Logs = append(receipt.Logs, &types.Log{
+				Address: to,
+				Topics:  []gethcommon.Hash{gethcommon.BigToHash(big.NewInt(int64(j))), gethcommon.BytesToHash(to.Bytes())},
+				Data:    make([]byte, 64),
+				TxHash:  tx.Hash(),
+				Index:   uint(i*stressLogsPerReceipt + j),
+			})
+		}
+		receipts = append(receipts, receipt)
+	}
+	for _, receipt := range receipts {
+		receipt.BlockHash = batch.Hash()
+	}
+	c.parent = batch
+	return batch, receipts, nil
+}
+
+// stressResult summarises the latencies of the operations of a workload
+type stressResult struct {
+	ops       int
+	total     time.Duration
+	p50, p99  time.Duration
+	opsPerSec float64
+}
+
+func (r stressResult) String() string {
+	return fmt.Sprintf("%d ops, %.0f ops/s, p50=%s, p99=%s", r.ops, r.opsPerSec, r.p50, r.p99)
+}
+
+func summarise(latencies []time.Duration) stressResult {
+	r := stressResult{ops: len(latencies)}
+	if len(latencies) == 0 {
+		return r
+	}
+	sorted := append([]time.Duration(nil), latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	for _, l := range sorted {
+		r.total += l
+	}
+	r.p50 = sorted[(len(sorted)-1)*50/100]
+	r.p99 = sorted[(len(sorted)-1)*99/100]
+	if r.total > 0 {
+		r.opsPerSec = float64(len(sorted)) / r.total.Seconds()
+	}
+	return r
+}
+
+// runStress runs the operations of the workload and returns their latencies
+func runStress(tb testing.TB, c *stressChain, workload stressWorkload, ops int) []time.Duration {
+	latencies := make([]time.Duration, 0, ops)
+	for i := 0; i < ops; i++ {
+		op, err := workload.prepare(c)
+		require.NoError(tb, err)
+		start := time.Now()
+		require.NoError(tb, op())
+		latencies = append(latencies, time.Since(start))
+	}
+	return latencies
+}
+
+func sortedBackends() []string {
+	backends := make([]string, 0, len(stressBackends))
+	for backend := range stressBackends {
+		backends = append(backends, backend)
+	}
+	sort.Strings(backends)
+	return backends
+}
+
+// BenchmarkStorage reports the throughput and the p99 latency of the write patterns of the enclave on each backend
+// (e.g. go test -run=^$ -bench=BenchmarkStorage ./go/enclave/storage)
+func BenchmarkStorage(b *testing.B) {
+	for _, backend := range sortedBackends() {
+		for _, workload := range stressWorkloads {
+			b.Run(backend+"/"+workload.name, func(b *testing.B) {
+				c, err := newStressChain(backend, b.TempDir())
+				require.NoError(b, err)
+				b.ResetTimer()
+				result := summarise(runStress(b, c, workload, b.N))
+				b.ReportMetric(result.opsPerSec, "ops/s")
+				b.ReportMetric(float64(result.p99.Microseconds()), "p99-µs")
+			})
+		}
+	}
+}
+
+// TestStorageStress runs each write pattern of the enclave on each backend, and fails when the p99 latency of a
+// workload regresses above its ceiling. It only runs when TEN_STORAGE_STRESS is set.
+func TestStorageStress(t *testing.T) {
+	if os.Getenv(stressEnvVar) == "" {
+		t.Skipf("set %s to run the storage stress test", stressEnvVar)
+	}
+	for _, backend := range sortedBackends() {
+		for _, workload := range stressWorkloads {
+			t.Run(backend+"/"+workload.name, func(t *testing.T) {
+				c, err := newStressChain(backend, t.TempDir())
+				require.NoError(t, err)
+				result := summarise(runStress(t, c, workload, stressTestOps))
+				t.Logf("%s/%s: %s", backend, workload.name, result)
+				require.LessOrEqual(t, result.p99, workload.maxP99, "p99 latency regression")
+			})
+		}
+	}
+}
+
+func TestSummarise(t *testing.T) {
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[len(latencies)-1-i] = time.Duration(i+1) * time.Millisecond
+	}
+	result := summarise(latencies)
+	require.Equal(t, 100, result.ops)
+	require.Equal(t, 50*time.Millisecond, result.p50)
+	require.Equal(t, 99*time.Millisecond, result.p99)
+	require.InDelta(t, 100/5.05, result.opsPerSec, 0.01)
+}
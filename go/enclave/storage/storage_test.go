@@ -0,0 +1,321 @@
+package storage
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/enclave/core"
+	"github.com/ten-protocol/go-ten/go/enclave/storage/init/sqlite"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+func TestBatchFees(t *testing.T) {
+	backingDB, err := sqlite.CreateTemporarySQLiteDB("", "", gethlog.New())
+	require.NoError(t, err)
+	s := NewStorage(backingDB, nil, nil, 0, gethlog.New())
+
+	for seqNo := uint64(1); seqNo <= 4; seqNo++ {
+		fees := &common.BatchFees{
+			SeqNo:        seqNo,
+			L1Fees:       big.NewInt(int64(seqNo)),
+			BaseFees:     big.NewInt(int64(10 * seqNo)),
+			Tips:         big.NewInt(0),
+			Distribution: common.FeeToCoinbase,
+			Credits:      []common.FeeCredit{{Recipient: gethcommon.HexToAddress("0x01"), Amount: big.NewInt(int64(10 * seqNo))}},
+		}
+		require.NoError(t, s.StoreBatchFees(fees))
+	}
+	// a batch executed again replaces its fees
+	require.NoError(t, s.StoreBatchFees(&common.BatchFees{SeqNo: 2, L1Fees: big.NewInt(7), BaseFees: big.NewInt(0), Tips: big.NewInt(0)}))
+
+	fees, err := s.FetchBatchFees(2, 3)
+	require.NoError(t, err)
+	require.Len(t, fees, 2)
+	require.Equal(t, uint64(2), fees[0].SeqNo)
+	require.Equal(t, big.NewInt(7), fees[0].L1Fees)
+	require.Empty(t, fees[0].Credits)
+	require.Equal(t, uint64(3), fees[1].SeqNo)
+	require.Equal(t, big.NewInt(30), fees[1].Credits[0].Amount)
+
+	_, _, err = s.FetchRollupBatchRange(gethcommon.HexToHash("0x01"))
+	require.ErrorIs(t, err, errutil.ErrNotFound)
+}
+
+func TestMempoolTxs(t *testing.T) {
+	backingDB, err := sqlite.CreateTemporarySQLiteDB("", "", gethlog.New())
+	require.NoError(t, err)
+	s := NewStorage(backingDB, nil, nil, 0, gethlog.New())
+
+	var txs []*common.L2Tx
+	for nonce := uint64(0); nonce < 3; nonce++ {
+		tx := types.NewTx(&types.LegacyTx{Nonce: nonce, GasPrice: big.NewInt(1), Gas: 21_000})
+		tx.SetTime(time.Unix(int64(1_700_000_000+nonce), 0))
+		require.NoError(t, s.StoreMempoolTx(tx))
+		txs = append(txs, tx)
+	}
+	require.NoError(t, s.DeleteMempoolTxs([]common.L2TxHash{txs[1].Hash()}))
+	require.NoError(t, s.DeleteMempoolTxs(nil))
+
+	persisted, err := s.FetchMempoolTxs()
+	require.NoError(t, err)
+	require.Len(t, persisted, 2)
+	for i, expected := range []*common.L2Tx{txs[0], txs[2]} {
+		require.Equal(t, expected.Hash(), persisted[i].Hash())
+		require.Equal(t, expected.Time(), persisted[i].Time())
+	}
+}
+
+func TestLastProfileRequest(t *testing.T) {
+	backingDB, err := sqlite.CreateTemporarySQLiteDB("", "", gethlog.New())
+	require.NoError(t, err)
+	s := NewStorage(backingDB, nil, nil, 0, gethlog.New())
+
+	last, err := s.FetchLastProfileRequest()
+	require.NoError(t, err)
+	require.Zero(t, last)
+	for _, timestamp := range []uint64{1_700_000_000, 1_700_000_060} {
+		require.NoError(t, s.StoreLastProfileRequest(timestamp))
+		last, err = s.FetchLastProfileRequest()
+		require.NoError(t, err)
+		require.Equal(t, timestamp, last)
+	}
+}
+
+func TestTxFailures(t *testing.T) {
+	backingDB, err := sqlite.CreateTemporarySQLiteDB("", "", gethlog.New())
+	require.NoError(t, err)
+	s := NewStorage(backingDB, nil, nil, 0, gethlog.New())
+
+	excluded := &common.TransactionError{TxHash: gethcommon.HexToHash("0x01"), Stage: common.TxFailedValidation, BatchSeqNo: 3, Reason: "nonce too high"}
+	reverted := &common.TransactionError{TxHash: gethcommon.HexToHash("0x02"), Stage: common.TxFailedExecution, BatchSeqNo: 3, Reason: "execution reverted", RevertData: []byte{0xde, 0xad}}
+	require.NoError(t, s.StoreTxFailures([]*common.TransactionError{excluded, reverted}))
+	require.NoError(t, s.StoreTxFailures(nil))
+
+	// the transaction excluded from a batch then fails in a later one
+	failedLater := &common.TransactionError{TxHash: excluded.TxHash, Stage: common.TxFailedExecution, BatchSeqNo: 4, Reason: "out of gas"}
+	require.NoError(t, s.StoreTxFailures([]*common.TransactionError{failedLater}))
+
+	failure, err := s.FetchTxFailure(excluded.TxHash)
+	require.NoError(t, err)
+	require.Equal(t, failedLater.Stage, failure.Stage)
+	require.Equal(t, failedLater.BatchSeqNo, failure.BatchSeqNo)
+	require.Equal(t, failedLater.Reason, failure.Reason)
+	require.Empty(t, failure.RevertData)
+	failure, err = s.FetchTxFailure(reverted.TxHash)
+	require.NoError(t, err)
+	require.Equal(t, reverted, failure)
+
+	_, err = s.FetchTxFailure(gethcommon.HexToHash("0x03"))
+	require.ErrorIs(t, err, errutil.ErrNotFound)
+}
+
+func TestRelaysAndOutboundMessages(t *testing.T) {
+	backingDB, err := sqlite.CreateTemporarySQLiteDB("", "", gethlog.New())
+	require.NoError(t, err)
+	s := NewStorage(backingDB, nil, nil, 0, gethlog.New())
+
+	block := crypto.Keccak256Hash([]byte("block"))
+	relays := common.MessageRelays{
+		{MessageHash: gethcommon.HexToHash("0x01"), Relayer: gethcommon.HexToAddress("0x11")},
+		{MessageHash: gethcommon.HexToHash("0x02"), Relayer: gethcommon.HexToAddress("0x12")},
+	}
+	require.NoError(t, s.StoreL1Relays(block, relays))
+	stored, err := s.GetL1Relays(block)
+	require.NoError(t, err)
+	require.Equal(t, relays, stored)
+	stored, err = s.GetL1Relays(crypto.Keccak256Hash([]byte("other block")))
+	require.NoError(t, err)
+	require.Empty(t, stored)
+
+	var messages []*common.OutboundMessage
+	for seqNo := uint64(1); seqNo <= 3; seqNo++ {
+		messages = append(messages, &common.OutboundMessage{
+			Hash:       gethcommon.BigToHash(big.NewInt(int64(seqNo))),
+			BatchSeqNo: seqNo,
+			Message:    common.CrossChainMessage{Sender: gethcommon.HexToAddress("0x21"), Sequence: seqNo, Payload: []byte{byte(seqNo)}},
+		})
+	}
+	require.NoError(t, s.StoreOutboundMessages(messages))
+	require.NoError(t, s.StoreOutboundMessages(nil))
+
+	fetched, err := s.FetchOutboundMessages(2, 3)
+	require.NoError(t, err)
+	require.Equal(t, messages[1:], fetched)
+}
+
+func TestBatchSeqNosByAddress(t *testing.T) {
+	backingDB, err := sqlite.CreateTemporarySQLiteDB("", "", gethlog.New())
+	require.NoError(t, err)
+	s := NewStorage(backingDB, nil, nil, 0, gethlog.New())
+
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)})
+	require.NoError(t, s.StoreBlock(block, nil))
+
+	senderKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	sender := crypto.PubkeyToAddress(senderKey.PublicKey)
+	recipient := gethcommon.HexToAddress("0x0b")
+	emitter := gethcommon.HexToAddress("0x0c")
+	signer := types.LatestSignerForChainID(big.NewInt(443))
+
+	// the first batch transfers from the sender to the recipient, the second calls a contract that emits a log
+	var parent gethcommon.Hash
+	for seqNo := uint64(1); seqNo <= 2; seqNo++ {
+		to := recipient
+		if seqNo == 2 {
+			to = gethcommon.HexToAddress("0x0d")
+		}
+		tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: seqNo, GasPrice: big.NewInt(1), Gas: 21_000, To: &to}), signer, senderKey)
+		require.NoError(t, err)
+		batch := &core.Batch{
+			Header: &common.BatchHeader{
+				ParentHash:       parent,
+				L1Proof:          block.Hash(),
+				Number:           big.NewInt(int64(seqNo - 1)),
+				SequencerOrderNo: big.NewInt(int64(seqNo)),
+			},
+			Transactions: []*common.L2Tx{tx},
+		}
+		receipt := &types.Receipt{TxHash: tx.Hash(), BlockHash: batch.Hash()}
+		if seqNo == 2 {
+			receipt.Logs = []*types.Log{{Address: emitter}}
+		}
+		require.NoError(t, s.StoreBatch(batch))
+		require.NoError(t, s.StoreExecutedBatch(batch, []*types.Receipt{receipt}))
+		parent = batch.Hash()
+	}
+
+	for address, expected := range map[gethcommon.Address][]uint64{
+		sender:    {1, 2},
+		recipient: {1},
+		emitter:   {2},
+	} {
+		seqNos, err := s.FetchBatchSeqNosByAddress(address, 1, 2)
+		require.NoError(t, err)
+		require.Equal(t, expected, seqNos)
+	}
+
+	seqNos, err := s.FetchBatchSeqNosByAddress(sender, 2, 5)
+	require.NoError(t, err)
+	require.Equal(t, []uint64{2}, seqNos)
+}
+
+func TestRolledUpBatchSeqNo(t *testing.T) {
+	backingDB, err := sqlite.CreateTemporarySQLiteDB("", "", gethlog.New())
+	require.NoError(t, err)
+	s := NewStorage(backingDB, nil, nil, 0, gethlog.New())
+
+	_, err = s.FetchRolledUpBatchSeqNo(10)
+	require.ErrorIs(t, err, errutil.ErrNotFound)
+
+	var blocks []*types.Block
+	var parent gethcommon.Hash
+	for height := int64(1); height <= 3; height++ {
+		block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(height), ParentHash: parent})
+		require.NoError(t, s.StoreBlock(block, nil))
+		blocks = append(blocks, block)
+		parent = block.Hash()
+	}
+	for i, lastSeqNo := range map[int]uint64{0: 5, 2: 9} {
+		rollup := &common.ExtRollup{Header: &common.RollupHeader{CompressionL1Head: blocks[i].Hash(), LastBatchSeqNo: lastSeqNo}}
+		require.NoError(t, s.StoreRollup(rollup, &common.CalldataRollupHeader{FirstBatchSequence: big.NewInt(int64(lastSeqNo - 3))}))
+	}
+
+	for maxL1Height, expected := range map[uint64]uint64{1: 5, 2: 5, 3: 9} {
+		seqNo, err := s.FetchRolledUpBatchSeqNo(maxL1Height)
+		require.NoError(t, err)
+		require.Equal(t, expected, seqNo)
+	}
+
+	// the rollups compressed on a block reorged out of the L1 chain are ignored
+	fork := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(3), ParentHash: blocks[1].Hash(), Extra: []byte("fork")})
+	require.NoError(t, s.StoreBlock(fork, &common.ChainFork{
+		NewCanonical:     fork,
+		OldCanonical:     blocks[2],
+		CommonAncestor:   blocks[1],
+		CanonicalPath:    []common.L1BlockHash{fork.Hash()},
+		NonCanonicalPath: []common.L1BlockHash{blocks[2].Hash()},
+	}))
+	seqNo, err := s.FetchRolledUpBatchSeqNo(3)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), seqNo)
+}
+
+func TestFilterLogsPage(t *testing.T) {
+	backingDB, err := sqlite.CreateTemporarySQLiteDB("", "", gethlog.New())
+	require.NoError(t, err)
+	s := NewStorage(backingDB, nil, nil, 0, gethlog.New())
+
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(1)})
+	require.NoError(t, s.StoreBlock(block, nil))
+
+	senderKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	emitter := gethcommon.HexToAddress("0x0c")
+	topic := crypto.Keccak256Hash([]byte("Event()"))
+	signer := types.LatestSignerForChainID(big.NewInt(443))
+
+	// batches with a transaction emitting two logs each, the logs are stored from the height 2
+	var parent gethcommon.Hash
+	for seqNo := uint64(1); seqNo <= 4; seqNo++ {
+		tx, err := types.SignTx(types.NewTx(&types.LegacyTx{Nonce: seqNo, GasPrice: big.NewInt(1), Gas: 21_000, To: &emitter}), signer, senderKey)
+		require.NoError(t, err)
+		batch := &core.Batch{
+			Header: &common.BatchHeader{
+				ParentHash:       parent,
+				L1Proof:          block.Hash(),
+				Number:           big.NewInt(int64(seqNo)),
+				SequencerOrderNo: big.NewInt(int64(seqNo)),
+			},
+			Transactions: []*common.L2Tx{tx},
+		}
+		receipt := &types.Receipt{TxHash: tx.Hash(), BlockHash: batch.Hash(), Logs: []*types.Log{
+			{Address: emitter, Topics: []gethcommon.Hash{topic}, TxHash: tx.Hash(), Index: 0},
+			{Address: emitter, Topics: []gethcommon.Hash{topic}, TxHash: tx.Hash(), Index: 1},
+		}}
+		require.NoError(t, s.StoreBatch(batch))
+		require.NoError(t, s.StoreExecutedBatch(batch, []*types.Receipt{receipt}))
+		parent = batch.Hash()
+	}
+
+	requester := gethcommon.HexToAddress("0x0a")
+	all, err := s.FilterLogsPage(&requester, big.NewInt(2), big.NewInt(4), nil, nil, nil, 0)
+	require.NoError(t, err)
+	require.Len(t, all, 6)
+	for i, l := range all {
+		require.Equal(t, uint64(2+i/2), l.BlockNumber)
+		require.Equal(t, uint(i%2), l.Index)
+	}
+
+	// the pages resume at the cursor, the first log of the next page, until all the logs were returned
+	var fetched []*types.Log
+	var cursor *common.LogCursor
+	for {
+		page, err := s.FilterLogsPage(&requester, big.NewInt(2), big.NewInt(4), nil, nil, cursor, 3)
+		require.NoError(t, err)
+		if len(page) < 3 {
+			fetched = append(fetched, page...)
+			break
+		}
+		fetched = append(fetched, page[:2]...)
+		next := page[2]
+		cursor = &common.LogCursor{BatchHeight: next.BlockNumber, TxIndex: next.TxIndex, LogIndex: next.Index}
+	}
+	require.Equal(t, all, fetched)
+
+	// the range bounds the batches searched
+	logs, err := s.FilterLogsPage(&requester, big.NewInt(3), big.NewInt(3), nil, nil, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, all[2:4], logs)
+	logs, err = s.FilterLogsPage(&requester, big.NewInt(0), big.NewInt(2), nil, nil, nil, 0)
+	require.NoError(t, err)
+	require.Equal(t, all[:2], logs)
+}
@@ -1,20 +1,38 @@
 package txpool
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	gethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/ten-protocol/go-ten/go/common/log"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	gethtxpool "github.com/ethereum/go-ethereum/core/txpool"
 	"github.com/ethereum/go-ethereum/core/txpool/legacypool"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/enclave/evm/ethchainadapter"
+	"github.com/ten-protocol/go-ten/go/enclave/storage"
 )
 
+const (
+	// the inclusion latency is estimated from the waits of this many of the latest transactions included in a batch
+	_inclusionLatencySamples = 256
+	// the aggregate statistics of the mempool are only computed over this many transactions at least, so that they
+	// reveal no individual transaction
+	_minTxsForStats = 5
+)
+
+// the percentiles of the gas prices of the pending transactions in the mempool statistics
+var _gasPricePercentiles = []int{10, 25, 50, 75, 90}
+
 // TxPool is an obscuro wrapper around geths transaction pool
 type TxPool struct {
 	txPoolConfig legacypool.Config
@@ -24,19 +42,38 @@ type TxPool struct {
 	gasTip       *big.Int
 	running      bool
 	logger       gethlog.Logger
+
+	// the transactions accepted in the pool are persisted in the sealed storage of the enclave, and restored when the
+	// pool starts, so they survive the restarts. Nil if the pool is not persisted.
+	journal storage.MempoolStorage
+
+	// the pending transactions waiting longer than the inclusion deadline are reported as overdue
+	inclusionDeadline time.Duration
+	overdueLock       sync.Mutex
+	overdue           map[gethcommon.Hash]struct{} // the overdue transactions already reported
+	now               func() time.Time
+
+	// the waits in the mempool of the latest transactions included in a batch, in a ring buffer
+	latenciesLock sync.Mutex
+	latencies     []time.Duration
+	nextLatency   int
 }
 
-// NewTxPool returns a new instance of the tx pool
-func NewTxPool(blockchain *ethchainadapter.EthChainAdapter, gasTip *big.Int, logger gethlog.Logger) (*TxPool, error) {
-	txPoolConfig := ethchainadapter.NewLegacyPoolConfig()
+// NewTxPool returns a new instance of the tx pool, persisted in the journal unless it is nil
+func NewTxPool(blockchain *ethchainadapter.EthChainAdapter, gasTip *big.Int, priceBump uint64, inclusionDeadline time.Duration, journal storage.MempoolStorage, logger gethlog.Logger) (*TxPool, error) {
+	txPoolConfig := ethchainadapter.NewLegacyPoolConfig(priceBump)
 	legacyPool := legacypool.New(txPoolConfig, blockchain)
 
 	return &TxPool{
-		blockchain:   blockchain,
-		txPoolConfig: txPoolConfig,
-		legacyPool:   legacyPool,
-		gasTip:       gasTip,
-		logger:       logger,
+		blockchain:        blockchain,
+		txPoolConfig:      txPoolConfig,
+		legacyPool:        legacyPool,
+		gasTip:            gasTip,
+		logger:            logger,
+		journal:           journal,
+		inclusionDeadline: inclusionDeadline,
+		overdue:           map[gethcommon.Hash]struct{}{},
+		now:               time.Now,
 	}, nil
 }
 
@@ -54,19 +91,71 @@ func (t *TxPool) Start() error {
 
 	t.pool = memp
 	t.running = true
+	return t.restore()
+}
+
+// restore re-validates the persisted transactions by adding them back to the pool, and forgets the ones rejected, e.g.
+// because they were included before the restart
+func (t *TxPool) restore() error {
+	if t.journal == nil {
+		return nil
+	}
+	persisted, err := t.journal.FetchMempoolTxs()
+	if err != nil {
+		return fmt.Errorf("unable to read the persisted mempool - %w", err)
+	}
+	if len(persisted) == 0 {
+		return nil
+	}
+
+	txs := make([]*gethtxpool.Transaction, len(persisted))
+	for i, tx := range persisted {
+		txs[i] = &gethtxpool.Transaction{Tx: tx}
+	}
+	var rejected []common.L2TxHash
+	for i, err := range t.pool.Add(txs, false, true) {
+		if err != nil {
+			t.logger.Debug("Persisted transaction rejected by the mempool", log.TxKey, persisted[i].Hash(), log.ErrKey, err)
+			rejected = append(rejected, persisted[i].Hash())
+		}
+	}
+	if err := t.journal.DeleteMempoolTxs(rejected); err != nil {
+		return fmt.Errorf("unable to delete the rejected transactions from the persisted mempool - %w", err)
+	}
+	t.logger.Info("Restored the persisted mempool", "restored", len(persisted)-len(rejected), "rejected", len(rejected))
 	return nil
 }
 
+// Forget removes the transactions that left the pool, e.g. because they were included in a batch, from the journal
+func (t *TxPool) Forget(transactions []*common.L2Tx) {
+	if t.journal == nil || len(transactions) == 0 {
+		return
+	}
+	hashes := make([]common.L2TxHash, len(transactions))
+	for i, tx := range transactions {
+		hashes[i] = tx.Hash()
+	}
+	if err := t.journal.DeleteMempoolTxs(hashes); err != nil {
+		t.logger.Error("Could not delete the included transactions from the persisted mempool", log.ErrKey, err)
+	}
+}
+
 // PendingTransactions returns all pending transactions grouped per address and ordered per nonce
 func (t *TxPool) PendingTransactions() map[gethcommon.Address][]*gethtxpool.LazyTransaction {
 	return t.pool.Pending(false)
 }
 
-// Add adds a new transactions to the pool
+// Add adds a new transactions to the pool. A transaction with the nonce of a transaction of the same sender still in
+// the pool replaces it (e.g. to speed it up or cancel it) if its fees are higher by the configured price bump.
 func (t *TxPool) Add(transaction *common.L2Tx) error {
+	replaced := t.sameNonceTx(transaction)
+
 	var strErrors []string
 	for _, err := range t.pool.Add([]*gethtxpool.Transaction{{Tx: transaction}}, false, false) {
-		if err != nil {
+		if errors.Is(err, gethtxpool.ErrReplaceUnderpriced) && replaced != nil {
+			strErrors = append(strErrors, fmt.Sprintf("%s - the fees must be %d%% higher than the ones of the pending transaction %s",
+				err, t.txPoolConfig.PriceBump, replaced.Hash()))
+		} else if err != nil {
 			strErrors = append(strErrors, err.Error())
 		}
 	}
@@ -74,9 +163,162 @@ func (t *TxPool) Add(transaction *common.L2Tx) error {
 	if len(strErrors) > 0 {
 		return fmt.Errorf(strings.Join(strErrors, "; "))
 	}
+	if replaced != nil {
+		t.logger.Info("Transaction replaced in the mempool", log.TxKey, transaction.Hash(), "replaced", replaced.Hash())
+	}
+	t.persist(transaction, replaced)
+	return nil
+}
+
+// the transaction is in the pool whether it is persisted or not, so a failure to persist it is only logged
+func (t *TxPool) persist(transaction *common.L2Tx, replaced *common.L2Tx) {
+	if t.journal == nil {
+		return
+	}
+	if err := t.journal.StoreMempoolTx(transaction); err != nil {
+		t.logger.Error("Could not persist the mempool transaction", log.TxKey, transaction.Hash(), log.ErrKey, err)
+	}
+	if replaced != nil {
+		t.Forget([]*common.L2Tx{replaced})
+	}
+}
+
+// returns the transaction in the pool with the sender and nonce of the transaction, or nil if there is none
+func (t *TxPool) sameNonceTx(transaction *common.L2Tx) *common.L2Tx {
+	sender, err := types.Sender(types.LatestSignerForChainID(transaction.ChainId()), transaction)
+	if err != nil {
+		// the pool rejects the transaction
+		return nil
+	}
+	pending, queued := t.legacyPool.ContentFrom(sender)
+	for _, txs := range [][]*types.Transaction{pending, queued} {
+		for _, tx := range txs {
+			if tx.Nonce() == transaction.Nonce() {
+				return tx
+			}
+		}
+	}
 	return nil
 }
 
+// InclusionReport returns the pending transactions that have waited in the mempool for longer than the inclusion
+// deadline, the longest waiting first. The ones exceeding it for the first time are logged as a warning, since they can
+// reveal censorship or scheduling bugs. The queued transactions are not reported, as they wait for a nonce gap of their
+// sender to be filled.
+func (t *TxPool) InclusionReport() *common.MempoolReport {
+	report := &common.MempoolReport{
+		InclusionDeadline:   hexutil.Uint64(t.inclusionDeadline / time.Second),
+		OverdueTransactions: []common.OverdueTransaction{},
+	}
+	if t.pool == nil {
+		return report
+	}
+
+	now := t.now()
+	t.overdueLock.Lock()
+	defer t.overdueLock.Unlock()
+	overdue := map[gethcommon.Hash]struct{}{}
+	for _, txs := range t.pool.Pending(false) {
+		for _, tx := range txs {
+			report.PendingCount++
+			waited := now.Sub(tx.Time)
+			if seconds := hexutil.Uint64(waited / time.Second); seconds > report.OldestTimeInMempool {
+				report.OldestTimeInMempool = seconds
+			}
+			if t.inclusionDeadline == 0 || waited <= t.inclusionDeadline {
+				continue
+			}
+
+			report.OverdueTransactions = append(report.OverdueTransactions, common.OverdueTransaction{
+				TxHash:        tx.Hash,
+				FirstSeen:     hexutil.Uint64(tx.Time.Unix()),
+				TimeInMempool: hexutil.Uint64(waited / time.Second),
+			})
+			overdue[tx.Hash] = struct{}{}
+			if _, reported := t.overdue[tx.Hash]; !reported {
+				t.logger.Warn("Transaction exceeded the inclusion deadline", log.TxKey, tx.Hash, "timeInMempool", waited,
+					"deadline", t.inclusionDeadline)
+			}
+		}
+	}
+	// the transactions no longer pending are forgotten
+	t.overdue = overdue
+
+	sort.Slice(report.OverdueTransactions, func(i, j int) bool {
+		return report.OverdueTransactions[i].TimeInMempool > report.OverdueTransactions[j].TimeInMempool
+	})
+	return report
+}
+
+// RecordInclusions records how long the transactions included in a batch waited in the mempool, for the inclusion
+// latency of the mempool statistics
+func (t *TxPool) RecordInclusions(transactions []*common.L2Tx) {
+	now := t.now()
+	t.latenciesLock.Lock()
+	defer t.latenciesLock.Unlock()
+	for _, tx := range transactions {
+		latency := now.Sub(tx.Time())
+		if latency < 0 {
+			latency = 0
+		}
+		if len(t.latencies) < _inclusionLatencySamples {
+			t.latencies = append(t.latencies, latency)
+			continue
+		}
+		t.latencies[t.nextLatency] = latency
+		t.nextLatency = (t.nextLatency + 1) % _inclusionLatencySamples
+	}
+}
+
+// Stats returns the aggregate statistics of the mempool, for the wallets to suggest fees: the number of transactions,
+// the percentiles of the gas prices of the pending ones and the median wait of the latest ones included in a batch. The
+// percentiles and the latency are left out below a minimum number of transactions, so they reveal no individual one.
+func (t *TxPool) Stats() *common.MempoolStats {
+	stats := &common.MempoolStats{GasPricePercentiles: []common.GasPricePercentile{}}
+	if t.pool == nil {
+		return stats
+	}
+
+	pending, queued := t.pool.Stats()
+	stats.PendingCount = hexutil.Uint64(pending)
+	stats.QueuedCount = hexutil.Uint64(queued)
+
+	var gasPrices []*big.Int
+	for _, txs := range t.pool.Pending(false) {
+		for _, tx := range txs {
+			gasPrices = append(gasPrices, tx.GasFeeCap)
+		}
+	}
+	if len(gasPrices) >= _minTxsForStats {
+		sort.Slice(gasPrices, func(i, j int) bool { return gasPrices[i].Cmp(gasPrices[j]) < 0 })
+		for _, percentile := range _gasPricePercentiles {
+			stats.GasPricePercentiles = append(stats.GasPricePercentiles, common.GasPricePercentile{
+				Percentile: hexutil.Uint64(percentile),
+				GasPrice:   (*hexutil.Big)(new(big.Int).Set(gasPrices[nearestRank(percentile, len(gasPrices))])),
+			})
+		}
+	}
+
+	t.latenciesLock.Lock()
+	latencies := append([]time.Duration{}, t.latencies...)
+	t.latenciesLock.Unlock()
+	if len(latencies) >= _minTxsForStats {
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		median := hexutil.Uint64(latencies[nearestRank(50, len(latencies))] / time.Second)
+		stats.InclusionLatency = &median
+	}
+	return stats
+}
+
+// returns the index of the percentile in a sorted list of the given length, by the nearest-rank method
+func nearestRank(percentile int, length int) int {
+	rank := (percentile*length + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return rank - 1
+}
+
 func (t *TxPool) Running() bool {
 	return t.running
 }
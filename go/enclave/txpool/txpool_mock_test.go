@@ -199,6 +199,11 @@ func (m *mockStorage) StoreExecutedBatch(batch *core.Batch, _ []*types.Receipt)
 	return nil
 }
 
+func (m *mockStorage) FetchBatchSeqNosByAddress(_ gethcommon.Address, _ uint64, _ uint64) ([]uint64, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (m *mockStorage) StoreRollup(_ *common.ExtRollup, _ *common.CalldataRollupHeader) error {
 	// TODO implement me
 	panic("implement me")
@@ -236,6 +241,11 @@ func (m *mockStorage) GetTransaction(_ common.L2TxHash) (*types.Transaction, get
 	panic("implement me")
 }
 
+func (m *mockStorage) GetTransactionBySenderNonce(_ gethcommon.Address, _ uint64) (*common.IncludedTransaction, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (m *mockStorage) GetTransactionReceipt(_ common.L2TxHash) (*types.Receipt, error) {
 	// TODO implement me
 	panic("implement me")
@@ -251,7 +261,7 @@ func (m *mockStorage) GetContractCreationTx(_ gethcommon.Address) (*gethcommon.H
 	panic("implement me")
 }
 
-func (m *mockStorage) FetchAttestedKey(_ gethcommon.Address) (*ecdsa.PublicKey, error) {
+func (m *mockStorage) FetchAttestedKeys(_ gethcommon.Address) ([]*ecdsa.PublicKey, error) {
 	// TODO implement me
 	panic("implement me")
 }
@@ -281,6 +291,26 @@ func (m *mockStorage) GetL1Transfers(_ common.L1BlockHash) (common.ValueTransfer
 	panic("implement me")
 }
 
+func (m *mockStorage) StoreL1Relays(_ common.L1BlockHash, _ common.MessageRelays) error {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *mockStorage) GetL1Relays(_ common.L1BlockHash) (common.MessageRelays, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *mockStorage) StoreOutboundMessages(_ []*common.OutboundMessage) error {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *mockStorage) FetchOutboundMessages(_ uint64, _ uint64) ([]*common.OutboundMessage, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (m *mockStorage) StoreEnclaveKey(_ *ecdsa.PrivateKey) error {
 	// TODO implement me
 	panic("implement me")
@@ -291,6 +321,71 @@ func (m *mockStorage) GetEnclaveKey() (*ecdsa.PrivateKey, error) {
 	panic("implement me")
 }
 
+func (m *mockStorage) StoreGovernanceChanges(_ []*common.GovernanceChange) error {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *mockStorage) FetchGovernanceChanges() ([]*common.GovernanceChange, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *mockStorage) StoreLastProfileRequest(uint64) error {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *mockStorage) FetchLastProfileRequest() (uint64, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *mockStorage) StoreBatchFees(*common.BatchFees) error {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *mockStorage) FetchBatchFees(uint64, uint64) ([]*common.BatchFees, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *mockStorage) StoreTxFailures([]*common.TransactionError) error {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *mockStorage) FetchTxFailure(gethcommon.Hash) (*common.TransactionError, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *mockStorage) FetchRollupBatchRange(common.L2RollupHash) (uint64, uint64, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *mockStorage) FetchRolledUpBatchSeqNo(uint64) (uint64, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *mockStorage) StoreMempoolTx(*common.L2Tx) error {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *mockStorage) DeleteMempoolTxs([]common.L2TxHash) error {
+	// TODO implement me
+	panic("implement me")
+}
+
+func (m *mockStorage) FetchMempoolTxs() ([]*common.L2Tx, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (m *mockStorage) GetContractCount() (*big.Int, error) {
 	// TODO implement me
 	panic("implement me")
@@ -331,6 +426,11 @@ func (m *mockStorage) FilterLogs(_ *gethcommon.Address, _, _ *big.Int, _ *common
 	panic("implement me")
 }
 
+func (m *mockStorage) FilterLogsPage(_ *gethcommon.Address, _, _ *big.Int, _ []gethcommon.Address, _ [][]gethcommon.Hash, _ *common.LogCursor, _ int) ([]*types.Log, error) {
+	// TODO implement me
+	panic("implement me")
+}
+
 func (m *mockStorage) DebugGetLogs(_ common.TxHash) ([]*tracers.DebugLogs, error) {
 	// TODO implement me
 	panic("implement me")
@@ -3,20 +3,77 @@ package txpool
 import (
 	"fmt"
 	"math/big"
+	"os"
 	"testing"
 	"time"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/state"
 	"github.com/ethereum/go-ethereum/core/types"
+	gethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/require"
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/enclave/core"
 	"github.com/ten-protocol/go-ten/go/enclave/evm/ethchainadapter"
+	"github.com/ten-protocol/go-ten/go/wallet"
 	"github.com/ten-protocol/go-ten/integration/common/testlog"
 	"github.com/ten-protocol/go-ten/integration/datagenerator"
+	"golang.org/x/exp/slices"
 )
 
+const testLogs = "../.build/tests/"
+
+// returns a chain with a genesis batch funding the accounts
+func newTestBlockchain(t *testing.T, chainID uint64, accounts []gethcommon.Address) *ethchainadapter.EthChainAdapter {
+	mockStore := newMockStorage()
+	mockRegistry := newMockBatchRegistry()
+
+	genesisState, err := applyGenesisState(mockStore, accounts)
+	require.NoError(t, err)
+	genesisBatch := &core.Batch{
+		Header: &common.BatchHeader{
+			ParentHash: common.L2BatchHash{},
+			// L1Proof:          common.ha,
+			Root:             genesisState,
+			TxHash:           types.EmptyRootHash,
+			Number:           big.NewInt(int64(0)),
+			SequencerOrderNo: big.NewInt(int64(common.L2GenesisSeqNo)), // genesis batch has seq number 1
+			ReceiptHash:      types.EmptyRootHash,
+			TransfersTree:    types.EmptyRootHash,
+			// Time:             timeNow,
+			// Coinbase:         coinbase,
+			// BaseFee:          baseFee,
+			GasLimit: 1_000_000_000_000, // todo (@siliev) - does the batch header need uint64?
+		},
+		Transactions: []*common.L2Tx{},
+	}
+
+	err = mockStore.StoreExecutedBatch(genesisBatch, nil)
+	require.NoError(t, err)
+
+	mockRegistry.OnBatchExecuted(genesisBatch, nil)
+
+	blockchain := ethchainadapter.NewEthChainAdapter(
+		big.NewInt(int64(chainID)),
+		mockRegistry,
+		mockStore,
+		testlog.Logger(),
+	)
+	err = blockchain.IngestNewBlock(genesisBatch)
+	require.NoError(t, err)
+	return blockchain
+}
+
+func TestMain(m *testing.M) {
+	testlog.Setup(&testlog.Cfg{
+		LogDir:      testLogs,
+		TestType:    "unit",
+		TestSubtype: "txpool",
+		LogLevel:    gethlog.LvlInfo,
+	})
+	os.Exit(m.Run())
+}
+
 func TestTxPool_AddTransaction_Pending(t *testing.T) {
 	chainID := datagenerator.RandomUInt64()
 	mockStore := newMockStorage()
@@ -57,7 +114,7 @@ func TestTxPool_AddTransaction_Pending(t *testing.T) {
 	err = blockchain.IngestNewBlock(genesisBatch)
 	require.NoError(t, err)
 
-	txPool, err := NewTxPool(blockchain, big.NewInt(1), testlog.Logger())
+	txPool, err := NewTxPool(blockchain, big.NewInt(1), 0, time.Minute, nil, testlog.Logger())
 	require.NoError(t, err)
 
 	// Start the TxPool
@@ -91,6 +148,216 @@ func TestTxPool_AddTransaction_Pending(t *testing.T) {
 	// TODO Mint a block and check if it's cleared from the pool
 }
 
+// adds a pending transaction to the pool, which must be started
+func addPendingTx(t *testing.T, txPool *TxPool, w wallet.Wallet, transaction *types.LegacyTx) *common.L2Tx {
+	signedTx, err := w.SignTransaction(transaction)
+	require.NoError(t, err)
+	require.NoError(t, txPool.Add(signedTx))
+	require.Eventually(t, func() bool {
+		pending := txPool.PendingTransactions()[w.Address()]
+		return len(pending) > 0 && pending[len(pending)-1].Hash == signedTx.Hash()
+	}, 5*time.Second, 10*time.Millisecond)
+	return signedTx
+}
+
+func TestTxPool_ReplaceTransaction(t *testing.T) {
+	chainID := datagenerator.RandomUInt64()
+	w := datagenerator.RandomWallet(int64(chainID))
+	blockchain := newTestBlockchain(t, chainID, []gethcommon.Address{w.Address()})
+
+	txPool, err := NewTxPool(blockchain, big.NewInt(1), 0, time.Minute, nil, testlog.Logger())
+	require.NoError(t, err)
+	require.NoError(t, txPool.Start())
+	defer txPool.Close()
+
+	randAddr := datagenerator.RandomAddress()
+	transaction := &types.LegacyTx{
+		Nonce:    0,
+		Value:    big.NewInt(1_000_000_000),
+		Gas:      uint64(1_000_000),
+		GasPrice: gethcommon.Big1,
+		To:       &randAddr,
+	}
+	signedTx := addPendingTx(t, txPool, w, transaction)
+
+	// A transaction with the same nonce and fees does not replace the pending one
+	transaction.Value = big.NewInt(2_000_000_000)
+	underpricedTx, err := w.SignTransaction(transaction)
+	require.NoError(t, err)
+	err = txPool.Add(underpricedTx)
+	require.ErrorContains(t, err, signedTx.Hash().Hex())
+
+	// A transaction with the same nonce and higher fees replaces it
+	transaction.GasPrice = big.NewInt(2)
+	replacementTx := addPendingTx(t, txPool, w, transaction)
+	pendingTxs := txPool.PendingTransactions()
+	require.Equal(t, len(pendingTxs[w.Address()]), 1)
+	require.Equal(t, pendingTxs[w.Address()][0].Hash.Hex(), replacementTx.Hash().Hex())
+}
+
+func TestTxPool_InclusionReport(t *testing.T) {
+	chainID := datagenerator.RandomUInt64()
+	w := datagenerator.RandomWallet(int64(chainID))
+	blockchain := newTestBlockchain(t, chainID, []gethcommon.Address{w.Address()})
+
+	txPool, err := NewTxPool(blockchain, big.NewInt(1), 0, time.Minute, nil, testlog.Logger())
+	require.NoError(t, err)
+	require.NoError(t, txPool.Start())
+	defer txPool.Close()
+
+	randAddr := datagenerator.RandomAddress()
+	signedTx := addPendingTx(t, txPool, w, &types.LegacyTx{
+		Nonce:    0,
+		Value:    big.NewInt(1_000_000_000),
+		Gas:      uint64(1_000_000),
+		GasPrice: gethcommon.Big1,
+		To:       &randAddr,
+	})
+
+	// The pending transaction is reported once it waited for longer than the inclusion deadline
+	report := txPool.InclusionReport()
+	require.EqualValues(t, 1, report.PendingCount)
+	require.Empty(t, report.OverdueTransactions)
+
+	txPool.now = func() time.Time { return time.Now().Add(2 * time.Minute) }
+	report = txPool.InclusionReport()
+	require.Len(t, report.OverdueTransactions, 1)
+	require.Equal(t, signedTx.Hash(), report.OverdueTransactions[0].TxHash)
+	require.GreaterOrEqual(t, uint64(report.OldestTimeInMempool), uint64(120))
+}
+
+func TestTxPool_Stats(t *testing.T) {
+	chainID := datagenerator.RandomUInt64()
+	w := datagenerator.RandomWallet(int64(chainID))
+	blockchain := newTestBlockchain(t, chainID, []gethcommon.Address{w.Address()})
+
+	txPool, err := NewTxPool(blockchain, big.NewInt(1), 0, time.Minute, nil, testlog.Logger())
+	require.NoError(t, err)
+	require.NoError(t, txPool.Start())
+	defer txPool.Close()
+
+	randAddr := datagenerator.RandomAddress()
+	var txs []*common.L2Tx
+	for nonce := uint64(0); nonce < 4; nonce++ {
+		signedTx, err := w.SignTransaction(&types.LegacyTx{
+			Nonce:    nonce,
+			Value:    big.NewInt(1),
+			Gas:      uint64(1_000_000),
+			GasPrice: big.NewInt(int64(nonce + 1)),
+			To:       &randAddr,
+		})
+		require.NoError(t, err)
+		require.NoError(t, txPool.Add(signedTx))
+		txs = append(txs, signedTx)
+	}
+
+	// below the minimum number of transactions, only the counts are returned
+	require.Eventually(t, func() bool { return txPool.Stats().PendingCount == 4 }, 5*time.Second, 10*time.Millisecond)
+	stats := txPool.Stats()
+	require.Empty(t, stats.GasPricePercentiles)
+	require.Nil(t, stats.InclusionLatency)
+
+	signedTx, err := w.SignTransaction(&types.LegacyTx{
+		Nonce:    4,
+		Value:    big.NewInt(1),
+		Gas:      uint64(1_000_000),
+		GasPrice: big.NewInt(5),
+		To:       &randAddr,
+	})
+	require.NoError(t, err)
+	require.NoError(t, txPool.Add(signedTx))
+	txs = append(txs, signedTx)
+
+	require.Eventually(t, func() bool { return txPool.Stats().PendingCount == 5 }, 5*time.Second, 10*time.Millisecond)
+	stats = txPool.Stats()
+	require.Len(t, stats.GasPricePercentiles, 5)
+	require.EqualValues(t, 10, stats.GasPricePercentiles[0].Percentile)
+	require.EqualValues(t, 1, stats.GasPricePercentiles[0].GasPrice.ToInt().Int64())
+	require.EqualValues(t, 50, stats.GasPricePercentiles[2].Percentile)
+	require.EqualValues(t, 3, stats.GasPricePercentiles[2].GasPrice.ToInt().Int64())
+	require.EqualValues(t, 5, stats.GasPricePercentiles[4].GasPrice.ToInt().Int64())
+
+	// the latency is the median wait of the transactions included in the batches
+	txPool.now = func() time.Time { return time.Now().Add(10 * time.Second) }
+	txPool.RecordInclusions(txs)
+	stats = txPool.Stats()
+	require.NotNil(t, stats.InclusionLatency)
+	require.GreaterOrEqual(t, uint64(*stats.InclusionLatency), uint64(10))
+}
+
+// memoryJournal persists the mempool in memory, in the order of the writes
+type memoryJournal struct {
+	txs []*common.L2Tx
+}
+
+func (j *memoryJournal) StoreMempoolTx(tx *common.L2Tx) error {
+	j.txs = append(j.txs, tx)
+	return nil
+}
+
+func (j *memoryJournal) DeleteMempoolTxs(hashes []common.L2TxHash) error {
+	for _, hash := range hashes {
+		j.txs = slices.DeleteFunc(j.txs, func(tx *common.L2Tx) bool { return tx.Hash() == hash })
+	}
+	return nil
+}
+
+func (j *memoryJournal) FetchMempoolTxs() ([]*common.L2Tx, error) {
+	return slices.Clone(j.txs), nil
+}
+
+func TestTxPool_RestoresPersistedTransactions(t *testing.T) {
+	chainID := datagenerator.RandomUInt64()
+	w := datagenerator.RandomWallet(int64(chainID))
+	unfunded := datagenerator.RandomWallet(int64(chainID))
+	blockchain := newTestBlockchain(t, chainID, []gethcommon.Address{w.Address()})
+	journal := &memoryJournal{}
+
+	txPool, err := NewTxPool(blockchain, big.NewInt(1), 0, time.Minute, journal, testlog.Logger())
+	require.NoError(t, err)
+	require.NoError(t, txPool.Start())
+
+	randAddr := datagenerator.RandomAddress()
+	transaction := &types.LegacyTx{
+		Nonce:    0,
+		Value:    big.NewInt(1_000_000_000),
+		Gas:      uint64(1_000_000),
+		GasPrice: gethcommon.Big1,
+		To:       &randAddr,
+	}
+	signedTx, err := w.SignTransaction(transaction)
+	require.NoError(t, err)
+	require.NoError(t, txPool.Add(signedTx))
+
+	// a replacement takes the place of the persisted transaction
+	transaction.GasPrice = big.NewInt(2)
+	replacementTx, err := w.SignTransaction(transaction)
+	require.NoError(t, err)
+	require.NoError(t, txPool.Add(replacementTx))
+	require.Equal(t, []*common.L2Tx{replacementTx}, journal.txs)
+	require.NoError(t, txPool.Close())
+
+	// a transaction that is no longer valid, e.g. accepted before the state changed
+	invalidTx, err := unfunded.SignTransaction(transaction)
+	require.NoError(t, err)
+	require.NoError(t, journal.StoreMempoolTx(invalidTx))
+
+	// the pool of the restarted enclave restores the valid transactions, and forgets the others
+	restarted, err := NewTxPool(blockchain, big.NewInt(1), 0, time.Minute, journal, testlog.Logger())
+	require.NoError(t, err)
+	require.NoError(t, restarted.Start())
+	defer restarted.Close()
+
+	pendingTxs := restarted.PendingTransactions()
+	require.Len(t, pendingTxs, 1)
+	require.Equal(t, replacementTx.Hash(), pendingTxs[w.Address()][0].Hash)
+	require.Equal(t, []*common.L2Tx{replacementTx}, journal.txs)
+
+	// the transactions included in a batch are forgotten
+	restarted.Forget([]*common.L2Tx{replacementTx})
+	require.Empty(t, journal.txs)
+}
+
 func applyGenesisState(storage *mockStorage, accounts []gethcommon.Address) (common.StateRoot, error) {
 	statedb, err := state.New(types.EmptyRootHash, storage.stateDB, nil)
 	if err != nil {
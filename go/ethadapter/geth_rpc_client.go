@@ -194,6 +194,13 @@ func (e *gethRPCClient) CallContract(msg ethereum.CallMsg) ([]byte, error) {
 	return e.client.CallContract(ctx, msg, nil)
 }
 
+func (e *gethRPCClient) SuggestGasPrice() (*big.Int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), e.timeout)
+	defer cancel()
+
+	return e.client.SuggestGasPrice(ctx)
+}
+
 func (e *gethRPCClient) EthClient() *ethclient.Client {
 	return e.client
 }
@@ -40,6 +40,8 @@ type EthClient interface {
 	PrepareTransactionToSend(txData types.TxData, from gethcommon.Address, nonce uint64) (types.TxData, error)
 	PrepareTransactionToRetry(txData types.TxData, from gethcommon.Address, nonce uint64, retries int) (types.TxData, error)
 
+	SuggestGasPrice() (*big.Int, error) // returns the gas price suggested by the node for a transaction to be included
+
 	FetchLastBatchSeqNo(address gethcommon.Address) (*big.Int, error)
 
 	Stop() // tries to cleanly stop the client and release any resources
@@ -2,15 +2,17 @@ package mgmtcontractlib
 
 import "github.com/ten-protocol/go-ten/contracts/generated/ManagementContract"
 
+// the calls are packed and unpacked with the bindings generated by tools/bindgen, the names of their methods are kept
+// for the callers matching the transactions by method
 const (
-	AddRollupMethod                = "AddRollup"
-	RespondSecretMethod            = "RespondNetworkSecret"
-	RequestSecretMethod            = "RequestNetworkSecret"
-	InitializeSecretMethod         = "InitializeNetworkSecret" //#nosec
-	GetHostAddressesMethod         = "GetHostAddresses"
-	GetImportantContractKeysMethod = "GetImportantContractKeys"
-	SetImportantContractsMethod    = "SetImportantContractAddress"
-	GetImportantAddressMethod      = "importantContractAddresses"
+	AddRollupMethod                = ManagementContract.AddRollupMethod
+	RespondSecretMethod            = ManagementContract.RespondNetworkSecretMethod
+	RequestSecretMethod            = ManagementContract.RequestNetworkSecretMethod
+	InitializeSecretMethod         = ManagementContract.InitializeNetworkSecretMethod //#nosec
+	GetHostAddressesMethod         = ManagementContract.GetHostAddressesMethod
+	GetImportantContractKeysMethod = ManagementContract.GetImportantContractKeysMethod
+	SetImportantContractsMethod    = ManagementContract.SetImportantContractAddressMethod
+	GetImportantAddressMethod      = ManagementContract.ImportantContractAddressesMethod
 )
 
 var MgmtContractABI = ManagementContract.ManagementContractMetaData.ABI
@@ -4,10 +4,8 @@ import (
 	"encoding/base64"
 	"fmt"
 	"math/big"
-	"strings"
 
 	"github.com/ethereum/go-ethereum"
-	"github.com/ethereum/go-ethereum/accounts/abi"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ten-protocol/go-ten/contracts/generated/ManagementContract"
 	"github.com/ten-protocol/go-ten/contracts/generated/MessageBus"
@@ -38,6 +36,12 @@ type MgmtContractLib interface {
 	GetHostAddressesMsg() (ethereum.CallMsg, error)
 	DecodeHostAddressesResponse(callResponse []byte) ([]string, error)
 
+	GetAttestedMsg(hostID gethcommon.Address) (ethereum.CallMsg, error)
+	DecodeAttestedResponse(callResponse []byte) (bool, error)
+
+	GetHostAddressMsg(hostID gethcommon.Address) (ethereum.CallMsg, error)
+	DecodeHostAddressResponse(callResponse []byte) (string, error)
+
 	SetImportantContractMsg(key string, address gethcommon.Address) (ethereum.CallMsg, error)
 
 	GetImportantContractKeysMsg() (ethereum.CallMsg, error)
@@ -48,21 +52,14 @@ type MgmtContractLib interface {
 }
 
 type contractLibImpl struct {
-	addr        *gethcommon.Address
-	contractABI abi.ABI
-	logger      gethlog.Logger
+	addr   *gethcommon.Address
+	logger gethlog.Logger
 }
 
 func NewMgmtContractLib(addr *gethcommon.Address, logger gethlog.Logger) MgmtContractLib {
-	contractABI, err := abi.JSON(strings.NewReader(MgmtContractABI))
-	if err != nil {
-		panic(err)
-	}
-
 	return &contractLibImpl{
-		addr:        addr,
-		contractABI: contractABI,
-		logger:      logger,
+		addr:   addr,
+		logger: logger,
 	}
 }
 
@@ -74,38 +71,32 @@ func (c *contractLibImpl) DecodeTx(tx *types.Transaction) ethadapter.L1Transacti
 	if tx.To() == nil || tx.To().Hex() != c.addr.Hex() || len(tx.Data()) == 0 {
 		return nil
 	}
-	method, err := c.contractABI.MethodById(tx.Data()[:methodBytesLen])
+	method, err := ManagementContract.ParsedManagementContractABI().MethodById(tx.Data()[:methodBytesLen])
 	if err != nil {
 		panic(err)
 	}
 
-	contractCallData := map[string]interface{}{}
 	switch method.Name {
 	case AddRollupMethod:
-		if err := method.Inputs.UnpackIntoMap(contractCallData, tx.Data()[4:]); err != nil {
+		input, err := ManagementContract.UnpackAddRollupInput(tx.Data())
+		if err != nil {
 			panic(err)
 		}
-		callData, found := contractCallData["_rollupData"]
-		if !found {
-			panic("call data not found for rollupData")
-		}
-		rollup := Base64DecodeFromString(callData.(string))
-
 		return &ethadapter.L1RollupTx{
-			Rollup: rollup,
+			Rollup: Base64DecodeFromString(input.RollupData),
 		}
 
 	case RespondSecretMethod:
-		return c.unpackRespondSecretTx(tx, method, contractCallData)
+		return c.unpackRespondSecretTx(tx)
 
 	case RequestSecretMethod:
-		return c.unpackRequestSecretTx(tx, method, contractCallData)
+		return c.unpackRequestSecretTx(tx)
 
 	case InitializeSecretMethod:
-		return c.unpackInitSecretTx(tx, method, contractCallData)
+		return c.unpackInitSecretTx(tx)
 
 	case SetImportantContractsMethod:
-		tx, err := c.unpackSetImportantContractsTx(tx, method, contractCallData)
+		tx, err := c.unpackSetImportantContractsTx(tx)
 		if err != nil {
 			c.logger.Warn("could not unpack set important contracts tx", log.ErrKey, err)
 			return nil
@@ -134,12 +125,7 @@ func (c *contractLibImpl) CreateRollup(t *ethadapter.L1RollupTx) types.TxData {
 		Messages: convertCrossChainMessages(decodedRollup.Header.CrossChainMessages),
 	}
 
-	data, err := c.contractABI.Pack(
-		AddRollupMethod,
-		metaRollup,
-		encRollupData,
-		crossChain,
-	)
+	data, err := ManagementContract.PackAddRollup(metaRollup, encRollupData, crossChain)
 	if err != nil {
 		panic(err)
 	}
@@ -151,7 +137,7 @@ func (c *contractLibImpl) CreateRollup(t *ethadapter.L1RollupTx) types.TxData {
 }
 
 func (c *contractLibImpl) CreateRequestSecret(tx *ethadapter.L1RequestSecretTx) types.TxData {
-	data, err := c.contractABI.Pack(RequestSecretMethod, base64EncodeToString(tx.Attestation))
+	data, err := ManagementContract.PackRequestNetworkSecret(base64EncodeToString(tx.Attestation))
 	if err != nil {
 		panic(err)
 	}
@@ -163,8 +149,7 @@ func (c *contractLibImpl) CreateRequestSecret(tx *ethadapter.L1RequestSecretTx)
 }
 
 func (c *contractLibImpl) CreateRespondSecret(tx *ethadapter.L1RespondSecretTx, verifyAttester bool) types.TxData {
-	data, err := c.contractABI.Pack(
-		RespondSecretMethod,
+	data, err := ManagementContract.PackRespondNetworkSecret(
 		tx.AttesterID,
 		tx.RequesterID,
 		tx.AttesterSig,
@@ -182,9 +167,8 @@ func (c *contractLibImpl) CreateRespondSecret(tx *ethadapter.L1RespondSecretTx,
 }
 
 func (c *contractLibImpl) CreateInitializeSecret(tx *ethadapter.L1InitializeSecretTx) types.TxData {
-	data, err := c.contractABI.Pack(
-		InitializeSecretMethod,
-		tx.AggregatorID,
+	data, err := ManagementContract.PackInitializeNetworkSecret(
+		*tx.AggregatorID,
 		tx.InitialSecret,
 		tx.HostAddress,
 		base64EncodeToString(tx.Attestation),
@@ -199,7 +183,7 @@ func (c *contractLibImpl) CreateInitializeSecret(tx *ethadapter.L1InitializeSecr
 }
 
 func (c *contractLibImpl) GetHostAddressesMsg() (ethereum.CallMsg, error) {
-	data, err := c.contractABI.Pack(GetHostAddressesMethod)
+	data, err := ManagementContract.PackGetHostAddresses()
 	if err != nil {
 		return ethereum.CallMsg{}, fmt.Errorf("could not pack the call data. Cause: %w", err)
 	}
@@ -207,51 +191,47 @@ func (c *contractLibImpl) GetHostAddressesMsg() (ethereum.CallMsg, error) {
 }
 
 func (c *contractLibImpl) DecodeHostAddressesResponse(callResponse []byte) ([]string, error) {
-	unpackedResponse, err := c.contractABI.Unpack(GetHostAddressesMethod, callResponse)
+	addresses, err := ManagementContract.UnpackGetHostAddressesOutput(callResponse)
 	if err != nil {
 		return nil, fmt.Errorf("could not unpack call response. Cause: %w", err)
 	}
-
-	// We expect the response to be a list containing one element, that element is a list of address strings
-	if len(unpackedResponse) != 1 {
-		return nil, fmt.Errorf("unexpected number of results (%d) returned from call, response: %s", len(unpackedResponse), unpackedResponse)
-	}
-	addresses, ok := unpackedResponse[0].([]string)
-	if !ok {
-		return nil, fmt.Errorf("could not convert element in call response to list of strings")
-	}
-
 	return addresses, nil
 }
 
-func (c *contractLibImpl) GetContractNamesMsg() (ethereum.CallMsg, error) {
-	data, err := c.contractABI.Pack(GetImportantContractKeysMethod)
+func (c *contractLibImpl) GetAttestedMsg(hostID gethcommon.Address) (ethereum.CallMsg, error) {
+	data, err := ManagementContract.PackAttested(hostID)
 	if err != nil {
 		return ethereum.CallMsg{}, fmt.Errorf("could not pack the call data. Cause: %w", err)
 	}
 	return ethereum.CallMsg{To: c.addr, Data: data}, nil
 }
 
-func (c *contractLibImpl) DecodeContractNamesResponse(callResponse []byte) ([]string, error) {
-	unpackedResponse, err := c.contractABI.Unpack(GetImportantContractKeysMethod, callResponse)
+func (c *contractLibImpl) DecodeAttestedResponse(callResponse []byte) (bool, error) {
+	attested, err := ManagementContract.UnpackAttestedOutput(callResponse)
 	if err != nil {
-		return nil, fmt.Errorf("could not unpack call response. Cause: %w", err)
+		return false, fmt.Errorf("could not unpack call response. Cause: %w", err)
 	}
+	return attested, nil
+}
 
-	// We expect the response to be a list containing one element, that element is a list of address strings
-	if len(unpackedResponse) != 1 {
-		return nil, fmt.Errorf("unexpected number of results (%d) returned from call, response: %s", len(unpackedResponse), unpackedResponse)
-	}
-	contractNames, ok := unpackedResponse[0].([]string)
-	if !ok {
-		return nil, fmt.Errorf("could not convert element in call response to list of strings")
+func (c *contractLibImpl) GetHostAddressMsg(hostID gethcommon.Address) (ethereum.CallMsg, error) {
+	data, err := ManagementContract.PackGetHostAddress(hostID)
+	if err != nil {
+		return ethereum.CallMsg{}, fmt.Errorf("could not pack the call data. Cause: %w", err)
 	}
+	return ethereum.CallMsg{To: c.addr, Data: data}, nil
+}
 
-	return contractNames, nil
+func (c *contractLibImpl) DecodeHostAddressResponse(callResponse []byte) (string, error) {
+	address, err := ManagementContract.UnpackGetHostAddressOutput(callResponse)
+	if err != nil {
+		return "", fmt.Errorf("could not unpack call response. Cause: %w", err)
+	}
+	return address, nil
 }
 
 func (c *contractLibImpl) SetImportantContractMsg(key string, address gethcommon.Address) (ethereum.CallMsg, error) {
-	data, err := c.contractABI.Pack(SetImportantContractsMethod, key, address)
+	data, err := ManagementContract.PackSetImportantContractAddress(key, address)
 	if err != nil {
 		return ethereum.CallMsg{}, fmt.Errorf("could not pack the call data. Cause: %w", err)
 	}
@@ -259,7 +239,7 @@ func (c *contractLibImpl) SetImportantContractMsg(key string, address gethcommon
 }
 
 func (c *contractLibImpl) GetImportantContractKeysMsg() (ethereum.CallMsg, error) {
-	data, err := c.contractABI.Pack(GetImportantContractKeysMethod)
+	data, err := ManagementContract.PackGetImportantContractKeys()
 	if err != nil {
 		return ethereum.CallMsg{}, fmt.Errorf("could not pack the call data. Cause: %w", err)
 	}
@@ -267,25 +247,15 @@ func (c *contractLibImpl) GetImportantContractKeysMsg() (ethereum.CallMsg, error
 }
 
 func (c *contractLibImpl) DecodeImportantContractKeysResponse(callResponse []byte) ([]string, error) {
-	unpackedResponse, err := c.contractABI.Unpack(GetImportantContractKeysMethod, callResponse)
+	contractNames, err := ManagementContract.UnpackGetImportantContractKeysOutput(callResponse)
 	if err != nil {
 		return nil, fmt.Errorf("could not unpack call response. Cause: %w", err)
 	}
-
-	// We expect the response to be a list containing one element, that element is a list of address strings
-	if len(unpackedResponse) != 1 {
-		return nil, fmt.Errorf("unexpected number of results (%d) returned from call, response: %s", len(unpackedResponse), unpackedResponse)
-	}
-	contractNames, ok := unpackedResponse[0].([]string)
-	if !ok {
-		return nil, fmt.Errorf("could not convert element in call response to list of strings")
-	}
-
 	return contractNames, nil
 }
 
 func (c *contractLibImpl) GetImportantAddressCallMsg(key string) (ethereum.CallMsg, error) {
-	data, err := c.contractABI.Pack(GetImportantAddressMethod, key)
+	data, err := ManagementContract.PackImportantContractAddresses(key)
 	if err != nil {
 		return ethereum.CallMsg{}, fmt.Errorf("could not pack the call data. Cause: %w", err)
 	}
@@ -293,140 +263,56 @@ func (c *contractLibImpl) GetImportantAddressCallMsg(key string) (ethereum.CallM
 }
 
 func (c *contractLibImpl) DecodeImportantAddressResponse(callResponse []byte) (gethcommon.Address, error) {
-	unpackedResponse, err := c.contractABI.Unpack(GetImportantAddressMethod, callResponse)
+	address, err := ManagementContract.UnpackImportantContractAddressesOutput(callResponse)
 	if err != nil {
 		return gethcommon.Address{}, fmt.Errorf("could not unpack call response. Cause: %w", err)
 	}
-
-	// We expect the response to be a list containing one element, that element is a list of address strings
-	if len(unpackedResponse) != 1 {
-		return gethcommon.Address{}, fmt.Errorf("unexpected number of results (%d) returned from call, response: %s", len(unpackedResponse), unpackedResponse)
-	}
-	address, ok := unpackedResponse[0].(gethcommon.Address)
-	if !ok {
-		return gethcommon.Address{}, fmt.Errorf("could not convert element in call response to list of strings")
-	}
-
 	return address, nil
 }
 
-func (c *contractLibImpl) unpackInitSecretTx(tx *types.Transaction, method *abi.Method, contractCallData map[string]interface{}) *ethadapter.L1InitializeSecretTx {
-	err := method.Inputs.UnpackIntoMap(contractCallData, tx.Data()[methodBytesLen:])
+func (c *contractLibImpl) unpackInitSecretTx(tx *types.Transaction) *ethadapter.L1InitializeSecretTx {
+	input, err := ManagementContract.UnpackInitializeNetworkSecretInput(tx.Data())
 	if err != nil {
 		panic(err)
 	}
-	callData, found := contractCallData["_genesisAttestation"]
-	if !found {
-		panic("call data not found for requestReport")
-	}
-
-	att := Base64DecodeFromString(callData.(string))
-	if err != nil {
-		c.logger.Crit("could not decode genesis attestation request.", log.ErrKey, err)
-	}
 
 	// todo (#1275) - add the other fields
 	return &ethadapter.L1InitializeSecretTx{
-		Attestation: att,
+		Attestation: Base64DecodeFromString(input.GenesisAttestation),
 	}
 }
 
-func (c *contractLibImpl) unpackRequestSecretTx(tx *types.Transaction, method *abi.Method, contractCallData map[string]interface{}) *ethadapter.L1RequestSecretTx {
-	err := method.Inputs.UnpackIntoMap(contractCallData, tx.Data()[methodBytesLen:])
+func (c *contractLibImpl) unpackRequestSecretTx(tx *types.Transaction) *ethadapter.L1RequestSecretTx {
+	input, err := ManagementContract.UnpackRequestNetworkSecretInput(tx.Data())
 	if err != nil {
 		panic(err)
 	}
-	callData, found := contractCallData["requestReport"]
-	if !found {
-		panic("call data not found for requestReport")
-	}
-
-	att := Base64DecodeFromString(callData.(string))
-	if err != nil {
-		c.logger.Crit("could not decode attestation request.", log.ErrKey, err)
-	}
 	return &ethadapter.L1RequestSecretTx{
-		Attestation: att,
+		Attestation: Base64DecodeFromString(input.RequestReport),
 	}
 }
 
-func (c *contractLibImpl) unpackRespondSecretTx(tx *types.Transaction, method *abi.Method, contractCallData map[string]interface{}) *ethadapter.L1RespondSecretTx {
-	err := method.Inputs.UnpackIntoMap(contractCallData, tx.Data()[methodBytesLen:])
+func (c *contractLibImpl) unpackRespondSecretTx(tx *types.Transaction) *ethadapter.L1RespondSecretTx {
+	input, err := ManagementContract.UnpackRespondNetworkSecretInput(tx.Data())
 	if err != nil {
 		c.logger.Crit("could not unpack transaction.", log.ErrKey, err)
 	}
-
-	requesterData, found := contractCallData["requesterID"]
-	if !found {
-		c.logger.Crit("call data not found for requesterID")
-	}
-	requesterAddr, ok := requesterData.(gethcommon.Address)
-	if !ok {
-		c.logger.Crit("could not decode requester data")
-	}
-
-	attesterData, found := contractCallData["attesterID"]
-	if !found {
-		c.logger.Crit("call data not found for attesterID")
-	}
-	attesterAddr, ok := attesterData.(gethcommon.Address)
-	if !ok {
-		c.logger.Crit("could not decode attester data")
-	}
-
-	responseSecretData, found := contractCallData["responseSecret"]
-	if !found {
-		c.logger.Crit("call data not found for responseSecret")
-	}
-	responseSecretBytes, ok := responseSecretData.([]uint8)
-	if !ok {
-		c.logger.Crit("could not decode responseSecret data")
-	}
-
-	hostAddressData, found := contractCallData["hostAddress"]
-	if !found {
-		c.logger.Crit("call data not found for hostAddress")
-	}
-	hostAddressString, ok := hostAddressData.(string)
-	if !ok {
-		c.logger.Crit("could not decode hostAddress data")
-	}
-
 	return &ethadapter.L1RespondSecretTx{
-		AttesterID:  attesterAddr,
-		RequesterID: requesterAddr,
-		Secret:      responseSecretBytes[:],
-		HostAddress: hostAddressString,
+		AttesterID:  input.AttesterID,
+		RequesterID: input.RequesterID,
+		Secret:      input.ResponseSecret,
+		HostAddress: input.HostAddress,
 	}
 }
 
-func (c *contractLibImpl) unpackSetImportantContractsTx(tx *types.Transaction, method *abi.Method, contractCallData map[string]interface{}) (*ethadapter.L1SetImportantContractsTx, error) {
-	err := method.Inputs.UnpackIntoMap(contractCallData, tx.Data()[methodBytesLen:])
+func (c *contractLibImpl) unpackSetImportantContractsTx(tx *types.Transaction) (*ethadapter.L1SetImportantContractsTx, error) {
+	input, err := ManagementContract.UnpackSetImportantContractAddressInput(tx.Data())
 	if err != nil {
 		return nil, fmt.Errorf("could not unpack transaction. Cause: %w", err)
 	}
-
-	keyData, found := contractCallData["key"]
-	if !found {
-		return nil, fmt.Errorf("call data not found for key")
-	}
-	keyString, ok := keyData.(string)
-	if !ok {
-		return nil, fmt.Errorf("could not decode key data")
-	}
-
-	contractAddressData, found := contractCallData["newAddress"]
-	if !found {
-		return nil, fmt.Errorf("call data not found for newAddress")
-	}
-	contractAddress, ok := contractAddressData.(gethcommon.Address)
-	if !ok {
-		return nil, fmt.Errorf("could not decode newAddress data")
-	}
-
 	return &ethadapter.L1SetImportantContractsTx{
-		Key:        keyString,
-		NewAddress: contractAddress,
+		Key:        input.Key,
+		NewAddress: input.NewAddress,
 	}, nil
 }
 
@@ -0,0 +1,344 @@
+package archive
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/host"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/config"
+	"github.com/ten-protocol/go-ten/go/host/db"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+// the archived batches are pruned in chunks, so that a long backlog does not result in one huge DB write
+const _maxBatchesPrunedPerWrite = 1000
+
+// Archiver periodically uploads the rolled-up batches older than the archive age, and the rollups that published them,
+// to an object storage. Each rollup is archived as a segment: the RLP-encoded batches published by the rollup, the
+// rollup blob and a manifest with the hashes of both. Once a batch is archived, the archiver deletes its transaction
+// data from the host DB, and fetches it back from the object storage when the enclave needs it.
+type Archiver struct {
+	db       *db.DB
+	store    ObjectStore
+	age      time.Duration
+	interval time.Duration
+	clock    clock.Clock
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+
+	lastErr      error // the error of the last archiving run, if it failed
+	lastErrMutex sync.RWMutex
+
+	// the batches are requested in order by the enclave catching up, so the last segment fetched is kept in memory
+	cachedSegment      *segment
+	cachedSegmentMutex sync.Mutex
+
+	logger gethlog.Logger
+}
+
+// segment is an archived segment fetched back from the object storage and verified
+type segment struct {
+	record  *db.ArchivedSegment
+	batches []*common.ExtBatch
+}
+
+func NewArchiver(cfg *config.HostConfig, database *db.DB, store ObjectStore, clk clock.Clock, logger gethlog.Logger) *Archiver {
+	return &Archiver{
+		db:       database,
+		store:    store,
+		age:      cfg.ArchiveAge,
+		interval: cfg.ArchiveInterval,
+		clock:    clk,
+		stopCh:   make(chan struct{}),
+		logger:   logger,
+	}
+}
+
+func (a *Archiver) Start() error {
+	a.wg.Add(1)
+	go a.run()
+	return nil
+}
+
+func (a *Archiver) Stop() error {
+	close(a.stopCh)
+	a.wg.Wait()
+	return nil
+}
+
+func (a *Archiver) HealthStatus() host.HealthStatus {
+	a.lastErrMutex.RLock()
+	defer a.lastErrMutex.RUnlock()
+	errMsg := ""
+	if a.lastErr != nil {
+		errMsg = a.lastErr.Error()
+	}
+	return &host.BasicErrHealthStatus{ErrMsg: errMsg}
+}
+
+// FetchBatch returns an archived batch, fetched back from the object storage and checked against the manifest of its
+// segment. It returns errutil.ErrNotFound if the batch was not archived.
+func (a *Archiver) FetchBatch(seqNo *big.Int) (*common.ExtBatch, error) {
+	if !seqNo.IsUint64() {
+		return nil, errutil.ErrNotFound
+	}
+	a.cachedSegmentMutex.Lock()
+	defer a.cachedSegmentMutex.Unlock()
+
+	s := a.cachedSegment
+	if s == nil || seqNo.Uint64() < s.record.FirstBatchSeqNo || seqNo.Uint64() > s.record.LastBatchSeqNo {
+		record, err := a.db.GetArchivedSegment(seqNo.Uint64())
+		if err != nil {
+			return nil, fmt.Errorf("could not find the archived segment of batch %d. Cause: %w", seqNo, err)
+		}
+		s, err = a.fetchSegment(context.Background(), record)
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch the archived segment of batch %d. Cause: %w", seqNo, err)
+		}
+		a.cachedSegment = s
+	}
+	return s.batches[seqNo.Uint64()-s.record.FirstBatchSeqNo], nil
+}
+
+func (a *Archiver) run() {
+	defer a.wg.Done()
+	ticker := a.clock.NewTicker(a.interval)
+	defer ticker.Stop()
+
+	for {
+		a.archive()
+		select {
+		case <-a.stopCh:
+			return
+		case <-ticker.C():
+		}
+	}
+}
+
+// archive uploads all the segments whose last batch is older than the archive age
+func (a *Archiver) archive() {
+	cutoff := a.clock.Now().Add(-a.age)
+	total := 0
+	for {
+		select {
+		case <-a.stopCh:
+			return
+		default:
+		}
+		archived, err := a.archiveNextSegment(context.Background(), cutoff)
+		a.setLastErr(err)
+		if err != nil {
+			a.logger.Error("Could not archive batches", log.ErrKey, err)
+			return
+		}
+		if !archived {
+			break
+		}
+		total++
+	}
+	if total > 0 {
+		a.logger.Info("Archived rolled-up batches older than the archive age", "segments", total, "cutoff", cutoff)
+	}
+	a.pruneArchivedBatches(cutoff)
+}
+
+// pruneArchivedBatches deletes the transaction data of the archived batches older than the cutoff from the host DB, then
+// compacts the DB to reclaim the space
+func (a *Archiver) pruneArchivedBatches(cutoff time.Time) {
+	total := 0
+	for {
+		select {
+		case <-a.stopCh:
+			return
+		default:
+		}
+		pruned, err := a.db.PruneArchivedBatches(cutoff, _maxBatchesPrunedPerWrite)
+		if err != nil {
+			a.setLastErr(err)
+			a.logger.Error("Could not prune the archived batches", log.ErrKey, err)
+			return
+		}
+		total += pruned
+		if pruned < _maxBatchesPrunedPerWrite {
+			break
+		}
+	}
+	if total == 0 {
+		return
+	}
+
+	a.logger.Info("Pruned the archived batches", "count", total, "cutoff", cutoff)
+	if err := a.db.CompactPrunedData(); err != nil {
+		a.logger.Warn("Could not compact the DB after pruning", log.ErrKey, err)
+	}
+}
+
+// archiveNextSegment uploads the batches of the next rollup if they are all older than the cutoff, and returns whether
+// it did
+func (a *Archiver) archiveNextSegment(ctx context.Context, cutoff time.Time) (bool, error) {
+	firstSeqNo, err := a.db.NextSeqNoToArchive()
+	if err != nil {
+		return false, fmt.Errorf("could not retrieve the next batch to archive. Cause: %w", err)
+	}
+	rollup, err := a.db.GetRollupHeaderBySeqNo(firstSeqNo.Uint64())
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			// the next batches are not rolled up yet
+			return false, nil
+		}
+		return false, fmt.Errorf("could not retrieve the rollup of batch %d. Cause: %w", firstSeqNo, err)
+	}
+
+	var batches []*common.ExtBatch
+	for seqNo := firstSeqNo.Uint64(); seqNo <= rollup.LastBatchSeqNo; seqNo++ {
+		batch, err := a.db.GetBatchBySequenceNumber(new(big.Int).SetUint64(seqNo))
+		if err != nil {
+			if errors.Is(err, errutil.ErrNotFound) {
+				// the host has not received this batch yet, the segment is archived once it arrives
+				return false, nil
+			}
+			return false, fmt.Errorf("could not retrieve batch %d. Cause: %w", seqNo, err)
+		}
+		batches = append(batches, batch)
+	}
+	if batches[len(batches)-1].Header.Time >= uint64(cutoff.Unix()) {
+		return false, nil
+	}
+
+	record, rollupHashes, err := a.uploadSegment(ctx, batches, rollup.Hash())
+	if err != nil {
+		return false, err
+	}
+	if err := a.db.AddArchivedSegment(record, rollupHashes); err != nil {
+		return false, fmt.Errorf("could not record the archived segment. Cause: %w", err)
+	}
+	a.logger.Debug("Archived segment", "first", record.FirstBatchSeqNo, "last", record.LastBatchSeqNo, "manifest", record.ManifestKey)
+	return true, nil
+}
+
+// uploadSegment uploads the batches and the rollup blob, if the host kept it, then the manifest
+func (a *Archiver) uploadSegment(ctx context.Context, batches []*common.ExtBatch, rollupHash common.L2RollupHash) (*db.ArchivedSegment, []common.L2RollupHash, error) {
+	first, last := batches[0].Header.SequencerOrderNo.Uint64(), batches[len(batches)-1].Header.SequencerOrderNo.Uint64()
+	prefix := segmentPrefix(first, last)
+	manifest := &Manifest{
+		Version:         _manifestVersion,
+		FirstBatchSeqNo: first,
+		LastBatchSeqNo:  last,
+		RollupHashes:    []gethcommon.Hash{},
+	}
+	for _, batch := range batches {
+		manifest.BatchHashes = append(manifest.BatchHashes, batch.Hash())
+	}
+
+	encodedBatches, err := rlp.EncodeToBytes(batches)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not encode the batches of segment %s. Cause: %w", prefix, err)
+	}
+	objects := map[string][]byte{prefix + "/batches.rlp": encodedBatches}
+	manifest.Objects = append(manifest.Objects, newManifestObject(prefix+"/batches.rlp", batchesObject, encodedBatches))
+
+	blob, err := a.db.GetRollupBlob(rollupHash)
+	switch {
+	case err == nil:
+		key := fmt.Sprintf("%s/rollup-%s.bin", prefix, rollupHash.Hex())
+		objects[key] = blob
+		manifest.Objects = append(manifest.Objects, newManifestObject(key, rollupObject, blob))
+		manifest.RollupHashes = append(manifest.RollupHashes, rollupHash)
+	case errors.Is(err, errutil.ErrNotFound):
+		a.logger.Debug("The rollup blob was not kept, the segment is archived without it", log.RollupHashKey, rollupHash)
+	default:
+		return nil, nil, fmt.Errorf("could not retrieve rollup blob %s. Cause: %w", rollupHash, err)
+	}
+
+	for _, object := range manifest.Objects {
+		if err := a.store.Put(ctx, object.Key, objects[object.Key]); err != nil {
+			return nil, nil, fmt.Errorf("could not upload %s. Cause: %w", object.Key, err)
+		}
+	}
+	encodedManifest, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not encode the manifest of segment %s. Cause: %w", prefix, err)
+	}
+	manifestKey := prefix + "/manifest.json"
+	if err := a.store.Put(ctx, manifestKey, encodedManifest); err != nil {
+		return nil, nil, fmt.Errorf("could not upload %s. Cause: %w", manifestKey, err)
+	}
+
+	record := &db.ArchivedSegment{
+		FirstBatchSeqNo: first,
+		LastBatchSeqNo:  last,
+		ManifestKey:     manifestKey,
+		ManifestHash:    sha256.Sum256(encodedManifest),
+	}
+	return record, manifest.RollupHashes, nil
+}
+
+// fetchSegment downloads the manifest and the batches of the segment, and checks them against the manifest hash
+// recorded in the host DB
+func (a *Archiver) fetchSegment(ctx context.Context, record *db.ArchivedSegment) (*segment, error) {
+	encodedManifest, err := a.store.Get(ctx, record.ManifestKey)
+	if err != nil {
+		return nil, fmt.Errorf("could not download %s. Cause: %w", record.ManifestKey, err)
+	}
+	if hash := gethcommon.Hash(sha256.Sum256(encodedManifest)); hash != record.ManifestHash {
+		return nil, fmt.Errorf("manifest %s has hash %s, expected %s", record.ManifestKey, hash, record.ManifestHash)
+	}
+	var manifest Manifest
+	if err := json.Unmarshal(encodedManifest, &manifest); err != nil {
+		return nil, fmt.Errorf("could not decode manifest %s. Cause: %w", record.ManifestKey, err)
+	}
+
+	object, err := manifest.object(batchesObject)
+	if err != nil {
+		return nil, err
+	}
+	encodedBatches, err := a.store.Get(ctx, object.Key)
+	if err != nil {
+		return nil, fmt.Errorf("could not download %s. Cause: %w", object.Key, err)
+	}
+	if err := object.Verify(encodedBatches); err != nil {
+		return nil, err
+	}
+	var batches []*common.ExtBatch
+	if err := rlp.DecodeBytes(encodedBatches, &batches); err != nil {
+		return nil, fmt.Errorf("could not decode %s. Cause: %w", object.Key, err)
+	}
+
+	if uint64(len(batches)) != record.LastBatchSeqNo-record.FirstBatchSeqNo+1 || len(batches) != len(manifest.BatchHashes) {
+		return nil, fmt.Errorf("segment %s has %d batches, expected %d", record.ManifestKey, len(batches), record.LastBatchSeqNo-record.FirstBatchSeqNo+1)
+	}
+	for i, batch := range batches {
+		if batch.Hash() != manifest.BatchHashes[i] {
+			return nil, fmt.Errorf("batch %d of segment %s has hash %s, expected %s", i, record.ManifestKey, batch.Hash(), manifest.BatchHashes[i])
+		}
+		if batch.Header.SequencerOrderNo.Uint64() != record.FirstBatchSeqNo+uint64(i) {
+			return nil, fmt.Errorf("batch %d of segment %s has seqNo %d", i, record.ManifestKey, batch.Header.SequencerOrderNo)
+		}
+	}
+	return &segment{record: record, batches: batches}, nil
+}
+
+func (a *Archiver) setLastErr(err error) {
+	a.lastErrMutex.Lock()
+	defer a.lastErrMutex.Unlock()
+	a.lastErr = err
+}
+
+// segmentPrefix zero-pads the sequence numbers, so the segments are listed in order in the object storage
+func segmentPrefix(first, last uint64) string {
+	return fmt.Sprintf("segments/%020d-%020d", first, last)
+}
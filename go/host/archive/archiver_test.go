@@ -0,0 +1,151 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/config"
+	"github.com/ten-protocol/go-ten/go/host/db"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+// memoryStore is an in-memory ObjectStore
+type memoryStore struct {
+	lock    sync.Mutex
+	objects map[string][]byte
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{objects: map[string][]byte{}}
+}
+
+func (s *memoryStore) Put(_ context.Context, key string, data []byte) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.objects[key] = append([]byte{}, data...)
+	return nil
+}
+
+func (s *memoryStore) Get(_ context.Context, key string) ([]byte, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	data, ok := s.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("object %s - %w", key, errutil.ErrNotFound)
+	}
+	return data, nil
+}
+
+func TestArchivesRolledUpBatchesOlderThanTheAge(t *testing.T) {
+	now := time.Now()
+	database := db.NewInMemoryDB(nil, nil)
+	for seqNo := int64(1); seqNo <= 6; seqNo++ {
+		created := now.Add(-2 * time.Hour)
+		if seqNo > 4 {
+			created = now
+		}
+		require.NoError(t, database.AddBatch(newTestBatch(seqNo, created)))
+	}
+	rollup := addTestRollup(t, database, 2)
+	require.NoError(t, database.AddRollupBlob(rollup.Hash(), []byte("rollup blob")))
+	addTestRollup(t, database, 4)
+	addTestRollup(t, database, 6)
+
+	store := newMemoryStore()
+	archiver := NewArchiver(&config.HostConfig{ArchiveAge: time.Hour}, database, store, clock.NewFake(now), gethlog.New())
+	archiver.archive()
+
+	// the batches of the last rollup are too recent
+	next, err := database.NextSeqNoToArchive()
+	require.NoError(t, err)
+	require.Equal(t, int64(5), next.Int64())
+	require.True(t, archiver.HealthStatus().OK())
+	require.Len(t, store.objects, 5)
+	require.Contains(t, store.objects, fmt.Sprintf("segments/%020d-%020d/rollup-%s.bin", 1, 2, rollup.Hash().Hex()))
+
+	// the archived rollup blobs are removed from the host DB
+	_, err = database.GetRollupBlob(rollup.Hash())
+	require.ErrorIs(t, err, errutil.ErrNotFound)
+
+	// only the archived batches are pruned
+	for seqNo := int64(1); seqNo <= 5; seqNo++ {
+		pruned, err := database.IsBatchPruned(big.NewInt(seqNo))
+		require.NoError(t, err)
+		require.Equal(t, seqNo <= 4, pruned)
+	}
+
+	for seqNo := int64(1); seqNo <= 4; seqNo++ {
+		batch, err := archiver.FetchBatch(big.NewInt(seqNo))
+		require.NoError(t, err)
+		require.Equal(t, newTestBatch(seqNo, now.Add(-2*time.Hour)).Hash(), batch.Hash())
+	}
+	_, err = archiver.FetchBatch(big.NewInt(5))
+	require.ErrorIs(t, err, errutil.ErrNotFound)
+}
+
+func TestRejectsTamperedArchives(t *testing.T) {
+	now := time.Now()
+	database := db.NewInMemoryDB(nil, nil)
+	for seqNo := int64(1); seqNo <= 2; seqNo++ {
+		require.NoError(t, database.AddBatch(newTestBatch(seqNo, now.Add(-2*time.Hour))))
+	}
+	addTestRollup(t, database, 2)
+
+	store := newMemoryStore()
+	archiver := NewArchiver(&config.HostConfig{ArchiveAge: time.Hour}, database, store, clock.NewFake(now), gethlog.New())
+	archiver.archive()
+
+	batchesKey := fmt.Sprintf("segments/%020d-%020d/batches.rlp", 1, 2)
+	manifestKey := fmt.Sprintf("segments/%020d-%020d/manifest.json", 1, 2)
+	batches, manifest := store.objects[batchesKey], store.objects[manifestKey]
+	require.NotEmpty(t, batches)
+	require.NotEmpty(t, manifest)
+
+	store.objects[batchesKey] = append([]byte{}, batches...)
+	store.objects[batchesKey][len(batches)-1]++
+	_, err := archiver.FetchBatch(big.NewInt(1))
+	require.ErrorContains(t, err, "has hash")
+
+	// the manifest is checked against the hash recorded in the host DB
+	store.objects[batchesKey] = batches
+	store.objects[manifestKey] = append([]byte{}, manifest...)
+	store.objects[manifestKey][len(manifest)-2] = ' '
+	_, err = archiver.FetchBatch(big.NewInt(1))
+	require.ErrorContains(t, err, "has hash")
+
+	store.objects[manifestKey] = manifest
+	batch, err := archiver.FetchBatch(big.NewInt(2))
+	require.NoError(t, err)
+	require.Equal(t, int64(2), batch.Header.SequencerOrderNo.Int64())
+}
+
+func newTestBatch(seqNo int64, created time.Time) *common.ExtBatch {
+	return &common.ExtBatch{
+		Header: &common.BatchHeader{
+			Number:           big.NewInt(seqNo),
+			SequencerOrderNo: big.NewInt(seqNo),
+			Time:             uint64(created.Unix()),
+		},
+		TxHashes:        []gethcommon.Hash{gethcommon.BigToHash(big.NewInt(seqNo))},
+		EncryptedTxBlob: []byte{byte(seqNo)},
+	}
+}
+
+// addTestRollup stores a rollup that includes the batches up to lastBatchSeqNo
+func addTestRollup(t *testing.T, database *db.DB, lastBatchSeqNo uint64) *common.ExtRollup {
+	rollup := &common.ExtRollup{Header: &common.RollupHeader{LastBatchSeqNo: lastBatchSeqNo}}
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(int64(lastBatchSeqNo))})
+	require.NoError(t, database.AddRollupHeader(rollup, block))
+	return rollup
+}
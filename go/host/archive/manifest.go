@@ -0,0 +1,61 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	_manifestVersion = 1
+
+	batchesObject = "batches"
+	rollupObject  = "rollup"
+)
+
+// Manifest describes the objects of an archived segment, so the segment can be verified by anyone reading the object
+// storage. It is uploaded last: a segment without a manifest is incomplete.
+type Manifest struct {
+	Version         int               `json:"version"`
+	FirstBatchSeqNo uint64            `json:"firstBatchSeqNo"`
+	LastBatchSeqNo  uint64            `json:"lastBatchSeqNo"`
+	BatchHashes     []gethcommon.Hash `json:"batchHashes"`
+	// RollupHashes are the hashes of the rollups archived with the segment, as they were published on the L1. It is
+	// empty if the host did not keep the rollup, e.g. because it was processed before the archiving was enabled
+	RollupHashes []gethcommon.Hash `json:"rollupHashes"`
+	Objects      []ManifestObject  `json:"objects"`
+}
+
+// ManifestObject is an object of an archived segment
+type ManifestObject struct {
+	Key    string          `json:"key"`
+	Type   string          `json:"type"` // the RLP-encoded batches of the segment, or a rollup blob
+	Size   int             `json:"size"`
+	SHA256 gethcommon.Hash `json:"sha256"`
+}
+
+func newManifestObject(key string, objectType string, data []byte) ManifestObject {
+	return ManifestObject{Key: key, Type: objectType, Size: len(data), SHA256: sha256.Sum256(data)}
+}
+
+// Verify checks that the data fetched from the object storage is the object described by the manifest
+func (o *ManifestObject) Verify(data []byte) error {
+	if len(data) != o.Size {
+		return fmt.Errorf("object %s has size %d, expected %d", o.Key, len(data), o.Size)
+	}
+	if hash := gethcommon.Hash(sha256.Sum256(data)); hash != o.SHA256 {
+		return fmt.Errorf("object %s has hash %s, expected %s", o.Key, hash, o.SHA256)
+	}
+	return nil
+}
+
+// object returns the first object of the given type
+func (m *Manifest) object(objectType string) (*ManifestObject, error) {
+	for i := range m.Objects {
+		if m.Objects[i].Type == objectType {
+			return &m.Objects[i], nil
+		}
+	}
+	return nil, fmt.Errorf("the manifest has no %s object", objectType)
+}
@@ -0,0 +1,174 @@
+package archive
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+)
+
+const (
+	_s3Service       = "s3"
+	_signAlgorithm   = "AWS4-HMAC-SHA256"
+	_amzDateFormat   = "20060102T150405Z"
+	_amzDayFormat    = "20060102"
+	_contentSHA256   = "X-Amz-Content-Sha256"
+	_amzDate         = "X-Amz-Date"
+	_maxErrorBodyLen = 1024
+	_requestTimeout  = 2 * time.Minute
+)
+
+// S3Config is the configuration of an S3-compatible object storage
+type S3Config struct {
+	// Endpoint is the URL of the storage, e.g. https://s3.eu-west-1.amazonaws.com or https://storage.googleapis.com
+	Endpoint  string
+	Bucket    string
+	Region    string // "auto" for GCS
+	AccessKey string
+	SecretKey string
+}
+
+// S3Store stores the objects in a bucket of an S3-compatible storage. The requests use the path-style URLs and are
+// signed with AWS Signature Version 4, which AWS S3, GCS (with HMAC keys) and MinIO all accept.
+type S3Store struct {
+	endpoint *url.URL
+	config   S3Config
+	client   *http.Client
+}
+
+func NewS3Store(config S3Config) (*S3Store, error) {
+	endpoint, err := url.Parse(config.Endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("invalid object storage endpoint %s - %w", config.Endpoint, err)
+	}
+	if endpoint.Scheme == "" || endpoint.Host == "" {
+		return nil, fmt.Errorf("invalid object storage endpoint %s - the scheme and the host are required", config.Endpoint)
+	}
+	if config.Bucket == "" {
+		return nil, fmt.Errorf("the object storage bucket is required")
+	}
+	return &S3Store{
+		endpoint: endpoint,
+		config:   config,
+		client:   &http.Client{Timeout: _requestTimeout},
+	}, nil
+}
+
+func (s *S3Store) Put(ctx context.Context, key string, data []byte) error {
+	resp, err := s.do(ctx, http.MethodPut, key, data)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return responseError(resp, key)
+	}
+	return nil
+}
+
+func (s *S3Store) Get(ctx context.Context, key string) ([]byte, error) {
+	resp, err := s.do(ctx, http.MethodGet, key, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("object %s - %w", key, errutil.ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, responseError(resp, key)
+	}
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("could not read object %s - %w", key, err)
+	}
+	return data, nil
+}
+
+func (s *S3Store) do(ctx context.Context, method string, key string, body []byte) (*http.Response, error) {
+	objectURL := *s.endpoint
+	objectURL.Path = strings.TrimSuffix(objectURL.Path, "/") + "/" + s.config.Bucket + "/" + key
+	req, err := http.NewRequestWithContext(ctx, method, objectURL.String(), bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("could not create request for object %s - %w", key, err)
+	}
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set(_contentSHA256, hex.EncodeToString(payloadHash[:]))
+	signV4(req, s.config, time.Now())
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request for object %s failed - %w", key, err)
+	}
+	return resp, nil
+}
+
+func responseError(resp *http.Response, key string) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, _maxErrorBodyLen))
+	return fmt.Errorf("object storage returned status %d for object %s - %s", resp.StatusCode, key, strings.TrimSpace(string(body)))
+}
+
+// signV4 adds the AWS Signature Version 4 of the request, signing its host and all its headers. The hash of the payload
+// must already be set in the X-Amz-Content-Sha256 header.
+func signV4(req *http.Request, config S3Config, now time.Time) {
+	now = now.UTC()
+	req.Header.Set(_amzDate, now.Format(_amzDateFormat))
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.Query().Encode(),
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get(_contentSHA256),
+	}, "\n")
+
+	scope := strings.Join([]string{now.Format(_amzDayFormat), config.Region, _s3Service, "aws4_request"}, "/")
+	canonicalRequestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := strings.Join([]string{
+		_signAlgorithm,
+		now.Format(_amzDateFormat),
+		scope,
+		hex.EncodeToString(canonicalRequestHash[:]),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+config.SecretKey), now.Format(_amzDayFormat))
+	signingKey = hmacSHA256(signingKey, config.Region)
+	signingKey = hmacSHA256(signingKey, _s3Service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s,SignedHeaders=%s,Signature=%s",
+		_signAlgorithm, config.AccessKey, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
@@ -0,0 +1,11 @@
+package archive
+
+import "context"
+
+// ObjectStore is the object storage the archived data is uploaded to. It is not trusted: the data fetched back is checked
+// against the hashes of the manifests, which are kept in the host DB.
+type ObjectStore interface {
+	Put(ctx context.Context, key string, data []byte) error
+	// Get returns errutil.ErrNotFound if there is no object with the key
+	Get(ctx context.Context, key string) ([]byte, error)
+}
@@ -4,9 +4,14 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/codec"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/queue"
+	"github.com/ten-protocol/go-ten/go/host/rpc/clientrpc"
 
 	"github.com/naoina/toml"
 
@@ -15,18 +20,33 @@ import (
 	gethcommon "github.com/ethereum/go-ethereum/common"
 )
 
+// HostConfigVersion is the version of the host config schema, which the .toml config files must be written for
+const HostConfigVersion = 1
+
+// HostConfigDocsFile is the generated documentation of the host configuration, kept next to the flags
+const HostConfigDocsFile = "host_config.md"
+
 // HostConfigToml is the structure that a host's .toml config is parsed into.
 type HostConfigToml struct {
+	Version                   int // the version of the host config schema the file was written for
 	IsGenesis                 bool
 	NodeType                  string
 	HasClientRPCHTTP          bool
 	ClientRPCPortHTTP         uint
 	HasClientRPCWebsockets    bool
 	ClientRPCPortWS           uint
+	ClientRPCHTTPModules      []string
+	ClientRPCWSModules        []string
+	ClientRPCIPCPath          string
+	ClientRPCMaxRequestSize   int64
+	ClientRESTPort            uint
 	ClientRPCHost             string
 	EnclaveRPCAddress         string
 	P2PBindAddress            string
 	P2PPublicAddress          string
+	P2PNAT                    string
+	P2PBootstrapDNS           string
+	P2PInsecure               bool
 	L1WebsocketURL            string
 	EnclaveRPCTimeout         int
 	L1RPCTimeout              int
@@ -35,6 +55,10 @@ type HostConfigToml struct {
 	MessageBusAddress         string
 	LogLevel                  int
 	LogPath                   string
+	LogRedactKeys             []string
+	LogRedactHexBytes         int
+	RequestLogSampleRate      float64
+	RequestLogMethodRates     map[string]float64
 	PrivateKeyString          string
 	L1ChainID                 int64
 	ObscuroChainID            int64
@@ -43,61 +67,170 @@ type HostConfigToml struct {
 	SequencerID               string
 	MetricsEnabled            bool
 	MetricsHTTPPort           uint
+	MetricsPath               string
 	UseInMemoryDB             bool
 	LevelDBPath               string
+	DBType                    string
 	DebugNamespaceEnabled     bool
+	SequencerAdminAddress     string
 	BatchInterval             string
 	MaxBatchInterval          string
 	RollupInterval            string
 	IsInboundP2PDisabled      bool
 	L1BlockTime               int
 	MaxRollupSize             int
+	NetworkGenesisPath        string
+	ProbesHTTPPort            uint
+	TerminationGracePeriod    string
+	DrainDelay                string
+	ArchiveAge                string
+	ArchiveInterval           string
+	ArchiveEndpoint           string
+	ArchiveBucket             string
+	ArchiveRegion             string
+	ArchiveAccessKey          string
+	ArchiveSecretKey          string
+	NTPServer                 string
+	ClockSkewWarning          string
+	MaxClockSkew              string
+	IngestionQueueSize        int
+	L1BlockQueuePolicy        string
+	TxQueuePolicy             string
+	QueueSpillDir             string
+	BatchCodec                string
+	EventLogPath              string
+	EventLogFinalityDepth     uint64
+	L1TxMaxRetries            int
+	L1RebidBlocks             int
+	L1GasOracleBlocks         int
+	L1MaxFeePerGas            uint64
+	L1MaxPriorityFeePerGas    uint64
+	L1BlobsAvailable          *bool
+	L1Profile                 string
+	DisableSecretResponses    bool
+	SecretResponseCooldown    string
+	MaxSecretResponsesPerHour int
+	P2PMaxMessageSize         int
+	P2PMaxPeerMsgsPerSecond   int
+	P2PBanThreshold           int
+	P2PBanDuration            string
+	PublicMempoolStats        bool
+	EnclaveRPCMinTimeout      string
+	EnclaveRPCMaxTimeout      string
+	StandbyEnclaveAddresses   []string
+	EnclaveFailoverThreshold  int
 }
 
 // ParseConfig returns a config.HostInputConfig based on either the file identified by the `config` flag, or the flags with
-// specific defaults (if the `config` flag isn't specified).
+// specific defaults (if the `config` flag isn't specified). The flags that are not passed on the command line can be set
+// by their env var (see host_config.md).
 func ParseConfig() (*config.HostInputConfig, error) {
+	return parseConfig(flag.CommandLine, os.Args[1:])
+}
+
+func parseConfig(fs *flag.FlagSet, args []string) (*config.HostInputConfig, error) {
 	cfg := config.DefaultHostParsedConfig()
 	flagUsageMap := getFlagUsageMap()
 
-	configPath := flag.String(configName, "", flagUsageMap[configName])
-	isGenesis := flag.Bool(isGenesisName, cfg.IsGenesis, flagUsageMap[isGenesisName])
-	nodeTypeStr := flag.String(nodeTypeName, cfg.NodeType.String(), flagUsageMap[nodeTypeName])
-	clientRPCPortHTTP := flag.Uint64(clientRPCPortHTTPName, cfg.ClientRPCPortHTTP, flagUsageMap[clientRPCPortHTTPName])
-	clientRPCPortWS := flag.Uint64(clientRPCPortWSName, cfg.ClientRPCPortWS, flagUsageMap[clientRPCPortWSName])
-	clientRPCHost := flag.String(clientRPCHostName, cfg.ClientRPCHost, flagUsageMap[clientRPCHostName])
-	enclaveRPCAddress := flag.String(enclaveRPCAddressName, cfg.EnclaveRPCAddress, flagUsageMap[enclaveRPCAddressName])
-	p2pBindAddress := flag.String(p2pBindAddressName, cfg.P2PBindAddress, flagUsageMap[p2pBindAddressName])
-	p2pPublicAddress := flag.String(p2pPublicAddressName, cfg.P2PPublicAddress, flagUsageMap[p2pPublicAddressName])
-	l1WSURL := flag.String(l1WebsocketURLName, cfg.L1WebsocketURL, flagUsageMap[l1WebsocketURLName])
-	enclaveRPCTimeoutSecs := flag.Uint64(enclaveRPCTimeoutSecsName, uint64(cfg.EnclaveRPCTimeout.Seconds()), flagUsageMap[enclaveRPCTimeoutSecsName])
-	l1RPCTimeoutSecs := flag.Uint64(l1RPCTimeoutSecsName, uint64(cfg.L1RPCTimeout.Seconds()), flagUsageMap[l1RPCTimeoutSecsName])
-	p2pConnectionTimeoutSecs := flag.Uint64(p2pConnectionTimeoutSecsName, uint64(cfg.P2PConnectionTimeout.Seconds()), flagUsageMap[p2pConnectionTimeoutSecsName])
-	managementContractAddress := flag.String(managementContractAddrName, cfg.ManagementContractAddress.Hex(), flagUsageMap[managementContractAddrName])
-	messageBusContractAddress := flag.String(messageBusContractAddrName, cfg.MessageBusAddress.Hex(), flagUsageMap[messageBusContractAddrName])
-	logLevel := flag.Int(logLevelName, cfg.LogLevel, flagUsageMap[logLevelName])
-	logPath := flag.String(logPathName, cfg.LogPath, flagUsageMap[logPathName])
-	l1ChainID := flag.Int64(l1ChainIDName, cfg.L1ChainID, flagUsageMap[l1ChainIDName])
-	obscuroChainID := flag.Int64(obscuroChainIDName, cfg.ObscuroChainID, flagUsageMap[obscuroChainIDName])
-	privateKeyStr := flag.String(privateKeyName, cfg.PrivateKeyString, flagUsageMap[privateKeyName])
-	profilerEnabled := flag.Bool(profilerEnabledName, cfg.ProfilerEnabled, flagUsageMap[profilerEnabledName])
-	l1StartHash := flag.String(l1StartHashName, cfg.L1StartHash.Hex(), flagUsageMap[l1StartHashName])
-	sequencerID := flag.String(sequencerIDName, cfg.SequencerID.Hex(), flagUsageMap[sequencerIDName])
-	metricsEnabled := flag.Bool(metricsEnabledName, cfg.MetricsEnabled, flagUsageMap[metricsEnabledName])
-	metricsHTPPPort := flag.Uint(metricsHTTPPortName, cfg.MetricsHTTPPort, flagUsageMap[metricsHTTPPortName])
-	useInMemoryDB := flag.Bool(useInMemoryDBName, cfg.UseInMemoryDB, flagUsageMap[useInMemoryDBName])
-	levelDBPath := flag.String(levelDBPathName, cfg.LevelDBPath, flagUsageMap[levelDBPathName])
-	debugNamespaceEnabled := flag.Bool(debugNamespaceEnabledName, cfg.DebugNamespaceEnabled, flagUsageMap[debugNamespaceEnabledName])
-	batchInterval := flag.String(batchIntervalName, cfg.BatchInterval.String(), flagUsageMap[batchIntervalName])
-	maxBatchInterval := flag.String(maxBatchIntervalName, cfg.MaxBatchInterval.String(), flagUsageMap[maxBatchIntervalName])
-	rollupInterval := flag.String(rollupIntervalName, cfg.RollupInterval.String(), flagUsageMap[rollupIntervalName])
-	isInboundP2PDisabled := flag.Bool(isInboundP2PDisabledName, cfg.IsInboundP2PDisabled, flagUsageMap[isInboundP2PDisabledName])
-	maxRollupSize := flag.Uint64(maxRollupSizeFlagName, cfg.MaxRollupSize, flagUsageMap[maxRollupSizeFlagName])
-
-	flag.Parse()
+	configPath := fs.String(configName, "", flagUsageMap[configName])
+	isGenesis := fs.Bool(isGenesisName, cfg.IsGenesis, flagUsageMap[isGenesisName])
+	nodeTypeStr := fs.String(nodeTypeName, cfg.NodeType.String(), flagUsageMap[nodeTypeName])
+	clientRPCPortHTTP := fs.Uint64(clientRPCPortHTTPName, cfg.ClientRPCPortHTTP, flagUsageMap[clientRPCPortHTTPName])
+	clientRPCPortWS := fs.Uint64(clientRPCPortWSName, cfg.ClientRPCPortWS, flagUsageMap[clientRPCPortWSName])
+	clientRPCHTTPModules := fs.String(clientRPCHTTPModulesName, strings.Join(cfg.ClientRPCHTTPModules, ","), flagUsageMap[clientRPCHTTPModulesName])
+	clientRPCWSModules := fs.String(clientRPCWSModulesName, strings.Join(cfg.ClientRPCWSModules, ","), flagUsageMap[clientRPCWSModulesName])
+	clientRPCIPCPath := fs.String(clientRPCIPCPathName, cfg.ClientRPCIPCPath, flagUsageMap[clientRPCIPCPathName])
+	clientRPCMaxRequestSize := fs.Int64(clientRPCMaxRequestSizeName, cfg.ClientRPCMaxRequestSize, flagUsageMap[clientRPCMaxRequestSizeName])
+	clientRESTPort := fs.Uint64(clientRESTPortName, cfg.ClientRESTPort, flagUsageMap[clientRESTPortName])
+	clientRPCHost := fs.String(clientRPCHostName, cfg.ClientRPCHost, flagUsageMap[clientRPCHostName])
+	enclaveRPCAddress := fs.String(enclaveRPCAddressName, cfg.EnclaveRPCAddress, flagUsageMap[enclaveRPCAddressName])
+	p2pBindAddress := fs.String(p2pBindAddressName, cfg.P2PBindAddress, flagUsageMap[p2pBindAddressName])
+	p2pPublicAddress := fs.String(p2pPublicAddressName, cfg.P2PPublicAddress, flagUsageMap[p2pPublicAddressName])
+	p2pNAT := fs.String(p2pNATName, cfg.P2PNAT, flagUsageMap[p2pNATName])
+	p2pBootstrapDNS := fs.String(p2pBootstrapDNSName, cfg.P2PBootstrapDNS, flagUsageMap[p2pBootstrapDNSName])
+	p2pInsecure := fs.Bool(p2pInsecureName, cfg.P2PInsecure, flagUsageMap[p2pInsecureName])
+	l1WSURL := fs.String(l1WebsocketURLName, cfg.L1WebsocketURL, flagUsageMap[l1WebsocketURLName])
+	enclaveRPCTimeoutSecs := fs.Uint64(enclaveRPCTimeoutSecsName, uint64(cfg.EnclaveRPCTimeout.Seconds()), flagUsageMap[enclaveRPCTimeoutSecsName])
+	l1RPCTimeoutSecs := fs.Uint64(l1RPCTimeoutSecsName, uint64(cfg.L1RPCTimeout.Seconds()), flagUsageMap[l1RPCTimeoutSecsName])
+	p2pConnectionTimeoutSecs := fs.Uint64(p2pConnectionTimeoutSecsName, uint64(cfg.P2PConnectionTimeout.Seconds()), flagUsageMap[p2pConnectionTimeoutSecsName])
+	managementContractAddress := fs.String(managementContractAddrName, cfg.ManagementContractAddress.Hex(), flagUsageMap[managementContractAddrName])
+	messageBusContractAddress := fs.String(messageBusContractAddrName, cfg.MessageBusAddress.Hex(), flagUsageMap[messageBusContractAddrName])
+	logLevel := fs.Int(logLevelName, cfg.LogLevel, flagUsageMap[logLevelName])
+	logPath := fs.String(logPathName, cfg.LogPath, flagUsageMap[logPathName])
+	logRedactKeys := fs.String(logRedactKeysName, strings.Join(cfg.LogRedactKeys, ","), flagUsageMap[logRedactKeysName])
+	logRedactHexBytes := fs.Int(logRedactHexBytesName, cfg.LogRedactHexBytes, flagUsageMap[logRedactHexBytesName])
+	requestLogSampleRate := fs.Float64(requestLogSampleRateName, cfg.RequestLogSampleRate, flagUsageMap[requestLogSampleRateName])
+	requestLogMethodRates := fs.String(requestLogMethodRatesName, clientrpc.FormatMethodRates(cfg.RequestLogMethodRates), flagUsageMap[requestLogMethodRatesName])
+	l1ChainID := fs.Int64(l1ChainIDName, cfg.L1ChainID, flagUsageMap[l1ChainIDName])
+	obscuroChainID := fs.Int64(obscuroChainIDName, cfg.ObscuroChainID, flagUsageMap[obscuroChainIDName])
+	privateKeyStr := fs.String(privateKeyName, cfg.PrivateKeyString, flagUsageMap[privateKeyName])
+	profilerEnabled := fs.Bool(profilerEnabledName, cfg.ProfilerEnabled, flagUsageMap[profilerEnabledName])
+	l1StartHash := fs.String(l1StartHashName, cfg.L1StartHash.Hex(), flagUsageMap[l1StartHashName])
+	sequencerID := fs.String(sequencerIDName, cfg.SequencerID.Hex(), flagUsageMap[sequencerIDName])
+	metricsEnabled := fs.Bool(metricsEnabledName, cfg.MetricsEnabled, flagUsageMap[metricsEnabledName])
+	metricsHTPPPort := fs.Uint(metricsHTTPPortName, cfg.MetricsHTTPPort, flagUsageMap[metricsHTTPPortName])
+	metricsPath := fs.String(metricsPathName, cfg.MetricsPath, flagUsageMap[metricsPathName])
+	useInMemoryDB := fs.Bool(useInMemoryDBName, cfg.UseInMemoryDB, flagUsageMap[useInMemoryDBName])
+	levelDBPath := fs.String(levelDBPathName, cfg.LevelDBPath, flagUsageMap[levelDBPathName])
+	dbType := fs.String(dbTypeName, cfg.DBType, flagUsageMap[dbTypeName])
+	debugNamespaceEnabled := fs.Bool(debugNamespaceEnabledName, cfg.DebugNamespaceEnabled, flagUsageMap[debugNamespaceEnabledName])
+	sequencerAdminAddress := fs.String(sequencerAdminAddressName, cfg.SequencerAdminAddress.Hex(), flagUsageMap[sequencerAdminAddressName])
+	batchInterval := fs.String(batchIntervalName, cfg.BatchInterval.String(), flagUsageMap[batchIntervalName])
+	maxBatchInterval := fs.String(maxBatchIntervalName, cfg.MaxBatchInterval.String(), flagUsageMap[maxBatchIntervalName])
+	rollupInterval := fs.String(rollupIntervalName, cfg.RollupInterval.String(), flagUsageMap[rollupIntervalName])
+	isInboundP2PDisabled := fs.Bool(isInboundP2PDisabledName, cfg.IsInboundP2PDisabled, flagUsageMap[isInboundP2PDisabledName])
+	maxRollupSize := fs.Uint64(maxRollupSizeFlagName, cfg.MaxRollupSize, flagUsageMap[maxRollupSizeFlagName])
+	networkGenesisPath := fs.String(networkGenesisPathName, cfg.NetworkGenesisPath, flagUsageMap[networkGenesisPathName])
+	probesHTTPPort := fs.Uint(probesHTTPPortName, cfg.ProbesHTTPPort, flagUsageMap[probesHTTPPortName])
+	terminationGracePeriod := fs.String(terminationGracePeriodName, cfg.TerminationGracePeriod.String(), flagUsageMap[terminationGracePeriodName])
+	drainDelay := fs.String(drainDelayName, cfg.DrainDelay.String(), flagUsageMap[drainDelayName])
+	archiveAge := fs.String(archiveAgeName, cfg.ArchiveAge.String(), flagUsageMap[archiveAgeName])
+	archiveInterval := fs.String(archiveIntervalName, cfg.ArchiveInterval.String(), flagUsageMap[archiveIntervalName])
+	archiveEndpoint := fs.String(archiveEndpointName, cfg.ArchiveEndpoint, flagUsageMap[archiveEndpointName])
+	archiveBucket := fs.String(archiveBucketName, cfg.ArchiveBucket, flagUsageMap[archiveBucketName])
+	archiveRegion := fs.String(archiveRegionName, cfg.ArchiveRegion, flagUsageMap[archiveRegionName])
+	archiveAccessKey := fs.String(archiveAccessKeyName, cfg.ArchiveAccessKey, flagUsageMap[archiveAccessKeyName])
+	archiveSecretKey := fs.String(archiveSecretKeyName, cfg.ArchiveSecretKey, flagUsageMap[archiveSecretKeyName])
+	ntpServer := fs.String(ntpServerName, cfg.NTPServer, flagUsageMap[ntpServerName])
+	clockSkewWarning := fs.String(clockSkewWarningName, cfg.ClockSkewWarning.String(), flagUsageMap[clockSkewWarningName])
+	maxClockSkew := fs.String(maxClockSkewName, cfg.MaxClockSkew.String(), flagUsageMap[maxClockSkewName])
+	ingestionQueueSize := fs.Int(ingestionQueueSizeName, cfg.IngestionQueueSize, flagUsageMap[ingestionQueueSizeName])
+	l1BlockQueuePolicy := fs.String(l1BlockQueuePolicyName, cfg.L1BlockQueuePolicy.String(), flagUsageMap[l1BlockQueuePolicyName])
+	txQueuePolicy := fs.String(txQueuePolicyName, cfg.TxQueuePolicy.String(), flagUsageMap[txQueuePolicyName])
+	queueSpillDir := fs.String(queueSpillDirName, cfg.QueueSpillDir, flagUsageMap[queueSpillDirName])
+	batchCodec := fs.String(batchCodecName, cfg.BatchCodec.String(), flagUsageMap[batchCodecName])
+	eventLogPath := fs.String(eventLogPathName, cfg.EventLogPath, flagUsageMap[eventLogPathName])
+	eventLogFinalityDepth := fs.Uint64(eventLogFinalityDepthName, cfg.EventLogFinalityDepth, flagUsageMap[eventLogFinalityDepthName])
+	l1TxMaxRetries := fs.Int(l1TxMaxRetriesName, cfg.L1TxMaxRetries, flagUsageMap[l1TxMaxRetriesName])
+	l1RebidBlocks := fs.Int(l1RebidBlocksName, cfg.L1RebidBlocks, flagUsageMap[l1RebidBlocksName])
+	l1GasOracleBlocks := fs.Int(l1GasOracleBlocksName, cfg.L1GasOracleBlocks, flagUsageMap[l1GasOracleBlocksName])
+	l1MaxFeePerGas := fs.Uint64(l1MaxFeePerGasName, cfg.L1MaxFeePerGas, flagUsageMap[l1MaxFeePerGasName])
+	l1MaxPriorityFeePerGas := fs.Uint64(l1MaxPriorityFeePerGasName, cfg.L1MaxPriorityFeePerGas, flagUsageMap[l1MaxPriorityFeePerGasName])
+	l1BlobsAvailable := fs.Bool(l1BlobsAvailableName, cfg.L1BlobsAvailable, flagUsageMap[l1BlobsAvailableName])
+	l1Profile := fs.String(l1ProfileName, cfg.L1Profile, flagUsageMap[l1ProfileName])
+	disableSecretResponses := fs.Bool(disableSecretResponsesName, cfg.DisableSecretResponses, flagUsageMap[disableSecretResponsesName])
+	secretResponseCooldown := fs.String(secretResponseCooldownName, cfg.SecretResponseCooldown.String(), flagUsageMap[secretResponseCooldownName])
+	maxSecretResponsesPerHour := fs.Int(maxSecretResponsesPerHourName, cfg.MaxSecretResponsesPerHour, flagUsageMap[maxSecretResponsesPerHourName])
+	p2pMaxMessageSize := fs.Int(p2pMaxMessageSizeName, cfg.P2PMaxMessageSize, flagUsageMap[p2pMaxMessageSizeName])
+	p2pMaxPeerMsgsPerSecond := fs.Int(p2pMaxPeerMsgsPerSecondName, cfg.P2PMaxPeerMsgsPerSecond, flagUsageMap[p2pMaxPeerMsgsPerSecondName])
+	p2pBanThreshold := fs.Int(p2pBanThresholdName, cfg.P2PBanThreshold, flagUsageMap[p2pBanThresholdName])
+	p2pBanDuration := fs.String(p2pBanDurationName, cfg.P2PBanDuration.String(), flagUsageMap[p2pBanDurationName])
+	publicMempoolStats := fs.Bool(publicMempoolStatsName, cfg.PublicMempoolStats, flagUsageMap[publicMempoolStatsName])
+	enclaveRPCMinTimeout := fs.String(enclaveRPCMinTimeoutName, cfg.EnclaveRPCMinTimeout.String(), flagUsageMap[enclaveRPCMinTimeoutName])
+	enclaveRPCMaxTimeout := fs.String(enclaveRPCMaxTimeoutName, cfg.EnclaveRPCMaxTimeout.String(), flagUsageMap[enclaveRPCMaxTimeoutName])
+	standbyEnclaveAddresses := fs.String(standbyEnclaveAddressesName, strings.Join(cfg.StandbyEnclaveAddresses, ","), flagUsageMap[standbyEnclaveAddressesName])
+	enclaveFailoverThreshold := fs.Int(enclaveFailoverThresholdName, cfg.EnclaveFailoverThreshold, flagUsageMap[enclaveFailoverThresholdName])
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	schema := newHostSchema(fs)
+	if err := schema.BindEnv(fs); err != nil {
+		return nil, err
+	}
 
 	if *configPath != "" {
-		return fileBasedConfig(*configPath)
+		return fileBasedConfig(*configPath, schema)
 	}
 
 	nodeType, err := common.ToNodeType(*nodeTypeStr)
@@ -111,10 +244,18 @@ func ParseConfig() (*config.HostInputConfig, error) {
 	cfg.ClientRPCPortHTTP = *clientRPCPortHTTP
 	cfg.HasClientRPCWebsockets = true
 	cfg.ClientRPCPortWS = *clientRPCPortWS
+	cfg.ClientRPCHTTPModules = log.ParseKeys(*clientRPCHTTPModules)
+	cfg.ClientRPCWSModules = log.ParseKeys(*clientRPCWSModules)
+	cfg.ClientRPCIPCPath = *clientRPCIPCPath
+	cfg.ClientRPCMaxRequestSize = *clientRPCMaxRequestSize
+	cfg.ClientRESTPort = *clientRESTPort
 	cfg.ClientRPCHost = *clientRPCHost
 	cfg.EnclaveRPCAddress = *enclaveRPCAddress
 	cfg.P2PBindAddress = *p2pBindAddress
 	cfg.P2PPublicAddress = *p2pPublicAddress
+	cfg.P2PNAT = *p2pNAT
+	cfg.P2PBootstrapDNS = *p2pBootstrapDNS
+	cfg.P2PInsecure = *p2pInsecure
 	cfg.L1WebsocketURL = *l1WSURL
 	cfg.EnclaveRPCTimeout = time.Duration(*enclaveRPCTimeoutSecs) * time.Second
 	cfg.L1RPCTimeout = time.Duration(*l1RPCTimeoutSecs) * time.Second
@@ -124,6 +265,13 @@ func ParseConfig() (*config.HostInputConfig, error) {
 	cfg.PrivateKeyString = *privateKeyStr
 	cfg.LogLevel = *logLevel
 	cfg.LogPath = *logPath
+	cfg.LogRedactKeys = log.ParseKeys(*logRedactKeys)
+	cfg.LogRedactHexBytes = *logRedactHexBytes
+	cfg.RequestLogSampleRate = *requestLogSampleRate
+	cfg.RequestLogMethodRates, err = clientrpc.ParseMethodRates(*requestLogMethodRates)
+	if err != nil {
+		return nil, err
+	}
 	cfg.L1ChainID = *l1ChainID
 	cfg.ObscuroChainID = *obscuroChainID
 	cfg.ProfilerEnabled = *profilerEnabled
@@ -131,9 +279,12 @@ func ParseConfig() (*config.HostInputConfig, error) {
 	cfg.SequencerID = gethcommon.HexToAddress(*sequencerID)
 	cfg.MetricsEnabled = *metricsEnabled
 	cfg.MetricsHTTPPort = *metricsHTPPPort
+	cfg.MetricsPath = *metricsPath
 	cfg.UseInMemoryDB = *useInMemoryDB
 	cfg.LevelDBPath = *levelDBPath
+	cfg.DBType = *dbType
 	cfg.DebugNamespaceEnabled = *debugNamespaceEnabled
+	cfg.SequencerAdminAddress = gethcommon.HexToAddress(*sequencerAdminAddress)
 	cfg.BatchInterval, err = time.ParseDuration(*batchInterval)
 	if err != nil {
 		return nil, err
@@ -148,21 +299,104 @@ func ParseConfig() (*config.HostInputConfig, error) {
 	}
 	cfg.IsInboundP2PDisabled = *isInboundP2PDisabled
 	cfg.MaxRollupSize = *maxRollupSize
+	cfg.NetworkGenesisPath = *networkGenesisPath
+	cfg.ProbesHTTPPort = *probesHTTPPort
+	cfg.TerminationGracePeriod, err = time.ParseDuration(*terminationGracePeriod)
+	if err != nil {
+		return nil, err
+	}
+	cfg.DrainDelay, err = time.ParseDuration(*drainDelay)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ArchiveAge, err = time.ParseDuration(*archiveAge)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ArchiveInterval, err = time.ParseDuration(*archiveInterval)
+	if err != nil {
+		return nil, err
+	}
+	cfg.ArchiveEndpoint = *archiveEndpoint
+	cfg.ArchiveBucket = *archiveBucket
+	cfg.ArchiveRegion = *archiveRegion
+	cfg.ArchiveAccessKey = *archiveAccessKey
+	cfg.ArchiveSecretKey = *archiveSecretKey
+	cfg.NTPServer = *ntpServer
+	cfg.ClockSkewWarning, err = time.ParseDuration(*clockSkewWarning)
+	if err != nil {
+		return nil, err
+	}
+	cfg.MaxClockSkew, err = time.ParseDuration(*maxClockSkew)
+	if err != nil {
+		return nil, err
+	}
+	cfg.IngestionQueueSize = *ingestionQueueSize
+	cfg.L1BlockQueuePolicy, err = queue.ToOverflowPolicy(*l1BlockQueuePolicy)
+	if err != nil {
+		return nil, err
+	}
+	cfg.TxQueuePolicy, err = queue.ToOverflowPolicy(*txQueuePolicy)
+	if err != nil {
+		return nil, err
+	}
+	cfg.QueueSpillDir = *queueSpillDir
+	cfg.BatchCodec, err = codec.ToType(*batchCodec)
+	if err != nil {
+		return nil, err
+	}
+	cfg.EventLogPath = *eventLogPath
+	cfg.EventLogFinalityDepth = *eventLogFinalityDepth
+	cfg.L1TxMaxRetries = *l1TxMaxRetries
+	cfg.L1RebidBlocks = *l1RebidBlocks
+	cfg.L1GasOracleBlocks = *l1GasOracleBlocks
+	cfg.L1MaxFeePerGas = *l1MaxFeePerGas
+	cfg.L1MaxPriorityFeePerGas = *l1MaxPriorityFeePerGas
+	cfg.L1BlobsAvailable = *l1BlobsAvailable
+	cfg.L1Profile = *l1Profile
+	cfg.DisableSecretResponses = *disableSecretResponses
+	cfg.SecretResponseCooldown, err = time.ParseDuration(*secretResponseCooldown)
+	if err != nil {
+		return nil, err
+	}
+	cfg.MaxSecretResponsesPerHour = *maxSecretResponsesPerHour
+	cfg.P2PMaxMessageSize = *p2pMaxMessageSize
+	cfg.P2PMaxPeerMsgsPerSecond = *p2pMaxPeerMsgsPerSecond
+	cfg.P2PBanThreshold = *p2pBanThreshold
+	cfg.P2PBanDuration, err = time.ParseDuration(*p2pBanDuration)
+	if err != nil {
+		return nil, err
+	}
+	cfg.PublicMempoolStats = *publicMempoolStats
+	cfg.EnclaveRPCMinTimeout, err = time.ParseDuration(*enclaveRPCMinTimeout)
+	if err != nil {
+		return nil, err
+	}
+	cfg.EnclaveRPCMaxTimeout, err = time.ParseDuration(*enclaveRPCMaxTimeout)
+	if err != nil {
+		return nil, err
+	}
+	cfg.StandbyEnclaveAddresses = log.ParseKeys(*standbyEnclaveAddresses)
+	cfg.EnclaveFailoverThreshold = *enclaveFailoverThreshold
 
 	return cfg, nil
 }
 
-// Parses the config from the .toml file at configPath.
-func fileBasedConfig(configPath string) (*config.HostInputConfig, error) {
+// Parses the config from the .toml file at configPath. The values left empty take their default value, and the invalid
+// ones are rejected.
+func fileBasedConfig(configPath string, schema *config.Schema) (*config.HostInputConfig, error) {
 	bytes, err := os.ReadFile(configPath)
 	if err != nil {
-		panic(fmt.Sprintf("could not read config file at %s. Cause: %s", configPath, err))
+		return nil, fmt.Errorf("could not read config file at %s - %w", configPath, err)
 	}
 
 	var tomlConfig HostConfigToml
 	err = toml.Unmarshal(bytes, &tomlConfig)
 	if err != nil {
-		panic(fmt.Sprintf("could not read config file at %s. Cause: %s", configPath, err))
+		return nil, fmt.Errorf("could not parse config file at %s - %w", configPath, err)
+	}
+	if err := schema.CheckVersion(tomlConfig.Version); err != nil {
+		return nil, fmt.Errorf("config file at %s - %w", configPath, err)
 	}
 
 	nodeType, err := common.ToNodeType(tomlConfig.NodeType)
@@ -170,15 +404,127 @@ func fileBasedConfig(configPath string) (*config.HostInputConfig, error) {
 		return &config.HostInputConfig{}, fmt.Errorf("unrecognised node type '%s'", tomlConfig.NodeType)
 	}
 
-	batchInterval, maxBatchInterval, rollupInterval := 1*time.Second, 1*time.Second, 5*time.Second
-	if interval, err := time.ParseDuration(tomlConfig.BatchInterval); err == nil {
-		batchInterval = interval
+	defaults := config.DefaultHostParsedConfig()
+	batchInterval, err := parseTomlDuration(batchIntervalName, tomlConfig.BatchInterval, 1*time.Second)
+	if err != nil {
+		return nil, err
 	}
-	if interval, err := time.ParseDuration(tomlConfig.RollupInterval); err == nil {
-		rollupInterval = interval
+	rollupInterval, err := parseTomlDuration(rollupIntervalName, tomlConfig.RollupInterval, 5*time.Second)
+	if err != nil {
+		return nil, err
 	}
-	if interval, err := time.ParseDuration(tomlConfig.MaxBatchInterval); err == nil {
-		maxBatchInterval = interval
+	maxBatchInterval, err := parseTomlDuration(maxBatchIntervalName, tomlConfig.MaxBatchInterval, 1*time.Second)
+	if err != nil {
+		return nil, err
+	}
+	terminationGracePeriod, err := parseTomlDuration(terminationGracePeriodName, tomlConfig.TerminationGracePeriod, defaults.TerminationGracePeriod)
+	if err != nil {
+		return nil, err
+	}
+	drainDelay, err := parseTomlDuration(drainDelayName, tomlConfig.DrainDelay, defaults.DrainDelay)
+	if err != nil {
+		return nil, err
+	}
+	archiveAge, err := parseTomlDuration(archiveAgeName, tomlConfig.ArchiveAge, 0)
+	if err != nil {
+		return nil, err
+	}
+	archiveInterval, err := parseTomlDuration(archiveIntervalName, tomlConfig.ArchiveInterval, defaults.ArchiveInterval)
+	if err != nil {
+		return nil, err
+	}
+	archiveRegion := defaults.ArchiveRegion
+	if tomlConfig.ArchiveRegion != "" {
+		archiveRegion = tomlConfig.ArchiveRegion
+	}
+	clockSkewWarning, err := parseTomlDuration(clockSkewWarningName, tomlConfig.ClockSkewWarning, defaults.ClockSkewWarning)
+	if err != nil {
+		return nil, err
+	}
+	maxClockSkew, err := parseTomlDuration(maxClockSkewName, tomlConfig.MaxClockSkew, defaults.MaxClockSkew)
+	if err != nil {
+		return nil, err
+	}
+	ingestionQueueSize := defaults.IngestionQueueSize
+	if tomlConfig.IngestionQueueSize > 0 {
+		ingestionQueueSize = tomlConfig.IngestionQueueSize
+	}
+	l1BlockQueuePolicy, txQueuePolicy := defaults.L1BlockQueuePolicy, defaults.TxQueuePolicy
+	if tomlConfig.L1BlockQueuePolicy != "" {
+		if l1BlockQueuePolicy, err = queue.ToOverflowPolicy(tomlConfig.L1BlockQueuePolicy); err != nil {
+			return nil, fmt.Errorf("invalid %s - %w", l1BlockQueuePolicyName, err)
+		}
+	}
+	if tomlConfig.TxQueuePolicy != "" {
+		if txQueuePolicy, err = queue.ToOverflowPolicy(tomlConfig.TxQueuePolicy); err != nil {
+			return nil, fmt.Errorf("invalid %s - %w", txQueuePolicyName, err)
+		}
+	}
+	batchCodec := defaults.BatchCodec
+	if tomlConfig.BatchCodec != "" {
+		if batchCodec, err = codec.ToType(tomlConfig.BatchCodec); err != nil {
+			return nil, fmt.Errorf("invalid %s - %w", batchCodecName, err)
+		}
+	}
+	logRedactHexBytes := defaults.LogRedactHexBytes
+	if tomlConfig.LogRedactHexBytes > 0 {
+		logRedactHexBytes = tomlConfig.LogRedactHexBytes
+	}
+	eventLogFinalityDepth := defaults.EventLogFinalityDepth
+	if tomlConfig.EventLogFinalityDepth > 0 {
+		eventLogFinalityDepth = tomlConfig.EventLogFinalityDepth
+	}
+	l1TxMaxRetries := defaults.L1TxMaxRetries
+	if tomlConfig.L1TxMaxRetries > 0 {
+		l1TxMaxRetries = tomlConfig.L1TxMaxRetries
+	}
+	l1RebidBlocks := defaults.L1RebidBlocks
+	if tomlConfig.L1RebidBlocks > 0 {
+		l1RebidBlocks = tomlConfig.L1RebidBlocks
+	}
+	l1GasOracleBlocks := defaults.L1GasOracleBlocks
+	if tomlConfig.L1GasOracleBlocks > 0 {
+		l1GasOracleBlocks = tomlConfig.L1GasOracleBlocks
+	}
+	secretResponseCooldown, err := parseTomlDuration(secretResponseCooldownName, tomlConfig.SecretResponseCooldown, defaults.SecretResponseCooldown)
+	if err != nil {
+		return nil, err
+	}
+	maxSecretResponsesPerHour := defaults.MaxSecretResponsesPerHour
+	if tomlConfig.MaxSecretResponsesPerHour > 0 {
+		maxSecretResponsesPerHour = tomlConfig.MaxSecretResponsesPerHour
+	}
+	p2pMaxMessageSize := defaults.P2PMaxMessageSize
+	if tomlConfig.P2PMaxMessageSize > 0 {
+		p2pMaxMessageSize = tomlConfig.P2PMaxMessageSize
+	}
+	p2pMaxPeerMsgsPerSecond := defaults.P2PMaxPeerMsgsPerSecond
+	if tomlConfig.P2PMaxPeerMsgsPerSecond > 0 {
+		p2pMaxPeerMsgsPerSecond = tomlConfig.P2PMaxPeerMsgsPerSecond
+	}
+	p2pBanThreshold := defaults.P2PBanThreshold
+	if tomlConfig.P2PBanThreshold > 0 {
+		p2pBanThreshold = tomlConfig.P2PBanThreshold
+	}
+	enclaveFailoverThreshold := defaults.EnclaveFailoverThreshold
+	if tomlConfig.EnclaveFailoverThreshold > 0 {
+		enclaveFailoverThreshold = tomlConfig.EnclaveFailoverThreshold
+	}
+	p2pBanDuration, err := parseTomlDuration(p2pBanDurationName, tomlConfig.P2PBanDuration, defaults.P2PBanDuration)
+	if err != nil {
+		return nil, err
+	}
+	enclaveRPCMinTimeout, err := parseTomlDuration(enclaveRPCMinTimeoutName, tomlConfig.EnclaveRPCMinTimeout, defaults.EnclaveRPCMinTimeout)
+	if err != nil {
+		return nil, err
+	}
+	enclaveRPCMaxTimeout, err := parseTomlDuration(enclaveRPCMaxTimeoutName, tomlConfig.EnclaveRPCMaxTimeout, defaults.EnclaveRPCMaxTimeout)
+	if err != nil {
+		return nil, err
+	}
+	l1BlobsAvailable := defaults.L1BlobsAvailable
+	if tomlConfig.L1BlobsAvailable != nil {
+		l1BlobsAvailable = *tomlConfig.L1BlobsAvailable
 	}
 
 	return &config.HostInputConfig{
@@ -188,10 +534,18 @@ func fileBasedConfig(configPath string) (*config.HostInputConfig, error) {
 		ClientRPCPortHTTP:         uint64(tomlConfig.ClientRPCPortHTTP),
 		HasClientRPCWebsockets:    tomlConfig.HasClientRPCWebsockets,
 		ClientRPCPortWS:           uint64(tomlConfig.ClientRPCPortWS),
+		ClientRPCHTTPModules:      tomlConfig.ClientRPCHTTPModules,
+		ClientRPCWSModules:        tomlConfig.ClientRPCWSModules,
+		ClientRPCIPCPath:          tomlConfig.ClientRPCIPCPath,
+		ClientRPCMaxRequestSize:   tomlConfig.ClientRPCMaxRequestSize,
+		ClientRESTPort:            uint64(tomlConfig.ClientRESTPort),
 		ClientRPCHost:             tomlConfig.ClientRPCHost,
 		EnclaveRPCAddress:         tomlConfig.EnclaveRPCAddress,
 		P2PBindAddress:            tomlConfig.P2PBindAddress,
 		P2PPublicAddress:          tomlConfig.P2PPublicAddress,
+		P2PNAT:                    tomlConfig.P2PNAT,
+		P2PBootstrapDNS:           tomlConfig.P2PBootstrapDNS,
+		P2PInsecure:               tomlConfig.P2PInsecure,
 		L1WebsocketURL:            tomlConfig.L1WebsocketURL,
 		EnclaveRPCTimeout:         time.Duration(tomlConfig.EnclaveRPCTimeout) * time.Second,
 		L1RPCTimeout:              time.Duration(tomlConfig.L1RPCTimeout) * time.Second,
@@ -200,6 +554,10 @@ func fileBasedConfig(configPath string) (*config.HostInputConfig, error) {
 		MessageBusAddress:         gethcommon.HexToAddress(tomlConfig.MessageBusAddress),
 		LogLevel:                  tomlConfig.LogLevel,
 		LogPath:                   tomlConfig.LogPath,
+		LogRedactKeys:             tomlConfig.LogRedactKeys,
+		LogRedactHexBytes:         logRedactHexBytes,
+		RequestLogSampleRate:      tomlConfig.RequestLogSampleRate,
+		RequestLogMethodRates:     tomlConfig.RequestLogMethodRates,
 		PrivateKeyString:          tomlConfig.PrivateKeyString,
 		L1ChainID:                 tomlConfig.L1ChainID,
 		ObscuroChainID:            tomlConfig.ObscuroChainID,
@@ -208,12 +566,72 @@ func fileBasedConfig(configPath string) (*config.HostInputConfig, error) {
 		SequencerID:               gethcommon.HexToAddress(tomlConfig.SequencerID),
 		MetricsEnabled:            tomlConfig.MetricsEnabled,
 		MetricsHTTPPort:           tomlConfig.MetricsHTTPPort,
+		MetricsPath:               tomlConfig.MetricsPath,
 		UseInMemoryDB:             tomlConfig.UseInMemoryDB,
 		LevelDBPath:               tomlConfig.LevelDBPath,
+		DBType:                    tomlConfig.DBType,
+		SequencerAdminAddress:     gethcommon.HexToAddress(tomlConfig.SequencerAdminAddress),
 		BatchInterval:             batchInterval,
 		MaxBatchInterval:          maxBatchInterval,
 		RollupInterval:            rollupInterval,
 		IsInboundP2PDisabled:      tomlConfig.IsInboundP2PDisabled,
 		L1BlockTime:               time.Duration(tomlConfig.L1BlockTime) * time.Second,
+		NetworkGenesisPath:        tomlConfig.NetworkGenesisPath,
+		ProbesHTTPPort:            tomlConfig.ProbesHTTPPort,
+		TerminationGracePeriod:    terminationGracePeriod,
+		DrainDelay:                drainDelay,
+		ArchiveAge:                archiveAge,
+		ArchiveInterval:           archiveInterval,
+		ArchiveEndpoint:           tomlConfig.ArchiveEndpoint,
+		ArchiveBucket:             tomlConfig.ArchiveBucket,
+		ArchiveRegion:             archiveRegion,
+		ArchiveAccessKey:          tomlConfig.ArchiveAccessKey,
+		ArchiveSecretKey:          tomlConfig.ArchiveSecretKey,
+		NTPServer:                 tomlConfig.NTPServer,
+		ClockSkewWarning:          clockSkewWarning,
+		MaxClockSkew:              maxClockSkew,
+		IngestionQueueSize:        ingestionQueueSize,
+		L1BlockQueuePolicy:        l1BlockQueuePolicy,
+		TxQueuePolicy:             txQueuePolicy,
+		QueueSpillDir:             tomlConfig.QueueSpillDir,
+		BatchCodec:                batchCodec,
+		EventLogPath:              tomlConfig.EventLogPath,
+		EventLogFinalityDepth:     eventLogFinalityDepth,
+		L1TxMaxRetries:            l1TxMaxRetries,
+		L1RebidBlocks:             l1RebidBlocks,
+		L1GasOracleBlocks:         l1GasOracleBlocks,
+		L1MaxFeePerGas:            tomlConfig.L1MaxFeePerGas,
+		L1MaxPriorityFeePerGas:    tomlConfig.L1MaxPriorityFeePerGas,
+		L1BlobsAvailable:          l1BlobsAvailable,
+		L1Profile:                 tomlConfig.L1Profile,
+		DisableSecretResponses:    tomlConfig.DisableSecretResponses,
+		SecretResponseCooldown:    secretResponseCooldown,
+		MaxSecretResponsesPerHour: maxSecretResponsesPerHour,
+		P2PMaxMessageSize:         p2pMaxMessageSize,
+		P2PMaxPeerMsgsPerSecond:   p2pMaxPeerMsgsPerSecond,
+		P2PBanThreshold:           p2pBanThreshold,
+		P2PBanDuration:            p2pBanDuration,
+		PublicMempoolStats:        tomlConfig.PublicMempoolStats,
+		EnclaveRPCMinTimeout:      enclaveRPCMinTimeout,
+		EnclaveRPCMaxTimeout:      enclaveRPCMaxTimeout,
+		StandbyEnclaveAddresses:   tomlConfig.StandbyEnclaveAddresses,
+		EnclaveFailoverThreshold:  enclaveFailoverThreshold,
 	}, nil
 }
+
+// newHostSchema describes the flags of the host, once they are defined on the flag set
+func newHostSchema(fs *flag.FlagSet) *config.Schema {
+	return config.NewFlagSetSchema("host", HostConfigVersion, "HOST_", fs)
+}
+
+// parseTomlDuration parses a duration of the .toml config, which takes its default value when left empty
+func parseTomlDuration(name string, value string, defaultValue time.Duration) (time.Duration, error) {
+	if value == "" {
+		return defaultValue, nil
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s '%s' - %w", name, value, err)
+	}
+	return duration, nil
+}
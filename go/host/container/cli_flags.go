@@ -2,79 +2,189 @@ package container
 
 // Flag names.
 const (
-	configName                   = "config"
-	nodeIDName                   = "id"
-	isGenesisName                = "isGenesis"
-	nodeTypeName                 = "nodeType"
-	clientRPCPortHTTPName        = "clientRPCPortHttp"
-	clientRPCPortWSName          = "clientRPCPortWs"
-	clientRPCHostName            = "clientRPCHost"
-	enclaveRPCAddressName        = "enclaveRPCAddress"
-	p2pBindAddressName           = "p2pBindAddress"
-	p2pPublicAddressName         = "p2pPublicAddress"
-	l1WebsocketURLName           = "l1WSURL"
-	enclaveRPCTimeoutSecsName    = "enclaveRPCTimeoutSecs"
-	l1RPCTimeoutSecsName         = "l1RPCTimeoutSecs"
-	p2pConnectionTimeoutSecsName = "p2pConnectionTimeoutSecs"
-	managementContractAddrName   = "managementContractAddress"
-	messageBusContractAddrName   = "messageBusContractAddress"
-	logLevelName                 = "logLevel"
-	logPathName                  = "logPath"
-	privateKeyName               = "privateKey"
-	l1ChainIDName                = "l1ChainID"
-	obscuroChainIDName           = "obscuroChainID"
-	profilerEnabledName          = "profilerEnabled"
-	l1StartHashName              = "l1Start"
-	sequencerIDName              = "sequencerID"
-	metricsEnabledName           = "metricsEnabled"
-	metricsHTTPPortName          = "metricsHTTPPort"
-	useInMemoryDBName            = "useInMemoryDB"
-	levelDBPathName              = "levelDBPath"
-	debugNamespaceEnabledName    = "debugNamespaceEnabled"
-	batchIntervalName            = "batchInterval"
-	maxBatchIntervalName         = "maxBatchInterval"
-	rollupIntervalName           = "rollupInterval"
-	isInboundP2PDisabledName     = "isInboundP2PDisabled"
-	maxRollupSizeFlagName        = "maxRollupSize"
+	configName                    = "config"
+	nodeIDName                    = "id"
+	isGenesisName                 = "isGenesis"
+	nodeTypeName                  = "nodeType"
+	clientRPCPortHTTPName         = "clientRPCPortHttp"
+	clientRPCPortWSName           = "clientRPCPortWs"
+	clientRPCHTTPModulesName      = "clientRPCHttpModules"
+	clientRPCWSModulesName        = "clientRPCWsModules"
+	clientRPCIPCPathName          = "clientRPCIPCPath"
+	clientRPCMaxRequestSizeName   = "clientRPCMaxRequestSize"
+	clientRESTPortName            = "clientRESTPort"
+	clientRPCHostName             = "clientRPCHost"
+	enclaveRPCAddressName         = "enclaveRPCAddress"
+	p2pBindAddressName            = "p2pBindAddress"
+	p2pPublicAddressName          = "p2pPublicAddress"
+	p2pNATName                    = "p2pNAT"
+	p2pBootstrapDNSName           = "p2pBootstrapDNS"
+	p2pInsecureName               = "p2pInsecure"
+	l1WebsocketURLName            = "l1WSURL"
+	enclaveRPCTimeoutSecsName     = "enclaveRPCTimeoutSecs"
+	l1RPCTimeoutSecsName          = "l1RPCTimeoutSecs"
+	p2pConnectionTimeoutSecsName  = "p2pConnectionTimeoutSecs"
+	managementContractAddrName    = "managementContractAddress"
+	messageBusContractAddrName    = "messageBusContractAddress"
+	logLevelName                  = "logLevel"
+	logPathName                   = "logPath"
+	logRedactKeysName             = "logRedactKeys"
+	logRedactHexBytesName         = "logRedactHexBytes"
+	requestLogSampleRateName      = "requestLogSampleRate"
+	requestLogMethodRatesName     = "requestLogMethodRates"
+	privateKeyName                = "privateKey"
+	l1ChainIDName                 = "l1ChainID"
+	obscuroChainIDName            = "obscuroChainID"
+	profilerEnabledName           = "profilerEnabled"
+	l1StartHashName               = "l1Start"
+	sequencerIDName               = "sequencerID"
+	metricsEnabledName            = "metricsEnabled"
+	metricsHTTPPortName           = "metricsHTTPPort"
+	metricsPathName               = "metricsPath"
+	useInMemoryDBName             = "useInMemoryDB"
+	levelDBPathName               = "levelDBPath"
+	dbTypeName                    = "dbType"
+	debugNamespaceEnabledName     = "debugNamespaceEnabled"
+	sequencerAdminAddressName     = "sequencerAdminAddress"
+	batchIntervalName             = "batchInterval"
+	maxBatchIntervalName          = "maxBatchInterval"
+	rollupIntervalName            = "rollupInterval"
+	isInboundP2PDisabledName      = "isInboundP2PDisabled"
+	maxRollupSizeFlagName         = "maxRollupSize"
+	networkGenesisPathName        = "networkGenesisPath"
+	probesHTTPPortName            = "probesHTTPPort"
+	terminationGracePeriodName    = "terminationGracePeriod"
+	drainDelayName                = "drainDelay"
+	archiveAgeName                = "archiveAge"
+	archiveIntervalName           = "archiveInterval"
+	archiveEndpointName           = "archiveEndpoint"
+	archiveBucketName             = "archiveBucket"
+	archiveRegionName             = "archiveRegion"
+	archiveAccessKeyName          = "archiveAccessKey"
+	archiveSecretKeyName          = "archiveSecretKey"
+	ntpServerName                 = "ntpServer"
+	clockSkewWarningName          = "clockSkewWarning"
+	maxClockSkewName              = "maxClockSkew"
+	ingestionQueueSizeName        = "ingestionQueueSize"
+	l1BlockQueuePolicyName        = "l1BlockQueuePolicy"
+	txQueuePolicyName             = "txQueuePolicy"
+	queueSpillDirName             = "queueSpillDir"
+	batchCodecName                = "batchCodec"
+	eventLogPathName              = "eventLogPath"
+	eventLogFinalityDepthName     = "eventLogFinalityDepth"
+	l1ProfileName                 = "l1Profile"
+	l1TxMaxRetriesName            = "l1TxMaxRetries"
+	l1RebidBlocksName             = "l1RebidBlocks"
+	l1GasOracleBlocksName         = "l1GasOracleBlocks"
+	l1MaxFeePerGasName            = "l1MaxFeePerGas"
+	l1MaxPriorityFeePerGasName    = "l1MaxPriorityFeePerGas"
+	l1BlobsAvailableName          = "l1BlobsAvailable"
+	disableSecretResponsesName    = "disableSecretResponses"
+	secretResponseCooldownName    = "secretResponseCooldown"
+	maxSecretResponsesPerHourName = "maxSecretResponsesPerHour"
+	p2pMaxMessageSizeName         = "p2pMaxMessageSize"
+	p2pMaxPeerMsgsPerSecondName   = "p2pMaxPeerMsgsPerSecond"
+	p2pBanThresholdName           = "p2pBanThreshold"
+	p2pBanDurationName            = "p2pBanDuration"
+	publicMempoolStatsName        = "publicMempoolStats"
+	enclaveRPCMinTimeoutName      = "enclaveRPCMinTimeout"
+	enclaveRPCMaxTimeoutName      = "enclaveRPCMaxTimeout"
+	standbyEnclaveAddressesName   = "standbyEnclaveAddresses"
+	enclaveFailoverThresholdName  = "enclaveFailoverThreshold"
 )
 
 // Returns a map of the flag usages.
 // While we could just use constants instead of a map, this approach allows us to test that all the expected flags are defined.
 func getFlagUsageMap() map[string]string {
 	return map[string]string{
-		configName:                   "The path to the host's config file. Overrides all other flags",
-		nodeIDName:                   "The 20 bytes of the host's address",
-		isGenesisName:                "Whether the host is the first host to join the network",
-		nodeTypeName:                 "The node's type (e.g. aggregator, validator)",
-		clientRPCPortHTTPName:        "The port on which to listen for client application RPC requests over HTTP",
-		clientRPCPortWSName:          "The port on which to listen for client application RPC requests over websockets",
-		clientRPCHostName:            "The host on which to handle client application RPC requests",
-		enclaveRPCAddressName:        "The address to use to connect to the Obscuro enclave service",
-		p2pBindAddressName:           "The address where the p2p server is bound to. Defaults to 0.0.0.0:10000",
-		p2pPublicAddressName:         "The P2P address where the other servers should connect to. Defaults to 127.0.0.1:10000",
-		l1WebsocketURLName:           "The websocket RPC address the host can use for L1 requests",
-		enclaveRPCTimeoutSecsName:    "The timeout for host <-> enclave RPC communication",
-		l1RPCTimeoutSecsName:         "The timeout for connecting to, and communicating with, the Ethereum client",
-		p2pConnectionTimeoutSecsName: "The timeout for host <-> host P2P messaging",
-		managementContractAddrName:   "The management contract address on the L1",
-		messageBusContractAddrName:   "The message bus contract address on the L1",
-		logLevelName:                 "The verbosity level of logs. (Defaults to Info)",
-		logPathName:                  "The path to use for the host's log file",
-		privateKeyName:               "The private key for the L1 host account",
-		l1ChainIDName:                "An integer representing the unique chain id of the Ethereum chain used as an L1 (default 1337)",
-		obscuroChainIDName:           "An integer representing the unique chain id of the Obscuro chain (default 443)",
-		profilerEnabledName:          "Runs a profiler instance (Defaults to false)",
-		l1StartHashName:              "The L1 block hash where the management contract was deployed",
-		sequencerIDName:              "The ID of the sequencer",
-		metricsEnabledName:           "Whether the metrics are enabled (Defaults to true)",
-		metricsHTTPPortName:          "The port on which the metrics are served (Defaults to 0.0.0.0:14000)",
-		useInMemoryDBName:            "Whether the host will use an in-memory DB rather than persist data",
-		levelDBPathName:              "Filepath for the levelDB persistence dir (can be empty if a throwaway file in /tmp/ is acceptable or if using InMemory DB)",
-		debugNamespaceEnabledName:    "Whether the debug names is enabled",
-		batchIntervalName:            "Duration between each batch. Can be put down as 1.0s",
-		maxBatchIntervalName:         "Max interval between each batch, if greater than batchInterval then some empty batches will be skipped. Can be put down as 1.0s",
-		rollupIntervalName:           "Duration between each rollup. Can be put down as 1.0s",
-		isInboundP2PDisabledName:     "Whether inbound p2p is enabled",
-		maxRollupSizeFlagName:        "Max size of a rollup",
+		configName:                    "The path to the host's config file. Overrides all other flags",
+		nodeIDName:                    "The 20 bytes of the host's address",
+		isGenesisName:                 "Whether the host is the first host to join the network",
+		nodeTypeName:                  "The node's type (e.g. aggregator, validator)",
+		clientRPCPortHTTPName:         "The port on which to listen for client application RPC requests over HTTP",
+		clientRPCPortWSName:           "The port on which to listen for client application RPC requests over websockets",
+		clientRPCHTTPModulesName:      "A comma-separated list of the API namespaces served over HTTP (Defaults to all of them)",
+		clientRPCWSModulesName:        "A comma-separated list of the API namespaces served over websockets (Defaults to all of them)",
+		clientRPCIPCPathName:          "The path of the unix socket on which to serve all the API namespaces over IPC (Defaults to empty, disabled)",
+		clientRPCMaxRequestSizeName:   "The maximum size of the client RPC requests over HTTP, in bytes (Defaults to 0, the 5 MiB limit of geth, which is the maximum)",
+		clientRESTPortName:            "The port on which to serve the REST API over the public data of the node (Defaults to 0, disabled)",
+		clientRPCHostName:             "The host on which to handle client application RPC requests",
+		enclaveRPCAddressName:         "The address to use to connect to the Obscuro enclave service",
+		p2pBindAddressName:            "The address where the p2p server is bound to, e.g. 0.0.0.0:10000 for IPv4 only or [::1]:10000 for an IPv6 address. Defaults to :10000, all the interfaces",
+		p2pPublicAddressName:          "The P2P address where the other servers should connect to. If the host is omitted (e.g. :10000), the external address is discovered with the p2pNAT mechanism. Defaults to 127.0.0.1:10000",
+		p2pNATName:                    "The mechanism used to map the P2P port on the router and discover the external address: none, any, upnp, pmp, pmp:<gateway IP> or extip:<IP> (Defaults to none)",
+		p2pBootstrapDNSName:           "The domain whose SRV (_ten-p2p._tcp.<domain>) and TXT (ten-p2p=<host>:<port>) records list bootstrap peers, in addition to the peers registered on the L1 (Defaults to empty, disabled)",
+		p2pInsecureName:               "Whether the P2P messages are sent and accepted in plaintext, without authenticating the peers. All the hosts of the network must set it alike (Defaults to false)",
+		l1WebsocketURLName:            "The websocket RPC address the host can use for L1 requests",
+		enclaveRPCTimeoutSecsName:     "The timeout for host <-> enclave RPC communication, until the latencies of each RPC method are known",
+		l1RPCTimeoutSecsName:          "The timeout for connecting to, and communicating with, the Ethereum client",
+		p2pConnectionTimeoutSecsName:  "The timeout for host <-> host P2P messaging",
+		managementContractAddrName:    "The management contract address on the L1",
+		messageBusContractAddrName:    "The message bus contract address on the L1",
+		logLevelName:                  "The verbosity level of logs. (Defaults to Info)",
+		logPathName:                   "The path to use for the host's log file",
+		logRedactKeysName:             "A comma-separated list of log keys whose values are redacted, in addition to the default deny-list and the keys labelled secret or key",
+		logRedactHexBytesName:         "The size in bytes above which the hex blobs in the logs are redacted (Defaults to 128, 0 disables it)",
+		requestLogSampleRateName:      "The share of the client RPC requests over HTTP that are logged, without their payloads (Defaults to 0, disabled)",
+		requestLogMethodRatesName:     "The comma-separated method=rate pairs overriding the share of the requests logged for these methods",
+		privateKeyName:                "The private key for the L1 host account",
+		l1ChainIDName:                 "An integer representing the unique chain id of the Ethereum chain used as an L1 (default 1337)",
+		obscuroChainIDName:            "An integer representing the unique chain id of the Obscuro chain (default 443)",
+		profilerEnabledName:           "Runs a profiler instance (Defaults to false)",
+		l1StartHashName:               "The L1 block hash where the management contract was deployed",
+		sequencerIDName:               "The ID of the sequencer",
+		metricsEnabledName:            "Whether the metrics are enabled (Defaults to true)",
+		metricsHTTPPortName:           "The port on which the metrics are served on all the interfaces (Defaults to 14000)",
+		metricsPathName:               "The path on which the metrics are served in the Prometheus format (Defaults to /metrics)",
+		useInMemoryDBName:             "Whether the host will use an in-memory DB rather than persist data",
+		levelDBPathName:               "Filepath for the levelDB persistence dir (can be empty if a throwaway file in /tmp/ is acceptable or if using InMemory DB)",
+		dbTypeName:                    "The backend of the persistent host DB, stored at levelDBPath: leveldb, pebble or sqlite (Defaults to leveldb)",
+		debugNamespaceEnabledName:     "Whether the debug names is enabled",
+		sequencerAdminAddressName:     "The address of the account allowed to switch the sequencer in and out of the read-only mode (Defaults to the zero address, disabled)",
+		batchIntervalName:             "Duration between each batch. Can be put down as 1.0s",
+		maxBatchIntervalName:          "Max interval between each batch, if greater than batchInterval then some empty batches will be skipped. Can be put down as 1.0s",
+		rollupIntervalName:            "Duration between each rollup. Can be put down as 1.0s",
+		isInboundP2PDisabledName:      "Whether inbound p2p is enabled",
+		maxRollupSizeFlagName:         "Max size of a rollup",
+		networkGenesisPathName:        "The path to the network genesis.json file. When set it replaces the l1ChainID, obscuroChainID, sequencerID, managementContractAddress, messageBusContractAddress and l1Start flags",
+		probesHTTPPortName:            "The port on which the /livez and /readyz probes are served (Defaults to 0, disabled)",
+		terminationGracePeriodName:    "The time the host has to drain and stop once terminated. Should match the orchestrator's grace period. Can be put down as 30s",
+		drainDelayName:                "The time the host keeps serving requests after failing its readiness probe, so load balancers can stop routing to it. Can be put down as 5s",
+		archiveAgeName:                "How old the rolled-up batches must be to be archived, with their rollups, to the object storage, e.g. 720h for 30 days. The archived batches are then pruned from the host DB (Defaults to 0, disabled)",
+		archiveIntervalName:           "Duration between each run of the job archiving the batches older than the archive age. Can be put down as 1h",
+		archiveEndpointName:           "The URL of the S3-compatible object storage the batches are archived to, e.g. https://storage.googleapis.com",
+		archiveBucketName:             "The bucket of the object storage the batches are archived to",
+		archiveRegionName:             "The region of the archive bucket, used to sign the requests, auto for GCS (Defaults to us-east-1)",
+		archiveAccessKeyName:          "The access key of the object storage the batches are archived to",
+		archiveSecretKeyName:          "The secret key of the object storage the batches are archived to",
+		ntpServerName:                 "The NTP server the sequencer compares the enclave and host clocks with, e.g. pool.ntp.org (Defaults to empty, disabled)",
+		clockSkewWarningName:          "The skew between the enclave clock, the host clock, the NTP time and the L1 block timestamps above which the sequencer warns. Can be put down as 1s",
+		maxClockSkewName:              "The clock skew above which the sequencer refuses to produce batches. Can be put down as 5s (0 disables it)",
+		ingestionQueueSizeName:        "The number of live L1 blocks, and of P2P transactions, held in memory while they wait for the enclave",
+		l1BlockQueuePolicyName:        "What happens to the live L1 blocks received while their queue is full: block, drop-oldest or spill-to-disk (Defaults to drop-oldest)",
+		txQueuePolicyName:             "What happens to the P2P transactions received while their queue is full: block, drop-oldest or spill-to-disk (Defaults to spill-to-disk)",
+		queueSpillDirName:             "The directory of the files of the queues with the spill-to-disk policy (Defaults to empty, the system temp directory)",
+		batchCodecName:                "The wire format of the batches sent to the peers: rlp or protobuf. The peers detect the format of the batches they receive (Defaults to rlp)",
+		eventLogPathName:              "The file the public lifecycle events of the network are appended to as newline-delimited JSON, for external indexers (Defaults to empty, disabled)",
+		eventLogFinalityDepthName:     "The number of L1 blocks on top of an L1 block for the event log to report it finalised, and for the batches of its rollups to be returned for the finalized block tag (Defaults to 64)",
+		l1TxMaxRetriesName:            "The number of times an L1 transaction that fails to broadcast, or gets no receipt in time, is re-sent with a higher gas price before the host gives up on it (Defaults to 10)",
+		l1RebidBlocksName:             "The number of L1 blocks an L1 transaction, e.g. a rollup, is given to be included before it is re-sent with a higher bid (Defaults to 6)",
+		l1GasOracleBlocksName:         "The number of recent L1 blocks whose median priority fee is bid by the L1 transactions (Defaults to 20)",
+		l1MaxFeePerGasName:            "The cap of the EIP-1559 fee per gas bid by the L1 transactions, in wei (Defaults to 0, no cap)",
+		l1MaxPriorityFeePerGasName:    "The cap of the EIP-1559 priority fee per gas bid by the L1 transactions, in wei (Defaults to 0, no cap)",
+		l1ProfileName:                 "The built-in profile of the L1 chain: sepolia or holesky. When set it replaces the L1 block time and the l1ChainID, eventLogFinalityDepth and l1BlobsAvailable flags, and fills in the managementContractAddress and messageBusContractAddress flags left empty (Defaults to empty, disabled)",
+		l1BlobsAvailableName:          "Whether the L1 chain accepts blob transactions (Defaults to true)",
+		disableSecretResponsesName:    "Whether the host never publishes the responses to the secret requests of the other nodes, e.g. on a validator-only node (Defaults to false)",
+		secretResponseCooldownName:    "The time before the host responds again to the secret requests of a requester. Can be put down as 10m (Defaults to 10m, 0 responds to every request)",
+		maxSecretResponsesPerHourName: "The number of secret responses the host publishes in an hour at most (Defaults to 60, 0 for no limit)",
+		p2pMaxMessageSizeName:         "The size of the largest P2P message accepted from a peer, in bytes (Defaults to 64 MiB, 0 for no limit)",
+		p2pMaxPeerMsgsPerSecondName:   "The number of P2P messages accepted from a peer per second at most (Defaults to 1000, 0 for no limit)",
+		p2pBanThresholdName:           "The number of offences of a P2P peer (flooding, oversized, invalid or duplicate messages) within the ban duration that get it banned (Defaults to 10, 0 never bans)",
+		p2pBanDurationName:            "The time a P2P peer is banned for, and the window its offences are counted over. Can be put down as 1h (Defaults to 1h)",
+		publicMempoolStatsName:        "Whether the sequencer host serves the aggregate statistics of its mempool with the public ten_mempoolStats RPC (Defaults to false)",
+		enclaveRPCMinTimeoutName:      "The shortest deadline of an enclave call, once the latencies of its RPC method are known. Can be put down as 1s (Defaults to 1s)",
+		enclaveRPCMaxTimeoutName:      "The longest deadline of an enclave call, once the latencies of its RPC method are known. Can be put down as 2m (Defaults to 2m)",
+		standbyEnclaveAddressesName:   "A comma-separated list of the addresses of the hot standby enclaves of the host, which take over when the active enclave fails (Defaults to none)",
+		enclaveFailoverThresholdName:  "The number of consecutive failed health checks of the active enclave before a standby enclave is promoted (Defaults to 3, 0 only promotes them with the admin API)",
 	}
 }
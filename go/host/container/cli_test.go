@@ -1,6 +1,7 @@
 package container
 
 import (
+	"flag"
 	"os"
 	"path"
 	"reflect"
@@ -22,7 +23,7 @@ func TestConfigIsParsedFromTomlFileIfConfigFlagIsPresent(t *testing.T) {
 		panic(err)
 	}
 
-	cfg, err := fileBasedConfig(path.Join(wd, testToml))
+	cfg, err := fileBasedConfig(path.Join(wd, testToml), newHostSchema(flag.NewFlagSet("host", flag.ContinueOnError)))
 	if err != nil {
 		t.Fatalf("could not parse config. Cause: %s", err)
 	}
@@ -44,6 +45,72 @@ func TestConfigIsParsedFromCmdLineFlagsIfConfigFlagIsNotPresent(t *testing.T) {
 	}
 }
 
+func TestInvalidTomlConfigIsRejected(t *testing.T) {
+	wd, err := os.Getwd()
+	if err != nil {
+		panic(err)
+	}
+	valid, err := os.ReadFile(path.Join(wd, testToml))
+	if err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name     string
+		override string
+		err      string
+	}{
+		{name: "another version", override: "version = 2", err: "version 2 of the schema, expected version 1"},
+		{name: "invalid duration", override: "BatchInterval = \"1 second\"", err: "invalid batchInterval '1 second'"},
+		{name: "invalid queue policy", override: "TxQueuePolicy = \"drop-all\"", err: "invalid txQueuePolicy"},
+		{name: "invalid codec", override: "BatchCodec = \"zip\"", err: "invalid batchCodec"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// the last value of a key wins, so the override is appended after the removal of the key it replaces
+			key := strings.Fields(tt.override)[0]
+			lines := []string{}
+			for _, line := range strings.Split(string(valid), "\n") {
+				if !strings.HasPrefix(strings.ToLower(line), strings.ToLower(key)+" ") {
+					lines = append(lines, line)
+				}
+			}
+			configPath := path.Join(t.TempDir(), "config.toml")
+			if err := os.WriteFile(configPath, []byte(strings.Join(append(lines, tt.override), "\n")), 0o600); err != nil {
+				panic(err)
+			}
+
+			_, err := fileBasedConfig(configPath, newHostSchema(flag.NewFlagSet("host", flag.ContinueOnError)))
+			if err == nil || !strings.Contains(err.Error(), tt.err) {
+				t.Fatalf("expected an error containing %q, got %v", tt.err, err)
+			}
+		})
+	}
+}
+
+// set to true to regenerate the committed docs when the flags change
+const regenerateDocs = false
+
+func TestHostConfigDocsAreUpToDate(t *testing.T) {
+	fs := flag.NewFlagSet("host", flag.ContinueOnError)
+	if _, err := parseConfig(fs, nil); err != nil {
+		t.Fatalf("could not parse config. Cause: %s", err)
+	}
+	docs := newHostSchema(fs).Markdown()
+	if regenerateDocs {
+		if err := os.WriteFile(HostConfigDocsFile, []byte(docs), 0o600); err != nil {
+			panic(err)
+		}
+	}
+	committed, err := os.ReadFile(HostConfigDocsFile)
+	if err != nil {
+		t.Fatalf("could not read %s. Cause: %s", HostConfigDocsFile, err)
+	}
+	if docs != string(committed) {
+		t.Fatalf("%s is out of date, regenerate it with regenerateDocs", HostConfigDocsFile)
+	}
+}
+
 func TestConfigFieldsMatchTomlConfigFields(t *testing.T) {
 	// We get all the config fields.
 	cfgReflection := reflect.TypeOf(config.HostInputConfig{})
@@ -54,9 +121,12 @@ func TestConfigFieldsMatchTomlConfigFields(t *testing.T) {
 
 	// We get all the .toml config fields.
 	cfgTomlReflection := reflect.TypeOf(HostConfigToml{})
-	cfgTomlFields := make([]string, cfgTomlReflection.NumField())
+	cfgTomlFields := make([]string, 0, cfgTomlReflection.NumField())
 	for i := 0; i < cfgTomlReflection.NumField(); i++ {
-		cfgTomlFields[i] = cfgTomlReflection.Field(i).Name
+		// the version is the one of the file, not a config field
+		if name := cfgTomlReflection.Field(i).Name; name != "Version" {
+			cfgTomlFields = append(cfgTomlFields, name)
+		}
 	}
 
 	sort.Strings(cfgFields)
@@ -1,22 +1,32 @@
 package container
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ten-protocol/go-ten/go/host/l1"
 
+	"github.com/ethereum/go-ethereum/p2p/nat"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+	"github.com/ten-protocol/go-ten/go/common/container"
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/common/metrics"
+	"github.com/ten-protocol/go-ten/go/common/networkgenesis"
 	"github.com/ten-protocol/go-ten/go/config"
 	"github.com/ten-protocol/go-ten/go/ethadapter"
 	"github.com/ten-protocol/go-ten/go/ethadapter/mgmtcontractlib"
 	"github.com/ten-protocol/go-ten/go/host"
 	"github.com/ten-protocol/go-ten/go/host/p2p"
 	"github.com/ten-protocol/go-ten/go/host/rpc/clientapi"
+	"github.com/ten-protocol/go-ten/go/host/rpc/clientrest"
 	"github.com/ten-protocol/go-ten/go/host/rpc/clientrpc"
 	"github.com/ten-protocol/go-ten/go/host/rpc/enclaverpc"
 	"github.com/ten-protocol/go-ten/go/wallet"
@@ -34,13 +44,27 @@ const (
 	APINamespaceNetwork     = "net"
 	APINamespaceTest        = "test"
 	APINamespaceDebug       = "debug"
+	APINamespaceAdmin       = "admin"
+	APINamespaceTen         = "ten"
+	APINamespaceWeb3        = "web3"
 )
 
+// pendingSendsFlusher is implemented by the P2P services that can wait for the messages being sent to be delivered
+type pendingSendsFlusher interface {
+	FlushPendingSends(ctx context.Context) error
+}
+
 type HostContainer struct {
 	host           hostcommon.Host
 	logger         gethlog.Logger
 	metricsService *metrics.Service
 	rpcServer      clientrpc.Server
+	restServer     *clientrest.Server
+	p2p            hostcommon.P2PHostService
+	probeServer    *container.ProbeServer
+
+	draining      atomic.Bool
+	stopRPCServer sync.Once
 }
 
 func (h *HostContainer) Start() error {
@@ -54,6 +78,12 @@ func (h *HostContainer) Start() error {
 	h.logger.Info("Started Obscuro host...")
 	fmt.Println("Started Obscuro host...")
 
+	if h.probeServer != nil {
+		if err = h.probeServer.Start(); err != nil {
+			return err
+		}
+	}
+
 	if h.rpcServer != nil {
 		err = h.rpcServer.Start()
 		if err != nil {
@@ -64,6 +94,12 @@ func (h *HostContainer) Start() error {
 		fmt.Println("Started Obscuro host RPC Server...")
 	}
 
+	if h.restServer != nil {
+		if err = h.restServer.Start(); err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
@@ -76,18 +112,77 @@ func (h *HostContainer) Stop() error {
 
 	h.metricsService.Stop()
 
+	if h.probeServer != nil {
+		if err = h.probeServer.Stop(); err != nil {
+			h.logger.Warn("Could not stop the probe server", log.ErrKey, err)
+		}
+	}
+
+	if h.restServer != nil {
+		if err = h.restServer.Stop(); err != nil {
+			h.logger.Warn("Could not stop the REST server", log.ErrKey, err)
+		}
+	}
+
 	if h.rpcServer != nil {
 		// rpc server cannot be stopped synchronously as it will kill current request
 		go func() {
 			// make sure it's not killing the connection before returning the response
 			time.Sleep(time.Second) // todo review this sleep
-			h.rpcServer.Stop()
+			h.stopRPCServer.Do(h.rpcServer.Stop)
 		}()
 	}
 
 	return nil
 }
 
+// StopReadiness makes the readiness probe of the host fail, so that the load balancers stop routing requests to it.
+// The host keeps serving requests.
+func (h *HostContainer) StopReadiness() {
+	h.draining.Store(true)
+	h.logger.Info("Obscuro host is no longer ready")
+}
+
+// Drain lets the work in progress complete before the host is stopped: the RPC server rejects the new requests and
+// waits for the ones in progress, then the P2P messages still being delivered to peers are flushed. The RPC server is
+// stopped last, without any request left waiting on the enclave. The calls of the host's own services to the enclave
+// are not awaited, they are interrupted when the host stops.
+func (h *HostContainer) Drain(ctx context.Context) error {
+	h.logger.Info("Draining Obscuro host...")
+	var errs []error
+	if h.rpcServer != nil {
+		errs = append(errs, h.rpcServer.Drain(ctx))
+	}
+	if flusher, ok := h.p2p.(pendingSendsFlusher); ok {
+		errs = append(errs, flusher.FlushPendingSends(ctx))
+	}
+
+	if h.rpcServer != nil {
+		h.stopRPCServer.Do(h.rpcServer.Stop)
+	}
+	if h.restServer != nil {
+		if err := h.restServer.Stop(); err != nil {
+			h.logger.Warn("Could not stop the REST server", log.ErrKey, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ready is the readiness check of the host: it must not be draining, and the host, enclave and DB must be healthy
+func (h *HostContainer) ready() error {
+	if h.draining.Load() {
+		return errors.New("host is draining")
+	}
+	health, err := h.host.HealthCheck()
+	if err != nil {
+		return err
+	}
+	if !health.OverallHealth {
+		return fmt.Errorf("host is not healthy - %s", strings.Join(health.Errors, "; "))
+	}
+	return nil
+}
+
 func (h *HostContainer) Host() hostcommon.Host {
 	return h.host
 }
@@ -95,6 +190,20 @@ func (h *HostContainer) Host() hostcommon.Host {
 // NewHostContainerFromConfig uses config to create all HostContainer dependencies and inject them into a new HostContainer
 // (Note: it does not start the HostContainer process, `Start()` must be called on the container)
 func NewHostContainerFromConfig(parsedConfig *config.HostInputConfig, logger gethlog.Logger) *HostContainer {
+	if parsedConfig.L1Profile != "" {
+		l1Profile, err := config.L1ProfileByName(parsedConfig.L1Profile)
+		if err != nil {
+			panic(fmt.Sprintf("unable to load the L1 profile. Cause: %s", err))
+		}
+		parsedConfig.ApplyL1Profile(l1Profile)
+	}
+	if parsedConfig.NetworkGenesisPath != "" {
+		networkGenesis, err := networkgenesis.Load(parsedConfig.NetworkGenesisPath)
+		if err != nil {
+			panic(fmt.Sprintf("unable to load the network genesis. Cause: %s", err))
+		}
+		parsedConfig.ApplyNetworkGenesis(networkGenesis)
+	}
 	cfg := parsedConfig.ToHostConfig()
 
 	addr, err := wallet.RetrieveAddress(parsedConfig.PrivateKeyString)
@@ -103,15 +212,26 @@ func NewHostContainerFromConfig(parsedConfig *config.HostInputConfig, logger get
 	}
 	cfg.ID = *addr
 
+	redaction := log.DefaultRedaction().WithDenyList(cfg.LogRedactKeys)
+	redaction.MaxHexBytes = cfg.LogRedactHexBytes
 	// create the logger if not set - used when the testlogger is injected
 	if logger == nil {
-		logger = log.New(log.HostCmp, cfg.LogLevel, cfg.LogPath, log.NodeIDKey, cfg.ID)
+		logger = log.NewWithRedaction(log.HostCmp, cfg.LogLevel, cfg.LogPath, redaction, log.NodeIDKey, cfg.ID)
+	}
+
+	natm, err := nat.Parse(cfg.P2PNAT)
+	if err != nil {
+		logger.Crit("invalid P2P NAT mechanism.", log.ErrKey, err)
+	}
+	cfg.P2PPublicAddress, err = p2p.ResolvePublicAddress(natm, cfg.P2PBindAddress, cfg.P2PPublicAddress)
+	if err != nil {
+		logger.Crit("could not resolve the P2P public address.", log.ErrKey, err)
 	}
 
 	fmt.Printf("Building host container with config: %+v\n", cfg)
 	logger.Info(fmt.Sprintf("Building host container with config: %+v", cfg))
 
-	ethWallet := wallet.NewInMemoryWalletFromConfig(cfg.PrivateKeyString, cfg.L1ChainID, log.New("wallet", cfg.LogLevel, cfg.LogPath))
+	ethWallet := wallet.NewInMemoryWalletFromConfig(cfg.PrivateKeyString, cfg.L1ChainID, log.NewWithRedaction("wallet", cfg.LogLevel, cfg.LogPath, redaction))
 
 	fmt.Println("Connecting to L1 network...")
 	l1Client, err := ethadapter.NewEthClientFromURL(cfg.L1WebsocketURL, cfg.L1RPCTimeout, cfg.ID, logger)
@@ -131,11 +251,11 @@ func NewHostContainerFromConfig(parsedConfig *config.HostInputConfig, logger get
 
 	fmt.Println("Connecting to the enclave...")
 	services := host.NewServicesRegistry(logger)
-	enclaveClient := enclaverpc.NewClient(cfg, logger)
+	metricsService := metrics.New(cfg.MetricsEnabled, cfg.MetricsHTTPPort, cfg.MetricsPath, logger)
+	enclaveClients := append([]common.Enclave{enclaverpc.NewClient(cfg, logger, metricsService.Registry())}, enclaverpc.NewStandbyClients(cfg, logger, metricsService.Registry())...)
 	p2pLogger := logger.New(log.CmpKey, log.P2PCmp)
-	metricsService := metrics.New(cfg.MetricsEnabled, cfg.MetricsHTTPPort, logger)
 
-	aggP2P := p2p.NewSocketP2PLayer(cfg, services, p2pLogger, metricsService.Registry())
+	aggP2P := p2p.NewSocketP2PLayer(cfg, natm, services, ethWallet.PrivateKey(), p2pLogger, metricsService.Registry())
 
 	rpcServer := clientrpc.NewServer(cfg, logger)
 
@@ -143,22 +263,29 @@ func NewHostContainerFromConfig(parsedConfig *config.HostInputConfig, logger get
 	obscuroRelevantContracts := []gethcommon.Address{cfg.ManagementContractAddress, cfg.MessageBusAddress}
 	l1Repo := l1.NewL1Repository(l1Client, obscuroRelevantContracts, logger)
 
-	return NewHostContainer(cfg, services, aggP2P, l1Client, l1Repo, enclaveClient, mgmtContractLib, ethWallet, rpcServer, logger, metricsService)
+	return NewHostContainer(cfg, services, aggP2P, l1Client, l1Repo, enclaveClients, mgmtContractLib, ethWallet, rpcServer, clock.New(), logger, metricsService)
 }
 
 // NewHostContainer builds a host container with dependency injection rather than from config.
 // Useful for testing etc. (want to be able to pass in logger, and also have option to mock out dependencies)
-func NewHostContainer(cfg *config.HostConfig, services *host.ServicesRegistry, p2p hostcommon.P2PHostService, l1Client ethadapter.EthClient, l1Repo hostcommon.L1RepoService, enclaveClient common.Enclave, contractLib mgmtcontractlib.MgmtContractLib, hostWallet wallet.Wallet, rpcServer clientrpc.Server, logger gethlog.Logger, metricsService *metrics.Service) *HostContainer {
-	h := host.NewHost(cfg, services, p2p, l1Client, l1Repo, enclaveClient, hostWallet, contractLib, logger, metricsService.Registry())
+func NewHostContainer(cfg *config.HostConfig, services *host.ServicesRegistry, p2p hostcommon.P2PHostService, l1Client ethadapter.EthClient, l1Repo hostcommon.L1RepoService, enclaveClients []common.Enclave, contractLib mgmtcontractlib.MgmtContractLib, hostWallet wallet.Wallet, rpcServer clientrpc.Server, clk clock.Clock, logger gethlog.Logger, metricsService *metrics.Service) *HostContainer {
+	h := host.NewHost(cfg, services, p2p, l1Client, l1Repo, enclaveClients, hostWallet, contractLib, clk, logger, metricsService.Registry())
 
 	hostContainer := &HostContainer{
 		host:           h,
 		logger:         logger,
 		rpcServer:      rpcServer,
+		p2p:            p2p,
 		metricsService: metricsService,
 	}
+	if cfg.ProbesHTTPPort != 0 {
+		hostContainer.probeServer = container.NewProbeServer(cfg.ProbesHTTPPort, hostContainer.ready, logger)
+	}
+	if cfg.ClientRESTPort != 0 {
+		hostContainer.restServer = clientrest.NewServer(cfg, h, logger)
+	}
 
-	if cfg.HasClientRPCHTTP || cfg.HasClientRPCWebsockets {
+	if cfg.HasClientRPCHTTP || cfg.HasClientRPCWebsockets || cfg.ClientRPCIPCPath != "" {
 		rpcServer.RegisterAPIs([]rpc.API{
 			{
 				Namespace: APINamespaceObscuro,
@@ -202,14 +329,37 @@ func NewHostContainer(cfg *config.HostConfig, services *host.ServicesRegistry, p
 				Service:   clientapi.NewScanAPI(h, logger),
 				Public:    true,
 			},
+			{
+				Namespace: APINamespaceTen,
+				Version:   APIVersion1,
+				Service:   clientapi.NewTenAPI(h),
+				Public:    true,
+			},
+			{
+				Namespace: APINamespaceWeb3,
+				Version:   APIVersion1,
+				Service:   clientapi.NewWeb3API(),
+				Public:    true,
+			},
 		})
 
+		if cfg.NodeType == common.Sequencer {
+			rpcServer.RegisterAPIs([]rpc.API{
+				{
+					Namespace: APINamespaceAdmin,
+					Version:   APIVersion1,
+					Service:   clientapi.NewAdminAPI(h),
+					Public:    true,
+				},
+			})
+		}
+
 		if cfg.DebugNamespaceEnabled {
 			rpcServer.RegisterAPIs([]rpc.API{
 				{
 					Namespace: APINamespaceDebug,
 					Version:   APIVersion1,
-					Service:   clientapi.NewNetworkDebug(h),
+					Service:   clientapi.NewNetworkDebug(h, p2p),
 					Public:    true,
 				},
 			})
@@ -0,0 +1,119 @@
+package container
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	hostcommon "github.com/ten-protocol/go-ten/go/common/host"
+	"github.com/ten-protocol/go-ten/go/host/rpc/clientrpc"
+)
+
+// drainRecorder records the steps of a drain in the order they complete
+type drainRecorder struct {
+	mu    sync.Mutex
+	steps []string
+}
+
+func (r *drainRecorder) record(step string) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.steps = append(r.steps, step)
+}
+
+func (r *drainRecorder) recorded() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]string{}, r.steps...)
+}
+
+type drainTestHost struct {
+	hostcommon.Host
+}
+
+func (h *drainTestHost) HealthCheck() (*hostcommon.HealthCheck, error) {
+	return &hostcommon.HealthCheck{OverallHealth: true}, nil
+}
+
+type drainTestP2P struct {
+	hostcommon.P2PHostService
+	recorder *drainRecorder
+	err      error
+}
+
+func (p *drainTestP2P) FlushPendingSends(context.Context) error {
+	p.recorder.record("p2p")
+	return p.err
+}
+
+// drainTestRPCServer has an RPC request in progress until it is released
+type drainTestRPCServer struct {
+	clientrpc.Server
+	recorder *drainRecorder
+	release  chan struct{}
+}
+
+func (s *drainTestRPCServer) Drain(ctx context.Context) error {
+	select {
+	case <-s.release:
+		s.recorder.record("rpc requests")
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (s *drainTestRPCServer) Stop() {
+	s.recorder.record("rpc server")
+}
+
+func newDrainTestContainer(p2pErr error) (*HostContainer, *drainTestRPCServer, *drainRecorder) {
+	recorder := &drainRecorder{}
+	rpcServer := &drainTestRPCServer{recorder: recorder, release: make(chan struct{})}
+	h := &HostContainer{
+		host:      &drainTestHost{},
+		logger:    gethlog.New(),
+		rpcServer: rpcServer,
+		p2p:       &drainTestP2P{recorder: recorder, err: p2pErr},
+	}
+	return h, rpcServer, recorder
+}
+
+func TestDrainStopsTheRPCServerAfterTheWorkInProgress(t *testing.T) {
+	h, rpcServer, recorder := newDrainTestContainer(nil)
+
+	require.NoError(t, h.ready())
+	h.StopReadiness()
+	require.Error(t, h.ready())
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- h.Drain(context.Background())
+	}()
+	select {
+	case <-drained:
+		t.Fatal("the host was drained with an RPC request in progress")
+	case <-time.After(50 * time.Millisecond):
+	}
+	require.Empty(t, recorder.recorded())
+
+	close(rpcServer.release)
+	require.NoError(t, <-drained)
+	require.Equal(t, []string{"rpc requests", "p2p", "rpc server"}, recorder.recorded())
+}
+
+func TestDrainReportsTheWorkNotCompleted(t *testing.T) {
+	h, _, recorder := newDrainTestContainer(errors.New("messages not delivered"))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	err := h.Drain(ctx)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.ErrorContains(t, err, "messages not delivered")
+	// the RPC server is stopped even if the work in progress did not complete in time
+	require.Equal(t, []string{"p2p", "rpc server"}, recorder.recorded())
+}
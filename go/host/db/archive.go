@@ -0,0 +1,147 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// DB methods relating to the archiving of the rolled-up batches to the object storage.
+// The rollups are indexed by the sequence number of their last batch, so the archiver can walk them in order. Each
+// archived segment covers the batches of a rollup, and its record keeps the hash of its manifest so the data fetched
+// back from the object storage, which is not trusted, can be verified.
+
+// ArchivedSegment is the record of a range of batches uploaded to the object storage
+type ArchivedSegment struct {
+	FirstBatchSeqNo uint64
+	LastBatchSeqNo  uint64
+	ManifestKey     string
+	ManifestHash    gethcommon.Hash // the sha256 of the manifest
+}
+
+// AddRollupBlob keeps the rollup as it was published on the L1, until it is archived
+func (db *DB) AddRollupBlob(hash common.L2RollupHash, blob []byte) error {
+	return db.kvStore.Put(rollupBlobKey(hash), blob)
+}
+
+// GetRollupBlob returns the rollup with the given hash as it was published on the L1, if it was not archived yet
+func (db *DB) GetRollupBlob(hash common.L2RollupHash) ([]byte, error) {
+	data, err := db.kvStore.Get(rollupBlobKey(hash))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) == 0 {
+		return nil, errutil.ErrNotFound
+	}
+	return data, nil
+}
+
+// GetRollupHeaderBySeqNo returns the header of the first rollup that includes the batches up to at least the given
+// sequence number, i.e. the rollup that published the batch if the rollups before it are known
+func (db *DB) GetRollupHeaderBySeqNo(seqNo uint64) (*common.RollupHeader, error) {
+	it := db.kvStore.NewIterator(rollupSeqNoPrefix, encodeSeqNo(seqNo))
+	defer it.Release()
+	if !it.Next() {
+		if err := it.Error(); err != nil {
+			return nil, err
+		}
+		return nil, errutil.ErrNotFound
+	}
+	return db.readRollupHeader(rollupHashKey(gethcommon.BytesToHash(it.Value())))
+}
+
+// NextSeqNoToArchive returns the sequence number of the oldest batch that was not archived yet. Before the first
+// segment is archived, it is the oldest batch that was not pruned, as the pruned batches cannot be archived anymore.
+func (db *DB) NextSeqNoToArchive() (*big.Int, error) {
+	data, err := db.kvStore.Get(nextSeqNoToArchiveKey)
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			return db.readNextSeqNoToPrune()
+		}
+		return nil, err
+	}
+	return big.NewInt(0).SetBytes(data), nil
+}
+
+// AddArchivedSegment records the segment, moves the archiving past its last batch and deletes the rollup blobs that
+// were archived with it
+func (db *DB) AddArchivedSegment(segment *ArchivedSegment, archivedRollups []common.L2RollupHash) error {
+	data, err := rlp.EncodeToBytes(segment)
+	if err != nil {
+		return fmt.Errorf("could not encode archived segment. Cause: %w", err)
+	}
+
+	b := db.kvStore.NewBatch()
+	if err := b.Put(archivedSegmentKey(segment.LastBatchSeqNo), data); err != nil {
+		return fmt.Errorf("could not write archived segment. Cause: %w", err)
+	}
+	nextSeqNo := new(big.Int).SetUint64(segment.LastBatchSeqNo + 1)
+	if err := b.Put(nextSeqNoToArchiveKey, nextSeqNo.Bytes()); err != nil {
+		return fmt.Errorf("could not write the next batch to archive. Cause: %w", err)
+	}
+	for _, hash := range archivedRollups {
+		if err := b.Delete(rollupBlobKey(hash)); err != nil {
+			return fmt.Errorf("could not delete archived rollup %s. Cause: %w", hash, err)
+		}
+	}
+	if err := b.Write(); err != nil {
+		return fmt.Errorf("could not write archived segment to DB. Cause: %w", err)
+	}
+	return nil
+}
+
+// GetArchivedSegment returns the archived segment that includes the batch with the given sequence number
+func (db *DB) GetArchivedSegment(seqNo uint64) (*ArchivedSegment, error) {
+	it := db.kvStore.NewIterator(archivedSegmentPrefix, encodeSeqNo(seqNo))
+	defer it.Release()
+	if !it.Next() {
+		if err := it.Error(); err != nil {
+			return nil, err
+		}
+		return nil, errutil.ErrNotFound
+	}
+	segment := new(ArchivedSegment)
+	if err := rlp.Decode(bytes.NewReader(it.Value()), segment); err != nil {
+		return nil, fmt.Errorf("could not decode archived segment. Cause: %w", err)
+	}
+	if segment.FirstBatchSeqNo > seqNo {
+		return nil, errutil.ErrNotFound
+	}
+	return segment, nil
+}
+
+// Stores the hash of the rollup, keyed by the sequence number of its last batch. A rollup republished with the same
+// batches replaces the previous one.
+func (db *DB) writeRollupBySeqNo(w ethdb.KeyValueWriter, header *common.RollupHeader) error {
+	return w.Put(rollupSeqNoKey(header.LastBatchSeqNo), header.Hash().Bytes())
+}
+
+// rollupBlobKey = rollupBlobPrefix + hash
+func rollupBlobKey(hash gethcommon.Hash) []byte {
+	return append(rollupBlobPrefix, hash.Bytes()...)
+}
+
+// rollupSeqNoKey = rollupSeqNoPrefix + big-endian seqNo, so the iterators return the rollups in batch order
+func rollupSeqNoKey(seqNo uint64) []byte {
+	return append(rollupSeqNoPrefix, encodeSeqNo(seqNo)...)
+}
+
+// archivedSegmentKey = archivedSegmentPrefix + big-endian seqNo of the last batch of the segment
+func archivedSegmentKey(lastSeqNo uint64) []byte {
+	return append(archivedSegmentPrefix, encodeSeqNo(lastSeqNo)...)
+}
+
+func encodeSeqNo(seqNo uint64) []byte {
+	enc := make([]byte, 8)
+	binary.BigEndian.PutUint64(enc, seqNo)
+	return enc
+}
@@ -132,6 +132,21 @@ func (db *DB) GetBatchBySequenceNumber(sequenceNumber *big.Int) (*common.ExtBatc
 	return db.GetBatch(*batchHash)
 }
 
+// GetBatchHashBySequenceNumber returns the hash of the batch with the given sequence number.
+func (db *DB) GetBatchHashBySequenceNumber(sequenceNumber *big.Int) (*gethcommon.Hash, error) {
+	db.batchReads.Inc(1)
+	return db.readBatchHashBySequenceNumber(sequenceNumber)
+}
+
+// Returns the header of the batch with the given sequence number.
+func (db *DB) getBatchHeaderBySequenceNumber(sequenceNumber *big.Int) (*common.BatchHeader, error) {
+	batchHash, err := db.readBatchHashBySequenceNumber(sequenceNumber)
+	if err != nil {
+		return nil, err
+	}
+	return db.readBatchHeader(*batchHash)
+}
+
 // GetBatchListing returns latest batches given a pagination.
 // For example, page 0, size 10 will return the latest 10 batches.
 // todo change this when the db changes - this is not super performant
@@ -142,11 +157,16 @@ func (db *DB) GetBatchListing(pagination *common.QueryPagination) (*common.Batch
 		return nil, err
 	}
 
+	// the batches are listed by sequence number, so the total includes the batches that are no longer canonical
+	total := header.SequencerOrderNo.Uint64() - common.L2GenesisSeqNo + 1
+	if pagination.Offset >= total {
+		return &common.BatchListingResponse{Total: total}, nil
+	}
 	batchesFrom := header.SequencerOrderNo.Uint64() - pagination.Offset
 	batchesToInclusive := int(batchesFrom) - int(pagination.Size) + 1
-	// batchesToInclusive can't go below zero
-	if batchesToInclusive < 0 {
-		batchesToInclusive = 0
+	// batchesToInclusive can't go below the genesis (the loop below would wrap around zero)
+	if batchesToInclusive < int(common.L2GenesisSeqNo) {
+		batchesToInclusive = int(common.L2GenesisSeqNo)
 	}
 
 	var batches []common.PublicBatch
@@ -159,12 +179,21 @@ func (db *DB) GetBatchListing(pagination *common.QueryPagination) (*common.Batch
 		}
 		if extBatch != nil {
 			batches = append(batches, common.PublicBatch{BatchHeader: *extBatch.Header, TxHashes: extBatch.TxHashes})
+			continue
+		}
+		// the transactions of the batch may have been pruned, in which case only the header is listed
+		batchHeader, err := db.getBatchHeaderBySequenceNumber(big.NewInt(int64(i)))
+		if err != nil && !errors.Is(err, errutil.ErrNotFound) {
+			return nil, err
+		}
+		if batchHeader != nil {
+			batches = append(batches, common.PublicBatch{BatchHeader: *batchHeader})
 		}
 	}
 
 	return &common.BatchListingResponse{
 		BatchesData: batches,
-		Total:       header.Number.Uint64(),
+		Total:       total,
 	}, nil
 }
 
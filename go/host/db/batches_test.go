@@ -272,4 +272,50 @@ func TestCanRetrieveTotalNumberOfTransactions(t *testing.T) {
 	}
 }
 
+func TestBatchListingStopsAtGenesis(t *testing.T) {
+	db := NewInMemoryDB(nil, nil)
+	var batches []*common.ExtBatch
+	for seqNo := common.L2GenesisSeqNo; seqNo <= 3; seqNo++ {
+		batch := common.ExtBatch{
+			Header: &common.BatchHeader{
+				Number:           new(big.Int).SetUint64(seqNo),
+				SequencerOrderNo: new(big.Int).SetUint64(seqNo),
+			},
+		}
+		if err := db.AddBatch(&batch); err != nil {
+			t.Fatalf("could not store batch. Cause: %s", err)
+		}
+		batches = append(batches, &batch)
+	}
+
+	tests := []struct {
+		name     string
+		offset   uint64
+		size     uint
+		expected []*common.ExtBatch
+	}{
+		{name: "latest batches", offset: 0, size: 2, expected: []*common.ExtBatch{batches[2], batches[1]}},
+		{name: "page past the genesis", offset: 1, size: 10, expected: []*common.ExtBatch{batches[1], batches[0]}},
+		{name: "offset at the genesis", offset: 2, size: 10, expected: []*common.ExtBatch{batches[0]}},
+		{name: "offset past the genesis", offset: 3, size: 10},
+		{name: "offset past the head", offset: 10, size: 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			listing, err := db.GetBatchListing(&common.QueryPagination{Offset: tt.offset, Size: tt.size})
+			if err != nil {
+				t.Fatalf("could not list batches. Cause: %s", err)
+			}
+			if len(listing.BatchesData) != len(tt.expected) {
+				t.Fatalf("expected %d batches, got %d", len(tt.expected), len(listing.BatchesData))
+			}
+			for i, batch := range tt.expected {
+				if listing.BatchesData[i].Hash() != batch.Hash() {
+					t.Errorf("expected batch %d to be %s, got %s", i, batch.Hash(), listing.BatchesData[i].Hash())
+				}
+			}
+		})
+	}
+}
+
 // todo (#718) - add tests of writing and reading extbatches.
@@ -51,6 +51,18 @@ func (db *DB) AddBlock(header *types.Header) error {
 		}
 	}
 
+	// The host starts processing the L1 from the block the network was deployed at, not from the L1 genesis.
+	firstHeight, err := db.readFirstBlockHeight()
+	if err != nil && !errors.Is(err, errutil.ErrNotFound) {
+		return fmt.Errorf("could not retrieve first block height. Cause: %w", err)
+	}
+	if firstHeight == nil || header.Number.Cmp(firstHeight) < 0 {
+		err = b.Put(firstBlockHeight, header.Number.Bytes())
+		if err != nil {
+			return fmt.Errorf("could not write first block height. Cause: %w", err)
+		}
+	}
+
 	if err = b.Write(); err != nil {
 		return fmt.Errorf("could not write batch to DB. Cause: %w", err)
 	}
@@ -66,18 +78,26 @@ func (db *DB) GetBlockListing(pagination *common.QueryPagination) (*common.Block
 	if err != nil {
 		return nil, err
 	}
+	firstHeight, err := db.readFirstBlockHeight()
+	if err != nil {
+		return nil, err
+	}
 
+	total := tipHeader.Number.Uint64() - firstHeight.Uint64() + 1
+	if pagination.Offset >= total {
+		return &common.BlockListingResponse{Total: total}, nil
+	}
 	blocksFrom := tipHeader.Number.Uint64() - pagination.Offset
 	blocksToInclusive := int(blocksFrom) - int(pagination.Size) + 1
-	// if blocksToInclusive would be negative, set it to 0
-	if blocksToInclusive < 0 {
-		blocksToInclusive = 0
+	// blocksToInclusive can't go below the first block processed by the host
+	if blocksToInclusive < int(firstHeight.Uint64()) {
+		blocksToInclusive = int(firstHeight.Uint64())
 	}
 
-	// fetch requested batches
+	// fetch requested blocks - counting the blocks rather than looping down to the height, as it can be zero
 	var blocks []common.PublicBlock
-	for i := blocksFrom; i > uint64(blocksToInclusive); i-- {
-		header, err := db.GetBlockByHeight(big.NewInt(int64(i)))
+	for i := uint64(0); i <= blocksFrom-uint64(blocksToInclusive); i++ {
+		header, err := db.GetBlockByHeight(new(big.Int).SetUint64(blocksFrom - i))
 		if err != nil {
 			return nil, err
 		}
@@ -98,7 +118,7 @@ func (db *DB) GetBlockListing(pagination *common.QueryPagination) (*common.Block
 
 	return &common.BlockListingResponse{
 		BlocksData: blocks,
-		Total:      tipHeader.Number.Uint64(),
+		Total:      total,
 	}, nil
 }
 
@@ -113,6 +133,15 @@ func (db *DB) GetBlockAtTip() (*types.Header, error) {
 	return db.GetBlockByHash(h)
 }
 
+// Retrieves the height of the first block processed by the host
+func (db *DB) readFirstBlockHeight() (*big.Int, error) {
+	value, err := db.kvStore.Get(firstBlockHeight)
+	if err != nil {
+		return nil, err
+	}
+	return new(big.Int).SetBytes(value), nil
+}
+
 // Stores the hash of the block at tip
 func (db *DB) writeBlockAtTip(w ethdb.KeyValueWriter, hash gethcommon.Hash) error {
 	err := w.Put(blockHeadedAtTip, hash.Bytes())
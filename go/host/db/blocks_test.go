@@ -5,6 +5,7 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/errutil"
 
 	"github.com/ethereum/go-ethereum/core/types"
@@ -41,3 +42,26 @@ func TestUnknownBlockHeaderReturnsNotFound(t *testing.T) {
 		t.Errorf("did not store block header but was able to retrieve it")
 	}
 }
+
+func TestBlockListingStartsAtTheFirstBlockProcessed(t *testing.T) {
+	db := NewInMemoryDB(nil, nil)
+	// the host starts processing the L1 from the block the network was deployed at
+	for height := int64(batchNumber); height < batchNumber+3; height++ {
+		if err := db.AddBlock(&types.Header{Number: big.NewInt(height)}); err != nil {
+			t.Fatalf("could not add block header. Cause: %s", err)
+		}
+	}
+
+	for _, offset := range []uint64{0, 1, 2, 3} {
+		listing, err := db.GetBlockListing(&common.QueryPagination{Offset: offset, Size: 10})
+		if err != nil {
+			t.Fatalf("could not list blocks. Cause: %s", err)
+		}
+		if listing.Total != 3 {
+			t.Errorf("expected 3 blocks in total, got %d", listing.Total)
+		}
+		if len(listing.BlocksData) != 3-int(offset) {
+			t.Errorf("offset %d: expected %d blocks, got %d", offset, 3-int(offset), len(listing.BlocksData))
+		}
+	}
+}
@@ -3,6 +3,7 @@ package db
 import (
 	"fmt"
 	"os"
+	"path/filepath"
 
 	"github.com/ten-protocol/go-ten/go/config"
 
@@ -15,6 +16,8 @@ import (
 
 // Schema keys, in alphabetical order.
 var (
+	nextSeqNoToArchiveKey   = []byte("ab")
+	archivedSegmentPrefix   = []byte("as")
 	blockHeaderPrefix       = []byte("b")
 	blockNumberHeaderPrefix = []byte("bnh")
 	batchHeaderPrefix       = []byte("ba")
@@ -24,11 +27,23 @@ var (
 	batchHashForSeqNoPrefix = []byte("bs")
 	batchTxHashesPrefix     = []byte("bt")
 	headBatch               = []byte("hb")
+	nextSeqNoToPruneKey     = []byte("pb")
 	totalTransactionsKey    = []byte("t")
 	rollupHeaderPrefix      = []byte("rh")
 	rollupHeaderBlockPrefix = []byte("rhb")
+	rollupBlobPrefix        = []byte("rb")
+	rollupSeqNoPrefix       = []byte("rs")
 	tipRollupHash           = []byte("tr")
 	blockHeadedAtTip        = []byte("bht")
+	firstBlockHeight        = []byte("fbh")
+	schemaVersionKey        = []byte("sv")
+)
+
+// The backends of the persistent host DB
+const (
+	LevelDBType = "leveldb"
+	PebbleType  = "pebble"
+	SQLiteType  = "sqlite"
 )
 
 // DB allows to access the nodes public nodeDB
@@ -59,14 +74,26 @@ func CreateDBFromConfig(cfg *config.HostConfig, regMetrics gethmetrics.Registry,
 		logger.Info("UseInMemoryDB flag is true, data will not be persisted. Creating in-memory database...")
 		return NewInMemoryDB(regMetrics, logger), nil
 	}
-	return NewLevelDBBackedDB(cfg.LevelDBPath, regMetrics, logger)
+	switch cfg.DBType {
+	case PebbleType:
+		return NewPebbleBackedDB(cfg.LevelDBPath, regMetrics, logger)
+	case SQLiteType:
+		return NewSQLiteBackedDB(cfg.LevelDBPath, regMetrics, logger)
+	default:
+		return NewLevelDBBackedDB(cfg.LevelDBPath, regMetrics, logger)
+	}
 }
 
 func validateDBConf(cfg *config.HostConfig) error {
 	if cfg.UseInMemoryDB && cfg.LevelDBPath != "" {
 		return fmt.Errorf("useInMemoryDB=true so levelDB will not be used and no path is needed, but levelDBPath=%s", cfg.LevelDBPath)
 	}
-	return nil
+	switch cfg.DBType {
+	case "", LevelDBType, PebbleType, SQLiteType:
+		return nil
+	default:
+		return fmt.Errorf("unknown host DB type %s, expected %s, %s or %s", cfg.DBType, LevelDBType, PebbleType, SQLiteType)
+	}
 }
 
 // NewInMemoryDB returns a new instance of the Node DB
@@ -76,20 +103,9 @@ func NewInMemoryDB(regMetrics gethmetrics.Registry, logger gethlog.Logger) *DB {
 
 // NewLevelDBBackedDB creates a persistent DB for the host, if dbPath == "" it will generate a temp file
 func NewLevelDBBackedDB(dbPath string, regMetrics gethmetrics.Registry, logger gethlog.Logger) (*DB, error) {
-	var err error
-	if dbPath == "" {
-		// todo (#1618) - we should remove this option before prod, if you want a temp DB it should be wired in via the config
-		dbPath, err = os.MkdirTemp("", "leveldb_*")
-		if err != nil {
-			return nil, fmt.Errorf("could not create temp leveldb directory - %w", err)
-		}
-		logger.Warn("dbPath was empty, created temp dir for persistence", "dbPath", dbPath)
-	}
-	// determine if a db file already exists, we don't want to overwrite it
-	_, err = os.Stat(dbPath)
-	dbDesc := "new"
-	if err == nil {
-		dbDesc = "existing"
+	dbPath, dbDesc, err := persistencePath(dbPath, "leveldb_*", "", logger)
+	if err != nil {
+		return nil, err
 	}
 
 	// todo (#1618) - these should be configs
@@ -100,7 +116,36 @@ func NewLevelDBBackedDB(dbPath string, regMetrics gethmetrics.Registry, logger g
 		return nil, fmt.Errorf("could not create leveldb - %w", err)
 	}
 	logger.Info(fmt.Sprintf("Opened %s level db dir at %s", dbDesc, dbPath))
-	return newDB(&ObscuroLevelDB{db: db}, regMetrics, logger), nil
+	return openDB(&ObscuroLevelDB{db: db}, regMetrics, logger)
+}
+
+// persistencePath returns the path of the persistent DB, and whether it is new or existing. If dbPath == "" it creates
+// a temp dir, and the DB is the file in it if the backend stores a file.
+func persistencePath(dbPath string, tempPattern string, tempFile string, logger gethlog.Logger) (string, string, error) {
+	if dbPath == "" {
+		// todo (#1618) - we should remove this option before prod, if you want a temp DB it should be wired in via the config
+		dir, err := os.MkdirTemp("", tempPattern)
+		if err != nil {
+			return "", "", fmt.Errorf("could not create temp DB directory - %w", err)
+		}
+		dbPath = filepath.Join(dir, tempFile)
+		logger.Warn("dbPath was empty, created temp dir for persistence", "dbPath", dbPath)
+	}
+	// determine if a db file already exists, we don't want to overwrite it
+	if _, err := os.Stat(dbPath); err == nil {
+		return dbPath, "existing", nil
+	}
+	return dbPath, "new", nil
+}
+
+// openDB brings the schema of the persistent DB up to date
+func openDB(kvStore ethdb.KeyValueStore, regMetrics gethmetrics.Registry, logger gethlog.Logger) (*DB, error) {
+	db := newDB(kvStore, regMetrics, logger)
+	if err := db.migrate(); err != nil {
+		_ = kvStore.Close()
+		return nil, err
+	}
+	return db, nil
 }
 
 func newDB(kvStore ethdb.KeyValueStore, regMetrics gethmetrics.Registry, logger gethlog.Logger) *DB {
@@ -0,0 +1,123 @@
+package db
+
+import (
+	"encoding/binary"
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+)
+
+var persistentBackends = map[string]func(string) (*DB, error){
+	LevelDBType: func(path string) (*DB, error) { return NewLevelDBBackedDB(path, nil, gethlog.New()) },
+	PebbleType:  func(path string) (*DB, error) { return NewPebbleBackedDB(path, nil, gethlog.New()) },
+	SQLiteType:  func(path string) (*DB, error) { return NewSQLiteBackedDB(path, nil, gethlog.New()) },
+}
+
+func TestPersistentBackendsKeepTheHeads(t *testing.T) {
+	for name, open := range persistentBackends {
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "host")
+			db, err := open(path)
+			if err != nil {
+				t.Fatalf("could not open DB. Cause: %s", err)
+			}
+			for number := int64(10); number <= 12; number++ {
+				if err := db.AddBlock(&types.Header{Number: big.NewInt(number)}); err != nil {
+					t.Fatalf("could not add block header. Cause: %s", err)
+				}
+			}
+			for seqNo := int64(1); seqNo <= 3; seqNo++ {
+				if err := db.AddBatch(retentionTestBatch(seqNo, time.Now())); err != nil {
+					t.Fatalf("could not add batch. Cause: %s", err)
+				}
+			}
+			if err := db.Stop(); err != nil {
+				t.Fatalf("could not close DB. Cause: %s", err)
+			}
+
+			db, err = open(path)
+			if err != nil {
+				t.Fatalf("could not reopen DB. Cause: %s", err)
+			}
+			defer db.Stop() //nolint:errcheck
+			tip, err := db.GetBlockAtTip()
+			if err != nil || tip.Number.Int64() != 12 {
+				t.Fatalf("expected the block at tip to be 12, got %v. Cause: %v", tip, err)
+			}
+			first, err := db.readFirstBlockHeight()
+			if err != nil || first.Int64() != 10 {
+				t.Fatalf("expected the first block height to be 10, got %v. Cause: %v", first, err)
+			}
+			head, err := db.GetHeadBatchHeader()
+			if err != nil || head.SequencerOrderNo.Int64() != 3 {
+				t.Fatalf("expected the head batch to be 3, got %v. Cause: %v", head, err)
+			}
+			if _, err = db.GetBatchBySequenceNumber(big.NewInt(4)); !errors.Is(err, errutil.ErrNotFound) {
+				t.Fatalf("expected not found for an unknown batch, got %v", err)
+			}
+
+			// the iterators only return the keys with the prefix, from start
+			it := db.kvStore.NewIterator(batchHashForSeqNoPrefix, []byte("2"))
+			defer it.Release()
+			var keys int
+			for it.Next() {
+				keys++
+			}
+			if it.Error() != nil || keys != 2 {
+				t.Fatalf("expected 2 batches from sequence number 2, got %d. Cause: %v", keys, it.Error())
+			}
+		})
+	}
+}
+
+func TestMigratesTheDBsWrittenBeforeTheSchemaVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "host.db")
+	db, err := NewSQLiteBackedDB(path, nil, gethlog.New())
+	if err != nil {
+		t.Fatalf("could not open DB. Cause: %s", err)
+	}
+	// the blocks stored before the first block height was recorded, without a schema version
+	for number := int64(5); number <= 7; number++ {
+		if err := db.AddBlock(&types.Header{Number: big.NewInt(number)}); err != nil {
+			t.Fatalf("could not add block header. Cause: %s", err)
+		}
+	}
+	for _, key := range [][]byte{firstBlockHeight, schemaVersionKey} {
+		if err := db.kvStore.Delete(key); err != nil {
+			t.Fatalf("could not delete key. Cause: %s", err)
+		}
+	}
+	if err := db.Stop(); err != nil {
+		t.Fatalf("could not close DB. Cause: %s", err)
+	}
+
+	db, err = NewSQLiteBackedDB(path, nil, gethlog.New())
+	if err != nil {
+		t.Fatalf("could not reopen DB. Cause: %s", err)
+	}
+	first, err := db.readFirstBlockHeight()
+	if err != nil || first.Int64() != 5 {
+		t.Fatalf("expected the first block height to be 5, got %v. Cause: %v", first, err)
+	}
+	version, err := db.readSchemaVersion()
+	if err != nil || version != currentSchemaVersion() {
+		t.Fatalf("expected schema version %d, got %d. Cause: %v", currentSchemaVersion(), version, err)
+	}
+
+	// a DB written by a newer host is not opened
+	if err := db.kvStore.Put(schemaVersionKey, binary.BigEndian.AppendUint64(nil, currentSchemaVersion()+1)); err != nil {
+		t.Fatalf("could not write schema version. Cause: %s", err)
+	}
+	if err := db.Stop(); err != nil {
+		t.Fatalf("could not close DB. Cause: %s", err)
+	}
+	if _, err = NewSQLiteBackedDB(path, nil, gethlog.New()); err == nil {
+		t.Fatal("expected the DB with a newer schema version to be rejected")
+	}
+}
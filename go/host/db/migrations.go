@@ -0,0 +1,108 @@
+package db
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+)
+
+// migration changes the data of the DB from the schema version before it, e.g. to write a new index for the existing
+// data. The migrations are applied in order when a persistent DB is opened.
+type migration struct {
+	name  string
+	apply func(db *DB) error
+}
+
+// the schema versions of the DB, starting at 1. The DBs written before the schema was versioned are at version 1
+var migrations = []migration{
+	{name: "record the first block height", apply: recordFirstBlockHeight},
+}
+
+func currentSchemaVersion() uint64 {
+	return uint64(len(migrations)) + 1
+}
+
+// migrate applies the migrations from the schema version of the DB. A new DB is at the current version.
+func (db *DB) migrate() error {
+	version, err := db.readSchemaVersion()
+	if err != nil {
+		return err
+	}
+	if version > currentSchemaVersion() {
+		return fmt.Errorf("the host DB has schema version %d, written by a newer version of the host than the supported version %d", version, currentSchemaVersion())
+	}
+	for ; version < currentSchemaVersion(); version++ {
+		m := migrations[version-1]
+		db.logger.Info("Migrating the host DB", "migration", m.name, "version", version+1)
+		if err := m.apply(db); err != nil {
+			return fmt.Errorf("could not migrate the host DB to schema version %d (%s) - %w", version+1, m.name, err)
+		}
+		if err := db.writeSchemaVersion(version + 1); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Retrieves the schema version of the DB. A DB without a version is at the current version if it is empty, or at
+// version 1 if it was written before the schema was versioned.
+func (db *DB) readSchemaVersion() (uint64, error) {
+	value, err := db.kvStore.Get(schemaVersionKey)
+	if err == nil {
+		return binary.BigEndian.Uint64(value), nil
+	}
+	if !errors.Is(err, errutil.ErrNotFound) {
+		return 0, fmt.Errorf("could not read the host DB schema version - %w", err)
+	}
+	it := db.kvStore.NewIterator(nil, nil)
+	defer it.Release()
+	if it.Next() {
+		return 1, nil
+	}
+	if err := it.Error(); err != nil {
+		return 0, err
+	}
+	version := currentSchemaVersion()
+	return version, db.writeSchemaVersion(version)
+}
+
+// Stores the schema version of the DB
+func (db *DB) writeSchemaVersion(version uint64) error {
+	if err := db.kvStore.Put(schemaVersionKey, binary.BigEndian.AppendUint64(nil, version)); err != nil {
+		return fmt.Errorf("could not write the host DB schema version - %w", err)
+	}
+	return nil
+}
+
+// recordFirstBlockHeight stores the height of the first block processed by the host, which is only recorded for the
+// blocks stored from version 2. It is the lowest of the blocks stored by height.
+func recordFirstBlockHeight(db *DB) error {
+	if _, err := db.kvStore.Get(firstBlockHeight); err == nil || !errors.Is(err, errutil.ErrNotFound) {
+		return err
+	}
+	var first *big.Int
+	it := db.kvStore.NewIterator(blockNumberHeaderPrefix, nil)
+	defer it.Release()
+	for it.Next() {
+		header := new(types.Header)
+		if err := rlp.Decode(bytes.NewReader(it.Value()), header); err != nil {
+			return fmt.Errorf("could not decode block header - %w", err)
+		}
+		if first == nil || header.Number.Cmp(first) < 0 {
+			first = header.Number
+		}
+	}
+	if err := it.Error(); err != nil {
+		return err
+	}
+	if first == nil {
+		return nil
+	}
+	return db.kvStore.Put(firstBlockHeight, first.Bytes())
+}
@@ -0,0 +1,49 @@
+//go:build (arm64 || amd64) && !openbsd
+
+package db
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/cockroachdb/pebble"
+	ethpebble "github.com/ethereum/go-ethereum/ethdb/pebble"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+)
+
+// ObscuroPebbleDB is a thin wrapper around a pebble database, which returns the obscuro ErrNotFound
+type ObscuroPebbleDB struct {
+	*ethpebble.Database
+}
+
+// Get is overridden here to return our internal NotFound error
+func (o *ObscuroPebbleDB) Get(key []byte) ([]byte, error) {
+	d, err := o.Database.Get(key)
+	if err != nil {
+		if errors.Is(err, pebble.ErrNotFound) {
+			return nil, errutil.ErrNotFound
+		}
+		return nil, err
+	}
+	return d, nil
+}
+
+// NewPebbleBackedDB creates a persistent DB for the host backed by pebble, if dbPath == "" it will generate a temp dir
+func NewPebbleBackedDB(dbPath string, regMetrics gethmetrics.Registry, logger gethlog.Logger) (*DB, error) {
+	dbPath, dbDesc, err := persistencePath(dbPath, "pebble_*", "", logger)
+	if err != nil {
+		return nil, err
+	}
+
+	// todo (#1618) - these should be configs
+	cache := 128
+	handles := 128
+	db, err := ethpebble.New(dbPath, cache, handles, "host", false)
+	if err != nil {
+		return nil, fmt.Errorf("could not create pebble db - %w", err)
+	}
+	logger.Info(fmt.Sprintf("Opened %s pebble db dir at %s", dbDesc, dbPath))
+	return openDB(&ObscuroPebbleDB{Database: db}, regMetrics, logger)
+}
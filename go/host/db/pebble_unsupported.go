@@ -0,0 +1,15 @@
+//go:build !((arm64 || amd64) && !openbsd)
+
+package db
+
+import (
+	"errors"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+// NewPebbleBackedDB fails on the platforms not supported by pebble
+func NewPebbleBackedDB(string, gethmetrics.Registry, gethlog.Logger) (*DB, error) {
+	return nil, errors.New("the pebble host DB is not supported on this platform")
+}
@@ -0,0 +1,161 @@
+package db
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+)
+
+// DB methods relating to the pruning of the archived batches.
+// The batch headers, the mappings from batch number and sequence number to batch hash, the rollup headers and the
+// aggregates (e.g. the total number of transactions) are kept forever. The batch bodies and the transaction indexes are
+// the bulk of the data, and they are pruned once they were archived to the object storage, which serves them back.
+// Only the batches included in a rollup are pruned: the nodes that fall behind recover them from the L1, and the
+// batches that are not rolled up yet are still served to the peers and published by the sequencer.
+
+// PruneArchivedBatches deletes the bodies and transaction indexes of at most maxBatches archived batches created before
+// the cutoff, in sequence number order. It returns the number of batches that were pruned.
+func (db *DB) PruneArchivedBatches(cutoff time.Time, maxBatches int) (int, error) {
+	nextSeqNoToArchive, err := db.NextSeqNoToArchive()
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve the next batch to archive. Cause: %w", err)
+	}
+	return db.pruneBatches(cutoff, maxBatches, nextSeqNoToArchive)
+}
+
+// pruneBatches deletes the bodies and transaction indexes of at most maxBatches batches created before the cutoff, up to
+// the last batch of the tip rollup, and not from the limit on either if the limit is not nil
+func (db *DB) pruneBatches(cutoff time.Time, maxBatches int, limit *big.Int) (int, error) {
+	tipRollup, err := db.GetTipRollupHeader()
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("could not retrieve tip rollup header. Cause: %w", err)
+	}
+	rolledUp := new(big.Int).SetUint64(tipRollup.LastBatchSeqNo + 1)
+	if limit == nil || rolledUp.Cmp(limit) < 0 {
+		limit = rolledUp
+	}
+	head, err := db.GetHeadBatchHeader()
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("could not retrieve head batch header. Cause: %w", err)
+	}
+	// the head batch is never pruned
+	if head.SequencerOrderNo.Cmp(limit) < 0 {
+		limit = head.SequencerOrderNo
+	}
+	seqNo, err := db.readNextSeqNoToPrune()
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve the next batch to prune. Cause: %w", err)
+	}
+
+	b := db.kvStore.NewBatch()
+	pruned := 0
+	for ; pruned < maxBatches && seqNo.Cmp(limit) < 0; seqNo.Add(seqNo, big.NewInt(1)) {
+		batchHash, err := db.readBatchHashBySequenceNumber(seqNo)
+		if err != nil {
+			if errors.Is(err, errutil.ErrNotFound) {
+				// the host has not received this batch yet, so we stop here to prune it once it arrives
+				break
+			}
+			return 0, fmt.Errorf("could not retrieve batch hash for seqNo=%d. Cause: %w", seqNo, err)
+		}
+		header, err := db.readBatchHeader(*batchHash)
+		if err != nil {
+			return 0, fmt.Errorf("could not retrieve batch header for seqNo=%d. Cause: %w", seqNo, err)
+		}
+		if header.Time >= uint64(cutoff.Unix()) {
+			break
+		}
+		if err := db.deleteBatchTxData(b, *batchHash); err != nil {
+			return 0, fmt.Errorf("could not prune batch with seqNo=%d. Cause: %w", seqNo, err)
+		}
+		pruned++
+	}
+	if pruned == 0 {
+		return 0, nil
+	}
+
+	if err := db.writeNextSeqNoToPrune(b, seqNo); err != nil {
+		return 0, fmt.Errorf("could not write the next batch to prune. Cause: %w", err)
+	}
+	if err := b.Write(); err != nil {
+		return 0, fmt.Errorf("could not write pruned batches to DB. Cause: %w", err)
+	}
+	return pruned, nil
+}
+
+// IsBatchPruned returns true if the transaction data of the batch with the given sequence number was pruned
+func (db *DB) IsBatchPruned(seqNo *big.Int) (bool, error) {
+	nextSeqNoToPrune, err := db.readNextSeqNoToPrune()
+	if err != nil {
+		return false, err
+	}
+	return seqNo.Cmp(nextSeqNoToPrune) < 0, nil
+}
+
+// CompactPrunedData compacts the key ranges affected by pruning, so that the disk space they used is reclaimed
+func (db *DB) CompactPrunedData() error {
+	for _, prefix := range [][]byte{batchPrefix, batchTxHashesPrefix, batchNumberPrefix} {
+		if err := db.kvStore.Compact(prefix, prefixLimit(prefix)); err != nil {
+			return fmt.Errorf("could not compact keys with prefix %s. Cause: %w", prefix, err)
+		}
+	}
+	return nil
+}
+
+// Deletes the body and the transaction indexes of the batch with the given hash.
+func (db *DB) deleteBatchTxData(w ethdb.KeyValueWriter, batchHash common.L2BatchHash) error {
+	txHashes, err := db.readBatchTxHashes(batchHash)
+	if err != nil && !errors.Is(err, errutil.ErrNotFound) {
+		return err
+	}
+	for _, txHash := range txHashes {
+		if err := w.Delete(batchNumberKey(txHash)); err != nil {
+			return err
+		}
+	}
+	if err := w.Delete(batchTxHashesKey(batchHash)); err != nil {
+		return err
+	}
+	return w.Delete(batchKey(batchHash))
+}
+
+// Retrieves the sequence number of the oldest batch that was not pruned yet.
+func (db *DB) readNextSeqNoToPrune() (*big.Int, error) {
+	data, err := db.kvStore.Get(nextSeqNoToPruneKey)
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			return big.NewInt(int64(common.L2GenesisSeqNo)), nil
+		}
+		return nil, err
+	}
+	return big.NewInt(0).SetBytes(data), nil
+}
+
+// Stores the sequence number of the oldest batch that was not pruned yet.
+func (db *DB) writeNextSeqNoToPrune(w ethdb.KeyValueWriter, seqNo *big.Int) error {
+	return w.Put(nextSeqNoToPruneKey, seqNo.Bytes())
+}
+
+// prefixLimit returns the smallest key that is greater than all the keys with the given prefix
+func prefixLimit(prefix []byte) []byte {
+	limit := make([]byte, len(prefix))
+	copy(limit, prefix)
+	for i := len(limit) - 1; i >= 0; i-- {
+		if limit[i] < 0xff {
+			limit[i]++
+			return limit[:i+1]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,166 @@
+package db
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+)
+
+func TestPruningKeepsHeadersAndAggregates(t *testing.T) {
+	db := NewInMemoryDB(nil, nil)
+	now := time.Now()
+	batches := []*common.ExtBatch{
+		retentionTestBatch(1, now.Add(-3*time.Hour)),
+		retentionTestBatch(2, now.Add(-2*time.Hour)),
+		retentionTestBatch(3, now),
+		retentionTestBatch(4, now), // head
+	}
+	for _, batch := range batches {
+		if err := db.AddBatch(batch); err != nil {
+			t.Fatalf("could not store batch. Cause: %s", err)
+		}
+	}
+
+	addRetentionTestRollup(t, db, 4)
+
+	pruned, err := db.pruneBatches(now.Add(-time.Hour), 10, nil)
+	if err != nil {
+		t.Fatalf("could not prune batches. Cause: %s", err)
+	}
+	if pruned != 2 {
+		t.Fatalf("expected 2 pruned batches, got %d", pruned)
+	}
+
+	for _, batch := range batches[:2] {
+		if _, err := db.GetBatch(batch.Hash()); !errors.Is(err, errutil.ErrNotFound) {
+			t.Errorf("batch body was not pruned")
+		}
+		if _, err := db.GetBatchNumber(batch.TxHashes[0]); !errors.Is(err, errutil.ErrNotFound) {
+			t.Errorf("transaction index was not pruned")
+		}
+		if _, err := db.GetBatchHeader(batch.Hash()); err != nil {
+			t.Errorf("batch header was pruned")
+		}
+	}
+	for _, batch := range batches[2:] {
+		if _, err := db.GetBatch(batch.Hash()); err != nil {
+			t.Errorf("batch within the retention period was pruned")
+		}
+	}
+	total, err := db.GetTotalTransactions()
+	if err != nil || total.Int64() != int64(len(batches)) {
+		t.Errorf("total number of transactions was not kept")
+	}
+
+	// the pruned batches are not pruned twice
+	pruned, err = db.pruneBatches(now.Add(-time.Hour), 10, nil)
+	if err != nil || pruned != 0 {
+		t.Errorf("expected no batch to be pruned, got %d. Cause: %v", pruned, err)
+	}
+}
+
+func TestPruningStopsAtMaxBatches(t *testing.T) {
+	db := NewInMemoryDB(nil, nil)
+	old := time.Now().Add(-time.Hour)
+	for seqNo := int64(1); seqNo <= 5; seqNo++ {
+		if err := db.AddBatch(retentionTestBatch(seqNo, old)); err != nil {
+			t.Fatalf("could not store batch. Cause: %s", err)
+		}
+	}
+
+	addRetentionTestRollup(t, db, 5)
+
+	// the head batch is never pruned, even if it was rolled up
+	for _, expected := range []int{3, 1, 0} {
+		pruned, err := db.pruneBatches(time.Now(), 3, nil)
+		if err != nil {
+			t.Fatalf("could not prune batches. Cause: %s", err)
+		}
+		if pruned != expected {
+			t.Errorf("expected %d pruned batches, got %d", expected, pruned)
+		}
+	}
+}
+
+func TestPruningKeepsBatchesNotRolledUp(t *testing.T) {
+	db := NewInMemoryDB(nil, nil)
+	old := time.Now().Add(-time.Hour)
+	for seqNo := int64(1); seqNo <= 5; seqNo++ {
+		if err := db.AddBatch(retentionTestBatch(seqNo, old)); err != nil {
+			t.Fatalf("could not store batch. Cause: %s", err)
+		}
+	}
+
+	// without a rollup, the batches are still served to the peers and nothing is pruned
+	pruned, err := db.pruneBatches(time.Now(), 10, nil)
+	if err != nil || pruned != 0 {
+		t.Fatalf("expected no batch to be pruned, got %d. Cause: %v", pruned, err)
+	}
+
+	addRetentionTestRollup(t, db, 2)
+	pruned, err = db.pruneBatches(time.Now(), 10, nil)
+	if err != nil || pruned != 2 {
+		t.Fatalf("expected 2 pruned batches, got %d. Cause: %v", pruned, err)
+	}
+	for seqNo := int64(1); seqNo <= 5; seqNo++ {
+		isPruned, err := db.IsBatchPruned(big.NewInt(seqNo))
+		if err != nil {
+			t.Fatalf("could not check whether the batch was pruned. Cause: %s", err)
+		}
+		if isPruned != (seqNo <= 2) {
+			t.Errorf("batch %d: expected pruned to be %t", seqNo, seqNo <= 2)
+		}
+	}
+}
+
+func TestPrunedBatchIsNotStoredAgain(t *testing.T) {
+	db := NewInMemoryDB(nil, nil)
+	old := time.Now().Add(-time.Hour)
+	batch := retentionTestBatch(1, old)
+	for _, b := range []*common.ExtBatch{batch, retentionTestBatch(2, old)} {
+		if err := db.AddBatch(b); err != nil {
+			t.Fatalf("could not store batch. Cause: %s", err)
+		}
+	}
+	addRetentionTestRollup(t, db, 2)
+	if _, err := db.pruneBatches(time.Now(), 10, nil); err != nil {
+		t.Fatalf("could not prune batches. Cause: %s", err)
+	}
+
+	if err := db.AddBatch(batch); !errors.Is(err, errutil.ErrAlreadyExists) {
+		t.Errorf("expected the pruned batch to already exist, got %v", err)
+	}
+	if _, err := db.GetBatch(batch.Hash()); !errors.Is(err, errutil.ErrNotFound) {
+		t.Errorf("pruned batch was stored again")
+	}
+	total, err := db.GetTotalTransactions()
+	if err != nil || total.Int64() != 2 {
+		t.Errorf("adding a pruned batch changed the total number of transactions")
+	}
+}
+
+func retentionTestBatch(seqNo int64, created time.Time) *common.ExtBatch {
+	return &common.ExtBatch{
+		Header: &common.BatchHeader{
+			Number:           big.NewInt(seqNo),
+			SequencerOrderNo: big.NewInt(seqNo),
+			Time:             uint64(created.Unix()),
+		},
+		TxHashes: []gethcommon.Hash{gethcommon.BigToHash(big.NewInt(seqNo))},
+	}
+}
+
+// addRetentionTestRollup stores a rollup that includes the batches up to lastBatchSeqNo
+func addRetentionTestRollup(t *testing.T, db *DB, lastBatchSeqNo uint64) {
+	rollup := &common.ExtRollup{Header: &common.RollupHeader{LastBatchSeqNo: lastBatchSeqNo}}
+	block := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(int64(lastBatchSeqNo))})
+	if err := db.AddRollupHeader(rollup, block); err != nil {
+		t.Fatalf("could not store rollup. Cause: %s", err)
+	}
+}
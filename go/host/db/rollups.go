@@ -3,6 +3,7 @@ package db
 import (
 	"bytes"
 	"fmt"
+	"math/big"
 
 	"github.com/ethereum/go-ethereum/ethdb"
 	"github.com/ethereum/go-ethereum/rlp"
@@ -37,6 +38,10 @@ func (db *DB) AddRollupHeader(rollup *common.ExtRollup, block *common.L1Block) e
 		return fmt.Errorf("could not write rollup block. Cause: %w", err)
 	}
 
+	if err := db.writeRollupBySeqNo(b, rollup.Header); err != nil {
+		return fmt.Errorf("could not write rollup seqNo. Cause: %w", err)
+	}
+
 	// Update the tip if the new height is greater than the existing one.
 	tipRollupHeader, err := db.GetTipRollupHeader()
 	if err != nil && !errors.Is(err, errutil.ErrNotFound) {
@@ -74,6 +79,30 @@ func (db *DB) GetRollupHeaderByBlock(blockHash gethcommon.Hash) (*common.RollupH
 	return db.readRollupHeader(rollupBlockKey(blockHash))
 }
 
+// GetLatestRollupHeader returns the header of the last rollup published in the L1 blocks at or below the given height,
+// walking down the blocks stored by height to the first block processed by the host
+func (db *DB) GetLatestRollupHeader(maxHeight *big.Int) (*common.RollupHeader, error) {
+	firstHeight, err := db.readFirstBlockHeight()
+	if err != nil {
+		return nil, err
+	}
+	for height := new(big.Int).Set(maxHeight); height.Cmp(firstHeight) >= 0; height.Sub(height, big.NewInt(1)) {
+		block, err := db.GetBlockByHeight(height)
+		if errors.Is(err, errutil.ErrNotFound) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve block at height %d. Cause: %w", height, err)
+		}
+		rollupHeader, err := db.GetRollupHeaderByBlock(block.Hash())
+		if errors.Is(err, errutil.ErrNotFound) {
+			continue
+		}
+		return rollupHeader, err
+	}
+	return nil, errutil.ErrNotFound
+}
+
 // Retrieves the rollup corresponding to the hash.
 func (db *DB) readRollupHeader(key []byte) (*common.RollupHeader, error) {
 	data, err := db.kvStore.Get(key)
@@ -0,0 +1,46 @@
+package db
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+func TestLatestRollupHeaderIsTheLastOneAtOrBelowTheHeight(t *testing.T) {
+	db := NewInMemoryDB(nil, nil)
+	rollupsByHeight := map[int64]uint64{batchNumber + 1: 5, batchNumber + 3: 9}
+	for height := int64(batchNumber); height < batchNumber+5; height++ {
+		header := &types.Header{Number: big.NewInt(height)}
+		if err := db.AddBlock(header); err != nil {
+			t.Fatalf("could not add block header. Cause: %s", err)
+		}
+		if lastBatchSeqNo, ok := rollupsByHeight[height]; ok {
+			rollup := &common.ExtRollup{Header: &common.RollupHeader{LastBatchSeqNo: lastBatchSeqNo}}
+			if err := db.AddRollupHeader(rollup, types.NewBlockWithHeader(header)); err != nil {
+				t.Fatalf("could not store rollup. Cause: %s", err)
+			}
+		}
+	}
+
+	for maxHeight, expected := range map[int64]uint64{batchNumber + 1: 5, batchNumber + 2: 5, batchNumber + 4: 9, batchNumber + 10: 9} {
+		rollupHeader, err := db.GetLatestRollupHeader(big.NewInt(maxHeight))
+		if err != nil {
+			t.Fatalf("could not retrieve the latest rollup at height %d. Cause: %s", maxHeight, err)
+		}
+		if rollupHeader.LastBatchSeqNo != expected {
+			t.Errorf("height %d: expected the rollup up to batch %d, got %d", maxHeight, expected, rollupHeader.LastBatchSeqNo)
+		}
+	}
+
+	// no rollup was published at or below the first block, nor before the first block processed by the host
+	for _, maxHeight := range []int64{batchNumber, batchNumber - 10} {
+		if _, err := db.GetLatestRollupHeader(big.NewInt(maxHeight)); !errors.Is(err, errutil.ErrNotFound) {
+			t.Errorf("height %d: expected no rollup, got %v", maxHeight, err)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package db
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/ethdb"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+
+	_ "github.com/mattn/go-sqlite3" // this imports the sqlite driver to make the sql.Open() connection work
+)
+
+const (
+	sqliteInit     = `create table if not exists keyvalue (ky blob primary key, val blob not null) without rowid;`
+	sqliteGet      = `select val from keyvalue where ky = ?;`
+	sqlitePut      = `replace into keyvalue values (?, ?);`
+	sqliteDelete   = `delete from keyvalue where ky = ?;`
+	sqliteIter     = `select ky, val from keyvalue where ky >= ? order by ky asc;`
+	sqliteIterUpTo = `select ky, val from keyvalue where ky >= ? and ky < ? order by ky asc;`
+	sqliteOptions  = "_journal_mode=WAL&_busy_timeout=5000&_synchronous=NORMAL"
+	sqliteTempFile = "host.db"
+)
+
+var errSQLiteSnapshot = errors.New("snapshots are not supported by the sqlite host DB")
+
+// SQLiteKVStore is a key-value store in a single table of a sqlite DB file, for the hosts that back their DBs up as
+// files or inspect them with the sqlite tools
+type SQLiteKVStore struct {
+	db *sql.DB
+}
+
+// NewSQLiteBackedDB creates a persistent DB for the host backed by a sqlite file, if dbPath == "" it will generate a
+// temp file
+func NewSQLiteBackedDB(dbPath string, regMetrics gethmetrics.Registry, logger gethlog.Logger) (*DB, error) {
+	dbPath, dbDesc, err := persistencePath(dbPath, "sqlite_*", sqliteTempFile, logger)
+	if err != nil {
+		return nil, err
+	}
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?%s", dbPath, sqliteOptions))
+	if err != nil {
+		return nil, fmt.Errorf("could not open sqlite db - %w", err)
+	}
+	if _, err = db.Exec(sqliteInit); err != nil {
+		_ = db.Close()
+		return nil, fmt.Errorf("could not initialise sqlite db - %w", err)
+	}
+	logger.Info(fmt.Sprintf("Opened %s sqlite db file at %s", dbDesc, dbPath))
+	return openDB(&SQLiteKVStore{db: db}, regMetrics, logger)
+}
+
+func (s *SQLiteKVStore) Has(key []byte) (bool, error) {
+	_, err := s.Get(key)
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *SQLiteKVStore) Get(key []byte) ([]byte, error) {
+	var value []byte
+	if err := s.db.QueryRow(sqliteGet, key).Scan(&value); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, errutil.ErrNotFound
+		}
+		return nil, err
+	}
+	return value, nil
+}
+
+func (s *SQLiteKVStore) Put(key []byte, value []byte) error {
+	_, err := s.db.Exec(sqlitePut, key, value)
+	return err
+}
+
+func (s *SQLiteKVStore) Delete(key []byte) error {
+	_, err := s.db.Exec(sqliteDelete, key)
+	return err
+}
+
+func (s *SQLiteKVStore) NewBatch() ethdb.Batch {
+	return &sqliteBatch{db: s.db}
+}
+
+func (s *SQLiteKVStore) NewBatchWithSize(int) ethdb.Batch {
+	return s.NewBatch()
+}
+
+// NewIterator streams the rows of the keys with the prefix, from start
+func (s *SQLiteKVStore) NewIterator(prefix []byte, start []byte) ethdb.Iterator {
+	from := append(append([]byte{}, prefix...), start...)
+	var rows *sql.Rows
+	var err error
+	if upTo := prefixUpperBound(prefix); upTo != nil {
+		rows, err = s.db.Query(sqliteIterUpTo, from, upTo)
+	} else {
+		rows, err = s.db.Query(sqliteIter, from)
+	}
+	if err != nil {
+		return &sqliteIterator{err: fmt.Errorf("could not query the sqlite keys - %w", err)}
+	}
+	return &sqliteIterator{rows: rows}
+}
+
+func (s *SQLiteKVStore) Stat(string) (string, error) {
+	return "", nil
+}
+
+func (s *SQLiteKVStore) Compact([]byte, []byte) error {
+	_, err := s.db.Exec("vacuum;")
+	return err
+}
+
+func (s *SQLiteKVStore) NewSnapshot() (ethdb.Snapshot, error) {
+	return nil, errSQLiteSnapshot
+}
+
+func (s *SQLiteKVStore) Close() error {
+	return s.db.Close()
+}
+
+// prefixUpperBound returns the lowest key above all the keys with the prefix, nil if there is none
+func prefixUpperBound(prefix []byte) []byte {
+	upTo := append([]byte{}, prefix...)
+	for i := len(upTo) - 1; i >= 0; i-- {
+		if upTo[i] < 0xff {
+			upTo[i]++
+			return upTo[:i+1]
+		}
+	}
+	return nil
+}
+
+type sqliteWrite struct {
+	key    []byte
+	value  []byte
+	delete bool
+}
+
+// sqliteBatch writes the queued changes in a single sqlite transaction
+type sqliteBatch struct {
+	db     *sql.DB
+	writes []sqliteWrite
+	size   int
+}
+
+func (b *sqliteBatch) Put(key []byte, value []byte) error {
+	b.writes = append(b.writes, sqliteWrite{key: append([]byte{}, key...), value: append([]byte{}, value...)})
+	b.size += len(key) + len(value)
+	return nil
+}
+
+func (b *sqliteBatch) Delete(key []byte) error {
+	b.writes = append(b.writes, sqliteWrite{key: append([]byte{}, key...), delete: true})
+	b.size += len(key)
+	return nil
+}
+
+func (b *sqliteBatch) ValueSize() int {
+	return b.size
+}
+
+func (b *sqliteBatch) Write() error {
+	tx, err := b.db.Begin()
+	if err != nil {
+		return err
+	}
+	for _, w := range b.writes {
+		if w.delete {
+			_, err = tx.Exec(sqliteDelete, w.key)
+		} else {
+			_, err = tx.Exec(sqlitePut, w.key, w.value)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+func (b *sqliteBatch) Reset() {
+	b.writes = b.writes[:0]
+	b.size = 0
+}
+
+func (b *sqliteBatch) Replay(w ethdb.KeyValueWriter) error {
+	for _, write := range b.writes {
+		var err error
+		if write.delete {
+			err = w.Delete(write.key)
+		} else {
+			err = w.Put(write.key, write.value)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// sqliteIterator walks over the rows of a key query
+type sqliteIterator struct {
+	rows  *sql.Rows
+	key   []byte
+	value []byte
+	err   error
+}
+
+func (it *sqliteIterator) Next() bool {
+	if it.err != nil || it.rows == nil || !it.rows.Next() {
+		if it.err == nil && it.rows != nil {
+			it.err = it.rows.Err()
+		}
+		it.key, it.value = nil, nil
+		return false
+	}
+	if err := it.rows.Scan(&it.key, &it.value); err != nil {
+		it.err = err
+		return false
+	}
+	return true
+}
+
+func (it *sqliteIterator) Error() error {
+	return it.err
+}
+
+func (it *sqliteIterator) Key() []byte {
+	return it.key
+}
+
+func (it *sqliteIterator) Value() []byte {
+	return it.value
+}
+
+func (it *sqliteIterator) Release() {
+	if it.rows != nil {
+		_ = it.rows.Close()
+	}
+	it.key, it.value = nil, nil
+}
@@ -0,0 +1,35 @@
+package enclave
+
+import (
+	"time"
+
+	"github.com/ten-protocol/go-ten/go/common"
+
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+// batchMetrics exports the lag of the batches processed by the enclave: the time between the production of a batch by
+// the sequencer, as recorded in its header, and its processing by the enclave of this host
+type batchMetrics struct {
+	batches   gethmetrics.Counter
+	lag       gethmetrics.Histogram // in milliseconds
+	lastLag   gethmetrics.Gauge     // in milliseconds
+	lastSeqNo gethmetrics.Gauge
+}
+
+func newBatchMetrics(registry gethmetrics.Registry) *batchMetrics {
+	return &batchMetrics{
+		batches:   gethmetrics.NewRegisteredCounter("host/batch/count", registry),
+		lag:       gethmetrics.NewRegisteredHistogram("host/batch/lag", registry, gethmetrics.NewExpDecaySample(1028, 0.015)),
+		lastLag:   gethmetrics.NewRegisteredGauge("host/batch/last/lag", registry),
+		lastSeqNo: gethmetrics.NewRegisteredGauge("host/batch/last/seqno", registry),
+	}
+}
+
+func (m *batchMetrics) onBatch(batch *common.ExtBatch, now time.Time) {
+	lag := now.Sub(time.Unix(int64(batch.Header.Time), 0)).Milliseconds()
+	m.batches.Inc(1)
+	m.lag.Update(lag)
+	m.lastLag.Update(lag)
+	m.lastSeqNo.Update(batch.Header.SequencerOrderNo.Int64())
+}
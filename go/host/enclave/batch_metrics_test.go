@@ -0,0 +1,29 @@
+package enclave
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestBatchMetrics(t *testing.T) {
+	registry := gethmetrics.NewRegistry()
+	metrics := newBatchMetrics(registry)
+
+	produced := time.Unix(1_700_000_000, 0)
+	metrics.onBatch(&common.ExtBatch{Header: &common.BatchHeader{SequencerOrderNo: big.NewInt(7), Time: uint64(produced.Unix())}}, produced.Add(1500*time.Millisecond))
+	metrics.onBatch(&common.ExtBatch{Header: &common.BatchHeader{SequencerOrderNo: big.NewInt(8), Time: uint64(produced.Unix())}}, produced.Add(500*time.Millisecond))
+
+	if !gethmetrics.Enabled {
+		return
+	}
+	require.Equal(t, int64(2), registry.Get("host/batch/count").(gethmetrics.Counter).Count())
+	require.Equal(t, int64(1500), registry.Get("host/batch/lag").(gethmetrics.Histogram).Max())
+	require.Equal(t, int64(500), registry.Get("host/batch/last/lag").(gethmetrics.Gauge).Value())
+	require.Equal(t, int64(8), registry.Get("host/batch/last/seqno").(gethmetrics.Gauge).Value())
+}
@@ -0,0 +1,196 @@
+package enclave
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"time"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+	"github.com/ten-protocol/go-ten/go/common/log"
+)
+
+const (
+	// time between the queries of the NTP server
+	_ntpCheckInterval = 10 * time.Minute
+
+	_ntpTimeout = 5 * time.Second
+	_ntpPort    = "123"
+)
+
+// the NTP timestamps count the seconds since 1900
+var _ntpEpoch = time.Date(1900, 1, 1, 0, 0, 0, 0, time.UTC)
+
+// clockMonitor compares the clock of the enclave, which sets the timestamps of the batches, with the clock of the host, the
+// time of an NTP server and the timestamps of the L1 blocks
+type clockMonitor struct {
+	ntpServer string        // empty when the NTP time is not checked
+	warnSkew  time.Duration // skew above which we warn, zero if we never do
+	maxSkew   time.Duration // skew above which the batch production is refused, zero if it never is
+	clock     clock.Clock
+	logger    gethlog.Logger
+
+	lock        sync.Mutex
+	enclaveSkew time.Duration // the enclave clock minus the host clock
+	ntpSkew     time.Duration // the NTP time minus the host clock
+	l1Skew      time.Duration // how far ahead of the host clock the timestamp of the latest L1 block was
+	outOfSync   bool          // whether a skew above the warning threshold was reported
+}
+
+func newClockMonitor(ntpServer string, warnSkew time.Duration, maxSkew time.Duration, clk clock.Clock, logger gethlog.Logger) *clockMonitor {
+	return &clockMonitor{
+		ntpServer: ntpServer,
+		warnSkew:  warnSkew,
+		maxSkew:   maxSkew,
+		clock:     clk,
+		logger:    logger,
+	}
+}
+
+// onEnclaveTime records the enclave clock, reported by a status request sent and answered at the given times
+func (c *clockMonitor) onEnclaveTime(enclaveTime time.Time, sent time.Time, received time.Time) {
+	if enclaveTime.IsZero() {
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.enclaveSkew = enclaveTime.Sub(sent.Add(received.Sub(sent) / 2))
+}
+
+// onL1Block records the timestamp of a new L1 head block. Only the blocks ahead of the host clock reveal a skew, the other
+// ones could just be late
+func (c *clockMonitor) onL1Block(timestamp uint64) {
+	skew := time.Unix(int64(timestamp), 0).Sub(c.clock.Now())
+	if skew < 0 {
+		skew = 0
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.l1Skew = skew
+}
+
+// checkNTP queries the time of the NTP server
+func (c *clockMonitor) checkNTP() {
+	if c.ntpServer == "" {
+		return
+	}
+	offset, err := sntpOffset(c.ntpServer, _ntpTimeout)
+	if err != nil {
+		// the server is unavailable or sent an invalid reply, which is not a skew: we keep the last known offset, the
+		// clocks don't drift apart quickly
+		c.logger.Warn("Could not query the NTP server", "server", c.ntpServer, log.ErrKey, err)
+		return
+	}
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.ntpSkew = offset
+}
+
+// checkSkew warns when the clocks are further apart than the warning threshold, and returns an error when they are
+// further apart than the max skew, in which case no batch must be produced
+func (c *clockMonitor) checkSkew() error {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	skews := []struct {
+		clocks string
+		skew   time.Duration
+	}{
+		{clocks: "enclave-host", skew: c.enclaveSkew},
+		{clocks: "enclave-ntp", skew: c.enclaveSkew - c.ntpSkew},
+		{clocks: "host-ntp", skew: -c.ntpSkew},
+		{clocks: "l1-host", skew: c.l1Skew},
+	}
+	var worst time.Duration
+	description := make([]string, len(skews))
+	for i, s := range skews {
+		if s.skew.Abs() > worst {
+			worst = s.skew.Abs()
+		}
+		description[i] = fmt.Sprintf("%s=%s", s.clocks, s.skew)
+	}
+
+	if c.warnSkew == 0 || worst <= c.warnSkew {
+		if c.outOfSync {
+			c.logger.Info("Clocks are back in sync", "skews", strings.Join(description, ","))
+			c.outOfSync = false
+		}
+	} else if !c.outOfSync {
+		c.logger.Warn("Clocks are out of sync", "skews", strings.Join(description, ","), "maxSkew", c.maxSkew)
+		c.outOfSync = true
+	}
+	if c.maxSkew != 0 && worst > c.maxSkew {
+		return fmt.Errorf("clock skew of %s exceeds the max skew of %s (%s)", worst, c.maxSkew, strings.Join(description, ","))
+	}
+	return nil
+}
+
+// sntpOffset queries the time of an NTP server (the SNTP protocol, RFC 4330), and returns its offset to the local clock
+func sntpOffset(server string, timeout time.Duration) (time.Duration, error) {
+	address := server
+	if _, _, err := net.SplitHostPort(server); err != nil {
+		address = net.JoinHostPort(server, _ntpPort)
+	}
+	conn, err := net.DialTimeout("udp", address, timeout)
+	if err != nil {
+		return 0, fmt.Errorf("could not connect to the NTP server - %w", err)
+	}
+	defer conn.Close()
+	if err = conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return 0, err
+	}
+
+	request := make([]byte, 48)
+	request[0] = 3<<3 | 3 // version 3, client mode
+	// the transmit timestamp of the request is random, the server echoes it so that a spoofed reply is detected
+	if _, err = rand.Read(request[40:]); err != nil {
+		return 0, err
+	}
+	sent := time.Now()
+	if _, err = conn.Write(request); err != nil {
+		return 0, fmt.Errorf("could not send the NTP request - %w", err)
+	}
+	reply := make([]byte, 48)
+	n, err := conn.Read(reply)
+	if err != nil {
+		return 0, fmt.Errorf("could not read the NTP reply - %w", err)
+	}
+	received := time.Now()
+
+	serverTime, err := parseSNTPReply(request, reply[:n])
+	if err != nil {
+		return 0, err
+	}
+	return serverTime.Sub(sent.Add(received.Sub(sent) / 2)), nil
+}
+
+// parseSNTPReply checks the reply of the NTP server to the request, and returns the time of the server
+func parseSNTPReply(request []byte, reply []byte) (time.Time, error) {
+	if len(reply) < 48 {
+		return time.Time{}, fmt.Errorf("the NTP reply has %d bytes, expected 48", len(reply))
+	}
+	leap, mode, stratum := reply[0]>>6, reply[0]&7, reply[1]
+	switch {
+	case mode != 4:
+		return time.Time{}, fmt.Errorf("the NTP reply has the mode %d, expected the server mode 4", mode)
+	case stratum == 0:
+		return time.Time{}, fmt.Errorf("the NTP server sent a kiss-o'-death with the code %q", reply[12:16])
+	case leap == 3 || stratum > 15:
+		return time.Time{}, fmt.Errorf("the NTP server is not synchronised")
+	case !bytes.Equal(reply[24:32], request[40:48]):
+		return time.Time{}, fmt.Errorf("the NTP reply does not answer the request")
+	}
+
+	// the transmit timestamp of the reply, as seconds and fractions of second
+	seconds := uint64(binary.BigEndian.Uint32(reply[40:]))
+	fraction := uint64(binary.BigEndian.Uint32(reply[44:]))
+	if seconds == 0 && fraction == 0 {
+		return time.Time{}, fmt.Errorf("the NTP reply has no transmit timestamp")
+	}
+	return _ntpEpoch.Add(time.Duration(seconds)*time.Second + time.Duration((fraction*uint64(time.Second))>>32)), nil
+}
@@ -0,0 +1,136 @@
+package enclave
+
+import (
+	"encoding/binary"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+)
+
+func TestClockSkew(t *testing.T) {
+	// the L1 block timestamps are in seconds
+	now := time.Now().Truncate(time.Second)
+	monitor := newClockMonitor("", time.Second, 5*time.Second, clock.NewFake(now), stateTrackerLogger)
+
+	tests := map[string]struct {
+		enclaveTime time.Time
+		l1Time      time.Time
+		err         string
+	}{
+		"clocks in sync": {
+			enclaveTime: now.Add(100 * time.Millisecond),
+			l1Time:      now.Add(-12 * time.Second),
+		},
+		"skew under the max": {
+			enclaveTime: now.Add(-3 * time.Second),
+			l1Time:      now,
+		},
+		"enclave clock behind": {
+			enclaveTime: now.Add(-6 * time.Second),
+			l1Time:      now,
+			err:         "clock skew of 6s exceeds the max skew of 5s",
+		},
+		"l1 block from the future": {
+			enclaveTime: now,
+			l1Time:      now.Add(10 * time.Second),
+			err:         "clock skew of 10s exceeds the max skew of 5s",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			monitor.onEnclaveTime(test.enclaveTime, now, now)
+			monitor.onL1Block(uint64(test.l1Time.Unix()))
+			err := monitor.checkSkew()
+			if test.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
+
+// returns the reply of an NTP server to the request, with the given time
+func sntpReply(request []byte, serverTime time.Time) []byte {
+	reply := make([]byte, 48)
+	reply[0] = 3<<3 | 4 // version 3, server mode
+	reply[1] = 2        // stratum
+	copy(reply[24:32], request[40:48])
+	binary.BigEndian.PutUint32(reply[40:], uint32(serverTime.Sub(_ntpEpoch)/time.Second))
+	return reply
+}
+
+func TestSNTPOffset(t *testing.T) {
+	server, err := net.ListenPacket("udp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer server.Close()
+
+	// the NTP server is an hour ahead
+	go func() {
+		request := make([]byte, 48)
+		_, addr, err := server.ReadFrom(request)
+		if err != nil {
+			return
+		}
+		_, _ = server.WriteTo(sntpReply(request, time.Now().Add(time.Hour)), addr)
+	}()
+
+	offset, err := sntpOffset(server.LocalAddr().String(), time.Second)
+	require.NoError(t, err)
+	require.InDelta(t, time.Hour, offset, float64(2*time.Second))
+}
+
+func TestParseSNTPReply(t *testing.T) {
+	request := make([]byte, 48)
+	copy(request[40:], []byte{1, 2, 3, 4, 5, 6, 7, 8})
+	now := time.Now().Truncate(time.Second)
+
+	tests := map[string]struct {
+		corrupt func(reply []byte) []byte
+		err     string
+	}{
+		"valid reply": {
+			corrupt: func(reply []byte) []byte { return reply },
+		},
+		"client mode": {
+			corrupt: func(reply []byte) []byte { reply[0] = 3<<3 | 3; return reply },
+			err:     "expected the server mode",
+		},
+		"kiss-o'-death": {
+			corrupt: func(reply []byte) []byte { reply[1] = 0; copy(reply[12:], "RATE"); return reply },
+			err:     `kiss-o'-death with the code "RATE"`,
+		},
+		"unsynchronised server": {
+			corrupt: func(reply []byte) []byte { reply[0] |= 3 << 6; return reply },
+			err:     "not synchronised",
+		},
+		"spoofed reply": {
+			corrupt: func(reply []byte) []byte { reply[24] = 0; return reply },
+			err:     "does not answer the request",
+		},
+		"no transmit timestamp": {
+			corrupt: func(reply []byte) []byte { copy(reply[40:], make([]byte, 8)); return reply },
+			err:     "no transmit timestamp",
+		},
+		"short reply": {
+			corrupt: func(reply []byte) []byte { return reply[:47] },
+			err:     "has 47 bytes",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			serverTime, err := parseSNTPReply(request, test.corrupt(sntpReply(request, now)))
+			if test.err == "" {
+				require.NoError(t, err)
+				require.Equal(t, now.UTC(), serverTime.UTC())
+				return
+			}
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+}
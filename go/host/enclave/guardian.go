@@ -5,16 +5,21 @@ import (
 	"math/big"
 	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
+	"github.com/ten-protocol/go-ten/go/common/clock"
+	"github.com/ten-protocol/go-ten/go/common/queue"
 	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/lru"
 
 	"github.com/ten-protocol/go-ten/go/common/gethutil"
 
 	"github.com/ethereum/go-ethereum/core/types"
 	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
 	"github.com/pkg/errors"
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/errutil"
@@ -35,6 +40,9 @@ const (
 
 	// when we have submitted request to L1 for the secret, how long do we wait for an answer before we retry
 	_maxWaitForSecretResponse = 2 * time.Minute
+
+	// number of L1 blocks submitted to the enclave that we remember, so that we don't submit them twice
+	_submittedBlocksCacheSize = 1000
 )
 
 // This private interface enforces the services that the guardian depends on
@@ -44,6 +52,8 @@ type guardianServiceLocator interface {
 	L1Repo() host.L1BlockRepository
 	L2Repo() host.L2BatchRepository
 	LogSubs() host.LogSubscriptionManager
+	EventLog() host.EventLog
+	HeadFeed() host.HeadFeed
 }
 
 // Guardian is a host service which monitors an enclave, it's responsibilities include:
@@ -58,25 +68,68 @@ type Guardian struct {
 	sl guardianServiceLocator
 	db *db.DB
 
+	keepRollupBlobs bool // the rollups are kept until they are archived, if the archiving is enabled
+
 	submitDataLock sync.Mutex // we only submit one block, batch or transaction to enclave at a time
 
+	// the L1 blocks recently processed by the enclave, a block can be received from both the L1 listener and the catch-up
+	submittedBlocks *lru.Cache[gethcommon.Hash, struct{}]
+
+	// the live L1 blocks and the P2P transactions wait here for the enclave, so that a slow enclave does not stall the L1
+	// listener and the P2P layer
+	l1Blocks *queue.Queue[*types.Block]
+	txs      *queue.Queue[common.EncryptedTx]
+
+	rollupMetrics *rollupMetrics // the compression statistics of the rollups processed by the enclave
+	batchMetrics  *batchMetrics  // the lag of the batches processed by the enclave
+
 	batchInterval  time.Duration
 	rollupInterval time.Duration
 	blockTime      time.Duration
 	l1StartHash    gethcommon.Hash
 	maxRollupSize  uint64
+	l1HasBlobs     bool // whether the L1 accepts blob transactions
+
+	// the rollup interval set by the L1 governance, reported by the enclave status. It overrides rollupInterval when set
+	governedRollupInterval atomic.Int64
 
 	hostInterrupter *stopcontrol.StopControl // host hostInterrupter so we can stop quickly
+	startup         *host.StartupTimer       // records the startup phases driven by the guardian
+
+	clock        clock.Clock
+	clockMonitor *clockMonitor // checks the enclave clock, which sets the timestamps of the batches
+
+	secretResponder *secretResponder // decides which of the secret responses of the enclave are published
 
 	logger           gethlog.Logger
 	maxBatchInterval time.Duration
 	lastBatchCreated time.Time
+
+	// in the read-only mode, the sequencer stops producing batches and publishing rollups but keeps serving the RPC requests
+	readOnlyLock   sync.RWMutex
+	readOnly       bool
+	readOnlyReason string
+
+	// a hot standby enclave is fed the L1 blocks and the batches of the active enclave of the host, but it produces no
+	// batch or rollup until it is promoted (see Service)
+	standby atomic.Bool
+	// feeds the batches produced by the enclave to the standby enclaves of the host, if any
+	onProducedBatch func(batch *common.ExtBatch)
+	// the batches produced by the active enclave of the host wait here for a standby enclave, which executes them one at
+	// a time in the order of their sequence numbers
+	standbyBatches *queue.Queue[*common.ExtBatch]
 }
 
-func NewGuardian(cfg *config.HostConfig, hostData host.Identity, serviceLocator guardianServiceLocator, enclaveClient common.Enclave, db *db.DB, interrupter *stopcontrol.StopControl, logger gethlog.Logger) *Guardian {
+func NewGuardian(cfg *config.HostConfig, hostData host.Identity, serviceLocator guardianServiceLocator, enclaveClient common.Enclave, db *db.DB, interrupter *stopcontrol.StopControl, startup *host.StartupTimer, clk clock.Clock, logger gethlog.Logger, regMetrics gethmetrics.Registry) *Guardian {
 	return &Guardian{
 		hostData:         hostData,
 		state:            NewStateTracker(logger),
+		submittedBlocks:  lru.NewCache[gethcommon.Hash, struct{}](_submittedBlocksCacheSize),
+		l1Blocks:         queue.New[*types.Block]("host/l1blocks", cfg.IngestionQueueSize, cfg.L1BlockQueuePolicy, cfg.QueueSpillDir, regMetrics, logger),
+		txs:              queue.New[common.EncryptedTx]("host/txs", cfg.IngestionQueueSize, cfg.TxQueuePolicy, cfg.QueueSpillDir, regMetrics, logger),
+		standbyBatches:   queue.New[*common.ExtBatch]("host/standbybatches", cfg.IngestionQueueSize, queue.SpillToDisk, cfg.QueueSpillDir, regMetrics, logger),
+		rollupMetrics:    newRollupMetrics(regMetrics),
+		batchMetrics:     newBatchMetrics(regMetrics),
 		enclaveClient:    enclaveClient,
 		sl:               serviceLocator,
 		batchInterval:    cfg.BatchInterval,
@@ -85,19 +138,30 @@ func NewGuardian(cfg *config.HostConfig, hostData host.Identity, serviceLocator
 		l1StartHash:      cfg.L1StartHash,
 		maxRollupSize:    cfg.MaxRollupSize,
 		blockTime:        cfg.L1BlockTime,
+		l1HasBlobs:       cfg.L1BlobsAvailable,
 		db:               db,
+		keepRollupBlobs:  cfg.ArchiveAge > 0,
 		hostInterrupter:  interrupter,
+		startup:          startup,
+		clock:            clk,
+		clockMonitor:     newClockMonitor(cfg.NTPServer, cfg.ClockSkewWarning, cfg.MaxClockSkew, clk, logger),
+		secretResponder:  newSecretResponder(cfg.DisableSecretResponses, cfg.SecretResponseCooldown, cfg.MaxSecretResponsesPerHour, clk, logger),
 		logger:           logger,
 	}
 }
 
 func (g *Guardian) Start() error {
+	g.resumeFromStoredHeads()
 	go g.mainLoop()
+	go g.processL1Blocks()
+	go g.processTransactions()
 	if g.hostData.IsSequencer {
-		// if we are a sequencer then we need to start the periodic batch/rollup production
-		// Note: after HA work this will need additional check that we are the **active** sequencer enclave
+		// if we are a sequencer then we need to start the periodic batch/rollup production, which only the active enclave
+		// of the host performs
 		go g.periodicBatchProduction()
 		go g.periodicRollupProduction()
+		go g.periodicNTPCheck()
+		go g.processStandbyBatches()
 	}
 
 	// subscribe for L1 and P2P data
@@ -111,7 +175,38 @@ func (g *Guardian) Start() error {
 	return nil
 }
 
+// resumeFromStoredHeads starts from the L1 and L2 heads stored in the host DB before the restart, so that the enclave of
+// a validator catches up with the stored batches before the first live block and batch arrive
+func (g *Guardian) resumeFromStoredHeads() {
+	if block, err := g.db.GetBlockAtTip(); err == nil {
+		g.state.OnReceivedBlock(block.Hash())
+		g.logger.Info("Resuming from the L1 head stored in the host DB", log.BlockHashKey, block.Hash(), log.BlockHeightKey, block.Number)
+	} else if !errors.Is(err, errutil.ErrNotFound) {
+		g.logger.Warn("Could not read the L1 head from the host DB", log.ErrKey, err)
+	}
+	if g.isActiveSequencer() {
+		// the batches of the sequencer come from its enclave
+		return
+	}
+	if batch, err := g.db.GetHeadBatchHeader(); err == nil {
+		g.state.OnReceivedBatch(batch.SequencerOrderNo)
+		g.logger.Info("Resuming from the L2 head stored in the host DB", log.BatchSeqNoKey, batch.SequencerOrderNo)
+	} else if !errors.Is(err, errutil.ErrNotFound) {
+		g.logger.Warn("Could not read the L2 head from the host DB", log.ErrKey, err)
+	}
+}
+
 func (g *Guardian) Stop() error {
+	if err := g.l1Blocks.Close(); err != nil {
+		g.logger.Error("error closing the L1 block queue", log.ErrKey, err)
+	}
+	if err := g.txs.Close(); err != nil {
+		g.logger.Error("error closing the transaction queue", log.ErrKey, err)
+	}
+	if err := g.standbyBatches.Close(); err != nil {
+		g.logger.Error("error closing the standby batch queue", log.ErrKey, err)
+	}
+
 	err := g.enclaveClient.Stop()
 	if err != nil {
 		g.logger.Error("error stopping enclave", log.ErrKey, err)
@@ -134,6 +229,67 @@ func (g *Guardian) HealthStatus() host.HealthStatus {
 	return &host.BasicErrHealthStatus{ErrMsg: errMsg}
 }
 
+// ReadOnlyMode returns whether the sequencer is in the read-only mode, and why
+func (g *Guardian) ReadOnlyMode() (bool, string) {
+	g.readOnlyLock.RLock()
+	defer g.readOnlyLock.RUnlock()
+	return g.readOnly, g.readOnlyReason
+}
+
+// SyncStatus returns the sync status of the enclave, with its latest progress through the rollups
+func (g *Guardian) SyncStatus() *common.SyncStatus {
+	return g.state.SyncStatus()
+}
+
+// SetReadOnlyMode switches the sequencer in or out of the read-only mode. The batch and rollup in progress, if any, are
+// completed
+func (g *Guardian) SetReadOnlyMode(readOnly bool, reason string) error {
+	if !g.hostData.IsSequencer {
+		return errors.New("only the sequencer has a read-only mode")
+	}
+	g.readOnlyLock.Lock()
+	defer g.readOnlyLock.Unlock()
+	g.readOnly = readOnly
+	g.readOnlyReason = ""
+	if readOnly {
+		g.readOnlyReason = reason
+		g.logger.Warn("Sequencer switched to the read-only mode, batch and rollup production stopped", "reason", reason)
+	} else {
+		g.logger.Info("Sequencer switched out of the read-only mode, batch and rollup production resumed", "reason", reason)
+	}
+	return nil
+}
+
+// stops the batch and rollup production when a critical invariant of the sequencer is violated, so that the operators
+// can investigate before the network builds on the faulty state
+func (g *Guardian) onInvariantViolation(violation error) {
+	g.logger.Error("Critical invariant of the sequencer violated", log.ErrKey, violation)
+	if err := g.SetReadOnlyMode(true, violation.Error()); err != nil {
+		g.logger.Error("Could not switch to the read-only mode", log.ErrKey, err)
+	}
+}
+
+func (g *Guardian) isReadOnly() bool {
+	readOnly, _ := g.ReadOnlyMode()
+	return readOnly
+}
+
+// IsStandby returns whether the enclave is a hot standby of the active enclave of the host
+func (g *Guardian) IsStandby() bool {
+	return g.standby.Load()
+}
+
+// setStandby switches the enclave between the active one of the host and a hot standby. An active sequencer enclave
+// produces the batches and the rollups, a standby one executes the batches of the active enclave
+func (g *Guardian) setStandby(standby bool) {
+	g.standby.Store(standby)
+}
+
+// returns whether the enclave produces the batches of the network
+func (g *Guardian) isActiveSequencer() bool {
+	return g.hostData.IsSequencer && !g.IsStandby()
+}
+
 func (g *Guardian) GetEnclaveState() *StateTracker {
 	return g.state
 }
@@ -152,21 +308,43 @@ func (g *Guardian) HandleBlock(block *types.Block) {
 	g.logger.Debug("Received L1 block", log.BlockHashKey, block.Hash(), log.BlockHeightKey, block.Number())
 	// record the newest block we've seen
 	g.state.OnReceivedBlock(block.Hash())
-	if !g.state.InSyncWithL1() {
-		// the enclave is still catching up with the L1 chain, it won't be able to process this new head block yet so return
-		return
+	g.startup.EndPhase(host.L1FeedPhase)
+	g.clockMonitor.onL1Block(block.Time())
+	if err := g.l1Blocks.Push(g.hostInterrupter.Context(), block); err != nil {
+		g.logger.Warn("could not queue L1 block", log.BlockHashKey, block.Hash(), log.ErrKey, err)
 	}
-	_, err := g.submitL1Block(block, true)
-	if err != nil {
-		g.logger.Warn("failure processing L1 block", log.ErrKey, err)
+}
+
+// HandleL1Reorg is called before the blocks of the new branch of an L1 reorg are handled. They are submitted to the
+// enclave in order from the fork point, which rolls back to it.
+func (g *Guardian) HandleL1Reorg(reorg *host.L1Reorg) {
+	g.logger.Info("Replaying the new branch of the L1 reorg to the enclave", "depth", reorg.Depth(),
+		"fork_height", reorg.ForkPoint.Number, "new_head", reorg.NewHead.Hash())
+}
+
+// processL1Blocks feeds the queued live L1 blocks to the enclave until the guardian is stopped
+func (g *Guardian) processL1Blocks() {
+	for {
+		block, err := g.l1Blocks.Pop(g.hostInterrupter.Context())
+		if err != nil {
+			return // the guardian is stopping
+		}
+		if !g.state.InSyncWithL1() {
+			// the enclave is still catching up with the L1 chain, it won't be able to process this new head block yet
+			continue
+		}
+		_, err = g.submitL1Block(block, true)
+		if err != nil {
+			g.logger.Warn("failure processing L1 block", log.ErrKey, err)
+		}
 	}
 }
 
 // HandleBatch is called by the L2 repository when a new batch arrives
 // Note: this should only be called for validators, sequencers produce their own batches
 func (g *Guardian) HandleBatch(batch *common.ExtBatch) {
-	if g.hostData.IsSequencer {
-		g.logger.Error("Repo received batch but we are a sequencer, ignoring")
+	if g.isActiveSequencer() {
+		g.logger.Error("Repo received batch but we are the active sequencer, ignoring")
 		return
 	}
 	g.logger.Debug("Received L2 block", log.BatchHashKey, batch.Hash(), log.BatchSeqNoKey, batch.Header.SequencerOrderNo)
@@ -182,13 +360,51 @@ func (g *Guardian) HandleBatch(batch *common.ExtBatch) {
 }
 
 func (g *Guardian) HandleTransaction(tx common.EncryptedTx) {
-	resp, sysError := g.enclaveClient.SubmitTx(tx)
-	if sysError != nil {
-		g.logger.Warn("could not submit transaction due to sysError", log.ErrKey, sysError)
-		return
+	if err := g.txs.Push(g.hostInterrupter.Context(), tx); err != nil {
+		g.logger.Warn("could not queue transaction", log.ErrKey, err)
 	}
-	if resp.Error() != nil {
-		g.logger.Trace("could not submit transaction", log.ErrKey, resp.Error())
+}
+
+// feedStandbyBatch queues a batch produced by the active enclave of the host, for this standby enclave to execute
+func (g *Guardian) feedStandbyBatch(batch *common.ExtBatch) {
+	if err := g.standbyBatches.Push(g.hostInterrupter.Context(), batch); err != nil {
+		g.logger.Warn("could not queue the batch of the active enclave", log.BatchHashKey, batch.Hash(), log.ErrKey, err)
+	}
+}
+
+// processStandbyBatches submits the queued batches of the active enclave of the host one at a time, skipping the ones
+// older than the last one submitted (which the stream of the active enclave sends again after a reconnection). The
+// enclave catches up with the batches it missed from the host DB.
+func (g *Guardian) processStandbyBatches() {
+	var lastSeqNo *big.Int
+	for {
+		batch, err := g.standbyBatches.Pop(g.hostInterrupter.Context())
+		if err != nil {
+			return // the guardian is stopping
+		}
+		if lastSeqNo != nil && batch.Header.SequencerOrderNo.Cmp(lastSeqNo) <= 0 {
+			continue
+		}
+		lastSeqNo = batch.Header.SequencerOrderNo
+		g.HandleBatch(batch)
+	}
+}
+
+// processTransactions submits the queued P2P transactions to the enclave until the guardian is stopped
+func (g *Guardian) processTransactions() {
+	for {
+		tx, err := g.txs.Pop(g.hostInterrupter.Context())
+		if err != nil {
+			return // the guardian is stopping
+		}
+		resp, sysError := g.enclaveClient.SubmitTx(tx)
+		if sysError != nil {
+			g.logger.Warn("could not submit transaction due to sysError", log.ErrKey, sysError)
+			continue
+		}
+		if resp.Error() != nil {
+			g.logger.Trace("could not submit transaction", log.ErrKey, resp.Error())
+		}
 	}
 }
 
@@ -201,44 +417,71 @@ func (g *Guardian) mainLoop() {
 		// or after the monitoring interval if we are healthy)
 		g.checkEnclaveStatus()
 		g.logger.Trace("mainLoop - enclave status", "status", g.state.GetStatus())
+		g.recordStartup(g.state.GetStatus())
 		switch g.state.GetStatus() {
 		case Disconnected, Unavailable:
 			// nothing to do, we are waiting for the enclave to be available
-			time.Sleep(_retryInterval)
+			g.sleep(_retryInterval)
 		case AwaitingSecret:
 			err := g.provideSecret()
 			if err != nil {
 				g.logger.Warn("could not provide secret to enclave", log.ErrKey, err)
-				time.Sleep(_retryInterval)
+				g.sleep(_retryInterval)
 			}
 		case L1Catchup:
 			// catchUpWithL1 will feed blocks 1-by-1 to the enclave until we are up-to-date, we hit an error or the guardian is stopped
 			err := g.catchupWithL1()
 			if err != nil {
 				g.logger.Warn("could not catch up with L1", log.ErrKey, err)
-				time.Sleep(_retryInterval)
+				g.sleep(_retryInterval)
 			}
 		case L2Catchup:
 			// catchUpWithL2 will feed batches 1-by-1 to the enclave until we are up-to-date, we hit an error or the guardian is stopped
 			err := g.catchupWithL2()
 			if err != nil {
 				g.logger.Warn("could not catch up with L2", log.ErrKey, err)
-				time.Sleep(_retryInterval)
+				g.sleep(_retryInterval)
 			}
 		case Live:
 			// we're healthy: loop back to enclave status again after long monitoring interval
-			select {
-			case <-time.After(_monitoringInterval):
-				// loop back to check status
-			case <-g.hostInterrupter.Done():
-				// stop sleeping, we've been interrupted by the host stopping
-			}
+			g.sleep(_monitoringInterval)
 		}
 	}
 	g.logger.Debug("stopping guardian main loop")
 }
 
+// recordStartup records the startup phases that depend on the status of the enclave, and logs the timing of the startup
+// once the enclave is live
+func (g *Guardian) recordStartup(status Status) {
+	if status == Disconnected || status == Unavailable || g.IsStandby() {
+		return
+	}
+	g.startup.EndPhase(host.EnclaveWaitPhase)
+	if status == AwaitingSecret {
+		g.startup.BeginPhase(host.SecretProvisioningPhase)
+		return
+	}
+	g.startup.EndPhase(host.SecretProvisioningPhase)
+	if status == L1Catchup {
+		g.startup.BeginPhase(host.BlockIngestionPhase)
+		return
+	}
+	g.startup.EndPhase(host.BlockIngestionPhase)
+	if status == Live && g.startup.Complete() {
+		g.logger.Info("Host startup completed", "timing", g.startup.Report())
+	}
+}
+
+// sleep waits for the duration, or until the host stops
+func (g *Guardian) sleep(d time.Duration) {
+	select {
+	case <-g.clock.After(d):
+	case <-g.hostInterrupter.Done():
+	}
+}
+
 func (g *Guardian) checkEnclaveStatus() {
+	sent := g.clock.Now()
 	s, err := g.enclaveClient.Status()
 	if err != nil {
 		g.logger.Error("Could not get enclave status", log.ErrKey, err)
@@ -246,7 +489,32 @@ func (g *Guardian) checkEnclaveStatus() {
 		g.state.OnDisconnected()
 		return
 	}
+	if g.state.GetStatus() == Disconnected {
+		// the enclave may have restarted while it was unreachable, so its status is derived again from scratch: whether it
+		// needs the secret, and how far behind the L1 and L2 heads it is
+		g.logger.Info("Enclave reachable again", "enclaveStatus", s.StatusCode, "l1Head", s.L1Head, "l2Head", s.L2Head)
+		g.submittedBlocks.Purge()
+	}
+	if s.L1Head != gethutil.EmptyHash && !g.submittedBlocks.Contains(s.L1Head) {
+		// the enclave head was not submitted by us (e.g. the enclave restarted), we can't tell what the enclave has seen anymore
+		g.submittedBlocks.Purge()
+	}
 	g.state.OnEnclaveStatus(s)
+	g.clockMonitor.onEnclaveTime(s.Time, sent, g.clock.Now())
+	if s.StatusCode == common.Running && s.RollupInterval != 0 {
+		previous := g.governedRollupInterval.Swap(int64(s.RollupInterval))
+		if time.Duration(previous) != s.RollupInterval {
+			g.logger.Info("Applying the rollup interval set by the governance", "rollupInterval", s.RollupInterval)
+		}
+	}
+}
+
+// returns the interval between rollups, either set by the governance or configured
+func (g *Guardian) currentRollupInterval() time.Duration {
+	if governed := g.governedRollupInterval.Load(); governed != 0 {
+		return time.Duration(governed)
+	}
+	return g.rollupInterval
 }
 
 // This method implements the procedure by which a node obtains the secret
@@ -271,7 +539,7 @@ func (g *Guardian) provideSecret() error {
 	}
 
 	// keep checking L1 blocks until we find a secret response for our request or timeout
-	err = retry.Do(func() error {
+	err = retry.DoWithContext(g.hostInterrupter.Context(), func() error {
 		nextBlock, _, err := g.sl.L1Repo().FetchNextBlock(awaitFromBlock)
 		if err != nil {
 			return fmt.Errorf("next block after block=%s not found - %w", awaitFromBlock, err)
@@ -359,8 +627,8 @@ func (g *Guardian) catchupWithL1() error {
 func (g *Guardian) catchupWithL2() error {
 	// while we are behind the L2 head and still running:
 	for !g.hostInterrupter.IsStopping() && g.state.GetStatus() == L2Catchup {
-		if g.hostData.IsSequencer {
-			return errors.New("l2 catchup is not supported for sequencer")
+		if g.isActiveSequencer() {
+			return errors.New("l2 catchup is not supported for the active sequencer")
 		}
 		// request the next batch by sequence number (based on what the enclave has been fed so far)
 		prevHead := g.state.GetEnclaveL2Head()
@@ -384,6 +652,15 @@ func (g *Guardian) catchupWithL2() error {
 // todo - @matt - think about removing the TryLock
 func (g *Guardian) submitL1Block(block *common.L1Block, isLatest bool) (bool, error) {
 	g.logger.Trace("submitting L1 block", log.BlockHashKey, block.Hash(), log.BlockHeightKey, block.Number())
+	if g.submittedBlocks.Contains(block.Hash()) {
+		if block.Hash() == g.state.GetEnclaveL1Head() {
+			// the block was already fed to the enclave, e.g. by the catch-up before the L1 listener received it
+			g.logger.Debug("L1 block already submitted to enclave, skipping", log.BlockHashKey, block.Hash())
+			return false, nil
+		}
+		// the enclave would reject the block as already processed, so we go straight to the next canonical block
+		return g.submitNextCanonicalBlock(block, isLatest)
+	}
 	if !g.submitDataLock.TryLock() {
 		g.logger.Debug("Unable to submit block, enclave is busy processing data")
 		// we are waiting for the enclave to process other data, and we don't want to leak goroutines, we wil catch up with the block later
@@ -402,17 +679,14 @@ func (g *Guardian) submitL1Block(block *common.L1Block, isLatest bool) (bool, er
 			// this is most common when we are returning to a previous fork and the enclave has already seen some of the blocks on it
 			// note: logging this because we don't expect it to happen often and would like visibility on that.
 			g.logger.Info("L1 block already processed by enclave, trying the next block", "block", block.Hash())
-			nextHeight := big.NewInt(0).Add(block.Number(), big.NewInt(1))
-			nextCanonicalBlock, err := g.sl.L1Repo().FetchBlockByHeight(nextHeight)
-			if err != nil {
-				return false, fmt.Errorf("failed to fetch next block after forking block=%s: %w", block.Hash(), err)
-			}
-			return g.submitL1Block(nextCanonicalBlock, isLatest)
+			g.submittedBlocks.Add(block.Hash(), struct{}{})
+			return g.submitNextCanonicalBlock(block, isLatest)
 		}
 		// something went wrong, return error and let the main loop check status and try again when appropriate
 		return false, errors.Wrap(err, "could not submit L1 block to enclave")
 	}
 	// successfully processed block, update the state
+	g.submittedBlocks.Add(block.Hash(), struct{}{})
 	g.state.OnProcessedBlock(block.Hash())
 	g.processL1BlockTransactions(block)
 
@@ -422,7 +696,6 @@ func (g *Guardian) submitL1Block(block *common.L1Block, isLatest bool) (bool, er
 		return false, fmt.Errorf("submitted block to enclave but could not store the block processing result. Cause: %w", err)
 	}
 
-	// todo: make sure this doesn't respond to old requests (once we have a proper protocol for that)
 	err = g.publishSharedSecretResponses(resp.ProducedSecretResponses)
 	if err != nil {
 		g.logger.Error("Failed to publish response to secret request", log.ErrKey, err)
@@ -430,6 +703,16 @@ func (g *Guardian) submitL1Block(block *common.L1Block, isLatest bool) (bool, er
 	return true, nil
 }
 
+// submits the canonical block following a block the enclave has already processed
+func (g *Guardian) submitNextCanonicalBlock(block *common.L1Block, isLatest bool) (bool, error) {
+	nextHeight := big.NewInt(0).Add(block.Number(), big.NewInt(1))
+	nextCanonicalBlock, err := g.sl.L1Repo().FetchBlockByHeight(nextHeight)
+	if err != nil {
+		return false, fmt.Errorf("failed to fetch next block after forking block=%s: %w", block.Hash(), err)
+	}
+	return g.submitL1Block(nextCanonicalBlock, isLatest)
+}
+
 func (g *Guardian) processL1BlockTransactions(block *common.L1Block) {
 	// if there are any secret responses in the block we should refresh our P2P list to re-sync with the network
 	secretRespTxs, rollupTxs, contractAddressTxs := g.sl.L1Publisher().ExtractObscuroRelevantTransactions(block)
@@ -450,7 +733,15 @@ func (g *Guardian) processL1BlockTransactions(block *common.L1Block) {
 			} else {
 				g.logger.Error("Could not store rollup.", log.ErrKey, err)
 			}
+			continue
 		}
+		if g.keepRollupBlobs {
+			if err = g.db.AddRollupBlob(r.Hash(), rollup.Rollup); err != nil {
+				g.logger.Error("Could not store rollup blob.", log.RollupHashKey, r.Hash(), log.ErrKey, err)
+			}
+		}
+		g.sl.EventLog().RollupPublished(r, block)
+		g.sl.HeadFeed().RollupPublished(r, block)
 	}
 
 	if len(contractAddressTxs) > 0 {
@@ -473,8 +764,12 @@ func (g *Guardian) publishSharedSecretResponses(scrtResponses []*common.Produced
 			return nil
 		}
 
+		if !g.secretResponder.allow(scrtResponse) {
+			continue
+		}
 		err := g.sl.L1Publisher().PublishSecretResponse(scrtResponse)
 		if err != nil {
+			g.secretResponder.failed(scrtResponse)
 			return errors.Wrap(err, "could not publish secret response")
 		}
 	}
@@ -501,7 +796,7 @@ func (g *Guardian) periodicBatchProduction() {
 	if interval == 0 {
 		interval = 1 * time.Second
 	}
-	batchProdTicker := time.NewTicker(interval)
+	batchProdTicker := g.clock.NewTicker(interval)
 	// attempt to produce rollup every time the timer ticks until we are stopped/interrupted
 	for {
 		if g.hostInterrupter.IsStopping() {
@@ -509,16 +804,28 @@ func (g *Guardian) periodicBatchProduction() {
 			return // stop periodic rollup production
 		}
 		select {
-		case <-batchProdTicker.C:
+		case <-batchProdTicker.C():
 			if !g.state.InSyncWithL1() {
 				// if we're behind the L1, we don't want to produce batches
 				g.logger.Debug("Skipping batch production because L1 is not up to date")
 				continue
 			}
+			if g.IsStandby() {
+				continue
+			}
+			if g.isReadOnly() {
+				g.logger.Debug("Skipping batch production because the sequencer is in the read-only mode")
+				continue
+			}
+			if err := g.clockMonitor.checkSkew(); err != nil {
+				// the batch timestamps are taken from the enclave clock
+				g.logger.Error("Skipping batch production because the clocks are out of sync", log.ErrKey, err)
+				continue
+			}
 			g.logger.Debug("Create batch")
 			// if maxBatchInterval is set higher than batchInterval then we are happy to skip creating batches when there is no data
 			// (up to a maximum time of maxBatchInterval)
-			skipBatchIfEmpty := g.maxBatchInterval > g.batchInterval && time.Since(g.lastBatchCreated) < g.maxBatchInterval
+			skipBatchIfEmpty := g.maxBatchInterval > g.batchInterval && g.clock.Since(g.lastBatchCreated) < g.maxBatchInterval
 			err := g.enclaveClient.CreateBatch(skipBatchIfEmpty)
 			if err != nil {
 				g.logger.Error("Unable to produce batch", log.ErrKey, err)
@@ -531,23 +838,46 @@ func (g *Guardian) periodicBatchProduction() {
 	}
 }
 
+// the sequencer compares its clocks with the NTP time before producing batches
+func (g *Guardian) periodicNTPCheck() {
+	ntpCheckTicker := g.clock.NewTicker(_ntpCheckInterval)
+	defer ntpCheckTicker.Stop()
+	for {
+		g.clockMonitor.checkNTP()
+		select {
+		case <-ntpCheckTicker.C():
+		case <-g.hostInterrupter.Done():
+			return
+		}
+	}
+}
+
 const batchCompressionFactor = 0.85
 
 func (g *Guardian) periodicRollupProduction() {
 	defer g.logger.Info("Stopping rollup production")
 
 	// check rollup every l1 block time
-	rollupCheckTicker := time.NewTicker(g.blockTime)
-	lastSuccessfulRollup := time.Now()
+	rollupCheckTicker := g.clock.NewTicker(g.blockTime)
+	lastSuccessfulRollup := g.clock.Now()
 
 	for {
 		select {
-		case <-rollupCheckTicker.C:
+		case <-rollupCheckTicker.C():
 			if !g.state.IsUpToDate() {
 				// if we're behind the L1, we don't want to produce rollups
 				g.logger.Debug("skipping rollup production because L1 is not up to date", "state", g.state)
 				continue
 			}
+			if g.IsStandby() {
+				// the interval runs from the promotion of the enclave
+				lastSuccessfulRollup = g.clock.Now()
+				continue
+			}
+			if g.isReadOnly() {
+				g.logger.Debug("skipping rollup production because the sequencer is in the read-only mode")
+				continue
+			}
 
 			fromBatch, err := g.getLatestBatchNo()
 			if err != nil {
@@ -556,7 +886,7 @@ func (g *Guardian) periodicRollupProduction() {
 			}
 
 			// estimate the size of a compressed rollup
-			availBatchesSumSize, err := g.calculateNonRolledupBatchesSize(fromBatch)
+			availBatchesSumSize, batchCount, err := g.calculateNonRolledupBatchesSize(fromBatch)
 			if err != nil {
 				g.logger.Error("Unable to estimate the size of the current rollup", log.ErrKey, err, "from_batch", fromBatch)
 				// todo - this should not happen. Is it worth continuing?
@@ -567,12 +897,13 @@ func (g *Guardian) periodicRollupProduction() {
 			estimatedRunningRollupSize := uint64(float64(availBatchesSumSize) * batchCompressionFactor)
 
 			// produce and issue rollup when either:
-			// it has passed g.rollupInterval from last lastSuccessfulRollup
+			// it has passed the rollup interval from last lastSuccessfulRollup
 			// or the size of accumulated batches is > g.maxRollupSize
-			timeExpired := time.Since(lastSuccessfulRollup) > g.rollupInterval
+			timeExpired := g.clock.Since(lastSuccessfulRollup) > g.currentRollupInterval()
 			sizeExceeded := estimatedRunningRollupSize >= g.maxRollupSize
 			if timeExpired || sizeExceeded {
 				g.logger.Info("Trigger rollup production.", "timeExpired", timeExpired, "sizeExceeded", sizeExceeded)
+				g.logRollupCost(estimatedRunningRollupSize, batchCount)
 				producedRollup, err := g.enclaveClient.CreateRollup(fromBatch)
 				if err != nil {
 					g.logger.Error("Unable to create rollup", log.BatchSeqNoKey, fromBatch, log.ErrKey, err)
@@ -580,7 +911,7 @@ func (g *Guardian) periodicRollupProduction() {
 				}
 				// this method waits until the receipt is received
 				g.sl.L1Publisher().PublishRollup(producedRollup)
-				lastSuccessfulRollup = time.Now()
+				lastSuccessfulRollup = g.clock.Now()
 			}
 
 		case <-g.hostInterrupter.Done():
@@ -603,39 +934,71 @@ func (g *Guardian) streamEnclaveData() {
 			if !ok {
 				stop()
 				g.logger.Warn("Batch streaming failed. Reconnecting after 3 seconds")
-				time.Sleep(3 * time.Second)
+				g.sleep(3 * time.Second)
 				streamChan, stop = g.enclaveClient.StreamL2Updates()
 
 				continue
 			}
 
+			if resp.Batch != nil && g.isActiveSequencer() {
+				// a batch conflicting with the previous one must not be stored or broadcast
+				if err := checkProducedBatch(lastBatch, resp.Batch); err != nil {
+					g.onInvariantViolation(err)
+					resp.Batch = nil
+				}
+			}
+
 			if resp.Batch != nil { //nolint:nestif
 				lastBatch = resp.Batch
 				g.logger.Trace("Received batch from stream", log.BatchHashKey, lastBatch.Hash())
 				err := g.sl.L2Repo().AddBatch(resp.Batch)
+				if err == nil {
+					g.sl.EventLog().BatchSealed(resp.Batch)
+					g.sl.HeadFeed().BatchSealed(resp.Batch)
+				}
 				if err != nil && !errors.Is(err, errutil.ErrAlreadyExists) {
-					// todo (@matt) this is a catastrophic scenario, the host may never get that batch - handle this
-					g.logger.Crit("failed to add batch to L2 repo", log.BatchHashKey, resp.Batch.Hash(), log.ErrKey, err)
+					if g.isActiveSequencer() {
+						// the batch would never reach the network, so the sequencer must not build on it
+						g.onInvariantViolation(fmt.Errorf("failed to add batch %s to the L2 repo - %w", resp.Batch.Hash(), err))
+					} else {
+						// todo (@matt) this is a catastrophic scenario, the host may never get that batch - handle this
+						g.logger.Crit("failed to add batch to L2 repo", log.BatchHashKey, resp.Batch.Hash(), log.ErrKey, err)
+					}
 				}
 
-				if g.hostData.IsSequencer { // if we are the sequencer we need to broadcast this new batch to the network
-					g.lastBatchCreated = time.Now()
+				if g.isActiveSequencer() { // if we are the sequencer we need to broadcast this new batch to the network
+					g.lastBatchCreated = g.clock.Now()
 					g.logger.Info("Batch produced. Sending to peers..", log.BatchHeightKey, resp.Batch.Header.Number, log.BatchHashKey, resp.Batch.Hash())
 
 					err = g.sl.P2P().BroadcastBatches([]*common.ExtBatch{resp.Batch})
 					if err != nil {
 						g.logger.Error("Failed to broadcast batch", log.BatchHashKey, resp.Batch.Hash(), log.ErrKey, err)
 					}
+					if g.onProducedBatch != nil {
+						g.onProducedBatch(resp.Batch)
+					}
 				} else {
 					g.logger.Debug("Received batch from enclave", log.BatchSeqNoKey, resp.Batch.Header.SequencerOrderNo, log.BatchHashKey, resp.Batch.Hash())
 				}
 				g.state.OnProcessedBatch(resp.Batch.Header.SequencerOrderNo)
+				if !g.IsStandby() {
+					g.batchMetrics.onBatch(resp.Batch, g.clock.Now())
+				}
 			}
 
-			if resp.Logs != nil {
+			// the subscribers and the metrics are served by the active enclave alone
+			if resp.Logs != nil && !g.IsStandby() {
 				g.sl.LogSubs().SendLogsToSubscribers(&resp.Logs)
 			}
 
+			if resp.SyncProgress != nil {
+				g.state.OnRollupSyncProgress(resp.SyncProgress)
+			}
+
+			if resp.RollupStats != nil && !g.IsStandby() {
+				g.rollupMetrics.onRollup(resp.RollupStats)
+			}
+
 		case <-g.hostInterrupter.Done():
 			// interrupted - end periodic process
 			return
@@ -643,11 +1006,61 @@ func (g *Guardian) streamEnclaveData() {
 	}
 }
 
-func (g *Guardian) calculateNonRolledupBatchesSize(seqNo uint64) (uint64, error) {
-	var size uint64
+// checkProducedBatch returns an error if the batch produced by the enclave of the sequencer conflicts with the previous
+// batch it produced: the sequencer must never produce two batches with the same sequence number, and each batch must
+// extend the previous one. Since the stream can miss batches when it reconnects, batches further ahead are accepted.
+func checkProducedBatch(prevBatch *common.ExtBatch, batch *common.ExtBatch) error {
+	if prevBatch == nil {
+		return nil
+	}
+	prevSeqNo, seqNo := prevBatch.Header.SequencerOrderNo, batch.Header.SequencerOrderNo
+	if seqNo.Cmp(prevSeqNo) == 0 && batch.Hash() != prevBatch.Hash() {
+		return fmt.Errorf("the enclave produced two batches with the sequence number %d: %s and %s", seqNo, prevBatch.Hash(), batch.Hash())
+	}
+	if seqNo.Cmp(big.NewInt(0).Add(prevSeqNo, big.NewInt(1))) == 0 && batch.Header.ParentHash != prevBatch.Hash() {
+		return fmt.Errorf("the batch %s with the sequence number %d does not extend the previous batch %s", batch.Hash(), seqNo, prevBatch.Hash())
+	}
+	return nil
+}
+
+// RollupCostEstimate estimates the L1 cost of publishing the pending rollup as calldata and as blobs, with the current
+// fees of the L1
+func (g *Guardian) RollupCostEstimate() (*common.RollupCostEstimate, error) {
+	if !g.hostData.IsSequencer {
+		return nil, errors.New("only the sequencer publishes rollups")
+	}
+	fromBatch, err := g.getLatestBatchNo()
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve the latest rolled up batch - %w", err)
+	}
+	size, batchCount, err := g.calculateNonRolledupBatchesSize(fromBatch)
+	if err != nil {
+		return nil, fmt.Errorf("could not estimate the size of the pending rollup - %w", err)
+	}
+	return g.sl.L1Publisher().EstimateRollupCost(uint64(float64(size)*batchCompressionFactor), batchCount, g.maxRollupSize)
+}
+
+// logRollupCost reports the cheapest way to publish the rollup produced. The rollups are only published as calldata for
+// now, the estimate tells the operators when switching to the blobs would pay off.
+func (g *Guardian) logRollupCost(rollupSize uint64, batchCount uint64) {
+	estimate, err := g.sl.L1Publisher().EstimateRollupCost(rollupSize, batchCount, g.maxRollupSize)
+	if err != nil {
+		g.logger.Debug("Could not estimate the cost of the rollup", log.ErrKey, err)
+		return
+	}
+	g.logger.Info("Estimated the cost of the rollup", "route", estimate.Route, "calldataCost", estimate.CalldataCost,
+		"blobCost", estimate.BlobCost, "breakEvenBatches", uint64(estimate.BreakEvenBatches))
+	if estimate.Route == common.BlobRoute && g.l1HasBlobs {
+		g.logger.Warn("Publishing the rollup as blobs would be cheaper, it is published as calldata", "size", rollupSize)
+	}
+}
+
+// calculateNonRolledupBatchesSize returns the size and the number of the batches from the sequence number
+func (g *Guardian) calculateNonRolledupBatchesSize(seqNo uint64) (uint64, uint64, error) {
+	var size, count uint64
 
 	if seqNo == 0 { // don't calculate for seqNo 0 batches
-		return 0, nil
+		return 0, 0, nil
 	}
 
 	currentNo := seqNo
@@ -657,15 +1070,16 @@ func (g *Guardian) calculateNonRolledupBatchesSize(seqNo uint64) (uint64, error)
 			if errors.Is(err, errutil.ErrNotFound) {
 				break // no more batches
 			}
-			return 0, err
+			return 0, 0, err
 		}
 
 		bSize := len(batch.EncryptedTxBlob)
 		size += uint64(bSize)
+		count++
 		currentNo++
 	}
 
-	return size, nil
+	return size, count, nil
 }
 
 func (g *Guardian) getLatestBatchNo() (uint64, error) {
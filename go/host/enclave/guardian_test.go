@@ -0,0 +1,266 @@
+package enclave
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/host"
+	"github.com/ten-protocol/go-ten/go/common/queue"
+	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
+	"github.com/ten-protocol/go-ten/go/ethadapter"
+	"github.com/ten-protocol/go-ten/go/host/db"
+	"github.com/ten-protocol/go-ten/go/responses"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+func newTestBatch(seqNo int64, parent common.L2BatchHash, extra byte) *common.ExtBatch {
+	return &common.ExtBatch{Header: &common.BatchHeader{
+		ParentHash:       parent,
+		Number:           big.NewInt(seqNo),
+		SequencerOrderNo: big.NewInt(seqNo),
+		BaseFee:          big.NewInt(int64(extra)),
+	}}
+}
+
+func TestCheckProducedBatch(t *testing.T) {
+	prevBatch := newTestBatch(10, gethcommon.Hash{}, 0)
+
+	tests := map[string]struct {
+		batch *common.ExtBatch
+		err   string
+	}{
+		"next batch": {
+			batch: newTestBatch(11, prevBatch.Hash(), 0),
+		},
+		"same batch streamed again": {
+			batch: prevBatch,
+		},
+		"batch after a gap in the stream": {
+			batch: newTestBatch(13, gethcommon.HexToHash("0x01"), 0),
+		},
+		"conflicting batch": {
+			batch: newTestBatch(10, gethcommon.Hash{}, 1),
+			err:   "two batches with the sequence number 10",
+		},
+		"next batch on another parent": {
+			batch: newTestBatch(11, gethcommon.HexToHash("0x01"), 0),
+			err:   "does not extend the previous batch",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := checkProducedBatch(prevBatch, test.batch)
+			if test.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, test.err)
+		})
+	}
+	require.NoError(t, checkProducedBatch(nil, prevBatch))
+}
+
+func TestReadOnlyMode(t *testing.T) {
+	sequencer := &Guardian{hostData: host.Identity{IsSequencer: true}, logger: stateTrackerLogger}
+	readOnly, _ := sequencer.ReadOnlyMode()
+	require.False(t, readOnly)
+
+	sequencer.onInvariantViolation(errors.New("conflicting batches"))
+	readOnly, reason := sequencer.ReadOnlyMode()
+	require.True(t, readOnly)
+	require.Equal(t, "conflicting batches", reason)
+
+	require.NoError(t, sequencer.SetReadOnlyMode(false, "investigated"))
+	readOnly, reason = sequencer.ReadOnlyMode()
+	require.False(t, readOnly)
+	require.Empty(t, reason)
+
+	validator := &Guardian{hostData: host.Identity{IsSequencer: false}, logger: stateTrackerLogger}
+	require.Error(t, validator.SetReadOnlyMode(true, "maintenance"))
+}
+
+// blockEnclave records the L1 blocks submitted to it, and rejects the blocks it has already processed
+type blockEnclave struct {
+	common.Enclave
+	submitted []gethcommon.Hash
+	processed map[gethcommon.Hash]bool
+}
+
+func (e *blockEnclave) SubmitL1Block(block common.L1Block, _ common.L1Receipts, _ bool) (*common.BlockSubmissionResponse, common.SystemError) {
+	e.submitted = append(e.submitted, block.Hash())
+	if e.processed[block.Hash()] {
+		return nil, errutil.ErrBlockAlreadyProcessed
+	}
+	e.processed[block.Hash()] = true
+	return &common.BlockSubmissionResponse{}, nil
+}
+
+// blockServices serves the L1 blocks of a chain
+type blockServices struct {
+	guardianServiceLocator
+	repo *blockRepo
+}
+
+func (s *blockServices) L1Repo() host.L1BlockRepository {
+	return s.repo
+}
+
+func (s *blockServices) L1Publisher() host.L1Publisher {
+	return &noTxPublisher{}
+}
+
+type blockRepo struct {
+	host.L1BlockRepository
+	chain []*types.Block
+}
+
+func (r *blockRepo) FetchObscuroReceipts(*common.L1Block) (types.Receipts, error) {
+	return nil, nil
+}
+
+func (r *blockRepo) FetchBlockByHeight(height *big.Int) (*types.Block, error) {
+	if height.Uint64() >= uint64(len(r.chain)) {
+		return nil, errors.New("not found")
+	}
+	return r.chain[height.Uint64()], nil
+}
+
+type noTxPublisher struct {
+	host.L1Publisher
+}
+
+func (p *noTxPublisher) ExtractObscuroRelevantTransactions(*types.Block) ([]*ethadapter.L1RespondSecretTx, []*ethadapter.L1RollupTx, []*ethadapter.L1SetImportantContractsTx) {
+	return nil, nil, nil
+}
+
+func TestL1BlocksSubmittedOnce(t *testing.T) {
+	var chain []*types.Block
+	parent := gethcommon.Hash{}
+	for i := int64(0); i < 3; i++ {
+		block := types.NewBlockWithHeader(&types.Header{ParentHash: parent, Number: big.NewInt(i)})
+		chain = append(chain, block)
+		parent = block.Hash()
+	}
+	enclave := &blockEnclave{processed: map[gethcommon.Hash]bool{}}
+	guardian := &Guardian{
+		state:           NewStateTracker(stateTrackerLogger),
+		enclaveClient:   enclave,
+		sl:              &blockServices{repo: &blockRepo{chain: chain}},
+		db:              db.NewInMemoryDB(nil, nil),
+		submittedBlocks: lru.NewCache[gethcommon.Hash, struct{}](_submittedBlocksCacheSize),
+		logger:          stateTrackerLogger,
+	}
+
+	// the catch-up feeds the blocks, then the L1 listener receives them
+	for _, block := range chain[:2] {
+		processed, err := guardian.submitL1Block(block, true)
+		require.NoError(t, err)
+		require.True(t, processed)
+	}
+	for _, block := range chain[:2] {
+		processed, err := guardian.submitL1Block(block, true)
+		require.NoError(t, err)
+		require.False(t, processed)
+	}
+	require.Equal(t, []gethcommon.Hash{chain[0].Hash(), chain[1].Hash()}, enclave.submitted)
+
+	// returning to a block the enclave has seen goes straight to the next canonical block
+	guardian.state.OnProcessedBlock(gethcommon.HexToHash("0x01"))
+	processed, err := guardian.submitL1Block(chain[1], false)
+	require.NoError(t, err)
+	require.True(t, processed)
+	require.Equal(t, []gethcommon.Hash{chain[0].Hash(), chain[1].Hash(), chain[2].Hash()}, enclave.submitted)
+	require.Equal(t, chain[2].Hash(), guardian.state.GetEnclaveL1Head())
+}
+
+// batchEnclave reports whether each batch it is asked to create could be skipped if empty
+type batchEnclave struct {
+	common.Enclave
+	created chan bool
+}
+
+func (e *batchEnclave) CreateBatch(skipIfEmpty bool) common.SystemError {
+	e.created <- skipIfEmpty
+	return nil
+}
+
+func TestPeriodicBatchProduction(t *testing.T) {
+	clk := clock.NewFake(time.Now())
+	enclave := &batchEnclave{created: make(chan bool)}
+	interrupter := stopcontrol.New()
+	defer interrupter.Stop()
+	guardian := &Guardian{
+		state:            NewStateTracker(stateTrackerLogger),
+		enclaveClient:    enclave,
+		batchInterval:    time.Second,
+		maxBatchInterval: 5 * time.Second,
+		hostInterrupter:  interrupter,
+		clock:            clk,
+		clockMonitor:     newClockMonitor("", 0, 0, clk, stateTrackerLogger),
+		logger:           stateTrackerLogger,
+	}
+	guardian.state.status = Live
+
+	go guardian.periodicBatchProduction()
+	clk.BlockUntil(1)
+
+	// no batch was produced yet, so the first one is produced even if empty
+	clk.Advance(time.Second)
+	require.False(t, <-enclave.created)
+	guardian.lastBatchCreated = clk.Now()
+
+	// then the empty batches are skipped until the max batch interval elapsed
+	for i := 0; i < 4; i++ {
+		clk.Advance(time.Second)
+		require.True(t, <-enclave.created)
+	}
+	clk.Advance(time.Second)
+	require.False(t, <-enclave.created)
+}
+
+// slowTxEnclave only accepts a transaction once it is released
+type slowTxEnclave struct {
+	common.Enclave
+	release   chan struct{}
+	submitted chan common.EncryptedTx
+}
+
+func (e *slowTxEnclave) SubmitTx(tx common.EncryptedTx) (*responses.RawTx, common.SystemError) {
+	<-e.release
+	e.submitted <- tx
+	return responses.AsEmptyResponse(), nil
+}
+
+func TestSlowEnclaveDoesNotStallTransactions(t *testing.T) {
+	enclave := &slowTxEnclave{release: make(chan struct{}), submitted: make(chan common.EncryptedTx, 10)}
+	interrupter := stopcontrol.New()
+	defer interrupter.Stop()
+	guardian := &Guardian{
+		enclaveClient:   enclave,
+		txs:             queue.New[common.EncryptedTx](t.Name(), 2, queue.SpillToDisk, t.TempDir(), nil, stateTrackerLogger),
+		hostInterrupter: interrupter,
+		logger:          stateTrackerLogger,
+	}
+	defer guardian.txs.Close()
+	go guardian.processTransactions()
+
+	// the transactions are received while the enclave is stuck on the first one
+	for i := byte(0); i < 5; i++ {
+		guardian.HandleTransaction(common.EncryptedTx{i})
+	}
+
+	close(enclave.release)
+	for i := byte(0); i < 5; i++ {
+		require.Equal(t, common.EncryptedTx{i}, <-enclave.submitted)
+	}
+}
@@ -0,0 +1,52 @@
+package enclave
+
+import (
+	"github.com/ten-protocol/go-ten/go/common"
+
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+// rollupMetrics exports the compression statistics of the rollups processed by the enclave: the totals, which give the
+// compression ratio over any period, and the sizes of the latest rollup
+type rollupMetrics struct {
+	rollups         gethmetrics.Counter
+	batches         gethmetrics.Counter
+	rawTxBytes      gethmetrics.Counter
+	payloadBytes    gethmetrics.Counter
+	headerBytes     gethmetrics.Counter
+	reorgExceptions gethmetrics.Counter
+
+	lastRawTxBytes   gethmetrics.Gauge
+	lastPayloadBytes gethmetrics.Gauge
+	lastHeaderBytes  gethmetrics.Gauge
+	lastRatio        gethmetrics.GaugeFloat64
+}
+
+func newRollupMetrics(registry gethmetrics.Registry) *rollupMetrics {
+	return &rollupMetrics{
+		rollups:          gethmetrics.NewRegisteredCounter("host/rollup/count", registry),
+		batches:          gethmetrics.NewRegisteredCounter("host/rollup/batches", registry),
+		rawTxBytes:       gethmetrics.NewRegisteredCounter("host/rollup/rawtx/bytes", registry),
+		payloadBytes:     gethmetrics.NewRegisteredCounter("host/rollup/payload/bytes", registry),
+		headerBytes:      gethmetrics.NewRegisteredCounter("host/rollup/header/bytes", registry),
+		reorgExceptions:  gethmetrics.NewRegisteredCounter("host/rollup/reorgs", registry),
+		lastRawTxBytes:   gethmetrics.NewRegisteredGauge("host/rollup/last/rawtx/bytes", registry),
+		lastPayloadBytes: gethmetrics.NewRegisteredGauge("host/rollup/last/payload/bytes", registry),
+		lastHeaderBytes:  gethmetrics.NewRegisteredGauge("host/rollup/last/header/bytes", registry),
+		lastRatio:        gethmetrics.NewRegisteredGaugeFloat64("host/rollup/last/ratio", registry),
+	}
+}
+
+func (m *rollupMetrics) onRollup(stats *common.RollupCompressionStats) {
+	m.rollups.Inc(1)
+	m.batches.Inc(int64(stats.Batches))
+	m.rawTxBytes.Inc(int64(stats.RawTxBytes))
+	m.payloadBytes.Inc(int64(stats.PayloadBytes))
+	m.headerBytes.Inc(int64(stats.HeaderBytes))
+	m.reorgExceptions.Inc(int64(stats.ReorgExceptions))
+
+	m.lastRawTxBytes.Update(int64(stats.RawTxBytes))
+	m.lastPayloadBytes.Update(int64(stats.PayloadBytes))
+	m.lastHeaderBytes.Update(int64(stats.HeaderBytes))
+	m.lastRatio.Update(stats.CompressionRatio())
+}
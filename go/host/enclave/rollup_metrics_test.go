@@ -0,0 +1,29 @@
+package enclave
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestRollupMetrics(t *testing.T) {
+	registry := gethmetrics.NewRegistry()
+	metrics := newRollupMetrics(registry)
+
+	metrics.onRollup(&common.RollupCompressionStats{Batches: 3, RawTxBytes: 1000, PayloadBytes: 400, HeaderBytes: 50, ReorgExceptions: 1})
+	metrics.onRollup(&common.RollupCompressionStats{Batches: 2, RawTxBytes: 1000, PayloadBytes: 250, HeaderBytes: 40})
+
+	if !gethmetrics.Enabled {
+		return
+	}
+	require.Equal(t, int64(2), registry.Get("host/rollup/count").(gethmetrics.Counter).Count())
+	require.Equal(t, int64(5), registry.Get("host/rollup/batches").(gethmetrics.Counter).Count())
+	require.Equal(t, int64(2000), registry.Get("host/rollup/rawtx/bytes").(gethmetrics.Counter).Count())
+	require.Equal(t, int64(650), registry.Get("host/rollup/payload/bytes").(gethmetrics.Counter).Count())
+	require.Equal(t, int64(1), registry.Get("host/rollup/reorgs").(gethmetrics.Counter).Count())
+	require.Equal(t, int64(40), registry.Get("host/rollup/last/header/bytes").(gethmetrics.Gauge).Value())
+	require.InDelta(t, 0.25, registry.Get("host/rollup/last/ratio").(gethmetrics.GaugeFloat64).Value(), 1e-9)
+}
@@ -0,0 +1,94 @@
+package enclave
+
+import (
+	"sync"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+)
+
+// the window the rate of the secret responses is measured over
+const _secretResponseRateWindow = time.Hour
+
+// secretResponder decides which of the secret responses produced by the enclave are published to the L1. The enclave
+// answers every secret request of every block it processes, including the requests replayed on the L1, so the responses
+// are deduplicated per attestation owner and rate-limited, as each of them costs an L1 transaction.
+type secretResponder struct {
+	disabled   bool          // whether the node never responds, e.g. on a validator-only node
+	cooldown   time.Duration // the time before an attestation owner is responded to again (0 for no deduplication)
+	maxPerHour int           // the number of responses published in an hour at most (0 for no limit)
+	clock      clock.Clock
+	logger     gethlog.Logger
+
+	lock      sync.Mutex
+	responded map[gethcommon.Address]time.Time // the time of the latest response to each attestation owner
+	published []time.Time                      // the times of the responses published in the rate window, oldest first
+}
+
+func newSecretResponder(disabled bool, cooldown time.Duration, maxPerHour int, clk clock.Clock, logger gethlog.Logger) *secretResponder {
+	return &secretResponder{
+		disabled:   disabled,
+		cooldown:   cooldown,
+		maxPerHour: maxPerHour,
+		clock:      clk,
+		logger:     logger,
+		responded:  map[gethcommon.Address]time.Time{},
+	}
+}
+
+// allow returns whether the response should be published, in which case it is counted as published
+func (r *secretResponder) allow(resp *common.ProducedSecretResponse) bool {
+	if r.disabled {
+		r.logger.Trace("Secret responses are disabled, not publishing response to secret request.", "requester", resp.RequesterID)
+		return false
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	now := r.clock.Now()
+
+	if last, found := r.responded[resp.RequesterID]; found && r.cooldown > 0 && now.Sub(last) < r.cooldown {
+		r.logger.Debug("Already responded to a secret request of the requester, not publishing the response.",
+			"requester", resp.RequesterID, "respondedAt", last)
+		return false
+	}
+
+	// forget the responses out of the window
+	i := 0
+	for i < len(r.published) && now.Sub(r.published[i]) >= _secretResponseRateWindow {
+		i++
+	}
+	r.published = r.published[i:]
+	if r.maxPerHour > 0 && len(r.published) >= r.maxPerHour {
+		r.logger.Warn("Too many secret responses published, not publishing the response.",
+			"requester", resp.RequesterID, "maxPerHour", r.maxPerHour)
+		return false
+	}
+
+	r.responded[resp.RequesterID] = now
+	r.published = append(r.published, now)
+	r.prune(now)
+	return true
+}
+
+// failed forgets a response allowed that could not be published, so that the requester can be responded to again
+func (r *secretResponder) failed(resp *common.ProducedSecretResponse) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	delete(r.responded, resp.RequesterID)
+	if len(r.published) > 0 {
+		r.published = r.published[:len(r.published)-1]
+	}
+}
+
+// prune forgets the owners whose cooldown is over, so that the map does not grow with every requester ever seen
+func (r *secretResponder) prune(now time.Time) {
+	for owner, last := range r.responded {
+		if now.Sub(last) >= r.cooldown {
+			delete(r.responded, owner)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+package enclave
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+func secretResponseTo(requester string) *common.ProducedSecretResponse {
+	return &common.ProducedSecretResponse{RequesterID: gethcommon.HexToAddress(requester)}
+}
+
+func TestSecretResponderDeduplicates(t *testing.T) {
+	clk := clock.NewFake(time.Now())
+	responder := newSecretResponder(false, 10*time.Minute, 0, clk, stateTrackerLogger)
+
+	require.True(t, responder.allow(secretResponseTo("0x01")))
+	// the request replayed in a later block is not responded to again
+	require.False(t, responder.allow(secretResponseTo("0x01")))
+	require.True(t, responder.allow(secretResponseTo("0x02")))
+
+	// the requester is responded to again once the cooldown is over
+	clk.Advance(10 * time.Minute)
+	require.True(t, responder.allow(secretResponseTo("0x01")))
+
+	// a response that could not be published does not count
+	responder.failed(secretResponseTo("0x01"))
+	require.True(t, responder.allow(secretResponseTo("0x01")))
+}
+
+func TestSecretResponderRateLimits(t *testing.T) {
+	clk := clock.NewFake(time.Now())
+	responder := newSecretResponder(false, 0, 2, clk, stateTrackerLogger)
+
+	require.True(t, responder.allow(secretResponseTo("0x01")))
+	clk.Advance(30 * time.Minute)
+	require.True(t, responder.allow(secretResponseTo("0x02")))
+	require.False(t, responder.allow(secretResponseTo("0x03")))
+
+	// the first response leaves the window
+	clk.Advance(30 * time.Minute)
+	require.True(t, responder.allow(secretResponseTo("0x03")))
+	require.False(t, responder.allow(secretResponseTo("0x04")))
+}
+
+func TestSecretResponderDisabled(t *testing.T) {
+	responder := newSecretResponder(true, 0, 0, clock.NewFake(time.Now()), stateTrackerLogger)
+	require.False(t, responder.allow(secretResponseTo("0x01")))
+}
@@ -1,9 +1,12 @@
 package enclave
 
 import (
+	"errors"
 	"fmt"
 	"math/big"
+	"sync"
 	"sync/atomic"
+	"time"
 
 	gethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -14,6 +17,11 @@ import (
 	"github.com/ten-protocol/go-ten/go/responses"
 )
 
+const (
+	// the health of the active enclave is checked at this interval, when the host has standby enclaves
+	_failoverCheckInterval = time.Second
+)
+
 // This private interface enforces the services that the enclaves service depends on
 type enclaveServiceLocator interface {
 	P2P() host.P2P
@@ -23,31 +31,59 @@ type enclaveServiceLocator interface {
 type Service struct {
 	hostData host.Identity
 	sl       enclaveServiceLocator
-	// eventually this service will support multiple enclaves for HA, but currently there's only one
-	// The service goes via the Guardian to talk to the enclave (because guardian knows if the enclave is healthy etc.)
-	enclaveGuardian *Guardian
+	// the guardians of the enclaves of the host: the primary enclave first, then the hot standby enclaves. The service
+	// goes via the Guardian of the active enclave to talk to it (because guardian knows if the enclave is healthy etc.)
+	guardians []*Guardian
+
+	promotionLock     sync.Mutex
+	active            atomic.Int32 // the index of the active enclave
+	failoverThreshold int          // the consecutive failed health checks of the active enclave before a failover, 0 disables it
+	failedChecks      int          // only accessed by the monitoring of the active enclave
 
 	running atomic.Bool
+	stop    chan struct{}
 	logger  gethlog.Logger
 }
 
-func NewService(hostData host.Identity, serviceLocator enclaveServiceLocator, enclaveGuardian *Guardian, logger gethlog.Logger) *Service {
-	return &Service{
-		hostData:        hostData,
-		sl:              serviceLocator,
-		enclaveGuardian: enclaveGuardian,
-		logger:          logger,
+func NewService(hostData host.Identity, serviceLocator enclaveServiceLocator, enclaveGuardians []*Guardian, failoverThreshold int, logger gethlog.Logger) *Service {
+	e := &Service{
+		hostData:          hostData,
+		sl:                serviceLocator,
+		guardians:         enclaveGuardians,
+		failoverThreshold: failoverThreshold,
+		stop:              make(chan struct{}),
+		logger:            logger,
+	}
+	for i, guardian := range enclaveGuardians {
+		guardian.setStandby(i > 0)
+		guardian.onProducedBatch = e.feedStandbys
 	}
+	return e
 }
 
 func (e *Service) Start() error {
 	e.running.Store(true)
-	return e.enclaveGuardian.Start()
+	for _, guardian := range e.guardians {
+		if err := guardian.Start(); err != nil {
+			return err
+		}
+	}
+	if len(e.guardians) > 1 && e.failoverThreshold > 0 {
+		go e.monitorActiveEnclave()
+	}
+	return nil
 }
 
 func (e *Service) Stop() error {
-	e.running.Store(false)
-	return e.enclaveGuardian.Stop()
+	if e.running.Swap(false) {
+		close(e.stop)
+	}
+	for _, guardian := range e.guardians {
+		if err := guardian.Stop(); err != nil {
+			e.logger.Error("error stopping enclave guardian", log.ErrKey, err)
+		}
+	}
+	return nil
 }
 
 func (e *Service) HealthStatus() host.HealthStatus {
@@ -56,14 +92,14 @@ func (e *Service) HealthStatus() host.HealthStatus {
 	}
 
 	// check the enclave health, which in turn checks the DB health
-	enclaveHealthy, err := e.enclaveGuardian.enclaveClient.HealthCheck()
+	enclaveHealthy, err := e.activeGuardian().enclaveClient.HealthCheck()
 	if err != nil {
 		return &host.BasicErrHealthStatus{ErrMsg: fmt.Sprintf("unable to HealthCheck enclave - %s", err.Error())}
 	} else if !enclaveHealthy {
 		return &host.BasicErrHealthStatus{ErrMsg: "enclave reported itself as not healthy"}
 	}
 
-	if !e.enclaveGuardian.GetEnclaveState().InSyncWithL1() {
+	if !e.activeGuardian().GetEnclaveState().InSyncWithL1() {
 		return &host.BasicErrHealthStatus{ErrMsg: "enclave not in sync with L1"}
 	}
 
@@ -71,25 +107,113 @@ func (e *Service) HealthStatus() host.HealthStatus {
 	return &host.BasicErrHealthStatus{ErrMsg: ""}
 }
 
+// returns the guardian of the active enclave
+func (e *Service) activeGuardian() *Guardian {
+	return e.guardians[e.active.Load()]
+}
+
+// PromoteEnclave makes the enclave at the index the active enclave of the host, the others becoming its hot standbys.
+// The enclave must be live, and, for a sequencer, have executed all the batches of the host, so that it produces the
+// next batch on top of the latest one.
+func (e *Service) PromoteEnclave(enclave int) error {
+	e.promotionLock.Lock()
+	defer e.promotionLock.Unlock()
+	if enclave < 0 || enclave >= len(e.guardians) {
+		return fmt.Errorf("no enclave %d, the host has %d enclaves", enclave, len(e.guardians))
+	}
+	previous := int(e.active.Load())
+	if enclave == previous {
+		return nil
+	}
+	candidate := e.guardians[enclave]
+	if status := candidate.GetEnclaveState().GetStatus(); status != Live {
+		return fmt.Errorf("the enclave %d is not live (%s)", enclave, status)
+	}
+	if e.hostData.IsSequencer {
+		head, err := candidate.db.GetHeadBatchHeader()
+		if err != nil && !errors.Is(err, errutil.ErrNotFound) {
+			return fmt.Errorf("could not read the head batch of the host - %w", err)
+		}
+		enclaveHead := candidate.GetEnclaveState().GetEnclaveL2Head()
+		if head != nil && (enclaveHead == nil || enclaveHead.Cmp(head.SequencerOrderNo) < 0) {
+			return fmt.Errorf("the enclave %d is behind the head batch %d of the host", enclave, head.SequencerOrderNo)
+		}
+	}
+
+	e.guardians[previous].setStandby(true)
+	candidate.setStandby(false)
+	e.active.Store(int32(enclave))
+	e.logger.Warn("Promoted a standby enclave to be the active enclave of the host", "enclave", enclave, "previous", previous)
+	return nil
+}
+
+// monitorActiveEnclave fails over to a standby enclave once the active enclave failed enough consecutive health checks
+func (e *Service) monitorActiveEnclave() {
+	ticker := time.NewTicker(_failoverCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			e.checkActiveEnclave()
+		case <-e.stop:
+			return
+		}
+	}
+}
+
+func (e *Service) checkActiveEnclave() {
+	healthy, err := e.activeGuardian().enclaveClient.HealthCheck()
+	if err == nil && healthy {
+		e.failedChecks = 0
+		return
+	}
+	e.failedChecks++
+	e.logger.Warn("The active enclave failed its health check", "failedChecks", e.failedChecks, log.ErrKey, err)
+	if e.failedChecks < e.failoverThreshold {
+		return
+	}
+	for i := range e.guardians {
+		if i == int(e.active.Load()) {
+			continue
+		}
+		err := e.PromoteEnclave(i)
+		if err == nil {
+			e.failedChecks = 0
+			return
+		}
+		e.logger.Warn("Could not fail over to the standby enclave", "enclave", i, log.ErrKey, err)
+	}
+	e.logger.Error("No standby enclave can take over from the failed active enclave")
+}
+
+// feeds a batch produced by the active enclave to the standby enclaves, each executing the batches in order
+func (e *Service) feedStandbys(batch *common.ExtBatch) {
+	for _, guardian := range e.guardians {
+		if guardian.IsStandby() {
+			guardian.feedStandbyBatch(batch)
+		}
+	}
+}
+
 // LookupBatchBySeqNo is used to fetch batch data from the enclave - it is only used as a fallback for the sequencer
 // host if it's missing a batch (other host services should use L2Repo to fetch batch data)
 func (e *Service) LookupBatchBySeqNo(seqNo *big.Int) (*common.ExtBatch, error) {
-	state := e.enclaveGuardian.GetEnclaveState()
+	state := e.activeGuardian().GetEnclaveState()
 	if state.GetEnclaveL2Head().Cmp(seqNo) < 0 {
 		return nil, errutil.ErrNotFound
 	}
-	client := e.enclaveGuardian.GetEnclaveClient()
+	client := e.activeGuardian().GetEnclaveClient()
 	return client.GetBatchBySeqNo(seqNo.Uint64())
 }
 
 func (e *Service) GetEnclaveClient() common.Enclave {
-	return e.enclaveGuardian.GetEnclaveClient()
+	return e.activeGuardian().GetEnclaveClient()
 }
 
 func (e *Service) SubmitAndBroadcastTx(encryptedParams common.EncryptedParamsSendRawTx) (*responses.RawTx, error) {
 	encryptedTx := common.EncryptedTx(encryptedParams)
 
-	enclaveResponse, sysError := e.enclaveGuardian.GetEnclaveClient().SubmitTx(encryptedTx)
+	enclaveResponse, sysError := e.activeGuardian().GetEnclaveClient().SubmitTx(encryptedTx)
 	if sysError != nil {
 		e.logger.Warn("Could not submit transaction due to sysError.", log.ErrKey, sysError)
 		return nil, sysError
@@ -99,6 +223,13 @@ func (e *Service) SubmitAndBroadcastTx(encryptedParams common.EncryptedParamsSen
 		return enclaveResponse, nil //nolint: nilerr
 	}
 
+	// the standby enclaves of the sequencer hold the pending transactions, in case they take over
+	for _, guardian := range e.guardians {
+		if guardian.IsStandby() && e.hostData.IsSequencer {
+			guardian.HandleTransaction(encryptedTx)
+		}
+	}
+
 	if !e.hostData.IsSequencer {
 		err := e.sl.P2P().SendTxToSequencer(encryptedTx)
 		if err != nil {
@@ -109,10 +240,53 @@ func (e *Service) SubmitAndBroadcastTx(encryptedParams common.EncryptedParamsSen
 	return enclaveResponse, nil
 }
 
+// Subscribe registers the subscription with all the enclaves, so that it survives a failover. The logs are only sent to
+// the subscribers by the active enclave.
 func (e *Service) Subscribe(id rpc.ID, encryptedParams common.EncryptedParamsLogSubscription) error {
-	return e.enclaveGuardian.GetEnclaveClient().Subscribe(id, encryptedParams)
+	if err := e.activeGuardian().GetEnclaveClient().Subscribe(id, encryptedParams); err != nil {
+		return err
+	}
+	for _, guardian := range e.guardians {
+		if !guardian.IsStandby() {
+			continue
+		}
+		if err := guardian.GetEnclaveClient().Subscribe(id, encryptedParams); err != nil {
+			e.logger.Warn("Could not register the subscription with a standby enclave", log.SubIDKey, id, log.ErrKey, err)
+		}
+	}
+	return nil
 }
 
 func (e *Service) Unsubscribe(id rpc.ID) error {
-	return e.enclaveGuardian.GetEnclaveClient().Unsubscribe(id)
+	for _, guardian := range e.guardians {
+		if !guardian.IsStandby() {
+			continue
+		}
+		if err := guardian.GetEnclaveClient().Unsubscribe(id); err != nil {
+			e.logger.Warn("Could not remove the subscription from a standby enclave", log.SubIDKey, id, log.ErrKey, err)
+		}
+	}
+	return e.activeGuardian().GetEnclaveClient().Unsubscribe(id)
+}
+
+func (e *Service) ReadOnlyMode() (bool, string) {
+	return e.activeGuardian().ReadOnlyMode()
+}
+
+// SetReadOnlyMode switches all the enclaves of the sequencer, so that the mode is kept through a failover
+func (e *Service) SetReadOnlyMode(readOnly bool, reason string) error {
+	for _, guardian := range e.guardians {
+		if err := guardian.SetReadOnlyMode(readOnly, reason); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (e *Service) SyncStatus() *common.SyncStatus {
+	return e.activeGuardian().SyncStatus()
+}
+
+func (e *Service) RollupCostEstimate() (*common.RollupCostEstimate, error) {
+	return e.activeGuardian().RollupCostEstimate()
 }
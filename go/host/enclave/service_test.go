@@ -0,0 +1,128 @@
+package enclave
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/host"
+	"github.com/ten-protocol/go-ten/go/common/queue"
+	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
+	"github.com/ten-protocol/go-ten/go/host/db"
+)
+
+// healthEnclave reports the configured health
+type healthEnclave struct {
+	common.Enclave
+	healthy bool
+}
+
+func (e *healthEnclave) HealthCheck() (bool, common.SystemError) {
+	if !e.healthy {
+		return false, errors.New("enclave unreachable")
+	}
+	return true, nil
+}
+
+func newTestGuardian(hostDB *db.DB, enclave *healthEnclave, l2Head int64) *Guardian {
+	guardian := &Guardian{
+		hostData:      host.Identity{IsSequencer: true},
+		state:         NewStateTracker(stateTrackerLogger),
+		enclaveClient: enclave,
+		db:            hostDB,
+		logger:        stateTrackerLogger,
+	}
+	guardian.state.status = Live
+	guardian.state.enclaveL2Head = big.NewInt(l2Head)
+	return guardian
+}
+
+func TestPromoteEnclave(t *testing.T) {
+	hostDB := db.NewInMemoryDB(nil, nil)
+	require.NoError(t, hostDB.AddBatch(newTestBatch(10, common.L2BatchHash{}, 0)))
+	guardians := []*Guardian{
+		newTestGuardian(hostDB, &healthEnclave{healthy: true}, 10),
+		newTestGuardian(hostDB, &healthEnclave{healthy: true}, 9),
+		newTestGuardian(hostDB, &healthEnclave{healthy: true}, 10),
+	}
+	guardians[2].state.status = L1Catchup
+	service := NewService(host.Identity{IsSequencer: true}, nil, guardians, 3, stateTrackerLogger)
+	require.False(t, guardians[0].IsStandby())
+	require.True(t, guardians[1].IsStandby())
+
+	require.ErrorContains(t, service.PromoteEnclave(3), "no enclave 3")
+	require.ErrorContains(t, service.PromoteEnclave(1), "behind the head batch 10")
+	require.ErrorContains(t, service.PromoteEnclave(2), "not live")
+	require.Equal(t, guardians[0], service.activeGuardian())
+
+	guardians[2].state.status = Live
+	require.NoError(t, service.PromoteEnclave(2))
+	require.Equal(t, guardians[2], service.activeGuardian())
+	require.True(t, guardians[0].IsStandby())
+	require.False(t, guardians[2].IsStandby())
+}
+
+func TestFailoverToStandbyEnclave(t *testing.T) {
+	hostDB := db.NewInMemoryDB(nil, nil)
+	require.NoError(t, hostDB.AddBatch(newTestBatch(10, common.L2BatchHash{}, 0)))
+	active := &healthEnclave{healthy: true}
+	guardians := []*Guardian{
+		newTestGuardian(hostDB, active, 10),
+		newTestGuardian(hostDB, &healthEnclave{healthy: true}, 10),
+	}
+	service := NewService(host.Identity{IsSequencer: true}, nil, guardians, 3, stateTrackerLogger)
+
+	// the failed checks must be consecutive
+	active.healthy = false
+	service.checkActiveEnclave()
+	service.checkActiveEnclave()
+	active.healthy = true
+	service.checkActiveEnclave()
+	require.Equal(t, guardians[0], service.activeGuardian())
+
+	active.healthy = false
+	for i := 0; i < 3; i++ {
+		service.checkActiveEnclave()
+	}
+	require.Equal(t, guardians[1], service.activeGuardian())
+	require.True(t, guardians[0].IsStandby())
+}
+
+// batchRecordingEnclave records the sequence numbers of the batches submitted to it
+type batchRecordingEnclave struct {
+	healthEnclave
+	submitted chan int64
+}
+
+func (e *batchRecordingEnclave) SubmitBatch(batch *common.ExtBatch) common.SystemError {
+	e.submitted <- batch.Header.SequencerOrderNo.Int64()
+	return nil
+}
+
+func TestStandbyExecutesBatchesInOrder(t *testing.T) {
+	interrupter := stopcontrol.New()
+	defer interrupter.Stop()
+	enclave := &batchRecordingEnclave{healthEnclave: healthEnclave{healthy: true}, submitted: make(chan int64, 10)}
+	active := newTestGuardian(db.NewInMemoryDB(nil, nil), &healthEnclave{healthy: true}, 0)
+	standby := newTestGuardian(db.NewInMemoryDB(nil, nil), &healthEnclave{healthy: true}, 1)
+	standby.enclaveClient = enclave
+	standby.hostInterrupter = interrupter
+	standby.standbyBatches = queue.New[*common.ExtBatch](t.Name(), 2, queue.SpillToDisk, t.TempDir(), nil, stateTrackerLogger)
+	defer standby.standbyBatches.Close()
+	standby.state.OnReceivedBatch(big.NewInt(1))
+	standby.state.OnReceivedBlock(_l1Block123)
+	standby.state.OnProcessedBlock(_l1Block123)
+	service := NewService(host.Identity{IsSequencer: true}, nil, []*Guardian{active, standby}, 3, stateTrackerLogger)
+
+	// the batches are queued while the standby enclave is busy, and a batch streamed again is skipped
+	for _, seqNo := range []int64{2, 3, 4, 3, 5, 6} {
+		service.feedStandbys(newTestBatch(seqNo, common.L2BatchHash{}, 0))
+	}
+	go standby.processStandbyBatches()
+	for _, seqNo := range []int64{2, 3, 4, 5, 6} {
+		require.Equal(t, seqNo, <-enclave.submitted)
+	}
+	require.Empty(t, enclave.submitted)
+}
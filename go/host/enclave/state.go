@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"math/big"
 	"sync"
+	"time"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	gethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/gethutil"
@@ -40,6 +42,9 @@ const (
 	L2Catchup
 )
 
+// a catching up enclave that did not process a block, a batch or a batch of a rollup for that long is stalled
+const syncStallTimeout = 2 * time.Minute
+
 // when the L2 head is 0 then it means no batch has been seen or processed (first seq number is always 1)
 var _noBatch = big.NewInt(0)
 
@@ -62,6 +67,10 @@ type StateTracker struct {
 	hostL1Head gethcommon.Hash
 	hostL2Head *big.Int
 
+	// the progress of the enclave, to tell a stalled enclave from one that is syncing slowly
+	lastProgress time.Time
+	rollupSync   *common.RollupSyncProgress
+
 	m      *sync.RWMutex
 	logger gethlog.Logger
 }
@@ -85,6 +94,7 @@ func (s *StateTracker) OnProcessedBlock(enclL1Head gethcommon.Hash) {
 	s.m.Lock()
 	defer s.m.Unlock()
 	s.enclaveL1Head = enclL1Head
+	s.lastProgress = time.Now()
 	s.setStatus(s.calculateStatus())
 }
 
@@ -104,9 +114,18 @@ func (s *StateTracker) OnProcessedBatch(enclL2HeadSeqNo *big.Int) {
 	}
 
 	s.enclaveL2Head = enclL2HeadSeqNo
+	s.lastProgress = time.Now()
 	s.setStatus(s.calculateStatus())
 }
 
+// OnRollupSyncProgress records the progress of the enclave through the batches of the rollups of an L1 block
+func (s *StateTracker) OnRollupSyncProgress(progress *common.RollupSyncProgress) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.rollupSync = progress
+	s.lastProgress = time.Now()
+}
+
 func (s *StateTracker) OnReceivedBatch(l2HeadSeqNo *big.Int) {
 	s.m.Lock()
 	defer s.m.Unlock()
@@ -187,6 +206,35 @@ func (s *StateTracker) GetEnclaveL2Head() *big.Int {
 	return big.NewInt(0).SetBytes(s.enclaveL2Head.Bytes())
 }
 
+// SyncStatus returns the sync status of the enclave. It is stalled when it is catching up and did not make any progress
+// for syncStallTimeout.
+func (s *StateTracker) SyncStatus() *common.SyncStatus {
+	s.m.RLock()
+	defer s.m.RUnlock()
+	status := &common.SyncStatus{
+		Status:        s.status.String(),
+		Syncing:       s.status != Live,
+		L1Head:        s.hostL1Head,
+		EnclaveL1Head: s.enclaveL1Head,
+		L2Head:        copyBig(s.hostL2Head),
+		EnclaveL2Head: copyBig(s.enclaveL2Head),
+		RollupSync:    s.rollupSync,
+	}
+	if !s.lastProgress.IsZero() {
+		status.LastProgress = hexutil.Uint64(s.lastProgress.Unix())
+		catchingUp := s.status == L1Catchup || s.status == L2Catchup
+		status.Stalled = catchingUp && time.Since(s.lastProgress) > syncStallTimeout
+	}
+	return status
+}
+
+func copyBig(i *big.Int) *hexutil.Big {
+	if i == nil {
+		return nil
+	}
+	return (*hexutil.Big)(new(big.Int).Set(i))
+}
+
 // this must be called from within write-lock
 func (s *StateTracker) setStatus(newStatus Status) {
 	if s.status == newStatus {
@@ -3,10 +3,12 @@ package enclave
 import (
 	"math/big"
 	"testing"
+	"time"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	gethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/log"
 )
 
@@ -73,3 +75,31 @@ func TestStateTracker_Disconnected(t *testing.T) {
 	s.OnDisconnected()
 	assert.Equal(t, Disconnected, s.GetStatus())
 }
+
+func TestStateTracker_SyncStatus(t *testing.T) {
+	s := NewStateTracker(stateTrackerLogger)
+	s.OnReceivedBlock(_l1Block123)
+	s.OnReceivedBatch(_l2Batch457)
+	s.OnEnclaveStatus(common.Status{StatusCode: common.Running, L1Head: _l1Block123, L2Head: _l2Batch456})
+	progress := &common.RollupSyncProgress{L1Block: _l1Block123, Batch: 3, Batches: 10, ETA: 7}
+	s.OnRollupSyncProgress(progress)
+
+	status := s.SyncStatus()
+	assert.Equal(t, L2Catchup.String(), status.Status)
+	assert.True(t, status.Syncing)
+	assert.False(t, status.Stalled)
+	assert.Equal(t, _l2Batch457, status.L2Head.ToInt())
+	assert.Equal(t, _l2Batch456, status.EnclaveL2Head.ToInt())
+	assert.Equal(t, progress, status.RollupSync)
+
+	// the enclave is stalled once it did not make any progress for a while
+	s.lastProgress = time.Now().Add(-syncStallTimeout - time.Second)
+	assert.True(t, s.SyncStatus().Stalled)
+
+	// a live enclave is neither syncing nor stalled
+	s.OnProcessedBatch(_l2Batch457)
+	s.lastProgress = time.Now().Add(-syncStallTimeout - time.Second)
+	status = s.SyncStatus()
+	assert.False(t, status.Syncing)
+	assert.False(t, status.Stalled)
+}
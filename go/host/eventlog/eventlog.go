@@ -0,0 +1,183 @@
+package eventlog
+
+import (
+	"encoding/json"
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+	"github.com/ten-protocol/go-ten/go/common/host"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/config"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+// This private interface enforces the services that the event log depends on
+type eventLogServiceLocator interface {
+	L1Repo() host.L1BlockRepository
+}
+
+// EventLog is a host service appending the public lifecycle events of the network to a file, one JSON record per line,
+// so that external indexers can follow the network without polling the RPC API. It does nothing if no file is
+// configured.
+type EventLog struct {
+	sl            eventLogServiceLocator
+	finalityDepth uint64
+	clock         clock.Clock
+
+	lock         sync.Mutex
+	log          *fileLog     // nil if the event log is disabled or stopped
+	head         *types.Block // the latest L1 head received
+	finalised    uint64       // the height of the last L1 block reported finalised
+	hasFinalised bool
+	lastErr      error // the last failure to write a record, reported by the health status
+	unsubscribe  func()
+
+	logger gethlog.Logger
+}
+
+// NewEventLog opens the event log configured, if any, and resumes its offsets
+func NewEventLog(cfg *config.HostConfig, serviceLocator eventLogServiceLocator, clk clock.Clock, logger gethlog.Logger) (*EventLog, error) {
+	l := &EventLog{
+		sl:            serviceLocator,
+		finalityDepth: cfg.EventLogFinalityDepth,
+		clock:         clk,
+		logger:        logger,
+	}
+	if cfg.EventLogPath == "" {
+		return l, nil
+	}
+	fileLog, err := openFileLog(cfg.EventLogPath)
+	if err != nil {
+		return nil, err
+	}
+	l.log = fileLog
+	return l, nil
+}
+
+func (l *EventLog) Start() error {
+	if l.log != nil {
+		l.unsubscribe = l.sl.L1Repo().Subscribe(l)
+	}
+	return nil
+}
+
+func (l *EventLog) Stop() error {
+	if l.unsubscribe != nil {
+		l.unsubscribe()
+	}
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.log == nil {
+		return nil
+	}
+	err := l.log.close()
+	l.log = nil
+	return err
+}
+
+func (l *EventLog) HealthStatus() host.HealthStatus {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	errMsg := ""
+	if l.lastErr != nil {
+		errMsg = "could not write to the event log - " + l.lastErr.Error()
+	}
+	return &host.BasicErrHealthStatus{ErrMsg: errMsg}
+}
+
+func (l *EventLog) BatchSealed(batch *common.ExtBatch) {
+	l.record(BatchSealed, batch.Hash().Hex(), &BatchSealedData{
+		Hash:       batch.Hash(),
+		ParentHash: batch.Header.ParentHash,
+		SeqNo:      batch.Header.SequencerOrderNo.Uint64(),
+		Number:     batch.Header.Number.Uint64(),
+		Timestamp:  batch.Header.Time,
+		L1Proof:    batch.Header.L1Proof,
+		TxCount:    len(batch.TxHashes),
+	})
+}
+
+func (l *EventLog) RollupPublished(rollup *common.ExtRollup, block *types.Block) {
+	l.record(RollupPublished, rollup.Hash().Hex(), &RollupPublishedData{
+		Hash:           rollup.Hash(),
+		LastBatchSeqNo: rollup.Header.LastBatchSeqNo,
+		L1BlockHash:    block.Hash(),
+		L1BlockNumber:  block.NumberU64(),
+	})
+}
+
+// HandleBlock records the L1 reorgs and the finalised L1 blocks as the L1 heads arrive. The heads can arrive out of
+// order, so a head that does not extend the previous one is only a reorg if the previous one is no longer canonical.
+func (l *EventLog) HandleBlock(block *types.Block) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.log == nil {
+		return
+	}
+
+	if l.head != nil && block.Hash() != l.head.Hash() && block.ParentHash() != l.head.Hash() {
+		canonical, err := l.sl.L1Repo().FetchBlockByHeight(l.head.Number())
+		switch {
+		case err != nil && !errors.Is(err, ethereum.NotFound):
+			l.logger.Warn("Could not check the L1 head for a reorg", log.ErrKey, err)
+		case err != nil || canonical.Hash() != l.head.Hash():
+			l.recordLocked(L1Reorg, block.Hash().Hex(), &L1ReorgData{
+				OldHeadHash:   l.head.Hash(),
+				OldHeadNumber: l.head.NumberU64(),
+				NewHeadHash:   block.Hash(),
+				NewHeadNumber: block.NumberU64(),
+			})
+		case block.NumberU64() <= l.head.NumberU64():
+			return // a late head, the previous one is still canonical
+		}
+	}
+	l.head = block
+	l.recordFinalised(block.NumberU64())
+}
+
+// recordFinalised records the L1 blocks that became final with the head. The L1 reorgs deeper than the finality depth
+// are not reported, the blocks are final for the indexers.
+func (l *EventLog) recordFinalised(headNumber uint64) {
+	if headNumber < l.finalityDepth {
+		return
+	}
+	target := headNumber - l.finalityDepth
+	from := target
+	if l.hasFinalised {
+		from = l.finalised + 1
+	}
+	for number := from; number <= target; number++ {
+		block, err := l.sl.L1Repo().FetchBlockByHeight(big.NewInt(0).SetUint64(number))
+		if err != nil {
+			l.logger.Warn("Could not fetch the finalised L1 block", "height", number, log.ErrKey, err)
+			return // retried with the next head
+		}
+		l.recordLocked(L1Finalised, block.Hash().Hex(), &L1FinalisedData{Hash: block.Hash(), Number: number})
+		l.finalised, l.hasFinalised = number, true
+	}
+}
+
+func (l *EventLog) record(eventType EventType, key string, data any) {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.log != nil {
+		l.recordLocked(eventType, key, data)
+	}
+}
+
+func (l *EventLog) recordLocked(eventType EventType, key string, data any) {
+	encoded, err := json.Marshal(data)
+	if err == nil {
+		err = l.log.append(&Record{Type: eventType, Key: key, Time: l.clock.Now().UTC(), Data: encoded})
+	}
+	if err != nil {
+		l.logger.Error("Could not write to the event log", "type", eventType, "hash", key, log.ErrKey, err)
+	}
+	l.lastErr = err
+}
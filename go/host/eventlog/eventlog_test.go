@@ -0,0 +1,155 @@
+package eventlog
+
+import (
+	"bufio"
+	"encoding/json"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+	"github.com/ten-protocol/go-ten/go/common/host"
+	"github.com/ten-protocol/go-ten/go/config"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+// chainServices serves the canonical blocks of an L1 chain
+type chainServices struct {
+	host.L1BlockRepository
+	canonical map[uint64]*types.Block
+}
+
+func (s *chainServices) L1Repo() host.L1BlockRepository {
+	return s
+}
+
+func (s *chainServices) FetchBlockByHeight(height *big.Int) (*types.Block, error) {
+	block, ok := s.canonical[height.Uint64()]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return block, nil
+}
+
+// extend returns a chain of blocks on top of the parent, made canonical
+func (s *chainServices) extend(parent *types.Block, length int, extra byte) []*types.Block {
+	blocks := make([]*types.Block, length)
+	for i := range blocks {
+		header := &types.Header{Number: big.NewInt(0), Extra: []byte{extra}}
+		if parent != nil {
+			header.ParentHash = parent.Hash()
+			header.Number = big.NewInt(0).Add(parent.Number(), big.NewInt(1))
+		}
+		parent = types.NewBlockWithHeader(header)
+		s.canonical[parent.NumberU64()] = parent
+		blocks[i] = parent
+	}
+	return blocks
+}
+
+func TestRecordsTheLifecycleEvents(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	services := &chainServices{canonical: map[uint64]*types.Block{}}
+	eventLog := newTestEventLog(t, path, services)
+
+	chain := services.extend(nil, 5, 0)
+	for _, block := range chain {
+		eventLog.HandleBlock(block)
+	}
+	// a late head is ignored
+	eventLog.HandleBlock(chain[3])
+
+	batch := &common.ExtBatch{Header: &common.BatchHeader{Number: big.NewInt(7), SequencerOrderNo: big.NewInt(9), Time: 100}, TxHashes: []common.TxHash{{1}}}
+	eventLog.BatchSealed(batch)
+	rollup := &common.ExtRollup{Header: &common.RollupHeader{LastBatchSeqNo: 9}}
+	eventLog.RollupPublished(rollup, chain[4])
+
+	// a fork from the block 2 replaces the blocks 3 and 4
+	fork := services.extend(chain[2], 2, 1)
+	eventLog.HandleBlock(fork[1])
+	require.NoError(t, eventLog.Stop())
+
+	records := readRecords(t, path)
+	require.Len(t, records, 6)
+	for i, record := range records {
+		require.Equal(t, uint64(i), record.Offset)
+	}
+	requireRecord(t, records[0], L1Finalised, chain[0].Hash(), &L1FinalisedData{Hash: chain[0].Hash(), Number: 0})
+	requireRecord(t, records[1], L1Finalised, chain[1].Hash(), &L1FinalisedData{Hash: chain[1].Hash(), Number: 1})
+	requireRecord(t, records[2], L1Finalised, chain[2].Hash(), &L1FinalisedData{Hash: chain[2].Hash(), Number: 2})
+	requireRecord(t, records[3], BatchSealed, batch.Hash(), &BatchSealedData{Hash: batch.Hash(), SeqNo: 9, Number: 7, Timestamp: 100, TxCount: 1})
+	requireRecord(t, records[4], RollupPublished, rollup.Hash(), &RollupPublishedData{Hash: rollup.Hash(), LastBatchSeqNo: 9, L1BlockHash: chain[4].Hash(), L1BlockNumber: 4})
+	requireRecord(t, records[5], L1Reorg, fork[1].Hash(), &L1ReorgData{OldHeadHash: chain[4].Hash(), OldHeadNumber: 4, NewHeadHash: fork[1].Hash(), NewHeadNumber: 4})
+}
+
+func TestResumesTheOffsetsAndDiscardsAPartialRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.ndjson")
+	services := &chainServices{canonical: map[uint64]*types.Block{}}
+	eventLog := newTestEventLog(t, path, services)
+	batch := &common.ExtBatch{Header: &common.BatchHeader{Number: big.NewInt(1), SequencerOrderNo: big.NewInt(1)}}
+	eventLog.BatchSealed(batch)
+	eventLog.BatchSealed(batch)
+	require.NoError(t, eventLog.Stop())
+
+	file, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	require.NoError(t, err)
+	_, err = file.WriteString(`{"offset":2,"type":"batchSe`)
+	require.NoError(t, err)
+	require.NoError(t, file.Close())
+
+	eventLog = newTestEventLog(t, path, services)
+	eventLog.BatchSealed(batch)
+	require.NoError(t, eventLog.Stop())
+
+	records := readRecords(t, path)
+	require.Len(t, records, 3)
+	require.Equal(t, uint64(2), records[2].Offset)
+}
+
+func TestDisabledEventLogRecordsNothing(t *testing.T) {
+	eventLog, err := NewEventLog(&config.HostConfig{}, &chainServices{}, clock.NewFake(time.Now()), gethlog.New())
+	require.NoError(t, err)
+	require.NoError(t, eventLog.Start())
+	eventLog.BatchSealed(&common.ExtBatch{Header: &common.BatchHeader{Number: big.NewInt(1), SequencerOrderNo: big.NewInt(1)}})
+	eventLog.HandleBlock(types.NewBlockWithHeader(&types.Header{Number: big.NewInt(10)}))
+	require.True(t, eventLog.HealthStatus().OK())
+	require.NoError(t, eventLog.Stop())
+}
+
+func newTestEventLog(t *testing.T, path string, services *chainServices) *EventLog {
+	eventLog, err := NewEventLog(&config.HostConfig{EventLogPath: path, EventLogFinalityDepth: 2}, services, clock.NewFake(time.Now()), gethlog.New())
+	require.NoError(t, err)
+	return eventLog
+}
+
+func readRecords(t *testing.T, path string) []*Record {
+	file, err := os.Open(path)
+	require.NoError(t, err)
+	defer file.Close()
+
+	var records []*Record
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		var record Record
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &record))
+		records = append(records, &record)
+	}
+	require.NoError(t, scanner.Err())
+	return records
+}
+
+func requireRecord[T any](t *testing.T, record *Record, eventType EventType, key gethcommon.Hash, expected *T) {
+	require.Equal(t, eventType, record.Type)
+	require.Equal(t, key.Hex(), record.Key)
+	var data T
+	require.NoError(t, json.Unmarshal(record.Data, &data))
+	require.Equal(t, expected, &data)
+}
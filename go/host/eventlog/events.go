@@ -0,0 +1,57 @@
+package eventlog
+
+import (
+	"encoding/json"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// EventType is the type of a public lifecycle event of the network
+type EventType string
+
+const (
+	BatchSealed     EventType = "batchSealed"     // a batch was produced or executed by the enclave
+	RollupPublished EventType = "rollupPublished" // a rollup was found in an L1 block
+	L1Reorg         EventType = "l1Reorg"         // the L1 head moved to a fork
+	L1Finalised     EventType = "l1Finalised"     // an L1 block is deep enough in the canonical chain to be considered final
+)
+
+// Record is a line of the event log. The records are self-contained so that the log can be streamed as is to a message
+// broker: the key is the hash the event is about (a batch, a rollup or an L1 block), usable as a Kafka message key.
+type Record struct {
+	Offset uint64          `json:"offset"` // the position of the record in the log, starting at 0 without gaps
+	Type   EventType       `json:"type"`
+	Key    string          `json:"key"`
+	Time   time.Time       `json:"time"` // when the host recorded the event
+	Data   json.RawMessage `json:"data"` // one of the *Data types below, depending on the type
+}
+
+type BatchSealedData struct {
+	Hash       gethcommon.Hash `json:"hash"`
+	ParentHash gethcommon.Hash `json:"parentHash"`
+	SeqNo      uint64          `json:"seqNo"`
+	Number     uint64          `json:"number"`
+	Timestamp  uint64          `json:"timestamp"`
+	L1Proof    gethcommon.Hash `json:"l1Proof"`
+	TxCount    int             `json:"txCount"`
+}
+
+type RollupPublishedData struct {
+	Hash           gethcommon.Hash `json:"hash"`
+	LastBatchSeqNo uint64          `json:"lastBatchSeqNo"`
+	L1BlockHash    gethcommon.Hash `json:"l1BlockHash"`
+	L1BlockNumber  uint64          `json:"l1BlockNumber"`
+}
+
+type L1ReorgData struct {
+	OldHeadHash   gethcommon.Hash `json:"oldHeadHash"`
+	OldHeadNumber uint64          `json:"oldHeadNumber"`
+	NewHeadHash   gethcommon.Hash `json:"newHeadHash"`
+	NewHeadNumber uint64          `json:"newHeadNumber"`
+}
+
+type L1FinalisedData struct {
+	Hash   gethcommon.Hash `json:"hash"`
+	Number uint64          `json:"number"`
+}
@@ -0,0 +1,95 @@
+package eventlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+)
+
+// the records are small, the last one is always found in the tail of the file
+const maxRecordSize = 64 * 1024
+
+// fileLog is an append-only file of records, one JSON record per line
+type fileLog struct {
+	file *os.File
+	size int64  // the size of the complete records
+	next uint64 // the offset of the next record
+}
+
+// openFileLog opens or creates the file and resumes its offsets. A record partially written when the host stopped is
+// discarded.
+func openFileLog(path string) (*fileLog, error) {
+	file, err := os.OpenFile(path, os.O_RDWR|os.O_CREATE|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	size, next, err := resume(file)
+	if err != nil {
+		_ = file.Close()
+		return nil, fmt.Errorf("could not resume the event log %s - %w", path, err)
+	}
+	return &fileLog{file: file, size: size, next: next}, nil
+}
+
+// resume truncates the partial record at the end of the file, if any, and returns the size of the file and the offset
+// following the last record
+func resume(file *os.File) (int64, uint64, error) {
+	info, err := file.Stat()
+	if err != nil {
+		return 0, 0, err
+	}
+	start := info.Size() - maxRecordSize
+	if start < 0 {
+		start = 0
+	}
+	tail := make([]byte, info.Size()-start)
+	if _, err = file.ReadAt(tail, start); err != nil && err != io.EOF {
+		return 0, 0, err
+	}
+
+	end := bytes.LastIndexByte(tail, '\n')
+	if end < 0 && start > 0 {
+		return 0, 0, fmt.Errorf("no record in the last %d bytes", maxRecordSize)
+	}
+	size := start + int64(end) + 1
+	if size < info.Size() {
+		if err = file.Truncate(size); err != nil {
+			return 0, 0, err
+		}
+	}
+	if end < 0 {
+		return 0, 0, nil // the file is empty
+	}
+
+	var last Record
+	if err = json.Unmarshal(tail[bytes.LastIndexByte(tail[:end], '\n')+1:end], &last); err != nil {
+		return 0, 0, fmt.Errorf("could not decode the last record - %w", err)
+	}
+	return size, last.Offset + 1, nil
+}
+
+// append writes the record at the end of the file, setting its offset. A record that could not be written completely is
+// truncated, so that the next records are not appended to it.
+func (f *fileLog) append(record *Record) error {
+	record.Offset = f.next
+	line, err := json.Marshal(record)
+	if err != nil {
+		return err
+	}
+	n, err := f.file.Write(append(line, '\n'))
+	if err != nil {
+		if n > 0 {
+			_ = f.file.Truncate(f.size)
+		}
+		return err
+	}
+	f.size += int64(n)
+	f.next++
+	return nil
+}
+
+func (f *fileLog) close() error {
+	return f.file.Close()
+}
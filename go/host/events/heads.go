@@ -0,0 +1,227 @@
+package events
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/host"
+	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// the events buffered for each subscription. A subscriber that falls further behind loses the oldest events, so a
+	// slow client does not hold up the others
+	_headSubscriptionBuffer = 64
+	// the subscriptions to the head events a client connection can hold at once
+	_maxHeadSubscriptionsPerConn = 16
+	// the sync status is polled at this interval while some clients are subscribed to its changes
+	_syncStatusPollInterval = time.Second
+)
+
+type headFeedServiceLocator interface {
+	Enclaves() host.EnclaveService
+}
+
+// HeadFeed streams the new batch heads, the rollups published on the L1 and the changes of the sync status of the node to
+// the client subscriptions (`eth_subscribe` over websockets).
+type HeadFeed struct {
+	sl headFeedServiceLocator
+
+	lock           sync.Mutex
+	subscriptions  map[host.HeadEventType]map[*headSubscription]struct{}
+	connSubs       map[string]int     // the number of subscriptions of each client connection
+	lastSyncStatus *common.SyncStatus // the latest sync status sent to the subscribers
+	stopped        bool
+
+	stopControl *stopcontrol.StopControl
+	logger      gethlog.Logger
+}
+
+type headSubscription struct {
+	conn    string
+	ch      chan any
+	dropped uint64 // the events dropped because the subscriber fell behind
+}
+
+func NewHeadFeed(serviceLocator headFeedServiceLocator, logger gethlog.Logger) *HeadFeed {
+	return &HeadFeed{
+		sl: serviceLocator,
+		subscriptions: map[host.HeadEventType]map[*headSubscription]struct{}{
+			host.NewHeadsEvent:   {},
+			host.NewRollupsEvent: {},
+			host.SyncStatusEvent: {},
+		},
+		connSubs:    map[string]int{},
+		stopControl: stopcontrol.New(),
+		logger:      logger,
+	}
+}
+
+func (f *HeadFeed) Start() error {
+	go f.pollSyncStatus()
+	return nil
+}
+
+// Stop closes the channels of all the subscriptions
+func (f *HeadFeed) Stop() error {
+	f.stopControl.Stop()
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	f.stopped = true
+	for _, subs := range f.subscriptions {
+		for sub := range subs {
+			close(sub.ch)
+			delete(subs, sub)
+		}
+	}
+	f.connSubs = map[string]int{}
+	return nil
+}
+
+func (f *HeadFeed) HealthStatus() host.HealthStatus {
+	// always healthy for now
+	return &host.BasicErrHealthStatus{ErrMsg: ""}
+}
+
+func (f *HeadFeed) Subscribe(eventType host.HeadEventType, conn string) (<-chan any, func(), error) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	subs, found := f.subscriptions[eventType]
+	if !found {
+		return nil, nil, fmt.Errorf("unsupported subscription type %s", eventType)
+	}
+	if f.stopped {
+		return nil, nil, fmt.Errorf("the head feed is stopped")
+	}
+	if f.connSubs[conn] >= _maxHeadSubscriptionsPerConn {
+		return nil, nil, fmt.Errorf("the connection already holds %d subscriptions", _maxHeadSubscriptionsPerConn)
+	}
+
+	sub := &headSubscription{conn: conn, ch: make(chan any, _headSubscriptionBuffer)}
+	subs[sub] = struct{}{}
+	f.connSubs[conn]++
+	// the subscribers of the sync status start from the current status
+	if eventType == host.SyncStatusEvent && f.lastSyncStatus != nil {
+		sub.ch <- f.lastSyncStatus
+	}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() { f.unsubscribe(eventType, sub) })
+	}
+	return sub.ch, unsubscribe, nil
+}
+
+func (f *HeadFeed) unsubscribe(eventType host.HeadEventType, sub *headSubscription) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	if _, found := f.subscriptions[eventType][sub]; !found {
+		return // the feed was stopped
+	}
+	delete(f.subscriptions[eventType], sub)
+	close(sub.ch)
+	// the status is not polled without subscribers, so the next subscribers must not start from a stale one
+	if eventType == host.SyncStatusEvent && len(f.subscriptions[eventType]) == 0 {
+		f.lastSyncStatus = nil
+	}
+	f.connSubs[sub.conn]--
+	if f.connSubs[sub.conn] <= 0 {
+		delete(f.connSubs, sub.conn)
+	}
+}
+
+func (f *HeadFeed) BatchSealed(batch *common.ExtBatch) {
+	f.publish(host.NewHeadsEvent, batch.Header)
+}
+
+func (f *HeadFeed) RollupPublished(rollup *common.ExtRollup, block *types.Block) {
+	f.publish(host.NewRollupsEvent, &common.PublishedRollup{
+		Hash:           rollup.Hash(),
+		LastBatchSeqNo: hexutil.Uint64(rollup.Header.LastBatchSeqNo),
+		L1BlockHash:    block.Hash(),
+		L1BlockNumber:  hexutil.Uint64(block.NumberU64()),
+	})
+}
+
+// publish sends the event to the subscribers without blocking. When the buffer of a subscriber is full, its oldest event
+// is dropped to make room.
+func (f *HeadFeed) publish(eventType host.HeadEventType, event any) {
+	f.lock.Lock()
+	defer f.lock.Unlock()
+	for sub := range f.subscriptions[eventType] {
+		select {
+		case sub.ch <- event:
+			continue
+		default:
+		}
+		select {
+		case <-sub.ch:
+		default:
+		}
+		select {
+		case sub.ch <- event:
+		default:
+		}
+		sub.dropped++
+		f.logger.Debug("Dropped an event for a slow subscriber", "type", eventType, "conn", sub.conn, "dropped", sub.dropped)
+	}
+}
+
+// pollSyncStatus publishes the sync status of the node when it changes, while some clients are subscribed to it
+func (f *HeadFeed) pollSyncStatus() {
+	ticker := time.NewTicker(_syncStatusPollInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-f.stopControl.Done():
+			return
+		case <-ticker.C:
+			f.lock.Lock()
+			subscribed := len(f.subscriptions[host.SyncStatusEvent]) > 0
+			f.lock.Unlock()
+			if !subscribed {
+				continue
+			}
+			status := f.sl.Enclaves().SyncStatus()
+			if status == nil {
+				continue
+			}
+			f.lock.Lock()
+			changed := syncStatusChanged(f.lastSyncStatus, status)
+			if changed {
+				f.lastSyncStatus = status
+			}
+			f.lock.Unlock()
+			if changed {
+				f.publish(host.SyncStatusEvent, status)
+			}
+		}
+	}
+}
+
+// returns whether the status or the heads of the node changed, ignoring the progress of the rollups being executed
+func syncStatusChanged(previous *common.SyncStatus, current *common.SyncStatus) bool {
+	if previous == nil {
+		return true
+	}
+	return previous.Status != current.Status ||
+		previous.Syncing != current.Syncing ||
+		previous.Stalled != current.Stalled ||
+		previous.L1Head != current.L1Head ||
+		previous.EnclaveL1Head != current.EnclaveL1Head ||
+		!sameSeqNo(previous.L2Head, current.L2Head) ||
+		!sameSeqNo(previous.EnclaveL2Head, current.EnclaveL2Head)
+}
+
+func sameSeqNo(a *hexutil.Big, b *hexutil.Big) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.ToInt().Cmp(b.ToInt()) == 0
+}
@@ -0,0 +1,91 @@
+package events
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/host"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+func testBatch(seqNo int64) *common.ExtBatch {
+	return &common.ExtBatch{Header: &common.BatchHeader{Number: big.NewInt(seqNo), SequencerOrderNo: big.NewInt(seqNo)}}
+}
+
+func TestHeadFeedDropsTheOldestEventsOfSlowSubscribers(t *testing.T) {
+	feed := NewHeadFeed(nil, gethlog.New())
+	events, unsubscribe, err := feed.Subscribe(host.NewHeadsEvent, "conn")
+	require.NoError(t, err)
+
+	for seqNo := int64(1); seqNo <= _headSubscriptionBuffer+10; seqNo++ {
+		feed.BatchSealed(testBatch(seqNo))
+	}
+	require.Len(t, events, _headSubscriptionBuffer)
+	first := (<-events).(*common.BatchHeader)
+	require.EqualValues(t, 11, first.SequencerOrderNo.Int64())
+
+	// the channel is closed once unsubscribed, and the events are no longer sent to it
+	unsubscribe()
+	unsubscribe()
+	feed.BatchSealed(testBatch(100))
+	remaining := 0
+	for range events {
+		remaining++
+	}
+	require.Equal(t, _headSubscriptionBuffer-1, remaining)
+}
+
+func TestHeadFeedCapsTheSubscriptionsPerConnection(t *testing.T) {
+	feed := NewHeadFeed(nil, gethlog.New())
+	var unsubscribes []func()
+	for i := 0; i < _maxHeadSubscriptionsPerConn; i++ {
+		_, unsubscribe, err := feed.Subscribe(host.NewRollupsEvent, "conn")
+		require.NoError(t, err)
+		unsubscribes = append(unsubscribes, unsubscribe)
+	}
+	_, _, err := feed.Subscribe(host.NewHeadsEvent, "conn")
+	require.Error(t, err)
+	_, _, err = feed.Subscribe(host.NewHeadsEvent, "other conn")
+	require.NoError(t, err)
+
+	unsubscribes[0]()
+	_, _, err = feed.Subscribe(host.NewHeadsEvent, "conn")
+	require.NoError(t, err)
+
+	_, _, err = feed.Subscribe("newPendingTransactions", "other conn")
+	require.Error(t, err)
+}
+
+func TestHeadFeedClosesTheSubscriptionsOnStop(t *testing.T) {
+	feed := NewHeadFeed(nil, gethlog.New())
+	events, unsubscribe, err := feed.Subscribe(host.SyncStatusEvent, "conn")
+	require.NoError(t, err)
+	require.NoError(t, feed.Stop())
+	_, ok := <-events
+	require.False(t, ok)
+	unsubscribe()
+
+	_, _, err = feed.Subscribe(host.SyncStatusEvent, "conn")
+	require.Error(t, err)
+}
+
+func TestSyncStatusChanged(t *testing.T) {
+	status := &common.SyncStatus{Status: "syncing", Syncing: true, L2Head: (*hexutil.Big)(big.NewInt(5))}
+	require.True(t, syncStatusChanged(nil, status))
+
+	progressed := *status
+	progressed.LastProgress = 10
+	require.False(t, syncStatusChanged(status, &progressed))
+
+	newHead := *status
+	newHead.L2Head = (*hexutil.Big)(big.NewInt(6))
+	require.True(t, syncStatusChanged(status, &newHead))
+
+	live := *status
+	live.Status, live.Syncing = "live", false
+	require.True(t, syncStatusChanged(status, &live))
+}
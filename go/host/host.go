@@ -2,10 +2,15 @@ package host
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"sync"
+	"time"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 
+	"github.com/ten-protocol/go-ten/go/host/archive"
+	"github.com/ten-protocol/go-ten/go/host/eventlog"
 	"github.com/ten-protocol/go-ten/go/host/l2"
 
 	"github.com/ten-protocol/go-ten/go/host/enclave"
@@ -14,6 +19,7 @@ import (
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/naoina/toml"
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/common/profiler"
 	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
@@ -39,6 +45,9 @@ type host struct {
 	// ignore incoming requests
 	stopControl *stopcontrol.StopControl
 
+	clock   clock.Clock              // the source of time of the host schedulers
+	startup *hostcommon.StartupTimer // the timing of the startup phases, reported with the health
+
 	db *db.DB // Stores the host's publicly-available data
 
 	logger gethlog.Logger
@@ -47,9 +56,31 @@ type host struct {
 
 	// l2MessageBusAddress is fetched from the enclave but cache it here because it never changes
 	l2MessageBusAddress *gethcommon.Address
+
+	// the timestamps of the admin requests of each kind must increase to prevent replays
+	adminRequestLock       sync.Mutex
+	lastReadOnlyTimestamp  uint64
+	lastPromotionTimestamp uint64
+
+	// the rollups are audited one at a time, as the enclave re-executes their batches
+	auditLock sync.Mutex
+
+	// the mempool stats are served publicly, so they are cached rather than computed by the enclave for each request
+	mempoolStatsLock    sync.Mutex
+	mempoolStats        *common.MempoolStats
+	mempoolStatsFetched time.Time
 }
 
-func NewHost(config *config.HostConfig, hostServices *ServicesRegistry, p2p hostcommon.P2PHostService, ethClient ethadapter.EthClient, l1Repo hostcommon.L1RepoService, enclaveClient common.Enclave, ethWallet wallet.Wallet, mgmtContractLib mgmtcontractlib.MgmtContractLib, logger gethlog.Logger, regMetrics gethmetrics.Registry) hostcommon.Host {
+const (
+	// the admin requests must be sent within this window of their signature
+	adminRequestValidity = time.Minute
+	// the sequencer host polls the mempool report of its enclave at this interval, to expose it as metrics
+	mempoolReportInterval = 30 * time.Second
+	// the mempool stats served publicly are fetched from the enclave at most this often
+	mempoolStatsTTL = 5 * time.Second
+)
+
+func NewHost(config *config.HostConfig, hostServices *ServicesRegistry, p2p hostcommon.P2PHostService, ethClient ethadapter.EthClient, l1Repo hostcommon.L1RepoService, enclaveClients []common.Enclave, ethWallet wallet.Wallet, mgmtContractLib mgmtcontractlib.MgmtContractLib, clk clock.Clock, logger gethlog.Logger, regMetrics gethmetrics.Registry) hostcommon.Host {
 	database, err := db.CreateDBFromConfig(config, regMetrics, logger)
 	if err != nil {
 		logger.Crit("unable to create database for host", log.ErrKey, err)
@@ -70,22 +101,61 @@ func NewHost(config *config.HostConfig, hostServices *ServicesRegistry, p2p host
 		metricRegistry: regMetrics,
 
 		stopControl: stopcontrol.New(),
+		clock:       clk,
+		startup:     hostcommon.NewStartupTimer(clk),
 	}
 
-	enclGuardian := enclave.NewGuardian(config, hostIdentity, hostServices, enclaveClient, database, host.stopControl, logger)
-	enclService := enclave.NewService(hostIdentity, hostServices, enclGuardian, logger)
-	l2Repo := l2.NewBatchRepository(config, hostServices, database, logger)
+	// the first enclave is the primary one, the others are its hot standbys
+	enclGuardians := make([]*enclave.Guardian, len(enclaveClients))
+	for i, enclaveClient := range enclaveClients {
+		identity, enclaveLogger, enclaveMetrics := hostIdentity, logger, regMetrics
+		if i > 0 {
+			// only the primary enclave of the genesis host generates the network secret, the standby ones request it
+			identity.IsGenesis = false
+			enclaveLogger = logger.New("standbyEnclave", i)
+			if regMetrics != nil {
+				enclaveMetrics = gethmetrics.NewPrefixedChildRegistry(regMetrics, fmt.Sprintf("standby%d/", i))
+			}
+		}
+		enclGuardians[i] = enclave.NewGuardian(config, identity, hostServices, enclaveClient, database, host.stopControl, host.startup, clk, enclaveLogger, enclaveMetrics)
+	}
+	enclService := enclave.NewService(hostIdentity, hostServices, enclGuardians, config.EnclaveFailoverThreshold, logger)
+	var archiver *archive.Archiver
+	if config.ArchiveAge > 0 {
+		store, err := archive.NewS3Store(archive.S3Config{
+			Endpoint:  config.ArchiveEndpoint,
+			Bucket:    config.ArchiveBucket,
+			Region:    config.ArchiveRegion,
+			AccessKey: config.ArchiveAccessKey,
+			SecretKey: config.ArchiveSecretKey,
+		})
+		if err != nil {
+			logger.Crit("unable to create the archive object storage", log.ErrKey, err)
+		}
+		archiver = archive.NewArchiver(config, database, store, clk, logger)
+	}
+	l2Repo := l2.NewBatchRepository(config, hostServices, database, archiver, logger)
 	subsService := events.NewLogEventManager(hostServices, logger)
+	eventLog, err := eventlog.NewEventLog(config, hostServices, clk, logger)
+	if err != nil {
+		logger.Crit("unable to open the event log", log.ErrKey, err)
+	}
 
 	hostServices.RegisterService(hostcommon.P2PName, p2p)
 	hostServices.RegisterService(hostcommon.L1BlockRepositoryName, l1Repo)
-	maxWaitForL1Receipt := 6 * config.L1BlockTime   // wait ~10 blocks to see if tx gets published before retrying
-	retryIntervalForL1Receipt := config.L1BlockTime // retry ~every block
-	l1Publisher := l1.NewL1Publisher(hostIdentity, ethWallet, ethClient, mgmtContractLib, l1Repo, host.stopControl, logger, maxWaitForL1Receipt, retryIntervalForL1Receipt)
+	maxWaitForL1Receipt := time.Duration(config.L1RebidBlocks) * config.L1BlockTime // wait to see if tx gets published before rebidding
+	retryIntervalForL1Receipt := config.L1BlockTime                                 // retry ~every block
+	gasOracle := l1.NewGasOracle(config.L1GasOracleBlocks, config.L1MaxFeePerGas, config.L1MaxPriorityFeePerGas, logger)
+	l1Publisher := l1.NewL1Publisher(hostIdentity, ethWallet, ethClient, mgmtContractLib, l1Repo, host.stopControl, logger, maxWaitForL1Receipt, retryIntervalForL1Receipt, config.L1TxMaxRetries, gasOracle, regMetrics)
 	hostServices.RegisterService(hostcommon.L1PublisherName, l1Publisher)
 	hostServices.RegisterService(hostcommon.L2BatchRepositoryName, l2Repo)
 	hostServices.RegisterService(hostcommon.EnclaveServiceName, enclService)
 	hostServices.RegisterService(hostcommon.LogSubscriptionServiceName, subsService)
+	hostServices.RegisterService(hostcommon.EventLogName, eventLog)
+	hostServices.RegisterService(hostcommon.HeadFeedName, events.NewHeadFeed(hostServices, logger))
+	if archiver != nil {
+		hostServices.RegisterService(hostcommon.ArchiverName, archiver)
+	}
 
 	var prof *profiler.Profiler
 	if config.ProfilerEnabled {
@@ -110,12 +180,26 @@ func (h *host) Start() error {
 
 	h.validateConfig()
 
+	// the guardian ends these phases once the enclave is available and the first L1 block is received
+	h.startup.BeginPhase(hostcommon.EnclaveWaitPhase)
+	h.startup.BeginPhase(hostcommon.L1FeedPhase)
+
 	// start all registered services
 	for name, service := range h.services.All() {
+		if name == hostcommon.P2PName {
+			h.startup.BeginPhase(hostcommon.P2PReadyPhase)
+		}
 		err := service.Start()
 		if err != nil {
 			return fmt.Errorf("could not start service=%s: %w", name, err)
 		}
+		if name == hostcommon.P2PName {
+			h.startup.EndPhase(hostcommon.P2PReadyPhase)
+		}
+	}
+
+	if h.config.NodeType == common.Sequencer {
+		go h.monitorMempool()
 	}
 
 	tomlConfig, err := toml.Marshal(h.config)
@@ -160,6 +244,13 @@ func (h *host) Unsubscribe(id rpc.ID) {
 	h.services.LogSubs().Unsubscribe(id)
 }
 
+func (h *host) SubscribeHeadEvents(eventType hostcommon.HeadEventType, conn string) (<-chan any, func(), error) {
+	if h.stopControl.IsStopping() {
+		return nil, nil, responses.ToInternalError(fmt.Errorf("requested SubscribeHeadEvents with the host stopping"))
+	}
+	return h.services.HeadFeed().Subscribe(eventType, conn)
+}
+
 func (h *host) Stop() error {
 	// block all incoming requests
 	h.stopControl.Stop()
@@ -197,12 +288,184 @@ func (h *host) HealthCheck() (*hostcommon.HealthCheck, error) {
 		}
 	}
 
+	readOnly, readOnlyReason := h.services.Enclaves().ReadOnlyMode()
 	return &hostcommon.HealthCheck{
-		OverallHealth: len(healthErrors) == 0,
-		Errors:        healthErrors,
+		OverallHealth:  len(healthErrors) == 0,
+		Errors:         healthErrors,
+		ReadOnlyMode:   readOnly,
+		ReadOnlyReason: readOnlyReason,
+		Startup:        h.startup.Report(),
 	}, nil
 }
 
+// SetReadOnlyMode switches the sequencer in or out of the read-only mode, if the request was signed by the sequencer
+// admin recently and was not used before
+func (h *host) SetReadOnlyMode(request *common.ReadOnlyModeRequest) error {
+	if h.stopControl.IsStopping() {
+		return responses.ToInternalError(fmt.Errorf("requested SetReadOnlyMode with the host stopping"))
+	}
+	signer, err := request.Signer()
+
+	h.adminRequestLock.Lock()
+	defer h.adminRequestLock.Unlock()
+	if err := h.checkAdminRequest("read-only mode", signer, err, uint64(request.Timestamp), &h.lastReadOnlyTimestamp); err != nil {
+		return err
+	}
+	return h.services.Enclaves().SetReadOnlyMode(request.ReadOnly, request.Reason)
+}
+
+// PromoteEnclave makes a standby enclave of the sequencer the active one, if the request was signed by the sequencer
+// admin recently and was not used before
+func (h *host) PromoteEnclave(request *common.PromoteEnclaveRequest) error {
+	if h.stopControl.IsStopping() {
+		return responses.ToInternalError(fmt.Errorf("requested PromoteEnclave with the host stopping"))
+	}
+	signer, err := request.Signer()
+
+	h.adminRequestLock.Lock()
+	defer h.adminRequestLock.Unlock()
+	if err := h.checkAdminRequest("enclave promotion", signer, err, uint64(request.Timestamp), &h.lastPromotionTimestamp); err != nil {
+		return err
+	}
+	return h.services.Enclaves().PromoteEnclave(int(request.Enclave))
+}
+
+// checkAdminRequest returns an error unless the request was signed by the sequencer admin within the validity window of
+// its timestamp, and after the last request of its kind. It is called with the admin request lock held.
+func (h *host) checkAdminRequest(kind string, signer gethcommon.Address, signerErr error, timestamp uint64, lastTimestamp *uint64) error {
+	if h.config.SequencerAdminAddress == (gethcommon.Address{}) {
+		return errors.New("no sequencer admin is configured")
+	}
+	if signerErr != nil {
+		return fmt.Errorf("invalid %s request signature - %w", kind, signerErr)
+	}
+	if signer != h.config.SequencerAdminAddress {
+		return fmt.Errorf("the %s request was signed by %s, not by the sequencer admin", kind, signer)
+	}
+
+	age := h.clock.Since(time.Unix(int64(timestamp), 0))
+	if age > adminRequestValidity || age < -adminRequestValidity {
+		return fmt.Errorf("the %s request expired, it must be sent within %s of its timestamp", kind, adminRequestValidity)
+	}
+	if timestamp <= *lastTimestamp {
+		return fmt.Errorf("the %s request was already used", kind)
+	}
+	*lastTimestamp = timestamp
+	return nil
+}
+
+// MempoolReport returns the pending transactions that waited in the mempool of the sequencer for longer than the
+// inclusion deadline
+func (h *host) MempoolReport() (*common.MempoolReport, error) {
+	if h.stopControl.IsStopping() {
+		return nil, responses.ToInternalError(fmt.Errorf("requested MempoolReport with the host stopping"))
+	}
+	report, err := h.EnclaveClient().MempoolReport()
+	if err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// MempoolStats returns the aggregate statistics of the mempool of the sequencer, if the host serves them publicly
+func (h *host) MempoolStats() (*common.MempoolStats, error) {
+	if !h.config.PublicMempoolStats {
+		return nil, fmt.Errorf("the node does not serve the mempool stats")
+	}
+	if h.config.NodeType != common.Sequencer {
+		return nil, fmt.Errorf("the mempool stats are only served by the sequencer")
+	}
+	if h.stopControl.IsStopping() {
+		return nil, responses.ToInternalError(fmt.Errorf("requested MempoolStats with the host stopping"))
+	}
+
+	h.mempoolStatsLock.Lock()
+	defer h.mempoolStatsLock.Unlock()
+	if h.mempoolStats != nil && h.clock.Now().Sub(h.mempoolStatsFetched) < mempoolStatsTTL {
+		return h.mempoolStats, nil
+	}
+	stats, err := h.EnclaveClient().MempoolStats()
+	if err != nil {
+		return nil, err
+	}
+	h.mempoolStats = stats
+	h.mempoolStatsFetched = h.clock.Now()
+	return stats, nil
+}
+
+// RollupWitness returns the encrypted witness of the batches of a rollup, produced by the enclave
+func (h *host) RollupWitness(rollupHash common.L2RollupHash) ([]byte, error) {
+	if h.stopControl.IsStopping() {
+		return nil, responses.ToInternalError(fmt.Errorf("requested RollupWitness with the host stopping"))
+	}
+	if !h.auditLock.TryLock() {
+		return nil, fmt.Errorf("a rollup is already being audited")
+	}
+	defer h.auditLock.Unlock()
+	return h.EnclaveClient().RollupWitness(rollupHash)
+}
+
+// VerifyRollupWitness returns the verdict of the enclave on a rollup, from the witness of its batches
+func (h *host) VerifyRollupWitness(witness []byte) (*common.RollupVerdict, error) {
+	if h.stopControl.IsStopping() {
+		return nil, responses.ToInternalError(fmt.Errorf("requested VerifyRollupWitness with the host stopping"))
+	}
+	if !h.auditLock.TryLock() {
+		return nil, fmt.Errorf("a rollup is already being audited")
+	}
+	defer h.auditLock.Unlock()
+	return h.EnclaveClient().VerifyRollupWitness(witness)
+}
+
+// RollupCostEstimate estimates the L1 cost of publishing the pending rollup of the sequencer as calldata and as blobs
+func (h *host) RollupCostEstimate() (*common.RollupCostEstimate, error) {
+	if h.stopControl.IsStopping() {
+		return nil, responses.ToInternalError(fmt.Errorf("requested RollupCostEstimate with the host stopping"))
+	}
+	return h.services.Enclaves().RollupCostEstimate()
+}
+
+// FailedL1Transactions returns the L1 transactions the publisher of the host gave up on after their retries
+func (h *host) FailedL1Transactions() ([]common.FailedL1Transaction, error) {
+	if h.stopControl.IsStopping() {
+		return nil, responses.ToInternalError(fmt.Errorf("requested FailedL1Transactions with the host stopping"))
+	}
+	return h.services.L1Publisher().FailedTransactions(), nil
+}
+
+// SyncStatus returns the sync status of the node, with the latest progress of its enclave through the rollups
+func (h *host) SyncStatus() *common.SyncStatus {
+	return h.services.Enclaves().SyncStatus()
+}
+
+// polls the mempool report of the enclave, and exposes the number of overdue transactions and the longest time a
+// pending transaction has waited as metrics, so that the censorship or scheduling bugs can be alerted on
+func (h *host) monitorMempool() {
+	overdueGauge := gethmetrics.NewRegisteredGauge("host/mempool/overdue", h.metricRegistry)
+	oldestGauge := gethmetrics.NewRegisteredGauge("host/mempool/oldest", h.metricRegistry)
+
+	ticker := h.clock.NewTicker(mempoolReportInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-h.stopControl.Done():
+			return
+		case <-ticker.C():
+			report, err := h.EnclaveClient().MempoolReport()
+			if err != nil {
+				h.logger.Debug("Could not fetch the mempool report", log.ErrKey, err)
+				continue
+			}
+			overdueGauge.Update(int64(len(report.OverdueTransactions)))
+			oldestGauge.Update(int64(report.OldestTimeInMempool))
+			if len(report.OverdueTransactions) > 0 {
+				h.logger.Warn("Transactions exceeded the inclusion deadline", "overdue", len(report.OverdueTransactions),
+					"oldestTimeInMempool", uint64(report.OldestTimeInMempool), "deadline", uint64(report.InclusionDeadline))
+			}
+		}
+	}
+}
+
 // ObscuroConfig returns info on the Obscuro network
 func (h *host) ObscuroConfig() (*common.ObscuroNetworkInfo, error) {
 	if h.l2MessageBusAddress == nil {
@@ -239,4 +502,8 @@ func (h *host) validateConfig() {
 	if h.config.L1BlockTime == 0 {
 		h.logger.Crit("the host must specify an L1 block time")
 	}
+
+	if h.config.ArchiveAge > 0 && h.config.ArchiveInterval <= 0 {
+		h.logger.Crit("the host must specify an archive interval when the archive age is set")
+	}
 }
@@ -18,7 +18,6 @@ import (
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/common/retry"
-	"github.com/ten-protocol/go-ten/go/ethadapter"
 )
 
 var (
@@ -32,18 +31,20 @@ var (
 type Repository struct {
 	blockSubscribers *subscription.Manager[host.L1BlockHandler]
 	// this eth client should only be used by the repository, the repository may "reconnect" it at any time and don't want to interfere with other processes
-	ethClient ethadapter.EthClient
+	ethClient Client
 	logger    gethlog.Logger
 
 	running                  atomic.Bool
 	head                     gethcommon.Hash
+	chain                    *ChainTracker // the canonical chain of the live blocks, to replay the reorgs and the gaps
 	obscuroRelevantContracts []gethcommon.Address
 }
 
-func NewL1Repository(ethClient ethadapter.EthClient, obscuroRelevantContracts []gethcommon.Address, logger gethlog.Logger) *Repository {
+func NewL1Repository(ethClient Client, obscuroRelevantContracts []gethcommon.Address, logger gethlog.Logger) *Repository {
 	return &Repository{
 		blockSubscribers:         subscription.NewManager[host.L1BlockHandler](),
 		ethClient:                ethClient,
+		chain:                    NewChainTracker(ethClient, _chainTrackerDepth),
 		obscuroRelevantContracts: obscuroRelevantContracts,
 		running:                  atomic.Bool{},
 		logger:                   logger,
@@ -166,8 +167,9 @@ func (r *Repository) FetchObscuroReceipts(block *common.L1Block) (types.Receipts
 	return receipts, nil
 }
 
-// stream blocks from L1 as they arrive and forward them to subscribers, no guarantee of perfect ordering or that there won't be gaps.
-// If streaming is interrupted it will carry on from latest, it won't try to replay missed blocks.
+// stream blocks from L1 as they arrive and forward them to subscribers. The chain tracker turns the heads into the
+// blocks that became canonical, in order, so the blocks missed while the stream was interrupted and the new branch of a
+// reorg are replayed to the subscribers.
 func (r *Repository) streamLiveBlocks() {
 	liveStream, streamSub := r.resetLiveStream()
 	for r.running.Load() {
@@ -180,9 +182,7 @@ func (r *Repository) streamLiveBlocks() {
 					log.BlockHeightKey, header.Number, log.ErrKey, err)
 				continue
 			}
-			for _, handler := range r.blockSubscribers.Subscribers() {
-				go handler.HandleBlock(block)
-			}
+			r.dispatch(r.updateChain(block))
 		case <-time.After(_timeoutNoBlocks):
 			r.logger.Warn("no new blocks received since timeout", "timeout", _timeoutNoBlocks)
 			// reset stream to ensure it has not died
@@ -195,6 +195,37 @@ func (r *Repository) streamLiveBlocks() {
 	}
 }
 
+// updateChain returns the update of the canonical chain caused by the new head. If the chain can't be tracked, the head
+// is forwarded on its own, and the enclave walks back to the fork itself.
+func (r *Repository) updateChain(block *types.Block) *ChainUpdate {
+	update, err := r.chain.Update(block)
+	if err != nil {
+		r.logger.Warn("Could not track the L1 chain", log.BlockHashKey, block.Hash(), log.ErrKey, err)
+		return &ChainUpdate{Applied: []*types.Block{block}}
+	}
+	if update != nil && update.Reorg != nil {
+		r.logger.Warn("L1 reorg", "depth", update.Reorg.Depth(), "fork_point", update.Reorg.ForkPoint.Hash(),
+			"fork_height", update.Reorg.ForkPoint.Number, "old_head", update.Reorg.OldHead.Hash(), "new_head", block.Hash())
+	}
+	return update
+}
+
+// dispatch notifies each subscriber of the reorg, if any, then of the new canonical blocks in order. The subscribers
+// are called from the stream, so the blocks of consecutive heads are not reordered.
+func (r *Repository) dispatch(update *ChainUpdate) {
+	if update == nil {
+		return
+	}
+	for _, handler := range r.blockSubscribers.Subscribers() {
+		if reorgHandler, ok := handler.(host.L1ReorgHandler); ok && update.Reorg != nil {
+			reorgHandler.HandleL1Reorg(update.Reorg)
+		}
+		for _, block := range update.Applied {
+			handler.HandleBlock(block)
+		}
+	}
+}
+
 func (r *Repository) resetLiveStream() (chan *types.Header, ethereum.Subscription) {
 	err := retry.Do(func() error {
 		if !r.running.Load() {
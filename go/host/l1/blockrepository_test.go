@@ -0,0 +1,65 @@
+package l1
+
+import (
+	"math/big"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/host/testsupport"
+)
+
+var _ Client = (*testsupport.FakeL1Client)(nil)
+
+func newTestBlock(number int64, parent gethcommon.Hash, extra string) *types.Block {
+	return types.NewBlockWithHeader(&types.Header{
+		Number:     big.NewInt(number),
+		ParentHash: parent,
+		Extra:      []byte(extra),
+	})
+}
+
+func TestFetchNextBlock(t *testing.T) {
+	genesis := newTestBlock(0, gethcommon.Hash{}, "")
+	b1 := newTestBlock(1, genesis.Hash(), "")
+	b2 := newTestBlock(2, b1.Hash(), "")
+	b3 := newTestBlock(3, b2.Hash(), "")
+	forkB2 := newTestBlock(2, b1.Hash(), "fork")
+
+	fakeL1 := testsupport.NewFakeL1Client(false)
+	for _, b := range []*types.Block{genesis, b1, b2, b3} {
+		fakeL1.AddBlock(b, true)
+	}
+	fakeL1.AddBlock(forkB2, false)
+
+	repo := NewL1Repository(fakeL1, nil, gethlog.New())
+	repo.head = b3.Hash()
+
+	tests := []struct {
+		name         string
+		prev         gethcommon.Hash
+		expected     *types.Block
+		expectedHead bool
+		expectedErr  error
+	}{
+		{name: "starts from genesis", prev: gethcommon.Hash{}, expected: genesis},
+		{name: "next on the same branch", prev: b1.Hash(), expected: b2},
+		{name: "next is the head", prev: b2.Hash(), expected: b3, expectedHead: true},
+		{name: "walks back from a fork", prev: forkB2.Hash(), expected: b2},
+		{name: "nothing after the head", prev: b3.Hash(), expectedErr: ErrNoNextBlock},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			blk, isHead, err := repo.FetchNextBlock(tc.prev)
+			if tc.expectedErr != nil {
+				require.ErrorIs(t, err, tc.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tc.expected.Hash(), blk.Hash())
+			require.Equal(t, tc.expectedHead, isHead)
+		})
+	}
+}
@@ -0,0 +1,157 @@
+package l1
+
+import (
+	"fmt"
+	"sync"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/common/host"
+)
+
+// the number of canonical L1 blocks below the head kept by the chain tracker. The reorgs deeper than that are still
+// detected, the blocks of the previous branch are then fetched from the L1 node
+const _chainTrackerDepth = 1024
+
+// ChainTracker maintains the view of the host of the canonical L1 chain, from the L1 heads as they arrive. A head that
+// does not extend the previous one is a reorg: the tracker walks back the new branch and the previous one to their fork
+// point, and returns the blocks of the new branch in order, so that they are replayed to the enclave without a gap.
+type ChainTracker struct {
+	ethClient Client
+	depth     uint64
+
+	lock      sync.Mutex
+	head      *types.Header
+	canonical map[uint64]gethcommon.Hash // the canonical blocks by height, from head-depth to the head
+	headers   map[gethcommon.Hash]*types.Header
+}
+
+func NewChainTracker(ethClient Client, depth uint64) *ChainTracker {
+	return &ChainTracker{
+		ethClient: ethClient,
+		depth:     depth,
+		canonical: map[uint64]gethcommon.Hash{},
+		headers:   map[gethcommon.Hash]*types.Header{},
+	}
+}
+
+// ChainUpdate is the change of the canonical L1 chain caused by a new head
+type ChainUpdate struct {
+	// Reorg is nil if the new head extends the previous one, possibly through blocks that were not received
+	Reorg *host.L1Reorg
+	// Applied are the new canonical blocks, from the one after the previous head or the fork point to the new head
+	Applied []*types.Block
+}
+
+// Head returns the head of the canonical chain, nil before the first head was received
+func (t *ChainTracker) Head() *types.Header {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	return t.head
+}
+
+// Update makes the block the head of the canonical chain, and returns the blocks that became canonical with it. The
+// chain before the first head received is not replayed. It returns nil if the block is already canonical, e.g. a late
+// head.
+func (t *ChainTracker) Update(block *types.Block) (*ChainUpdate, error) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.head == nil {
+		t.add(block.Header())
+		return &ChainUpdate{Applied: []*types.Block{block}}, nil
+	}
+	if hash, found := t.canonical[block.NumberU64()]; found && hash == block.Hash() {
+		return nil, nil //nolint:nilnil
+	}
+	if block.NumberU64()+t.depth < t.head.Number.Uint64() {
+		// a head below the blocks kept can only be a late head
+		return nil, nil //nolint:nilnil
+	}
+
+	// the new branch is walked back to the height of the previous head, then both branches are walked back together
+	// until they meet
+	var applied []*types.Block
+	var reverted []*types.Header
+	newBlock := block
+	oldHeader := t.head
+	var err error
+	for newBlock.NumberU64() > oldHeader.Number.Uint64() {
+		applied = append(applied, newBlock)
+		if newBlock, err = t.parent(newBlock); err != nil {
+			return nil, err
+		}
+	}
+	for oldHeader.Number.Uint64() > newBlock.NumberU64() {
+		reverted = append(reverted, oldHeader)
+		if oldHeader, err = t.header(oldHeader.ParentHash); err != nil {
+			return nil, err
+		}
+	}
+	for newBlock.Hash() != oldHeader.Hash() {
+		if newBlock.NumberU64() == 0 {
+			return nil, fmt.Errorf("the L1 block %s does not share any ancestor with the head %s", block.Hash(), t.head.Hash())
+		}
+		applied = append(applied, newBlock)
+		reverted = append(reverted, oldHeader)
+		if newBlock, err = t.parent(newBlock); err != nil {
+			return nil, err
+		}
+		if oldHeader, err = t.header(oldHeader.ParentHash); err != nil {
+			return nil, err
+		}
+	}
+
+	update := &ChainUpdate{Applied: make([]*types.Block, len(applied))}
+	for i, b := range applied {
+		update.Applied[len(applied)-1-i] = b
+	}
+	if len(reverted) > 0 {
+		update.Reorg = &host.L1Reorg{ForkPoint: oldHeader, Reverted: reverted, OldHead: t.head, NewHead: block.Header()}
+		for _, header := range reverted {
+			delete(t.canonical, header.Number.Uint64())
+		}
+	}
+	for _, b := range update.Applied {
+		t.add(b.Header())
+	}
+	return update, nil
+}
+
+// add makes the header the head of the canonical chain, and forgets the blocks that are too old
+func (t *ChainTracker) add(header *types.Header) {
+	number := header.Number.Uint64()
+	t.head = header
+	t.canonical[number] = header.Hash()
+	t.headers[header.Hash()] = header
+	if number < t.depth {
+		return
+	}
+	for hash, h := range t.headers {
+		if h.Number.Uint64() < number-t.depth {
+			delete(t.headers, hash)
+			if t.canonical[h.Number.Uint64()] == hash {
+				delete(t.canonical, h.Number.Uint64())
+			}
+		}
+	}
+}
+
+func (t *ChainTracker) parent(block *types.Block) (*types.Block, error) {
+	parent, err := t.ethClient.BlockByHash(block.ParentHash())
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch the parent of L1 block %s - %w", block.Hash(), err)
+	}
+	return parent, nil
+}
+
+func (t *ChainTracker) header(hash gethcommon.Hash) (*types.Header, error) {
+	if header, found := t.headers[hash]; found {
+		return header, nil
+	}
+	block, err := t.ethClient.BlockByHash(hash)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch L1 block %s - %w", hash, err)
+	}
+	return block.Header(), nil
+}
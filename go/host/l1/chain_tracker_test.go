@@ -0,0 +1,83 @@
+package l1
+
+import (
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/host/testsupport"
+)
+
+// newTestChain returns the blocks from..to on top of parent, all known by the fake L1 client
+func newTestChain(fakeL1 *testsupport.FakeL1Client, parent *types.Block, to int64, extra string, canonical bool) []*types.Block {
+	var chain []*types.Block
+	for number := parent.Number().Int64() + 1; number <= to; number++ {
+		block := newTestBlock(number, parent.Hash(), extra)
+		fakeL1.AddBlock(block, canonical)
+		chain = append(chain, block)
+		parent = block
+	}
+	return chain
+}
+
+func TestChainTrackerExtendsTheHead(t *testing.T) {
+	fakeL1 := testsupport.NewFakeL1Client(false)
+	genesis := newTestBlock(0, gethcommon.Hash{}, "")
+	fakeL1.AddBlock(genesis, true)
+	chain := newTestChain(fakeL1, genesis, 5, "", true)
+	tracker := NewChainTracker(fakeL1, _chainTrackerDepth)
+
+	update, err := tracker.Update(chain[0])
+	require.NoError(t, err)
+	require.Equal(t, &ChainUpdate{Applied: []*types.Block{chain[0]}}, update)
+
+	update, err = tracker.Update(chain[1])
+	require.NoError(t, err)
+	require.Equal(t, &ChainUpdate{Applied: []*types.Block{chain[1]}}, update)
+
+	// the blocks missed are replayed
+	update, err = tracker.Update(chain[4])
+	require.NoError(t, err)
+	require.Equal(t, &ChainUpdate{Applied: chain[2:]}, update)
+	require.Equal(t, chain[4].Header(), tracker.Head())
+
+	// a late head is ignored
+	update, err = tracker.Update(chain[3])
+	require.NoError(t, err)
+	require.Nil(t, update)
+}
+
+func TestChainTrackerReplaysTheReorgs(t *testing.T) {
+	fakeL1 := testsupport.NewFakeL1Client(false)
+	genesis := newTestBlock(0, gethcommon.Hash{}, "")
+	fakeL1.AddBlock(genesis, true)
+	chain := newTestChain(fakeL1, genesis, 10, "", false)
+	// the tracker keeps fewer blocks than the reorgs are deep, so it fetches the previous branch from the L1 node
+	tracker := NewChainTracker(fakeL1, 2)
+	for _, block := range chain {
+		_, err := tracker.Update(block)
+		require.NoError(t, err)
+	}
+
+	// a fork from block 4, one block longer than the previous branch
+	fork := newTestChain(fakeL1, chain[3], 11, "fork", true)
+	update, err := tracker.Update(fork[len(fork)-1])
+	require.NoError(t, err)
+	require.Equal(t, fork, update.Applied)
+	require.NotNil(t, update.Reorg)
+	require.Equal(t, chain[3].Header(), update.Reorg.ForkPoint)
+	require.Equal(t, 6, update.Reorg.Depth())
+	require.Equal(t, chain[9].Header(), update.Reorg.Reverted[0])
+	require.Equal(t, chain[4].Header(), update.Reorg.Reverted[5])
+	require.Equal(t, chain[9].Header(), update.Reorg.OldHead)
+	require.Equal(t, fork[len(fork)-1].Header(), update.Reorg.NewHead)
+
+	// a shallow reorg to a sibling of the head
+	sibling := newTestChain(fakeL1, fork[len(fork)-2], 11, "sibling", true)
+	update, err = tracker.Update(sibling[0])
+	require.NoError(t, err)
+	require.Equal(t, sibling, update.Applied)
+	require.Equal(t, fork[len(fork)-2].Header(), update.Reorg.ForkPoint)
+	require.Equal(t, []*types.Header{fork[len(fork)-1].Header()}, update.Reorg.Reverted)
+}
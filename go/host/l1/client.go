@@ -0,0 +1,47 @@
+package l1
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/ethadapter"
+)
+
+// the real L1 client must always satisfy the narrow interface
+var _ Client = ethadapter.EthClient(nil)
+
+// Client is the narrow view of the L1 that the host's L1 services depend on.
+// It is satisfied by ethadapter.EthClient, and by the scriptable fake in the host testsupport package so the
+// publication and block streaming logic can be unit-tested without running a simulation.
+type Client interface {
+	// BlockByHash retrieves a block given a hash
+	BlockByHash(id gethcommon.Hash) (*types.Block, error)
+	// BlockByNumber retrieves a block given a number - returns head block if n is nil
+	BlockByNumber(n *big.Int) (*types.Block, error)
+	// FetchHeadBlock retrieves the block at head height
+	FetchHeadBlock() (*types.Block, error)
+	// BlockListener subscribes to new blocks and returns a listener with the block heads and the subscription handler
+	BlockListener() (chan *types.Header, ethereum.Subscription)
+	// GetLogs fetches the logs for a given query
+	GetLogs(q ethereum.FilterQuery) ([]types.Log, error)
+
+	// SendTransaction issues an ethereum transaction (expects signed tx)
+	SendTransaction(signedTx *types.Transaction) error
+	// TransactionReceipt fetches the ethereum transaction receipt
+	TransactionReceipt(hash gethcommon.Hash) (*types.Receipt, error)
+	// Nonce fetches the pending nonce of the account from the L1 node
+	Nonce(address gethcommon.Address) (uint64, error)
+	// PrepareTransactionToRetry updates the tx with from address, nonce and gas estimates that increase with the retries
+	PrepareTransactionToRetry(txData types.TxData, from gethcommon.Address, nonce uint64, retries int) (types.TxData, error)
+	// CallContract runs the provided call message on the latest block
+	CallContract(msg ethereum.CallMsg) ([]byte, error)
+	// SuggestGasPrice returns the gas price suggested by the L1 node for a transaction to be included
+	SuggestGasPrice() (*big.Int, error)
+	// FetchLastBatchSeqNo returns the sequence number of the last batch rolled up to the management contract
+	FetchLastBatchSeqNo(address gethcommon.Address) (*big.Int, error)
+
+	// ReconnectIfClosed closes and creates a new connection
+	ReconnectIfClosed() error
+}
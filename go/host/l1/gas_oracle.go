@@ -0,0 +1,139 @@
+package l1
+
+import (
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"golang.org/x/exp/slices"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	// the priority fee offered while no transaction was seen in the recent L1 blocks
+	defaultPriorityFee = params.GWei
+	// each retry bids 20% (6/5) more than the previous attempt, the L1 mempools reject replacements bidding less than 10% more
+	rebidNumerator   = 6
+	rebidDenominator = 5
+	// the bids stop increasing after this many retries, the fee caps of the config bound them anyway
+	maxRebids = 5
+)
+
+// GasOracle tracks the base fee and the priority fees paid in the recent L1 blocks, to price the EIP-1559 transactions
+// of the host. It handles the canonical blocks of the L1 block repository.
+type GasOracle struct {
+	window         int      // the number of recent blocks the priority fee is taken from
+	maxFeePerGas   *big.Int // the cap of the fee bid (nil for no cap)
+	maxPriorityFee *big.Int // the cap of the priority fee bid (nil for no cap)
+	baseFee        *big.Int // the base fee of the latest block
+	priorityFees   []*big.Int
+	lock           sync.RWMutex
+
+	logger gethlog.Logger
+}
+
+// NewGasOracle returns an oracle pricing the transactions from the priority fees of the last window blocks. The zero
+// fee caps do not cap the bids.
+func NewGasOracle(window int, maxFeePerGas uint64, maxPriorityFee uint64, logger gethlog.Logger) *GasOracle {
+	o := &GasOracle{
+		window: window,
+		logger: logger,
+	}
+	if maxFeePerGas > 0 {
+		o.maxFeePerGas = new(big.Int).SetUint64(maxFeePerGas)
+	}
+	if maxPriorityFee > 0 {
+		o.maxPriorityFee = new(big.Int).SetUint64(maxPriorityFee)
+	}
+	return o
+}
+
+// HandleBlock records the base fee of the block and the median priority fee paid by its transactions
+func (o *GasOracle) HandleBlock(block *types.Block) {
+	baseFee := block.BaseFee()
+	if baseFee == nil {
+		// the L1 does not support EIP-1559, the transactions keep the legacy gas price
+		return
+	}
+
+	tips := make([]*big.Int, 0, len(block.Transactions()))
+	for _, tx := range block.Transactions() {
+		tip, err := tx.EffectiveGasTip(baseFee)
+		if err != nil {
+			continue
+		}
+		tips = append(tips, tip)
+	}
+
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	o.baseFee = baseFee
+	if len(tips) == 0 {
+		return
+	}
+	o.priorityFees = append(o.priorityFees, median(tips))
+	if len(o.priorityFees) > o.window {
+		o.priorityFees = o.priorityFees[len(o.priorityFees)-o.window:]
+	}
+}
+
+// Fees returns the fee cap and the priority fee to bid for an attempt of a transaction, raised for each retry, or false
+// if no block with a base fee was seen yet. The fee cap covers twice the latest base fee, so the transaction stays
+// includable while the base fee rises for a few blocks.
+func (o *GasOracle) Fees(retries int) (*big.Int, *big.Int, bool) {
+	o.lock.RLock()
+	defer o.lock.RUnlock()
+	if o.baseFee == nil {
+		return nil, nil, false
+	}
+
+	tip := big.NewInt(defaultPriorityFee)
+	if len(o.priorityFees) > 0 {
+		tip = new(big.Int).Set(median(o.priorityFees))
+	}
+	feeCap := new(big.Int).Add(new(big.Int).Mul(o.baseFee, big.NewInt(2)), tip)
+
+	for i := 0; i < retries && i < maxRebids; i++ {
+		tip = rebid(tip)
+		feeCap = rebid(feeCap)
+	}
+
+	if o.maxPriorityFee != nil && tip.Cmp(o.maxPriorityFee) > 0 {
+		tip = new(big.Int).Set(o.maxPriorityFee)
+	}
+	if o.maxFeePerGas != nil && feeCap.Cmp(o.maxFeePerGas) > 0 {
+		o.logger.Warn("The L1 fee bid is capped by the config", "feeCap", feeCap, "maxFeePerGas", o.maxFeePerGas)
+		feeCap = new(big.Int).Set(o.maxFeePerGas)
+	}
+	if tip.Cmp(feeCap) > 0 {
+		tip = new(big.Int).Set(feeCap)
+	}
+	return feeCap, tip, true
+}
+
+// toDynamicFeeTx returns the prepared transaction as an EIP-1559 transaction with the fees bid
+func toDynamicFeeTx(txData types.TxData, chainID *big.Int, feeCap *big.Int, tip *big.Int) types.TxData {
+	tx := types.NewTx(txData)
+	return &types.DynamicFeeTx{
+		ChainID:   chainID,
+		Nonce:     tx.Nonce(),
+		GasTipCap: tip,
+		GasFeeCap: feeCap,
+		Gas:       tx.Gas(),
+		To:        tx.To(),
+		Value:     tx.Value(),
+		Data:      tx.Data(),
+	}
+}
+
+func rebid(fee *big.Int) *big.Int {
+	return new(big.Int).Div(new(big.Int).Mul(fee, big.NewInt(rebidNumerator)), big.NewInt(rebidDenominator))
+}
+
+func median(values []*big.Int) *big.Int {
+	sorted := append([]*big.Int{}, values...)
+	slices.SortFunc(sorted, func(a, b *big.Int) int { return a.Cmp(b) })
+	return sorted[len(sorted)/2]
+}
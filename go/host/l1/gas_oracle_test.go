@@ -0,0 +1,66 @@
+package l1
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/stretchr/testify/require"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+// newFeeBlock returns a block with the base fee, and a transaction paying each of the priority fees
+func newFeeBlock(number int64, baseFee int64, tips ...int64) *types.Block {
+	txs := make([]*types.Transaction, 0, len(tips))
+	for _, tip := range tips {
+		txs = append(txs, types.NewTx(&types.DynamicFeeTx{GasTipCap: big.NewInt(tip), GasFeeCap: big.NewInt(baseFee + tip)}))
+	}
+	return types.NewBlockWithHeader(&types.Header{Number: big.NewInt(number), BaseFee: big.NewInt(baseFee)}).WithBody(txs, nil)
+}
+
+func TestGasOracleFees(t *testing.T) {
+	oracle := NewGasOracle(2, 0, 0, gethlog.New())
+
+	// nothing is bid before a block with a base fee is seen
+	_, _, ok := oracle.Fees(0)
+	require.False(t, ok)
+	oracle.HandleBlock(newTestBlock(1, [32]byte{}, ""))
+	_, _, ok = oracle.Fees(0)
+	require.False(t, ok)
+
+	// the default priority fee is bid while no transaction was seen
+	oracle.HandleBlock(newFeeBlock(2, 100))
+	feeCap, tip, ok := oracle.Fees(0)
+	require.True(t, ok)
+	require.Equal(t, big.NewInt(params.GWei), tip)
+	require.Equal(t, big.NewInt(200+params.GWei), feeCap)
+
+	// the median priority fee of the blocks of the window is bid, with twice the latest base fee
+	oracle.HandleBlock(newFeeBlock(3, 100, 1, 50, 3))
+	oracle.HandleBlock(newFeeBlock(4, 110, 20, 30, 40))
+	oracle.HandleBlock(newFeeBlock(5, 120, 10))
+	feeCap, tip, _ = oracle.Fees(0)
+	require.Equal(t, big.NewInt(30), tip)
+	require.Equal(t, big.NewInt(270), feeCap)
+
+	// the retries bid more
+	feeCap, tip, _ = oracle.Fees(1)
+	require.Equal(t, big.NewInt(36), tip)
+	require.Equal(t, big.NewInt(324), feeCap)
+}
+
+func TestGasOracleCaps(t *testing.T) {
+	oracle := NewGasOracle(20, 250, 20, gethlog.New())
+	oracle.HandleBlock(newFeeBlock(1, 100, 30))
+
+	feeCap, tip, _ := oracle.Fees(0)
+	require.Equal(t, big.NewInt(20), tip)
+	require.Equal(t, big.NewInt(230), feeCap)
+
+	// the caps hold when rebidding
+	feeCap, tip, _ = oracle.Fees(5)
+	require.Equal(t, big.NewInt(20), tip)
+	require.Equal(t, big.NewInt(250), feeCap)
+}
@@ -1,6 +1,7 @@
 package l1
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"math/big"
@@ -10,8 +11,10 @@ import (
 	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
 	"github.com/pkg/errors"
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/host"
@@ -25,7 +28,7 @@ import (
 type Publisher struct {
 	hostData        host.Identity
 	hostWallet      wallet.Wallet // Wallet used to issue ethereum transactions
-	ethClient       ethadapter.EthClient
+	ethClient       Client
 	mgmtContractLib mgmtcontractlib.MgmtContractLib // Library to handle Management Contract lib operations
 
 	// cached map of important contract addresses (updated when we see a SetImportantContractsTx)
@@ -36,22 +39,40 @@ type Publisher struct {
 	repository host.L1BlockRepository
 	logger     gethlog.Logger
 
+	// prices the transactions from the fees of the recent L1 blocks
+	gasOracle   *GasOracle
+	unsubscribe func()
+
 	hostStopper *stopcontrol.StopControl
 
 	maxWaitForL1Receipt       time.Duration
 	retryIntervalForL1Receipt time.Duration
+	// the number of times a transaction is re-sent after a failed broadcast or a missing receipt before giving up on it
+	maxTxRetries int
+
+	// the transactions the publisher gave up on, oldest first, for the operator to resolve
+	deadLetters     []common.FailedL1Transaction
+	deadLettersLock sync.Mutex
+
+	metrics *publisherMetrics
 }
 
+// the dead-letter queue only keeps the latest failed transactions
+const maxDeadLetters = 100
+
 func NewL1Publisher(
 	hostData host.Identity,
 	hostWallet wallet.Wallet,
-	client ethadapter.EthClient,
+	client Client,
 	mgmtContract mgmtcontractlib.MgmtContractLib,
 	repository host.L1BlockRepository,
 	hostStopper *stopcontrol.StopControl,
 	logger gethlog.Logger,
 	maxWaitForL1Receipt time.Duration,
 	retryIntervalForL1Receipt time.Duration,
+	maxTxRetries int,
+	gasOracle *GasOracle,
+	regMetrics gethmetrics.Registry,
 ) *Publisher {
 	return &Publisher{
 		hostData:                  hostData,
@@ -63,6 +84,9 @@ func NewL1Publisher(
 		logger:                    logger,
 		maxWaitForL1Receipt:       maxWaitForL1Receipt,
 		retryIntervalForL1Receipt: retryIntervalForL1Receipt,
+		maxTxRetries:              maxTxRetries,
+		gasOracle:                 gasOracle,
+		metrics:                   newPublisherMetrics(regMetrics),
 
 		importantContractAddresses: map[string]gethcommon.Address{},
 		importantAddressesMutex:    sync.RWMutex{},
@@ -70,6 +94,7 @@ func NewL1Publisher(
 }
 
 func (p *Publisher) Start() error {
+	p.unsubscribe = p.repository.Subscribe(p.gasOracle)
 	go func() {
 		// Do an initial read of important contract addresses when service starts up
 		err := p.ResyncImportantContracts()
@@ -81,6 +106,9 @@ func (p *Publisher) Start() error {
 }
 
 func (p *Publisher) Stop() error {
+	if p.unsubscribe != nil {
+		p.unsubscribe()
+	}
 	return nil
 }
 
@@ -106,7 +134,7 @@ func (p *Publisher) InitializeSecret(attestation *common.AttestationReport, encS
 	}
 	initialiseSecretTx := p.mgmtContractLib.CreateInitializeSecret(l1tx)
 	// we block here until we confirm a successful receipt. It is important this is published before the initial rollup.
-	return p.publishTransaction(initialiseSecretTx)
+	return p.publishTransaction(p.hostStopper.Context(), initialiseSecretTx)
 }
 
 func (p *Publisher) RequestSecret(attestation *common.AttestationReport) (gethcommon.Hash, error) {
@@ -131,7 +159,7 @@ func (p *Publisher) RequestSecret(attestation *common.AttestationReport) (gethco
 	}
 	requestSecretTx := p.mgmtContractLib.CreateRequestSecret(l1tx)
 	// we wait until the secret req transaction has succeeded before we start polling for the secret
-	err = p.publishTransaction(requestSecretTx)
+	err = p.publishTransaction(p.hostStopper.Context(), requestSecretTx)
 	if err != nil {
 		return gethcommon.Hash{}, err
 	}
@@ -152,7 +180,7 @@ func (p *Publisher) PublishSecretResponse(secretResponse *common.ProducedSecretR
 
 	// fire-and-forget (track the receipt asynchronously)
 	go func() {
-		err := p.publishTransaction(respondSecretTx)
+		err := p.publishTransaction(p.hostStopper.Context(), respondSecretTx)
 		if err != nil {
 			p.logger.Error("Could not broadcast secret response L1 tx", log.ErrKey, err)
 		}
@@ -192,6 +220,18 @@ func (p *Publisher) FetchLatestSeqNo() (*big.Int, error) {
 	return p.ethClient.FetchLastBatchSeqNo(*p.mgmtContractLib.GetContractAddr())
 }
 
+func (p *Publisher) EstimateRollupCost(rollupSize uint64, batchCount uint64, maxRollupSize uint64) (*common.RollupCostEstimate, error) {
+	head, err := p.ethClient.FetchHeadBlock()
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch the L1 head - %w", err)
+	}
+	gasPrice, err := p.ethClient.SuggestGasPrice()
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch the L1 gas price - %w", err)
+	}
+	return EstimateRollupCost(rollupSize, batchCount, maxRollupSize, head.Header(), gasPrice), nil
+}
+
 func (p *Publisher) PublishRollup(producedRollup *common.ExtRollup) {
 	encRollup, err := common.EncodeRollup(producedRollup)
 	if err != nil {
@@ -214,7 +254,8 @@ func (p *Publisher) PublishRollup(producedRollup *common.ExtRollup) {
 
 	rollupTx := p.mgmtContractLib.CreateRollup(tx)
 
-	err = p.publishTransaction(rollupTx)
+	err = p.publishTransaction(p.hostStopper.Context(), rollupTx)
+	p.metrics.onRollup(err)
 	if err != nil {
 		p.logger.Error("Could not issue rollup tx", log.RollupHashKey, producedRollup.Hash(), log.ErrKey, err)
 	} else {
@@ -253,6 +294,30 @@ func (p *Publisher) FetchLatestPeersList() ([]string, error) {
 	return filteredHostAddresses, nil
 }
 
+func (p *Publisher) IsHostAttested(hostID gethcommon.Address) (bool, error) {
+	msg, err := p.mgmtContractLib.GetAttestedMsg(hostID)
+	if err != nil {
+		return false, err
+	}
+	response, err := p.ethClient.CallContract(msg)
+	if err != nil {
+		return false, err
+	}
+	return p.mgmtContractLib.DecodeAttestedResponse(response)
+}
+
+func (p *Publisher) HostAddressOf(hostID gethcommon.Address) (string, error) {
+	msg, err := p.mgmtContractLib.GetHostAddressMsg(hostID)
+	if err != nil {
+		return "", err
+	}
+	response, err := p.ethClient.CallContract(msg)
+	if err != nil {
+		return "", err
+	}
+	return p.mgmtContractLib.DecodeHostAddressResponse(response)
+}
+
 func (p *Publisher) GetImportantContracts() map[string]gethcommon.Address {
 	p.importantAddressesMutex.RLock()
 	defer p.importantAddressesMutex.RUnlock()
@@ -302,21 +367,42 @@ func (p *Publisher) ResyncImportantContracts() error {
 	return nil
 }
 
+// FailedTransactions returns the dead-letter queue of the publisher: the transactions it gave up on, oldest first
+func (p *Publisher) FailedTransactions() []common.FailedL1Transaction {
+	p.deadLettersLock.Lock()
+	defer p.deadLettersLock.Unlock()
+	return append([]common.FailedL1Transaction{}, p.deadLetters...)
+}
+
 // publishTransaction will keep trying unless the L1 seems to be unavailable or the tx is otherwise rejected
 // It is responsible for keeping the nonce accurate, according to the following rules:
 // - Caller should not increment the wallet nonce before this method is called
 // - This method will increment the wallet nonce only if the transaction is successfully broadcast
-// - This method will continue to resend the tx using latest gas price until it is successfully broadcast or the L1 is unavailable/this service is shutdown
+// - This method will resend the tx with the fees of the gas oracle (or the latest gas price), bumped for each retry, until
+// it gets a receipt, and gives up
+// after maxTxRetries failed broadcasts or missing receipts. The given up transactions go to the dead-letter queue.
+// - After a failed broadcast, or when giving up, the wallet nonce is resynchronised with the nonce of the L1 node
 // - **ONLY** the L1 publisher service is publishing transactions for this wallet (to avoid nonce conflicts)
-// todo (@matt) this method should take a context so we can try to cancel if the tx is no longer required
-func (p *Publisher) publishTransaction(tx types.TxData) error {
+// The attempts stop when the context is done, e.g. when the host stops
+func (p *Publisher) publishTransaction(ctx context.Context, tx types.TxData) error {
 	// the nonce to be used for this tx attempt
 	nonce := p.hostWallet.GetNonceAndIncrement()
 	retries := -1
+	var lastTxHash gethcommon.Hash
+	var lastErr error
 
 	// while the publisher service is still alive we keep trying to get the transaction into the L1
-	for !p.hostStopper.IsStopping() {
+	for {
+		if ctx.Err() != nil {
+			return fmt.Errorf("stopped publishing the L1 tx - %w", ctx.Err())
+		}
 		retries++ // count each attempt so we can increase gas price
+		if retries > p.maxTxRetries {
+			return p.giveUp(tx, nonce, lastTxHash, retries, lastErr)
+		}
+		if retries > 0 {
+			p.metrics.txRetries.Inc(1)
+		}
 
 		// make sure an earlier tx hasn't been abandoned
 		if nonce > p.hostWallet.GetNonce() {
@@ -328,6 +414,10 @@ func (p *Publisher) publishTransaction(tx types.TxData) error {
 			p.hostWallet.SetNonce(nonce) // revert the wallet nonce because we failed to complete the transaction
 			return errors.Wrap(err, "could not estimate gas/gas price for L1 tx")
 		}
+		// bid the EIP-1559 fees of the recent L1 blocks, the legacy gas price is kept until the oracle sees a block
+		if feeCap, tip, ok := p.gasOracle.Fees(retries); ok {
+			tx = toDynamicFeeTx(tx, p.hostWallet.ChainID(), feeCap, tip)
+		}
 
 		signedTx, err := p.hostWallet.SignTransaction(tx)
 		if err != nil {
@@ -336,16 +426,25 @@ func (p *Publisher) publishTransaction(tx types.TxData) error {
 		}
 
 		p.logger.Info("Host issuing l1 tx", log.TxKey, signedTx.Hash(), "size", signedTx.Size()/1024, "retries", retries)
+		lastTxHash = signedTx.Hash()
 		err = p.ethClient.SendTransaction(signedTx)
 		if err != nil {
-			p.hostWallet.SetNonce(nonce) // revert the wallet nonce because we failed to complete the transaction
-			return errors.Wrap(err, "could not broadcast L1 tx")
+			lastErr = errors.Wrap(err, "could not broadcast L1 tx")
+			p.logger.Warn("Could not broadcast L1 tx, we will re-attempt", log.TxKey, signedTx.Hash(), log.ErrKey, err)
+			// the L1 node may have seen a nonce the wallet did not, e.g. if the failed broadcast still reached it
+			nonce = p.resyncNonce(nonce)
+			select {
+			case <-ctx.Done():
+			case <-time.After(p.retryIntervalForL1Receipt):
+			}
+			continue
 		}
 		p.logger.Info("Successfully submitted tx to L1", "txHash", signedTx.Hash())
 
 		var receipt *types.Receipt
 		// retry until receipt is found
-		err = retry.Do(
+		err = retry.DoWithContext(
+			ctx,
 			func() error {
 				receipt, err = p.ethClient.TransactionReceipt(signedTx.Hash())
 				if err != nil {
@@ -356,17 +455,63 @@ func (p *Publisher) publishTransaction(tx types.TxData) error {
 			retry.NewTimeoutStrategy(p.maxWaitForL1Receipt, p.retryIntervalForL1Receipt),
 		)
 		if err != nil {
+			lastErr = err
 			p.logger.Info("Receipt not found for transaction, we will re-attempt", log.ErrKey, err)
 			continue // try again on the same nonce, with updated gas price
 		}
 
+		p.metrics.onReceipt(receipt)
 		if err == nil && receipt.Status != types.ReceiptStatusSuccessful {
 			return fmt.Errorf("unsuccessful receipt found for published L1 transaction, status=%d", receipt.Status)
 		}
 
 		p.logger.Debug("L1 transaction successful receipt found.", log.TxKey, signedTx.Hash(),
 			log.BlockHeightKey, receipt.BlockNumber, log.BlockHashKey, receipt.BlockHash)
-		break
+		return nil
 	}
-	return nil
+}
+
+// resyncNonce fetches the pending nonce of the host wallet from the L1 node. If it differs from the nonce of the failed
+// attempt, the wallet nonce is reset to it and the next attempt takes a new nonce, otherwise the attempt keeps its nonce.
+func (p *Publisher) resyncNonce(nonce uint64) uint64 {
+	l1Nonce, err := p.ethClient.Nonce(p.hostWallet.Address())
+	if err != nil {
+		p.logger.Warn("Could not fetch the L1 nonce of the host wallet", log.ErrKey, err)
+		return nonce
+	}
+	if l1Nonce == nonce {
+		return nonce
+	}
+	p.logger.Warn("Resynchronising the nonce of the host wallet with the L1", "nonce", nonce, "l1Nonce", l1Nonce)
+	p.hostWallet.SetNonce(l1Nonce)
+	return p.hostWallet.GetNonceAndIncrement()
+}
+
+// giveUp moves a transaction that exhausted its retries to the dead-letter queue, and resets the wallet nonce to the
+// nonce of the L1 node, or to the nonce of the transaction if the L1 node cannot be reached
+func (p *Publisher) giveUp(tx types.TxData, nonce uint64, lastTxHash gethcommon.Hash, attempts int, lastErr error) error {
+	l1Nonce, err := p.ethClient.Nonce(p.hostWallet.Address())
+	if err != nil {
+		p.logger.Warn("Could not fetch the L1 nonce of the host wallet", log.ErrKey, err)
+		l1Nonce = nonce
+	}
+	p.hostWallet.SetNonce(l1Nonce)
+
+	p.logger.Error("Giving up on L1 tx after its retries", log.TxKey, lastTxHash, "nonce", nonce, "attempts", attempts, log.ErrKey, lastErr)
+	p.metrics.txDeadLetters.Inc(1)
+
+	p.deadLettersLock.Lock()
+	defer p.deadLettersLock.Unlock()
+	p.deadLetters = append(p.deadLetters, common.FailedL1Transaction{
+		TxHash:   lastTxHash,
+		To:       types.NewTx(tx).To(),
+		Nonce:    hexutil.Uint64(nonce),
+		Attempts: hexutil.Uint64(attempts),
+		Error:    lastErr.Error(),
+		FailedAt: hexutil.Uint64(time.Now().Unix()),
+	})
+	if len(p.deadLetters) > maxDeadLetters {
+		p.deadLetters = p.deadLetters[len(p.deadLetters)-maxDeadLetters:]
+	}
+	return fmt.Errorf("gave up on the L1 tx after %d attempts - %w", attempts, lastErr)
 }
@@ -0,0 +1,53 @@
+package l1
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+// publisherMetrics exports the outcome of the transactions issued to the L1 by the host, and the gas they used
+type publisherMetrics struct {
+	txSuccesses gethmetrics.Counter
+	txFailures  gethmetrics.Counter
+	gasUsed     gethmetrics.Counter
+	lastGasUsed gethmetrics.Gauge
+
+	rollupsPublished gethmetrics.Counter
+	rollupsFailed    gethmetrics.Counter
+
+	txRetries     gethmetrics.Counter
+	txDeadLetters gethmetrics.Counter
+}
+
+func newPublisherMetrics(registry gethmetrics.Registry) *publisherMetrics {
+	return &publisherMetrics{
+		txSuccesses:      gethmetrics.NewRegisteredCounter("host/l1/tx/success", registry),
+		txFailures:       gethmetrics.NewRegisteredCounter("host/l1/tx/failure", registry),
+		gasUsed:          gethmetrics.NewRegisteredCounter("host/l1/tx/gas", registry),
+		lastGasUsed:      gethmetrics.NewRegisteredGauge("host/l1/tx/last/gas", registry),
+		rollupsPublished: gethmetrics.NewRegisteredCounter("host/l1/rollup/published", registry),
+		rollupsFailed:    gethmetrics.NewRegisteredCounter("host/l1/rollup/failed", registry),
+		txRetries:        gethmetrics.NewRegisteredCounter("host/l1/tx/retries", registry),
+		txDeadLetters:    gethmetrics.NewRegisteredCounter("host/l1/tx/deadletter", registry),
+	}
+}
+
+// onReceipt records the gas used by a transaction included in the L1, whether it was successful or not
+func (m *publisherMetrics) onReceipt(receipt *types.Receipt) {
+	if receipt.Status == types.ReceiptStatusSuccessful {
+		m.txSuccesses.Inc(1)
+	} else {
+		m.txFailures.Inc(1)
+	}
+	m.gasUsed.Inc(int64(receipt.GasUsed))
+	m.lastGasUsed.Update(int64(receipt.GasUsed))
+}
+
+func (m *publisherMetrics) onRollup(err error) {
+	if err != nil {
+		m.rollupsFailed.Inc(1)
+		return
+	}
+	m.rollupsPublished.Inc(1)
+}
@@ -0,0 +1,253 @@
+package l1
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/host"
+	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
+	"github.com/ten-protocol/go-ten/go/ethadapter"
+	"github.com/ten-protocol/go-ten/go/ethadapter/mgmtcontractlib"
+	"github.com/ten-protocol/go-ten/go/host/testsupport"
+	"github.com/ten-protocol/go-ten/go/wallet"
+)
+
+var errSendFailed = errors.New("send failed")
+
+type publisherTest struct {
+	publisher *Publisher
+	fakeL1    *testsupport.FakeL1Client
+	wallet    wallet.Wallet
+	mgmtLib   mgmtcontractlib.MgmtContractLib
+}
+
+func newPublisherTest(t *testing.T, autoReceipts bool) *publisherTest {
+	key, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	logger := gethlog.New()
+	hostWallet := wallet.NewInMemoryWalletFromPK(big.NewInt(1337), key, logger)
+	mgmtAddr := gethcommon.HexToAddress("0x01")
+	mgmtLib := mgmtcontractlib.NewMgmtContractLib(&mgmtAddr, logger)
+	fakeL1 := testsupport.NewFakeL1Client(autoReceipts)
+
+	publisher := NewL1Publisher(host.Identity{ID: hostWallet.Address()}, hostWallet, fakeL1, mgmtLib, nil,
+		stopcontrol.New(), logger, 100*time.Millisecond, 10*time.Millisecond, 0, NewGasOracle(20, 0, 0, logger), gethmetrics.NewRegistry())
+	return &publisherTest{publisher: publisher, fakeL1: fakeL1, wallet: hostWallet, mgmtLib: mgmtLib}
+}
+
+// signedAs returns the transaction the publisher signs for the tx data on its first attempt with the given nonce
+func (pt *publisherTest) signedAs(t *testing.T, txData types.TxData, nonce uint64) *types.Transaction {
+	prepared, err := pt.fakeL1.PrepareTransactionToRetry(txData, pt.wallet.Address(), nonce, 0)
+	require.NoError(t, err)
+	signedTx, err := pt.wallet.SignTransaction(prepared)
+	require.NoError(t, err)
+	return signedTx
+}
+
+func TestPublishTransaction(t *testing.T) {
+	tests := []struct {
+		name          string
+		sendErrs      []error
+		failedReceipt bool
+		expectErr     bool
+		expectedNonce uint64
+		expectedSent  int
+	}{
+		{name: "successful receipt", expectedNonce: 1, expectedSent: 1},
+		{name: "broadcast failure reverts the nonce", sendErrs: []error{errSendFailed}, expectErr: true, expectedNonce: 0},
+		{name: "unsuccessful receipt", failedReceipt: true, expectErr: true, expectedNonce: 1, expectedSent: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pt := newPublisherTest(t, !tc.failedReceipt)
+			pt.fakeL1.FailNextSends(tc.sendErrs...)
+
+			attestation := &common.AttestationReport{Owner: pt.wallet.Address()}
+			if tc.failedReceipt {
+				encodedAttestation, err := common.EncodeAttestation(attestation)
+				require.NoError(t, err)
+				txData := pt.mgmtLib.CreateInitializeSecret(&ethadapter.L1InitializeSecretTx{
+					AggregatorID: &pt.publisher.hostData.ID,
+					Attestation:  encodedAttestation,
+				})
+				signedTx := pt.signedAs(t, txData, 0)
+				pt.fakeL1.AddReceipt(signedTx.Hash(), &types.Receipt{Status: types.ReceiptStatusFailed, TxHash: signedTx.Hash()})
+			}
+
+			err := pt.publisher.InitializeSecret(attestation, nil)
+			if tc.expectErr {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+			}
+			require.Equal(t, tc.expectedNonce, pt.wallet.GetNonce())
+			require.Len(t, pt.fakeL1.SentTransactions(), tc.expectedSent)
+		})
+	}
+}
+
+func TestPublishTransactionRetries(t *testing.T) {
+	tests := []struct {
+		name             string
+		maxRetries       int
+		sendErrs         []error
+		noReceipts       bool
+		expectErr        bool
+		expectedNonce    uint64
+		expectedSent     int
+		expectedAttempts uint64
+	}{
+		{name: "failed broadcasts are retried", maxRetries: 2, sendErrs: []error{errSendFailed, errSendFailed}, expectedNonce: 1, expectedSent: 1},
+		{name: "gives up after the failed broadcasts", maxRetries: 1, sendErrs: []error{errSendFailed, errSendFailed}, expectErr: true, expectedNonce: 0, expectedAttempts: 2},
+		{name: "gives up on a stuck transaction", maxRetries: 1, noReceipts: true, expectErr: true, expectedNonce: 1, expectedSent: 2, expectedAttempts: 2},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			pt := newPublisherTest(t, !tc.noReceipts)
+			pt.publisher.maxTxRetries = tc.maxRetries
+			pt.fakeL1.FailNextSends(tc.sendErrs...)
+
+			err := pt.publisher.InitializeSecret(&common.AttestationReport{Owner: pt.wallet.Address()}, nil)
+			require.Equal(t, tc.expectErr, err != nil)
+			require.Equal(t, tc.expectedNonce, pt.wallet.GetNonce())
+			require.Len(t, pt.fakeL1.SentTransactions(), tc.expectedSent)
+
+			failed := pt.publisher.FailedTransactions()
+			if !tc.expectErr {
+				require.Empty(t, failed)
+				return
+			}
+			require.Len(t, failed, 1)
+			require.Equal(t, tc.expectedAttempts, uint64(failed[0].Attempts))
+			require.Zero(t, uint64(failed[0].Nonce))
+			require.Equal(t, pt.mgmtLib.GetContractAddr(), failed[0].To)
+		})
+	}
+}
+
+func TestPublishTransactionResyncsNonceAfterFailedBroadcast(t *testing.T) {
+	pt := newPublisherTest(t, true)
+	pt.publisher.maxTxRetries = 1
+
+	// a transaction of the wallet the host does not know about takes nonce 0 on the L1
+	otherTx := pt.signedAs(t, &types.LegacyTx{To: &gethcommon.Address{}, Gas: 21_000}, 0)
+	require.NoError(t, pt.fakeL1.SendTransaction(otherTx))
+	pt.fakeL1.FailNextSends(errors.New("nonce too low"))
+
+	err := pt.publisher.InitializeSecret(&common.AttestationReport{Owner: pt.wallet.Address()}, nil)
+	require.NoError(t, err)
+	sent := pt.fakeL1.SentTransactions()
+	require.Len(t, sent, 2)
+	require.Equal(t, uint64(1), sent[1].Nonce())
+	require.Equal(t, uint64(2), pt.wallet.GetNonce())
+	require.Empty(t, pt.publisher.FailedTransactions())
+}
+
+func TestPublishTransactionBidsOracleFees(t *testing.T) {
+	pt := newPublisherTest(t, true)
+	pt.publisher.gasOracle.HandleBlock(newFeeBlock(1, 100, 10))
+
+	err := pt.publisher.InitializeSecret(&common.AttestationReport{Owner: pt.wallet.Address()}, nil)
+	require.NoError(t, err)
+	sent := pt.fakeL1.SentTransactions()
+	require.Len(t, sent, 1)
+	require.Equal(t, uint8(types.DynamicFeeTxType), sent[0].Type())
+	require.Equal(t, big.NewInt(210), sent[0].GasFeeCap())
+	require.Equal(t, big.NewInt(10), sent[0].GasTipCap())
+}
+
+func TestPublishSecretResponse(t *testing.T) {
+	pt := newPublisherTest(t, true)
+
+	err := pt.publisher.PublishSecretResponse(&common.ProducedSecretResponse{
+		Secret:      []byte("secret"),
+		RequesterID: gethcommon.HexToAddress("0x02"),
+		HostAddress: "127.0.0.1:10000",
+	})
+	require.NoError(t, err)
+
+	// the response is broadcast asynchronously
+	require.Eventually(t, func() bool {
+		return len(pt.fakeL1.SentTransactions()) == 1
+	}, time.Second, 10*time.Millisecond)
+
+	decoded := pt.mgmtLib.DecodeTx(pt.fakeL1.SentTransactions()[0])
+	respondSecretTx, ok := decoded.(*ethadapter.L1RespondSecretTx)
+	require.True(t, ok)
+	require.Equal(t, gethcommon.HexToAddress("0x02"), respondSecretTx.RequesterID)
+	require.Equal(t, pt.wallet.Address(), respondSecretTx.AttesterID)
+}
+
+func TestRequestSecret(t *testing.T) {
+	pt := newPublisherTest(t, true)
+	head := newTestBlock(5, gethcommon.Hash{}, "")
+	pt.fakeL1.AddBlock(head, true)
+
+	watchFrom, err := pt.publisher.RequestSecret(&common.AttestationReport{Owner: pt.wallet.Address()})
+	require.NoError(t, err)
+	require.Equal(t, head.Hash(), watchFrom)
+	require.Len(t, pt.fakeL1.SentTransactions(), 1)
+	require.Zero(t, pt.fakeL1.Reconnects())
+}
+
+func TestRequestSecretReconnectsWhenHeadIsUnavailable(t *testing.T) {
+	pt := newPublisherTest(t, true)
+
+	// the fake has no head block, so the publisher reconnects once and then gives up
+	require.Panics(t, func() {
+		_, _ = pt.publisher.RequestSecret(&common.AttestationReport{Owner: pt.wallet.Address()})
+	})
+	require.Equal(t, 1, pt.fakeL1.Reconnects())
+	require.Empty(t, pt.fakeL1.SentTransactions())
+}
+
+func TestPublishRollupRecoversFromSendFailures(t *testing.T) {
+	pt := newPublisherTest(t, true)
+	pt.fakeL1.FailNextSends(errSendFailed)
+	rollup := &common.ExtRollup{Header: &common.RollupHeader{}}
+
+	// the failed publication is only logged, and it must not consume the nonce
+	pt.publisher.PublishRollup(rollup)
+	require.Empty(t, pt.fakeL1.SentTransactions())
+	require.Zero(t, pt.wallet.GetNonce())
+
+	pt.publisher.PublishRollup(rollup)
+	sent := pt.fakeL1.SentTransactions()
+	require.Len(t, sent, 1)
+	require.Zero(t, sent[0].Nonce())
+	_, isRollup := pt.mgmtLib.DecodeTx(sent[0]).(*ethadapter.L1RollupTx)
+	require.True(t, isRollup)
+}
+
+func TestPublisherMetrics(t *testing.T) {
+	registry := gethmetrics.NewRegistry()
+	pt := newPublisherTest(t, false)
+	pt.publisher.metrics = newPublisherMetrics(registry)
+	pt.fakeL1.FailNextSends(errSendFailed)
+	rollup := &common.ExtRollup{Header: &common.RollupHeader{}}
+	encRollup, err := common.EncodeRollup(rollup)
+	require.NoError(t, err)
+	signedTx := pt.signedAs(t, pt.mgmtLib.CreateRollup(&ethadapter.L1RollupTx{Rollup: encRollup}), 0)
+	pt.fakeL1.AddReceipt(signedTx.Hash(), &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: signedTx.Hash(), GasUsed: 50_000})
+
+	pt.publisher.PublishRollup(rollup)
+	pt.publisher.PublishRollup(rollup)
+
+	if !gethmetrics.Enabled {
+		return
+	}
+	require.Equal(t, int64(1), registry.Get("host/l1/rollup/published").(gethmetrics.Counter).Count())
+	require.Equal(t, int64(1), registry.Get("host/l1/rollup/failed").(gethmetrics.Counter).Count())
+	require.Equal(t, int64(1), registry.Get("host/l1/tx/success").(gethmetrics.Counter).Count())
+	require.Equal(t, int64(50_000), registry.Get("host/l1/tx/gas").(gethmetrics.Counter).Count())
+	require.Equal(t, int64(50_000), registry.Get("host/l1/tx/last/gas").(gethmetrics.Gauge).Value())
+}
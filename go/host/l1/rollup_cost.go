@@ -0,0 +1,84 @@
+package l1
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/consensus/misc/eip4844"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+// the bytes of rollup data a blob holds, each field element only holds 31 bytes so that it stays below the modulus
+const blobCapacity = params.BlobTxFieldElementsPerBlob * (params.BlobTxBytesPerFieldElement - 1)
+
+// EstimateRollupCost estimates the L1 cost of publishing a rollup of the given size, holding the given number of batches,
+// as calldata and as blobs, with the gas price and the blob gas price of the L1 head. The rollups are encrypted, so
+// every byte of calldata is priced as a non-zero byte.
+func EstimateRollupCost(rollupSize uint64, batchCount uint64, maxRollupSize uint64, head *types.Header, gasPrice *big.Int) *common.RollupCostEstimate {
+	estimate := &common.RollupCostEstimate{
+		L1BlockHash:    head.Hash(),
+		PendingBatches: hexutil.Uint64(batchCount),
+		RollupSize:     hexutil.Uint64(rollupSize),
+		GasPrice:       (*hexutil.Big)(gasPrice),
+		CalldataCost:   (*hexutil.Big)(calldataCost(rollupSize, gasPrice)),
+		Route:          common.CalldataRoute,
+	}
+	if head.ExcessBlobGas == nil {
+		return estimate // the L1 does not support the blobs yet
+	}
+
+	blobGasPrice := eip4844.CalcBlobFee(*head.ExcessBlobGas)
+	blobCost := blobCost(rollupSize, gasPrice, blobGasPrice)
+	estimate.BlobGasPrice = (*hexutil.Big)(blobGasPrice)
+	estimate.BlobCost = (*hexutil.Big)(blobCost)
+	if blobCost.Cmp(estimate.CalldataCost.ToInt()) < 0 {
+		estimate.Route = common.BlobRoute
+	}
+	if batchCount > 0 {
+		estimate.BreakEvenBatches = hexutil.Uint64(breakEvenBatches(rollupSize/batchCount, maxRollupSize, gasPrice, blobGasPrice))
+	}
+	return estimate
+}
+
+func calldataCost(size uint64, gasPrice *big.Int) *big.Int {
+	gas := params.TxGas + size*params.TxDataNonZeroGasEIP2028
+	return big.NewInt(0).Mul(big.NewInt(0).SetUint64(gas), gasPrice)
+}
+
+func blobCost(size uint64, gasPrice *big.Int, blobGasPrice *big.Int) *big.Int {
+	blobs := (size + blobCapacity - 1) / blobCapacity
+	if blobs == 0 {
+		blobs = 1
+	}
+	cost := big.NewInt(0).Mul(big.NewInt(0).SetUint64(blobs*params.BlobTxBlobGasPerBlob), blobGasPrice)
+	return cost.Add(cost, big.NewInt(0).Mul(big.NewInt(0).SetUint64(params.TxGas), gasPrice))
+}
+
+// breakEvenBatches returns the number of batches of the given size from which a blob is cheaper than the calldata, or 0
+// if it is not cheaper for any rollup up to the max rollup size. The calldata cost grows with every byte while a blob
+// costs the same until it is full, so if a full blob is not cheaper, no rollup spanning more blobs is.
+func breakEvenBatches(batchSize uint64, maxRollupSize uint64, gasPrice *big.Int, blobGasPrice *big.Int) uint64 {
+	if batchSize == 0 || gasPrice.Sign() == 0 {
+		return 0
+	}
+	// the smallest size whose calldata costs more than a blob
+	blobFee := big.NewInt(0).Mul(big.NewInt(params.BlobTxBlobGasPerBlob), blobGasPrice)
+	byteFee := big.NewInt(0).Mul(big.NewInt(int64(params.TxDataNonZeroGasEIP2028)), gasPrice)
+	minSize := blobFee.Div(blobFee, byteFee)
+	minSize.Add(minSize, big.NewInt(1))
+
+	limit := maxRollupSize
+	if limit > blobCapacity {
+		limit = blobCapacity
+	}
+	if !minSize.IsUint64() || minSize.Uint64() > limit {
+		return 0
+	}
+	batches := (minSize.Uint64() + batchSize - 1) / batchSize
+	if batches*batchSize > limit {
+		return 0
+	}
+	return batches
+}
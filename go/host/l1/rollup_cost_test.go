@@ -0,0 +1,68 @@
+package l1
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+func TestEstimateRollupCost(t *testing.T) {
+	zeroExcess := uint64(0)
+	tests := []struct {
+		name              string
+		excessBlobGas     *uint64
+		rollupSize        uint64
+		batchCount        uint64
+		maxRollupSize     uint64
+		expectedRoute     common.RollupPublicationRoute
+		expectedBlobCost  int64 // 0 if the blobs are not supported
+		expectedBreakEven uint64
+	}{
+		{name: "no blobs before cancun", rollupSize: 2000, batchCount: 2, maxRollupSize: 64 * 1024, expectedRoute: common.CalldataRoute},
+		// a blob costs as much as 8192 bytes of calldata at these prices
+		{name: "small rollup", excessBlobGas: &zeroExcess, rollupSize: 2000, batchCount: 2, maxRollupSize: 64 * 1024, expectedRoute: common.CalldataRoute, expectedBlobCost: 152_072, expectedBreakEven: 9},
+		{name: "break-even above the max rollup size", excessBlobGas: &zeroExcess, rollupSize: 2000, batchCount: 2, maxRollupSize: 8000, expectedRoute: common.CalldataRoute, expectedBlobCost: 152_072},
+		{name: "large rollup", excessBlobGas: &zeroExcess, rollupSize: 20_000, batchCount: 4, maxRollupSize: 64 * 1024, expectedRoute: common.BlobRoute, expectedBlobCost: 152_072, expectedBreakEven: 2},
+		{name: "no pending batch", excessBlobGas: &zeroExcess, maxRollupSize: 64 * 1024, expectedRoute: common.CalldataRoute, expectedBlobCost: 152_072},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			head := &types.Header{Number: big.NewInt(1), ExcessBlobGas: tt.excessBlobGas}
+			estimate := EstimateRollupCost(tt.rollupSize, tt.batchCount, tt.maxRollupSize, head, big.NewInt(1))
+
+			require.Equal(t, head.Hash(), estimate.L1BlockHash)
+			require.Equal(t, int64(21_000+16*tt.rollupSize), estimate.CalldataCost.ToInt().Int64())
+			require.Equal(t, tt.expectedRoute, estimate.Route)
+			require.Equal(t, tt.expectedBreakEven, uint64(estimate.BreakEvenBatches))
+			if tt.expectedBlobCost == 0 {
+				require.Nil(t, estimate.BlobCost)
+				require.Nil(t, estimate.BlobGasPrice)
+				return
+			}
+			require.Equal(t, tt.expectedBlobCost, estimate.BlobCost.ToInt().Int64())
+		})
+	}
+}
+
+func TestBlobCostCountsTheBlobs(t *testing.T) {
+	require.Equal(t, int64(131_072+21_000), blobCost(blobCapacity, big.NewInt(1), big.NewInt(1)).Int64())
+	require.Equal(t, int64(2*131_072+21_000), blobCost(blobCapacity+1, big.NewInt(1), big.NewInt(1)).Int64())
+}
+
+func TestPublisherEstimatesWithTheL1Fees(t *testing.T) {
+	pt := newPublisherTest(t, true)
+	excessBlobGas := uint64(0)
+	head := types.NewBlockWithHeader(&types.Header{Number: big.NewInt(5), ExcessBlobGas: &excessBlobGas})
+	pt.fakeL1.AddBlock(head, true)
+	pt.fakeL1.GasPrice = big.NewInt(100)
+
+	estimate, err := pt.publisher.EstimateRollupCost(20_000, 4, 64*1024)
+	require.NoError(t, err)
+	require.Equal(t, head.Hash(), estimate.L1BlockHash)
+	require.Equal(t, int64(100), estimate.GasPrice.ToInt().Int64())
+	require.Equal(t, int64(1), estimate.BlobGasPrice.ToInt().Int64())
+	require.Equal(t, common.BlobRoute, estimate.Route)
+}
@@ -13,13 +13,14 @@ import (
 	"github.com/ten-protocol/go-ten/go/common/host"
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/config"
+	"github.com/ten-protocol/go-ten/go/host/archive"
 	"github.com/ten-protocol/go-ten/go/host/db"
 )
 
 const (
 	// if request asks for batches from seq no. X we don't want to return potentially thousands of batches, so we limit
 	// the number of batches we return with this cap
-	// (recipient will request the next ones as required, and they should be catching up from roll-ups first)
+	// (recipient requests the next page of its range once it receives the response)
 	_maxBatchesInP2PResponse      = 50
 	_timeoutWaitingForP2PResponse = 30 * time.Second
 )
@@ -37,6 +38,7 @@ type Repository struct {
 
 	sl          batchRepoServiceLocator
 	db          *db.DB
+	archive     *archive.Archiver // nil if the archiving is disabled
 	isSequencer bool
 
 	// high watermark for batch sequence numbers seen so far. If we can't find batch for seq no < this, then we should ask peers for missing batches
@@ -48,18 +50,27 @@ type Repository struct {
 	// So we keep track of the last request time and what was requested, using a mutex to avoid concurrent access errors on them
 	p2pReqMutex          sync.Mutex
 	p2pInFlightRequested *big.Int
+	p2pInFlightTo        *big.Int // the last batch of the range requested, the rest of the range is requested page by page
 	p2pInFlightReqTime   *time.Time
 
 	running atomic.Bool
 	logger  gethlog.Logger
 }
 
-func NewBatchRepository(cfg *config.HostConfig, hostService batchRepoServiceLocator, database *db.DB, logger gethlog.Logger) *Repository {
+func NewBatchRepository(cfg *config.HostConfig, hostService batchRepoServiceLocator, database *db.DB, archiver *archive.Archiver, logger gethlog.Logger) *Repository {
+	// a persistent DB resumes from the head batch stored before the restart
+	latestBatchSeqNo := big.NewInt(0)
+	if head, err := database.GetHeadBatchHeader(); err == nil {
+		latestBatchSeqNo.Set(head.SequencerOrderNo)
+	} else if !errors.Is(err, errutil.ErrNotFound) {
+		logger.Warn("Could not read the head batch from the host DB", log.ErrKey, err)
+	}
 	return &Repository{
 		sl:               hostService,
 		db:               database,
+		archive:          archiver,
 		isSequencer:      cfg.NodeType == common.Sequencer,
-		latestBatchSeqNo: big.NewInt(0),
+		latestBatchSeqNo: latestBatchSeqNo,
 		running:          atomic.Bool{},
 		logger:           logger,
 	}
@@ -93,13 +104,23 @@ func (r *Repository) HealthStatus() host.HealthStatus {
 // If the batch is the new head of the L2 then it notifies subscribers to this service that a new batch has arrived
 func (r *Repository) HandleBatches(batches []*common.ExtBatch, isLive bool) {
 	// if these batches resolve the in-flight request we made then clear the in-flight request (see type def for details)
+	var nextPageFrom, nextPageTo *big.Int
 	r.p2pReqMutex.Lock()
 	if !isLive && len(batches) > 0 && r.p2pInFlightRequested != nil && batches[0].Header.SequencerOrderNo.Cmp(r.p2pInFlightRequested) == 0 {
 		// the first bach in the response is the one we requested, so clear the in-flight request
+		lastSeqNo := batches[len(batches)-1].Header.SequencerOrderNo
+		if r.p2pInFlightTo != nil && lastSeqNo.Cmp(r.p2pInFlightTo) < 0 {
+			// the response is a page of the range, the next page is requested straight away
+			nextPageFrom, nextPageTo = new(big.Int).Add(lastSeqNo, big.NewInt(1)), r.p2pInFlightTo
+		}
 		r.p2pInFlightRequested = nil
+		r.p2pInFlightTo = nil
 		r.p2pInFlightReqTime = nil
 	}
 	r.p2pReqMutex.Unlock()
+	if nextPageFrom != nil {
+		go r.requestBatchesFromPeers(nextPageFrom, nextPageTo)
+	}
 
 	// try to add all the batches to the db, and notify subscribers if they are new and live
 	for _, batch := range batches {
@@ -120,12 +141,16 @@ func (r *Repository) HandleBatches(batches []*common.ExtBatch, isLive bool) {
 	}
 }
 
-// HandleBatchRequest handles a request for a batch from a peer, sending batches to the requester asynchronously
+// HandleBatchRequest handles a request for a range of batches from a peer, sending the first page of the range the host
+// has to the requester asynchronously. Any host serves the batches it stored, the requester checks their signature.
 // todo (#1625) - only allow requests for batches since last rollup, to avoid DoS attacks.
-func (r *Repository) HandleBatchRequest(requesterID string, fromSeqNo *big.Int) {
+func (r *Repository) HandleBatchRequest(requesterID string, fromSeqNo *big.Int, toSeqNo *big.Int) {
+	if fromSeqNo == nil {
+		return
+	}
 	batches := make([]*common.ExtBatch, 0)
-	nextSeqNum := fromSeqNo
-	for len(batches) <= _maxBatchesInP2PResponse {
+	nextSeqNum := new(big.Int).Set(fromSeqNo)
+	for len(batches) < _maxBatchesInP2PResponse && (toSeqNo == nil || nextSeqNum.Cmp(toSeqNo) <= 0) {
 		batch, err := r.db.GetBatchBySequenceNumber(nextSeqNum)
 		if err != nil {
 			if !errors.Is(err, errutil.ErrNotFound) {
@@ -154,7 +179,17 @@ func (r *Repository) Subscribe(subscriber host.L2BatchHandler) {
 func (r *Repository) FetchBatchBySeqNo(seqNo *big.Int) (*common.ExtBatch, error) {
 	b, err := r.db.GetBatchBySequenceNumber(seqNo)
 	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) && r.archive != nil {
+			// the batches are only pruned once archived, so a pruned batch is fetched back from the object storage
+			if pruned, pruneErr := r.db.IsBatchPruned(seqNo); pruneErr == nil && pruned {
+				return r.archive.FetchBatch(seqNo)
+			}
+		}
 		if errors.Is(err, errutil.ErrNotFound) && seqNo.Cmp(r.latestBatchSeqNo) < 0 {
+			// the batch was rolled up and pruned, the peers can't be expected to serve it either
+			if pruned, pruneErr := r.db.IsBatchPruned(seqNo); pruneErr != nil || pruned {
+				return nil, err
+			}
 			if r.isSequencer {
 				// sequencer does not request batches from peers, it checks if its enclave has the batch
 				return r.fetchBatchFallbackToEnclave(seqNo)
@@ -205,23 +240,33 @@ func (r *Repository) fetchBatchFallbackToEnclave(seqNo *big.Int) (*common.ExtBat
 	return b, nil
 }
 
-// RequestMissingBatches requests batches from peers from the specified sequence number.
-// It is an asynchronous request and the repository does not expect to be notified of the result.
+// requestMissingBatchesFromPeers requests the batches from the specified sequence number up to the latest batch seen
+// from peers. It is an asynchronous request and the repository does not expect to be notified of the result.
 func (r *Repository) requestMissingBatchesFromPeers(fromSeqNo *big.Int) {
+	r.latestSeqNoMutex.Lock()
+	toSeqNo := new(big.Int).Set(r.latestBatchSeqNo)
+	r.latestSeqNoMutex.Unlock()
+	r.requestBatchesFromPeers(fromSeqNo, toSeqNo)
+}
+
+// requests the batches of the range from peers, unless a request is already in flight
+func (r *Repository) requestBatchesFromPeers(fromSeqNo *big.Int, toSeqNo *big.Int) {
 	r.p2pReqMutex.Lock()
 	defer r.p2pReqMutex.Unlock()
 	if r.p2pInFlightReqTime != nil && time.Since(*r.p2pInFlightReqTime) < _timeoutWaitingForP2PResponse {
 		// don't send request if we have sent one too recently
-		r.logger.Trace("not requesting missing batches from sequencer - too soon since last request", "fromSeqNo", fromSeqNo, "lastReq", r.p2pInFlightReqTime)
+		r.logger.Trace("not requesting missing batches from peers - too soon since last request", "fromSeqNo", fromSeqNo, "lastReq", r.p2pInFlightReqTime)
 		return
 	}
 
-	r.logger.Debug("requesting missing batches from sequencer", "fromSeqNo", fromSeqNo)
-	err := r.sl.P2P().RequestBatchesFromSequencer(fromSeqNo)
+	r.logger.Debug("requesting missing batches from peers", "fromSeqNo", fromSeqNo, "toSeqNo", toSeqNo)
+	err := r.sl.P2P().RequestBatches(fromSeqNo, toSeqNo)
 	if err != nil {
-		r.logger.Warn("unable to request missing batches from sequencer", "fromSeqNo", fromSeqNo, log.ErrKey, err)
+		r.logger.Warn("unable to request missing batches from peers", "fromSeqNo", fromSeqNo, log.ErrKey, err)
 		return
 	}
 	now := time.Now()
+	r.p2pInFlightRequested = fromSeqNo
+	r.p2pInFlightTo = toSeqNo
 	r.p2pInFlightReqTime = &now
 }
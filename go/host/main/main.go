@@ -15,5 +15,5 @@ func main() {
 	}
 
 	hostContainer := hostcontainer.NewHostContainerFromConfig(parsedConfig, nil)
-	container.Serve(hostContainer)
+	container.ServeWithGracePeriod(hostContainer, parsedConfig.TerminationGracePeriod, parsedConfig.DrainDelay)
 }
@@ -0,0 +1,105 @@
+package p2p
+
+import (
+	"regexp"
+	"sync"
+	"time"
+
+	"github.com/ten-protocol/go-ten/go/common/host"
+
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+// the characters of the peer addresses that are not valid in a metric name
+var metricNameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_]`)
+
+// bandwidthTracker counts the bytes and the messages exchanged with each peer, and of each message type. The counts are
+// also exported as metrics, so they can be scraped by Prometheus.
+type bandwidthTracker struct {
+	lock          sync.Mutex
+	byPeer        map[string]*host.P2PTraffic
+	byMessageType map[msgType]*host.P2PTraffic
+	registry      gethmetrics.Registry
+}
+
+func newBandwidthTracker(registry gethmetrics.Registry) *bandwidthTracker {
+	return &bandwidthTracker{
+		byPeer:        map[string]*host.P2PTraffic{},
+		byMessageType: map[msgType]*host.P2PTraffic{},
+		registry:      registry,
+	}
+}
+
+func (b *bandwidthTracker) sent(peer string, msgType msgType, size int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, traffic := range []*host.P2PTraffic{b.peerTraffic(peer), b.messageTypeTraffic(msgType)} {
+		traffic.BytesSent += uint64(size)
+		traffic.MessagesSent++
+	}
+	b.counter("host/p2p/peer/" + metricName(peer) + "/sent/bytes").Inc(int64(size))
+	b.counter("host/p2p/msg/" + msgType.String() + "/sent/bytes").Inc(int64(size))
+	b.counter("host/p2p/msg/" + msgType.String() + "/sent/count").Inc(1)
+}
+
+func (b *bandwidthTracker) received(peer string, msgType msgType, size int) {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	for _, traffic := range []*host.P2PTraffic{b.peerTraffic(peer), b.messageTypeTraffic(msgType)} {
+		traffic.BytesReceived += uint64(size)
+		traffic.MessagesReceived++
+	}
+	b.counter("host/p2p/peer/" + metricName(peer) + "/received/bytes").Inc(int64(size))
+	b.counter("host/p2p/msg/" + msgType.String() + "/received/bytes").Inc(int64(size))
+	b.counter("host/p2p/msg/" + msgType.String() + "/received/count").Inc(1)
+}
+
+// broadcastRound records the duration of a gossip round, from the broadcast of a message until all the peers have
+// received it or could not be reached, and the number of peers that could not be reached
+func (b *bandwidthTracker) broadcastRound(msgType msgType, duration time.Duration, failures int64) {
+	gethmetrics.GetOrRegisterTimer("host/p2p/broadcast/"+msgType.String()+"/duration", b.registry).Update(duration)
+	b.counter("host/p2p/broadcast/" + msgType.String() + "/failures").Inc(failures)
+}
+
+// bandwidth returns a copy of the counts
+func (b *bandwidthTracker) bandwidth() *host.P2PBandwidth {
+	b.lock.Lock()
+	defer b.lock.Unlock()
+	bandwidth := &host.P2PBandwidth{
+		ByPeer:        make(map[string]host.P2PTraffic, len(b.byPeer)),
+		ByMessageType: make(map[string]host.P2PTraffic, len(b.byMessageType)),
+	}
+	for peer, traffic := range b.byPeer {
+		bandwidth.ByPeer[peer] = *traffic
+	}
+	for msgType, traffic := range b.byMessageType {
+		bandwidth.ByMessageType[msgType.String()] = *traffic
+	}
+	return bandwidth
+}
+
+func (b *bandwidthTracker) peerTraffic(peer string) *host.P2PTraffic {
+	traffic, ok := b.byPeer[peer]
+	if !ok {
+		traffic = &host.P2PTraffic{}
+		b.byPeer[peer] = traffic
+	}
+	return traffic
+}
+
+func (b *bandwidthTracker) messageTypeTraffic(msgType msgType) *host.P2PTraffic {
+	traffic, ok := b.byMessageType[msgType]
+	if !ok {
+		traffic = &host.P2PTraffic{}
+		b.byMessageType[msgType] = traffic
+	}
+	return traffic
+}
+
+func (b *bandwidthTracker) counter(name string) gethmetrics.Counter {
+	return gethmetrics.GetOrRegisterCounter(name, b.registry)
+}
+
+func metricName(peer string) string {
+	return metricNameInvalidChars.ReplaceAllString(peer, "_")
+}
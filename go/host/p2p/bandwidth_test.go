@@ -0,0 +1,95 @@
+package p2p
+
+import (
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/host"
+
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestBandwidthTracker(t *testing.T) {
+	registry := gethmetrics.NewRegistry()
+	tracker := newBandwidthTracker(registry)
+
+	tracker.sent("10.0.0.1:10000", msgTypeBatches, 100)
+	tracker.sent("10.0.0.2:10000", msgTypeBatches, 50)
+	tracker.received("10.0.0.1:10000", msgTypeBatchRequest, 10)
+
+	bandwidth := tracker.bandwidth()
+	require.Equal(t, map[string]host.P2PTraffic{
+		"10.0.0.1:10000": {BytesSent: 100, MessagesSent: 1, BytesReceived: 10, MessagesReceived: 1},
+		"10.0.0.2:10000": {BytesSent: 50, MessagesSent: 1},
+	}, bandwidth.ByPeer)
+	require.Equal(t, map[string]host.P2PTraffic{
+		"batches":      {BytesSent: 150, MessagesSent: 2},
+		"batchRequest": {BytesReceived: 10, MessagesReceived: 1},
+	}, bandwidth.ByMessageType)
+
+	// the returned counts are a copy
+	tracker.sent("10.0.0.2:10000", msgTypeBatches, 50)
+	require.Equal(t, uint64(50), bandwidth.ByPeer["10.0.0.2:10000"].BytesSent)
+
+	if !gethmetrics.Enabled {
+		return
+	}
+	require.Equal(t, int64(100), registry.Get("host/p2p/peer/10_0_0_1_10000/sent/bytes").(gethmetrics.Counter).Count())
+	require.Equal(t, int64(3), registry.Get("host/p2p/msg/batches/sent/count").(gethmetrics.Counter).Count())
+}
+
+func TestBandwidthTrackerBroadcastRounds(t *testing.T) {
+	registry := gethmetrics.NewRegistry()
+	tracker := newBandwidthTracker(registry)
+
+	tracker.broadcastRound(msgTypeBatches, 20*time.Millisecond, 0)
+	tracker.broadcastRound(msgTypeBatches, 40*time.Millisecond, 2)
+
+	if !gethmetrics.Enabled {
+		return
+	}
+	duration := registry.Get("host/p2p/broadcast/batches/duration").(gethmetrics.Timer)
+	require.Equal(t, int64(2), duration.Count())
+	require.Equal(t, (40 * time.Millisecond).Nanoseconds(), duration.Max())
+	require.Equal(t, int64(2), registry.Get("host/p2p/broadcast/batches/failures").(gethmetrics.Counter).Count())
+}
+
+func TestServiceAccountsTheBandwidth(t *testing.T) {
+	listener, err := net.Listen(tcp, "127.0.0.1:0")
+	require.NoError(t, err)
+	sequencerAddress := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	validatorAddress := "127.0.0.1:20000"
+	sequencer := newTestService(common.Sequencer, sequencerAddress, []string{validatorAddress})
+	handler := &testTxHandler{txs: make(chan common.EncryptedTx, 1)}
+	sequencer.SubscribeForTx(handler)
+	require.NoError(t, sequencer.Start())
+	defer sequencer.Stop() //nolint:errcheck
+
+	validator := newTestService(common.Validator, validatorAddress, []string{sequencerAddress})
+	validator.running.Store(true)
+	validator.RefreshPeerList()
+	require.NoError(t, validator.SendTxToSequencer(common.EncryptedTx("tx")))
+
+	select {
+	case <-handler.txs:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the sequencer did not receive the transaction")
+	}
+
+	sent := validator.Bandwidth().ByMessageType["tx"]
+	require.Equal(t, uint64(1), sent.MessagesSent)
+	require.Positive(t, sent.BytesSent)
+	require.Eventually(t, func() bool {
+		return sequencer.Bandwidth().ByMessageType["tx"] == host.P2PTraffic{BytesReceived: sent.BytesSent, MessagesReceived: 1}
+	}, time.Second, 10*time.Millisecond)
+	// the validator also sent its protocol version to the sequencer
+	require.Eventually(t, func() bool {
+		sentToSequencer := validator.Bandwidth().ByPeer[sequencerAddress]
+		return sentToSequencer.MessagesSent == 2 && sequencer.Bandwidth().ByPeer[validatorAddress].BytesReceived == sentToSequencer.BytesSent
+	}, time.Second, 10*time.Millisecond)
+}
@@ -0,0 +1,108 @@
+package p2p
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+var (
+	errUnknownSequencerKey = errors.New("the key of the sequencer is not known yet")
+	errInvalidBatchSig     = errors.New("the batch is not signed by the sequencer")
+)
+
+// the order of the secp256k1 curve, and its half, over which the signatures are malleable
+var (
+	_secp256k1N     = crypto.S256().Params().N
+	_secp256k1HalfN = new(big.Int).Rsh(_secp256k1N, 1)
+)
+
+// batchVerifier checks that the batches served by the peers other than the sequencer are signed by the sequencer, so a
+// lagging host can pull its missing batches from any peer. The host does not know the key of the sequencer enclave, so
+// it recovers it from the batches received from the sequencer itself: each signature matches two candidate keys, and
+// the key is the candidate matching the signatures of all the batches.
+type batchVerifier struct {
+	lock       sync.RWMutex
+	candidates []*ecdsa.PublicKey // the keys matching the signatures of the sequencer's batches seen so far
+}
+
+// learn narrows the candidate keys of the sequencer with the signatures of batches received from the sequencer
+func (v *batchVerifier) learn(batches []*common.ExtBatch) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+	for _, batch := range batches {
+		keys := recoverSigners(batch)
+		if len(keys) == 0 {
+			continue
+		}
+		if v.candidates == nil {
+			v.candidates = keys
+			continue
+		}
+		var matching []*ecdsa.PublicKey
+		for _, candidate := range v.candidates {
+			for _, key := range keys {
+				if candidate.Equal(key) {
+					matching = append(matching, candidate)
+				}
+			}
+		}
+		// the sequencer rotated its key, e.g. after a failover, so the candidates are learnt again
+		if len(matching) == 0 {
+			matching = keys
+		}
+		v.candidates = matching
+	}
+}
+
+// verify returns an error unless all the batches are signed by the sequencer
+func (v *batchVerifier) verify(batches []*common.ExtBatch) error {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	if len(v.candidates) != 1 {
+		return errUnknownSequencerKey
+	}
+	for _, batch := range batches {
+		h := batch.Hash()
+		if batch.Header.R == nil || batch.Header.S == nil || !ecdsa.Verify(v.candidates[0], h[:], batch.Header.R, batch.Header.S) {
+			return fmt.Errorf("batch %s: %w", h, errInvalidBatchSig)
+		}
+	}
+	return nil
+}
+
+// knowsKey returns whether the key of the sequencer is known, so the batches of the other peers can be verified
+func (v *batchVerifier) knowsKey() bool {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	return len(v.candidates) == 1
+}
+
+// returns the keys that could have produced the signature of the batch, one for each recovery ID
+func recoverSigners(batch *common.ExtBatch) []*ecdsa.PublicKey {
+	r, s := batch.Header.R, batch.Header.S
+	if r == nil || s == nil || r.Sign() <= 0 || s.Sign() <= 0 || r.Cmp(_secp256k1N) >= 0 || s.Cmp(_secp256k1N) >= 0 {
+		return nil
+	}
+	// the recovery requires the low S form of the signature, which flips the recovery ID, so both IDs are tried anyway
+	if s.Cmp(_secp256k1HalfN) > 0 {
+		s = new(big.Int).Sub(_secp256k1N, s)
+	}
+	sig := make([]byte, crypto.SignatureLength)
+	r.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	h := batch.Hash()
+	var keys []*ecdsa.PublicKey
+	for recoveryID := byte(0); recoveryID < 2; recoveryID++ {
+		sig[64] = recoveryID
+		if key, err := crypto.SigToPub(h[:], sig); err == nil {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
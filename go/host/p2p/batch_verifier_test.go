@@ -0,0 +1,55 @@
+package p2p
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+)
+
+func signedBatch(t *testing.T, key *ecdsa.PrivateKey, seqNo int64) *common.ExtBatch {
+	batch := &common.ExtBatch{Header: &common.BatchHeader{
+		Number: big.NewInt(seqNo), SequencerOrderNo: big.NewInt(seqNo), BaseFee: big.NewInt(1),
+	}}
+	h := batch.Hash()
+	var err error
+	batch.Header.R, batch.Header.S, err = ecdsa.Sign(rand.Reader, key, h[:])
+	require.NoError(t, err)
+	return batch
+}
+
+func TestBatchVerifierLearnsTheSequencerKey(t *testing.T) {
+	sequencerKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	otherKey, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	verifier := &batchVerifier{}
+
+	// the batches cannot be verified until the key of the sequencer is known
+	require.ErrorIs(t, verifier.verify([]*common.ExtBatch{signedBatch(t, sequencerKey, 1)}), errUnknownSequencerKey)
+
+	// a few batches of the sequencer single out its key among the candidates of their signatures
+	for seqNo := int64(1); seqNo <= 8 && !verifier.knowsKey(); seqNo++ {
+		verifier.learn([]*common.ExtBatch{signedBatch(t, sequencerKey, seqNo)})
+	}
+	require.True(t, verifier.knowsKey())
+
+	require.NoError(t, verifier.verify([]*common.ExtBatch{signedBatch(t, sequencerKey, 10), signedBatch(t, sequencerKey, 11)}))
+	require.ErrorIs(t, verifier.verify([]*common.ExtBatch{signedBatch(t, sequencerKey, 10), signedBatch(t, otherKey, 11)}), errInvalidBatchSig)
+
+	// a tampered batch no longer matches its signature
+	header := *signedBatch(t, sequencerKey, 12).Header
+	header.SequencerOrderNo = big.NewInt(13)
+	tampered := &common.ExtBatch{Header: &header}
+	require.ErrorIs(t, verifier.verify([]*common.ExtBatch{tampered}), errInvalidBatchSig)
+
+	// the key is learnt again when the sequencer rotates it
+	for seqNo := int64(20); seqNo <= 28; seqNo++ {
+		verifier.learn([]*common.ExtBatch{signedBatch(t, otherKey, seqNo)})
+	}
+	require.NoError(t, verifier.verify([]*common.ExtBatch{signedBatch(t, otherKey, 30)}))
+}
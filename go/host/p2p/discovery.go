@@ -0,0 +1,211 @@
+package p2p
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ten-protocol/go-ten/go/common/host"
+)
+
+const (
+	// the score of a peer goes up with each message delivered and down with each delivery that failed, between 0 and
+	// _maxPeerScore. A new peer starts at _initialPeerScore
+	_maxPeerScore     = 100
+	_initialPeerScore = 50
+	_peerScoreSuccess = 5
+	_peerScoreFailure = 20
+
+	// a peer that could not be reached is not gossiped to for a backoff that doubles with each consecutive failure
+	_minPeerBackoff = 10 * time.Second
+	_maxPeerBackoff = 10 * time.Minute
+)
+
+var (
+	// the interval between the refreshes of the peer list from the L1 and the DNS records
+	_peerRefreshInterval = 10 * time.Minute
+	// the interval between the attempts to reconnect to the peers whose backoff is over
+	_peerReconnectInterval = 10 * time.Second
+)
+
+// peerScores scores the peers by the outcome of the deliveries of the messages sent to them. The peers that could not be
+// reached are skipped by the gossip until their backoff is over, then the host reconnects to them with a handshake.
+type peerScores struct {
+	lock   sync.RWMutex
+	byPeer map[string]*peerScore
+}
+
+type peerScore struct {
+	score        int
+	failures     int       // the consecutive failures
+	backoffUntil time.Time // zero if the peer is healthy
+	lastSuccess  time.Time
+}
+
+func newPeerScores() *peerScores {
+	return &peerScores{byPeer: map[string]*peerScore{}}
+}
+
+func (s *peerScores) peer(address string) *peerScore {
+	score, found := s.byPeer[address]
+	if !found {
+		score = &peerScore{score: _initialPeerScore}
+		s.byPeer[address] = score
+	}
+	return score
+}
+
+// delivered records a message delivered to the peer
+func (s *peerScores) delivered(address string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	score := s.peer(address)
+	score.score += _peerScoreSuccess
+	if score.score > _maxPeerScore {
+		score.score = _maxPeerScore
+	}
+	score.failures = 0
+	score.backoffUntil = time.Time{}
+	score.lastSuccess = time.Now()
+}
+
+// failed records a message that could not be delivered to the peer
+func (s *peerScores) failed(address string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	score := s.peer(address)
+	score.score -= _peerScoreFailure
+	if score.score < 0 {
+		score.score = 0
+	}
+	score.failures++
+	backoff := _minPeerBackoff
+	for i := 1; i < score.failures && backoff < _maxPeerBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > _maxPeerBackoff {
+		backoff = _maxPeerBackoff
+	}
+	score.backoffUntil = time.Now().Add(backoff)
+}
+
+// healthy returns the peers that are not backing off, the best scored first
+func (s *peerScores) healthy(addresses []string) []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	now := time.Now()
+	healthy := make([]string, 0, len(addresses))
+	for _, address := range addresses {
+		if score, found := s.byPeer[address]; found && now.Before(score.backoffUntil) {
+			continue
+		}
+		healthy = append(healthy, address)
+	}
+	sort.SliceStable(healthy, func(i, j int) bool {
+		return s.scoreOf(healthy[i]) > s.scoreOf(healthy[j])
+	})
+	return healthy
+}
+
+// reconnectable returns the peers whose backoff is over since their last failure
+func (s *peerScores) reconnectable(addresses []string) []string {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	now := time.Now()
+	var peers []string
+	for _, address := range addresses {
+		if score, found := s.byPeer[address]; found && score.failures > 0 && !now.Before(score.backoffUntil) {
+			peers = append(peers, address)
+		}
+	}
+	return peers
+}
+
+// forget drops the scores of the peers no longer in the peer list
+func (s *peerScores) forget(addresses []string) {
+	current := make(map[string]bool, len(addresses))
+	for _, address := range addresses {
+		current[address] = true
+	}
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	for address := range s.byPeer {
+		if !current[address] {
+			delete(s.byPeer, address)
+		}
+	}
+}
+
+func (s *peerScores) scoreOf(address string) int {
+	if score, found := s.byPeer[address]; found {
+		return score.score
+	}
+	return _initialPeerScore
+}
+
+// report returns the state of the peers, with the sources they were discovered from
+func (s *peerScores) report(addresses []string, sources map[string][]string) []host.P2PPeer {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	now := time.Now()
+	peers := make([]host.P2PPeer, 0, len(addresses))
+	for _, address := range addresses {
+		peer := host.P2PPeer{Address: address, Sources: sources[address], Score: _initialPeerScore, Healthy: true}
+		if score, found := s.byPeer[address]; found {
+			peer.Score = score.score
+			peer.ConsecutiveFailures = score.failures
+			peer.LastSuccess = score.lastSuccess
+			if now.Before(score.backoffUntil) {
+				peer.Healthy = false
+				peer.BackoffUntil = score.backoffUntil
+			}
+		}
+		peers = append(peers, peer)
+	}
+	return peers
+}
+
+// Refreshes the peer list periodically, so the hosts registered on the L1 since, and the changes to the DNS records, are
+// picked up
+func (p *Service) refreshPeers() {
+	ticker := time.NewTicker(_peerRefreshInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !p.running.Load() {
+			return
+		}
+		p.RefreshPeerList()
+	}
+}
+
+// Reconnects to the peers whose backoff is over with a handshake, so they are gossiped to again once they are reachable
+func (p *Service) reconnectPeers() {
+	ticker := time.NewTicker(_peerReconnectInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !p.running.Load() {
+			return
+		}
+		p.peerAddressesMutex.RLock()
+		peers := p.scores.reconnectable(p.peerAddresses)
+		p.peerAddressesMutex.RUnlock()
+		for _, peer := range peers {
+			p.logger.Debug("Reconnecting to peer", "peer", peer)
+			go p.sendVersion(peer, true)
+		}
+	}
+}
+
+// Peers returns the peers of the host, with the sources they were discovered from and their health
+func (p *Service) Peers() []host.P2PPeer {
+	p.peerAddressesMutex.RLock()
+	defer p.peerAddressesMutex.RUnlock()
+	sources := map[string][]string{}
+	for _, peer := range p.l1Peers {
+		sources[peer] = append(sources[peer], host.P2PPeerSourceL1)
+	}
+	for _, peer := range p.bootstrapPeers {
+		sources[peer] = append(sources[peer], host.P2PPeerSourceDNS)
+	}
+	return p.scores.report(p.peerAddresses, sources)
+}
@@ -0,0 +1,55 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/host"
+)
+
+func TestPeerScoresBackOffFromUnreachablePeers(t *testing.T) {
+	scores := newPeerScores()
+	peers := []string{"peer1", "peer2", "peer3"}
+
+	scores.delivered("peer2")
+	scores.failed("peer3")
+	// the best scored peers first, the unreachable one skipped
+	require.Equal(t, []string{"peer2", "peer1"}, scores.healthy(peers))
+	require.Empty(t, scores.reconnectable(peers))
+
+	// the backoff doubles with each consecutive failure
+	scores.failed("peer3")
+	require.WithinDuration(t, time.Now().Add(2*_minPeerBackoff), scores.byPeer["peer3"].backoffUntil, time.Second)
+	for i := 0; i < 20; i++ {
+		scores.failed("peer3")
+	}
+	require.WithinDuration(t, time.Now().Add(_maxPeerBackoff), scores.byPeer["peer3"].backoffUntil, time.Second)
+	require.Zero(t, scores.byPeer["peer3"].score)
+
+	// the peer is reconnected to once its backoff is over, and gossiped to again once it is reachable
+	scores.byPeer["peer3"].backoffUntil = time.Now().Add(-time.Second)
+	require.Equal(t, []string{"peer3"}, scores.reconnectable(peers))
+	scores.delivered("peer3")
+	require.Empty(t, scores.reconnectable(peers))
+	require.Contains(t, scores.healthy(peers), "peer3")
+
+	scores.forget([]string{"peer1", "peer2"})
+	require.NotContains(t, scores.byPeer, "peer3")
+}
+
+func TestReportsThePeerSources(t *testing.T) {
+	p := newTestService(common.Validator, "127.0.0.1:0", nil)
+	p.l1Peers = []string{"sequencer:10000", "validator:10000"}
+	p.bootstrapPeers = []string{"validator:10000", "bootstrap:10000"}
+	p.peerAddresses = mergePeers(p.l1Peers, p.bootstrapPeers, p.ourPublicAddress)
+	p.scores.failed("bootstrap:10000")
+
+	peers := p.Peers()
+	require.Len(t, peers, 3)
+	require.Equal(t, host.P2PPeer{Address: "sequencer:10000", Sources: []string{host.P2PPeerSourceL1}, Score: _initialPeerScore, Healthy: true}, peers[0])
+	require.Equal(t, []string{host.P2PPeerSourceL1, host.P2PPeerSourceDNS}, peers[1].Sources)
+	require.False(t, peers[2].Healthy)
+	require.Equal(t, 1, peers[2].ConsecutiveFailures)
+}
@@ -0,0 +1,71 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// the SRV records of _ten-p2p._tcp.<domain> point to the bootstrap peers
+	dnsSRVService = "ten-p2p"
+	// the TXT records of <domain> of the form "ten-p2p=<host>:<port>" list the bootstrap peers
+	dnsTXTPrefix = "ten-p2p="
+
+	dnsLookupTimeout = 10 * time.Second
+)
+
+// dnsResolver is the subset of net.Resolver used to look up the bootstrap peers
+type dnsResolver interface {
+	LookupSRV(ctx context.Context, service, proto, name string) (string, []*net.SRV, error)
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+}
+
+// lookupBootstrapPeers returns the P2P addresses of the bootstrap peers published in the DNS records of the domain, so
+// operators can rotate the peers without changing the config of every node. Both the SRV and the TXT records are
+// looked up, and the domain must publish at least one of them.
+func lookupBootstrapPeers(ctx context.Context, resolver dnsResolver, domain string) ([]string, error) {
+	ctx, cancel := context.WithTimeout(ctx, dnsLookupTimeout)
+	defer cancel()
+
+	var peers []string
+	_, srvs, srvErr := resolver.LookupSRV(ctx, dnsSRVService, tcp, domain)
+	for _, srv := range srvs {
+		peers = append(peers, net.JoinHostPort(strings.TrimSuffix(srv.Target, "."), strconv.Itoa(int(srv.Port))))
+	}
+
+	txts, txtErr := resolver.LookupTXT(ctx, domain)
+	for _, txt := range txts {
+		if !strings.HasPrefix(txt, dnsTXTPrefix) {
+			continue
+		}
+		address := strings.TrimPrefix(txt, dnsTXTPrefix)
+		if _, _, err := net.SplitHostPort(address); err != nil {
+			return nil, fmt.Errorf("invalid bootstrap peer %s in the TXT records of %s - %w", address, domain, err)
+		}
+		peers = append(peers, address)
+	}
+
+	if srvErr != nil && txtErr != nil {
+		return nil, fmt.Errorf("could not look up the bootstrap peers of %s - SRV: %s, TXT: %w", domain, srvErr, txtErr)
+	}
+	return peers, nil
+}
+
+// mergePeers appends the bootstrap peers not already known to the peers registered on the L1. The order of the L1
+// peers is kept, since the first one is the sequencer. Our own address is excluded.
+func mergePeers(l1Peers []string, bootstrapPeers []string, ourAddress string) []string {
+	merged := make([]string, 0, len(l1Peers)+len(bootstrapPeers))
+	seen := map[string]bool{ourAddress: true}
+	for _, peer := range append(append([]string{}, l1Peers...), bootstrapPeers...) {
+		if seen[peer] {
+			continue
+		}
+		seen[peer] = true
+		merged = append(merged, peer)
+	}
+	return merged
+}
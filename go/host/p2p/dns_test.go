@@ -0,0 +1,131 @@
+package p2p
+
+import (
+	"context"
+	"errors"
+	"net"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/host"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+// the addresses the test hosts were registered at in the management contract, by identity
+var testHostAddresses sync.Map
+
+type testL1Publisher struct {
+	host.L1Publisher
+	peers         []string
+	revoked       map[gethcommon.Address]bool
+	hostAddresses map[gethcommon.Address]string // overrides testHostAddresses
+}
+
+func (l *testL1Publisher) FetchLatestPeersList() ([]string, error) {
+	return l.peers, nil
+}
+
+func (l *testL1Publisher) IsHostAttested(hostID gethcommon.Address) (bool, error) {
+	return !l.revoked[hostID], nil
+}
+
+func (l *testL1Publisher) HostAddressOf(hostID gethcommon.Address) (string, error) {
+	if address, found := l.hostAddresses[hostID]; found {
+		return address, nil
+	}
+	address, _ := testHostAddresses.Load(hostID)
+	hostAddress, _ := address.(string)
+	return hostAddress, nil
+}
+
+type testServiceLocator struct {
+	publisher *testL1Publisher
+}
+
+func (l *testServiceLocator) L1Publisher() host.L1Publisher  { return l.publisher }
+func (l *testServiceLocator) L2Repo() host.L2BatchRepository { return nil }
+
+type testResolver struct {
+	srvs   []*net.SRV
+	txts   []string
+	srvErr error
+	txtErr error
+}
+
+func (r *testResolver) LookupSRV(_ context.Context, service, proto, name string) (string, []*net.SRV, error) {
+	return "_" + service + "._" + proto + "." + name, r.srvs, r.srvErr
+}
+
+func (r *testResolver) LookupTXT(context.Context, string) ([]string, error) {
+	return r.txts, r.txtErr
+}
+
+func TestLookupBootstrapPeers(t *testing.T) {
+	noSuchHost := &net.DNSError{Err: "no such host", IsNotFound: true}
+	tests := []struct {
+		name          string
+		resolver      *testResolver
+		expectedPeers []string
+		expectErr     bool
+	}{
+		{
+			name: "SRV and TXT records",
+			resolver: &testResolver{
+				srvs: []*net.SRV{{Target: "peer1.ten.xyz.", Port: 10000}},
+				txts: []string{"ten-p2p=peer2.ten.xyz:10001", "v=spf1 -all", "ten-p2p=[2001:db8::1]:10002"},
+			},
+			expectedPeers: []string{"peer1.ten.xyz:10000", "peer2.ten.xyz:10001", "[2001:db8::1]:10002"},
+		},
+		{
+			name:          "only TXT records",
+			resolver:      &testResolver{srvErr: noSuchHost, txts: []string{"ten-p2p=peer2.ten.xyz:10001"}},
+			expectedPeers: []string{"peer2.ten.xyz:10001"},
+		},
+		{name: "no records", resolver: &testResolver{srvErr: noSuchHost, txtErr: noSuchHost}, expectErr: true},
+		{name: "invalid TXT record", resolver: &testResolver{txts: []string{"ten-p2p=peer2.ten.xyz"}}, expectErr: true},
+		{name: "records without peers", resolver: &testResolver{txts: []string{"v=spf1 -all"}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			peers, err := lookupBootstrapPeers(context.Background(), tt.resolver, "ten.xyz")
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedPeers, peers)
+		})
+	}
+}
+
+func TestMergePeersKeepsTheL1PeersFirst(t *testing.T) {
+	l1Peers := []string{"sequencer:10000", "validator:10000"}
+	bootstrapPeers := []string{"validator:10000", "us:10000", "bootstrap:10000"}
+
+	merged := mergePeers(l1Peers, bootstrapPeers, "us:10000")
+	require.Equal(t, []string{"sequencer:10000", "validator:10000", "bootstrap:10000"}, merged)
+	require.Equal(t, []string{"sequencer:10000", "validator:10000"}, l1Peers)
+}
+
+func TestRefreshPeerListKeepsTheBootstrapPeersWhenTheLookupFails(t *testing.T) {
+	p := &Service{
+		sl:           &testServiceLocator{publisher: &testL1Publisher{peers: []string{"sequencer:10000"}}},
+		bootstrapDNS: "ten.xyz",
+		resolver:     &testResolver{txts: []string{"ten-p2p=bootstrap:10000"}},
+		versions:     newVersionTracker(gethmetrics.NewRegistry(), gethlog.New()),
+		scores:       newPeerScores(),
+		logger:       gethlog.New(),
+	}
+	p.running.Store(true)
+
+	p.RefreshPeerList()
+	require.Equal(t, []string{"sequencer:10000", "bootstrap:10000"}, p.peerAddresses)
+
+	p.resolver = &testResolver{srvErr: errors.New("timeout"), txtErr: errors.New("timeout")}
+	p.RefreshPeerList()
+	require.Equal(t, []string{"sequencer:10000", "bootstrap:10000"}, p.peerAddresses)
+}
@@ -0,0 +1,159 @@
+package p2p
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/lru"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+// the number of gossiped messages remembered to drop their duplicates
+const _seenMessagesCacheSize = 16384
+
+var errMessageTooLarge = errors.New("message exceeds the size limit")
+
+// floodGuard protects the host from the peers flooding it. The gossiped messages already seen are dropped rather than
+// forwarded to the enclave again, each peer can send a limited number of messages per second, and the peers that
+// repeatedly resend messages, exceed their rate or send oversized or invalid messages are banned for a while.
+type floodGuard struct {
+	maxMsgsPerSecond int           // 0 for no limit
+	banThreshold     int           // the offences within banDuration that get a peer banned (0 never bans)
+	banDuration      time.Duration // the time a peer is banned for, and the window its offences are counted over
+	clock            clock.Clock
+	logger           gethlog.Logger
+
+	duplicates gethmetrics.Counter
+	throttled  gethmetrics.Counter
+	bans       gethmetrics.Counter
+
+	lock  sync.Mutex
+	seen  *lru.Cache[gethcommon.Hash, string] // the hashes of the messages seen, with the peer that sent them first
+	peers map[string]*peerAllowance
+}
+
+type peerAllowance struct {
+	tokens      float64 // the messages the peer can still send right away
+	refilledAt  time.Time
+	offences    []time.Time // the times of the offences within the ban window, oldest first
+	bannedUntil time.Time
+}
+
+func newFloodGuard(maxMsgsPerSecond int, banThreshold int, banDuration time.Duration, clk clock.Clock, registry gethmetrics.Registry, logger gethlog.Logger) *floodGuard {
+	return &floodGuard{
+		maxMsgsPerSecond: maxMsgsPerSecond,
+		banThreshold:     banThreshold,
+		banDuration:      banDuration,
+		clock:            clk,
+		logger:           logger,
+		duplicates:       gethmetrics.GetOrRegisterCounter("host/p2p/flood/duplicates", registry),
+		throttled:        gethmetrics.GetOrRegisterCounter("host/p2p/flood/throttled", registry),
+		bans:             gethmetrics.GetOrRegisterCounter("host/p2p/flood/bans", registry),
+		seen:             lru.NewCache[gethcommon.Hash, string](_seenMessagesCacheSize),
+		peers:            map[string]*peerAllowance{},
+	}
+}
+
+// isBanned returns whether the messages of the peer are rejected
+func (g *floodGuard) isBanned(peer string) bool {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	allowance, found := g.peers[peer]
+	return found && g.clock.Now().Before(allowance.bannedUntil)
+}
+
+// allow returns whether the peer is within its rate, in which case the message is counted against it. Exceeding the
+// rate is an offence
+func (g *floodGuard) allow(peer string) bool {
+	if g.maxMsgsPerSecond <= 0 {
+		return true
+	}
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	now := g.clock.Now()
+	allowance := g.allowance(peer, now)
+
+	// the allowance refills continuously, up to a second worth of messages
+	allowance.tokens += now.Sub(allowance.refilledAt).Seconds() * float64(g.maxMsgsPerSecond)
+	if allowance.tokens > float64(g.maxMsgsPerSecond) {
+		allowance.tokens = float64(g.maxMsgsPerSecond)
+	}
+	allowance.refilledAt = now
+	if allowance.tokens < 1 {
+		g.throttled.Inc(1)
+		g.offend(peer, allowance, now, "rate exceeded")
+		return false
+	}
+	allowance.tokens--
+	return true
+}
+
+// isDuplicate returns whether the message was already seen, in which case it is dropped. A peer resending a message is
+// an offence, while the same message gossiped by different peers is not
+func (g *floodGuard) isDuplicate(peer string, msgType msgType, contents []byte) bool {
+	hash := crypto.Keccak256Hash([]byte{byte(msgType)}, contents)
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	firstSender, seen := g.seen.Get(hash)
+	if !seen {
+		g.seen.Add(hash, peer)
+		return false
+	}
+	g.duplicates.Inc(1)
+	if firstSender == peer {
+		now := g.clock.Now()
+		g.offend(peer, g.allowance(peer, now), now, "duplicate "+msgType.String()+" message")
+	}
+	return true
+}
+
+// offence records an offence of the peer, e.g. an oversized or undecodable message
+func (g *floodGuard) offence(peer string, reason string) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+	now := g.clock.Now()
+	g.offend(peer, g.allowance(peer, now), now, reason)
+}
+
+// records the offence, and bans the peer once it reaches the threshold within the ban window
+func (g *floodGuard) offend(peer string, allowance *peerAllowance, now time.Time, reason string) {
+	if g.banThreshold <= 0 {
+		return
+	}
+	i := 0
+	for i < len(allowance.offences) && now.Sub(allowance.offences[i]) >= g.banDuration {
+		i++
+	}
+	allowance.offences = append(allowance.offences[i:], now)
+	if len(allowance.offences) < g.banThreshold || now.Before(allowance.bannedUntil) {
+		return
+	}
+	allowance.bannedUntil = now.Add(g.banDuration)
+	allowance.offences = nil
+	g.bans.Inc(1)
+	g.logger.Warn("Banned P2P peer for repeated offences", "peer", peer, "reason", reason, "until", allowance.bannedUntil)
+}
+
+// returns the allowance of the peer, forgetting the peers idle for longer than the ban window so the map does not
+// grow with every peer ever seen
+func (g *floodGuard) allowance(peer string, now time.Time) *peerAllowance {
+	allowance, found := g.peers[peer]
+	if found {
+		return allowance
+	}
+	for other, a := range g.peers {
+		idle := len(a.offences) == 0 || now.Sub(a.offences[len(a.offences)-1]) >= g.banDuration
+		if idle && now.Sub(a.refilledAt) >= g.banDuration && now.After(a.bannedUntil) {
+			delete(g.peers, other)
+		}
+	}
+	allowance = &peerAllowance{tokens: float64(g.maxMsgsPerSecond), refilledAt: now}
+	g.peers[peer] = allowance
+	return allowance
+}
@@ -0,0 +1,124 @@
+package p2p
+
+import (
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+	"github.com/ten-protocol/go-ten/go/common/codec"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestFloodGuardBansRepeatOffenders(t *testing.T) {
+	clk := clock.NewFake(time.Now())
+	guard := newFloodGuard(2, 3, time.Hour, clk, gethmetrics.NewRegistry(), gethlog.New())
+
+	// the peer can send two messages per second
+	require.True(t, guard.allow("peer1"))
+	require.True(t, guard.allow("peer1"))
+	require.False(t, guard.allow("peer1"))
+	require.True(t, guard.allow("peer2"))
+	clk.Advance(500 * time.Millisecond)
+	require.True(t, guard.allow("peer1"))
+	require.False(t, guard.allow("peer1"))
+
+	// the peer resending a message is an offence, the same message from another peer is only dropped
+	require.False(t, guard.isDuplicate("peer1", msgTypeTx, []byte("tx")))
+	require.True(t, guard.isDuplicate("peer2", msgTypeTx, []byte("tx")))
+	require.False(t, guard.isBanned("peer1"))
+	require.False(t, guard.isDuplicate("peer1", msgTypeBatches, []byte("tx")))
+	require.True(t, guard.isDuplicate("peer1", msgTypeTx, []byte("tx")))
+
+	// the third offence within the hour bans the peer for an hour
+	require.True(t, guard.isBanned("peer1"))
+	require.False(t, guard.isBanned("peer2"))
+	clk.Advance(time.Hour)
+	require.False(t, guard.isBanned("peer1"))
+
+	// the offences older than the ban window are forgotten
+	guard.offence("peer2", "oversized message")
+	guard.offence("peer2", "oversized message")
+	clk.Advance(time.Hour)
+	guard.offence("peer2", "oversized message")
+	require.False(t, guard.isBanned("peer2"))
+}
+
+func TestFloodGuardForgetsIdlePeers(t *testing.T) {
+	clk := clock.NewFake(time.Now())
+	guard := newFloodGuard(10, 3, time.Minute, clk, gethmetrics.NewRegistry(), gethlog.New())
+	require.True(t, guard.allow("peer1"))
+	clk.Advance(time.Minute)
+	require.True(t, guard.allow("peer2"))
+	require.NotContains(t, guard.peers, "peer1")
+}
+
+// the duplicated live batches are not forwarded, and the peers sending oversized messages are banned
+func TestServiceDropsDuplicatesAndBansOffenders(t *testing.T) {
+	validator := newTestService(common.Validator, "127.0.0.1:0", nil)
+	validator.insecure = true
+	validator.peerAddresses = []string{"sequencer"}
+	validator.maxMessageSize = 1024
+	validator.flood = newFloodGuard(0, 2, time.Hour, clock.New(), gethmetrics.NewRegistry(), gethlog.New())
+	handler := &testBatchHandler{batches: make(chan []*common.ExtBatch, 2)}
+	validator.SubscribeForBatches(handler)
+
+	batch := &common.ExtBatch{Header: &common.BatchHeader{
+		Number: big.NewInt(1), SequencerOrderNo: big.NewInt(1), BaseFee: big.NewInt(1), R: big.NewInt(2), S: big.NewInt(3),
+	}}
+	contents, err := codec.New(codec.RLP).EncodeBatches([]*common.ExtBatch{batch}, true)
+	require.NoError(t, err)
+	encoded, err := rlp.EncodeToBytes(message{Sender: "sequencer", Type: msgTypeBatches, Contents: contents})
+	require.NoError(t, err)
+	send := func(encoded []byte) {
+		sender, receiver := net.Pipe()
+		go func() {
+			_, _ = sender.Write(encoded)
+			_ = sender.Close()
+		}()
+		validator.handle(receiver)
+	}
+
+	send(encoded)
+	send(encoded)
+	select {
+	case <-handler.batches:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the validator did not receive the batch")
+	}
+	select {
+	case <-handler.batches:
+		t.Fatal("the validator received the duplicated batch")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// the duplicate was the first offence, the oversized message the second
+	send(make([]byte, 2048))
+	require.True(t, validator.flood.isBanned("pipe"))
+}
+
+// the authenticated peers are banned by their identity, not by the address they claim
+func TestServiceBansOffendersByIdentity(t *testing.T) {
+	sequencerAddress, validatorAddress := freeAddress(t), freeAddress(t)
+	sequencer := newTestService(common.Sequencer, sequencerAddress, []string{validatorAddress})
+	sequencer.maxMessageSize = 16
+	sequencer.flood = newFloodGuard(0, 1, time.Hour, clock.New(), gethmetrics.NewRegistry(), gethlog.New())
+	require.NoError(t, sequencer.Start())
+	defer sequencer.Stop() //nolint:errcheck
+
+	validator := newTestService(common.Validator, validatorAddress, []string{sequencerAddress})
+	validator.running.Store(true)
+	validator.RefreshPeerList()
+	require.NoError(t, validator.SendTxToSequencer(make(common.EncryptedTx, 1024)))
+
+	identity := crypto.PubkeyToAddress(validator.identityKey.PublicKey)
+	require.Eventually(t, func() bool { return sequencer.flood.isBanned(identity.Hex()) }, 5*time.Second, 10*time.Millisecond)
+	require.False(t, sequencer.flood.isBanned(validatorAddress))
+}
@@ -0,0 +1,65 @@
+package p2p
+
+import (
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/p2p/nat"
+)
+
+const natMappingName = "ten p2p"
+
+// ResolvePublicAddress returns the P2P address advertised to the other hosts. The configured public address is used as
+// is, unless its host is empty: the external address of the router is then discovered with the NAT mechanism, and the
+// port defaults to the port the P2P server is bound to.
+func ResolvePublicAddress(natm nat.Interface, bindAddress string, publicAddress string) (string, error) {
+	publicHost, publicPort := "", ""
+	if publicAddress != "" {
+		var err error
+		publicHost, publicPort, err = net.SplitHostPort(publicAddress)
+		if err != nil {
+			return "", fmt.Errorf("invalid P2P public address %s - %w", publicAddress, err)
+		}
+	}
+	if publicHost != "" {
+		return publicAddress, nil
+	}
+
+	if natm == nil {
+		return "", fmt.Errorf("the P2P public address has no host, and no NAT mechanism is configured to discover it")
+	}
+	externalIP, err := natm.ExternalIP()
+	if err != nil {
+		return "", fmt.Errorf("could not discover the external address with %s - %w", natm, err)
+	}
+
+	if publicPort == "" {
+		_, bindPort, err := net.SplitHostPort(bindAddress)
+		if err != nil {
+			return "", fmt.Errorf("invalid P2P bind address %s - %w", bindAddress, err)
+		}
+		publicPort = bindPort
+	}
+	return net.JoinHostPort(externalIP.String(), publicPort), nil
+}
+
+// Maps the port of the public address to the port the P2P server listens on, on the router of the host, and keeps the
+// mapping alive until the service is stopped
+func (p *Service) mapPort() {
+	_, publicPort, err := net.SplitHostPort(p.ourPublicAddress)
+	if err != nil {
+		p.logger.Warn("could not map the P2P port - invalid public address", "publicAddress", p.ourPublicAddress)
+		return
+	}
+	extPort, err := strconv.Atoi(publicPort)
+	if err != nil {
+		p.logger.Warn("could not map the P2P port - invalid public port", "publicAddress", p.ourPublicAddress)
+		return
+	}
+	intPort := p.listener.Addr().(*net.TCPAddr).Port
+
+	p.logger.Info("Mapping the P2P port on the router", "nat", p.nat, "externalPort", extPort, "internalPort", intPort)
+	// blocks until the channel is closed, then deletes the mapping
+	nat.Map(p.nat, p.natStop, tcp, extPort, intPort, natMappingName)
+}
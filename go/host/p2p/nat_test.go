@@ -0,0 +1,37 @@
+package p2p
+
+import (
+	"net"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/p2p/nat"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolvePublicAddress(t *testing.T) {
+	extIP := nat.ExtIP(net.ParseIP("77.12.33.4"))
+	tests := []struct {
+		name            string
+		natm            nat.Interface
+		publicAddress   string
+		expectedAddress string
+	}{
+		{name: "configured address", natm: extIP, publicAddress: "10.0.0.1:10000", expectedAddress: "10.0.0.1:10000"},
+		{name: "configured address without NAT", publicAddress: "10.0.0.1:10000", expectedAddress: "10.0.0.1:10000"},
+		{name: "discovered host", natm: extIP, publicAddress: ":20000", expectedAddress: "77.12.33.4:20000"},
+		{name: "discovered host and bind port", natm: extIP, publicAddress: "", expectedAddress: "77.12.33.4:10000"},
+		{name: "no host without NAT", publicAddress: ":20000"},
+		{name: "invalid address", natm: extIP, publicAddress: "10.0.0.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			address, err := ResolvePublicAddress(tt.natm, "0.0.0.0:10000", tt.publicAddress)
+			if tt.expectedAddress == "" {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expectedAddress, address)
+		})
+	}
+}
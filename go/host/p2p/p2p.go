@@ -1,8 +1,9 @@
 package p2p
 
 import (
+	"context"
+	"crypto/ecdsa"
 	"fmt"
-	"io"
 	"math/big"
 	"net"
 	"sync"
@@ -12,6 +13,8 @@ import (
 	"github.com/ten-protocol/go-ten/go/enclave/core"
 
 	"github.com/pkg/errors"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+	"github.com/ten-protocol/go-ten/go/common/codec"
 	"github.com/ten-protocol/go-ten/go/common/measure"
 	"github.com/ten-protocol/go-ten/go/common/retry"
 	"github.com/ten-protocol/go-ten/go/common/subscription"
@@ -24,6 +27,7 @@ import (
 
 	gethlog "github.com/ethereum/go-ethereum/log"
 	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+	"github.com/ethereum/go-ethereum/p2p/nat"
 )
 
 const (
@@ -32,19 +36,36 @@ const (
 	msgTypeTx msgType = iota
 	msgTypeBatches
 	msgTypeBatchRequest
+	msgTypeVersion
 )
 
 var (
 	_alertPeriod        = 5 * time.Minute
+	_flushPollInterval  = 50 * time.Millisecond
 	errUnknownSequencer = errors.New("sequencer address not known")
 )
 
 // A P2P message's type.
 type msgType uint8
 
+func (t msgType) String() string {
+	switch t {
+	case msgTypeTx:
+		return "tx"
+	case msgTypeBatches:
+		return "batches"
+	case msgTypeBatchRequest:
+		return "batchRequest"
+	case msgTypeVersion:
+		return "version"
+	default:
+		return "unknown"
+	}
+}
+
 // Associates an encoded message to its type.
 type message struct {
-	Sender   string // todo (#1619) - this needs to be authed in the future
+	Sender   string // the registered address of the sender, checked against the one it authenticated as
 	Type     msgType
 	Contents []byte
 }
@@ -54,8 +75,9 @@ type p2pServiceLocator interface {
 	L2Repo() host.L2BatchRepository
 }
 
-// NewSocketP2PLayer - returns the Socket implementation of the P2P
-func NewSocketP2PLayer(config *config.HostConfig, serviceLocator p2pServiceLocator, logger gethlog.Logger, metricReg gethmetrics.Registry) *Service {
+// NewSocketP2PLayer - returns the Socket implementation of the P2P. The NAT mechanism, if any, maps the P2P port on the
+// router of the host. The identity key is the one of the host's L1 wallet, authenticating the host to its peers
+func NewSocketP2PLayer(config *config.HostConfig, natm nat.Interface, serviceLocator p2pServiceLocator, identityKey *ecdsa.PrivateKey, logger gethlog.Logger, metricReg gethmetrics.Registry) *Service {
 	return &Service{
 		batchSubscribers: subscription.NewManager[host.P2PBatchHandler](),
 		txSubscribers:    subscription.NewManager[host.P2PTxHandler](),
@@ -68,11 +90,26 @@ func NewSocketP2PLayer(config *config.HostConfig, serviceLocator p2pServiceLocat
 		ourPublicAddress: config.P2PPublicAddress,
 		peerAddresses:    []string{},
 		p2pTimeout:       config.P2PConnectionTimeout,
+		nat:              natm,
+		natStop:          make(chan struct{}),
+		bootstrapDNS:     config.P2PBootstrapDNS,
+		resolver:         net.DefaultResolver,
+		batchCodec:       codec.New(config.BatchCodec),
+		identityKey:      identityKey,
+		insecure:         config.P2PInsecure,
+		attestations:     newAttestationCache(fetchAttestation(serviceLocator.L1Publisher), logger),
+		maxMessageSize:   config.P2PMaxMessageSize,
+		flood:            newFloodGuard(config.P2PMaxPeerMsgsPerSecond, config.P2PBanThreshold, config.P2PBanDuration, clock.New(), metricReg, logger),
+		batchVerifier:    &batchVerifier{},
 
 		peerAddressesMutex: sync.RWMutex{},
 
 		// monitoring
 		peerTracker:     newPeerTracker(),
+		scores:          newPeerScores(),
+		versions:        newVersionTracker(metricReg, logger),
+		bandwidth:       newBandwidthTracker(metricReg),
+		rejectedPeers:   gethmetrics.GetOrRegisterCounter("host/p2p/handshake/rejected", metricReg),
 		metricsRegistry: metricReg,
 		logger:          logger,
 
@@ -85,8 +122,9 @@ type Service struct {
 	txSubscribers    *subscription.Manager[host.P2PTxHandler]
 	batchReqHandlers *subscription.Manager[host.P2PBatchRequestHandler]
 
-	listener net.Listener
-	running  atomic.Bool // new connections won't be accepted if this is false
+	listener     net.Listener
+	running      atomic.Bool  // new connections won't be accepted if this is false
+	pendingSends atomic.Int64 // the broadcasts still being delivered to peers, flushed when the host is drained
 
 	sl p2pServiceLocator
 
@@ -95,8 +133,29 @@ type Service struct {
 	ourPublicAddress string
 	peerAddresses    []string
 	p2pTimeout       time.Duration
+	nat              nat.Interface // maps the P2P port on the router, nil if the host is not behind NAT
+	natStop          chan struct{} // closed to delete the port mapping
+	bootstrapDNS     string        // the domain publishing the bootstrap peers, if any
+	resolver         dnsResolver
+	l1Peers          []string    // the peers last fetched from the management contract
+	bootstrapPeers   []string    // the peers last looked up in the DNS records of bootstrapDNS
+	batchCodec       codec.Codec // encodes the batches sent, the ones received are decoded with the codec of the sender
+	identityKey      *ecdsa.PrivateKey
+	insecure         bool // the connections are neither authenticated nor encrypted, for the local testnets only
+	attestations     *attestationCache
+	maxMessageSize   int // the size of the largest message accepted from a peer (0 for no limit)
+	flood            *floodGuard
+	batchVerifier    *batchVerifier // verifies the batches served by the peers other than the sequencer
+	batchSourceIdx   atomic.Uint64  // the peers take turns to serve the batch requests of the host
+
+	unknownPeerRefreshMutex sync.Mutex
+	lastUnknownPeerRefresh  time.Time // the last refresh of the peer list for a peer claiming an unknown address
 
 	peerTracker           *peerTracker
+	scores                *peerScores     // the health of the peers, the gossip skips the unreachable ones
+	versions              *versionTracker // the protocol versions of the peers
+	bandwidth             *bandwidthTracker
+	rejectedPeers         gethmetrics.Counter // the peers rejected in the handshake, as unregistered or not attested
 	metricsRegistry       gethmetrics.Registry
 	logger                gethlog.Logger
 	peerAddressesMutex    sync.RWMutex
@@ -104,10 +163,14 @@ type Service struct {
 }
 
 func (p *Service) Start() error {
+	if p.identityKey == nil && !p.insecure {
+		return errors.New("the P2P connections cannot be authenticated without an identity key")
+	}
 	p.running.Store(true)
 
 	if p.isIncomingP2PDisabled {
 		go p.RefreshPeerList()
+		go p.refreshPeers()
 		return nil
 	}
 
@@ -123,8 +186,15 @@ func (p *Service) Start() error {
 
 	go p.handleConnections()
 
+	if p.nat != nil {
+		go p.mapPort()
+	}
+
 	// ensure we have re-synced the peer list from management contract after startup
 	go p.RefreshPeerList()
+	go p.refreshPeers()
+	go p.reconnectPeers()
+	go p.gossipVersion()
 
 	return nil
 }
@@ -132,6 +202,9 @@ func (p *Service) Start() error {
 func (p *Service) Stop() error {
 	p.logger.Info("Shutting down P2P.")
 	p.running.Store(false)
+	if p.nat != nil {
+		close(p.natStop)
+	}
 	if p.listener != nil {
 		// todo immediately shutting down the listener seems to impact other hosts shutdown process
 		time.Sleep(time.Second)
@@ -168,7 +241,8 @@ func (p *Service) SubscribeForBatchRequests(handler host.P2PBatchRequestHandler)
 	return p.batchReqHandlers.Subscribe(handler)
 }
 
-// RefreshPeerList - fetches the latest peer list from L1 and updates the peerAddresses.
+// RefreshPeerList - fetches the latest peer list from L1, adds the bootstrap peers published in the DNS records if any,
+// and updates the peerAddresses.
 // Note: this is designed to be run in a separate goroutine, it will retry a few times before giving up.
 func (p *Service) RefreshPeerList() {
 	var newPeers []string
@@ -190,10 +264,33 @@ func (p *Service) RefreshPeerList() {
 		return
 	}
 
+	var bootstrapPeers []string
+	bootstrapPeersFound := false
+	if p.bootstrapDNS != "" {
+		bootstrapPeers, err = lookupBootstrapPeers(context.Background(), p.resolver, p.bootstrapDNS)
+		if err != nil {
+			p.logger.Warn("unable to look up the bootstrap peers - keeping the previous ones", "domain", p.bootstrapDNS, log.ErrKey, err)
+		}
+		bootstrapPeersFound = err == nil
+	}
+
 	p.peerAddressesMutex.Lock()
 	defer p.peerAddressesMutex.Unlock()
+	if bootstrapPeersFound {
+		p.bootstrapPeers = bootstrapPeers
+	}
+	p.l1Peers = newPeers
+	newPeers = mergePeers(newPeers, p.bootstrapPeers, p.ourPublicAddress)
 	p.logger.Info(fmt.Sprintf("Updated peer list - old: %s new: %s", p.peerAddresses, newPeers))
 	p.peerAddresses = newPeers
+	p.scores.forget(newPeers)
+
+	// the new peers are sent our protocol version as a handshake, and answer with theirs
+	for _, peer := range newPeers {
+		if !p.versions.knows(peer) {
+			go p.sendVersion(peer, true)
+		}
+	}
 }
 
 func (p *Service) SendTxToSequencer(tx common.EncryptedTx) error {
@@ -215,21 +312,16 @@ func (p *Service) BroadcastBatches(batches []*common.ExtBatch) error {
 	if !p.isSequencer {
 		return errors.New("only sequencer can broadcast batches")
 	}
-	batchMsg := host.BatchMsg{
-		Batches: batches,
-		IsLive:  true,
-	}
-
-	encodedBatchMsg, err := rlp.EncodeToBytes(batchMsg)
+	encodedBatchMsg, err := p.batchCodec.EncodeBatches(batches, true)
 	if err != nil {
-		return fmt.Errorf("could not encode batch using RLP. Cause: %w", err)
+		return fmt.Errorf("could not encode batch using %s. Cause: %w", p.batchCodec.Type(), err)
 	}
 
 	msg := message{Sender: p.ourPublicAddress, Type: msgTypeBatches, Contents: encodedBatchMsg}
 	return p.broadcast(msg)
 }
 
-func (p *Service) RequestBatchesFromSequencer(fromSeqNo *big.Int) error {
+func (p *Service) RequestBatches(fromSeqNo *big.Int, toSeqNo *big.Int) error {
 	if p.isIncomingP2PDisabled {
 		return nil
 	}
@@ -239,8 +331,8 @@ func (p *Service) RequestBatchesFromSequencer(fromSeqNo *big.Int) error {
 	batchRequest := &common.BatchRequest{
 		Requester: p.ourPublicAddress,
 		FromSeqNo: fromSeqNo,
+		ToSeqNo:   toSeqNo,
 	}
-	defer core.LogMethodDuration(p.logger, measure.NewStopwatch(), "Requested batches from sequencer", "fromSeqNo", batchRequest.FromSeqNo)
 
 	encodedBatchRequest, err := rlp.EncodeToBytes(batchRequest)
 	if err != nil {
@@ -248,29 +340,21 @@ func (p *Service) RequestBatchesFromSequencer(fromSeqNo *big.Int) error {
 	}
 
 	msg := message{Sender: p.ourPublicAddress, Type: msgTypeBatchRequest, Contents: encodedBatchRequest}
-	// todo (#718) - allow missing batches to be requested from peers other than sequencer?
-	sequencer, err := p.getSequencer()
+	peer, err := p.nextBatchSource()
 	if err != nil {
-		return fmt.Errorf("failed to find sequencer - %w", err)
+		return fmt.Errorf("failed to find peer to request batches from - %w", err)
 	}
-	return p.send(msg, sequencer)
+	defer core.LogMethodDuration(p.logger, measure.NewStopwatch(), "Requested batches from peer", "peer", peer, "fromSeqNo", fromSeqNo, "toSeqNo", toSeqNo)
+	return p.send(msg, peer)
 }
 
 func (p *Service) RespondToBatchRequest(requestID string, batches []*common.ExtBatch) error {
 	if p.isIncomingP2PDisabled {
 		return nil
 	}
-	if !p.isSequencer {
-		return errors.New("only sequencer can respond to batch requests")
-	}
-	batchMsg := &host.BatchMsg{
-		Batches: batches,
-		IsLive:  false,
-	}
-
-	encodedBatchMsg, err := rlp.EncodeToBytes(batchMsg)
+	encodedBatchMsg, err := p.batchCodec.EncodeBatches(batches, false)
 	if err != nil {
-		return fmt.Errorf("could not encode batches using RLP. Cause: %w", err)
+		return fmt.Errorf("could not encode batches using %s. Cause: %w", p.batchCodec.Type(), err)
 	}
 
 	msg := message{Sender: p.ourPublicAddress, Type: msgTypeBatches, Contents: encodedBatchMsg}
@@ -323,16 +407,44 @@ func (p *Service) handle(conn net.Conn) {
 		defer conn.Close()
 	}
 
-	encodedMsg, err := io.ReadAll(conn)
+	s, err := p.acceptHandshake(conn)
 	if err != nil {
-		p.logger.Debug("Failed to read message from peer", log.ErrKey, err)
+		p.logger.Debug("Rejected connection from peer", "remoteAddress", conn.RemoteAddr(), log.ErrKey, err)
+		return
+	}
+	// the flood guard accounts for the peers by their attested identity rather than by the address they claim, or by their
+	// remote host when they are not authenticated
+	peer := s.identity.Hex()
+	if p.insecure {
+		peer = remoteHost(conn)
+	}
+	if p.flood.isBanned(peer) {
+		p.logger.Debug("Rejected connection from banned peer", "peer", peer, "address", s.peer)
+		return
+	}
+
+	encodedMsg, err := s.readAll(p.maxMessageSize)
+	if err != nil {
+		if errors.Is(err, errMessageTooLarge) {
+			p.flood.offence(peer, "oversized message")
+		}
+		p.logger.Debug("Failed to read message from peer", "peer", peer, log.ErrKey, err)
 		return
 	}
 
 	msg := message{}
 	err = rlp.DecodeBytes(encodedMsg, &msg)
 	if err != nil {
-		p.logger.Debug("Failed to decode message received from peer: ", log.ErrKey, err)
+		p.flood.offence(peer, "undecodable message")
+		p.logger.Debug("Failed to decode message received from peer: ", "peer", peer, log.ErrKey, err)
+		return
+	}
+	if !p.insecure && msg.Sender != s.peer {
+		p.logger.Warn("Peer sent a message on behalf of another peer", "peer", s.peer, "sender", msg.Sender, "identity", s.identity)
+		return
+	}
+	if !p.flood.allow(peer) {
+		p.logger.Debug("Dropped message of peer over its rate limit", "peer", peer, "type", msg.Type)
 		return
 	}
 
@@ -342,6 +454,9 @@ func (p *Service) handle(conn net.Conn) {
 			p.logger.Error("Received transaction from peer, but not a sequencer node")
 			return
 		}
+		if p.flood.isDuplicate(peer, msg.Type, msg.Contents) {
+			return
+		}
 		// The transaction is encrypted, so we cannot check that it's correctly formed.
 		for _, txSubs := range p.txSubscribers.Subscribers() {
 			txSubs.HandleTransaction(msg.Contents)
@@ -351,26 +466,47 @@ func (p *Service) handle(conn net.Conn) {
 			p.logger.Error("received batch from peer, but this is a sequencer node")
 			return
 		}
-		var batchMsg *host.BatchMsg
-		err := rlp.DecodeBytes(msg.Contents, &batchMsg)
+		batches, isLive, err := codec.Detect(msg.Contents).DecodeBatches(msg.Contents)
 		if err != nil {
+			p.flood.offence(peer, "undecodable batches")
 			p.logger.Warn("unable to decode batch received from peer", log.ErrKey, err)
 			// nothing to send to subscribers
 			break
 		}
-		// todo - check the batch signature
+		// the batches sent in response to our requests may legitimately be sent again, e.g. when requested again
+		if isLive && p.flood.isDuplicate(peer, msg.Type, msg.Contents) {
+			return
+		}
+		// the batches of the sequencer teach the host its key, the ones served by the other peers are checked against it
+		if sequencer, _ := p.getSequencer(); msg.Sender == sequencer {
+			p.batchVerifier.learn(batches)
+		} else if err = p.batchVerifier.verify(batches); err != nil {
+			if errors.Is(err, errInvalidBatchSig) {
+				p.flood.offence(peer, "batch not signed by the sequencer")
+			}
+			p.logger.Warn("Dropped batches received from peer", "peer", peer, log.ErrKey, err)
+			break
+		}
 		for _, batchSubs := range p.batchSubscribers.Subscribers() {
-			go batchSubs.HandleBatches(batchMsg.Batches, batchMsg.IsLive)
+			go batchSubs.HandleBatches(batches, isLive)
 		}
 	case msgTypeBatchRequest:
-		if !p.isSequencer {
-			p.logger.Error("received batch request from peer, but not a sequencer node")
-			return
-		}
 		// this is an incoming request, p2p service is responsible for finding the response and returning it
 		go p.handleBatchRequest(msg.Contents)
+	case msgTypeVersion:
+		p.handleVersion(msg.Sender, msg.Contents)
 	}
 	p.peerTracker.receivedPeerMsg(msg.Sender)
+	p.bandwidth.received(msg.Sender, msg.Type, len(encodedMsg))
+}
+
+// returns the host of the remote address of the connection
+func remoteHost(conn net.Conn) string {
+	remote := conn.RemoteAddr().String()
+	if host, _, err := net.SplitHostPort(remote); err == nil {
+		return host
+	}
+	return remote
 }
 
 // Broadcasts a message to all peers.
@@ -380,21 +516,35 @@ func (p *Service) broadcast(msg message) error {
 		return fmt.Errorf("could not encode message to send to peers. Cause: %w", err)
 	}
 
-	// clone current known addresses
+	// the current known addresses, skipping the peers that could not be reached recently
 	p.peerAddressesMutex.RLock()
-	currentAddresses := make([]string, len(p.peerAddresses))
-	copy(currentAddresses, p.peerAddresses)
+	currentAddresses := p.scores.healthy(p.peerAddresses)
 	p.peerAddressesMutex.RUnlock()
 
+	// the gossip round lasts until the message has been delivered to all the peers, or the delivery has failed
+	start := time.Now()
+	var round sync.WaitGroup
+	var failures atomic.Int64
 	for _, address := range currentAddresses {
 		closureAddr := address
+		p.pendingSends.Add(1)
+		round.Add(1)
 		go func() {
+			defer p.pendingSends.Add(-1)
+			defer round.Done()
 			err := p.sendBytesWithRetry(closureAddr, msgEncoded)
 			if err != nil {
+				failures.Add(1)
 				p.logger.Debug("Could not send message to peer", "peer", closureAddr, log.ErrKey, err)
+				return
 			}
+			p.bandwidth.sent(closureAddr, msg.Type, len(msgEncoded))
 		}()
 	}
+	go func() {
+		round.Wait()
+		p.bandwidth.broadcastRound(msg.Type, time.Since(start), failures.Load())
+	}()
 
 	return nil
 }
@@ -402,7 +552,7 @@ func (p *Service) broadcast(msg message) error {
 // Sends a message to the provided address.
 func (p *Service) send(msg message, to string) error {
 	// sanity check the message to discover bugs
-	if !(msg.Type >= 1 && msg.Type <= 3) {
+	if !(msg.Type >= msgTypeTx && msg.Type <= msgTypeVersion) {
 		p.logger.Error(fmt.Sprintf("Sending message with wrong message type: %v", msg))
 	}
 	if len(msg.Sender) == 0 {
@@ -420,9 +570,39 @@ func (p *Service) send(msg message, to string) error {
 	if err != nil {
 		return err
 	}
+	p.bandwidth.sent(to, msg.Type, len(msgEncoded))
 	return nil
 }
 
+// PeerVersions returns the protocol versions of the peers, with a warning when a significant fraction of them run a newer
+// version than the host
+func (p *Service) PeerVersions() *host.P2PPeerVersions {
+	return p.versions.versions()
+}
+
+// Bandwidth returns the data exchanged with each peer, and of each message type, since the host started
+func (p *Service) Bandwidth() *host.P2PBandwidth {
+	return p.bandwidth.bandwidth()
+}
+
+// FlushPendingSends waits for the broadcasts in progress to be delivered, so that the batches and transactions sent
+// just before shutting down are not lost, or for the context to be done
+func (p *Service) FlushPendingSends(ctx context.Context) error {
+	ticker := time.NewTicker(_flushPollInterval)
+	defer ticker.Stop()
+	for {
+		pending := p.pendingSends.Load()
+		if pending == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d P2P messages not delivered to peers - %w", pending, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
 // Sends the bytes to the provided address.
 // Until introducing libp2p (or equivalent), we have a simple retry
 func (p *Service) sendBytesWithRetry(address string, msgEncoded []byte) error {
@@ -430,21 +610,24 @@ func (p *Service) sendBytesWithRetry(address string, msgEncoded []byte) error {
 	err := retry.Do(func() error {
 		return p.sendBytes(address, msgEncoded)
 	}, retry.NewDoublingBackoffStrategy(100*time.Millisecond, 5))
-	return err
+	if err != nil {
+		p.scores.failed(address)
+		return err
+	}
+	p.scores.delivered(address)
+	return nil
 }
 
 // Sends the bytes to the provided address.
 func (p *Service) sendBytes(address string, tx []byte) error {
-	conn, err := net.DialTimeout(tcp, address, p.p2pTimeout)
-	if conn != nil {
-		defer conn.Close()
-	}
+	s, err := p.dial(address)
 	if err != nil {
 		p.logger.Debug(fmt.Sprintf("could not connect to peer on address %s", address), log.ErrKey, err)
 		return err
 	}
+	defer s.conn.Close()
 
-	_, err = conn.Write(tx)
+	err = s.write(tx)
 	if err != nil {
 		p.logger.Debug(fmt.Sprintf("could not send message to peer on address %s", address), log.ErrKey, err)
 		return err
@@ -464,6 +647,28 @@ func (p *Service) getSequencer() (string, error) {
 	return p.peerAddresses[0], nil
 }
 
+// returns the peer to request the next batches from. The healthy peers take turns once the key of the sequencer is known
+// to verify the batches they serve, until then the batches are requested from the sequencer
+func (p *Service) nextBatchSource() (string, error) {
+	if !p.batchVerifier.knowsKey() {
+		return p.getSequencer()
+	}
+	p.peerAddressesMutex.RLock()
+	peers := p.scores.healthy(p.peerAddresses)
+	p.peerAddressesMutex.RUnlock()
+
+	sources := make([]string, 0, len(peers))
+	for _, peer := range peers {
+		if peer != p.ourPublicAddress {
+			sources = append(sources, peer)
+		}
+	}
+	if len(sources) == 0 {
+		return p.getSequencer()
+	}
+	return sources[(p.batchSourceIdx.Add(1)-1)%uint64(len(sources))], nil
+}
+
 func (p *Service) handleBatchRequest(encodedBatchRequest common.EncodedBatchRequest) {
 	var batchRequest *common.BatchRequest
 	err := rlp.DecodeBytes(encodedBatchRequest, &batchRequest)
@@ -474,6 +679,58 @@ func (p *Service) handleBatchRequest(encodedBatchRequest common.EncodedBatchRequ
 
 	// todo (@matt) should this response be synchronous?
 	for _, requestHandler := range p.batchReqHandlers.Subscribers() {
-		go requestHandler.HandleBatchRequest(batchRequest.Requester, batchRequest.FromSeqNo)
+		go requestHandler.HandleBatchRequest(batchRequest.Requester, batchRequest.FromSeqNo, batchRequest.ToSeqNo)
+	}
+}
+
+// gossips the protocol version of the host to its peers periodically, until the service is stopped
+func (p *Service) gossipVersion() {
+	ticker := time.NewTicker(_versionGossipInterval)
+	defer ticker.Stop()
+	for range ticker.C {
+		if !p.running.Load() {
+			return
+		}
+		msg, err := newVersionMessage(p.ourPublicAddress, false)
+		if err != nil {
+			p.logger.Error("Could not encode the version message", log.ErrKey, err)
+			continue
+		}
+		if err = p.broadcast(msg); err != nil {
+			p.logger.Warn("Could not gossip the protocol version", log.ErrKey, err)
+		}
+	}
+}
+
+// sends the protocol version of the host to a peer, asking for its version in return for a handshake
+func (p *Service) sendVersion(peer string, wantsReply bool) {
+	msg, err := newVersionMessage(p.ourPublicAddress, wantsReply)
+	if err != nil {
+		p.logger.Error("Could not encode the version message", log.ErrKey, err)
+		return
+	}
+	if err = p.send(msg, peer); err != nil {
+		p.logger.Debug("Could not send the protocol version to peer", "peer", peer, log.ErrKey, err)
+	}
+}
+
+// records the protocol version of a peer, and answers its handshake
+func (p *Service) handleVersion(sender string, encodedVersion []byte) {
+	var version versionMsg
+	if err := rlp.DecodeBytes(encodedVersion, &version); err != nil {
+		p.logger.Warn("unable to decode version message received from peer", "peer", sender, log.ErrKey, err)
+		return
+	}
+	p.versions.record(sender, version.ProtocolVersion)
+	if version.WantsReply {
+		go p.sendVersion(sender, false)
+	}
+}
+
+func newVersionMessage(sender string, wantsReply bool) (message, error) {
+	encodedVersion, err := rlp.EncodeToBytes(&versionMsg{ProtocolVersion: protocolVersion, WantsReply: wantsReply})
+	if err != nil {
+		return message{}, err
 	}
+	return message{Sender: sender, Type: msgTypeVersion, Contents: encodedVersion}, nil
 }
@@ -0,0 +1,132 @@
+package p2p
+
+import (
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/codec"
+	"github.com/ten-protocol/go-ten/go/config"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+type testTxHandler struct {
+	txs chan common.EncryptedTx
+}
+
+func (h *testTxHandler) HandleTransaction(tx common.EncryptedTx) {
+	h.txs <- tx
+}
+
+func newTestService(nodeType common.NodeType, bindAddress string, peers []string) *Service {
+	cfg := &config.HostConfig{NodeType: nodeType, P2PBindAddress: bindAddress, P2PPublicAddress: bindAddress, P2PConnectionTimeout: time.Second}
+	sl := &testServiceLocator{publisher: &testL1Publisher{peers: peers}}
+	identityKey, err := crypto.GenerateKey()
+	if err != nil {
+		panic(err)
+	}
+	testHostAddresses.Store(crypto.PubkeyToAddress(identityKey.PublicKey), bindAddress)
+	return NewSocketP2PLayer(cfg, nil, sl, identityKey, gethlog.New(), gethmetrics.NewRegistry())
+}
+
+func TestSendsMessagesOverIPv6(t *testing.T) {
+	listener, err := net.Listen(tcp, "[::1]:0")
+	if err != nil {
+		t.Skip("IPv6 is not available", err)
+	}
+	sequencerAddress := listener.Addr().String()
+	require.NoError(t, listener.Close())
+
+	validatorAddress := "[::1]:20000"
+	sequencer := newTestService(common.Sequencer, sequencerAddress, []string{validatorAddress})
+	handler := &testTxHandler{txs: make(chan common.EncryptedTx, 1)}
+	sequencer.SubscribeForTx(handler)
+	require.NoError(t, sequencer.Start())
+	defer sequencer.Stop() //nolint:errcheck
+
+	validator := newTestService(common.Validator, validatorAddress, []string{sequencerAddress})
+	validator.running.Store(true)
+	validator.RefreshPeerList()
+	require.NoError(t, validator.SendTxToSequencer(common.EncryptedTx("tx")))
+
+	select {
+	case tx := <-handler.txs:
+		require.Equal(t, common.EncryptedTx("tx"), tx)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the sequencer did not receive the transaction")
+	}
+}
+
+type testBatchHandler struct {
+	batches chan []*common.ExtBatch
+}
+
+func (h *testBatchHandler) HandleBatches(batches []*common.ExtBatch, _ bool) {
+	h.batches <- batches
+}
+
+// the validators decode the batches in the format the sequencer sent them, whatever their own batch codec
+func TestReceivesBatchesOfAnyCodec(t *testing.T) {
+	validator := newTestService(common.Validator, "127.0.0.1:0", nil)
+	validator.insecure = true
+	validator.peerAddresses = []string{"sequencer"}
+	handler := &testBatchHandler{batches: make(chan []*common.ExtBatch, 1)}
+	validator.SubscribeForBatches(handler)
+
+	batch := &common.ExtBatch{Header: &common.BatchHeader{
+		Number: big.NewInt(1), SequencerOrderNo: big.NewInt(1), BaseFee: big.NewInt(1), R: big.NewInt(2), S: big.NewInt(3),
+	}}
+	for _, codecType := range []codec.Type{codec.RLP, codec.Protobuf} {
+		contents, err := codec.New(codecType).EncodeBatches([]*common.ExtBatch{batch}, true)
+		require.NoError(t, err)
+		encoded, err := rlp.EncodeToBytes(message{Sender: "sequencer", Type: msgTypeBatches, Contents: contents})
+		require.NoError(t, err)
+
+		sender, receiver := net.Pipe()
+		go func() {
+			_, _ = sender.Write(encoded)
+			_ = sender.Close()
+		}()
+		validator.handle(receiver)
+
+		select {
+		case received := <-handler.batches:
+			require.Len(t, received, 1)
+			require.Equal(t, batch.Hash(), received[0].Hash())
+		case <-time.After(5 * time.Second):
+			t.Fatalf("the validator did not receive the %s batch", codecType)
+		}
+	}
+}
+
+// a host joining the peer list of another host learns its protocol version through the handshake
+func TestExchangesProtocolVersions(t *testing.T) {
+	sequencerAddress, validatorAddress := freeAddress(t), freeAddress(t)
+	sequencer := newTestService(common.Sequencer, sequencerAddress, []string{validatorAddress})
+	require.NoError(t, sequencer.Start())
+	defer sequencer.Stop() //nolint:errcheck
+
+	validator := newTestService(common.Validator, validatorAddress, []string{sequencerAddress})
+	require.NoError(t, validator.Start())
+	defer validator.Stop() //nolint:errcheck
+
+	require.Eventually(t, func() bool {
+		return validator.versions.knows(sequencerAddress) && sequencer.versions.knows(validatorAddress)
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, protocolVersion, validator.PeerVersions().Peers[sequencerAddress].ProtocolVersion)
+	require.Empty(t, validator.PeerVersions().Warning)
+}
+
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen(tcp, "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return listener.Addr().String()
+}
@@ -0,0 +1,432 @@
+package p2p
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdh"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/rlp"
+	"golang.org/x/crypto/hkdf"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ten-protocol/go-ten/go/common/host"
+)
+
+const (
+	// the domain of the signatures of the handshakes and of the derivation of the session keys
+	_handshakeDomain = "ten-p2p-handshake-v1"
+	// the messages are split in frames of at most this size, so that a frame is authenticated before much is buffered
+	_maxChunkSize = 1 << 20
+	// the largest frame read from a peer before it is authenticated
+	_maxHandshakeFrameSize = 1024
+)
+
+var (
+	// the attestations of the hosts are checked on the L1 again after this time, so that the revoked hosts are rejected.
+	// A host found not attested is checked again sooner, as it may just have been admitted
+	_attestedHostTTL   = 10 * time.Minute
+	_unattestedHostTTL = 30 * time.Second
+	// the peer list is refreshed at most this often when a peer claims an address that is not registered yet
+	_unknownPeerRefreshInterval = 30 * time.Second
+
+	errUnregisteredPeer = errors.New("the peer address is not registered in the management contract")
+	errUnattestedPeer   = errors.New("the peer identity is not attested in the management contract")
+	errMisboundPeer     = errors.New("the peer identity is registered at another address in the management contract")
+)
+
+// the flag of the frames of a session, authenticated with their content, so that a message cut at a frame boundary is
+// not taken for a whole one
+const (
+	_moreFrames byte = iota
+	_finalFrame
+)
+
+// handshakeMsg is what a host proves in the handshake of a connection: the address registered for it in the management
+// contract, and its identity, the L1 address of its key, by signing the ephemeral keys of both ends of the connection
+type handshakeMsg struct {
+	EphemeralKey []byte
+	Address      string
+	Signature    []byte
+}
+
+// session is an authenticated connection, encrypting the messages from the initiator of the connection to the responder
+type session struct {
+	conn     net.Conn
+	peer     string             // the registered address of the peer
+	identity gethcommon.Address // the identity of the peer
+	aead     cipher.AEAD        // nil for the insecure connections
+	nonce    uint64
+}
+
+// dials the peer and authenticates it. The initiator sends its ephemeral key, the responder answers with its handshake,
+// and the initiator completes it with its own, so that each end signs the ephemeral keys of both
+func (p *Service) dial(address string) (*session, error) {
+	conn, err := net.DialTimeout(tcp, address, p.p2pTimeout)
+	if err != nil {
+		return nil, err
+	}
+	if p.insecure {
+		return &session{conn: conn, peer: address}, nil
+	}
+
+	s, err := p.initiateHandshake(conn, address)
+	if err != nil {
+		conn.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+func (p *Service) initiateHandshake(conn net.Conn, address string) (*session, error) {
+	if err := conn.SetDeadline(time.Now().Add(p.p2pTimeout)); err != nil {
+		return nil, err
+	}
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	if err = writeFrame(conn, ephemeral.PublicKey().Bytes()); err != nil {
+		return nil, err
+	}
+
+	var response handshakeMsg
+	if err = readHandshake(conn, &response); err != nil {
+		return nil, fmt.Errorf("could not read the handshake of the peer - %w", err)
+	}
+	// the peer must be the host registered at the address we dialed
+	if response.Address != address {
+		return nil, fmt.Errorf("the peer at %s claims the address %s", address, response.Address)
+	}
+	initiatorKey, responderKey := ephemeral.PublicKey().Bytes(), response.EphemeralKey
+	identity, err := p.authenticate(&response, handshakeHash(false, initiatorKey, responderKey, response.Address))
+	if err != nil {
+		return nil, err
+	}
+
+	signature, err := crypto.Sign(handshakeHash(true, initiatorKey, responderKey, p.ourPublicAddress), p.identityKey)
+	if err != nil {
+		return nil, err
+	}
+	if err = writeHandshake(conn, &handshakeMsg{Address: p.ourPublicAddress, Signature: signature}); err != nil {
+		return nil, err
+	}
+
+	aead, err := sessionCipher(ephemeral, responderKey, initiatorKey)
+	if err != nil {
+		return nil, err
+	}
+	return &session{conn: conn, peer: address, identity: identity, aead: aead}, conn.SetDeadline(time.Time{})
+}
+
+// accepts the handshake of a peer that connected to the host
+func (p *Service) acceptHandshake(conn net.Conn) (*session, error) {
+	if p.insecure {
+		return &session{conn: conn}, nil
+	}
+	if err := conn.SetDeadline(time.Now().Add(p.p2pTimeout)); err != nil {
+		return nil, err
+	}
+	initiatorKey, err := readFrame(conn, _maxHandshakeFrameSize)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the ephemeral key of the peer - %w", err)
+	}
+	ephemeral, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	responderKey := ephemeral.PublicKey().Bytes()
+
+	signature, err := crypto.Sign(handshakeHash(false, initiatorKey, responderKey, p.ourPublicAddress), p.identityKey)
+	if err != nil {
+		return nil, err
+	}
+	if err = writeHandshake(conn, &handshakeMsg{EphemeralKey: responderKey, Address: p.ourPublicAddress, Signature: signature}); err != nil {
+		return nil, err
+	}
+
+	var request handshakeMsg
+	if err = readHandshake(conn, &request); err != nil {
+		return nil, fmt.Errorf("could not read the handshake of the peer - %w", err)
+	}
+	identity, err := p.authenticate(&request, handshakeHash(true, initiatorKey, responderKey, request.Address))
+	if err != nil {
+		return nil, err
+	}
+
+	aead, err := sessionCipher(ephemeral, initiatorKey, initiatorKey)
+	if err != nil {
+		return nil, err
+	}
+	return &session{conn: conn, peer: request.Address, identity: identity, aead: aead}, conn.SetDeadline(time.Time{})
+}
+
+// authenticate recovers the identity of the peer from its handshake, and checks that the address it claims is registered
+// in the management contract, that its identity is attested, and that the address is the one registered for the identity
+// when it was attested, so that a host cannot use its attestation to impersonate another registered host
+func (p *Service) authenticate(hs *handshakeMsg, hash []byte) (gethcommon.Address, error) {
+	pubKey, err := crypto.SigToPub(hash, hs.Signature)
+	if err != nil {
+		return gethcommon.Address{}, fmt.Errorf("invalid handshake signature - %w", err)
+	}
+	identity := crypto.PubkeyToAddress(*pubKey)
+
+	if !p.isRegistered(hs.Address) {
+		p.rejectedPeers.Inc(1)
+		return gethcommon.Address{}, fmt.Errorf("%w: %s", errUnregisteredPeer, hs.Address)
+	}
+	registered, err := p.attestations.hostOf(identity)
+	if err != nil {
+		return gethcommon.Address{}, fmt.Errorf("could not check the attestation of the peer - %w", err)
+	}
+	if !registered.attested {
+		p.rejectedPeers.Inc(1)
+		return gethcommon.Address{}, fmt.Errorf("%w: %s at %s", errUnattestedPeer, identity, hs.Address)
+	}
+	if registered.address != hs.Address {
+		p.rejectedPeers.Inc(1)
+		return gethcommon.Address{}, fmt.Errorf("%w: %s claims %s but is registered at %s", errMisboundPeer, identity, hs.Address, registered.address)
+	}
+	return identity, nil
+}
+
+// isRegistered returns whether the address is the one of a host registered in the management contract. The peer list is
+// refreshed first when the address is not known, as the host may just have been registered
+func (p *Service) isRegistered(address string) bool {
+	registered := func() bool {
+		p.peerAddressesMutex.RLock()
+		defer p.peerAddressesMutex.RUnlock()
+		for _, peer := range p.l1Peers {
+			if peer == address {
+				return true
+			}
+		}
+		return false
+	}
+	if registered() {
+		return true
+	}
+
+	p.unknownPeerRefreshMutex.Lock()
+	defer p.unknownPeerRefreshMutex.Unlock()
+	if time.Since(p.lastUnknownPeerRefresh) < _unknownPeerRefreshInterval {
+		return registered()
+	}
+	p.lastUnknownPeerRefresh = time.Now()
+	p.RefreshPeerList()
+	return registered()
+}
+
+// writes a message to the session, encrypted unless the session is insecure. The message is split in frames, the last
+// one flagged as final
+func (s *session) write(msg []byte) error {
+	if s.aead == nil {
+		_, err := s.conn.Write(msg)
+		return err
+	}
+	for {
+		chunk, flag := msg, _finalFrame
+		if len(msg) > _maxChunkSize {
+			chunk, flag = msg[:_maxChunkSize], _moreFrames
+		}
+		frame := append([]byte{flag}, s.aead.Seal(nil, s.nextNonce(), chunk, []byte{flag})...)
+		if err := writeFrame(s.conn, frame); err != nil {
+			return err
+		}
+		if flag == _finalFrame {
+			return nil
+		}
+		msg = msg[_maxChunkSize:]
+	}
+}
+
+// reads the message of the session, failing with errMessageTooLarge once it exceeds maxSize (0 for no limit). The
+// encrypted messages end with their final frame, the insecure ones when the peer closes the connection.
+func (s *session) readAll(maxSize int) ([]byte, error) {
+	if s.aead == nil {
+		if maxSize <= 0 {
+			return io.ReadAll(s.conn)
+		}
+		msg, err := io.ReadAll(io.LimitReader(s.conn, int64(maxSize)+1))
+		if err == nil && len(msg) > maxSize {
+			return nil, errMessageTooLarge
+		}
+		return msg, err
+	}
+
+	var msg []byte
+	for {
+		frame, err := readFrame(s.conn, uint32(1+_maxChunkSize+s.aead.Overhead()))
+		if errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("the peer closed the connection before the end of the message - %w", io.ErrUnexpectedEOF)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(frame) == 0 {
+			return nil, errors.New("empty frame")
+		}
+		flag := frame[0]
+		plaintext, err := s.aead.Open(nil, s.nextNonce(), frame[1:], []byte{flag})
+		if err != nil {
+			return nil, fmt.Errorf("could not decrypt the message of the peer - %w", err)
+		}
+		if maxSize > 0 && len(msg)+len(plaintext) > maxSize {
+			return nil, errMessageTooLarge
+		}
+		msg = append(msg, plaintext...)
+		if flag == _finalFrame {
+			return msg, nil
+		}
+	}
+}
+
+// the nonces of the frames are their sequence number in the session
+func (s *session) nextNonce() []byte {
+	nonce := make([]byte, s.aead.NonceSize())
+	binary.BigEndian.PutUint64(nonce[len(nonce)-8:], s.nonce)
+	s.nonce++
+	return nonce
+}
+
+// handshakeHash is the hash signed by a host in the handshake: the ephemeral keys of both ends and the address of the
+// host, with its role in the connection so that a signature of a responder cannot be replayed by an initiator
+func handshakeHash(initiator bool, initiatorKey, responderKey []byte, address string) []byte {
+	role := []byte("responder")
+	if initiator {
+		role = []byte("initiator")
+	}
+	return crypto.Keccak256([]byte(_handshakeDomain), role, initiatorKey, responderKey, []byte(address))
+}
+
+// sessionCipher derives the key encrypting the messages of a session from the shared secret of the ephemeral keys
+func sessionCipher(ephemeral *ecdh.PrivateKey, peerKey []byte, initiatorKey []byte) (cipher.AEAD, error) {
+	peerPubKey, err := ecdh.X25519().NewPublicKey(peerKey)
+	if err != nil {
+		return nil, fmt.Errorf("invalid ephemeral key - %w", err)
+	}
+	shared, err := ephemeral.ECDH(peerPubKey)
+	if err != nil {
+		return nil, err
+	}
+	key := make([]byte, 32)
+	if _, err = io.ReadFull(hkdf.New(sha256.New, shared, initiatorKey, []byte(_handshakeDomain)), key); err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func writeHandshake(w io.Writer, hs *handshakeMsg) error {
+	encoded, err := rlp.EncodeToBytes(hs)
+	if err != nil {
+		return err
+	}
+	return writeFrame(w, encoded)
+}
+
+func readHandshake(r io.Reader, hs *handshakeMsg) error {
+	encoded, err := readFrame(r, _maxHandshakeFrameSize)
+	if err != nil {
+		return err
+	}
+	return rlp.DecodeBytes(encoded, hs)
+}
+
+// frames are prefixed with their length
+func writeFrame(w io.Writer, frame []byte) error {
+	prefix := make([]byte, 4)
+	binary.BigEndian.PutUint32(prefix, uint32(len(frame)))
+	if _, err := w.Write(prefix); err != nil {
+		return err
+	}
+	_, err := w.Write(frame)
+	return err
+}
+
+func readFrame(r io.Reader, maxSize uint32) ([]byte, error) {
+	prefix := make([]byte, 4)
+	if _, err := io.ReadFull(r, prefix); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(prefix)
+	if size > maxSize {
+		return nil, fmt.Errorf("%w: frame of %d bytes over %d bytes", errMessageTooLarge, size, maxSize)
+	}
+	frame := make([]byte, size)
+	if _, err := io.ReadFull(r, frame); err != nil {
+		return nil, err
+	}
+	return frame, nil
+}
+
+// attestationCache caches the attestations of the hosts in the management contract, and their registered addresses
+type attestationCache struct {
+	lock   sync.Mutex
+	byHost map[gethcommon.Address]attestation
+	fetch  func(gethcommon.Address) (attestation, error)
+	logger gethlog.Logger
+}
+
+type attestation struct {
+	attested  bool
+	address   string // the P2P address registered for the host when it was attested
+	checkedAt time.Time
+}
+
+func newAttestationCache(fetch func(gethcommon.Address) (attestation, error), logger gethlog.Logger) *attestationCache {
+	return &attestationCache{byHost: map[gethcommon.Address]attestation{}, fetch: fetch, logger: logger}
+}
+
+// fetchAttestation returns a fetch of the attestations of the hosts from the L1 publisher
+func fetchAttestation(publisher func() host.L1Publisher) func(gethcommon.Address) (attestation, error) {
+	return func(hostID gethcommon.Address) (attestation, error) {
+		attested, err := publisher().IsHostAttested(hostID)
+		if err != nil || !attested {
+			return attestation{}, err
+		}
+		address, err := publisher().HostAddressOf(hostID)
+		if err != nil {
+			return attestation{}, err
+		}
+		return attestation{attested: true, address: address}, nil
+	}
+}
+
+// hostOf returns the attestation of the host, from the cache while it is recent enough
+func (c *attestationCache) hostOf(hostID gethcommon.Address) (attestation, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	cached, found := c.byHost[hostID]
+	ttl := _unattestedHostTTL
+	if cached.attested {
+		ttl = _attestedHostTTL
+	}
+	if found && time.Since(cached.checkedAt) < ttl {
+		return cached, nil
+	}
+
+	fetched, err := c.fetch(hostID)
+	if err != nil {
+		return attestation{}, err
+	}
+	if found && cached.attested && !fetched.attested {
+		c.logger.Warn("Peer is no longer attested in the management contract", "peer", hostID)
+	}
+	fetched.checkedAt = time.Now()
+	c.byHost[hostID] = fetched
+	return fetched, nil
+}
@@ -0,0 +1,143 @@
+package p2p
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// the sequencer only accepts the messages of the hosts registered in the management contract and still attested
+func TestRejectsUnregisteredAndRevokedPeers(t *testing.T) {
+	sequencerAddress, validatorAddress := freeAddress(t), freeAddress(t)
+	sequencer := newTestService(common.Sequencer, sequencerAddress, nil)
+	handler := &testTxHandler{txs: make(chan common.EncryptedTx, 1)}
+	sequencer.SubscribeForTx(handler)
+	require.NoError(t, sequencer.Start())
+	defer sequencer.Stop() //nolint:errcheck
+
+	validator := newTestService(common.Validator, validatorAddress, []string{sequencerAddress})
+	validator.running.Store(true)
+	validator.RefreshPeerList()
+
+	// the validator is not registered yet
+	require.NoError(t, validator.SendTxToSequencer(common.EncryptedTx("tx")))
+	requireNoTx(t, handler)
+
+	// the validator is registered and attested
+	publisher := sequencer.sl.L1Publisher().(*testL1Publisher)
+	publisher.peers = []string{validatorAddress}
+	sequencer.lastUnknownPeerRefresh = time.Time{}
+	require.NoError(t, validator.SendTxToSequencer(common.EncryptedTx("tx")))
+	select {
+	case tx := <-handler.txs:
+		require.Equal(t, common.EncryptedTx("tx"), tx)
+	case <-time.After(5 * time.Second):
+		t.Fatal("the sequencer did not receive the transaction")
+	}
+
+	// the attestation of the validator is revoked
+	publisher.revoked = map[gethcommon.Address]bool{crypto.PubkeyToAddress(validator.identityKey.PublicKey): true}
+	sequencer.attestations = newAttestationCache(fetchAttestation(sequencer.sl.L1Publisher), sequencer.logger)
+	require.NoError(t, validator.SendTxToSequencer(common.EncryptedTx("tx")))
+	requireNoTx(t, handler)
+}
+
+// a host only sends its messages to the host registered at the address it dialed
+func TestRejectsPeerClaimingAnotherAddress(t *testing.T) {
+	sequencerAddress, validatorAddress := freeAddress(t), freeAddress(t)
+	sequencer := newTestService(common.Sequencer, sequencerAddress, []string{validatorAddress})
+	sequencer.ourPublicAddress = "impostor:10000"
+	require.NoError(t, sequencer.Start())
+	defer sequencer.Stop() //nolint:errcheck
+
+	validator := newTestService(common.Validator, validatorAddress, []string{sequencerAddress})
+	_, err := validator.dial(sequencerAddress)
+	require.ErrorContains(t, err, "claims the address impostor:10000")
+}
+
+// a host only accepts the messages of a peer from the address registered for its identity, so that an attested host
+// cannot impersonate another registered host
+func TestRejectsPeerRegisteredAtAnotherAddress(t *testing.T) {
+	sequencerAddress, validatorAddress, impersonatedAddress := freeAddress(t), freeAddress(t), freeAddress(t)
+	sequencer := newTestService(common.Sequencer, sequencerAddress, []string{validatorAddress, impersonatedAddress})
+	handler := &testTxHandler{txs: make(chan common.EncryptedTx, 1)}
+	sequencer.SubscribeForTx(handler)
+	require.NoError(t, sequencer.Start())
+	defer sequencer.Stop() //nolint:errcheck
+
+	// the validator claims the address of another registered host
+	validator := newTestService(common.Validator, impersonatedAddress, []string{sequencerAddress})
+	validator.running.Store(true)
+	validator.RefreshPeerList()
+	publisher := sequencer.sl.L1Publisher().(*testL1Publisher)
+	publisher.hostAddresses = map[gethcommon.Address]string{crypto.PubkeyToAddress(validator.identityKey.PublicKey): validatorAddress}
+	require.NoError(t, validator.SendTxToSequencer(common.EncryptedTx("tx")))
+	requireNoTx(t, handler)
+}
+
+// a message cut at a frame boundary is rejected rather than taken for a whole one
+func TestRejectsTruncatedMessages(t *testing.T) {
+	aead := testAEAD(t)
+	msg := make([]byte, 2*_maxChunkSize+1)
+	_, err := rand.Read(msg)
+	require.NoError(t, err)
+
+	var stream bytes.Buffer
+	require.NoError(t, (&session{conn: &testConn{Writer: &stream}, aead: aead}).write(msg))
+	whole := stream.Bytes()
+
+	read, err := (&session{conn: &testConn{Reader: bytes.NewReader(whole)}, aead: aead}).readAll(0)
+	require.NoError(t, err)
+	require.Equal(t, msg, read)
+
+	// the stream without its final frame
+	firstFrames := whole[:len(whole)-(4+1+1+aead.Overhead())]
+	_, err = (&session{conn: &testConn{Reader: bytes.NewReader(firstFrames)}, aead: aead}).readAll(0)
+	require.ErrorIs(t, err, io.ErrUnexpectedEOF)
+
+	// the frames reflagged as final are not authentic
+	reflagged := append([]byte{}, firstFrames...)
+	reflagged[4] = _finalFrame
+	_, err = (&session{conn: &testConn{Reader: bytes.NewReader(reflagged)}, aead: aead}).readAll(0)
+	require.ErrorContains(t, err, "could not decrypt")
+}
+
+func testAEAD(t *testing.T) cipher.AEAD {
+	key := make([]byte, 32)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	block, err := aes.NewCipher(key)
+	require.NoError(t, err)
+	aead, err := cipher.NewGCM(block)
+	require.NoError(t, err)
+	return aead
+}
+
+// testConn is a connection over a reader or a writer
+type testConn struct {
+	net.Conn
+	io.Reader
+	io.Writer
+}
+
+func (c *testConn) Read(b []byte) (int, error)  { return c.Reader.Read(b) }
+func (c *testConn) Write(b []byte) (int, error) { return c.Writer.Write(b) }
+
+func requireNoTx(t *testing.T, handler *testTxHandler) {
+	select {
+	case <-handler.txs:
+		t.Fatal("the sequencer received the transaction of a rejected peer")
+	case <-time.After(200 * time.Millisecond):
+	}
+}
@@ -0,0 +1,126 @@
+package p2p
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ten-protocol/go-ten/go/common/host"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// protocolVersion is the version of the protocol of the network run by the host. It is increased with each change of
+	// the P2P messages, the batches or the rollups that the hosts running the previous version cannot handle, ahead of the
+	// activation of the change
+	protocolVersion uint64 = 3
+
+	// the fraction of the peers running a newer protocol version from which the host warns its operator to upgrade
+	_newerPeersWarningFraction = 0.25
+)
+
+// the interval between the gossips of the protocol version of the host, so that the peers that missed the handshake, or
+// were upgraded since, are tracked
+var _versionGossipInterval = 5 * time.Minute
+
+// the versions of the peers that did not gossip for this long are forgotten, as the peers left the network
+var _versionExpiry = 3 * _versionGossipInterval
+
+// versionMsg is the content of the version messages, sent to the new peers as a handshake and gossiped periodically
+type versionMsg struct {
+	ProtocolVersion uint64
+	WantsReply      bool // whether the message is a handshake, which the peer answers with its version
+}
+
+// versionTracker tracks the protocol versions of the peers, and warns when a significant fraction of them run a newer
+// version than the host
+type versionTracker struct {
+	lock     sync.RWMutex
+	byPeer   map[string]host.P2PPeerVersion
+	warning  string // the current warning, empty when the host is not behind its peers
+	newer    gethmetrics.Gauge
+	outdated gethmetrics.Gauge
+	logger   gethlog.Logger
+}
+
+func newVersionTracker(registry gethmetrics.Registry, logger gethlog.Logger) *versionTracker {
+	return &versionTracker{
+		byPeer:   map[string]host.P2PPeerVersion{},
+		newer:    gethmetrics.NewRegisteredGauge("host/p2p/peers/newer", registry),
+		outdated: gethmetrics.NewRegisteredGauge("host/p2p/version/outdated", registry),
+		logger:   logger,
+	}
+}
+
+// knows returns whether the protocol version of the peer is known
+func (v *versionTracker) knows(peer string) bool {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	_, found := v.byPeer[peer]
+	return found
+}
+
+// record records the protocol version of a peer
+func (v *versionTracker) record(peer string, version uint64) {
+	v.lock.Lock()
+	defer v.lock.Unlock()
+
+	previous, found := v.byPeer[peer]
+	if (!found || previous.ProtocolVersion != version) && version != protocolVersion {
+		v.logger.Info("Peer runs a different protocol version", "peer", peer, "peerVersion", version, "ourVersion", protocolVersion)
+	}
+	v.byPeer[peer] = host.P2PPeerVersion{ProtocolVersion: version, SeenAt: time.Now()}
+	v.evaluate()
+}
+
+// evaluate forgets the expired versions and updates the warning from the versions of the peers, the lock must be held
+func (v *versionTracker) evaluate() {
+	for peer, version := range v.byPeer {
+		if time.Since(version.SeenAt) > _versionExpiry {
+			delete(v.byPeer, peer)
+		}
+	}
+
+	newer, highest := 0, protocolVersion
+	for _, peer := range v.byPeer {
+		if peer.ProtocolVersion > protocolVersion {
+			newer++
+		}
+		if peer.ProtocolVersion > highest {
+			highest = peer.ProtocolVersion
+		}
+	}
+	v.newer.Update(int64(newer))
+
+	warning := ""
+	if newer > 0 && float64(newer) >= _newerPeersWarningFraction*float64(len(v.byPeer)) {
+		warning = fmt.Sprintf("%d of %d peers run a newer protocol version (up to %d) than this node (%d), upgrade the node before the activation of the new version",
+			newer, len(v.byPeer), highest, protocolVersion)
+	}
+	if warning != "" && v.warning == "" {
+		v.logger.Warn(warning)
+	}
+	v.warning = warning
+	if warning != "" {
+		v.outdated.Update(1)
+	} else {
+		v.outdated.Update(0)
+	}
+}
+
+// versions returns the protocol versions of the peers
+func (v *versionTracker) versions() *host.P2PPeerVersions {
+	v.lock.RLock()
+	defer v.lock.RUnlock()
+	versions := &host.P2PPeerVersions{
+		ProtocolVersion: protocolVersion,
+		Peers:           make(map[string]host.P2PPeerVersion, len(v.byPeer)),
+		Warning:         v.warning,
+	}
+	for peer, version := range v.byPeer {
+		versions.Peers[peer] = version
+	}
+	return versions
+}
@@ -0,0 +1,40 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/host"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestWarnsWhenPeersRunNewerVersion(t *testing.T) {
+	registry := gethmetrics.NewRegistry()
+	tracker := newVersionTracker(registry, gethlog.New())
+
+	for _, peer := range []string{"peer1", "peer2", "peer3", "peer4"} {
+		tracker.record(peer, protocolVersion)
+	}
+	tracker.record("peer5", protocolVersion+1)
+	require.Empty(t, tracker.versions().Warning)
+
+	// a quarter of the peers run a newer version once a peer left, and its version expired
+	tracker.byPeer["peer4"] = host.P2PPeerVersion{ProtocolVersion: protocolVersion, SeenAt: time.Now().Add(-_versionExpiry - time.Second)}
+	tracker.record("peer5", protocolVersion+1)
+	versions := tracker.versions()
+	require.Contains(t, versions.Warning, "1 of 4 peers run a newer protocol version")
+	require.Equal(t, protocolVersion+1, versions.Peers["peer5"].ProtocolVersion)
+	require.NotContains(t, versions.Peers, "peer4")
+	if gethmetrics.Enabled {
+		require.Equal(t, int64(1), registry.Get("host/p2p/peers/newer").(gethmetrics.Gauge).Value())
+		require.Equal(t, int64(1), registry.Get("host/p2p/version/outdated").(gethmetrics.Gauge).Value())
+	}
+
+	// the peer runs our version again
+	tracker.record("peer5", protocolVersion)
+	require.Empty(t, tracker.versions().Warning)
+	require.Len(t, tracker.versions().Peers, 4)
+}
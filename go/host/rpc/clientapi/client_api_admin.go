@@ -0,0 +1,65 @@
+package clientapi
+
+import (
+	"context"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/host"
+)
+
+// AdminAPI implements the operations of the sequencer admin. The requests are authenticated with the signature of the
+// admin, so the API can be served publicly.
+type AdminAPI struct {
+	host host.Host
+}
+
+func NewAdminAPI(host host.Host) *AdminAPI {
+	return &AdminAPI{
+		host: host,
+	}
+}
+
+// SetReadOnlyMode switches the sequencer in or out of the read-only mode, in which it stops producing batches and
+// publishing rollups but keeps serving the RPC requests
+func (api *AdminAPI) SetReadOnlyMode(_ context.Context, request common.ReadOnlyModeRequest) error {
+	return api.host.SetReadOnlyMode(&request)
+}
+
+// PromoteEnclave makes a standby enclave of the sequencer the active one, which produces the batches and the rollups. The
+// enclave must be live and have executed all the batches of the sequencer.
+func (api *AdminAPI) PromoteEnclave(_ context.Context, request common.PromoteEnclaveRequest) error {
+	return api.host.PromoteEnclave(&request)
+}
+
+// MempoolReport returns the pending transactions that waited in the mempool of the sequencer for longer than the
+// inclusion deadline. It only holds the hashes and timings of the transactions.
+func (api *AdminAPI) MempoolReport(_ context.Context) (*common.MempoolReport, error) {
+	return api.host.MempoolReport()
+}
+
+// RollupCostEstimate returns the estimated L1 cost of publishing the pending rollup as calldata and as blobs with the
+// current L1 fees, the cheapest route and the number of batches from which the blobs are cheaper
+func (api *AdminAPI) RollupCostEstimate(_ context.Context) (*common.RollupCostEstimate, error) {
+	return api.host.RollupCostEstimate()
+}
+
+// FailedL1Transactions returns the dead-letter queue of the L1 publisher of the host: the L1 transactions it gave up on
+// after their retries, with the hash of their last attempt, their nonce and the last error
+func (api *AdminAPI) FailedL1Transactions(_ context.Context) ([]common.FailedL1Transaction, error) {
+	return api.host.FailedL1Transactions()
+}
+
+// RollupWitness returns the witness of the batches of a rollup: the data they read, encrypted for the enclaves. It is
+// submitted to the VerifyRollupWitness endpoint of the node of an auditor.
+func (api *AdminAPI) RollupWitness(_ context.Context, rollupHash gethcommon.Hash) (hexutil.Bytes, error) {
+	return api.host.RollupWitness(rollupHash)
+}
+
+// VerifyRollupWitness re-executes the batches of a rollup from its witness, without the chain state of the node, and
+// returns the verdict signed by the key of the enclave, which is bound to the enclave by its attestation
+func (api *AdminAPI) VerifyRollupWitness(_ context.Context, witness hexutil.Bytes) (*common.RollupVerdict, error) {
+	return api.host.VerifyRollupWitness(witness)
+}
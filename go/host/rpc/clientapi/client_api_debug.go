@@ -2,21 +2,41 @@ package clientapi
 
 import (
 	"context"
+	"errors"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/host"
 	"github.com/ten-protocol/go-ten/go/common/tracers"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 )
 
+// bandwidthReporter is implemented by the P2P services accounting the data exchanged with the peers
+type bandwidthReporter interface {
+	Bandwidth() *host.P2PBandwidth
+}
+
+// versionReporter is implemented by the P2P services tracking the protocol versions of the peers
+type versionReporter interface {
+	PeerVersions() *host.P2PPeerVersions
+}
+
+// peersReporter is implemented by the P2P services tracking the health of the peers
+type peersReporter interface {
+	Peers() []host.P2PPeer
+}
+
 // NetworkDebug implements a subset of the Ethereum network JSON RPC operations.
 type NetworkDebug struct {
 	host host.Host
+	p2p  host.P2P
 }
 
-func NewNetworkDebug(host host.Host) *NetworkDebug {
+func NewNetworkDebug(host host.Host, p2p host.P2P) *NetworkDebug {
 	return &NetworkDebug{
 		host: host,
+		p2p:  p2p,
 	}
 }
 
@@ -38,3 +58,44 @@ func (api *NetworkDebug) EventLogRelevancy(_ context.Context, hash gethcommon.Ha
 	}
 	return response, nil
 }
+
+// EnclaveProfile returns a gzipped pprof profile of the enclave, encrypted to the key of the profiling admin. The request
+// must be signed by the profiling admin configured in the enclave, and the enclave must be built with the enclaveprofiling
+// build tag
+func (api *NetworkDebug) EnclaveProfile(_ context.Context, request common.ProfileRequest) (hexutil.Bytes, error) {
+	profile, err := api.host.EnclaveClient().DebugProfile(&request)
+	if err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// P2PBandwidth returns the bytes and the messages exchanged with each peer, and of each P2P message type, since the host
+// started
+func (api *NetworkDebug) P2PBandwidth(_ context.Context) (*host.P2PBandwidth, error) {
+	reporter, ok := api.p2p.(bandwidthReporter)
+	if !ok {
+		return nil, errors.New("the P2P service does not account the bandwidth")
+	}
+	return reporter.Bandwidth(), nil
+}
+
+// P2PPeerVersions returns the protocol version of the host and the ones announced by its peers, with a warning when a
+// significant fraction of the peers run a newer version
+func (api *NetworkDebug) P2PPeerVersions(_ context.Context) (*host.P2PPeerVersions, error) {
+	reporter, ok := api.p2p.(versionReporter)
+	if !ok {
+		return nil, errors.New("the P2P service does not track the versions of the peers")
+	}
+	return reporter.PeerVersions(), nil
+}
+
+// P2PPeers returns the peers of the host, with the sources they were discovered from (the L1 or the DNS records) and their
+// health
+func (api *NetworkDebug) P2PPeers(_ context.Context) ([]host.P2PPeer, error) {
+	reporter, ok := api.p2p.(peersReporter)
+	if !ok {
+		return nil, errors.New("the P2P service does not track the health of the peers")
+	}
+	return reporter.Peers(), nil
+}
@@ -2,13 +2,16 @@ package clientapi
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
 	"github.com/ten-protocol/go-ten/go/common/host"
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/responses"
@@ -47,22 +50,55 @@ func (api *EthereumAPI) BlockNumber() hexutil.Uint64 {
 	return hexutil.Uint64(header.Number.Uint64())
 }
 
-// GetBlockByNumber returns the header of the batch with the given height.
-func (api *EthereumAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, _ bool) (*common.BatchHeader, error) {
+// Syncing returns false when the node is up to date. Otherwise, like Geth, it returns the progress of the sync, where the
+// blocks are the sequence numbers of the batches: the currentBlock is the head of the enclave, and the highestBlock the
+// latest batch known to the host. The eta, in seconds, is the one of the rollups being executed, if any. The details of
+// the sync are returned by ten_syncStatus.
+func (api *EthereumAPI) Syncing() (interface{}, error) {
+	status := api.host.SyncStatus()
+	if !status.Syncing {
+		return false, nil
+	}
+	progress := map[string]interface{}{
+		"currentBlock": status.EnclaveL2Head,
+		"highestBlock": status.L2Head,
+		"stalled":      status.Stalled,
+	}
+	if status.RollupSync != nil {
+		progress["eta"] = status.RollupSync.ETA
+	}
+	return progress, nil
+}
+
+// GetBlockByNumber returns the batch with the given height as an Ethereum block, or nil if there is none.
+func (api *EthereumAPI) GetBlockByNumber(ctx context.Context, number rpc.BlockNumber, fullTx bool) (map[string]interface{}, error) {
 	batchHash, err := api.batchNumberToBatchHash(number)
+	if errors.Is(err, errutil.ErrNotFound) {
+		return nil, nil //nolint:nilnil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("could not find batch with height %d. Cause: %w", number, err)
 	}
-	return api.GetBlockByHash(ctx, *batchHash, true)
+	return api.GetBlockByHash(ctx, *batchHash, fullTx)
 }
 
-// GetBlockByHash returns the header of the batch with the given hash.
-func (api *EthereumAPI) GetBlockByHash(_ context.Context, hash gethcommon.Hash, _ bool) (*common.BatchHeader, error) {
+// GetBlockByHash returns the batch with the given hash as an Ethereum block, or nil if there is none. The block has the
+// fields of the batch header, and the fields of an Ethereum block that a batch does not have (e.g. the difficulty or
+// the uncles) take their post-merge values, so that the standard tooling can decode it. The transactions are private,
+// so the block lists their hashes even when the full transactions are requested.
+func (api *EthereumAPI) GetBlockByHash(_ context.Context, hash gethcommon.Hash, _ bool) (map[string]interface{}, error) {
 	batchHeader, err := api.host.DB().GetBatchHeader(hash)
+	if errors.Is(err, errutil.ErrNotFound) {
+		return nil, nil //nolint:nilnil
+	}
 	if err != nil {
 		return nil, err
 	}
-	return batchHeader, nil
+	txHashes, err := api.host.DB().GetBatchTxs(hash)
+	if err != nil && !errors.Is(err, errutil.ErrNotFound) {
+		return nil, fmt.Errorf("could not retrieve the transactions of batch %s. Cause: %w", hash, err)
+	}
+	return batchToBlock(batchHeader, txHashes)
 }
 
 // GasPrice is a placeholder for an RPC method required by MetaMask/Remix.
@@ -203,18 +239,44 @@ type FeeHistoryResult struct {
 	GasUsedRatio []float64        `json:"gasUsedRatio"`
 }
 
+// batchToBlock returns the batch header with the fields of an Ethereum block, as returned by Geth
+func batchToBlock(header *common.BatchHeader, txHashes []gethcommon.Hash) (map[string]interface{}, error) {
+	encoded, err := json.Marshal(header)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode the batch header. Cause: %w", err)
+	}
+	block := map[string]interface{}{}
+	if err = json.Unmarshal(encoded, &block); err != nil {
+		return nil, fmt.Errorf("could not encode the batch header. Cause: %w", err)
+	}
+	if txHashes == nil {
+		txHashes = []gethcommon.Hash{}
+	}
+	block["transactions"] = txHashes
+	block["uncles"] = []gethcommon.Hash{}
+	block["sha3Uncles"] = types.EmptyUncleHash
+	block["logsBloom"] = types.Bloom{}
+	block["difficulty"] = (*hexutil.Big)(big.NewInt(0))
+	block["totalDifficulty"] = (*hexutil.Big)(big.NewInt(0))
+	block["mixHash"] = gethcommon.Hash{}
+	block["nonce"] = types.BlockNonce{}
+	return block, nil
+}
+
 // Given a batch number, returns the hash of the batch with that number.
 func (api *EthereumAPI) batchNumberToBatchHash(batchNumber rpc.BlockNumber) (*gethcommon.Hash, error) {
 	// Handling the special cases first. No special handling is required for rpc.EarliestBlockNumber.
-	// note: our API currently treats all these block statuses the same for obscuro batches
-	if batchNumber == rpc.LatestBlockNumber || batchNumber == rpc.PendingBlockNumber ||
-		batchNumber == rpc.FinalizedBlockNumber || batchNumber == rpc.SafeBlockNumber {
+	switch batchNumber { //nolint:exhaustive
+	// the pending batch is not exposed, so it is the head batch
+	case rpc.LatestBlockNumber, rpc.PendingBlockNumber:
 		batchHeader, err := api.host.DB().GetHeadBatchHeader()
 		if err != nil {
 			return nil, err
 		}
 		batchHash := batchHeader.Hash()
 		return &batchHash, nil
+	case rpc.SafeBlockNumber, rpc.FinalizedBlockNumber:
+		return api.rolledUpBatchHash(batchNumber == rpc.FinalizedBlockNumber)
 	}
 
 	batchNumberBig := big.NewInt(batchNumber.Int64())
@@ -225,6 +287,24 @@ func (api *EthereumAPI) batchNumberToBatchHash(batchNumber rpc.BlockNumber) (*ge
 	return batchHash, nil
 }
 
+// rolledUpBatchHash returns the hash of the last batch included in a rollup published to the L1, which is the "safe"
+// batch. The "finalized" batch is the last batch included in a rollup published in an L1 block that is final.
+func (api *EthereumAPI) rolledUpBatchHash(finalized bool) (*gethcommon.Hash, error) {
+	l1Head, err := api.host.DB().GetBlockAtTip()
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve the L1 head. Cause: %w", err)
+	}
+	maxHeight := new(big.Int).Set(l1Head.Number)
+	if finalized {
+		maxHeight.Sub(maxHeight, new(big.Int).SetUint64(api.host.Config().EventLogFinalityDepth))
+	}
+	rollupHeader, err := api.host.DB().GetLatestRollupHeader(maxHeight)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve the last rollup. Cause: %w", err)
+	}
+	return api.host.DB().GetBatchHashBySequenceNumber(new(big.Int).SetUint64(rollupHeader.LastBatchSeqNo))
+}
+
 func (api *EthereumAPI) handleSysError(function string, sysError common.SystemError) (responses.EnclaveResponse, error) {
 	api.logger.Error(fmt.Sprintf("Enclave System Error. Function %s", function), log.ErrKey, sysError)
 	return responses.EnclaveResponse{
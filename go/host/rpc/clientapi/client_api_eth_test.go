@@ -0,0 +1,54 @@
+package clientapi
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// the batches are returned as blocks that the Ethereum tooling and the TEN clients can both decode
+func TestBatchToBlock(t *testing.T) {
+	header := &common.BatchHeader{
+		ParentHash:       gethcommon.HexToHash("0x01"),
+		Number:           big.NewInt(7),
+		SequencerOrderNo: big.NewInt(9),
+		GasLimit:         30_000_000,
+		GasUsed:          21_000,
+		Time:             1_700_000_000,
+		BaseFee:          big.NewInt(1_000),
+		R:                big.NewInt(2),
+		S:                big.NewInt(3),
+	}
+	txHash := gethcommon.HexToHash("0x02")
+	block, err := batchToBlock(header, []gethcommon.Hash{txHash})
+	require.NoError(t, err)
+	encoded, err := json.Marshal(block)
+	require.NoError(t, err)
+
+	var ethHeader types.Header
+	require.NoError(t, json.Unmarshal(encoded, &ethHeader))
+	require.Equal(t, header.ParentHash, ethHeader.ParentHash)
+	require.EqualValues(t, 7, ethHeader.Number.Int64())
+	require.Equal(t, header.Time, ethHeader.Time)
+	require.EqualValues(t, 1_000, ethHeader.BaseFee.Int64())
+	require.Equal(t, types.EmptyUncleHash, ethHeader.UncleHash)
+	require.Empty(t, ethHeader.Extra)
+
+	var ethBlock struct {
+		Hash         gethcommon.Hash   `json:"hash"`
+		Transactions []gethcommon.Hash `json:"transactions"`
+	}
+	require.NoError(t, json.Unmarshal(encoded, &ethBlock))
+	require.Equal(t, header.Hash(), ethBlock.Hash)
+	require.Equal(t, []gethcommon.Hash{txHash}, ethBlock.Transactions)
+
+	var batchHeader common.BatchHeader
+	require.NoError(t, json.Unmarshal(encoded, &batchHeader))
+	require.Equal(t, header.Hash(), batchHeader.Hash())
+}
@@ -97,6 +97,54 @@ func (api *FilterAPI) Logs(ctx context.Context, encryptedParams common.Encrypted
 	return subscription, nil
 }
 
+// NewHeads returns a subscription to the headers of the batches produced or executed by the node.
+func (api *FilterAPI) NewHeads(ctx context.Context) (*rpc.Subscription, error) {
+	return api.subscribeHeadEvents(ctx, host.NewHeadsEvent)
+}
+
+// NewRollups returns a subscription to the rollups published on the L1.
+func (api *FilterAPI) NewRollups(ctx context.Context) (*rpc.Subscription, error) {
+	return api.subscribeHeadEvents(ctx, host.NewRollupsEvent)
+}
+
+// SyncStatus returns a subscription to the sync status of the node, starting from the current status.
+func (api *FilterAPI) SyncStatus(ctx context.Context) (*rpc.Subscription, error) {
+	return api.subscribeHeadEvents(ctx, host.SyncStatusEvent)
+}
+
+// subscribeHeadEvents forwards the head events of a type to the client until it unsubscribes or its connection closes.
+// The subscriptions are counted per connection, and the events the client cannot keep up with are dropped by the host.
+func (api *FilterAPI) subscribeHeadEvents(ctx context.Context, eventType host.HeadEventType) (*rpc.Subscription, error) {
+	notifier, supported := rpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, fmt.Errorf("creation of subscriptions is not supported")
+	}
+	events, unsubscribe, err := api.host.SubscribeHeadEvents(eventType, rpc.PeerInfoFromContext(ctx).RemoteAddr)
+	if err != nil {
+		return nil, fmt.Errorf("could not subscribe for %s. Cause: %w", eventType, err)
+	}
+	subscription := notifier.CreateSubscription()
+
+	go func() {
+		defer unsubscribe()
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+				if err := notifier.Notify(subscription.ID, event); err != nil {
+					api.logger.Debug("could not send event to client on subscription", log.SubIDKey, subscription.ID, log.ErrKey, err)
+				}
+			case <-subscription.Err():
+				return
+			}
+		}
+	}()
+
+	return subscription, nil
+}
+
 // GetLogs returns the logs matching the filter.
 func (api *FilterAPI) GetLogs(_ context.Context, encryptedParams common.EncryptedParamsGetLogs) (responses.EnclaveResponse, error) {
 	enclaveResponse, sysError := api.host.EnclaveClient().GetLogs(encryptedParams)
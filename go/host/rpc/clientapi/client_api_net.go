@@ -21,3 +21,8 @@ func NewNetworkAPI(host host.Host) *NetworkAPI {
 func (api *NetworkAPI) Version() string {
 	return fmt.Sprintf("%d", api.host.Config().ObscuroChainID)
 }
+
+// Listening returns true, as the host always serves the client requests it receives.
+func (api *NetworkAPI) Listening() bool {
+	return true
+}
@@ -0,0 +1,30 @@
+package clientapi
+
+import (
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/host"
+)
+
+// TenAPI implements the JSON RPC operations specific to the TEN nodes.
+type TenAPI struct {
+	host host.Host
+}
+
+func NewTenAPI(host host.Host) *TenAPI {
+	return &TenAPI{
+		host: host,
+	}
+}
+
+// SyncStatus returns the sync status of the node: its status, the heads of the L1 and L2 chains known to the host and
+// processed by the enclave, and the latest progress of the enclave through the batches of the rollups it executes.
+func (api *TenAPI) SyncStatus() *common.SyncStatus {
+	return api.host.SyncStatus()
+}
+
+// MempoolStats returns the aggregate statistics of the mempool of the sequencer, for the wallets to suggest fees: the
+// number of transactions, the percentiles of the gas prices of the pending ones and the median inclusion latency. They
+// reveal no individual transaction, and are only served by the sequencers with the publicMempoolStats flag.
+func (api *TenAPI) MempoolStats() (*common.MempoolStats, error) {
+	return api.host.MempoolStats()
+}
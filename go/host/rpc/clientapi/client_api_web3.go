@@ -0,0 +1,26 @@
+package clientapi
+
+import (
+	"fmt"
+	"runtime"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// Web3API implements the web3 JSON RPC operations, for the standard tooling to identify the node.
+type Web3API struct{}
+
+func NewWeb3API() *Web3API {
+	return &Web3API{}
+}
+
+// ClientVersion returns the name of the node software, in the format of Geth.
+func (api *Web3API) ClientVersion() string {
+	return fmt.Sprintf("ten/%s-%s/%s", runtime.GOOS, runtime.GOARCH, runtime.Version())
+}
+
+// Sha3 returns the Keccak-256 hash of the given data.
+func (api *Web3API) Sha3(input hexutil.Bytes) hexutil.Bytes {
+	return crypto.Keccak256(input)
+}
@@ -0,0 +1,158 @@
+package clientrest
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+)
+
+const (
+	openAPIVersion = "3.0.3"
+	apiVersion     = "v1"
+	schemaRefRoot  = "#/components/schemas/"
+)
+
+// generateSpec generates the OpenAPI specification of the API from its routes, so that it can't drift from the
+// implementation. The schemas are derived from the types of the responses.
+func generateSpec(routes []route) ([]byte, error) {
+	schemas := map[string]interface{}{}
+	errorSchema, err := schemaFor(reflect.TypeOf(Error{}), schemas)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := map[string]interface{}{}
+	for _, rt := range routes {
+		responseSchema := map[string]interface{}{"type": "object"}
+		if rt.response != nil {
+			responseSchema, err = schemaFor(reflect.TypeOf(rt.response), schemas)
+			if err != nil {
+				return nil, fmt.Errorf("could not generate the schema of %s - %w", rt.path, err)
+			}
+		}
+
+		params := make([]interface{}, len(rt.params))
+		for i, p := range rt.params {
+			schema := map[string]interface{}{"type": "string"}
+			if p.in == "query" {
+				schema = map[string]interface{}{"type": "integer", "minimum": 0}
+			}
+			params[i] = map[string]interface{}{
+				"name":        p.name,
+				"in":          p.in,
+				"required":    p.in == "path",
+				"description": p.description,
+				"schema":      schema,
+			}
+		}
+
+		paths[rt.path] = map[string]interface{}{
+			"get": map[string]interface{}{
+				"operationId": rt.operationID,
+				"summary":     rt.summary,
+				"parameters":  params,
+				"responses": map[string]interface{}{
+					"200":     jsonResponse("OK", responseSchema),
+					"default": jsonResponse("Error", errorSchema),
+				},
+			},
+		}
+	}
+
+	spec := map[string]interface{}{
+		"openapi": openAPIVersion,
+		"info": map[string]interface{}{
+			"title":       "Obscuro host REST API",
+			"version":     apiVersion,
+			"description": "Read-only access to the public data of an Obscuro node. The listings are paginated with the `offset` and `size` query parameters, from the most recent item.",
+		},
+		"paths":      paths,
+		"components": map[string]interface{}{"schemas": schemas},
+	}
+	return json.MarshalIndent(spec, "", "  ")
+}
+
+func jsonResponse(description string, schema map[string]interface{}) map[string]interface{} {
+	return map[string]interface{}{
+		"description": description,
+		"content": map[string]interface{}{
+			"application/json": map[string]interface{}{"schema": schema},
+		},
+	}
+}
+
+// schemaFor returns the schema of the JSON encoding of the given type, adding the schemas of the structs to the
+// components schemas and referencing them
+func schemaFor(t reflect.Type, schemas map[string]interface{}) (map[string]interface{}, error) {
+	switch t.Kind() { //nolint:exhaustive
+	case reflect.String:
+		return map[string]interface{}{"type": "string"}, nil
+	case reflect.Bool:
+		return map[string]interface{}{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return map[string]interface{}{"type": "integer", "format": "int64"}, nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]interface{}{"type": "integer", "minimum": 0}, nil
+	case reflect.Ptr:
+		return schemaFor(t.Elem(), schemas)
+	case reflect.Slice:
+		items, err := schemaFor(t.Elem(), schemas)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("map keys of type %s cannot be encoded as JSON object keys", t.Key())
+		}
+		values, err := schemaFor(t.Elem(), schemas)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		if _, ok := schemas[t.Name()]; !ok {
+			if err := addStructSchema(t, schemas); err != nil {
+				return nil, err
+			}
+		}
+		return map[string]interface{}{"$ref": schemaRefRoot + t.Name()}, nil
+	default:
+		return nil, fmt.Errorf("no schema for type %s", t)
+	}
+}
+
+func addStructSchema(t reflect.Type, schemas map[string]interface{}) error {
+	// reserve the name first, in case the struct references itself
+	schemas[t.Name()] = nil
+
+	properties := map[string]interface{}{}
+	required := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+		name := field.Name
+		if tag := strings.Split(field.Tag.Get("json"), ",")[0]; tag != "" {
+			name = tag
+		}
+		if name == "-" {
+			continue
+		}
+		schema, err := schemaFor(field.Type, schemas)
+		if err != nil {
+			return fmt.Errorf("field %s.%s - %w", t.Name(), field.Name, err)
+		}
+		properties[name] = schema
+		required = append(required, name)
+	}
+
+	schemas[t.Name()] = map[string]interface{}{
+		"type":       "object",
+		"properties": properties,
+		"required":   required,
+	}
+	return nil
+}
@@ -0,0 +1,183 @@
+package clientrest
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// The pagination conventions of the API: the listings are ordered from the most recent item, `offset` is the number of
+// items to skip and `size` the number of items to return (between 1 and _maxPageSize). Every page includes the total
+// number of items.
+const (
+	_defaultPageSize = 10
+	_maxPageSize     = 100
+)
+
+var (
+	hashParam   = param{name: "hash", in: "path", description: "The 0x-prefixed hex hash"}
+	offsetParam = param{name: "offset", in: "query", description: "The number of items to skip, from the most recent (defaults to 0)"}
+	sizeParam   = param{name: "size", in: "query", description: "The number of items to return, between 1 and 100 (defaults to 10)"}
+)
+
+func (s *Server) apiRoutes() []route {
+	// the routes with a literal segment must come before the routes with a parameter in the same position
+	return []route{
+		{path: "/v1/batches", operationID: "listBatches", summary: "Lists the batches, from the most recent", params: []param{offsetParam, sizeParam}, response: BatchPage{}, handler: s.listBatches},
+		{path: "/v1/batches/head", operationID: "getHeadBatch", summary: "Returns the head batch", response: Batch{}, handler: s.getHeadBatch},
+		{path: "/v1/batches/{hash}", operationID: "getBatch", summary: "Returns the batch with the given hash", params: []param{hashParam}, response: Batch{}, handler: s.getBatch},
+		{path: "/v1/rollups/head", operationID: "getHeadRollup", summary: "Returns the most recent rollup", response: Rollup{}, handler: s.getHeadRollup},
+		{path: "/v1/rollups/{hash}", operationID: "getRollup", summary: "Returns the rollup with the given hash", params: []param{hashParam}, response: Rollup{}, handler: s.getRollup},
+		{path: "/v1/blocks", operationID: "listBlocks", summary: "Lists the L1 blocks processed by the node, from the most recent", params: []param{offsetParam, sizeParam}, response: BlockPage{}, handler: s.listBlocks},
+		{path: "/v1/node", operationID: "getNodeInfo", summary: "Returns the identity and the health of the node", response: NodeInfo{}, handler: s.getNodeInfo},
+		{path: "/v1/network/config", operationID: "getNetworkConfig", summary: "Returns the configuration of the network", response: NetworkConfig{}, handler: s.getNetworkConfig},
+		{path: "/v1/openapi.json", operationID: "getOpenAPISpec", summary: "Returns the OpenAPI specification of the API", handler: s.getSpec},
+	}
+}
+
+func (s *Server) listBatches(r *http.Request, _ map[string]string) (interface{}, error) {
+	pagination, err := parsePagination(r)
+	if err != nil {
+		return nil, err
+	}
+	page := BatchPage{Items: []Batch{}, Offset: pagination.Offset, Size: uint64(pagination.Size)}
+
+	listing, err := s.host.DB().GetBatchListing(pagination)
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			return page, nil
+		}
+		return nil, err
+	}
+	page.Total = listing.Total
+	for i := range listing.BatchesData {
+		page.Items = append(page.Items, toBatch(&listing.BatchesData[i].BatchHeader, listing.BatchesData[i].TxHashes))
+	}
+	return page, nil
+}
+
+func (s *Server) getHeadBatch(_ *http.Request, _ map[string]string) (interface{}, error) {
+	header, err := s.host.DB().GetHeadBatchHeader()
+	if err != nil {
+		return nil, err
+	}
+	return s.batchWithTxs(header)
+}
+
+func (s *Server) getBatch(_ *http.Request, pathParams map[string]string) (interface{}, error) {
+	hash, err := parseHash(pathParams[hashParam.name])
+	if err != nil {
+		return nil, err
+	}
+	header, err := s.host.DB().GetBatchHeader(hash)
+	if err != nil {
+		return nil, err
+	}
+	return s.batchWithTxs(header)
+}
+
+func (s *Server) batchWithTxs(header *common.BatchHeader) (Batch, error) {
+	// the transaction hashes are missing if the transaction data of the batch was pruned
+	txHashes, err := s.host.DB().GetBatchTxs(header.Hash())
+	if err != nil && !errors.Is(err, errutil.ErrNotFound) {
+		return Batch{}, err
+	}
+	return toBatch(header, txHashes), nil
+}
+
+func (s *Server) getHeadRollup(_ *http.Request, _ map[string]string) (interface{}, error) {
+	header, err := s.host.DB().GetTipRollupHeader()
+	if err != nil {
+		return nil, err
+	}
+	return toRollup(header), nil
+}
+
+func (s *Server) getRollup(_ *http.Request, pathParams map[string]string) (interface{}, error) {
+	hash, err := tentypes.ParseRollupHash(pathParams[hashParam.name])
+	if err != nil {
+		return nil, badRequest("invalid hash %q", pathParams[hashParam.name])
+	}
+	header, err := s.host.DB().GetRollupHeader(hash.Hash())
+	if err != nil {
+		return nil, err
+	}
+	return toRollup(header), nil
+}
+
+func (s *Server) listBlocks(r *http.Request, _ map[string]string) (interface{}, error) {
+	pagination, err := parsePagination(r)
+	if err != nil {
+		return nil, err
+	}
+	page := BlockPage{Items: []Block{}, Offset: pagination.Offset, Size: uint64(pagination.Size)}
+
+	listing, err := s.host.DB().GetBlockListing(pagination)
+	if err != nil {
+		if errors.Is(err, errutil.ErrNotFound) {
+			return page, nil
+		}
+		return nil, err
+	}
+	page.Total = listing.Total
+	for i := range listing.BlocksData {
+		page.Items = append(page.Items, toBlock(&listing.BlocksData[i].BlockHeader, listing.BlocksData[i].RollupHash))
+	}
+	return page, nil
+}
+
+func (s *Server) getNodeInfo(_ *http.Request, _ map[string]string) (interface{}, error) {
+	health, err := s.host.HealthCheck()
+	if err != nil {
+		return nil, err
+	}
+	return toNodeInfo(s.host, health), nil
+}
+
+func (s *Server) getNetworkConfig(_ *http.Request, _ map[string]string) (interface{}, error) {
+	info, err := s.host.ObscuroConfig()
+	if err != nil {
+		return nil, err
+	}
+	return toNetworkConfig(info), nil
+}
+
+func (s *Server) getSpec(_ *http.Request, _ map[string]string) (interface{}, error) {
+	return json.RawMessage(s.spec), nil
+}
+
+func parsePagination(r *http.Request) (*common.QueryPagination, error) {
+	pagination := &common.QueryPagination{Size: _defaultPageSize}
+	query := r.URL.Query()
+	if offset := query.Get(offsetParam.name); offset != "" {
+		value, err := strconv.ParseUint(offset, 10, 64)
+		if err != nil {
+			return nil, badRequest("invalid offset %q", offset)
+		}
+		pagination.Offset = value
+	}
+	if size := query.Get(sizeParam.name); size != "" {
+		value, err := strconv.ParseUint(size, 10, 32)
+		if err != nil || value < 1 || value > _maxPageSize {
+			return nil, badRequest("size must be between 1 and %d", _maxPageSize)
+		}
+		pagination.Size = uint(value)
+	}
+	return pagination, nil
+}
+
+func parseHash(s string) (gethcommon.Hash, error) {
+	b, err := hexutil.Decode(s)
+	if err != nil || len(b) != gethcommon.HashLength {
+		return gethcommon.Hash{}, badRequest("invalid hash %q", s)
+	}
+	return gethcommon.BytesToHash(b), nil
+}
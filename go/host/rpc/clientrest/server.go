@@ -0,0 +1,186 @@
+package clientrest
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/host"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/config"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	allOrigins = "*"
+
+	_shutdownTimeout = 5 * time.Second
+)
+
+// Server is an optional, read-only REST facade over the public data of the node (batches, rollups, node info and
+// network config), for the integrators who don't want to speak JSON-RPC. The OpenAPI specification of the API is
+// generated from its routes, and served at /v1/openapi.json.
+type Server struct {
+	host    host.Host
+	address string
+	routes  []route
+	spec    []byte
+	server  *http.Server
+	logger  gethlog.Logger
+}
+
+func NewServer(cfg *config.HostConfig, h host.Host, logger gethlog.Logger) *Server {
+	s := &Server{
+		host:    h,
+		address: net.JoinHostPort(cfg.ClientRPCHost, strconv.FormatUint(cfg.ClientRESTPort, 10)),
+		logger:  logger.New(log.CmpKey, log.HostRESTCmp),
+	}
+	s.routes = s.apiRoutes()
+
+	spec, err := generateSpec(s.routes)
+	if err != nil {
+		logger.Crit("could not generate the OpenAPI specification of the REST API.", log.ErrKey, err)
+	}
+	s.spec = spec
+	return s
+}
+
+func (s *Server) Start() error {
+	listener, err := net.Listen("tcp", s.address)
+	if err != nil {
+		return fmt.Errorf("could not listen on %s - %w", s.address, err)
+	}
+	s.server = &http.Server{
+		Handler:           s,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		if err := s.server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.logger.Error("REST server stopped unexpectedly", log.ErrKey, err)
+		}
+	}()
+	s.logger.Info("Started REST server", "address", s.address)
+	return nil
+}
+
+func (s *Server) Stop() error {
+	if s.server == nil {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), _shutdownTimeout)
+	defer cancel()
+	return s.server.Shutdown(ctx)
+}
+
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// todo - review if this poses a security issue (the same policy is used by the RPC server)
+	w.Header().Set("Access-Control-Allow-Origin", allOrigins)
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodOptions:
+		w.Header().Set("Access-Control-Allow-Methods", http.MethodGet)
+		w.WriteHeader(http.StatusNoContent)
+		return
+	default:
+		s.writeJSON(w, http.StatusMethodNotAllowed, Error{Error: "only GET requests are supported"})
+		return
+	}
+
+	for _, rt := range s.routes {
+		pathParams, ok := rt.match(r.URL.Path)
+		if !ok {
+			continue
+		}
+		result, err := rt.handler(r, pathParams)
+		if err != nil {
+			s.writeError(w, r, err)
+			return
+		}
+		s.writeJSON(w, http.StatusOK, result)
+		return
+	}
+	s.writeJSON(w, http.StatusNotFound, Error{Error: fmt.Sprintf("no route for %s", r.URL.Path)})
+}
+
+func (s *Server) writeError(w http.ResponseWriter, r *http.Request, err error) {
+	var badReqErr *badRequestError
+	switch {
+	case errors.As(err, &badReqErr):
+		s.writeJSON(w, http.StatusBadRequest, Error{Error: badReqErr.Error()})
+	case errors.Is(err, errutil.ErrNotFound):
+		s.writeJSON(w, http.StatusNotFound, Error{Error: "not found"})
+	default:
+		// the internal errors are not returned, as they could leak details about the node
+		s.logger.Warn("could not serve REST request", "path", r.URL.Path, log.ErrKey, err)
+		s.writeJSON(w, http.StatusInternalServerError, Error{Error: "internal error"})
+	}
+}
+
+func (s *Server) writeJSON(w http.ResponseWriter, status int, body interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	if err := json.NewEncoder(w).Encode(body); err != nil {
+		s.logger.Debug("could not write REST response", log.ErrKey, err)
+	}
+}
+
+// badRequestError is returned by the handlers when the request is invalid
+type badRequestError struct {
+	msg string
+}
+
+func (e *badRequestError) Error() string {
+	return e.msg
+}
+
+func badRequest(format string, args ...interface{}) error {
+	return &badRequestError{msg: fmt.Sprintf(format, args...)}
+}
+
+// param is a path or query parameter of a route
+type param struct {
+	name        string
+	in          string // "path" or "query"
+	description string
+}
+
+// route is an endpoint of the API. The OpenAPI specification is generated from the routes.
+type route struct {
+	path        string // the path of the route, where the segments like {hash} are path parameters
+	operationID string
+	summary     string
+	params      []param
+	response    interface{} // a value of the type returned by the handler, nil if the response is free-form JSON
+	handler     func(r *http.Request, pathParams map[string]string) (interface{}, error)
+}
+
+// match returns the path parameters of the route if the path matches it
+func (rt *route) match(path string) (map[string]string, bool) {
+	routeSegments := strings.Split(strings.Trim(rt.path, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(routeSegments) != len(pathSegments) {
+		return nil, false
+	}
+	pathParams := map[string]string{}
+	for i, segment := range routeSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if pathSegments[i] == "" {
+				return nil, false
+			}
+			pathParams[strings.Trim(segment, "{}")] = pathSegments[i]
+			continue
+		}
+		if segment != pathSegments[i] {
+			return nil, false
+		}
+	}
+	return pathParams, true
+}
@@ -0,0 +1,210 @@
+package clientrest
+
+import (
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/host"
+	"github.com/ten-protocol/go-ten/go/config"
+	"github.com/ten-protocol/go-ten/go/host/db"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+// fakeHost serves the data of an in-memory DB
+type fakeHost struct {
+	host.Host
+	db     *db.DB
+	config *config.HostConfig
+}
+
+func (h *fakeHost) DB() *db.DB {
+	return h.db
+}
+
+func (h *fakeHost) Config() *config.HostConfig {
+	return h.config
+}
+
+func (h *fakeHost) HealthCheck() (*host.HealthCheck, error) {
+	startup := &host.StartupReport{
+		Duration: 3 * time.Second,
+		Phases:   []host.StartupPhaseTiming{{Phase: host.EnclaveWaitPhase, Duration: 1500 * time.Millisecond, Completed: true}},
+	}
+	return &host.HealthCheck{OverallHealth: false, Errors: []string{"[enclaves] not healthy"}, ReadOnlyMode: true, ReadOnlyReason: "maintenance", Startup: startup}, nil
+}
+
+func (h *fakeHost) ObscuroConfig() (*common.ObscuroNetworkInfo, error) {
+	return &common.ObscuroNetworkInfo{
+		ManagementContractAddress: gethcommon.HexToAddress("0x01"),
+		ImportantContracts:        map[string]gethcommon.Address{"L1Bridge": gethcommon.HexToAddress("0x02")},
+	}, nil
+}
+
+func newTestServer(t *testing.T, nrBatches int) (*Server, []*common.ExtBatch) {
+	cfg := config.DefaultHostParsedConfig().ToHostConfig()
+	cfg.ID = gethcommon.HexToAddress("0x03")
+	database := db.NewInMemoryDB(nil, nil)
+
+	batches := make([]*common.ExtBatch, nrBatches)
+	for i := range batches {
+		seqNo := big.NewInt(int64(i + 1))
+		batches[i] = &common.ExtBatch{
+			Header:   &common.BatchHeader{Number: seqNo, SequencerOrderNo: seqNo, BaseFee: big.NewInt(1)},
+			TxHashes: []gethcommon.Hash{gethcommon.BigToHash(seqNo)},
+		}
+		require.NoError(t, database.AddBatch(batches[i]))
+	}
+
+	return NewServer(cfg, &fakeHost{db: database, config: cfg}, gethlog.New()), batches
+}
+
+func get(t *testing.T, s *Server, path string, expectedStatus int, result interface{}) {
+	recorder := httptest.NewRecorder()
+	s.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, path, nil))
+	require.Equal(t, expectedStatus, recorder.Code, recorder.Body.String())
+	if result != nil {
+		require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), result))
+	}
+}
+
+func TestBatchEndpoints(t *testing.T) {
+	s, batches := newTestServer(t, 3)
+
+	var page BatchPage
+	get(t, s, "/v1/batches?size=2", http.StatusOK, &page)
+	require.Equal(t, uint64(3), page.Total)
+	require.Len(t, page.Items, 2)
+	require.Equal(t, batches[2].Hash().Hex(), page.Items[0].Hash)
+	require.Equal(t, batches[1].Hash().Hex(), page.Items[1].Hash)
+
+	get(t, s, "/v1/batches?offset=2&size=2", http.StatusOK, &page)
+	require.Len(t, page.Items, 1)
+	require.Equal(t, batches[0].Hash().Hex(), page.Items[0].Hash)
+
+	get(t, s, "/v1/batches?offset=10", http.StatusOK, &page)
+	require.Empty(t, page.Items)
+
+	var batch Batch
+	get(t, s, "/v1/batches/head", http.StatusOK, &batch)
+	require.Equal(t, batches[2].Hash().Hex(), batch.Hash)
+
+	get(t, s, "/v1/batches/"+batches[1].Hash().Hex(), http.StatusOK, &batch)
+	require.Equal(t, uint64(2), batch.SequencerOrderNo)
+	require.Equal(t, []string{batches[1].TxHashes[0].Hex()}, batch.TxHashes)
+
+	var apiErr Error
+	get(t, s, "/v1/batches/"+gethcommon.Hash{}.Hex(), http.StatusNotFound, &apiErr)
+	get(t, s, "/v1/batches/0x1234", http.StatusBadRequest, &apiErr)
+	get(t, s, "/v1/batches?size=0", http.StatusBadRequest, &apiErr)
+	get(t, s, "/v1/batches?size=101", http.StatusBadRequest, &apiErr)
+	get(t, s, "/v1/unknown", http.StatusNotFound, &apiErr)
+}
+
+func TestBlockEndpoints(t *testing.T) {
+	s, _ := newTestServer(t, 0)
+	// the node started processing the L1 at an arbitrary height
+	for height := int64(100); height < 103; height++ {
+		require.NoError(t, s.host.DB().AddBlock(&types.Header{Number: big.NewInt(height)}))
+	}
+
+	var page BlockPage
+	get(t, s, "/v1/blocks?size=2", http.StatusOK, &page)
+	require.Equal(t, uint64(3), page.Total)
+	require.Len(t, page.Items, 2)
+	require.Equal(t, uint64(102), page.Items[0].Number)
+
+	get(t, s, "/v1/blocks?offset=2&size=2", http.StatusOK, &page)
+	require.Len(t, page.Items, 1)
+	require.Equal(t, uint64(100), page.Items[0].Number)
+	require.Equal(t, uint64(3), page.Total)
+}
+
+func TestBatchTotalIncludesBatchesNoLongerCanonical(t *testing.T) {
+	s, batches := newTestServer(t, 2)
+	// a batch produced after a reorg has the same height as the previous head, so the batch height lags the sequence
+	// numbers from then on
+	for _, header := range []*common.BatchHeader{
+		{Number: big.NewInt(2), SequencerOrderNo: big.NewInt(3), BaseFee: big.NewInt(1)},
+		{Number: big.NewInt(3), SequencerOrderNo: big.NewInt(4), BaseFee: big.NewInt(1)},
+	} {
+		require.NoError(t, s.host.DB().AddBatch(&common.ExtBatch{Header: header}))
+	}
+
+	var page BatchPage
+	get(t, s, "/v1/batches", http.StatusOK, &page)
+	require.Equal(t, uint64(4), page.Total)
+	require.Len(t, page.Items, 4)
+	require.Equal(t, batches[0].Hash().Hex(), page.Items[3].Hash)
+}
+
+func TestEmptyNode(t *testing.T) {
+	s, _ := newTestServer(t, 0)
+
+	var page BatchPage
+	get(t, s, "/v1/batches", http.StatusOK, &page)
+	require.Empty(t, page.Items)
+	require.Zero(t, page.Total)
+
+	var blocks BlockPage
+	get(t, s, "/v1/blocks", http.StatusOK, &blocks)
+	require.Empty(t, blocks.Items)
+
+	get(t, s, "/v1/rollups/head", http.StatusNotFound, nil)
+}
+
+func TestNodeEndpoints(t *testing.T) {
+	s, _ := newTestServer(t, 0)
+
+	var info NodeInfo
+	get(t, s, "/v1/node", http.StatusOK, &info)
+	require.Equal(t, gethcommon.HexToAddress("0x03").Hex(), info.ID)
+	require.Equal(t, common.Sequencer.String(), info.NodeType)
+	require.False(t, info.Healthy)
+	require.Len(t, info.HealthErrors, 1)
+	require.True(t, info.ReadOnlyMode)
+	require.Equal(t, "maintenance", info.ReadOnlyReason)
+	require.False(t, info.Startup.Completed)
+	require.Equal(t, int64(3000), info.Startup.DurationMs)
+	require.Equal(t, []StartupPhase{{Phase: "enclaveWait", DurationMs: 1500, Completed: true}}, info.Startup.Phases)
+
+	var networkConfig NetworkConfig
+	get(t, s, "/v1/network/config", http.StatusOK, &networkConfig)
+	require.Equal(t, gethcommon.HexToAddress("0x01").Hex(), networkConfig.ManagementContractAddress)
+	require.Equal(t, gethcommon.HexToAddress("0x02").Hex(), networkConfig.ImportantContracts["L1Bridge"])
+}
+
+func TestOpenAPISpecDescribesAllRoutes(t *testing.T) {
+	s, _ := newTestServer(t, 0)
+
+	var spec struct {
+		OpenAPI    string                                       `json:"openapi"`
+		Paths      map[string]map[string]map[string]interface{} `json:"paths"`
+		Components struct {
+			Schemas map[string]interface{} `json:"schemas"`
+		} `json:"components"`
+	}
+	get(t, s, "/v1/openapi.json", http.StatusOK, &spec)
+	require.Equal(t, openAPIVersion, spec.OpenAPI)
+
+	for _, rt := range s.routes {
+		operation, ok := spec.Paths[rt.path]["get"]
+		require.True(t, ok, "route %s is not in the spec", rt.path)
+		require.Equal(t, rt.operationID, operation["operationId"])
+	}
+
+	// every schema reference must resolve
+	for _, ref := range strings.Split(string(s.spec), `"$ref": "`)[1:] {
+		name := strings.TrimPrefix(ref[:strings.Index(ref, `"`)], schemaRefRoot)
+		require.Contains(t, spec.Components.Schemas, name)
+	}
+}
@@ -0,0 +1,206 @@
+package clientrest
+
+import (
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/host"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// The types returned by the REST API. They are decoupled from the internal types, so that the API stays stable when
+// the internal types change, and only use plain JSON types: the hashes and addresses are 0x-prefixed hex strings, and
+// the amounts are decimal strings, as they can exceed the range of JSON numbers.
+
+// Batch is a batch of L2 transactions.
+type Batch struct {
+	Hash             string   `json:"hash"`
+	ParentHash       string   `json:"parentHash"`
+	Number           uint64   `json:"number"`
+	SequencerOrderNo uint64   `json:"sequencerOrderNo"`
+	Timestamp        uint64   `json:"timestamp"`
+	L1Proof          string   `json:"l1Proof"`
+	StateRoot        string   `json:"stateRoot"`
+	TransactionsRoot string   `json:"transactionsRoot"`
+	ReceiptsRoot     string   `json:"receiptsRoot"`
+	GasLimit         uint64   `json:"gasLimit"`
+	GasUsed          uint64   `json:"gasUsed"`
+	BaseFee          string   `json:"baseFee"`
+	Coinbase         string   `json:"coinbase"`
+	TxHashes         []string `json:"txHashes"` // empty if the transaction data of the batch was pruned
+}
+
+// Rollup is a rollup published to the L1.
+type Rollup struct {
+	Hash              string `json:"hash"`
+	Coinbase          string `json:"coinbase"`
+	CompressionL1Head string `json:"compressionL1Head"`
+	PayloadHash       string `json:"payloadHash"`
+	LastBatchSeqNo    uint64 `json:"lastBatchSeqNo"`
+}
+
+// Block is an L1 block processed by the node.
+type Block struct {
+	Hash       string `json:"hash"`
+	ParentHash string `json:"parentHash"`
+	Number     uint64 `json:"number"`
+	Timestamp  uint64 `json:"timestamp"`
+	RollupHash string `json:"rollupHash"` // the zero hash if the block does not contain a rollup
+}
+
+// BatchPage is a page of batches, from the most recent.
+type BatchPage struct {
+	Items  []Batch `json:"items"`
+	Offset uint64  `json:"offset"`
+	Size   uint64  `json:"size"`
+	Total  uint64  `json:"total"` // the number of batches produced, including the ones that are no longer canonical
+}
+
+// BlockPage is a page of L1 blocks, from the most recent.
+type BlockPage struct {
+	Items  []Block `json:"items"`
+	Offset uint64  `json:"offset"`
+	Size   uint64  `json:"size"`
+	Total  uint64  `json:"total"` // the number of L1 blocks processed by the node
+}
+
+// NodeInfo describes the node serving the API.
+type NodeInfo struct {
+	ID             string   `json:"id"`
+	NodeType       string   `json:"nodeType"`
+	L1ChainID      int64    `json:"l1ChainId"`
+	ObscuroChainID int64    `json:"obscuroChainId"`
+	Healthy        bool     `json:"healthy"`
+	HealthErrors   []string `json:"healthErrors"`
+	ReadOnlyMode   bool     `json:"readOnlyMode"` // the sequencer stopped producing batches and publishing rollups
+	ReadOnlyReason string   `json:"readOnlyReason,omitempty"`
+	Startup        Startup  `json:"startup"`
+}
+
+// Startup is the timing of the startup of the node, until its enclave was live.
+type Startup struct {
+	Completed  bool           `json:"completed"`
+	DurationMs int64          `json:"durationMs"` // so far, if the startup is not completed
+	Phases     []StartupPhase `json:"phases"`
+}
+
+// StartupPhase is the timing of a phase of the startup: enclaveWait, secretProvisioning, l1Feed, blockIngestion or
+// p2pReady.
+type StartupPhase struct {
+	Phase      string `json:"phase"`
+	OffsetMs   int64  `json:"offsetMs"` // since the start of the node
+	DurationMs int64  `json:"durationMs"`
+	Completed  bool   `json:"completed"`
+}
+
+// NetworkConfig is the configuration shared by all the nodes of the network.
+type NetworkConfig struct {
+	ManagementContractAddress string            `json:"managementContractAddress"`
+	L1StartHash               string            `json:"l1StartHash"`
+	SequencerID               string            `json:"sequencerId"`
+	MessageBusAddress         string            `json:"messageBusAddress"`
+	L2MessageBusAddress       string            `json:"l2MessageBusAddress"`
+	ImportantContracts        map[string]string `json:"importantContracts"`
+}
+
+// Error is returned with every non-2xx response.
+type Error struct {
+	Error string `json:"error"`
+}
+
+func toBatch(header *common.BatchHeader, txHashes []gethcommon.Hash) Batch {
+	hashes := make([]string, len(txHashes))
+	for i, txHash := range txHashes {
+		hashes[i] = txHash.Hex()
+	}
+	baseFee := "0"
+	if header.BaseFee != nil {
+		baseFee = header.BaseFee.String()
+	}
+	return Batch{
+		Hash:             header.Hash().Hex(),
+		ParentHash:       header.ParentHash.Hex(),
+		Number:           header.Number.Uint64(),
+		SequencerOrderNo: header.SequencerOrderNo.Uint64(),
+		Timestamp:        header.Time,
+		L1Proof:          header.L1Proof.Hex(),
+		StateRoot:        header.Root.Hex(),
+		TransactionsRoot: header.TxHash.Hex(),
+		ReceiptsRoot:     header.ReceiptHash.Hex(),
+		GasLimit:         header.GasLimit,
+		GasUsed:          header.GasUsed,
+		BaseFee:          baseFee,
+		Coinbase:         header.Coinbase.Hex(),
+		TxHashes:         hashes,
+	}
+}
+
+func toRollup(header *common.RollupHeader) Rollup {
+	return Rollup{
+		Hash:              header.Hash().Hex(),
+		Coinbase:          header.Coinbase.Hex(),
+		CompressionL1Head: header.CompressionL1Head.Hex(),
+		PayloadHash:       header.PayloadHash.Hex(),
+		LastBatchSeqNo:    header.LastBatchSeqNo,
+	}
+}
+
+func toBlock(header *types.Header, rollupHash gethcommon.Hash) Block {
+	return Block{
+		Hash:       header.Hash().Hex(),
+		ParentHash: header.ParentHash.Hex(),
+		Number:     header.Number.Uint64(),
+		Timestamp:  header.Time,
+		RollupHash: rollupHash.Hex(),
+	}
+}
+
+func toNodeInfo(h host.Host, health *host.HealthCheck) NodeInfo {
+	cfg := h.Config()
+	healthErrors := health.Errors
+	if healthErrors == nil {
+		healthErrors = []string{}
+	}
+	return NodeInfo{
+		ID:             cfg.ID.Hex(),
+		NodeType:       cfg.NodeType.String(),
+		L1ChainID:      cfg.L1ChainID,
+		ObscuroChainID: cfg.ObscuroChainID,
+		Healthy:        health.OverallHealth,
+		HealthErrors:   healthErrors,
+		ReadOnlyMode:   health.ReadOnlyMode,
+		ReadOnlyReason: health.ReadOnlyReason,
+		Startup:        toStartup(health.Startup),
+	}
+}
+
+func toStartup(report *host.StartupReport) Startup {
+	if report == nil {
+		return Startup{Phases: []StartupPhase{}}
+	}
+	phases := make([]StartupPhase, len(report.Phases))
+	for i, phase := range report.Phases {
+		phases[i] = StartupPhase{
+			Phase:      string(phase.Phase),
+			OffsetMs:   phase.Offset.Milliseconds(),
+			DurationMs: phase.Duration.Milliseconds(),
+			Completed:  phase.Completed,
+		}
+	}
+	return Startup{Completed: report.Completed, DurationMs: report.Duration.Milliseconds(), Phases: phases}
+}
+
+func toNetworkConfig(info *common.ObscuroNetworkInfo) NetworkConfig {
+	importantContracts := make(map[string]string, len(info.ImportantContracts))
+	for name, addr := range info.ImportantContracts {
+		importantContracts[name] = addr.Hex()
+	}
+	return NetworkConfig{
+		ManagementContractAddress: info.ManagementContractAddress.Hex(),
+		L1StartHash:               info.L1StartHash.Hex(),
+		SequencerID:               info.SequencerID.Hex(),
+		MessageBusAddress:         info.MessageBusAddress.Hex(),
+		L2MessageBusAddress:       info.L2MessageBusAddress.Hex(),
+		ImportantContracts:        importantContracts,
+	}
+}
@@ -0,0 +1,224 @@
+package clientrpc
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	mathrand "math/rand"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+// the bytes of the hash of a caller that are logged, enough to tell the callers apart
+const callerHashSize = 8
+
+// RequestLog logs a sample of the client RPC requests served over HTTP: their method, latency, status and a hash of
+// their caller. The params and the results of the requests are never logged, they can be confidential.
+type RequestLog struct {
+	defaultRate float64
+	methodRates map[string]float64
+	// the callers are hashed with a salt drawn when the host starts, so that their addresses cannot be recovered by
+	// hashing all the possible addresses
+	salt   []byte
+	sample func() float64
+	logger gethlog.Logger
+}
+
+// NewRequestLog returns a request log sampling the requests at the default rate, or at the rate of their method if set
+func NewRequestLog(defaultRate float64, methodRates map[string]float64, logger gethlog.Logger) *RequestLog {
+	salt := make([]byte, 32)
+	if _, err := rand.Read(salt); err != nil {
+		panic(fmt.Sprintf("could not draw the salt of the callers - %s", err))
+	}
+	return &RequestLog{
+		defaultRate: defaultRate,
+		methodRates: methodRates,
+		salt:        salt,
+		sample:      mathrand.Float64, //nolint:gosec
+		logger:      logger,
+	}
+}
+
+// Enabled returns whether any request is logged
+func (l *RequestLog) Enabled() bool {
+	if l.defaultRate > 0 {
+		return true
+	}
+	for _, rate := range l.methodRates {
+		if rate > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// Handler logs a sample of the requests served by the handler
+func (l *RequestLog) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		r.Body = io.NopCloser(bytes.NewReader(body))
+
+		sampled := l.sampleCalls(body)
+		if len(sampled) == 0 {
+			next.ServeHTTP(w, r)
+			return
+		}
+		recorder := &responseRecorder{ResponseWriter: w, status: http.StatusOK}
+		start := time.Now()
+		next.ServeHTTP(recorder, r)
+		latency := time.Since(start)
+
+		caller := l.callerHash(r)
+		errCodes := responseErrorCodes(recorder.body.Bytes())
+		for _, call := range sampled {
+			status := "ok"
+			if recorder.status != http.StatusOK {
+				status = "http " + strconv.Itoa(recorder.status)
+			} else if code, failed := errCodes[string(call.ID)]; failed {
+				status = "error " + strconv.Itoa(code)
+			}
+			l.logger.Info("RPC request", "method", call.Method, "latency", latency, "status", status, "caller", caller)
+		}
+	})
+}
+
+// rpcCall is the part of a request that is logged
+type rpcCall struct {
+	ID     json.RawMessage `json:"id"`
+	Method string          `json:"method"`
+}
+
+// sampleCalls returns the calls of a single or batch request that are logged
+func (l *RequestLog) sampleCalls(body []byte) []rpcCall {
+	var calls []rpcCall
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		if err := json.Unmarshal(trimmed, &calls); err != nil {
+			return nil // the malformed requests are rejected by the server
+		}
+	} else {
+		var call rpcCall
+		if err := json.Unmarshal(trimmed, &call); err != nil {
+			return nil
+		}
+		calls = []rpcCall{call}
+	}
+
+	sampled := calls[:0]
+	for _, call := range calls {
+		if l.sample() < l.rate(call.Method) {
+			sampled = append(sampled, call)
+		}
+	}
+	return sampled
+}
+
+func (l *RequestLog) rate(method string) float64 {
+	if rate, found := l.methodRates[method]; found {
+		return rate
+	}
+	return l.defaultRate
+}
+
+// callerHash returns the salted hash of the address of the caller, or of the client it is forwarded for
+func (l *RequestLog) callerHash(r *http.Request) string {
+	caller := r.RemoteAddr
+	if host, _, err := net.SplitHostPort(caller); err == nil {
+		caller = host
+	}
+	if forwarded := r.Header.Get("X-Forwarded-For"); forwarded != "" {
+		caller = strings.TrimSpace(strings.Split(forwarded, ",")[0])
+	}
+	hash := sha256.Sum256(append(append([]byte{}, l.salt...), caller...))
+	return hex.EncodeToString(hash[:callerHashSize])
+}
+
+// responseErrorCodes returns the error codes of the failed calls of a single or batch response, by call ID
+func responseErrorCodes(body []byte) map[string]int {
+	type rpcResponse struct {
+		ID    json.RawMessage `json:"id"`
+		Error *struct {
+			Code int `json:"code"`
+		} `json:"error"`
+	}
+	var responses []rpcResponse
+	if trimmed := bytes.TrimSpace(body); len(trimmed) > 0 && trimmed[0] == '[' {
+		_ = json.Unmarshal(trimmed, &responses)
+	} else {
+		var response rpcResponse
+		if json.Unmarshal(trimmed, &response) == nil {
+			responses = []rpcResponse{response}
+		}
+	}
+
+	codes := map[string]int{}
+	for _, response := range responses {
+		if response.Error != nil {
+			codes[string(response.ID)] = response.Error.Code
+		}
+	}
+	return codes
+}
+
+// responseRecorder keeps a copy of the response, to find its status
+type responseRecorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *responseRecorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *responseRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+// ParseMethodRates returns the sampling rates of a comma-separated list of method=rate pairs
+func ParseMethodRates(list string) (map[string]float64, error) {
+	rates := map[string]float64{}
+	for _, pair := range strings.Split(list, ",") {
+		if pair = strings.TrimSpace(pair); pair == "" {
+			continue
+		}
+		method, rateStr, found := strings.Cut(pair, "=")
+		if !found {
+			return nil, fmt.Errorf("expected method=rate, got %q", pair)
+		}
+		rate, err := strconv.ParseFloat(strings.TrimSpace(rateStr), 64)
+		if err != nil || rate < 0 || rate > 1 {
+			return nil, fmt.Errorf("the rate of the method %s must be between 0 and 1, got %q", method, rateStr)
+		}
+		rates[strings.TrimSpace(method)] = rate
+	}
+	if len(rates) == 0 {
+		return nil, nil
+	}
+	return rates, nil
+}
+
+// FormatMethodRates returns the comma-separated list of method=rate pairs of the sampling rates
+func FormatMethodRates(rates map[string]float64) string {
+	pairs := make([]string, 0, len(rates))
+	for method, rate := range rates {
+		pairs = append(pairs, method+"="+strconv.FormatFloat(rate, 'f', -1, 64))
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
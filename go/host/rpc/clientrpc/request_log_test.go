@@ -0,0 +1,126 @@
+package clientrpc
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+type testService struct{}
+
+func (testService) Echo(secret string) string { return secret }
+
+func (testService) Fail() error { return errors.New("failed") }
+
+// newTestRequestLog returns a request log serving the test service, and the records it logs
+func newTestRequestLog(t *testing.T, defaultRate float64, methodRates map[string]float64) (*httptest.Server, *RequestLog, *[]*gethlog.Record) {
+	var records []*gethlog.Record
+	logger := gethlog.New()
+	logger.SetHandler(gethlog.FuncHandler(func(r *gethlog.Record) error {
+		records = append(records, r)
+		return nil
+	}))
+	requestLog := NewRequestLog(defaultRate, methodRates, logger)
+
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("test", testService{}))
+	server := httptest.NewServer(requestLog.Handler(srv))
+	t.Cleanup(server.Close)
+	return server, requestLog, &records
+}
+
+func post(t *testing.T, url string, body string, forwardedFor string) {
+	req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+	require.NoError(t, err)
+	req.Header.Set("Content-Type", "application/json")
+	if forwardedFor != "" {
+		req.Header.Set("X-Forwarded-For", forwardedFor)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+}
+
+func recordValue(r *gethlog.Record, key string) any {
+	for i := 0; i+1 < len(r.Ctx); i += 2 {
+		if r.Ctx[i] == key {
+			return r.Ctx[i+1]
+		}
+	}
+	return nil
+}
+
+func TestRequestLogLogsTheStatusWithoutThePayloads(t *testing.T) {
+	server, _, records := newTestRequestLog(t, 1, nil)
+
+	post(t, server.URL, `{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["a confidential param"]}`, "")
+	post(t, server.URL, `[{"jsonrpc":"2.0","id":2,"method":"test_fail"},{"jsonrpc":"2.0","id":3,"method":"test_echo","params":["x"]}]`, "")
+
+	require.Len(t, *records, 3)
+	require.Equal(t, "test_echo", recordValue((*records)[0], "method"))
+	require.Equal(t, "ok", recordValue((*records)[0], "status"))
+	require.Equal(t, "test_fail", recordValue((*records)[1], "method"))
+	require.Equal(t, "error -32000", recordValue((*records)[1], "status"))
+	require.Equal(t, "ok", recordValue((*records)[2], "status"))
+	for _, record := range *records {
+		require.NotContains(t, fmt.Sprint(record.Ctx...), "confidential")
+	}
+}
+
+func TestRequestLogSamplesByMethod(t *testing.T) {
+	server, requestLog, records := newTestRequestLog(t, 0, map[string]float64{"test_fail": 0.5})
+	draws := []float64{0.4, 0.6}
+	requestLog.sample = func() float64 {
+		draw := draws[0]
+		draws = draws[1:]
+		return draw
+	}
+	require.True(t, requestLog.Enabled())
+
+	post(t, server.URL, `{"jsonrpc":"2.0","id":1,"method":"test_fail"}`, "")
+	post(t, server.URL, `{"jsonrpc":"2.0","id":2,"method":"test_fail"}`, "")
+	require.Len(t, *records, 1)
+
+	requestLog.sample = func() float64 { return 0 }
+	post(t, server.URL, `{"jsonrpc":"2.0","id":3,"method":"test_echo","params":["x"]}`, "")
+	require.Len(t, *records, 1, "the methods without a rate are logged at the default rate")
+}
+
+func TestRequestLogHashesTheCaller(t *testing.T) {
+	server, _, records := newTestRequestLog(t, 1, nil)
+
+	post(t, server.URL, `{"jsonrpc":"2.0","id":1,"method":"test_echo","params":["x"]}`, "203.0.113.7, 10.0.0.1")
+	post(t, server.URL, `{"jsonrpc":"2.0","id":2,"method":"test_echo","params":["x"]}`, "203.0.113.7")
+	post(t, server.URL, `{"jsonrpc":"2.0","id":3,"method":"test_echo","params":["x"]}`, "")
+
+	require.Len(t, *records, 3)
+	caller := recordValue((*records)[0], "caller")
+	require.Len(t, caller, 2*callerHashSize)
+	require.Equal(t, caller, recordValue((*records)[1], "caller"))
+	require.NotEqual(t, caller, recordValue((*records)[2], "caller"))
+	require.NotContains(t, caller, "203.0.113.7")
+}
+
+func TestParseMethodRates(t *testing.T) {
+	rates, err := ParseMethodRates("eth_call=0.1, eth_sendRawTransaction=1")
+	require.NoError(t, err)
+	require.Equal(t, map[string]float64{"eth_call": 0.1, "eth_sendRawTransaction": 1}, rates)
+	require.Equal(t, "eth_call=0.1,eth_sendRawTransaction=1", FormatMethodRates(rates))
+
+	rates, err = ParseMethodRates("")
+	require.NoError(t, err)
+	require.Nil(t, rates)
+
+	_, err = ParseMethodRates("eth_call")
+	require.Error(t, err)
+	_, err = ParseMethodRates("eth_call=2")
+	require.Error(t, err)
+}
@@ -1,16 +1,25 @@
 package clientrpc
 
 import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"time"
+
 	"github.com/ethereum/go-ethereum/node"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/config"
+	"golang.org/x/exp/slices"
 
 	gethlog "github.com/ethereum/go-ethereum/log"
 )
 
 const (
 	allOrigins = "*"
+
+	drainPollInterval = 50 * time.Millisecond
 )
 
 // Server is the layer responsible for handling RPC requests from Obscuro client applications.
@@ -18,12 +27,22 @@ type Server interface {
 	Start() error
 	Stop()
 	RegisterAPIs(apis []rpc.API)
+	// Drain rejects the new requests over HTTP, and waits for the ones in progress to complete, or for the context to be
+	// done. The websocket and IPC connections are closed when the server is stopped.
+	Drain(ctx context.Context) error
 }
 
-// An implementation of `host.Server` that reuses the Geth `node` package for client communication.
+// An implementation of `host.Server` that reuses the Geth `node` package for client communication. The node serves the
+// same APIs over HTTP, websockets and IPC, each transport being restricted to its own modules (i.e. API namespaces).
+// The IPC endpoint serves all the modules, its access is controlled by the permissions of its unix socket.
 type serverImpl struct {
-	node   *node.Node
-	logger gethlog.Logger
+	node *node.Node
+	// serves the requests over HTTP, through the drain gate, the request log and the size limit, with the modules served
+	// over HTTP
+	httpRPC     *rpc.Server
+	httpModules []string
+	gate        *drainGate
+	logger      gethlog.Logger
 }
 
 func NewServer(config *config.HostConfig, logger gethlog.Logger) Server {
@@ -35,24 +54,57 @@ func NewServer(config *config.HostConfig, logger gethlog.Logger) Server {
 		rpcConfig.HTTPPort = int(config.ClientRPCPortHTTP)
 		// todo (@pedro) - review if this poses a security issue
 		rpcConfig.HTTPVirtualHosts = []string{allOrigins}
+		rpcConfig.HTTPModules = config.ClientRPCHTTPModules
 	}
 	if config.HasClientRPCWebsockets {
 		rpcConfig.WSHost = config.ClientRPCHost
 		rpcConfig.WSPort = int(config.ClientRPCPortWS)
 		// todo (@pedro) - review if this poses a security issue
 		rpcConfig.WSOrigins = []string{allOrigins}
+		rpcConfig.WSModules = config.ClientRPCWSModules
 	}
+	// a bare file name is resolved in the temp directory
+	rpcConfig.IPCPath = config.ClientRPCIPCPath
 
 	rpcServerNode, err := node.New(&rpcConfig)
 	if err != nil {
 		logger.Crit("could not create new client server.", log.ErrKey, err)
 	}
 
-	return &serverImpl{node: rpcServerNode, logger: logger}
+	server := &serverImpl{node: rpcServerNode, httpModules: config.ClientRPCHTTPModules, gate: &drainGate{}, logger: logger}
+	requestLog := NewRequestLog(config.RequestLogSampleRate, config.RequestLogMethodRates, logger.New(log.CmpKey, log.HostRPCCmp))
+	if config.HasClientRPCHTTP {
+		// the handlers registered on the node take precedence over its own RPC handler, so the RPC requests served over
+		// HTTP go through the drain gate, the request log and the size limit, and are then served by the same APIs, with
+		// the same virtual hosts
+		server.httpRPC = rpc.NewServer()
+		var handler http.Handler = server.httpRPC
+		if requestLog.Enabled() {
+			handler = requestLog.Handler(handler)
+		}
+		if config.ClientRPCMaxRequestSize > 0 {
+			handler = maxRequestSizeHandler(handler, config.ClientRPCMaxRequestSize)
+		}
+		handler = server.gate.handler(handler)
+		rpcServerNode.RegisterHandler("rpc-http", "/", node.NewHTTPHandlerStack(handler, nil, rpcConfig.HTTPVirtualHosts, nil))
+	}
+
+	return server
 }
 
 func (s *serverImpl) RegisterAPIs(apis []rpc.API) {
 	s.node.RegisterAPIs(apis)
+	if s.httpRPC == nil {
+		return
+	}
+	for _, api := range apis {
+		if len(s.httpModules) > 0 && !slices.Contains(s.httpModules, api.Namespace) {
+			continue
+		}
+		if err := s.httpRPC.RegisterName(api.Namespace, api.Service); err != nil {
+			s.logger.Crit("could not register the API of the client server.", log.ErrKey, err)
+		}
+	}
 }
 
 func (s *serverImpl) Start() error {
@@ -60,8 +112,65 @@ func (s *serverImpl) Start() error {
 }
 
 func (s *serverImpl) Stop() {
+	if s.httpRPC != nil {
+		s.httpRPC.Stop()
+	}
 	err := s.node.Close()
 	if err != nil {
 		s.logger.Crit("could not stop node client server.", log.ErrKey, err)
 	}
 }
+
+func (s *serverImpl) Drain(ctx context.Context) error {
+	return s.gate.drain(ctx)
+}
+
+// drainGate counts the requests in progress, and rejects the new ones once the server is draining
+type drainGate struct {
+	draining atomic.Bool
+	inFlight atomic.Int64
+}
+
+func (g *drainGate) handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		g.inFlight.Add(1)
+		defer g.inFlight.Add(-1)
+		// checked after the request is counted, so that a request accepted is always awaited by the drain
+		if g.draining.Load() {
+			w.Header().Set("Connection", "close")
+			http.Error(w, "the server is shutting down", http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// drain rejects the new requests, and waits for the requests in progress to complete, or for the context to be done
+func (g *drainGate) drain(ctx context.Context) error {
+	g.draining.Store(true)
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for {
+		inProgress := g.inFlight.Load()
+		if inProgress == 0 {
+			return nil
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%d RPC requests still in progress - %w", inProgress, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// maxRequestSizeHandler rejects the requests whose body is larger than maxSize, before they are read
+func maxRequestSizeHandler(next http.Handler, maxSize int64) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.ContentLength > maxSize {
+			http.Error(w, fmt.Sprintf("content length too large (%d>%d)", r.ContentLength, maxSize), http.StatusRequestEntityTooLarge)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxSize)
+		next.ServeHTTP(w, r)
+	})
+}
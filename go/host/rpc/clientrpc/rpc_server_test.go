@@ -0,0 +1,132 @@
+package clientrpc
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/config"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+// TestServerTransports checks that the APIs are served over HTTP and IPC at once, and that the HTTP transport only
+// serves its modules, with and without the request log
+func TestServerTransports(t *testing.T) {
+	for _, sampleRate := range []float64{0, 1} {
+		ipcPath := filepath.Join(t.TempDir(), "ten.ipc")
+		server := NewServer(&config.HostConfig{
+			HasClientRPCHTTP:     true,
+			ClientRPCHost:        "127.0.0.1",
+			ClientRPCHTTPModules: []string{"test"},
+			ClientRPCIPCPath:     ipcPath,
+			RequestLogSampleRate: sampleRate,
+		}, gethlog.New())
+		server.RegisterAPIs([]rpc.API{
+			{Namespace: "test", Service: testService{}},
+			{Namespace: "admin", Service: testService{}},
+		})
+		require.NoError(t, server.Start())
+
+		httpClient, err := rpc.Dial(server.(*serverImpl).node.HTTPEndpoint())
+		require.NoError(t, err)
+		ipcClient, err := rpc.Dial(ipcPath)
+		require.NoError(t, err)
+
+		var echoed string
+		require.NoError(t, httpClient.Call(&echoed, "test_echo", "hello"))
+		require.Equal(t, "hello", echoed)
+		require.Error(t, httpClient.Call(&echoed, "admin_echo", "hello"))
+
+		// the IPC endpoint serves all the modules
+		require.NoError(t, ipcClient.Call(&echoed, "admin_echo", "local"))
+		require.Equal(t, "local", echoed)
+
+		httpClient.Close()
+		ipcClient.Close()
+		server.Stop()
+	}
+}
+
+// TestServerMaxRequestSize checks that the requests over HTTP larger than the limit are rejected
+func TestServerMaxRequestSize(t *testing.T) {
+	server := NewServer(&config.HostConfig{
+		HasClientRPCHTTP:        true,
+		ClientRPCHost:           "127.0.0.1",
+		ClientRPCMaxRequestSize: 1024,
+	}, gethlog.New())
+	server.RegisterAPIs([]rpc.API{{Namespace: "test", Service: testService{}}})
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpc.Dial(server.(*serverImpl).node.HTTPEndpoint())
+	require.NoError(t, err)
+	defer client.Close()
+
+	var echoed string
+	require.NoError(t, client.Call(&echoed, "test_echo", "hello"))
+	require.Equal(t, "hello", echoed)
+	err = client.Call(&echoed, "test_echo", strings.Repeat("a", 2048))
+	require.ErrorContains(t, err, "content length too large")
+}
+
+// blockingService holds its calls until they are released
+type blockingService struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s blockingService) Wait() string {
+	s.started <- struct{}{}
+	<-s.release
+	return "released"
+}
+
+// TestServerDrain checks that a draining server rejects the new requests over HTTP, and waits for the ones in progress
+func TestServerDrain(t *testing.T) {
+	server := NewServer(&config.HostConfig{
+		HasClientRPCHTTP: true,
+		ClientRPCHost:    "127.0.0.1",
+	}, gethlog.New())
+	service := blockingService{started: make(chan struct{}), release: make(chan struct{})}
+	server.RegisterAPIs([]rpc.API{
+		{Namespace: "test", Service: testService{}},
+		{Namespace: "block", Service: service},
+	})
+	require.NoError(t, server.Start())
+	defer server.Stop()
+
+	client, err := rpc.Dial(server.(*serverImpl).node.HTTPEndpoint())
+	require.NoError(t, err)
+	defer client.Close()
+
+	inProgress := make(chan error, 1)
+	go func() {
+		var result string
+		inProgress <- client.Call(&result, "block_wait")
+	}()
+	<-service.started
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- server.Drain(context.Background())
+	}()
+	require.Eventually(t, func() bool {
+		var echoed string
+		err := client.Call(&echoed, "test_echo", "hello")
+		return err != nil && strings.Contains(err.Error(), "503")
+	}, time.Second, 10*time.Millisecond)
+	select {
+	case <-drained:
+		t.Fatal("the server was drained with a request in progress")
+	default:
+	}
+
+	close(service.release)
+	require.NoError(t, <-inProgress)
+	require.NoError(t, <-drained)
+}
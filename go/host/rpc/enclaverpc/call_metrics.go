@@ -0,0 +1,32 @@
+package enclaverpc
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"google.golang.org/grpc"
+
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+// callMetrics exports the latency and the errors of the enclave calls, by RPC method
+type callMetrics struct {
+	registry gethmetrics.Registry
+}
+
+func (m *callMetrics) interceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	start := time.Now()
+	err := invoker(ctx, method, req, reply, cc, opts...)
+	name := "host/enclave/rpc/" + methodName(method)
+	gethmetrics.GetOrRegisterTimer(name+"/latency", m.registry).UpdateSince(start)
+	if err != nil {
+		gethmetrics.GetOrRegisterCounter(name+"/errors", m.registry).Inc(1)
+	}
+	return err
+}
+
+// methodName strips the service from the full gRPC method name, e.g. /generated.EnclaveProto/SubmitL1Block
+func methodName(fullMethod string) string {
+	return fullMethod[strings.LastIndex(fullMethod, "/")+1:]
+}
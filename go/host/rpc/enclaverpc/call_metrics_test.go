@@ -0,0 +1,38 @@
+package enclaverpc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestCallMetrics(t *testing.T) {
+	registry := gethmetrics.NewRegistry()
+	metrics := &callMetrics{registry: registry}
+	succeed := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		time.Sleep(time.Millisecond)
+		return nil
+	}
+	fail := func(context.Context, string, interface{}, interface{}, *grpc.ClientConn, ...grpc.CallOption) error {
+		return errors.New("enclave unavailable")
+	}
+
+	require.NoError(t, metrics.interceptor(context.Background(), "/generated.EnclaveProto/SubmitL1Block", nil, nil, nil, succeed))
+	require.NoError(t, metrics.interceptor(context.Background(), "/generated.EnclaveProto/SubmitL1Block", nil, nil, nil, succeed))
+	require.Error(t, metrics.interceptor(context.Background(), "/generated.EnclaveProto/Status", nil, nil, nil, fail))
+
+	if !gethmetrics.Enabled {
+		return
+	}
+	latency := registry.Get("host/enclave/rpc/SubmitL1Block/latency").(gethmetrics.Timer)
+	require.Equal(t, int64(2), latency.Count())
+	require.GreaterOrEqual(t, latency.Min(), time.Millisecond.Nanoseconds())
+	require.Nil(t, registry.Get("host/enclave/rpc/SubmitL1Block/errors"))
+	require.Equal(t, int64(1), registry.Get("host/enclave/rpc/Status/errors").(gethmetrics.Counter).Count())
+}
@@ -0,0 +1,79 @@
+package enclaverpc
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/backoff"
+	"google.golang.org/grpc/connectivity"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+// the reconnections to the enclave back off exponentially, from the base delay up to the max delay
+var _reconnectBackoff = backoff.Config{
+	BaseDelay:  500 * time.Millisecond,
+	Multiplier: 2,
+	Jitter:     0.2,
+	MaxDelay:   30 * time.Second,
+}
+
+// connectionOptions returns the dial options keeping the connection to the enclave up. The calls made while the enclave
+// is unreachable (e.g. while it restarts) wait for the connection within their deadline, rather than failing at once.
+func connectionOptions() []grpc.DialOption {
+	return []grpc.DialOption{
+		grpc.WithConnectParams(grpc.ConnectParams{Backoff: _reconnectBackoff, MinConnectTimeout: 5 * time.Second}),
+		grpc.WithDefaultCallOptions(grpc.WaitForReady(true)),
+	}
+}
+
+// connectionMonitor reconnects to the enclave when the connection is lost. gRPC only reconnects a dropped connection on
+// the next call, and the health checks of the host fail fast without calling the enclave while it is not connected, so
+// the host would never see the enclave again after a restart.
+type connectionMonitor struct {
+	connection *grpc.ClientConn
+	reconnects gethmetrics.Counter
+	connected  gethmetrics.Gauge
+	logger     gethlog.Logger
+}
+
+func newConnectionMonitor(connection *grpc.ClientConn, registry gethmetrics.Registry, logger gethlog.Logger) *connectionMonitor {
+	return &connectionMonitor{
+		connection: connection,
+		reconnects: gethmetrics.NewRegisteredCounter("host/enclave/rpc/reconnects", registry),
+		connected:  gethmetrics.NewRegisteredGauge("host/enclave/rpc/connected", registry),
+		logger:     logger,
+	}
+}
+
+// run follows the state of the connection until it is closed
+func (m *connectionMonitor) run() {
+	var lostAt time.Time
+	state := m.connection.GetState()
+	for state != connectivity.Shutdown {
+		switch state {
+		case connectivity.Ready:
+			m.connected.Update(1)
+			if !lostAt.IsZero() {
+				m.reconnects.Inc(1)
+				m.logger.Info("Reconnected to the enclave", "outage", time.Since(lostAt))
+				lostAt = time.Time{}
+			}
+		case connectivity.Idle:
+			// gRPC backs off between the attempts to connect
+			m.connection.Connect()
+			fallthrough
+		case connectivity.Connecting, connectivity.TransientFailure:
+			m.connected.Update(0)
+			if lostAt.IsZero() {
+				lostAt = time.Now()
+				m.logger.Warn("Lost the connection to the enclave, reconnecting", "state", state.String())
+			}
+		}
+		m.connection.WaitForStateChange(context.Background(), state)
+		state = m.connection.GetState()
+	}
+	m.connected.Update(0)
+}
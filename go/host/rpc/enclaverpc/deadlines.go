@@ -0,0 +1,139 @@
+package enclaverpc
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+const (
+	// the latencies of the latest calls of each method the deadlines are derived from
+	_latencySamples = 200
+	// the calls of a method take the initial timeout until this many of them were observed
+	_minLatencySamples = 20
+	// the deadline of a method is recomputed every this many calls
+	_deadlineRecomputeInterval = 10
+	// the deadline of a method is this multiple of the p99 of its latencies, so its slow calls are not cut off
+	_deadlineP99Factor = 3
+)
+
+// callDeadlines sets the deadline of each enclave call from the latencies observed for its method, so that a quick
+// call (e.g. a health check) fails fast when the enclave hangs, while a slow one (e.g. the submission of an L1 block
+// while catching up) is given the time it usually needs. The deadline is the p99 of the latest calls times a factor,
+// within the min and max timeouts, and it doubles when a call runs out of time, so a slowing enclave is caught up with.
+// The calls of a method take the initial timeout until enough of them were observed.
+type callDeadlines struct {
+	initial time.Duration
+	min     time.Duration
+	max     time.Duration
+
+	lock     sync.Mutex
+	methods  map[string]*methodLatencies
+	registry gethmetrics.Registry
+}
+
+type methodLatencies struct {
+	samples  []time.Duration // a ring of the latest latencies
+	next     int             // the position of the next sample, once the ring is full
+	pending  int             // the samples recorded since the deadline was last computed
+	deadline time.Duration   // 0 until enough samples were recorded
+}
+
+// newCallDeadlines returns the deadlines of the calls. An unset min timeout leaves the deadlines no shorter than the
+// initial one, and the max timeout is at least the initial one.
+func newCallDeadlines(initial time.Duration, minTimeout time.Duration, maxTimeout time.Duration, registry gethmetrics.Registry) *callDeadlines {
+	if minTimeout <= 0 || minTimeout > initial {
+		minTimeout = initial
+	}
+	if maxTimeout < initial {
+		maxTimeout = initial
+	}
+	return &callDeadlines{
+		initial:  initial,
+		min:      minTimeout,
+		max:      maxTimeout,
+		methods:  map[string]*methodLatencies{},
+		registry: registry,
+	}
+}
+
+func (d *callDeadlines) interceptor(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+	name := methodName(method)
+	deadline := d.deadline(name)
+	callCtx, cancel := context.WithTimeout(ctx, deadline)
+	defer cancel()
+
+	// the calls waiting for the enclave to reconnect, or cut off by the deadline of their caller, tell nothing about the
+	// latency of the method
+	connected := cc == nil || cc.GetState() == connectivity.Ready
+	start := time.Now()
+	err := invoker(callCtx, method, req, reply, cc, opts...)
+	if !connected || ctx.Err() != nil {
+		return err
+	}
+	d.record(name, time.Since(start), errors.Is(callCtx.Err(), context.DeadlineExceeded))
+	return err
+}
+
+// deadline returns the deadline of the next call of the method
+func (d *callDeadlines) deadline(method string) time.Duration {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if latencies, found := d.methods[method]; found && latencies.deadline > 0 {
+		return latencies.deadline
+	}
+	return d.initial
+}
+
+func (d *callDeadlines) record(method string, latency time.Duration, timedOut bool) {
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	latencies, found := d.methods[method]
+	if !found {
+		latencies = &methodLatencies{}
+		d.methods[method] = latencies
+	}
+	if len(latencies.samples) < _latencySamples {
+		latencies.samples = append(latencies.samples, latency)
+	} else {
+		latencies.samples[latencies.next] = latency
+		latencies.next = (latencies.next + 1) % _latencySamples
+	}
+	latencies.pending++
+
+	switch {
+	case timedOut && latencies.deadline > 0:
+		latencies.deadline = d.clamp(2 * latencies.deadline)
+	case len(latencies.samples) >= _minLatencySamples && (latencies.deadline == 0 || latencies.pending >= _deadlineRecomputeInterval):
+		latencies.deadline = d.clamp(_deadlineP99Factor * p99(latencies.samples))
+	default:
+		return
+	}
+	latencies.pending = 0
+	gethmetrics.GetOrRegisterGauge("host/enclave/rpc/"+method+"/deadline", d.registry).Update(latencies.deadline.Milliseconds())
+}
+
+func (d *callDeadlines) clamp(deadline time.Duration) time.Duration {
+	if deadline < d.min {
+		return d.min
+	}
+	if deadline > d.max {
+		return d.max
+	}
+	return deadline
+}
+
+// returns the 99th percentile of the latencies, by the nearest-rank method
+func p99(latencies []time.Duration) time.Duration {
+	sorted := append([]time.Duration{}, latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := (99*len(sorted) + 99) / 100
+	return sorted[rank-1]
+}
@@ -0,0 +1,68 @@
+package enclaverpc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
+)
+
+func TestCallDeadlines(t *testing.T) {
+	deadlines := newCallDeadlines(10*time.Second, time.Second, time.Minute, gethmetrics.NewRegistry())
+
+	// the calls take the initial timeout until enough of them were observed
+	for i := 0; i < _minLatencySamples-1; i++ {
+		deadlines.record("HealthCheck", time.Millisecond, false)
+		deadlines.record("SubmitL1Block", 20*time.Second, false)
+	}
+	require.Equal(t, 10*time.Second, deadlines.deadline("HealthCheck"))
+	require.Equal(t, 10*time.Second, deadlines.deadline("SubmitL1Block"))
+
+	// then the quick calls are bounded by the min timeout, and the slow ones are given three times their p99
+	deadlines.record("HealthCheck", time.Millisecond, false)
+	deadlines.record("SubmitL1Block", 20*time.Second, false)
+	require.Equal(t, time.Second, deadlines.deadline("HealthCheck"))
+	require.Equal(t, time.Minute, deadlines.deadline("SubmitL1Block"))
+
+	for i := 0; i < _deadlineRecomputeInterval; i++ {
+		deadlines.record("SubmitL1Block", 5*time.Second, false)
+	}
+	require.Equal(t, time.Minute, deadlines.deadline("SubmitL1Block"), "the p99 still covers the slow calls")
+
+	// a call running out of time doubles the deadline
+	deadlines.record("HealthCheck", time.Second, true)
+	require.Equal(t, 2*time.Second, deadlines.deadline("HealthCheck"))
+}
+
+func TestCallDeadlinesWithoutBounds(t *testing.T) {
+	// without a min timeout, the deadlines are never shorter than the initial one
+	deadlines := newCallDeadlines(5*time.Minute, 0, 0, gethmetrics.NewRegistry())
+	for i := 0; i < _minLatencySamples; i++ {
+		deadlines.record("Status", time.Millisecond, false)
+	}
+	require.Equal(t, 5*time.Minute, deadlines.deadline("Status"))
+}
+
+func TestCallDeadlinesInterceptor(t *testing.T) {
+	deadlines := newCallDeadlines(50*time.Millisecond, 0, 0, gethmetrics.NewRegistry())
+	hang := func(ctx context.Context, _ string, _ interface{}, _ interface{}, _ *grpc.ClientConn, _ ...grpc.CallOption) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	start := time.Now()
+	err := deadlines.interceptor(context.Background(), "/generated.EnclaveProto/Status", nil, nil, nil, hang)
+	require.ErrorIs(t, err, context.DeadlineExceeded)
+	require.Less(t, time.Since(start), time.Second)
+	require.Len(t, deadlines.methods["Status"].samples, 1)
+
+	// the calls cancelled by their caller are not recorded
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	require.Error(t, deadlines.interceptor(ctx, "/generated.EnclaveProto/Status", nil, nil, nil, hang))
+	require.Len(t, deadlines.methods["Status"].samples, 1)
+}
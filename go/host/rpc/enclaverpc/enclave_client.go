@@ -4,7 +4,9 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"math/big"
 	"time"
 
@@ -13,6 +15,7 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/codec"
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/common/measure"
 	"github.com/ten-protocol/go-ten/go/common/retry"
@@ -29,6 +32,7 @@ import (
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	gethlog "github.com/ethereum/go-ethereum/log"
+	gethmetrics "github.com/ethereum/go-ethereum/metrics"
 	gethrpc "github.com/ethereum/go-ethereum/rpc"
 )
 
@@ -37,24 +41,64 @@ type Client struct {
 	protoClient generated.EnclaveProtoClient
 	connection  *grpc.ClientConn
 	config      *config.HostConfig
+	deadlines   *callDeadlines
 	logger      gethlog.Logger
 }
 
-func NewClient(config *config.HostConfig, logger gethlog.Logger) common.Enclave {
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(insecure.NewCredentials())}
-	connection, err := grpc.Dial(config.EnclaveRPCAddress, opts...)
+// NewClient connects to the primary enclave of the host, and waits until it is available
+func NewClient(config *config.HostConfig, logger gethlog.Logger, regMetrics gethmetrics.Registry) common.Enclave {
+	return dial(config, config.EnclaveRPCAddress, true, logger, regMetrics)
+}
+
+// NewStandbyClients connects to the hot standby enclaves of the host. Unlike the primary enclave, they are not waited
+// for: the host feeds them once they are available
+func NewStandbyClients(config *config.HostConfig, logger gethlog.Logger, regMetrics gethmetrics.Registry) []common.Enclave {
+	clients := make([]common.Enclave, len(config.StandbyEnclaveAddresses))
+	for i, address := range config.StandbyEnclaveAddresses {
+		registry := regMetrics
+		if regMetrics != nil {
+			registry = gethmetrics.NewPrefixedChildRegistry(regMetrics, fmt.Sprintf("standby%d/", i+1))
+		}
+		clients[i] = dial(config, address, false, logger.New("standbyEnclave", i+1), registry)
+	}
+	return clients
+}
+
+func dial(config *config.HostConfig, address string, waitForConnection bool, logger gethlog.Logger, regMetrics gethmetrics.Registry) common.Enclave {
+	callMetrics := &callMetrics{registry: regMetrics}
+	deadlines := newCallDeadlines(config.EnclaveRPCTimeout, config.EnclaveRPCMinTimeout, config.EnclaveRPCMaxTimeout, regMetrics)
+	opts := append([]grpc.DialOption{
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithChainUnaryInterceptor(callMetrics.interceptor, deadlines.interceptor),
+	}, connectionOptions()...)
+	connection, err := grpc.Dial(address, opts...)
 	if err != nil {
 		logger.Crit("Failed to connect to enclave RPC service.", log.ErrKey, err)
 	}
 	connection.Connect()
+	if waitForConnection {
+		waitUntilReady(connection, address, logger)
+	}
+	go newConnectionMonitor(connection, regMetrics, logger).run()
+
+	return &Client{
+		protoClient: generated.NewEnclaveProtoClient(connection),
+		connection:  connection,
+		config:      config,
+		deadlines:   deadlines,
+		logger:      logger,
+	}
+}
+
+// waits for the RPC connection to be ready
+func waitUntilReady(connection *grpc.ClientConn, address string, logger gethlog.Logger) {
 	// perform an initial sleep because that Connect() method is not blocking and the retry immediately checks the status
 	time.Sleep(500 * time.Millisecond)
 
-	// We wait for the RPC connection to be ready.
-	err = retry.Do(func() error {
+	err := retry.Do(func() error {
 		currState := connection.GetState()
 		if currState != connectivity.Ready {
-			logger.Info("retrying connection until enclave is available", "status", currState.String(), "rpcAddr", config.EnclaveRPCAddress)
+			logger.Info("retrying connection until enclave is available", "status", currState.String(), "rpcAddr", address)
 			connection.Connect()
 			return fmt.Errorf("connection is not ready, status=%s", currState)
 		}
@@ -66,13 +110,12 @@ func NewClient(config *config.HostConfig, logger gethlog.Logger) common.Enclave
 		// this should not happen as we retry forever...
 		logger.Crit("failed to connect to enclave", log.ErrKey, err)
 	}
+}
 
-	return &Client{
-		protoClient: generated.NewEnclaveProtoClient(connection),
-		connection:  connection,
-		config:      config,
-		logger:      logger,
-	}
+// callContext returns the context of a unary enclave call. Its timeout is the longest deadline, which the deadlines
+// interceptor shortens to the one of the RPC method.
+func (c *Client) callContext() (context.Context, context.CancelFunc) {
+	return context.WithTimeout(context.Background(), c.deadlines.max)
 }
 
 func (c *Client) StopClient() common.SystemError {
@@ -85,10 +128,11 @@ func (c *Client) Status() (common.Status, common.SystemError) {
 		return common.Status{StatusCode: common.Unavailable}, syserr.NewInternalError(fmt.Errorf("RPC connection is not ready"))
 	}
 
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
-	response, err := c.protoClient.Status(timeoutCtx, &generated.StatusRequest{})
+	// the status tells whether the enclave is reachable, so it does not wait for the connection
+	response, err := c.protoClient.Status(timeoutCtx, &generated.StatusRequest{}, grpc.WaitForReady(false))
 	if err != nil {
 		return common.Status{StatusCode: common.Unavailable}, syserr.NewRPCError(err)
 	}
@@ -96,15 +140,21 @@ func (c *Client) Status() (common.Status, common.SystemError) {
 		return common.Status{StatusCode: common.Unavailable}, syserr.NewInternalError(fmt.Errorf("%s", response.SystemError.ErrorString))
 	}
 
+	var enclaveTime time.Time
+	if response.Time != 0 {
+		enclaveTime = time.UnixMilli(response.Time)
+	}
 	return common.Status{
-		StatusCode: common.StatusCode(response.StatusCode),
-		L1Head:     gethcommon.BytesToHash(response.L1Head),
-		L2Head:     big.NewInt(0).SetBytes(response.L2Head),
+		StatusCode:     common.StatusCode(response.StatusCode),
+		L1Head:         gethcommon.BytesToHash(response.L1Head),
+		L2Head:         big.NewInt(0).SetBytes(response.L2Head),
+		RollupInterval: time.Duration(response.RollupInterval) * time.Second,
+		Time:           enclaveTime,
 	}, nil
 }
 
 func (c *Client) Attestation() (*common.AttestationReport, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.Attestation(timeoutCtx, &generated.AttestationRequest{})
@@ -118,7 +168,7 @@ func (c *Client) Attestation() (*common.AttestationReport, common.SystemError) {
 }
 
 func (c *Client) GenerateSecret() (common.EncryptedSharedEnclaveSecret, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.GenerateSecret(timeoutCtx, &generated.GenerateSecretRequest{})
@@ -133,7 +183,7 @@ func (c *Client) GenerateSecret() (common.EncryptedSharedEnclaveSecret, common.S
 }
 
 func (c *Client) InitEnclave(secret common.EncryptedSharedEnclaveSecret) common.SystemError {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.InitEnclave(timeoutCtx, &generated.InitEnclaveRequest{EncryptedSharedEnclaveSecret: secret})
@@ -147,7 +197,7 @@ func (c *Client) InitEnclave(secret common.EncryptedSharedEnclaveSecret) common.
 }
 
 func (c *Client) SubmitL1Block(block types.Block, receipts types.Receipts, isLatest bool) (*common.BlockSubmissionResponse, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	var buffer bytes.Buffer
@@ -173,7 +223,7 @@ func (c *Client) SubmitL1Block(block types.Block, receipts types.Receipts, isLat
 }
 
 func (c *Client) SubmitTx(tx common.EncryptedTx) (*responses.RawTx, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.SubmitTx(timeoutCtx, &generated.SubmitTxRequest{EncryptedTx: tx})
@@ -190,7 +240,7 @@ func (c *Client) SubmitTx(tx common.EncryptedTx) (*responses.RawTx, common.Syste
 func (c *Client) SubmitBatch(batch *common.ExtBatch) common.SystemError {
 	defer core.LogMethodDuration(c.logger, measure.NewStopwatch(), "SubmitBatch rpc call")
 
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	batchMsg := rpc.ToExtBatchMsg(batch)
@@ -206,7 +256,7 @@ func (c *Client) SubmitBatch(batch *common.ExtBatch) common.SystemError {
 }
 
 func (c *Client) ObsCall(encryptedParams common.EncryptedParamsCall) (*responses.Call, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.ObsCall(timeoutCtx, &generated.ObsCallRequest{
@@ -223,7 +273,7 @@ func (c *Client) ObsCall(encryptedParams common.EncryptedParamsCall) (*responses
 }
 
 func (c *Client) GetTransactionCount(encryptedParams common.EncryptedParamsGetTxCount) (*responses.TxCount, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.GetTransactionCount(timeoutCtx, &generated.GetTransactionCountRequest{EncryptedParams: encryptedParams})
@@ -240,7 +290,7 @@ func (c *Client) GetTransactionCount(encryptedParams common.EncryptedParamsGetTx
 func (c *Client) Stop() common.SystemError {
 	c.logger.Info("Shutting down enclave client.")
 
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.Stop(timeoutCtx, &generated.StopRequest{})
@@ -254,7 +304,7 @@ func (c *Client) Stop() common.SystemError {
 }
 
 func (c *Client) GetTransaction(encryptedParams common.EncryptedParamsGetTxByHash) (*responses.TxByHash, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.GetTransaction(timeoutCtx, &generated.GetTransactionRequest{EncryptedParams: encryptedParams})
@@ -269,7 +319,7 @@ func (c *Client) GetTransaction(encryptedParams common.EncryptedParamsGetTxByHas
 }
 
 func (c *Client) GetTransactionReceipt(encryptedParams common.EncryptedParamsGetTxReceipt) (*responses.TxReceipt, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.GetTransactionReceipt(timeoutCtx, &generated.GetTransactionReceiptRequest{EncryptedParams: encryptedParams})
@@ -284,7 +334,7 @@ func (c *Client) GetTransactionReceipt(encryptedParams common.EncryptedParamsGet
 }
 
 func (c *Client) GetBalance(encryptedParams common.EncryptedParamsGetBalance) (*responses.Balance, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.GetBalance(timeoutCtx, &generated.GetBalanceRequest{
@@ -301,7 +351,7 @@ func (c *Client) GetBalance(encryptedParams common.EncryptedParamsGetBalance) (*
 }
 
 func (c *Client) GetCode(address gethcommon.Address, batchHash *gethcommon.Hash) ([]byte, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.GetCode(timeoutCtx, &generated.GetCodeRequest{
@@ -319,7 +369,7 @@ func (c *Client) GetCode(address gethcommon.Address, batchHash *gethcommon.Hash)
 }
 
 func (c *Client) Subscribe(id gethrpc.ID, encryptedParams common.EncryptedParamsLogSubscription) common.SystemError {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.Subscribe(timeoutCtx, &generated.SubscribeRequest{
@@ -336,7 +386,7 @@ func (c *Client) Subscribe(id gethrpc.ID, encryptedParams common.EncryptedParams
 }
 
 func (c *Client) Unsubscribe(id gethrpc.ID) common.SystemError {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.Unsubscribe(timeoutCtx, &generated.UnsubscribeRequest{
@@ -352,7 +402,7 @@ func (c *Client) Unsubscribe(id gethrpc.ID) common.SystemError {
 }
 
 func (c *Client) EstimateGas(encryptedParams common.EncryptedParamsEstimateGas) (*responses.Gas, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.EstimateGas(timeoutCtx, &generated.EstimateGasRequest{
@@ -369,7 +419,7 @@ func (c *Client) EstimateGas(encryptedParams common.EncryptedParamsEstimateGas)
 }
 
 func (c *Client) GetLogs(encryptedParams common.EncryptedParamsGetLogs) (*responses.Logs, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.GetLogs(timeoutCtx, &generated.GetLogsRequest{
@@ -386,10 +436,10 @@ func (c *Client) GetLogs(encryptedParams common.EncryptedParamsGetLogs) (*respon
 }
 
 func (c *Client) HealthCheck() (bool, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
-	response, err := c.protoClient.HealthCheck(timeoutCtx, &generated.EmptyArgs{})
+	response, err := c.protoClient.HealthCheck(timeoutCtx, &generated.EmptyArgs{}, grpc.WaitForReady(false))
 	if err != nil {
 		return false, syserr.NewRPCError(err)
 	}
@@ -402,7 +452,7 @@ func (c *Client) HealthCheck() (bool, common.SystemError) {
 func (c *Client) CreateBatch(skipIfEmpty bool) common.SystemError {
 	defer core.LogMethodDuration(c.logger, measure.NewStopwatch(), "CreateBatch rpc call")
 
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.CreateBatch(timeoutCtx, &generated.CreateBatchRequest{SkipIfEmpty: skipIfEmpty})
@@ -418,7 +468,7 @@ func (c *Client) CreateBatch(skipIfEmpty bool) common.SystemError {
 func (c *Client) CreateRollup(fromSeqNo uint64) (*common.ExtRollup, common.SystemError) {
 	defer core.LogMethodDuration(c.logger, measure.NewStopwatch(), "CreateRollup rpc call")
 
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.CreateRollup(timeoutCtx, &generated.CreateRollupRequest{
@@ -435,7 +485,7 @@ func (c *Client) CreateRollup(fromSeqNo uint64) (*common.ExtRollup, common.Syste
 }
 
 func (c *Client) DebugTraceTransaction(hash gethcommon.Hash, config *tracers.TraceConfig) (json.RawMessage, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	confBytes, err := json.Marshal(config)
@@ -458,7 +508,7 @@ func (c *Client) DebugTraceTransaction(hash gethcommon.Hash, config *tracers.Tra
 }
 
 func (c *Client) GetBatch(hash common.L2BatchHash) (*common.ExtBatch, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	batchMsg, err := c.protoClient.GetBatch(timeoutCtx, &generated.GetBatchRequest{KnownHead: hash.Bytes()})
@@ -466,11 +516,11 @@ func (c *Client) GetBatch(hash common.L2BatchHash) (*common.ExtBatch, common.Sys
 		return nil, fmt.Errorf("rpc GetBatch failed. Cause: %w", err)
 	}
 
-	return common.DecodeExtBatch(batchMsg.Batch)
+	return codec.Detect(batchMsg.Batch).DecodeBatch(batchMsg.Batch)
 }
 
 func (c *Client) GetBatchBySeqNo(seqNo uint64) (*common.ExtBatch, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	batchMsg, err := c.protoClient.GetBatchBySeqNo(timeoutCtx, &generated.GetBatchBySeqNoRequest{SeqNo: seqNo})
@@ -478,7 +528,7 @@ func (c *Client) GetBatchBySeqNo(seqNo uint64) (*common.ExtBatch, common.SystemE
 		return nil, fmt.Errorf("rpc GetBatchBySeqNo failed. Cause: %w", err)
 	}
 
-	return common.DecodeExtBatch(batchMsg.Batch)
+	return codec.Detect(batchMsg.Batch).DecodeBatch(batchMsg.Batch)
 }
 
 func (c *Client) StreamL2Updates() (chan common.StreamL2UpdatesResponse, func()) {
@@ -527,7 +577,7 @@ func (c *Client) StreamL2Updates() (chan common.StreamL2UpdatesResponse, func())
 }
 
 func (c *Client) DebugEventLogRelevancy(hash gethcommon.Hash) (json.RawMessage, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.DebugEventLogRelevancy(timeoutCtx, &generated.DebugEventLogRelevancyRequest{
@@ -542,8 +592,113 @@ func (c *Client) DebugEventLogRelevancy(hash gethcommon.Hash) (json.RawMessage,
 	return json.RawMessage(response.Msg), nil
 }
 
+func (c *Client) MempoolReport() (*common.MempoolReport, common.SystemError) {
+	timeoutCtx, cancel := c.callContext()
+	defer cancel()
+
+	response, err := c.protoClient.MempoolReport(timeoutCtx, &generated.MempoolReportRequest{})
+	if err != nil {
+		return nil, syserr.NewRPCError(err)
+	}
+	if response != nil && response.SystemError != nil {
+		return nil, syserr.NewInternalError(fmt.Errorf("%s", response.SystemError.ErrorString))
+	}
+
+	var report common.MempoolReport
+	if err = json.Unmarshal(response.Report, &report); err != nil {
+		return nil, syserr.NewInternalError(fmt.Errorf("unable to decode the mempool report - %w", err))
+	}
+	return &report, nil
+}
+
+func (c *Client) MempoolStats() (*common.MempoolStats, common.SystemError) {
+	timeoutCtx, cancel := c.callContext()
+	defer cancel()
+
+	response, err := c.protoClient.MempoolStats(timeoutCtx, &generated.MempoolStatsRequest{})
+	if err != nil {
+		return nil, syserr.NewRPCError(err)
+	}
+	if response != nil && response.SystemError != nil {
+		return nil, syserr.NewInternalError(fmt.Errorf("%s", response.SystemError.ErrorString))
+	}
+
+	var stats common.MempoolStats
+	if err = json.Unmarshal(response.Stats, &stats); err != nil {
+		return nil, syserr.NewInternalError(fmt.Errorf("unable to decode the mempool stats - %w", err))
+	}
+	return &stats, nil
+}
+
+func (c *Client) RollupWitness(rollupHash common.L2RollupHash) ([]byte, common.SystemError) {
+	timeoutCtx, cancel := c.callContext()
+	defer cancel()
+
+	response, err := c.protoClient.RollupWitness(timeoutCtx, &generated.RollupWitnessRequest{RollupHash: rollupHash.Bytes()})
+	if err != nil {
+		return nil, syserr.NewRPCError(err)
+	}
+	if response != nil && response.SystemError != nil {
+		return nil, syserr.NewInternalError(fmt.Errorf("%s", response.SystemError.ErrorString))
+	}
+	return response.Witness, nil
+}
+
+func (c *Client) VerifyRollupWitness(witness []byte) (*common.RollupVerdict, common.SystemError) {
+	timeoutCtx, cancel := c.callContext()
+	defer cancel()
+
+	response, err := c.protoClient.VerifyRollupWitness(timeoutCtx, &generated.VerifyRollupWitnessRequest{Witness: witness})
+	if err != nil {
+		return nil, syserr.NewRPCError(err)
+	}
+	if response != nil && response.SystemError != nil {
+		return nil, syserr.NewInternalError(fmt.Errorf("%s", response.SystemError.ErrorString))
+	}
+
+	var verdict common.RollupVerdict
+	if err = json.Unmarshal(response.Verdict, &verdict); err != nil {
+		return nil, syserr.NewInternalError(fmt.Errorf("unable to decode the rollup verdict - %w", err))
+	}
+	return &verdict, nil
+}
+
+func (c *Client) DebugProfile(req *common.ProfileRequest) ([]byte, common.SystemError) {
+	// a CPU profile takes the requested duration to capture
+	timeout := c.config.EnclaveRPCTimeout + time.Duration(req.Seconds)*time.Second
+	timeoutCtx, cancel := context.WithTimeout(context.Background(), timeout)
+	defer cancel()
+
+	stream, err := c.protoClient.DebugProfile(timeoutCtx, &generated.DebugProfileRequest{
+		Kind:      req.Kind,
+		Seconds:   uint64(req.Seconds),
+		Timestamp: uint64(req.Timestamp),
+		EnclaveID: req.EnclaveID.Bytes(),
+		ChainID:   uint64(req.ChainID),
+		Signature: req.Signature,
+	})
+	if err != nil {
+		return nil, syserr.NewRPCError(err)
+	}
+
+	var profile []byte
+	for {
+		chunk, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return profile, nil
+		}
+		if err != nil {
+			return nil, syserr.NewRPCError(err)
+		}
+		if chunk.SystemError != nil {
+			return nil, syserr.NewInternalError(fmt.Errorf("%s", chunk.SystemError.ErrorString))
+		}
+		profile = append(profile, chunk.Data...)
+	}
+}
+
 func (c *Client) GetTotalContractCount() (*big.Int, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.GetTotalContractCount(timeoutCtx, &generated.GetTotalContractCountRequest{})
@@ -557,7 +712,7 @@ func (c *Client) GetTotalContractCount() (*big.Int, common.SystemError) {
 }
 
 func (c *Client) GetCustomQuery(encryptedParams common.EncryptedParamsGetStorageAt) (*responses.Receipts, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.GetReceiptsByAddress(timeoutCtx, &generated.GetReceiptsByAddressRequest{
@@ -574,7 +729,7 @@ func (c *Client) GetCustomQuery(encryptedParams common.EncryptedParamsGetStorage
 }
 
 func (c *Client) GetPublicTransactionData(pagination *common.QueryPagination) (*common.TransactionListingResponse, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.GetPublicTransactionData(timeoutCtx, &generated.GetPublicTransactionDataRequest{
@@ -600,7 +755,7 @@ func (c *Client) GetPublicTransactionData(pagination *common.QueryPagination) (*
 }
 
 func (c *Client) EnclavePublicConfig() (*common.EnclavePublicConfig, common.SystemError) {
-	timeoutCtx, cancel := context.WithTimeout(context.Background(), c.config.EnclaveRPCTimeout)
+	timeoutCtx, cancel := c.callContext()
 	defer cancel()
 
 	response, err := c.protoClient.EnclavePublicConfig(timeoutCtx, &generated.EnclavePublicConfigRequest{})
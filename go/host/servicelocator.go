@@ -59,3 +59,11 @@ func (s *ServicesRegistry) Enclaves() hostcommon.EnclaveService {
 func (s *ServicesRegistry) LogSubs() hostcommon.LogSubscriptionManager {
 	return s.getService(hostcommon.LogSubscriptionServiceName).(hostcommon.LogSubscriptionManager)
 }
+
+func (s *ServicesRegistry) EventLog() hostcommon.EventLog {
+	return s.getService(hostcommon.EventLogName).(hostcommon.EventLog)
+}
+
+func (s *ServicesRegistry) HeadFeed() hostcommon.HeadFeed {
+	return s.getService(hostcommon.HeadFeedName).(hostcommon.HeadFeed)
+}
@@ -0,0 +1,237 @@
+package testsupport
+
+import (
+	"errors"
+	"math/big"
+	"sync"
+
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+)
+
+// FakeL1Client is a scriptable, in-memory implementation of the host's L1 client (l1.Client).
+// Tests build up a chain with AddBlock, decide how calls behave by setting the exported hooks and queued errors, and
+// inspect what the host sent afterwards.
+type FakeL1Client struct {
+	mu sync.Mutex
+
+	blocksByHash map[gethcommon.Hash]*types.Block
+	canonical    map[uint64]*types.Block
+	head         *types.Block
+	logs         map[gethcommon.Hash][]types.Log
+	receipts     map[gethcommon.Hash]*types.Receipt
+	headFeed     chan *types.Header
+
+	sendTxErrs   []error
+	sentTxs      []*types.Transaction
+	reconnects   int
+	autoReceipts bool
+
+	// CallContractFn scripts the responses of contract calls (returns an error if not set)
+	CallContractFn func(msg ethereum.CallMsg) ([]byte, error)
+	// LastBatchSeqNo is returned by FetchLastBatchSeqNo
+	LastBatchSeqNo *big.Int
+	// GasPrice is returned by SuggestGasPrice
+	GasPrice *big.Int
+}
+
+// NewFakeL1Client returns a fake with an empty chain.
+// If autoReceipts is true, every transaction sent gets a successful receipt immediately.
+func NewFakeL1Client(autoReceipts bool) *FakeL1Client {
+	return &FakeL1Client{
+		blocksByHash:   map[gethcommon.Hash]*types.Block{},
+		canonical:      map[uint64]*types.Block{},
+		logs:           map[gethcommon.Hash][]types.Log{},
+		receipts:       map[gethcommon.Hash]*types.Receipt{},
+		headFeed:       make(chan *types.Header, 100),
+		autoReceipts:   autoReceipts,
+		LastBatchSeqNo: big.NewInt(0),
+		GasPrice:       big.NewInt(1),
+	}
+}
+
+// AddBlock makes the block known to the fake. If canonical is true it becomes the canonical block at its height and,
+// if it is the highest, the head. Canonical blocks are also published to the block listener.
+func (f *FakeL1Client) AddBlock(block *types.Block, canonical bool) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.blocksByHash[block.Hash()] = block
+	if !canonical {
+		return
+	}
+	f.canonical[block.NumberU64()] = block
+	if f.head == nil || block.NumberU64() >= f.head.NumberU64() {
+		f.head = block
+		select {
+		case f.headFeed <- block.Header():
+		default: // nobody is listening, heads are dropped like on a real L1 subscription
+		}
+	}
+}
+
+// AddLogs sets the logs returned for a block hash
+func (f *FakeL1Client) AddLogs(blockHash gethcommon.Hash, logs []types.Log) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.logs[blockHash] = logs
+}
+
+// AddReceipt sets the receipt returned for a transaction hash
+func (f *FakeL1Client) AddReceipt(txHash gethcommon.Hash, receipt *types.Receipt) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.receipts[txHash] = receipt
+}
+
+// FailNextSends queues errors returned by the next calls to SendTransaction, in order
+func (f *FakeL1Client) FailNextSends(errs ...error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.sendTxErrs = append(f.sendTxErrs, errs...)
+}
+
+// SentTransactions returns the transactions successfully sent so far
+func (f *FakeL1Client) SentTransactions() []*types.Transaction {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*types.Transaction{}, f.sentTxs...)
+}
+
+// Reconnects returns the number of times the client was asked to reconnect
+func (f *FakeL1Client) Reconnects() int {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.reconnects
+}
+
+func (f *FakeL1Client) BlockByHash(id gethcommon.Hash) (*types.Block, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	block, ok := f.blocksByHash[id]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return block, nil
+}
+
+func (f *FakeL1Client) BlockByNumber(n *big.Int) (*types.Block, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if n == nil {
+		if f.head == nil {
+			return nil, ethereum.NotFound
+		}
+		return f.head, nil
+	}
+	block, ok := f.canonical[n.Uint64()]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return block, nil
+}
+
+func (f *FakeL1Client) FetchHeadBlock() (*types.Block, error) {
+	return f.BlockByNumber(nil)
+}
+
+func (f *FakeL1Client) BlockListener() (chan *types.Header, ethereum.Subscription) {
+	return f.headFeed, &fakeSubscription{err: make(chan error)}
+}
+
+func (f *FakeL1Client) GetLogs(q ethereum.FilterQuery) ([]types.Log, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if q.BlockHash == nil {
+		return nil, errors.New("fake only supports log queries by block hash")
+	}
+	return f.logs[*q.BlockHash], nil
+}
+
+func (f *FakeL1Client) SendTransaction(signedTx *types.Transaction) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if len(f.sendTxErrs) > 0 {
+		err := f.sendTxErrs[0]
+		f.sendTxErrs = f.sendTxErrs[1:]
+		if err != nil {
+			return err
+		}
+	}
+	f.sentTxs = append(f.sentTxs, signedTx)
+	if f.autoReceipts {
+		f.receipts[signedTx.Hash()] = &types.Receipt{Status: types.ReceiptStatusSuccessful, TxHash: signedTx.Hash()}
+	}
+	return nil
+}
+
+func (f *FakeL1Client) TransactionReceipt(hash gethcommon.Hash) (*types.Receipt, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	receipt, ok := f.receipts[hash]
+	if !ok {
+		return nil, ethereum.NotFound
+	}
+	return receipt, nil
+}
+
+// Nonce returns the nonce following the highest nonce sent, as the fake only receives the transactions of the host wallet
+func (f *FakeL1Client) Nonce(gethcommon.Address) (uint64, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var nonce uint64
+	for _, tx := range f.sentTxs {
+		if tx.Nonce() >= nonce {
+			nonce = tx.Nonce() + 1
+		}
+	}
+	return nonce, nil
+}
+
+func (f *FakeL1Client) PrepareTransactionToRetry(txData types.TxData, _ gethcommon.Address, nonce uint64, _ int) (types.TxData, error) {
+	if legacyTx, ok := txData.(*types.LegacyTx); ok {
+		prepared := *legacyTx
+		prepared.Nonce = nonce
+		if prepared.GasPrice == nil {
+			prepared.GasPrice = big.NewInt(1)
+		}
+		return &prepared, nil
+	}
+	return txData, nil
+}
+
+func (f *FakeL1Client) CallContract(msg ethereum.CallMsg) ([]byte, error) {
+	if f.CallContractFn == nil {
+		return nil, errors.New("no contract call response scripted")
+	}
+	return f.CallContractFn(msg)
+}
+
+func (f *FakeL1Client) SuggestGasPrice() (*big.Int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.GasPrice, nil
+}
+
+func (f *FakeL1Client) FetchLastBatchSeqNo(gethcommon.Address) (*big.Int, error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.LastBatchSeqNo, nil
+}
+
+func (f *FakeL1Client) ReconnectIfClosed() error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.reconnects++
+	return nil
+}
+
+type fakeSubscription struct {
+	err chan error
+}
+
+func (s *fakeSubscription) Unsubscribe() {}
+
+func (s *fakeSubscription) Err() <-chan error {
+	return s.err
+}
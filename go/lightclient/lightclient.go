@@ -0,0 +1,308 @@
+package lightclient
+
+import (
+	"crypto/ecdsa"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+	"sync"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/subscription"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
+	"github.com/ten-protocol/go-ten/go/ethadapter"
+	"github.com/ten-protocol/go-ten/go/ethadapter/mgmtcontractlib"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	// ErrNoSequencerKey is returned while the attestation of the sequencer enclave was not found in the synced L1 blocks
+	ErrNoSequencerKey = errors.New("the attested key of the sequencer was not found on the L1")
+	// ErrInvalidSignature is returned when a header was not signed by the attested sequencer enclave
+	ErrInvalidSignature = errors.New("invalid sequencer signature")
+	// ErrNotRolledUp is returned when no rollup published in the synced L1 blocks contains the batch yet
+	ErrNotRolledUp = errors.New("the batch is not in a rollup published on the L1 yet")
+)
+
+// L1Client is the subset of the L1 client used to read the management contract transactions
+type L1Client interface {
+	BlockNumber() (uint64, error)
+	BlockByNumber(n *big.Int) (*types.Block, error)
+	TransactionReceipt(hash gethcommon.Hash) (*types.Receipt, error)
+}
+
+// TxHandler is notified of the management contract transactions found in the synced L1 blocks, e.g. to monitor the
+// activity of the sequencer
+type TxHandler interface {
+	// HandleManagementTx is called by Sync, in the order of the L1, after the block of the transaction was processed
+	HandleManagementTx(block *types.Block, tx *types.Transaction, l1Tx ethadapter.L1Transaction)
+}
+
+// Config is the configuration of the light client
+type Config struct {
+	// SequencerID is the address of the owner of the sequencer enclave, as published in its attestation
+	SequencerID gethcommon.Address
+	// StartBlock is the first L1 block scanned, usually the block the management contract was deployed in
+	StartBlock uint64
+	// Confirmations is the number of blocks a block must be buried under before it is scanned, so the client never sees
+	// a block that could be reorganised away
+	Confirmations uint64
+}
+
+// Inclusion locates the rollup that published a batch on the L1
+type Inclusion struct {
+	RollupHash      tentypes.RollupHash `json:"rollupHash"`
+	FirstBatchSeqNo tentypes.BatchSeqNo `json:"firstBatchSeqNo"` // the first batch of the rollup, as far as the client knows
+	LastBatchSeqNo  tentypes.BatchSeqNo `json:"lastBatchSeqNo"`
+	L1Block         uint64              `json:"l1Block"`
+	L1BlockHash     gethcommon.Hash     `json:"l1BlockHash"`
+	L1TxHash        gethcommon.Hash     `json:"l1TxHash"`
+}
+
+// Client verifies the signed batch headers served by an untrusted TEN node against the management contract data on the
+// L1, without running a full node. The L1 client is trusted.
+type Client struct {
+	l1      L1Client
+	mgmtLib mgmtcontractlib.MgmtContractLib
+	config  Config
+	logger  gethlog.Logger
+
+	txSubscribers *subscription.Manager[TxHandler]
+
+	lock         sync.RWMutex
+	nextBlock    uint64
+	sequencerKey *ecdsa.PublicKey
+	rollups      []*Inclusion // ordered by batch sequence number
+}
+
+func New(l1 L1Client, mgmtLib mgmtcontractlib.MgmtContractLib, config Config, logger gethlog.Logger) *Client {
+	return &Client{
+		l1:            l1,
+		mgmtLib:       mgmtLib,
+		config:        config,
+		logger:        logger,
+		txSubscribers: subscription.NewManager[TxHandler](),
+		nextBlock:     config.StartBlock,
+	}
+}
+
+// Subscribe registers a handler for the management contract transactions found by the following syncs, returns the
+// unsubscribe func
+func (c *Client) Subscribe(handler TxHandler) func() {
+	return c.txSubscribers.Subscribe(handler)
+}
+
+// Sync scans the L1 blocks confirmed since the last sync for the attestation of the sequencer and the rollups it
+// published. It is meant to be called periodically.
+func (c *Client) Sync() error {
+	head, err := c.l1.BlockNumber()
+	if err != nil {
+		return fmt.Errorf("could not fetch the L1 head - %w", err)
+	}
+	if head < c.config.Confirmations {
+		return nil
+	}
+	confirmed := head - c.config.Confirmations
+
+	for next := c.NextBlock(); next <= confirmed; next++ {
+		block, err := c.l1.BlockByNumber(new(big.Int).SetUint64(next))
+		if err != nil {
+			return fmt.Errorf("could not fetch L1 block %d - %w", next, err)
+		}
+
+		c.lock.Lock()
+		mgmtTxs, err := c.processBlock(block)
+		if err == nil {
+			c.nextBlock = next + 1
+		}
+		c.lock.Unlock()
+		if err != nil {
+			return err
+		}
+
+		// the handlers are notified without the lock, so they can query the client
+		for _, mgmtTx := range mgmtTxs {
+			for _, handler := range c.txSubscribers.Subscribers() {
+				handler.HandleManagementTx(block, mgmtTx.tx, mgmtTx.l1Tx)
+			}
+		}
+	}
+	return nil
+}
+
+// NextBlock returns the number of the next L1 block to scan
+func (c *Client) NextBlock() uint64 {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.nextBlock
+}
+
+// SequencerAttested returns whether the attested key of the sequencer was found on the L1
+func (c *Client) SequencerAttested() bool {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.sequencerKey != nil
+}
+
+// LatestRollup returns the last rollup of the sequencer published on the L1, or nil if there is none yet
+func (c *Client) LatestRollup() *Inclusion {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	if len(c.rollups) == 0 {
+		return nil
+	}
+	inclusion := *c.rollups[len(c.rollups)-1]
+	return &inclusion
+}
+
+// VerifyBatchHeader checks that the header was signed by the attested sequencer enclave
+func (c *Client) VerifyBatchHeader(header *common.BatchHeader) error {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	return c.verifySignature(header.Hash(), header.R, header.S)
+}
+
+// RollupInclusion returns the first rollup published on the L1 that covers the batch sequence number, or ErrNotRolledUp
+func (c *Client) RollupInclusion(seqNo tentypes.BatchSeqNo) (*Inclusion, error) {
+	if err := seqNo.Validate(); err != nil {
+		return nil, err
+	}
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+	i := sort.Search(len(c.rollups), func(i int) bool {
+		return c.rollups[i].LastBatchSeqNo >= seqNo
+	})
+	if i == len(c.rollups) {
+		return nil, fmt.Errorf("%w - batch %d", ErrNotRolledUp, seqNo)
+	}
+	inclusion := *c.rollups[i]
+	return &inclusion, nil
+}
+
+// VerifyBatch checks the signature of the header, and returns the rollup that published the batch on the L1
+func (c *Client) VerifyBatch(header *common.BatchHeader) (*Inclusion, error) {
+	if err := c.VerifyBatchHeader(header); err != nil {
+		return nil, err
+	}
+	seqNo, err := tentypes.BatchSeqNoFromBig(header.SequencerOrderNo)
+	if err != nil {
+		return nil, err
+	}
+	return c.RollupInclusion(seqNo)
+}
+
+type managementTx struct {
+	tx   *types.Transaction
+	l1Tx ethadapter.L1Transaction
+}
+
+// returns the management contract transactions of the block. Must be called with the lock held
+func (c *Client) processBlock(block *types.Block) ([]managementTx, error) {
+	var mgmtTxs []managementTx
+	for _, tx := range block.Transactions() {
+		decoded := c.mgmtLib.DecodeTx(tx)
+		if decoded == nil {
+			continue
+		}
+		mgmtTxs = append(mgmtTxs, managementTx{tx: tx, l1Tx: decoded})
+
+		switch l1Tx := decoded.(type) {
+		case *ethadapter.L1InitializeSecretTx:
+			if c.sequencerKey != nil {
+				continue
+			}
+			successful, err := c.isSuccessful(tx)
+			if err != nil {
+				return nil, err
+			}
+			if !successful {
+				continue
+			}
+			att, err := common.DecodeAttestation(l1Tx.Attestation)
+			if err != nil {
+				c.logger.Warn("Could not decode attestation", "l1TxHash", tx.Hash(), log.ErrKey, err)
+				continue
+			}
+			if att.Owner != c.config.SequencerID {
+				continue
+			}
+			key, err := gethcrypto.DecompressPubkey(att.PubKey)
+			if err != nil {
+				c.logger.Warn("Could not parse the attested key of the sequencer", "l1TxHash", tx.Hash(), log.ErrKey, err)
+				continue
+			}
+			c.sequencerKey = key
+			c.logger.Info("Found the attested key of the sequencer", "l1Block", block.NumberU64(), "sequencer", att.Owner)
+
+		case *ethadapter.L1RollupTx:
+			if err := c.processRollup(block, tx, l1Tx); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return mgmtTxs, nil
+}
+
+// Must be called with the lock held
+func (c *Client) processRollup(block *types.Block, tx *types.Transaction, rollupTx *ethadapter.L1RollupTx) error {
+	rollup, err := common.DecodeRollup(rollupTx.Rollup)
+	if err != nil {
+		c.logger.Warn("Could not decode rollup", "l1TxHash", tx.Hash(), log.ErrKey, err)
+		return nil
+	}
+	successful, err := c.isSuccessful(tx)
+	if err != nil || !successful {
+		return err
+	}
+	if err := c.verifySignature(rollup.Hash(), rollup.Header.R, rollup.Header.S); err != nil {
+		c.logger.Warn("Ignoring rollup not signed by the sequencer", "l1TxHash", tx.Hash(), log.ErrKey, err)
+		return nil
+	}
+
+	first := tentypes.FirstBatchSeqNo
+	if len(c.rollups) > 0 {
+		last := c.rollups[len(c.rollups)-1].LastBatchSeqNo
+		if tentypes.BatchSeqNo(rollup.Header.LastBatchSeqNo) <= last {
+			// nothing new, e.g. a rollup republished after a failed attempt
+			return nil
+		}
+		first = last + 1
+	}
+	c.rollups = append(c.rollups, &Inclusion{
+		RollupHash:      tentypes.RollupHash(rollup.Hash()),
+		FirstBatchSeqNo: first,
+		LastBatchSeqNo:  tentypes.BatchSeqNo(rollup.Header.LastBatchSeqNo),
+		L1Block:         block.NumberU64(),
+		L1BlockHash:     block.Hash(),
+		L1TxHash:        tx.Hash(),
+	})
+	return nil
+}
+
+func (c *Client) isSuccessful(tx *types.Transaction) (bool, error) {
+	receipt, err := c.l1.TransactionReceipt(tx.Hash())
+	if err != nil {
+		return false, fmt.Errorf("could not fetch the receipt of transaction %s - %w", tx.Hash(), err)
+	}
+	return receipt.Status == types.ReceiptStatusSuccessful, nil
+}
+
+// Must be called with the lock held
+func (c *Client) verifySignature(hash gethcommon.Hash, r *big.Int, s *big.Int) error {
+	if c.sequencerKey == nil {
+		return ErrNoSequencerKey
+	}
+	if r == nil || s == nil {
+		return fmt.Errorf("%w - missing signature", ErrInvalidSignature)
+	}
+	if !ecdsa.Verify(c.sequencerKey, hash.Bytes(), r, s) {
+		return ErrInvalidSignature
+	}
+	return nil
+}
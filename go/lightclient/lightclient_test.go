@@ -0,0 +1,171 @@
+package lightclient
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
+	"github.com/ten-protocol/go-ten/go/ethadapter"
+	"github.com/ten-protocol/go-ten/go/ethadapter/mgmtcontractlib"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	testMgmtContract = gethcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	testSequencerID  = gethcommon.HexToAddress("0x2000000000000000000000000000000000000002")
+)
+
+// testL1 serves the blocks by number, and the receipts of the management contract transactions
+type testL1 struct {
+	blocks       []*types.Block
+	failedTxs    map[gethcommon.Hash]bool
+	fetchedCount int
+}
+
+func (l *testL1) BlockNumber() (uint64, error) {
+	return uint64(len(l.blocks) - 1), nil
+}
+
+func (l *testL1) BlockByNumber(n *big.Int) (*types.Block, error) {
+	l.fetchedCount++
+	return l.blocks[n.Uint64()], nil
+}
+
+func (l *testL1) TransactionReceipt(hash gethcommon.Hash) (*types.Receipt, error) {
+	if l.failedTxs[hash] {
+		return &types.Receipt{Status: types.ReceiptStatusFailed}, nil
+	}
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil
+}
+
+// testHandler records the management contract transactions it is notified of
+type testHandler struct {
+	txs []ethadapter.L1Transaction
+}
+
+func (h *testHandler) HandleManagementTx(_ *types.Block, _ *types.Transaction, l1Tx ethadapter.L1Transaction) {
+	h.txs = append(h.txs, l1Tx)
+}
+
+func newTestKey(t *testing.T) *ecdsa.PrivateKey {
+	key, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	return key
+}
+
+func newTestInitSecretTx(t *testing.T, mgmtLib mgmtcontractlib.MgmtContractLib, owner gethcommon.Address, key *ecdsa.PrivateKey) *types.Transaction {
+	att, err := common.EncodeAttestation(&common.AttestationReport{
+		PubKey: gethcrypto.CompressPubkey(&key.PublicKey),
+		Owner:  owner,
+	})
+	require.NoError(t, err)
+	return types.NewTx(mgmtLib.CreateInitializeSecret(&ethadapter.L1InitializeSecretTx{
+		AggregatorID: &owner,
+		Attestation:  att,
+	}))
+}
+
+func newTestRollupTx(t *testing.T, mgmtLib mgmtcontractlib.MgmtContractLib, lastBatchSeqNo uint64, key *ecdsa.PrivateKey) *types.Transaction {
+	rollup := &common.ExtRollup{Header: &common.RollupHeader{LastBatchSeqNo: lastBatchSeqNo}}
+	var err error
+	rollup.Header.R, rollup.Header.S, err = ecdsa.Sign(rand.Reader, key, rollup.Header.Hash().Bytes())
+	require.NoError(t, err)
+	encoded, err := common.EncodeRollup(rollup)
+	require.NoError(t, err)
+	return types.NewTx(mgmtLib.CreateRollup(&ethadapter.L1RollupTx{Rollup: encoded}))
+}
+
+func newTestBatchHeader(t *testing.T, seqNo int64, key *ecdsa.PrivateKey) *common.BatchHeader {
+	header := &common.BatchHeader{Number: big.NewInt(seqNo), SequencerOrderNo: big.NewInt(seqNo)}
+	var err error
+	header.R, header.S, err = ecdsa.Sign(rand.Reader, key, header.Hash().Bytes())
+	require.NoError(t, err)
+	return header
+}
+
+func newTestBlock(number int64, txs ...*types.Transaction) *types.Block {
+	return types.NewBlock(&types.Header{Number: big.NewInt(number)}, txs, nil, nil, trie.NewStackTrie(nil))
+}
+
+func TestVerifiesBatchesAgainstTheL1(t *testing.T) {
+	mgmtLib := mgmtcontractlib.NewMgmtContractLib(&testMgmtContract, gethlog.New())
+	sequencerKey := newTestKey(t)
+	otherKey := newTestKey(t)
+
+	failedRollup := newTestRollupTx(t, mgmtLib, 30, sequencerKey)
+	rollup := newTestRollupTx(t, mgmtLib, 20, sequencerKey)
+	l1 := &testL1{
+		blocks: []*types.Block{
+			newTestBlock(0),
+			newTestBlock(1, newTestInitSecretTx(t, mgmtLib, gethcommon.HexToAddress("0x03"), otherKey), newTestInitSecretTx(t, mgmtLib, testSequencerID, sequencerKey)),
+			newTestBlock(2, newTestRollupTx(t, mgmtLib, 10, sequencerKey)),
+			newTestBlock(3, newTestRollupTx(t, mgmtLib, 15, otherKey), rollup),
+			newTestBlock(4, failedRollup),
+			newTestBlock(5, newTestRollupTx(t, mgmtLib, 40, sequencerKey)),
+		},
+		failedTxs: map[gethcommon.Hash]bool{failedRollup.Hash(): true},
+	}
+
+	client := New(l1, mgmtLib, Config{SequencerID: testSequencerID, StartBlock: 1, Confirmations: 1}, gethlog.New())
+	handler := &testHandler{}
+	client.Subscribe(handler)
+	require.NoError(t, client.Sync())
+	require.Equal(t, 4, l1.fetchedCount)
+	require.Equal(t, uint64(5), client.NextBlock())
+	require.True(t, client.SequencerAttested())
+	require.Len(t, handler.txs, 6)
+	require.IsType(t, &ethadapter.L1InitializeSecretTx{}, handler.txs[0])
+	require.IsType(t, &ethadapter.L1RollupTx{}, handler.txs[5])
+
+	// the signatures are checked against the key attested for the sequencer
+	require.NoError(t, client.VerifyBatchHeader(newTestBatchHeader(t, 12, sequencerKey)))
+	require.ErrorIs(t, client.VerifyBatchHeader(newTestBatchHeader(t, 12, otherKey)), ErrInvalidSignature)
+	tampered := newTestBatchHeader(t, 12, sequencerKey)
+	tampered.Number = big.NewInt(13)
+	require.ErrorIs(t, client.VerifyBatchHeader(tampered), ErrInvalidSignature)
+
+	// the rollup signed by another key and the failed rollup are ignored
+	inclusion, err := client.VerifyBatch(newTestBatchHeader(t, 12, sequencerKey))
+	require.NoError(t, err)
+	require.Equal(t, rollup.Hash(), inclusion.L1TxHash)
+	require.Equal(t, uint64(3), inclusion.L1Block)
+	require.Equal(t, tentypes.BatchSeqNo(11), inclusion.FirstBatchSeqNo)
+	require.Equal(t, tentypes.BatchSeqNo(20), inclusion.LastBatchSeqNo)
+
+	// the last block is not confirmed yet
+	_, err = client.RollupInclusion(25)
+	require.ErrorIs(t, err, ErrNotRolledUp)
+
+	l1.blocks = append(l1.blocks, newTestBlock(6))
+	require.NoError(t, client.Sync())
+	require.Equal(t, 5, l1.fetchedCount)
+	inclusion, err = client.RollupInclusion(25)
+	require.NoError(t, err)
+	require.Equal(t, uint64(5), inclusion.L1Block)
+	require.Equal(t, inclusion, client.LatestRollup())
+}
+
+func TestRejectsHeadersBeforeTheSequencerIsAttested(t *testing.T) {
+	mgmtLib := mgmtcontractlib.NewMgmtContractLib(&testMgmtContract, gethlog.New())
+	sequencerKey := newTestKey(t)
+	l1 := &testL1{blocks: []*types.Block{
+		newTestBlock(0, newTestRollupTx(t, mgmtLib, 10, sequencerKey)),
+	}}
+
+	client := New(l1, mgmtLib, Config{SequencerID: testSequencerID}, gethlog.New())
+	require.NoError(t, client.Sync())
+	require.False(t, client.SequencerAttested())
+	require.Nil(t, client.LatestRollup())
+	require.ErrorIs(t, client.VerifyBatchHeader(newTestBatchHeader(t, 1, sequencerKey)), ErrNoSequencerKey)
+	_, err := client.RollupInclusion(1)
+	require.ErrorIs(t, err, ErrNotRolledUp)
+}
@@ -2,13 +2,16 @@ package obsclient
 
 import (
 	"context"
+	"fmt"
 	"math/big"
 
 	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ten-protocol/go-ten/contracts/generated/EthereumBridge"
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
 	"github.com/ten-protocol/go-ten/go/common/viewingkey"
 	"github.com/ten-protocol/go-ten/go/responses"
 	"github.com/ten-protocol/go-ten/go/rpc"
@@ -16,6 +19,7 @@ import (
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	gethlog "github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
 )
 
 const (
@@ -24,6 +28,9 @@ const (
 	filterKeyToBlock   = "toBlock"
 	filterKeyAddress   = "address"
 	filterKeyTopics    = "topics"
+
+	// L2BridgeContractKey is the key of the L2 bridge in the important contracts of the network
+	L2BridgeContractKey = "L2Bridge"
 )
 
 // AuthObsClient extends the functionality of the ObsClient for all methods that require encryption when communicating with the enclave
@@ -106,6 +113,214 @@ func (ac *AuthObsClient) CallContract(ctx context.Context, msg ethereum.CallMsg,
 	return []byte(result), nil
 }
 
+// SimulateTransaction executes the transaction against the state of the given batch (the head batch if nil) without
+// submitting it, and returns the gas used, the revert reason and the logs visible to the account registered on this client
+func (ac *AuthObsClient) SimulateTransaction(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) (*common.SimulatedTransaction, error) {
+	msg.From = ac.account
+	var result common.SimulatedTransaction
+	err := ac.rpcClient.CallContext(ctx, &result, rpc.GetStorageAt, common.SimulateTransactionCQMethod, ToCallArg(msg), toBlockNumArg(blockNumber))
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetAccountProof returns the Merkle proof of the state of the account registered on this client, and of its entries in
+// the given contract mappings, against the state root of the batch with the given sequence number
+func (ac *AuthObsClient) GetAccountProof(ctx context.Context, batchSeqNo tentypes.BatchSeqNo, mappings []common.MappingQuery) (*common.AccountProof, error) {
+	query := common.AccountProofQuery{
+		Address:    ac.account,
+		BatchSeqNo: batchSeqNo,
+		Mappings:   mappings,
+	}
+	var result common.AccountProof
+	err := ac.rpcClient.CallContext(ctx, &result, rpc.GetStorageAt, common.AccountProofCQMethod, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// GetProof returns the Merkle proofs of an account and of its storage slots at the given batch, like eth_getProof. The
+// account must be the account registered on this client, or a contract deployed by it.
+func (ac *AuthObsClient) GetProof(ctx context.Context, address gethcommon.Address, storageKeys []gethcommon.Hash, block gethrpc.BlockNumberOrHash) (*common.Proof, error) {
+	query := common.ProofQuery{
+		Address:     address,
+		StorageKeys: storageKeys,
+		Block:       block,
+	}
+	var result common.Proof
+	err := ac.rpcClient.CallContext(ctx, &result, rpc.GetStorageAt, common.ProofCQMethod, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// FeeRevenue returns the fees collected in the batches between the two sequence numbers included
+func (ac *AuthObsClient) FeeRevenue(ctx context.Context, fromBatchSeqNo, toBatchSeqNo tentypes.BatchSeqNo) (*common.FeeRevenue, error) {
+	return ac.feeRevenue(ctx, common.FeeRevenueQuery{Address: ac.account, FromBatchSeqNo: fromBatchSeqNo, ToBatchSeqNo: toBatchSeqNo})
+}
+
+// RollupFeeRevenue returns the fees collected in the batches of the rollup
+func (ac *AuthObsClient) RollupFeeRevenue(ctx context.Context, rollupHash tentypes.RollupHash) (*common.FeeRevenue, error) {
+	return ac.feeRevenue(ctx, common.FeeRevenueQuery{Address: ac.account, RollupHash: &rollupHash})
+}
+
+func (ac *AuthObsClient) feeRevenue(ctx context.Context, query common.FeeRevenueQuery) (*common.FeeRevenue, error) {
+	var result common.FeeRevenue
+	err := ac.rpcClient.CallContext(ctx, &result, rpc.GetStorageAt, common.FeeRevenueCQMethod, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// AddressBatches returns the canonical batches between the two sequence numbers included that involve the account
+// registered on this client
+func (ac *AuthObsClient) AddressBatches(ctx context.Context, fromBatchSeqNo, toBatchSeqNo tentypes.BatchSeqNo) ([]common.AddressBatch, error) {
+	query := common.AddressBatchesQuery{Address: ac.account, FromBatchSeqNo: fromBatchSeqNo, ToBatchSeqNo: toBatchSeqNo}
+	var result []common.AddressBatch
+	err := ac.rpcClient.CallContext(ctx, &result, rpc.GetStorageAt, common.AddressBatchesCQMethod, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// RelayableMessages returns the L2->L1 messages of the batches between the two sequence numbers included that were not
+// relayed yet, with a relay fee of at least minFee, the highest fees first
+func (ac *AuthObsClient) RelayableMessages(ctx context.Context, fromBatchSeqNo, toBatchSeqNo tentypes.BatchSeqNo, minFee *big.Int) ([]common.RelayableMessage, error) {
+	query := common.RelayableMessagesQuery{Address: ac.account, FromBatchSeqNo: fromBatchSeqNo, ToBatchSeqNo: toBatchSeqNo, MinFee: (*hexutil.Big)(minFee)}
+	var result []common.RelayableMessage
+	err := ac.rpcClient.CallContext(ctx, &result, rpc.GetStorageAt, common.RelayableMessagesCQMethod, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// QuoteRelayFee returns the relay fee that covers the L1 relay of a message with the payload size. The fee is paid by
+// sending it to common.RelayFeeEscrowAddress, with the hash of the message as data.
+func (ac *AuthObsClient) QuoteRelayFee(ctx context.Context, payloadSize uint64) (*common.RelayFeeQuote, error) {
+	query := common.RelayFeeQuoteQuery{Address: ac.account, PayloadSize: hexutil.Uint64(payloadSize)}
+	var result common.RelayFeeQuote
+	err := ac.rpcClient.CallContext(ctx, &result, rpc.GetStorageAt, common.RelayFeeQuoteCQMethod, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// TokenMappings returns the L1<->L2 token mappings registered with the L2 bridge, in the order of their registration,
+// from the offset and at most limit of them
+func (ac *AuthObsClient) TokenMappings(ctx context.Context, offset, limit uint64) ([]common.TokenMapping, error) {
+	data, err := EthereumBridge.PackTokenMappings(new(big.Int).SetUint64(offset), new(big.Int).SetUint64(limit))
+	if err != nil {
+		return nil, err
+	}
+	result, err := ac.callL2Bridge(ctx, data)
+	if err != nil {
+		return nil, err
+	}
+	return EthereumBridge.UnpackTokenMappingsOutput(result)
+}
+
+// TokenMappingsCount returns the number of token mappings registered with the L2 bridge
+func (ac *AuthObsClient) TokenMappingsCount(ctx context.Context) (uint64, error) {
+	data, err := EthereumBridge.PackTokenMappingsCount()
+	if err != nil {
+		return 0, err
+	}
+	result, err := ac.callL2Bridge(ctx, data)
+	if err != nil {
+		return 0, err
+	}
+	count, err := EthereumBridge.UnpackTokenMappingsCountOutput(result)
+	if err != nil {
+		return 0, err
+	}
+	return count.Uint64(), nil
+}
+
+// L2TokenOf returns the wrapped token of the L1 token on the L2, or the zero address if the token is not registered
+func (ac *AuthObsClient) L2TokenOf(ctx context.Context, l1Token gethcommon.Address) (gethcommon.Address, error) {
+	data, err := EthereumBridge.PackL2TokenOf(l1Token)
+	if err != nil {
+		return gethcommon.Address{}, err
+	}
+	result, err := ac.callL2Bridge(ctx, data)
+	if err != nil {
+		return gethcommon.Address{}, err
+	}
+	return EthereumBridge.UnpackL2TokenOfOutput(result)
+}
+
+// L1TokenOf returns the L1 token of the wrapped token, or the zero address if it is not a wrapped token of the bridge
+func (ac *AuthObsClient) L1TokenOf(ctx context.Context, l2Token gethcommon.Address) (gethcommon.Address, error) {
+	data, err := EthereumBridge.PackL1TokenOf(l2Token)
+	if err != nil {
+		return gethcommon.Address{}, err
+	}
+	result, err := ac.callL2Bridge(ctx, data)
+	if err != nil {
+		return gethcommon.Address{}, err
+	}
+	return EthereumBridge.UnpackL1TokenOfOutput(result)
+}
+
+// callL2Bridge calls the L2 bridge registered in the important contracts of the network, at the head batch
+func (ac *AuthObsClient) callL2Bridge(ctx context.Context, data []byte) ([]byte, error) {
+	config, err := ac.GetConfig()
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch the network config - %w", err)
+	}
+	bridge, found := config.ImportantContracts[L2BridgeContractKey]
+	if !found {
+		return nil, fmt.Errorf("the network has no %s contract", L2BridgeContractKey)
+	}
+	// the result of the call is returned as a hex string
+	result, err := ac.CallContract(ctx, ethereum.CallMsg{From: ac.account, To: &bridge, Data: data}, nil)
+	if err != nil {
+		return nil, err
+	}
+	return hexutil.Decode(string(result))
+}
+
+// TransactionByNonce returns the transaction of the account registered on this client included with the nonce, so that
+// the replacements of a transaction (e.g. to speed it up or cancel it) can be told apart
+func (ac *AuthObsClient) TransactionByNonce(ctx context.Context, nonce uint64) (*common.IncludedTransaction, error) {
+	query := common.TransactionByNonceQuery{Address: ac.account, Nonce: hexutil.Uint64(nonce)}
+	var result common.IncludedTransaction
+	err := ac.rpcClient.CallContext(ctx, &result, rpc.GetStorageAt, common.TransactionByNonceCQMethod, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+// TransactionError returns why a transaction failed: the validation error that excluded it from a batch, or the error and
+// the revert data of its execution. The details are only returned if the transaction was sent by the account registered
+// on this client.
+func (ac *AuthObsClient) TransactionError(ctx context.Context, txHash gethcommon.Hash) (*common.TransactionError, error) {
+	query := common.TransactionErrorQuery{Address: ac.account, TxHash: txHash}
+	var result common.TransactionError
+	err := ac.rpcClient.CallContext(ctx, &result, rpc.GetStorageAt, common.TransactionErrorCQMethod, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 func (ac *AuthObsClient) SendTransaction(ctx context.Context, signedTx *types.Transaction) error {
 	var result responses.RawTxType
 	err := ac.rpcClient.CallContext(ctx, &result, rpc.SendRawTransaction, encodeTx(signedTx))
@@ -149,6 +364,23 @@ func (ac *AuthObsClient) GetLogs(ctx context.Context, filterCriteria common.Filt
 	return result, nil
 }
 
+// GetLogsPage returns a page of the logs of the account matching the filter, starting at the cursor returned with the
+// previous page (nil for the first page). The returned cursor is nil once all the logs were returned
+func (ac *AuthObsClient) GetLogsPage(ctx context.Context, filterCriteria common.FilterCriteriaJSON, cursor *common.LogCursor) (*common.LogsPage, error) {
+	query := common.LogsPageQuery{
+		Address: ac.account,
+		Filter:  filterCriteria,
+		Cursor:  cursor,
+	}
+	var result common.LogsPage
+	err := ac.rpcClient.CallContext(ctx, &result, rpc.GetStorageAt, common.LogsPageCQMethod, query, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return &result, nil
+}
+
 func (ac *AuthObsClient) Address() gethcommon.Address {
 	return ac.account
 }
@@ -2,13 +2,20 @@ package obsclient
 
 import (
 	"context"
+	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/contracts/generated/EthereumBridge"
 	"github.com/ten-protocol/go-ten/go/responses"
 	"github.com/ten-protocol/go-ten/go/rpc"
+
+	tencommon "github.com/ten-protocol/go-ten/go/common"
 )
 
 // These tests use a mocked RPC client, they test any transformations of the Go objects -> RPC params, as well as any
@@ -44,6 +51,36 @@ func TestNonceAt_ConvertsNilBlockNumberToLatest(t *testing.T) {
 	assert.Equal(t, uint64(2), nonce)
 }
 
+func TestTokenMappings_CallsTheL2BridgeOfTheNetwork(t *testing.T) {
+	mockRPC, authClient := createAuthClientWithMockRPCClient()
+	bridge := common.HexToAddress("0x0b")
+	mappings := []tencommon.TokenMapping{{L1Token: common.HexToAddress("0x01"), L2Token: common.HexToAddress("0x02"), Name: "Token", Symbol: "TKN"}}
+
+	mockRPC.On(
+		"Call",
+		mock.AnythingOfType("*common.ObscuroNetworkInfo"), rpc.Config, []interface{}(nil),
+	).Return(nil).Run(func(args mock.Arguments) {
+		res := args.Get(0).(*tencommon.ObscuroNetworkInfo)
+		res.ImportantContracts = map[string]common.Address{L2BridgeContractKey: bridge}
+	})
+	data, err := EthereumBridge.PackTokenMappings(big.NewInt(0), big.NewInt(10))
+	require.NoError(t, err)
+	mockRPC.On(
+		"CallContext",
+		testCtx, mock.AnythingOfType("*string"), rpc.Call, []interface{}{ToCallArg(ethereum.CallMsg{From: testAcc, To: &bridge, Data: data}), "latest"},
+	).Return(nil).Run(func(args mock.Arguments) {
+		output, err := EthereumBridge.ParsedEthereumBridgeABI().Methods[EthereumBridge.TokenMappingsMethod].Outputs.Pack(mappings)
+		require.NoError(t, err)
+		*args.Get(1).(*responses.CallType) = hexutil.Encode(output)
+	})
+
+	result, err := authClient.TokenMappings(testCtx, 0, 10)
+
+	mockRPC.AssertExpectations(t)
+	require.NoError(t, err)
+	assert.Equal(t, mappings, result)
+}
+
 func createAuthClientWithMockRPCClient() (*rpcClientMock, *AuthObsClient) {
 	mockRPC := new(rpcClientMock)
 	authClient := &AuthObsClient{
@@ -12,8 +12,10 @@ import (
 )
 
 const (
-	ws   = "ws://"
-	http = "http://"
+	ws    = "ws://"
+	wss   = "wss://"
+	http  = "http://"
+	https = "https://"
 )
 
 // networkClient is a Client implementation that wraps Geth's rpc.Client to make calls to the obscuro node
@@ -36,8 +38,9 @@ func NewEncNetworkClient(rpcAddress string, viewingKey *viewingkey.ViewingKey, l
 
 // NewNetworkClient returns a client that can make RPC calls to an Obscuro node
 func NewNetworkClient(address string) (Client, error) {
-	if !strings.HasPrefix(address, http) && !strings.HasPrefix(address, ws) {
-		return nil, fmt.Errorf("clients for Obscuro only support the %s and %s protocols", http, ws)
+	if !strings.HasPrefix(address, http) && !strings.HasPrefix(address, https) &&
+		!strings.HasPrefix(address, ws) && !strings.HasPrefix(address, wss) {
+		return nil, fmt.Errorf("clients for Obscuro only support the %s, %s, %s and %s protocols", http, https, ws, wss)
 	}
 
 	rpcClient, err := rpc.Dial(address)
@@ -71,9 +71,11 @@ func DefaultEnclaveConfig() *config.EnclaveConfig {
 		ManagementContractAddress: gethcommon.BytesToAddress([]byte("")),
 		LogLevel:                  int(gethlog.LvlInfo),
 		LogPath:                   log.SysOut,
+		LogRedactHexBytes:         log.DefaultRedactHexBytes,
 		UseInMemoryDB:             true, // todo (config) - persistence should be on by default before production release
 		EdgelessDBHost:            "",
 		SqliteDBPath:              "",
+		SqliteJournalMode:         "",
 		ProfilerEnabled:           false,
 		MinGasPrice:               big.NewInt(1),
 		SequencerID:               gethcommon.BytesToAddress([]byte("")),
@@ -81,9 +83,12 @@ func DefaultEnclaveConfig() *config.EnclaveConfig {
 		DebugNamespaceEnabled:     false,
 		MaxBatchSize:              1024 * 25,
 		MaxRollupSize:             1024 * 64,
+		RollupVerificationWorkers: 4,
 		GasPaymentAddress:         gethcommon.HexToAddress("0xd6C9230053f45F873Cb66D8A02439380a37A4fbF"),
 		BaseFee:                   new(big.Int).SetUint64(1),
 		GasBatchExecutionLimit:    30_000_000,
 		GasLocalExecutionCapFlag:  40_000_000,
+		MaxLogsBlockRange:         10_000,
+		MaxLogsPerQuery:           10_000,
 	}
 }
@@ -14,6 +14,8 @@ const (
 	StartPortFaucetHTTPUnitTest      = 48000
 	StartPortTenscanUnitTest         = 52000
 	StartPortTenGatewayUnitTest      = 56000
+	StartPortSimulationChaos         = 60000
+	StartPortSimulationGeographic    = 64000
 
 	DefaultGethWSPortOffset         = 100
 	DefaultGethAUTHPortOffset       = 200
@@ -79,6 +79,22 @@ func (m *mockContractLib) DecodeHostAddressesResponse([]byte) ([]string, error)
 	return []string{""}, nil
 }
 
+func (m *mockContractLib) GetAttestedMsg(gethcommon.Address) (ethereum.CallMsg, error) {
+	return ethereum.CallMsg{}, nil
+}
+
+func (m *mockContractLib) DecodeAttestedResponse([]byte) (bool, error) {
+	return true, nil
+}
+
+func (m *mockContractLib) GetHostAddressMsg(gethcommon.Address) (ethereum.CallMsg, error) {
+	return ethereum.CallMsg{}, nil
+}
+
+func (m *mockContractLib) DecodeHostAddressResponse([]byte) (string, error) {
+	return "", nil
+}
+
 func (m *mockContractLib) GetImportantContractKeysMsg() (ethereum.CallMsg, error) {
 	return ethereum.CallMsg{}, nil
 }
@@ -5,15 +5,15 @@ import (
 	"time"
 
 	"github.com/ten-protocol/go-ten/go/common/async"
+	"github.com/ten-protocol/go-ten/go/common/clock"
 
 	"github.com/ten-protocol/go-ten/go/common/log"
 
+	"github.com/ten-protocol/go-ten/integration/simulation/latency"
 	"github.com/ten-protocol/go-ten/integration/simulation/stats"
 
 	"github.com/ten-protocol/go-ten/integration/common/testlog"
 
-	testcommon "github.com/ten-protocol/go-ten/integration/common"
-
 	"github.com/ten-protocol/go-ten/go/ethadapter"
 
 	"github.com/ten-protocol/go-ten/go/common"
@@ -29,17 +29,19 @@ type MockEthNetwork struct {
 	AllNodes []*Node
 
 	// config
-	avgLatency       time.Duration
+	latency          latency.Model
+	clock            clock.Clock
 	avgBlockDuration time.Duration
 
 	Stats *stats.Stats
 }
 
 // NewMockEthNetwork returns an instance of a configured L1 Network (no nodes)
-func NewMockEthNetwork(avgBlockDuration time.Duration, avgLatency time.Duration, stats *stats.Stats) *MockEthNetwork {
+func NewMockEthNetwork(avgBlockDuration time.Duration, latency latency.Model, clk clock.Clock, stats *stats.Stats) *MockEthNetwork {
 	return &MockEthNetwork{
 		Stats:            stats,
-		avgLatency:       avgLatency,
+		latency:          latency,
+		clock:            clk,
 		avgBlockDuration: avgBlockDuration,
 	}
 }
@@ -50,7 +52,7 @@ func (n *MockEthNetwork) BroadcastBlock(b common.EncodedL1Block, p common.Encode
 	for _, m := range n.AllNodes {
 		if m.Info().L2ID != n.CurrentNode.Info().L2ID {
 			t := m
-			async.Schedule(n.delay(), func() { t.P2PReceiveBlock(b, p) })
+			async.ScheduleWithClock(n.clock, n.delay(m), func() { t.P2PReceiveBlock(b, p) })
 		} else {
 			m.logger.Info(printBlock(bl, m))
 		}
@@ -66,15 +68,20 @@ func (n *MockEthNetwork) BroadcastTx(tx *types.Transaction) {
 			t := m
 			// the time to broadcast a tx is half that of a L1 block, because it is smaller.
 			// todo - find a better way to express this
-			d := n.delay() / 2
-			async.Schedule(d, func() { t.P2PGossipTx(tx) })
+			d := n.delay(m) / 2
+			async.ScheduleWithClock(n.clock, d, func() { t.P2PGossipTx(tx) })
 		}
 	}
 }
 
-// delay returns an expected delay on the l1 network
-func (n *MockEthNetwork) delay() time.Duration {
-	return testcommon.RndBtwTime(n.avgLatency/10, 2*n.avgLatency)
+// delay returns an expected delay on the l1 network, from the current node to the given node
+func (n *MockEthNetwork) delay(to *Node) time.Duration {
+	return n.latency.Latency(nodeIndex(n.CurrentNode), nodeIndex(to))
+}
+
+// the mock nodes are created with the address of their index
+func nodeIndex(node *Node) int {
+	return int(node.Info().L2ID.Big().Int64())
 }
 
 func printBlock(b *types.Block, m *Node) string {
@@ -2,16 +2,17 @@ package ethereummock
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
 	"math/big"
 	"sync"
-	"sync/atomic"
 	"time"
 
 	"github.com/ten-protocol/go-ten/go/enclave/storage"
 
 	"github.com/ten-protocol/go-ten/go/common/async"
+	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
 
 	"github.com/google/uuid"
 
@@ -70,7 +71,7 @@ type Node struct {
 	// Channels
 	exitCh       chan bool // the Node stops
 	exitMiningCh chan bool // the mining loop is notified to stop
-	interrupt    *int32
+	stopControl  *stopcontrol.StopControl
 
 	p2pCh       chan *types.Block       // this is where blocks received from peers are dropped
 	miningCh    chan *types.Block       // this is where blocks created by the mining setup of the current node are dropped
@@ -114,6 +115,10 @@ func (m *Node) TransactionReceipt(_ gethcommon.Hash) (*types.Receipt, error) {
 	}, nil
 }
 
+func (m *Node) SuggestGasPrice() (*big.Int, error) {
+	return big.NewInt(1), nil
+}
+
 func (m *Node) Nonce(gethcommon.Address) (uint64, error) {
 	return 0, nil
 }
@@ -344,7 +349,7 @@ func (m *Node) processBlock(b *types.Block, head *types.Block) *types.Block {
 
 // Notifies the Miner to start mining on the new block and the aggregator to produce rollups
 func (m *Node) setHead(b *types.Block) *types.Block {
-	if atomic.LoadInt32(m.interrupt) == 1 {
+	if m.stopControl.IsStopping() {
 		return b
 	}
 
@@ -362,7 +367,7 @@ func (m *Node) setHead(b *types.Block) *types.Block {
 
 func (m *Node) setFork(blocks []*types.Block) *types.Block {
 	head := blocks[len(blocks)-1]
-	if atomic.LoadInt32(m.interrupt) == 1 {
+	if m.stopControl.IsStopping() {
 		return head
 	}
 
@@ -382,7 +387,7 @@ func (m *Node) setFork(blocks []*types.Block) *types.Block {
 // P2PReceiveBlock is called by counterparties when there is a block to broadcast
 // All it does is drop the blocks in a channel for processing.
 func (m *Node) P2PReceiveBlock(b common.EncodedL1Block, p common.EncodedL1Block) {
-	if atomic.LoadInt32(m.interrupt) == 1 {
+	if m.stopControl.IsStopping() {
 		return
 	}
 	decodedBlock, err := b.DecodeBlock()
@@ -403,8 +408,8 @@ func (m *Node) startMining() {
 	m.logger.Info(" starting miner...")
 	// stores all transactions seen from the beginning of time.
 	mempool := make([]*types.Transaction, 0)
-	z := int32(0)
-	interrupt := &z
+	stopRound := func() {}
+	defer func() { stopRound() }()
 
 	for {
 		select {
@@ -420,20 +425,19 @@ func (m *Node) startMining() {
 			mempool = m.removeCommittedTransactions(canonicalBlock, mempool, m.Resolver, m.db)
 
 			// notify the existing mining go routine to stop mining
-			atomic.StoreInt32(interrupt, 1)
-			c := int32(0)
-			interrupt = &c
+			stopRound()
+			round, cancelRound := context.WithCancel(m.stopControl.Context())
+			stopRound = cancelRound
 
 			// Generate a random number, and wait for that number of ms. Equivalent to PoW
 			// Include all rollups received during this period.
-			async.Schedule(m.cfg.PowTime(), func() {
+			async.ScheduleWithContext(round, m.cfg.PowTime(), func() {
 				toInclude := findNotIncludedTxs(canonicalBlock, mempool, m.Resolver, m.db)
 				// todo - iterate through the rollup transactions and include only the ones with the proof on the canonical chain
-				if atomic.LoadInt32(m.interrupt) == 1 {
-					return
+				select {
+				case m.miningCh <- NewBlock(canonicalBlock, m.l2ID, toInclude):
+				case <-round.Done():
 				}
-
-				m.miningCh <- NewBlock(canonicalBlock, m.l2ID, toInclude)
 			})
 		}
 	}
@@ -441,7 +445,7 @@ func (m *Node) startMining() {
 
 // P2PGossipTx receive rollups to publish from the linked aggregators
 func (m *Node) P2PGossipTx(tx *types.Transaction) {
-	if atomic.LoadInt32(m.interrupt) == 1 {
+	if m.stopControl.IsStopping() {
 		return
 	}
 
@@ -454,7 +458,7 @@ func (m *Node) BroadcastTx(tx types.TxData) {
 
 func (m *Node) Stop() {
 	// block all requests
-	atomic.StoreInt32(m.interrupt, 1)
+	m.stopControl.Stop()
 	time.Sleep(time.Millisecond * 100)
 
 	m.exitMiningCh <- true
@@ -524,7 +528,7 @@ func NewMiner(
 		Network:          network,
 		exitCh:           make(chan bool),
 		exitMiningCh:     make(chan bool),
-		interrupt:        new(int32),
+		stopControl:      stopcontrol.New(),
 		p2pCh:            make(chan *types.Block),
 		miningCh:         make(chan *types.Block),
 		canonicalCh:      make(chan *types.Block),
@@ -0,0 +1,113 @@
+package rpcconformance
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+const (
+	_requestPrefix  = ">> "
+	_responsePrefix = "<< "
+	_commentPrefix  = "//"
+
+	// _specOnlyDirective marks the tests whose responses depend on the chain the suite was generated against, so only
+	// their shape is checked
+	_specOnlyDirective = "speconly"
+	// _accountPlaceholder is replaced with the account of the viewing key of the runner. The private data is only
+	// returned to its owner, so the adapted tests query the account of the runner
+	_accountPlaceholder = "${account}"
+)
+
+// Fixture is a test of the execution-apis conformance suite: a file `<method>/<test name>.io` listing the requests
+// sent to the node (`>> `) and the responses expected (`<< `)
+type Fixture struct {
+	Method    string
+	Name      string
+	SpecOnly  bool
+	Exchanges []Exchange
+}
+
+// Exchange is a request of a fixture and the response expected for it
+type Exchange struct {
+	Request  json.RawMessage
+	Response json.RawMessage
+}
+
+type jsonRPCRequest struct {
+	Method string            `json:"method"`
+	Params []json.RawMessage `json:"params"`
+}
+
+type jsonRPCResponse struct {
+	Result json.RawMessage `json:"result"`
+	Error  json.RawMessage `json:"error"`
+}
+
+// LoadFixtures loads the fixtures of the suite in the directory, sorted by method and name
+func LoadFixtures(dir string) ([]*Fixture, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*", "*.io"))
+	if err != nil {
+		return nil, err
+	}
+	if len(paths) == 0 {
+		return nil, fmt.Errorf("no conformance tests found in %s", dir)
+	}
+	sort.Strings(paths)
+
+	fixtures := make([]*Fixture, 0, len(paths))
+	for _, path := range paths {
+		fixture, err := loadFixture(path)
+		if err != nil {
+			return nil, fmt.Errorf("could not load conformance test %s - %w", path, err)
+		}
+		fixtures = append(fixtures, fixture)
+	}
+	return fixtures, nil
+}
+
+func loadFixture(path string) (*Fixture, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+
+	fixture := &Fixture{
+		Method: filepath.Base(filepath.Dir(path)),
+		Name:   strings.TrimSuffix(filepath.Base(path), ".io"),
+	}
+	scanner := bufio.NewScanner(file)
+	// the responses of the blocks with their transactions can be large
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, _commentPrefix):
+			if strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(line, _commentPrefix)), _specOnlyDirective) {
+				fixture.SpecOnly = true
+			}
+		case strings.HasPrefix(line, _requestPrefix):
+			fixture.Exchanges = append(fixture.Exchanges, Exchange{Request: json.RawMessage(strings.TrimPrefix(line, _requestPrefix))})
+		case strings.HasPrefix(line, _responsePrefix):
+			if len(fixture.Exchanges) == 0 || fixture.Exchanges[len(fixture.Exchanges)-1].Response != nil {
+				return nil, fmt.Errorf("response without a request: %s", line)
+			}
+			fixture.Exchanges[len(fixture.Exchanges)-1].Response = json.RawMessage(strings.TrimPrefix(line, _responsePrefix))
+		default:
+			return nil, fmt.Errorf("unexpected line: %s", line)
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(fixture.Exchanges) == 0 {
+		return nil, fmt.Errorf("no requests")
+	}
+	return fixture, nil
+}
@@ -0,0 +1,108 @@
+package rpcconformance
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Report lists the conformance tests that passed and failed, by method
+type Report struct {
+	Methods map[string]*MethodReport `json:"methods"`
+}
+
+// MethodReport lists the tests of a method that passed, and the reason of the failure of the others
+type MethodReport struct {
+	Passed []string          `json:"passed"`
+	Failed map[string]string `json:"failed"`
+}
+
+// Passing returns whether the method passed all its tests
+func (m *MethodReport) Passing() bool {
+	return len(m.Failed) == 0
+}
+
+func newReport() *Report {
+	return &Report{Methods: map[string]*MethodReport{}}
+}
+
+func (r *Report) record(fixture *Fixture, err error) {
+	method, found := r.Methods[fixture.Method]
+	if !found {
+		method = &MethodReport{Passed: []string{}, Failed: map[string]string{}}
+		r.Methods[fixture.Method] = method
+	}
+	if err != nil {
+		method.Failed[fixture.Name] = err.Error()
+		return
+	}
+	method.Passed = append(method.Passed, fixture.Name)
+}
+
+// Compare returns the methods of the baseline that no longer pass all their tests, and the methods passing all their
+// tests that are not in the baseline yet
+func (r *Report) Compare(baseline []string) (regressions []string, improvements []string) {
+	inBaseline := map[string]bool{}
+	for _, method := range baseline {
+		inBaseline[method] = true
+		if report, found := r.Methods[method]; !found || !report.Passing() {
+			regressions = append(regressions, method)
+		}
+	}
+	for _, method := range r.sortedMethods() {
+		if r.Methods[method].Passing() && !inBaseline[method] {
+			improvements = append(improvements, method)
+		}
+	}
+	return regressions, improvements
+}
+
+// Summary returns a line per method with the number of tests passed, followed by the reasons of the failures
+func (r *Report) Summary() string {
+	var summary strings.Builder
+	passingMethods := 0
+	for _, name := range r.sortedMethods() {
+		method := r.Methods[name]
+		status := "PASS"
+		if method.Passing() {
+			passingMethods++
+		} else {
+			status = "FAIL"
+		}
+		fmt.Fprintf(&summary, "%s %-40s %d/%d\n", status, name, len(method.Passed), len(method.Passed)+len(method.Failed))
+		tests := make([]string, 0, len(method.Failed))
+		for test := range method.Failed {
+			tests = append(tests, test)
+		}
+		sort.Strings(tests)
+		for _, test := range tests {
+			fmt.Fprintf(&summary, "     %s: %s\n", test, method.Failed[test])
+		}
+	}
+	fmt.Fprintf(&summary, "%d/%d methods passing\n", passingMethods, len(r.Methods))
+	return summary.String()
+}
+
+// Write writes the report as JSON to the path, creating its directory if needed
+func (r *Report) Write(path string) error {
+	encoded, err := json.MarshalIndent(r, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err = os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(path, encoded, 0o644) //nolint:gosec
+}
+
+func (r *Report) sortedMethods() []string {
+	methods := make([]string, 0, len(r.Methods))
+	for method := range r.Methods {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	return methods
+}
@@ -0,0 +1,173 @@
+package rpcconformance
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/ten-protocol/go-ten/go/common/viewingkey"
+	"github.com/ten-protocol/go-ten/go/rpc"
+	"github.com/ten-protocol/go-ten/integration/common/testlog"
+	"github.com/ten-protocol/go-ten/integration/datagenerator"
+	"github.com/ten-protocol/go-ten/integration/networktest"
+
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// the JSON-RPC error code of the methods that do not exist
+const _methodNotFoundCode = -32601
+
+// RunSuite runs the conformance tests of TestsDir against the first validator of the network, and reports the tests
+// that passed and failed for each method. The confidential methods are called with the viewing key of a new account,
+// so their requests and responses are encrypted like the ones of a wallet.
+//
+// The verification fails when a method listed in the baseline (a JSON array of method names) no longer passes all its
+// tests, so that the RPC compatibility of the node is tracked over time.
+type RunSuite struct {
+	TestsDir     string
+	BaselinePath string
+	ReportPath   string // the JSON report is written there, if set
+
+	report *Report
+}
+
+func (s *RunSuite) String() string {
+	return fmt.Sprintf("RunSuite [%s]", s.TestsDir)
+}
+
+func (s *RunSuite) Run(ctx context.Context, network networktest.NetworkConnector) (context.Context, error) {
+	fixtures, err := LoadFixtures(s.TestsDir)
+	if err != nil {
+		return ctx, err
+	}
+
+	viewingKey, err := viewingkey.GenerateViewingKeyForWallet(datagenerator.RandomWallet(network.ChainID()))
+	if err != nil {
+		return ctx, err
+	}
+	client, err := rpc.NewEncNetworkClient(network.ValidatorRPCAddress(0), viewingKey, testlog.Logger())
+	if err != nil {
+		return ctx, fmt.Errorf("could not connect to the validator - %w", err)
+	}
+	defer client.Stop()
+
+	s.report = newReport()
+	for _, fixture := range fixtures {
+		s.report.record(fixture, runFixture(ctx, client, fixture))
+	}
+
+	fmt.Print(s.report.Summary())
+	if s.ReportPath != "" {
+		if err = s.report.Write(s.ReportPath); err != nil {
+			return ctx, fmt.Errorf("could not write the conformance report - %w", err)
+		}
+	}
+	return ctx, nil
+}
+
+func (s *RunSuite) Verify(_ context.Context, _ networktest.NetworkConnector) error {
+	encodedBaseline, err := os.ReadFile(s.BaselinePath)
+	if err != nil {
+		return fmt.Errorf("could not read the conformance baseline - %w", err)
+	}
+	var baseline []string
+	if err = json.Unmarshal(encodedBaseline, &baseline); err != nil {
+		return fmt.Errorf("could not decode the conformance baseline - %w", err)
+	}
+
+	regressions, improvements := s.report.Compare(baseline)
+	if len(improvements) > 0 {
+		fmt.Printf("Methods now passing all their conformance tests, to add to the baseline: %s\n", strings.Join(improvements, ", "))
+	}
+	if len(regressions) > 0 {
+		return fmt.Errorf("methods of the baseline failing their conformance tests: %s", strings.Join(regressions, ", "))
+	}
+	return nil
+}
+
+// runs the requests of the fixture in order, and returns the first mismatch with the responses expected
+func runFixture(ctx context.Context, client *rpc.EncRPCClient, fixture *Fixture) error {
+	account := strings.ToLower(client.Account().Hex())
+	for i, exchange := range fixture.Exchanges {
+		var request jsonRPCRequest
+		if err := json.Unmarshal(withAccount(exchange.Request, account), &request); err != nil {
+			return fmt.Errorf("request %d is not a JSON-RPC request - %w", i, err)
+		}
+		var expected jsonRPCResponse
+		if err := json.Unmarshal(withAccount(exchange.Response, account), &expected); err != nil {
+			return fmt.Errorf("response %d is not a JSON-RPC response - %w", i, err)
+		}
+
+		params := make([]interface{}, len(request.Params))
+		for j, param := range request.Params {
+			params[j] = param
+		}
+		var result json.RawMessage
+		err := client.CallContext(ctx, &result, request.Method, params...)
+		if errors.Is(err, rpc.ErrNilResponse) {
+			// the confidential methods return a nil response for a null result
+			result, err = json.RawMessage("null"), nil
+		}
+		if err = compare(&expected, result, err, fixture.SpecOnly); err != nil {
+			return fmt.Errorf("request %d (%s): %w", i, request.Method, err)
+		}
+	}
+	return nil
+}
+
+// compare checks the response of the node against the one expected. The codes and messages of the errors are not
+// compared, as the confidential methods return the errors of the enclave as plain messages, but a method the node does
+// not serve fails its tests
+func compare(expected *jsonRPCResponse, result json.RawMessage, callErr error, specOnly bool) error {
+	var rpcErr gethrpc.Error
+	if errors.As(callErr, &rpcErr) && rpcErr.ErrorCode() == _methodNotFoundCode {
+		return fmt.Errorf("the method is not served - %w", callErr)
+	}
+	if !isNull(expected.Error) {
+		if callErr == nil {
+			return fmt.Errorf("expected an error, got the result %s", result)
+		}
+		return nil
+	}
+	if callErr != nil {
+		return fmt.Errorf("expected a result, got the error %w", callErr)
+	}
+
+	var expectedValue, actualValue interface{}
+	if err := json.Unmarshal(nullIfEmpty(expected.Result), &expectedValue); err != nil {
+		return fmt.Errorf("the expected result is not valid JSON - %w", err)
+	}
+	if err := json.Unmarshal(nullIfEmpty(result), &actualValue); err != nil {
+		return fmt.Errorf("the result is not valid JSON - %w", err)
+	}
+	if specOnly {
+		if reflect.TypeOf(expectedValue) != reflect.TypeOf(actualValue) {
+			return fmt.Errorf("expected a result of type %T, got %s", expectedValue, result)
+		}
+		return nil
+	}
+	if !reflect.DeepEqual(expectedValue, actualValue) {
+		return fmt.Errorf("expected the result %s, got %s", expected.Result, result)
+	}
+	return nil
+}
+
+func withAccount(raw json.RawMessage, account string) []byte {
+	return bytes.ReplaceAll(raw, []byte(_accountPlaceholder), []byte(account))
+}
+
+func isNull(raw json.RawMessage) bool {
+	return len(raw) == 0 || bytes.Equal(raw, []byte("null"))
+}
+
+func nullIfEmpty(raw json.RawMessage) json.RawMessage {
+	if len(raw) == 0 {
+		return json.RawMessage("null")
+	}
+	return raw
+}
@@ -0,0 +1,47 @@
+package rpcconformance
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/integration/networktest"
+	"github.com/ten-protocol/go-ten/integration/networktest/actions/rpcconformance"
+	"github.com/ten-protocol/go-ten/integration/networktest/env"
+)
+
+const (
+	// conformanceEnvVar enables TestRPCConformance, which spins up a local network
+	conformanceEnvVar = "TEN_RPC_CONFORMANCE"
+	// testsDirEnvVar points to another suite, e.g. the `tests` directory of a checkout of ethereum/execution-apis
+	testsDirEnvVar = "TEN_RPC_CONFORMANCE_TESTS"
+
+	_testsDir     = "testdata"
+	_baselinePath = "baseline.json"
+	_reportPath   = "../../../.build/rpcconformance/report.json"
+)
+
+// TestRPCConformance runs the JSON-RPC conformance suite against a validator of a local network, writes the pass/fail
+// report of each method and fails when a method of the baseline regresses. It only runs when TEN_RPC_CONFORMANCE is set.
+func TestRPCConformance(t *testing.T) {
+	if os.Getenv(conformanceEnvVar) == "" {
+		t.Skipf("set %s to run the RPC conformance suite", conformanceEnvVar)
+	}
+	testsDir := _testsDir
+	if dir := os.Getenv(testsDirEnvVar); dir != "" {
+		testsDir = dir
+	}
+	networktest.Run(
+		"rpc-conformance",
+		t,
+		env.LocalDevNetwork(),
+		&rpcconformance.RunSuite{TestsDir: testsDir, BaselinePath: _baselinePath, ReportPath: _reportPath},
+	)
+}
+
+// the adapted suite is loaded whenever the tests run, so that a broken test file does not wait for the conformance run
+func TestConformanceTestsAreValid(t *testing.T) {
+	fixtures, err := rpcconformance.LoadFixtures(_testsDir)
+	require.NoError(t, err)
+	require.NotEmpty(t, fixtures)
+}
@@ -0,0 +1,4 @@
+// retrieves the number of the head batch
+// speconly
+>> {"jsonrpc":"2.0","id":1,"method":"eth_blockNumber"}
+<< {"jsonrpc":"2.0","id":1,"result":"0x0"}
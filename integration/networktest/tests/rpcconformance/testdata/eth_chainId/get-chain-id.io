@@ -0,0 +1,3 @@
+// retrieves the chain ID of the TEN network
+>> {"jsonrpc":"2.0","id":1,"method":"eth_chainId"}
+<< {"jsonrpc":"2.0","id":1,"result":"0x1bb"}
@@ -0,0 +1,4 @@
+// estimates the gas of a transfer of no value from the new account of the runner
+// speconly
+>> {"jsonrpc":"2.0","id":1,"method":"eth_estimateGas","params":[{"from":"${account}","to":"0x0000000000000000000000000000000000000001","value":"0x0"}]}
+<< {"jsonrpc":"2.0","id":1,"result":"0x5208"}
@@ -0,0 +1,3 @@
+// retrieves the balance of the new account of the runner
+>> {"jsonrpc":"2.0","id":1,"method":"eth_getBalance","params":["${account}","latest"]}
+<< {"jsonrpc":"2.0","id":1,"result":"0x0"}
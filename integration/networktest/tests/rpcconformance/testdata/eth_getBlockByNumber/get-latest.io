@@ -0,0 +1,4 @@
+// retrieves the head batch
+// speconly
+>> {"jsonrpc":"2.0","id":1,"method":"eth_getBlockByNumber","params":["latest",false]}
+<< {"jsonrpc":"2.0","id":1,"result":{}}
@@ -0,0 +1,3 @@
+// retrieves the code of an externally owned account
+>> {"jsonrpc":"2.0","id":1,"method":"eth_getCode","params":["${account}","latest"]}
+<< {"jsonrpc":"2.0","id":1,"result":"0x"}
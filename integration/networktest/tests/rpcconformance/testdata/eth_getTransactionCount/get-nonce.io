@@ -0,0 +1,3 @@
+// retrieves the nonce of the new account of the runner
+>> {"jsonrpc":"2.0","id":1,"method":"eth_getTransactionCount","params":["${account}","latest"]}
+<< {"jsonrpc":"2.0","id":1,"result":"0x0"}
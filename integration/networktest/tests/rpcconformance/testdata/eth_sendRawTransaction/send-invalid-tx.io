@@ -0,0 +1,3 @@
+// sends bytes that do not decode to a transaction
+>> {"jsonrpc":"2.0","id":1,"method":"eth_sendRawTransaction","params":["0xdeadbeef"]}
+<< {"jsonrpc":"2.0","id":1,"error":{"code":-32000,"message":"typed transaction too short"}}
@@ -2,6 +2,7 @@ package noderunner
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/ten-protocol/go-ten/go/node"
 	"github.com/ten-protocol/go-ten/go/wallet"
@@ -84,6 +85,32 @@ func (d *InMemNode) Upgrade(networkCfg *node.NetworkConfig) error {
 	return nil
 }
 
+// RestartEnclave kills the enclave of the node and starts a new one after the downtime. The host keeps running, it has to
+// notice the restart and bring the new enclave up to date on its own
+func (d *InMemNode) RestartEnclave(downtime time.Duration) error {
+	testlog.Logger().Info("Restarting enclave", log.NodeIDKey, d.cfg.ToEnclaveConfig().HostID)
+	if err := d.enclave.Stop(); err != nil {
+		return fmt.Errorf("could not stop the enclave - %w", err)
+	}
+	time.Sleep(downtime)
+	return d.startEnclave()
+}
+
+// RestartHost kills the host of the node and starts a new one after the downtime. A host stops its enclave when it stops,
+// so a new enclave is started before the new host. The downtime must leave the host RPC server, which stops
+// asynchronously, the time to release its ports
+func (d *InMemNode) RestartHost(downtime time.Duration) error {
+	testlog.Logger().Info("Restarting host", log.NodeIDKey, d.cfg.ToEnclaveConfig().HostID)
+	if err := d.host.Stop(); err != nil {
+		return fmt.Errorf("could not stop the host - %w", err)
+	}
+	time.Sleep(downtime)
+	if err := d.startEnclave(); err != nil {
+		return err
+	}
+	return d.startHost()
+}
+
 func (d *InMemNode) startHost() error {
 	hostConfig := d.cfg.ToHostConfig()
 	// calculate the host ID from the private key here, so we have it for the logger
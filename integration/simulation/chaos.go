@@ -0,0 +1,153 @@
+package simulation
+
+import (
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/integration/common/testlog"
+	"github.com/ten-protocol/go-ten/integration/simulation/network"
+	"github.com/ten-protocol/go-ten/integration/simulation/params"
+
+	testcommon "github.com/ten-protocol/go-ten/integration/common"
+)
+
+const (
+	chaosRecoveryTimeout      = 2 * time.Minute        // How long the nodes have to recover once the chaos stopped.
+	chaosRecoveryPollInterval = 500 * time.Millisecond // The interval between two checks of the recovery of the nodes.
+)
+
+// chaosController restarts random validator enclaves and hosts while the transactions are injected. Only one node is down at
+// a time. The sequencer is spared: its enclave holds the only copy of the chain when the enclave DBs are in memory, so the
+// network cannot recover from its restart
+type chaosController struct {
+	network network.RestartableNetwork
+	params  *params.SimParams
+	stop    chan struct{}
+	wg      sync.WaitGroup
+
+	restartLock sync.Mutex // held while a node is down
+	statsLock   sync.Mutex
+	restarts    map[string]int // the number of restarts, by kind of restart
+	errs        []error
+}
+
+func newChaosController(netw network.RestartableNetwork, params *params.SimParams) *chaosController {
+	return &chaosController{
+		network:  netw,
+		params:   params,
+		stop:     make(chan struct{}),
+		restarts: map[string]int{},
+	}
+}
+
+// Start restarts random validators until Stop is called
+func (c *chaosController) Start() {
+	if c.params.NumberOfNodes < 2 {
+		testlog.Logger().Warn("No validator to restart, the chaos mode is disabled")
+		return
+	}
+	if c.params.ChaosEnclaveRestartInterval > 0 {
+		c.wg.Add(1)
+		go c.restartAtRandom("enclave", c.params.ChaosEnclaveRestartInterval, c.network.RestartEnclave)
+	}
+	if c.params.ChaosHostRestartInterval > 0 {
+		c.wg.Add(1)
+		go c.restartAtRandom("host", c.params.ChaosHostRestartInterval, c.network.RestartHost)
+	}
+}
+
+// Stop waits for the restart in progress, if any, and stops restarting the validators
+func (c *chaosController) Stop() {
+	close(c.stop)
+	c.wg.Wait()
+}
+
+func (c *chaosController) restartAtRandom(kind string, avgInterval time.Duration, restart func(int, time.Duration) error) {
+	defer c.wg.Done()
+	for {
+		select {
+		case <-c.stop:
+			return
+		case <-time.After(testcommon.RndBtwTime(avgInterval/2, avgInterval*3/2)):
+		}
+
+		nodeIdx := 1 + rand.Intn(c.params.NumberOfNodes-1) //nolint:gosec
+		c.restartLock.Lock()
+		testlog.Logger().Info(fmt.Sprintf("Chaos - restarting the %s of node %d", kind, nodeIdx))
+		err := restart(nodeIdx, c.params.ChaosDowntime)
+		c.restartLock.Unlock()
+
+		c.statsLock.Lock()
+		c.restarts[kind]++
+		if err != nil {
+			testlog.Logger().Error(fmt.Sprintf("Chaos - could not restart the %s of node %d", kind, nodeIdx), log.ErrKey, err)
+			c.errs = append(c.errs, fmt.Errorf("could not restart the %s of node %d - %w", kind, nodeIdx, err))
+		}
+		c.statsLock.Unlock()
+	}
+}
+
+// checkRecovery checks that the restarted nodes came back without any intervention: they are healthy and agree on the
+// head of the chain
+func (c *chaosController) checkRecovery(t TestingT, rpcHandles *network.RPCHandles) {
+	if !c.params.RunsCheck(params.RecoveryCheck) {
+		return
+	}
+	c.statsLock.Lock()
+	t.Logf("Chaos - restarts: %v", c.restarts)
+	for _, err := range c.errs {
+		t.Error(err)
+	}
+	c.statsLock.Unlock()
+
+	deadline := time.Now().Add(chaosRecoveryTimeout)
+	for {
+		err := checkNodesConverged(rpcHandles)
+		if err == nil {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Errorf("Chaos - the nodes did not recover after %s - %s", chaosRecoveryTimeout, err)
+			return
+		}
+		time.Sleep(chaosRecoveryPollInterval)
+	}
+}
+
+// checkNodesConverged returns an error unless all the nodes are healthy and have the same batch at the height of the lowest
+// head, with the other heads at most one batch ahead of it (a batch can always be on its way to some nodes)
+func checkNodesConverged(rpcHandles *network.RPCHandles) error {
+	heights := make([]uint64, len(rpcHandles.ObscuroClients))
+	for nodeIdx, client := range rpcHandles.ObscuroClients {
+		if healthy, err := client.Health(); !healthy {
+			return fmt.Errorf("node %d is not healthy - %w", nodeIdx, err)
+		}
+		height, err := client.BatchNumber()
+		if err != nil {
+			return fmt.Errorf("could not fetch the head of node %d - %w", nodeIdx, err)
+		}
+		heights[nodeIdx] = height
+	}
+	lowest, highest := minMax(heights)
+	if highest-lowest > 1 {
+		return fmt.Errorf("the heads of the nodes are too far apart - %v", heights)
+	}
+
+	var headHash string
+	for nodeIdx, client := range rpcHandles.ObscuroClients {
+		header, err := client.BatchHeaderByNumber(big.NewInt(int64(lowest)))
+		if err != nil {
+			return fmt.Errorf("could not fetch the batch %d of node %d - %w", lowest, nodeIdx, err)
+		}
+		if nodeIdx == 0 {
+			headHash = header.Hash().Hex()
+		} else if header.Hash().Hex() != headHash {
+			return fmt.Errorf("node %d has the batch %s at height %d, node 0 has %s", nodeIdx, header.Hash().Hex(), lowest, headHash)
+		}
+	}
+	return nil
+}
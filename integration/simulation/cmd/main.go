@@ -0,0 +1,85 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"runtime"
+	"sync"
+
+	"github.com/ten-protocol/go-ten/integration/common/testlog"
+	"github.com/ten-protocol/go-ten/integration/simulation"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+// Runs the simulation described by a scenario file, e.g.:
+//
+//	go run ./integration/simulation/cmd -scenario integration/simulation/scenarios/in_mem_small.yaml
+func main() {
+	scenarioPath := flag.String("scenario", "", "The YAML file of the scenario to run")
+	logDir := flag.String("logDir", "./.build/simulations/", "The directory of the log file of the simulation")
+	flag.Parse()
+	if *scenarioPath == "" {
+		fmt.Println("Exiting. The scenario is required, e.g. -scenario x.yaml")
+		os.Exit(2)
+	}
+
+	scenario, err := simulation.LoadScenario(*scenarioPath)
+	if err != nil {
+		fmt.Printf("Exiting. %s\n", err)
+		os.Exit(2)
+	}
+	testlog.Setup(&testlog.Cfg{
+		LogDir:      *logDir,
+		TestType:    "sim-log",
+		TestSubtype: "scenario-" + scenario.Name,
+		LogLevel:    gethlog.LvlTrace,
+	})
+	fmt.Printf("Running the scenario %s, logging to %s\n", scenario.Name, testlog.LogFile())
+
+	r := &reporter{}
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		simulation.RunScenario(r, scenario)
+	}()
+	<-done
+
+	if r.failed {
+		fmt.Printf("The scenario %s failed\n", scenario.Name)
+		os.Exit(1)
+	}
+	fmt.Printf("The scenario %s passed\n", scenario.Name)
+}
+
+// reporter receives the results of the simulation like a testing.T. An error fails the run, a fatal error also ends it.
+type reporter struct {
+	lock   sync.Mutex
+	failed bool
+}
+
+func (r *reporter) Error(args ...any) {
+	r.fail(fmt.Sprint(args...))
+}
+
+func (r *reporter) Errorf(format string, args ...any) {
+	r.fail(fmt.Sprintf(format, args...))
+}
+
+// Fatalf ends the goroutine of the simulation after running its deferred calls, like testing.T does
+func (r *reporter) Fatalf(format string, args ...any) {
+	r.fail(fmt.Sprintf(format, args...))
+	runtime.Goexit()
+}
+
+func (r *reporter) Logf(format string, args ...any) {
+	fmt.Printf(format+"\n", args...)
+}
+
+func (r *reporter) fail(msg string) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+	r.failed = true
+	fmt.Println("FAIL: " + msg)
+}
@@ -16,6 +16,7 @@ import (
 
 	gethlog "github.com/ethereum/go-ethereum/log"
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/common/metrics"
 	"github.com/ten-protocol/go-ten/go/config"
@@ -134,6 +135,18 @@ func (n *InMemNodeOperator) createHostContainer() *hostcontainer.HostContainer {
 		RollupInterval:            n.config.RollupInterval,
 		L1BlockTime:               n.config.L1BlockTime,
 		MaxRollupSize:             1024 * 64,
+		IngestionQueueSize:        config.DefaultHostParsedConfig().IngestionQueueSize,
+		L1BlockQueuePolicy:        config.DefaultHostParsedConfig().L1BlockQueuePolicy,
+		TxQueuePolicy:             config.DefaultHostParsedConfig().TxQueuePolicy,
+		L1TxMaxRetries:            config.DefaultHostParsedConfig().L1TxMaxRetries,
+		L1RebidBlocks:             config.DefaultHostParsedConfig().L1RebidBlocks,
+		L1GasOracleBlocks:         config.DefaultHostParsedConfig().L1GasOracleBlocks,
+		SecretResponseCooldown:    config.DefaultHostParsedConfig().SecretResponseCooldown,
+		MaxSecretResponsesPerHour: config.DefaultHostParsedConfig().MaxSecretResponsesPerHour,
+		P2PMaxMessageSize:         config.DefaultHostParsedConfig().P2PMaxMessageSize,
+		P2PMaxPeerMsgsPerSecond:   config.DefaultHostParsedConfig().P2PMaxPeerMsgsPerSecond,
+		P2PBanThreshold:           config.DefaultHostParsedConfig().P2PBanThreshold,
+		P2PBanDuration:            config.DefaultHostParsedConfig().P2PBanDuration,
 	}
 
 	hostLogger := testlog.Logger().New(log.NodeIDKey, n.l1Wallet.Address(), log.CmpKey, log.HostCmp)
@@ -141,14 +154,14 @@ func (n *InMemNodeOperator) createHostContainer() *hostcontainer.HostContainer {
 	// create a socket P2P layer
 	p2pLogger := hostLogger.New(log.CmpKey, log.P2PCmp)
 	svcLocator := host.NewServicesRegistry(n.logger)
-	nodeP2p := p2p.NewSocketP2PLayer(hostConfig, svcLocator, p2pLogger, nil)
+	nodeP2p := p2p.NewSocketP2PLayer(hostConfig, nil, svcLocator, n.l1Wallet.PrivateKey(), p2pLogger, nil)
 	// create an enclave client
 
-	enclaveClient := enclaverpc.NewClient(hostConfig, testlog.Logger().New(log.NodeIDKey, n.l1Wallet.Address()))
+	enclaveClient := enclaverpc.NewClient(hostConfig, testlog.Logger().New(log.NodeIDKey, n.l1Wallet.Address()), nil)
 	rpcServer := clientrpc.NewServer(hostConfig, n.logger)
 	mgmtContractLib := mgmtcontractlib.NewMgmtContractLib(&hostConfig.ManagementContractAddress, n.logger)
 	l1Repo := l1.NewL1Repository(n.l1Client, []gethcommon.Address{hostConfig.ManagementContractAddress, hostConfig.MessageBusAddress}, n.logger)
-	return hostcontainer.NewHostContainer(hostConfig, svcLocator, nodeP2p, n.l1Client, l1Repo, enclaveClient, mgmtContractLib, n.l1Wallet, rpcServer, hostLogger, metrics.New(false, 0, n.logger))
+	return hostcontainer.NewHostContainer(hostConfig, svcLocator, nodeP2p, n.l1Client, l1Repo, []common.Enclave{enclaveClient}, mgmtContractLib, n.l1Wallet, rpcServer, clock.New(), hostLogger, metrics.New(false, 0, "", n.logger))
 }
 
 func (n *InMemNodeOperator) createEnclaveContainer() *enclavecontainer.EnclaveContainer {
@@ -0,0 +1,122 @@
+package latency
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+	"time"
+
+	testcommon "github.com/ten-protocol/go-ten/integration/common"
+)
+
+// Model gives the latency of the messages sent between the nodes of the mock networks, identified by their index. The
+// mock networks scale it down for the messages smaller than the L1 blocks (the L1 transactions and the P2P messages)
+type Model interface {
+	Latency(from int, to int) time.Duration
+}
+
+type uniform struct {
+	avg time.Duration
+}
+
+// Uniform picks latencies uniformly between a tenth and twice the average latency
+func Uniform(avg time.Duration) Model {
+	return &uniform{avg: avg}
+}
+
+func (m *uniform) Latency(int, int) time.Duration {
+	return testcommon.RndBtwTime(m.avg/10, 2*m.avg)
+}
+
+type constant struct {
+	latency time.Duration
+}
+
+// Constant gives all the messages the same latency
+func Constant(latency time.Duration) Model {
+	return &constant{latency: latency}
+}
+
+func (m *constant) Latency(int, int) time.Duration {
+	return m.latency
+}
+
+type normal struct {
+	mean   time.Duration
+	stdDev time.Duration
+}
+
+// Normal picks latencies from a normal distribution, the negative ones are cut to zero
+func Normal(mean time.Duration, stdDev time.Duration) Model {
+	return &normal{mean: mean, stdDev: stdDev}
+}
+
+func (m *normal) Latency(int, int) time.Duration {
+	latency := time.Duration(rand.NormFloat64()*float64(m.stdDev)) + m.mean //nolint:gosec
+	if latency < 0 {
+		return 0
+	}
+	return latency
+}
+
+type pareto struct {
+	min   time.Duration
+	shape float64
+}
+
+// Pareto picks latencies from a Pareto distribution of minimum min. Its long tail models the congested links, the lower the
+// shape the longer the tail (the mean is min*shape/(shape-1) for a shape above 1)
+func Pareto(min time.Duration, shape float64) Model {
+	return &pareto{min: min, shape: shape}
+}
+
+func (m *pareto) Latency(int, int) time.Duration {
+	// 1-rand.Float64() is in (0, 1], so the latency is never infinite
+	return time.Duration(float64(m.min) / math.Pow(1-rand.Float64(), 1/m.shape)) //nolint:gosec
+}
+
+// Route is a pair of regions
+type Route struct {
+	From string
+	To   string
+}
+
+type geographic struct {
+	nodeRegions []string
+	latencies   map[Route]time.Duration
+	jitter      time.Duration
+}
+
+// Geographic places the nodes in named regions and gives the messages the latency between their regions, plus a uniform
+// jitter. The nodes are assigned to the nodeRegions in turn (e.g. the nodes 0 and 3 are in the first of three regions).
+// The latency between two regions is looked up in both directions, and is zero within a region unless it is set
+func Geographic(nodeRegions []string, latencies map[Route]time.Duration, jitter time.Duration) (Model, error) {
+	if len(nodeRegions) == 0 {
+		return nil, fmt.Errorf("no region for the nodes")
+	}
+	m := &geographic{nodeRegions: nodeRegions, latencies: latencies, jitter: jitter}
+	for _, from := range nodeRegions {
+		for _, to := range nodeRegions {
+			if _, found := m.regionLatency(from, to); !found && from != to {
+				return nil, fmt.Errorf("no latency between the regions %s and %s", from, to)
+			}
+		}
+	}
+	return m, nil
+}
+
+func (m *geographic) Latency(from int, to int) time.Duration {
+	latency, _ := m.regionLatency(m.nodeRegions[from%len(m.nodeRegions)], m.nodeRegions[to%len(m.nodeRegions)])
+	if m.jitter > 0 {
+		latency += time.Duration(rand.Int63n(int64(m.jitter))) //nolint:gosec
+	}
+	return latency
+}
+
+func (m *geographic) regionLatency(from string, to string) (time.Duration, bool) {
+	if latency, found := m.latencies[Route{From: from, To: to}]; found {
+		return latency, true
+	}
+	latency, found := m.latencies[Route{From: to, To: from}]
+	return latency, found
+}
@@ -0,0 +1,32 @@
+package latency
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestGeographic(t *testing.T) {
+	latencies := map[Route]time.Duration{
+		{From: "europe", To: "asia"}: 100 * time.Millisecond,
+	}
+	model, err := Geographic([]string{"europe", "asia"}, latencies, 0)
+	require.NoError(t, err)
+
+	require.Equal(t, 100*time.Millisecond, model.Latency(0, 1))
+	require.Equal(t, 100*time.Millisecond, model.Latency(1, 0))
+	require.Equal(t, 100*time.Millisecond, model.Latency(2, 3)) // the nodes are assigned to the regions in turn
+	require.Zero(t, model.Latency(0, 2))
+
+	_, err = Geographic([]string{"europe", "asia", "north-america"}, latencies, 0)
+	require.ErrorContains(t, err, "no latency between the regions europe and north-america")
+}
+
+func TestDistributions(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		require.GreaterOrEqual(t, Pareto(10*time.Millisecond, 1.5).Latency(0, 1), 10*time.Millisecond)
+		require.GreaterOrEqual(t, Normal(10*time.Millisecond, 20*time.Millisecond).Latency(0, 1), time.Duration(0))
+		require.Equal(t, 10*time.Millisecond, Constant(10*time.Millisecond).Latency(0, 1))
+	}
+}
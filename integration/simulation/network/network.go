@@ -2,6 +2,7 @@ package network
 
 import (
 	"math/rand"
+	"time"
 
 	"github.com/ten-protocol/go-ten/go/rpc"
 
@@ -28,6 +29,15 @@ type Network interface {
 	TearDown()
 }
 
+// RestartableNetwork is a Network whose nodes can be restarted while the simulation runs, for the chaos mode
+type RestartableNetwork interface {
+	Network
+	// RestartEnclave kills the enclave of the node and starts a new one after the downtime
+	RestartEnclave(nodeIdx int, downtime time.Duration) error
+	// RestartHost kills the host of the node and starts a new one after the downtime
+	RestartHost(nodeIdx int, downtime time.Duration) error
+}
+
 type RPCHandles struct {
 	// an eth client per eth node in the network
 	EthClients []ethadapter.EthClient
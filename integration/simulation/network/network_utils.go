@@ -11,6 +11,7 @@ import (
 	"github.com/ten-protocol/go-ten/go/host/l1"
 
 	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/common/metrics"
 	"github.com/ten-protocol/go-ten/go/config"
@@ -23,6 +24,7 @@ import (
 	"github.com/ten-protocol/go-ten/integration"
 	"github.com/ten-protocol/go-ten/integration/common/testlog"
 	"github.com/ten-protocol/go-ten/integration/ethereummock"
+	"github.com/ten-protocol/go-ten/integration/simulation/latency"
 	"github.com/ten-protocol/go-ten/integration/simulation/stats"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
@@ -36,8 +38,8 @@ const (
 	DefaultL1RPCTimeout     = 15 * time.Second
 )
 
-func createMockEthNode(id int64, nrNodes int, avgBlockDuration time.Duration, avgNetworkLatency time.Duration, stats *stats.Stats) *ethereummock.Node {
-	mockEthNetwork := ethereummock.NewMockEthNetwork(avgBlockDuration, avgNetworkLatency, stats)
+func createMockEthNode(id int64, nrNodes int, avgBlockDuration time.Duration, networkLatency latency.Model, clk clock.Clock, stats *stats.Stats) *ethereummock.Node {
+	mockEthNetwork := ethereummock.NewMockEthNetwork(avgBlockDuration, networkLatency, clk, stats)
 	ethereumMockCfg := defaultMockEthNodeCfg(nrNodes, avgBlockDuration)
 	// create an in memory mock ethereum node responsible with notifying the layer 2 node about blocks
 	miner := ethereummock.NewMiner(gethcommon.BigToAddress(big.NewInt(id)), ethereumMockCfg, mockEthNetwork, stats)
@@ -60,6 +62,7 @@ func createInMemObscuroNode(
 	batchInterval time.Duration,
 	incomingP2PDisabled bool,
 	l1BlockTime time.Duration,
+	clk clock.Clock,
 ) *container.HostContainer {
 	mgtContractAddress := mgmtContractLib.GetContractAddr()
 
@@ -76,6 +79,18 @@ func createInMemObscuroNode(
 		BatchInterval:             batchInterval,
 		IsInboundP2PDisabled:      incomingP2PDisabled,
 		L1BlockTime:               l1BlockTime,
+		IngestionQueueSize:        config.DefaultHostParsedConfig().IngestionQueueSize,
+		L1BlockQueuePolicy:        config.DefaultHostParsedConfig().L1BlockQueuePolicy,
+		TxQueuePolicy:             config.DefaultHostParsedConfig().TxQueuePolicy,
+		L1TxMaxRetries:            config.DefaultHostParsedConfig().L1TxMaxRetries,
+		L1RebidBlocks:             config.DefaultHostParsedConfig().L1RebidBlocks,
+		L1GasOracleBlocks:         config.DefaultHostParsedConfig().L1GasOracleBlocks,
+		SecretResponseCooldown:    config.DefaultHostParsedConfig().SecretResponseCooldown,
+		MaxSecretResponsesPerHour: config.DefaultHostParsedConfig().MaxSecretResponsesPerHour,
+		P2PMaxMessageSize:         config.DefaultHostParsedConfig().P2PMaxMessageSize,
+		P2PMaxPeerMsgsPerSecond:   config.DefaultHostParsedConfig().P2PMaxPeerMsgsPerSecond,
+		P2PBanThreshold:           config.DefaultHostParsedConfig().P2PBanThreshold,
+		P2PBanDuration:            config.DefaultHostParsedConfig().P2PBanDuration,
 	}
 
 	enclaveConfig := &config.EnclaveConfig{
@@ -103,9 +118,9 @@ func createInMemObscuroNode(
 
 	// create an in memory obscuro node
 	hostLogger := testlog.Logger().New(log.NodeIDKey, id, log.CmpKey, log.HostCmp)
-	metricsService := metrics.New(hostConfig.MetricsEnabled, hostConfig.MetricsHTTPPort, hostLogger)
+	metricsService := metrics.New(hostConfig.MetricsEnabled, hostConfig.MetricsHTTPPort, hostConfig.MetricsPath, hostLogger)
 	l1Repo := l1.NewL1Repository(ethClient, ethereummock.MgmtContractAddresses, hostLogger)
-	currentContainer := container.NewHostContainer(hostConfig, host.NewServicesRegistry(hostLogger), mockP2P, ethClient, l1Repo, enclaveClient, mgmtContractLib, ethWallet, nil, hostLogger, metricsService)
+	currentContainer := container.NewHostContainer(hostConfig, host.NewServicesRegistry(hostLogger), mockP2P, ethClient, l1Repo, []common.Enclave{enclaveClient}, mgmtContractLib, ethWallet, nil, clk, hostLogger, metricsService)
 
 	return currentContainer
 }
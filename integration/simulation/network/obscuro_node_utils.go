@@ -33,7 +33,7 @@ func startInMemoryObscuroNodes(params *params.SimParams, genesisJSON []byte, l1C
 	// Create the in memory obscuro nodes, each connect each to a geth node
 	obscuroNodes := make([]*hostcontainer.HostContainer, params.NumberOfNodes)
 	obscuroHosts := make([]host.Host, params.NumberOfNodes)
-	mockP2PNetw := p2p.NewMockP2PNetwork(params.AvgBlockDuration, params.AvgNetworkLatency, params.NodeWithInboundP2PDisabled)
+	mockP2PNetw := p2p.NewMockP2PNetwork(params.AvgBlockDuration, params.NetworkLatency(), params.SimClock(), params.NodeWithInboundP2PDisabled)
 
 	for i := 0; i < params.NumberOfNodes; i++ {
 		isGenesis := i == 0
@@ -53,6 +53,7 @@ func startInMemoryObscuroNodes(params *params.SimParams, genesisJSON []byte, l1C
 			params.AvgBlockDuration/3,
 			true,
 			params.AvgBlockDuration,
+			params.SimClock(),
 		)
 		obscuroHosts[i] = obscuroNodes[i].Host()
 	}
@@ -35,6 +35,8 @@ type networkOfSocketNodes struct {
 	gethClients    []ethadapter.EthClient
 	wallets        *params.SimWallets
 	obscuroClients []*obsclient.ObsClient
+
+	nodes []*noderunner.InMemNode
 }
 
 func NewNetworkOfSocketNodes(wallets *params.SimWallets) Network {
@@ -65,7 +67,7 @@ func (n *networkOfSocketNodes) Create(simParams *params.SimParams, _ *stats.Stat
 	seqHostAddress := crypto.PubkeyToAddress(seqPrivateKey.PublicKey)
 
 	// create the nodes
-	nodes := make([]node.Node, simParams.NumberOfNodes)
+	n.nodes = make([]*noderunner.InMemNode, simParams.NumberOfNodes)
 	var err error
 	for i := 0; i < simParams.NumberOfNodes; i++ {
 		privateKey := seqPrivKey
@@ -89,7 +91,7 @@ func (n *networkOfSocketNodes) Create(simParams *params.SimParams, _ *stats.Stat
 		}
 
 		// create the nodes
-		nodes[i] = noderunner.NewInMemNode(
+		n.nodes[i] = noderunner.NewInMemNode(
 			node.NewNodeConfig(
 				node.WithGenesis(i == 0),
 				node.WithHostID(hostAddress.String()),
@@ -114,7 +116,7 @@ func (n *networkOfSocketNodes) Create(simParams *params.SimParams, _ *stats.Stat
 		)
 
 		// start the nodes
-		err = nodes[i].Start()
+		err = n.nodes[i].Start()
 		if err != nil {
 			errCheck := checkProcessPort(err.Error())
 			if errCheck != nil {
@@ -146,6 +148,14 @@ func (n *networkOfSocketNodes) TearDown() {
 	CheckHostRPCServersStopped(n.hostWebsocketURLs)
 }
 
+func (n *networkOfSocketNodes) RestartEnclave(nodeIdx int, downtime time.Duration) error {
+	return n.nodes[nodeIdx].RestartEnclave(downtime)
+}
+
+func (n *networkOfSocketNodes) RestartHost(nodeIdx int, downtime time.Duration) error {
+	return n.nodes[nodeIdx].RestartHost(downtime)
+}
+
 func (n *networkOfSocketNodes) createConnections(simParams *params.SimParams) error {
 	// create the clients in the structs
 	n.l2Clients = make([]rpc.Client, simParams.NumberOfNodes)
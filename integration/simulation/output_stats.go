@@ -116,6 +116,7 @@ func (o *OutputStats) incrementStats(block *types.Block, _ ethadapter.EthClient)
 }
 
 func (o *OutputStats) String() string {
+	snapshot := o.simulation.Stats.Snapshot()
 	return fmt.Sprintf("\n"+
 		"nrMiners: %d\n"+
 		"l1Height: %d\n"+
@@ -135,22 +136,22 @@ func (o *OutputStats) String() string {
 		"nrTransferTransactions: %d\n"+
 		"nrBlockParsedERC20Deposits: %d\n"+
 		"gasBridgeCount: %d\n",
-		o.simulation.Stats.NrMiners,
+		snapshot.NrMiners,
 		o.l1Height,
 		o.l2Height,
-		o.simulation.Stats.TotalL1Blocks,
-		o.simulation.Stats.NoL2Blocks,
+		snapshot.TotalL1Blocks,
+		snapshot.NoL2Blocks,
 		o.l2RollupCountInHeaders,
 		o.l2RollupCountInL1Blocks,
 		o.l2RollupTxCountInL1Blocks,
-		o.simulation.Stats.MaxRollupsPerBlock,
-		o.simulation.Stats.NrEmptyBlocks,
-		o.simulation.Stats.NoL1Reorgs,
-		o.simulation.Stats.NoL2Recalcs,
-		o.simulation.Stats.TotalDepositedAmount,
-		o.simulation.Stats.TotalWithdrawalRequestedAmount,
-		o.simulation.Stats.RollupWithMoreRecentProofCount,
-		o.simulation.Stats.NrTransferTransactions,
+		snapshot.MaxRollupsPerBlock,
+		snapshot.NrEmptyBlocks,
+		snapshot.NoL1Reorgs,
+		snapshot.NoL2Recalcs,
+		snapshot.TotalDepositedAmount,
+		snapshot.TotalWithdrawalRequestedAmount,
+		snapshot.RollupWithMoreRecentProofCount,
+		snapshot.NrTransferTransactions,
 		o.canonicalERC20DepositCount,
 		len(o.simulation.TxInjector.TxTracker.GasBridgeTransactions),
 	)
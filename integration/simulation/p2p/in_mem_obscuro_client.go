@@ -219,6 +219,10 @@ func (c *inMemObscuroClient) getBatchByNumber(result interface{}, args []interfa
 	if err != nil {
 		return fmt.Errorf("`%s` call failed. Cause: %w", rpc.GetBatchByNumber, err)
 	}
+	if headerMap == nil {
+		*result.(**common.BatchHeader) = nil
+		return nil
+	}
 
 	headerJSON, err := json.Marshal(headerMap)
 	if err != nil {
@@ -244,6 +248,10 @@ func (c *inMemObscuroClient) getBatchByHash(result interface{}, args []interface
 	if err != nil {
 		return fmt.Errorf("`%s` call failed. Cause: %w", rpc.GetBatchByHash, err)
 	}
+	if headerMap == nil {
+		*result.(**common.BatchHeader) = nil
+		return nil
+	}
 
 	headerJSON, err := json.Marshal(headerMap)
 	if err != nil {
@@ -9,11 +9,12 @@ import (
 	"github.com/ten-protocol/go-ten/go/common/subscription"
 
 	"github.com/ten-protocol/go-ten/go/common/async"
+	"github.com/ten-protocol/go-ten/go/common/clock"
 
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/host"
 
-	testcommon "github.com/ten-protocol/go-ten/integration/common"
+	"github.com/ten-protocol/go-ten/integration/simulation/latency"
 )
 
 const _sequencerID = "0"
@@ -21,7 +22,8 @@ const _sequencerID = "0"
 type MockP2PNetwork struct {
 	nodes map[string]*MockP2P
 
-	avgLatency                  time.Duration
+	latency                     latency.Model
+	clock                       clock.Clock
 	avgBlockDuration            time.Duration
 	nodeWithIncomingP2PDisabled int
 }
@@ -30,11 +32,12 @@ type MockP2PNetworkIntf interface {
 	NewNode(id int) host.P2PHostService
 }
 
-func NewMockP2PNetwork(avgBlockDuration time.Duration, avgLatency time.Duration, nodeWithIncomingP2PDisabled int) MockP2PNetworkIntf {
+func NewMockP2PNetwork(avgBlockDuration time.Duration, latency latency.Model, clk clock.Clock, nodeWithIncomingP2PDisabled int) MockP2PNetworkIntf {
 	return &MockP2PNetwork{
 		nodes:                       make(map[string]*MockP2P),
 		avgBlockDuration:            avgBlockDuration,
-		avgLatency:                  avgLatency,
+		latency:                     latency,
+		clock:                       clk,
 		nodeWithIncomingP2PDisabled: nodeWithIncomingP2PDisabled,
 	}
 }
@@ -47,27 +50,27 @@ func (m *MockP2PNetwork) NewNode(id int) host.P2PHostService {
 	return node
 }
 
-func (m *MockP2PNetwork) RequestBatchesFromSequencer(id string, fromSeqNo *big.Int) {
+func (m *MockP2PNetwork) RequestBatches(id string, fromSeqNo *big.Int, toSeqNo *big.Int) {
 	seqNode := m.nodes[_sequencerID]
-	async.Schedule(m.delay()/2, func() { seqNode.ReceiveBatchRequest(id, fromSeqNo) })
+	async.ScheduleWithClock(m.clock, m.delay(id, _sequencerID)/2, func() { seqNode.ReceiveBatchRequest(id, fromSeqNo, toSeqNo) })
 }
 
-func (m *MockP2PNetwork) SendTransactionToSequencer(tx common.EncryptedTx) {
+func (m *MockP2PNetwork) SendTransactionToSequencer(fromNodeID string, tx common.EncryptedTx) {
 	seqNode := m.nodes[_sequencerID]
-	async.Schedule(m.delay()/2, func() { seqNode.ReceiveTransaction(tx) })
+	async.ScheduleWithClock(m.clock, m.delay(fromNodeID, _sequencerID)/2, func() { seqNode.ReceiveTransaction(tx) })
 }
 
 func (m *MockP2PNetwork) BroadcastBatch(fromNodeID string, batches []*common.ExtBatch) {
 	for _, node := range m.nodes {
 		if node.id != fromNodeID {
 			tempNode := node
-			async.Schedule(m.delay()/2, func() { tempNode.ReceiveBatches(batches, true) })
+			async.ScheduleWithClock(m.clock, m.delay(fromNodeID, node.id)/2, func() { tempNode.ReceiveBatches(batches, true) })
 		}
 	}
 }
 
 func (m *MockP2PNetwork) RespondToBatchRequest(requesterID string, batches []*common.ExtBatch) {
-	async.Schedule(m.delay()/2, func() {
+	async.ScheduleWithClock(m.clock, m.delay(_sequencerID, requesterID)/2, func() {
 		requester, ok := m.nodes[requesterID]
 		if !ok {
 			panic("requester not found in mock p2p service")
@@ -76,9 +79,11 @@ func (m *MockP2PNetwork) RespondToBatchRequest(requesterID string, batches []*co
 	})
 }
 
-// delay returns an expected delay on the l2
-func (m *MockP2PNetwork) delay() time.Duration {
-	return testcommon.RndBtwTime(m.avgLatency/10, 2*m.avgLatency)
+// delay returns an expected delay on the l2, between the nodes of the given IDs (their index)
+func (m *MockP2PNetwork) delay(fromNodeID string, toNodeID string) time.Duration {
+	from, _ := strconv.Atoi(fromNodeID)
+	to, _ := strconv.Atoi(toNodeID)
+	return m.latency.Latency(from, to)
 }
 
 // MockP2P - models the p2p service of a host, but instead of sending messages over tcp it uses the `MockP2PNetwork` to distribute messages
@@ -131,7 +136,7 @@ func (n *MockP2P) SendTxToSequencer(tx common.EncryptedTx) error {
 	if atomic.LoadInt32(n.listenerInterrupt) == 1 {
 		return nil
 	}
-	n.network.SendTransactionToSequencer(tx)
+	n.network.SendTransactionToSequencer(n.id, tx)
 	return nil
 }
 
@@ -171,7 +176,7 @@ func (n *MockP2P) SubscribeForBatchRequests(handler host.P2PBatchRequestHandler)
 	return n.batchReqHandlers.Subscribe(handler)
 }
 
-func (n *MockP2P) RequestBatchesFromSequencer(fromSeqNo *big.Int) error {
+func (n *MockP2P) RequestBatches(fromSeqNo *big.Int, toSeqNo *big.Int) error {
 	if n.isIncomingP2PDisabled {
 		return nil
 	}
@@ -179,7 +184,7 @@ func (n *MockP2P) RequestBatchesFromSequencer(fromSeqNo *big.Int) error {
 	if atomic.LoadInt32(n.listenerInterrupt) == 1 {
 		return nil
 	}
-	n.network.RequestBatchesFromSequencer(n.id, fromSeqNo)
+	n.network.RequestBatches(n.id, fromSeqNo, toSeqNo)
 	return nil
 }
 
@@ -214,13 +219,13 @@ func (n *MockP2P) ReceiveBatches(batches []*common.ExtBatch, isLive bool) {
 }
 
 // ReceiveBatchRequest is a mock method that simulates receiving a batch request from a peer and then forwarding to all subscribers
-func (n *MockP2P) ReceiveBatchRequest(requestID string, fromSeqNo *big.Int) {
+func (n *MockP2P) ReceiveBatchRequest(requestID string, fromSeqNo *big.Int, toSeqNo *big.Int) {
 	if n.isIncomingP2PDisabled {
 		return
 	}
 
 	for _, sub := range n.batchReqHandlers.Subscribers() {
-		sub.HandleBatchRequest(requestID, fromSeqNo)
+		sub.HandleBatchRequest(requestID, fromSeqNo, toSeqNo)
 	}
 }
 
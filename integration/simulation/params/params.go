@@ -4,9 +4,12 @@ import (
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
+	"golang.org/x/exp/slices"
 
+	"github.com/ten-protocol/go-ten/go/common/clock"
 	"github.com/ten-protocol/go-ten/go/ethadapter/erc20contractlib"
 	"github.com/ten-protocol/go-ten/go/ethadapter/mgmtcontractlib"
+	"github.com/ten-protocol/go-ten/integration/simulation/latency"
 )
 
 // SimParams are the parameters for setting up the simulation.
@@ -16,6 +19,8 @@ type SimParams struct {
 	// A critical parameter of the simulation. The value should be as low as possible, as long as the test is still meaningful
 	AvgBlockDuration  time.Duration
 	AvgNetworkLatency time.Duration // artificial latency injected between sending and receiving messages on the mock network
+	LatencyModel      latency.Model // the latency of the messages between the nodes of the mock networks, Uniform(AvgNetworkLatency) if nil
+	Clock             clock.Clock   // the clock of the in-memory hosts and of the mock networks, the system clock if nil
 
 	SimulationTime time.Duration // how long the simulations should run for
 
@@ -39,6 +44,72 @@ type SimParams struct {
 	StoppingDelay              time.Duration // How long to wait between injection and verification
 	NodeWithInboundP2PDisabled int
 	WithPrefunding             bool
+
+	// The chaos mode restarts random validators while the transactions are injected, to check that they recover on their own
+	ChaosEnclaveRestartInterval time.Duration // The average time between two restarts of a validator enclave, 0 to disable them.
+	ChaosHostRestartInterval    time.Duration // The average time between two restarts of a validator host, 0 to disable them.
+	ChaosDowntime               time.Duration // How long a restarted enclave or host stays down.
+
+	PropagationHeatmapWidth int // The width of the heatmap of the node head divergence printed at the end of the run, 0 to skip it.
+
+	Workloads  []Workload // The kinds of transactions the transaction injector issues, all of them if empty.
+	TxsToIssue int        // The number of transactions of each kind to issue, 0 to issue them until the end of the run.
+	Checks     []Check    // The checks of the results of the run, all of them if empty.
+}
+
+// Workload is a kind of transactions issued by the transaction injector
+type Workload string
+
+const (
+	DepositsWorkload       Workload = "deposits"       // L1 deposits to the bridge
+	WithdrawalsWorkload    Workload = "withdrawals"    // L2 withdrawals to the L1
+	GasBridgeWorkload      Workload = "gasBridge"      // gas bridged from the L1 to the L2, not supported by the in-memory networks
+	TransfersWorkload      Workload = "transfers"      // L2 ERC20 transfers
+	ValueTransfersWorkload Workload = "valueTransfers" // L2 native value transfers
+	InvalidTxsWorkload     Workload = "invalidTxs"     // L2 transactions that must be rejected
+)
+
+// Workloads are all the kinds of transactions issued by the transaction injector
+var Workloads = []Workload{DepositsWorkload, WithdrawalsWorkload, GasBridgeWorkload, TransfersWorkload, ValueTransfersWorkload, InvalidTxsWorkload}
+
+// Check is a check of the results of a simulation run
+type Check string
+
+const (
+	TransactionsInjectedCheck Check = "transactionsInjected" // enough transactions of each kind were injected
+	ChainsCheck               Check = "chains"               // the L1 and L2 chains of the nodes are valid and agree
+	LogsCheck                 Check = "logs"                 // the subscribers received the relevant logs only
+	ScanCheck                 Check = "scan"                 // the scan API reports the transactions
+	RecoveryCheck             Check = "recovery"             // the nodes restarted by the chaos mode recovered
+)
+
+// Checks are all the checks of the results of a simulation run
+var Checks = []Check{TransactionsInjectedCheck, ChainsCheck, LogsCheck, ScanCheck, RecoveryCheck}
+
+// IssuesWorkload returns whether the transaction injector issues the kind of transactions
+func (p *SimParams) IssuesWorkload(workload Workload) bool {
+	return len(p.Workloads) == 0 || slices.Contains(p.Workloads, workload)
+}
+
+// RunsCheck returns whether the results of the run are checked with the check
+func (p *SimParams) RunsCheck(check Check) bool {
+	return len(p.Checks) == 0 || slices.Contains(p.Checks, check)
+}
+
+// NetworkLatency returns the latency model of the mock networks of the simulation
+func (p *SimParams) NetworkLatency() latency.Model {
+	if p.LatencyModel != nil {
+		return p.LatencyModel
+	}
+	return latency.Uniform(p.AvgNetworkLatency)
+}
+
+// SimClock returns the clock of the in-memory hosts and of the mock networks
+func (p *SimParams) SimClock() clock.Clock {
+	if p.Clock != nil {
+		return p.Clock
+	}
+	return clock.New()
 }
 
 type L1SetupData struct {
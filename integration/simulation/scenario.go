@@ -0,0 +1,252 @@
+package simulation
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/ten-protocol/go-ten/integration"
+	"github.com/ten-protocol/go-ten/integration/ethereummock"
+	"github.com/ten-protocol/go-ten/integration/simulation/latency"
+	"github.com/ten-protocol/go-ten/integration/simulation/network"
+	"github.com/ten-protocol/go-ten/integration/simulation/params"
+	"golang.org/x/exp/slices"
+	"gopkg.in/yaml.v3"
+)
+
+// The networks a scenario can run on
+const (
+	InMemoryNetwork     = "in-memory"      // in-memory nodes on a mock L1
+	GethInMemoryNetwork = "geth-in-memory" // in-memory nodes on a geth L1
+	FullNetwork         = "full"           // nodes with their full RPC layer on a geth L1
+)
+
+// The latency models of the mock networks a scenario can use
+const (
+	UniformLatency    = "uniform"
+	ConstantLatency   = "constant"
+	NormalLatency     = "normal"
+	ParetoLatency     = "pareto"
+	GeographicLatency = "geographic"
+)
+
+// Scenario is a simulation configured from a YAML file, so that a new scenario does not require writing Go. The fields
+// left out take the values of the simulation tests.
+type Scenario struct {
+	Name           string        `yaml:"name"`
+	Network        string        `yaml:"network"` // in-memory (the default), geth-in-memory or full
+	Nodes          int           `yaml:"nodes"`
+	BlockDuration  time.Duration `yaml:"blockDuration"` // the average time between two L1 blocks
+	Duration       time.Duration `yaml:"duration"`      // how long the transactions are injected for
+	ReceiptTimeout time.Duration `yaml:"receiptTimeout"`
+	StoppingDelay  time.Duration `yaml:"stoppingDelay"` // how long to wait between the injection and the checks
+	StartPort      int           `yaml:"startPort"`     // the port of the network of the simulation tests if 0
+
+	Workload   ScenarioWorkload   `yaml:"workload"`
+	Faults     ScenarioFaults     `yaml:"faults"`
+	Assertions ScenarioAssertions `yaml:"assertions"`
+}
+
+// ScenarioWorkload is the transactions injected into the network
+type ScenarioWorkload struct {
+	Wallets    int               `yaml:"wallets"`
+	Types      []params.Workload `yaml:"types"`      // all the kinds of transactions if empty
+	TxsPerType int               `yaml:"txsPerType"` // 0 to inject them until the end of the run
+}
+
+// ScenarioFaults is the faults injected into the network
+type ScenarioFaults struct {
+	Latency                ScenarioLatency `yaml:"latency"`
+	InboundP2PDisabledNode int             `yaml:"inboundP2PDisabledNode"` // the validator that only dials out, none if 0
+	EnclaveRestartInterval time.Duration   `yaml:"enclaveRestartInterval"` // the average time between two restarts of a validator enclave
+	HostRestartInterval    time.Duration   `yaml:"hostRestartInterval"`    // the average time between two restarts of a validator host
+	Downtime               time.Duration   `yaml:"downtime"`               // how long a restarted enclave or host stays down
+}
+
+// ScenarioLatency is the latency of the messages between the nodes of the mock networks
+type ScenarioLatency struct {
+	Model   string               `yaml:"model"`   // uniform (the default), constant, normal, pareto or geographic
+	Average time.Duration        `yaml:"average"` // the average latency of the uniform, constant and normal models, a 15th of the block duration if 0
+	StdDev  time.Duration        `yaml:"stdDev"`  // the standard deviation of the normal model
+	Min     time.Duration        `yaml:"min"`     // the minimum latency of the pareto model
+	Shape   float64              `yaml:"shape"`   // the shape of the pareto model
+	Regions []string             `yaml:"regions"` // the regions of the nodes of the geographic model, assigned in turn
+	Routes  []ScenarioRouteDelay `yaml:"routes"`  // the latencies between the regions of the geographic model
+	Jitter  time.Duration        `yaml:"jitter"`  // the jitter of the geographic model
+}
+
+// ScenarioRouteDelay is the latency between two regions
+type ScenarioRouteDelay struct {
+	From    string        `yaml:"from"`
+	To      string        `yaml:"to"`
+	Latency time.Duration `yaml:"latency"`
+}
+
+// ScenarioAssertions is the checks of the results of the run
+type ScenarioAssertions struct {
+	L1Efficiency float64        `yaml:"l1Efficiency"` // the minimum share of the L1 blocks on the canonical chain
+	Checks       []params.Check `yaml:"checks"`       // all the checks if empty
+}
+
+// LoadScenario reads and validates the scenario of a YAML file. The unknown fields are rejected, so that a typo does not
+// silently run a different scenario.
+func LoadScenario(path string) (*Scenario, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("could not read the scenario - %w", err)
+	}
+	decoder := yaml.NewDecoder(bytes.NewReader(content))
+	decoder.KnownFields(true)
+	scenario := &Scenario{}
+	if err = decoder.Decode(scenario); err != nil {
+		return nil, fmt.Errorf("could not parse the scenario %s - %w", path, err)
+	}
+	scenario.setDefaults()
+	if err = scenario.validate(); err != nil {
+		return nil, fmt.Errorf("invalid scenario %s - %w", path, err)
+	}
+	return scenario, nil
+}
+
+func (s *Scenario) setDefaults() {
+	if s.Network == "" {
+		s.Network = InMemoryNetwork
+	}
+	if s.Nodes == 0 {
+		s.Nodes = 5
+	}
+	if s.BlockDuration == 0 {
+		s.BlockDuration = time.Second
+	}
+	if s.ReceiptTimeout == 0 {
+		s.ReceiptTimeout = 30 * time.Second
+	}
+	if s.StoppingDelay == 0 {
+		s.StoppingDelay = 10 * time.Second
+	}
+	if s.StartPort == 0 {
+		s.StartPort = map[string]int{
+			InMemoryNetwork:     integration.StartPortSimulationInMem,
+			GethInMemoryNetwork: integration.StartPortSimulationGethInMem,
+			FullNetwork:         integration.StartPortSimulationFullNetwork,
+		}[s.Network]
+	}
+	if s.Workload.Wallets == 0 {
+		s.Workload.Wallets = 5
+	}
+	if s.Faults.Latency.Model == "" {
+		s.Faults.Latency.Model = UniformLatency
+	}
+	if s.Faults.Latency.Average == 0 {
+		s.Faults.Latency.Average = s.BlockDuration / 15
+	}
+	if s.Assertions.L1Efficiency == 0 {
+		s.Assertions.L1Efficiency = 0.2
+	}
+}
+
+func (s *Scenario) validate() error {
+	switch {
+	case s.Network != InMemoryNetwork && s.Network != GethInMemoryNetwork && s.Network != FullNetwork:
+		return fmt.Errorf("unknown network %q", s.Network)
+	case s.Nodes < 1:
+		return fmt.Errorf("the network needs at least one node")
+	case s.BlockDuration < 0 || s.Duration <= 0 || s.ReceiptTimeout < 0 || s.StoppingDelay < 0:
+		return fmt.Errorf("the duration must be set, and the other durations cannot be negative")
+	case s.Faults.InboundP2PDisabledNode < 0 || s.Faults.InboundP2PDisabledNode >= s.Nodes:
+		return fmt.Errorf("the node with the inbound P2P disabled must be one of the %d nodes", s.Nodes)
+	case (s.Faults.EnclaveRestartInterval > 0 || s.Faults.HostRestartInterval > 0) && s.Network != FullNetwork:
+		return fmt.Errorf("the nodes can only be restarted on the %s network", FullNetwork)
+	}
+	for _, workload := range s.Workload.Types {
+		if !slices.Contains(params.Workloads, workload) {
+			return fmt.Errorf("unknown workload %q, expected one of %v", workload, params.Workloads)
+		}
+	}
+	for _, check := range s.Assertions.Checks {
+		if !slices.Contains(params.Checks, check) {
+			return fmt.Errorf("unknown check %q, expected one of %v", check, params.Checks)
+		}
+	}
+	_, err := s.latencyModel()
+	return err
+}
+
+func (s *Scenario) latencyModel() (latency.Model, error) {
+	l := s.Faults.Latency
+	switch l.Model {
+	case UniformLatency:
+		return latency.Uniform(l.Average), nil
+	case ConstantLatency:
+		return latency.Constant(l.Average), nil
+	case NormalLatency:
+		return latency.Normal(l.Average, l.StdDev), nil
+	case ParetoLatency:
+		if l.Min <= 0 || l.Shape <= 0 {
+			return nil, fmt.Errorf("the pareto latency needs a min and a shape")
+		}
+		return latency.Pareto(l.Min, l.Shape), nil
+	case GeographicLatency:
+		routes := map[latency.Route]time.Duration{}
+		for _, route := range l.Routes {
+			routes[latency.Route{From: route.From, To: route.To}] = route.Latency
+		}
+		return latency.Geographic(l.Regions, routes, l.Jitter)
+	default:
+		return nil, fmt.Errorf("unknown latency model %q", l.Model)
+	}
+}
+
+// SimParams returns the parameters of the simulation of the scenario
+func (s *Scenario) SimParams() (*params.SimParams, error) {
+	latencyModel, err := s.latencyModel()
+	if err != nil {
+		return nil, err
+	}
+	simParams := &params.SimParams{
+		NumberOfNodes:               s.Nodes,
+		AvgBlockDuration:            s.BlockDuration,
+		AvgNetworkLatency:           s.Faults.Latency.Average,
+		LatencyModel:                latencyModel,
+		SimulationTime:              s.Duration,
+		L1EfficiencyThreshold:       s.Assertions.L1Efficiency,
+		Wallets:                     params.NewSimWallets(s.Workload.Wallets, s.Nodes, integration.EthereumChainID, integration.TenChainID),
+		StartPort:                   s.StartPort,
+		IsInMem:                     s.Network != FullNetwork,
+		ReceiptTimeout:              s.ReceiptTimeout,
+		StoppingDelay:               s.StoppingDelay,
+		NodeWithInboundP2PDisabled:  s.Faults.InboundP2PDisabledNode,
+		ChaosEnclaveRestartInterval: s.Faults.EnclaveRestartInterval,
+		ChaosHostRestartInterval:    s.Faults.HostRestartInterval,
+		ChaosDowntime:               s.Faults.Downtime,
+		Workloads:                   s.Workload.Types,
+		TxsToIssue:                  s.Workload.TxsPerType,
+		Checks:                      s.Assertions.Checks,
+	}
+	if s.Network == InMemoryNetwork {
+		simParams.MgmtContractLib = ethereummock.NewMgmtContractLibMock()
+		simParams.ERC20ContractLib = ethereummock.NewERC20ContractLibMock()
+		simParams.L1SetupData = &params.L1SetupData{}
+	}
+	return simParams, nil
+}
+
+// RunScenario runs the simulation of the scenario and checks its results, reporting them to t
+func RunScenario(t TestingT, scenario *Scenario) {
+	simParams, err := scenario.SimParams()
+	if err != nil {
+		t.Fatalf("Invalid scenario - %s", err)
+		return
+	}
+	var netw network.Network
+	switch scenario.Network {
+	case InMemoryNetwork:
+		netw = network.NewBasicNetworkOfInMemoryNodes()
+	case GethInMemoryNetwork:
+		netw = network.NewNetworkInMemoryGeth(simParams.Wallets)
+	case FullNetwork:
+		netw = network.NewNetworkOfSocketNodes(simParams.Wallets)
+	}
+	testSimulation(t, netw, simParams)
+}
@@ -0,0 +1,84 @@
+package simulation
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/integration"
+	"github.com/ten-protocol/go-ten/integration/simulation/params"
+)
+
+func TestExampleScenariosAreValid(t *testing.T) {
+	paths, err := filepath.Glob(filepath.Join("scenarios", "*.yaml"))
+	require.NoError(t, err)
+	require.NotEmpty(t, paths)
+	for _, path := range paths {
+		scenario, err := LoadScenario(path)
+		require.NoError(t, err, path)
+		_, err = scenario.SimParams()
+		require.NoError(t, err, path)
+	}
+}
+
+func TestScenarioSimParams(t *testing.T) {
+	scenario := loadScenario(t, `
+name: transfers
+duration: 20s
+workload:
+  types: [transfers]
+  txsPerType: 10
+faults:
+  latency: {model: constant, average: 5ms}
+assertions:
+  checks: [chains]
+`)
+	simParams, err := scenario.SimParams()
+	require.NoError(t, err)
+
+	require.Equal(t, 5, simParams.NumberOfNodes)
+	require.Equal(t, time.Second, simParams.AvgBlockDuration)
+	require.Equal(t, 20*time.Second, simParams.SimulationTime)
+	require.Equal(t, integration.StartPortSimulationInMem, simParams.StartPort)
+	require.True(t, simParams.IsInMem)
+	require.NotNil(t, simParams.MgmtContractLib)
+	require.Equal(t, 5*time.Millisecond, simParams.NetworkLatency().Latency(0, 1))
+	require.Equal(t, 10, simParams.TxsToIssue)
+	require.True(t, simParams.IssuesWorkload(params.TransfersWorkload))
+	require.False(t, simParams.IssuesWorkload(params.DepositsWorkload))
+	require.True(t, simParams.RunsCheck(params.ChainsCheck))
+	require.False(t, simParams.RunsCheck(params.LogsCheck))
+}
+
+func TestInvalidScenariosAreRejected(t *testing.T) {
+	tests := map[string]string{
+		"unknown field":       "duration: 1s\nnodez: 3",
+		"no duration":         "nodes: 3",
+		"unknown network":     "duration: 1s\nnetwork: testnet",
+		"unknown workload":    "duration: 1s\nworkload: {types: [swaps]}",
+		"unknown check":       "duration: 1s\nassertions: {checks: [balances]}",
+		"restarts in memory":  "duration: 1s\nfaults: {hostRestartInterval: 10s}",
+		"missing route":       "duration: 1s\nfaults: {latency: {model: geographic, regions: [eu, us, asia], routes: [{from: eu, to: us, latency: 1ms}]}}",
+		"node out of network": "duration: 1s\nnodes: 3\nfaults: {inboundP2PDisabledNode: 3}",
+	}
+	for name, content := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := LoadScenario(writeScenario(t, content))
+			require.Error(t, err)
+		})
+	}
+}
+
+func loadScenario(t *testing.T, content string) *Scenario {
+	scenario, err := LoadScenario(writeScenario(t, content))
+	require.NoError(t, err)
+	return scenario
+}
+
+func writeScenario(t *testing.T, content string) string {
+	path := filepath.Join(t.TempDir(), "scenario.yaml")
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
@@ -31,6 +31,8 @@ import (
 
 const (
 	allocObsWallets = 750_000_000_000_000 // The amount the faucet allocates to each Obscuro wallet.
+
+	headSamplingInterval = 100 * time.Millisecond // The interval between the samples of the node heads, for the propagation stats.
 )
 
 var initialBalance = common.ValueInWei(big.NewInt(5000))
@@ -45,6 +47,7 @@ type Simulation struct {
 	Params           *params.SimParams
 	LogChannels      map[string][]chan common.IDAndLog // Maps an owner to the channels on which they receive logs for each client.
 	Subscriptions    []ethereum.Subscription           // A slice of all created event subscriptions.
+	chaos            *chaosController                  // Restarts the validators during the injection, nil unless in chaos mode.
 	ctx              context.Context
 }
 
@@ -66,10 +69,17 @@ func (s *Simulation) Start() {
 	s.prefundL1Accounts()      // Prefund every L1 wallet
 	s.checkHealthStatus()      // Checks the nodes health status
 
+	stopTrackingHeads := make(chan struct{})
+	defer close(stopTrackingHeads)
+	go s.trackHeads(stopTrackingHeads)
+
 	timer := time.Now()
 	fmt.Printf("Starting injection\n")
 	testlog.Logger().Info("Starting injection")
 	go s.TxInjector.Start()
+	if s.chaos != nil {
+		s.chaos.Start()
+	}
 
 	// Allow for some time after tx injection was stopped so that the network can process all transactions, catch up
 	// on missed batches, etc.
@@ -80,6 +90,10 @@ func (s *Simulation) Start() {
 	testlog.Logger().Info("Stopping injection")
 
 	s.TxInjector.Stop()
+	if s.chaos != nil {
+		// the nodes recover during the stopping delay
+		s.chaos.Stop()
+	}
 
 	time.Sleep(s.Params.StoppingDelay)
 
@@ -286,6 +300,29 @@ func (s *Simulation) prefundL1Accounts() {
 	}
 }
 
+// trackHeads samples the head batch of every node until stopped, to measure how the batches propagate
+func (s *Simulation) trackHeads(stop <-chan struct{}) {
+	ticker := time.NewTicker(headSamplingInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case at := <-ticker.C:
+			heights := map[int]uint64{}
+			for nodeIdx, client := range s.RPCHandles.ObscuroClients {
+				height, err := client.BatchNumber()
+				if err != nil {
+					testlog.Logger().Debug("Could not fetch the head batch of the node", "node", nodeIdx, log.ErrKey, err)
+					continue
+				}
+				heights[nodeIdx] = height
+			}
+			s.Stats.HeadHeights(at, heights)
+		}
+	}
+}
+
 func (s *Simulation) checkHealthStatus() {
 	for _, client := range s.RPCHandles.ObscuroClients {
 		if healthy, err := client.Health(); !healthy || err != nil {
@@ -0,0 +1,42 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ten-protocol/go-ten/integration"
+
+	"github.com/ten-protocol/go-ten/integration/simulation/params"
+
+	"github.com/ten-protocol/go-ten/integration/simulation/network"
+)
+
+// This test runs the full network simulation in chaos mode: the enclaves and the hosts of random validators are killed and
+// restarted while the transactions are injected. The nodes must recover, re-attest when their enclave lost its state, and
+// converge on the same head without any intervention.
+func TestFullNetworkChaosSimulation(t *testing.T) {
+	setupSimTestLog("chaos")
+
+	numberOfNodes := 5
+	numberOfSimWallets := 5
+
+	wallets := params.NewSimWallets(numberOfSimWallets, numberOfNodes, integration.EthereumChainID, integration.TenChainID)
+
+	simParams := &params.SimParams{
+		NumberOfNodes:               numberOfNodes,
+		AvgBlockDuration:            1 * time.Second,
+		SimulationTime:              120 * time.Second,
+		L1EfficiencyThreshold:       0.2,
+		Wallets:                     wallets,
+		StartPort:                   integration.StartPortSimulationChaos,
+		ReceiptTimeout:              30 * time.Second,
+		StoppingDelay:               30 * time.Second,
+		NodeWithInboundP2PDisabled:  2,
+		ChaosEnclaveRestartInterval: 20 * time.Second,
+		ChaosHostRestartInterval:    30 * time.Second,
+		ChaosDowntime:               3 * time.Second,
+	}
+	simParams.AvgNetworkLatency = simParams.AvgBlockDuration / 15
+
+	testSimulation(t, network.NewNetworkOfSocketNodes(wallets), simParams)
+}
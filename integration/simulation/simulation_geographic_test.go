@@ -0,0 +1,60 @@
+package simulation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/ten-protocol/go-ten/integration"
+	"github.com/ten-protocol/go-ten/integration/ethereummock"
+	"github.com/ten-protocol/go-ten/integration/simulation/latency"
+	"github.com/ten-protocol/go-ten/integration/simulation/network"
+	"github.com/ten-protocol/go-ten/integration/simulation/params"
+)
+
+// This test runs the in memory simulation with the nodes spread over three regions, so the latency between two nodes
+// depends on their regions instead of being uniform. It reports how the batches propagate across the regions.
+func TestInMemoryGeographicSimulation(t *testing.T) {
+	setupSimTestLog("geographic")
+
+	numberOfNodes := 5
+	numberOfSimWallets := 10
+	wallets := params.NewSimWallets(numberOfSimWallets, numberOfNodes, integration.EthereumChainID, integration.TenChainID)
+
+	simParams := params.SimParams{
+		NumberOfNodes:              numberOfNodes,
+		AvgBlockDuration:           250 * time.Millisecond,
+		SimulationTime:             30 * time.Second,
+		L1EfficiencyThreshold:      0.2,
+		MgmtContractLib:            ethereummock.NewMgmtContractLibMock(),
+		ERC20ContractLib:           ethereummock.NewERC20ContractLibMock(),
+		Wallets:                    wallets,
+		StartPort:                  integration.StartPortSimulationGeographic,
+		IsInMem:                    true,
+		L1SetupData:                &params.L1SetupData{},
+		ReceiptTimeout:             5 * time.Second,
+		StoppingDelay:              4 * time.Second,
+		NodeWithInboundP2PDisabled: 2,
+		PropagationHeatmapWidth:    100,
+	}
+
+	simParams.AvgNetworkLatency = simParams.AvgBlockDuration / 15
+	// the latencies are scaled down like the block duration
+	latencyModel, err := latency.Geographic(
+		[]string{"europe", "north-america", "asia"},
+		map[latency.Route]time.Duration{
+			{From: "europe", To: "europe"}:               2 * time.Millisecond,
+			{From: "north-america", To: "north-america"}: 2 * time.Millisecond,
+			{From: "asia", To: "asia"}:                   2 * time.Millisecond,
+			{From: "europe", To: "north-america"}:        10 * time.Millisecond,
+			{From: "europe", To: "asia"}:                 25 * time.Millisecond,
+			{From: "north-america", To: "asia"}:          20 * time.Millisecond,
+		},
+		simParams.AvgNetworkLatency/4,
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	simParams.LatencyModel = latencyModel
+
+	testSimulation(t, network.NewBasicNetworkOfInMemoryNodes(), &simParams)
+}
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"math/rand"
 	"runtime"
-	"testing"
 	"time"
 
 	"github.com/ten-protocol/go-ten/integration/common/testlog"
@@ -20,8 +19,17 @@ import (
 	"github.com/google/uuid"
 )
 
+// TestingT is the part of testing.T the simulations report their results to, so that they can also run outside of the go
+// tests (see RunScenario)
+type TestingT interface {
+	Error(args ...any)
+	Errorf(format string, args ...any)
+	Fatalf(format string, args ...any)
+	Logf(format string, args ...any)
+}
+
 // testSimulation encapsulates the shared logic for simulating and testing various types of nodes.
-func testSimulation(t *testing.T, netw network.Network, params *params.SimParams) {
+func testSimulation(t TestingT, netw network.Network, params *params.SimParams) {
 	defer func() {
 		// wait until clean up is complete before we log the lingering goroutine count
 		testlog.Logger().Info(fmt.Sprintf("goroutine leak monitor - simulation end - %d goroutines currently running", runtime.NumGoroutine()))
@@ -50,7 +58,7 @@ func testSimulation(t *testing.T, netw network.Network, params *params.SimParams
 		&params.L1SetupData.MgmtContractAddress,
 		params.MgmtContractLib,
 		params.ERC20ContractLib,
-		0,
+		params.TxsToIssue,
 		params,
 	)
 
@@ -64,6 +72,13 @@ func testSimulation(t *testing.T, netw network.Network, params *params.SimParams
 		LogChannels:      make(map[string][]chan common.IDAndLog),
 		Subscriptions:    []ethereum.Subscription{},
 	}
+	if params.ChaosEnclaveRestartInterval > 0 || params.ChaosHostRestartInterval > 0 {
+		restartable, ok := netw.(network.RestartableNetwork)
+		if !ok {
+			t.Fatalf("The nodes of the network cannot be restarted, the chaos mode is not supported")
+		}
+		simulation.chaos = newChaosController(restartable, params)
+	}
 
 	// execute the simulation
 	fmt.Printf("Starting simulation\n")
@@ -73,6 +88,9 @@ func testSimulation(t *testing.T, netw network.Network, params *params.SimParams
 	// run tests
 	fmt.Printf("Validating simulation results\n")
 	testlog.Logger().Info("Validating simulation results")
+	if simulation.chaos != nil {
+		simulation.chaos.checkRecovery(t, networkClients)
+	}
 	checkNetworkValidity(t, &simulation)
 
 	fmt.Printf("Stopping simulation\n")
@@ -82,4 +100,16 @@ func testSimulation(t *testing.T, netw network.Network, params *params.SimParams
 	// generate and print the final stats
 	t.Logf("Simulation results:%+v", NewOutputStats(&simulation))
 	testlog.Logger().Info(fmt.Sprintf("Simulation results:%+v", NewOutputStats(&simulation)))
+
+	// the propagation of the batches to each node, so regressions are visible rather than averaged away
+	propagation := stats.PropagationReport()
+	for _, node := range propagation.Nodes {
+		t.Logf("Batch propagation: %+v", node)
+	}
+	if params.PropagationHeatmapWidth > 0 {
+		t.Logf("Batch propagation:\n%s", propagation.Heatmap(params.PropagationHeatmapWidth))
+	}
+	if err = writePropagationReport(propagation); err != nil {
+		t.Logf("Could not write the batch propagation report: %s", err)
+	}
 }
@@ -0,0 +1,165 @@
+package stats
+
+import (
+	"math/big"
+	"math/rand"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// the number of cells of the sharded counters, enough for the goroutines running at once to rarely share a cell
+var shardCount = nextPowerOfTwo(2 * runtime.GOMAXPROCS(0))
+
+func nextPowerOfTwo(n int) int {
+	p := 1
+	for p < n {
+		p <<= 1
+	}
+	return p
+}
+
+// randomShard returns the shard an update goes to
+func randomShard() int {
+	// the top-level functions of math/rand do not lock when the global source is not seeded
+	return rand.Intn(shardCount) //nolint:gosec
+}
+
+// gateShard is a shard of the snapshot gate, padded to its own cache line
+type gateShard struct {
+	sync.RWMutex
+	_ [40]byte
+}
+
+// snapshotGate lets the updates run concurrently, and the snapshots wait for the updates in flight and hold the new
+// ones. The updates read-lock the shard they update, and the snapshots lock all the shards, so the updates do not
+// contend on the reader count of a single lock.
+type snapshotGate struct {
+	shards []gateShard
+}
+
+func newSnapshotGate() *snapshotGate {
+	return &snapshotGate{shards: make([]gateShard, shardCount)}
+}
+
+// enter returns the shard of an update, which must call exit with it once done
+func (g *snapshotGate) enter() int {
+	shard := randomShard()
+	g.shards[shard].RLock()
+	return shard
+}
+
+func (g *snapshotGate) exit(shard int) {
+	g.shards[shard].RUnlock()
+}
+
+func (g *snapshotGate) lock() {
+	for i := range g.shards {
+		g.shards[i].Lock()
+	}
+}
+
+func (g *snapshotGate) unlock() {
+	for i := range g.shards {
+		g.shards[i].Unlock()
+	}
+}
+
+// a cell of a sharded counter, padded to its own cache line so that the updates of the other cells do not invalidate it
+type counterCell struct {
+	atomic.Int64
+	_ [56]byte
+}
+
+// counter is an int64 counter spread over cells, the updates go to the cell of their shard so that the goroutines of
+// the simulation do not contend on a single cache line. Its value is the sum of the cells.
+type counter struct {
+	cells []counterCell
+}
+
+func newCounter() *counter {
+	return &counter{cells: make([]counterCell, shardCount)}
+}
+
+func (c *counter) add(shard int, delta int64) {
+	c.cells[shard].Add(delta)
+}
+
+func (c *counter) value() int64 {
+	var total int64
+	for i := range c.cells {
+		total += c.cells[i].Load()
+	}
+	return total
+}
+
+// maxGauge holds the highest value it was updated with
+type maxGauge struct {
+	atomic.Uint64
+}
+
+func (g *maxGauge) update(v uint64) {
+	for {
+		current := g.Load()
+		if v <= current || g.CompareAndSwap(current, v) {
+			return
+		}
+	}
+}
+
+// keyedCounter holds a counter per key. The keys are the nodes of the simulation, so the updates are already spread
+// over a few counters.
+type keyedCounter[K comparable] struct {
+	counters sync.Map // K -> *atomic.Int64
+}
+
+func (c *keyedCounter[K]) add(key K, delta int64) {
+	value, found := c.counters.Load(key)
+	if !found {
+		value, _ = c.counters.LoadOrStore(key, new(atomic.Int64))
+	}
+	value.(*atomic.Int64).Add(delta)
+}
+
+func (c *keyedCounter[K]) values() map[K]int64 {
+	result := map[K]int64{}
+	c.counters.Range(func(key, value any) bool {
+		result[key.(K)] = value.(*atomic.Int64).Load()
+		return true
+	})
+	return result
+}
+
+// amountCell is a cell of a sharded amount, the amounts are big integers so each cell has its own lock
+type amountCell struct {
+	lock   sync.Mutex
+	amount big.Int
+	_      [24]byte
+}
+
+// amount is a sum of big integers spread over cells like the counter, the updates of a shard may still run at once
+type amount struct {
+	cells []amountCell
+}
+
+func newAmount() *amount {
+	return &amount{cells: make([]amountCell, shardCount)}
+}
+
+func (a *amount) add(shard int, v *big.Int) {
+	cell := &a.cells[shard]
+	cell.lock.Lock()
+	defer cell.lock.Unlock()
+	cell.amount.Add(&cell.amount, v)
+}
+
+func (a *amount) value() *big.Int {
+	total := new(big.Int)
+	for i := range a.cells {
+		cell := &a.cells[i]
+		cell.lock.Lock()
+		total.Add(total, &cell.amount)
+		cell.lock.Unlock()
+	}
+	return total
+}
@@ -0,0 +1,192 @@
+package stats
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+// the characters of the heatmap cells, from no divergence to the highest divergence of the run
+const heatmapShades = " .:-=+*#%@"
+
+// NodePropagation summarises how the batches propagated to a node
+type NodePropagation struct {
+	Node    int `json:"node"`
+	Batches int `json:"batches"` // the number of batches that arrived at the node
+	// the percentiles of the latencies of the batches, measured from their first arrival at any node
+	LatencyP50Ms float64 `json:"latencyP50Ms"`
+	LatencyP90Ms float64 `json:"latencyP90Ms"`
+	LatencyP99Ms float64 `json:"latencyP99Ms"`
+	LatencyMaxMs float64 `json:"latencyMaxMs"`
+	// the number of batches the head of the node was behind the highest head, at worst and on average
+	MaxDivergence  uint64  `json:"maxDivergence"`
+	MeanDivergence float64 `json:"meanDivergence"`
+}
+
+// DivergenceSample holds how many batches the head of each node was behind the highest head at a point in time
+type DivergenceSample struct {
+	Time       time.Time `json:"time"`
+	Divergence []uint64  `json:"divergence"` // by node index
+}
+
+// PropagationReport is the machine-readable report of the propagation of the batches during a simulation
+type PropagationReport struct {
+	Nodes      []NodePropagation  `json:"nodes"`
+	Divergence []DivergenceSample `json:"divergence"`
+}
+
+type headSample struct {
+	at      time.Time
+	heights map[int]uint64
+}
+
+// HeadHeights records the heights of the head batches of the nodes at a point in time, a node whose head could not be
+// fetched is omitted. The batches between two samples of a node are considered to have arrived at the time of the second
+// sample
+func (s *Stats) HeadHeights(at time.Time, heights map[int]uint64) {
+	s.propagationMu.Lock()
+	defer s.propagationMu.Unlock()
+
+	for node, height := range heights {
+		last, seen := s.lastHeights[node]
+		s.lastHeights[node] = height
+		if !seen {
+			// the arrival time of the batches before the first sample is unknown
+			continue
+		}
+		if s.batchArrivals[node] == nil {
+			s.batchArrivals[node] = map[uint64]time.Time{}
+		}
+		for h := last + 1; h <= height; h++ {
+			s.batchArrivals[node][h] = at
+		}
+	}
+	// the nodes omitted from the sample are still at their last known head
+	sampleHeights := make(map[int]uint64, len(s.lastHeights))
+	for node, height := range s.lastHeights {
+		sampleHeights[node] = height
+	}
+	s.headSamples = append(s.headSamples, headSample{at: at, heights: sampleHeights})
+}
+
+// PropagationReport returns the latencies of the batch arrivals and the divergence of the heads of the nodes
+func (s *Stats) PropagationReport() *PropagationReport {
+	s.propagationMu.RLock()
+	defer s.propagationMu.RUnlock()
+
+	// the latency of a batch at a node is measured from its first arrival at any node
+	firstArrivals := map[uint64]time.Time{}
+	for _, arrivals := range s.batchArrivals {
+		for height, at := range arrivals {
+			if first, found := firstArrivals[height]; !found || at.Before(first) {
+				firstArrivals[height] = at
+			}
+		}
+	}
+
+	report := &PropagationReport{}
+	for _, sample := range s.headSamples {
+		var highest uint64
+		for _, height := range sample.heights {
+			if height > highest {
+				highest = height
+			}
+		}
+		divergence := make([]uint64, s.nrMiners)
+		for node := range divergence {
+			// a node is only counted once it reported its head
+			if height, found := sample.heights[node]; found {
+				divergence[node] = highest - height
+			}
+		}
+		report.Divergence = append(report.Divergence, DivergenceSample{Time: sample.at, Divergence: divergence})
+	}
+
+	for node := 0; node < s.nrMiners; node++ {
+		latencies := make([]time.Duration, 0, len(s.batchArrivals[node]))
+		for height, at := range s.batchArrivals[node] {
+			latencies = append(latencies, at.Sub(firstArrivals[height]))
+		}
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+
+		nodeReport := NodePropagation{
+			Node:         node,
+			Batches:      len(latencies),
+			LatencyP50Ms: percentileMs(latencies, 50),
+			LatencyP90Ms: percentileMs(latencies, 90),
+			LatencyP99Ms: percentileMs(latencies, 99),
+			LatencyMaxMs: percentileMs(latencies, 100),
+		}
+		var totalDivergence uint64
+		for _, sample := range report.Divergence {
+			divergence := sample.Divergence[node]
+			totalDivergence += divergence
+			if divergence > nodeReport.MaxDivergence {
+				nodeReport.MaxDivergence = divergence
+			}
+		}
+		if len(report.Divergence) > 0 {
+			nodeReport.MeanDivergence = float64(totalDivergence) / float64(len(report.Divergence))
+		}
+		report.Nodes = append(report.Nodes, nodeReport)
+	}
+	return report
+}
+
+// Heatmap renders the divergence of the nodes over time, a row per node and a column per period of the run. A cell shows
+// the highest divergence of its period, the darker the further behind the node was
+func (r *PropagationReport) Heatmap(width int) string {
+	if len(r.Divergence) == 0 || width <= 0 {
+		return ""
+	}
+	if width > len(r.Divergence) {
+		width = len(r.Divergence)
+	}
+	samplesPerCell := float64(len(r.Divergence)) / float64(width)
+
+	var highest uint64
+	for _, sample := range r.Divergence {
+		for _, divergence := range sample.Divergence {
+			if divergence > highest {
+				highest = divergence
+			}
+		}
+	}
+
+	var heatmap strings.Builder
+	fmt.Fprintf(&heatmap, "head divergence per node over %s (' ' = 0, '@' = %d batches)\n",
+		r.Divergence[len(r.Divergence)-1].Time.Sub(r.Divergence[0].Time).Round(time.Second), highest)
+	for node := range r.Nodes {
+		fmt.Fprintf(&heatmap, "node %2d |", node)
+		for cell := 0; cell < width; cell++ {
+			var cellDivergence uint64
+			for i := int(float64(cell) * samplesPerCell); i < int(float64(cell+1)*samplesPerCell); i++ {
+				if divergence := r.Divergence[i].Divergence[node]; divergence > cellDivergence {
+					cellDivergence = divergence
+				}
+			}
+			heatmap.WriteByte(heatmapShade(cellDivergence, highest))
+		}
+		heatmap.WriteString("|\n")
+	}
+	return heatmap.String()
+}
+
+func heatmapShade(divergence uint64, highest uint64) byte {
+	if divergence == 0 {
+		return heatmapShades[0]
+	}
+	// any divergence is visible, the darkest shade is the highest divergence
+	shade := math.Ceil(float64(divergence) / float64(highest) * float64(len(heatmapShades)-1))
+	return heatmapShades[int(shade)]
+}
+
+func percentileMs(sorted []time.Duration, percentile int) float64 {
+	if len(sorted) == 0 {
+		return 0
+	}
+	idx := (len(sorted) - 1) * percentile / 100
+	return float64(sorted[idx]) / float64(time.Millisecond)
+}
@@ -0,0 +1,40 @@
+package stats
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestPropagationReport(t *testing.T) {
+	stats := NewStats(2)
+	start := time.Now()
+	at := func(ms int) time.Time { return start.Add(time.Duration(ms) * time.Millisecond) }
+
+	stats.HeadHeights(at(0), map[int]uint64{0: 10, 1: 10})
+	stats.HeadHeights(at(100), map[int]uint64{0: 11, 1: 10})
+	stats.HeadHeights(at(200), map[int]uint64{0: 12}) // the head of node 1 could not be fetched
+	stats.HeadHeights(at(300), map[int]uint64{0: 12, 1: 12})
+
+	report := stats.PropagationReport()
+	require.Len(t, report.Divergence, 4)
+	require.Equal(t, []uint64{0, 1}, report.Divergence[1].Divergence)
+	require.Equal(t, []uint64{0, 2}, report.Divergence[2].Divergence)
+
+	require.Equal(t, 2, report.Nodes[0].Batches)
+	require.Zero(t, report.Nodes[0].LatencyMaxMs)
+	require.Zero(t, report.Nodes[0].MaxDivergence)
+
+	// batches 11 and 12 arrived at node 1 after 200ms and 100ms
+	require.Equal(t, 2, report.Nodes[1].Batches)
+	require.Equal(t, float64(100), report.Nodes[1].LatencyP50Ms)
+	require.Equal(t, float64(200), report.Nodes[1].LatencyMaxMs)
+	require.Equal(t, uint64(2), report.Nodes[1].MaxDivergence)
+	require.Equal(t, 0.75, report.Nodes[1].MeanDivergence)
+
+	heatmap := strings.Split(report.Heatmap(4), "\n")
+	require.Equal(t, "node  0 |    |", heatmap[1])
+	require.Equal(t, "node  1 | +@ |", heatmap[2])
+}
@@ -3,15 +3,43 @@ package stats
 import (
 	"math/big"
 	"sync"
+	"sync/atomic"
+	"time"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
-
-	"github.com/ten-protocol/go-ten/go/common"
 )
 
-// Stats - collects information during the simulation. It can be checked programmatically.
+// Stats - collects information during the simulation. The counters are updated concurrently by the nodes and the
+// injectors without contending on a single lock, and are read through consistent snapshots (see Snapshot).
 type Stats struct {
+	nrMiners int
+
+	gate *snapshotGate
+
+	totalL1Blocks      atomic.Uint64
+	maxRollupsPerBlock maxGauge
+	nrEmptyBlocks      *counter
+
+	noL1Reorgs  keyedCounter[gethcommon.Address]
+	noL2Recalcs keyedCounter[gethcommon.Address]
+	noL2Blocks  keyedCounter[int]
+
+	totalDepositedAmount           *amount
+	totalWithdrawalRequestedAmount *amount
+	rollupWithMoreRecentProofCount *counter
+	nrTransferTransactions         *counter
+
+	// the propagation of the batches to the nodes (see PropagationReport)
+	propagationMu sync.RWMutex
+	lastHeights   map[int]uint64
+	batchArrivals map[int]map[uint64]time.Time
+	headSamples   []headSample
+}
+
+// Snapshot - the information collected up to a point in time. It is a copy, so it can be checked while the simulation
+// goes on.
+type Snapshot struct {
 	NrMiners int
 
 	TotalL1Blocks uint64
@@ -27,57 +55,95 @@ type Stats struct {
 	TotalWithdrawalRequestedAmount *big.Int
 	RollupWithMoreRecentProofCount uint64
 	NrTransferTransactions         int
-	statsMu                        *sync.RWMutex
 }
 
 func NewStats(nrMiners int) *Stats {
 	return &Stats{
-		NrMiners:                       nrMiners,
+		nrMiners:                       nrMiners,
+		gate:                           newSnapshotGate(),
+		nrEmptyBlocks:                  newCounter(),
+		totalDepositedAmount:           newAmount(),
+		totalWithdrawalRequestedAmount: newAmount(),
+		rollupWithMoreRecentProofCount: newCounter(),
+		nrTransferTransactions:         newCounter(),
+		lastHeights:                    map[int]uint64{},
+		batchArrivals:                  map[int]map[uint64]time.Time{},
+	}
+}
+
+// Snapshot returns the information collected so far. The updates in flight complete before it is taken and the new
+// ones wait for it, so it never observes part of an update.
+func (s *Stats) Snapshot() *Snapshot {
+	s.gate.lock()
+	defer s.gate.unlock()
+
+	snapshot := &Snapshot{
+		NrMiners:                       s.nrMiners,
+		TotalL1Blocks:                  s.totalL1Blocks.Load(),
+		MaxRollupsPerBlock:             uint32(s.maxRollupsPerBlock.Load()),
+		NrEmptyBlocks:                  int(s.nrEmptyBlocks.value()),
 		NoL1Reorgs:                     map[gethcommon.Address]int{},
 		NoL2Recalcs:                    map[gethcommon.Address]int{},
 		NoL2Blocks:                     map[int]uint64{},
-		TotalDepositedAmount:           big.NewInt(0),
-		TotalWithdrawalRequestedAmount: big.NewInt(0),
-		statsMu:                        &sync.RWMutex{},
+		TotalDepositedAmount:           s.totalDepositedAmount.value(),
+		TotalWithdrawalRequestedAmount: s.totalWithdrawalRequestedAmount.value(),
+		RollupWithMoreRecentProofCount: uint64(s.rollupWithMoreRecentProofCount.value()),
+		NrTransferTransactions:         int(s.nrTransferTransactions.value()),
+	}
+	for id, n := range s.noL1Reorgs.values() {
+		snapshot.NoL1Reorgs[id] = int(n)
 	}
+	for id, n := range s.noL2Recalcs.values() {
+		snapshot.NoL2Recalcs[id] = int(n)
+	}
+	for idx, n := range s.noL2Blocks.values() {
+		snapshot.NoL2Blocks[idx] = uint64(n)
+	}
+	return snapshot
 }
 
 func (s *Stats) L1Reorg(id gethcommon.Address) {
-	s.statsMu.Lock()
-	s.NoL1Reorgs[id]++
-	s.statsMu.Unlock()
+	shard := s.gate.enter()
+	defer s.gate.exit(shard)
+	s.noL1Reorgs.add(id, 1)
 }
 
 func (s *Stats) NewBlock(b *types.Block) {
-	s.statsMu.Lock()
-	// s.l1Height = nodecommon.MaxInt(s.l1Height, b.Number)
-	s.MaxRollupsPerBlock = common.MaxInt(s.MaxRollupsPerBlock, uint32(len(b.Transactions())))
+	shard := s.gate.enter()
+	defer s.gate.exit(shard)
+	s.maxRollupsPerBlock.update(uint64(len(b.Transactions())))
 	if len(b.Transactions()) == 0 {
-		s.NrEmptyBlocks++
+		s.nrEmptyBlocks.add(shard, 1)
 	}
-	s.statsMu.Unlock()
+}
+
+// SetTotalL1Blocks records the number of blocks of the L1 chain, counted when the simulation is validated
+func (s *Stats) SetTotalL1Blocks(total uint64) {
+	shard := s.gate.enter()
+	defer s.gate.exit(shard)
+	s.totalL1Blocks.Store(total)
 }
 
 func (s *Stats) NewRollup(nodeIdx int) {
-	s.statsMu.Lock()
-	s.NoL2Blocks[nodeIdx]++
-	s.statsMu.Unlock()
+	shard := s.gate.enter()
+	defer s.gate.exit(shard)
+	s.noL2Blocks.add(nodeIdx, 1)
 }
 
 func (s *Stats) Deposit(v *big.Int) {
-	s.statsMu.Lock()
-	s.TotalDepositedAmount.Add(s.TotalDepositedAmount, v)
-	s.statsMu.Unlock()
+	shard := s.gate.enter()
+	defer s.gate.exit(shard)
+	s.totalDepositedAmount.add(shard, v)
 }
 
 func (s *Stats) Transfer() {
-	s.statsMu.Lock()
-	s.NrTransferTransactions++
-	s.statsMu.Unlock()
+	shard := s.gate.enter()
+	defer s.gate.exit(shard)
+	s.nrTransferTransactions.add(shard, 1)
 }
 
 func (s *Stats) Withdrawal(v *big.Int) {
-	s.statsMu.Lock()
-	s.TotalWithdrawalRequestedAmount = s.TotalWithdrawalRequestedAmount.Add(s.TotalWithdrawalRequestedAmount, v)
-	s.statsMu.Unlock()
+	shard := s.gate.enter()
+	defer s.gate.exit(shard)
+	s.totalWithdrawalRequestedAmount.add(shard, v)
 }
@@ -0,0 +1,81 @@
+package stats
+
+import (
+	"math/big"
+	"sync"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+func TestSnapshotUnderConcurrentUpdates(t *testing.T) {
+	const writers = 8
+	const updates = 1000
+	stats := NewStats(writers)
+	node := gethcommon.HexToAddress("0x01")
+
+	var wg sync.WaitGroup
+	for w := 0; w < writers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			for i := 0; i < updates; i++ {
+				stats.Deposit(big.NewInt(2))
+				stats.Transfer()
+				stats.NewRollup(w)
+				stats.L1Reorg(node)
+				stats.NewBlock(types.NewBlockWithHeader(&types.Header{}))
+			}
+		}(w)
+	}
+
+	// the snapshots taken meanwhile only ever see the totals grow
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		var last *Snapshot
+		for i := 0; i < 100; i++ {
+			snapshot := stats.Snapshot()
+			if last != nil {
+				assert.GreaterOrEqual(t, snapshot.NrTransferTransactions, last.NrTransferTransactions)
+				assert.GreaterOrEqual(t, snapshot.TotalDepositedAmount.Cmp(last.TotalDepositedAmount), 0)
+				assert.GreaterOrEqual(t, snapshot.NoL1Reorgs[node], last.NoL1Reorgs[node])
+			}
+			last = snapshot
+		}
+	}()
+	wg.Wait()
+	<-done
+
+	snapshot := stats.Snapshot()
+	require.Equal(t, writers, snapshot.NrMiners)
+	require.Equal(t, writers*updates, snapshot.NrTransferTransactions)
+	require.Equal(t, writers*updates, snapshot.NrEmptyBlocks)
+	require.Equal(t, big.NewInt(2*writers*updates), snapshot.TotalDepositedAmount)
+	require.Equal(t, writers*updates, snapshot.NoL1Reorgs[node])
+	for w := 0; w < writers; w++ {
+		require.Equal(t, uint64(updates), snapshot.NoL2Blocks[w])
+	}
+	require.Zero(t, snapshot.MaxRollupsPerBlock)
+
+	// the snapshot is a copy
+	snapshot.TotalDepositedAmount.SetInt64(0)
+	require.Equal(t, big.NewInt(2*writers*updates), stats.Snapshot().TotalDepositedAmount)
+}
+
+func TestMaxRollupsPerBlock(t *testing.T) {
+	stats := NewStats(1)
+	tx := types.NewTx(&types.LegacyTx{})
+	stats.NewBlock(types.NewBlockWithHeader(&types.Header{}).WithBody(types.Transactions{tx, tx, tx}, nil))
+	stats.NewBlock(types.NewBlockWithHeader(&types.Header{}).WithBody(types.Transactions{tx}, nil))
+	stats.SetTotalL1Blocks(2)
+
+	snapshot := stats.Snapshot()
+	require.Equal(t, uint32(3), snapshot.MaxRollupsPerBlock)
+	require.Zero(t, snapshot.NrEmptyBlocks)
+	require.Equal(t, uint64(2), snapshot.TotalL1Blocks)
+}
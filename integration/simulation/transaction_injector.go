@@ -122,42 +122,32 @@ func NewTransactionInjector(
 // Generates and issues L1 and L2 transactions to the network
 func (ti *TransactionInjector) Start() {
 	var wg errgroup.Group
-	wg.Go(func() error {
-		ti.issueRandomDeposits()
-		return nil
-	})
-
-	wg.Go(func() error {
-		ti.issueRandomWithdrawals()
-		return nil
-	})
+	ti.issue(&wg, params.DepositsWorkload, ti.issueRandomDeposits)
+	ti.issue(&wg, params.WithdrawalsWorkload, ti.issueRandomWithdrawals)
 
 	// in mem sim does not support the contract libraries required
 	// to do complex bridge transactions
 	if !ti.params.IsInMem {
-		wg.Go(func() error {
-			ti.bridgeRandomGasTransfers()
-			return nil
-		})
+		ti.issue(&wg, params.GasBridgeWorkload, ti.bridgeRandomGasTransfers)
 	}
 
-	wg.Go(func() error {
-		ti.issueRandomTransfers()
-		return nil
-	})
+	ti.issue(&wg, params.TransfersWorkload, ti.issueRandomTransfers)
+	ti.issue(&wg, params.ValueTransfersWorkload, ti.issueRandomValueTransfers)
+	ti.issue(&wg, params.InvalidTxsWorkload, ti.issueInvalidL2Txs)
 
-	wg.Go(func() error {
-		ti.issueRandomValueTransfers()
-		return nil
-	})
+	_ = wg.Wait() // future proofing to return errors
+	ti.fullyStoppedChan <- true
+}
 
+// issue runs the issuing of a kind of transactions in the group, unless the simulation does not issue them
+func (ti *TransactionInjector) issue(wg *errgroup.Group, workload params.Workload, issueTxs func()) {
+	if !ti.params.IssuesWorkload(workload) {
+		return
+	}
 	wg.Go(func() error {
-		ti.issueInvalidL2Txs()
+		issueTxs()
 		return nil
 	})
-
-	_ = wg.Wait() // future proofing to return errors
-	ti.fullyStoppedChan <- true
 }
 
 func (ti *TransactionInjector) Stop() {
@@ -2,8 +2,11 @@ package simulation
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"math/big"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -12,6 +15,7 @@ import (
 	"github.com/ten-protocol/go-ten/integration/common/testlog"
 
 	testcommon "github.com/ten-protocol/go-ten/integration/common"
+	simstats "github.com/ten-protocol/go-ten/integration/simulation/stats"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
@@ -35,6 +39,18 @@ func setupSimTestLog(simType string) {
 	})
 }
 
+// writes the propagation report next to the log file of the simulation
+func writePropagationReport(report *simstats.PropagationReport) error {
+	if testlog.LogFile() == "" {
+		return nil
+	}
+	reportJSON, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(strings.TrimSuffix(testlog.LogFile(), ".txt")+"-propagation.json", reportJSON, 0o600)
+}
+
 func minMax(arr []uint64) (min uint64, max uint64) {
 	min = ^uint64(0)
 	for _, no := range arr {
@@ -7,7 +7,6 @@ import (
 	"sort"
 	"strings"
 	"sync"
-	"testing"
 	"time"
 
 	"github.com/ten-protocol/go-ten/contracts/generated/MessageBus"
@@ -20,6 +19,7 @@ import (
 	"github.com/ten-protocol/go-ten/go/obsclient"
 
 	"github.com/ten-protocol/go-ten/integration/simulation/network"
+	"github.com/ten-protocol/go-ten/integration/simulation/params"
 
 	"github.com/ten-protocol/go-ten/go/common/log"
 
@@ -50,28 +50,36 @@ const (
 // After a simulation has run, check as much as possible that the outputs of the simulation are expected.
 // For example, all injected transactions were processed correctly, the height of the rollup chain is a function of the total
 // time of the simulation and the average block duration, that all Obscuro nodes are roughly in sync, etc
-func checkNetworkValidity(t *testing.T, s *Simulation) {
+func checkNetworkValidity(t TestingT, s *Simulation) {
 	time.Sleep(2 * time.Second)
-	checkTransactionsInjected(t, s)
-	l1MaxHeight := checkEthereumBlockchainValidity(t, s)
-	checkObscuroBlockchainValidity(t, s, l1MaxHeight)
-	checkReceivedLogs(t, s)
-	checkObscuroscan(t, s)
+	if s.Params.RunsCheck(params.TransactionsInjectedCheck) {
+		checkTransactionsInjected(t, s)
+	}
+	if s.Params.RunsCheck(params.ChainsCheck) {
+		l1MaxHeight := checkEthereumBlockchainValidity(t, s)
+		checkObscuroBlockchainValidity(t, s, l1MaxHeight)
+	}
+	if s.Params.RunsCheck(params.LogsCheck) {
+		checkReceivedLogs(t, s)
+	}
+	if s.Params.RunsCheck(params.ScanCheck) {
+		checkObscuroscan(t, s)
+	}
 }
 
 // Ensures that L1 and L2 txs were actually issued.
-func checkTransactionsInjected(t *testing.T, s *Simulation) {
-	if len(s.TxInjector.TxTracker.L1Transactions) < txThreshold {
+func checkTransactionsInjected(t TestingT, s *Simulation) {
+	if s.Params.IssuesWorkload(params.DepositsWorkload) && len(s.TxInjector.TxTracker.L1Transactions) < txThreshold {
 		t.Errorf("Simulation only issued %d L1 transactions. At least %d expected", len(s.TxInjector.TxTracker.L1Transactions), txThreshold)
 	}
-	if len(s.TxInjector.TxTracker.TransferL2Transactions) < txThreshold {
+	if s.Params.IssuesWorkload(params.TransfersWorkload) && len(s.TxInjector.TxTracker.TransferL2Transactions) < txThreshold {
 		t.Errorf("Simulation only issued %d transfer L2 transactions. At least %d expected", len(s.TxInjector.TxTracker.TransferL2Transactions), txThreshold)
 	}
 	// todo (@stefan) - reenable when old contract deployer phased out.
 	/*if len(s.TxInjector.TxTracker.WithdrawalL2Transactions) < txThreshold {
 		t.Errorf("Simulation only issued %d withdrawal L2 transactions. At least %d expected", len(s.TxInjector.TxTracker.WithdrawalL2Transactions), txThreshold)
 	}*/
-	if len(s.TxInjector.TxTracker.NativeValueTransferL2Transactions) < txThreshold {
+	if s.Params.IssuesWorkload(params.ValueTransfersWorkload) && len(s.TxInjector.TxTracker.NativeValueTransferL2Transactions) < txThreshold {
 		t.Errorf("Simulation only issued %d transfer L2 transactions. At least %d expected", len(s.TxInjector.TxTracker.NativeValueTransferL2Transactions), txThreshold)
 	}
 }
@@ -82,7 +90,7 @@ func checkTransactionsInjected(t *testing.T, s *Simulation) {
 // - there are no duplicate txs
 // - efficiency - number of created blocks/height
 // - no reorgs
-func checkEthereumBlockchainValidity(t *testing.T, s *Simulation) uint64 {
+func checkEthereumBlockchainValidity(t TestingT, s *Simulation) uint64 {
 	// Sanity check number for a minimum height
 	minHeight := uint64(float64(s.Params.SimulationTime.Microseconds()) / (2 * float64(s.Params.AvgBlockDuration)))
 
@@ -107,7 +115,7 @@ func checkEthereumBlockchainValidity(t *testing.T, s *Simulation) uint64 {
 // - check efficiency - no of created blocks/ height
 // - check amount in the system
 // - check withdrawals/deposits
-func checkObscuroBlockchainValidity(t *testing.T, s *Simulation, maxL1Height uint64) {
+func checkObscuroBlockchainValidity(t TestingT, s *Simulation, maxL1Height uint64) {
 	// Sanity check number for a minimum height
 	minHeight := uint64(float64(s.Params.SimulationTime.Microseconds()) / (2 * float64(s.Params.AvgBlockDuration)))
 
@@ -130,7 +138,7 @@ func checkObscuroBlockchainValidity(t *testing.T, s *Simulation, maxL1Height uin
 // the cost of an empty rollup - adjust if the management contract changes. This is the rollup overhead.
 const emptyRollupGas = 110_000
 
-func checkCollectedL1Fees(_ *testing.T, node ethadapter.EthClient, s *Simulation, nodeIdx int, rollupReceipts types.Receipts) {
+func checkCollectedL1Fees(_ TestingT, node ethadapter.EthClient, s *Simulation, nodeIdx int, rollupReceipts types.Receipts) {
 	costOfRollupsWithTransactions := big.NewInt(0)
 	costOfEmptyRollups := big.NewInt(0)
 
@@ -168,7 +176,7 @@ func checkCollectedL1Fees(_ *testing.T, node ethadapter.EthClient, s *Simulation
 	//}
 }
 
-func checkBlockchainOfEthereumNode(t *testing.T, node ethadapter.EthClient, minHeight uint64, s *Simulation, nodeIdx int) uint64 {
+func checkBlockchainOfEthereumNode(t TestingT, node ethadapter.EthClient, minHeight uint64, s *Simulation, nodeIdx int) uint64 {
 	head, err := node.FetchHeadBlock()
 	if err != nil {
 		t.Errorf("Node %d: Could not find head block. Cause: %s", nodeIdx, err)
@@ -180,7 +188,7 @@ func checkBlockchainOfEthereumNode(t *testing.T, node ethadapter.EthClient, minH
 	}
 
 	deposits, rollups, _, blockCount, _, rollupReceipts := ExtractDataFromEthereumChain(ethereummock.MockGenesisBlock, head, node, s, nodeIdx)
-	s.Stats.TotalL1Blocks = uint64(blockCount)
+	s.Stats.SetTotalL1Blocks(uint64(blockCount))
 
 	checkCollectedL1Fees(t, node, s, nodeIdx, rollupReceipts)
 
@@ -206,7 +214,7 @@ func checkBlockchainOfEthereumNode(t *testing.T, node ethadapter.EthClient, minH
 	} */
 
 	// compare the number of reorgs for this node against the height
-	reorgs := s.Stats.NoL1Reorgs[node.Info().L2ID]
+	reorgs := s.Stats.Snapshot().NoL1Reorgs[node.Info().L2ID]
 	reorgEfficiency := float64(reorgs) / float64(height)
 	if reorgEfficiency > s.Params.L1EfficiencyThreshold {
 		t.Errorf("Node %d: The number of reorgs is too high: %d. ", nodeIdx, reorgs)
@@ -221,7 +229,7 @@ func checkBlockchainOfEthereumNode(t *testing.T, node ethadapter.EthClient, minH
 // this function only performs a very brief check.
 // the ultimate check that everything works fine is that each node is able to respond to queries
 // and has processed all batches correctly.
-func checkRollups(t *testing.T, s *Simulation, nodeIdx int, rollups []*common.ExtRollup) {
+func checkRollups(t TestingT, s *Simulation, nodeIdx int, rollups []*common.ExtRollup) {
 	if len(rollups) < 2 {
 		t.Errorf("Node %d: Found less than two submitted rollups! Successful simulation should always produce more than 2", nodeIdx)
 	}
@@ -297,7 +305,7 @@ func ExtractDataFromEthereumChain(
 	return deposits, rollups, totalDeposited, len(blockchain), successfulDeposits, rollupReceipts
 }
 
-func verifyGasBridgeTransactions(t *testing.T, s *Simulation, nodeIdx int) {
+func verifyGasBridgeTransactions(t TestingT, s *Simulation, nodeIdx int) {
 	time.Sleep(3 * time.Second)
 	mbusABI, _ := abi.JSON(strings.NewReader(MessageBus.MessageBusMetaData.ABI))
 	gasBridgeRecords := s.TxInjector.TxTracker.GasBridgeTransactions
@@ -325,7 +333,7 @@ func verifyGasBridgeTransactions(t *testing.T, s *Simulation, nodeIdx int) {
 	}
 }
 
-func checkBlockchainOfObscuroNode(t *testing.T, rpcHandles *network.RPCHandles, minObscuroHeight uint64, maxEthereumHeight uint64, s *Simulation, wg *sync.WaitGroup, heights []uint64, nodeIdx int) {
+func checkBlockchainOfObscuroNode(t TestingT, rpcHandles *network.RPCHandles, minObscuroHeight uint64, maxEthereumHeight uint64, s *Simulation, wg *sync.WaitGroup, heights []uint64, nodeIdx int) {
 	defer wg.Done()
 	obscuroClient := rpcHandles.ObscuroClients[nodeIdx]
 
@@ -403,13 +411,14 @@ func checkBlockchainOfObscuroNode(t *testing.T, rpcHandles *network.RPCHandles,
 	// expected condition : some Txs (stats) did not make it to the blockchain
 	// best condition : all Txs (stats) were issue and consumed in the blockchain
 	// can't happen : sum of headers withdraws greater than issued Txs (stats)
-	if totalSuccessfullyWithdrawn.Cmp(s.Stats.TotalWithdrawalRequestedAmount) > 0 {
-		t.Errorf("Node %d: The amount withdrawn %d exceeds the actual amount requested %d", nodeIdx, totalSuccessfullyWithdrawn, s.Stats.TotalWithdrawalRequestedAmount)
+	totalWithdrawalRequested := s.Stats.Snapshot().TotalWithdrawalRequestedAmount
+	if totalSuccessfullyWithdrawn.Cmp(totalWithdrawalRequested) > 0 {
+		t.Errorf("Node %d: The amount withdrawn %d exceeds the actual amount requested %d", nodeIdx, totalSuccessfullyWithdrawn, totalWithdrawalRequested)
 	}
 
 	// As withdrawals are highly random, here we check that some at least are successful.
 	if totalSuccessfullyWithdrawn.Cmp(gethcommon.Big0) < 0 {
-		t.Errorf("Node %d: The amount withdrawn %d is far smaller than the amount requested %d", nodeIdx, totalSuccessfullyWithdrawn, s.Stats.TotalWithdrawalRequestedAmount)
+		t.Errorf("Node %d: The amount withdrawn %d is far smaller than the amount requested %d", nodeIdx, totalSuccessfullyWithdrawn, totalWithdrawalRequested)
 	}
 
 	// check that the sum of all balances matches the total amount of money that must be in the system
@@ -516,7 +525,7 @@ func getSender(tx *common.L2Tx) gethcommon.Address {
 }
 
 // Checks that there is a receipt available for each L2 transaction.
-func checkTransactionReceipts(ctx context.Context, t *testing.T, nodeIdx int, rpcHandles *network.RPCHandles, txInjector *TransactionInjector) {
+func checkTransactionReceipts(ctx context.Context, t TestingT, nodeIdx int, rpcHandles *network.RPCHandles, txInjector *TransactionInjector) {
 	l2Txs := append(txInjector.TxTracker.TransferL2Transactions, txInjector.TxTracker.WithdrawalL2Transactions...)
 
 	nrSuccessful := 0
@@ -547,7 +556,7 @@ func checkTransactionReceipts(ctx context.Context, t *testing.T, nodeIdx int, rp
 	}
 }
 
-func extractWithdrawals(t *testing.T, obscuroClient *obsclient.ObsClient, nodeIdx int) (totalSuccessfullyWithdrawn *big.Int) {
+func extractWithdrawals(t TestingT, obscuroClient *obsclient.ObsClient, nodeIdx int) (totalSuccessfullyWithdrawn *big.Int) {
 	totalSuccessfullyWithdrawn = big.NewInt(0)
 	header, err := getHeadBatchHeader(obscuroClient)
 	if err != nil {
@@ -579,7 +588,7 @@ func extractWithdrawals(t *testing.T, obscuroClient *obsclient.ObsClient, nodeId
 }
 
 // Terminates all subscriptions and validates the received events.
-func checkReceivedLogs(t *testing.T, s *Simulation) {
+func checkReceivedLogs(t TestingT, s *Simulation) {
 	logsFromSnapshots := 0
 	// rough estimation. In total there should be 2 relevant events for each successful transfer.
 	// We assume 66% will pass
@@ -613,7 +622,7 @@ func checkReceivedLogs(t *testing.T, s *Simulation) {
 }
 
 // Checks that a subscription has received the expected logs.
-func checkSubscribedLogs(t *testing.T, owner string, channel chan common.IDAndLog) int {
+func checkSubscribedLogs(t TestingT, owner string, channel chan common.IDAndLog) int {
 	var logs []*types.Log
 
 	for {
@@ -628,7 +637,7 @@ func checkSubscribedLogs(t *testing.T, owner string, channel chan common.IDAndLo
 	return len(logs)
 }
 
-func checkSnapshotLogs(t *testing.T, client *obsclient.AuthObsClient) int {
+func checkSnapshotLogs(t TestingT, client *obsclient.AuthObsClient) int {
 	// To exercise the filtering mechanism, we get a snapshot for HOC events only, ignoring POC events.
 	hocFilter := common.FilterCriteriaJSON{
 		Addresses: []gethcommon.Address{gethcommon.HexToAddress("0x" + testcommon.HOCAddr)},
@@ -644,7 +653,7 @@ func checkSnapshotLogs(t *testing.T, client *obsclient.AuthObsClient) int {
 }
 
 // Asserts that the logs meet various criteria.
-func assertLogsValid(t *testing.T, owner string, logs []*types.Log) {
+func assertLogsValid(t TestingT, owner string, logs []*types.Log) {
 	for _, receivedLog := range logs {
 		assertRelevantLogsOnly(t, owner, *receivedLog)
 
@@ -658,7 +667,7 @@ func assertLogsValid(t *testing.T, owner string, logs []*types.Log) {
 }
 
 // Asserts that the log is relevant to the recipient (either a lifecycle event or a relevant user event).
-func assertRelevantLogsOnly(t *testing.T, owner string, receivedLog types.Log) {
+func assertRelevantLogsOnly(t TestingT, owner string, receivedLog types.Log) {
 	// Since addresses are 20 bytes long, while hashes are 32, only topics with 12 leading zero bytes can (potentially)
 	// be user addresses. We filter these out. In theory, we should also check whether the topics exist in the state DB
 	// and are contract addresses, but we cannot do this as part of chain validation.
@@ -693,7 +702,7 @@ func assertRelevantLogsOnly(t *testing.T, owner string, receivedLog types.Log) {
 }
 
 // Asserts that there are no duplicate logs in the provided list.
-func assertNoDupeLogs(t *testing.T, logs []*types.Log) {
+func assertNoDupeLogs(t TestingT, logs []*types.Log) {
 	logCount := make(map[string]int)
 
 	for _, item := range logs {
@@ -720,7 +729,7 @@ func assertNoDupeLogs(t *testing.T, logs []*types.Log) {
 }
 
 // Checks that the various APIs powering Obscuroscan are working correctly.
-func checkObscuroscan(t *testing.T, s *Simulation) {
+func checkObscuroscan(t TestingT, s *Simulation) {
 	for idx, client := range s.RPCHandles.RPCClients {
 		checkTotalTransactions(t, client, idx)
 		latestTxHashes := checkLatestTxs(t, client, idx)
@@ -731,7 +740,7 @@ func checkObscuroscan(t *testing.T, s *Simulation) {
 }
 
 // Checks that the node has stored sufficient transactions.
-func checkTotalTransactions(t *testing.T, client rpc.Client, nodeIdx int) {
+func checkTotalTransactions(t TestingT, client rpc.Client, nodeIdx int) {
 	var totalTxs *big.Int
 	err := client.Call(&totalTxs, rpc.GetTotalTxs)
 	if err != nil {
@@ -743,7 +752,7 @@ func checkTotalTransactions(t *testing.T, client rpc.Client, nodeIdx int) {
 }
 
 // Checks that we can retrieve the latest transactions for the node.
-func checkLatestTxs(t *testing.T, client rpc.Client, nodeIdx int) []gethcommon.Hash {
+func checkLatestTxs(t TestingT, client rpc.Client, nodeIdx int) []gethcommon.Hash {
 	var latestTxHashes []gethcommon.Hash
 	err := client.Call(&latestTxHashes, rpc.GetLatestTxs, txThreshold)
 	if err != nil {
@@ -756,7 +765,7 @@ func checkLatestTxs(t *testing.T, client rpc.Client, nodeIdx int) []gethcommon.H
 }
 
 // Retrieves the batch using the transaction hash, and validates it.
-func checkBatchFromTxs(t *testing.T, client rpc.Client, txHash gethcommon.Hash, nodeIdx int) {
+func checkBatchFromTxs(t TestingT, client rpc.Client, txHash gethcommon.Hash, nodeIdx int) {
 	var batchByTx *common.ExtBatch
 	err := client.Call(&batchByTx, rpc.GetBatchForTx, txHash)
 	if err != nil {
@@ -1,6 +1,7 @@
 package smartcontract
 
 import (
+	"math/big"
 	"reflect"
 	"testing"
 	"time"
@@ -10,6 +11,7 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/stretchr/testify/assert"
+	"github.com/ten-protocol/go-ten/contracts/generated/ManagementContract"
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/common/constants"
 	"github.com/ten-protocol/go-ten/go/ethadapter"
@@ -107,6 +109,7 @@ func TestManagementContract(t *testing.T) {
 		"nonAttestedNodesCannotAttest":       nonAttestedNodesCannotAttest,
 		"newlyAttestedNodesCanAttest":        newlyAttestedNodesCanAttest,
 		"attestedNodeHostAddressesAreStored": attestedNodeHostAddressesAreStored,
+		"governanceChangesAreDecoded":        governanceChangesAreDecoded,
 	} {
 		t.Run(name, func(t *testing.T) {
 			bytecode, err := constants.Bytecode()
@@ -528,3 +531,29 @@ func attestedNodeHostAddressesAreStored(t *testing.T, mgmtContractLib *debugMgmt
 		t.Errorf("expected to find host addresses %s, found %s", expectedHostAddresses, hostAddresses)
 	}
 }
+
+// governanceChangesAreDecoded sets a governance parameter and checks the emitted event is decoded by the bindings the enclaves use
+func governanceChangesAreDecoded(t *testing.T, mgmtContractLib *debugMgmtContractLib, w *debugWallet, client ethadapter.EthClient) {
+	data, err := ManagementContract.PackSetGovernanceParameter(common.GovernanceBaseFee, big.NewInt(7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, receipt, err := w.AwaitedSignAndSendTransaction(client, &types.LegacyTx{To: mgmtContractLib.GetContractAddr(), Data: data})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		t.Fatalf("transaction should have succeeded, expected %d got %d", types.ReceiptStatusSuccessful, receipt.Status)
+	}
+
+	if len(receipt.Logs) != 1 || receipt.Logs[0].Topics[0] != ManagementContract.GovernanceParameterChangedEventID {
+		t.Fatalf("expected one GovernanceParameterChanged event, got %v", receipt.Logs)
+	}
+	event, err := ManagementContract.UnpackGovernanceParameterChangedEvent(*receipt.Logs[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if event.Name != common.GovernanceBaseFee || event.Value.Cmp(big.NewInt(7)) != 0 {
+		t.Errorf("unexpected governance change %s=%s", event.Name, event.Value)
+	}
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strings"
+	"text/template"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// binding is the data of the generated file of a contract
+type binding struct {
+	Package  string
+	Contract string
+	Methods  []method
+	Events   []event
+	UsesBig  bool // whether the bindings use *big.Int
+}
+
+type method struct {
+	Name      string // the name of the method in the ABI
+	GoName    string
+	Signature string
+	Inputs    []field
+	Outputs   []field
+}
+
+type event struct {
+	Name      string
+	GoName    string
+	Signature string
+	ID        string
+	// the indexed address fields, which make the event private to the user accounts they hold
+	PrivateTo []string
+}
+
+type field struct {
+	GoName string // the name of the field of the input or output struct
+	Param  string // the name of the parameter of the pack function
+	Type   string
+}
+
+// generate returns the Go source of the typed bindings of the contract, for the package generated by abigen for it
+func generate(contractABI abi.ABI, pkg string, contract string) ([]byte, error) {
+	b := &binding{Package: pkg, Contract: contract}
+
+	for _, name := range sortedKeys(contractABI.Methods) {
+		m := contractABI.Methods[name]
+		gm := method{
+			Name:      m.Name,
+			GoName:    abi.ToCamelCase(m.Name),
+			Signature: m.Sig,
+		}
+		var err error
+		if gm.Inputs, err = b.fields(m.Inputs, "arg"); err != nil {
+			return nil, fmt.Errorf("method %s - %w", m.Name, err)
+		}
+		if gm.Outputs, err = b.fields(m.Outputs, "out"); err != nil {
+			return nil, fmt.Errorf("method %s - %w", m.Name, err)
+		}
+		b.Methods = append(b.Methods, gm)
+	}
+
+	for _, name := range sortedKeys(contractABI.Events) {
+		e := contractABI.Events[name]
+		ge := event{Name: e.Name, GoName: abi.ToCamelCase(e.Name), Signature: e.Sig, ID: e.ID.Hex()}
+		for _, input := range e.Inputs {
+			if input.Indexed && input.Type.T == abi.AddressTy {
+				ge.PrivateTo = append(ge.PrivateTo, input.Name)
+			}
+		}
+		b.Events = append(b.Events, ge)
+	}
+
+	var src bytes.Buffer
+	if err := bindingTemplate.Execute(&src, b); err != nil {
+		return nil, fmt.Errorf("could not execute the template - %w", err)
+	}
+	formatted, err := format.Source(src.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("could not format the generated code - %w\n%s", err, src.String())
+	}
+	return formatted, nil
+}
+
+func (b *binding) fields(args abi.Arguments, prefix string) ([]field, error) {
+	fields := make([]field, len(args))
+	for i, arg := range args {
+		goType, err := b.goType(arg.Type)
+		if err != nil {
+			return nil, err
+		}
+		goName := abi.ToCamelCase(arg.Name)
+		if goName == "" {
+			goName = fmt.Sprintf("%s%d", abi.ToCamelCase(prefix), i)
+		}
+		param := strings.ToLower(goName[:1]) + goName[1:]
+		// the parameters cannot shadow the keywords nor the imported packages
+		if token.IsKeyword(param) || param == "abi" || param == "big" || param == "bind" || param == "types" || param == "fmt" {
+			param = fmt.Sprintf("%s%d", prefix, i)
+		}
+		fields[i] = field{GoName: goName, Param: param, Type: goType}
+	}
+	return fields, nil
+}
+
+// goType returns the Go type of an ABI type, named as abigen names it, so that the structs generated by abigen are reused
+func (b *binding) goType(t abi.Type) (string, error) {
+	switch t.T {
+	case abi.IntTy, abi.UintTy:
+		prefix := "int"
+		if t.T == abi.UintTy {
+			prefix = "uint"
+		}
+		switch t.Size {
+		case 8, 16, 32, 64:
+			return fmt.Sprintf("%s%d", prefix, t.Size), nil
+		}
+		b.UsesBig = true
+		return "*big.Int", nil
+	case abi.BoolTy:
+		return "bool", nil
+	case abi.StringTy:
+		return "string", nil
+	case abi.AddressTy:
+		return "gethcommon.Address", nil
+	case abi.BytesTy:
+		return "[]byte", nil
+	case abi.FixedBytesTy:
+		return fmt.Sprintf("[%d]byte", t.Size), nil
+	case abi.SliceTy, abi.ArrayTy:
+		elem, err := b.goType(*t.Elem)
+		if err != nil {
+			return "", err
+		}
+		if t.T == abi.SliceTy {
+			return "[]" + elem, nil
+		}
+		return fmt.Sprintf("[%d]%s", t.Size, elem), nil
+	case abi.TupleTy:
+		if t.TupleRawName == "" {
+			return "", fmt.Errorf("the tuple %s has no struct name, the ABI lacks its internal type", t.String())
+		}
+		return abi.ToCamelCase(t.TupleRawName), nil
+	default:
+		return "", fmt.Errorf("unsupported type %s", t.String())
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+var bindingTemplate = template.Must(template.New("binding").Funcs(template.FuncMap{"join": strings.Join}).Parse(`// Code generated by tools/bindgen - DO NOT EDIT.
+// This file is a generated binding and any manual changes will be lost.
+
+package {{.Package}}
+
+import (
+	"fmt"
+{{- if .UsesBig}}
+	"math/big"
+{{- end}}
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	"github.com/ethereum/go-ethereum/core/types"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// Reference imports to suppress errors if they are not otherwise used.
+var (
+	_ = bind.NewBoundContract
+	_ = types.Log{}
+	_ = gethcommon.Hash{}
+)
+
+// the ABI of the contract, parsed once
+var parsed{{.Contract}}ABI = func() *abi.ABI {
+	parsed, err := {{.Contract}}MetaData.GetAbi()
+	if err != nil {
+		panic(err)
+	}
+	return parsed
+}()
+
+// Parsed{{.Contract}}ABI returns the parsed ABI of the {{.Contract}} contract
+func Parsed{{.Contract}}ABI() *abi.ABI {
+	return parsed{{.Contract}}ABI
+}
+
+// unpackInput returns the arguments of a call to the method, checking that the call data is a call to it
+func unpackInput(methodName string, data []byte) ([]interface{}, error) {
+	if len(data) < 4 {
+		return nil, fmt.Errorf("the call data is too short to be a call to %s", methodName)
+	}
+	m, err := parsed{{.Contract}}ABI.MethodById(data[:4])
+	if err != nil {
+		return nil, err
+	}
+	if m.Name != methodName {
+		return nil, fmt.Errorf("the call data is a call to %s, not %s", m.Name, methodName)
+	}
+	return m.Inputs.Unpack(data[4:])
+}
+{{range .Methods}}
+// {{.GoName}}Method is the name of the {{.Signature}} method in the ABI
+const {{.GoName}}Method = "{{.Name}}"
+
+// Pack{{.GoName}} packs a call to {{.Signature}}
+func Pack{{.GoName}}({{range $i, $f := .Inputs}}{{if $i}}, {{end}}{{$f.Param}} {{$f.Type}}{{end}}) ([]byte, error) {
+	return parsed{{$.Contract}}ABI.Pack({{.GoName}}Method{{range .Inputs}}, {{.Param}}{{end}})
+}
+{{if .Inputs}}
+// {{.GoName}}Input is the arguments of a call to {{.Signature}}
+type {{.GoName}}Input struct {
+{{- range .Inputs}}
+	{{.GoName}} {{.Type}}
+{{- end}}
+}
+
+// Unpack{{.GoName}}Input unpacks the arguments of a call to {{.Signature}} from its call data
+func Unpack{{.GoName}}Input(data []byte) (*{{.GoName}}Input, error) {
+	values, err := unpackInput({{.GoName}}Method, data)
+	if err != nil {
+		return nil, err
+	}
+	return &{{.GoName}}Input{
+{{- range $i, $f := .Inputs}}
+		{{$f.GoName}}: *abi.ConvertType(values[{{$i}}], new({{$f.Type}})).(*{{$f.Type}}),
+{{- end}}
+	}, nil
+}
+{{end}}{{if .Outputs}}{{if eq (len .Outputs) 1}}{{$out := index .Outputs 0}}
+// Unpack{{.GoName}}Output unpacks the result of a call to {{.Signature}}
+func Unpack{{.GoName}}Output(data []byte) ({{$out.Type}}, error) {
+	values, err := parsed{{$.Contract}}ABI.Unpack({{.GoName}}Method, data)
+	if err != nil {
+		return *new({{$out.Type}}), err
+	}
+	return *abi.ConvertType(values[0], new({{$out.Type}})).(*{{$out.Type}}), nil
+}
+{{else}}
+// {{.GoName}}Output is the results of a call to {{.Signature}}
+type {{.GoName}}Output struct {
+{{- range .Outputs}}
+	{{.GoName}} {{.Type}}
+{{- end}}
+}
+
+// Unpack{{.GoName}}Output unpacks the results of a call to {{.Signature}}
+func Unpack{{.GoName}}Output(data []byte) (*{{.GoName}}Output, error) {
+	values, err := parsed{{$.Contract}}ABI.Unpack({{.GoName}}Method, data)
+	if err != nil {
+		return nil, err
+	}
+	return &{{.GoName}}Output{
+{{- range $i, $f := .Outputs}}
+		{{$f.GoName}}: *abi.ConvertType(values[{{$i}}], new({{$f.Type}})).(*{{$f.Type}}),
+{{- end}}
+	}, nil
+}
+{{end}}{{end}}{{end}}{{range .Events}}
+// {{.GoName}}EventID is the topic of the {{.Signature}} event
+var {{.GoName}}EventID = gethcommon.HexToHash("{{.ID}}")
+
+{{if .PrivateTo -}}
+// {{.GoName}}IsLifecycle is false: on TEN, the {{.GoName}} events are only visible to the user accounts in their
+// indexed {{join .PrivateTo ", "}} fields, or to everyone if none of them holds a user account.
+{{- else -}}
+// {{.GoName}}IsLifecycle is true: on TEN, the {{.GoName}} events have no indexed address field, so they are lifecycle
+// events visible to everyone.
+{{- end}}
+const {{.GoName}}IsLifecycle = {{if .PrivateTo}}false{{else}}true{{end}}
+
+// Unpack{{.GoName}}Event unpacks a {{.Signature}} log
+func Unpack{{.GoName}}Event(log types.Log) (*{{$.Contract}}{{.GoName}}, error) {
+	event := new({{$.Contract}}{{.GoName}})
+	contract := bind.NewBoundContract(gethcommon.Address{}, *parsed{{$.Contract}}ABI, nil, nil, nil)
+	if err := contract.UnpackLog(event, "{{.Name}}", log); err != nil {
+		return nil, err
+	}
+	event.Raw = log
+	return event, nil
+}
+{{end}}`))
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/stretchr/testify/require"
+)
+
+const testABI = `[
+	{"type":"function","name":"addRollup","stateMutability":"nonpayable","outputs":[],"inputs":[
+		{"name":"r","type":"tuple","internalType":"struct Structs.MetaRollup","components":[
+			{"name":"Hash","type":"bytes32","internalType":"bytes32"},
+			{"name":"LastSequenceNumber","type":"uint256","internalType":"uint256"}]},
+		{"name":"_rollupData","type":"string","internalType":"string"},
+		{"name":"","type":"uint64","internalType":"uint64"}]},
+	{"type":"function","name":"balances","stateMutability":"view","inputs":[{"name":"type","type":"address","internalType":"address"}],
+		"outputs":[{"name":"amount","type":"uint256","internalType":"uint256"},{"name":"","type":"bool","internalType":"bool"}]},
+	{"type":"event","name":"Deposit","anonymous":false,"inputs":[
+		{"name":"receiver","type":"address","indexed":true,"internalType":"address"},
+		{"name":"amount","type":"uint256","indexed":false,"internalType":"uint256"}]},
+	{"type":"event","name":"SequenceBumped","anonymous":false,"inputs":[
+		{"name":"sequence","type":"uint64","indexed":true,"internalType":"uint64"}]}
+]`
+
+func TestGenerate(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(testABI))
+	require.NoError(t, err)
+
+	src, err := generate(contractABI, "Bridge", "Bridge")
+	require.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "Bridge_ten.go", src, parser.AllErrors)
+	require.NoError(t, err)
+
+	code := string(src)
+	for _, expected := range []string{
+		`func PackAddRollup(r StructsMetaRollup, rollupData string, arg2 uint64) ([]byte, error)`,
+		"RollupData string",
+		"Arg2       uint64",
+		// a parameter named after a keyword is renamed
+		`func PackBalances(arg0 gethcommon.Address) ([]byte, error)`,
+		"func UnpackBalancesOutput(data []byte) (*BalancesOutput, error)",
+		"Amount *big.Int",
+		"Out1   bool",
+		"const DepositIsLifecycle = false",
+		"indexed receiver fields",
+		"const SequenceBumpedIsLifecycle = true",
+		"func UnpackDepositEvent(log types.Log) (*BridgeDeposit, error)",
+	} {
+		require.Contains(t, code, expected)
+	}
+}
+
+func TestGenerateRejectsUnnamedStructs(t *testing.T) {
+	contractABI, err := abi.JSON(strings.NewReader(`[{"type":"function","name":"f","inputs":[
+		{"name":"s","type":"tuple","components":[{"name":"a","type":"uint256"}]}],"outputs":[]}]`))
+	require.NoError(t, err)
+
+	_, err = generate(contractABI, "Bridge", "Bridge")
+	require.ErrorContains(t, err, "no struct name")
+}
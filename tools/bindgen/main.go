@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// Tool to generate the typed bindings of the TEN system contracts: functions packing the calls to each method and
+// unpacking their arguments, results and events into the structs generated by abigen, and the visibility of each event
+// on TEN. The bindings are written next to the abigen bindings of the contract, in the same package, so that the code
+// building the L1 transactions itself (e.g. the management contract lib) does not pack the calls by name.
+//
+// Example:
+//
+//	bindgen -abi ./exported/src/management/ManagementContract.sol/ManagementContract.json \
+//	  -pkg ManagementContract -out ./generated/ManagementContract/ManagementContract_ten.go
+
+func main() {
+	abiPath := flag.String("abi", "", "The path of the JSON ABI of the contract")
+	pkg := flag.String("pkg", "", "The package of the abigen bindings of the contract")
+	contract := flag.String("contract", "", "The name of the contract in the abigen bindings (Defaults to the package)")
+	out := flag.String("out", "", "The path the bindings are written to")
+	flag.Parse()
+
+	if *abiPath == "" || *pkg == "" || *out == "" {
+		exit(fmt.Errorf("the abi, pkg and out flags are required"))
+	}
+	if *contract == "" {
+		*contract = *pkg
+	}
+
+	content, err := os.ReadFile(*abiPath)
+	if err != nil {
+		exit(err)
+	}
+	contractABI, err := abi.JSON(strings.NewReader(string(content)))
+	if err != nil {
+		exit(fmt.Errorf("could not parse the ABI - %w", err))
+	}
+	src, err := generate(contractABI, *pkg, *contract)
+	if err != nil {
+		exit(err)
+	}
+	if err = os.WriteFile(*out, src, 0o644); err != nil { //nolint:gosec
+		exit(err)
+	}
+}
+
+func exit(err error) {
+	fmt.Fprintf(os.Stderr, "bindgen: %s\n", err)
+	os.Exit(1)
+}
@@ -0,0 +1,169 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/ten-protocol/go-ten/go/common"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+const (
+	// Flag names, defaults and usages.
+	l1RPCName    = "l1RPC"
+	l1RPCDefault = "http://127.0.0.1:8545"
+	l1RPCUsage   = "The http address of the L1 node the checkpoints are read from. Default: http://127.0.0.1:8545"
+
+	nodeRPCName    = "nodeRPC"
+	nodeRPCDefault = "http://127.0.0.1:80"
+	nodeRPCUsage   = "The http address of the TEN node the proofs are requested from. Default: http://127.0.0.1:80"
+
+	managementContractName    = "managementContract"
+	managementContractDefault = ""
+	managementContractUsage   = "The address of the management contract on the L1"
+
+	sequencerEnclaveIDName  = "sequencerEnclaveID"
+	sequencerEnclaveIDUsage = "The ID of an attested enclave of the sequencer, as logged by the enclave at startup (repeatable). Only the checkpoints signed by these enclaves are trusted"
+
+	chainIDName    = "chainId"
+	chainIDDefault = 443
+	chainIDUsage   = "The ID of the TEN chain. Default: 443"
+
+	privateKeyName    = "privateKey"
+	privateKeyDefault = ""
+	privateKeyUsage   = "The private key of the account, used to sign a new viewing key. Not needed if a viewing key is given"
+
+	accountName    = "account"
+	accountDefault = ""
+	accountUsage   = "The address of the account the viewing key is bound to"
+
+	viewingKeyName    = "viewingKey"
+	viewingKeyDefault = ""
+	viewingKeyUsage   = "The hex encoded private key of a viewing key of the account"
+
+	viewingKeySignatureName    = "viewingKeySignature"
+	viewingKeySignatureDefault = ""
+	viewingKeySignatureUsage   = "The hex encoded signature of the viewing key by the account"
+
+	maxL1BlocksName    = "maxL1Blocks"
+	maxL1BlocksDefault = 10_000
+	maxL1BlocksUsage   = "The maximum number of L1 blocks scanned back from the head to find a state root checkpoint. Default: 10000"
+
+	outName    = "out"
+	outDefault = "exit-proof.json"
+	outUsage   = "The path the exit proof is written to. Default: exit-proof.json"
+
+	mappingName  = "mapping"
+	mappingUsage = "A mapping keyed by address to prove the account's entry of, as contractAddress=slot (repeatable), e.g. the balances of an ERC20"
+)
+
+type exitProofConfig struct {
+	l1RPC               string
+	nodeRPC             string
+	managementContract  gethcommon.Address
+	sequencerEnclaveIDs []gethcommon.Address
+	chainID             int64
+	privateKey          string
+	account             gethcommon.Address
+	viewingKey          string
+	viewingKeySignature string
+	maxL1Blocks         uint64
+	out                 string
+	mappings            []common.MappingQuery
+}
+
+func parseCLIArgs() (*exitProofConfig, error) {
+	l1RPC := flag.String(l1RPCName, l1RPCDefault, l1RPCUsage)
+	nodeRPC := flag.String(nodeRPCName, nodeRPCDefault, nodeRPCUsage)
+	managementContract := flag.String(managementContractName, managementContractDefault, managementContractUsage)
+	var sequencerEnclaveIDs listFlag
+	flag.Var(&sequencerEnclaveIDs, sequencerEnclaveIDName, sequencerEnclaveIDUsage)
+	chainID := flag.Int64(chainIDName, chainIDDefault, chainIDUsage)
+	privateKey := flag.String(privateKeyName, privateKeyDefault, privateKeyUsage)
+	account := flag.String(accountName, accountDefault, accountUsage)
+	viewingKey := flag.String(viewingKeyName, viewingKeyDefault, viewingKeyUsage)
+	viewingKeySignature := flag.String(viewingKeySignatureName, viewingKeySignatureDefault, viewingKeySignatureUsage)
+	maxL1Blocks := flag.Uint64(maxL1BlocksName, maxL1BlocksDefault, maxL1BlocksUsage)
+	out := flag.String(outName, outDefault, outUsage)
+	var mappings listFlag
+	flag.Var(&mappings, mappingName, mappingUsage)
+	flag.Parse()
+
+	if !gethcommon.IsHexAddress(*managementContract) {
+		return nil, fmt.Errorf("invalid management contract address %q", *managementContract)
+	}
+	if len(sequencerEnclaveIDs) == 0 {
+		return nil, fmt.Errorf("at least one enclave ID of the sequencer is required")
+	}
+	enclaveIDs := make([]gethcommon.Address, 0, len(sequencerEnclaveIDs))
+	for _, id := range sequencerEnclaveIDs {
+		if !gethcommon.IsHexAddress(id) {
+			return nil, fmt.Errorf("invalid enclave ID %q", id)
+		}
+		enclaveIDs = append(enclaveIDs, gethcommon.HexToAddress(id))
+	}
+	if *privateKey == "" && (*viewingKey == "" || *viewingKeySignature == "" || !gethcommon.IsHexAddress(*account)) {
+		return nil, fmt.Errorf("either the private key of the account, or a viewing key with its signature and account, is required")
+	}
+	mappingQueries, err := parseMappings(mappings)
+	if err != nil {
+		return nil, err
+	}
+
+	return &exitProofConfig{
+		l1RPC:               *l1RPC,
+		nodeRPC:             *nodeRPC,
+		managementContract:  gethcommon.HexToAddress(*managementContract),
+		sequencerEnclaveIDs: enclaveIDs,
+		chainID:             *chainID,
+		privateKey:          *privateKey,
+		account:             gethcommon.HexToAddress(*account),
+		viewingKey:          *viewingKey,
+		viewingKeySignature: *viewingKeySignature,
+		maxL1Blocks:         *maxL1Blocks,
+		out:                 *out,
+		mappings:            mappingQueries,
+	}, nil
+}
+
+// parseMappings groups the contractAddress=slot pairs by contract
+func parseMappings(mappings []string) ([]common.MappingQuery, error) {
+	var queries []common.MappingQuery
+	byContract := map[gethcommon.Address]int{}
+	for _, mapping := range mappings {
+		parts := strings.SplitN(mapping, "=", 2)
+		if len(parts) != 2 || !gethcommon.IsHexAddress(parts[0]) {
+			return nil, fmt.Errorf("invalid mapping %s, expected contractAddress=slot", mapping)
+		}
+		slot, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid slot in mapping %s - %w", mapping, err)
+		}
+
+		contract := gethcommon.HexToAddress(parts[0])
+		i, found := byContract[contract]
+		if !found {
+			i = len(queries)
+			byContract[contract] = i
+			queries = append(queries, common.MappingQuery{Contract: contract})
+		}
+		queries[i].Slots = append(queries[i].Slots, hexutil.Uint64(slot))
+	}
+	return queries, nil
+}
+
+// listFlag collects the values of a flag that can be repeated
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
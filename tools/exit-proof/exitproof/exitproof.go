@@ -0,0 +1,207 @@
+package exitproof
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
+	"github.com/ten-protocol/go-ten/go/ethadapter"
+	"github.com/ten-protocol/go-ten/go/ethadapter/mgmtcontractlib"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+// ErrNoCheckpoint is returned when no state root checkpoint was published in the scanned L1 blocks, so the state of the
+// account is not final on the L1 yet
+var ErrNoCheckpoint = errutil.NewBridgeError(errutil.BridgeInsufficientFinality, "no state root checkpoint found on the L1")
+
+// L1Client is the subset of the L1 client used to read the published rollups
+type L1Client interface {
+	BlockNumber() (uint64, error)
+	BlockByNumber(n *big.Int) (*types.Block, error)
+	TransactionReceipt(hash gethcommon.Hash) (*types.Receipt, error)
+}
+
+// ProofClient is the subset of the authenticated TEN client used to request the account proofs from the enclave
+type ProofClient interface {
+	GetAccountProof(ctx context.Context, batchSeqNo tentypes.BatchSeqNo, mappings []common.MappingQuery) (*common.AccountProof, error)
+}
+
+// Checkpoint is a state root published to the L1 in the header of a rollup
+type Checkpoint struct {
+	StateRoot   gethcommon.Hash     `json:"stateRoot"`
+	BatchSeqNo  tentypes.BatchSeqNo `json:"batchSeqNo"`
+	RollupHash  tentypes.RollupHash `json:"rollupHash"`
+	L1Block     hexutil.Uint64      `json:"l1Block"`
+	L1BlockHash gethcommon.Hash     `json:"l1BlockHash"`
+	L1TxHash    gethcommon.Hash     `json:"l1TxHash"`
+}
+
+// ExitProof is what a user needs to exit through the bridge escape hatch: the proof of the state of their account, and
+// of their token balances, against a state root that can be found on the L1 independently of any TEN node.
+type ExitProof struct {
+	Checkpoint *Checkpoint          `json:"checkpoint"`
+	Proof      *common.AccountProof `json:"proof"`
+}
+
+// Generator produces the exit proofs of an account
+type Generator struct {
+	l1          L1Client
+	mgmtLib     mgmtcontractlib.MgmtContractLib
+	proofClient ProofClient
+	// the IDs (the addresses of the enclave keys) of the attested enclaves of the sequencer, the only ones whose
+	// checkpoints are trusted
+	sequencerEnclaveIDs map[gethcommon.Address]bool
+	maxL1Blocks         uint64
+	logger              gethlog.Logger
+}
+
+func NewGenerator(l1 L1Client, mgmtLib mgmtcontractlib.MgmtContractLib, proofClient ProofClient, sequencerEnclaveIDs []gethcommon.Address, maxL1Blocks uint64, logger gethlog.Logger) *Generator {
+	enclaveIDs := make(map[gethcommon.Address]bool, len(sequencerEnclaveIDs))
+	for _, id := range sequencerEnclaveIDs {
+		enclaveIDs[id] = true
+	}
+	return &Generator{
+		l1:                  l1,
+		mgmtLib:             mgmtLib,
+		proofClient:         proofClient,
+		sequencerEnclaveIDs: enclaveIDs,
+		maxL1Blocks:         maxL1Blocks,
+		logger:              logger,
+	}
+}
+
+// Generate finds the latest state root checkpoint on the L1, requests the proof of the account and of its entries in the
+// given mappings against it from the enclave, and checks the proof before returning it. The TEN node is not trusted:
+// the proof is only returned if it matches the state root published on the L1.
+func (g *Generator) Generate(ctx context.Context, mappings []common.MappingQuery) (*ExitProof, error) {
+	checkpoint, err := g.LatestCheckpoint()
+	if err != nil {
+		return nil, err
+	}
+	g.logger.Info("Found state root checkpoint", "l1Block", uint64(checkpoint.L1Block), "batchSeqNo", checkpoint.BatchSeqNo,
+		"stateRoot", checkpoint.StateRoot)
+
+	proof, err := g.proofClient.GetAccountProof(ctx, checkpoint.BatchSeqNo, mappings)
+	if err != nil {
+		return nil, fmt.Errorf("could not get the proof of batch %d - %w", checkpoint.BatchSeqNo, err)
+	}
+	if proof.StateRoot != checkpoint.StateRoot {
+		return nil, errutil.NewBridgeError(errutil.BridgeProofMismatch, "the proof is against state root %s, but the checkpoint of batch %d is %s",
+			proof.StateRoot, checkpoint.BatchSeqNo, checkpoint.StateRoot)
+	}
+	if err := proof.Verify(); err != nil {
+		return nil, errutil.NewBridgeError(errutil.BridgeProofMismatch, "invalid proof - %s", err)
+	}
+
+	return &ExitProof{Checkpoint: checkpoint, Proof: proof}, nil
+}
+
+// LatestCheckpoint scans the L1 backwards from the head for the latest rollup that published a state root checkpoint.
+// At most maxL1Blocks blocks are scanned.
+func (g *Generator) LatestCheckpoint() (*Checkpoint, error) {
+	head, err := g.l1.BlockNumber()
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch the L1 head - %w", err)
+	}
+
+	for scanned := uint64(0); scanned < g.maxL1Blocks && scanned <= head; scanned++ {
+		block, err := g.l1.BlockByNumber(new(big.Int).SetUint64(head - scanned))
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch L1 block %d - %w", head-scanned, err)
+		}
+		checkpoint, err := g.checkpointInBlock(block)
+		if err != nil {
+			return nil, err
+		}
+		if checkpoint != nil {
+			return checkpoint, nil
+		}
+	}
+	return nil, fmt.Errorf("%w in the last %d blocks", ErrNoCheckpoint, g.maxL1Blocks)
+}
+
+// returns the last checkpoint published in the block, or nil if there is none
+func (g *Generator) checkpointInBlock(block *types.Block) (*Checkpoint, error) {
+	txs := block.Transactions()
+	for i := len(txs) - 1; i >= 0; i-- {
+		rollupTx, ok := g.mgmtLib.DecodeTx(txs[i]).(*ethadapter.L1RollupTx)
+		if !ok {
+			continue
+		}
+		rollup, err := common.DecodeRollup(rollupTx.Rollup)
+		if err != nil {
+			g.logger.Warn("Could not decode rollup", "l1TxHash", txs[i].Hash(), log.ErrKey, err)
+			continue
+		}
+		if rollup.Header.StateRootCheckpoint == (gethcommon.Hash{}) {
+			continue
+		}
+
+		// the management contract only checks that the aggregator ID of a rollup is attested, so anyone can publish a
+		// rollup with a forged checkpoint. Only the rollups signed by an enclave of the sequencer are trusted
+		if !g.signedBySequencer(rollup) {
+			g.logger.Warn("Ignoring checkpoint not signed by an enclave of the sequencer", "l1TxHash", txs[i].Hash())
+			continue
+		}
+
+		receipt, err := g.l1.TransactionReceipt(txs[i].Hash())
+		if err != nil {
+			return nil, fmt.Errorf("could not fetch the receipt of rollup transaction %s - %w", txs[i].Hash(), err)
+		}
+		if receipt.Status != types.ReceiptStatusSuccessful {
+			continue
+		}
+
+		return &Checkpoint{
+			StateRoot:   rollup.Header.StateRootCheckpoint,
+			BatchSeqNo:  tentypes.BatchSeqNo(rollup.Header.LastBatchSeqNo),
+			RollupHash:  tentypes.RollupHash(rollup.Hash()),
+			L1Block:     hexutil.Uint64(block.NumberU64()),
+			L1BlockHash: block.Hash(),
+			L1TxHash:    txs[i].Hash(),
+		}, nil
+	}
+	return nil, nil //nolint:nilnil
+}
+
+// returns whether the rollup header was signed by one of the enclaves of the sequencer. The signature has no recovery
+// ID, so both the keys it can be recovered to are checked.
+func (g *Generator) signedBySequencer(rollup *common.ExtRollup) bool {
+	if rollup.Header.R == nil || rollup.Header.S == nil {
+		return false
+	}
+	// the recovery only accepts the signatures in their lower-S form, which is valid for the same key
+	curveN := crypto.S256().Params().N
+	s := new(big.Int).Set(rollup.Header.S)
+	if s.Cmp(new(big.Int).Rsh(curveN, 1)) > 0 {
+		s.Sub(curveN, s)
+	}
+	if !crypto.ValidateSignatureValues(0, rollup.Header.R, s, true) {
+		return false
+	}
+
+	hash := rollup.Hash()
+	sig := make([]byte, crypto.SignatureLength)
+	rollup.Header.R.FillBytes(sig[:32])
+	s.FillBytes(sig[32:64])
+	for _, recoveryID := range []byte{0, 1} {
+		sig[crypto.RecoveryIDOffset] = recoveryID
+		pubKey, err := crypto.SigToPub(hash.Bytes(), sig)
+		if err != nil {
+			continue
+		}
+		if g.sequencerEnclaveIDs[crypto.PubkeyToAddress(*pubKey)] {
+			return true
+		}
+	}
+	return false
+}
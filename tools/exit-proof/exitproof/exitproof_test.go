@@ -0,0 +1,231 @@
+package exitproof
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/rawdb"
+	"github.com/ethereum/go-ethereum/core/state"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
+	"github.com/ten-protocol/go-ten/go/ethadapter"
+	"github.com/ten-protocol/go-ten/go/ethadapter/mgmtcontractlib"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	testMgmtContract = gethcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	testAccount      = gethcommon.HexToAddress("0x2000000000000000000000000000000000000002")
+	testEnclaveKey   = mustGenerateKey()
+	testEnclaveIDs   = []gethcommon.Address{crypto.PubkeyToAddress(testEnclaveKey.PublicKey)}
+)
+
+func mustGenerateKey() *ecdsa.PrivateKey {
+	key, err := crypto.GenerateKey()
+	if err != nil {
+		panic(err)
+	}
+	return key
+}
+
+// testL1 serves the blocks by number, and the receipts of the rollup transactions
+type testL1 struct {
+	blocks       []*types.Block
+	failedTxs    map[gethcommon.Hash]bool
+	fetchedCount int
+}
+
+func (l *testL1) BlockNumber() (uint64, error) {
+	return uint64(len(l.blocks) - 1), nil
+}
+
+func (l *testL1) BlockByNumber(n *big.Int) (*types.Block, error) {
+	l.fetchedCount++
+	return l.blocks[n.Uint64()], nil
+}
+
+func (l *testL1) TransactionReceipt(hash gethcommon.Hash) (*types.Receipt, error) {
+	if l.failedTxs[hash] {
+		return &types.Receipt{Status: types.ReceiptStatusFailed}, nil
+	}
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil
+}
+
+type testProofClient struct {
+	proof *common.AccountProof
+}
+
+func (c *testProofClient) GetAccountProof(_ context.Context, batchSeqNo tentypes.BatchSeqNo, _ []common.MappingQuery) (*common.AccountProof, error) {
+	if c.proof == nil {
+		return nil, errors.New("not found")
+	}
+	proof := *c.proof
+	proof.BatchSeqNo = batchSeqNo
+	return &proof, nil
+}
+
+func newTestRollupTx(t *testing.T, mgmtLib mgmtcontractlib.MgmtContractLib, lastBatchSeqNo uint64, stateRoot gethcommon.Hash) *types.Transaction {
+	return newSignedTestRollupTx(t, mgmtLib, testEnclaveKey, lastBatchSeqNo, stateRoot)
+}
+
+func newSignedTestRollupTx(t *testing.T, mgmtLib mgmtcontractlib.MgmtContractLib, key *ecdsa.PrivateKey, lastBatchSeqNo uint64, stateRoot gethcommon.Hash) *types.Transaction {
+	header := &common.RollupHeader{
+		LastBatchSeqNo:      lastBatchSeqNo,
+		StateRootCheckpoint: stateRoot,
+	}
+	hash := header.Hash()
+	var err error
+	header.R, header.S, err = ecdsa.Sign(rand.Reader, key, hash[:])
+	require.NoError(t, err)
+	return types.NewTx(mgmtLib.CreateRollup(&ethadapter.L1RollupTx{Rollup: mustEncodeRollup(t, &common.ExtRollup{Header: header})}))
+}
+
+func newTestBlock(number int64, txs ...*types.Transaction) *types.Block {
+	return types.NewBlock(&types.Header{Number: big.NewInt(number)}, txs, nil, nil, trie.NewStackTrie(nil))
+}
+
+// returns the proof of the account against a state with a balance
+func newTestAccountProof(t *testing.T) *common.AccountProof {
+	stateDB, err := state.New(types.EmptyRootHash, state.NewDatabase(rawdb.NewMemoryDatabase()), nil)
+	require.NoError(t, err)
+	stateDB.SetBalance(testAccount, big.NewInt(1000))
+	root, err := stateDB.Commit(0, true)
+	require.NoError(t, err)
+	stateDB, err = state.New(root, stateDB.Database(), nil)
+	require.NoError(t, err)
+	accountProof, err := stateDB.GetProof(testAccount)
+	require.NoError(t, err)
+
+	proof := &common.AccountProof{
+		Address:     testAccount,
+		StateRoot:   root,
+		Balance:     (*hexutil.Big)(big.NewInt(1000)),
+		CodeHash:    types.EmptyCodeHash,
+		StorageHash: types.EmptyRootHash,
+	}
+	for _, node := range accountProof {
+		proof.AccountProof = append(proof.AccountProof, node)
+	}
+	return proof
+}
+
+func TestFindsTheLatestSuccessfulCheckpoint(t *testing.T) {
+	mgmtLib := mgmtcontractlib.NewMgmtContractLib(&testMgmtContract, gethlog.New())
+	oldCheckpoint := newTestRollupTx(t, mgmtLib, 10, gethcommon.HexToHash("0x0a"))
+	checkpoint := newTestRollupTx(t, mgmtLib, 20, gethcommon.HexToHash("0x14"))
+	failedCheckpoint := newTestRollupTx(t, mgmtLib, 30, gethcommon.HexToHash("0x1e"))
+	l1 := &testL1{
+		blocks: []*types.Block{
+			newTestBlock(0),
+			newTestBlock(1, oldCheckpoint),
+			newTestBlock(2, checkpoint, newTestRollupTx(t, mgmtLib, 25, gethcommon.Hash{})),
+			newTestBlock(3, failedCheckpoint),
+			newTestBlock(4, newTestRollupTx(t, mgmtLib, 35, gethcommon.Hash{})),
+		},
+		failedTxs: map[gethcommon.Hash]bool{failedCheckpoint.Hash(): true},
+	}
+
+	found, err := NewGenerator(l1, mgmtLib, nil, testEnclaveIDs, 100, gethlog.New()).LatestCheckpoint()
+	require.NoError(t, err)
+	require.Equal(t, gethcommon.HexToHash("0x14"), found.StateRoot)
+	require.Equal(t, tentypes.BatchSeqNo(20), found.BatchSeqNo)
+	require.Equal(t, hexutil.Uint64(2), found.L1Block)
+	require.Equal(t, checkpoint.Hash(), found.L1TxHash)
+	require.Equal(t, 3, l1.fetchedCount)
+}
+
+func TestIgnoresTheCheckpointsNotSignedByTheSequencer(t *testing.T) {
+	mgmtLib := mgmtcontractlib.NewMgmtContractLib(&testMgmtContract, gethlog.New())
+	forgedCheckpoint := newSignedTestRollupTx(t, mgmtLib, mustGenerateKey(), 20, gethcommon.HexToHash("0x14"))
+	unsignedCheckpoint := types.NewTx(mgmtLib.CreateRollup(&ethadapter.L1RollupTx{Rollup: mustEncodeRollup(t, &common.ExtRollup{
+		Header: &common.RollupHeader{LastBatchSeqNo: 30, StateRootCheckpoint: gethcommon.HexToHash("0x1e"), R: big.NewInt(1), S: big.NewInt(1)},
+	})}))
+	l1 := &testL1{blocks: []*types.Block{
+		newTestBlock(0, newTestRollupTx(t, mgmtLib, 10, gethcommon.HexToHash("0x0a"))),
+		newTestBlock(1, forgedCheckpoint),
+		newTestBlock(2, unsignedCheckpoint),
+	}}
+
+	found, err := NewGenerator(l1, mgmtLib, nil, testEnclaveIDs, 100, gethlog.New()).LatestCheckpoint()
+	require.NoError(t, err)
+	require.Equal(t, gethcommon.HexToHash("0x0a"), found.StateRoot)
+	require.Equal(t, hexutil.Uint64(0), found.L1Block)
+}
+
+func mustEncodeRollup(t *testing.T, rollup *common.ExtRollup) []byte {
+	encoded, err := common.EncodeRollup(rollup)
+	require.NoError(t, err)
+	return encoded
+}
+
+func TestStopsScanningTheL1AfterTheMaxBlocks(t *testing.T) {
+	mgmtLib := mgmtcontractlib.NewMgmtContractLib(&testMgmtContract, gethlog.New())
+	l1 := &testL1{blocks: []*types.Block{
+		newTestBlock(0, newTestRollupTx(t, mgmtLib, 10, gethcommon.HexToHash("0x0a"))),
+		newTestBlock(1),
+		newTestBlock(2),
+	}}
+
+	_, err := NewGenerator(l1, mgmtLib, nil, testEnclaveIDs, 2, gethlog.New()).LatestCheckpoint()
+	require.ErrorIs(t, err, ErrNoCheckpoint)
+	require.ErrorIs(t, err, errutil.ErrBridgeInsufficientFinality)
+	require.Equal(t, 2, l1.fetchedCount)
+}
+
+func TestGeneratesProofsAgainstTheL1Checkpoint(t *testing.T) {
+	mgmtLib := mgmtcontractlib.NewMgmtContractLib(&testMgmtContract, gethlog.New())
+	proof := newTestAccountProof(t)
+
+	tests := map[string]struct {
+		checkpointRoot gethcommon.Hash
+		tamper         func(p *common.AccountProof)
+		err            string
+	}{
+		"valid proof": {
+			checkpointRoot: proof.StateRoot,
+		},
+		"proof against another state root": {
+			checkpointRoot: gethcommon.HexToHash("0x01"),
+			err:            "the proof is against state root",
+		},
+		"invalid proof": {
+			checkpointRoot: proof.StateRoot,
+			tamper: func(p *common.AccountProof) {
+				p.Balance = (*hexutil.Big)(big.NewInt(1_000_000))
+			},
+			err: "invalid proof",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			l1 := &testL1{blocks: []*types.Block{newTestBlock(0, newTestRollupTx(t, mgmtLib, 10, test.checkpointRoot))}}
+			served := *proof
+			if test.tamper != nil {
+				test.tamper(&served)
+			}
+
+			exitProof, err := NewGenerator(l1, mgmtLib, &testProofClient{proof: &served}, testEnclaveIDs, 10, gethlog.New()).Generate(context.Background(), nil)
+			if test.err != "" {
+				require.ErrorContains(t, err, test.err)
+				require.ErrorIs(t, err, errutil.ErrBridgeProofMismatch)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tentypes.BatchSeqNo(10), exitProof.Proof.BatchSeqNo)
+			require.Equal(t, test.checkpointRoot, exitProof.Checkpoint.StateRoot)
+		})
+	}
+}
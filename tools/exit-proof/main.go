@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+	"github.com/ten-protocol/go-ten/go/common/errutil"
+	"github.com/ten-protocol/go-ten/go/common/viewingkey"
+	"github.com/ten-protocol/go-ten/go/ethadapter"
+	"github.com/ten-protocol/go-ten/go/ethadapter/mgmtcontractlib"
+	"github.com/ten-protocol/go-ten/go/obsclient"
+	"github.com/ten-protocol/go-ten/go/rpc"
+	"github.com/ten-protocol/go-ten/go/wallet"
+	"github.com/ten-protocol/go-ten/tools/exit-proof/exitproof"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+const l1Timeout = 30 * time.Second
+
+// Tool to generate the proof a user needs to exit through the bridge escape hatch if the TEN network stops processing
+// their withdrawals. The proof of the account's state, and of its token balances, is requested from the enclave and
+// checked against the latest state root checkpoint published on the L1, so the TEN node does not need to be trusted.
+//
+// Example:
+//
+//	exit-proof -l1RPC https://sepolia.infura.io/v3/... -nodeRPC http://erpc.testnet.ten.xyz:80 \
+//	  -managementContract 0x... -sequencerEnclaveID 0x... -privateKey 0x... \
+//	  -mapping 0x<erc20 contract>=0 -out exit-proof.json
+func main() {
+	cfg, err := parseCLIArgs()
+	if err != nil {
+		exit(err)
+	}
+
+	logger := gethlog.New()
+	logger.SetHandler(gethlog.LvlFilterHandler(gethlog.LvlInfo, gethlog.StreamHandler(os.Stderr, gethlog.TerminalFormat(false))))
+
+	proof, err := generateExitProof(cfg, logger)
+	if err != nil {
+		exit(err)
+	}
+
+	content, err := json.MarshalIndent(proof, "", "  ")
+	if err != nil {
+		exit(err)
+	}
+	if err = os.WriteFile(cfg.out, content, 0o644); err != nil { //nolint:gosec
+		exit(err)
+	}
+	fmt.Printf("Wrote the exit proof of %s against the state root of batch %d (L1 block %d) to %s\n",
+		proof.Proof.Address, proof.Checkpoint.BatchSeqNo, proof.Checkpoint.L1Block, cfg.out)
+}
+
+func generateExitProof(cfg *exitProofConfig, logger gethlog.Logger) (*exitproof.ExitProof, error) {
+	vk, err := viewingKey(cfg, logger)
+	if err != nil {
+		return nil, err
+	}
+	encClient, err := rpc.NewEncNetworkClient(cfg.nodeRPC, vk, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to the TEN node - %w", err)
+	}
+	defer encClient.Stop()
+
+	l1Client, err := ethadapter.NewEthClientFromURL(cfg.l1RPC, l1Timeout, gethcommon.Address{}, logger)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to the L1 node - %w", err)
+	}
+	defer l1Client.Stop()
+
+	mgmtLib := mgmtcontractlib.NewMgmtContractLib(&cfg.managementContract, logger)
+	generator := exitproof.NewGenerator(l1Client, mgmtLib, obsclient.NewAuthObsClient(encClient), cfg.sequencerEnclaveIDs, cfg.maxL1Blocks, logger)
+	return generator.Generate(context.Background(), cfg.mappings)
+}
+
+// returns the viewing key given on the command line, or signs a new one with the private key of the account
+func viewingKey(cfg *exitProofConfig, logger gethlog.Logger) (*viewingkey.ViewingKey, error) {
+	if cfg.privateKey != "" {
+		w := wallet.NewInMemoryWalletFromConfig(cfg.privateKey, cfg.chainID, logger)
+		return viewingkey.GenerateViewingKeyForWallet(w)
+	}
+
+	vkPrivateKey, err := crypto.HexToECDSA(strings.TrimPrefix(cfg.viewingKey, "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid viewing key - %w", err)
+	}
+	signature, err := hexutil.Decode(cfg.viewingKeySignature)
+	if err != nil {
+		return nil, fmt.Errorf("invalid viewing key signature - %w", err)
+	}
+	return &viewingkey.ViewingKey{
+		Account:    &cfg.account,
+		PrivateKey: ecies.ImportECDSA(vkPrivateKey),
+		PublicKey:  crypto.CompressPubkey(&vkPrivateKey.PublicKey),
+		Signature:  signature,
+	}, nil
+}
+
+func exit(err error) {
+	fmt.Fprintf(os.Stderr, "unable to generate the exit proof - %s\n", err)
+	// the code of the bridge failures lets the scripts calling the tool act on them
+	if bridgeErr, ok := errutil.AsBridgeError(err); ok {
+		fmt.Fprintf(os.Stderr, "error code: %s\n", bridgeErr.Code)
+	}
+	os.Exit(1)
+}
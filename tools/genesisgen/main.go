@@ -0,0 +1,171 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"math/big"
+	"os"
+	"strconv"
+	"strings"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ten-protocol/go-ten/go/common/networkgenesis"
+)
+
+// Tool to generate the network genesis.json for a TEN network launch, with the faucet and infrastructure accounts
+// prefunded and the system contracts predeployed.
+//
+// Example:
+//
+//	genesisgen -chainId 443 -l1ChainId 1337 -sequencerId 0x... -managementContract 0x... \
+//	  -prefund 0xA58C60cc047592DE97BF1E8d2f225Fc5D959De77=7500000000000000000000000000000 \
+//	  -contract 0x4200000000000000000000000000000000000001=./build/Faucet.bin-runtime \
+//	  -fork relevancyRulesV1=0 \
+//	  -out genesis.json
+
+func main() {
+	base := flag.String("base", "", "An existing genesis file to add the allocations to")
+	out := flag.String("out", "genesis.json", "The path the genesis file is written to")
+	chainID := flag.Int64("chainId", 0, "The ID of the TEN chain")
+	l1ChainID := flag.Int64("l1ChainId", 0, "The ID of the L1 chain")
+	sequencerID := flag.String("sequencerId", "", "The address of the sequencer")
+	mgmtContract := flag.String("managementContract", "", "The address of the management contract on the L1")
+	messageBus := flag.String("messageBus", "", "The address of the message bus contract on the L1")
+	l1Start := flag.String("l1Start", "", "The hash of the L1 block the network is streamed from")
+	var prefunds, contracts, forks listFlag
+	flag.Var(&prefunds, "prefund", "An account to prefund, as address=amountInWei (repeatable)")
+	flag.Var(&contracts, "contract", "A contract to predeploy, as address=pathToRuntimeBytecodeHex (repeatable)")
+	flag.Var(&forks, "fork", "A fork of the schedule, as name=height (repeatable)")
+	flag.Parse()
+
+	g := &networkgenesis.NetworkGenesis{}
+	if *base != "" {
+		existing, err := networkgenesis.Load(*base)
+		if err != nil {
+			exit(err)
+		}
+		g = existing
+	}
+
+	if *chainID != 0 {
+		g.ChainID = *chainID
+	}
+	if *l1ChainID != 0 {
+		g.L1ChainID = *l1ChainID
+	}
+	if *sequencerID != "" {
+		g.SequencerID = gethcommon.HexToAddress(*sequencerID)
+	}
+	if *mgmtContract != "" {
+		g.ManagementContractAddress = gethcommon.HexToAddress(*mgmtContract)
+	}
+	if *messageBus != "" {
+		g.MessageBusAddress = gethcommon.HexToAddress(*messageBus)
+	}
+	if *l1Start != "" {
+		g.L1StartHash = gethcommon.HexToHash(*l1Start)
+	}
+
+	if err := addAllocations(g, prefunds, contracts); err != nil {
+		exit(err)
+	}
+	if err := addForks(g, forks); err != nil {
+		exit(err)
+	}
+
+	if err := g.Validate(); err != nil {
+		exit(err)
+	}
+	hash, err := g.Hash()
+	if err != nil {
+		exit(err)
+	}
+
+	content, err := json.MarshalIndent(g, "", "  ")
+	if err != nil {
+		exit(err)
+	}
+	if err = os.WriteFile(*out, content, 0o644); err != nil { //nolint:gosec
+		exit(err)
+	}
+	fmt.Printf("Wrote genesis with %d prefunded accounts and %d contracts to %s (hash %s)\n", len(g.Accounts), len(g.Contracts), *out, hash)
+}
+
+// addAllocations adds the prefunded accounts (address=amountInWei) and the predeployed contracts
+// (address=pathToRuntimeBytecodeHex) to the genesis
+func addAllocations(g *networkgenesis.NetworkGenesis, prefunds []string, contracts []string) error {
+	for _, prefund := range prefunds {
+		addr, amountStr, err := splitAllocation(prefund)
+		if err != nil {
+			return err
+		}
+		amount, ok := new(big.Int).SetString(amountStr, 10)
+		if !ok {
+			return fmt.Errorf("invalid amount %s for %s", amountStr, addr.Hex())
+		}
+		g.Accounts = append(g.Accounts, networkgenesis.Account{Address: addr, Amount: amount})
+	}
+
+	for _, contract := range contracts {
+		addr, codePath, err := splitAllocation(contract)
+		if err != nil {
+			return err
+		}
+		code, err := os.ReadFile(codePath)
+		if err != nil {
+			return fmt.Errorf("could not read bytecode for %s - %w", addr.Hex(), err)
+		}
+		g.Contracts = append(g.Contracts, networkgenesis.Contract{
+			Address: addr,
+			Code:    gethcommon.FromHex(strings.TrimSpace(string(code))),
+		})
+	}
+
+	return nil
+}
+
+// addForks sets the activation heights of the forks (name=height) of the genesis
+func addForks(g *networkgenesis.NetworkGenesis, forks []string) error {
+	for _, fork := range forks {
+		parts := strings.SplitN(fork, "=", 2)
+		if len(parts) != 2 {
+			return fmt.Errorf("invalid fork %s, expected name=height", fork)
+		}
+		height, err := strconv.ParseUint(parts[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid height for fork %s - %w", parts[0], err)
+		}
+		if g.Forks == nil {
+			g.Forks = map[string]uint64{}
+		}
+		g.Forks[parts[0]] = height
+	}
+	return nil
+}
+
+// splitAllocation parses an `address=value` pair
+func splitAllocation(allocation string) (gethcommon.Address, string, error) {
+	parts := strings.SplitN(allocation, "=", 2)
+	if len(parts) != 2 || !gethcommon.IsHexAddress(parts[0]) {
+		return gethcommon.Address{}, "", fmt.Errorf("invalid allocation %s, expected address=value", allocation)
+	}
+	return gethcommon.HexToAddress(parts[0]), parts[1], nil
+}
+
+func exit(err error) {
+	fmt.Fprintf(os.Stderr, "unable to generate genesis - %s\n", err)
+	os.Exit(1)
+}
+
+// listFlag collects the values of a flag that can be repeated
+type listFlag []string
+
+func (l *listFlag) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *listFlag) Set(value string) error {
+	*l = append(*l, value)
+	return nil
+}
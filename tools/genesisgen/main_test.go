@@ -0,0 +1,55 @@
+package main
+
+import (
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/networkgenesis"
+)
+
+func TestAddAllocations(t *testing.T) {
+	codePath := filepath.Join(t.TempDir(), "Faucet.bin-runtime")
+	require.NoError(t, os.WriteFile(codePath, []byte("0x6080604052\n"), 0o600))
+
+	g := &networkgenesis.NetworkGenesis{}
+	err := addAllocations(g,
+		[]string{"0xA58C60cc047592DE97BF1E8d2f225Fc5D959De77=7500000000000000000000000000000"},
+		[]string{"0x4200000000000000000000000000000000000001=" + codePath},
+	)
+	require.NoError(t, err)
+
+	amount, _ := new(big.Int).SetString("7500000000000000000000000000000", 10)
+	require.Equal(t, []networkgenesis.Account{{Address: gethcommon.HexToAddress("0xA58C60cc047592DE97BF1E8d2f225Fc5D959De77"), Amount: amount}}, g.Accounts)
+	require.Len(t, g.Contracts, 1)
+	require.Equal(t, gethcommon.HexToAddress("0x4200000000000000000000000000000000000001"), g.Contracts[0].Address)
+	require.Equal(t, gethcommon.FromHex("0x6080604052"), []byte(g.Contracts[0].Code))
+}
+
+func TestAddAllocationsRejectsMalformedValues(t *testing.T) {
+	tests := map[string][]string{
+		"missing amount":  {"0xA58C60cc047592DE97BF1E8d2f225Fc5D959De77"},
+		"invalid address": {"0x1234=10"},
+		"invalid amount":  {"0xA58C60cc047592DE97BF1E8d2f225Fc5D959De77=1e18"},
+	}
+	for name, prefunds := range tests {
+		t.Run(name, func(t *testing.T) {
+			require.Error(t, addAllocations(&networkgenesis.NetworkGenesis{}, prefunds, nil))
+		})
+	}
+
+	missingCode := []string{"0x4200000000000000000000000000000000000001=" + filepath.Join(t.TempDir(), "missing")}
+	require.Error(t, addAllocations(&networkgenesis.NetworkGenesis{}, nil, missingCode))
+}
+
+func TestAddForks(t *testing.T) {
+	g := &networkgenesis.NetworkGenesis{}
+	require.NoError(t, addForks(g, []string{networkgenesis.RelevancyRulesV1Fork + "=50000"}))
+	require.Equal(t, map[string]uint64{networkgenesis.RelevancyRulesV1Fork: 50_000}, g.Forks)
+
+	require.Error(t, addForks(g, []string{networkgenesis.RelevancyRulesV1Fork}))
+	require.Error(t, addForks(g, []string{networkgenesis.RelevancyRulesV1Fork + "=-1"}))
+}
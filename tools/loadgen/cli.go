@@ -0,0 +1,127 @@
+package main
+
+import (
+	"flag"
+	"math/big"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/params"
+	"github.com/ten-protocol/go-ten/tools/loadgen/loadgen"
+)
+
+const (
+	// Flag names, defaults and usages.
+	targetName    = "target"
+	targetDefault = loadgen.GatewayTarget
+	targetUsage   = "Where the load is sent: gateway, or host to send encrypted requests to the host directly. Default: gateway"
+
+	gatewayHTTPName    = "gatewayHTTP"
+	gatewayHTTPDefault = "http://127.0.0.1:3000"
+	gatewayHTTPUsage   = "The http address of the gateway. Default: http://127.0.0.1:3000"
+
+	gatewayWSName    = "gatewayWS"
+	gatewayWSDefault = "ws://127.0.0.1:3001"
+	gatewayWSUsage   = "The websocket address of the gateway. Default: ws://127.0.0.1:3001"
+
+	hostHTTPName    = "hostHTTP"
+	hostHTTPDefault = "http://127.0.0.1:80"
+	hostHTTPUsage   = "The http address of the host, used with the host target. Default: http://127.0.0.1:80"
+
+	hostWSName    = "hostWS"
+	hostWSDefault = "ws://127.0.0.1:81"
+	hostWSUsage   = "The websocket address of the host, used with the host target. Default: ws://127.0.0.1:81"
+
+	chainIDName    = "chainId"
+	chainIDDefault = 443
+	chainIDUsage   = "The ID of the TEN chain. Default: 443"
+
+	funderPKName    = "funderPK"
+	funderPKDefault = ""
+	funderPKUsage   = "The prefunded private key used to fund the users. Required to send transactions"
+
+	fundAmountName    = "fundAmount"
+	fundAmountDefault = 1
+	fundAmountUsage   = "The amount each user is funded with (in ETH). Default: 1"
+
+	usersName    = "users"
+	usersDefault = 10
+	usersUsage   = "The number of concurrent gateway users. Default: 10"
+
+	rateName    = "rate"
+	rateDefault = 50
+	rateUsage   = "The total number of requests per second. Default: 50"
+
+	durationName    = "duration"
+	durationDefault = time.Minute
+	durationUsage   = "How long to generate load for. Default: 1m"
+
+	sendTxWeightName    = "sendTxWeight"
+	sendTxWeightDefault = 0
+	sendTxWeightUsage   = "The relative weight of eth_sendRawTransaction in the request mix. Requires the funder key. Default: 0"
+
+	callWeightName    = "callWeight"
+	callWeightDefault = 5
+	callWeightUsage   = "The relative weight of eth_call in the request mix. Default: 5"
+
+	getLogsWeightName    = "getLogsWeight"
+	getLogsWeightDefault = 2
+	getLogsWeightUsage   = "The relative weight of eth_getLogs in the request mix. Default: 2"
+
+	subscriptionsName    = "subscriptionsPerUser"
+	subscriptionsDefault = 1
+	subscriptionsUsage   = "The number of log subscriptions each user keeps open. Default: 1"
+
+	callToName    = "callTo"
+	callToDefault = ""
+	callToUsage   = "The contract targeted by the calls and the log queries. Default: each user's own account"
+
+	callDataName    = "callData"
+	callDataDefault = ""
+	callDataUsage   = "The hex encoded data of the calls"
+)
+
+func parseCLIArgs() *loadgen.Config {
+	target := flag.String(targetName, targetDefault, targetUsage)
+	gatewayHTTP := flag.String(gatewayHTTPName, gatewayHTTPDefault, gatewayHTTPUsage)
+	gatewayWS := flag.String(gatewayWSName, gatewayWSDefault, gatewayWSUsage)
+	hostHTTP := flag.String(hostHTTPName, hostHTTPDefault, hostHTTPUsage)
+	hostWS := flag.String(hostWSName, hostWSDefault, hostWSUsage)
+	chainID := flag.Int64(chainIDName, chainIDDefault, chainIDUsage)
+	funderPK := flag.String(funderPKName, funderPKDefault, funderPKUsage)
+	fundAmount := flag.Int64(fundAmountName, fundAmountDefault, fundAmountUsage)
+	users := flag.Int(usersName, usersDefault, usersUsage)
+	rate := flag.Int(rateName, rateDefault, rateUsage)
+	duration := flag.Duration(durationName, durationDefault, durationUsage)
+	sendTxWeight := flag.Int(sendTxWeightName, sendTxWeightDefault, sendTxWeightUsage)
+	callWeight := flag.Int(callWeightName, callWeightDefault, callWeightUsage)
+	getLogsWeight := flag.Int(getLogsWeightName, getLogsWeightDefault, getLogsWeightUsage)
+	subscriptions := flag.Int(subscriptionsName, subscriptionsDefault, subscriptionsUsage)
+	callTo := flag.String(callToName, callToDefault, callToUsage)
+	callData := flag.String(callDataName, callDataDefault, callDataUsage)
+	flag.Parse()
+
+	cfg := &loadgen.Config{
+		Target:               *target,
+		GatewayHTTP:          *gatewayHTTP,
+		GatewayWS:            *gatewayWS,
+		HostHTTP:             *hostHTTP,
+		HostWS:               *hostWS,
+		ChainID:              big.NewInt(*chainID),
+		FunderPK:             *funderPK,
+		FundAmount:           new(big.Int).Mul(big.NewInt(*fundAmount), big.NewInt(params.Ether)),
+		Users:                *users,
+		Rate:                 *rate,
+		Duration:             *duration,
+		SendTxWeight:         *sendTxWeight,
+		CallWeight:           *callWeight,
+		GetLogsWeight:        *getLogsWeight,
+		SubscriptionsPerUser: *subscriptions,
+		CallData:             gethcommon.FromHex(*callData),
+	}
+	if *callTo != "" {
+		addr := gethcommon.HexToAddress(*callTo)
+		cfg.CallTo = &addr
+	}
+	return cfg
+}
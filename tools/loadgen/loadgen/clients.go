@@ -0,0 +1,108 @@
+package loadgen
+
+import (
+	"context"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/eth/filters"
+	"github.com/ethereum/go-ethereum/ethclient"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/obsclient"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// rpcClient is the subset of the eth API a user sends its requests through. It is implemented by the geth client
+// connected to the gateway, and by hostClient.
+type rpcClient interface {
+	SendTransaction(ctx context.Context, tx *types.Transaction) error
+	CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error)
+	FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error)
+	SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error)
+	PendingNonceAt(ctx context.Context, account gethcommon.Address) (uint64, error)
+	SuggestGasPrice(ctx context.Context) (*big.Int, error)
+	TransactionReceipt(ctx context.Context, txHash gethcommon.Hash) (*types.Receipt, error)
+	Close()
+}
+
+// hostClient sends the requests of a user to the host directly, encrypted with the user's viewing key
+type hostClient struct {
+	authClient *obsclient.AuthObsClient
+	// serves the requests that are not encrypted
+	client *ethclient.Client
+}
+
+func dialHost(address string, authClient *obsclient.AuthObsClient) (*hostClient, error) {
+	client, err := ethclient.Dial(address)
+	if err != nil {
+		return nil, err
+	}
+	return &hostClient{authClient: authClient, client: client}, nil
+}
+
+func (c *hostClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
+	return c.authClient.SendTransaction(ctx, tx)
+}
+
+func (c *hostClient) CallContract(ctx context.Context, msg ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
+	return c.authClient.CallContract(ctx, msg, blockNumber)
+}
+
+func (c *hostClient) FilterLogs(ctx context.Context, query ethereum.FilterQuery) ([]types.Log, error) {
+	topics := make([]interface{}, len(query.Topics))
+	for i, t := range query.Topics {
+		topics[i] = t
+	}
+	logs, err := c.authClient.GetLogs(ctx, common.FilterCriteriaJSON{BlockHash: query.BlockHash, Addresses: query.Addresses, Topics: topics})
+	if err != nil {
+		return nil, err
+	}
+	result := make([]types.Log, len(logs))
+	for i, l := range logs {
+		result[i] = *l
+	}
+	return result, nil
+}
+
+func (c *hostClient) SubscribeFilterLogs(ctx context.Context, query ethereum.FilterQuery, ch chan<- types.Log) (ethereum.Subscription, error) {
+	idAndLogs := make(chan common.IDAndLog)
+	sub, err := c.authClient.SubscribeFilterLogs(ctx, filters.FilterCriteria(query), idAndLogs)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		for {
+			select {
+			case <-sub.Err():
+				return
+			case l := <-idAndLogs:
+				select {
+				case ch <- *l.Log:
+				case <-sub.Err():
+					return
+				}
+			}
+		}
+	}()
+	return sub, nil
+}
+
+// PendingNonceAt returns the nonce of the account the client is authenticated with
+func (c *hostClient) PendingNonceAt(ctx context.Context, _ gethcommon.Address) (uint64, error) {
+	return c.authClient.NonceAt(ctx, nil)
+}
+
+func (c *hostClient) SuggestGasPrice(ctx context.Context) (*big.Int, error) {
+	return c.client.SuggestGasPrice(ctx)
+}
+
+func (c *hostClient) TransactionReceipt(ctx context.Context, txHash gethcommon.Hash) (*types.Receipt, error) {
+	return c.authClient.TransactionReceipt(ctx, txHash)
+}
+
+func (c *hostClient) Close() {
+	c.authClient.Close()
+	c.client.Close()
+}
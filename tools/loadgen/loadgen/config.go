@@ -0,0 +1,84 @@
+package loadgen
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// The RPC endpoints the load can be sent to
+const (
+	GatewayTarget = "gateway" // the users join the gateway and send their requests through it
+	HostTarget    = "host"    // the users register a viewing key and send encrypted requests to the host directly
+)
+
+// Config is the configuration of a load generation run
+type Config struct {
+	Target      string // the RPC endpoint the load is sent to, GatewayTarget or HostTarget
+	GatewayHTTP string // the http address of the gateway, e.g. http://127.0.0.1:3000
+	GatewayWS   string // the websocket address of the gateway, e.g. ws://127.0.0.1:3001
+	HostHTTP    string // the http address of the host, e.g. http://127.0.0.1:80
+	HostWS      string // the websocket address of the host, e.g. ws://127.0.0.1:81
+	ChainID     *big.Int
+
+	FunderPK   string   // the prefunded key used to fund the users. If empty, no transactions are sent
+	FundAmount *big.Int // the amount each user is funded with
+
+	Users    int           // the number of concurrent gateway users
+	Rate     int           // the total number of requests per second, across all users
+	Duration time.Duration // how long the load is generated for
+
+	// the relative weights of the request types in the generated mix
+	SendTxWeight  int
+	CallWeight    int
+	GetLogsWeight int
+	// the contract targeted by the calls and log queries. If not set, the calls target the user's own account
+	CallTo   *gethcommon.Address
+	CallData []byte
+
+	// the number of log subscriptions each user keeps open for the duration of the run
+	SubscriptionsPerUser int
+}
+
+// Validate checks the configuration is usable
+func (c *Config) Validate() error {
+	if c.Target != GatewayTarget && c.Target != HostTarget {
+		return fmt.Errorf("unknown target %q, expected %s or %s", c.Target, GatewayTarget, HostTarget)
+	}
+	if c.httpAddress() == "" {
+		return fmt.Errorf("the %s http address must be set", c.Target)
+	}
+	if c.SubscriptionsPerUser > 0 && c.wsAddress() == "" {
+		return fmt.Errorf("the %s websocket address must be set to open subscriptions", c.Target)
+	}
+	if c.Users <= 0 || c.Rate <= 0 || c.Duration <= 0 {
+		return errors.New("the number of users, the rate and the duration must be positive")
+	}
+	if c.SendTxWeight < 0 || c.CallWeight < 0 || c.GetLogsWeight < 0 {
+		return errors.New("the request weights can't be negative")
+	}
+	if c.FunderPK == "" && c.SendTxWeight > 0 {
+		return errors.New("a funder key is required to send transactions")
+	}
+	if c.SendTxWeight+c.CallWeight+c.GetLogsWeight == 0 {
+		return errors.New("at least one request type must have a positive weight")
+	}
+	return nil
+}
+
+func (c *Config) httpAddress() string {
+	if c.Target == HostTarget {
+		return c.HostHTTP
+	}
+	return c.GatewayHTTP
+}
+
+func (c *Config) wsAddress() string {
+	if c.Target == HostTarget {
+		return c.HostWS
+	}
+	return c.GatewayWS
+}
@@ -0,0 +1,52 @@
+package loadgen
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	validCfg := func() *Config {
+		return &Config{
+			Target:               GatewayTarget,
+			GatewayHTTP:          "http://127.0.0.1:3000",
+			GatewayWS:            "ws://127.0.0.1:3001",
+			Users:                1,
+			Rate:                 1,
+			Duration:             time.Second,
+			CallWeight:           1,
+			SubscriptionsPerUser: 1,
+		}
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Config)
+		valid  bool
+	}{
+		{name: "gateway without funder key", modify: func(*Config) {}, valid: true},
+		{name: "transactions without funder key", modify: func(c *Config) { c.SendTxWeight = 1 }},
+		{name: "transactions with funder key", modify: func(c *Config) { c.SendTxWeight, c.FunderPK = 1, "0x01" }, valid: true},
+		{name: "unknown target", modify: func(c *Config) { c.Target = "enclave" }},
+		{name: "host without host address", modify: func(c *Config) { c.Target = HostTarget }},
+		{name: "host subscriptions without websocket address", modify: func(c *Config) { c.Target, c.HostHTTP = HostTarget, "http://127.0.0.1:80" }},
+		{name: "host", modify: func(c *Config) {
+			c.Target, c.HostHTTP, c.HostWS = HostTarget, "http://127.0.0.1:80", "ws://127.0.0.1:81"
+		}, valid: true},
+		{name: "no request type", modify: func(c *Config) { c.CallWeight = 0 }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := validCfg()
+			tt.modify(cfg)
+			err := cfg.Validate()
+			if tt.valid {
+				require.NoError(t, err)
+			} else {
+				require.Error(t, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,370 @@
+package loadgen
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/ethclient"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/retry"
+	"github.com/ten-protocol/go-ten/go/obsclient"
+	"github.com/ten-protocol/go-ten/go/wallet"
+	"github.com/ten-protocol/go-ten/tools/walletextension/lib"
+)
+
+// The request types in the generated mix
+const (
+	SendTxRequest    = "sendRawTransaction"
+	CallRequest      = "call"
+	GetLogsRequest   = "getLogs"
+	SubscribeRequest = "subscribe"
+)
+
+const (
+	requestTimeout   = 30 * time.Second
+	fundingTimeout   = 2 * time.Minute
+	userQueueSize    = 10
+	transferGasLimit = 21_000
+)
+
+// LoadGenerator sends a configurable mix of RPC requests through the gateway or to the host, from a number of
+// concurrent users, at a fixed total rate
+type LoadGenerator struct {
+	cfg      *Config
+	users    []*user
+	gasPrice *big.Int
+	stats    *Stats
+	logger   gethlog.Logger
+}
+
+// user is an account registered with the gateway, or with the host through a viewing key
+type user struct {
+	wallet     wallet.Wallet
+	httpClient rpcClient
+	wsClient   rpcClient
+	requests   chan string
+}
+
+func NewLoadGenerator(cfg *Config, logger gethlog.Logger) *LoadGenerator {
+	return &LoadGenerator{
+		cfg:    cfg,
+		stats:  NewStats(),
+		logger: logger,
+	}
+}
+
+// Run creates and funds the users, then generates load until the configured duration elapses or the context is
+// cancelled. It returns the stats of the run and how long the load was generated for.
+func (g *LoadGenerator) Run(ctx context.Context) (*Stats, time.Duration, error) {
+	if err := g.cfg.Validate(); err != nil {
+		return nil, 0, err
+	}
+	defer g.closeUsers()
+
+	for i := 0; i < g.cfg.Users; i++ {
+		key, err := crypto.GenerateKey()
+		if err != nil {
+			return nil, 0, err
+		}
+		u, err := g.newUser(wallet.NewInMemoryWalletFromPK(g.cfg.ChainID, key, g.logger))
+		if err != nil {
+			return nil, 0, fmt.Errorf("could not create %s user %d - %w", g.cfg.Target, i, err)
+		}
+		g.users = append(g.users, u)
+	}
+	g.logger.Info("Created users", "target", g.cfg.Target, "users", len(g.users))
+
+	if g.cfg.FunderPK != "" {
+		if err := g.fundUsers(ctx); err != nil {
+			return nil, 0, fmt.Errorf("could not fund users - %w", err)
+		}
+	}
+
+	runCtx, cancel := context.WithTimeout(ctx, g.cfg.Duration)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, u := range g.users {
+		for i := 0; i < g.cfg.SubscriptionsPerUser; i++ {
+			g.subscribe(runCtx, u, &wg)
+		}
+		wg.Add(1)
+		go func(u *user) {
+			defer wg.Done()
+			for requestType := range u.requests {
+				g.execute(runCtx, u, requestType)
+			}
+		}(u)
+	}
+
+	start := time.Now()
+	g.generate(runCtx)
+	elapsed := time.Since(start)
+	for _, u := range g.users {
+		close(u.requests)
+	}
+	wg.Wait()
+	return g.stats, elapsed, nil
+}
+
+func (g *LoadGenerator) newUser(w wallet.Wallet) (*user, error) {
+	if g.cfg.Target == HostTarget {
+		return g.newHostUser(w)
+	}
+	return g.newGatewayUser(w)
+}
+
+func (g *LoadGenerator) newGatewayUser(w wallet.Wallet) (*user, error) {
+	tgClient := lib.NewTenGatewayLibrary(g.cfg.GatewayHTTP, g.cfg.GatewayWS)
+	if err := tgClient.Join(); err != nil {
+		return nil, err
+	}
+	if err := tgClient.RegisterAccount(w.PrivateKey(), w.Address()); err != nil {
+		return nil, err
+	}
+
+	u := &user{wallet: w, requests: make(chan string, userQueueSize)}
+	var err error
+	u.httpClient, err = ethclient.Dial(g.cfg.GatewayHTTP + "/v1/?token=" + tgClient.UserID())
+	if err != nil {
+		return nil, err
+	}
+	if g.cfg.SubscriptionsPerUser > 0 {
+		u.wsClient, err = ethclient.Dial(g.cfg.GatewayWS + "/v1/?token=" + tgClient.UserID())
+		if err != nil {
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+func (g *LoadGenerator) newHostUser(w wallet.Wallet) (*user, error) {
+	u := &user{wallet: w, requests: make(chan string, userQueueSize)}
+	var err error
+	u.httpClient, err = g.dialHost(g.cfg.HostHTTP, w)
+	if err != nil {
+		return nil, err
+	}
+	if g.cfg.SubscriptionsPerUser > 0 {
+		u.wsClient, err = g.dialHost(g.cfg.HostWS, w)
+		if err != nil {
+			u.httpClient.Close()
+			return nil, err
+		}
+	}
+	return u, nil
+}
+
+func (g *LoadGenerator) dialHost(address string, w wallet.Wallet) (rpcClient, error) {
+	authClient, err := obsclient.DialWithAuth(address, w, g.logger)
+	if err != nil {
+		return nil, err
+	}
+	client, err := dialHost(address, authClient)
+	if err != nil {
+		authClient.Close()
+		return nil, err
+	}
+	return client, nil
+}
+
+func (g *LoadGenerator) closeUsers() {
+	for _, u := range g.users {
+		u.close()
+	}
+}
+
+func (u *user) close() {
+	u.httpClient.Close()
+	if u.wsClient != nil {
+		u.wsClient.Close()
+	}
+}
+
+// fundUsers transfers the fund amount from the funder to every user, and waits for the transfers to be included
+func (g *LoadGenerator) fundUsers(ctx context.Context) error {
+	funder, err := g.newUser(wallet.NewInMemoryWalletFromConfig(g.cfg.FunderPK, g.cfg.ChainID.Int64(), g.logger))
+	if err != nil {
+		return err
+	}
+	defer funder.close()
+
+	nonce, err := funder.httpClient.PendingNonceAt(ctx, funder.wallet.Address())
+	if err != nil {
+		return err
+	}
+	funder.wallet.SetNonce(nonce)
+	g.gasPrice, err = funder.httpClient.SuggestGasPrice(ctx)
+	if err != nil {
+		return err
+	}
+
+	fundingTxs := make([]gethcommon.Hash, len(g.users))
+	for i, u := range g.users {
+		to := u.wallet.Address()
+		tx, err := funder.wallet.SignTransaction(&types.LegacyTx{
+			Nonce:    funder.wallet.GetNonceAndIncrement(),
+			GasPrice: g.gasPrice,
+			Gas:      transferGasLimit,
+			To:       &to,
+			Value:    g.cfg.FundAmount,
+		})
+		if err != nil {
+			return err
+		}
+		if err = funder.httpClient.SendTransaction(ctx, tx); err != nil {
+			return err
+		}
+		fundingTxs[i] = tx.Hash()
+	}
+
+	for _, txHash := range fundingTxs {
+		err = retry.Do(func() error {
+			receipt, err := funder.httpClient.TransactionReceipt(ctx, txHash)
+			if err != nil {
+				return err
+			}
+			if receipt.Status != types.ReceiptStatusSuccessful {
+				return retry.FailFast(fmt.Errorf("funding transaction %s failed", txHash))
+			}
+			return nil
+		}, retry.NewTimeoutStrategy(fundingTimeout, time.Second))
+		if err != nil {
+			return err
+		}
+	}
+	g.logger.Info("Funded users", "users", len(g.users), "amount", g.cfg.FundAmount)
+	return nil
+}
+
+// generate hands out requests to the users in turn, at the configured rate, with the type picked according to the
+// configured weights
+func (g *LoadGenerator) generate(ctx context.Context) {
+	ticker := time.NewTicker(time.Second / time.Duration(g.cfg.Rate))
+	defer ticker.Stop()
+	rnd := rand.New(rand.NewSource(time.Now().UnixNano())) //nolint:gosec
+
+	for i := 0; ; i++ {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			u := g.users[i%len(g.users)]
+			select {
+			case u.requests <- g.pickRequestType(rnd):
+			default:
+				g.stats.RecordDropped()
+			}
+		}
+	}
+}
+
+func (g *LoadGenerator) pickRequestType(rnd *rand.Rand) string {
+	pick := rnd.Intn(g.cfg.SendTxWeight + g.cfg.CallWeight + g.cfg.GetLogsWeight)
+	switch {
+	case pick < g.cfg.SendTxWeight:
+		return SendTxRequest
+	case pick < g.cfg.SendTxWeight+g.cfg.CallWeight:
+		return CallRequest
+	default:
+		return GetLogsRequest
+	}
+}
+
+func (g *LoadGenerator) execute(ctx context.Context, u *user, requestType string) {
+	if ctx.Err() != nil {
+		return
+	}
+	reqCtx, cancel := context.WithTimeout(ctx, requestTimeout)
+	defer cancel()
+
+	start := time.Now()
+	var err error
+	switch requestType {
+	case SendTxRequest:
+		err = g.sendTransaction(reqCtx, u)
+	case CallRequest:
+		_, err = u.httpClient.CallContract(reqCtx, ethereum.CallMsg{From: u.wallet.Address(), To: g.callTarget(u), Data: g.cfg.CallData}, nil)
+	case GetLogsRequest:
+		_, err = u.httpClient.FilterLogs(reqCtx, g.logsQuery())
+	}
+	g.stats.Record(requestType, time.Since(start), err)
+	if err != nil {
+		g.logger.Debug("Request failed", "type", requestType, log.ErrKey, err)
+	}
+}
+
+// sendTransaction sends a zero-value transfer from the user to itself
+func (g *LoadGenerator) sendTransaction(ctx context.Context, u *user) error {
+	to := u.wallet.Address()
+	tx, err := u.wallet.SignTransaction(&types.LegacyTx{
+		Nonce:    u.wallet.GetNonceAndIncrement(),
+		GasPrice: g.gasPrice,
+		Gas:      transferGasLimit,
+		To:       &to,
+		Value:    big.NewInt(0),
+	})
+	if err != nil {
+		return err
+	}
+	err = u.httpClient.SendTransaction(ctx, tx)
+	if err != nil {
+		// the nonce may not have been consumed, resync it so the following transactions are not stuck
+		if nonce, nonceErr := u.httpClient.PendingNonceAt(ctx, u.wallet.Address()); nonceErr == nil {
+			u.wallet.SetNonce(nonce)
+		}
+	}
+	return err
+}
+
+func (g *LoadGenerator) subscribe(ctx context.Context, u *user, wg *sync.WaitGroup) {
+	logs := make(chan types.Log)
+	start := time.Now()
+	sub, err := u.wsClient.SubscribeFilterLogs(ctx, g.logsQuery(), logs)
+	g.stats.Record(SubscribeRequest, time.Since(start), err)
+	if err != nil {
+		g.logger.Debug("Subscription failed", log.ErrKey, err)
+		return
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		defer sub.Unsubscribe()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-sub.Err():
+				return
+			case <-logs:
+				g.stats.RecordEvent()
+			}
+		}
+	}()
+}
+
+func (g *LoadGenerator) callTarget(u *user) *gethcommon.Address {
+	if g.cfg.CallTo != nil {
+		return g.cfg.CallTo
+	}
+	addr := u.wallet.Address()
+	return &addr
+}
+
+func (g *LoadGenerator) logsQuery() ethereum.FilterQuery {
+	query := ethereum.FilterQuery{}
+	if g.cfg.CallTo != nil {
+		query.Addresses = []gethcommon.Address{*g.cfg.CallTo}
+	}
+	return query
+}
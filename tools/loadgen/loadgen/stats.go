@@ -0,0 +1,126 @@
+package loadgen
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// Stats records the outcome and latency of every request, per request type
+type Stats struct {
+	mu        sync.Mutex
+	latencies map[string][]time.Duration
+	errors    map[string]int
+	limited   map[string]int
+	events    int
+	dropped   int
+}
+
+func NewStats() *Stats {
+	return &Stats{
+		latencies: map[string][]time.Duration{},
+		errors:    map[string]int{},
+		limited:   map[string]int{},
+	}
+}
+
+// Record stores the result of a request of the given type
+func (s *Stats) Record(requestType string, latency time.Duration, err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	switch {
+	case err == nil:
+		s.latencies[requestType] = append(s.latencies[requestType], latency)
+	case isRateLimited(err):
+		s.limited[requestType]++
+	default:
+		s.errors[requestType]++
+	}
+}
+
+// isRateLimited returns true if the request was rejected with a 429 status by the gateway, the host or the
+// infrastructure in front of them
+func isRateLimited(err error) bool {
+	var httpErr gethrpc.HTTPError
+	return errors.As(err, &httpErr) && httpErr.StatusCode == http.StatusTooManyRequests
+}
+
+// RecordEvent counts an event received on a subscription
+func (s *Stats) RecordEvent() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.events++
+}
+
+// RecordDropped counts a request that was not sent because the user was still busy with the previous ones
+func (s *Stats) RecordDropped() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.dropped++
+}
+
+// Percentile returns the latency under which the given percentage of the successful requests of a type completed
+func (s *Stats) Percentile(requestType string, percentage float64) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return percentile(s.latencies[requestType], percentage)
+}
+
+// Report writes a table with the throughput and the latency percentiles of each request type
+func (s *Stats) Report(w io.Writer, elapsed time.Duration) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	types := map[string]bool{}
+	for _, m := range []map[string]int{s.errors, s.limited} {
+		for t := range m {
+			types[t] = true
+		}
+	}
+	for t := range s.latencies {
+		types[t] = true
+	}
+	sortedTypes := make([]string, 0, len(types))
+	for t := range types {
+		sortedTypes = append(sortedTypes, t)
+	}
+	sort.Strings(sortedTypes)
+
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "request\tok\terrors\trate limited\treq/s\tp50\tp90\tp99\tmax")
+	for _, t := range sortedTypes {
+		latencies := s.latencies[t]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\n",
+			t, len(latencies), s.errors[t], s.limited[t], float64(len(latencies))/elapsed.Seconds(),
+			percentile(latencies, 50), percentile(latencies, 90), percentile(latencies, 99), percentile(latencies, 100),
+		)
+	}
+	fmt.Fprintf(tw, "subscription events received: %d\n", s.events)
+	fmt.Fprintf(tw, "requests dropped because the users were saturated: %d\n", s.dropped)
+	return tw.Flush()
+}
+
+// percentile uses the nearest-rank method
+func percentile(latencies []time.Duration, percentage float64) time.Duration {
+	if len(latencies) == 0 {
+		return 0
+	}
+	sorted := append([]time.Duration{}, latencies...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+	rank := int(math.Ceil(percentage/100*float64(len(sorted)))) - 1
+	if rank < 0 {
+		rank = 0
+	}
+	if rank >= len(sorted) {
+		rank = len(sorted) - 1
+	}
+	return sorted[rank]
+}
@@ -0,0 +1,55 @@
+package loadgen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPercentiles(t *testing.T) {
+	stats := NewStats()
+	for i := 1; i <= 100; i++ {
+		stats.Record(CallRequest, time.Duration(i)*time.Millisecond, nil)
+	}
+
+	require.Equal(t, time.Millisecond, stats.Percentile(CallRequest, 0))
+	require.Equal(t, 50*time.Millisecond, stats.Percentile(CallRequest, 50))
+	require.Equal(t, 99*time.Millisecond, stats.Percentile(CallRequest, 99))
+	require.Equal(t, 100*time.Millisecond, stats.Percentile(CallRequest, 100))
+	require.Equal(t, time.Duration(0), stats.Percentile(GetLogsRequest, 50))
+}
+
+func TestReportSeparatesRateLimitedRequests(t *testing.T) {
+	stats := NewStats()
+	stats.Record(SendTxRequest, time.Millisecond, nil)
+	stats.Record(SendTxRequest, 0, gethrpc.HTTPError{StatusCode: http.StatusTooManyRequests, Status: "429 Too Many Requests"})
+	stats.Record(SendTxRequest, 0, errors.New("nonce too low"))
+
+	var out bytes.Buffer
+	require.NoError(t, stats.Report(&out, time.Second))
+	require.Regexp(t, `sendRawTransaction\s+1\s+1\s+1\s+1\.0`, out.String())
+}
+
+func TestIsRateLimited(t *testing.T) {
+	tests := []struct {
+		name    string
+		err     error
+		limited bool
+	}{
+		{name: "429 status", err: gethrpc.HTTPError{StatusCode: http.StatusTooManyRequests}, limited: true},
+		{name: "wrapped 429 status", err: fmt.Errorf("request failed - %w", gethrpc.HTTPError{StatusCode: http.StatusTooManyRequests}), limited: true},
+		{name: "other status", err: gethrpc.HTTPError{StatusCode: http.StatusServiceUnavailable, Body: []byte("429")}},
+		{name: "429 in the message only", err: errors.New("insufficient funds for gas * price + value: balance 429")},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.limited, isRateLimited(tt.err))
+		})
+	}
+}
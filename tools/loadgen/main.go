@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/ten-protocol/go-ten/tools/loadgen/loadgen"
+)
+
+// Tool to generate a realistic RPC load through the TEN gateway, or to the host, and report the latency percentiles per request type.
+//
+// Example:
+//
+//	loadgen -gatewayHTTP https://testnet.obscu.ro -gatewayWS wss://testnet.obscu.ro -funderPK 0x... \
+//	  -sendTxWeight 1 -users 50 -rate 200 -duration 5m
+func main() {
+	cfg := parseCLIArgs()
+
+	logger := gethlog.New()
+	logger.SetHandler(gethlog.LvlFilterHandler(gethlog.LvlInfo, gethlog.StreamHandler(os.Stderr, gethlog.TerminalFormat(false))))
+
+	// stop early on an interrupt, and still report what was measured
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	stats, elapsed, err := loadgen.NewLoadGenerator(cfg, logger).Run(ctx)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "load generation failed - %s\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Generated load for %s with %d users at %d req/s\n\n", elapsed.Round(time.Millisecond), cfg.Users, cfg.Rate)
+	if err = stats.Report(os.Stdout, elapsed); err != nil {
+		fmt.Fprintf(os.Stderr, "could not write report - %s\n", err)
+		os.Exit(1)
+	}
+}
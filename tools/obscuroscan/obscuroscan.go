@@ -523,7 +523,7 @@ func decryptTxBlob(encryptedTxBytesBase64 []byte) ([]byte, error) {
 
 	compressionService := compression.NewBrotliDataCompressionService()
 
-	encodedTxs, err := compressionService.Decompress(compressedTxs)
+	encodedTxs, err := compressionService.Decompress(compressedTxs, compression.DefaultLimits)
 	if err != nil {
 		return nil, fmt.Errorf("could not decompress L2 transactions. Cause: %w", err)
 	}
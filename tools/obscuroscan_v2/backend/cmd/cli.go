@@ -2,31 +2,91 @@ package main
 
 import (
 	"flag"
+	"os"
+	"time"
 
 	"github.com/ten-protocol/go-ten/tools/obscuroscan_v2/backend/config"
+
+	tenconfig "github.com/ten-protocol/go-ten/go/config"
 )
 
-func parseCLIArgs() *config.Config {
+// configVersion is the version of the Obscuroscan config schema, which the config files must be written for
+const configVersion = 1
+
+// configDocsFile is the generated documentation of the Obscuroscan configuration, kept next to the flags
+const configDocsFile = "obscuroscan_config.md"
+
+// parseCLIArgs returns the config set by the flags. The flags that are not passed on the command line can be set by
+// their env var, or else by the TOML file passed with the config flag (see obscuroscan_config.md).
+func parseCLIArgs() (*config.Config, error) {
+	return parseConfig(flag.CommandLine, os.Args[1:])
+}
+
+func parseConfig(fs *flag.FlagSet, args []string) (*config.Config, error) {
 	defaultConfig := &config.Config{
-		NodeHostAddress: "http://erpc.dev-testnet.obscu.ro:80",
-		ServerAddress:   "0.0.0.0:80",
-		LogPath:         "obscuroscan_logs.txt",
+		NodeHostAddress:        "http://erpc.dev-testnet.obscu.ro:80",
+		ServerAddress:          "0.0.0.0:80",
+		LogPath:                "obscuroscan_logs.txt",
+		TerminationGracePeriod: 30 * time.Second,
+		DrainDelay:             5 * time.Second,
+		GatewayAddress:         "",
+		PersonalSessionTTL:     30 * time.Minute,
+		IndexDBPath:            "obscuroscan_index.db",
+		IndexInterval:          10 * time.Second,
+		IndexRetention:         90 * 24 * time.Hour,
+		IndexPruneInterval:     time.Hour,
 	}
 
-	nodeHostAddress := flag.String(nodeHostAddressName, defaultConfig.NodeHostAddress, nodeHostAddressUsage)
-	serverAddress := flag.String(serverAddressName, defaultConfig.ServerAddress, serverAddressUsage)
-	logPath := flag.String(logPathName, defaultConfig.LogPath, logPathUsage)
+	configPath := fs.String(configName, "", configUsage)
+	nodeHostAddress := fs.String(nodeHostAddressName, defaultConfig.NodeHostAddress, nodeHostAddressUsage)
+	serverAddress := fs.String(serverAddressName, defaultConfig.ServerAddress, serverAddressUsage)
+	logPath := fs.String(logPathName, defaultConfig.LogPath, logPathUsage)
+	terminationGracePeriod := fs.Duration(terminationGracePeriodName, defaultConfig.TerminationGracePeriod, terminationGracePeriodUsage)
+	drainDelay := fs.Duration(drainDelayName, defaultConfig.DrainDelay, drainDelayUsage)
+	gatewayAddress := fs.String(gatewayAddressName, defaultConfig.GatewayAddress, gatewayAddressUsage)
+	personalSessionTTL := fs.Duration(personalSessionTTLName, defaultConfig.PersonalSessionTTL, personalSessionTTLUsage)
+	indexDBPath := fs.String(indexDBPathName, defaultConfig.IndexDBPath, indexDBPathUsage)
+	indexInterval := fs.Duration(indexIntervalName, defaultConfig.IndexInterval, indexIntervalUsage)
+	indexRetention := fs.Duration(indexRetentionName, defaultConfig.IndexRetention, indexRetentionUsage)
+	indexPruneInterval := fs.Duration(indexPruneIntervalName, defaultConfig.IndexPruneInterval, indexPruneIntervalUsage)
 
-	flag.Parse()
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+	schema := newSchema(fs)
+	if err := schema.BindEnv(fs); err != nil {
+		return nil, err
+	}
+	if *configPath != "" {
+		if err := schema.BindFile(fs, *configPath); err != nil {
+			return nil, err
+		}
+	}
 
 	return &config.Config{
-		NodeHostAddress: *nodeHostAddress,
-		ServerAddress:   *serverAddress,
-		LogPath:         *logPath,
-	}
+		NodeHostAddress:        *nodeHostAddress,
+		ServerAddress:          *serverAddress,
+		LogPath:                *logPath,
+		TerminationGracePeriod: *terminationGracePeriod,
+		DrainDelay:             *drainDelay,
+		GatewayAddress:         *gatewayAddress,
+		PersonalSessionTTL:     *personalSessionTTL,
+		IndexDBPath:            *indexDBPath,
+		IndexInterval:          *indexInterval,
+		IndexRetention:         *indexRetention,
+		IndexPruneInterval:     *indexPruneInterval,
+	}, nil
+}
+
+// newSchema describes the flags of Obscuroscan, once they are defined on the flag set
+func newSchema(fs *flag.FlagSet) *tenconfig.Schema {
+	return tenconfig.NewFlagSetSchema("obscuroscan", configVersion, "OBSCUROSCAN_", fs)
 }
 
 const (
+	configName  = "config"
+	configUsage = "The path of a TOML file setting the flags that are neither passed on the command line nor set by their env var"
+
 	nodeHostAddressName  = "nodeHostAddress"
 	nodeHostAddressUsage = "The Obscuro Host Node address"
 
@@ -35,4 +95,28 @@ const (
 
 	logPathName  = "logPath"
 	logPathUsage = "The path to use for Obscuroscan's log file"
+
+	terminationGracePeriodName  = "terminationGracePeriod"
+	terminationGracePeriodUsage = "The time Obscuroscan has to drain and stop once terminated. Should match the orchestrator's grace period"
+
+	drainDelayName  = "drainDelay"
+	drainDelayUsage = "The time Obscuroscan keeps serving requests after failing its readiness probe, so load balancers can stop routing to it"
+
+	gatewayAddressName  = "gatewayAddress"
+	gatewayAddressUsage = "The address of the gateway the users can log in through to see their personal activity, e.g. https://testnet.obscu.ro. Login through the gateway is disabled when empty"
+
+	personalSessionTTLName  = "personalSessionTTL"
+	personalSessionTTLUsage = "The inactivity after which the personal sessions expire"
+
+	indexDBPathName  = "indexDBPath"
+	indexDBPathUsage = "The path of the sqlite DB Obscuroscan indexes the batches into"
+
+	indexIntervalName  = "indexInterval"
+	indexIntervalUsage = "How often Obscuroscan indexes the new batches"
+
+	indexRetentionName  = "indexRetention"
+	indexRetentionUsage = "How long the indexed batches are kept. The older ones are folded into daily statistics and deleted. 0 keeps them forever"
+
+	indexPruneIntervalName  = "indexPruneInterval"
+	indexPruneIntervalUsage = "How often the indexed batches older than the retention period are pruned"
 )
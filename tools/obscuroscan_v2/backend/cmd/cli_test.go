@@ -0,0 +1,49 @@
+package main
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+// set to true to regenerate the committed docs when the flags change
+const regenerateDocs = false
+
+func TestConfigIsBoundFromFlagsEnvAndFile(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "obscuroscan.toml")
+	require.NoError(t, os.WriteFile(configPath, []byte(`version = 1
+serverAddress = "0.0.0.0:8080"
+indexRetention = "720h"
+logPath = "file.log"
+`), 0o600))
+	t.Setenv("OBSCUROSCAN_LOGPATH", "env.log")
+
+	cfg, err := parseConfig(flag.NewFlagSet("obscuroscan", flag.ContinueOnError), []string{"-config", configPath, "-drainDelay", "1s"})
+	require.NoError(t, err)
+	require.Equal(t, "0.0.0.0:8080", cfg.ServerAddress)
+	require.Equal(t, 720*time.Hour, cfg.IndexRetention)
+	require.Equal(t, "env.log", cfg.LogPath)
+	require.Equal(t, time.Second, cfg.DrainDelay)
+	require.Equal(t, 30*time.Second, cfg.TerminationGracePeriod)
+
+	require.NoError(t, os.WriteFile(configPath, []byte("version = 1\nindexRetention = \"90 days\"\n"), 0o600))
+	_, err = parseConfig(flag.NewFlagSet("obscuroscan", flag.ContinueOnError), []string{"-config", configPath})
+	require.ErrorContains(t, err, "invalid value for indexRetention")
+}
+
+func TestConfigDocsAreUpToDate(t *testing.T) {
+	fs := flag.NewFlagSet("obscuroscan", flag.ContinueOnError)
+	_, err := parseConfig(fs, nil)
+	require.NoError(t, err)
+	docs := newSchema(fs).Markdown()
+	if regenerateDocs {
+		require.NoError(t, os.WriteFile(configDocsFile, []byte(docs), 0o600))
+	}
+	committed, err := os.ReadFile(configDocsFile)
+	require.NoError(t, err)
+	require.Equal(t, docs, string(committed), "%s is out of date, regenerate it with regenerateDocs", configDocsFile)
+}
@@ -1,7 +1,17 @@
 package config
 
+import "time"
+
 type Config struct {
-	NodeHostAddress string
-	ServerAddress   string
-	LogPath         string
+	NodeHostAddress        string
+	ServerAddress          string
+	LogPath                string
+	TerminationGracePeriod time.Duration
+	DrainDelay             time.Duration
+	GatewayAddress         string        // The gateway the users can log in through, to access their personal data.
+	PersonalSessionTTL     time.Duration // The inactivity after which the personal sessions expire.
+	IndexDBPath            string        // The sqlite DB the batches are indexed into.
+	IndexInterval          time.Duration // How often the new batches are indexed.
+	IndexRetention         time.Duration // How long the indexed batches are kept before being folded into the daily statistics. 0 keeps them forever.
+	IndexPruneInterval     time.Duration // How often the indexed batches older than the retention period are pruned.
 }
@@ -1,13 +1,17 @@
 package container
 
 import (
+	"context"
 	"fmt"
 
+	"github.com/ten-protocol/go-ten/go/common/clock"
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/obsclient"
 	"github.com/ten-protocol/go-ten/go/rpc"
 	"github.com/ten-protocol/go-ten/tools/obscuroscan_v2/backend"
 	"github.com/ten-protocol/go-ten/tools/obscuroscan_v2/backend/config"
+	"github.com/ten-protocol/go-ten/tools/obscuroscan_v2/backend/indexer"
+	"github.com/ten-protocol/go-ten/tools/obscuroscan_v2/backend/personal"
 	"github.com/ten-protocol/go-ten/tools/obscuroscan_v2/backend/webserver"
 
 	gethlog "github.com/ethereum/go-ethereum/log"
@@ -15,6 +19,7 @@ import (
 
 type ObscuroScanContainer struct {
 	backend   *backend.Backend
+	indexer   *indexer.Indexer
 	webServer *webserver.WebServer
 }
 
@@ -26,21 +31,41 @@ func NewObscuroScanContainer(config *config.Config) (*ObscuroScanContainer, erro
 
 	obsClient := obsclient.NewObsClient(client)
 
-	scanBackend := backend.NewBackend(obsClient)
 	logger := log.New(log.ObscuroscanCmp, int(gethlog.LvlInfo), config.LogPath)
+	sessions := personal.NewSessions(client, config.GatewayAddress, config.PersonalSessionTTL, logger)
+	batchIndexer, err := indexer.New(config.IndexDBPath, obsClient, config.IndexRetention, config.IndexInterval, config.IndexPruneInterval, clock.New(), logger)
+	if err != nil {
+		return nil, fmt.Errorf("unable to create the batch indexer - %w", err)
+	}
+	scanBackend := backend.NewBackend(obsClient, sessions, batchIndexer)
 	webServer := webserver.New(scanBackend, config.ServerAddress, logger)
 
 	logger.Info("Created Obscuro Scan with the following: ", "args", config)
 	return &ObscuroScanContainer{
-		backend:   backend.NewBackend(obsClient),
+		backend:   scanBackend,
+		indexer:   batchIndexer,
 		webServer: webServer,
 	}, nil
 }
 
 func (c *ObscuroScanContainer) Start() error {
+	c.indexer.Start()
 	return c.webServer.Start()
 }
 
+// StopReadiness stops reporting the container as ready, so that the load balancer stops routing requests to it
+func (c *ObscuroScanContainer) StopReadiness() {
+	c.webServer.StopReadiness()
+}
+
+// Drain lets the in-flight requests finish and stops accepting new ones
+func (c *ObscuroScanContainer) Drain(ctx context.Context) error {
+	return c.webServer.Drain(ctx)
+}
+
 func (c *ObscuroScanContainer) Stop() error {
-	return c.webServer.Stop()
+	if err := c.webServer.Stop(); err != nil {
+		return err
+	}
+	return c.indexer.Stop()
 }
@@ -0,0 +1,303 @@
+package indexer
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+	"github.com/ten-protocol/go-ten/go/common/log"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	_ "github.com/mattn/go-sqlite3" // this imports the sqlite driver to make the sql.Open() connection work
+)
+
+const (
+	// the batches are indexed in chunks, so that a long backlog does not hold the DB for long
+	_maxBatchesIndexedPerRun = 1000
+	secondsPerDay            = 24 * 60 * 60
+)
+
+const schema = `
+create table if not exists batch
+(
+    number   integer primary key,
+    hash     binary(32) not null,
+    time     integer    not null,
+    tx_count integer    not null
+);
+create index if not exists IDX_BATCH_TIME on batch (time);
+create table if not exists tx
+(
+    hash         binary(32) primary key,
+    batch_number integer not null
+);
+create index if not exists IDX_TX_BATCH on tx (batch_number);
+create table if not exists daily_stats
+(
+    day          integer primary key,
+    batches      integer not null,
+    transactions integer not null
+);
+create table if not exists cursor
+(
+    id   integer primary key check (id = 0),
+    next integer not null
+);
+`
+
+// BatchSource is the node the batches are indexed from
+type BatchSource interface {
+	BatchHeaderByNumber(number *big.Int) (*common.BatchHeader, error)
+	BatchByHash(hash common.L2BatchHash) (*common.ExtBatch, error)
+}
+
+// DailyStats are the number of batches and transactions of a UTC day
+type DailyStats struct {
+	Day          time.Time `json:"day"`
+	Batches      uint64    `json:"batches"`
+	Transactions uint64    `json:"transactions"`
+}
+
+// Indexer copies the batches of the network and their transaction hashes into the DB of Obscuroscan, and prunes the
+// rows older than the retention period in the background, so that long-running explorer deployments don't grow their
+// DB unboundedly. The rows are folded into daily statistics before they are deleted, and the statistics are kept forever.
+type Indexer struct {
+	db            *sql.DB
+	source        BatchSource
+	retention     time.Duration // 0 keeps the rows forever
+	indexInterval time.Duration
+	pruneInterval time.Duration
+	clock         clock.Clock
+
+	stopCh chan struct{}
+	wg     sync.WaitGroup
+
+	lastErr      error // the error of the last indexing or pruning run, if it failed
+	lastErrMutex sync.RWMutex
+
+	logger gethlog.Logger
+}
+
+func New(dbPath string, source BatchSource, retention, indexInterval, pruneInterval time.Duration, clk clock.Clock, logger gethlog.Logger) (*Indexer, error) {
+	db, err := sql.Open("sqlite3", fmt.Sprintf("file:%s?_journal_mode=wal", dbPath))
+	if err != nil {
+		return nil, fmt.Errorf("could not open the index DB - %w", err)
+	}
+	// sqlite fails with table locks when there are multiple connections
+	db.SetMaxOpenConns(1)
+	if _, err := db.Exec(schema); err != nil {
+		return nil, fmt.Errorf("could not initialise the index DB - %w", err)
+	}
+	return &Indexer{
+		db:            db,
+		source:        source,
+		retention:     retention,
+		indexInterval: indexInterval,
+		pruneInterval: pruneInterval,
+		clock:         clk,
+		stopCh:        make(chan struct{}),
+		logger:        logger,
+	}, nil
+}
+
+func (i *Indexer) Start() {
+	i.wg.Add(1)
+	go i.run()
+}
+
+func (i *Indexer) Stop() error {
+	close(i.stopCh)
+	i.wg.Wait()
+	return i.db.Close()
+}
+
+// LastErr returns the error of the last indexing or pruning run, if it failed
+func (i *Indexer) LastErr() error {
+	i.lastErrMutex.RLock()
+	defer i.lastErrMutex.RUnlock()
+	return i.lastErr
+}
+
+func (i *Indexer) run() {
+	defer i.wg.Done()
+	indexTicker := i.clock.NewTicker(i.indexInterval)
+	defer indexTicker.Stop()
+	pruneTicker := i.clock.NewTicker(i.pruneInterval)
+	defer pruneTicker.Stop()
+
+	i.index()
+	i.prune()
+	for {
+		select {
+		case <-i.stopCh:
+			return
+		case <-indexTicker.C():
+			i.index()
+		case <-pruneTicker.C():
+			i.prune()
+		}
+	}
+}
+
+// index copies the batches produced since the last run
+func (i *Indexer) index() {
+	indexed, err := i.indexBatches(_maxBatchesIndexedPerRun)
+	i.setLastErr(err)
+	if err != nil {
+		i.logger.Error("Could not index the batches", log.ErrKey, err)
+		return
+	}
+	if indexed > 0 {
+		i.logger.Debug("Indexed batches", "count", indexed)
+	}
+}
+
+// prune folds the rows older than the retention period into the daily statistics and deletes them
+func (i *Indexer) prune() {
+	if i.retention == 0 {
+		return
+	}
+	cutoff := i.clock.Now().Add(-i.retention)
+	pruned, err := i.pruneBatches(cutoff)
+	i.setLastErr(err)
+	if err != nil {
+		i.logger.Error("Could not prune the indexed batches", log.ErrKey, err)
+		return
+	}
+	if pruned > 0 {
+		i.logger.Info("Pruned the indexed batches older than the retention period", "count", pruned, "cutoff", cutoff)
+	}
+}
+
+// indexBatches copies at most maxBatches batches from the next one to index, and returns how many it copied
+func (i *Indexer) indexBatches(maxBatches int) (int, error) {
+	head, err := i.source.BatchHeaderByNumber(nil)
+	if err != nil {
+		return 0, fmt.Errorf("could not retrieve the head batch - %w", err)
+	}
+	next, err := i.nextBatch()
+	if err != nil {
+		return 0, err
+	}
+
+	indexed := 0
+	for ; indexed < maxBatches && next <= head.Number.Uint64(); next++ {
+		header, err := i.source.BatchHeaderByNumber(new(big.Int).SetUint64(next))
+		if err != nil {
+			return indexed, fmt.Errorf("could not retrieve the batch %d - %w", next, err)
+		}
+		batch, err := i.source.BatchByHash(header.Hash())
+		if err != nil {
+			return indexed, fmt.Errorf("could not retrieve the batch %s - %w", header.Hash(), err)
+		}
+		if err := i.storeBatch(batch, next+1); err != nil {
+			return indexed, fmt.Errorf("could not index the batch %d - %w", next, err)
+		}
+		indexed++
+	}
+	return indexed, nil
+}
+
+// storeBatch stores the batch and its transaction hashes, and moves the cursor to the next batch
+func (i *Indexer) storeBatch(batch *common.ExtBatch, next uint64) error {
+	tx, err := i.db.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	hash := batch.Hash()
+	if _, err := tx.Exec("insert or replace into batch values (?,?,?,?)", batch.Header.Number.Uint64(), hash.Bytes(), batch.Header.Time, len(batch.TxHashes)); err != nil {
+		return err
+	}
+	for _, txHash := range batch.TxHashes {
+		if _, err := tx.Exec("insert or replace into tx values (?,?)", txHash.Bytes(), batch.Header.Number.Uint64()); err != nil {
+			return err
+		}
+	}
+	if _, err := tx.Exec("insert or replace into cursor values (0, ?)", next); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// the number of the next batch to index
+func (i *Indexer) nextBatch() (uint64, error) {
+	var next uint64
+	err := i.db.QueryRow("select next from cursor where id = 0").Scan(&next)
+	if errors.Is(err, sql.ErrNoRows) {
+		return common.L2GenesisHeight, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("could not read the next batch to index - %w", err)
+	}
+	return next, nil
+}
+
+// pruneBatches adds the batches created before the cutoff to the daily statistics, then deletes them and their
+// transactions, in a single DB transaction so that no batch is counted twice or lost. It returns the number of batches
+// that were pruned.
+func (i *Indexer) pruneBatches(cutoff time.Time) (int64, error) {
+	tx, err := i.db.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback() //nolint:errcheck
+
+	_, err = tx.Exec(`insert into daily_stats (day, batches, transactions)
+		select time / ?, count(*), sum(tx_count) from batch where time < ? group by time / ?
+		on conflict (day) do update set batches = batches + excluded.batches, transactions = transactions + excluded.transactions`,
+		secondsPerDay, cutoff.Unix(), secondsPerDay)
+	if err != nil {
+		return 0, fmt.Errorf("could not aggregate the batches - %w", err)
+	}
+	if _, err := tx.Exec("delete from tx where batch_number in (select number from batch where time < ?)", cutoff.Unix()); err != nil {
+		return 0, fmt.Errorf("could not delete the transactions - %w", err)
+	}
+	result, err := tx.Exec("delete from batch where time < ?", cutoff.Unix())
+	if err != nil {
+		return 0, fmt.Errorf("could not delete the batches - %w", err)
+	}
+	pruned, err := result.RowsAffected()
+	if err != nil {
+		return 0, err
+	}
+	return pruned, tx.Commit()
+}
+
+// DailyStats returns the statistics of the days between the two times included, from the pruned and the indexed batches
+func (i *Indexer) DailyStats(from time.Time, to time.Time) ([]DailyStats, error) {
+	rows, err := i.db.Query(`select day, sum(batches), sum(transactions) from (
+			select day, batches, transactions from daily_stats
+			union all
+			select time / ? as day, count(*) as batches, sum(tx_count) as transactions from batch group by time / ?
+		) where day between ? and ? group by day order by day`,
+		secondsPerDay, secondsPerDay, from.Unix()/secondsPerDay, to.Unix()/secondsPerDay)
+	if err != nil {
+		return nil, fmt.Errorf("could not query the daily statistics - %w", err)
+	}
+	defer rows.Close()
+
+	var stats []DailyStats
+	for rows.Next() {
+		var day int64
+		var s DailyStats
+		if err := rows.Scan(&day, &s.Batches, &s.Transactions); err != nil {
+			return nil, err
+		}
+		s.Day = time.Unix(day*secondsPerDay, 0).UTC()
+		stats = append(stats, s)
+	}
+	return stats, rows.Err()
+}
+
+func (i *Indexer) setLastErr(err error) {
+	i.lastErrMutex.Lock()
+	defer i.lastErrMutex.Unlock()
+	i.lastErr = err
+}
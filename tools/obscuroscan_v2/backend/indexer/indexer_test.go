@@ -0,0 +1,160 @@
+package indexer
+
+import (
+	"errors"
+	"math/big"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	day1 = time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	day2 = day1.Add(24 * time.Hour)
+	day3 = day2.Add(24 * time.Hour)
+)
+
+// batchSource serves the batches it was given, by number and by hash
+type batchSource struct {
+	batches []*common.ExtBatch
+}
+
+func (s *batchSource) add(batchTime time.Time, txCount int) {
+	number := int64(common.L2GenesisHeight) + int64(len(s.batches))
+	txHashes := make([]gethcommon.Hash, txCount)
+	for i := range txHashes {
+		txHashes[i] = gethcommon.BigToHash(big.NewInt(number*1000 + int64(i)))
+	}
+	s.batches = append(s.batches, &common.ExtBatch{
+		Header:   &common.BatchHeader{Number: big.NewInt(number), Time: uint64(batchTime.Unix())},
+		TxHashes: txHashes,
+	})
+}
+
+func (s *batchSource) BatchHeaderByNumber(number *big.Int) (*common.BatchHeader, error) {
+	if len(s.batches) == 0 {
+		return nil, errors.New("not found")
+	}
+	if number == nil {
+		return s.batches[len(s.batches)-1].Header, nil
+	}
+	index := number.Uint64() - common.L2GenesisHeight
+	if index >= uint64(len(s.batches)) {
+		return nil, errors.New("not found")
+	}
+	return s.batches[index].Header, nil
+}
+
+func (s *batchSource) BatchByHash(hash common.L2BatchHash) (*common.ExtBatch, error) {
+	for _, batch := range s.batches {
+		if batch.Hash() == hash {
+			return batch, nil
+		}
+	}
+	return nil, errors.New("not found")
+}
+
+func newTestIndexer(t *testing.T, source BatchSource, clk clock.Clock) *Indexer {
+	i, err := New(filepath.Join(t.TempDir(), "index.db"), source, 24*time.Hour, time.Second, time.Minute, clk, gethlog.New())
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = i.db.Close() })
+	return i
+}
+
+func TestIndexesBatchesIncrementally(t *testing.T) {
+	source := &batchSource{}
+	for n := 0; n < 5; n++ {
+		source.add(day1, 2)
+	}
+	i := newTestIndexer(t, source, clock.NewFake(day1))
+
+	indexed, err := i.indexBatches(3)
+	require.NoError(t, err)
+	require.Equal(t, 3, indexed)
+
+	// the indexing resumes from the batch after the last indexed one
+	source.add(day2, 1)
+	indexed, err = i.indexBatches(10)
+	require.NoError(t, err)
+	require.Equal(t, 3, indexed)
+
+	indexed, err = i.indexBatches(10)
+	require.NoError(t, err)
+	require.Equal(t, 0, indexed)
+
+	stats, err := i.DailyStats(day1, day2)
+	require.NoError(t, err)
+	require.Equal(t, []DailyStats{
+		{Day: day1.Truncate(24 * time.Hour), Batches: 5, Transactions: 10},
+		{Day: day2.Truncate(24 * time.Hour), Batches: 1, Transactions: 1},
+	}, stats)
+}
+
+func TestPruningKeepsDailyStats(t *testing.T) {
+	source := &batchSource{}
+	source.add(day1, 1)
+	source.add(day1, 2)
+	source.add(day2, 3)
+	source.add(day3, 4)
+	i := newTestIndexer(t, source, clock.NewFake(day3))
+
+	_, err := i.indexBatches(10)
+	require.NoError(t, err)
+
+	// pruning twice neither counts the batches twice nor loses them
+	pruned, err := i.pruneBatches(day2.Add(time.Second))
+	require.NoError(t, err)
+	require.EqualValues(t, 3, pruned)
+	pruned, err = i.pruneBatches(day2.Add(time.Second))
+	require.NoError(t, err)
+	require.EqualValues(t, 0, pruned)
+
+	var batches, txs int
+	require.NoError(t, i.db.QueryRow("select count(*) from batch").Scan(&batches))
+	require.NoError(t, i.db.QueryRow("select count(*) from tx").Scan(&txs))
+	require.Equal(t, 1, batches)
+	require.Equal(t, 4, txs)
+
+	stats, err := i.DailyStats(day1, day3)
+	require.NoError(t, err)
+	require.Equal(t, []DailyStats{
+		{Day: day1.Truncate(24 * time.Hour), Batches: 2, Transactions: 3},
+		{Day: day2.Truncate(24 * time.Hour), Batches: 1, Transactions: 3},
+		{Day: day3.Truncate(24 * time.Hour), Batches: 1, Transactions: 4},
+	}, stats)
+}
+
+func TestIndexerPrunesInBackground(t *testing.T) {
+	source := &batchSource{}
+	source.add(day1, 1)
+	clk := clock.NewFake(day1)
+	i := newTestIndexer(t, source, clk)
+
+	i.Start()
+	defer i.wg.Wait()
+	defer close(i.stopCh)
+
+	require.Eventually(t, func() bool {
+		stats, err := i.DailyStats(day1, day1)
+		return err == nil && len(stats) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+
+	// the batch is pruned once it is older than the retention period, and only its statistics remain
+	clk.Advance(48 * time.Hour)
+	require.Eventually(t, func() bool {
+		var batches int
+		return i.db.QueryRow("select count(*) from batch").Scan(&batches) == nil && batches == 0
+	}, 5*time.Second, 10*time.Millisecond)
+	require.NoError(t, i.LastErr())
+
+	stats, err := i.DailyStats(day1, day1)
+	require.NoError(t, err)
+	require.Equal(t, []DailyStats{{Day: day1.Truncate(24 * time.Hour), Batches: 1, Transactions: 1}}, stats)
+}
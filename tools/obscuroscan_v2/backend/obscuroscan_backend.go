@@ -5,6 +5,7 @@ import (
 	"crypto/cipher"
 	"encoding/base64"
 	"fmt"
+	"time"
 
 	"github.com/ethereum/go-ethereum/rlp"
 	"github.com/ten-protocol/go-ten/go/common/compression"
@@ -12,17 +13,23 @@ import (
 
 	"github.com/ten-protocol/go-ten/go/common"
 	"github.com/ten-protocol/go-ten/go/obsclient"
+	"github.com/ten-protocol/go-ten/tools/obscuroscan_v2/backend/indexer"
+	"github.com/ten-protocol/go-ten/tools/obscuroscan_v2/backend/personal"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 )
 
 type Backend struct {
 	obsClient *obsclient.ObsClient
+	sessions  *personal.Sessions
+	indexer   *indexer.Indexer
 }
 
-func NewBackend(obsClient *obsclient.ObsClient) *Backend {
+func NewBackend(obsClient *obsclient.ObsClient, sessions *personal.Sessions, batchIndexer *indexer.Indexer) *Backend {
 	return &Backend{
 		obsClient: obsClient,
+		sessions:  sessions,
+		indexer:   batchIndexer,
 	}
 }
 
@@ -47,6 +54,12 @@ func (b *Backend) GetTotalTransactionCount() (int, error) {
 	return b.obsClient.GetTotalTransactionCount()
 }
 
+// GetDailyStats returns the number of batches and transactions of each day between the two times, including the days
+// whose batches were already pruned from the index
+func (b *Backend) GetDailyStats(from time.Time, to time.Time) ([]indexer.DailyStats, error) {
+	return b.indexer.DailyStats(from, to)
+}
+
 func (b *Backend) GetLatestRollupHeader() (*common.RollupHeader, error) {
 	return b.obsClient.GetLatestRollupHeader()
 }
@@ -110,7 +123,7 @@ func (b *Backend) DecryptTxBlob(payload string) ([]*common.L2Tx, error) {
 
 	compressionService := compression.NewBrotliDataCompressionService()
 
-	encodedTxs, err := compressionService.Decompress(compressedTxs)
+	encodedTxs, err := compressionService.Decompress(compressedTxs, compression.DefaultLimits)
 	if err != nil {
 		return nil, fmt.Errorf("could not decompress L2 transactions. Cause: %w", err)
 	}
@@ -126,3 +139,8 @@ func (b *Backend) DecryptTxBlob(payload string) ([]*common.L2Tx, error) {
 func (b *Backend) GetConfig() (*common.ObscuroNetworkInfo, error) {
 	return b.obsClient.GetConfig()
 }
+
+// Personal returns the sessions of the users browsing their personal activity
+func (b *Backend) Personal() *personal.Sessions {
+	return b.sessions
+}
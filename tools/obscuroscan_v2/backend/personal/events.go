@@ -0,0 +1,68 @@
+package personal
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// DecodedEvent is an event log, with its name and arguments when it is a standard token event
+type DecodedEvent struct {
+	Log  *types.Log             `json:"log"`
+	Name string                 `json:"name,omitempty"`
+	Args map[string]interface{} `json:"args,omitempty"`
+}
+
+// the standard events of the ERC-20 and ERC-721 tokens, which share the same signatures. The ERC-721 events index
+// their last argument.
+type standardEvent struct {
+	name     string
+	argNames []string
+}
+
+var standardEvents = map[gethcommon.Hash]standardEvent{
+	crypto.Keccak256Hash([]byte("Transfer(address,address,uint256)")):    {name: "Transfer", argNames: []string{"from", "to", "value"}},
+	crypto.Keccak256Hash([]byte("Approval(address,address,uint256)")):    {name: "Approval", argNames: []string{"owner", "spender", "value"}},
+	crypto.Keccak256Hash([]byte("ApprovalForAll(address,address,bool)")): {name: "ApprovalForAll", argNames: []string{"owner", "operator", "approved"}},
+}
+
+func decodeEvent(l *types.Log) *DecodedEvent {
+	event := &DecodedEvent{Log: l}
+	if len(l.Topics) == 0 {
+		return event
+	}
+	standard, found := standardEvents[l.Topics[0]]
+	if !found {
+		return event
+	}
+
+	// the two addresses are always indexed, the last argument is either indexed or the only data word
+	var last []byte
+	switch {
+	case len(l.Topics) == 4 && len(l.Data) == 0:
+		last = l.Topics[3].Bytes()
+	case len(l.Topics) == 3 && len(l.Data) == 32:
+		last = l.Data
+	default:
+		return event
+	}
+
+	args := map[string]interface{}{
+		standard.argNames[0]: gethcommon.BytesToAddress(l.Topics[1].Bytes()),
+		standard.argNames[1]: gethcommon.BytesToAddress(l.Topics[2].Bytes()),
+	}
+	switch {
+	case standard.name == "ApprovalForAll":
+		args[standard.argNames[2]] = new(big.Int).SetBytes(last).Sign() != 0
+	case len(l.Topics) == 4:
+		args["tokenId"] = new(big.Int).SetBytes(last)
+	default:
+		args[standard.argNames[2]] = new(big.Int).SetBytes(last)
+	}
+	event.Name = standard.name
+	event.Args = args
+	return event
+}
@@ -0,0 +1,265 @@
+package personal
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/crypto/ecies"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
+	"github.com/ten-protocol/go-ten/go/common/viewingkey"
+	"github.com/ten-protocol/go-ten/go/rpc"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+const (
+	// the viewing keys awaiting a signature are dropped after this time
+	pendingViewingKeyTTL = 5 * time.Minute
+	// bounds the memory used by the sessions and pending viewing keys
+	maxSessions   = 10_000
+	loginTimeout  = 10 * time.Second
+	tokenByteSize = 32
+)
+
+var (
+	ErrUnknownSession     = errors.New("unknown or expired session")
+	ErrTooManySessions    = errors.New("too many sessions, try again later")
+	ErrGatewayUnavailable = errors.New("login through the gateway is not configured")
+)
+
+// Sessions holds the personal sessions of the users of obscuroscan. A session gives access to the private data of an
+// account, through a viewing key registered by the user or through the gateway the user joined.
+// Everything is held in memory and dropped with the session: the viewing keys and decrypted data are never persisted.
+type Sessions struct {
+	nodeClient     rpc.Client
+	gatewayAddress string
+	ttl            time.Duration
+	logger         gethlog.Logger
+
+	lock        sync.Mutex
+	pendingVKs  map[gethcommon.Address]*pendingViewingKey
+	sessions    map[string]*Session
+	dialGateway func(address string) (rpc.Client, error)
+}
+
+type pendingViewingKey struct {
+	vk     *viewingkey.ViewingKey
+	expiry time.Time
+}
+
+func NewSessions(nodeClient rpc.Client, gatewayAddress string, ttl time.Duration, logger gethlog.Logger) *Sessions {
+	return &Sessions{
+		nodeClient:     nodeClient,
+		gatewayAddress: gatewayAddress,
+		ttl:            ttl,
+		logger:         logger,
+		pendingVKs:     map[gethcommon.Address]*pendingViewingKey{},
+		sessions:       map[string]*Session{},
+		dialGateway:    rpc.NewNetworkClient,
+	}
+}
+
+// GenerateViewingKey generates a viewing key for the account, and returns its public key for the user to sign
+func (s *Sessions) GenerateViewingKey(address gethcommon.Address) (string, error) {
+	viewingKeyPrivate, err := crypto.GenerateKey()
+	if err != nil {
+		return "", fmt.Errorf("unable to generate a new keypair - %w", err)
+	}
+	viewingPublicKeyBytes := crypto.CompressPubkey(&viewingKeyPrivate.PublicKey)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.pruneExpired()
+	if len(s.pendingVKs)+len(s.sessions) >= maxSessions {
+		return "", ErrTooManySessions
+	}
+	s.pendingVKs[address] = &pendingViewingKey{
+		vk: &viewingkey.ViewingKey{
+			Account:    &address,
+			PrivateKey: ecies.ImportECDSA(viewingKeyPrivate),
+			PublicKey:  viewingPublicKeyBytes,
+		},
+		expiry: time.Now().Add(pendingViewingKeyTTL),
+	}
+	return hex.EncodeToString(viewingPublicKeyBytes), nil
+}
+
+// Login opens a session with the viewing key of the account, once signed by the user, and returns the session token
+func (s *Sessions) Login(address gethcommon.Address, signature []byte) (string, error) {
+	s.lock.Lock()
+	pending, found := s.pendingVKs[address]
+	delete(s.pendingVKs, address)
+	s.lock.Unlock()
+	if !found || time.Now().After(pending.expiry) {
+		return "", fmt.Errorf("no viewing key to sign for account %s, a viewing key must be generated first", address)
+	}
+	if len(signature) != crypto.SignatureLength {
+		return "", fmt.Errorf("invalid signature length %d", len(signature))
+	}
+
+	// the V of the signatures produced by the wallets is 27/28, see walletextension.SubmitViewingKey
+	vk := pending.vk
+	vk.Signature = gethcommon.CopyBytes(signature)
+	vk.Signature[64] -= 27
+
+	client, err := rpc.NewEncRPCClient(s.nodeClient, vk, s.logger)
+	if err != nil {
+		return "", fmt.Errorf("could not create the encrypted client - %w", err)
+	}
+	return s.open(&Session{address: address, client: client})
+}
+
+// LoginWithGateway opens a session with the viewing key the account registered for the user ID of the gateway, and
+// returns the session token
+func (s *Sessions) LoginWithGateway(address gethcommon.Address, userID string) (string, error) {
+	if s.gatewayAddress == "" {
+		return "", ErrGatewayUnavailable
+	}
+	if _, err := tentypes.ParseViewingKeyID(userID); err != nil {
+		return "", fmt.Errorf("invalid user ID - %w", err)
+	}
+	client, err := s.dialGateway(fmt.Sprintf("%s/v1/?u=%s", s.gatewayAddress, userID))
+	if err != nil {
+		return "", fmt.Errorf("could not connect to the gateway - %w", err)
+	}
+	return s.open(&Session{address: address, client: client, ownsClient: true})
+}
+
+// Get returns the session of the token, whose expiry is extended
+func (s *Sessions) Get(token string) (*Session, error) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	session, found := s.sessions[token]
+	if !found || time.Now().After(session.expiry) {
+		return nil, ErrUnknownSession
+	}
+	session.expiry = time.Now().Add(s.ttl)
+	return session, nil
+}
+
+// Logout closes the session of the token
+func (s *Sessions) Logout(token string) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if session, found := s.sessions[token]; found {
+		session.close()
+		delete(s.sessions, token)
+	}
+}
+
+// checks that the client gives access to the account before opening the session
+func (s *Sessions) open(session *Session) (string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), loginTimeout)
+	defer cancel()
+	if _, err := session.Balance(ctx); err != nil {
+		session.close()
+		return "", fmt.Errorf("could not access the account %s - %w", session.address, err)
+	}
+
+	tokenBytes := make([]byte, tokenByteSize)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		session.close()
+		return "", fmt.Errorf("could not generate the session token - %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.pruneExpired()
+	if len(s.pendingVKs)+len(s.sessions) >= maxSessions {
+		session.close()
+		return "", ErrTooManySessions
+	}
+	session.expiry = time.Now().Add(s.ttl)
+	s.sessions[token] = session
+	return token, nil
+}
+
+// must be called with the lock held
+func (s *Sessions) pruneExpired() {
+	now := time.Now()
+	for address, pending := range s.pendingVKs {
+		if now.After(pending.expiry) {
+			delete(s.pendingVKs, address)
+		}
+	}
+	for token, session := range s.sessions {
+		if now.After(session.expiry) {
+			session.close()
+			delete(s.sessions, token)
+		}
+	}
+}
+
+// Session gives access to the private data of an account. The queries are proxied to the node, and their results are
+// returned to the user without being stored.
+type Session struct {
+	address    gethcommon.Address
+	client     rpc.Client
+	ownsClient bool      // the encrypted clients share the connection to the node, which must stay open
+	expiry     time.Time // guarded by the lock of the sessions
+}
+
+func (s *Session) close() {
+	if s.ownsClient {
+		s.client.Stop()
+	}
+}
+
+func (s *Session) Address() gethcommon.Address {
+	return s.address
+}
+
+// Balance returns the balance of the account at the head batch
+func (s *Session) Balance(ctx context.Context) (*big.Int, error) {
+	var balance hexutil.Big
+	if err := s.client.CallContext(ctx, &balance, rpc.GetBalance, s.address, "latest"); err != nil {
+		return nil, err
+	}
+	return balance.ToInt(), nil
+}
+
+// Transactions returns a page of the receipts of the transactions of the account
+func (s *Session) Transactions(ctx context.Context, pagination *common.QueryPagination) (*common.PrivateQueryResponse, error) {
+	query := common.PrivateCustomQueryListTransactions{Address: s.address, Pagination: *pagination}
+	var result common.PrivateQueryResponse
+	if err := s.client.CallContext(ctx, &result, rpc.GetStorageAt, common.ListPersonalTransactionsCQMethod, query, nil); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
+// Events returns the events visible to the account emitted in the batches between the heights, decoded when they are
+// standard token events
+func (s *Session) Events(ctx context.Context, fromBatch, toBatch *big.Int) ([]*DecodedEvent, error) {
+	filter := common.FilterCriteriaJSON{}
+	if fromBatch != nil {
+		from := gethrpc.BlockNumber(fromBatch.Int64())
+		filter.FromBlock = &from
+	}
+	if toBatch != nil {
+		to := gethrpc.BlockNumber(toBatch.Int64())
+		filter.ToBlock = &to
+	}
+	var logs []*types.Log
+	if err := s.client.CallContext(ctx, &logs, rpc.GetLogs, filter, s.address); err != nil {
+		return nil, err
+	}
+	events := make([]*DecodedEvent, len(logs))
+	for i, l := range logs {
+		events[i] = decodeEvent(l)
+	}
+	return events, nil
+}
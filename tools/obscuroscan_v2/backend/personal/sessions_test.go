@@ -0,0 +1,143 @@
+package personal
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/rpc"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+var (
+	testAccount = gethcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	testUserID  = strings.Repeat("ab", 20)
+)
+
+// gatewayClient serves the balance of the accounts registered with the gateway
+type gatewayClient struct {
+	accounts map[gethcommon.Address]*big.Int
+	stopped  bool
+}
+
+func (c *gatewayClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *gatewayClient) CallContext(_ context.Context, result interface{}, method string, args ...interface{}) error {
+	if method != rpc.GetBalance {
+		return errors.New("unexpected method")
+	}
+	balance, found := c.accounts[args[0].(gethcommon.Address)]
+	if !found {
+		return errors.New("no viewing key for the account")
+	}
+	*result.(*hexutil.Big) = hexutil.Big(*balance)
+	return nil
+}
+
+func (c *gatewayClient) Subscribe(context.Context, interface{}, string, interface{}, ...interface{}) (*gethrpc.ClientSubscription, error) {
+	return nil, errors.New("unexpected subscription")
+}
+
+func (c *gatewayClient) Stop() {
+	c.stopped = true
+}
+
+func newTestSessions(ttl time.Duration, gateway *gatewayClient) *Sessions {
+	sessions := NewSessions(nil, "http://gateway", ttl, gethlog.New())
+	sessions.dialGateway = func(string) (rpc.Client, error) {
+		return gateway, nil
+	}
+	return sessions
+}
+
+func TestLoginWithGateway(t *testing.T) {
+	gateway := &gatewayClient{accounts: map[gethcommon.Address]*big.Int{testAccount: big.NewInt(42)}}
+	sessions := newTestSessions(time.Hour, gateway)
+
+	_, err := sessions.LoginWithGateway(gethcommon.HexToAddress("0x2"), testUserID)
+	require.ErrorContains(t, err, "could not access the account")
+	require.True(t, gateway.stopped)
+
+	gateway.stopped = false
+	token, err := sessions.LoginWithGateway(testAccount, testUserID)
+	require.NoError(t, err)
+	session, err := sessions.Get(token)
+	require.NoError(t, err)
+	balance, err := session.Balance(context.Background())
+	require.NoError(t, err)
+	require.Equal(t, big.NewInt(42), balance)
+
+	sessions.Logout(token)
+	require.True(t, gateway.stopped)
+	_, err = sessions.Get(token)
+	require.ErrorIs(t, err, ErrUnknownSession)
+}
+
+func TestSessionsExpire(t *testing.T) {
+	gateway := &gatewayClient{accounts: map[gethcommon.Address]*big.Int{testAccount: big.NewInt(42)}}
+	sessions := newTestSessions(time.Millisecond, gateway)
+
+	token, err := sessions.LoginWithGateway(testAccount, testUserID)
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	_, err = sessions.Get(token)
+	require.ErrorIs(t, err, ErrUnknownSession)
+}
+
+func TestLoginRequiresAGeneratedViewingKey(t *testing.T) {
+	sessions := newTestSessions(time.Hour, nil)
+	_, err := sessions.Login(testAccount, make([]byte, 65))
+	require.ErrorContains(t, err, "a viewing key must be generated first")
+
+	viewingKey, err := sessions.GenerateViewingKey(testAccount)
+	require.NoError(t, err)
+	require.Len(t, viewingKey, 66)
+	_, err = sessions.Login(testAccount, make([]byte, 10))
+	require.ErrorContains(t, err, "invalid signature length")
+}
+
+func TestDecodeEvent(t *testing.T) {
+	from := gethcommon.HexToAddress("0x1")
+	to := gethcommon.HexToAddress("0x2")
+	transferID := gethcommon.HexToHash("0xddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef")
+
+	tests := map[string]struct {
+		log  *types.Log
+		name string
+		args map[string]interface{}
+	}{
+		"erc20 transfer": {
+			log:  &types.Log{Topics: []gethcommon.Hash{transferID, from.Hash(), to.Hash()}, Data: gethcommon.BigToHash(big.NewInt(5)).Bytes()},
+			name: "Transfer",
+			args: map[string]interface{}{"from": from, "to": to, "value": big.NewInt(5)},
+		},
+		"erc721 transfer": {
+			log:  &types.Log{Topics: []gethcommon.Hash{transferID, from.Hash(), to.Hash(), gethcommon.BigToHash(big.NewInt(7))}},
+			name: "Transfer",
+			args: map[string]interface{}{"from": from, "to": to, "tokenId": big.NewInt(7)},
+		},
+		"custom event": {
+			log: &types.Log{Topics: []gethcommon.Hash{gethcommon.HexToHash("0x1234")}},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			event := decodeEvent(test.log)
+			require.Equal(t, test.log, event.Log)
+			require.Equal(t, test.name, event.Name)
+			require.Equal(t, test.args, event.Args)
+		})
+	}
+}
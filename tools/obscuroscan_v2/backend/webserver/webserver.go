@@ -5,11 +5,13 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/log"
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/ten-protocol/go-ten/go/common/container"
 	"github.com/ten-protocol/go-ten/tools/obscuroscan_v2/backend"
 )
 
@@ -19,6 +21,7 @@ type WebServer struct {
 	bindAddress string
 	logger      log.Logger
 	server      *http.Server
+	draining    atomic.Bool // set once the server is terminating, so that it is no longer reported as ready
 }
 
 func New(backend *backend.Backend, bindAddress string, logger log.Logger) *WebServer {
@@ -43,9 +46,12 @@ func New(backend *backend.Backend, bindAddress string, logger log.Logger) *WebSe
 	// routes
 	routeItems(r, server)
 	routeCounts(r, server)
+	routePersonal(r, server)
 
 	// todo group/format these into items, counts, actions
 	r.GET("/health/", server.health)
+	r.GET(container.LivenessPath, server.live)
+	r.GET(container.ReadinessPath, server.ready)
 	r.GET("/batchHeader/:hash", server.getBatchHeader)
 	r.GET("/tx/:hash", server.getTransaction)
 	r.POST("/actions/decryptTxBlob/", server.decryptTxBlob)
@@ -83,10 +89,40 @@ func (w *WebServer) Stop() error {
 	return w.server.Shutdown(ctx)
 }
 
+// StopReadiness fails the readiness probe, the server keeps serving requests
+func (w *WebServer) StopReadiness() {
+	w.draining.Store(true)
+}
+
+// Drain gracefully shuts down the server: it stops accepting connections and waits for the in-flight requests
+func (w *WebServer) Drain(ctx context.Context) error {
+	if w.server == nil {
+		return nil
+	}
+	return w.server.Shutdown(ctx)
+}
+
 func (w *WebServer) health(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"healthy": true})
 }
 
+func (w *WebServer) live(c *gin.Context) {
+	c.String(http.StatusOK, "ok")
+}
+
+func (w *WebServer) ready(c *gin.Context) {
+	if w.draining.Load() {
+		c.String(http.StatusServiceUnavailable, "draining")
+		return
+	}
+	// obscuroscan can only serve requests if the node it reads from is reachable
+	if _, err := w.backend.GetLatestBatch(); err != nil {
+		c.String(http.StatusServiceUnavailable, fmt.Sprintf("node unavailable: %s", err))
+		return
+	}
+	c.String(http.StatusOK, "ok")
+}
+
 func (w *WebServer) decryptTxBlob(c *gin.Context) {
 	// Read the payload as a string
 	payloadBytes, err := c.GetRawData()
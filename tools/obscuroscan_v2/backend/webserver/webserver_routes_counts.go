@@ -3,6 +3,8 @@ package webserver
 import (
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -10,6 +12,7 @@ import (
 func routeCounts(r *gin.Engine, server *WebServer) {
 	r.GET("/count/contracts/", server.getTotalContractCount)
 	r.GET("/count/transactions/", server.getTotalTransactionCount)
+	r.GET("/count/daily/", server.getDailyStats)
 }
 
 func (w *WebServer) getTotalContractCount(c *gin.Context) {
@@ -31,3 +34,28 @@ func (w *WebServer) getTotalTransactionCount(c *gin.Context) {
 
 	c.JSON(http.StatusOK, gin.H{"count": count})
 }
+
+func (w *WebServer) getDailyStats(c *gin.Context) {
+	fromStr := c.DefaultQuery("from", "0")
+	toStr := c.DefaultQuery("to", strconv.FormatInt(time.Now().Unix(), 10))
+
+	fromSecs, err := strconv.ParseInt(fromStr, 10, 64)
+	if err != nil {
+		errorHandler(c, fmt.Errorf("unable to execute request %w", err), w.logger)
+		return
+	}
+
+	toSecs, err := strconv.ParseInt(toStr, 10, 64)
+	if err != nil {
+		errorHandler(c, fmt.Errorf("unable to execute request %w", err), w.logger)
+		return
+	}
+
+	stats, err := w.backend.GetDailyStats(time.Unix(fromSecs, 0), time.Unix(toSecs, 0))
+	if err != nil {
+		errorHandler(c, fmt.Errorf("unable to execute request %w", err), w.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": stats})
+}
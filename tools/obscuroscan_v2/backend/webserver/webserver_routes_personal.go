@@ -0,0 +1,179 @@
+package webserver
+
+import (
+	"fmt"
+	"math/big"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/gin-gonic/gin"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/tools/obscuroscan_v2/backend/personal"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+const bearerPrefix = "Bearer "
+
+// the personal routes serve the private data of the logged-in users, who authenticate with the token of their session
+func routePersonal(r *gin.Engine, server *WebServer) {
+	r.POST("/personal/viewingkey/", server.generateViewingKey)
+	r.POST("/personal/login/", server.login)
+	r.POST("/personal/login/gateway/", server.loginWithGateway)
+	r.POST("/personal/logout/", server.logout)
+	r.GET("/personal/balance/", server.getPersonalBalance)
+	r.GET("/personal/transactions/", server.getPersonalTransactions)
+	r.GET("/personal/events/", server.getPersonalEvents)
+}
+
+type LoginData struct {
+	Address   string `json:"address"`
+	Signature string `json:"signature"`
+	UserID    string `json:"userID"`
+}
+
+func (w *WebServer) generateViewingKey(c *gin.Context) {
+	var data LoginData
+	if err := c.ShouldBindJSON(&data); err != nil || !gethcommon.IsHexAddress(data.Address) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a valid address is required"})
+		return
+	}
+
+	viewingKey, err := w.backend.Personal().GenerateViewingKey(gethcommon.HexToAddress(data.Address))
+	if err != nil {
+		errorHandler(c, fmt.Errorf("unable to execute request %w", err), w.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"viewingKey": viewingKey})
+}
+
+func (w *WebServer) login(c *gin.Context) {
+	var data LoginData
+	if err := c.ShouldBindJSON(&data); err != nil || !gethcommon.IsHexAddress(data.Address) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a valid address is required"})
+		return
+	}
+	signature, err := hexutil.Decode(data.Signature)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "the signature must be hex-encoded"})
+		return
+	}
+
+	token, err := w.backend.Personal().Login(gethcommon.HexToAddress(data.Address), signature)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token})
+}
+
+func (w *WebServer) loginWithGateway(c *gin.Context) {
+	var data LoginData
+	if err := c.ShouldBindJSON(&data); err != nil || !gethcommon.IsHexAddress(data.Address) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "a valid address is required"})
+		return
+	}
+
+	token, err := w.backend.Personal().LoginWithGateway(gethcommon.HexToAddress(data.Address), data.UserID)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"token": token})
+}
+
+func (w *WebServer) logout(c *gin.Context) {
+	w.backend.Personal().Logout(sessionToken(c))
+	c.JSON(http.StatusOK, gin.H{})
+}
+
+func (w *WebServer) getPersonalBalance(c *gin.Context) {
+	session, ok := w.session(c)
+	if !ok {
+		return
+	}
+
+	balance, err := session.Balance(c.Request.Context())
+	if err != nil {
+		errorHandler(c, fmt.Errorf("unable to execute request %w", err), w.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"item": (*hexutil.Big)(balance)})
+}
+
+func (w *WebServer) getPersonalTransactions(c *gin.Context) {
+	session, ok := w.session(c)
+	if !ok {
+		return
+	}
+
+	offset, err := strconv.ParseUint(c.DefaultQuery("offset", "0"), 10, 32)
+	if err != nil {
+		errorHandler(c, fmt.Errorf("unable to execute request %w", err), w.logger)
+		return
+	}
+	size, err := strconv.ParseUint(c.DefaultQuery("size", "10"), 10, 64)
+	if err != nil {
+		errorHandler(c, fmt.Errorf("unable to execute request %w", err), w.logger)
+		return
+	}
+
+	txs, err := session.Transactions(c.Request.Context(), &common.QueryPagination{Offset: offset, Size: uint(size)})
+	if err != nil {
+		errorHandler(c, fmt.Errorf("unable to execute request %w", err), w.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": txs})
+}
+
+func (w *WebServer) getPersonalEvents(c *gin.Context) {
+	session, ok := w.session(c)
+	if !ok {
+		return
+	}
+
+	var batchRange [2]*big.Int
+	for i, param := range []string{"fromBatch", "toBatch"} {
+		value := c.Query(param)
+		if value == "" {
+			continue
+		}
+		height, err := strconv.ParseUint(value, 10, 63)
+		if err != nil {
+			errorHandler(c, fmt.Errorf("unable to execute request %w", err), w.logger)
+			return
+		}
+		batchRange[i] = new(big.Int).SetUint64(height)
+	}
+
+	events, err := session.Events(c.Request.Context(), batchRange[0], batchRange[1])
+	if err != nil {
+		errorHandler(c, fmt.Errorf("unable to execute request %w", err), w.logger)
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"result": events})
+}
+
+// returns the session of the request, or aborts it when the session is unknown
+func (w *WebServer) session(c *gin.Context) (*personal.Session, bool) {
+	session, err := w.backend.Personal().Get(sessionToken(c))
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return nil, false
+	}
+	// the responses hold private data, which must not be cached on the way
+	c.Header("Cache-Control", "no-store")
+	return session, true
+}
+
+func sessionToken(c *gin.Context) string {
+	return strings.TrimPrefix(c.GetHeader("Authorization"), bearerPrefix)
+}
@@ -0,0 +1,80 @@
+package webserver
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/container"
+)
+
+func freeAddress(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	defer listener.Close()
+	return listener.Addr().String()
+}
+
+func TestDrainCompletesInFlightRequests(t *testing.T) {
+	address := freeAddress(t)
+	w := New(nil, address, gethlog.New())
+	started := make(chan struct{})
+	release := make(chan struct{})
+	w.engine.GET("/slow", func(c *gin.Context) {
+		close(started)
+		<-release
+		c.String(http.StatusOK, "done")
+	})
+	require.NoError(t, w.Start())
+
+	get := func(path string) (int, error) {
+		resp, err := http.Get(fmt.Sprintf("http://%s%s", address, path)) //nolint:noctx
+		if err != nil {
+			return 0, err
+		}
+		defer resp.Body.Close()
+		return resp.StatusCode, nil
+	}
+	require.Eventually(t, func() bool {
+		code, err := get(container.LivenessPath)
+		return err == nil && code == http.StatusOK
+	}, time.Second, 10*time.Millisecond)
+
+	// once the readiness fails, the server still serves requests
+	w.StopReadiness()
+	code, err := get(container.ReadinessPath)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusServiceUnavailable, code)
+	code, err = get(container.LivenessPath)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, code)
+
+	slowCode := make(chan int, 1)
+	go func() {
+		code, _ := get("/slow")
+		slowCode <- code
+	}()
+	<-started
+
+	drained := make(chan error, 1)
+	go func() {
+		drained <- w.Drain(context.Background())
+	}()
+	select {
+	case <-drained:
+		t.Fatal("the server was drained with a request in progress")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	close(release)
+	require.Equal(t, http.StatusOK, <-slowCode)
+	require.NoError(t, <-drained)
+	_, err = get(container.LivenessPath)
+	require.Error(t, err)
+}
@@ -199,6 +199,29 @@ func (m *AccountManager) suggestAccountClient(req *wecommon.RPCRequest, accClien
 		}
 	}
 
+	if isSimulateTransactionQuery(req) {
+		// the transaction to simulate follows the name of the query
+		paramsMap, err := parseParams(req.Params[1:])
+		if err != nil {
+			return nil
+		}
+		fromClient, _ := checkForFromField(paramsMap, accClients)
+		return fromClient
+	}
+
+	if isCustomQuery(req, common.TransactionByNonceCQMethod) || isCustomQuery(req, common.TransactionErrorCQMethod) {
+		// the query is answered with the viewing key of the account it names
+		queryMap, err := parseParams(req.Params[1:])
+		if err != nil {
+			return nil
+		}
+		address, ok := queryMap["address"].(string)
+		if !ok {
+			return nil
+		}
+		return accClients[gethcommon.HexToAddress(address)]
+	}
+
 	paramsMap, err := parseParams(req.Params)
 	if err != nil {
 		// no further info to deduce calling client
@@ -222,6 +245,21 @@ func (m *AccountManager) suggestAccountClient(req *wecommon.RPCRequest, accClien
 	return nil
 }
 
+// isSimulateTransactionQuery returns whether the request is the custom query a ten_simulateTransaction request is
+// translated to, that is eth_getStorageAt with the name of the query as first parameter
+func isSimulateTransactionQuery(req *wecommon.RPCRequest) bool {
+	return isCustomQuery(req, common.SimulateTransactionCQMethod)
+}
+
+// isCustomQuery returns whether the request is the private custom query with the name, sent through eth_getStorageAt
+func isCustomQuery(req *wecommon.RPCRequest, name string) bool {
+	if req.Method != rpc.GetStorageAt || len(req.Params) < 2 {
+		return false
+	}
+	method, ok := req.Params[0].(string)
+	return ok && method == name
+}
+
 // Many eth RPC requests provide params as first argument in a json map with similar fields (e.g. a `from` field)
 func parseParams(args []interface{}) (map[string]interface{}, error) {
 	if len(args) == 0 {
@@ -321,6 +359,18 @@ func submitCall(client *rpc.EncRPCClient, req *wecommon.RPCRequest, resp *interf
 		}
 	}
 
+	if isSimulateTransactionQuery(req) {
+		// Never modify the original request, as it might be reused.
+		req = req.Clone()
+
+		// The enclave encrypts the simulation outcome for the sender of the transaction, so the 'from' field is required.
+		callParams, err := setFromFieldIfMissing(req.Params[1:], *client.Account())
+		if err != nil {
+			return err
+		}
+		req.Params = append([]interface{}{req.Params[0]}, callParams...)
+	}
+
 	if req.Method == rpc.GetLogs {
 		// Never modify the original request, as it might be reused.
 		req = req.Clone()
@@ -5,6 +5,9 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ten-protocol/go-ten/go/rpc"
+
+	gocommon "github.com/ten-protocol/go-ten/go/common"
+	wecommon "github.com/ten-protocol/go-ten/tools/walletextension/common"
 )
 
 const (
@@ -65,3 +68,19 @@ func TestDataFieldTooShort(t *testing.T) {
 		t.Fatal("`data` field was too short but address was found anyway")
 	}
 }
+
+func TestSuggestsTheSenderClientForSimulatedTransactions(t *testing.T) {
+	clientOne, clientTwo := &rpc.EncRPCClient{}, &rpc.EncRPCClient{}
+	clients := map[common.Address]*rpc.EncRPCClient{viewingKeyAddressOne: clientOne, viewingKeyAddressTwo: clientTwo}
+	m := &AccountManager{}
+
+	query := func(tx map[string]interface{}) *wecommon.RPCRequest {
+		return &wecommon.RPCRequest{Method: rpc.GetStorageAt, Params: []interface{}{gocommon.SimulateTransactionCQMethod, tx, "latest"}}
+	}
+	if client := m.suggestAccountClient(query(map[string]interface{}{"from": viewingKeyAddressTwo.Hex()}), clients); client != clientTwo {
+		t.Fatal("did not suggest the client of the sender of the simulated transaction")
+	}
+	if client := m.suggestAccountClient(query(map[string]interface{}{"to": viewingKeyAddressTwo.Hex()}), clients); client != nil {
+		t.Fatal("suggested a client for a simulated transaction without sender")
+	}
+}
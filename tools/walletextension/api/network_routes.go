@@ -0,0 +1,77 @@
+package api
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ten-protocol/go-ten/tools/walletextension/common"
+)
+
+// NetworkRoutes are the routes of the wallet extension serving a TEN network
+type NetworkRoutes struct {
+	ChainID   int
+	Hostnames []string
+	Routes    []Route
+}
+
+// RouteByNetwork merges the routes of the networks into routes which dispatch each request to the network of its
+// chainId query parameter or, without it, to the network of its hostname. The other requests go to the first network.
+func RouteByNetwork(networks []NetworkRoutes) []Route {
+	if len(networks) == 1 {
+		return networks[0].Routes
+	}
+
+	byChainID := map[int]int{}
+	byHostname := map[string]int{}
+	for i, network := range networks {
+		byChainID[network.ChainID] = i
+		for _, hostname := range network.Hostnames {
+			byHostname[strings.ToLower(hostname)] = i
+		}
+	}
+
+	// all the networks have the same routes
+	routes := make([]Route, len(networks[0].Routes))
+	for r, route := range networks[0].Routes {
+		r := r
+		routes[r] = Route{
+			Name: route.Name,
+			Func: func(resp http.ResponseWriter, req *http.Request) {
+				network, err := selectNetwork(req, byChainID, byHostname)
+				if err != nil {
+					http.Error(resp, err.Error(), http.StatusNotFound)
+					return
+				}
+				networks[network].Routes[r].Func(resp, req)
+			},
+		}
+	}
+	return routes
+}
+
+// returns the index of the network serving the request
+func selectNetwork(req *http.Request, byChainID map[int]int, byHostname map[string]int) (int, error) {
+	if chainIDParam := req.URL.Query().Get(common.ChainIDQueryParameter); chainIDParam != "" {
+		chainID, err := strconv.Atoi(chainIDParam)
+		if err != nil {
+			return 0, fmt.Errorf("invalid %s query parameter %s", common.ChainIDQueryParameter, chainIDParam)
+		}
+		network, found := byChainID[chainID]
+		if !found {
+			return 0, fmt.Errorf("no network with the chain ID %d", chainID)
+		}
+		return network, nil
+	}
+
+	hostname := req.Host
+	if host, _, err := net.SplitHostPort(req.Host); err == nil {
+		hostname = host
+	}
+	if network, found := byHostname[strings.ToLower(hostname)]; found {
+		return network, nil
+	}
+	return 0, nil
+}
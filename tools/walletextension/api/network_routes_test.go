@@ -0,0 +1,55 @@
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+// returns the routes of a network which answer with the network name
+func newTestNetworkRoutes(name string, chainID int, hostnames ...string) NetworkRoutes {
+	return NetworkRoutes{
+		ChainID:   chainID,
+		Hostnames: hostnames,
+		Routes: []Route{{
+			Name: "/",
+			Func: func(resp http.ResponseWriter, _ *http.Request) {
+				_, _ = resp.Write([]byte(name))
+			},
+		}},
+	}
+}
+
+func TestRouteByNetwork(t *testing.T) {
+	routes := RouteByNetwork([]NetworkRoutes{
+		newTestNetworkRoutes("testnet", 443),
+		newTestNetworkRoutes("sepolia", 444, "sepolia.ten.xyz"),
+		newTestNetworkRoutes("mainnet", 1, "Mainnet.ten.xyz", "gateway.ten.xyz"),
+	})
+
+	tests := map[string]struct {
+		url    string
+		status int
+		body   string
+	}{
+		"default network":             {url: "http://localhost:3000/", status: http.StatusOK, body: "testnet"},
+		"hostname":                    {url: "http://sepolia.ten.xyz/", status: http.StatusOK, body: "sepolia"},
+		"hostname with port and case": {url: "http://MAINNET.ten.xyz:3000/", status: http.StatusOK, body: "mainnet"},
+		"chain ID over hostname":      {url: "http://gateway.ten.xyz/?chainId=444", status: http.StatusOK, body: "sepolia"},
+		"unknown chain ID":            {url: "http://localhost:3000/?chainId=5", status: http.StatusNotFound},
+		"invalid chain ID":            {url: "http://localhost:3000/?chainId=ten", status: http.StatusNotFound},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			resp := httptest.NewRecorder()
+			routes[0].Func(resp, httptest.NewRequest(http.MethodGet, test.url, nil))
+			require.Equal(t, test.status, resp.Code)
+			if test.body != "" {
+				require.Equal(t, test.body, resp.Body.String())
+			}
+		})
+	}
+}
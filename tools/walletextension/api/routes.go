@@ -51,6 +51,14 @@ func NewHTTPRoutes(walletExt *walletextension.WalletExtension) []Route {
 			Name: common.APIVersion1 + common.PathAuthenticate,
 			Func: httpHandler(walletExt, authenticateRequestHandler),
 		},
+		{
+			Name: common.APIVersion1 + common.PathHardwareWalletMessage,
+			Func: httpHandler(walletExt, hardwareWalletMessageRequestHandler),
+		},
+		{
+			Name: common.APIVersion1 + common.PathHardwareWalletAuthenticate,
+			Func: httpHandler(walletExt, hardwareWalletAuthenticateRequestHandler),
+		},
 		{
 			Name: common.APIVersion1 + common.PathQuery,
 			Func: httpHandler(walletExt, queryRequestHandler),
@@ -67,6 +75,10 @@ func NewHTTPRoutes(walletExt *walletextension.WalletExtension) []Route {
 			Name: common.PathVersion,
 			Func: httpHandler(walletExt, versionRequestHandler),
 		},
+		{
+			Name: common.APIVersion1 + common.PathInfo,
+			Func: httpHandler(walletExt, infoRequestHandler),
+		},
 	}
 }
 
@@ -87,7 +99,7 @@ func httpRequestHandler(walletExt *walletextension.WalletExtension, resp http.Re
 	if httputil.EnableCORS(resp, req) {
 		return
 	}
-	userConn := userconn.NewUserConnHTTP(resp, req, walletExt.Logger())
+	userConn := userconn.NewUserConnHTTP(resp, req, walletExt.MaxRequestSize(), walletExt.Logger())
 	fun(walletExt, userConn)
 }
 
@@ -129,7 +141,7 @@ func wsRequestHandler(walletExt *walletextension.WalletExtension, resp http.Resp
 		return
 	}
 
-	userConn, err := userconn.NewUserConnWS(resp, req, walletExt.Logger())
+	userConn, err := userconn.NewUserConnWS(resp, req, walletExt.MaxRequestSize(), walletExt.Logger())
 	if err != nil {
 		return
 	}
@@ -165,6 +177,9 @@ func ethRequestHandler(walletExt *walletextension.WalletExtension, conn userconn
 	if err != nil || !walletExt.UserExists(hexUserID) {
 		walletExt.Logger().Info("user not found in the query params: %w. Using the default user", log.ErrKey, err)
 		hexUserID = hex.EncodeToString([]byte(common.DefaultUser)) // todo (@ziga) - this can be removed once old WE endpoints are removed
+	} else if err = walletExt.CheckUserOrigin(hexUserID, conn.GetHTTPRequest()); err != nil {
+		handleEthError(request, conn, walletExt.Logger(), err)
+		return
 	}
 
 	// todo (@pedro) remove this conn dependency
@@ -323,6 +338,85 @@ func authenticateRequestHandler(walletExt *walletextension.WalletExtension, conn
 		walletExt.Logger().Error(fmt.Sprintf("error adding address: %s to user: %s with signature: %s", address, hexUserID, signature))
 		return
 	}
+	// the dapp that authenticated the account is the only one allowed to use the token, besides the ones it was bound to before
+	err = walletExt.BindUserToOrigin(hexUserID, conn.GetHTTPRequest())
+	if err != nil {
+		handleError(conn, walletExt.Logger(), fmt.Errorf("internal error"))
+		walletExt.Logger().Error("unable to bind the user to the origin", "hexUserID", hexUserID, log.ErrKey, err)
+		return
+	}
+	err = conn.WriteResponse([]byte(common.SuccessMsg))
+	if err != nil {
+		walletExt.Logger().Error("error writing success response", log.ErrKey, err)
+	}
+}
+
+// This function handles request to /hardwarewallet/message endpoint.
+// It returns the EIP-712 message to be signed by a hardware wallet to authenticate an address with the userID, together
+// with its domain and message hashes, for the wallets connected to the browser (over WebUSB/WebHID) that only sign hashes.
+// The signature is then submitted to the /hardwarewallet/authenticate endpoint.
+func hardwareWalletMessageRequestHandler(walletExt *walletextension.WalletExtension, conn userconn.UserConn) {
+	// read userID from query params
+	hexUserID, err := getUserID(conn, 3)
+	if err != nil {
+		handleError(conn, walletExt.Logger(), fmt.Errorf("malformed query: 'u' required - representing encryption token - %w", err))
+		return
+	}
+
+	message, err := walletExt.GetHardwareWalletMessage(hexUserID)
+	if err != nil {
+		handleError(conn, walletExt.Logger(), fmt.Errorf("unable to generate the message - %w", err))
+		return
+	}
+
+	msg, err := json.Marshal(message)
+	if err != nil {
+		handleError(conn, walletExt.Logger(), fmt.Errorf("error marshaling the message - %w", err))
+		return
+	}
+	err = conn.WriteResponse(msg)
+	if err != nil {
+		walletExt.Logger().Error("error writing success response", log.ErrKey, err)
+	}
+}
+
+// This function handles request to /hardwarewallet/authenticate endpoint.
+// It takes the signature made on a hardware wallet with the hashes the device signed, checks that they are the ones of the
+// authentication message of the userID and adds the address to the user.
+func hardwareWalletAuthenticateRequestHandler(walletExt *walletextension.WalletExtension, conn userconn.UserConn) {
+	// read the request
+	body, err := conn.ReadRequest()
+	if err != nil {
+		handleError(conn, walletExt.Logger(), fmt.Errorf("error reading request: %w", err))
+		return
+	}
+
+	var signature walletextension.HardwareWalletSignature
+	err = json.Unmarshal(body, &signature)
+	if err != nil {
+		handleError(conn, walletExt.Logger(), fmt.Errorf("could not unmarshal the hardware wallet signature - %w", err))
+		return
+	}
+
+	// read userID from query params
+	hexUserID, err := getUserID(conn, 3)
+	if err != nil {
+		handleError(conn, walletExt.Logger(), fmt.Errorf("malformed query: 'u' required - representing encryption token - %w", err))
+		return
+	}
+
+	err = walletExt.AuthenticateHardwareWallet(hexUserID, &signature)
+	if err != nil {
+		handleError(conn, walletExt.Logger(), fmt.Errorf("unable to authenticate the hardware wallet account - %w", err))
+		return
+	}
+	// the dapp that authenticated the account is the only one allowed to use the token, besides the ones it was bound to before
+	err = walletExt.BindUserToOrigin(hexUserID, conn.GetHTTPRequest())
+	if err != nil {
+		handleError(conn, walletExt.Logger(), fmt.Errorf("internal error"))
+		walletExt.Logger().Error("unable to bind the user to the origin", "hexUserID", hexUserID, log.ErrKey, err)
+		return
+	}
 	err = conn.WriteResponse([]byte(common.SuccessMsg))
 	if err != nil {
 		walletExt.Logger().Error("error writing success response", log.ErrKey, err)
@@ -357,6 +451,10 @@ func queryRequestHandler(walletExt *walletextension.WalletExtension, conn userco
 		handleError(conn, walletExt.Logger(), fmt.Errorf("provided address length is %d, expected: %d", len(address), common.EthereumAddressLen))
 		return
 	}
+	if err = walletExt.CheckUserOrigin(hexUserID, conn.GetHTTPRequest()); err != nil {
+		handleError(conn, walletExt.Logger(), err)
+		return
+	}
 
 	// check if this account is registered with given user
 	found, err := walletExt.UserHasAccount(hexUserID, address)
@@ -399,6 +497,11 @@ func revokeRequestHandler(walletExt *walletextension.WalletExtension, conn userc
 		return
 	}
 
+	if err = walletExt.CheckUserOrigin(hexUserID, conn.GetHTTPRequest()); err != nil {
+		handleError(conn, walletExt.Logger(), err)
+		return
+	}
+
 	// delete user and accounts associated with it from the database
 	err = walletExt.DeleteUser(hexUserID)
 	if err != nil {
@@ -428,6 +531,26 @@ func healthRequestHandler(walletExt *walletextension.WalletExtension, conn userc
 	}
 }
 
+// Handles request to /v1/info endpoint. The frontend and the wallets use it to detect a gateway running locally.
+func infoRequestHandler(walletExt *walletextension.WalletExtension, conn userconn.UserConn) {
+	// read the request
+	_, err := conn.ReadRequest()
+	if err != nil {
+		walletExt.Logger().Error("error reading request", log.ErrKey, err)
+		return
+	}
+
+	info, err := json.Marshal(walletExt.Info())
+	if err != nil {
+		handleError(conn, walletExt.Logger(), fmt.Errorf("error marshaling the gateway info - %w", err))
+		return
+	}
+	err = conn.WriteResponse(info)
+	if err != nil {
+		walletExt.Logger().Error("error writing success response", log.ErrKey, err)
+	}
+}
+
 // Handles request to /version endpoint.
 func versionRequestHandler(walletExt *walletextension.WalletExtension, userConn userconn.UserConn) {
 	// read the request
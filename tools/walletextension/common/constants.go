@@ -33,16 +33,23 @@ const (
 	PathAuthenticate                    = "/authenticate/"
 	PathQuery                           = "/query/"
 	PathRevoke                          = "/revoke/"
+	PathHardwareWalletMessage           = "/hardwarewallet/message/"
+	PathHardwareWalletAuthenticate      = "/hardwarewallet/authenticate/"
 	PathObscuroGateway                  = "/"
 	PathHealth                          = "/health/"
+	PathInfo                            = "/info/"
 	WSProtocol                          = "ws://"
 	DefaultUser                         = "defaultUser"
 	UserQueryParameter                  = "u"
 	EncryptedTokenQueryParameter        = "token"
 	AddressQueryParameter               = "a"
+	ChainIDQueryParameter               = "chainId"
 	MessageUserIDLen                    = 40
 	EthereumAddressLen                  = 42
 	GetStorageAtUserIDRequestMethodName = "getUserID"
+	SimulateTransactionMethodName       = "ten_simulateTransaction"
+	TransactionByNonceMethodName        = "ten_getTransactionByNonce"
+	TransactionErrorMethodName          = "ten_getTransactionError"
 	SuccessMsg                          = "success"
 	APIVersion1                         = "/v1"
 	MethodEthSubscription               = "eth_subscription"
@@ -9,3 +9,11 @@ type UserDB struct {
 	UserID     []byte
 	PrivateKey []byte
 }
+
+// GatewayInfo describes the gateway to the wallets and the frontend, which detect a local gateway with it
+type GatewayInfo struct {
+	Version    string `json:"version"`
+	TenChainID int    `json:"tenChainID"`
+	Local      bool   `json:"local"`
+	PortWS     int    `json:"portWS"`
+}
@@ -1,5 +1,11 @@
 package config
 
+import (
+	"fmt"
+	"net"
+	"strings"
+)
+
 // Config contains the configuration required by the WalletExtension.
 type Config struct {
 	WalletExtensionHost     string
@@ -7,10 +13,157 @@ type Config struct {
 	WalletExtensionPortWS   int
 	NodeRPCHTTPAddress      string
 	NodeRPCWebsocketAddress string
+	Upstreams               []UpstreamConfig // Replace the NodeRPCWebsocketAddress with a pool of nodes when set.
 	LogPath                 string
 	DBPathOverride          string // Overrides the database file location. Used in tests.
 	VerboseFlag             bool
 	DBType                  string
 	DBConnectionURL         string
 	TenChainID              int
+	Networks                []NetworkConfig // The networks served in addition to the default one configured above.
+	LocalMode               bool            // Runs the gateway on the machine of its only user, see ValidateLocalMode.
+	GatewayOrigins          []string        // The web origins of the gateway frontend, whose authentications are not bound to an origin.
+	MaxRequestSize          int64           // The maximum size of an HTTP request body or websocket message, in bytes. Defaults to DefaultMaxRequestSize.
+}
+
+// DefaultMaxRequestSize is the request size limit of the nodes
+const DefaultMaxRequestSize = 5 * 1024 * 1024
+
+// RequestSizeLimit returns the maximum size of a request
+func (c *Config) RequestSizeLimit() int64 {
+	if c.MaxRequestSize <= 0 {
+		return DefaultMaxRequestSize
+	}
+	return c.MaxRequestSize
+}
+
+// NetworkConfig is a TEN network served by the gateway, with its own node and viewing keys database. The requests are
+// routed to it by their chainId query parameter or by their hostname.
+type NetworkConfig struct {
+	Name                    string           `json:"name"`
+	TenChainID              int              `json:"tenChainID"`
+	Hostnames               []string         `json:"hostnames"`
+	NodeRPCWebsocketAddress string           `json:"nodeRPCWebsocketAddress"`
+	Upstreams               []UpstreamConfig `json:"upstreams"`
+	DBType                  string           `json:"dbType"`
+	DBConnectionURL         string           `json:"dbConnectionURL"`
+	DBPathOverride          string           `json:"databasePath"`
+}
+
+// UpstreamConfig is a node of the pool serving a network. The nodes receive a share of the requests proportional to
+// their weight, which defaults to 1.
+type UpstreamConfig struct {
+	Address string `json:"address"`
+	Weight  int    `json:"weight"`
+}
+
+// AllUpstreams returns the nodes serving the default network
+func (c *Config) AllUpstreams() []UpstreamConfig {
+	return upstreamsOf(c.NodeRPCWebsocketAddress, c.Upstreams)
+}
+
+// AllUpstreams returns the nodes serving the network
+func (n *NetworkConfig) AllUpstreams() []UpstreamConfig {
+	return upstreamsOf(n.NodeRPCWebsocketAddress, n.Upstreams)
+}
+
+func upstreamsOf(nodeAddress string, upstreams []UpstreamConfig) []UpstreamConfig {
+	if len(upstreams) > 0 {
+		return upstreams
+	}
+	if nodeAddress == "" {
+		return nil
+	}
+	return []UpstreamConfig{{Address: nodeAddress, Weight: 1}}
+}
+
+// AllNetworks returns the default network followed by the additional ones
+func (c *Config) AllNetworks() []NetworkConfig {
+	defaultNetwork := NetworkConfig{
+		Name:                    "default",
+		TenChainID:              c.TenChainID,
+		NodeRPCWebsocketAddress: c.NodeRPCWebsocketAddress,
+		Upstreams:               c.Upstreams,
+		DBType:                  c.DBType,
+		DBConnectionURL:         c.DBConnectionURL,
+		DBPathOverride:          c.DBPathOverride,
+	}
+	return append([]NetworkConfig{defaultNetwork}, c.Networks...)
+}
+
+// ForNetwork returns the config of a gateway serving only the network
+func (c *Config) ForNetwork(network NetworkConfig) Config {
+	cfg := *c
+	cfg.TenChainID = network.TenChainID
+	cfg.NodeRPCWebsocketAddress = network.NodeRPCWebsocketAddress
+	cfg.Upstreams = network.Upstreams
+	cfg.DBType = network.DBType
+	cfg.DBConnectionURL = network.DBConnectionURL
+	cfg.DBPathOverride = network.DBPathOverride
+	cfg.Networks = nil
+	return cfg
+}
+
+// Validate checks that the requests can be routed unambiguously to the networks, and that the networks do not share
+// their viewing keys database
+func (c *Config) Validate() error {
+	if c.LocalMode {
+		if err := c.validateLocalMode(); err != nil {
+			return err
+		}
+	}
+	chainIDs := map[int]string{}
+	hostnames := map[string]string{}
+	databases := map[string]string{}
+	for _, network := range c.AllNetworks() {
+		upstreams := network.AllUpstreams()
+		if len(upstreams) == 0 {
+			return fmt.Errorf("no node address for network %s", network.Name)
+		}
+		for _, upstream := range upstreams {
+			if upstream.Address == "" {
+				return fmt.Errorf("upstream without address for network %s", network.Name)
+			}
+			if upstream.Weight < 0 {
+				return fmt.Errorf("negative weight %d for upstream %s of network %s", upstream.Weight, upstream.Address, network.Name)
+			}
+		}
+		if other, found := chainIDs[network.TenChainID]; found {
+			return fmt.Errorf("networks %s and %s have the same chain ID %d", other, network.Name, network.TenChainID)
+		}
+		chainIDs[network.TenChainID] = network.Name
+		for _, hostname := range network.Hostnames {
+			hostname = strings.ToLower(hostname)
+			if other, found := hostnames[hostname]; found {
+				return fmt.Errorf("networks %s and %s have the same hostname %s", other, network.Name, hostname)
+			}
+			hostnames[hostname] = network.Name
+		}
+		// an empty sqlite path is a temporary database, so it is never shared
+		database := network.DBType + ":" + network.DBConnectionURL + network.DBPathOverride
+		if network.DBConnectionURL != "" || network.DBPathOverride != "" {
+			if other, found := databases[database]; found {
+				return fmt.Errorf("networks %s and %s have the same database", other, network.Name)
+			}
+			databases[database] = network.Name
+		}
+	}
+	return nil
+}
+
+// validateLocalMode checks that a local gateway keeps the viewing keys on the machine of its user: it is only
+// reachable from that machine, and its networks store the keys in sqlite files that survive restarts
+func (c *Config) validateLocalMode() error {
+	if c.WalletExtensionHost != "localhost" {
+		ip := net.ParseIP(c.WalletExtensionHost)
+		if ip == nil || !ip.IsLoopback() {
+			return fmt.Errorf("the local gateway must listen on the loopback interface, not on %s", c.WalletExtensionHost)
+		}
+	}
+	for _, network := range c.AllNetworks() {
+		if network.DBType != "sqlite" || network.DBPathOverride == "" {
+			return fmt.Errorf("the local gateway must store the viewing keys of network %s in a sqlite database file", network.Name)
+		}
+	}
+	return nil
 }
@@ -0,0 +1,92 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestValidate(t *testing.T) {
+	newConfig := func(networks ...NetworkConfig) *Config {
+		return &Config{
+			TenChainID:              443,
+			NodeRPCWebsocketAddress: "testnet:81",
+			DBType:                  "sqlite",
+			DBPathOverride:          "testnet.db",
+			Networks:                networks,
+		}
+	}
+	localConfig := func(host string, dbType string, dbPath string) *Config {
+		cfg := newConfig()
+		cfg.LocalMode = true
+		cfg.WalletExtensionHost = host
+		cfg.DBType = dbType
+		cfg.DBPathOverride = dbPath
+		return cfg
+	}
+	sepolia := NetworkConfig{Name: "sepolia", TenChainID: 444, Hostnames: []string{"sepolia.ten.xyz"}, NodeRPCWebsocketAddress: "sepolia:81", DBType: "sqlite", DBPathOverride: "sepolia.db"}
+
+	tests := map[string]struct {
+		config *Config
+		err    string
+	}{
+		"single network": {
+			config: newConfig(),
+		},
+		"multiple networks": {
+			config: newConfig(sepolia, NetworkConfig{Name: "mainnet", TenChainID: 1, Hostnames: []string{"ten.xyz"}, NodeRPCWebsocketAddress: "mainnet:81", DBType: "sqlite"}),
+		},
+		"no node": {
+			config: newConfig(NetworkConfig{Name: "mainnet", TenChainID: 1}),
+			err:    "no node address for network mainnet",
+		},
+		"upstream pool": {
+			config: newConfig(NetworkConfig{Name: "mainnet", TenChainID: 1, Upstreams: []UpstreamConfig{{Address: "mainnet-1:81", Weight: 2}, {Address: "mainnet-2:81"}}}),
+		},
+		"negative upstream weight": {
+			config: newConfig(NetworkConfig{Name: "mainnet", TenChainID: 1, Upstreams: []UpstreamConfig{{Address: "mainnet-1:81", Weight: -1}}}),
+			err:    "negative weight -1 for upstream mainnet-1:81 of network mainnet",
+		},
+		"same chain ID": {
+			config: newConfig(sepolia, NetworkConfig{Name: "mainnet", TenChainID: 444, NodeRPCWebsocketAddress: "mainnet:81"}),
+			err:    "networks sepolia and mainnet have the same chain ID 444",
+		},
+		"same hostname": {
+			config: newConfig(sepolia, NetworkConfig{Name: "mainnet", TenChainID: 1, Hostnames: []string{"Sepolia.ten.xyz"}, NodeRPCWebsocketAddress: "mainnet:81"}),
+			err:    "networks sepolia and mainnet have the same hostname sepolia.ten.xyz",
+		},
+		"local mode": {
+			config: localConfig("127.0.0.1", "sqlite", "testnet.db"),
+		},
+		"local mode on localhost": {
+			config: localConfig("localhost", "sqlite", "testnet.db"),
+		},
+		"local mode on a public interface": {
+			config: localConfig("0.0.0.0", "sqlite", "testnet.db"),
+			err:    "the local gateway must listen on the loopback interface, not on 0.0.0.0",
+		},
+		"local mode with a remote database": {
+			config: localConfig("127.0.0.1", "mariaDB", ""),
+			err:    "the local gateway must store the viewing keys of network default in a sqlite database file",
+		},
+		"local mode with a temporary database": {
+			config: localConfig("127.0.0.1", "sqlite", ""),
+			err:    "the local gateway must store the viewing keys of network default in a sqlite database file",
+		},
+		"same database": {
+			config: newConfig(NetworkConfig{Name: "mainnet", TenChainID: 1, NodeRPCWebsocketAddress: "mainnet:81", DBType: "sqlite", DBPathOverride: "testnet.db"}),
+			err:    "networks default and mainnet have the same database",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			err := test.config.Validate()
+			if test.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, test.err)
+		})
+	}
+}
@@ -14,11 +14,11 @@ import (
 
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/go/common/stopcontrol"
-	"github.com/ten-protocol/go-ten/go/rpc"
 	"github.com/ten-protocol/go-ten/tools/walletextension"
 	"github.com/ten-protocol/go-ten/tools/walletextension/api"
 	"github.com/ten-protocol/go-ten/tools/walletextension/config"
 	"github.com/ten-protocol/go-ten/tools/walletextension/storage"
+	"github.com/ten-protocol/go-ten/tools/walletextension/upstream"
 	"github.com/ten-protocol/go-ten/tools/walletextension/useraccountmanager"
 
 	gethlog "github.com/ethereum/go-ethereum/log"
@@ -26,32 +26,56 @@ import (
 )
 
 type WalletExtensionContainer struct {
-	hostAddr           string
-	userAccountManager *useraccountmanager.UserAccountManager
-	storage            storage.Storage
-	stopControl        *stopcontrol.StopControl
-	logger             gethlog.Logger
-	walletExt          *walletextension.WalletExtension
-	httpServer         *api.Server
-	wsServer           *api.Server
+	walletExts  []*walletextension.WalletExtension // one per network, the first serves the default network
+	stopControl *stopcontrol.StopControl
+	logger      gethlog.Logger
+	httpServer  *api.Server
+	wsServer    *api.Server
 }
 
 func NewWalletExtensionContainerFromConfig(config config.Config, logger gethlog.Logger) *WalletExtensionContainer {
-	// create the account manager with a single unauthenticated connection
-	hostRPCBindAddr := wecommon.WSProtocol + config.NodeRPCWebsocketAddress
-	unAuthedClient, err := rpc.NewNetworkClient(hostRPCBindAddr)
-	if err != nil {
-		logger.Crit("unable to create temporary client for request ", log.ErrKey, err)
-		os.Exit(1)
+	// captures version in the env vars
+	version := os.Getenv("OBSCURO_GATEWAY_VERSION")
+	if version == "" {
+		version = "dev"
+	}
+
+	stopControl := stopcontrol.New()
+	var walletExts []*walletextension.WalletExtension
+	var httpNetworkRoutes, wsNetworkRoutes []api.NetworkRoutes
+	for _, network := range config.AllNetworks() {
+		networkConfig := config.ForNetwork(network)
+		walletExt := newNetworkWalletExtension(&networkConfig, stopControl, version, logger.New("network", network.Name))
+		walletExts = append(walletExts, walletExt)
+		httpNetworkRoutes = append(httpNetworkRoutes, api.NetworkRoutes{ChainID: network.TenChainID, Hostnames: network.Hostnames, Routes: api.NewHTTPRoutes(walletExt)})
+		wsNetworkRoutes = append(wsNetworkRoutes, api.NetworkRoutes{ChainID: network.TenChainID, Hostnames: network.Hostnames, Routes: api.NewWSRoutes(walletExt)})
 	}
 
+	httpServer := api.NewHTTPServer(fmt.Sprintf("%s:%d", config.WalletExtensionHost, config.WalletExtensionPortHTTP), api.RouteByNetwork(httpNetworkRoutes))
+	wsServer := api.NewWSServer(fmt.Sprintf("%s:%d", config.WalletExtensionHost, config.WalletExtensionPortWS), api.RouteByNetwork(wsNetworkRoutes))
+	return NewWalletExtensionContainer(
+		walletExts,
+		stopControl,
+		httpServer,
+		wsServer,
+		logger,
+	)
+}
+
+// creates the wallet extension serving a single network, with its own node and viewing keys database
+func newNetworkWalletExtension(config *config.Config, stopControl *stopcontrol.StopControl, version string, logger gethlog.Logger) *walletextension.WalletExtension {
+	// the clients of all the users share the connections to the upstream nodes of the pool
+	upstreams := upstream.NewPool(config.AllUpstreams(), logger)
+	upstreams.Start()
+	unAuthedClient := upstreams.NewClient()
+
 	// start the database
 	databaseStorage, err := storage.New(config.DBType, config.DBConnectionURL, config.DBPathOverride)
 	if err != nil {
 		logger.Crit("unable to create database to store viewing keys ", log.ErrKey, err)
 		os.Exit(1)
 	}
-	userAccountManager := useraccountmanager.NewUserAccountManager(unAuthedClient, logger, databaseStorage, hostRPCBindAddr)
+	userAccountManager := useraccountmanager.NewUserAccountManager(unAuthedClient, logger, databaseStorage, upstreams)
 
 	// add default user (when no UserID is provided in the query parameter - for WE endpoints)
 	defaultUserAccountManager := userAccountManager.AddAndReturnAccountManager(hex.EncodeToString([]byte(wecommon.DefaultUser)))
@@ -84,7 +108,7 @@ func NewWalletExtensionContainerFromConfig(config config.Config, logger gethlog.
 				os.Exit(1)
 			}
 			for _, account := range accounts {
-				encClient, err := wecommon.CreateEncClient(hostRPCBindAddr, account.AccountAddress, user.PrivateKey, account.Signature, logger)
+				encClient, err := wecommon.CreateEncClient(upstreams.NewClient(), account.AccountAddress, user.PrivateKey, account.Signature, logger)
 				if err != nil {
 					logger.Error(fmt.Errorf("error creating new client, %w", err).Error())
 					os.Exit(1)
@@ -102,50 +126,22 @@ func NewWalletExtensionContainerFromConfig(config config.Config, logger gethlog.
 		os.Exit(1)
 	}
 
-	// captures version in the env vars
-	version := os.Getenv("OBSCURO_GATEWAY_VERSION")
-	if version == "" {
-		version = "dev"
-	}
-
-	stopControl := stopcontrol.New()
-	walletExt := walletextension.New(hostRPCBindAddr, &userAccountManager, databaseStorage, stopControl, version, logger, &config)
-	httpRoutes := api.NewHTTPRoutes(walletExt)
-	httpServer := api.NewHTTPServer(fmt.Sprintf("%s:%d", config.WalletExtensionHost, config.WalletExtensionPortHTTP), httpRoutes)
-
-	wsRoutes := api.NewWSRoutes(walletExt)
-	wsServer := api.NewWSServer(fmt.Sprintf("%s:%d", config.WalletExtensionHost, config.WalletExtensionPortWS), wsRoutes)
-	return NewWalletExtensionContainer(
-		hostRPCBindAddr,
-		walletExt,
-		&userAccountManager,
-		databaseStorage,
-		stopControl,
-		httpServer,
-		wsServer,
-		logger,
-	)
+	return walletextension.New(upstreams, &userAccountManager, databaseStorage, stopControl, version, logger, config)
 }
 
 func NewWalletExtensionContainer(
-	hostAddr string,
-	walletExt *walletextension.WalletExtension,
-	userAccountManager *useraccountmanager.UserAccountManager,
-	storage storage.Storage,
+	walletExts []*walletextension.WalletExtension,
 	stopControl *stopcontrol.StopControl,
 	httpServer *api.Server,
 	wsServer *api.Server,
 	logger gethlog.Logger,
 ) *WalletExtensionContainer {
 	return &WalletExtensionContainer{
-		hostAddr:           hostAddr,
-		walletExt:          walletExt,
-		userAccountManager: userAccountManager,
-		storage:            storage,
-		stopControl:        stopControl,
-		httpServer:         httpServer,
-		wsServer:           wsServer,
-		logger:             logger,
+		walletExts:  walletExts,
+		stopControl: stopControl,
+		httpServer:  httpServer,
+		wsServer:    wsServer,
+		logger:      logger,
 	}
 }
 
@@ -180,6 +176,10 @@ func (w *WalletExtensionContainer) Stop() error {
 		w.logger.Warn("could not shut down wallet extension", log.ErrKey, err)
 	}
 
+	for _, walletExt := range w.walletExts {
+		walletExt.Stop()
+	}
+
 	// todo (@pedro) correctly surface shutdown errors
 	return nil
 }
@@ -1,13 +1,40 @@
 import { apiRoutes } from "../routes";
-import { httpRequest } from ".";
+import { getGatewayAddress, httpRequest, setGatewayAddress } from ".";
 import { pathToUrl } from "../routes/router";
-import { AuthenticationResponse } from "@/types/interfaces/GatewayInterfaces";
-import { tenGatewayAddress } from "../lib/constants";
+import {
+  AuthenticationResponse,
+  GatewayInfo,
+  HardwareWalletMessage,
+  HardwareWalletSignature,
+} from "@/types/interfaces/GatewayInterfaces";
+import {
+  LOCAL_GATEWAY_DETECTION_TIMEOUT,
+  localGatewayAddress,
+} from "../lib/constants";
+
+// detectLocalGateway switches the requests to a gateway run by the user on their machine, if one answers. The viewing
+// keys registered through it never leave the machine of the user.
+export async function detectLocalGateway(): Promise<boolean> {
+  try {
+    const info = await httpRequest<GatewayInfo>({
+      method: "get",
+      url: localGatewayAddress + pathToUrl(apiRoutes.info),
+      timeout: LOCAL_GATEWAY_DETECTION_TIMEOUT,
+    });
+    if (!info?.local) {
+      return false;
+    }
+    setGatewayAddress(localGatewayAddress);
+    return true;
+  } catch (error) {
+    return false;
+  }
+}
 
 export async function fetchVersion(): Promise<string> {
   return await httpRequest<string>({
     method: "get",
-    url: tenGatewayAddress + pathToUrl(apiRoutes.version),
+    url: getGatewayAddress() + pathToUrl(apiRoutes.version),
   });
 }
 
@@ -17,7 +44,7 @@ export async function accountIsAuthenticated(
 ): Promise<AuthenticationResponse> {
   return await httpRequest<AuthenticationResponse>({
     method: "get",
-    url: tenGatewayAddress + pathToUrl(apiRoutes.queryAccountToken),
+    url: getGatewayAddress() + pathToUrl(apiRoutes.queryAccountToken),
     searchParams: {
       token,
       a: account,
@@ -34,7 +61,7 @@ export const authenticateUser = async (
 ) => {
   return await httpRequest({
     method: "post",
-    url: tenGatewayAddress + pathToUrl(apiRoutes.authenticate),
+    url: getGatewayAddress() + pathToUrl(apiRoutes.authenticate),
     data: authenticateFields,
     searchParams: {
       token,
@@ -42,10 +69,36 @@ export const authenticateUser = async (
   });
 };
 
+export async function fetchHardwareWalletMessage(
+  token: string
+): Promise<HardwareWalletMessage> {
+  return await httpRequest<HardwareWalletMessage>({
+    method: "get",
+    url: getGatewayAddress() + pathToUrl(apiRoutes.hardwareWalletMessage),
+    searchParams: {
+      token,
+    },
+  });
+}
+
+export const authenticateHardwareWallet = async (
+  token: string,
+  signature: HardwareWalletSignature
+) => {
+  return await httpRequest({
+    method: "post",
+    url: getGatewayAddress() + pathToUrl(apiRoutes.hardwareWalletAuthenticate),
+    data: signature,
+    searchParams: {
+      token,
+    },
+  });
+};
+
 export async function revokeAccountsApi(token: string): Promise<string> {
   return await httpRequest<string>({
     method: "get",
-    url: tenGatewayAddress + pathToUrl(apiRoutes.revoke),
+    url: getGatewayAddress() + pathToUrl(apiRoutes.revoke),
     searchParams: {
       token,
     },
@@ -55,6 +108,6 @@ export async function revokeAccountsApi(token: string): Promise<string> {
 export async function joinTestnet(): Promise<string> {
   return await httpRequest<string>({
     method: "get",
-    url: tenGatewayAddress + pathToUrl(apiRoutes.join),
+    url: getGatewayAddress() + pathToUrl(apiRoutes.join),
   });
 }
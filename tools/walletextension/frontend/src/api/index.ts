@@ -27,6 +27,16 @@ const baseConfig: AxiosRequestConfig = {
 
 export const https: AxiosInstance = axios.create(baseConfig);
 
+// the gateway the requests are sent to, which is replaced by the local gateway when one is detected
+let gatewayAddress = tenGatewayAddress;
+
+export const getGatewayAddress = () => gatewayAddress;
+
+export const setGatewayAddress = (address: string) => {
+  gatewayAddress = address;
+  https.defaults.baseURL = address;
+};
+
 export const httpRequest = async <ResponseData>(
   options: HttpOptions,
   config: AxiosRequestConfig = {}
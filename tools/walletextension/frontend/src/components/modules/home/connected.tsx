@@ -9,14 +9,25 @@ import {
   TableHeader,
   TableRow,
 } from "../../ui/table";
-import { Account } from "../../../types/interfaces/WalletInterfaces";
 import React from "react";
 import TruncatedAddress from "../common/truncated-address";
 import { socialLinks } from "../../../lib/constants";
 import { Skeleton } from "../../ui/skeleton";
+import {
+  hardwareWalletNames,
+  isHardwareWalletSupported,
+} from "../../../lib/hardware-wallet";
+import {
+  Account,
+  HardwareWalletDevice,
+} from "../../../types/interfaces/WalletInterfaces";
 
 const Connected = () => {
-  const { accounts, connectAccount, revokeAccounts } = useWalletConnection();
+  const { accounts, connectAccount, connectHardwareWallet, revokeAccounts } =
+    useWalletConnection();
+  const hardwareWallets = (
+    Object.keys(hardwareWalletNames) as HardwareWalletDevice[]
+  ).filter(isHardwareWalletSupported);
 
   return (
     <>
@@ -31,6 +42,16 @@ const Connected = () => {
           <LinkButton size={"sm"} href={socialLinks.discord} target="_blank">
             Request Tokens
           </LinkButton>
+          {hardwareWallets.map((device) => (
+            <Button
+              key={device}
+              size={"sm"}
+              variant={"outline"}
+              onClick={() => connectHardwareWallet(device)}
+            >
+              Connect {hardwareWalletNames[device]}
+            </Button>
+          ))}
           <Button size={"sm"} variant={"destructive"} onClick={revokeAccounts}>
             Revoke Accounts
           </Button>
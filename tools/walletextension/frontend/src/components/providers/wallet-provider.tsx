@@ -3,11 +3,15 @@ import {
   WalletConnectionContextType,
   WalletConnectionProviderProps,
   Account,
+  HardwareWalletDevice,
 } from "../../types/interfaces/WalletInterfaces";
 import { showToast } from "../ui/use-toast";
 import { ethereum, isValidTokenFormat } from "../../lib/utils";
 import {
   accountIsAuthenticated,
+  authenticateHardwareWallet,
+  detectLocalGateway,
+  fetchHardwareWalletMessage,
   fetchVersion,
   revokeAccountsApi,
 } from "../../api/gateway";
@@ -18,6 +22,22 @@ import {
 } from "@/api/ethRequests";
 import { ethers } from "ethers";
 import ethService from "@/services/ethService";
+import {
+  hardwareWalletNames,
+  openHardwareWallet,
+} from "@/lib/hardware-wallet";
+
+// the Trezor One asks for its PIN as the positions of the digits in the
+// scrambled matrix it displays, which the user types like on a numeric keypad
+const requestTrezorPin = async (): Promise<string> => {
+  const pin = window.prompt(
+    "Enter the PIN of your Trezor, using the positions of its digits in the matrix displayed on the device (7 8 9 / 4 5 6 / 1 2 3)."
+  );
+  if (!pin) {
+    throw new Error("No PIN entered.");
+  }
+  return pin;
+};
 
 const WalletConnectionContext =
   createContext<WalletConnectionContextType | null>(null);
@@ -54,6 +74,10 @@ export const WalletConnectionProvider = ({
     }
 
     try {
+      // a gateway run by the user is preferred, so that the viewing keys stay on their machine
+      if (await detectLocalGateway()) {
+        showToast(ToastType.INFO, "Using the local Ten Gateway.");
+      }
       await ethService.checkIfMetamaskIsLoaded(providerInstance);
 
       const fetchedToken = await getToken(providerInstance);
@@ -110,6 +134,57 @@ export const WalletConnectionProvider = ({
     }
   };
 
+  // authenticates the account of a hardware wallet connected to the browser:
+  // the message hashes are signed on the device, the key never leaves it, and
+  // the gateway checks that the device signed the message of the user
+  const connectHardwareWallet = async (device: HardwareWalletDevice) => {
+    if (!token) {
+      showToast(
+        ToastType.INFO,
+        "Encryption token is required to connect an account."
+      );
+      return;
+    }
+    const name = hardwareWalletNames[device];
+    let signer;
+    try {
+      signer = await openHardwareWallet(device, requestTrezorPin);
+      const address = await signer.getAddress();
+      const { domainHash, messageHash } = await fetchHardwareWalletMessage(
+        token
+      );
+      showToast(
+        ToastType.INFO,
+        `Please confirm the signature on your ${name}.`
+      );
+      const signature = await signer.signHashes(domainHash, messageHash);
+      await authenticateHardwareWallet(token, {
+        device,
+        address,
+        signature,
+        domainHash,
+        messageHash,
+      });
+      const { status } = await accountIsAuthenticated(token, address);
+      if (!status) {
+        showToast(ToastType.DESTRUCTIVE, "Account authentication failed.");
+        return;
+      }
+      showToast(ToastType.SUCCESS, `${name} account authenticated!`);
+      setAccounts((accounts) => {
+        const others = (accounts || []).filter((acc) => acc.name !== address);
+        return [...others, { name: address, connected: true }];
+      });
+    } catch (error: any) {
+      showToast(
+        ToastType.DESTRUCTIVE,
+        `${name} authentication failed: ${error?.message}`
+      );
+    } finally {
+      await signer?.close();
+    }
+  };
+
   const revokeAccounts = async () => {
     if (!token) {
       showToast(
@@ -206,6 +281,7 @@ export const WalletConnectionProvider = ({
     accounts,
     token,
     connectAccount,
+    connectHardwareWallet,
     version,
     revokeAccounts,
     loading,
@@ -1,6 +1,10 @@
 export const tenGatewayAddress =
   process.env.NEXT_PUBLIC_API_GATEWAY_URL || "http://127.0.0.1:3000";
 
+// the address of a gateway run by the user with the -local flag, which the frontend prefers when it is detected
+export const localGatewayAddress = "http://127.0.0.1:3000";
+export const LOCAL_GATEWAY_DETECTION_TIMEOUT = 1000;
+
 export const tenscanLink = "https://testnet.tenscan.io";
 
 export const socialLinks = {
@@ -28,6 +32,10 @@ export const testnetUrls = {
     url: "https://dev-testnet.obscu.ro",
     rpc: "https://rpc.dev-testnet.obscu.ro",
   },
+  local: {
+    name: "Local Ten Gateway",
+    url: localGatewayAddress,
+  },
   default: {
     name: "Obscuro Testnet",
     url: tenGatewayAddress,
@@ -0,0 +1,62 @@
+import {
+  getLedgerAddress,
+  isWebHIDSupported,
+  openLedger,
+  signLedgerEIP712HashedMessage,
+} from "./ledger";
+import {
+  closeTrezor,
+  getTrezorAddress,
+  isWebUSBSupported,
+  openTrezor,
+  PinRequester,
+  signTrezorEIP712HashedMessage,
+} from "./trezor";
+import {
+  HardwareWalletDevice,
+  HardwareWalletSigner,
+} from "@/types/interfaces/WalletInterfaces";
+
+export const hardwareWalletNames: Record<HardwareWalletDevice, string> = {
+  ledger: "Ledger",
+  trezor: "Trezor",
+};
+
+// Ledger devices are reached over WebHID, Trezor devices over WebUSB
+export function isHardwareWalletSupported(
+  device: HardwareWalletDevice
+): boolean {
+  return device === "ledger" ? isWebHIDSupported() : isWebUSBSupported();
+}
+
+// asks the user to select the device and opens it
+export async function openHardwareWallet(
+  device: HardwareWalletDevice,
+  requestPin: PinRequester
+): Promise<HardwareWalletSigner> {
+  if (device === "ledger") {
+    const ledger = await openLedger();
+    return {
+      getAddress: () => getLedgerAddress(ledger),
+      signHashes: (domainHash, messageHash) =>
+        signLedgerEIP712HashedMessage(ledger, domainHash, messageHash),
+      close: async () => {
+        if (ledger.opened) {
+          await ledger.close();
+        }
+      },
+    };
+  }
+  const trezor = await openTrezor();
+  return {
+    getAddress: () => getTrezorAddress(trezor, requestPin),
+    signHashes: (domainHash, messageHash) =>
+      signTrezorEIP712HashedMessage(
+        trezor,
+        requestPin,
+        domainHash,
+        messageHash
+      ),
+    close: () => closeTrezor(trezor),
+  };
+}
@@ -0,0 +1,149 @@
+import { ethers } from "ethers";
+
+// Talks to a Ledger device connected to the browser over WebHID, so that the
+// authentication message is signed on the device and the key never leaves it.
+// Only the APDUs needed to authenticate an account are implemented.
+
+const LEDGER_VENDOR_ID = 0x2c97;
+const HID_PACKET_SIZE = 64;
+const HID_CHANNEL = 0x0101;
+const HID_TAG_APDU = 0x05;
+
+const CLA_ETH = 0xe0;
+const INS_GET_ADDRESS = 0x02;
+const INS_SIGN_EIP712 = 0x0c;
+const SW_OK = 0x9000;
+
+export const defaultLedgerPath = "44'/60'/0'/0/0";
+
+export function isWebHIDSupported(): boolean {
+  return typeof navigator !== "undefined" && "hid" in navigator;
+}
+
+// asks the user to select the Ledger device and opens it
+export async function openLedger(): Promise<any> {
+  if (!isWebHIDSupported()) {
+    throw new Error("WebHID is not supported by this browser.");
+  }
+  const devices = await (navigator as any).hid.requestDevice({
+    filters: [{ vendorId: LEDGER_VENDOR_ID }],
+  });
+  if (!devices || devices.length === 0) {
+    throw new Error("No Ledger device selected.");
+  }
+  const device = devices[0];
+  if (!device.opened) {
+    await device.open();
+  }
+  return device;
+}
+
+// returns the address of the account at the given path, without displaying it
+// on the device
+export async function getLedgerAddress(
+  device: any,
+  path: string = defaultLedgerPath
+): Promise<string> {
+  const response = await exchange(
+    device,
+    apdu(INS_GET_ADDRESS, serializePath(path))
+  );
+  const publicKeyLength = response[0];
+  const addressLength = response[1 + publicKeyLength];
+  const address = new TextDecoder().decode(
+    response.slice(2 + publicKeyLength, 2 + publicKeyLength + addressLength)
+  );
+  return ethers.utils.getAddress("0x" + address);
+}
+
+// signs the EIP-712 message given its domain and message hashes, as returned
+// by the gateway's /hardwarewallet/message endpoint. The signature is returned
+// in the r || s || v format the gateway expects.
+export async function signLedgerEIP712HashedMessage(
+  device: any,
+  domainHash: string,
+  messageHash: string,
+  path: string = defaultLedgerPath
+): Promise<string> {
+  const data = ethers.utils.concat([
+    serializePath(path),
+    ethers.utils.arrayify(domainHash),
+    ethers.utils.arrayify(messageHash),
+  ]);
+  const response = await exchange(device, apdu(INS_SIGN_EIP712, data));
+  const v = response.slice(0, 1);
+  const rs = response.slice(1, 65);
+  return ethers.utils.hexlify(ethers.utils.concat([rs, v]));
+}
+
+function serializePath(path: string): Uint8Array {
+  const elements = path.split("/").map((element) => {
+    const hardened = element.endsWith("'");
+    const index = parseInt(hardened ? element.slice(0, -1) : element, 10);
+    return hardened ? (index | 0x80000000) >>> 0 : index;
+  });
+  const result = new Uint8Array(1 + elements.length * 4);
+  const view = new DataView(result.buffer);
+  result[0] = elements.length;
+  elements.forEach((element, i) => view.setUint32(1 + i * 4, element));
+  return result;
+}
+
+function apdu(ins: number, data: Uint8Array): Uint8Array {
+  return ethers.utils.concat([[CLA_ETH, ins, 0x00, 0x00, data.length], data]);
+}
+
+// sends the APDU framed in HID packets, and returns the response data once the
+// status word was checked
+async function exchange(device: any, command: Uint8Array): Promise<Uint8Array> {
+  const response = new Promise<Uint8Array>((resolve) => {
+    let expectedLength = -1;
+    let received = new Uint8Array(0);
+    const onInputReport = (event: any) => {
+      const packet = new Uint8Array(event.data.buffer);
+      // skip the channel, the tag and the sequence index
+      let chunk = packet.slice(5);
+      if (expectedLength < 0) {
+        expectedLength = (chunk[0] << 8) | chunk[1];
+        chunk = chunk.slice(2);
+      }
+      received = ethers.utils.concat([received, chunk]);
+      if (received.length >= expectedLength) {
+        device.removeEventListener("inputreport", onInputReport);
+        resolve(received.slice(0, expectedLength));
+      }
+    };
+    device.addEventListener("inputreport", onInputReport);
+  });
+
+  const payload = ethers.utils.concat([
+    [command.length >> 8, command.length & 0xff],
+    command,
+  ]);
+  for (
+    let offset = 0, sequence = 0;
+    offset < payload.length;
+    offset += HID_PACKET_SIZE - 5, sequence++
+  ) {
+    const packet = new Uint8Array(HID_PACKET_SIZE);
+    packet.set([
+      HID_CHANNEL >> 8,
+      HID_CHANNEL & 0xff,
+      HID_TAG_APDU,
+      sequence >> 8,
+      sequence & 0xff,
+    ]);
+    packet.set(payload.slice(offset, offset + HID_PACKET_SIZE - 5), 5);
+    await device.sendReport(0, packet);
+  }
+
+  const result = await response;
+  const statusWord =
+    (result[result.length - 2] << 8) | result[result.length - 1];
+  if (statusWord !== SW_OK) {
+    throw new Error(
+      `Ledger returned the status 0x${statusWord.toString(16)}. Is the Ethereum app open and unlocked?`
+    );
+  }
+  return result.slice(0, result.length - 2);
+}
@@ -0,0 +1,283 @@
+import { ethers } from "ethers";
+
+// Talks to a Trezor device connected to the browser over WebUSB, so that the
+// authentication message is signed on the device and the key never leaves it.
+// Only the messages needed to authenticate an account are implemented, and
+// they are encoded by hand rather than with the whole protobuf schema.
+
+const TREZOR_VENDOR_ID = 0x1209;
+const TREZOR_PRODUCT_ID = 0x53c1;
+const USB_INTERFACE = 0;
+const USB_ENDPOINT = 1;
+const USB_PACKET_SIZE = 64;
+const PACKET_MAGIC = 0x3f; // '?'
+const HEADER_MAGIC = 0x23; // '#'
+
+const MSG_INITIALIZE = 0;
+const MSG_FAILURE = 3;
+const MSG_FEATURES = 17;
+const MSG_PIN_MATRIX_REQUEST = 18;
+const MSG_PIN_MATRIX_ACK = 19;
+const MSG_BUTTON_REQUEST = 26;
+const MSG_BUTTON_ACK = 27;
+const MSG_PASSPHRASE_REQUEST = 41;
+const MSG_PASSPHRASE_ACK = 42;
+const MSG_ETHEREUM_GET_ADDRESS = 56;
+const MSG_ETHEREUM_ADDRESS = 57;
+const MSG_ETHEREUM_TYPED_DATA_SIGNATURE = 467;
+const MSG_ETHEREUM_SIGN_TYPED_HASH = 836;
+
+const WIRE_VARINT = 0;
+const WIRE_BYTES = 2;
+
+export const defaultTrezorPath = "44'/60'/0'/0/0";
+
+// asks the user for the PIN, as the positions of its digits in the matrix
+// displayed by the device (laid out like a numeric keypad)
+export type PinRequester = () => Promise<string>;
+
+export function isWebUSBSupported(): boolean {
+  return typeof navigator !== "undefined" && "usb" in navigator;
+}
+
+// asks the user to select the Trezor device, opens it and starts a session
+export async function openTrezor(): Promise<any> {
+  if (!isWebUSBSupported()) {
+    throw new Error("WebUSB is not supported by this browser.");
+  }
+  const device = await (navigator as any).usb.requestDevice({
+    filters: [{ vendorId: TREZOR_VENDOR_ID, productId: TREZOR_PRODUCT_ID }],
+  });
+  if (!device) {
+    throw new Error("No Trezor device selected.");
+  }
+  if (!device.opened) {
+    await device.open();
+  }
+  if (device.configuration === null) {
+    await device.selectConfiguration(1);
+  }
+  await device.claimInterface(USB_INTERFACE);
+  await call(device, MSG_INITIALIZE, new Uint8Array(0), MSG_FEATURES);
+  return device;
+}
+
+export async function closeTrezor(device: any): Promise<void> {
+  if (device?.opened) {
+    await device.releaseInterface(USB_INTERFACE);
+    await device.close();
+  }
+}
+
+// returns the address of the account at the given path, without displaying it
+// on the device
+export async function getTrezorAddress(
+  device: any,
+  requestPin: PinRequester,
+  path: string = defaultTrezorPath
+): Promise<string> {
+  const response = await call(
+    device,
+    MSG_ETHEREUM_GET_ADDRESS,
+    ethers.utils.concat([encodePath(path), encodeVarintField(2, 0)]),
+    MSG_ETHEREUM_ADDRESS,
+    requestPin
+  );
+  return ethers.utils.getAddress(
+    new TextDecoder().decode(decodeFields(response)[2])
+  );
+}
+
+// signs the EIP-712 message given its domain and message hashes, as returned
+// by the gateway's /hardwarewallet/message endpoint. The signature is returned
+// in the r || s || v format the gateway expects.
+export async function signTrezorEIP712HashedMessage(
+  device: any,
+  requestPin: PinRequester,
+  domainHash: string,
+  messageHash: string,
+  path: string = defaultTrezorPath
+): Promise<string> {
+  const response = await call(
+    device,
+    MSG_ETHEREUM_SIGN_TYPED_HASH,
+    ethers.utils.concat([
+      encodePath(path),
+      encodeBytesField(2, ethers.utils.arrayify(domainHash)),
+      encodeBytesField(3, ethers.utils.arrayify(messageHash)),
+    ]),
+    MSG_ETHEREUM_TYPED_DATA_SIGNATURE,
+    requestPin
+  );
+  return ethers.utils.hexlify(decodeFields(response)[1]);
+}
+
+// sends the message and answers the requests of the device (button presses,
+// PIN and passphrase) until it returns the expected message
+async function call(
+  device: any,
+  type: number,
+  payload: Uint8Array,
+  expectedType: number,
+  requestPin?: PinRequester
+): Promise<Uint8Array> {
+  await write(device, type, payload);
+  for (;;) {
+    const response = await read(device);
+    switch (response.type) {
+      case expectedType:
+        return response.payload;
+      case MSG_BUTTON_REQUEST:
+        await write(device, MSG_BUTTON_ACK, new Uint8Array(0));
+        break;
+      case MSG_PIN_MATRIX_REQUEST: {
+        if (!requestPin) {
+          throw new Error("The Trezor is locked.");
+        }
+        const pin = await requestPin();
+        await write(
+          device,
+          MSG_PIN_MATRIX_ACK,
+          encodeBytesField(1, ethers.utils.toUtf8Bytes(pin))
+        );
+        break;
+      }
+      case MSG_PASSPHRASE_REQUEST:
+        // the accounts of the standard wallet, without passphrase, are used
+        await write(
+          device,
+          MSG_PASSPHRASE_ACK,
+          encodeBytesField(1, new Uint8Array(0))
+        );
+        break;
+      case MSG_FAILURE: {
+        const message = decodeFields(response.payload)[2];
+        throw new Error(
+          `Trezor returned a failure: ${
+            message ? new TextDecoder().decode(message) : "unknown error"
+          }`
+        );
+      }
+      default:
+        throw new Error(`Unexpected Trezor message ${response.type}.`);
+    }
+  }
+}
+
+// sends the message framed in USB packets: the first one holds the header with
+// the type and length of the message
+async function write(device: any, type: number, payload: Uint8Array) {
+  const header = new Uint8Array(8);
+  const view = new DataView(header.buffer);
+  header.set([HEADER_MAGIC, HEADER_MAGIC]);
+  view.setUint16(2, type);
+  view.setUint32(4, payload.length);
+  const message = ethers.utils.concat([header, payload]);
+  for (
+    let offset = 0;
+    offset < message.length;
+    offset += USB_PACKET_SIZE - 1
+  ) {
+    const packet = new Uint8Array(USB_PACKET_SIZE);
+    packet[0] = PACKET_MAGIC;
+    packet.set(message.slice(offset, offset + USB_PACKET_SIZE - 1), 1);
+    await device.transferOut(USB_ENDPOINT, packet);
+  }
+}
+
+async function read(
+  device: any
+): Promise<{ type: number; payload: Uint8Array }> {
+  const first = await readPacket(device);
+  if (first[0] !== HEADER_MAGIC || first[1] !== HEADER_MAGIC) {
+    throw new Error("Malformed message received from the Trezor.");
+  }
+  const view = new DataView(first.buffer, first.byteOffset);
+  const type = view.getUint16(2);
+  const length = view.getUint32(4);
+  let payload = first.slice(8);
+  while (payload.length < length) {
+    payload = ethers.utils.concat([payload, await readPacket(device)]);
+  }
+  return { type, payload: payload.slice(0, length) };
+}
+
+// returns the content of the next USB packet, without its magic byte
+async function readPacket(device: any): Promise<Uint8Array> {
+  const result = await device.transferIn(USB_ENDPOINT, USB_PACKET_SIZE);
+  const packet = new Uint8Array(result.data.buffer);
+  if (packet[0] !== PACKET_MAGIC) {
+    throw new Error("Malformed packet received from the Trezor.");
+  }
+  return packet.slice(1);
+}
+
+// the path is the repeated address_n field of the Ethereum messages
+function encodePath(path: string): Uint8Array {
+  const elements = path.split("/").map((element) => {
+    const hardened = element.endsWith("'");
+    const index = parseInt(hardened ? element.slice(0, -1) : element, 10);
+    return hardened ? (index | 0x80000000) >>> 0 : index;
+  });
+  return ethers.utils.concat(
+    elements.map((element) => encodeVarintField(1, element))
+  );
+}
+
+function encodeVarintField(field: number, value: number): Uint8Array {
+  return ethers.utils.concat([
+    encodeVarint((field << 3) | WIRE_VARINT),
+    encodeVarint(value),
+  ]);
+}
+
+function encodeBytesField(field: number, value: Uint8Array): Uint8Array {
+  return ethers.utils.concat([
+    encodeVarint((field << 3) | WIRE_BYTES),
+    encodeVarint(value.length),
+    value,
+  ]);
+}
+
+function encodeVarint(value: number): Uint8Array {
+  const bytes: number[] = [];
+  while (value > 0x7f) {
+    bytes.push((value & 0x7f) | 0x80);
+    value = Math.floor(value / 0x80);
+  }
+  bytes.push(value);
+  return new Uint8Array(bytes);
+}
+
+// returns the length-delimited fields of the message by number, skipping the
+// varint ones
+function decodeFields(message: Uint8Array): Record<number, Uint8Array> {
+  const fields: Record<number, Uint8Array> = {};
+  let offset = 0;
+  const readVarint = () => {
+    let value = 0;
+    let multiplier = 1;
+    for (;;) {
+      const byte = message[offset++];
+      value += (byte & 0x7f) * multiplier;
+      if ((byte & 0x80) === 0) {
+        return value;
+      }
+      multiplier *= 0x80;
+    }
+  };
+  while (offset < message.length) {
+    const key = readVarint();
+    const wireType = key & 0x07;
+    if (wireType === WIRE_VARINT) {
+      readVarint();
+    } else if (wireType === WIRE_BYTES) {
+      const length = readVarint();
+      fields[key >> 3] = message.slice(offset, offset + length);
+      offset += length;
+    } else {
+      throw new Error(`Unsupported protobuf wire type ${wireType}.`);
+    }
+  }
+  return fields;
+}
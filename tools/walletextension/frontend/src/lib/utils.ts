@@ -3,10 +3,10 @@ import { formatDistanceToNow } from "date-fns";
 import { twMerge } from "tailwind-merge";
 import {
   tenChainIDHex,
-  tenGatewayAddress,
   testnetUrls,
   tokenHexLength,
 } from "./constants";
+import { getGatewayAddress } from "../api";
 
 export function cn(...inputs: ClassValue[]) {
   return twMerge(clsx(inputs));
@@ -29,7 +29,9 @@ export function getRandomIntAsString(min: number, max: number) {
 }
 
 export function getNetworkName() {
-  switch (tenGatewayAddress) {
+  switch (getGatewayAddress()) {
+    case testnetUrls.local.url:
+      return testnetUrls.local.name;
     case testnetUrls.uat.url:
       return testnetUrls.uat.name;
     case testnetUrls.sepolia.url:
@@ -6,9 +6,12 @@ export const NavLinks: NavLink[] = [];
 export const apiRoutes = {
   join: `/${tenGatewayVersion}/join/`,
   authenticate: `/${tenGatewayVersion}/authenticate/`,
+  hardwareWalletMessage: `/${tenGatewayVersion}/hardwarewallet/message/`,
+  hardwareWalletAuthenticate: `/${tenGatewayVersion}/hardwarewallet/authenticate/`,
   queryAccountToken: `/${tenGatewayVersion}/query/`,
   revoke: `/${tenGatewayVersion}/revoke/`,
   version: `/version/`,
+  info: `/${tenGatewayVersion}/info/`,
 };
 
 export const requestMethods = {
@@ -1,10 +1,10 @@
 import { ToastType } from "@/types/interfaces";
 import { joinTestnet } from "../api/gateway";
+import { getGatewayAddress } from "../api";
 import { useWalletConnection } from "../components/providers/wallet-provider";
 import { showToast } from "../components/ui/use-toast";
 import {
   SWITCHED_CODE,
-  tenGatewayAddress,
   tenGatewayVersion,
 } from "../lib/constants";
 import { isTenChain, isValidTokenFormat } from "../lib/utils";
@@ -56,7 +56,7 @@ const useGatewayService = () => {
         showToast(ToastType.INFO, "Adding Obscuro Testnet...");
         const user = await joinTestnet();
         const rpcUrls = [
-          `${tenGatewayAddress}/${tenGatewayVersion}/?token=${user}`,
+          `${getGatewayAddress()}/${tenGatewayVersion}/?token=${user}`,
         ];
         await addNetworkToMetaMask(rpcUrls);
         showToast(ToastType.SUCCESS, "Added Obscuro Testnet");
@@ -1,3 +1,24 @@
 export type AuthenticationResponse = {
   status: boolean;
 };
+
+export type HardwareWalletMessage = {
+  typedData: Record<string, any>;
+  domainHash: string;
+  messageHash: string;
+};
+
+export type HardwareWalletSignature = {
+  device: string;
+  address: string;
+  signature: string;
+  domainHash: string;
+  messageHash: string;
+};
+
+export type GatewayInfo = {
+  version: string;
+  tenChainID: number;
+  local: boolean;
+  portWS: number;
+};
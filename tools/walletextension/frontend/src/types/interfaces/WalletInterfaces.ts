@@ -4,6 +4,7 @@ export interface WalletConnectionContextType {
   accounts: Account[] | null;
   walletConnected: boolean;
   connectAccount: (account: string) => Promise<void>;
+  connectHardwareWallet: (device: HardwareWalletDevice) => Promise<void>;
   token: string | null;
   version: string | null;
   revokeAccounts: () => void;
@@ -21,6 +22,16 @@ export interface WalletConnectionProviderProps {
   children: React.ReactNode;
 }
 
+export type HardwareWalletDevice = "ledger" | "trezor";
+
+// the operations of a hardware wallet connected to the browser needed to
+// authenticate its account
+export interface HardwareWalletSigner {
+  getAddress: () => Promise<string>;
+  signHashes: (domainHash: string, messageHash: string) => Promise<string>;
+  close: () => Promise<void>;
+}
+
 export type Account = {
   name: string;
   connected: boolean;
@@ -0,0 +1,86 @@
+package walletextension
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/signer/core/apitypes"
+	"github.com/ten-protocol/go-ten/go/common/viewingkey"
+)
+
+// HardwareWalletMessage is the message a hardware wallet signs to authenticate an address with a user.
+// The wallets that can parse EIP-712 messages sign the typed data, the others (e.g. Ledger with signEIP712HashedMessage,
+// or Trezor with ethereumSignTypedHash) sign the domain and message hashes. The signature is the same in both cases.
+// The device is connected to the user's browser (Ledger over WebHID, Trezor over WebUSB), never to the gateway, and the
+// signature is submitted to the /hardwarewallet/authenticate endpoint.
+type HardwareWalletMessage struct {
+	TypedData   *apitypes.TypedData `json:"typedData"`
+	DomainHash  string              `json:"domainHash"`
+	MessageHash string              `json:"messageHash"`
+}
+
+// HardwareWalletSignature is the signature of the authentication message made on a hardware wallet, together with the
+// hashes the device signed
+type HardwareWalletSignature struct {
+	Device      string `json:"device"`
+	Address     string `json:"address"`
+	Signature   string `json:"signature"`
+	DomainHash  string `json:"domainHash"`
+	MessageHash string `json:"messageHash"`
+}
+
+// the hardware wallets the gateway frontend can sign the authentication message with
+var hardwareWalletDevices = map[string]bool{
+	"ledger": true,
+	"trezor": true,
+}
+
+// GetHardwareWalletMessage returns the message to be signed by a hardware wallet to authenticate an address with the user
+func (w *WalletExtension) GetHardwareWalletMessage(hexUserID string) (*HardwareWalletMessage, error) {
+	typedData, err := viewingkey.GenerateAuthenticationEIP712TypedData(hexUserID, int64(w.config.TenChainID))
+	if err != nil {
+		return nil, err
+	}
+	domainHash, messageHash, err := viewingkey.GenerateAuthenticationEIP712Hashes(hexUserID, int64(w.config.TenChainID))
+	if err != nil {
+		return nil, err
+	}
+	return &HardwareWalletMessage{
+		TypedData:   typedData,
+		DomainHash:  hexutil.Encode(domainHash),
+		MessageHash: hexutil.Encode(messageHash),
+	}, nil
+}
+
+// AuthenticateHardwareWallet checks that the device signed the authentication message of the user, then adds the address
+// to the user. The devices that only sign hashes display the hashes instead of the message, so a device that signed the
+// hashes of another message is reported as such, rather than as an invalid signature.
+func (w *WalletExtension) AuthenticateHardwareWallet(hexUserID string, sig *HardwareWalletSignature) error {
+	if !hardwareWalletDevices[sig.Device] {
+		return fmt.Errorf("unsupported hardware wallet: %q", sig.Device)
+	}
+	domainHash, messageHash, err := viewingkey.GenerateAuthenticationEIP712Hashes(hexUserID, int64(w.config.TenChainID))
+	if err != nil {
+		return err
+	}
+	signedDomainHash, err := hexutil.Decode(sig.DomainHash)
+	if err != nil {
+		return fmt.Errorf("unable to decode the domain hash - %w", err)
+	}
+	signedMessageHash, err := hexutil.Decode(sig.MessageHash)
+	if err != nil {
+		return fmt.Errorf("unable to decode the message hash - %w", err)
+	}
+	if !bytes.Equal(signedDomainHash, domainHash) || !bytes.Equal(signedMessageHash, messageHash) {
+		return errors.New("the device did not sign the authentication message of the user")
+	}
+	signature, err := hexutil.Decode(sig.Signature)
+	if err != nil {
+		return fmt.Errorf("unable to decode the signature - %w", err)
+	}
+
+	w.logger.Info("Authenticating a hardware wallet account", "device", sig.Device, "address", sig.Address)
+	return w.AddAddressToUser(hexUserID, sig.Address, signature)
+}
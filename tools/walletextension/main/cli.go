@@ -1,8 +1,13 @@
 package main
 
 import (
+	"encoding/json"
 	"flag"
 	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
 
 	"github.com/ten-protocol/go-ten/tools/walletextension/config"
 )
@@ -55,6 +60,29 @@ const (
 	tenChainIDName      = "tenChainID"
 	tenChainIDDefault   = 443
 	tenChainIDFlagUsage = "ChainID of Ten network that the gateway is communicating with"
+
+	upstreamNodesName    = "upstreamNodes"
+	upstreamNodesDefault = ""
+	upstreamNodesUsage   = "A comma-separated list of host:port websocket addresses of the nodes sharing the requests of the default network, each optionally followed by =weight. Replaces nodeHost and nodePortWS when set. ex: node1:81=2,node2:81"
+
+	networksConfigName    = "networksConfig"
+	networksConfigDefault = ""
+	networksConfigUsage   = "The path to a JSON file listing the networks served in addition to the default one, each with its name, tenChainID, hostnames, nodeRPCWebsocketAddress, dbType, dbConnectionURL and databasePath"
+
+	localModeName    = "local"
+	localModeDefault = false
+	localModeUsage   = "Runs the gateway on the machine of its only user: it only listens on the loopback interface and keeps the viewing keys in a sqlite database in the home directory (.ten/gateway_database.db), unless databasePath is set"
+
+	gatewayOriginsName    = "gatewayOrigins"
+	gatewayOriginsDefault = ""
+	gatewayOriginsUsage   = "A comma-separated list of the web origins the gateway frontend is served from, when it is not served by the gateway itself. The accounts authenticated from these origins are not bound to them. ex: https://gateway.ten.xyz"
+
+	maxRequestSizeName    = "maxRequestSize"
+	maxRequestSizeDefault = config.DefaultMaxRequestSize
+	maxRequestSizeUsage   = "The maximum size of an HTTP request body or websocket message, in bytes"
+
+	// the database of the local gateway, relative to the home directory of the user
+	localDatabasePath = ".ten/gateway_database.db"
 )
 
 func parseCLIArgs() config.Config {
@@ -70,19 +98,96 @@ func parseCLIArgs() config.Config {
 	dbType := flag.String(dbTypeFlagName, dbTypeFlagDefault, dbTypeFlagUsage)
 	dbConnectionURL := flag.String(dbConnectionURLFlagName, dbConnectionURLFlagDefault, dbConnectionURLFlagUsage)
 	tenChainID := flag.Int(tenChainIDName, tenChainIDDefault, tenChainIDFlagUsage)
+	upstreamNodes := flag.String(upstreamNodesName, upstreamNodesDefault, upstreamNodesUsage)
+	networksConfig := flag.String(networksConfigName, networksConfigDefault, networksConfigUsage)
+	localMode := flag.Bool(localModeName, localModeDefault, localModeUsage)
+	gatewayOrigins := flag.String(gatewayOriginsName, gatewayOriginsDefault, gatewayOriginsUsage)
+	maxRequestSize := flag.Int64(maxRequestSizeName, maxRequestSizeDefault, maxRequestSizeUsage)
 	flag.Parse()
 
+	if *localMode && !isFlagSet(databasePathName) {
+		homeDir, err := os.UserHomeDir()
+		if err != nil {
+			panic(fmt.Sprintf("could not find the home directory for the local database. Cause: %s", err))
+		}
+		*databasePath = filepath.Join(homeDir, localDatabasePath)
+	}
+
+	var networks []config.NetworkConfig
+	if *networksConfig != "" {
+		networksJSON, err := os.ReadFile(*networksConfig)
+		if err != nil {
+			panic(fmt.Sprintf("could not read the networks config file. Cause: %s", err))
+		}
+		if err = json.Unmarshal(networksJSON, &networks); err != nil {
+			panic(fmt.Sprintf("could not parse the networks config file. Cause: %s", err))
+		}
+	}
+
+	upstreams, err := parseUpstreams(*upstreamNodes)
+	if err != nil {
+		panic(fmt.Sprintf("could not parse the upstream nodes. Cause: %s", err))
+	}
+
 	return config.Config{
 		WalletExtensionHost:     *walletExtensionHost,
 		WalletExtensionPortHTTP: *walletExtensionPort,
 		WalletExtensionPortWS:   *walletExtensionPortWS,
 		NodeRPCHTTPAddress:      fmt.Sprintf("%s:%d", *nodeHost, *nodeHTTPPort),
 		NodeRPCWebsocketAddress: fmt.Sprintf("%s:%d", *nodeHost, *nodeWebsocketPort),
+		Upstreams:               upstreams,
 		LogPath:                 *logPath,
 		DBPathOverride:          *databasePath,
 		VerboseFlag:             *verboseFlag,
 		DBType:                  *dbType,
 		DBConnectionURL:         *dbConnectionURL,
 		TenChainID:              *tenChainID,
+		Networks:                networks,
+		LocalMode:               *localMode,
+		GatewayOrigins:          parseOrigins(*gatewayOrigins),
+		MaxRequestSize:          *maxRequestSize,
+	}
+}
+
+// parseOrigins parses a comma-separated list of web origins
+func parseOrigins(origins string) []string {
+	var parsed []string
+	for _, origin := range strings.Split(origins, ",") {
+		if origin = strings.TrimSpace(origin); origin != "" {
+			parsed = append(parsed, origin)
+		}
+	}
+	return parsed
+}
+
+// isFlagSet returns whether the flag was passed on the command line
+func isFlagSet(name string) bool {
+	set := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			set = true
+		}
+	})
+	return set
+}
+
+// parses a list of upstreams in the node1:81=2,node2:81 format
+func parseUpstreams(upstreamNodes string) ([]config.UpstreamConfig, error) {
+	if upstreamNodes == "" {
+		return nil, nil
+	}
+	var upstreams []config.UpstreamConfig
+	for _, node := range strings.Split(upstreamNodes, ",") {
+		address, weightStr, hasWeight := strings.Cut(strings.TrimSpace(node), "=")
+		weight := 1
+		if hasWeight {
+			var err error
+			weight, err = strconv.Atoi(weightStr)
+			if err != nil {
+				return nil, fmt.Errorf("invalid weight %s for upstream %s", weightStr, address)
+			}
+		}
+		upstreams = append(upstreams, config.UpstreamConfig{Address: address, Weight: weight})
 	}
+	return upstreams, nil
 }
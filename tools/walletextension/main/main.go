@@ -9,6 +9,7 @@ import (
 
 	"github.com/ten-protocol/go-ten/go/common/log"
 	"github.com/ten-protocol/go-ten/tools/walletextension/common"
+	"github.com/ten-protocol/go-ten/tools/walletextension/config"
 	"github.com/ten-protocol/go-ten/tools/walletextension/container"
 
 	gethlog "github.com/ethereum/go-ethereum/log"
@@ -24,24 +25,18 @@ func main() {
 	fmt.Printf("Welcome to the Obscuro wallet extension. \n\n")
 	fmt.Printf("Starting with following config: \n%s\n", string(jsonConfig))
 
-	// We wait thirty seconds for a connection to the node. If we cannot establish one, we exit the program.
-	fmt.Printf("Waiting up to thirty seconds for connection to host at %s...\n", config.NodeRPCWebsocketAddress)
-	counter := 30
-	for {
-		conn, err := net.Dial(tcp, config.NodeRPCWebsocketAddress)
-		if conn != nil {
-			conn.Close()
-		}
-		if err == nil {
-			break
-		}
+	if err := config.Validate(); err != nil {
+		fmt.Printf("Exiting. Invalid config. Cause: %s\n", err)
+		return
+	}
 
-		counter--
-		if counter <= 0 {
-			fmt.Printf("Exiting. Could not establish connection to host at %s. Cause: %s\n", config.NodeRPCWebsocketAddress, err)
+	// We wait thirty seconds for a connection to a node of each network. If we cannot establish one, we exit the program.
+	// The pool ejects the nodes that are not reachable until they recover.
+	for _, network := range config.AllNetworks() {
+		if err := waitForAnyNode(network.AllUpstreams()); err != nil {
+			fmt.Printf("Exiting. Could not establish connection to a host of network %s. Cause: %s\n", network.Name, err)
 			return
 		}
-		time.Sleep(time.Second)
 	}
 
 	// Sets up the log file.
@@ -76,6 +71,39 @@ func main() {
 	walletExtensionAddr := fmt.Sprintf("%s:%d", common.Localhost, config.WalletExtensionPortHTTP)
 	fmt.Printf("💡 Wallet extension started \n") // Some tests rely on seeing this message. Removed in next PR.
 	fmt.Printf("💡 Obscuro Gateway started - visit http://%s to use it.\n", walletExtensionAddr)
+	if config.LocalMode {
+		fmt.Printf("💡 Running as a local gateway. The viewing keys are stored in %s and never leave this machine.\n", config.DBPathOverride)
+	}
 
 	select {}
 }
+
+func waitForAnyNode(upstreams []config.UpstreamConfig) error {
+	var err error
+	for _, upstream := range upstreams {
+		if err = waitForNode(upstream.Address); err == nil {
+			return nil
+		}
+	}
+	return err
+}
+
+func waitForNode(address string) error {
+	fmt.Printf("Waiting up to thirty seconds for connection to host at %s...\n", address)
+	counter := 30
+	for {
+		conn, err := net.Dial(tcp, address)
+		if conn != nil {
+			conn.Close()
+		}
+		if err == nil {
+			return nil
+		}
+
+		counter--
+		if counter <= 0 {
+			return err
+		}
+		time.Sleep(time.Second)
+	}
+}
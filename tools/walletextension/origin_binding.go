@@ -0,0 +1,126 @@
+package walletextension
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ten-protocol/go-ten/tools/walletextension/common"
+	"golang.org/x/exp/slices"
+)
+
+// ErrOriginNotAuthorised is returned when an encryption token is used by a web origin it was not authorised by
+var ErrOriginNotAuthorised = errors.New("encryption token not authorised for this origin")
+
+// The viewing keys of a user are bound to the web origins (i.e. the dapps) that authenticated its accounts, so that a
+// token granted to one dapp cannot be reused by another dapp running in the same browser.
+// The requests without an origin and the ones sent by the wallets from their browser extension are not bound, and
+// neither are the authentications done by the gateway frontend, which the user trusts with all their dapps. A token
+// that was never authenticated from a dapp can be used from any origin. Once it is bound, the requests from an opaque
+// origin (i.e. "null", sent by the sandboxed iframes and the local files, or any other scheme) are rejected, since any
+// page can send them.
+
+// the kinds of origin of the requests
+type originKind int
+
+const (
+	unboundOrigin originKind = iota // no origin, a wallet extension or the gateway frontend
+	dappOrigin                      // the web origin of a dapp
+	opaqueOrigin                    // an origin that does not identify a web page
+)
+
+// the schemes of the origins of the browser extensions
+var extensionSchemes = []string{"chrome-extension", "moz-extension", "safari-web-extension"}
+
+// BindUserToOrigin binds the user to the web origin of the request, after the request authenticated an account
+func (w *WalletExtension) BindUserToOrigin(hexUserID string, req *http.Request) error {
+	origin, kind := w.requestOrigin(req)
+	if kind != dappOrigin {
+		return nil
+	}
+	userIDBytes, err := common.GetUserIDbyte(hexUserID)
+	if err != nil {
+		return fmt.Errorf("error decoding userID. It should be in hex format - %w", err)
+	}
+	return w.storage.AddUserOrigin(userIDBytes, origin)
+}
+
+// CheckUserOrigin returns ErrOriginNotAuthorised when the user is bound to some web origins, and the request comes from
+// another one, or from an opaque origin
+func (w *WalletExtension) CheckUserOrigin(hexUserID string, req *http.Request) error {
+	origin, kind := w.requestOrigin(req)
+	if kind == unboundOrigin {
+		return nil
+	}
+	userIDBytes, err := common.GetUserIDbyte(hexUserID)
+	if err != nil {
+		return fmt.Errorf("error decoding userID. It should be in hex format - %w", err)
+	}
+	origins, err := w.storage.GetUserOrigins(userIDBytes)
+	if err != nil {
+		return fmt.Errorf("could not read the origins of the user - %w", err)
+	}
+	if len(origins) == 0 {
+		return nil
+	}
+	if kind == opaqueOrigin {
+		return fmt.Errorf("%w: the origin %q does not identify a web page. Use the gateway from the page of a dapp that authenticated an account with it", ErrOriginNotAuthorised, origin)
+	}
+	if slices.Contains(origins, origin) {
+		return nil
+	}
+	return fmt.Errorf("%w: %s did not authenticate any account with it. Authenticate an account from %s to use the gateway there", ErrOriginNotAuthorised, origin, origin)
+}
+
+// requestOrigin returns the origin of the request and its kind. The web origins are returned in lower case
+func (w *WalletExtension) requestOrigin(req *http.Request) (string, originKind) {
+	if req == nil {
+		return "", unboundOrigin
+	}
+	header := strings.TrimSpace(req.Header.Get("Origin"))
+	if header == "" {
+		return "", unboundOrigin
+	}
+	origin, ok := webOrigin(header)
+	if !ok {
+		if isExtensionOrigin(header) {
+			return "", unboundOrigin
+		}
+		return header, opaqueOrigin
+	}
+	// the frontend is either served by the gateway itself, or configured as one of its origins
+	if origin == "http://"+strings.ToLower(req.Host) || origin == "https://"+strings.ToLower(req.Host) {
+		return "", unboundOrigin
+	}
+	for _, gatewayOrigin := range w.config.GatewayOrigins {
+		if normalised, ok := webOrigin(gatewayOrigin); ok && normalised == origin {
+			return "", unboundOrigin
+		}
+	}
+	return origin, dappOrigin
+}
+
+// webOrigin returns the origin of a web page, in lower case, and false for the origins that are not web pages (e.g.
+// "null" or chrome-extension://...)
+func webOrigin(origin string) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(origin))
+	if err != nil || u.Host == "" {
+		return "", false
+	}
+	scheme := strings.ToLower(u.Scheme)
+	if scheme != "http" && scheme != "https" {
+		return "", false
+	}
+	return scheme + "://" + strings.ToLower(u.Host), true
+}
+
+// isExtensionOrigin returns whether the origin is the one of a browser extension, e.g. a wallet
+func isExtensionOrigin(origin string) bool {
+	u, err := url.Parse(origin)
+	if err != nil || u.Host == "" {
+		return false
+	}
+	return slices.Contains(extensionSchemes, strings.ToLower(u.Scheme))
+}
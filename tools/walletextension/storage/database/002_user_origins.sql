@@ -0,0 +1,8 @@
+USE ogdb;
+
+CREATE TABLE IF NOT EXISTS ogdb.user_origins (
+    user_id varbinary(20),
+    origin varchar(255),
+    PRIMARY KEY(user_id, origin),
+    FOREIGN KEY(user_id) REFERENCES users(user_id) ON DELETE CASCADE
+    );
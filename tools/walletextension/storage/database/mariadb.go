@@ -2,13 +2,17 @@ package database
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 
-	_ "github.com/go-sql-driver/mysql" // Importing MariaDB driver
+	"github.com/go-sql-driver/mysql"
 	"github.com/ten-protocol/go-ten/go/common/errutil"
 	"github.com/ten-protocol/go-ten/tools/walletextension/common"
 )
 
+// the MariaDB error number of a query on a table that does not exist
+const errNoSuchTable = 1146
+
 type MariaDB struct {
 	db *sql.DB
 }
@@ -20,6 +24,16 @@ func NewMariaDB(dbURL string) (*MariaDB, error) {
 		return nil, fmt.Errorf("failed to connect to database: %w", err)
 	}
 
+	// the schema is created by the init scripts of the database container, which only run on a new database. The
+	// databases created before a migration was added have to be migrated by hand
+	if _, err = db.Exec("SELECT 1 FROM user_origins LIMIT 1"); err != nil {
+		var mysqlErr *mysql.MySQLError
+		if errors.As(err, &mysqlErr) && mysqlErr.Number == errNoSuchTable {
+			return nil, fmt.Errorf("the user_origins table is missing, apply storage/database/002_user_origins.sql to the database: %w", err)
+		}
+		return nil, fmt.Errorf("failed to connect to database: %w", err)
+	}
+
 	return &MariaDB{db: db}, nil
 }
 
@@ -127,3 +141,45 @@ func (m *MariaDB) GetAllUsers() ([]common.UserDB, error) {
 
 	return users, nil
 }
+
+func (m *MariaDB) AddUserOrigin(userID []byte, origin string) error {
+	stmt, err := m.db.Prepare("INSERT IGNORE INTO user_origins(user_id, origin) VALUES (?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(userID, origin)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (m *MariaDB) GetUserOrigins(userID []byte) ([]string, error) {
+	return queryUserOrigins(m.db, userID)
+}
+
+// queryUserOrigins returns the web origins the user is bound to, the query being the same in both databases
+func queryUserOrigins(db *sql.DB, userID []byte) ([]string, error) {
+	rows, err := db.Query("SELECT origin FROM user_origins WHERE user_id = ?", userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var origins []string
+	for rows.Next() {
+		var origin string
+		if err := rows.Scan(&origin); err != nil {
+			return nil, err
+		}
+		origins = append(origins, origin)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return origins, nil
+}
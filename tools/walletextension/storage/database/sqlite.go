@@ -59,6 +59,18 @@ func NewSqliteDatabase(dbPath string) (*SqliteDatabase, error) {
 		return nil, err
 	}
 
+	// create user origins table
+	_, err = db.Exec(`CREATE TABLE IF NOT EXISTS user_origins (
+		user_id binary(20),
+		origin varchar(255),
+		PRIMARY KEY(user_id, origin),
+		FOREIGN KEY(user_id) REFERENCES users(user_id) ON DELETE CASCADE
+	);`)
+
+	if err != nil {
+		return nil, err
+	}
+
 	return &SqliteDatabase{db: db}, nil
 }
 
@@ -167,6 +179,25 @@ func (s *SqliteDatabase) GetAllUsers() ([]common.UserDB, error) {
 	return users, nil
 }
 
+func (s *SqliteDatabase) AddUserOrigin(userID []byte, origin string) error {
+	stmt, err := s.db.Prepare("INSERT OR IGNORE INTO user_origins(user_id, origin) VALUES (?, ?)")
+	if err != nil {
+		return err
+	}
+	defer stmt.Close()
+
+	_, err = stmt.Exec(userID, origin)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (s *SqliteDatabase) GetUserOrigins(userID []byte) ([]string, error) {
+	return queryUserOrigins(s.db, userID)
+}
+
 func createOrLoad(dbPath string) (string, error) {
 	// If path is empty we create a random throwaway temp file, otherwise we use the path to the database
 	if dbPath == "" {
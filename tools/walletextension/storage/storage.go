@@ -14,6 +14,8 @@ type Storage interface {
 	AddAccount(userID []byte, accountAddress []byte, signature []byte) error
 	GetAccounts(userID []byte) ([]common.AccountDB, error)
 	GetAllUsers() ([]common.UserDB, error)
+	AddUserOrigin(userID []byte, origin string) error
+	GetUserOrigins(userID []byte) ([]string, error)
 }
 
 func New(dbType string, dbConnectionURL, dbPath string) (Storage, error) {
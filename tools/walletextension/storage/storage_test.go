@@ -14,6 +14,7 @@ var tests = map[string]func(storage Storage, t *testing.T){
 	"testAddAndGetAccounts": testAddAndGetAccounts,
 	"testDeleteUser":        testDeleteUser,
 	"testGetAllUsers":       testGetAllUsers,
+	"testAddAndGetOrigins":  testAddAndGetOrigins,
 }
 
 func TestSQLiteGatewayDB(t *testing.T) {
@@ -144,3 +145,44 @@ func testGetAllUsers(storage Storage, t *testing.T) {
 		t.Errorf("Expected user count to increase by 1. Got %d initially and %d after insert", len(initialUsers), len(afterInsertUsers))
 	}
 }
+
+func testAddAndGetOrigins(storage Storage, t *testing.T) {
+	userID := []byte("originsUserID")
+	privateKey := []byte("originsPrivateKey")
+
+	err := storage.AddUser(userID, privateKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	origins, err := storage.GetUserOrigins(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	require.Empty(t, origins)
+
+	// binding the same origin twice is a no-op
+	for _, origin := range []string{"https://dapp-a.example", "https://dapp-b.example", "https://dapp-a.example"} {
+		err = storage.AddUserOrigin(userID, origin)
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	origins, err = storage.GetUserOrigins(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	require.ElementsMatch(t, []string{"https://dapp-a.example", "https://dapp-b.example"}, origins)
+
+	err = storage.DeleteUser(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	origins, err = storage.GetUserOrigins(userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	require.Empty(t, origins)
+}
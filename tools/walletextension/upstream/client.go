@@ -0,0 +1,56 @@
+package upstream
+
+import (
+	"context"
+
+	"github.com/ten-protocol/go-ten/go/rpc"
+
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// pooledClient is an rpc.Client which sends each call to the next upstream of the pool, and retries it once on another
+// upstream when the request did not go through. The subscriptions stay on the upstream they were created on.
+type pooledClient struct {
+	pool *Pool
+}
+
+func (c *pooledClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *pooledClient) CallContext(ctx context.Context, result interface{}, method string, args ...interface{}) error {
+	var tried *upstream
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		var u *upstream
+		var client rpc.Client
+		u, client, err = c.pool.next(tried)
+		if err != nil {
+			if u == nil {
+				return err
+			}
+			tried = u
+			continue
+		}
+		err = client.CallContext(ctx, result, method, args...)
+		c.pool.recordResult(u, err)
+		if err == nil || !isTransportFailure(err) || ctx.Err() != nil {
+			return err
+		}
+		tried = u
+	}
+	return err
+}
+
+func (c *pooledClient) Subscribe(ctx context.Context, result interface{}, namespace string, channel interface{}, args ...interface{}) (*gethrpc.ClientSubscription, error) {
+	u, client, err := c.pool.next(nil)
+	if err != nil {
+		return nil, err
+	}
+	sub, err := client.Subscribe(ctx, result, namespace, channel, args...)
+	c.pool.recordResult(u, err)
+	return sub, err
+}
+
+// Stop does nothing, the connections to the upstreams are shared by the clients and closed by the pool
+func (c *pooledClient) Stop() {}
@@ -0,0 +1,250 @@
+package upstream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/rpc"
+	"github.com/ten-protocol/go-ten/tools/walletextension/config"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	wecommon "github.com/ten-protocol/go-ten/tools/walletextension/common"
+)
+
+const (
+	healthCheckInterval = 10 * time.Second
+	healthCheckTimeout  = 5 * time.Second
+	// an upstream is ejected after this many consecutive failed requests, or after a failed health check
+	maxConsecutiveFailures = 3
+	// an ejected upstream receives requests again after this time, or when it passes a health check
+	ejectionTime = 30 * time.Second
+)
+
+var errNoUpstream = errors.New("no upstream node configured")
+
+// Pool spreads the requests of a network over its upstream nodes, with a smooth weighted round-robin. The upstreams
+// that fail their health checks, or too many requests in a row, are ejected from the rotation for a while.
+// The pool holds a single connection per upstream, shared by all the clients of the users.
+type Pool struct {
+	upstreams []*upstream
+	dial      func(address string) (rpc.Client, error)
+	logger    gethlog.Logger
+
+	lock sync.Mutex
+	stop chan struct{}
+}
+
+type upstream struct {
+	address string
+	weight  int
+
+	// the fields below are guarded by the lock of the pool
+	client              rpc.Client // dialed lazily
+	currentWeight       int
+	consecutiveFailures int
+	ejectedUntil        time.Time
+}
+
+// NewPool returns a pool of the upstreams, whose websocket connections are dialed on first use
+func NewPool(upstreams []config.UpstreamConfig, logger gethlog.Logger) *Pool {
+	return newPool(upstreams, func(address string) (rpc.Client, error) {
+		return rpc.NewNetworkClient(wecommon.WSProtocol + address)
+	}, logger)
+}
+
+func newPool(upstreams []config.UpstreamConfig, dial func(address string) (rpc.Client, error), logger gethlog.Logger) *Pool {
+	p := &Pool{dial: dial, logger: logger, stop: make(chan struct{})}
+	for _, u := range upstreams {
+		weight := u.Weight
+		if weight <= 0 {
+			weight = 1
+		}
+		p.upstreams = append(p.upstreams, &upstream{address: u.Address, weight: weight})
+	}
+	return p
+}
+
+// Start runs the periodic health checks of the upstreams
+func (p *Pool) Start() {
+	go func() {
+		ticker := time.NewTicker(healthCheckInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				p.checkHealth()
+			case <-p.stop:
+				return
+			}
+		}
+	}()
+}
+
+// Stop ends the health checks and closes the connections to the upstreams
+func (p *Pool) Stop() {
+	close(p.stop)
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	for _, u := range p.upstreams {
+		if u.client != nil {
+			u.client.Stop()
+			u.client = nil
+		}
+	}
+}
+
+// NewClient returns a client whose calls are spread over the upstreams
+func (p *Pool) NewClient() rpc.Client {
+	return &pooledClient{pool: p}
+}
+
+// returns the next upstream of the rotation, other than the excluded one if possible, with its connection
+func (p *Pool) next(excluded *upstream) (*upstream, rpc.Client, error) {
+	selected := p.selectUpstream(excluded)
+	if selected == nil {
+		return nil, nil, errNoUpstream
+	}
+	client, err := p.connect(selected)
+	if err != nil {
+		p.recordResult(selected, err)
+		return selected, nil, err
+	}
+	return selected, client, nil
+}
+
+func (p *Pool) selectUpstream(excluded *upstream) *upstream {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if len(p.upstreams) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	candidates := make([]*upstream, 0, len(p.upstreams))
+	for _, u := range p.upstreams {
+		if u != excluded && !now.Before(u.ejectedUntil) {
+			candidates = append(candidates, u)
+		}
+	}
+	// when all the upstreams are ejected, we keep trying them rather than failing every request
+	if len(candidates) == 0 {
+		candidates = p.upstreams
+	}
+
+	// the smooth weighted round-robin of nginx, which interleaves the upstreams instead of sending bursts to each
+	var selected *upstream
+	totalWeight := 0
+	for _, u := range candidates {
+		u.currentWeight += u.weight
+		totalWeight += u.weight
+		if selected == nil || u.currentWeight > selected.currentWeight {
+			selected = u
+		}
+	}
+	selected.currentWeight -= totalWeight
+	return selected
+}
+
+// returns the connection to the upstream, dialing it if needed. The dial happens outside the lock, so that a slow
+// upstream does not hold the requests to the others.
+func (p *Pool) connect(u *upstream) (rpc.Client, error) {
+	p.lock.Lock()
+	client := u.client
+	p.lock.Unlock()
+	if client != nil {
+		return client, nil
+	}
+
+	client, err := p.dial(u.address)
+	if err != nil {
+		return nil, fmt.Errorf("could not connect to upstream %s - %w", u.address, err)
+	}
+
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	// another request connected to the upstream in the meantime
+	if u.client != nil {
+		client.Stop()
+		return u.client, nil
+	}
+	u.client = client
+	return client, nil
+}
+
+// records the outcome of a request to the upstream. Only the transport failures count, the errors returned by the node
+// are the responses to the request.
+func (p *Pool) recordResult(u *upstream, err error) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+	if err != nil && isTransportFailure(err) {
+		p.recordFailure(u, err)
+		return
+	}
+	u.consecutiveFailures = 0
+}
+
+// must be called with the lock held
+func (p *Pool) recordFailure(u *upstream, err error) {
+	u.consecutiveFailures++
+	if u.consecutiveFailures >= maxConsecutiveFailures && !time.Now().Before(u.ejectedUntil) {
+		p.eject(u, err)
+	}
+}
+
+// must be called with the lock held
+func (p *Pool) eject(u *upstream, err error) {
+	u.ejectedUntil = time.Now().Add(ejectionTime)
+	p.logger.Warn("Ejecting upstream node", "address", u.address, "until", u.ejectedUntil, log.ErrKey, err)
+}
+
+func (p *Pool) checkHealth() {
+	for _, u := range p.upstreams {
+		err := p.checkUpstreamHealth(u)
+
+		p.lock.Lock()
+		if err != nil {
+			if !time.Now().Before(u.ejectedUntil) {
+				p.eject(u, err)
+			} else {
+				u.ejectedUntil = time.Now().Add(ejectionTime)
+			}
+		} else if time.Now().Before(u.ejectedUntil) {
+			p.logger.Info("Upstream node is healthy again", "address", u.address)
+			u.ejectedUntil = time.Time{}
+			u.consecutiveFailures = 0
+		}
+		p.lock.Unlock()
+	}
+}
+
+func (p *Pool) checkUpstreamHealth(u *upstream) error {
+	client, err := p.connect(u)
+	if err != nil {
+		return err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), healthCheckTimeout)
+	defer cancel()
+	var health struct{ OverallHealth bool }
+	if err := client.CallContext(ctx, &health, rpc.Health); err != nil {
+		return err
+	}
+	if !health.OverallHealth {
+		return fmt.Errorf("the node reported itself unhealthy")
+	}
+	return nil
+}
+
+// the errors returned by the node are JSON-RPC errors, the others mean that the request did not go through
+func isTransportFailure(err error) bool {
+	var rpcErr gethrpc.Error
+	if errors.As(err, &rpcErr) || errors.Is(err, context.Canceled) || errors.Is(err, rpc.ErrNilResponse) {
+		return false
+	}
+	return true
+}
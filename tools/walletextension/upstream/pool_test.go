@@ -0,0 +1,124 @@
+package upstream
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/rpc"
+	"github.com/ten-protocol/go-ten/tools/walletextension/config"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+)
+
+// fakeClient records the calls it receives, and fails them with its err
+type fakeClient struct {
+	address string
+	calls   *[]string
+	err     error
+	healthy bool
+}
+
+func (c *fakeClient) Call(result interface{}, method string, args ...interface{}) error {
+	return c.CallContext(context.Background(), result, method, args...)
+}
+
+func (c *fakeClient) CallContext(_ context.Context, result interface{}, method string, _ ...interface{}) error {
+	if method == rpc.Health {
+		result.(*struct{ OverallHealth bool }).OverallHealth = c.healthy
+		return c.err
+	}
+	*c.calls = append(*c.calls, c.address)
+	return c.err
+}
+
+func (c *fakeClient) Subscribe(context.Context, interface{}, string, interface{}, ...interface{}) (*gethrpc.ClientSubscription, error) {
+	*c.calls = append(*c.calls, c.address)
+	return nil, c.err
+}
+
+func (c *fakeClient) Stop() {}
+
+type rpcError struct{}
+
+func (rpcError) Error() string  { return "execution reverted" }
+func (rpcError) ErrorCode() int { return 3 }
+
+func newTestPool(upstreams []config.UpstreamConfig) (*Pool, map[string]*fakeClient, *[]string) {
+	calls := &[]string{}
+	clients := map[string]*fakeClient{}
+	for _, u := range upstreams {
+		clients[u.Address] = &fakeClient{address: u.Address, calls: calls, healthy: true}
+	}
+	pool := newPool(upstreams, func(address string) (rpc.Client, error) {
+		return clients[address], nil
+	}, gethlog.New())
+	return pool, clients, calls
+}
+
+func TestRequestsAreSpreadByWeight(t *testing.T) {
+	pool, _, calls := newTestPool([]config.UpstreamConfig{{Address: "a", Weight: 3}, {Address: "b"}})
+	client := pool.NewClient()
+	for i := 0; i < 8; i++ {
+		require.NoError(t, client.Call(nil, "eth_blockNumber"))
+	}
+	// the smooth round-robin interleaves the upstreams
+	require.Equal(t, []string{"a", "a", "b", "a", "a", "a", "b", "a"}, *calls)
+}
+
+func TestFailedRequestsAreRetriedAndEjectTheUpstream(t *testing.T) {
+	pool, clients, calls := newTestPool([]config.UpstreamConfig{{Address: "a"}, {Address: "b"}})
+	clients["a"].err = errors.New("connection refused")
+	client := pool.NewClient()
+
+	for i := 0; i < 2*maxConsecutiveFailures; i++ {
+		require.NoError(t, client.Call(nil, "eth_blockNumber"))
+	}
+	// the requests sent to a are retried on b, until a is ejected
+	require.Equal(t, []string{"a", "b", "b", "a", "b", "b", "a", "b", "b"}, *calls)
+
+	// the upstream receives the requests again when it passes a health check
+	clients["a"].err = nil
+	pool.checkHealth()
+	*calls = nil
+	require.NoError(t, client.Call(nil, "eth_blockNumber"))
+	require.NoError(t, client.Call(nil, "eth_blockNumber"))
+	require.ElementsMatch(t, []string{"a", "b"}, *calls)
+}
+
+func TestNodeErrorsDoNotEjectTheUpstream(t *testing.T) {
+	pool, clients, calls := newTestPool([]config.UpstreamConfig{{Address: "a"}, {Address: "b"}})
+	clients["a"].err = rpcError{}
+	client := pool.NewClient()
+
+	for i := 0; i < 2*maxConsecutiveFailures; i++ {
+		_ = client.Call(nil, "eth_call")
+	}
+	// the node errors are returned to the user without retry
+	require.Equal(t, []string{"a", "b", "a", "b", "a", "b"}, *calls)
+}
+
+func TestUnhealthyUpstreamsAreEjected(t *testing.T) {
+	pool, clients, calls := newTestPool([]config.UpstreamConfig{{Address: "a"}, {Address: "b"}})
+	clients["b"].healthy = false
+	pool.checkHealth()
+
+	client := pool.NewClient()
+	for i := 0; i < 3; i++ {
+		require.NoError(t, client.Call(nil, "eth_blockNumber"))
+	}
+	require.Equal(t, []string{"a", "a", "a"}, *calls)
+	require.True(t, time.Now().Before(pool.upstreams[1].ejectedUntil))
+}
+
+func TestAllUpstreamsEjectedStillServeRequests(t *testing.T) {
+	pool, clients, calls := newTestPool([]config.UpstreamConfig{{Address: "a"}})
+	clients["a"].healthy = false
+	pool.checkHealth()
+
+	require.NoError(t, pool.NewClient().Call(nil, "eth_blockNumber"))
+	require.Equal(t, []string{"a"}, *calls)
+}
@@ -10,22 +10,23 @@ import (
 	"github.com/ten-protocol/go-ten/tools/walletextension/accountmanager"
 	wecommon "github.com/ten-protocol/go-ten/tools/walletextension/common"
 	"github.com/ten-protocol/go-ten/tools/walletextension/storage"
+	"github.com/ten-protocol/go-ten/tools/walletextension/upstream"
 )
 
 type UserAccountManager struct {
 	userAccountManager    map[string]*accountmanager.AccountManager
 	unauthenticatedClient rpc.Client
 	storage               storage.Storage
-	hostRPCBinAddr        string
+	upstreams             *upstream.Pool
 	logger                gethlog.Logger
 }
 
-func NewUserAccountManager(unauthenticatedClient rpc.Client, logger gethlog.Logger, storage storage.Storage, hostRPCBindAddr string) UserAccountManager {
+func NewUserAccountManager(unauthenticatedClient rpc.Client, logger gethlog.Logger, storage storage.Storage, upstreams *upstream.Pool) UserAccountManager {
 	return UserAccountManager{
 		userAccountManager:    make(map[string]*accountmanager.AccountManager),
 		unauthenticatedClient: unauthenticatedClient,
 		storage:               storage,
-		hostRPCBinAddr:        hostRPCBindAddr,
+		upstreams:             upstreams,
 		logger:                logger,
 	}
 }
@@ -87,7 +88,7 @@ func (m *UserAccountManager) GetUserAccountManager(userID string) (*accountmanag
 		}
 
 		// create a new client
-		encClient, err := wecommon.CreateEncClient(m.hostRPCBinAddr, account.AccountAddress, userPrivateKey, account.Signature, m.logger)
+		encClient, err := wecommon.CreateEncClient(m.upstreams.NewClient(), account.AccountAddress, userPrivateKey, account.Signature, m.logger)
 		if err != nil {
 			m.logger.Error(fmt.Errorf("error creating new client, %w", err).Error())
 		}
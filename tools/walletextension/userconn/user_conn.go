@@ -1,6 +1,7 @@
 package userconn
 
 import (
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
@@ -29,9 +30,10 @@ type UserConn interface {
 
 // Represents a user's connection over HTTP.
 type userConnHTTP struct {
-	resp   http.ResponseWriter
-	req    *http.Request
-	logger gethlog.Logger
+	resp           http.ResponseWriter
+	req            *http.Request
+	maxRequestSize int64
+	logger         gethlog.Logger
 }
 
 // Represents a user's connection websockets.
@@ -43,11 +45,14 @@ type userConnWS struct {
 	mu       sync.Mutex
 }
 
-func NewUserConnHTTP(resp http.ResponseWriter, req *http.Request, logger gethlog.Logger) UserConn {
-	return &userConnHTTP{resp: resp, req: req, logger: logger}
+// NewUserConnHTTP returns the connection of an HTTP request, whose body is rejected beyond maxRequestSize bytes
+func NewUserConnHTTP(resp http.ResponseWriter, req *http.Request, maxRequestSize int64, logger gethlog.Logger) UserConn {
+	return &userConnHTTP{resp: resp, req: req, maxRequestSize: maxRequestSize, logger: logger}
 }
 
-func NewUserConnWS(resp http.ResponseWriter, req *http.Request, logger gethlog.Logger) (UserConn, error) {
+// NewUserConnWS upgrades the request to a websocket connection, which is closed by a message larger than maxRequestSize
+// bytes
+func NewUserConnWS(resp http.ResponseWriter, req *http.Request, maxRequestSize int64, logger gethlog.Logger) (UserConn, error) {
 	// We search all the request's headers. If there's a websocket upgrade header, we upgrade to a websocket connection.
 	conn, err := upgrader.Upgrade(resp, req, nil)
 	if err != nil {
@@ -56,6 +61,7 @@ func NewUserConnWS(resp http.ResponseWriter, req *http.Request, logger gethlog.L
 		logger.Error("unable to upgrade to websocket connection", log.ErrKey, err)
 		return nil, err
 	}
+	conn.SetReadLimit(maxRequestSize)
 
 	return &userConnWS{
 		conn:   conn,
@@ -65,7 +71,7 @@ func NewUserConnWS(resp http.ResponseWriter, req *http.Request, logger gethlog.L
 }
 
 func (h *userConnHTTP) ReadRequest() ([]byte, error) {
-	body, err := io.ReadAll(h.req.Body)
+	body, err := io.ReadAll(http.MaxBytesReader(h.resp, h.req.Body, h.maxRequestSize))
 	if err != nil {
 		return nil, fmt.Errorf("could not read request body: %w", err)
 	}
@@ -99,7 +105,8 @@ func (h *userConnHTTP) GetHTTPRequest() *http.Request {
 func (w *userConnWS) ReadRequest() ([]byte, error) {
 	_, msg, err := w.conn.ReadMessage()
 	if err != nil {
-		if websocket.IsCloseError(err) {
+		if websocket.IsCloseError(err) || errors.Is(err, websocket.ErrReadLimit) {
+			// the connection is closed after a message over the read limit
 			w.isClosed = true
 		}
 		return nil, fmt.Errorf("could not read request: %w", err)
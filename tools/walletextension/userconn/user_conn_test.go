@@ -0,0 +1,63 @@
+package userconn
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTTPRequestSizeLimit(t *testing.T) {
+	for _, tc := range []struct {
+		body    string
+		allowed bool
+	}{
+		{body: strings.Repeat("a", 16), allowed: true},
+		{body: strings.Repeat("a", 17)},
+	} {
+		req := httptest.NewRequest(http.MethodPost, "/", strings.NewReader(tc.body))
+		conn := NewUserConnHTTP(httptest.NewRecorder(), req, 16, gethlog.New())
+		body, err := conn.ReadRequest()
+		if !tc.allowed {
+			require.Error(t, err)
+			continue
+		}
+		require.NoError(t, err)
+		require.Equal(t, tc.body, string(body))
+	}
+}
+
+func TestWebsocketRequestSizeLimit(t *testing.T) {
+	type result struct {
+		body   string
+		err    error
+		closed bool
+	}
+	results := make(chan result, 2)
+	server := httptest.NewServer(http.HandlerFunc(func(resp http.ResponseWriter, req *http.Request) {
+		conn, err := NewUserConnWS(resp, req, 16, gethlog.New())
+		require.NoError(t, err)
+		for i := 0; i < 2; i++ {
+			body, err := conn.ReadRequest()
+			results <- result{body: string(body), err: err, closed: conn.IsClosed()}
+		}
+	}))
+	defer server.Close()
+
+	client, _, err := websocket.DefaultDialer.Dial("ws"+strings.TrimPrefix(server.URL, "http"), nil)
+	require.NoError(t, err)
+	defer client.Close()
+	require.NoError(t, client.WriteMessage(websocket.TextMessage, []byte("small")))
+	require.NoError(t, client.WriteMessage(websocket.TextMessage, []byte(strings.Repeat("a", 17))))
+
+	small := <-results
+	require.NoError(t, small.err)
+	require.Equal(t, "small", small.body)
+	large := <-results
+	require.ErrorIs(t, large.err, websocket.ErrReadLimit)
+	require.True(t, large.closed)
+}
@@ -12,6 +12,8 @@ import (
 
 	"github.com/ten-protocol/go-ten/go/common/log"
 
+	obscurocommon "github.com/ten-protocol/go-ten/go/common"
+
 	"github.com/ten-protocol/go-ten/tools/walletextension/useraccountmanager"
 
 	"github.com/ethereum/go-ethereum/crypto"
@@ -22,6 +24,7 @@ import (
 	"github.com/ten-protocol/go-ten/go/rpc"
 	"github.com/ten-protocol/go-ten/tools/walletextension/common"
 	"github.com/ten-protocol/go-ten/tools/walletextension/storage"
+	"github.com/ten-protocol/go-ten/tools/walletextension/upstream"
 	"github.com/ten-protocol/go-ten/tools/walletextension/userconn"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
@@ -30,7 +33,7 @@ import (
 
 // WalletExtension handles the management of viewing keys and the forwarding of Ethereum JSON-RPC requests.
 type WalletExtension struct {
-	hostAddr           string // The address on which the Obscuro host can be reached.
+	upstreams          *upstream.Pool // The Obscuro hosts which serve the requests.
 	userAccountManager *useraccountmanager.UserAccountManager
 	unsignedVKs        map[gethcommon.Address]*viewingkey.ViewingKey // Map temporarily holding VKs that have been generated but not yet signed
 	storage            storage.Storage
@@ -41,7 +44,7 @@ type WalletExtension struct {
 }
 
 func New(
-	hostAddr string,
+	upstreams *upstream.Pool,
 	userAccountManager *useraccountmanager.UserAccountManager,
 	storage storage.Storage,
 	stopControl *stopcontrol.StopControl,
@@ -50,7 +53,7 @@ func New(
 	config *config.Config,
 ) *WalletExtension {
 	return &WalletExtension{
-		hostAddr:           hostAddr,
+		upstreams:          upstreams,
 		userAccountManager: userAccountManager,
 		unsignedVKs:        map[gethcommon.Address]*viewingkey.ViewingKey{},
 		storage:            storage,
@@ -66,6 +69,16 @@ func (w *WalletExtension) IsStopping() bool {
 	return w.stopControl.IsStopping()
 }
 
+// Stop closes the connections to the upstream nodes
+func (w *WalletExtension) Stop() {
+	w.upstreams.Stop()
+}
+
+// MaxRequestSize returns the maximum size of a request of the users
+func (w *WalletExtension) MaxRequestSize() int64 {
+	return w.config.RequestSizeLimit()
+}
+
 // Logger returns the WE set logger
 func (w *WalletExtension) Logger() gethlog.Logger {
 	return w.logger
@@ -89,6 +102,33 @@ func (w *WalletExtension) ProxyEthRequest(request *common.RPCRequest, conn userc
 		}
 	}
 
+	// ten_simulateTransaction is served by the enclave as a custom query sent through eth_getStorageAt
+	if request.Method == common.SimulateTransactionMethodName {
+		query, err := toSimulateTransactionQuery(request)
+		if err != nil {
+			return nil, err
+		}
+		request = query
+	}
+
+	// ten_getTransactionByNonce is served by the enclave as a custom query sent through eth_getStorageAt
+	if request.Method == common.TransactionByNonceMethodName {
+		query, err := toTransactionByNonceQuery(request)
+		if err != nil {
+			return nil, err
+		}
+		request = query
+	}
+
+	// ten_getTransactionError is served by the enclave as a custom query sent through eth_getStorageAt
+	if request.Method == common.TransactionErrorMethodName {
+		query, err := toTransactionErrorQuery(request)
+		if err != nil {
+			return nil, err
+		}
+		request = query
+	}
+
 	// get account manager for current user (if there is no users in the query parameters - use defaultUser for WE endpoints)
 	selectedAccountManager, err := w.userAccountManager.GetUserAccountManager(hexUserID)
 	if err != nil {
@@ -155,8 +195,7 @@ func (w *WalletExtension) SubmitViewingKey(address gethcommon.Address, signature
 		return fmt.Errorf("error saving user: %s", common.DefaultUser)
 	}
 	// create an encrypted RPC client with the signed VK and register it with the enclave
-	// todo (@ziga) - Create the clients lazily, to reduce connections to the host.
-	client, err := rpc.NewEncNetworkClient(w.hostAddr, vk, w.logger)
+	client, err := rpc.NewEncRPCClient(w.upstreams.NewClient(), vk, w.logger)
 	if err != nil {
 		return fmt.Errorf("failed to create encrypted RPC client for account %s - %w", address, err)
 	}
@@ -212,9 +251,12 @@ func (w *WalletExtension) AddAddressToUser(hexUserID string, address string, sig
 	addressFromMessage := gethcommon.HexToAddress(address)
 	// check if a message was signed by the correct address and if the signature is valid
 	valid, err := viewingkey.VerifySignatureEIP712(hexUserID, &addressFromMessage, signature, int64(w.config.TenChainID))
-	if !valid && err != nil {
+	if err != nil {
 		return fmt.Errorf("signature is not valid: %w", err)
 	}
+	if !valid {
+		return errors.New("signature is not valid")
+	}
 
 	// register the account for that viewing key
 	userIDBytes, err := common.GetUserIDbyte(hexUserID)
@@ -236,7 +278,7 @@ func (w *WalletExtension) AddAddressToUser(hexUserID string, address string, sig
 
 	accManager := w.userAccountManager.AddAndReturnAccountManager(hexUserID)
 
-	encClient, err := common.CreateEncClient(w.hostAddr, addressFromMessage.Bytes(), privateKeyBytes, signature, w.Logger())
+	encClient, err := common.CreateEncClient(w.upstreams.NewClient(), addressFromMessage.Bytes(), privateKeyBytes, signature, w.Logger())
 	if err != nil {
 		w.Logger().Error(fmt.Errorf("error creating encrypted client for user: (%s), %w", hexUserID, err).Error())
 		return fmt.Errorf("error creating encrypted client for user: (%s), %w", hexUserID, err)
@@ -381,6 +423,90 @@ func (w *WalletExtension) checkParametersForInterceptedGetStorageAt(params []int
 	return false
 }
 
+// toSimulateTransactionQuery translates a ten_simulateTransaction request, whose parameters are the transaction and an
+// optional block number, to the custom query the enclave serves through eth_getStorageAt
+func toSimulateTransactionQuery(request *common.RPCRequest) (*common.RPCRequest, error) {
+	if len(request.Params) != 1 && len(request.Params) != 2 {
+		return nil, fmt.Errorf("%s expects the transaction and an optional block number, but %d parameters received",
+			common.SimulateTransactionMethodName, len(request.Params))
+	}
+
+	var blockNumber interface{} = "latest"
+	if len(request.Params) == 2 && request.Params[1] != nil {
+		if _, ok := request.Params[1].(string); !ok {
+			return nil, fmt.Errorf("the block number must be a hex string or a tag")
+		}
+		blockNumber = request.Params[1]
+	}
+
+	query := request.Clone()
+	query.Method = rpc.GetStorageAt
+	query.Params = []interface{}{obscurocommon.SimulateTransactionCQMethod, request.Params[0], blockNumber}
+	return query, nil
+}
+
+// toTransactionByNonceQuery translates a ten_getTransactionByNonce request, whose parameters are the address of the
+// sender and the nonce, to the custom query the enclave serves through eth_getStorageAt
+func toTransactionByNonceQuery(request *common.RPCRequest) (*common.RPCRequest, error) {
+	if len(request.Params) != 2 {
+		return nil, fmt.Errorf("%s expects the address of the sender and the nonce, but %d parameters received",
+			common.TransactionByNonceMethodName, len(request.Params))
+	}
+	address, ok := request.Params[0].(string)
+	if !ok || !gethcommon.IsHexAddress(address) {
+		return nil, fmt.Errorf("the sender must be a hex address")
+	}
+	nonce, ok := request.Params[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("the nonce must be a hex string")
+	}
+
+	query := request.Clone()
+	query.Method = rpc.GetStorageAt
+	query.Params = []interface{}{
+		obscurocommon.TransactionByNonceCQMethod,
+		map[string]interface{}{"address": address, "nonce": nonce},
+		nil,
+	}
+	return query, nil
+}
+
+// toTransactionErrorQuery translates a ten_getTransactionError request, whose parameters are the address of the requesting
+// account and the hash of the transaction, to the custom query the enclave serves through eth_getStorageAt
+func toTransactionErrorQuery(request *common.RPCRequest) (*common.RPCRequest, error) {
+	if len(request.Params) != 2 {
+		return nil, fmt.Errorf("%s expects the address of the account and the hash of the transaction, but %d parameters received",
+			common.TransactionErrorMethodName, len(request.Params))
+	}
+	address, ok := request.Params[0].(string)
+	if !ok || !gethcommon.IsHexAddress(address) {
+		return nil, fmt.Errorf("the account must be a hex address")
+	}
+	txHash, ok := request.Params[1].(string)
+	if !ok {
+		return nil, fmt.Errorf("the transaction hash must be a hex string")
+	}
+
+	query := request.Clone()
+	query.Method = rpc.GetStorageAt
+	query.Params = []interface{}{
+		obscurocommon.TransactionErrorCQMethod,
+		map[string]interface{}{"address": address, "txHash": txHash},
+		nil,
+	}
+	return query, nil
+}
+
 func (w *WalletExtension) Version() string {
 	return w.version
 }
+
+// Info describes the gateway and the network it serves
+func (w *WalletExtension) Info() *common.GatewayInfo {
+	return &common.GatewayInfo{
+		Version:    w.version,
+		TenChainID: w.config.TenChainID,
+		Local:      w.config.LocalMode,
+		PortWS:     w.config.WalletExtensionPortWS,
+	}
+}
@@ -0,0 +1,200 @@
+package walletextension
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common/viewingkey"
+	"github.com/ten-protocol/go-ten/go/rpc"
+	"github.com/ten-protocol/go-ten/tools/walletextension/common"
+	"github.com/ten-protocol/go-ten/tools/walletextension/config"
+	"github.com/ten-protocol/go-ten/tools/walletextension/storage"
+
+	gethlog "github.com/ethereum/go-ethereum/log"
+	obscurocommon "github.com/ten-protocol/go-ten/go/common"
+)
+
+const testChainID = 443
+
+func TestAddAddressToUserRejectsInvalidSignatures(t *testing.T) {
+	db, err := storage.New("sqlite", "", "")
+	require.NoError(t, err)
+	w := New(nil, nil, db, nil, "", gethlog.New(), &config.Config{TenChainID: testChainID})
+
+	userID := make([]byte, 20)
+	require.NoError(t, db.AddUser(userID, []byte("privateKey")))
+	hexUserID := hex.EncodeToString(userID)
+
+	signer, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	rawData, err := viewingkey.GenerateAuthenticationEIP712RawDataOptions(hexUserID, testChainID)
+	require.NoError(t, err)
+	signature, err := crypto.Sign(crypto.Keccak256(rawData[0]), signer)
+	require.NoError(t, err)
+	otherMessageSignature, err := crypto.Sign(crypto.Keccak256([]byte("another message")), signer)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name      string
+		address   string
+		signature []byte
+	}{
+		{name: "signed by another account", address: crypto.PubkeyToAddress(other.PublicKey).Hex(), signature: signature},
+		{name: "another message", address: crypto.PubkeyToAddress(signer.PublicKey).Hex(), signature: otherMessageSignature},
+		{name: "truncated signature", address: crypto.PubkeyToAddress(signer.PublicKey).Hex(), signature: signature[:64]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := w.AddAddressToUser(hexUserID, tt.address, append([]byte{}, tt.signature...))
+			require.ErrorContains(t, err, "signature is not valid")
+			require.False(t, strings.Contains(err.Error(), "%!"), "malformed error: %s", err)
+
+			accounts, err := db.GetAccounts(userID)
+			require.NoError(t, err)
+			require.Empty(t, accounts)
+		})
+	}
+}
+
+func TestAuthenticateHardwareWalletChecksTheSignedHashes(t *testing.T) {
+	db, err := storage.New("sqlite", "", "")
+	require.NoError(t, err)
+	w := New(nil, nil, db, nil, "", gethlog.New(), &config.Config{TenChainID: testChainID})
+
+	userID := make([]byte, 20)
+	require.NoError(t, db.AddUser(userID, []byte("privateKey")))
+	hexUserID := hex.EncodeToString(userID)
+	otherUserID := strings.Repeat("01", 20)
+
+	signer, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	other, err := crypto.GenerateKey()
+	require.NoError(t, err)
+	address := crypto.PubkeyToAddress(signer.PublicKey)
+	// the devices sign keccak256(0x1901 || domainHash || messageHash) and return v as 27/28
+	signHashes := func(userID string) *HardwareWalletSignature {
+		domainHash, messageHash, err := viewingkey.GenerateAuthenticationEIP712Hashes(userID, testChainID)
+		require.NoError(t, err)
+		signature, err := crypto.Sign(crypto.Keccak256([]byte{0x19, 0x01}, domainHash, messageHash), signer)
+		require.NoError(t, err)
+		signature[64] += 27
+		return &HardwareWalletSignature{
+			Device:      "trezor",
+			Address:     address.Hex(),
+			Signature:   hexutil.Encode(signature),
+			DomainHash:  hexutil.Encode(domainHash),
+			MessageHash: hexutil.Encode(messageHash),
+		}
+	}
+
+	unsupported := signHashes(hexUserID)
+	unsupported.Device = "keepkey"
+	require.ErrorContains(t, w.AuthenticateHardwareWallet(hexUserID, unsupported), "unsupported hardware wallet")
+
+	// the hashes of the message of another user are reported before the signature is checked
+	require.ErrorContains(t, w.AuthenticateHardwareWallet(hexUserID, signHashes(otherUserID)), "did not sign the authentication message")
+
+	// the hashes are right, but the signature was made by another account
+	signedByOther := signHashes(hexUserID)
+	signedByOther.Address = crypto.PubkeyToAddress(other.PublicKey).Hex()
+	require.ErrorContains(t, w.AuthenticateHardwareWallet(hexUserID, signedByOther), "signature is not valid")
+
+	// the signature of the device is the one the gateway expects for the EIP-712 message
+	valid := signHashes(hexUserID)
+	signature, err := hexutil.Decode(valid.Signature)
+	require.NoError(t, err)
+	ok, err := viewingkey.VerifySignatureEIP712(hexUserID, &address, signature, testChainID)
+	require.NoError(t, err)
+	require.True(t, ok)
+
+	accounts, err := db.GetAccounts(userID)
+	require.NoError(t, err)
+	require.Empty(t, accounts)
+}
+
+func TestUserIsBoundToTheOriginsThatAuthenticatedIt(t *testing.T) {
+	db, err := storage.New("sqlite", "", "")
+	require.NoError(t, err)
+	w := New(nil, nil, db, nil, "", gethlog.New(), &config.Config{TenChainID: testChainID, GatewayOrigins: []string{"https://gateway.example"}})
+
+	userID := make([]byte, 20)
+	require.NoError(t, db.AddUser(userID, []byte("privateKey")))
+	hexUserID := hex.EncodeToString(userID)
+
+	request := func(origin string) *http.Request {
+		req, err := http.NewRequest(http.MethodPost, "http://localhost:3000/v1/", nil)
+		require.NoError(t, err)
+		if origin != "" {
+			req.Header.Set("Origin", origin)
+		}
+		return req
+	}
+
+	// the gateway frontend does not bind the user, so the token can be used from any origin until a dapp authenticates
+	require.NoError(t, w.BindUserToOrigin(hexUserID, request("https://gateway.example")))
+	require.NoError(t, w.BindUserToOrigin(hexUserID, request("http://localhost:3000")))
+	require.NoError(t, w.CheckUserOrigin(hexUserID, request("https://dapp-b.example")))
+
+	require.NoError(t, w.BindUserToOrigin(hexUserID, request("https://DAPP-A.example")))
+	require.NoError(t, w.CheckUserOrigin(hexUserID, request("https://dapp-a.example")))
+	err = w.CheckUserOrigin(hexUserID, request("https://dapp-b.example"))
+	require.ErrorIs(t, err, ErrOriginNotAuthorised)
+	require.ErrorContains(t, err, "https://dapp-b.example")
+
+	// the requests without an origin, sent by the wallets, or by the gateway frontend are not checked
+	for _, origin := range []string{"", "chrome-extension://nkbihfbeogaeaoehlefnkodbefgpgknn", "moz-extension://0b2f6bd4-7b8e-4b5a-9b39-1b8f2e0f6b6c", "https://gateway.example", "http://localhost:3000"} {
+		require.NoError(t, w.CheckUserOrigin(hexUserID, request(origin)), origin)
+	}
+
+	// any page can send the requests of an opaque origin, e.g. from a sandboxed iframe, so they cannot use a bound token
+	for _, origin := range []string{"null", "file://", "data:text/html,test", "ftp://dapp-a.example"} {
+		require.ErrorIs(t, w.CheckUserOrigin(hexUserID, request(origin)), ErrOriginNotAuthorised, origin)
+		require.NoError(t, w.BindUserToOrigin(hexUserID, request(origin)), origin)
+	}
+
+	// a token that is not bound yet can still be used from an opaque origin
+	unboundUserID := make([]byte, 20)
+	unboundUserID[0] = 1
+	require.NoError(t, db.AddUser(unboundUserID, []byte("privateKey")))
+	require.NoError(t, w.CheckUserOrigin(hex.EncodeToString(unboundUserID), request("null")))
+}
+
+func TestToSimulateTransactionQuery(t *testing.T) {
+	tx := map[string]interface{}{"to": "0x71C7656EC7ab88b098defB751B7401B5f6d8976F", "data": "0x"}
+	tests := []struct {
+		name           string
+		params         []interface{}
+		expectedParams []interface{}
+	}{
+		{name: "latest batch by default", params: []interface{}{tx}, expectedParams: []interface{}{obscurocommon.SimulateTransactionCQMethod, tx, "latest"}},
+		{name: "null block number", params: []interface{}{tx, nil}, expectedParams: []interface{}{obscurocommon.SimulateTransactionCQMethod, tx, "latest"}},
+		{name: "block number", params: []interface{}{tx, "0x10"}, expectedParams: []interface{}{obscurocommon.SimulateTransactionCQMethod, tx, "0x10"}},
+		{name: "no transaction", params: []interface{}{}},
+		{name: "block number not a string", params: []interface{}{tx, 16}},
+		{name: "too many parameters", params: []interface{}{tx, "latest", "latest"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &common.RPCRequest{ID: json.RawMessage("1"), Method: common.SimulateTransactionMethodName, Params: tt.params}
+			query, err := toSimulateTransactionQuery(request)
+			if tt.expectedParams == nil {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, rpc.GetStorageAt, query.Method)
+			require.Equal(t, tt.expectedParams, query.Params)
+			require.Equal(t, request.ID, query.ID)
+			// the original request is left untouched
+			require.Equal(t, common.SimulateTransactionMethodName, request.Method)
+		})
+	}
+}
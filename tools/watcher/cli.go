@@ -0,0 +1,90 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"time"
+
+	"github.com/ten-protocol/go-ten/go/common"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// Flag names, defaults and usages.
+	l1RPCName    = "l1RPC"
+	l1RPCDefault = "http://127.0.0.1:8545"
+	l1RPCUsage   = "The http address of the L1 node the management contract is read from. Default: http://127.0.0.1:8545"
+
+	nodeRPCName    = "nodeRPC"
+	nodeRPCDefault = ""
+	nodeRPCUsage   = "The http address of the TEN node whose batch headers are checked. No node is watched if empty"
+
+	managementContractName    = "managementContract"
+	managementContractDefault = ""
+	managementContractUsage   = "The address of the management contract on the L1"
+
+	sequencerIDName    = "sequencerID"
+	sequencerIDDefault = ""
+	sequencerIDUsage   = "The address of the owner of the sequencer enclave, as published in its attestation"
+
+	startBlockName    = "startBlock"
+	startBlockDefault = 0
+	startBlockUsage   = "The first L1 block scanned, usually the block the management contract was deployed in. Default: 0"
+
+	confirmationsName    = "confirmations"
+	confirmationsDefault = common.HeightCommittedBlocks
+	confirmationsUsage   = "The number of blocks an L1 block must be buried under before it is scanned. Default: 15"
+
+	pollIntervalName    = "pollInterval"
+	pollIntervalDefault = 12 * time.Second
+	pollIntervalUsage   = "The interval between the polls of the L1 and of the TEN node. Default: 12s"
+
+	portName    = "port"
+	portDefault = 8080
+	portUsage   = "The port the status API is served on. Default: 8080"
+)
+
+type watcherConfig struct {
+	l1RPC              string
+	nodeRPC            string
+	managementContract gethcommon.Address
+	sequencerID        gethcommon.Address
+	startBlock         uint64
+	confirmations      uint64
+	pollInterval       time.Duration
+	port               uint
+}
+
+func parseCLIArgs() (*watcherConfig, error) {
+	l1RPC := flag.String(l1RPCName, l1RPCDefault, l1RPCUsage)
+	nodeRPC := flag.String(nodeRPCName, nodeRPCDefault, nodeRPCUsage)
+	managementContract := flag.String(managementContractName, managementContractDefault, managementContractUsage)
+	sequencerID := flag.String(sequencerIDName, sequencerIDDefault, sequencerIDUsage)
+	startBlock := flag.Uint64(startBlockName, startBlockDefault, startBlockUsage)
+	confirmations := flag.Uint64(confirmationsName, confirmationsDefault, confirmationsUsage)
+	pollInterval := flag.Duration(pollIntervalName, pollIntervalDefault, pollIntervalUsage)
+	port := flag.Uint(portName, portDefault, portUsage)
+	flag.Parse()
+
+	if !gethcommon.IsHexAddress(*managementContract) {
+		return nil, fmt.Errorf("invalid management contract address %q", *managementContract)
+	}
+	if !gethcommon.IsHexAddress(*sequencerID) {
+		return nil, fmt.Errorf("invalid sequencer ID %q", *sequencerID)
+	}
+	if *pollInterval <= 0 {
+		return nil, fmt.Errorf("the poll interval must be positive")
+	}
+
+	return &watcherConfig{
+		l1RPC:              *l1RPC,
+		nodeRPC:            *nodeRPC,
+		managementContract: gethcommon.HexToAddress(*managementContract),
+		sequencerID:        gethcommon.HexToAddress(*sequencerID),
+		startBlock:         *startBlock,
+		confirmations:      *confirmations,
+		pollInterval:       *pollInterval,
+		port:               *port,
+	}, nil
+}
@@ -0,0 +1,103 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/ten-protocol/go-ten/go/common/clock"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/ethadapter"
+	"github.com/ten-protocol/go-ten/go/ethadapter/mgmtcontractlib"
+	"github.com/ten-protocol/go-ten/go/lightclient"
+	"github.com/ten-protocol/go-ten/go/obsclient"
+	"github.com/ten-protocol/go-ten/tools/watcher/watcher"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	l1Timeout       = 30 * time.Second
+	shutdownTimeout = 5 * time.Second
+)
+
+// Tool following the management contract on the L1, and optionally the public batch headers of a TEN node, to monitor the
+// behaviour of the sequencer independently. It runs without an enclave, so it needs no SGX hardware. The status is served
+// as JSON on /status.
+//
+// Example:
+//
+//	watcher -l1RPC https://sepolia.infura.io/v3/... -nodeRPC http://erpc.testnet.ten.xyz:80 \
+//	  -managementContract 0x... -sequencerID 0x... -startBlock 4000000 -port 8080
+func main() {
+	cfg, err := parseCLIArgs()
+	if err != nil {
+		exit(err)
+	}
+
+	logger := gethlog.New()
+	logger.SetHandler(gethlog.LvlFilterHandler(gethlog.LvlInfo, gethlog.StreamHandler(os.Stderr, gethlog.TerminalFormat(false))))
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+	if err = run(ctx, cfg, logger); err != nil {
+		exit(err)
+	}
+}
+
+func run(ctx context.Context, cfg *watcherConfig, logger gethlog.Logger) error {
+	l1Client, err := ethadapter.NewEthClientFromURL(cfg.l1RPC, l1Timeout, gethcommon.Address{}, logger)
+	if err != nil {
+		return fmt.Errorf("could not connect to the L1 node - %w", err)
+	}
+	defer l1Client.Stop()
+
+	var node watcher.NodeClient
+	if cfg.nodeRPC != "" {
+		obsClient, dialErr := obsclient.Dial(cfg.nodeRPC)
+		if dialErr != nil {
+			return fmt.Errorf("could not connect to the TEN node - %w", dialErr)
+		}
+		defer obsClient.Close()
+		node = obsClient
+	}
+
+	mgmtLib := mgmtcontractlib.NewMgmtContractLib(&cfg.managementContract, logger)
+	client := lightclient.New(l1Client, mgmtLib, lightclient.Config{
+		SequencerID:   cfg.sequencerID,
+		StartBlock:    cfg.startBlock,
+		Confirmations: cfg.confirmations,
+	}, logger)
+	w := watcher.New(client, node, cfg.pollInterval, clock.New(), logger)
+
+	listener, err := net.Listen("tcp", net.JoinHostPort("", strconv.FormatUint(uint64(cfg.port), 10)))
+	if err != nil {
+		return fmt.Errorf("could not listen on port %d - %w", cfg.port, err)
+	}
+	server := &http.Server{Handler: w.Handler(), ReadHeaderTimeout: shutdownTimeout}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Error("Status server stopped unexpectedly", log.ErrKey, err)
+		}
+	}()
+	logger.Info("Watcher started", "port", cfg.port, "managementContract", cfg.managementContract, "sequencer", cfg.sequencerID)
+
+	w.Run(ctx)
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return server.Shutdown(shutdownCtx)
+}
+
+func exit(err error) {
+	fmt.Fprintf(os.Stderr, "watcher stopped - %s\n", err)
+	os.Exit(1)
+}
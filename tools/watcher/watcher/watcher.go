@@ -0,0 +1,204 @@
+package watcher
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+	"github.com/ten-protocol/go-ten/go/common/log"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
+	"github.com/ten-protocol/go-ten/go/ethadapter"
+	"github.com/ten-protocol/go-ten/go/lightclient"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+const (
+	StatusPath   = "/status"
+	LivenessPath = "/livez"
+)
+
+// NodeClient is the subset of the TEN node client used to read the public batch headers
+type NodeClient interface {
+	// BatchHeaderByNumber returns the head batch if the number is nil
+	BatchHeaderByNumber(number *big.Int) (*common.BatchHeader, error)
+}
+
+// Status is the view of the network served by the watcher
+type Status struct {
+	NextL1Block       uint64                 `json:"nextL1Block"`
+	SequencerAttested bool                   `json:"sequencerAttested"`
+	ManagementTxs     map[string]uint64      `json:"managementTxs"` // the number of management contract transactions, by type
+	LatestRollup      *lightclient.Inclusion `json:"latestRollup"`
+	HeadBatch         *BatchStatus           `json:"headBatch,omitempty"` // only set if a TEN node is watched
+	LastPoll          time.Time              `json:"lastPoll"`
+	LastError         string                 `json:"lastError,omitempty"`
+}
+
+// BatchStatus is the state of the head batch served by the TEN node
+type BatchStatus struct {
+	SeqNo          tentypes.BatchSeqNo `json:"seqNo"`
+	Hash           gethcommon.Hash     `json:"hash"`
+	ValidSignature bool                `json:"validSignature"`
+	// UnpublishedBatches is the number of batches produced by the sequencer since the last rollup published on the L1
+	UnpublishedBatches uint64 `json:"unpublishedBatches"`
+}
+
+// Watcher follows the management contract on the L1, and optionally the public batch headers of a TEN node, so anyone
+// can monitor the behaviour of the sequencer without an enclave
+type Watcher struct {
+	client       *lightclient.Client
+	node         NodeClient // nil if no node is watched
+	pollInterval time.Duration
+	clock        clock.Clock
+	logger       gethlog.Logger
+
+	lock   sync.RWMutex
+	status Status
+}
+
+func New(client *lightclient.Client, node NodeClient, pollInterval time.Duration, clock clock.Clock, logger gethlog.Logger) *Watcher {
+	w := &Watcher{
+		client:       client,
+		node:         node,
+		pollInterval: pollInterval,
+		clock:        clock,
+		logger:       logger,
+		status:       Status{ManagementTxs: map[string]uint64{}},
+	}
+	client.Subscribe(w)
+	return w
+}
+
+// Run polls the L1 and the TEN node until the context is done
+func (w *Watcher) Run(ctx context.Context) {
+	ticker := w.clock.NewTicker(w.pollInterval)
+	defer ticker.Stop()
+	for {
+		w.Poll()
+		select {
+		case <-ticker.C():
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// Poll syncs the light client with the L1, and checks the head batch of the TEN node against it
+func (w *Watcher) Poll() {
+	err := w.client.Sync()
+	if err != nil {
+		w.logger.Warn("Could not sync with the L1", log.ErrKey, err)
+	}
+
+	var headBatch *BatchStatus
+	if w.node != nil {
+		var headErr error
+		headBatch, headErr = w.checkHeadBatch()
+		if headErr != nil {
+			w.logger.Warn("Could not check the head batch of the TEN node", log.ErrKey, headErr)
+			err = errors.Join(err, headErr)
+		}
+	}
+
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.status.NextL1Block = w.client.NextBlock()
+	w.status.SequencerAttested = w.client.SequencerAttested()
+	w.status.LatestRollup = w.client.LatestRollup()
+	if headBatch != nil {
+		w.status.HeadBatch = headBatch
+	}
+	w.status.LastPoll = w.clock.Now()
+	w.status.LastError = ""
+	if err != nil {
+		w.status.LastError = err.Error()
+	}
+}
+
+func (w *Watcher) checkHeadBatch() (*BatchStatus, error) {
+	header, err := w.node.BatchHeaderByNumber(nil)
+	if err != nil {
+		return nil, fmt.Errorf("could not fetch the head batch - %w", err)
+	}
+	seqNo, err := tentypes.BatchSeqNoFromBig(header.SequencerOrderNo)
+	if err != nil {
+		return nil, fmt.Errorf("invalid head batch - %w", err)
+	}
+
+	status := &BatchStatus{SeqNo: seqNo, Hash: header.Hash()}
+	if err = w.client.VerifyBatchHeader(header); err == nil {
+		status.ValidSignature = true
+	} else if errors.Is(err, lightclient.ErrInvalidSignature) {
+		w.logger.Error("The head batch served by the TEN node was not signed by the sequencer", "seqNo", seqNo, "hash", status.Hash)
+	}
+
+	published := tentypes.BatchSeqNo(0)
+	if rollup := w.client.LatestRollup(); rollup != nil {
+		published = rollup.LastBatchSeqNo
+	}
+	if seqNo > published {
+		status.UnpublishedBatches = uint64(seqNo - published)
+	}
+	return status, nil
+}
+
+// HandleManagementTx counts the management contract transactions by type
+func (w *Watcher) HandleManagementTx(_ *types.Block, _ *types.Transaction, l1Tx ethadapter.L1Transaction) {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+	w.status.ManagementTxs[txType(l1Tx)]++
+}
+
+// Status returns a copy of the current status
+func (w *Watcher) Status() Status {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	status := w.status
+	status.ManagementTxs = make(map[string]uint64, len(w.status.ManagementTxs))
+	for txType, count := range w.status.ManagementTxs {
+		status.ManagementTxs[txType] = count
+	}
+	return status
+}
+
+// Handler serves the status API
+func (w *Watcher) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(StatusPath, func(rw http.ResponseWriter, _ *http.Request) {
+		rw.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(rw).Encode(w.Status()); err != nil {
+			w.logger.Warn("Could not write the status", log.ErrKey, err)
+		}
+	})
+	mux.HandleFunc(LivenessPath, func(rw http.ResponseWriter, _ *http.Request) {
+		rw.WriteHeader(http.StatusOK)
+	})
+	return mux
+}
+
+func txType(l1Tx ethadapter.L1Transaction) string {
+	switch l1Tx.(type) {
+	case *ethadapter.L1RollupTx:
+		return "rollup"
+	case *ethadapter.L1InitializeSecretTx:
+		return "initializeSecret"
+	case *ethadapter.L1RequestSecretTx:
+		return "requestSecret"
+	case *ethadapter.L1RespondSecretTx:
+		return "respondSecret"
+	case *ethadapter.L1SetImportantContractsTx:
+		return "setImportantContracts"
+	default:
+		return "other"
+	}
+}
@@ -0,0 +1,142 @@
+package watcher
+
+import (
+	"crypto/ecdsa"
+	"crypto/rand"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/trie"
+	"github.com/stretchr/testify/require"
+	"github.com/ten-protocol/go-ten/go/common"
+	"github.com/ten-protocol/go-ten/go/common/clock"
+	"github.com/ten-protocol/go-ten/go/common/tentypes"
+	"github.com/ten-protocol/go-ten/go/ethadapter"
+	"github.com/ten-protocol/go-ten/go/ethadapter/mgmtcontractlib"
+	"github.com/ten-protocol/go-ten/go/lightclient"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	gethcrypto "github.com/ethereum/go-ethereum/crypto"
+	gethlog "github.com/ethereum/go-ethereum/log"
+)
+
+var (
+	testMgmtContract = gethcommon.HexToAddress("0x1000000000000000000000000000000000000001")
+	testSequencerID  = gethcommon.HexToAddress("0x2000000000000000000000000000000000000002")
+)
+
+type testL1 struct {
+	blocks []*types.Block
+}
+
+func (l *testL1) BlockNumber() (uint64, error) {
+	return uint64(len(l.blocks) - 1), nil
+}
+
+func (l *testL1) BlockByNumber(n *big.Int) (*types.Block, error) {
+	return l.blocks[n.Uint64()], nil
+}
+
+func (l *testL1) TransactionReceipt(gethcommon.Hash) (*types.Receipt, error) {
+	return &types.Receipt{Status: types.ReceiptStatusSuccessful}, nil
+}
+
+// testNode serves the head batch header
+type testNode struct {
+	head *common.BatchHeader
+}
+
+func (n *testNode) BatchHeaderByNumber(*big.Int) (*common.BatchHeader, error) {
+	return n.head, nil
+}
+
+func newTestBlock(number int64, txs ...*types.Transaction) *types.Block {
+	return types.NewBlock(&types.Header{Number: big.NewInt(number)}, txs, nil, nil, trie.NewStackTrie(nil))
+}
+
+func newTestInitSecretTx(t *testing.T, mgmtLib mgmtcontractlib.MgmtContractLib, key *ecdsa.PrivateKey) *types.Transaction {
+	att, err := common.EncodeAttestation(&common.AttestationReport{
+		PubKey: gethcrypto.CompressPubkey(&key.PublicKey),
+		Owner:  testSequencerID,
+	})
+	require.NoError(t, err)
+	return types.NewTx(mgmtLib.CreateInitializeSecret(&ethadapter.L1InitializeSecretTx{
+		AggregatorID: &testSequencerID,
+		Attestation:  att,
+	}))
+}
+
+func newTestRollupTx(t *testing.T, mgmtLib mgmtcontractlib.MgmtContractLib, lastBatchSeqNo uint64, key *ecdsa.PrivateKey) *types.Transaction {
+	rollup := &common.ExtRollup{Header: &common.RollupHeader{LastBatchSeqNo: lastBatchSeqNo}}
+	var err error
+	rollup.Header.R, rollup.Header.S, err = ecdsa.Sign(rand.Reader, key, rollup.Header.Hash().Bytes())
+	require.NoError(t, err)
+	encoded, err := common.EncodeRollup(rollup)
+	require.NoError(t, err)
+	return types.NewTx(mgmtLib.CreateRollup(&ethadapter.L1RollupTx{Rollup: encoded}))
+}
+
+func newTestBatchHeader(t *testing.T, seqNo int64, key *ecdsa.PrivateKey) *common.BatchHeader {
+	header := &common.BatchHeader{Number: big.NewInt(seqNo), SequencerOrderNo: big.NewInt(seqNo)}
+	var err error
+	header.R, header.S, err = ecdsa.Sign(rand.Reader, key, header.Hash().Bytes())
+	require.NoError(t, err)
+	return header
+}
+
+func TestWatchesTheSequencer(t *testing.T) {
+	mgmtLib := mgmtcontractlib.NewMgmtContractLib(&testMgmtContract, gethlog.New())
+	sequencerKey, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	l1 := &testL1{blocks: []*types.Block{
+		newTestBlock(0, newTestInitSecretTx(t, mgmtLib, sequencerKey)),
+		newTestBlock(1, newTestRollupTx(t, mgmtLib, 10, sequencerKey)),
+		newTestBlock(2, newTestRollupTx(t, mgmtLib, 20, sequencerKey)),
+	}}
+	node := &testNode{head: newTestBatchHeader(t, 25, sequencerKey)}
+	client := lightclient.New(l1, mgmtLib, lightclient.Config{SequencerID: testSequencerID}, gethlog.New())
+	now := time.Unix(1_700_000_000, 0)
+	w := New(client, node, time.Second, clock.NewFake(now), gethlog.New())
+
+	w.Poll()
+	status := w.Status()
+	require.Empty(t, status.LastError)
+	require.Equal(t, uint64(3), status.NextL1Block)
+	require.True(t, status.SequencerAttested)
+	require.Equal(t, map[string]uint64{"initializeSecret": 1, "rollup": 2}, status.ManagementTxs)
+	require.Equal(t, tentypes.BatchSeqNo(20), status.LatestRollup.LastBatchSeqNo)
+	require.Equal(t, tentypes.BatchSeqNo(25), status.HeadBatch.SeqNo)
+	require.True(t, status.HeadBatch.ValidSignature)
+	require.Equal(t, uint64(5), status.HeadBatch.UnpublishedBatches)
+	require.Equal(t, now, status.LastPoll)
+
+	// a header not signed by the sequencer is reported
+	otherKey, err := gethcrypto.GenerateKey()
+	require.NoError(t, err)
+	node.head = newTestBatchHeader(t, 26, otherKey)
+	w.Poll()
+	require.False(t, w.Status().HeadBatch.ValidSignature)
+}
+
+func TestServesTheStatus(t *testing.T) {
+	mgmtLib := mgmtcontractlib.NewMgmtContractLib(&testMgmtContract, gethlog.New())
+	l1 := &testL1{blocks: []*types.Block{newTestBlock(0)}}
+	client := lightclient.New(l1, mgmtLib, lightclient.Config{SequencerID: testSequencerID}, gethlog.New())
+	w := New(client, nil, time.Second, clock.NewFake(time.Now()), gethlog.New())
+	w.Poll()
+
+	recorder := httptest.NewRecorder()
+	w.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, StatusPath, nil))
+	require.Equal(t, http.StatusOK, recorder.Code)
+	var status Status
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &status))
+	require.Equal(t, uint64(1), status.NextL1Block)
+	require.False(t, status.SequencerAttested)
+	require.Nil(t, status.LatestRollup)
+	require.Nil(t, status.HeadBatch)
+}